@@ -90,6 +90,14 @@ func AdaptAuthOptions(a *meta.ResourceAttribute) (client.ActionID, []client.Reso
 		return sys.CloudSelectionRecommend, make([]client.Resource, 0), nil
 	case meta.ArgumentTemplate:
 		return genArgumentTemplateResource(a)
+	case meta.LoadBalancer:
+		return genLoadBalancerResource(a)
+	case meta.Snapshot:
+		return genSnapshotResource(a)
+	case meta.KeyPair:
+		return genKeyPairResource(a)
+	case meta.NatGateway:
+		return genNatGatewayResource(a)
 	default:
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm auth type: %s", a.Basic.Type)
 	}
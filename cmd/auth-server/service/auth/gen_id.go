@@ -378,6 +378,50 @@ func genRouteTableResource(a *meta.ResourceAttribute) (client.ActionID, []client
 	return genIaaSResourceResource(a)
 }
 
+// genLoadBalancerResource generate load balancer's related iam resource.
+func genLoadBalancerResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
+// genSnapshotResource generate disk snapshot's related iam resource.
+func genSnapshotResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
+// genKeyPairResource generate ssh key pair's related iam resource.
+func genKeyPairResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	if a.Basic.Action != meta.KeyAccess {
+		return genIaaSResourceResource(a)
+	}
+
+	// 下载私钥使用独立的权限，不复用IaaS资源操作权限
+	if a.BizID > 0 {
+		bizRes := client.Resource{
+			System: sys.SystemIDCMDB,
+			Type:   sys.Biz,
+			ID:     strconv.FormatInt(a.BizID, 10),
+		}
+		return sys.BizKeyPairPrivateKeyDownload, []client.Resource{bizRes}, nil
+	}
+
+	res := client.Resource{
+		System: sys.SystemIDHCM,
+		Type:   sys.Account,
+	}
+
+	// compatible for authorize any
+	if len(a.ResourceID) > 0 {
+		res.ID = a.ResourceID
+	}
+
+	return sys.KeyPairPrivateKeyDownload, []client.Resource{res}, nil
+}
+
+// genNatGatewayResource generate nat gateway's related iam resource.
+func genNatGatewayResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	return genIaaSResourceResource(a)
+}
+
 // genRouteResource generate route's related iam resource.
 func genRouteResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
 	return genIaaSResourceResource(a)
@@ -518,7 +562,8 @@ func genProxyResourceFind(a *meta.ResourceAttribute) (client.ActionID, []client.
 // genCostManageResource generate cost manage related iam resource.
 func genCostManageResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
 	switch a.Basic.Action {
-	case meta.Find:
+	// 预算的增删改与费用查看使用同一个权限
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
 		return sys.CostManage, make([]client.Resource, 0), nil
 	default:
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
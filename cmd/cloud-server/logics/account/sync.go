@@ -76,7 +76,10 @@ func Sync(kt *kit.Kit, cli *client.ClientSet, vendor enumor.Vendor, accountID st
 			}
 		}()
 
-		err = SyncAllResource(kt, cli, vendor, accountID, isNeedSyncPublicResFlag)
+		// 同步请求标记为后台同步来源，同步发现的资源变更会据此记录为资源变更事件
+		syncKt := kt.NewSubKit()
+		syncKt.RequestSource = enumor.BackgroundSync
+		err = SyncAllResource(syncKt, cli, vendor, accountID, isNeedSyncPublicResFlag)
 		if err != nil {
 			logs.Errorf("sync account: %s failed, err: %v, rid: %s", accountID, err, kt.Rid)
 		}
@@ -0,0 +1,459 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package approval 内置审批引擎，与ITSM实现相同的 itsm.Client 接口，部署环境没有ITSM时通过配置选择使用。
+// 审批流按申请单类型和业务配置多级审批节点，单据审批结束后通过 ResultHandler 回调申请单的审批结果。
+package approval
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"hcm/pkg/api/core"
+	coreapproval "hcm/pkg/api/core/approval"
+	dataproto "hcm/pkg/api/data-service"
+	dsapproval "hcm/pkg/api/data-service/approval"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+	"hcm/pkg/thirdparty/api-gateway/itsm"
+)
+
+// ResultHandler 单据审批结束后的回调，作用与ITSM回调申请单审批结果一致
+type ResultHandler func(kt *kit.Kit, result itsm.TicketResult) error
+
+// ticketStep 与 itsm.Ticket 的 CurrentSteps 元素类型一致
+type ticketStep = struct {
+	Id      int64  `json:"id"`
+	Tag     string `json:"tag"`
+	Name    string `json:"name"`
+	StateID int64  `json:"state_id"`
+}
+
+var _ itsm.Client = new(Native)
+
+// NewNative new native approval engine.
+func NewNative(client *client.ClientSet) *Native {
+	return &Native{client: client}
+}
+
+// Native 内置审批引擎
+type Native struct {
+	client  *client.ClientSet
+	handler ResultHandler
+}
+
+// SetResultHandler set the handler called after the ticket is finished.
+func (n *Native) SetResultHandler(handler ResultHandler) {
+	n.handler = handler
+}
+
+// CreateTicket 按申请单类型和业务匹配审批流创建单据，单据号即为审批单据ID
+func (n *Native) CreateTicket(kt *kit.Kit, params *itsm.CreateTicketParams) (string, error) {
+	bizID := params.BkBizID
+	if bizID == 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	workflow, err := n.matchWorkflow(kt, params.ApplicationType, bizID)
+	if err != nil {
+		return "", err
+	}
+
+	stages, err := buildStages(workflow, params.VariableApprovers)
+	if err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &dsapproval.TicketCreateReq{
+		ApplicationType: params.ApplicationType,
+		BkBizID:         bizID,
+		Title:           params.Title,
+		Content:         params.ContentDisplay,
+		Stages:          stages,
+	}
+	result, err := n.client.DataService().Global.Approval.CreateTicket(kt, req)
+	if err != nil {
+		logs.Errorf("create approval ticket failed, err: %v, title: %s, rid: %s", err, params.Title, kt.Rid)
+		return "", err
+	}
+
+	return result.ID, nil
+}
+
+// matchWorkflow 优先使用业务下的审批流，业务下没有配置时使用申请单类型的默认审批流，都没有配置时返回空
+func (n *Native) matchWorkflow(kt *kit.Kit, appType enumor.ApplicationType, bizID int64) (
+	[]coreapproval.WorkflowStage, error) {
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "application_type", Op: filter.Equal.Factory(), Value: appType},
+				filter.AtomRule{Field: "bk_biz_id", Op: filter.In.Factory(),
+					Value: []int64{bizID, constant.UnassignedBiz}},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := n.client.DataService().Global.Approval.ListWorkflow(kt, req)
+	if err != nil {
+		logs.Errorf("list approval workflow failed, err: %v, type: %s, biz: %d, rid: %s", err, appType, bizID,
+			kt.Rid)
+		return nil, err
+	}
+
+	var stages []coreapproval.WorkflowStage
+	for _, one := range result.Details {
+		if one.BkBizID == bizID {
+			return one.Stages, nil
+		}
+		stages = one.Stages
+	}
+
+	return stages, nil
+}
+
+// GetTicketResult ...
+func (n *Native) GetTicketResult(kt *kit.Kit, sn string) (itsm.TicketResult, error) {
+	ticket, err := n.GetTicket(kt, sn)
+	if err != nil {
+		return itsm.TicketResult{}, err
+	}
+
+	return toTicketResult(ticket), nil
+}
+
+// GetTicket get approval ticket by sn.
+func (n *Native) GetTicket(kt *kit.Kit, sn string) (*coreapproval.Ticket, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", sn),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := n.client.DataService().Global.Approval.ListTicket(kt, req)
+	if err != nil {
+		logs.Errorf("list approval ticket failed, err: %v, sn: %s, rid: %s", err, sn, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "approval ticket %s not found", sn)
+	}
+
+	return &result.Details[0], nil
+}
+
+// ListTicket 按查询视角查询用户的单据，按创建时间倒序
+func (n *Native) ListTicket(kt *kit.Kit, view enumor.ApprovalTicketView, user string, page *core.BasePage) (
+	*core.ListResultT[coreapproval.Ticket], error) {
+
+	var rule filter.RuleFactory
+	switch view {
+	case enumor.MyTodoApprovalTicketView:
+		rule = filter.AtomRule{Field: "current_approvers", Op: filter.JSONContains.Factory(), Value: user}
+	case enumor.MyCreatedApprovalTicketView:
+		rule = filter.AtomRule{Field: "creator", Op: filter.Equal.Factory(), Value: user}
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported approval ticket view: %s", view)
+	}
+
+	if !page.Count && len(page.Sort) == 0 {
+		page.Sort = "created_at"
+		page.Order = core.Descending
+	}
+
+	req := &core.ListReq{Filter: &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{rule}}, Page: page}
+	return n.client.DataService().Global.Approval.ListTicket(kt, req)
+}
+
+// WithdrawTicket ...
+func (n *Native) WithdrawTicket(kt *kit.Kit, sn string, operator string) error {
+	return n.Withdraw(kt, sn, operator, "")
+}
+
+// VerifyToken 内置审批引擎直接通过 ResultHandler 回调审批结果，不接受外部的审批结果回调
+func (n *Native) VerifyToken(_ *kit.Kit, _ string) (bool, error) {
+	return false, nil
+}
+
+// GetTicketsByUser 将用户的待审批和已提交的单据转为ITSM的单据格式，state_id为当前审批节点的编号(从1开始)
+func (n *Native) GetTicketsByUser(kt *kit.Kit, req *itsm.GetTicketsByUserReq) (*itsm.GetTicketsByUserRespData,
+	error) {
+
+	var view enumor.ApprovalTicketView
+	switch req.ViewType {
+	case itsm.MyTODO, itsm.MyApproval:
+		view = enumor.MyTodoApprovalTicketView
+	case itsm.MyCreated:
+		view = enumor.MyCreatedApprovalTicketView
+	default:
+		return nil, fmt.Errorf("native approval engine not support view type: %s", req.ViewType)
+	}
+
+	pageSize := req.PageSize
+	if pageSize <= 0 || pageSize > int64(core.DefaultMaxPageLimit) {
+		pageSize = int64(core.DefaultMaxPageLimit)
+	}
+	pageNo := req.Page
+	if pageNo <= 0 {
+		pageNo = 1
+	}
+
+	countPage := core.NewCountPage()
+	countResult, err := n.ListTicket(kt, view, req.User, countPage)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &core.BasePage{Start: uint32((pageNo - 1) * pageSize), Limit: uint(pageSize)}
+	result, err := n.ListTicket(kt, view, req.User, page)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &itsm.GetTicketsByUserRespData{
+		Page:      pageNo,
+		TotalPage: (int64(countResult.Count) + pageSize - 1) / pageSize,
+		Count:     int64(countResult.Count),
+		Items:     make([]itsm.Ticket, 0, len(result.Details)),
+	}
+	for _, one := range result.Details {
+		ticket := itsm.Ticket{
+			Sn:                one.ID,
+			Title:             one.Title,
+			BkBizId:           one.BkBizID,
+			CurrentStatus:     toTicketResult(&one).CurrentStatus,
+			CreateAt:          one.CreatedAt,
+			Creator:           one.Creator,
+			CurrentProcessors: strings.Join(one.CurrentApprovers, ","),
+			WaitingApprove:    !one.Status.IsFinished(),
+			CanOperate:        !one.Status.IsFinished(),
+			CanWithdraw:       !one.Status.IsFinished() && one.Creator == req.User,
+		}
+		if !one.Status.IsFinished() && int(one.CurrentStage) < len(one.Stages) {
+			ticket.CurrentSteps = append(ticket.CurrentSteps, ticketStep{
+				Name:    one.Stages[one.CurrentStage].Name,
+				StateID: int64(one.CurrentStage) + 1,
+			})
+		}
+		resp.Items = append(resp.Items, ticket)
+	}
+
+	return resp, nil
+}
+
+// Approve 兼容ITSM的快捷审批，action为"true"表示通过，"false"表示拒绝
+func (n *Native) Approve(kt *kit.Kit, req *itsm.ApproveReq) error {
+	switch req.Action {
+	case "true":
+		return n.Pass(kt, req.Sn, req.StateID, req.Approver, req.Remark)
+	case "false":
+		return n.Reject(kt, req.Sn, req.StateID, req.Approver, req.Remark)
+	default:
+		return errf.Newf(errf.InvalidParameter, "unsupported approve action: %s", req.Action)
+	}
+}
+
+// Pass 通过当前审批节点，stateID为当前审批节点的编号，为0时不校验
+func (n *Native) Pass(kt *kit.Kit, sn string, stateID int, operator, remark string) error {
+	return n.operate(kt, sn, stateID, func(ticket *coreapproval.Ticket, now string) error {
+		return passTicket(ticket, operator, remark, now)
+	})
+}
+
+// Reject 拒绝当前审批节点，单据审批结束
+func (n *Native) Reject(kt *kit.Kit, sn string, stateID int, operator, remark string) error {
+	return n.operate(kt, sn, stateID, func(ticket *coreapproval.Ticket, now string) error {
+		return rejectTicket(ticket, operator, remark, now)
+	})
+}
+
+// Transfer 将当前审批节点转给其他审批人
+func (n *Native) Transfer(kt *kit.Kit, sn string, stateID int, operator string, transferee []string,
+	remark string) error {
+
+	return n.operate(kt, sn, stateID, func(ticket *coreapproval.Ticket, now string) error {
+		return transferTicket(ticket, operator, transferee, remark, now)
+	})
+}
+
+// Withdraw 提单人撤销单据
+func (n *Native) Withdraw(kt *kit.Kit, sn string, operator, remark string) error {
+	return n.operate(kt, sn, 0, func(ticket *coreapproval.Ticket, now string) error {
+		return withdrawTicket(ticket, operator, remark, now)
+	})
+}
+
+// operate 在单据当前节点上执行审批操作，单据被他人并发操作或当前节点已转审时返回 RecordNotUpdate 错误。
+// 单据结束后回调审批结果，回调失败时单据不回滚，由 Reconcile 补偿。
+func (n *Native) operate(kt *kit.Kit, sn string, stateID int,
+	op func(ticket *coreapproval.Ticket, now string) error) error {
+
+	ticket, err := n.GetTicket(kt, sn)
+	if err != nil {
+		return err
+	}
+
+	if stateID != 0 && stateID != int(ticket.CurrentStage)+1 {
+		return errf.Newf(errf.RecordNotUpdate, "approval ticket %s stage %d has been operated", sn, stateID)
+	}
+
+	sourceStage, sourceApprovers := ticket.CurrentStage, ticket.CurrentApprovers
+	if err = op(ticket, time.Now().Format(constant.TimeStdFormat)); err != nil {
+		return err
+	}
+
+	req := &dsapproval.TicketUpdateReq{
+		SourceStage:      sourceStage,
+		SourceApprovers:  sourceApprovers,
+		Status:           ticket.Status,
+		CurrentStage:     ticket.CurrentStage,
+		Stages:           ticket.Stages,
+		CurrentApprovers: ticket.CurrentApprovers,
+	}
+	if err = n.client.DataService().Global.Approval.UpdateTicket(kt, sn, req); err != nil {
+		logs.Errorf("update approval ticket failed, err: %v, sn: %s, rid: %s", err, sn, kt.Rid)
+		return err
+	}
+
+	if !ticket.Status.IsFinished() {
+		return nil
+	}
+
+	if n.handler == nil {
+		return errors.New("approval result handler is not set")
+	}
+
+	// 单据已经结束，回调失败时申请单仍为审批中，由 Reconcile 重新回调审批结果
+	if err = n.handler(kt, toTicketResult(ticket)); err != nil {
+		logs.Errorf("handle approval ticket result failed, will be reconciled later, err: %v, sn: %s, rid: %s", err,
+			sn, kt.Rid)
+	}
+
+	return nil
+}
+
+// ReconcileTiming 定时补偿单据已结束但申请单仍为审批中的审批结果回调，仅在主节点执行
+func (n *Native) ReconcileTiming(intervalMin uint64, sd serviced.ServiceDiscover) {
+	logs.Infof("native approval reconcile start, intervalMin: %d", intervalMin)
+
+	for {
+		time.Sleep(time.Duration(intervalMin) * time.Minute)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		if err := n.Reconcile(kt); err != nil {
+			logs.Errorf("native approval reconcile failed, err: %v, rid: %s", err, kt.Rid)
+		}
+	}
+}
+
+// Reconcile 查询审批中的申请单，对应单据已经结束时重新回调审批结果，回调只处理审批中的申请单，重复回调不会重复处理
+func (n *Native) Reconcile(kt *kit.Kit) error {
+	if n.handler == nil {
+		return errors.New("approval result handler is not set")
+	}
+
+	// 回调会将申请单移出审批中状态，按偏移量分页会跳过数据，因此按ID排序并以上一页最后的ID作为游标查询
+	lastID := ""
+	for {
+		rules := []filter.RuleFactory{
+			filter.AtomRule{Field: "status", Op: filter.Equal.Factory(), Value: enumor.Pending},
+		}
+		if len(lastID) != 0 {
+			rules = append(rules, filter.AtomRule{Field: "id", Op: filter.GreaterThan.Factory(), Value: lastID})
+		}
+
+		page := &core.BasePage{Limit: core.DefaultMaxPageLimit, Sort: "id", Order: core.Ascending}
+		appReq := &dataproto.ApplicationListReq{
+			Filter: &filter.Expression{Op: filter.And, Rules: rules},
+			Page:   page,
+		}
+		apps, err := n.client.DataService().Global.Application.List(kt, appReq)
+		if err != nil {
+			logs.Errorf("list pending application failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+
+		if len(apps.Details) == 0 {
+			return nil
+		}
+
+		sns := make([]string, 0, len(apps.Details))
+		for _, one := range apps.Details {
+			sns = append(sns, one.SN)
+		}
+
+		if err = n.reconcileTickets(kt, sns); err != nil {
+			return err
+		}
+
+		if uint(len(apps.Details)) < page.Limit {
+			return nil
+		}
+		lastID = apps.Details[len(apps.Details)-1].ID
+	}
+}
+
+// reconcileTickets 重新回调已结束单据的审批结果，单个单据回调失败不影响其他单据
+func (n *Native) reconcileTickets(kt *kit.Kit, sns []string) error {
+	if len(sns) == 0 {
+		return nil
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: sns},
+				filter.AtomRule{Field: "status", Op: filter.NotEqual.Factory(),
+					Value: enumor.RunningApprovalTicketStatus},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	tickets, err := n.client.DataService().Global.Approval.ListTicket(kt, req)
+	if err != nil {
+		logs.Errorf("list finished approval ticket failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	for i := range tickets.Details {
+		ticket := &tickets.Details[i]
+		if err = n.handler(kt, toTicketResult(ticket)); err != nil {
+			logs.Errorf("reconcile approval ticket result failed, err: %v, sn: %s, rid: %s", err, ticket.ID, kt.Rid)
+			continue
+		}
+		logs.Infof("reconcile approval ticket result success, sn: %s, status: %s, rid: %s", ticket.ID,
+			ticket.Status, kt.Rid)
+	}
+
+	return nil
+}
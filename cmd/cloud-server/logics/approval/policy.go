@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package approval
+
+import (
+	"hcm/pkg/api/core"
+	coreapproval "hcm/pkg/api/core/approval"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// applicationVendors 只有部分云厂商支持提交申请单的申请单类型，需要和申请单创建接口支持的云厂商保持一致，
+// 未列出的申请单类型不区分云厂商
+var applicationVendors = map[enumor.ApplicationType][]enumor.Vendor{
+	enumor.ChangeSecurityGroupRule: {enumor.TCloud},
+	enumor.CreateSubnet:            {enumor.TCloud},
+	enumor.CreateEip:               {enumor.TCloud},
+}
+
+// SupportApplication 云厂商是否支持提交该类型的申请单
+func SupportApplication(appType enumor.ApplicationType, vendor enumor.Vendor) bool {
+	vendors, exist := applicationVendors[appType]
+	if !exist {
+		return true
+	}
+
+	for _, one := range vendors {
+		if one == vendor {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckPolicy 校验业务审批策略，策略要求审批的操作返回ApprovalRequired错误，需要改为提交对应类型的申请单，
+// 业务下没有配置审批策略时使用默认策略，都没有配置时不需要审批。云厂商不支持提交该类型申请单时，策略要求审批的操作
+// 无法通过申请单执行，返回ApplicationUnsupported错误，直接拒绝操作
+func CheckPolicy(kt *kit.Kit, cli *dataservice.Client, bizID int64, appType enumor.ApplicationType,
+	vendor enumor.Vendor) error {
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "bk_biz_id", Op: filter.In.Factory(),
+					Value: []int64{bizID, constant.UnassignedBiz}},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.Global.Approval.ListPolicy(kt, req)
+	if err != nil {
+		logs.Errorf("list approval policy failed, err: %v, biz: %d, rid: %s", err, bizID, kt.Rid)
+		return err
+	}
+
+	return decidePolicy(result.Details, bizID, appType, vendor)
+}
+
+// decidePolicy 根据业务匹配的审批策略判断操作是否可以直接执行
+func decidePolicy(policies []coreapproval.Policy, bizID int64, appType enumor.ApplicationType,
+	vendor enumor.Vendor) error {
+
+	policy := matchPolicy(policies, bizID)
+	if policy == nil || !policy.NeedApproval(appType) {
+		return nil
+	}
+
+	if !SupportApplication(appType, vendor) {
+		return errf.Newf(errf.ApplicationUnsupported, "%s of biz %d requires approval, but vendor %s does not "+
+			"support %s application, operation is rejected, supported vendors: %v", appType, bizID, vendor, appType,
+			applicationVendors[appType])
+	}
+
+	return errf.Newf(errf.ApprovalRequired, "%s of biz %d requires approval, please submit an application",
+		appType, bizID)
+}
+
+// matchPolicy 优先使用业务下的审批策略，没有时使用默认策略
+func matchPolicy(policies []coreapproval.Policy, bizID int64) *coreapproval.Policy {
+	var matched *coreapproval.Policy
+	for idx := range policies {
+		if policies[idx].BkBizID == bizID {
+			return &policies[idx]
+		}
+
+		if policies[idx].BkBizID == constant.UnassignedBiz {
+			matched = &policies[idx]
+		}
+	}
+
+	return matched
+}
+
+// CheckBizPolicy 按请求路径中的业务校验业务审批策略，用于业务下直接执行的操作接口，不区分云厂商的操作vendor为空
+func CheckBizPolicy(cts *rest.Contexts, cli *dataservice.Client, appType enumor.ApplicationType,
+	vendor enumor.Vendor) error {
+
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return CheckPolicy(cts.Kit, cli, bizID, appType, vendor)
+}
@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package approval
+
+import (
+	"testing"
+
+	coreapproval "hcm/pkg/api/core/approval"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+)
+
+func TestMatchPolicy(t *testing.T) {
+	policies := []coreapproval.Policy{
+		{ID: "default", BkBizID: constant.UnassignedBiz, ApplicationTypes: []enumor.ApplicationType{enumor.DeleteCvm}},
+		{ID: "biz", BkBizID: 100, ApplicationTypes: []enumor.ApplicationType{enumor.CreateEip}},
+	}
+
+	policy := matchPolicy(policies, 100)
+	if policy == nil || policy.ID != "biz" {
+		t.Fatalf("biz policy should be matched first, got: %+v", policy)
+	}
+	if policy.NeedApproval(enumor.DeleteCvm) || !policy.NeedApproval(enumor.CreateEip) {
+		t.Errorf("biz policy should override default policy")
+	}
+
+	policy = matchPolicy(policies, 200)
+	if policy == nil || policy.ID != "default" {
+		t.Fatalf("default policy should be matched, got: %+v", policy)
+	}
+
+	if policy = matchPolicy(policies[1:], 200); policy != nil {
+		t.Errorf("no policy should be matched, got: %+v", policy)
+	}
+}
+
+func TestSupportApplication(t *testing.T) {
+	cases := []struct {
+		appType enumor.ApplicationType
+		vendor  enumor.Vendor
+		want    bool
+	}{
+		{appType: enumor.ChangeSecurityGroupRule, vendor: enumor.TCloud, want: true},
+		{appType: enumor.ChangeSecurityGroupRule, vendor: enumor.Aws, want: false},
+		{appType: enumor.CreateSubnet, vendor: enumor.HuaWei, want: false},
+		{appType: enumor.CreateEip, vendor: enumor.Gcp, want: false},
+		{appType: enumor.DeleteCvm, vendor: "", want: true},
+		{appType: enumor.AssociateEip, vendor: enumor.Azure, want: true},
+	}
+
+	for _, c := range cases {
+		if got := SupportApplication(c.appType, c.vendor); got != c.want {
+			t.Errorf("%s of %s support application should be %v, got %v", c.appType, c.vendor, c.want, got)
+		}
+	}
+}
+
+func TestDecidePolicy(t *testing.T) {
+	policies := []coreapproval.Policy{
+		{ID: "biz", BkBizID: 100, ApplicationTypes: []enumor.ApplicationType{enumor.ChangeSecurityGroupRule,
+			enumor.CreateSubnet, enumor.CreateEip, enumor.DeleteCvm}},
+	}
+
+	cases := []struct {
+		bizID   int64
+		appType enumor.ApplicationType
+		vendor  enumor.Vendor
+		// code 为0表示不需要审批
+		code int32
+	}{
+		{bizID: 100, appType: enumor.ChangeSecurityGroupRule, vendor: enumor.TCloud, code: errf.ApprovalRequired},
+		{bizID: 100, appType: enumor.ChangeSecurityGroupRule, vendor: enumor.Aws, code: errf.ApplicationUnsupported},
+		{bizID: 100, appType: enumor.CreateSubnet, vendor: enumor.HuaWei, code: errf.ApplicationUnsupported},
+		{bizID: 100, appType: enumor.CreateEip, vendor: enumor.Gcp, code: errf.ApplicationUnsupported},
+		{bizID: 100, appType: enumor.DeleteCvm, vendor: "", code: errf.ApprovalRequired},
+		{bizID: 100, appType: enumor.AssociateEip, vendor: "", code: 0},
+		{bizID: 200, appType: enumor.CreateEip, vendor: enumor.Azure, code: 0},
+	}
+
+	for _, c := range cases {
+		err := decidePolicy(policies, c.bizID, c.appType, c.vendor)
+		if c.code == 0 {
+			if err != nil {
+				t.Errorf("%s of %s in biz %d should not require approval, got err: %v", c.appType, c.vendor,
+					c.bizID, err)
+			}
+			continue
+		}
+
+		if err == nil || errf.Error(err).Code != c.code {
+			t.Errorf("%s of %s in biz %d should return error code %d, got err: %v", c.appType, c.vendor,
+				c.bizID, c.code, err)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package approval
+
+import (
+	"fmt"
+
+	coreapproval "hcm/pkg/api/core/approval"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/thirdparty/api-gateway/itsm"
+	"hcm/pkg/tools/slice"
+)
+
+// 与ITSM的单据状态保持一致，申请单按相同的方式处理两种审批引擎的审批结果
+const (
+	runningTicketStatus  = "RUNNING"
+	finishedTicketStatus = "FINISHED"
+	revokedTicketStatus  = "REVOKED"
+)
+
+// buildStages 按审批流配置生成单据的审批节点，节点审批人为配置的审批人与变量引用的审批人的并集。
+// 没有配置审批流时，申请单提供的每个审批人不为空的变量依次作为一个审批节点。
+func buildStages(workflow []coreapproval.WorkflowStage, variables []itsm.VariableApprover) (
+	[]coreapproval.TicketStage, error) {
+
+	varApprovers := make(map[string][]string, len(variables))
+	for _, one := range variables {
+		varApprovers[one.Variable] = append(varApprovers[one.Variable], one.Approvers...)
+	}
+
+	if len(workflow) == 0 {
+		for _, one := range variables {
+			if len(varApprovers[one.Variable]) == 0 {
+				continue
+			}
+			workflow = append(workflow, coreapproval.WorkflowStage{Name: one.Variable, Variable: one.Variable})
+		}
+	}
+
+	stages := make([]coreapproval.TicketStage, 0, len(workflow))
+	for _, one := range workflow {
+		approvers := append(append(make([]string, 0), one.Approvers...), varApprovers[one.Variable]...)
+		approvers = slice.Filter(slice.Unique(approvers), func(approver string) bool { return len(approver) != 0 })
+		if len(approvers) == 0 {
+			return nil, fmt.Errorf("approval stage %s has no approver", one.Name)
+		}
+
+		stages = append(stages, coreapproval.TicketStage{
+			Name:      one.Name,
+			Approvers: approvers,
+			Records:   make([]coreapproval.TicketRecord, 0),
+		})
+	}
+
+	if len(stages) == 0 {
+		return nil, fmt.Errorf("approval ticket has no stage")
+	}
+
+	return stages, nil
+}
+
+// checkOperable 校验单据是否处于审批中以及操作人是否为当前节点的审批人
+func checkOperable(ticket *coreapproval.Ticket, operator string) error {
+	if ticket.Status.IsFinished() {
+		return errf.Newf(errf.InvalidParameter, "approval ticket %s is already %s", ticket.ID, ticket.Status)
+	}
+
+	if int(ticket.CurrentStage) >= len(ticket.Stages) {
+		return fmt.Errorf("approval ticket %s current stage %d out of range", ticket.ID, ticket.CurrentStage)
+	}
+
+	if !slice.IsItemInSlice(ticket.CurrentApprovers, operator) {
+		return errf.Newf(errf.PermissionDenied, "%s is not the approver of approval ticket %s", operator, ticket.ID)
+	}
+
+	return nil
+}
+
+func addRecord(ticket *coreapproval.Ticket, record coreapproval.TicketRecord) {
+	stage := &ticket.Stages[ticket.CurrentStage]
+	stage.Records = append(stage.Records, record)
+}
+
+func finish(ticket *coreapproval.Ticket, status enumor.ApprovalTicketStatus) {
+	ticket.Status = status
+	ticket.CurrentApprovers = make([]string, 0)
+}
+
+// passTicket 当前节点通过，最后一个节点通过后单据审批通过
+func passTicket(ticket *coreapproval.Ticket, operator, remark, now string) error {
+	if err := checkOperable(ticket, operator); err != nil {
+		return err
+	}
+
+	addRecord(ticket, coreapproval.TicketRecord{Operator: operator, Action: enumor.ApproveApprovalAction,
+		Remark: remark, OperatedAt: now})
+
+	if int(ticket.CurrentStage)+1 >= len(ticket.Stages) {
+		finish(ticket, enumor.ApprovedApprovalTicketStatus)
+		return nil
+	}
+
+	ticket.CurrentStage++
+	ticket.CurrentApprovers = ticket.Stages[ticket.CurrentStage].Approvers
+	return nil
+}
+
+// rejectTicket 任意节点拒绝后单据审批结束
+func rejectTicket(ticket *coreapproval.Ticket, operator, remark, now string) error {
+	if err := checkOperable(ticket, operator); err != nil {
+		return err
+	}
+
+	addRecord(ticket, coreapproval.TicketRecord{Operator: operator, Action: enumor.RejectApprovalAction,
+		Remark: remark, OperatedAt: now})
+	finish(ticket, enumor.RejectedApprovalTicketStatus)
+	return nil
+}
+
+// transferTicket 将当前节点转给其他审批人，转审后原审批人不再能审批该节点
+func transferTicket(ticket *coreapproval.Ticket, operator string, transferee []string, remark, now string) error {
+	if err := checkOperable(ticket, operator); err != nil {
+		return err
+	}
+
+	transferee = slice.Filter(slice.Unique(transferee), func(approver string) bool { return len(approver) != 0 })
+	if len(transferee) == 0 {
+		return errf.New(errf.InvalidParameter, "transferee is required")
+	}
+
+	addRecord(ticket, coreapproval.TicketRecord{Operator: operator, Action: enumor.TransferApprovalAction,
+		Transferee: transferee, Remark: remark, OperatedAt: now})
+	ticket.Stages[ticket.CurrentStage].Approvers = transferee
+	ticket.CurrentApprovers = transferee
+	return nil
+}
+
+// withdrawTicket 提单人撤销审批中的单据
+func withdrawTicket(ticket *coreapproval.Ticket, operator, remark, now string) error {
+	if ticket.Status.IsFinished() {
+		return errf.Newf(errf.InvalidParameter, "approval ticket %s is already %s", ticket.ID, ticket.Status)
+	}
+
+	if ticket.Creator != operator {
+		return errf.Newf(errf.PermissionDenied, "only creator can withdraw approval ticket %s", ticket.ID)
+	}
+
+	addRecord(ticket, coreapproval.TicketRecord{Operator: operator, Action: enumor.WithdrawApprovalAction,
+		Remark: remark, OperatedAt: now})
+	finish(ticket, enumor.WithdrawnApprovalTicketStatus)
+	return nil
+}
+
+// toTicketResult 将单据转为ITSM的单据结果
+func toTicketResult(ticket *coreapproval.Ticket) itsm.TicketResult {
+	result := itsm.TicketResult{SN: ticket.ID, CurrentStatus: runningTicketStatus}
+
+	switch ticket.Status {
+	case enumor.ApprovedApprovalTicketStatus:
+		result.CurrentStatus = finishedTicketStatus
+		result.ApproveResult = true
+	case enumor.RejectedApprovalTicketStatus:
+		result.CurrentStatus = finishedTicketStatus
+	case enumor.WithdrawnApprovalTicketStatus:
+		result.CurrentStatus = revokedTicketStatus
+	}
+
+	return result
+}
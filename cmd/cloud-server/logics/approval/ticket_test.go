@@ -0,0 +1,145 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package approval
+
+import (
+	"reflect"
+	"testing"
+
+	"hcm/pkg/api/core"
+	coreapproval "hcm/pkg/api/core/approval"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/thirdparty/api-gateway/itsm"
+)
+
+func TestBuildStages(t *testing.T) {
+	variables := []itsm.VariableApprover{
+		{Variable: "platform_manager", Approvers: []string{"admin", "ops"}},
+		{Variable: "account_manager", Approvers: []string{"jim"}},
+	}
+
+	stages, err := buildStages(nil, variables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stages) != 2 || !reflect.DeepEqual(stages[0].Approvers, []string{"admin", "ops"}) {
+		t.Errorf("stages without workflow not expected: %+v", stages)
+	}
+
+	workflow := []coreapproval.WorkflowStage{
+		{Name: "leader", Approvers: []string{"tom"}},
+		{Name: "platform", Approvers: []string{"ops", ""}, Variable: "platform_manager"},
+	}
+	stages, err = buildStages(workflow, variables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stages) != 2 || !reflect.DeepEqual(stages[1].Approvers, []string{"ops", "admin"}) {
+		t.Errorf("stages with workflow not expected: %+v", stages)
+	}
+
+	workflow = []coreapproval.WorkflowStage{{Name: "unknown", Variable: "biz_manager"}}
+	if _, err = buildStages(workflow, variables); err == nil {
+		t.Error("stage without approver should be invalid")
+	}
+
+	// 内置审批引擎没有ITSM的平台管理员，没有审批流时跳过审批人为空的变量
+	variables = []itsm.VariableApprover{
+		{Variable: "platform_manager"},
+		{Variable: "account_manager", Approvers: []string{"jim"}},
+	}
+	stages, err = buildStages(nil, variables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stages) != 1 || stages[0].Name != "account_manager" {
+		t.Errorf("stages without empty variable not expected: %+v", stages)
+	}
+
+	if _, err = buildStages(nil, []itsm.VariableApprover{{Variable: "platform_manager"}}); err == nil {
+		t.Error("ticket without any approver should be invalid")
+	}
+}
+
+func newTestTicket() *coreapproval.Ticket {
+	stages := []coreapproval.TicketStage{
+		{Name: "leader", Approvers: []string{"tom"}},
+		{Name: "platform", Approvers: []string{"admin"}},
+	}
+	return &coreapproval.Ticket{
+		ID:               "REQ001",
+		Status:           enumor.RunningApprovalTicketStatus,
+		Stages:           stages,
+		CurrentApprovers: stages[0].Approvers,
+		Revision:         core.Revision{Creator: "jim"},
+	}
+}
+
+func TestTicketTransition(t *testing.T) {
+	ticket := newTestTicket()
+	if err := passTicket(ticket, "admin", "", ""); err == nil {
+		t.Error("approver of other stage should not pass current stage")
+	}
+
+	if err := transferTicket(ticket, "tom", []string{"lucy"}, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := passTicket(ticket, "tom", "", ""); err == nil {
+		t.Error("approver should not pass after transfer")
+	}
+
+	if err := passTicket(ticket, "lucy", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if ticket.CurrentStage != 1 || toTicketResult(ticket).CurrentStatus != runningTicketStatus {
+		t.Errorf("ticket should be running at second stage: %+v", ticket)
+	}
+
+	if err := passTicket(ticket, "admin", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	result := toTicketResult(ticket)
+	if result.CurrentStatus != finishedTicketStatus || !result.ApproveResult || len(ticket.CurrentApprovers) != 0 {
+		t.Errorf("ticket should be approved: %+v", ticket)
+	}
+
+	ticket = newTestTicket()
+	if err := rejectTicket(ticket, "tom", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	result = toTicketResult(ticket)
+	if result.CurrentStatus != finishedTicketStatus || result.ApproveResult {
+		t.Errorf("ticket should be rejected: %+v", ticket)
+	}
+
+	ticket = newTestTicket()
+	if err := withdrawTicket(ticket, "tom", "", ""); err == nil {
+		t.Error("only creator can withdraw ticket")
+	}
+	if err := withdrawTicket(ticket, "jim", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if toTicketResult(ticket).CurrentStatus != revokedTicketStatus {
+		t.Errorf("ticket should be withdrawn: %+v", ticket)
+	}
+	if err := passTicket(ticket, "tom", "", ""); err == nil {
+		t.Error("finished ticket should not be operable")
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"hcm/pkg/api/core"
+	ts "hcm/pkg/api/task-server"
 	taskserver "hcm/pkg/client/task-server"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/dal/dao/tools"
@@ -33,51 +34,69 @@ import (
 	"hcm/pkg/runtime/filter"
 )
 
-// WaitTaskToEnd 等待异步任务结束
-// TODO: 临时方案，等异步任务上线后，将该逻辑去除
+// waitEventTimeoutSec 单次长轮询等待任务流事件的最长时间
+const waitEventTimeoutSec = 30
+
+// WaitTaskToEnd 等待异步任务结束，通过长轮询任务流状态变更事件感知任务流结束，避免频繁查询任务流状态
 func WaitTaskToEnd(kt *kit.Kit, cli *taskserver.Client, id string) error {
+	flow, err := cli.GetFlow(kt, id)
+	if err != nil {
+		logs.Errorf("get flow failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
 
+	state := flow.State
+	req := &ts.WatchFlowEventReq{
+		FlowIDs:    []string{id},
+		Types:      []enumor.AsyncEventType{enumor.FlowStateEvent},
+		TimeoutSec: waitEventTimeoutSec,
+	}
 	end := time.Now().Add(5 * time.Minute)
-	for {
+	for state != enumor.FlowSuccess && state != enumor.FlowFailed {
 		if time.Now().After(end) {
 			return fmt.Errorf("wait timeout, async task: %s is running", id)
 		}
 
-		flow, err := cli.GetFlow(kt, id)
+		result, err := cli.WatchFlowEvent(kt, req)
 		if err != nil {
-			logs.Errorf("get flow failed, err: %v, rid: %s", err, kt.Rid)
+			logs.Errorf("watch flow event failed, err: %v, flow: %s, rid: %s", err, id, kt.Rid)
 			return err
 		}
 
-		if flow.State == enumor.FlowFailed {
-			// 临时方案，选取一个错误当作错误原因
-			req := &core.ListReq{
-				Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{
-					"flow_id": id,
-					"state":   enumor.TaskFailed,
-				}),
-				Page: &core.BasePage{
-					Start: 0,
-					Limit: 1,
-				},
-			}
-			result, err := cli.ListTask(kt, req)
-			if err != nil {
-				logs.Errorf("list task failed, err: %v, rid: %s", err, kt.Rid)
-				return err
-			}
+		req.Cursor = result.Cursor
+		for _, event := range result.Details {
+			state = enumor.FlowState(event.Target)
+		}
+	}
 
-			if len(result.Details) == 0 {
-				return fmt.Errorf("flow: %s not found failed task", id)
-			}
+	if state == enumor.FlowSuccess {
+		return nil
+	}
 
-			return errors.New(result.Details[0].Reason.Message)
-		}
+	return getFlowFailedReason(kt, cli, id)
+}
 
-		if flow.State == enumor.FlowSuccess {
-			return nil
-		}
+// getFlowFailedReason 临时方案，选取一个失败任务的错误当作错误原因
+func getFlowFailedReason(kt *kit.Kit, cli *taskserver.Client, id string) error {
+	req := &core.ListReq{
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{
+			"flow_id": id,
+			"state":   enumor.TaskFailed,
+		}),
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: 1,
+		},
+	}
+	result, err := cli.ListTask(kt, req)
+	if err != nil {
+		logs.Errorf("list task failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
 
-		time.Sleep(500 * time.Millisecond)
+	if len(result.Details) == 0 {
+		return fmt.Errorf("flow: %s not found failed task", id)
 	}
+
+	return errors.New(result.Details[0].Reason.Message)
 }
@@ -0,0 +1,318 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package budget 预算执行情况的计算以及超出阈值时的告警通知
+package budget
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"hcm/pkg/api/core"
+	corebudget "hcm/pkg/api/core/budget"
+	dsbudget "hcm/pkg/api/data-service/budget"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+const monthLayout = "2006-01"
+
+// GetStatus 统计预算在 now 所在月份的已产生费用，并预测月底费用
+func GetStatus(kt *kit.Kit, dataCli *dataservice.Client, budget *corebudget.Budget, now time.Time) (
+	*corebudget.BudgetStatus, error) {
+
+	expr, err := monthBillExpr(budget, now)
+	if err != nil {
+		return nil, err
+	}
+
+	spent, err := monthSpent(kt, dataCli, budget, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	billedDays, err := monthBilledDays(kt, dataCli, budget, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return CalcStatus(budget, spent, billedDays, now)
+}
+
+// monthBillExpr 生成预算范围内 now 所在月份同币种账单日汇总数据的查询条件
+func monthBillExpr(budget *corebudget.Budget, now time.Time) (*filter.Expression, error) {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	end := start.AddDate(0, 1, -1)
+
+	rules := []filter.RuleFactory{
+		&filter.AtomRule{Field: "bill_date", Op: filter.GreaterThanEqual.Factory(),
+			Value: start.Format(constant.DateLayout)},
+		&filter.AtomRule{Field: "bill_date", Op: filter.LessThanEqual.Factory(), Value: end.Format(constant.DateLayout)},
+		&filter.AtomRule{Field: "currency", Op: filter.Equal.Factory(), Value: budget.Currency},
+	}
+	switch budget.Scope {
+	case enumor.BizBudgetScope:
+		rules = append(rules, &filter.AtomRule{Field: "bk_biz_id", Op: filter.Equal.Factory(), Value: budget.BkBizID})
+	case enumor.AccountBudgetScope:
+		rules = append(rules, &filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(),
+			Value: budget.AccountID})
+	default:
+		return nil, fmt.Errorf("unsupported budget scope: %s", budget.Scope)
+	}
+
+	return &filter.Expression{Op: filter.And, Rules: rules}, nil
+}
+
+// monthSpent 汇总本地账单日汇总数据中预算范围内当月同币种的费用
+func monthSpent(kt *kit.Kit, dataCli *dataservice.Client, budget *corebudget.Budget, expr *filter.Expression) (
+	string, error) {
+
+	req := &dsbill.BillDailyAggregateReq{
+		Filter:  expr,
+		GroupBy: []string{"currency"},
+		Page:    core.NewDefaultBasePage(),
+	}
+	result, err := dataCli.Global.Bill.AggregateDailyItem(kt, req)
+	if err != nil {
+		logs.Errorf("aggregate budget month spent failed, err: %v, budget: %s, rid: %s", err, budget.ID, kt.Rid)
+		return "", err
+	}
+
+	if len(result.Details) == 0 {
+		return "0", nil
+	}
+
+	return result.Details[0].Cost, nil
+}
+
+// monthBilledDays 查询预算范围内当月已拉取的最新账单日期，返回该日期在当月的天数，即已产生费用覆盖的天数，没有账单时返回0
+func monthBilledDays(kt *kit.Kit, dataCli *dataservice.Client, budget *corebudget.Budget,
+	expr *filter.Expression) (int, error) {
+
+	req := &core.ListReq{
+		Fields: []string{"bill_date"},
+		Filter: expr,
+		Page:   &core.BasePage{Limit: 1, Sort: "bill_date", Order: core.Descending},
+	}
+	result, err := dataCli.Global.Bill.ListDailyItem(kt, req)
+	if err != nil {
+		logs.Errorf("list budget latest bill date failed, err: %v, budget: %s, rid: %s", err, budget.ID, kt.Rid)
+		return 0, err
+	}
+
+	if len(result.Details) == 0 {
+		return 0, nil
+	}
+
+	billDate, err := time.Parse(constant.DateLayout, result.Details[0].BillDate)
+	if err != nil {
+		return 0, fmt.Errorf("parse bill date %s failed, err: %v", result.Details[0].BillDate, err)
+	}
+
+	return billDate.Day(), nil
+}
+
+// CalcStatus 根据当月已产生的费用计算预算执行情况，月底费用按照已产生费用覆盖天数的日均费用进行预测
+func CalcStatus(budget *corebudget.Budget, spent string, billedDays int, now time.Time) (*corebudget.BudgetStatus,
+	error) {
+
+	amount, err := strconv.ParseFloat(budget.Amount, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse budget amount %s failed, err: %v", budget.Amount, err)
+	}
+
+	spentValue, err := strconv.ParseFloat(spent, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse budget spent %s failed, err: %v", spent, err)
+	}
+
+	usedPercent := float64(0)
+	if amount > 0 {
+		usedPercent = math.Round(spentValue/amount*10000) / 100
+	}
+
+	return &corebudget.BudgetStatus{
+		BudgetID:          budget.ID,
+		Month:             now.Format(monthLayout),
+		Currency:          budget.Currency,
+		Amount:            budget.Amount,
+		Spent:             spent,
+		Forecast:          strconv.FormatFloat(Forecast(spentValue, billedDays, now), 'f', 2, 64),
+		UsedPercent:       usedPercent,
+		ReachedThresholds: ReachedThresholds(budget.Thresholds, usedPercent),
+	}, nil
+}
+
+// Forecast 预测月底费用，billedDays为已拉取的最新账单日期在当月的天数，账单的拉取存在延迟，不能按当前日期计算日均费用，
+// 当月还没有账单时直接返回已产生费用
+func Forecast(spent float64, billedDays int, now time.Time) float64 {
+	if billedDays <= 0 {
+		return spent
+	}
+
+	daysInMonth := time.Date(now.Year(), now.Month()+1, 0, 0, 0, 0, 0, now.Location()).Day()
+	return spent / float64(billedDays) * float64(daysInMonth)
+}
+
+// ReachedThresholds 返回已产生费用百分比已达到的告警阈值，按从小到大排序
+func ReachedThresholds(thresholds []int64, usedPercent float64) []int64 {
+	reached := make([]int64, 0)
+	for _, threshold := range thresholds {
+		if usedPercent >= float64(threshold) {
+			reached = append(reached, threshold)
+		}
+	}
+
+	sort.Slice(reached, func(i, j int) bool { return reached[i] < reached[j] })
+	return reached
+}
+
+// Evaluator 评估预算执行情况并对新达到的告警阈值进行通知
+type Evaluator struct {
+	dataCli  *dataservice.Client
+	notifier Notifier
+}
+
+// NewEvaluator new budget evaluator.
+func NewEvaluator(dataCli *dataservice.Client, notifier Notifier) *Evaluator {
+	return &Evaluator{
+		dataCli:  dataCli,
+		notifier: notifier,
+	}
+}
+
+// Evaluate 每个预算在每个月的每个阈值只通知一次，通知失败的告警会在下一轮评估时重新通知
+func (e *Evaluator) Evaluate(kt *kit.Kit, budget *corebudget.Budget, now time.Time) error {
+	status, err := GetStatus(kt, e.dataCli, budget, now)
+	if err != nil {
+		return err
+	}
+
+	if len(status.ReachedThresholds) == 0 {
+		return nil
+	}
+
+	alerts, err := e.listMonthAlert(kt, budget.ID, status.Month)
+	if err != nil {
+		return err
+	}
+
+	for _, threshold := range status.ReachedThresholds {
+		alert, exists := alerts[uint(threshold)]
+		if exists && alert.State == enumor.BudgetAlertNotified {
+			continue
+		}
+
+		notification := &Notification{
+			BudgetID:    budget.ID,
+			BudgetName:  budget.Name,
+			Scope:       budget.Scope,
+			BkBizID:     budget.BkBizID,
+			AccountID:   budget.AccountID,
+			Month:       status.Month,
+			Currency:    status.Currency,
+			Amount:      status.Amount,
+			Spent:       status.Spent,
+			Forecast:    status.Forecast,
+			Threshold:   threshold,
+			UsedPercent: status.UsedPercent,
+			Receivers:   budget.Receivers,
+		}
+		state, message := enumor.BudgetAlertNotified, ""
+		if err = e.notifier.Notify(kt, notification); err != nil {
+			logs.Errorf("notify budget alert failed, err: %v, budget: %s, threshold: %d, rid: %s", err, budget.ID,
+				threshold, kt.Rid)
+			state, message = enumor.BudgetAlertNotifyFailed, truncate(err.Error(), 1024)
+		}
+
+		if exists {
+			updateReq := &dsbudget.BudgetAlertUpdateReq{
+				Spent:    status.Spent,
+				Forecast: status.Forecast,
+				State:    state,
+				Message:  message,
+			}
+			if err = e.dataCli.Global.Budget.UpdateBudgetAlert(kt, alert.ID, updateReq); err != nil {
+				logs.Errorf("update budget alert failed, err: %v, id: %s, rid: %s", err, alert.ID, kt.Rid)
+				return err
+			}
+			continue
+		}
+
+		createReq := &dsbudget.BudgetAlertCreateReq{
+			BudgetID:  budget.ID,
+			Month:     status.Month,
+			Threshold: uint(threshold),
+			Amount:    status.Amount,
+			Spent:     status.Spent,
+			Forecast:  status.Forecast,
+			State:     state,
+			Message:   message,
+		}
+		if _, err = e.dataCli.Global.Budget.CreateBudgetAlert(kt, createReq); err != nil {
+			logs.Errorf("create budget alert failed, err: %v, budget: %s, threshold: %d, rid: %s", err, budget.ID,
+				threshold, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Evaluator) listMonthAlert(kt *kit.Kit, budgetID, month string) (map[uint]corebudget.BudgetAlert, error) {
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "budget_id", Op: filter.Equal.Factory(), Value: budgetID},
+				&filter.AtomRule{Field: "month", Op: filter.Equal.Factory(), Value: month},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := e.dataCli.Global.Budget.ListBudgetAlert(kt, req)
+	if err != nil {
+		logs.Errorf("list budget alert failed, err: %v, budget: %s, month: %s, rid: %s", err, budgetID, month,
+			kt.Rid)
+		return nil, err
+	}
+
+	alerts := make(map[uint]corebudget.BudgetAlert, len(result.Details))
+	for _, one := range result.Details {
+		alerts[one.Threshold] = one
+	}
+
+	return alerts, nil
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+
+	return s[:max]
+}
@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package budget
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	corebudget "hcm/pkg/api/core/budget"
+	"hcm/pkg/cc"
+	"hcm/pkg/kit"
+)
+
+func TestCalcStatus(t *testing.T) {
+	budget := &corebudget.Budget{
+		ID:         "00000001",
+		Currency:   "CNY",
+		Amount:     "3000",
+		Thresholds: []int64{100, 50, 80},
+	}
+
+	// 4月已拉取10天的账单，日均费用150，预测月底费用为4500
+	now := time.Date(2024, 4, 11, 8, 0, 0, 0, time.Local)
+	status, err := CalcStatus(budget, "1500", 10, now)
+	if err != nil {
+		t.Fatalf("calc budget status failed, err: %v", err)
+	}
+
+	if status.Month != "2024-04" || status.Forecast != "4500.00" || status.UsedPercent != 50 {
+		t.Errorf("unexpected budget status: %+v", status)
+	}
+
+	if !reflect.DeepEqual(status.ReachedThresholds, []int64{50}) {
+		t.Errorf("reached thresholds %v, expect [50]", status.ReachedThresholds)
+	}
+
+	status, err = CalcStatus(budget, "3000.5", 10, now)
+	if err != nil {
+		t.Fatalf("calc budget status failed, err: %v", err)
+	}
+
+	if !reflect.DeepEqual(status.ReachedThresholds, []int64{50, 80, 100}) {
+		t.Errorf("reached thresholds %v, expect [50 80 100]", status.ReachedThresholds)
+	}
+}
+
+func TestForecast(t *testing.T) {
+	if got := Forecast(100, 0, time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local)); got != 100 {
+		t.Errorf("forecast without bill of month %v, expect 100", got)
+	}
+
+	// 闰年2月共29天
+	if got := Forecast(70, 7, time.Date(2024, 2, 8, 0, 0, 0, 0, time.Local)); got != 290 {
+		t.Errorf("forecast %v, expect 290", got)
+	}
+
+	// 账单拉取延迟，2月10日只拉取到2月5日的账单时，按5天的日均费用预测
+	if got := Forecast(50, 5, time.Date(2024, 2, 10, 0, 0, 0, 0, time.Local)); got != 290 {
+		t.Errorf("forecast with delayed bill %v, expect 290", got)
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	received := make(chan *Notification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		notification := new(Notification)
+		if err := json.NewDecoder(r.Body).Decode(notification); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- notification
+	}))
+	defer server.Close()
+
+	notification := &Notification{BudgetID: "00000001", Month: "2024-04", Threshold: 80}
+	notifier, err := NewNotifier(cc.BudgetNotifier{
+		Type:    cc.WebhookBudgetNotifier,
+		Webhook: cc.Webhook{Url: server.URL, TimeoutSec: 5, Headers: map[string]string{"X-Token": "test"}},
+	})
+	if err != nil {
+		t.Fatalf("new notifier failed, err: %v", err)
+	}
+
+	if err = notifier.Notify(kit.New(), notification); err != nil {
+		t.Fatalf("notify failed, err: %v", err)
+	}
+
+	if got := <-received; !reflect.DeepEqual(got, notification) {
+		t.Errorf("webhook received %+v, expect %+v", got, notification)
+	}
+
+	unauthorized := NewWebhookNotifier(cc.Webhook{Url: server.URL, TimeoutSec: 5})
+	if err = unauthorized.Notify(kit.New(), notification); err == nil {
+		t.Errorf("notify should fail when webhook response status is not 2xx")
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package budget
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Notification 预算告警通知内容
+type Notification struct {
+	BudgetID   string             `json:"budget_id"`
+	BudgetName string             `json:"budget_name"`
+	Scope      enumor.BudgetScope `json:"scope"`
+	BkBizID    int64              `json:"bk_biz_id"`
+	AccountID  string             `json:"account_id"`
+	Month      string             `json:"month"`
+	Currency   string             `json:"currency"`
+	Amount     string             `json:"amount"`
+	Spent      string             `json:"spent"`
+	Forecast   string             `json:"forecast"`
+	// Threshold 本次触发的告警阈值
+	Threshold   int64    `json:"threshold"`
+	UsedPercent float64  `json:"used_percent"`
+	Receivers   []string `json:"receivers"`
+}
+
+// Notifier 预算告警通知接口，返回错误时该告警会在下一轮评估时重新通知
+type Notifier interface {
+	Notify(kt *kit.Kit, notification *Notification) error
+}
+
+// NewNotifier 根据配置创建预算告警通知方式
+func NewNotifier(conf cc.BudgetNotifier) (Notifier, error) {
+	switch conf.Type {
+	case cc.LogBudgetNotifier:
+		return new(logNotifier), nil
+	case cc.WebhookBudgetNotifier:
+		return NewWebhookNotifier(conf.Webhook), nil
+	default:
+		return nil, fmt.Errorf("unsupported budget notifier type: %s", conf.Type)
+	}
+}
+
+// logNotifier 只将告警打印到日志中，用于未接入通知渠道的环境
+type logNotifier struct{}
+
+// Notify budget alert by log.
+func (n *logNotifier) Notify(kt *kit.Kit, notification *Notification) error {
+	logs.Infof("budget %s(%s) reached threshold %d%% in %s, spent: %s, forecast: %s, amount: %s %s, rid: %s",
+		notification.BudgetName, notification.BudgetID, notification.Threshold, notification.Month,
+		notification.Spent, notification.Forecast, notification.Amount, notification.Currency, kt.Rid)
+	return nil
+}
+
+// NewWebhookNotifier 创建将告警内容POST到回调地址的通知方式
+func NewWebhookNotifier(conf cc.Webhook) Notifier {
+	return &webhookNotifier{
+		url:     conf.Url,
+		headers: conf.Headers,
+		client:  &http.Client{Timeout: time.Duration(conf.TimeoutSec) * time.Second},
+	}
+}
+
+type webhookNotifier struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// Notify post budget alert to webhook, response status other than 2xx is treated as failure.
+func (n *webhookNotifier) Notify(kt *kit.Kit, notification *Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("marshal budget notification failed, err: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("new webhook request failed, err: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range n.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request webhook failed, err: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook response status: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/logics/disk"
 	"hcm/cmd/cloud-server/logics/eip"
+	proto "hcm/pkg/api/cloud-server/cvm"
 	"hcm/pkg/api/core"
 	rr "hcm/pkg/api/core/recycle-record"
 	"hcm/pkg/client"
@@ -41,6 +42,8 @@ type Interface interface {
 		records []rr.CvmRecycleRecord) (*core.BatchOperateResult, error)
 	GetNotCmdbRecyclableHosts(kt *kit.Kit, bizHostsIds map[int64][]string) ([]string, error)
 	RecyclePreCheck(kt *kit.Kit, infoMap map[string]types.CloudResourceBasicInfo) error
+	RecycleCvm(kt *kit.Kit, infos []proto.CvmRecycleInfo, basicInfoMap map[string]types.CloudResourceBasicInfo) (
+		string, error)
 	BatchFinalizeRelRecord(kt *kit.Kit, resType enumor.CloudResourceType,
 		status enumor.RecycleRecordStatus, resIds []string) error
 }
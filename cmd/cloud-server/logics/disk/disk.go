@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	csdisk "hcm/pkg/api/cloud-server/disk"
 	"hcm/pkg/api/cloud-server/recycle"
 	"hcm/pkg/api/core"
 	recyclerecord "hcm/pkg/api/core/recycle-record"
@@ -52,6 +53,8 @@ type Interface interface {
 	BatchGetDiskInfo(kt *kit.Kit, cvmDetail map[string]*recycle.CvmDetail) (err error)
 	BatchDetach(kt *kit.Kit, cvmRecycleMap map[string]*recycle.CvmDetail) (failed []string, err error)
 	BatchReattachDisk(kt *kit.Kit, cvmRecycleMap map[string]*recycle.CvmDetail) (err error)
+	RecycleDisk(kt *kit.Kit, infos []csdisk.DiskRecycleInfo, basicInfoMap map[string]types.CloudResourceBasicInfo) (
+		interface{}, error)
 }
 type disk struct {
 	client *client.ClientSet
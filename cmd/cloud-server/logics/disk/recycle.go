@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package disk
+
+import (
+	csdisk "hcm/pkg/api/cloud-server/disk"
+	csrecycle "hcm/pkg/api/cloud-server/recycle"
+	"hcm/pkg/api/core"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	dsrr "hcm/pkg/api/data-service/recycle-record"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// RecycleDisk 回收硬盘，创建回收审计后解绑主机并创建回收记录，全部成功时返回回收任务ID，部分失败时返回批量操作结果
+func (d *disk) RecycleDisk(kt *kit.Kit, infos []csdisk.DiskRecycleInfo,
+	basicInfoMap map[string]types.CloudResourceBasicInfo) (interface{}, error) {
+
+	auditInfos := slice.Map(infos, func(info csdisk.DiskRecycleInfo) protoaudit.CloudResRecycleAuditInfo {
+		return protoaudit.CloudResRecycleAuditInfo{ResID: info.ID, Data: info.DiskRecycleOptions}
+	})
+	// create recycle audit
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
+		ResType: enumor.DiskAuditResType,
+		Action:  protoaudit.Recycle,
+		Infos:   auditInfos,
+	}
+	if err := d.audit.ResRecycleAudit(kt, auditReq); err != nil {
+		logs.Errorf("create recycle audit failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	ids := slice.Map(infos, func(info csdisk.DiskRecycleInfo) string { return info.ID })
+
+	// detach disk from cvm
+	detachRes, err := d.detachDiskByIDs(kt, ids, basicInfoMap)
+	if err != nil {
+		logs.Errorf("detach disks failed, err: %v, ids: %v, result: %+v, rid: %s", err, ids, detachRes, kt.Rid)
+		return nil, err
+	}
+
+	res := new(core.BatchOperateAllResult)
+
+	failedIDMap := make(map[string]struct{})
+	if detachRes != nil {
+		res.Failed = detachRes.Failed
+		for _, info := range detachRes.Failed {
+			failedIDMap[info.ID] = struct{}{}
+		}
+	}
+
+	// create recycle record
+	opt := &dsrr.BatchRecycleReq{
+		ResType:            enumor.DiskCloudResType,
+		DefaultRecycleTime: cc.CloudServer().Recycle.AutoDeleteTime,
+		Infos:              make([]dsrr.RecycleReq, 0),
+	}
+	for _, info := range infos {
+		if _, exists := failedIDMap[info.ID]; exists {
+			continue
+		}
+		opt.Infos = append(opt.Infos, dsrr.RecycleReq{
+			ID:     info.ID,
+			Detail: info.DiskRecycleOptions,
+		})
+	}
+
+	taskID, err := d.client.DataService().Global.RecycleRecord.BatchRecycleCloudRes(kt, opt)
+	if err != nil {
+		for _, info := range opt.Infos {
+			res.Failed = append(res.Failed, core.FailedInfo{ID: info.ID, Error: err})
+		}
+		return res, err
+	}
+
+	if len(res.Failed) > 0 {
+		return res, res.Failed[0].Error
+	}
+	return &csrecycle.RecycleResult{TaskID: taskID}, nil
+}
+
+func (d *disk) detachDiskByIDs(kt *kit.Kit, ids []string, basicInfoMap map[string]types.CloudResourceBasicInfo) (
+	*core.BatchOperateAllResult, error) {
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	if len(ids) > constant.BatchOperationMaxLimit {
+		return nil, errf.Newf(errf.InvalidParameter, "ids should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("disk_id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	relRes, err := d.client.DataService().Global.ListDiskCvmRel(kt, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(relRes.Details) == 0 {
+		return nil, nil
+	}
+
+	res := &core.BatchOperateAllResult{
+		Succeeded: make([]string, 0),
+		Failed:    make([]core.FailedInfo, 0),
+	}
+
+	diskCvmMap := make(map[string]string)
+	for _, detail := range relRes.Details {
+		diskCvmMap[detail.DiskID] = detail.CvmID
+	}
+
+	for _, id := range ids {
+		cvmID, exists := diskCvmMap[id]
+		if !exists {
+			res.Succeeded = append(res.Succeeded, id)
+			continue
+		}
+
+		info, exists := basicInfoMap[id]
+		if !exists {
+			res.Succeeded = append(res.Succeeded, id)
+			continue
+		}
+
+		err = d.DetachDisk(kt, info.Vendor, cvmID, id)
+		if err != nil {
+			res.Failed = append(res.Failed, core.FailedInfo{ID: id, Error: err})
+			continue
+		}
+		res.Succeeded = append(res.Succeeded, id)
+	}
+
+	if len(res.Failed) > 0 {
+		return res, res.Failed[0].Error
+	}
+	return res, nil
+}
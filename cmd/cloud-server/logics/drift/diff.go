@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package drift
+
+import (
+	"reflect"
+	"sort"
+
+	coredrift "hcm/pkg/api/core/drift"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/thirdparty/esb/cmdb"
+	"hcm/pkg/tools/json"
+)
+
+// 期望状态中记录的资源属性
+const (
+	FieldName              = "name"
+	FieldCidrs             = "cidrs"
+	FieldIpv4Cidr          = "ipv4_cidr"
+	FieldCloudRouteTableID = "cloud_route_table_id"
+	FieldMachineType       = "machine_type"
+	FieldCloudImageID      = "cloud_image_id"
+	FieldPowerState        = "power_state"
+	FieldCloudSubnetIDs    = "cloud_subnet_ids"
+	FieldCloudSGIDs        = "cloud_security_group_ids"
+)
+
+// 归一化后的主机电源状态
+const (
+	PowerStateRunning      = "running"
+	PowerStateStopped      = "stopped"
+	PowerStateTerminated   = "terminated"
+	PowerStateTransitional = "transitional"
+)
+
+// PowerState 将各云厂商的主机状态归一化为 running、stopped、terminated，开关机中等中间状态为 transitional
+func PowerState(vendor enumor.Vendor, status string) string {
+	switch cmdb.HcmCmdbHostStatusMap[vendor][status] {
+	case cmdb.RunningCloudHostStatus:
+		return PowerStateRunning
+	case cmdb.StoppedCloudHostStatus:
+		return PowerStateStopped
+	case cmdb.TerminatedCloudHostStatus:
+		return PowerStateTerminated
+	default:
+		return PowerStateTransitional
+	}
+}
+
+// Diff 对比期望状态与当前状态，返回值不一致的属性，只对比期望状态中记录的属性。
+// 属性值经过json序列化后再对比，避免期望状态从数据库读出后数值、列表类型与当前状态不一致。
+func Diff(desired, actual coredrift.Spec) ([]coredrift.FieldDiff, error) {
+	desiredNorm, err := normalize(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	actualNorm, err := normalize(actual)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(desiredNorm))
+	for field := range desiredNorm {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	diffs := make([]coredrift.FieldDiff, 0)
+	for _, field := range fields {
+		// 主机开关机中等中间状态不认为发生了漂移，等待下次检测
+		if field == FieldPowerState && actualNorm[field] == PowerStateTransitional {
+			continue
+		}
+
+		if reflect.DeepEqual(desiredNorm[field], actualNorm[field]) {
+			continue
+		}
+
+		diffs = append(diffs, coredrift.FieldDiff{
+			Field:   field,
+			Desired: desiredNorm[field],
+			Actual:  actualNorm[field],
+		})
+	}
+
+	return diffs, nil
+}
+
+func normalize(spec coredrift.Spec) (map[string]interface{}, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{})
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func sortedCopy(list []string) []string {
+	result := make([]string, len(list))
+	copy(result, list)
+	sort.Strings(result)
+	return result
+}
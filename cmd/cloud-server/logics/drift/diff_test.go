@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package drift
+
+import (
+	"testing"
+
+	coredrift "hcm/pkg/api/core/drift"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestPowerState(t *testing.T) {
+	cases := []struct {
+		vendor enumor.Vendor
+		status string
+		expect string
+	}{
+		{enumor.TCloud, "RUNNING", PowerStateRunning},
+		{enumor.TCloud, "STOPPED", PowerStateStopped},
+		{enumor.Aws, "stopping", PowerStateTransitional},
+		{enumor.Azure, "PowerState/deallocated", PowerStateStopped},
+		{enumor.HuaWei, "DELETED", PowerStateTerminated},
+		{enumor.Gcp, "not-exist", PowerStateTransitional},
+	}
+	for _, c := range cases {
+		if got := PowerState(c.vendor, c.status); got != c.expect {
+			t.Errorf("%s status %s power state = %s, expect %s", c.vendor, c.status, got, c.expect)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	desired := coredrift.Spec{
+		FieldName:           "vm",
+		FieldPowerState:     PowerStateRunning,
+		FieldCloudSGIDs:     []string{"sg-1", "sg-2"},
+		FieldCloudImageID:   "img-1",
+		FieldMachineType:    "S5.MEDIUM2",
+		FieldCloudSubnetIDs: []string{"subnet-1"},
+	}
+
+	actual := coredrift.Spec{
+		FieldName:           "vm",
+		FieldPowerState:     PowerStateStopped,
+		FieldCloudSGIDs:     []interface{}{"sg-1"},
+		FieldCloudImageID:   "img-1",
+		FieldMachineType:    "S5.MEDIUM2",
+		FieldCloudSubnetIDs: []interface{}{"subnet-1"},
+		"extra":             "ignored",
+	}
+
+	diffs, err := Diff(desired, actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []string{FieldCloudSGIDs, FieldPowerState}
+	if len(diffs) != len(expect) {
+		t.Fatalf("got %d diffs, expect %d: %+v", len(diffs), len(expect), diffs)
+	}
+	for idx := range expect {
+		if diffs[idx].Field != expect[idx] {
+			t.Errorf("diff[%d] field = %s, expect %s", idx, diffs[idx].Field, expect[idx])
+		}
+	}
+
+	actual[FieldPowerState] = PowerStateTransitional
+	actual[FieldCloudSGIDs] = []string{"sg-1", "sg-2"}
+	if diffs, err = Diff(desired, actual); err != nil || len(diffs) != 0 {
+		t.Errorf("transitional power state should not drift, diffs: %+v, err: %v", diffs, err)
+	}
+}
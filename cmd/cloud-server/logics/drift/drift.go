@@ -0,0 +1,331 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package drift 资源配置漂移检测，交付时保存资源的期望状态，定期与同步到的资源对比
+package drift
+
+import (
+	"fmt"
+
+	logicsipam "hcm/cmd/cloud-server/logics/ipam"
+	"hcm/pkg/api/core"
+	coredrift "hcm/pkg/api/core/drift"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dsdrift "hcm/pkg/api/data-service/drift"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// SupportedResTypes 支持漂移检测的资源类型
+var SupportedResTypes = []enumor.CloudResourceType{
+	enumor.VpcCloudResType,
+	enumor.SubnetCloudResType,
+	enumor.CvmCloudResType,
+}
+
+// resource 资源的基本信息以及当前状态
+type resource struct {
+	ID        string
+	CloudID   string
+	Vendor    enumor.Vendor
+	AccountID string
+	BkBizID   int64
+	Spec      coredrift.Spec
+}
+
+// Snapshot 保存资源交付时的期望状态，同一资源已有的期望状态会被替换
+func Snapshot(kt *kit.Kit, cli *dataservice.Client, appID string, resType enumor.CloudResourceType,
+	ids []string) error {
+
+	ids = slice.Unique(ids)
+	for _, part := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+		resources, err := listResource(kt, cli, resType, part)
+		if err != nil {
+			return err
+		}
+
+		if len(resources) == 0 {
+			continue
+		}
+
+		states := make([]dsdrift.DesiredStateCreate, 0, len(resources))
+		for _, one := range resources {
+			// 刚交付的主机可能还处于开机中，期望状态为运行中
+			if resType == enumor.CvmCloudResType && one.Spec[FieldPowerState] == PowerStateTransitional {
+				one.Spec[FieldPowerState] = PowerStateRunning
+			}
+
+			states = append(states, dsdrift.DesiredStateCreate{
+				ResType:       resType,
+				ResID:         one.ID,
+				CloudResID:    one.CloudID,
+				Vendor:        one.Vendor,
+				AccountID:     one.AccountID,
+				BkBizID:       one.BkBizID,
+				ApplicationID: appID,
+				Spec:          one.Spec,
+			})
+		}
+
+		req := &dsdrift.DesiredStateBatchCreateReq{States: states}
+		if _, err = cli.Global.Drift.BatchCreateDesiredState(kt, req); err != nil {
+			logs.Errorf("batch create desired state failed, err: %v, type: %s, ids: %v, rid: %s", err, resType,
+				part, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Check 对比符合条件的期望状态与资源的当前状态，保存漂移检测结果，返回检测的期望状态数量
+func Check(kt *kit.Kit, cli *dataservice.Client, expr *filter.Expression) (int, error) {
+	req := &core.ListReq{Filter: expr, Page: core.NewDefaultBasePage()}
+	total := 0
+	for {
+		result, err := cli.Global.Drift.ListDesiredState(kt, req)
+		if err != nil {
+			logs.Errorf("list desired state failed, err: %v, rid: %s", err, kt.Rid)
+			return total, err
+		}
+
+		if err = checkStates(kt, cli, result.Details); err != nil {
+			return total, err
+		}
+		total += len(result.Details)
+
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return total, nil
+}
+
+func checkStates(kt *kit.Kit, cli *dataservice.Client, states []coredrift.DesiredState) error {
+	typeStates := make(map[enumor.CloudResourceType][]coredrift.DesiredState)
+	for _, one := range states {
+		typeStates[one.ResType] = append(typeStates[one.ResType], one)
+	}
+
+	updates := make([]dsdrift.DesiredStateUpdate, 0, len(states))
+	for resType, list := range typeStates {
+		for _, part := range slice.Split(list, constant.BatchOperationMaxLimit) {
+			ids := slice.Map(part, func(one coredrift.DesiredState) string { return one.ResID })
+			resources, err := listResource(kt, cli, resType, ids)
+			if err != nil {
+				return err
+			}
+
+			actualMap := make(map[string]coredrift.Spec, len(resources))
+			for _, one := range resources {
+				actualMap[one.ID] = one.Spec
+			}
+
+			for _, state := range part {
+				update, err := compare(state, actualMap)
+				if err != nil {
+					logs.Errorf("compare desired state failed, err: %v, id: %s, rid: %s", err, state.ID, kt.Rid)
+					return err
+				}
+				updates = append(updates, update)
+			}
+		}
+	}
+
+	for _, part := range slice.Split(updates, constant.BatchOperationMaxLimit) {
+		req := &dsdrift.DesiredStateBatchUpdateReq{States: part}
+		if err := cli.Global.Drift.BatchUpdateDesiredState(kt, req); err != nil {
+			logs.Errorf("batch update desired state failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func compare(state coredrift.DesiredState, actualMap map[string]coredrift.Spec) (dsdrift.DesiredStateUpdate, error) {
+	update := dsdrift.DesiredStateUpdate{ID: state.ID}
+
+	actual, exist := actualMap[state.ResID]
+	if !exist || actual[FieldPowerState] == PowerStateTerminated {
+		update.DriftStatus = enumor.MissingDriftStatus
+		return update, nil
+	}
+
+	diffs, err := Diff(state.Spec, actual)
+	if err != nil {
+		return update, err
+	}
+
+	update.DriftStatus = enumor.InSyncDriftStatus
+	if len(diffs) != 0 {
+		update.DriftStatus = enumor.DriftedDriftStatus
+		update.DriftDetail = diffs
+	}
+
+	return update, nil
+}
+
+// ActualSpecs 查询资源的当前状态，返回资源ID到当前状态的映射，已不存在的资源不在返回结果中
+func ActualSpecs(kt *kit.Kit, cli *dataservice.Client, resType enumor.CloudResourceType, ids []string) (
+	map[string]coredrift.Spec, error) {
+
+	resources, err := listResource(kt, cli, resType, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := make(map[string]coredrift.Spec, len(resources))
+	for _, one := range resources {
+		specs[one.ID] = one.Spec
+	}
+
+	return specs, nil
+}
+
+func listResource(kt *kit.Kit, cli *dataservice.Client, resType enumor.CloudResourceType, ids []string) (
+	[]resource, error) {
+
+	switch resType {
+	case enumor.VpcCloudResType:
+		return listVpc(kt, cli, ids)
+	case enumor.SubnetCloudResType:
+		return listSubnet(kt, cli, ids)
+	case enumor.CvmCloudResType:
+		return listCvm(kt, cli, ids)
+	default:
+		return nil, fmt.Errorf("resource type: %s not support drift check", resType)
+	}
+}
+
+func listVpc(kt *kit.Kit, cli *dataservice.Client, ids []string) ([]resource, error) {
+	vpcs, err := logicsipam.ListVpcCidr(kt, cli, tools.ContainersExpression("id", ids))
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]resource, 0, len(vpcs))
+	for _, one := range vpcs {
+		resources = append(resources, resource{
+			ID:        one.ID,
+			CloudID:   one.CloudID,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			BkBizID:   one.BkBizID,
+			Spec: coredrift.Spec{
+				FieldName:  one.Name,
+				FieldCidrs: sortedCopy(one.Cidrs),
+			},
+		})
+	}
+
+	return resources, nil
+}
+
+func listSubnet(kt *kit.Kit, cli *dataservice.Client, ids []string) ([]resource, error) {
+	req := &core.ListReq{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := cli.Global.Subnet.List(kt.Ctx, kt.Header(), req)
+	if err != nil {
+		logs.Errorf("list subnet failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	resources := make([]resource, 0, len(result.Details))
+	for _, one := range result.Details {
+		resources = append(resources, resource{
+			ID:        one.ID,
+			CloudID:   one.CloudID,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			BkBizID:   one.BkBizID,
+			Spec: coredrift.Spec{
+				FieldName:              one.Name,
+				FieldIpv4Cidr:          sortedCopy(one.Ipv4Cidr),
+				FieldCloudRouteTableID: one.CloudRouteTableID,
+			},
+		})
+	}
+
+	return resources, nil
+}
+
+func listCvm(kt *kit.Kit, cli *dataservice.Client, ids []string) ([]resource, error) {
+	req := &core.ListReq{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := cli.Global.Cvm.ListCvm(kt, req)
+	if err != nil {
+		logs.Errorf("list cvm failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return make([]resource, 0), nil
+	}
+
+	relReq := &protocloud.SGCvmRelWithSecurityGroupListReq{CvmIDs: ids}
+	rels, err := cli.Global.SGCvmRel.ListWithSecurityGroup(kt.Ctx, kt.Header(), relReq)
+	if err != nil {
+		logs.Errorf("list security group cvm rel failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	cvmSGMap := make(map[string][]string)
+	for _, one := range rels {
+		cvmSGMap[one.CvmID] = append(cvmSGMap[one.CvmID], one.CloudID)
+	}
+
+	resources := make([]resource, 0, len(result.Details))
+	for _, one := range result.Details {
+		sgIDs := cvmSGMap[one.ID]
+		if sgIDs == nil {
+			sgIDs = make([]string, 0)
+		}
+
+		resources = append(resources, resource{
+			ID:        one.ID,
+			CloudID:   one.CloudID,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			BkBizID:   one.BkBizID,
+			Spec: coredrift.Spec{
+				FieldName:           one.Name,
+				FieldMachineType:    one.MachineType,
+				FieldCloudImageID:   one.CloudImageID,
+				FieldPowerState:     PowerState(one.Vendor, one.Status),
+				FieldCloudSubnetIDs: sortedCopy(one.CloudSubnetIDs),
+				FieldCloudSGIDs:     sortedCopy(sgIDs),
+			},
+		})
+	}
+
+	return resources, nil
+}
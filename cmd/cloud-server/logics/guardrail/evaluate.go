@@ -0,0 +1,172 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package guardrail
+
+import (
+	"strings"
+
+	coreguardrail "hcm/pkg/api/core/guardrail"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+
+	"github.com/tidwall/gjson"
+)
+
+// Evaluate 使用规则校验资源创建请求内容，返回请求违反的规则，未启用的规则不参与校验。
+func Evaluate(rules []coreguardrail.Rule, content string) []coreguardrail.Violation {
+	doc := gjson.Parse(content)
+	violations := make([]coreguardrail.Violation, 0)
+	for _, rule := range rules {
+		if !rule.Enabled || Match(rule.Expression, doc) {
+			continue
+		}
+
+		violations = append(violations, coreguardrail.Violation{
+			RuleID:   rule.ID,
+			RuleName: rule.Name,
+			Message:  rule.Message,
+		})
+	}
+
+	return violations
+}
+
+// Match 判断请求内容是否满足规则表达式，空表达式视为满足。
+func Match(expr *filter.Expression, doc gjson.Result) bool {
+	if expr.IsEmpty() {
+		return true
+	}
+
+	for _, one := range expr.Rules {
+		matched := matchRule(one, doc)
+		if expr.Op == filter.Or && matched {
+			return true
+		}
+
+		if expr.Op != filter.Or && !matched {
+			return false
+		}
+	}
+
+	return expr.Op != filter.Or
+}
+
+func matchRule(rule filter.RuleFactory, doc gjson.Result) bool {
+	switch one := rule.(type) {
+	case *filter.Expression:
+		return Match(one, doc)
+	case *filter.AtomRule:
+		return matchAtom(one, doc)
+	case filter.AtomRule:
+		return matchAtom(&one, doc)
+	default:
+		return false
+	}
+}
+
+// matchAtom 字段值为数组时要求每个元素都满足条件，字段不存在时按null参与比较。
+func matchAtom(rule *filter.AtomRule, doc gjson.Result) bool {
+	expected, err := json.Marshal(rule.Value)
+	if err != nil {
+		return false
+	}
+	value := gjson.ParseBytes(expected)
+
+	for _, actual := range fieldValues(doc, rule.Field) {
+		if !matchValue(filter.OpType(rule.Op), actual, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fieldValues 按json路径获取字段值，路径中的 # 表示数组中的每个元素，如 data_disk.#.disk_type。
+func fieldValues(doc gjson.Result, path string) []gjson.Result {
+	if idx := strings.Index(path, ".#."); idx >= 0 {
+		values := make([]gjson.Result, 0)
+		for _, elem := range doc.Get(path[:idx]).Array() {
+			values = append(values, fieldValues(elem, path[idx+3:])...)
+		}
+		return values
+	}
+
+	value := doc.Get(path)
+	if value.IsArray() {
+		return value.Array()
+	}
+
+	return []gjson.Result{value}
+}
+
+func matchValue(op filter.OpType, actual, expected gjson.Result) bool {
+	switch op {
+	case filter.Equal:
+		return equal(actual, expected)
+	case filter.NotEqual:
+		return !equal(actual, expected)
+	case filter.GreaterThan:
+		return actual.Type == gjson.Number && expected.Type == gjson.Number && actual.Num > expected.Num
+	case filter.GreaterThanEqual:
+		return actual.Type == gjson.Number && expected.Type == gjson.Number && actual.Num >= expected.Num
+	case filter.LessThan:
+		return actual.Type == gjson.Number && expected.Type == gjson.Number && actual.Num < expected.Num
+	case filter.LessThanEqual:
+		return actual.Type == gjson.Number && expected.Type == gjson.Number && actual.Num <= expected.Num
+	case filter.In:
+		for _, one := range expected.Array() {
+			if equal(actual, one) {
+				return true
+			}
+		}
+		return false
+	case filter.NotIn:
+		for _, one := range expected.Array() {
+			if equal(actual, one) {
+				return false
+			}
+		}
+		return true
+	case filter.ContainsSensitive:
+		return actual.Type == gjson.String && strings.Contains(actual.Str, expected.Str)
+	case filter.ContainsInsensitive:
+		return actual.Type == gjson.String && strings.Contains(strings.ToLower(actual.Str),
+			strings.ToLower(expected.Str))
+	default:
+		return false
+	}
+}
+
+func equal(actual, expected gjson.Result) bool {
+	if actual.Type != expected.Type {
+		return false
+	}
+
+	switch actual.Type {
+	case gjson.Number:
+		return actual.Num == expected.Num
+	case gjson.String:
+		return actual.Str == expected.Str
+	case gjson.Null, gjson.True, gjson.False:
+		return true
+	default:
+		return actual.Raw == expected.Raw
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package guardrail
+
+import (
+	"testing"
+
+	coreguardrail "hcm/pkg/api/core/guardrail"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+)
+
+func mustExpr(t *testing.T, raw string) *filter.Expression {
+	expr := new(filter.Expression)
+	if err := json.UnmarshalFromString(raw, expr); err != nil {
+		t.Fatalf("unmarshal expression failed, err: %v", err)
+	}
+
+	if err := coreguardrail.ValidateExpression(expr); err != nil {
+		t.Fatalf("validate expression failed, err: %v", err)
+	}
+
+	return expr
+}
+
+func TestEvaluate(t *testing.T) {
+	rules := []coreguardrail.Rule{
+		{ID: "region", Enabled: true, Message: "region not allowed", Expression: mustExpr(t,
+			`{"op":"and","rules":[{"field":"region","op":"in","value":["ap-guangzhou","ap-shanghai"]}]}`)},
+		{ID: "public-ip", Enabled: true, Message: "public ip not allowed", Expression: mustExpr(t,
+			`{"op":"and","rules":[{"field":"public_ip_assigned","op":"neq","value":true}]}`)},
+		{ID: "family", Enabled: true, Message: "instance family not allowed", Expression: mustExpr(t,
+			`{"op":"or","rules":[{"field":"instance_type","op":"cs","value":"S5."},
+			{"field":"instance_type","op":"cs","value":"SA2."}]}`)},
+		{ID: "data-disk", Enabled: true, Message: "data disk type not allowed", Expression: mustExpr(t,
+			`{"op":"and","rules":[{"field":"data_disk.#.disk_type","op":"in","value":["CLOUD_SSD"]},
+			{"field":"data_disk.#.disk_size_gb","op":"lte","value":1000}]}`)},
+		{ID: "disabled", Enabled: false, Message: "disabled", Expression: mustExpr(t,
+			`{"op":"and","rules":[{"field":"region","op":"eq","value":"none"}]}`)},
+	}
+
+	passed := `{"region":"ap-guangzhou","instance_type":"S5.MEDIUM4","public_ip_assigned":false,
+		"data_disk":[{"disk_type":"CLOUD_SSD","disk_size_gb":100}]}`
+	if violations := Evaluate(rules, passed); len(violations) != 0 {
+		t.Errorf("request should pass all rules, got: %+v", violations)
+	}
+
+	// 未传的公网IP字段按null比较，满足neq条件；没有数据盘时数组条件视为满足
+	omitted := `{"region":"ap-shanghai","instance_type":"SA2.LARGE8"}`
+	if violations := Evaluate(rules, omitted); len(violations) != 0 {
+		t.Errorf("request with omitted fields should pass all rules, got: %+v", violations)
+	}
+
+	violated := `{"region":"ap-beijing","instance_type":"IT5.4XLARGE64","public_ip_assigned":true,
+		"data_disk":[{"disk_type":"CLOUD_SSD","disk_size_gb":100},{"disk_type":"CLOUD_PREMIUM","disk_size_gb":100}]}`
+	violations := Evaluate(rules, violated)
+	expected := []string{"region", "public-ip", "family", "data-disk"}
+	if len(violations) != len(expected) {
+		t.Fatalf("request should violate %d rules, got: %+v", len(expected), violations)
+	}
+	for idx, id := range expected {
+		if violations[idx].RuleID != id {
+			t.Errorf("violation %d should be rule %s, got: %s", idx, id, violations[idx].RuleID)
+		}
+	}
+}
+
+func TestValidateExpression(t *testing.T) {
+	expr := new(filter.Expression)
+	raw := `{"op":"and","rules":[{"field":"tags","op":"json_contains","value":"prod"}]}`
+	if err := json.UnmarshalFromString(raw, expr); err != nil {
+		t.Fatalf("unmarshal expression failed, err: %v", err)
+	}
+
+	if err := coreguardrail.ValidateExpression(expr); err == nil {
+		t.Errorf("json operator should not be supported")
+	}
+
+	if err := coreguardrail.ValidateExpression(new(filter.Expression)); err == nil {
+		t.Errorf("empty expression should not be allowed")
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package guardrail 资源创建管控规则，直接创建资源以及提交资源创建申请单前校验请求是否满足组织的管控要求
+package guardrail
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/api/core"
+	coreguardrail "hcm/pkg/api/core/guardrail"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// Target 待校验的资源创建请求
+type Target struct {
+	// BkBizID 资源所属业务，未分配业务时为-1，只校验适用于全部业务的规则
+	BkBizID int64
+	ResType enumor.CloudResourceType
+	Vendor  enumor.Vendor
+	// Content 资源创建请求的json内容
+	Content string
+}
+
+// applicationResTypes 需要校验管控规则的申请单类型及其创建的资源类型
+var applicationResTypes = map[enumor.ApplicationType]enumor.CloudResourceType{
+	enumor.CreateCvm:    enumor.CvmCloudResType,
+	enumor.CreateDisk:   enumor.DiskCloudResType,
+	enumor.CreateVpc:    enumor.VpcCloudResType,
+	enumor.CreateSubnet: enumor.SubnetCloudResType,
+	enumor.CreateEip:    enumor.EipCloudResType,
+}
+
+// ApplicationResType 返回申请单创建的资源类型，不创建资源的申请单不需要校验管控规则
+func ApplicationResType(appType enumor.ApplicationType) (enumor.CloudResourceType, bool) {
+	resType, exist := applicationResTypes[appType]
+	return resType, exist
+}
+
+// EvaluateTarget 查询对请求生效的规则并校验，规则适用于全部或请求所属的业务、全部或请求的云厂商时生效
+func EvaluateTarget(kt *kit.Kit, cli *dataservice.Client, target *Target) (*coreguardrail.EvaluateResult, error) {
+	rules, err := listRule(kt, cli, target)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := Evaluate(rules, target.Content)
+	return &coreguardrail.EvaluateResult{Passed: len(violations) == 0, Violations: violations}, nil
+}
+
+// Check 校验资源创建请求，违反规则时返回GuardrailViolated错误，错误信息中包含全部违反规则的原因
+func Check(kt *kit.Kit, cli *dataservice.Client, target *Target) error {
+	result, err := EvaluateTarget(kt, cli, target)
+	if err != nil {
+		return err
+	}
+
+	if result.Passed {
+		return nil
+	}
+
+	reasons := make([]string, 0, len(result.Violations))
+	for _, one := range result.Violations {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", one.RuleName, one.Message))
+	}
+
+	return errf.Newf(errf.GuardrailViolated, "create %s violates guardrail rules, %s", target.ResType,
+		strings.Join(reasons, "; "))
+}
+
+func listRule(kt *kit.Kit, cli *dataservice.Client, target *Target) ([]coreguardrail.Rule, error) {
+	bizIDs := []int64{constant.UnassignedBiz}
+	if target.BkBizID > 0 {
+		bizIDs = append(bizIDs, target.BkBizID)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "bk_biz_id", Op: filter.In.Factory(), Value: bizIDs},
+				filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: target.ResType},
+				filter.AtomRule{Field: "vendor", Op: filter.In.Factory(), Value: []string{"", string(target.Vendor)}},
+				filter.AtomRule{Field: "enabled", Op: filter.Equal.Factory(), Value: true},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+
+	rules := make([]coreguardrail.Rule, 0)
+	for {
+		result, err := cli.Global.Guardrail.ListRule(kt, req)
+		if err != nil {
+			logs.Errorf("list guardrail rule failed, err: %v, target: %s/%s, rid: %s", err, target.ResType,
+				target.Vendor, kt.Rid)
+			return nil, err
+		}
+
+		rules = append(rules, result.Details...)
+
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return rules, nil
+}
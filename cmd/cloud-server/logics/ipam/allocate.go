@@ -0,0 +1,199 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package ipam IP地址管理，按管理员划分的地址池为vpc、子网分配不重叠的网段，并分析已同步vpc之间的网段冲突。
+package ipam
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+
+	coreipam "hcm/pkg/api/core/ipam"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/cidr"
+)
+
+// MatchPool 返回适用于业务、地域的地址池，按匹配程度排序：同时匹配业务和地域、只匹配业务、只匹配地域、全局地址池。
+func MatchPool(pools []coreipam.Pool, bizID int64, region string) []coreipam.Pool {
+	weight := func(pool coreipam.Pool) int {
+		score := 0
+		switch {
+		case pool.BkBizID == bizID && bizID > 0:
+			score += 2
+		case pool.BkBizID != constant.UnassignedBiz:
+			return -1
+		}
+
+		switch {
+		case len(pool.Region) != 0 && pool.Region == region:
+			score++
+		case len(pool.Region) != 0:
+			return -1
+		}
+
+		return score
+	}
+
+	matched := make([]coreipam.Pool, 0)
+	for _, pool := range pools {
+		if weight(pool) >= 0 {
+			matched = append(matched, pool)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return weight(matched[i]) > weight(matched[j])
+	})
+
+	return matched
+}
+
+// AllocateFromPool 从地址池中分配不与 used 重叠的vpc网段，并分配vpc网段中的第一个子网网段，掩码长度为0时使用地址池的默认值。
+func AllocateFromPool(pool coreipam.Pool, used []string, vpcMaskLen, subnetMaskLen uint) (
+	*coreipam.AllocateResult, error) {
+
+	if vpcMaskLen == 0 {
+		vpcMaskLen = pool.VpcMaskLen
+	}
+	if subnetMaskLen == 0 {
+		subnetMaskLen = pool.SubnetMaskLen
+	}
+	if subnetMaskLen < vpcMaskLen {
+		return nil, fmt.Errorf("subnet mask length %d is shorter than vpc mask length %d", subnetMaskLen, vpcMaskLen)
+	}
+
+	vpcCidr, err := NextCidr(pool.Cidr, used, vpcMaskLen)
+	if err != nil {
+		return nil, fmt.Errorf("allocate vpc cidr from pool %s(%s) failed, err: %v", pool.ID, pool.Cidr, err)
+	}
+
+	subnetCidr, err := NextCidr(vpcCidr, nil, subnetMaskLen)
+	if err != nil {
+		return nil, fmt.Errorf("allocate subnet cidr from vpc cidr %s failed, err: %v", vpcCidr, err)
+	}
+
+	return &coreipam.AllocateResult{PoolID: pool.ID, VpcCidr: vpcCidr, SubnetCidr: subnetCidr}, nil
+}
+
+// NextCidr 在 parent 网段中分配第一个不与 used 重叠、掩码长度为 maskLen 的网段，used 中的非IPv4网段会被忽略。
+func NextCidr(parent string, used []string, maskLen uint) (string, error) {
+	_, outer, err := net.ParseCIDR(parent)
+	if err != nil {
+		return "", fmt.Errorf("parse cidr %s failed, err: %v", parent, err)
+	}
+
+	usedNets := make([]net.IPNet, 0, len(used))
+	for _, one := range used {
+		if _, ipNet, err := net.ParseCIDR(one); err == nil {
+			usedNets = append(usedNets, *ipNet)
+		}
+	}
+
+	next, err := cidr.FirstAvailableNet(*outer, usedNets, int(maskLen))
+	if err != nil {
+		return "", err
+	}
+
+	return next.String(), nil
+}
+
+// FirstHostIP 返回网段中第一个可用的主机IP，一般作为子网的网关地址。
+func FirstHostIP(subnet string) (string, error) {
+	_, ipNet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return "", fmt.Errorf("parse cidr %s failed, err: %v", subnet, err)
+	}
+
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		return "", fmt.Errorf("cidr %s is not ipv4 cidr", subnet)
+	}
+
+	gateway := make(net.IP, len(ip))
+	copy(gateway, ip)
+	gateway[3]++
+	if !ipNet.Contains(gateway) {
+		return "", fmt.Errorf("cidr %s has no host ip", subnet)
+	}
+
+	return gateway.String(), nil
+}
+
+type cidrRange struct {
+	vpcIdx int
+	cidr   string
+	start  uint64
+	end    uint64
+}
+
+// FindConflicts 找出不同vpc之间相互重叠的IPv4网段，重叠的网段会导致vpc之间无法通过对等连接、VPN、专线等方式互通。
+func FindConflicts(vpcs []coreipam.VpcCidr) []coreipam.Conflict {
+	ranges := make([]cidrRange, 0, len(vpcs))
+	for idx, vpc := range vpcs {
+		for _, one := range vpc.Cidrs {
+			_, ipNet, err := net.ParseCIDR(one)
+			if err != nil || ipNet.IP.To4() == nil {
+				continue
+			}
+
+			ones, _ := ipNet.Mask.Size()
+			start := uint64(binary.BigEndian.Uint32(ipNet.IP.To4()))
+			ranges = append(ranges, cidrRange{vpcIdx: idx, cidr: ipNet.String(), start: start,
+				end: start + 1<<(32-ones)})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].start < ranges[j].start
+	})
+
+	conflicts := make([]coreipam.Conflict, 0)
+	for i := range ranges {
+		for j := i + 1; j < len(ranges) && ranges[j].start < ranges[i].end; j++ {
+			if ranges[i].vpcIdx == ranges[j].vpcIdx {
+				continue
+			}
+
+			vpc, peer := vpcs[ranges[i].vpcIdx], vpcs[ranges[j].vpcIdx]
+			conflicts = append(conflicts, coreipam.Conflict{
+				Kind:     conflictKind(vpc, peer),
+				Vpc:      vpc,
+				PeerVpc:  peer,
+				Cidr:     ranges[i].cidr,
+				PeerCidr: ranges[j].cidr,
+			})
+		}
+	}
+
+	return conflicts
+}
+
+func conflictKind(vpc, peer coreipam.VpcCidr) enumor.IPAMConflictKind {
+	switch {
+	case vpc.Vendor != peer.Vendor:
+		return enumor.CrossVendorIPAMConflict
+	case vpc.AccountID != peer.AccountID:
+		return enumor.CrossAccountIPAMConflict
+	default:
+		return enumor.SameAccountIPAMConflict
+	}
+}
@@ -0,0 +1,367 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	coreipam "hcm/pkg/api/core/ipam"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// AllocateOption 分配vpc网段的参数
+type AllocateOption struct {
+	BkBizID int64
+	Region  string
+	// VpcMaskLen、SubnetMaskLen 为0时使用地址池的默认值
+	VpcMaskLen    uint
+	SubnetMaskLen uint
+}
+
+// Allocate 按业务、地域匹配地址池，从地址池中分配不与任何已同步vpc重叠的vpc网段及其第一个子网网段，
+// 匹配到多个地址池时按匹配程度依次尝试。
+func Allocate(kt *kit.Kit, cli *dataservice.Client, opt *AllocateOption) (*coreipam.AllocateResult, error) {
+	bizIDs := []int64{constant.UnassignedBiz}
+	if opt.BkBizID > 0 {
+		bizIDs = append(bizIDs, opt.BkBizID)
+	}
+
+	expr, err := tools.And(tools.ContainersExpression("bk_biz_id", bizIDs),
+		tools.ContainersExpression("region", []string{"", opt.Region}))
+	if err != nil {
+		return nil, err
+	}
+
+	pools, err := listPool(kt, cli, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := MatchPool(pools, opt.BkBizID, opt.Region)
+	if len(candidates) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "no ipam pool matches biz: %d, region: %s", opt.BkBizID,
+			opt.Region)
+	}
+
+	vpcs, err := ListVpcCidr(kt, cli, tools.AllExpression())
+	if err != nil {
+		return nil, err
+	}
+
+	used := make([]string, 0, len(vpcs))
+	for _, vpc := range vpcs {
+		used = append(used, vpc.Cidrs...)
+	}
+
+	for _, pool := range candidates {
+		result, err := AllocateFromPool(pool, used, opt.VpcMaskLen, opt.SubnetMaskLen)
+		if err != nil {
+			logs.Warnf("allocate cidr from ipam pool failed, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("no available cidr in ipam pools that match biz: %d, region: %s", opt.BkBizID,
+		opt.Region)
+}
+
+// SuggestSubnet 在vpc网段中建议第一个不与vpc下已有子网重叠的子网网段，gcp的vpc没有网段，不支持建议。
+func SuggestSubnet(kt *kit.Kit, cli *dataservice.Client, vpcID string, maskLen uint) (
+	*coreipam.SubnetSuggestResult, error) {
+
+	vpcs, err := ListVpcCidr(kt, cli, tools.EqualExpression("id", vpcID))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vpcs) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "vpc: %s not found", vpcID)
+	}
+
+	if vpcs[0].Vendor == enumor.Gcp {
+		return nil, errf.New(errf.InvalidParameter, "gcp vpc has no cidr, subnet cidr should be allocated from pool")
+	}
+
+	subnets, err := listSubnet(kt, cli, tools.EqualExpression("vpc_id", vpcID))
+	if err != nil {
+		return nil, err
+	}
+
+	used := make([]string, 0, len(subnets))
+	for _, subnet := range subnets {
+		used = append(used, subnet.Ipv4Cidr...)
+	}
+
+	for _, vpcCidr := range vpcs[0].Cidrs {
+		subnetCidr, err := NextCidr(vpcCidr, used, maskLen)
+		if err != nil {
+			continue
+		}
+
+		return &coreipam.SubnetSuggestResult{VpcID: vpcID, VpcCidr: vpcCidr, SubnetCidr: subnetCidr}, nil
+	}
+
+	return nil, fmt.Errorf("no available subnet cidr with mask length %d in vpc: %s", maskLen, vpcID)
+}
+
+// ConflictReport 分析全部已同步vpc之间的网段冲突，包括跨账号、跨云厂商的网段重叠。
+func ConflictReport(kt *kit.Kit, cli *dataservice.Client) (*coreipam.ConflictReport, error) {
+	vpcs, err := ListVpcCidr(kt, cli, tools.AllExpression())
+	if err != nil {
+		return nil, err
+	}
+
+	return &coreipam.ConflictReport{VpcCount: len(vpcs), Conflicts: FindConflicts(vpcs)}, nil
+}
+
+// ListVpcCidr 查询vpc及其IPv4网段，gcp的vpc没有网段，使用其下子网的网段。
+func ListVpcCidr(kt *kit.Kit, cli *dataservice.Client, expr *filter.Expression) ([]coreipam.VpcCidr, error) {
+	result := make([]coreipam.VpcCidr, 0)
+
+	tcloud, err := listVpcExt(kt, expr, enumor.TCloud,
+		func(req *core.ListReq) (*protocloud.VpcExtListResult[corecloud.TCloudVpcExtension], error) {
+			return cli.TCloud.Vpc.ListVpcExt(kt.Ctx, kt.Header(), req)
+		},
+		func(ext *corecloud.TCloudVpcExtension) []string {
+			cidrs := make([]string, 0, len(ext.Cidr))
+			for _, one := range ext.Cidr {
+				if one.Type == enumor.Ipv4 {
+					cidrs = append(cidrs, one.Cidr)
+				}
+			}
+			return cidrs
+		})
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, tcloud...)
+
+	aws, err := listVpcExt(kt, expr, enumor.Aws,
+		func(req *core.ListReq) (*protocloud.VpcExtListResult[corecloud.AwsVpcExtension], error) {
+			return cli.Aws.Vpc.ListVpcExt(kt.Ctx, kt.Header(), req)
+		},
+		func(ext *corecloud.AwsVpcExtension) []string {
+			cidrs := make([]string, 0, len(ext.Cidr))
+			for _, one := range ext.Cidr {
+				if one.Type == enumor.Ipv4 {
+					cidrs = append(cidrs, one.Cidr)
+				}
+			}
+			return cidrs
+		})
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, aws...)
+
+	huawei, err := listVpcExt(kt, expr, enumor.HuaWei,
+		func(req *core.ListReq) (*protocloud.VpcExtListResult[corecloud.HuaWeiVpcExtension], error) {
+			return cli.HuaWei.Vpc.ListVpcExt(kt.Ctx, kt.Header(), req)
+		},
+		func(ext *corecloud.HuaWeiVpcExtension) []string {
+			cidrs := make([]string, 0, len(ext.Cidr))
+			for _, one := range ext.Cidr {
+				if one.Type == enumor.Ipv4 {
+					cidrs = append(cidrs, one.Cidr)
+				}
+			}
+			return cidrs
+		})
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, huawei...)
+
+	azure, err := listVpcExt(kt, expr, enumor.Azure,
+		func(req *core.ListReq) (*protocloud.VpcExtListResult[corecloud.AzureVpcExtension], error) {
+			return cli.Azure.Vpc.ListVpcExt(kt.Ctx, kt.Header(), req)
+		},
+		func(ext *corecloud.AzureVpcExtension) []string {
+			cidrs := make([]string, 0, len(ext.Cidr))
+			for _, one := range ext.Cidr {
+				if one.Type == enumor.Ipv4 {
+					cidrs = append(cidrs, one.Cidr)
+				}
+			}
+			return cidrs
+		})
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, azure...)
+
+	gcp, err := listGcpVpcCidr(kt, cli, expr)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, gcp...)
+
+	return result, nil
+}
+
+// listVpcExt 分页查询指定云厂商的vpc，并通过 cidrFn 提取扩展信息中的IPv4网段
+func listVpcExt[T corecloud.VpcExtension](kt *kit.Kit, expr *filter.Expression, vendor enumor.Vendor,
+	listFn func(req *core.ListReq) (*protocloud.VpcExtListResult[T], error), cidrFn func(ext *T) []string) (
+	[]coreipam.VpcCidr, error) {
+
+	vendorExpr, err := tools.And(expr, tools.EqualExpression("vendor", vendor))
+	if err != nil {
+		return nil, err
+	}
+
+	req := &core.ListReq{Filter: vendorExpr, Page: core.NewDefaultBasePage()}
+	result := make([]coreipam.VpcCidr, 0)
+	for {
+		resp, err := listFn(req)
+		if err != nil {
+			logs.Errorf("list %s vpc failed, err: %v, rid: %s", vendor, err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Details {
+			vpc := convVpcCidr(&one.BaseVpc)
+			if one.Extension != nil {
+				vpc.Cidrs = cidrFn(one.Extension)
+			}
+			result = append(result, vpc)
+		}
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return result, nil
+}
+
+// listGcpVpcCidr gcp的vpc没有网段，使用vpc下子网的网段作为vpc的网段
+func listGcpVpcCidr(kt *kit.Kit, cli *dataservice.Client, expr *filter.Expression) ([]coreipam.VpcCidr, error) {
+	vendorExpr, err := tools.And(expr, tools.EqualExpression("vendor", enumor.Gcp))
+	if err != nil {
+		return nil, err
+	}
+
+	req := &core.ListReq{Filter: vendorExpr, Page: core.NewDefaultBasePage()}
+	vpcs := make([]coreipam.VpcCidr, 0)
+	for {
+		resp, err := cli.Global.Vpc.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list gcp vpc failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for idx := range resp.Details {
+			vpcs = append(vpcs, convVpcCidr(&resp.Details[idx]))
+		}
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	if len(vpcs) == 0 {
+		return vpcs, nil
+	}
+
+	subnets, err := listSubnet(kt, cli, tools.EqualExpression("vendor", enumor.Gcp))
+	if err != nil {
+		return nil, err
+	}
+
+	vpcCidrs := make(map[string][]string)
+	for _, subnet := range subnets {
+		vpcCidrs[subnet.VpcID] = append(vpcCidrs[subnet.VpcID], subnet.Ipv4Cidr...)
+	}
+
+	for idx := range vpcs {
+		vpcs[idx].Cidrs = vpcCidrs[vpcs[idx].ID]
+	}
+
+	return vpcs, nil
+}
+
+func convVpcCidr(vpc *corecloud.BaseVpc) coreipam.VpcCidr {
+	return coreipam.VpcCidr{
+		ID:        vpc.ID,
+		CloudID:   vpc.CloudID,
+		Name:      vpc.Name,
+		Vendor:    vpc.Vendor,
+		AccountID: vpc.AccountID,
+		Region:    vpc.Region,
+		BkBizID:   vpc.BkBizID,
+		Cidrs:     make([]string, 0),
+	}
+}
+
+func listSubnet(kt *kit.Kit, cli *dataservice.Client, expr *filter.Expression) ([]corecloud.BaseSubnet, error) {
+	req := &core.ListReq{Filter: expr, Page: core.NewDefaultBasePage()}
+	subnets := make([]corecloud.BaseSubnet, 0)
+	for {
+		resp, err := cli.Global.Subnet.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list subnet failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		subnets = append(subnets, resp.Details...)
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return subnets, nil
+}
+
+func listPool(kt *kit.Kit, cli *dataservice.Client, expr *filter.Expression) ([]coreipam.Pool, error) {
+	req := &core.ListReq{Filter: expr, Page: core.NewDefaultBasePage()}
+	pools := make([]coreipam.Pool, 0)
+	for {
+		resp, err := cli.Global.IPAM.ListPool(kt, req)
+		if err != nil {
+			logs.Errorf("list ipam pool failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		pools = append(pools, resp.Details...)
+
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return pools, nil
+}
@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	"testing"
+
+	coreipam "hcm/pkg/api/core/ipam"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestMatchPool(t *testing.T) {
+	pools := []coreipam.Pool{
+		{ID: "global", BkBizID: constant.UnassignedBiz},
+		{ID: "region", BkBizID: constant.UnassignedBiz, Region: "ap-guangzhou"},
+		{ID: "biz", BkBizID: 100},
+		{ID: "biz-region", BkBizID: 100, Region: "ap-guangzhou"},
+		{ID: "other-biz", BkBizID: 200},
+		{ID: "other-region", BkBizID: constant.UnassignedBiz, Region: "ap-shanghai"},
+	}
+
+	expects := map[int64][]string{
+		100: {"biz-region", "biz", "region", "global"},
+		0:   {"region", "global"},
+	}
+	for bizID, expect := range expects {
+		matched := MatchPool(pools, bizID, "ap-guangzhou")
+		if len(matched) != len(expect) {
+			t.Fatalf("biz %d matched %d pools, expect %d", bizID, len(matched), len(expect))
+		}
+		for idx := range expect {
+			if matched[idx].ID != expect[idx] {
+				t.Errorf("biz %d matched pool[%d] = %s, expect %s", bizID, idx, matched[idx].ID, expect[idx])
+			}
+		}
+	}
+}
+
+func TestAllocateFromPool(t *testing.T) {
+	pool := coreipam.Pool{ID: "pool", Cidr: "10.0.0.0/8", VpcMaskLen: 16, SubnetMaskLen: 24}
+	used := []string{"10.0.0.0/16", "10.1.128.0/24", "172.16.0.0/12", "fd00::/8"}
+
+	result, err := AllocateFromPool(pool, used, 0, 0)
+	if err != nil {
+		t.Fatalf("allocate failed, err: %v", err)
+	}
+	if result.VpcCidr != "10.2.0.0/16" || result.SubnetCidr != "10.2.0.0/24" {
+		t.Errorf("allocate result %+v is not expected", result)
+	}
+
+	result, err = AllocateFromPool(pool, used, 17, 20)
+	if err != nil {
+		t.Fatalf("allocate failed, err: %v", err)
+	}
+	if result.VpcCidr != "10.1.0.0/17" || result.SubnetCidr != "10.1.0.0/20" {
+		t.Errorf("allocate result %+v is not expected", result)
+	}
+
+	if _, err = AllocateFromPool(pool, []string{"10.0.0.0/8"}, 0, 0); err == nil {
+		t.Errorf("allocate from exhausted pool should fail")
+	}
+
+	gateway, err := FirstHostIP(result.SubnetCidr)
+	if err != nil || gateway != "10.1.0.1" {
+		t.Errorf("first host ip of %s is %s, err: %v", result.SubnetCidr, gateway, err)
+	}
+}
+
+func TestFindConflicts(t *testing.T) {
+	vpcs := []coreipam.VpcCidr{
+		{ID: "a", Vendor: enumor.TCloud, AccountID: "1", Cidrs: []string{"10.0.0.0/16", "10.0.1.0/24"}},
+		{ID: "b", Vendor: enumor.TCloud, AccountID: "1", Cidrs: []string{"10.0.128.0/17"}},
+		{ID: "c", Vendor: enumor.TCloud, AccountID: "2", Cidrs: []string{"10.0.1.0/24"}},
+		{ID: "d", Vendor: enumor.Aws, AccountID: "3", Cidrs: []string{"10.0.0.0/8"}},
+		{ID: "e", Vendor: enumor.Aws, AccountID: "3", Cidrs: []string{"192.168.0.0/16"}},
+	}
+
+	expects := map[string]enumor.IPAMConflictKind{
+		"a-b": enumor.SameAccountIPAMConflict,
+		"a-c": enumor.CrossAccountIPAMConflict,
+		"a-d": enumor.CrossVendorIPAMConflict,
+		"b-d": enumor.CrossVendorIPAMConflict,
+		"c-d": enumor.CrossVendorIPAMConflict,
+	}
+
+	conflicts := FindConflicts(vpcs)
+	got := make(map[string]int)
+	for _, one := range conflicts {
+		key := one.Vpc.ID + "-" + one.PeerVpc.ID
+		if one.Vpc.ID > one.PeerVpc.ID {
+			key = one.PeerVpc.ID + "-" + one.Vpc.ID
+		}
+
+		kind, exists := expects[key]
+		if !exists {
+			t.Errorf("unexpected conflict between %s and %s", one.Vpc.ID, one.PeerVpc.ID)
+			continue
+		}
+		if kind != one.Kind {
+			t.Errorf("conflict %s kind is %s, expect %s", key, one.Kind, kind)
+		}
+		got[key]++
+	}
+
+	for key := range expects {
+		if got[key] == 0 {
+			t.Errorf("conflict %s not found", key)
+		}
+	}
+}
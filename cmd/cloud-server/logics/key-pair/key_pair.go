@@ -0,0 +1,145 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair ...
+package keypair
+
+import (
+	"errors"
+	"fmt"
+
+	logicaudit "hcm/cmd/cloud-server/logics/audit"
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	datakeypair "hcm/pkg/api/data-service/cloud/key-pair"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// Assign 分配密钥对到业务下
+func Assign(kt *kit.Kit, cli *dataservice.Client, ids []string, bizID int64) error {
+	if len(ids) == 0 {
+		return errors.New("ids is required")
+	}
+
+	if err := ValidateBeforeAssign(kt, cli, ids); err != nil {
+		return err
+	}
+
+	// create assign audit
+	audit := logicaudit.NewAudit(cli)
+	if err := audit.ResBizAssignAudit(kt, enumor.KeyPairAuditResType, ids, bizID); err != nil {
+		logs.Errorf("create assign key pair audit failed, ids: %v, bizID: %d, err: %v, rid: %s", ids, bizID, err,
+			kt.Rid)
+		return err
+	}
+
+	// assign
+	req := &datakeypair.KeyPairBatchUpdateExprReq{
+		IDs:     ids,
+		BkBizID: bizID,
+	}
+	if err := cli.Global.KeyPair.BatchUpdateKeyPairBizID(kt, req); err != nil {
+		logs.Errorf("batch update key pair biz failed, ids: %v, bizID: %d, err: %v, rid: %s", ids, bizID, err,
+			kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ValidateBeforeAssign 分配前置校验
+func ValidateBeforeAssign(kt *kit.Kit, cli *dataservice.Client, ids []string) error {
+	// 判断是否已经分配
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: ids},
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.NotEqual.Factory(), Value: constant.UnassignedBiz},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	listResp, err := cli.Global.KeyPair.ListKeyPair(kt, listReq)
+	if err != nil {
+		logs.Errorf("list key pair failed, req: %+v, err: %v, rid: %s", listReq, err, kt.Rid)
+		return err
+	}
+
+	if len(listResp.Details) != 0 {
+		return fmt.Errorf("key pair(ids=%v) already assigned", slice.Map(listResp.Details,
+			func(one corekeypair.BaseKeyPair) string { return one.ID }))
+	}
+
+	return nil
+}
+
+// CvmKeyPairOption 创建主机时选择的密钥对需要满足的条件
+type CvmKeyPairOption struct {
+	KeyPairID string
+	Vendor    enumor.Vendor
+	AccountID string
+	// Region 腾讯云、谷歌云的密钥对与地域无关，不校验
+	Region  string
+	BkBizID int64
+}
+
+// GetForCvmCreate 获取创建主机时选择的密钥对，密钥对需要与主机属于相同的云厂商、账号、地域和业务
+func GetForCvmCreate(kt *kit.Kit, cli *dataservice.Client, opt *CvmKeyPairOption) (*corekeypair.BaseKeyPair,
+	error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", opt.KeyPairID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := cli.Global.KeyPair.ListKeyPair(kt, listReq)
+	if err != nil {
+		logs.Errorf("list key pair failed, id: %s, err: %v, rid: %s", opt.KeyPairID, err, kt.Rid)
+		return nil, err
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "key pair: %s not found", opt.KeyPairID)
+	}
+
+	keyPair := listResp.Details[0]
+	if keyPair.Vendor != opt.Vendor || keyPair.AccountID != opt.AccountID {
+		return nil, errf.Newf(errf.InvalidParameter, "key pair: %s not belongs to %s account: %s",
+			opt.KeyPairID, opt.Vendor, opt.AccountID)
+	}
+
+	if len(keyPair.Region) != 0 && keyPair.Region != opt.Region {
+		return nil, errf.Newf(errf.InvalidParameter, "key pair: %s not in region: %s", opt.KeyPairID, opt.Region)
+	}
+
+	if opt.BkBizID > 0 && keyPair.BkBizID != opt.BkBizID {
+		return nil, errf.Newf(errf.InvalidParameter, "key pair: %s not belongs to biz: %d", opt.KeyPairID,
+			opt.BkBizID)
+	}
+
+	return &keyPair, nil
+}
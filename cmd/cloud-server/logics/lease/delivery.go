@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package lease
+
+import (
+	cslease "hcm/pkg/api/cloud-server/lease"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	dslease "hcm/pkg/api/data-service/lease"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// CreateForDelivery 资源交付成功后按照申请时设置的租约创建租约，未设置到期时间时跳过，已有租约的资源不重复创建
+func CreateForDelivery(kt *kit.Kit, cli *dataservice.Client, opt cslease.LeaseOption, applicant string,
+	resType enumor.CloudResourceType, ids []string) error {
+
+	if opt.ExpireAt == nil || len(ids) == 0 {
+		return nil
+	}
+
+	owner := opt.Owner
+	if len(owner) == 0 {
+		owner = applicant
+	}
+
+	for _, part := range slice.Split(ids, int(core.DefaultMaxPageLimit)) {
+		basicInfoReq := dataproto.ListResourceBasicInfoReq{
+			ResourceType: resType,
+			IDs:          part,
+			Fields:       types.CommonBasicInfoFields,
+		}
+		basicInfoMap, err := cli.Global.Cloud.ListResBasicInfo(kt, basicInfoReq)
+		if err != nil {
+			logs.Errorf("list resource basic info failed, err: %v, ids: %v, rid: %s", err, part, kt.Rid)
+			return err
+		}
+
+		exists, err := listLeasedResID(kt, cli, resType, part)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range part {
+			if _, exist := exists[id]; exist {
+				continue
+			}
+
+			info, exist := basicInfoMap[id]
+			if !exist {
+				return errf.Newf(errf.RecordNotFound, "%s: %s not found", resType, id)
+			}
+
+			createReq := &dslease.LeaseCreateReq{
+				ResType:  resType,
+				ResID:    id,
+				Vendor:   info.Vendor,
+				BkBizID:  info.BkBizID,
+				Owner:    owner,
+				ExpireAt: *opt.ExpireAt,
+			}
+			if _, err = cli.Global.Lease.CreateLease(kt, createReq); err != nil {
+				logs.Errorf("create resource lease failed, err: %v, res: %s(%s), rid: %s", err, resType, id, kt.Rid)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func listLeasedResID(kt *kit.Kit, cli *dataservice.Client, resType enumor.CloudResourceType, resIDs []string) (
+	map[string]struct{}, error) {
+
+	expr, err := tools.And(tools.EqualExpression("res_type", resType), tools.ContainersExpression("res_id", resIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"res_id"},
+	}
+	result, err := cli.Global.Lease.ListLease(kt, listReq)
+	if err != nil {
+		logs.Errorf("list resource lease failed, err: %v, res_ids: %v, rid: %s", err, resIDs, kt.Rid)
+		return nil, err
+	}
+
+	exists := make(map[string]struct{}, len(result.Details))
+	for _, one := range result.Details {
+		exists[one.ResID] = struct{}{}
+	}
+
+	return exists, nil
+}
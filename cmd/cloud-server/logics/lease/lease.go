@@ -0,0 +1,260 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package lease 资源租约相关逻辑，到期前提醒负责人，到期后将资源移入回收站
+package lease
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/cmd/cloud-server/logics"
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+	csdisk "hcm/pkg/api/cloud-server/disk"
+	csrecycle "hcm/pkg/api/cloud-server/recycle"
+	"hcm/pkg/api/core"
+	corelease "hcm/pkg/api/core/lease"
+	corerecord "hcm/pkg/api/core/recycle-record"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	dslease "hcm/pkg/api/data-service/lease"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+)
+
+// Classify 从生效中的租约里筛选出需要发送到期提醒和已到期需要回收的租约，每个到期时间只提醒一次
+func Classify(leases []corelease.Lease, now time.Time, remindBefore time.Duration) (remind,
+	expired []corelease.Lease, err error) {
+
+	for _, one := range leases {
+		if one.State != enumor.LeaseActive {
+			continue
+		}
+
+		expireAt, err := time.Parse(constant.TimeStdFormat, one.ExpireAt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse lease %s expire_at failed, err: %v", one.ID, err)
+		}
+
+		switch {
+		case !expireAt.After(now):
+			expired = append(expired, one)
+		case !one.Reminded && !expireAt.Add(-remindBefore).After(now):
+			remind = append(remind, one)
+		}
+	}
+
+	return remind, expired, nil
+}
+
+// RenewExpireAt 计算续期后的到期时间，已过期的租约从当前时间开始续期
+func RenewExpireAt(expireAt, now time.Time, days uint64) time.Time {
+	if expireAt.Before(now) {
+		expireAt = now
+	}
+
+	return expireAt.Add(time.Duration(days) * 24 * time.Hour)
+}
+
+// Checker 检查生效中的租约，发送到期提醒并回收到期资源
+type Checker struct {
+	cliSet   *client.ClientSet
+	logics   *logics.Logics
+	notifier Notifier
+	conf     cc.Lease
+}
+
+// NewChecker new lease checker.
+func NewChecker(cliSet *client.ClientSet, lgc *logics.Logics, notifier Notifier, conf cc.Lease) *Checker {
+	return &Checker{
+		cliSet:   cliSet,
+		logics:   lgc,
+		notifier: notifier,
+		conf:     conf,
+	}
+}
+
+// Check 处理在提醒时间范围内的所有租约，单个租约处理失败不影响其他租约，会在下一轮检查时重试
+func (c *Checker) Check(kt *kit.Kit, now time.Time) error {
+	remindBefore := time.Duration(c.conf.RemindBeforeHour) * time.Hour
+	leases, err := c.listDueLease(kt, now.Add(remindBefore))
+	if err != nil {
+		return err
+	}
+
+	remind, expired, err := Classify(leases, now, remindBefore)
+	if err != nil {
+		return err
+	}
+
+	for idx := range remind {
+		if err = c.remind(kt, &remind[idx]); err != nil {
+			logs.Errorf("remind lease failed, err: %v, id: %s, rid: %s", err, remind[idx].ID, kt.Rid)
+		}
+	}
+
+	for idx := range expired {
+		if err = c.recycle(kt, &expired[idx]); err != nil {
+			logs.Errorf("recycle expired lease resource failed, err: %v, id: %s, rid: %s", err, expired[idx].ID,
+				kt.Rid)
+		}
+	}
+
+	return nil
+}
+
+// listDueLease 先查询出全部待处理租约再处理，避免处理过程中租约状态变化影响分页
+func (c *Checker) listDueLease(kt *kit.Kit, before time.Time) ([]corelease.Lease, error) {
+	expr, err := tools.And(
+		tools.EqualExpression("state", enumor.LeaseActive),
+		&filter.AtomRule{Field: "expire_at", Op: filter.LessThanEqual.Factory(),
+			Value: times.ConvStdTimeFormat(before)},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+	}
+	leases := make([]corelease.Lease, 0)
+	for {
+		result, err := c.cliSet.DataService().Global.Lease.ListLease(kt, listReq)
+		if err != nil {
+			logs.Errorf("list due lease failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		leases = append(leases, result.Details...)
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return leases, nil
+}
+
+func (c *Checker) remind(kt *kit.Kit, lease *corelease.Lease) error {
+	if err := c.notifier.Notify(kt, newNotification(ExpiringEvent, lease)); err != nil {
+		return err
+	}
+
+	req := &dslease.LeaseUpdateReq{Reminded: converter.ValToPtr(true)}
+	return c.cliSet.DataService().Global.Lease.UpdateLease(kt, lease.ID, req)
+}
+
+// recycle 将到期资源移入回收站，资源已被删除时直接删除租约，资源已在回收站中时只更新租约状态
+func (c *Checker) recycle(kt *kit.Kit, lease *corelease.Lease) error {
+	infoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: lease.ResType,
+		IDs:          []string{lease.ResID},
+		Fields:       append(types.CommonBasicInfoFields, "region", "recycle_status"),
+	}
+	basicInfoMap, err := c.cliSet.DataService().Global.Cloud.ListResBasicInfo(kt, infoReq)
+	if err != nil {
+		if ef := errf.Error(err); ef.Code == errf.RecordNotFound {
+			logs.Infof("resource %s(%s) of lease %s not found, delete lease, rid: %s", lease.ResType, lease.ResID,
+				lease.ID, kt.Rid)
+			return c.cliSet.DataService().Global.Lease.BatchDeleteLease(kt,
+				&core.BatchDeleteReq{IDs: []string{lease.ID}})
+		}
+		return err
+	}
+
+	info, exists := basicInfoMap[lease.ResID]
+	if !exists {
+		return fmt.Errorf("resource %s(%s) basic info not found", lease.ResType, lease.ResID)
+	}
+
+	taskID := ""
+	if info.RecycleStatus != enumor.RecycleStatus {
+		if taskID, err = c.recycleRes(kt, lease, basicInfoMap); err != nil {
+			return err
+		}
+	}
+
+	req := &dslease.LeaseUpdateReq{State: enumor.LeaseRecycled}
+	if err = c.cliSet.DataService().Global.Lease.UpdateLease(kt, lease.ID, req); err != nil {
+		return err
+	}
+
+	notification := newNotification(RecycledEvent, lease)
+	notification.RecycleTaskID = taskID
+	// 资源已进入回收站，通知失败不重试
+	if err = c.notifier.Notify(kt, notification); err != nil {
+		logs.Errorf("notify lease recycled failed, err: %v, id: %s, rid: %s", err, lease.ID, kt.Rid)
+	}
+
+	return nil
+}
+
+func (c *Checker) recycleRes(kt *kit.Kit, lease *corelease.Lease,
+	basicInfoMap map[string]types.CloudResourceBasicInfo) (string, error) {
+
+	switch lease.ResType {
+	case enumor.CvmCloudResType:
+		// 随主机创建的硬盘一同回收，eip不随主机回收
+		infos := []cscvm.CvmRecycleInfo{{
+			ID:                lease.ResID,
+			CvmRecycleOptions: corerecord.CvmRecycleOptions{WithDisk: true},
+		}}
+		return c.logics.Cvm.RecycleCvm(kt, infos, basicInfoMap)
+
+	case enumor.DiskCloudResType:
+		infos := []csdisk.DiskRecycleInfo{{ID: lease.ResID, DiskRecycleOptions: new(corerecord.DiskRecycleOptions)}}
+		result, err := c.logics.Disk.RecycleDisk(kt, infos, basicInfoMap)
+		if err != nil {
+			return "", err
+		}
+
+		if res, ok := result.(*csrecycle.RecycleResult); ok {
+			return res.TaskID, nil
+		}
+		return "", nil
+
+	default:
+		return "", fmt.Errorf("res_type %s not support lease", lease.ResType)
+	}
+}
+
+func newNotification(event Event, lease *corelease.Lease) *Notification {
+	return &Notification{
+		Event:    event,
+		LeaseID:  lease.ID,
+		ResType:  lease.ResType,
+		ResID:    lease.ResID,
+		Vendor:   lease.Vendor,
+		BkBizID:  lease.BkBizID,
+		Owner:    lease.Owner,
+		ExpireAt: lease.ExpireAt,
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package lease
+
+import (
+	"testing"
+	"time"
+
+	corelease "hcm/pkg/api/core/lease"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/times"
+)
+
+func TestClassify(t *testing.T) {
+	now := time.Date(2024, 5, 23, 10, 0, 0, 0, time.Local)
+	newLease := func(id string, expireAt time.Time, reminded bool, state enumor.LeaseState) corelease.Lease {
+		return corelease.Lease{ID: id, ExpireAt: times.ConvStdTimeFormat(expireAt), Reminded: reminded, State: state}
+	}
+
+	leases := []corelease.Lease{
+		newLease("expired", now.Add(-time.Minute), true, enumor.LeaseActive),
+		newLease("expire_now", now, false, enumor.LeaseActive),
+		newLease("remind", now.Add(2*time.Hour), false, enumor.LeaseActive),
+		newLease("reminded", now.Add(2*time.Hour), true, enumor.LeaseActive),
+		newLease("not_due", now.Add(48*time.Hour), false, enumor.LeaseActive),
+		newLease("recycled", now.Add(-time.Hour), false, enumor.LeaseRecycled),
+	}
+
+	remind, expired, err := Classify(leases, now, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("classify lease failed, err: %v", err)
+	}
+
+	if len(remind) != 1 || remind[0].ID != "remind" {
+		t.Errorf("unexpected remind leases: %+v", remind)
+	}
+
+	if len(expired) != 2 || expired[0].ID != "expired" || expired[1].ID != "expire_now" {
+		t.Errorf("unexpected expired leases: %+v", expired)
+	}
+
+	if _, _, err = Classify([]corelease.Lease{{ID: "invalid", ExpireAt: "2024-05-23", State: enumor.LeaseActive}},
+		now, time.Hour); err == nil {
+		t.Errorf("classify lease with invalid expire_at should fail")
+	}
+}
+
+func TestRenewExpireAt(t *testing.T) {
+	now := time.Date(2024, 5, 23, 10, 0, 0, 0, time.Local)
+
+	// 未到期的租约在原到期时间基础上续期
+	if got := RenewExpireAt(now.Add(time.Hour), now, 7); !got.Equal(now.Add(7*24*time.Hour + time.Hour)) {
+		t.Errorf("renew active lease got %v", got)
+	}
+
+	// 已过期的租约从当前时间开始续期
+	if got := RenewExpireAt(now.Add(-48*time.Hour), now, 1); !got.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("renew expired lease got %v", got)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package lease
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Event 租约通知事件
+type Event string
+
+const (
+	// ExpiringEvent 租约即将到期，负责人可以续期避免资源被回收
+	ExpiringEvent Event = "expiring"
+	// RecycledEvent 租约已到期，资源已进入回收站
+	RecycledEvent Event = "recycled"
+)
+
+// Notification 租约通知内容
+type Notification struct {
+	Event    Event                    `json:"event"`
+	LeaseID  string                   `json:"lease_id"`
+	ResType  enumor.CloudResourceType `json:"res_type"`
+	ResID    string                   `json:"res_id"`
+	Vendor   enumor.Vendor            `json:"vendor"`
+	BkBizID  int64                    `json:"bk_biz_id"`
+	Owner    string                   `json:"owner"`
+	ExpireAt string                   `json:"expire_at"`
+	// RecycleTaskID 资源进入回收站时的回收任务ID，仅recycled事件有值
+	RecycleTaskID string `json:"recycle_task_id,omitempty"`
+}
+
+// Notifier 租约通知接口，到期提醒返回错误时会在下一轮检查时重新通知
+type Notifier interface {
+	Notify(kt *kit.Kit, notification *Notification) error
+}
+
+// NewNotifier 根据配置创建租约通知方式
+func NewNotifier(conf cc.LeaseNotifier) (Notifier, error) {
+	switch conf.Type {
+	case cc.LogLeaseNotifier:
+		return new(logNotifier), nil
+	case cc.WebhookLeaseNotifier:
+		return NewWebhookNotifier(conf.Webhook), nil
+	default:
+		return nil, fmt.Errorf("unsupported lease notifier type: %s", conf.Type)
+	}
+}
+
+// logNotifier 只将通知打印到日志中，用于未接入通知渠道的环境
+type logNotifier struct{}
+
+// Notify lease event by log.
+func (n *logNotifier) Notify(kt *kit.Kit, notification *Notification) error {
+	logs.Infof("lease %s of %s(%s) %s, owner: %s, expire at: %s, rid: %s", notification.LeaseID,
+		notification.ResType, notification.ResID, notification.Event, notification.Owner, notification.ExpireAt,
+		kt.Rid)
+	return nil
+}
+
+// NewWebhookNotifier 创建将通知内容POST到回调地址的通知方式
+func NewWebhookNotifier(conf cc.Webhook) Notifier {
+	return &webhookNotifier{
+		url:     conf.Url,
+		headers: conf.Headers,
+		client:  &http.Client{Timeout: time.Duration(conf.TimeoutSec) * time.Second},
+	}
+}
+
+type webhookNotifier struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// Notify post lease event to webhook, response status other than 2xx is treated as failure.
+func (n *webhookNotifier) Notify(kt *kit.Kit, notification *Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("marshal lease notification failed, err: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("new webhook request failed, err: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range n.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request webhook failed, err: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook response status: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package reachability
+
+import (
+	"fmt"
+	"net"
+
+	sgexposure "hcm/cmd/cloud-server/logics/sg-exposure"
+	corecloud "hcm/pkg/api/core/cloud"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Endpoint 参与可达性判断的主机及作用于主机的路由、安全组规则。
+type Endpoint struct {
+	Cvm *corecvm.BaseCvm
+	// Routes 主机主网卡所在子网关联的路由表中的路由
+	Routes []Route
+	// RuleSets 作用于主机的安全组规则，gcp为主机所在vpc下作用于全部实例的防火墙规则
+	RuleSets []sgexposure.RuleSet
+}
+
+// Analyze 判断源主机访问目的主机指定协议、端口的流量是否可达，依次判断网络路径、源主机出站规则、目的主机入站规则。
+func Analyze(src, dst *Endpoint, protocol string, port int64) *corecloud.ReachabilityResult {
+	result, step := analyzeNetwork(src, dst)
+	result.Steps = append(result.Steps, step)
+	if !step.Allowed {
+		return result
+	}
+
+	// 安全组引用只在私网互通时生效
+	srcRefs, dstRefs := make([]string, 0), make([]string, 0)
+	if result.Path != enumor.PublicPath {
+		srcRefs, dstRefs = ruleSetRefs(src), ruleSetRefs(dst)
+	}
+
+	egress := &sgexposure.Traffic{Type: enumor.Egress, Protocol: protocol, Port: port,
+		RemoteIP: result.DestinationIP, RemoteRefs: dstRefs}
+	step = evaluateRuleSets(src.Cvm.Vendor, src.RuleSets, egress, enumor.SourceEgressStage)
+	result.Steps = append(result.Steps, step)
+	if !step.Allowed {
+		return result
+	}
+
+	ingress := &sgexposure.Traffic{Type: enumor.Ingress, Protocol: protocol, Port: port,
+		RemoteIP: result.SourceIP, RemoteRefs: srcRefs}
+	step = evaluateRuleSets(dst.Cvm.Vendor, dst.RuleSets, ingress, enumor.DestinationIngressStage)
+	result.Steps = append(result.Steps, step)
+	result.Reachable = step.Allowed
+
+	return result
+}
+
+// analyzeNetwork 判断两台主机之间的网络路径，优先使用vpc内及路由表中的私网路径，其次使用目的主机的公网IP
+func analyzeNetwork(src, dst *Endpoint) (*corecloud.ReachabilityResult, corecloud.ReachabilityStep) {
+	result := &corecloud.ReachabilityResult{Path: enumor.NonePath, Steps: make([]corecloud.ReachabilityStep, 0, 3)}
+	step := corecloud.ReachabilityStep{Stage: enumor.NetworkStage}
+
+	srcIP, dstIP := privateIP(src.Cvm), privateIP(dst.Cvm)
+	sameFamily := len(srcIP) != 0 && len(dstIP) != 0 && isIPv4(srcIP) == isIPv4(dstIP)
+
+	if src.Cvm.Vendor == dst.Cvm.Vendor && sameFamily {
+		if vpcID, ok := sameVpc(src.Cvm, dst.Cvm); ok {
+			result.Path, result.SourceIP, result.DestinationIP = enumor.SameVpcPath, srcIP, dstIP
+			step.Allowed = true
+			step.Message = fmt.Sprintf("both cvms are in vpc %s", vpcID)
+			return result, step
+		}
+
+		forward, reverse := MatchRoute(src.Routes, dstIP), MatchRoute(dst.Routes, srcIP)
+		if forward != nil && forward.Private && reverse != nil && reverse.Private {
+			result.Path, result.SourceIP, result.DestinationIP = enumor.RoutePath, srcIP, dstIP
+			step.Allowed = true
+			step.RouteTableID, step.RouteID = forward.RouteTableID, forward.ID
+			step.Message = fmt.Sprintf("%s is routed to %s %s, return traffic is routed by route %s of route table %s",
+				dstIP, forward.NextHopType, forward.NextHop, reverse.ID, reverse.RouteTableID)
+			return result, step
+		}
+	}
+
+	for _, pair := range [][2][]string{
+		{src.Cvm.PublicIPv4Addresses, dst.Cvm.PublicIPv4Addresses},
+		{src.Cvm.PublicIPv6Addresses, dst.Cvm.PublicIPv6Addresses},
+	} {
+		if len(pair[0]) == 0 || len(pair[1]) == 0 {
+			continue
+		}
+
+		result.Path, result.SourceIP, result.DestinationIP = enumor.PublicPath, pair[0][0], pair[1][0]
+		step.Allowed = true
+		step.Message = fmt.Sprintf("no private route between cvms, use public ip %s", pair[1][0])
+		return result, step
+	}
+
+	step.Message = "no private route between cvms, and there is no public ip of the same ip family on both cvms"
+	return result, step
+}
+
+// evaluateRuleSets 评估作用于主机的安全组规则，多个安全组之间任一安全组放通即认为放通
+func evaluateRuleSets(vendor enumor.Vendor, ruleSets []sgexposure.RuleSet, traffic *sgexposure.Traffic,
+	stage enumor.ReachabilityStage) corecloud.ReachabilityStep {
+
+	step := corecloud.ReachabilityStep{Stage: stage}
+	if len(ruleSets) == 0 {
+		step.Allowed = true
+		step.Message = "no security group is bound, traffic is not filtered"
+		return step
+	}
+
+	var denied *sgexposure.Rule
+	for _, ruleSet := range ruleSets {
+		rule := sgexposure.Evaluate(ruleSet.Rules, traffic)
+		if rule == nil {
+			if sgexposure.DefaultAllow(vendor, traffic.Type) {
+				step.Allowed, step.SecurityGroupID = true, ruleSet.ID
+				step.Message = fmt.Sprintf("no rule matched in %s, allowed by default", ruleSet.ID)
+				return step
+			}
+			continue
+		}
+
+		if rule.Allow {
+			step.Allowed, step.SecurityGroupID, step.RuleID = true, ruleSet.ID, rule.ID
+			step.Message = fmt.Sprintf("allowed by rule %s of %s", rule.ID, ruleSet.ID)
+			return step
+		}
+
+		if denied == nil {
+			denied = rule
+		}
+	}
+
+	if denied != nil {
+		step.SecurityGroupID, step.RuleID = denied.GroupID, denied.ID
+		step.Message = fmt.Sprintf("denied by rule %s of %s", denied.ID, denied.GroupID)
+		return step
+	}
+
+	step.SecurityGroupID = ruleSets[0].ID
+	step.Message = "no rule matched, denied by default"
+	return step
+}
+
+// ruleSetRefs 返回主机作为对端时可以被规则引用的标识，包括主机关联的安全组云ID，Azure还包括 VirtualNetwork 服务标签
+func ruleSetRefs(endpoint *Endpoint) []string {
+	refs := make([]string, 0, len(endpoint.RuleSets)+1)
+	for _, ruleSet := range endpoint.RuleSets {
+		if endpoint.Cvm.Vendor != enumor.Gcp {
+			refs = append(refs, ruleSet.CloudID)
+		}
+	}
+
+	if endpoint.Cvm.Vendor == enumor.Azure {
+		refs = append(refs, sgexposure.AzureVirtualNetwork)
+	}
+
+	return refs
+}
+
+func sameVpc(src, dst *corecvm.BaseCvm) (string, bool) {
+	for _, one := range src.VpcIDs {
+		for _, vpcID := range dst.VpcIDs {
+			if one == vpcID {
+				return one, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// privateIP 返回主机的内网IP，优先使用IPv4
+func privateIP(cvm *corecvm.BaseCvm) string {
+	if len(cvm.PrivateIPv4Addresses) != 0 {
+		return cvm.PrivateIPv4Addresses[0]
+	}
+
+	if len(cvm.PrivateIPv6Addresses) != 0 {
+		return cvm.PrivateIPv6Addresses[0]
+	}
+
+	return ""
+}
+
+func isIPv4(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && parsed.To4() != nil
+}
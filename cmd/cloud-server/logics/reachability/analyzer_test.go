@@ -0,0 +1,105 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package reachability
+
+import (
+	"testing"
+
+	sgexposure "hcm/cmd/cloud-server/logics/sg-exposure"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	routetable "hcm/pkg/api/core/cloud/route-table"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+func TestMatchRoute(t *testing.T) {
+	routes := make([]Route, 0)
+	for _, one := range []routetable.TCloudRoute{
+		{ID: "local", DestinationCidrBlock: "10.0.0.0/16", GatewayType: "LOCAL", Enabled: true},
+		{ID: "default", DestinationCidrBlock: "0.0.0.0/0", GatewayType: "NAT", Enabled: true},
+		{ID: "peer", DestinationCidrBlock: "10.1.0.0/16", GatewayType: "PEERCONNECTION", Enabled: true},
+		{ID: "peer-disabled", DestinationCidrBlock: "10.1.1.0/24", GatewayType: "PEERCONNECTION"},
+		{ID: "v6", DestinationCidrBlock: "", DestinationIpv6CidrBlock: converter.ValToPtr("::/0"),
+			GatewayType: "CCN", Enabled: true},
+	} {
+		routes = append(routes, FromTCloudRoute(one)...)
+	}
+
+	cases := map[string]string{"10.1.1.1": "peer", "10.0.0.8": "local", "8.8.8.8": "default", "fd00::1": "v6"}
+	for ip, expect := range cases {
+		route := MatchRoute(routes, ip)
+		if route == nil || route.ID != expect {
+			t.Errorf("ip %s should match route %s, but got %+v", ip, expect, route)
+		}
+	}
+
+	if MatchRoute(routes, "8.8.8.8").Private {
+		t.Errorf("route to nat gateway should not be private")
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	src := &Endpoint{
+		Cvm: &corecvm.BaseCvm{ID: "src", Vendor: enumor.TCloud, VpcIDs: []string{"vpc-a"},
+			PrivateIPv4Addresses: []string{"10.0.0.8"}},
+		Routes: []Route{{ID: "peer", RouteTableID: "rtb-a", Destination: "10.1.0.0/16", Private: true}},
+		RuleSets: []sgexposure.RuleSet{{ID: "sg-a", CloudID: "sg-cloud-a", Rules: []sgexposure.Rule{
+			{ID: "egress-all", GroupID: "sg-a", Type: enumor.Egress, Protocol: "all",
+				Remotes: []string{"0.0.0.0/0"}, Allow: true},
+		}}},
+	}
+	dst := &Endpoint{
+		Cvm: &corecvm.BaseCvm{ID: "dst", Vendor: enumor.TCloud, VpcIDs: []string{"vpc-b"},
+			PrivateIPv4Addresses: []string{"10.1.0.9"}},
+		Routes: []Route{{ID: "peer-back", RouteTableID: "rtb-b", Destination: "10.0.0.0/16", Private: true}},
+		RuleSets: []sgexposure.RuleSet{{ID: "sg-b", CloudID: "sg-cloud-b", Rules: []sgexposure.Rule{
+			{ID: "deny-3306", GroupID: "sg-b", Type: enumor.Ingress, Protocol: "tcp",
+				Ports: []sgexposure.PortRange{{From: 3306, To: 3306}}, Remotes: []string{"0.0.0.0/0"}, Priority: 0},
+			{ID: "allow-sg", GroupID: "sg-b", Type: enumor.Ingress, Protocol: "tcp",
+				Remotes: []string{"sg-cloud-a"}, Allow: true, Priority: 1},
+		}}},
+	}
+
+	result := Analyze(src, dst, "tcp", 80)
+	if !result.Reachable || result.Path != enumor.RoutePath || len(result.Steps) != 3 {
+		t.Fatalf("tcp 80 should be reachable by route, result: %+v", result)
+	}
+	if result.Steps[0].RouteID != "peer" || result.Steps[2].RuleID != "allow-sg" {
+		t.Errorf("unexpected rule chain: %+v", result.Steps)
+	}
+
+	result = Analyze(src, dst, "tcp", 3306)
+	if result.Reachable || result.Steps[len(result.Steps)-1].RuleID != "deny-3306" {
+		t.Errorf("tcp 3306 should be denied by deny-3306, result: %+v", result)
+	}
+
+	result = Analyze(src, dst, "udp", 53)
+	last := result.Steps[len(result.Steps)-1]
+	if result.Reachable || last.Stage != enumor.DestinationIngressStage || len(last.RuleID) != 0 {
+		t.Errorf("udp 53 should be denied by default, result: %+v", result)
+	}
+
+	// 删除回程路由后没有私网路径，两台主机都没有公网IP，网络不可达
+	dst.Routes = nil
+	result = Analyze(src, dst, "tcp", 80)
+	if result.Reachable || result.Path != enumor.NonePath || len(result.Steps) != 1 {
+		t.Errorf("should be unreachable without return route, result: %+v", result)
+	}
+}
@@ -0,0 +1,205 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package reachability 主机间流量可达性分析，基于本地同步的vpc、子网、路由表及安全组规则判断流量是否可达，不调用云厂商接口。
+package reachability
+
+import (
+	"fmt"
+
+	sgexposure "hcm/cmd/cloud-server/logics/sg-exposure"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	routetable "hcm/pkg/api/data-service/cloud/route-table"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Interface define cvm reachability logics.
+type Interface interface {
+	// Query 查询源主机访问目的主机指定协议、端口的流量是否可达，并返回判断依据。
+	Query(kt *kit.Kit, src, dst *corecvm.BaseCvm, protocol string, port int64) (*corecloud.ReachabilityResult, error)
+}
+
+// NewReachability new cvm reachability logics.
+func NewReachability(client *client.ClientSet) Interface {
+	return &reachability{client: client, exposure: sgexposure.NewExposure(client)}
+}
+
+type reachability struct {
+	client   *client.ClientSet
+	exposure sgexposure.Interface
+}
+
+// Query 查询源主机访问目的主机指定协议、端口的流量是否可达。
+func (r *reachability) Query(kt *kit.Kit, src, dst *corecvm.BaseCvm, protocol string, port int64) (
+	*corecloud.ReachabilityResult, error) {
+
+	srcEndpoint, err := r.loadEndpoint(kt, src)
+	if err != nil {
+		return nil, err
+	}
+
+	dstEndpoint, err := r.loadEndpoint(kt, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return Analyze(srcEndpoint, dstEndpoint, protocol, port), nil
+}
+
+func (r *reachability) loadEndpoint(kt *kit.Kit, cvm *corecvm.BaseCvm) (*Endpoint, error) {
+	ruleSets, err := r.exposure.ListCvmRuleSets(kt, cvm)
+	if err != nil {
+		return nil, err
+	}
+
+	routes, err := r.listCvmRoutes(kt, cvm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Endpoint{Cvm: cvm, Routes: routes, RuleSets: ruleSets}, nil
+}
+
+// listCvmRoutes 查询主机主网卡所在子网关联的路由表中的路由，子网未关联路由表时使用vpc下的路由表（如gcp）
+func (r *reachability) listCvmRoutes(kt *kit.Kit, cvm *corecvm.BaseCvm) ([]Route, error) {
+	if len(cvm.SubnetIDs) == 0 {
+		return make([]Route, 0), nil
+	}
+
+	subnetReq := &core.ListReq{Filter: tools.EqualExpression("id", cvm.SubnetIDs[0]), Page: core.NewDefaultBasePage()}
+	subnets, err := r.client.DataService().Global.Subnet.List(kt.Ctx, kt.Header(), subnetReq)
+	if err != nil {
+		logs.Errorf("list subnet failed, err: %v, id: %s, rid: %s", err, cvm.SubnetIDs[0], kt.Rid)
+		return nil, err
+	}
+
+	if len(subnets.Details) == 0 {
+		return make([]Route, 0), nil
+	}
+
+	routeTableID := subnets.Details[0].RouteTableID
+	if len(routeTableID) == 0 {
+		tableReq := &core.ListReq{
+			Filter: tools.EqualExpression("vpc_id", subnets.Details[0].VpcID),
+			Page:   &core.BasePage{Limit: 1},
+		}
+		tables, err := r.client.DataService().Global.RouteTable.List(kt.Ctx, kt.Header(), tableReq)
+		if err != nil {
+			logs.Errorf("list route table failed, err: %v, vpcID: %s, rid: %s", err, subnets.Details[0].VpcID,
+				kt.Rid)
+			return nil, err
+		}
+
+		if len(tables.Details) == 0 {
+			return make([]Route, 0), nil
+		}
+		routeTableID = tables.Details[0].ID
+	}
+
+	routes := make([]Route, 0)
+	page := core.NewDefaultBasePage()
+	for {
+		count, err := r.listRoutePage(kt, cvm.Vendor, routeTableID, page, &routes)
+		if err != nil {
+			logs.Errorf("list %s route failed, err: %v, routeTableID: %s, rid: %s", cvm.Vendor, err, routeTableID,
+				kt.Rid)
+			return nil, err
+		}
+
+		if uint(count) < page.Limit {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+
+	return routes, nil
+}
+
+func (r *reachability) listRoutePage(kt *kit.Kit, vendor enumor.Vendor, routeTableID string, page *core.BasePage,
+	routes *[]Route) (int, error) {
+
+	ds := r.client.DataService()
+	req := &core.ListReq{Filter: tools.EqualExpression("route_table_id", routeTableID), Page: page}
+	switch vendor {
+	case enumor.TCloud:
+		result, err := ds.TCloud.RouteTable.ListRoute(kt.Ctx, kt.Header(), routeTableID, req)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, one := range result.Details {
+			*routes = append(*routes, FromTCloudRoute(one)...)
+		}
+		return len(result.Details), nil
+
+	case enumor.Aws:
+		result, err := ds.Aws.RouteTable.ListRoute(kt.Ctx, kt.Header(), routeTableID, req)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, one := range result.Details {
+			*routes = append(*routes, FromAwsRoute(one)...)
+		}
+		return len(result.Details), nil
+
+	case enumor.HuaWei:
+		result, err := ds.HuaWei.RouteTable.ListRoute(kt.Ctx, kt.Header(), routeTableID, req)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, one := range result.Details {
+			*routes = append(*routes, FromHuaWeiRoute(one)...)
+		}
+		return len(result.Details), nil
+
+	case enumor.Azure:
+		result, err := ds.Azure.RouteTable.ListRoute(kt.Ctx, kt.Header(), routeTableID, req)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, one := range result.Details {
+			*routes = append(*routes, FromAzureRoute(one)...)
+		}
+		return len(result.Details), nil
+
+	case enumor.Gcp:
+		gcpReq := &routetable.GcpRouteListReq{ListReq: req, RouteTableID: routeTableID}
+		result, err := ds.Gcp.RouteTable.ListRoute(kt.Ctx, kt.Header(), gcpReq)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, one := range result.Details {
+			*routes = append(*routes, FromGcpRoute(one)...)
+		}
+		return len(result.Details), nil
+
+	default:
+		return 0, fmt.Errorf("vendor: %s not support", vendor)
+	}
+}
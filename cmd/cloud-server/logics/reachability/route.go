@@ -0,0 +1,222 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package reachability
+
+import (
+	"net"
+	"strings"
+
+	routetable "hcm/pkg/api/core/cloud/route-table"
+	"hcm/pkg/tools/converter"
+)
+
+// Route 各云厂商路由归一化后的路由。
+type Route struct {
+	ID           string
+	RouteTableID string
+	// Destination 目的网段
+	Destination string
+	// NextHopType 下一跳类型，使用各云厂商原始的下一跳类型
+	NextHopType string
+	NextHop     string
+	// Private 下一跳是否为本地路由或对等连接、云联网、专线、VPN、转发实例等私网互通的下一跳，
+	// 公网网关、NAT网关以及丢弃流量的路由为false
+	Private bool
+	// Priority 路由优先级，数值越小优先级越高，只有gcp存在
+	Priority int64
+}
+
+// MatchRoute 按最长前缀匹配返回目的IP命中的路由，前缀长度相同时优先级数值小的路由生效，没有命中的路由时返回nil。
+func MatchRoute(routes []Route, ip string) *Route {
+	target := net.ParseIP(ip)
+	if target == nil {
+		return nil
+	}
+	isIPv4 := target.To4() != nil
+
+	var matched *Route
+	matchedOnes := -1
+	for idx := range routes {
+		_, ipNet, err := net.ParseCIDR(routes[idx].Destination)
+		if err != nil {
+			continue
+		}
+
+		if (ipNet.IP.To4() != nil) != isIPv4 || !ipNet.Contains(target) {
+			continue
+		}
+
+		ones, _ := ipNet.Mask.Size()
+		if ones > matchedOnes || (ones == matchedOnes && routes[idx].Priority < matched.Priority) {
+			matched = &routes[idx]
+			matchedOnes = ones
+		}
+	}
+
+	return matched
+}
+
+// FromTCloudRoute 归一化腾讯云路由，同时存在IPv4、IPv6目的网段时拆分为两条路由，未启用的路由不生效。
+func FromTCloudRoute(one routetable.TCloudRoute) []Route {
+	if !one.Enabled {
+		return nil
+	}
+
+	private := true
+	switch strings.ToUpper(one.GatewayType) {
+	case "NAT", "EIP":
+		private = false
+	}
+
+	routes := make([]Route, 0, 2)
+	for _, dest := range []string{one.DestinationCidrBlock, converter.PtrToVal(one.DestinationIpv6CidrBlock)} {
+		if len(dest) == 0 {
+			continue
+		}
+
+		routes = append(routes, Route{
+			ID:           one.ID,
+			RouteTableID: one.RouteTableID,
+			Destination:  dest,
+			NextHopType:  one.GatewayType,
+			NextHop:      one.CloudGatewayID,
+			Private:      private,
+		})
+	}
+
+	return routes
+}
+
+// FromAwsRoute 归一化Aws路由，blackhole状态的路由会丢弃流量，不属于私网路由。
+func FromAwsRoute(one routetable.AwsRoute) []Route {
+	nextHopType, nextHop, private := "", "", one.State != "blackhole"
+	switch {
+	case len(converter.PtrToVal(one.CloudVpcPeeringConnectionID)) != 0:
+		nextHopType, nextHop = "vpc_peering_connection", *one.CloudVpcPeeringConnectionID
+	case len(converter.PtrToVal(one.CloudTransitGatewayID)) != 0:
+		nextHopType, nextHop = "transit_gateway", *one.CloudTransitGatewayID
+	case len(converter.PtrToVal(one.CloudNatGatewayID)) != 0:
+		nextHopType, nextHop, private = "nat_gateway", *one.CloudNatGatewayID, false
+	case len(converter.PtrToVal(one.CloudNetworkInterfaceID)) != 0:
+		nextHopType, nextHop = "network_interface", *one.CloudNetworkInterfaceID
+	case len(converter.PtrToVal(one.CloudInstanceID)) != 0:
+		nextHopType, nextHop = "instance", *one.CloudInstanceID
+	case len(converter.PtrToVal(one.CloudGatewayID)) != 0:
+		// local 为vpc内的本地路由，vgw- 为VPN网关，igw- 等其余网关为公网网关
+		nextHopType, nextHop = "gateway", *one.CloudGatewayID
+		if nextHop != "local" && !strings.HasPrefix(nextHop, "vgw-") {
+			private = false
+		}
+	default:
+		private = false
+	}
+
+	routes := make([]Route, 0, 2)
+	for _, dest := range []string{converter.PtrToVal(one.DestinationCidrBlock),
+		converter.PtrToVal(one.DestinationIpv6CidrBlock)} {
+
+		if len(dest) == 0 {
+			continue
+		}
+
+		routes = append(routes, Route{
+			ID:           one.ID,
+			RouteTableID: one.RouteTableID,
+			Destination:  dest,
+			NextHopType:  nextHopType,
+			NextHop:      nextHop,
+			Private:      private,
+		})
+	}
+
+	return routes
+}
+
+// FromHuaWeiRoute 归一化华为云路由，下一跳为NAT网关、公网网关的路由不属于私网路由。
+func FromHuaWeiRoute(one routetable.HuaWeiRoute) []Route {
+	private := true
+	switch strings.ToLower(one.Type) {
+	case "nat", "egw", "igw":
+		private = false
+	}
+
+	return []Route{{
+		ID:           one.ID,
+		RouteTableID: one.RouteTableID,
+		Destination:  one.Destination,
+		NextHopType:  one.Type,
+		NextHop:      one.NextHop,
+		Private:      private,
+	}}
+}
+
+// FromAzureRoute 归一化Azure路由，下一跳类型为 None 的路由会丢弃流量，Internet 为公网出口。
+func FromAzureRoute(one routetable.AzureRoute) []Route {
+	private := false
+	switch strings.ToLower(one.NextHopType) {
+	case "vnetlocal", "virtualnetworkgateway", "virtualappliance":
+		private = true
+	}
+
+	return []Route{{
+		ID:           one.ID,
+		RouteTableID: one.RouteTableID,
+		Destination:  one.AddressPrefix,
+		NextHopType:  one.NextHopType,
+		NextHop:      converter.PtrToVal(one.NextHopIPAddress),
+		Private:      private,
+	}}
+}
+
+// FromGcpRoute 归一化Gcp路由，指定了实例标签的路由只作用于部分实例，无法确定是否作用于主机，不进行匹配。
+func FromGcpRoute(one routetable.GcpRoute) []Route {
+	if len(one.Tags) != 0 {
+		return nil
+	}
+
+	route := Route{
+		ID:           one.ID,
+		RouteTableID: one.RouteTableID,
+		Destination:  one.DestRange,
+		Private:      true,
+		Priority:     one.Priority,
+	}
+
+	switch {
+	case len(converter.PtrToVal(one.NextHopNetwork)) != 0:
+		route.NextHopType, route.NextHop = "network", *one.NextHopNetwork
+	case len(converter.PtrToVal(one.NextHopPeering)) != 0:
+		route.NextHopType, route.NextHop = "peering", *one.NextHopPeering
+	case len(converter.PtrToVal(one.NextHopVpnTunnel)) != 0:
+		route.NextHopType, route.NextHop = "vpn_tunnel", *one.NextHopVpnTunnel
+	case len(converter.PtrToVal(one.NextHopInstance)) != 0:
+		route.NextHopType, route.NextHop = "instance", *one.NextHopInstance
+	case len(converter.PtrToVal(one.NextHopIlb)) != 0:
+		route.NextHopType, route.NextHop = "ilb", *one.NextHopIlb
+	case len(converter.PtrToVal(one.NextHopIp)) != 0:
+		route.NextHopType, route.NextHop = "ip", *one.NextHopIp
+	case len(converter.PtrToVal(one.NextHopGateway)) != 0:
+		route.NextHopType, route.NextHop, route.Private = "gateway", *one.NextHopGateway, false
+	default:
+		route.Private = false
+	}
+
+	return []Route{route}
+}
@@ -0,0 +1,254 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgexposure
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	"hcm/pkg/criteria/enumor"
+)
+
+// sensitivePort 对公网开放时存在高风险的端口
+type sensitivePort struct {
+	Port    int64
+	Service string
+}
+
+var sensitivePorts = []sensitivePort{
+	{Port: 22, Service: "SSH"},
+	{Port: 23, Service: "Telnet"},
+	{Port: 3389, Service: "RDP"},
+	{Port: 3306, Service: "MySQL"},
+	{Port: 1433, Service: "SQL Server"},
+	{Port: 1521, Service: "Oracle"},
+	{Port: 5432, Service: "PostgreSQL"},
+	{Port: 6379, Service: "Redis"},
+	{Port: 27017, Service: "MongoDB"},
+	{Port: 9200, Service: "Elasticsearch"},
+	{Port: 11211, Service: "Memcached"},
+}
+
+// AnalyzeRules 分析同一个安全组（gcp为同一个vpc）下的规则，返回公网暴露、规则覆盖及重叠等风险项。
+func AnalyzeRules(rules []Rule) []corecloud.ExposureFinding {
+	sorted := sortRules(rules)
+
+	findings := analyzePublicExposure(sorted)
+	findings = append(findings, analyzeRuleConflict(sorted, enumor.Ingress)...)
+	findings = append(findings, analyzeRuleConflict(sorted, enumor.Egress)...)
+
+	return findings
+}
+
+// sortRules 按规则的生效顺序排序，优先级数值越小越先生效，相同优先级时拒绝规则先生效
+func sortRules(rules []Rule) []Rule {
+	sorted := make([]Rule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority < sorted[j].Priority
+		}
+
+		return !sorted[i].Allow && sorted[j].Allow
+	})
+
+	return sorted
+}
+
+func filterRules(rules []Rule, ruleType enumor.SecurityGroupRuleType) []Rule {
+	result := make([]Rule, 0, len(rules))
+	for _, one := range rules {
+		if one.Type == ruleType {
+			result = append(result, one)
+		}
+	}
+
+	return result
+}
+
+// decideRule 返回公网访问 probe 流量时第一条生效的规则，只有对端包含全部地址的规则才能决定公网流量是否放通
+func decideRule(rules []Rule, world string, probe *Rule) *Rule {
+	for idx := range rules {
+		one := &rules[idx]
+		if one.hasRemote(world) && one.protocolCovers(probe) && one.portsCover(probe) {
+			return one
+		}
+	}
+
+	return nil
+}
+
+// analyzePublicExposure 分析入站规则中对公网（0.0.0.0/0、::/0）开放全部端口及敏感端口的风险
+func analyzePublicExposure(sorted []Rule) []corecloud.ExposureFinding {
+	ingress := filterRules(sorted, enumor.Ingress)
+
+	findings := make([]corecloud.ExposureFinding, 0)
+	for _, world := range []string{ipv4World, ipv6World} {
+		allPortProbe := &Rule{Protocol: protocolAll}
+		allPortRule := decideRule(ingress, world, allPortProbe)
+		if allPortRule != nil && allPortRule.Allow {
+			findings = append(findings, corecloud.ExposureFinding{
+				Kind:            enumor.PublicAllPortRisk,
+				Level:           enumor.HighExposureRisk,
+				SecurityGroupID: allPortRule.GroupID,
+				RuleType:        enumor.Ingress,
+				RuleID:          allPortRule.ID,
+				Protocol:        protocolAll,
+				Port:            protocolAll,
+				Cidr:            world,
+				Message:         fmt.Sprintf("all ports are open to %s", world),
+			})
+		}
+
+		for _, port := range sensitivePorts {
+			probe := &Rule{Protocol: "tcp", Ports: []PortRange{{From: port.Port, To: port.Port}}}
+			rule := decideRule(ingress, world, probe)
+			if rule == nil || !rule.Allow {
+				continue
+			}
+
+			// 已经报告了全部端口对公网开放的规则，不再重复报告敏感端口
+			if allPortRule != nil && allPortRule.Allow && allPortRule.ID == rule.ID {
+				continue
+			}
+
+			findings = append(findings, corecloud.ExposureFinding{
+				Kind:            enumor.PublicSensitivePortRisk,
+				Level:           enumor.HighExposureRisk,
+				SecurityGroupID: rule.GroupID,
+				RuleType:        enumor.Ingress,
+				RuleID:          rule.ID,
+				Protocol:        rule.Protocol,
+				Port:            strconv.FormatInt(port.Port, 10),
+				Cidr:            world,
+				Message:         fmt.Sprintf("%s port %d is open to %s", port.Service, port.Port, world),
+			})
+		}
+	}
+
+	return findings
+}
+
+// analyzeRuleConflict 分析规则之间的覆盖、重叠关系，被更高优先级规则完全覆盖的规则永远不会生效
+func analyzeRuleConflict(sorted []Rule, ruleType enumor.SecurityGroupRuleType) []corecloud.ExposureFinding {
+	rules := filterRules(sorted, ruleType)
+
+	findings := make([]corecloud.ExposureFinding, 0)
+	for i := range rules {
+		cur := &rules[i]
+
+		var covered, overlapped *Rule
+		for j := 0; j < i; j++ {
+			prev := &rules[j]
+			// gcp同一条防火墙规则拆分出的多条规则之间不进行比较
+			if prev.ID == cur.ID {
+				continue
+			}
+
+			if prev.covers(cur) {
+				covered = prev
+				break
+			}
+
+			if overlapped == nil && prev.Allow != cur.Allow && prev.overlaps(cur) {
+				overlapped = prev
+			}
+		}
+
+		switch {
+		case covered != nil && covered.Allow != cur.Allow:
+			findings = append(findings, buildConflictFinding(enumor.ShadowedRuleRisk, enumor.MediumExposureRisk, cur,
+				covered, "rule is fully covered by higher priority rule with opposite action, it never takes effect"))
+
+		case covered != nil:
+			findings = append(findings, buildConflictFinding(enumor.RedundantRuleRisk, enumor.LowExposureRisk, cur,
+				covered, "rule is fully covered by higher priority rule with same action, it is redundant"))
+
+		case overlapped != nil:
+			findings = append(findings, buildConflictFinding(enumor.OverlappingRuleRisk, enumor.LowExposureRisk, cur,
+				overlapped, "rule partially overlaps with higher priority rule with opposite action"))
+		}
+	}
+
+	return findings
+}
+
+func buildConflictFinding(kind enumor.ExposureRiskKind, level enumor.ExposureRiskLevel, rule, related *Rule,
+	msg string) corecloud.ExposureFinding {
+
+	return corecloud.ExposureFinding{
+		Kind:            kind,
+		Level:           level,
+		SecurityGroupID: rule.GroupID,
+		RuleType:        rule.Type,
+		RuleID:          rule.ID,
+		RelatedRuleID:   related.ID,
+		Protocol:        rule.Protocol,
+		Port:            rule.portString(),
+		Message:         msg,
+	}
+}
+
+// AnalyzeCvm 汇总主机关联的安全组中对公网开放的风险项，主机拥有公网IP时为高风险，否则只能通过内网访问，为中风险。
+// 多个安全组之间的优先级不同云厂商规则不一致，这里按照并集进行分析，结果偏保守。
+func AnalyzeCvm(cvm *corecvm.BaseCvm, sgIDs []string,
+	sgFindings map[string][]corecloud.ExposureFinding) *corecloud.CvmExposure {
+
+	result := &corecloud.CvmExposure{
+		ID:                  cvm.ID,
+		CloudID:             cvm.CloudID,
+		Name:                cvm.Name,
+		Vendor:              cvm.Vendor,
+		PublicIPv4Addresses: cvm.PublicIPv4Addresses,
+		PublicIPv6Addresses: cvm.PublicIPv6Addresses,
+		SecurityGroupIDs:    sgIDs,
+		Findings:            make([]corecloud.ExposureFinding, 0),
+	}
+
+	for _, sgID := range sgIDs {
+		for _, one := range sgFindings[sgID] {
+			if one.Kind == enumor.PublicAllPortRisk || one.Kind == enumor.PublicSensitivePortRisk {
+				result.Findings = append(result.Findings, one)
+			}
+		}
+	}
+
+	if len(result.Findings) == 0 {
+		return result
+	}
+
+	if len(cvm.PublicIPv4Addresses) == 0 && len(cvm.PublicIPv6Addresses) == 0 {
+		result.Level = enumor.MediumExposureRisk
+		return result
+	}
+
+	result.Level = enumor.HighExposureRisk
+	publicFinding := corecloud.ExposureFinding{
+		Kind:    enumor.PublicCvmRisk,
+		Level:   enumor.HighExposureRisk,
+		Message: "cvm has public ip and is bound to security groups open to public network",
+	}
+	result.Findings = append([]corecloud.ExposureFinding{publicFinding}, result.Findings...)
+
+	return result
+}
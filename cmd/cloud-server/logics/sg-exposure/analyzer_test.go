@@ -0,0 +1,117 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgexposure
+
+import (
+	"reflect"
+	"testing"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+func TestAnalyzeRules(t *testing.T) {
+	tcloudRules := []corecloud.TCloudSecurityGroupRule{
+		// 拒绝公网访问3389，优先级高于下面放通全部端口的规则
+		{ID: "r1", CloudPolicyIndex: 0, Protocol: converter.ValToPtr("TCP"), Port: converter.ValToPtr("3389"),
+			IPv4Cidr: converter.ValToPtr("0.0.0.0/0"), Action: "DROP", Type: enumor.Ingress},
+		{ID: "r2", CloudPolicyIndex: 1, Protocol: converter.ValToPtr("TCP"), Port: converter.ValToPtr("22,80"),
+			IPv4Cidr: converter.ValToPtr("0.0.0.0/0"), Action: "ACCEPT", Type: enumor.Ingress},
+		// 被 r2 完全覆盖
+		{ID: "r3", CloudPolicyIndex: 2, Protocol: converter.ValToPtr("TCP"), Port: converter.ValToPtr("22"),
+			IPv4Cidr: converter.ValToPtr("10.0.0.0/8"), Action: "ACCEPT", Type: enumor.Ingress},
+		// 被 r1 完全覆盖，且动作相反
+		{ID: "r4", CloudPolicyIndex: 3, Protocol: converter.ValToPtr("TCP"), Port: converter.ValToPtr("3389"),
+			IPv4Cidr: converter.ValToPtr("1.1.1.1"), Action: "ACCEPT", Type: enumor.Ingress},
+		// 与 r1 部分重叠，且动作相反
+		{ID: "r5", CloudPolicyIndex: 4, Protocol: converter.ValToPtr("TCP"), Port: converter.ValToPtr("3000-4000"),
+			IPv4Cidr: converter.ValToPtr("192.168.0.0/16"), Action: "ACCEPT", Type: enumor.Ingress},
+		// 使用参数模板，不进行分析
+		{ID: "r6", CloudPolicyIndex: 5, CloudServiceID: converter.ValToPtr("ppm-xxx"), Action: "ACCEPT",
+			IPv4Cidr: converter.ValToPtr("0.0.0.0/0"), Type: enumor.Ingress},
+	}
+
+	rules := make([]Rule, 0)
+	for _, one := range tcloudRules {
+		if rule, ok := FromTCloudRule(one); ok {
+			rules = append(rules, rule)
+		}
+	}
+
+	type brief struct {
+		Kind    enumor.ExposureRiskKind
+		RuleID  string
+		Related string
+		Port    string
+	}
+	got := make([]brief, 0)
+	for _, one := range AnalyzeRules(rules) {
+		got = append(got, brief{Kind: one.Kind, RuleID: one.RuleID, Related: one.RelatedRuleID, Port: one.Port})
+	}
+
+	expect := []brief{
+		{Kind: enumor.PublicSensitivePortRisk, RuleID: "r2", Port: "22"},
+		{Kind: enumor.RedundantRuleRisk, RuleID: "r3", Related: "r2", Port: "22"},
+		{Kind: enumor.ShadowedRuleRisk, RuleID: "r4", Related: "r1", Port: "3389"},
+		{Kind: enumor.OverlappingRuleRisk, RuleID: "r5", Related: "r1", Port: "3000-4000"},
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expect %+v, but got %+v", expect, got)
+	}
+}
+
+func TestPublicAllPort(t *testing.T) {
+	azureRule := corecloud.AzureSecurityGroupRule{ID: "r1", Protocol: "*", Access: "Allow", Priority: 100,
+		Type: enumor.Ingress, DestinationPortRange: converter.ValToPtr("*"),
+		SourceAddressPrefix: converter.ValToPtr("Internet")}
+	rule, ok := FromAzureRule(azureRule)
+	if !ok {
+		t.Fatalf("normalize azure rule failed")
+	}
+
+	findings := AnalyzeRules([]Rule{rule})
+	if len(findings) != 2 {
+		t.Fatalf("expect 2 findings, but got %+v", findings)
+	}
+
+	for _, one := range findings {
+		if one.Kind != enumor.PublicAllPortRisk || one.Level != enumor.HighExposureRisk {
+			t.Errorf("expect public all port risk, but got %+v", one)
+		}
+	}
+
+	// 华为云未指定对端地址表示全部地址，相同优先级时拒绝规则优先
+	huaweiRules := []corecloud.HuaWeiSecurityGroupRule{
+		{ID: "h1", Protocol: "tcp", Port: "22", Priority: 1, Action: "allow", Type: enumor.Ingress},
+		{ID: "h2", Protocol: "tcp", Port: "22", Priority: 1, Action: "deny", Type: enumor.Ingress},
+	}
+	rules := make([]Rule, 0)
+	for _, one := range huaweiRules {
+		rule, _ := FromHuaWeiRule(one)
+		rules = append(rules, rule)
+	}
+
+	for _, one := range AnalyzeRules(rules) {
+		if one.Kind == enumor.PublicSensitivePortRisk {
+			t.Errorf("deny rule should take effect first, but got %+v", one)
+		}
+	}
+}
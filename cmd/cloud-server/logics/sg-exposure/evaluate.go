@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgexposure
+
+import (
+	"net"
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// Traffic 待评估的流量，入站流量的对端为源地址，出站流量的对端为目的地址。
+type Traffic struct {
+	Type     enumor.SecurityGroupRuleType
+	Protocol string
+	// Port 目的端口，tcp、udp以外的协议为0
+	Port int64
+	// RemoteIP 对端IP
+	RemoteIP string
+	// RemoteRefs 对端的引用标识，如对端主机关联的安全组云ID，Azure的 VirtualNetwork 服务标签等
+	RemoteRefs []string
+}
+
+// RuleSet 一个安全组（gcp为一个vpc）下归一化后的规则。
+type RuleSet struct {
+	// ID 安全组ID，gcp为vpc ID
+	ID string
+	// CloudID 安全组云ID，gcp为vpc云ID
+	CloudID string
+	Vendor  enumor.Vendor
+	Rules   []Rule
+}
+
+// Evaluate 按规则的生效顺序返回第一条匹配流量的规则，没有匹配的规则时返回nil。
+func Evaluate(rules []Rule, traffic *Traffic) *Rule {
+	sorted := sortRules(filterRules(rules, traffic.Type))
+	for idx := range sorted {
+		if sorted[idx].matches(traffic) {
+			return &sorted[idx]
+		}
+	}
+
+	return nil
+}
+
+// DefaultAllow 没有匹配的规则时流量是否默认放通，gcp隐含放通全部出站流量，其余云厂商默认拒绝。
+func DefaultAllow(vendor enumor.Vendor, ruleType enumor.SecurityGroupRuleType) bool {
+	return vendor == enumor.Gcp && ruleType == enumor.Egress
+}
+
+// AzureDefaultRules Azure网络安全组内置的默认规则，优先级低于全部自定义规则。
+func AzureDefaultRules(groupID string) []Rule {
+	return []Rule{
+		{ID: "AllowVnetInBound", GroupID: groupID, Type: enumor.Ingress, Protocol: protocolAll,
+			Remotes: []string{AzureVirtualNetwork}, Allow: true, Priority: 65000},
+		{ID: "AllowAzureLoadBalancerInBound", GroupID: groupID, Type: enumor.Ingress, Protocol: protocolAll,
+			Remotes: []string{"AzureLoadBalancer"}, Allow: true, Priority: 65001},
+		{ID: "DenyAllInBound", GroupID: groupID, Type: enumor.Ingress, Protocol: protocolAll,
+			Remotes: []string{ipv4World, ipv6World}, Allow: false, Priority: 65500},
+		{ID: "AllowVnetOutBound", GroupID: groupID, Type: enumor.Egress, Protocol: protocolAll,
+			Remotes: []string{AzureVirtualNetwork}, Allow: true, Priority: 65000},
+		{ID: "AllowInternetOutBound", GroupID: groupID, Type: enumor.Egress, Protocol: protocolAll,
+			Remotes: []string{ipv4World, ipv6World}, Allow: true, Priority: 65001},
+		{ID: "DenyAllOutBound", GroupID: groupID, Type: enumor.Egress, Protocol: protocolAll,
+			Remotes: []string{ipv4World, ipv6World}, Allow: false, Priority: 65500},
+	}
+}
+
+// AzureVirtualNetwork Azure虚拟网络服务标签，对端与主机处于同一vnet或对等连接的vnet时，需要作为对端引用标识进行评估。
+const AzureVirtualNetwork = "VirtualNetwork"
+
+// matches 判断规则是否匹配流量
+func (r *Rule) matches(t *Traffic) bool {
+	if r.Protocol != protocolAll && r.Protocol != normProtocol(t.Protocol) {
+		return false
+	}
+
+	if len(r.Ports) != 0 {
+		matched := false
+		for _, port := range r.Ports {
+			if port.From <= t.Port && t.Port <= port.To {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	ip := net.ParseIP(t.RemoteIP)
+	for _, remote := range r.Remotes {
+		if _, ipNet, err := net.ParseCIDR(remote); err == nil {
+			if ip != nil && ipNet.Contains(ip) && (ip.To4() != nil) == (ipNet.IP.To4() != nil) {
+				return true
+			}
+			continue
+		}
+
+		for _, ref := range t.RemoteRefs {
+			if strings.EqualFold(remote, ref) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
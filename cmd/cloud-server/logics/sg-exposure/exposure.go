@@ -0,0 +1,530 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package sgexposure 安全组风险分析，将各云厂商的安全组规则归一化后，分析公网暴露、规则覆盖及重叠等风险。
+package sgexposure
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// Interface define security group exposure analyze logics.
+type Interface interface {
+	// AnalyzeBiz 分析业务下的安全组及关联的主机，sgIDs 不为空时只分析指定的安全组。
+	AnalyzeBiz(kt *kit.Kit, bizID int64, sgIDs []string) (*corecloud.BizExposureResult, error)
+	// AnalyzeCvm 分析主机通过关联的安全组对公网暴露的风险。
+	AnalyzeCvm(kt *kit.Kit, cvm *corecvm.BaseCvm) (*corecloud.CvmExposure, error)
+	// ListCvmRuleSets 查询作用于主机的安全组规则，gcp为主机所在vpc下作用于全部实例的防火墙规则。
+	ListCvmRuleSets(kt *kit.Kit, cvm *corecvm.BaseCvm) ([]RuleSet, error)
+}
+
+// NewExposure new security group exposure analyze logics.
+func NewExposure(client *client.ClientSet) Interface {
+	return &exposure{client: client}
+}
+
+type exposure struct {
+	client *client.ClientSet
+}
+
+// AnalyzeBiz 分析业务下的安全组及关联的主机，主机和安全组的关联关系由同步时的主机关联资源管理维护。
+func (e *exposure) AnalyzeBiz(kt *kit.Kit, bizID int64, sgIDs []string) (*corecloud.BizExposureResult, error) {
+	expr := tools.EqualExpression("bk_biz_id", bizID)
+	if len(sgIDs) != 0 {
+		var err error
+		expr, err = tools.And(expr, tools.ContainersExpression("id", sgIDs))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sgs, err := e.listSecurityGroup(kt, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &corecloud.BizExposureResult{
+		SecurityGroups: make([]corecloud.SecurityGroupExposure, 0),
+		Cvms:           make([]corecloud.CvmExposure, 0),
+	}
+	sgFindings, err := e.analyzeSecurityGroups(kt, sgs, result)
+	if err != nil {
+		return nil, err
+	}
+
+	analyzedSGIDs := make([]string, 0, len(sgs))
+	for _, one := range sgs {
+		analyzedSGIDs = append(analyzedSGIDs, one.ID)
+	}
+
+	cvmSGMap, err := e.listCvmSGMap(kt, "security_group_id", analyzedSGIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	cvmIDs := make([]string, 0, len(cvmSGMap))
+	for cvmID := range cvmSGMap {
+		cvmIDs = append(cvmIDs, cvmID)
+	}
+
+	cvms, err := e.listCvm(kt, cvmIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range cvms {
+		cvmExposure := AnalyzeCvm(&cvms[idx], cvmSGMap[cvms[idx].ID], sgFindings)
+		// 业务维度只返回拥有公网IP且存在公网暴露风险的主机
+		if cvmExposure.Level == enumor.HighExposureRisk {
+			result.Cvms = append(result.Cvms, *cvmExposure)
+		}
+	}
+
+	// gcp使用vpc下的防火墙规则，没有安全组，指定了安全组时不进行分析
+	if len(sgIDs) != 0 {
+		return result, nil
+	}
+
+	if err = e.analyzeBizGcp(kt, bizID, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// analyzeSecurityGroups 分析安全组规则，存在风险的安全组添加到结果中，返回各安全组的风险项
+func (e *exposure) analyzeSecurityGroups(kt *kit.Kit, sgs []corecloud.BaseSecurityGroup,
+	result *corecloud.BizExposureResult) (map[string][]corecloud.ExposureFinding, error) {
+
+	sgFindings := make(map[string][]corecloud.ExposureFinding, len(sgs))
+	for _, sg := range sgs {
+		rules, err := e.listRule(kt, sg)
+		if err != nil {
+			return nil, err
+		}
+
+		findings := AnalyzeRules(rules)
+		sgFindings[sg.ID] = findings
+		if len(findings) == 0 || result == nil {
+			continue
+		}
+
+		result.SecurityGroups = append(result.SecurityGroups, corecloud.SecurityGroupExposure{
+			ID:        sg.ID,
+			CloudID:   sg.CloudID,
+			Name:      sg.Name,
+			Vendor:    sg.Vendor,
+			AccountID: sg.AccountID,
+			Region:    sg.Region,
+			Findings:  findings,
+		})
+	}
+
+	return sgFindings, nil
+}
+
+// analyzeBizGcp 分析业务下的gcp防火墙规则，按vpc进行分组分析，主机只分析作用于vpc下全部实例的规则
+func (e *exposure) analyzeBizGcp(kt *kit.Kit, bizID int64, result *corecloud.BizExposureResult) error {
+	firewalls, err := e.listGcpFirewall(kt, tools.EqualExpression("bk_biz_id", bizID))
+	if err != nil {
+		return err
+	}
+
+	if len(firewalls) == 0 {
+		return nil
+	}
+
+	vpcFirewalls := make(map[string][]corecloud.GcpFirewallRule)
+	for _, one := range firewalls {
+		vpcFirewalls[one.VpcId] = append(vpcFirewalls[one.VpcId], one)
+	}
+
+	for vpcID, list := range vpcFirewalls {
+		findings := AnalyzeRules(gcpRules(list, false))
+		if len(findings) == 0 {
+			continue
+		}
+
+		result.SecurityGroups = append(result.SecurityGroups, corecloud.SecurityGroupExposure{
+			ID:        vpcID,
+			CloudID:   list[0].CloudVpcID,
+			Vendor:    enumor.Gcp,
+			AccountID: list[0].AccountID,
+			Findings:  findings,
+		})
+	}
+
+	expr := tools.EqualWithOpExpression(filter.And, map[string]interface{}{"bk_biz_id": bizID, "vendor": enumor.Gcp})
+	cvms, err := e.listCvmByFilter(kt, expr)
+	if err != nil {
+		return err
+	}
+
+	for idx := range cvms {
+		cvmExposure := analyzeGcpCvm(&cvms[idx], vpcFirewalls)
+		if cvmExposure.Level == enumor.HighExposureRisk {
+			result.Cvms = append(result.Cvms, *cvmExposure)
+		}
+	}
+
+	return nil
+}
+
+// AnalyzeCvm 分析主机通过关联的安全组对公网暴露的风险。
+func (e *exposure) AnalyzeCvm(kt *kit.Kit, cvm *corecvm.BaseCvm) (*corecloud.CvmExposure, error) {
+	if cvm.Vendor == enumor.Gcp {
+		vpcFirewalls := make(map[string][]corecloud.GcpFirewallRule)
+		if len(cvm.VpcIDs) == 0 {
+			return analyzeGcpCvm(cvm, vpcFirewalls), nil
+		}
+
+		firewalls, err := e.listGcpFirewall(kt, tools.ContainersExpression("vpc_id", cvm.VpcIDs))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range firewalls {
+			vpcFirewalls[one.VpcId] = append(vpcFirewalls[one.VpcId], one)
+		}
+
+		return analyzeGcpCvm(cvm, vpcFirewalls), nil
+	}
+
+	cvmSGMap, err := e.listCvmSGMap(kt, "cvm_id", []string{cvm.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	sgIDs := cvmSGMap[cvm.ID]
+	if len(sgIDs) == 0 {
+		return AnalyzeCvm(cvm, make([]string, 0), nil), nil
+	}
+
+	sgs, err := e.listSecurityGroup(kt, tools.ContainersExpression("id", sgIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	sgFindings, err := e.analyzeSecurityGroups(kt, sgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return AnalyzeCvm(cvm, sgIDs, sgFindings), nil
+}
+
+// ListCvmRuleSets 查询作用于主机的安全组规则，Azure安全组会补充内置的默认规则。
+func (e *exposure) ListCvmRuleSets(kt *kit.Kit, cvm *corecvm.BaseCvm) ([]RuleSet, error) {
+	if cvm.Vendor == enumor.Gcp {
+		return e.listGcpCvmRuleSets(kt, cvm)
+	}
+
+	cvmSGMap, err := e.listCvmSGMap(kt, "cvm_id", []string{cvm.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	sgIDs := cvmSGMap[cvm.ID]
+	if len(sgIDs) == 0 {
+		return make([]RuleSet, 0), nil
+	}
+
+	sgs, err := e.listSecurityGroup(kt, tools.ContainersExpression("id", sgIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	ruleSets := make([]RuleSet, 0, len(sgs))
+	for _, sg := range sgs {
+		rules, err := e.listRule(kt, sg)
+		if err != nil {
+			return nil, err
+		}
+
+		if sg.Vendor == enumor.Azure {
+			rules = append(rules, AzureDefaultRules(sg.ID)...)
+		}
+
+		ruleSets = append(ruleSets, RuleSet{ID: sg.ID, CloudID: sg.CloudID, Vendor: sg.Vendor, Rules: rules})
+	}
+
+	return ruleSets, nil
+}
+
+func (e *exposure) listGcpCvmRuleSets(kt *kit.Kit, cvm *corecvm.BaseCvm) ([]RuleSet, error) {
+	ruleSets := make([]RuleSet, 0, len(cvm.VpcIDs))
+	if len(cvm.VpcIDs) == 0 {
+		return ruleSets, nil
+	}
+
+	firewalls, err := e.listGcpFirewall(kt, tools.ContainersExpression("vpc_id", cvm.VpcIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	vpcFirewalls := make(map[string][]corecloud.GcpFirewallRule)
+	for _, one := range firewalls {
+		vpcFirewalls[one.VpcId] = append(vpcFirewalls[one.VpcId], one)
+	}
+
+	for idx, vpcID := range cvm.VpcIDs {
+		ruleSet := RuleSet{ID: vpcID, Vendor: enumor.Gcp, Rules: gcpRules(vpcFirewalls[vpcID], true)}
+		if idx < len(cvm.CloudVpcIDs) {
+			ruleSet.CloudID = cvm.CloudVpcIDs[idx]
+		}
+		ruleSets = append(ruleSets, ruleSet)
+	}
+
+	return ruleSets, nil
+}
+
+// analyzeGcpCvm 分析gcp主机所在vpc下作用于全部实例的防火墙规则，指定了目标标签或服务账号的规则无法确定是否作用于该主机
+func analyzeGcpCvm(cvm *corecvm.BaseCvm, vpcFirewalls map[string][]corecloud.GcpFirewallRule) *corecloud.CvmExposure {
+
+	findings := make(map[string][]corecloud.ExposureFinding, len(cvm.VpcIDs))
+	for _, vpcID := range cvm.VpcIDs {
+		findings[vpcID] = AnalyzeRules(gcpRules(vpcFirewalls[vpcID], true))
+	}
+
+	return AnalyzeCvm(cvm, cvm.VpcIDs, findings)
+}
+
+// gcpRules 归一化gcp防火墙规则，onlyAllTarget 为true时只返回作用于vpc下全部实例的规则
+func gcpRules(firewalls []corecloud.GcpFirewallRule, onlyAllTarget bool) []Rule {
+	rules := make([]Rule, 0, len(firewalls))
+	for _, one := range firewalls {
+		if onlyAllTarget && (len(one.TargetTags) != 0 || len(one.TargetServiceAccounts) != 0) {
+			continue
+		}
+
+		rules = append(rules, FromGcpRule(one)...)
+	}
+
+	return rules
+}
+
+func (e *exposure) listSecurityGroup(kt *kit.Kit, expr *filter.Expression) ([]corecloud.BaseSecurityGroup, error) {
+	req := &dataproto.SecurityGroupListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	sgs := make([]corecloud.BaseSecurityGroup, 0)
+	for {
+		result, err := e.client.DataService().Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list security group failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		sgs = append(sgs, result.Details...)
+
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return sgs, nil
+}
+
+// listRule 查询安全组下的全部规则，并归一化
+func (e *exposure) listRule(kt *kit.Kit, sg corecloud.BaseSecurityGroup) ([]Rule, error) {
+	rules := make([]Rule, 0)
+	page := core.NewDefaultBasePage()
+	for {
+		count, err := e.listRulePage(kt, sg, page, &rules)
+		if err != nil {
+			logs.Errorf("list %s security group rule failed, err: %v, sgID: %s, rid: %s", sg.Vendor, err, sg.ID,
+				kt.Rid)
+			return nil, err
+		}
+
+		if uint(count) < page.Limit {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+
+	return rules, nil
+}
+
+func (e *exposure) listRulePage(kt *kit.Kit, sg corecloud.BaseSecurityGroup, page *core.BasePage,
+	rules *[]Rule) (int, error) {
+
+	ds := e.client.DataService()
+	switch sg.Vendor {
+	case enumor.TCloud:
+		req := &dataproto.TCloudSGRuleListReq{Filter: tools.AllExpression(), Page: page}
+		result, err := ds.TCloud.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), req, sg.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, one := range result.Details {
+			if rule, ok := FromTCloudRule(one); ok {
+				*rules = append(*rules, rule)
+			}
+		}
+		return len(result.Details), nil
+
+	case enumor.Aws:
+		req := &dataproto.AwsSGRuleListReq{Filter: tools.AllExpression(), Page: page}
+		result, err := ds.Aws.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), req, sg.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, one := range result.Details {
+			if rule, ok := FromAwsRule(one); ok {
+				*rules = append(*rules, rule)
+			}
+		}
+		return len(result.Details), nil
+
+	case enumor.HuaWei:
+		req := &dataproto.HuaWeiSGRuleListReq{Filter: tools.AllExpression(), Page: page}
+		result, err := ds.HuaWei.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), req, sg.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, one := range result.Details {
+			if rule, ok := FromHuaWeiRule(one); ok {
+				*rules = append(*rules, rule)
+			}
+		}
+		return len(result.Details), nil
+
+	case enumor.Azure:
+		req := &dataproto.AzureSGRuleListReq{Filter: tools.AllExpression(), Page: page}
+		result, err := ds.Azure.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(), req, sg.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, one := range result.Details {
+			if rule, ok := FromAzureRule(one); ok {
+				*rules = append(*rules, rule)
+			}
+		}
+		return len(result.Details), nil
+
+	default:
+		return 0, fmt.Errorf("vendor: %s not support", sg.Vendor)
+	}
+}
+
+func (e *exposure) listGcpFirewall(kt *kit.Kit, expr *filter.Expression) ([]corecloud.GcpFirewallRule, error) {
+	req := &dataproto.GcpFirewallRuleListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	firewalls := make([]corecloud.GcpFirewallRule, 0)
+	for {
+		result, err := e.client.DataService().Gcp.Firewall.ListFirewallRule(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("list gcp firewall rule failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		firewalls = append(firewalls, result.Details...)
+
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return firewalls, nil
+}
+
+// listCvmSGMap 按主机ID或安全组ID查询主机和安全组的关联关系，返回 {cvmID: sgIDs}
+func (e *exposure) listCvmSGMap(kt *kit.Kit, field string, values []string) (map[string][]string, error) {
+	cvmSGMap := make(map[string][]string)
+	for _, part := range slice.Split(values, int(core.DefaultMaxPageLimit)) {
+		req := &core.ListReq{Filter: tools.ContainersExpression(field, part), Page: core.NewDefaultBasePage()}
+		for {
+			result, err := e.client.DataService().Global.SGCvmRel.List(kt.Ctx, kt.Header(), req)
+			if err != nil {
+				logs.Errorf("list security group cvm rel failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+
+			for _, rel := range result.Details {
+				cvmSGMap[rel.CvmID] = append(cvmSGMap[rel.CvmID], rel.SecurityGroupID)
+			}
+
+			if uint(len(result.Details)) < req.Page.Limit {
+				break
+			}
+			req.Page.Start += uint32(req.Page.Limit)
+		}
+	}
+
+	return cvmSGMap, nil
+}
+
+func (e *exposure) listCvm(kt *kit.Kit, cvmIDs []string) ([]corecvm.BaseCvm, error) {
+	cvms := make([]corecvm.BaseCvm, 0, len(cvmIDs))
+	for _, part := range slice.Split(cvmIDs, int(core.DefaultMaxPageLimit)) {
+		list, err := e.listCvmByFilter(kt, tools.ContainersExpression("id", part))
+		if err != nil {
+			return nil, err
+		}
+		cvms = append(cvms, list...)
+	}
+
+	return cvms, nil
+}
+
+func (e *exposure) listCvmByFilter(kt *kit.Kit, expr *filter.Expression) ([]corecvm.BaseCvm, error) {
+	req := &core.ListReq{Filter: expr, Page: core.NewDefaultBasePage()}
+
+	cvms := make([]corecvm.BaseCvm, 0)
+	for {
+		result, err := e.client.DataService().Global.Cvm.ListCvm(kt, req)
+		if err != nil {
+			logs.Errorf("list cvm failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		cvms = append(cvms, result.Details...)
+
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return cvms, nil
+}
@@ -0,0 +1,490 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sgexposure
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+const (
+	// protocolAll 全部协议
+	protocolAll = "all"
+	maxPort     = 65535
+)
+
+// PortRange 端口范围，包含起止端口。
+type PortRange struct {
+	From int64
+	To   int64
+}
+
+var allPorts = PortRange{From: 0, To: maxPort}
+
+func (p PortRange) contains(o PortRange) bool {
+	return p.From <= o.From && p.To >= o.To
+}
+
+func (p PortRange) overlaps(o PortRange) bool {
+	return p.From <= o.To && o.From <= p.To
+}
+
+// String ...
+func (p PortRange) String() string {
+	if p.From == p.To {
+		return strconv.FormatInt(p.From, 10)
+	}
+
+	return fmt.Sprintf("%d-%d", p.From, p.To)
+}
+
+// Rule 各云厂商安全组规则归一化后的规则。
+type Rule struct {
+	ID string
+	// GroupID 规则所属的安全组ID，gcp为防火墙规则所属的vpc ID
+	GroupID string
+	Type    enumor.SecurityGroupRuleType
+	// Protocol 小写的协议名，all 表示全部协议
+	Protocol string
+	// Ports 端口范围，为空表示全部端口
+	Ports []PortRange
+	// Remotes 对端地址，入站规则为源地址，出站规则为目的地址，可能为CIDR，也可能为安全组、地址组等引用
+	Remotes []string
+	Allow   bool
+	// Priority 规则优先级，数值越小优先级越高，相同优先级时拒绝规则优先生效
+	Priority int64
+}
+
+func (r *Rule) protocolCovers(o *Rule) bool {
+	return r.Protocol == protocolAll || r.Protocol == o.Protocol
+}
+
+func (r *Rule) protocolOverlaps(o *Rule) bool {
+	return r.Protocol == protocolAll || o.Protocol == protocolAll || r.Protocol == o.Protocol
+}
+
+func (r *Rule) portsCover(o *Rule) bool {
+	if len(r.Ports) == 0 {
+		return true
+	}
+
+	target := o.Ports
+	if len(target) == 0 {
+		target = []PortRange{allPorts}
+	}
+
+	for _, one := range target {
+		covered := false
+		for _, port := range r.Ports {
+			if port.contains(one) {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Rule) portsOverlap(o *Rule) bool {
+	if len(r.Ports) == 0 || len(o.Ports) == 0 {
+		return true
+	}
+
+	for _, one := range r.Ports {
+		for _, port := range o.Ports {
+			if one.overlaps(port) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (r *Rule) remotesCover(o *Rule) bool {
+	for _, one := range o.Remotes {
+		covered := false
+		for _, remote := range r.Remotes {
+			if remoteContains(remote, one) {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Rule) remotesOverlap(o *Rule) bool {
+	for _, one := range r.Remotes {
+		for _, remote := range o.Remotes {
+			if remoteOverlaps(one, remote) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// covers 判断当前规则匹配的流量是否完全包含规则 o 匹配的流量
+func (r *Rule) covers(o *Rule) bool {
+	return r.protocolCovers(o) && r.portsCover(o) && r.remotesCover(o)
+}
+
+// overlaps 判断当前规则匹配的流量是否与规则 o 匹配的流量存在交集
+func (r *Rule) overlaps(o *Rule) bool {
+	return r.protocolOverlaps(o) && r.portsOverlap(o) && r.remotesOverlap(o)
+}
+
+func (r *Rule) hasRemote(remote string) bool {
+	for _, one := range r.Remotes {
+		if one == remote {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Rule) portString() string {
+	if len(r.Ports) == 0 {
+		return protocolAll
+	}
+
+	ports := make([]string, 0, len(r.Ports))
+	for _, one := range r.Ports {
+		ports = append(ports, one.String())
+	}
+
+	return strings.Join(ports, ",")
+}
+
+// parsePorts 解析端口字符串，支持单个端口、端口范围以及逗号分隔的多个端口，为空或ALL时表示全部端口。
+func parsePorts(ports ...string) ([]PortRange, bool) {
+	result := make([]PortRange, 0)
+	for _, one := range ports {
+		for _, part := range strings.Split(one, ",") {
+			part = strings.TrimSpace(part)
+			switch strings.ToLower(part) {
+			case "":
+				continue
+			case "*", "all", "-1":
+				return nil, true
+			}
+
+			from, to, found := strings.Cut(part, "-")
+			if !found {
+				to = from
+			}
+
+			fromPort, err := strconv.ParseInt(strings.TrimSpace(from), 10, 64)
+			if err != nil {
+				return nil, false
+			}
+
+			toPort, err := strconv.ParseInt(strings.TrimSpace(to), 10, 64)
+			if err != nil || fromPort > toPort {
+				return nil, false
+			}
+
+			result = append(result, PortRange{From: fromPort, To: toPort})
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, true
+	}
+
+	return result, true
+}
+
+// normProtocol 统一协议名称，部分云厂商使用协议号或 -1、* 表示协议
+func normProtocol(protocol string) string {
+	switch strings.ToLower(strings.TrimSpace(protocol)) {
+	case "", "*", "-1", "all", "any":
+		return protocolAll
+	case "6", "tcp":
+		return "tcp"
+	case "17", "udp":
+		return "udp"
+	case "1", "icmp":
+		return "icmp"
+	case "58", "icmpv6":
+		return "icmpv6"
+	default:
+		return strings.ToLower(strings.TrimSpace(protocol))
+	}
+}
+
+const (
+	ipv4World = "0.0.0.0/0"
+	ipv6World = "::/0"
+)
+
+// normRemotes 统一对端地址，单个IP转换为CIDR，* 及 Internet 等标签转换为全部地址
+func normRemotes(remotes ...string) []string {
+	result := make([]string, 0, len(remotes))
+	for _, one := range remotes {
+		one = strings.TrimSpace(one)
+		switch strings.ToLower(one) {
+		case "":
+			continue
+		case "*", "any", "internet":
+			result = append(result, ipv4World, ipv6World)
+			continue
+		}
+
+		if ip := net.ParseIP(one); ip != nil {
+			if ip.To4() != nil {
+				result = append(result, one+"/32")
+			} else {
+				result = append(result, one+"/128")
+			}
+			continue
+		}
+
+		if _, ipNet, err := net.ParseCIDR(one); err == nil {
+			result = append(result, ipNet.String())
+			continue
+		}
+
+		result = append(result, one)
+	}
+
+	return result
+}
+
+// remoteContains 判断对端地址 a 是否包含 b，非CIDR的地址引用只有相同时才认为包含
+func remoteContains(a, b string) bool {
+	_, netA, errA := net.ParseCIDR(a)
+	_, netB, errB := net.ParseCIDR(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	onesA, bitsA := netA.Mask.Size()
+	onesB, bitsB := netB.Mask.Size()
+	return bitsA == bitsB && onesA <= onesB && netA.Contains(netB.IP)
+}
+
+// remoteOverlaps 判断对端地址 a 与 b 是否存在交集，非CIDR的地址引用只有相同时才认为存在交集
+func remoteOverlaps(a, b string) bool {
+	_, netA, errA := net.ParseCIDR(a)
+	_, netB, errB := net.ParseCIDR(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	_, bitsA := netA.Mask.Size()
+	_, bitsB := netB.Mask.Size()
+	return bitsA == bitsB && (netA.Contains(netB.IP) || netB.Contains(netA.IP))
+}
+
+// FromTCloudRule 归一化腾讯云安全组规则，使用参数模板的规则无法解析端口和地址，不进行分析。
+func FromTCloudRule(one corecloud.TCloudSecurityGroupRule) (Rule, bool) {
+	if len(converter.PtrToVal(one.CloudServiceID)) != 0 || len(converter.PtrToVal(one.CloudServiceGroupID)) != 0 {
+		return Rule{}, false
+	}
+
+	ports, ok := parsePorts(converter.PtrToVal(one.Port))
+	if !ok {
+		return Rule{}, false
+	}
+
+	remotes := normRemotes(converter.PtrToVal(one.IPv4Cidr), converter.PtrToVal(one.IPv6Cidr),
+		converter.PtrToVal(one.CloudTargetSecurityGroupID), converter.PtrToVal(one.CloudAddressID),
+		converter.PtrToVal(one.CloudAddressGroupID))
+	if len(remotes) == 0 {
+		return Rule{}, false
+	}
+
+	return Rule{
+		ID:       one.ID,
+		GroupID:  one.SecurityGroupID,
+		Type:     one.Type,
+		Protocol: normProtocol(converter.PtrToVal(one.Protocol)),
+		Ports:    ports,
+		Remotes:  remotes,
+		Allow:    strings.EqualFold(one.Action, "ACCEPT"),
+		Priority: one.CloudPolicyIndex,
+	}, true
+}
+
+// FromAwsRule 归一化Aws安全组规则，Aws安全组只有允许规则，且规则之间没有优先级。
+func FromAwsRule(one corecloud.AwsSecurityGroupRule) (Rule, bool) {
+	protocol := normProtocol(converter.PtrToVal(one.Protocol))
+
+	var ports []PortRange
+	if (protocol == "tcp" || protocol == "udp") && one.FromPort != nil && one.ToPort != nil {
+		ports = []PortRange{{From: *one.FromPort, To: *one.ToPort}}
+	}
+
+	remotes := normRemotes(converter.PtrToVal(one.IPv4Cidr), converter.PtrToVal(one.IPv6Cidr),
+		converter.PtrToVal(one.CloudPrefixListID), converter.PtrToVal(one.CloudTargetSecurityGroupID))
+	if len(remotes) == 0 {
+		return Rule{}, false
+	}
+
+	return Rule{
+		ID:       one.ID,
+		GroupID:  one.SecurityGroupID,
+		Type:     one.Type,
+		Protocol: protocol,
+		Ports:    ports,
+		Remotes:  remotes,
+		Allow:    true,
+	}, true
+}
+
+// FromHuaWeiRule 归一化华为云安全组规则，未指定对端地址时表示全部地址。
+func FromHuaWeiRule(one corecloud.HuaWeiSecurityGroupRule) (Rule, bool) {
+	ports, ok := parsePorts(one.Port)
+	if !ok {
+		return Rule{}, false
+	}
+
+	remotes := normRemotes(one.RemoteIPPrefix, one.CloudRemoteGroupID, one.CloudRemoteAddressGroupID)
+	if len(remotes) == 0 {
+		if strings.EqualFold(one.Ethertype, "IPv6") {
+			remotes = []string{ipv6World}
+		} else {
+			remotes = []string{ipv4World}
+		}
+	}
+
+	return Rule{
+		ID:       one.ID,
+		GroupID:  one.SecurityGroupID,
+		Type:     one.Type,
+		Protocol: normProtocol(one.Protocol),
+		Ports:    ports,
+		Remotes:  remotes,
+		Allow:    !strings.EqualFold(one.Action, "deny"),
+		Priority: one.Priority,
+	}, true
+}
+
+// FromAzureRule 归一化Azure安全组规则，入站规则的对端为源地址，出站规则的对端为目的地址。
+func FromAzureRule(one corecloud.AzureSecurityGroupRule) (Rule, bool) {
+	portList := []string{converter.PtrToVal(one.DestinationPortRange)}
+	for _, port := range one.DestinationPortRanges {
+		portList = append(portList, converter.PtrToVal(port))
+	}
+
+	ports, ok := parsePorts(portList...)
+	if !ok {
+		return Rule{}, false
+	}
+
+	prefix, prefixes, asgIDs := one.SourceAddressPrefix, one.SourceAddressPrefixes, one.CloudSourceAppSecurityGroupIDs
+	if one.Type == enumor.Egress {
+		prefix, prefixes = one.DestinationAddressPrefix, one.DestinationAddressPrefixes
+		asgIDs = one.CloudDestinationAppSecurityGroupIDs
+	}
+
+	remoteList := []string{converter.PtrToVal(prefix)}
+	for _, remote := range prefixes {
+		remoteList = append(remoteList, converter.PtrToVal(remote))
+	}
+	for _, asgID := range asgIDs {
+		remoteList = append(remoteList, converter.PtrToVal(asgID))
+	}
+
+	remotes := normRemotes(remoteList...)
+	if len(remotes) == 0 {
+		return Rule{}, false
+	}
+
+	return Rule{
+		ID:       one.ID,
+		GroupID:  one.SecurityGroupID,
+		Type:     one.Type,
+		Protocol: normProtocol(one.Protocol),
+		Ports:    ports,
+		Remotes:  remotes,
+		Allow:    strings.EqualFold(one.Access, "Allow"),
+		Priority: int64(one.Priority),
+	}, true
+}
+
+// FromGcpRule 归一化Gcp防火墙规则，一条防火墙规则可能包含多个协议，按协议拆分为多条规则，
+// 防火墙规则作用于vpc，GroupID 为规则所属的vpc ID。
+func FromGcpRule(one corecloud.GcpFirewallRule) []Rule {
+	if one.Disabled {
+		return nil
+	}
+
+	ruleType := enumor.Ingress
+	remoteList := make([]string, 0, len(one.SourceRanges)+len(one.SourceTags)+len(one.SourceServiceAccounts))
+	remoteList = append(remoteList, one.SourceRanges...)
+	remoteList = append(remoteList, one.SourceTags...)
+	remoteList = append(remoteList, one.SourceServiceAccounts...)
+	remotes := normRemotes(remoteList...)
+	if strings.EqualFold(one.Type, "EGRESS") {
+		ruleType = enumor.Egress
+		remotes = normRemotes(one.DestinationRanges...)
+	}
+
+	// 未指定对端地址时默认为全部IPv4地址
+	if len(remotes) == 0 {
+		remotes = []string{ipv4World}
+	}
+
+	rules := make([]Rule, 0, len(one.Allowed)+len(one.Denied))
+	build := func(sets []corecloud.GcpProtocolSet, allow bool) {
+		for _, set := range sets {
+			ports, ok := parsePorts(set.Port...)
+			if !ok {
+				continue
+			}
+
+			rules = append(rules, Rule{
+				ID:       one.ID,
+				GroupID:  one.VpcId,
+				Type:     ruleType,
+				Protocol: normProtocol(set.Protocol),
+				Ports:    ports,
+				Remotes:  remotes,
+				Allow:    allow,
+				Priority: one.Priority,
+			})
+		}
+	}
+	build(one.Denied, false)
+	build(one.Allowed, true)
+
+	return rules
+}
@@ -0,0 +1,142 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package snapshot ...
+package snapshot
+
+import (
+	"sort"
+	"time"
+
+	cssnapshot "hcm/pkg/api/cloud-server/snapshot"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/times"
+)
+
+// cloudTimeLayouts 各云厂商返回的快照创建时间格式
+var cloudTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05.000000",
+	"2006-01-02T15:04:05",
+}
+
+// CreatedTime 返回快照的创建时间，优先使用云上创建时间，解析失败时使用入库时间
+func CreatedTime(one *coresnapshot.BaseSnapshot) time.Time {
+	for _, layout := range cloudTimeLayouts {
+		if t, err := time.ParseInLocation(layout, one.CloudCreatedTime, time.Local); err == nil {
+			return t
+		}
+	}
+
+	if one.Revision != nil {
+		if t, err := time.Parse(time.RFC3339, one.Revision.CreatedAt); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// ExpiredSnapshotIDs 返回策略即将创建一个新快照时，超出保留数量需要删除的快照ID，
+// 只有该策略创建的快照参与计算，按创建时间从新到旧保留 retention-1 个。
+func ExpiredSnapshotIDs(snapshots []coresnapshot.BaseSnapshot, policyID string, retention uint) []string {
+	owned := make([]coresnapshot.BaseSnapshot, 0, len(snapshots))
+	for _, one := range snapshots {
+		if one.PolicyID == policyID {
+			owned = append(owned, one)
+		}
+	}
+
+	keep := 0
+	if retention > 0 {
+		keep = int(retention) - 1
+	}
+	if len(owned) <= keep {
+		return make([]string, 0)
+	}
+
+	sort.SliceStable(owned, func(i, j int) bool {
+		return CreatedTime(&owned[i]).After(CreatedTime(&owned[j]))
+	})
+
+	ids := make([]string, 0, len(owned)-keep)
+	for _, one := range owned[keep:] {
+		ids = append(ids, one.ID)
+	}
+	return ids
+}
+
+// Coverage 统计云盘的快照覆盖情况，云盘在 since 之后有可用快照时视为已备份。
+func Coverage(disks []*coredisk.BaseDisk, snapshots []coresnapshot.BaseSnapshot,
+	policies []coresnapshot.SnapshotPolicy, since time.Time) *cssnapshot.CoverageResult {
+
+	diskPolicyMap := make(map[string][]string)
+	for _, policy := range policies {
+		if policy.State != enumor.SnapshotPolicyEnabled {
+			continue
+		}
+		for _, diskID := range policy.DiskIDs {
+			diskPolicyMap[diskID] = append(diskPolicyMap[diskID], policy.ID)
+		}
+	}
+
+	diskSnapshotMap := make(map[string][]coresnapshot.BaseSnapshot)
+	for _, one := range snapshots {
+		diskSnapshotMap[one.DiskID] = append(diskSnapshotMap[one.DiskID], one)
+	}
+
+	result := &cssnapshot.CoverageResult{Details: make([]cssnapshot.DiskCoverage, 0, len(disks))}
+	for _, disk := range disks {
+		coverage := cssnapshot.DiskCoverage{
+			DiskID:      disk.ID,
+			CloudDiskID: disk.CloudID,
+			DiskName:    disk.Name,
+			Vendor:      enumor.Vendor(disk.Vendor),
+			AccountID:   disk.AccountID,
+			PolicyIDs:   diskPolicyMap[disk.ID],
+		}
+		if coverage.PolicyIDs == nil {
+			coverage.PolicyIDs = make([]string, 0)
+		}
+
+		var latest time.Time
+		for idx := range diskSnapshotMap[disk.ID] {
+			created := CreatedTime(&diskSnapshotMap[disk.ID][idx])
+			coverage.SnapshotCount++
+			if created.After(latest) {
+				latest = created
+				coverage.LatestSnapshot = times.ConvStdTimeFormat(created)
+			}
+		}
+		coverage.Covered = !latest.IsZero() && !latest.Before(since)
+
+		result.Total++
+		if coverage.Covered {
+			result.Covered++
+		} else {
+			result.Uncovered++
+		}
+		result.Details = append(result.Details, coverage)
+	}
+
+	return result
+}
@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestExpiredSnapshotIDs(t *testing.T) {
+	snapshots := []coresnapshot.BaseSnapshot{
+		{ID: "1", PolicyID: "p1", CloudCreatedTime: "2024-04-01T00:00:00Z"},
+		{ID: "2", PolicyID: "p1", CloudCreatedTime: "2024-04-03 00:00:00"},
+		{ID: "3", PolicyID: "p1", CloudCreatedTime: "2024-04-02T00:00:00.000000"},
+		{ID: "4", PolicyID: "", CloudCreatedTime: "2024-03-01T00:00:00Z"},
+		{ID: "5", PolicyID: "p2", CloudCreatedTime: "2024-03-01T00:00:00Z"},
+	}
+
+	cases := []struct {
+		retention uint
+		expect    []string
+	}{
+		{retention: 1, expect: []string{"2", "3", "1"}},
+		{retention: 2, expect: []string{"3", "1"}},
+		{retention: 3, expect: []string{"1"}},
+		{retention: 4, expect: []string{}},
+	}
+	for _, c := range cases {
+		got := ExpiredSnapshotIDs(snapshots, "p1", c.retention)
+		if !reflect.DeepEqual(got, c.expect) {
+			t.Errorf("retention %d expect %v, but got %v", c.retention, c.expect, got)
+		}
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	disks := []*coredisk.BaseDisk{{ID: "d1", Vendor: string(enumor.TCloud)}, {ID: "d2"}, {ID: "d3"}}
+	snapshots := []coresnapshot.BaseSnapshot{
+		{ID: "s1", DiskID: "d1", CloudCreatedTime: "2024-04-10T00:00:00+08:00"},
+		{ID: "s2", DiskID: "d1", CloudCreatedTime: "2024-03-01T00:00:00+08:00"},
+		{ID: "s3", DiskID: "d2", CloudCreatedTime: "2024-03-01T00:00:00+08:00"},
+	}
+	policies := []coresnapshot.SnapshotPolicy{
+		{ID: "p1", DiskIDs: []string{"d1", "d3"}, State: enumor.SnapshotPolicyEnabled},
+		{ID: "p2", DiskIDs: []string{"d2"}, State: enumor.SnapshotPolicyDisabled},
+	}
+	since, _ := time.Parse(time.RFC3339, "2024-04-03T00:00:00+08:00")
+
+	result := Coverage(disks, snapshots, policies, since)
+	if result.Total != 3 || result.Covered != 1 || result.Uncovered != 2 {
+		t.Fatalf("unexpected coverage summary: %+v", result)
+	}
+
+	d1 := result.Details[0]
+	if !d1.Covered || d1.SnapshotCount != 2 || !reflect.DeepEqual(d1.PolicyIDs, []string{"p1"}) {
+		t.Errorf("unexpected d1 coverage: %+v", d1)
+	}
+
+	d2 := result.Details[1]
+	if d2.Covered || d2.SnapshotCount != 1 || len(d2.PolicyIDs) != 0 {
+		t.Errorf("unexpected d2 coverage: %+v", d2)
+	}
+
+	d3 := result.Details[2]
+	if d3.Covered || d3.SnapshotCount != 0 || !reflect.DeepEqual(d3.PolicyIDs, []string{"p1"}) {
+		t.Errorf("unexpected d3 coverage: %+v", d3)
+	}
+}
@@ -0,0 +1,448 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package stack
+
+import (
+	"fmt"
+	"strconv"
+
+	actioncvm "hcm/cmd/task-server/logics/action/cvm"
+	actiondisk "hcm/cmd/task-server/logics/action/disk"
+	actioneip "hcm/cmd/task-server/logics/action/eip"
+	actionsg "hcm/cmd/task-server/logics/action/security-group"
+	actionstack "hcm/cmd/task-server/logics/action/stack"
+	actionsubnet "hcm/cmd/task-server/logics/action/subnet"
+	actionvpc "hcm/cmd/task-server/logics/action/vpc"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	typeeip "hcm/pkg/adaptor/types/eip"
+	csstack "hcm/pkg/api/cloud-server/stack"
+	corestack "hcm/pkg/api/core/stack"
+	hcservice "hcm/pkg/api/hc-service"
+	hccvm "hcm/pkg/api/hc-service/cvm"
+	hcdisk "hcm/pkg/api/hc-service/disk"
+	hceip "hcm/pkg/api/hc-service/eip"
+	hcsubnet "hcm/pkg/api/hc-service/subnet"
+	hcvpc "hcm/pkg/api/hc-service/vpc"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/action"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/tools/slice"
+)
+
+// VersionShareDataKey apply任务流共享数据中本次apply的模版版本，任务流成功后更新为资源栈已apply的版本
+const VersionShareDataKey = "stack_version"
+
+// ValidateVendor 校验资源栈是否支持该云厂商，资源栈使用的vpc、子网、安全组等创建任务目前只实现了腾讯云
+func ValidateVendor(vendor enumor.Vendor) error {
+	if vendor != enumor.TCloud {
+		return errf.Newf(errf.InvalidParameter, "stack only supports vendor %s, vendor %s is not supported",
+			enumor.TCloud, vendor)
+	}
+
+	return nil
+}
+
+// CompileOption 将资源栈plan编译为任务流的参数
+type CompileOption struct {
+	Stack    *corestack.Stack
+	Template *corestack.Template
+	Plan     *csstack.PlanResult
+	// Current 资源栈当前已创建的资源
+	Current []corestack.Resource
+	// CloudSubnetVpcs 主机引用的模版外已存在子网的云ID到所属vpc云ID的映射
+	CloudSubnetVpcs map[string]string
+}
+
+// CompileApply 将plan编译为apply任务流的任务，资源使用各资源的创建任务创建，创建结果按资源名称保存到共享数据中。
+// 创建任务之间按资源引用关系设置依赖，删除任务按主机、硬盘、弹性IP，安全组、子网，vpc的顺序执行。
+// 每个创建的资源都有对应的失败分支任务，任务流存在失败任务时删除本次已创建的资源。
+func CompileApply(opt *CompileOption) ([]ts.CustomFlowTask, error) {
+	if err := ValidateVendor(opt.Stack.Vendor); err != nil {
+		return nil, err
+	}
+
+	c := newCompiler(opt.Stack, opt.Current)
+	actions := make(map[string]enumor.StackPlanAction, len(opt.Plan.Items))
+	deletes := make([]corestack.Resource, 0)
+	for _, item := range opt.Plan.Items {
+		actions[resourceKey(item.ResType, item.Name)] = item.Action
+		if item.Action == enumor.DeleteStackPlanAction {
+			deletes = append(deletes, corestack.Resource{ResType: item.ResType, Name: item.Name, IDs: item.IDs})
+		}
+	}
+	needCreate := func(resType enumor.CloudResourceType, name string) bool {
+		return actions[resourceKey(resType, name)] == enumor.CreateStackPlanAction
+	}
+
+	for _, vpc := range opt.Template.Vpcs {
+		if needCreate(enumor.VpcCloudResType, vpc.Name) {
+			c.createVpc(vpc)
+		}
+
+		for _, subnet := range vpc.Subnets {
+			if needCreate(enumor.SubnetCloudResType, subnet.Name) {
+				if err := c.createSubnet(vpc.Name, subnet); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for _, sg := range opt.Template.SecurityGroups {
+		if needCreate(enumor.SecurityGroupCloudResType, sg.Name) {
+			c.createSecurityGroup(sg)
+		}
+	}
+
+	for _, cvm := range opt.Template.Cvms {
+		if needCreate(enumor.CvmCloudResType, cvm.Name) {
+			if err := c.createCvm(opt.Template, cvm, opt.CloudSubnetVpcs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, disk := range opt.Template.Disks {
+		if needCreate(enumor.DiskCloudResType, disk.Name) {
+			c.createDisk(disk)
+		}
+	}
+
+	for _, eip := range opt.Template.Eips {
+		if needCreate(enumor.EipCloudResType, eip.Name) {
+			c.createEip(eip)
+		}
+	}
+
+	c.deleteInOrder(deletes, false)
+	c.deleteInOrder(c.created, true)
+
+	return c.tasks, nil
+}
+
+// CompileDestroy 编译销毁资源栈全部资源的任务流任务
+func CompileDestroy(stack *corestack.Stack, current []corestack.Resource) ([]ts.CustomFlowTask, error) {
+	if err := ValidateVendor(stack.Vendor); err != nil {
+		return nil, err
+	}
+
+	c := newCompiler(stack, current)
+	c.deleteInOrder(current, false)
+	return c.tasks, nil
+}
+
+type compiler struct {
+	stack   *corestack.Stack
+	current map[string]corestack.Resource
+	tasks   []ts.CustomFlowTask
+	// createIDs 资源到创建该资源的任务ID的映射
+	createIDs map[string]action.ActIDType
+	// created 本次创建的资源，用于生成失败分支的回滚任务
+	created []corestack.Resource
+}
+
+func newCompiler(stack *corestack.Stack, current []corestack.Resource) *compiler {
+	c := &compiler{
+		stack:     stack,
+		current:   make(map[string]corestack.Resource, len(current)),
+		tasks:     make([]ts.CustomFlowTask, 0),
+		createIDs: make(map[string]action.ActIDType),
+		created:   make([]corestack.Resource, 0),
+	}
+	for _, one := range current {
+		c.current[resourceKey(one.ResType, one.Name)] = one
+	}
+
+	return c
+}
+
+func (c *compiler) addTask(name enumor.ActionName, params interface{}, dependOn []action.ActIDType,
+	onFailure bool) action.ActIDType {
+
+	id := action.ActIDType(strconv.Itoa(len(c.tasks) + 1))
+	c.tasks = append(c.tasks, ts.CustomFlowTask{
+		ActionID:     id,
+		ActionName:   name,
+		Params:       params,
+		DependOn:     slice.Unique(dependOn),
+		OnFailure:    onFailure,
+		RateLimitKey: tableasync.NewRateLimitKey(c.stack.Vendor, c.stack.AccountID, c.stack.Region),
+	})
+	return id
+}
+
+// addCreateTask 添加创建资源的任务，创建任务将资源云ID保存到共享数据的 actionstack.ResourceKey 中
+func (c *compiler) addCreateTask(resType enumor.CloudResourceType, resName string, name enumor.ActionName,
+	params interface{}, dependOn []action.ActIDType) action.ActIDType {
+
+	id := c.addTask(name, params, dependOn, false)
+	c.createIDs[resourceKey(resType, resName)] = id
+	c.created = append(c.created, corestack.Resource{ResType: resType, Name: resName})
+	return id
+}
+
+// cloudID 返回已创建资源的云ID，资源在本次任务流中创建时返回保存资源云ID的共享数据键和创建任务ID
+func (c *compiler) cloudID(resType enumor.CloudResourceType, name string) (cloudID string, shareDataKey string,
+	taskID action.ActIDType, err error) {
+
+	key := resourceKey(resType, name)
+	if res, exist := c.current[key]; exist && len(res.CloudIDs) != 0 {
+		return res.CloudIDs[0], "", "", nil
+	}
+
+	if id, exist := c.createIDs[key]; exist {
+		return "", actionstack.ResourceKey(resType, name), id, nil
+	}
+
+	return "", "", "", fmt.Errorf("%s: %s is neither created nor to be created", resType, name)
+}
+
+func (c *compiler) createVpc(vpc corestack.VpcTemplate) {
+	// 子网使用单独的创建任务创建
+	c.addCreateTask(enumor.VpcCloudResType, vpc.Name, enumor.ActionCreateVpc, &actionvpc.CreateVpcOption{
+		Vendor:  c.stack.Vendor,
+		BkBizID: c.stack.BkBizID,
+		SaveKey: actionstack.ResourceKey(enumor.VpcCloudResType, vpc.Name),
+		TCloudVpc: &hcvpc.VpcCreateReq[hcvpc.TCloudVpcCreateExt]{
+			BaseVpcCreateReq: &hcvpc.BaseVpcCreateReq{
+				AccountID: c.stack.AccountID,
+				Name:      vpc.Name,
+				Category:  enumor.BizVpcCategory,
+				Memo:      vpc.Memo,
+				BkCloudID: vpc.BkCloudID,
+				BkBizID:   c.stack.BkBizID,
+			},
+			Extension: &hcvpc.TCloudVpcCreateExt{
+				Region:   c.stack.Region,
+				IPv4Cidr: vpc.IPv4Cidr,
+			},
+		},
+	}, nil)
+}
+
+func (c *compiler) createSubnet(vpcName string, subnet corestack.SubnetTemplate) error {
+	cloudVpcID, vpcKey, vpcTaskID, err := c.cloudID(enumor.VpcCloudResType, vpcName)
+	if err != nil {
+		return err
+	}
+
+	dependOn := make([]action.ActIDType, 0)
+	if len(vpcTaskID) != 0 {
+		dependOn = append(dependOn, vpcTaskID)
+	}
+
+	c.addCreateTask(enumor.SubnetCloudResType, subnet.Name, enumor.ActionCreateSubnet,
+		&actionsubnet.CreateSubnetOption{
+			Vendor:        c.stack.Vendor,
+			CloudVpcIDKey: vpcKey,
+			SaveKey:       actionstack.ResourceKey(enumor.SubnetCloudResType, subnet.Name),
+			TCloudSubnet: &hcsubnet.TCloudSubnetBatchCreateReq{
+				BkBizID:    c.stack.BkBizID,
+				AccountID:  c.stack.AccountID,
+				Region:     c.stack.Region,
+				CloudVpcID: cloudVpcID,
+				Subnets: []hcsubnet.TCloudOneSubnetCreateReq{
+					{IPv4Cidr: subnet.IPv4Cidr, Name: subnet.Name, Zone: subnet.Zone},
+				},
+			},
+		}, dependOn)
+	return nil
+}
+
+func (c *compiler) createSecurityGroup(sg corestack.SecurityGroupTemplate) {
+	c.addCreateTask(enumor.SecurityGroupCloudResType, sg.Name, enumor.ActionCreateSecurityGroup,
+		&actionsg.CreateSGOption{
+			Vendor:  c.stack.Vendor,
+			SaveKey: actionstack.ResourceKey(enumor.SecurityGroupCloudResType, sg.Name),
+			TCloudSecurityGroup: &hcservice.TCloudSecurityGroupCreateReq{
+				Region:    c.stack.Region,
+				Name:      sg.Name,
+				Memo:      sg.Memo,
+				AccountID: c.stack.AccountID,
+				BkBizID:   c.stack.BkBizID,
+			},
+		}, nil)
+}
+
+// createCvm 添加创建主机以及将主机分配到资源栈所属业务的任务，本次任务流中创建的网络资源在执行时从共享数据中获取
+func (c *compiler) createCvm(tpl *corestack.Template, cvm corestack.CvmTemplate,
+	cloudSubnetVpcs map[string]string) error {
+
+	req := hccvm.TCloudBatchCreateReq{
+		AccountID:             c.stack.AccountID,
+		Region:                c.stack.Region,
+		Name:                  cvm.Name,
+		Zone:                  cvm.Zone,
+		InstanceType:          cvm.InstanceType,
+		CloudImageID:          cvm.CloudImageID,
+		RequiredCount:         cvm.Count,
+		CloudSecurityGroupIDs: make([]string, 0),
+		InstanceChargeType:    typecvm.TCloudInstanceChargeType(cvm.InstanceChargeType),
+		SystemDisk: &typecvm.TCloudSystemDisk{
+			DiskType:   typecvm.TCloudSystemDiskType(cvm.SystemDisk.DiskType),
+			DiskSizeGB: &cvm.SystemDisk.DiskSizeGB,
+		},
+		DataDisk:                make([]typecvm.TCloudDataDisk, 0, len(cvm.DataDisks)),
+		PublicIPAssigned:        cvm.PublicIPAssigned,
+		InternetMaxBandwidthOut: cvm.InternetMaxBandwidthOut,
+		KeyPairID:               cvm.KeyPairID,
+	}
+	for idx := range cvm.DataDisks {
+		req.DataDisk = append(req.DataDisk, typecvm.TCloudDataDisk{DiskSizeGB: &cvm.DataDisks[idx].DiskSizeGB,
+			DiskType: typecvm.TCloudDataDiskType(cvm.DataDisks[idx].DiskType)})
+	}
+
+	keys := new(actioncvm.CloudIDKeys)
+	dependOn := make([]action.ActIDType, 0)
+	resolve := func(resType enumor.CloudResourceType, name string) (string, string, error) {
+		cloudID, key, taskID, err := c.cloudID(resType, name)
+		if err != nil {
+			return "", "", err
+		}
+		if len(taskID) != 0 {
+			dependOn = append(dependOn, taskID)
+		}
+		return cloudID, key, nil
+	}
+
+	var err error
+	if vpc, _, exist := tpl.FindSubnet(cvm.Subnet); exist {
+		if req.CloudVpcID, keys.CloudVpcID, err = resolve(enumor.VpcCloudResType, vpc.Name); err != nil {
+			return err
+		}
+		if req.CloudSubnetID, keys.CloudSubnetID, err = resolve(enumor.SubnetCloudResType, cvm.Subnet); err != nil {
+			return err
+		}
+	} else {
+		cloudVpcID, exist := cloudSubnetVpcs[cvm.Subnet]
+		if !exist {
+			return fmt.Errorf("subnet: %s of cvm: %s not found", cvm.Subnet, cvm.Name)
+		}
+		req.CloudVpcID, req.CloudSubnetID = cloudVpcID, cvm.Subnet
+	}
+
+	for _, sg := range cvm.SecurityGroups {
+		if !tpl.HasSecurityGroup(sg) {
+			req.CloudSecurityGroupIDs = append(req.CloudSecurityGroupIDs, sg)
+			continue
+		}
+
+		cloudID, key, err := resolve(enumor.SecurityGroupCloudResType, sg)
+		if err != nil {
+			return err
+		}
+		if len(cloudID) != 0 {
+			req.CloudSecurityGroupIDs = append(req.CloudSecurityGroupIDs, cloudID)
+		} else {
+			keys.CloudSecurityGroupIDs = append(keys.CloudSecurityGroupIDs, key)
+		}
+	}
+
+	saveKey := actionstack.ResourceKey(enumor.CvmCloudResType, cvm.Name)
+	createOpt := &actioncvm.CreateOption{
+		Vendor:               c.stack.Vendor,
+		CreateExtOption:      actioncvm.CreateExtOption{SaveKey: saveKey},
+		TCloudBatchCreateReq: req,
+	}
+	if len(dependOn) != 0 {
+		createOpt.CloudIDKeys = keys
+	}
+	createID := c.addCreateTask(enumor.CvmCloudResType, cvm.Name, enumor.ActionCreateCvm, createOpt, dependOn)
+
+	c.addTask(enumor.ActionAssignCvm, &actioncvm.AssignCvmOption{BizID: c.stack.BkBizID, CloudIDKey: saveKey},
+		[]action.ActIDType{createID}, false)
+	return nil
+}
+
+func (c *compiler) createDisk(disk corestack.DiskTemplate) {
+	name := disk.Name
+	c.addCreateTask(enumor.DiskCloudResType, disk.Name, enumor.ActionCreateDisk, &actiondisk.CreateDiskOption{
+		Vendor:  c.stack.Vendor,
+		BkBizID: c.stack.BkBizID,
+		SaveKey: actionstack.ResourceKey(enumor.DiskCloudResType, disk.Name),
+		TCloudDisk: &hcdisk.TCloudDiskCreateReq{
+			DiskBaseCreateReq: &hcdisk.DiskBaseCreateReq{
+				AccountID: c.stack.AccountID,
+				DiskName:  &name,
+				Region:    c.stack.Region,
+				Zone:      disk.Zone,
+				DiskSize:  disk.DiskSize,
+				DiskType:  disk.DiskType,
+				DiskCount: disk.Count,
+			},
+			Extension: &hcdisk.TCloudDiskExtensionCreateReq{DiskChargeType: disk.DiskChargeType},
+		},
+	}, nil)
+}
+
+func (c *compiler) createEip(eip corestack.EipTemplate) {
+	name := eip.Name
+	c.addCreateTask(enumor.EipCloudResType, eip.Name, enumor.ActionCreateEIP, &actioneip.CreateEIPOption{
+		Vendor:  c.stack.Vendor,
+		SaveKey: actionstack.ResourceKey(enumor.EipCloudResType, eip.Name),
+		TCloudEip: &hceip.TCloudEipCreateReq{
+			AccountID: c.stack.AccountID,
+			BkBizID:   c.stack.BkBizID,
+			TCloudEipCreateOption: &typeeip.TCloudEipCreateOption{
+				Region:          c.stack.Region,
+				EipName:         &name,
+				EipCount:        eip.Count,
+				ServiceProvider: "BGP",
+				AddressType:     "EIP",
+			},
+		},
+	}, nil)
+}
+
+// deleteStages 删除资源的顺序，后面阶段的资源依赖前面阶段的资源先删除
+var deleteStages = [][]enumor.CloudResourceType{
+	{enumor.CvmCloudResType, enumor.DiskCloudResType, enumor.EipCloudResType},
+	{enumor.SecurityGroupCloudResType, enumor.SubnetCloudResType},
+	{enumor.VpcCloudResType},
+}
+
+// deleteInOrder 按阶段生成删除任务，onFailure为true时生成失败分支的回滚任务，回滚任务按名称从共享数据中获取资源ID
+func (c *compiler) deleteInOrder(resources []corestack.Resource, onFailure bool) {
+	dependOn := make([]action.ActIDType, 0)
+	for _, stage := range deleteStages {
+		stageIDs := make([]action.ActIDType, 0)
+		for _, res := range resources {
+			if !slice.IsItemInSlice(stage, res.ResType) {
+				continue
+			}
+			if !onFailure && len(res.IDs) == 0 {
+				continue
+			}
+
+			params := &actionstack.DeleteResourceOption{
+				Vendor:    c.stack.Vendor,
+				AccountID: c.stack.AccountID,
+				Region:    c.stack.Region,
+				ResType:   res.ResType,
+				Name:      res.Name,
+				IDs:       res.IDs,
+			}
+			stageIDs = append(stageIDs, c.addTask(enumor.ActionDeleteStackResource, params, dependOn, onFailure))
+		}
+		dependOn = append(dependOn, stageIDs...)
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package stack
+
+import (
+	logicsdrift "hcm/cmd/cloud-server/logics/drift"
+	csstack "hcm/pkg/api/cloud-server/stack"
+	coredrift "hcm/pkg/api/core/drift"
+	corestack "hcm/pkg/api/core/stack"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// FieldCount 按数量创建的资源，已创建的数量与模版不一致时的差异属性
+const FieldCount = "count"
+
+// Plan 对比模版与资源栈已创建的资源，current 为 Refresh 后的资源。模版中新增的资源需要创建，从模版中移除的资源需要删除，
+// 已创建资源的属性与模版不一致时标记为漂移，apply不会修改已创建的资源。
+func Plan(kt *kit.Kit, cli *dataservice.Client, version uint, tpl *corestack.Template,
+	current []corestack.Resource) (*csstack.PlanResult, error) {
+
+	actual := make(map[string]coredrift.Spec)
+	for _, one := range current {
+		if one.ResType != enumor.VpcCloudResType && one.ResType != enumor.SubnetCloudResType &&
+			one.ResType != enumor.CvmCloudResType {
+			continue
+		}
+
+		for _, ids := range slice.Split(one.IDs, constant.BatchOperationMaxLimit) {
+			specs, err := logicsdrift.ActualSpecs(kt, cli, one.ResType, ids)
+			if err != nil {
+				logs.Errorf("get %s actual spec failed, err: %v, ids: %v, rid: %s", one.ResType, err, ids, kt.Rid)
+				return nil, err
+			}
+			for id, spec := range specs {
+				actual[id] = spec
+			}
+		}
+	}
+
+	return buildPlan(version, tpl, current, actual)
+}
+
+func buildPlan(version uint, tpl *corestack.Template, current []corestack.Resource,
+	actual map[string]coredrift.Spec) (*csstack.PlanResult, error) {
+
+	currentMap := make(map[string]corestack.Resource, len(current))
+	for _, one := range current {
+		currentMap[resourceKey(one.ResType, one.Name)] = one
+	}
+
+	result := &csstack.PlanResult{Version: version, Items: make([]csstack.PlanItem, 0)}
+	seen := make(map[string]struct{})
+	add := func(resType enumor.CloudResourceType, name string, desired coredrift.Spec, count int) error {
+		key := resourceKey(resType, name)
+		seen[key] = struct{}{}
+
+		res, exist := currentMap[key]
+		if !exist {
+			result.Items = append(result.Items, csstack.PlanItem{ResType: resType, Name: name,
+				Action: enumor.CreateStackPlanAction, IDs: make([]string, 0), Diffs: make([]coredrift.FieldDiff, 0)})
+			return nil
+		}
+
+		diffs := make([]coredrift.FieldDiff, 0)
+		if count > 0 && len(res.IDs) != count {
+			diffs = append(diffs, coredrift.FieldDiff{Field: FieldCount, Desired: count, Actual: len(res.IDs)})
+		}
+
+		if len(desired) != 0 {
+			fields := make(map[string]struct{})
+			for _, id := range res.IDs {
+				spec, ok := actual[id]
+				if !ok {
+					continue
+				}
+
+				one, err := logicsdrift.Diff(desired, spec)
+				if err != nil {
+					return err
+				}
+
+				// 按数量创建的资源，同一属性只保留第一个不一致的资源
+				for _, diff := range one {
+					if _, ok = fields[diff.Field]; ok {
+						continue
+					}
+					fields[diff.Field] = struct{}{}
+					diffs = append(diffs, diff)
+				}
+			}
+		}
+
+		action := enumor.NoChangeStackPlanAction
+		if len(diffs) != 0 {
+			action = enumor.DriftedStackPlanAction
+		}
+		result.Items = append(result.Items, csstack.PlanItem{ResType: resType, Name: name, Action: action,
+			IDs: res.IDs, Diffs: diffs})
+		return nil
+	}
+
+	for _, vpc := range tpl.Vpcs {
+		desired := coredrift.Spec{logicsdrift.FieldName: vpc.Name, logicsdrift.FieldCidrs: []string{vpc.IPv4Cidr}}
+		if err := add(enumor.VpcCloudResType, vpc.Name, desired, 0); err != nil {
+			return nil, err
+		}
+
+		for _, subnet := range vpc.Subnets {
+			desired = coredrift.Spec{logicsdrift.FieldName: subnet.Name,
+				logicsdrift.FieldIpv4Cidr: []string{subnet.IPv4Cidr}}
+			if err := add(enumor.SubnetCloudResType, subnet.Name, desired, 0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, sg := range tpl.SecurityGroups {
+		if err := add(enumor.SecurityGroupCloudResType, sg.Name, nil, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, cvm := range tpl.Cvms {
+		desired := coredrift.Spec{logicsdrift.FieldMachineType: cvm.InstanceType,
+			logicsdrift.FieldCloudImageID: cvm.CloudImageID}
+		if err := add(enumor.CvmCloudResType, cvm.Name, desired, int(cvm.Count)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, disk := range tpl.Disks {
+		if err := add(enumor.DiskCloudResType, disk.Name, nil, int(disk.Count)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, eip := range tpl.Eips {
+		if err := add(enumor.EipCloudResType, eip.Name, nil, int(eip.Count)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, one := range current {
+		if _, exist := seen[resourceKey(one.ResType, one.Name)]; exist {
+			continue
+		}
+		result.Items = append(result.Items, csstack.PlanItem{ResType: one.ResType, Name: one.Name,
+			Action: enumor.DeleteStackPlanAction, IDs: one.IDs, Diffs: make([]coredrift.FieldDiff, 0)})
+	}
+
+	return result, nil
+}
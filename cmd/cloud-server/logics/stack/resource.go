@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package stack
+
+import (
+	actionstack "hcm/cmd/task-server/logics/action/stack"
+	corestack "hcm/pkg/api/core/stack"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+func resourceKey(resType enumor.CloudResourceType, name string) string {
+	return string(resType) + "/" + name
+}
+
+// MergeResources 合并同类型同名称的资源，资源ID和云ID去重
+func MergeResources(resources ...[]corestack.Resource) []corestack.Resource {
+	index := make(map[string]int)
+	merged := make([]corestack.Resource, 0)
+	for _, list := range resources {
+		for _, one := range list {
+			key := resourceKey(one.ResType, one.Name)
+			idx, exist := index[key]
+			if !exist {
+				index[key] = len(merged)
+				merged = append(merged, corestack.Resource{ResType: one.ResType, Name: one.Name,
+					IDs: slice.Unique(one.IDs), CloudIDs: slice.Unique(one.CloudIDs)})
+				continue
+			}
+			merged[idx].IDs = slice.Unique(append(merged[idx].IDs, one.IDs...))
+			merged[idx].CloudIDs = slice.Unique(append(merged[idx].CloudIDs, one.CloudIDs...))
+		}
+	}
+
+	return merged
+}
+
+// Refresh 合并资源栈的资源并按资源ID或云ID查询资源，已经不存在的资源会被过滤掉。
+// 任务流中创建的资源只记录了云ID，刷新后补全资源ID
+func Refresh(kt *kit.Kit, cli *dataservice.Client, resources []corestack.Resource) ([]corestack.Resource, error) {
+	result := make([]corestack.Resource, 0, len(resources))
+	for _, one := range MergeResources(resources) {
+		ids, cloudIDs := make([]string, 0), make([]string, 0)
+		lookups := []struct {
+			field  string
+			values []string
+		}{{field: "id", values: one.IDs}, {field: "cloud_id", values: one.CloudIDs}}
+		for _, lookup := range lookups {
+			if len(lookup.values) == 0 {
+				continue
+			}
+
+			foundIDs, foundCloudIDs, err := actionstack.ListResource(kt, cli, one.ResType, lookup.field,
+				lookup.values)
+			if err != nil {
+				logs.Errorf("list stack %s: %s failed, err: %v, %s: %v, rid: %s", one.ResType, one.Name, err,
+					lookup.field, lookup.values, kt.Rid)
+				return nil, err
+			}
+			ids, cloudIDs = append(ids, foundIDs...), append(cloudIDs, foundCloudIDs...)
+		}
+
+		if len(ids) == 0 {
+			continue
+		}
+		result = append(result, corestack.Resource{ResType: one.ResType, Name: one.Name, IDs: slice.Unique(ids),
+			CloudIDs: slice.Unique(cloudIDs)})
+	}
+
+	return result, nil
+}
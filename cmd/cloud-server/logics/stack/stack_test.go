@@ -0,0 +1,200 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package stack
+
+import (
+	"testing"
+
+	actioncvm "hcm/cmd/task-server/logics/action/cvm"
+	actionstack "hcm/cmd/task-server/logics/action/stack"
+	actionsubnet "hcm/cmd/task-server/logics/action/subnet"
+	csstack "hcm/pkg/api/cloud-server/stack"
+	coredrift "hcm/pkg/api/core/drift"
+	corestack "hcm/pkg/api/core/stack"
+	"hcm/pkg/async/action"
+	"hcm/pkg/criteria/enumor"
+)
+
+const testTemplate = `
+vpcs:
+  - name: vpc-a
+    ipv4_cidr: 10.0.0.0/16
+    bk_cloud_id: 1
+    subnets:
+      - name: subnet-a
+        zone: ap-guangzhou-3
+        ipv4_cidr: 10.0.1.0/24
+security_groups:
+  - name: sg-a
+cvms:
+  - name: web
+    count: 2
+    zone: ap-guangzhou-3
+    instance_type: S5.MEDIUM2
+    cloud_image_id: img-1
+    subnet: subnet-a
+    security_groups: [sg-a, sg-exist]
+    key_pair_id: skey-1
+    system_disk:
+      disk_type: CLOUD_PREMIUM
+      disk_size_gb: 50
+eips:
+  - name: eip-a
+`
+
+func TestParseTemplate(t *testing.T) {
+	tpl, err := ParseTemplate(enumor.YamlStackTemplateFormat, testTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tpl.Cvms[0].InstanceChargeType != corestack.DefaultInstanceChargeType || tpl.Eips[0].Count != 1 {
+		t.Errorf("template default not set: %+v", tpl)
+	}
+
+	if _, err = ParseTemplate(enumor.JsonStackTemplateFormat, `{"vpcs": [], "unknown": 1}`); err == nil {
+		t.Error("template with unknown field should be invalid")
+	}
+
+	// 模版不支持登录密码，避免明文密码保存在模版版本和任务参数中
+	password := `{"cvms": [{"name": "web", "zone": "ap-guangzhou-3", "instance_type": "S5.MEDIUM2",
+		"cloud_image_id": "img-1", "subnet": "subnet-1", "security_groups": ["sg-1"], "password": "Passw0rd!",
+		"system_disk": {"disk_type": "CLOUD_PREMIUM", "disk_size_gb": 50}}]}`
+	if _, err = ParseTemplate(enumor.JsonStackTemplateFormat, password); err == nil {
+		t.Error("template with cvm password should be invalid")
+	}
+
+	dup := `{"security_groups": [{"name": "sg"}, {"name": "sg"}]}`
+	if _, err = ParseTemplate(enumor.JsonStackTemplateFormat, dup); err == nil {
+		t.Error("template with duplicate name should be invalid")
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+	tpl, err := ParseTemplate(enumor.YamlStackTemplateFormat, testTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := []corestack.Resource{
+		{ResType: enumor.VpcCloudResType, Name: "vpc-a", IDs: []string{"vpc-1"}},
+		{ResType: enumor.SubnetCloudResType, Name: "subnet-a", IDs: []string{"subnet-1"}},
+		{ResType: enumor.CvmCloudResType, Name: "web", IDs: []string{"cvm-1"}},
+		{ResType: enumor.DiskCloudResType, Name: "old-disk", IDs: []string{"disk-1"}},
+	}
+	actual := map[string]coredrift.Spec{
+		"vpc-1":    {"name": "vpc-a", "cidrs": []interface{}{"10.0.0.0/16"}},
+		"subnet-1": {"name": "subnet-a", "ipv4_cidr": []interface{}{"10.0.1.0/24"}},
+		"cvm-1":    {"machine_type": "S5.MEDIUM2", "cloud_image_id": "img-1"},
+	}
+
+	plan, err := buildPlan(2, tpl, current, actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := map[string]enumor.StackPlanAction{
+		"vpc/vpc-a":           enumor.NoChangeStackPlanAction,
+		"subnet/subnet-a":     enumor.NoChangeStackPlanAction,
+		"security_group/sg-a": enumor.CreateStackPlanAction,
+		"cvm/web":             enumor.DriftedStackPlanAction,
+		"eip/eip-a":           enumor.CreateStackPlanAction,
+		"disk/old-disk":       enumor.DeleteStackPlanAction,
+	}
+	if len(plan.Items) != len(expect) {
+		t.Fatalf("got %d plan items, expect %d: %+v", len(plan.Items), len(expect), plan.Items)
+	}
+	for _, item := range plan.Items {
+		key := resourceKey(item.ResType, item.Name)
+		if item.Action != expect[key] {
+			t.Errorf("%s plan action = %s, expect %s", key, item.Action, expect[key])
+		}
+		if key == "cvm/web" && (len(item.Diffs) != 1 || item.Diffs[0].Field != FieldCount) {
+			t.Errorf("cvm diffs = %+v, expect count diff", item.Diffs)
+		}
+	}
+}
+
+func TestCompileApply(t *testing.T) {
+	tpl, err := ParseTemplate(enumor.YamlStackTemplateFormat, testTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stack := &corestack.Stack{Vendor: enumor.TCloud, AccountID: "account", Region: "ap-guangzhou", BkBizID: 1}
+	current := []corestack.Resource{
+		{ResType: enumor.DiskCloudResType, Name: "old-disk", IDs: []string{"disk-1"}},
+	}
+	plan, err := buildPlan(1, tpl, current, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, err := CompileApply(&CompileOption{Stack: stack, Template: tpl, Plan: plan, Current: current})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// vpc、子网、安全组、主机、弹性IP的创建任务，主机的分配任务，旧硬盘的删除任务，以及五个创建任务的回滚任务
+	if len(tasks) != 12 {
+		t.Fatalf("got %d tasks, expect 12", len(tasks))
+	}
+
+	subnet, ok := tasks[1].Params.(*actionsubnet.CreateSubnetOption)
+	if !ok || subnet.CloudVpcIDKey != actionstack.ResourceKey(enumor.VpcCloudResType, "vpc-a") {
+		t.Errorf("task 2 should create subnet in vpc created by task 1, got: %+v", tasks[1].Params)
+	}
+
+	cvm := tasks[3]
+	opt, ok := cvm.Params.(*actioncvm.CreateOption)
+	if !ok || cvm.ActionName != enumor.ActionCreateCvm {
+		t.Fatalf("task 4 should create cvm, got: %+v", cvm.Params)
+	}
+	if len(cvm.DependOn) != 3 || cvm.DependOn[0] != action.ActIDType("1") ||
+		cvm.DependOn[1] != action.ActIDType("2") || cvm.DependOn[2] != action.ActIDType("3") {
+		t.Errorf("cvm task depend on = %v, expect [1 2 3]", cvm.DependOn)
+	}
+	if opt.CloudIDKeys == nil || len(opt.CloudIDKeys.CloudVpcID) == 0 || len(opt.CloudIDKeys.CloudSubnetID) == 0 ||
+		len(opt.CloudIDKeys.CloudSecurityGroupIDs) != 1 || len(opt.TCloudBatchCreateReq.CloudSecurityGroupIDs) != 1 {
+		t.Errorf("cvm cloud id keys = %+v, security groups = %v", opt.CloudIDKeys,
+			opt.TCloudBatchCreateReq.CloudSecurityGroupIDs)
+	}
+
+	assign := tasks[4]
+	if assign.ActionName != enumor.ActionAssignCvm || len(assign.DependOn) != 1 ||
+		assign.DependOn[0] != cvm.ActionID {
+		t.Errorf("task 5 should assign cvm created by task 4, got: %+v", assign)
+	}
+
+	for _, task := range tasks[7:] {
+		if !task.OnFailure || task.ActionName != enumor.ActionDeleteStackResource {
+			t.Errorf("task %s should be on failure rollback task", task.ActionID)
+		}
+	}
+	// 回滚任务中vpc最后删除，依赖其他全部回滚任务
+	if vpc := tasks[11]; len(vpc.DependOn) != 4 {
+		t.Errorf("vpc rollback task depend on = %v, expect 4 tasks", vpc.DependOn)
+	}
+
+	if _, err = CompileApply(&CompileOption{Stack: &corestack.Stack{Vendor: enumor.Aws}, Template: tpl,
+		Plan: &csstack.PlanResult{}}); err == nil {
+		t.Error("aws stack should not be supported")
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package stack 资源栈模版解析、plan以及将plan编译为异步任务流
+package stack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	corestack "hcm/pkg/api/core/stack"
+	"hcm/pkg/criteria/enumor"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParseTemplate 解析并校验资源栈模版，yaml格式的模版先转换为json再解析，两种格式使用同一套字段定义，
+// 模版中存在未定义的字段时返回错误，避免字段名拼写错误被忽略。
+func ParseTemplate(format enumor.StackTemplateFormat, content string) (*corestack.Template, error) {
+	data := []byte(content)
+	switch format {
+	case enumor.JsonStackTemplateFormat:
+	case enumor.YamlStackTemplateFormat:
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("unmarshal yaml template failed, err: %v", err)
+		}
+
+		var err error
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("convert yaml template to json failed, err: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("template format: %s not support", format)
+	}
+
+	tpl := new(corestack.Template)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(tpl); err != nil {
+		return nil, fmt.Errorf("unmarshal template failed, err: %v", err)
+	}
+
+	tpl.SetDefault()
+	if err := tpl.Validate(); err != nil {
+		return nil, err
+	}
+
+	return tpl, nil
+}
@@ -27,14 +27,20 @@ import (
 	accounthandler "hcm/cmd/cloud-server/service/application/handlers/account"
 	awscvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/aws"
 	azurecvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/azure"
+	cvmdeletionhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/deletion"
 	gcpcvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/gcp"
 	huaweicvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/huawei"
+	cvmrecyclehandler "hcm/cmd/cloud-server/service/application/handlers/cvm/recycle"
 	tcloudcvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/tcloud"
 	awsdiskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/aws"
 	azurediskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/azure"
 	gcpdiskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/gcp"
 	huaweidiskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/huawei"
 	tclouddiskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/tcloud"
+	eipassociatehandler "hcm/cmd/cloud-server/service/application/handlers/eip/associate"
+	tcloudeiphandler "hcm/cmd/cloud-server/service/application/handlers/eip/tcloud"
+	tcloudsgrulehandler "hcm/cmd/cloud-server/service/application/handlers/security-group/tcloud"
+	tcloudsubnethandler "hcm/cmd/cloud-server/service/application/handlers/subnet/tcloud"
 	awsvpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/aws"
 	azurevpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/azure"
 	gcpvpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/gcp"
@@ -45,11 +51,12 @@ import (
 	csdisk "hcm/pkg/api/cloud-server/disk"
 	csvpc "hcm/pkg/api/cloud-server/vpc"
 	dataproto "hcm/pkg/api/data-service"
-	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/thirdparty/api-gateway/itsm"
 	"hcm/pkg/tools/json"
 )
 
@@ -99,14 +106,30 @@ func (a *applicationSvc) approve(cts *rest.Contexts) (interface{}, error) {
 		)
 	}
 
+	result := itsm.TicketResult{SN: req.SN, CurrentStatus: req.CurrentStatus, ApproveResult: *req.ApproveResult}
+	return nil, a.handleApproveResult(cts.Kit, result)
+}
+
+// handleApproveResult 处理单据的审批结果，ITSM回调以及内置审批引擎的单据审批结束时都会调用。
+// 审批结果可能被重复处理(ITSM重复回调、内置审批引擎补偿回调失败的单据)，只处理审批中的申请单。
+func (a *applicationSvc) handleApproveResult(kt *kit.Kit, result itsm.TicketResult) error {
 	// 查询单据
-	application, err := a.getApplicationBySN(cts, req.SN)
+	application, err := a.deliverStore.GetApplicationBySN(kt, result.SN)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if application.Status != enumor.Pending {
+		logs.Infof("application %s is already %s, skip approve result, sn: %s, rid: %s", application.ID,
+			application.Status, result.SN, kt.Rid)
+		return nil
 	}
 
 	// 将ITSM单据状态转为hcm定义的单据状态
-	status := a.convertToStatus(req.CurrentStatus, *req.ApproveResult)
+	status := a.convertToStatus(result.CurrentStatus, result.ApproveResult)
+	if status == enumor.Pending {
+		return nil
+	}
 
 	// 计算下个状态，实际上除了通过外，其他状态都是不需要变化了，要么是终结态，要么是持续中
 	nextStatus := status
@@ -115,19 +138,30 @@ func (a *applicationSvc) approve(cts *rest.Contexts) (interface{}, error) {
 		nextStatus = enumor.Delivering
 	}
 
-	// 更新状态
-	err = a.updateStatusWithDetail(cts, application.ID, nextStatus, "")
-	if err != nil {
-		return nil, err
+	// 更新状态，申请单已被其他回调处理时不再重复处理
+	req := &dataproto.ApplicationUpdateReq{Status: nextStatus, SourceStatus: enumor.Pending}
+	if err = a.deliverStore.UpdateApplication(kt, application.ID, req); err != nil {
+		if ef := errf.Error(err); ef != nil && ef.Code == errf.RecordNotUpdate {
+			logs.Infof("application %s approve result has been handled, sn: %s, rid: %s", application.ID,
+				result.SN, kt.Rid)
+			return nil
+		}
+		return err
 	}
 
-	// 通过后需要进行资源交付
+	// 通过后创建交付任务流进行资源交付
 	if status == enumor.Pass {
-		// TODO: 需要引入异步任务框架，这里先暂时用goroutine异步执行，无法记录状态等的，包括可能被kill等异常情况都无法处理和记录
-		go a.deliver(cts, application)
+		if err = a.startDeliver(kt, application); err != nil {
+			if updateErr := a.updateStatusWithDetail(kt, application.ID, enumor.DeliverError,
+				flowCreateFailedDetail(err)); updateErr != nil {
+				logs.Errorf("update application[id=%s] status failed, err: %v, rid: %s", application.ID, updateErr,
+					kt.Rid)
+			}
+			return err
+		}
 	}
 
-	return nil, nil
+	return nil
 }
 
 func parseReqFromApplicationContent[T any](content string) (*T, error) {
@@ -262,6 +296,7 @@ func (a *applicationSvc) getHandlerByApplication(
 	cts *rest.Contexts, application *dataproto.ApplicationResp,
 ) (handlers.ApplicationHandler, error) {
 	opt := a.getHandlerOption(cts)
+	opt.ApplicationID = application.ID
 
 	// 只解析申请单的vendor
 	onlyVendor, err := parseReqFromApplicationContent[struct {
@@ -286,72 +321,58 @@ func (a *applicationSvc) getHandlerByApplication(
 		return a.getHandlerOfCreateVpc(opt, vendor, application)
 	case enumor.CreateDisk:
 		return a.getHandlerOfCreateDisk(opt, vendor, application)
-	}
-	return nil, errors.New("not handler to support")
-}
-
-func (a *applicationSvc) deliver(cts *rest.Contexts, application *dataproto.ApplicationResp) {
-	// 将执行人设置为申请人
-	cts.Kit.User = application.Applicant
-
-	// 除非交付成功，否则都属于交付失败状态
-	deliverStatus := enumor.DeliverError
-	deliveryDetailStr := `{"error": "unknown deliver error"}`
-	defer func() {
-		err := a.updateStatusWithDetail(cts, application.ID, deliverStatus, deliveryDetailStr)
+	case enumor.DeleteCvm:
+		req, err := parseReqFromApplicationContent[proto.CvmDeleteReq](application.Content)
 		if err != nil {
-			logs.Errorf("%s execute application[id=%s] delivery of %s failed, updateStatusWithDetail err: %s, rid: %s",
-				constant.ApplicationDeliverFailed, application.ID, application.Type, err, cts.Kit.Rid)
-			return
+			return nil, err
 		}
-	}()
-
-	// 根据不同申请单类型，获取对应的Handler
-	handler, err := a.getHandlerByApplication(cts, application)
-	if err != nil {
-		logs.Errorf("execute application[id=%s] delivery of %s failed, NewHandler err: %s, rid: %s",
-			application.ID, application.Type, err, cts.Kit.Rid)
-		deliveryDetailStr = fmt.Sprintf(`{"error": "get handler by application failed, err: %v"}`, err)
-		return
+		return cvmdeletionhandler.NewApplicationOfDeleteCvm(opt, req), nil
+	case enumor.RecycleCvm:
+		req, err := parseReqFromApplicationContent[proto.CvmRecycleReq](application.Content)
+		if err != nil {
+			return nil, err
+		}
+		return cvmrecyclehandler.NewApplicationOfRecycleCvm(opt, req), nil
+	case enumor.AssociateEip:
+		req, err := parseReqFromApplicationContent[proto.EipAssociateReq](application.Content)
+		if err != nil {
+			return nil, err
+		}
+		return eipassociatehandler.NewApplicationOfAssociateEip(opt, req), nil
+	case enumor.CreateEip, enumor.CreateSubnet, enumor.ChangeSecurityGroupRule:
+		return a.getTCloudOnlyHandler(opt, vendor, application)
 	}
+	return nil, errors.New("not handler to support")
+}
 
-	// 预处理申请内容数据，来自DB的数据
-	err = handler.PrepareReqFromContent()
-	if err != nil {
-		logs.Errorf("execute application[id=%s] delivery of %s failed, PrepareReqFromContent err: %s, rid: %s",
-			application.ID, application.Type, err, cts.Kit.Rid)
-		deliveryDetailStr = fmt.Sprintf(`{"error": "prepare request from content failed, err: %v"}`, err)
-		return
-	}
+// getTCloudOnlyHandler 目前只支持腾讯云的申请单类型
+func (a *applicationSvc) getTCloudOnlyHandler(opt *handlers.HandlerOption, vendor enumor.Vendor,
+	application *dataproto.ApplicationResp) (handlers.ApplicationHandler, error) {
 
-	// 再次校验数据正确性（特别是唯一性校验，申请时可能通过，但是审批后可能已经有其他存在了）
-	err = handler.CheckReq()
-	if err != nil {
-		logs.Errorf("execute application[id=%s] delivery of %s failed, CheckReq err: %s, rid: %s",
-			application.ID, application.Type, err, cts.Kit.Rid)
-		deliveryDetailStr = fmt.Sprintf(`{"error": "check request failed, err: %v"}`, err)
-		return
+	if vendor != enumor.TCloud {
+		return nil, fmt.Errorf("not support handler of %s %s", application.Type, vendor)
 	}
 
-	// 执行交付
-	deliverStatus, deliveryDetail, err := handler.Deliver()
-	// Note: 排查需要，这里无论失败还是成功，都记录日志，因为没有异步框架可以记录这些信息
-	logs.Infof("execute application[id=%s] delivery of %s, deliver status: %s, detail: %+v, rid: %s",
-		application.ID, application.Type, deliverStatus, deliveryDetail, cts.Kit.Rid)
-
-	if err != nil {
-		logs.Errorf("execute application[id=%s] delivery of %s failed, err: %s, rid: %s", application.ID,
-			application.Type, err, cts.Kit.Rid)
-
-		deliverStatus = enumor.DeliverError
+	switch application.Type {
+	case enumor.CreateEip:
+		req, err := parseReqFromApplicationContent[proto.TCloudEipCreateReq](application.Content)
+		if err != nil {
+			return nil, err
+		}
+		return tcloudeiphandler.NewApplicationOfCreateTCloudEip(opt, req), nil
+	case enumor.CreateSubnet:
+		req, err := parseReqFromApplicationContent[proto.TCloudSubnetCreateReq](application.Content)
+		if err != nil {
+			return nil, err
+		}
+		return tcloudsubnethandler.NewApplicationOfCreateTCloudSubnet(opt, req), nil
+	case enumor.ChangeSecurityGroupRule:
+		req, err := parseReqFromApplicationContent[proto.TCloudSGRuleChangeReq](application.Content)
+		if err != nil {
+			return nil, err
+		}
+		return tcloudsgrulehandler.NewApplicationOfChangeTCloudSGRule(opt, req), nil
 	}
 
-	// 更新DB里单据的交付状态和详情
-	deliveryDetailStr, err = json.MarshalToString(deliveryDetail)
-	if err != nil {
-		logs.Errorf("marshal deliver detail failed, err: %v, detail: %+v, rid: %s", err, deliveryDetail, cts.Kit.Rid)
-
-		deliverStatus = enumor.DeliverError
-		deliveryDetailStr = `{"error": "marshal deliver detail failed"}`
-	}
+	return nil, fmt.Errorf("not support handler of %s", application.Type)
 }
@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"testing"
+
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/thirdparty/api-gateway/itsm"
+)
+
+func TestHandleApproveResultRepeatedly(t *testing.T) {
+	svc, store := newTestDeliverSvc(new(fakeDeliverHandler))
+	store.apps["app"] = &dataproto.ApplicationResp{ID: "app", SN: "sn", Status: enumor.Pending,
+		DeliveryDetail: "{}"}
+	kt := kit.New()
+
+	// 内置审批引擎单据结束后回调失败时会补偿回调，重复回调只创建一次交付任务流
+	result := itsm.TicketResult{SN: "sn", CurrentStatus: "FINISHED", ApproveResult: true}
+	for i := 0; i < 2; i++ {
+		if err := svc.handleApproveResult(kt, result); err != nil {
+			t.Fatalf("handle approve result failed, err: %v", err)
+		}
+	}
+	if len(store.flows) != 1 || store.apps["app"].Status != enumor.Delivering {
+		t.Errorf("repeated approve result should deliver once, flows: %d, status: %s", len(store.flows),
+			store.apps["app"].Status)
+	}
+
+	// 申请单不在审批中时不再处理审批结果
+	result = itsm.TicketResult{SN: "sn", CurrentStatus: "FINISHED"}
+	if err := svc.handleApproveResult(kt, result); err != nil {
+		t.Fatalf("handle approve result failed, err: %v", err)
+	}
+	if store.apps["app"].Status != enumor.Delivering {
+		t.Errorf("approve result of not pending application should be skipped, status: %s",
+			store.apps["app"].Status)
+	}
+
+	// 单据仍在审批中时申请单保持审批中
+	store.apps["app"].Status = enumor.Pending
+	result = itsm.TicketResult{SN: "sn", CurrentStatus: "RUNNING"}
+	if err := svc.handleApproveResult(kt, result); err != nil {
+		t.Fatalf("handle approve result failed, err: %v", err)
+	}
+	if store.apps["app"].Status != enumor.Pending || len(store.flows) != 1 {
+		t.Errorf("running ticket should not change application, status: %s", store.apps["app"].Status)
+	}
+}
@@ -49,7 +49,7 @@ func (a *applicationSvc) Cancel(cts *rest.Contexts) (interface{}, error) {
 	}
 
 	// 更新状态
-	err = a.updateStatusWithDetail(cts, applicationID, enumor.Cancelled, "")
+	err = a.updateStatusWithDetail(cts.Kit, applicationID, enumor.Cancelled, "")
 	if err != nil {
 		return nil, err
 	}
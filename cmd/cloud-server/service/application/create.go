@@ -22,18 +22,25 @@ package application
 import (
 	"fmt"
 
+	logicsguardrail "hcm/cmd/cloud-server/logics/guardrail"
 	"hcm/cmd/cloud-server/service/application/handlers"
 	accounthandler "hcm/cmd/cloud-server/service/application/handlers/account"
 	awscvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/aws"
 	azurecvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/azure"
+	cvmdeletionhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/deletion"
 	gcpcvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/gcp"
 	huaweicvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/huawei"
+	cvmrecyclehandler "hcm/cmd/cloud-server/service/application/handlers/cvm/recycle"
 	tcloudcvmhandler "hcm/cmd/cloud-server/service/application/handlers/cvm/tcloud"
 	awsdiskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/aws"
 	azurediskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/azure"
 	gcpdiskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/gcp"
 	huaweidiskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/huawei"
 	tclouddiskhandler "hcm/cmd/cloud-server/service/application/handlers/disk/tcloud"
+	eipassociatehandler "hcm/cmd/cloud-server/service/application/handlers/eip/associate"
+	tcloudeiphandler "hcm/cmd/cloud-server/service/application/handlers/eip/tcloud"
+	tcloudsgrulehandler "hcm/cmd/cloud-server/service/application/handlers/security-group/tcloud"
+	tcloudsubnethandler "hcm/cmd/cloud-server/service/application/handlers/subnet/tcloud"
 	awsvpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/aws"
 	azurevpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/azure"
 	gcpvpchandler "hcm/cmd/cloud-server/service/application/handlers/vpc/gcp"
@@ -51,6 +58,8 @@ import (
 	"hcm/pkg/rest"
 	"hcm/pkg/thirdparty/api-gateway/itsm"
 	"hcm/pkg/tools/json"
+
+	"github.com/tidwall/gjson"
 )
 
 func decodeCommonReqAndValidate(cts *rest.Contexts) (*proto.CreateCommonReq, error) {
@@ -83,6 +92,11 @@ func (a *applicationSvc) create(cts *rest.Contexts, req *proto.CreateCommonReq,
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	// 校验资源创建管控规则
+	if err := a.checkGuardrail(cts, handler); err != nil {
+		return nil, err
+	}
+
 	// 预处理数据
 	if err := handler.PrepareReq(); err != nil {
 		return nil, err
@@ -113,6 +127,15 @@ func (a *applicationSvc) create(cts *rest.Contexts, req *proto.CreateCommonReq,
 	// 获取ITSM单据涉及到的各个节点审批人
 	approvers := handler.GetItsmApprover(managers)
 
+	// 申请单内容，内置审批引擎按申请单所属业务匹配审批流
+	content, err := json.MarshalToString(handler.GenerateApplicationContent())
+	if err != nil {
+		return nil, errf.NewFromErr(
+			errf.InvalidParameter,
+			fmt.Errorf("json marshal request data failed, err: %w", err),
+		)
+	}
+
 	// 调用ITSM创建单据
 	sn, err := a.itsmCli.CreateTicket(
 		cts.Kit,
@@ -124,6 +147,8 @@ func (a *applicationSvc) create(cts *rest.Contexts, req *proto.CreateCommonReq,
 			ContentDisplay: itsmForm,
 			// ITSM流程里使用变量引用的方式设置各个节点审批人
 			VariableApprovers: approvers,
+			ApplicationType:   applicationType,
+			BkBizID:           gjson.Get(content, "bk_biz_id").Int(),
 		},
 	)
 	if err != nil {
@@ -131,17 +156,8 @@ func (a *applicationSvc) create(cts *rest.Contexts, req *proto.CreateCommonReq,
 	}
 
 	// 调用DB创建单据
-	content, err := json.MarshalToString(handler.GenerateApplicationContent())
-	if err != nil {
-		return nil, errf.NewFromErr(
-			errf.InvalidParameter,
-			fmt.Errorf("json marshal request data failed, err: %w", err),
-		)
-	}
-
-	result, err := a.client.DataService().Global.Application.Create(
-		cts.Kit.Ctx,
-		cts.Kit.Header(),
+	result, err := a.createStore.CreateApplication(
+		cts.Kit,
 		&dataproto.ApplicationCreateReq{
 			SN:             sn,
 			Type:           applicationType,
@@ -159,6 +175,27 @@ func (a *applicationSvc) create(cts *rest.Contexts, req *proto.CreateCommonReq,
 	return result, nil
 }
 
+// checkGuardrail 资源创建申请单提交前校验管控规则，申请单内容与直接创建资源时对应云厂商的请求内容一致
+func (a *applicationSvc) checkGuardrail(cts *rest.Contexts, handler handlers.ApplicationHandler) error {
+	resType, exist := logicsguardrail.ApplicationResType(handler.GetType())
+	if !exist {
+		return nil
+	}
+
+	content, err := json.MarshalToString(handler.GenerateApplicationContent())
+	if err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("json marshal request data failed, err: %w", err))
+	}
+
+	target := &logicsguardrail.Target{
+		BkBizID: gjson.Get(content, "bk_biz_id").Int(),
+		ResType: resType,
+		Vendor:  handler.Vendor(),
+		Content: content,
+	}
+	return logicsguardrail.Check(cts.Kit, a.client.DataService(), target)
+}
+
 func parseReqFromRequestBody[T any](cts *rest.Contexts) (*T, error) {
 	req := new(T)
 	if err := cts.DecodeInto(req); err != nil {
@@ -367,3 +404,153 @@ func (a *applicationSvc) CreateForCreateDisk(cts *rest.Contexts) (interface{}, e
 
 	return nil, nil
 }
+
+// CreateForDeleteCvm ...
+func (a *applicationSvc) CreateForDeleteCvm(cts *rest.Contexts) (interface{}, error) {
+	commReq, err := decodeCommonReqAndValidate(cts)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := a.checkBizResPermission(cts, meta.Cvm, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	req, err := parseReqFromRequestBody[proto.CvmDeleteReq](cts)
+	if err != nil {
+		return nil, err
+	}
+	handler := cvmdeletionhandler.NewApplicationOfDeleteCvm(a.getHandlerOption(cts), req)
+
+	return a.create(cts, commReq, handler)
+}
+
+// CreateForRecycleCvm ...
+func (a *applicationSvc) CreateForRecycleCvm(cts *rest.Contexts) (interface{}, error) {
+	commReq, err := decodeCommonReqAndValidate(cts)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := a.checkBizResPermission(cts, meta.Cvm, meta.Recycle); err != nil {
+		return nil, err
+	}
+
+	req, err := parseReqFromRequestBody[proto.CvmRecycleReq](cts)
+	if err != nil {
+		return nil, err
+	}
+	handler := cvmrecyclehandler.NewApplicationOfRecycleCvm(a.getHandlerOption(cts), req)
+
+	return a.create(cts, commReq, handler)
+}
+
+// CreateForAssociateEip ...
+func (a *applicationSvc) CreateForAssociateEip(cts *rest.Contexts) (interface{}, error) {
+	commReq, err := decodeCommonReqAndValidate(cts)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := a.checkBizResPermission(cts, meta.Eip, meta.Associate); err != nil {
+		return nil, err
+	}
+
+	req, err := parseReqFromRequestBody[proto.EipAssociateReq](cts)
+	if err != nil {
+		return nil, err
+	}
+	handler := eipassociatehandler.NewApplicationOfAssociateEip(a.getHandlerOption(cts), req)
+
+	return a.create(cts, commReq, handler)
+}
+
+// CreateForCreateEip ...
+func (a *applicationSvc) CreateForCreateEip(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	commReq, err := decodeCommonReqAndValidate(cts)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := a.checkBizResPermission(cts, meta.Eip, meta.Create); err != nil {
+		return nil, err
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		req, err := parseReqFromRequestBody[proto.TCloudEipCreateReq](cts)
+		if err != nil {
+			return nil, err
+		}
+		handler := tcloudeiphandler.NewApplicationOfCreateTCloudEip(a.getHandlerOption(cts), req)
+		return a.create(cts, commReq, handler)
+	default:
+		return nil, errf.Newf(errf.ApplicationUnsupported, "vendor %s does not support %s application", vendor,
+			enumor.CreateEip)
+	}
+}
+
+// CreateForCreateSubnet ...
+func (a *applicationSvc) CreateForCreateSubnet(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	commReq, err := decodeCommonReqAndValidate(cts)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := a.checkBizResPermission(cts, meta.Subnet, meta.Create); err != nil {
+		return nil, err
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		req, err := parseReqFromRequestBody[proto.TCloudSubnetCreateReq](cts)
+		if err != nil {
+			return nil, err
+		}
+		handler := tcloudsubnethandler.NewApplicationOfCreateTCloudSubnet(a.getHandlerOption(cts), req)
+		return a.create(cts, commReq, handler)
+	default:
+		return nil, errf.Newf(errf.ApplicationUnsupported, "vendor %s does not support %s application", vendor,
+			enumor.CreateSubnet)
+	}
+}
+
+// CreateForChangeSGRule ...
+func (a *applicationSvc) CreateForChangeSGRule(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.Request.PathParameter("vendor"))
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	commReq, err := decodeCommonReqAndValidate(cts)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := a.checkBizResPermission(cts, meta.SecurityGroupRule, meta.Update); err != nil {
+		return nil, err
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		req, err := parseReqFromRequestBody[proto.TCloudSGRuleChangeReq](cts)
+		if err != nil {
+			return nil, err
+		}
+		handler := tcloudsgrulehandler.NewApplicationOfChangeTCloudSGRule(a.getHandlerOption(cts), req)
+		return a.create(cts, commReq, handler)
+	default:
+		return nil, errf.Newf(errf.ApplicationUnsupported, "vendor %s does not support %s application", vendor,
+			enumor.ChangeSecurityGroupRule)
+	}
+}
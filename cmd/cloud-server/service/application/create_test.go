@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"testing"
+
+	"hcm/cmd/cloud-server/service/application/handlers"
+	proto "hcm/pkg/api/cloud-server/application"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/thirdparty/api-gateway/itsm"
+)
+
+type fakeCreateStore struct {
+	processes    []*dataproto.ApprovalProcessResp
+	listTimes    int
+	applications []*dataproto.ApplicationCreateReq
+}
+
+func (s *fakeCreateStore) ListApprovalProcess(_ *kit.Kit, _ enumor.ApplicationType) (
+	[]*dataproto.ApprovalProcessResp, error) {
+
+	s.listTimes++
+	return s.processes, nil
+}
+
+func (s *fakeCreateStore) CreateApplication(_ *kit.Kit, req *dataproto.ApplicationCreateReq) (
+	*core.CreateResult, error) {
+
+	s.applications = append(s.applications, req)
+	return &core.CreateResult{ID: "app"}, nil
+}
+
+type fakeTicketClient struct {
+	itsm.Client
+	params []*itsm.CreateTicketParams
+}
+
+func (c *fakeTicketClient) CreateTicket(_ *kit.Kit, params *itsm.CreateTicketParams) (string, error) {
+	c.params = append(c.params, params)
+	return "sn", nil
+}
+
+type fakeCreateHandler struct {
+	handlers.ApplicationHandler
+}
+
+func (h *fakeCreateHandler) GetType() enumor.ApplicationType {
+	return enumor.DeleteCvm
+}
+
+func (h *fakeCreateHandler) CheckReq() error {
+	return nil
+}
+
+func (h *fakeCreateHandler) PrepareReq() error {
+	return nil
+}
+
+func (h *fakeCreateHandler) RenderItsmTitle() (string, error) {
+	return "title", nil
+}
+
+func (h *fakeCreateHandler) RenderItsmForm() (string, error) {
+	return "form", nil
+}
+
+func (h *fakeCreateHandler) GetItsmApprover(managers []string) []itsm.VariableApprover {
+	return []itsm.VariableApprover{{Variable: "platform_manager", Approvers: managers}}
+}
+
+func (h *fakeCreateHandler) GenerateApplicationContent() interface{} {
+	return map[string]interface{}{"bk_biz_id": 100}
+}
+
+func TestCreateWithNativeApproval(t *testing.T) {
+	store := new(fakeCreateStore)
+	ticketCli := new(fakeTicketClient)
+	svc := &applicationSvc{itsmCli: ticketCli, createStore: store, nativeApproval: true}
+	kt := kit.New()
+	kt.User = "applicant"
+
+	// 内置审批引擎不需要ITSM的审批流程记录
+	if _, err := svc.create(newTestContexts(kt, nil), new(proto.CreateCommonReq), new(fakeCreateHandler)); err != nil {
+		t.Fatalf("create application with native approval failed, err: %v", err)
+	}
+	if store.listTimes != 0 {
+		t.Errorf("native approval should not list approval process, times: %d", store.listTimes)
+	}
+	if len(ticketCli.params) != 1 || ticketCli.params[0].ServiceID != 0 || ticketCli.params[0].BkBizID != 100 {
+		t.Errorf("create ticket params not expected: %+v", ticketCli.params)
+	}
+	if len(store.applications) != 1 || store.applications[0].SN != "sn" ||
+		store.applications[0].Status != enumor.Pending {
+		t.Errorf("created application not expected: %+v", store.applications)
+	}
+
+	// ITSM审批时没有审批流程记录无法创建申请单
+	svc.nativeApproval = false
+	if _, err := svc.create(newTestContexts(kt, nil), new(proto.CreateCommonReq), new(fakeCreateHandler)); err == nil {
+		t.Error("create application with itsm should fail without approval process")
+	}
+	if store.listTimes != 1 || len(ticketCli.params) != 1 {
+		t.Errorf("itsm approval should list approval process and not create ticket, times: %d", store.listTimes)
+	}
+}
@@ -0,0 +1,498 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	actionapp "hcm/cmd/task-server/logics/action/application"
+	csapplication "hcm/pkg/api/cloud-server/application"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/action"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+)
+
+const (
+	// deliverFlowKey 交付详情中记录的交付任务流ID
+	deliverFlowKey = "deliver_flow_id"
+	// deliverStepKey 交付详情中记录的下一个待执行的交付步骤，deliver步骤开始执行时移除，用于保证交付只执行一次
+	deliverStepKey = "deliver_step"
+)
+
+// deliverStore 申请单交付依赖的申请单和任务流数据操作
+type deliverStore interface {
+	GetApplication(kt *kit.Kit, id string) (*dataproto.ApplicationResp, error)
+	GetApplicationBySN(kt *kit.Kit, sn string) (*dataproto.ApplicationResp, error)
+	// UpdateApplication 更新申请单，设置了 SourceDeliverStep 时交付步骤已被其他执行者变更则返回 RecordNotUpdate 错误
+	UpdateApplication(kt *kit.Kit, id string, req *dataproto.ApplicationUpdateReq) error
+	CreateDeliverFlow(kt *kit.Kit, req *ts.AddCustomFlowReq) (string, error)
+	ListFailedTaskReason(kt *kit.Kit, flowID string) ([]string, error)
+}
+
+type clientDeliverStore struct {
+	client *client.ClientSet
+}
+
+// GetApplication get application.
+func (s *clientDeliverStore) GetApplication(kt *kit.Kit, id string) (*dataproto.ApplicationResp, error) {
+	return s.client.DataService().Global.Application.Get(kt.Ctx, kt.Header(), id)
+}
+
+// GetApplicationBySN get application by approval ticket sn.
+func (s *clientDeliverStore) GetApplicationBySN(kt *kit.Kit, sn string) (*dataproto.ApplicationResp, error) {
+	resp, err := s.client.DataService().Global.Application.List(
+		kt,
+		&dataproto.ApplicationListReq{
+			Filter: tools.EqualExpression("sn", sn),
+			Page:   &core.BasePage{Count: false, Start: 0, Limit: 1},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || len(resp.Details) == 0 {
+		return nil, fmt.Errorf("not found application by sn(%s)", sn)
+	}
+
+	return resp.Details[0], nil
+}
+
+// UpdateApplication update application.
+func (s *clientDeliverStore) UpdateApplication(kt *kit.Kit, id string, req *dataproto.ApplicationUpdateReq) error {
+	_, err := s.client.DataService().Global.Application.Update(kt, id, req)
+	return err
+}
+
+// CreateDeliverFlow create application deliver flow.
+func (s *clientDeliverStore) CreateDeliverFlow(kt *kit.Kit, req *ts.AddCustomFlowReq) (string, error) {
+	flow, err := s.client.TaskServer().CreateCustomFlow(kt, req)
+	if err != nil {
+		return "", err
+	}
+
+	return flow.ID, nil
+}
+
+// ListFailedTaskReason list failed task reason of the deliver flow.
+func (s *clientDeliverStore) ListFailedTaskReason(kt *kit.Kit, flowID string) ([]string, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{"flow_id": flowID,
+			"state": enumor.TaskFailed}),
+		Page: core.NewDefaultBasePage(),
+	}
+	tasks, err := s.client.TaskServer().ListTask(kt, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := make([]string, 0, len(tasks.Details))
+	for _, task := range tasks.Details {
+		if task.Reason != nil {
+			reasons = append(reasons, task.Reason.Message)
+		}
+	}
+
+	return reasons, nil
+}
+
+// startDeliver 创建交付任务流，依次执行校验、交付步骤，任务流存在失败任务时将申请单更新为交付异常
+func (a *applicationSvc) startDeliver(kt *kit.Kit, application *dataproto.ApplicationResp) error {
+	retry := &tableasync.Retry{Enable: true, Policy: &tableasync.RetryPolicy{Count: 3,
+		SleepRangeMS: [2]uint{1000, 5000}}}
+	addReq := &ts.AddCustomFlowReq{
+		Name: enumor.FlowDeliverApplication,
+		Memo: fmt.Sprintf("deliver application %s", application.SN),
+		Tasks: []ts.CustomFlowTask{
+			{
+				ActionID:   "1",
+				ActionName: enumor.ActionDeliverApplication,
+				Params:     &actionapp.DeliverOption{ApplicationID: application.ID, Step: enumor.CheckDeliverStep},
+				Retry:      retry,
+			},
+			{
+				// 交付可能已经创建了部分资源，失败后不自动重试
+				ActionID:   "2",
+				ActionName: enumor.ActionDeliverApplication,
+				Params:     &actionapp.DeliverOption{ApplicationID: application.ID, Step: enumor.DeliverDeliverStep},
+				DependOn:   []action.ActIDType{"1"},
+			},
+			{
+				ActionID:   "3",
+				ActionName: enumor.ActionDeliverApplication,
+				Params:     &actionapp.DeliverOption{ApplicationID: application.ID, Step: enumor.FailDeliverStep},
+				Retry:      retry,
+				OnFailure:  true,
+			},
+		},
+	}
+	flowID, err := a.deliverStore.CreateDeliverFlow(kt, addReq)
+	if err != nil {
+		logs.Errorf("create application deliver flow failed, err: %v, id: %s, rid: %s", err, application.ID, kt.Rid)
+		return err
+	}
+
+	// 交付任务流记录前执行的步骤会因任务流不匹配而失败，由步骤的重试保证执行
+	detail := map[string]interface{}{deliverFlowKey: flowID, deliverStepKey: enumor.CheckDeliverStep}
+	return a.updateDeliverDetail(kt, application.ID, "", enumor.Delivering, detail)
+}
+
+// canExecuteDeliverStep 交付步骤只允许交付任务流调用，web-server和api-server转发的请求会被设置为各自的AppCode和用户
+func canExecuteDeliverStep(kt *kit.Kit) error {
+	if kt.AppCode != constant.BackendOperationAppCodeKey || kt.User != constant.BackendOperationUserKey {
+		return errf.Newf(errf.PermissionDenied, "app[%s] user[%s] has no permission to execute deliver step",
+			kt.AppCode, kt.User)
+	}
+
+	return nil
+}
+
+// checkDeliverFlow 校验任务流是否为申请单当前记录的交付任务流，重试前的旧任务流不能再变更申请单
+func checkDeliverFlow(application *dataproto.ApplicationResp, flowID string) error {
+	recordFlowID := gjson.Get(application.DeliveryDetail, deliverFlowKey).String()
+	if len(recordFlowID) == 0 || recordFlowID != flowID {
+		return errf.Newf(errf.PermissionDenied, "flow[%s] is not the deliver flow[%s] of application %s", flowID,
+			recordFlowID, application.ID)
+	}
+
+	return nil
+}
+
+// ExecuteDeliverStep 执行申请单交付任务流的步骤，内部接口，只允许交付任务流中的任务调用，只有交付中的申请单可以执行
+func (a *applicationSvc) ExecuteDeliverStep(cts *rest.Contexts) (interface{}, error) {
+	if err := canExecuteDeliverStep(cts.Kit); err != nil {
+		return nil, err
+	}
+
+	applicationID := cts.PathParameter("application_id").String()
+	step := enumor.ApplicationDeliverStep(cts.PathParameter("step").String())
+	if err := step.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(csapplication.ExecuteDeliverStepReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	application, err := a.deliverStore.GetApplication(cts.Kit, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.Status != enumor.Delivering {
+		// 申请单已经结束交付时失败步骤不需要处理
+		if step == enumor.FailDeliverStep {
+			return nil, nil
+		}
+		return nil, errf.Newf(errf.InvalidParameter, "application status is %s, can not be delivered",
+			application.Status)
+	}
+
+	if err = checkDeliverFlow(application, req.FlowID); err != nil {
+		return nil, err
+	}
+
+	// 将执行人设置为申请单记录中的申请人
+	cts.Kit.User = application.Applicant
+
+	switch step {
+	case enumor.CheckDeliverStep:
+		return nil, a.checkDeliver(cts, application)
+	case enumor.DeliverDeliverStep:
+		return nil, a.deliver(cts, application)
+	default:
+		return nil, a.failDeliver(cts.Kit, application)
+	}
+}
+
+func (a *applicationSvc) checkDeliver(cts *rest.Contexts, application *dataproto.ApplicationResp) error {
+	step := gjson.Get(application.DeliveryDetail, deliverStepKey).String()
+	if step != string(enumor.CheckDeliverStep) {
+		return fmt.Errorf("application deliver step is %s, can not be checked", step)
+	}
+
+	// 根据不同申请单类型，获取对应的Handler
+	handler, err := a.getDeliverHandler(cts, application)
+	if err != nil {
+		logs.Errorf("get application[id=%s] handler of %s failed, err: %v, rid: %s", application.ID,
+			application.Type, err, cts.Kit.Rid)
+		return fmt.Errorf("get handler by application failed, err: %v", err)
+	}
+
+	// 预处理申请内容数据，来自DB的数据
+	if err = handler.PrepareReqFromContent(); err != nil {
+		logs.Errorf("prepare application[id=%s] request of %s failed, err: %v, rid: %s", application.ID,
+			application.Type, err, cts.Kit.Rid)
+		return fmt.Errorf("prepare request from content failed, err: %v", err)
+	}
+
+	// 再次校验数据正确性（特别是唯一性校验，申请时可能通过，但是审批后可能已经有其他存在了）
+	if err = handler.CheckReq(); err != nil {
+		logs.Errorf("check application[id=%s] request of %s failed, err: %v, rid: %s", application.ID,
+			application.Type, err, cts.Kit.Rid)
+		return fmt.Errorf("check request failed, err: %v", err)
+	}
+
+	flowID := gjson.Get(application.DeliveryDetail, deliverFlowKey).String()
+	detail := map[string]interface{}{deliverFlowKey: flowID, deliverStepKey: enumor.DeliverDeliverStep}
+	_, err = a.updateDeliverStep(cts.Kit, application.ID, flowID, enumor.CheckDeliverStep, detail)
+	return err
+}
+
+func (a *applicationSvc) deliver(cts *rest.Contexts, application *dataproto.ApplicationResp) error {
+	step := gjson.Get(application.DeliveryDetail, deliverStepKey).String()
+	if step != string(enumor.DeliverDeliverStep) {
+		return fmt.Errorf("application deliver step is %s, can not be delivered", step)
+	}
+
+	handler, err := a.getDeliverHandler(cts, application)
+	if err != nil {
+		return fmt.Errorf("get handler by application failed, err: %v", err)
+	}
+
+	if err = handler.PrepareReqFromContent(); err != nil {
+		return fmt.Errorf("prepare request from content failed, err: %v", err)
+	}
+
+	// 交付开始前移除待执行步骤，交付中断后不会被再次执行，并发执行时只有移除成功的执行者进行交付，避免重复创建资源
+	flowID := gjson.Get(application.DeliveryDetail, deliverFlowKey).String()
+	updated, err := a.updateDeliverStep(cts.Kit, application.ID, flowID, enumor.DeliverDeliverStep,
+		map[string]interface{}{deliverFlowKey: flowID})
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		return nil
+	}
+
+	// 执行交付
+	deliverStatus, deliveryDetail, err := handler.Deliver()
+	logs.Infof("execute application[id=%s] delivery of %s, deliver status: %s, detail: %+v, rid: %s",
+		application.ID, application.Type, deliverStatus, deliveryDetail, cts.Kit.Rid)
+	if err != nil {
+		logs.Errorf("%s execute application[id=%s] delivery of %s failed, err: %v, rid: %s",
+			constant.ApplicationDeliverFailed, application.ID, application.Type, err, cts.Kit.Rid)
+		deliverStatus = enumor.DeliverError
+	}
+
+	if deliveryDetail == nil {
+		deliveryDetail = make(map[string]interface{})
+	}
+	deliveryDetail[deliverFlowKey] = flowID
+
+	updateErr := a.updateDeliverDetail(cts.Kit, application.ID, flowID, deliverStatus, deliveryDetail)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	// 交付失败时申请单已经更新为交付异常，任务流的失败步骤不需要再处理
+	return err
+}
+
+// failDeliver 交付任务流存在失败任务时，将申请单更新为交付异常，交付详情中记录失败任务的原因，
+// 只更新仍由该任务流交付的申请单，申请单已被重试或交付结束时不做处理
+func (a *applicationSvc) failDeliver(kt *kit.Kit, application *dataproto.ApplicationResp) error {
+	// 交付已经执行，交付结果由创建主机任务流的处理逻辑更新
+	if gjson.Get(application.DeliveryDetail, "flow_id").Exists() {
+		return nil
+	}
+
+	flowID := gjson.Get(application.DeliveryDetail, deliverFlowKey).String()
+	detail := map[string]interface{}{deliverFlowKey: flowID}
+	step := gjson.Get(application.DeliveryDetail, deliverStepKey)
+	if !step.Exists() {
+		detail["error"] = "delivery is interrupted, resources may be partially created"
+		return a.updateFailedDeliverDetail(kt, application.ID, flowID, detail)
+	}
+	// 保留未执行的交付步骤，重试时据此判断是否可能已经创建了资源
+	detail[deliverStepKey] = step.String()
+
+	reasons, err := a.deliverStore.ListFailedTaskReason(kt, flowID)
+	if err != nil {
+		logs.Errorf("list deliver flow failed task failed, err: %v, flow: %s, rid: %s", err, flowID, kt.Rid)
+		return err
+	}
+	detail["error"] = strings.Join(reasons, "; ")
+
+	return a.updateFailedDeliverDetail(kt, application.ID, flowID, detail)
+}
+
+func (a *applicationSvc) updateFailedDeliverDetail(kt *kit.Kit, applicationID, flowID string,
+	detail map[string]interface{}) error {
+
+	err := a.updateDeliverDetail(kt, applicationID, flowID, enumor.DeliverError, detail)
+	if ef := errf.Error(err); ef != nil && ef.Code == errf.RecordNotUpdate {
+		logs.Warnf("application is not delivered by flow %s anymore, skip fail it, id: %s, rid: %s", flowID,
+			applicationID, kt.Rid)
+		return nil
+	}
+
+	return err
+}
+
+// deliverStepConsumed 交付详情中没有待执行的交付步骤时，交付已经执行(交付失败或中断)，可能已经创建了部分资源
+func deliverStepConsumed(application *dataproto.ApplicationResp) bool {
+	return !gjson.Get(application.DeliveryDetail, deliverStepKey).Exists()
+}
+
+// flowCreateFailedDetail 创建交付任务流失败时的交付详情，交付步骤还未执行
+func flowCreateFailedDetail(err error) string {
+	detail := map[string]interface{}{
+		"error":        fmt.Sprintf("create deliver flow failed, err: %v", err),
+		deliverStepKey: enumor.CheckDeliverStep,
+	}
+	detailStr, marshalErr := json.MarshalToString(detail)
+	if marshalErr != nil {
+		return `{"error": "create deliver flow failed"}`
+	}
+	return detailStr
+}
+
+// RetryDeliver 重新交付交付异常的申请单，创建新的交付任务流，交付步骤已经执行过的申请单可能已经创建了部分资源，需要申请人确认后再重试
+func (a *applicationSvc) RetryDeliver(cts *rest.Contexts) (interface{}, error) {
+	retryReq := new(csapplication.RetryDeliverReq)
+	if err := cts.DecodeInto(retryReq); err != nil {
+		return nil, err
+	}
+
+	if err := retryReq.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	applicationID := cts.PathParameter("application_id").String()
+	application, err := a.deliverStore.GetApplication(cts.Kit, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 只能重试自己的申请单
+	if application.Applicant != cts.Kit.User {
+		return nil, errf.NewFromErr(
+			errf.PermissionDenied, fmt.Errorf("you can not operate other people's application"),
+		)
+	}
+
+	if application.Status != enumor.DeliverError {
+		return nil, errf.Newf(errf.InvalidParameter, "application status is %s, only %s can be retried",
+			application.Status, enumor.DeliverError)
+	}
+
+	if deliverStepConsumed(application) && !retryReq.Confirm {
+		return nil, errf.Newf(errf.InvalidParameter, "application %s has been delivered and resources may be "+
+			"partially created, please check the resources and confirm to retry", applicationID)
+	}
+
+	// 先将申请单从交付异常变更为交付中，并发重试时只有变更成功的请求创建交付任务流
+	emptyDetail := "{}"
+	req := &dataproto.ApplicationUpdateReq{Status: enumor.Delivering, DeliveryDetail: &emptyDetail,
+		SourceStatus: enumor.DeliverError}
+	if err = a.deliverStore.UpdateApplication(cts.Kit, applicationID, req); err != nil {
+		if ef := errf.Error(err); ef != nil && ef.Code == errf.RecordNotUpdate {
+			return nil, errf.Newf(errf.InvalidParameter, "application %s has been retried by others", applicationID)
+		}
+
+		logs.Errorf("update application to delivering failed, err: %v, id: %s, rid: %s", err, applicationID,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	if err = a.startDeliver(cts.Kit, application); err != nil {
+		// 创建交付任务流失败时恢复为交付异常，允许再次重试
+		detail := flowCreateFailedDetail(err)
+		revertReq := &dataproto.ApplicationUpdateReq{Status: enumor.DeliverError, DeliveryDetail: &detail,
+			SourceStatus: enumor.Delivering}
+		if updateErr := a.deliverStore.UpdateApplication(cts.Kit, applicationID, revertReq); updateErr != nil {
+			logs.Errorf("revert application to deliver error failed, err: %v, id: %s, rid: %s", updateErr,
+				applicationID, cts.Kit.Rid)
+		}
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// updateDeliverStep 以交付任务流和交付步骤作为条件更新交付详情，交付步骤已被其他执行者变更时返回false
+func (a *applicationSvc) updateDeliverStep(kt *kit.Kit, applicationID, flowID string,
+	sourceStep enumor.ApplicationDeliverStep, detail map[string]interface{}) (bool, error) {
+
+	detailStr, err := json.MarshalToString(detail)
+	if err != nil {
+		logs.Errorf("marshal deliver detail failed, err: %v, detail: %+v, rid: %s", err, detail, kt.Rid)
+		return false, err
+	}
+
+	req := &dataproto.ApplicationUpdateReq{Status: enumor.Delivering, DeliveryDetail: &detailStr,
+		SourceDeliverStep: sourceStep, SourceDeliverFlowID: flowID}
+	if err = a.deliverStore.UpdateApplication(kt, applicationID, req); err != nil {
+		if ef := errf.Error(err); ef != nil && ef.Code == errf.RecordNotUpdate {
+			logs.Warnf("application deliver step %s has been executed by others, id: %s, rid: %s", sourceStep,
+				applicationID, kt.Rid)
+			return false, nil
+		}
+
+		logs.Errorf("update application deliver step failed, err: %v, id: %s, rid: %s", err, applicationID, kt.Rid)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// updateDeliverDetail 更新交付中申请单的状态和交付详情，sourceFlowID不为空时只更新仍由该任务流交付的申请单，
+// 申请单已变更时返回 RecordNotUpdate 错误
+func (a *applicationSvc) updateDeliverDetail(kt *kit.Kit, applicationID, sourceFlowID string,
+	status enumor.ApplicationStatus, detail map[string]interface{}) error {
+
+	detailStr, err := json.MarshalToString(detail)
+	if err != nil {
+		logs.Errorf("marshal deliver detail failed, err: %v, detail: %+v, rid: %s", err, detail, kt.Rid)
+		detailStr = `{"error": "marshal deliver detail failed"}`
+		status = enumor.DeliverError
+	}
+
+	req := &dataproto.ApplicationUpdateReq{Status: status, DeliveryDetail: &detailStr, SourceStatus: enumor.Delivering,
+		SourceDeliverFlowID: sourceFlowID}
+	if err = a.deliverStore.UpdateApplication(kt, applicationID, req); err != nil {
+		logs.Errorf("update application deliver detail failed, err: %v, id: %s, rid: %s", err, applicationID,
+			kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,389 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/tidwall/gjson"
+
+	"hcm/cmd/cloud-server/service/application/handlers"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+type fakeDeliverStore struct {
+	apps    map[string]*dataproto.ApplicationResp
+	flows   []*ts.AddCustomFlowReq
+	reasons []string
+}
+
+func (s *fakeDeliverStore) GetApplication(_ *kit.Kit, id string) (*dataproto.ApplicationResp, error) {
+	app, exist := s.apps[id]
+	if !exist {
+		return nil, errf.Newf(errf.RecordNotFound, "application %s not found", id)
+	}
+
+	copied := *app
+	return &copied, nil
+}
+
+func (s *fakeDeliverStore) GetApplicationBySN(_ *kit.Kit, sn string) (*dataproto.ApplicationResp, error) {
+	for _, app := range s.apps {
+		if app.SN == sn {
+			copied := *app
+			return &copied, nil
+		}
+	}
+	return nil, errf.Newf(errf.RecordNotFound, "application of sn %s not found", sn)
+}
+
+func (s *fakeDeliverStore) UpdateApplication(_ *kit.Kit, id string, req *dataproto.ApplicationUpdateReq) error {
+	app := s.apps[id]
+	if len(req.SourceDeliverStep) != 0 && (app.Status != enumor.Delivering ||
+		gjson.Get(app.DeliveryDetail, deliverStepKey).String() != string(req.SourceDeliverStep)) {
+		return errf.Newf(errf.RecordNotUpdate, "application %s deliver step has been changed", id)
+	}
+	if len(req.SourceStatus) != 0 && app.Status != req.SourceStatus {
+		return errf.Newf(errf.RecordNotUpdate, "application %s status has been changed", id)
+	}
+	if len(req.SourceDeliverFlowID) != 0 &&
+		gjson.Get(app.DeliveryDetail, deliverFlowKey).String() != req.SourceDeliverFlowID {
+		return errf.Newf(errf.RecordNotUpdate, "application %s deliver flow has been changed", id)
+	}
+
+	app.Status = req.Status
+	if req.DeliveryDetail != nil {
+		app.DeliveryDetail = *req.DeliveryDetail
+	}
+	return nil
+}
+
+func (s *fakeDeliverStore) CreateDeliverFlow(_ *kit.Kit, req *ts.AddCustomFlowReq) (string, error) {
+	s.flows = append(s.flows, req)
+	return fmt.Sprintf("flow-%d", len(s.flows)), nil
+}
+
+func (s *fakeDeliverStore) ListFailedTaskReason(_ *kit.Kit, _ string) ([]string, error) {
+	return s.reasons, nil
+}
+
+type fakeDeliverHandler struct {
+	handlers.ApplicationHandler
+	checkErr     error
+	deliverErr   error
+	deliverTimes int
+}
+
+func (h *fakeDeliverHandler) PrepareReqFromContent() error {
+	return nil
+}
+
+func (h *fakeDeliverHandler) CheckReq() error {
+	return h.checkErr
+}
+
+func (h *fakeDeliverHandler) Deliver() (enumor.ApplicationStatus, map[string]interface{}, error) {
+	h.deliverTimes++
+	if h.deliverErr != nil {
+		return enumor.DeliverError, map[string]interface{}{"error": h.deliverErr.Error()}, h.deliverErr
+	}
+	return enumor.Completed, map[string]interface{}{"ids": []string{"00000001"}}, nil
+}
+
+func newTestDeliverSvc(handler *fakeDeliverHandler) (*applicationSvc, *fakeDeliverStore) {
+	store := &fakeDeliverStore{
+		apps: map[string]*dataproto.ApplicationResp{
+			"app": {ID: "app", SN: "sn", Status: enumor.Delivering, Applicant: "applicant",
+				DeliveryDetail: `{"deliver_flow_id":"flow","deliver_step":"check"}`},
+		},
+	}
+	svc := &applicationSvc{
+		deliverStore: store,
+		getDeliverHandler: func(*rest.Contexts, *dataproto.ApplicationResp) (handlers.ApplicationHandler, error) {
+			return handler, nil
+		},
+	}
+	return svc, store
+}
+
+func newTestContexts(kt *kit.Kit, params map[string]string) *rest.Contexts {
+	return newTestContextsWithBody(kt, params, "")
+}
+
+func newTestContextsWithBody(kt *kit.Kit, params map[string]string, body string) *rest.Contexts {
+	req := restful.NewRequest(&http.Request{Body: io.NopCloser(strings.NewReader(body))})
+	for k, v := range params {
+		req.PathParameters()[k] = v
+	}
+	return &rest.Contexts{Kit: kt, Request: req}
+}
+
+func executeStep(svc *applicationSvc, step enumor.ApplicationDeliverStep) error {
+	return executeFlowStep(svc, "flow", step)
+}
+
+func executeFlowStep(svc *applicationSvc, flowID string, step enumor.ApplicationDeliverStep) error {
+	cts := newTestContextsWithBody(core.NewBackendKit(), map[string]string{"application_id": "app",
+		"step": string(step)}, fmt.Sprintf(`{"flow_id":"%s"}`, flowID))
+	_, err := svc.ExecuteDeliverStep(cts)
+	return err
+}
+
+func TestExecuteDeliverStep(t *testing.T) {
+	handler := new(fakeDeliverHandler)
+	svc, store := newTestDeliverSvc(handler)
+
+	if err := executeStep(svc, enumor.CheckDeliverStep); err != nil {
+		t.Fatalf("execute check step failed, err: %v", err)
+	}
+	if step := gjson.Get(store.apps["app"].DeliveryDetail, deliverStepKey).String(); step != "deliver" {
+		t.Fatalf("deliver step should be deliver after check, but got %s", step)
+	}
+
+	if err := executeStep(svc, enumor.DeliverDeliverStep); err != nil {
+		t.Fatalf("execute deliver step failed, err: %v", err)
+	}
+	app := store.apps["app"]
+	if app.Status != enumor.Completed || handler.deliverTimes != 1 {
+		t.Fatalf("application should be delivered once, status: %s, times: %d", app.Status, handler.deliverTimes)
+	}
+	if gjson.Get(app.DeliveryDetail, deliverFlowKey).String() != "flow" {
+		t.Errorf("deliver flow id should be kept, detail: %s", app.DeliveryDetail)
+	}
+
+	// 交付结束后失败步骤不需要处理
+	if err := executeStep(svc, enumor.FailDeliverStep); err != nil {
+		t.Errorf("execute fail step after delivered should be skipped, err: %v", err)
+	}
+	if store.apps["app"].Status != enumor.Completed {
+		t.Errorf("application status should not be changed by fail step, got %s", store.apps["app"].Status)
+	}
+}
+
+func TestExecuteDeliverStepOnlyForBackend(t *testing.T) {
+	svc, _ := newTestDeliverSvc(new(fakeDeliverHandler))
+
+	kt := kit.New()
+	kt.User = "applicant"
+	kt.AppCode = "hcm-web-server"
+	cts := newTestContextsWithBody(kt, map[string]string{"application_id": "app", "step": "check"},
+		`{"flow_id":"flow"}`)
+	_, err := svc.ExecuteDeliverStep(cts)
+	if ef := errf.Error(err); ef == nil || ef.Code != errf.PermissionDenied {
+		t.Errorf("execute deliver step from web should be denied, err: %v", err)
+	}
+}
+
+func TestStaleDeliverFlow(t *testing.T) {
+	handler := new(fakeDeliverHandler)
+	svc, store := newTestDeliverSvc(handler)
+
+	err := executeFlowStep(svc, "stale", enumor.CheckDeliverStep)
+	if ef := errf.Error(err); ef == nil || ef.Code != errf.PermissionDenied {
+		t.Fatalf("stale flow should not execute deliver step, err: %v", err)
+	}
+
+	// 失败步骤读取申请单后，申请单被新的交付任务流接管
+	app, _ := store.GetApplication(nil, "app")
+	store.apps["app"].DeliveryDetail = `{"deliver_flow_id":"flow-new","deliver_step":"check"}`
+	if err = svc.failDeliver(core.NewBackendKit(), app); err != nil {
+		t.Fatalf("fail step of stale flow should be skipped, err: %v", err)
+	}
+
+	if store.apps["app"].Status != enumor.Delivering {
+		t.Errorf("stale flow should not fail application, got %s", store.apps["app"].Status)
+	}
+}
+
+func TestConcurrentDeliverOnlyOnce(t *testing.T) {
+	handler := new(fakeDeliverHandler)
+	svc, store := newTestDeliverSvc(handler)
+	store.apps["app"].DeliveryDetail = `{"deliver_flow_id":"flow","deliver_step":"deliver"}`
+
+	// 两个执行者都在交付步骤移除前读取到了申请单
+	first, _ := store.GetApplication(nil, "app")
+	second, _ := store.GetApplication(nil, "app")
+
+	cts := newTestContexts(core.NewBackendKit(), nil)
+	if err := svc.deliver(cts, first); err != nil {
+		t.Fatalf("first deliver failed, err: %v", err)
+	}
+	if err := svc.deliver(cts, second); err != nil {
+		t.Fatalf("second deliver should exit without error, err: %v", err)
+	}
+
+	if handler.deliverTimes != 1 {
+		t.Errorf("application should be delivered only once, but delivered %d times", handler.deliverTimes)
+	}
+}
+
+func TestDeliverStepFailed(t *testing.T) {
+	handler := &fakeDeliverHandler{checkErr: errors.New("vpc name duplicated")}
+	svc, store := newTestDeliverSvc(handler)
+	store.reasons = []string{"check request failed, err: vpc name duplicated"}
+
+	if err := executeStep(svc, enumor.CheckDeliverStep); err == nil {
+		t.Fatal("execute check step should be failed")
+	}
+
+	if err := executeStep(svc, enumor.FailDeliverStep); err != nil {
+		t.Fatalf("execute fail step failed, err: %v", err)
+	}
+
+	app := store.apps["app"]
+	if app.Status != enumor.DeliverError || handler.deliverTimes != 0 {
+		t.Fatalf("application should be deliver error without delivery, status: %s, times: %d", app.Status,
+			handler.deliverTimes)
+	}
+	if reason := gjson.Get(app.DeliveryDetail, "error").String(); reason != store.reasons[0] {
+		t.Errorf("deliver error should be failed task reason, got %s", reason)
+	}
+
+	// 校验步骤失败时没有执行交付，不需要确认即可重试
+	kt := core.NewBackendKit()
+	kt.User = "applicant"
+	params := map[string]string{"application_id": "app"}
+	if _, err := svc.RetryDeliver(newTestContextsWithBody(kt, params, "{}")); err != nil {
+		t.Fatalf("retry application failed before delivery failed, err: %v", err)
+	}
+}
+
+func TestDeliverInterrupted(t *testing.T) {
+	handler := new(fakeDeliverHandler)
+	svc, store := newTestDeliverSvc(handler)
+	// 交付步骤已经移除，交付执行过程中中断
+	store.apps["app"].DeliveryDetail = `{"deliver_flow_id":"flow"}`
+
+	if err := executeStep(svc, enumor.DeliverDeliverStep); err == nil {
+		t.Fatal("interrupted delivery should not be delivered again")
+	}
+	if err := executeStep(svc, enumor.FailDeliverStep); err != nil {
+		t.Fatalf("execute fail step failed, err: %v", err)
+	}
+
+	app := store.apps["app"]
+	if app.Status != enumor.DeliverError || handler.deliverTimes != 0 {
+		t.Fatalf("interrupted application should be deliver error, status: %s, times: %d", app.Status,
+			handler.deliverTimes)
+	}
+	if !gjson.Get(app.DeliveryDetail, "error").Exists() {
+		t.Errorf("interrupted reason should be recorded, detail: %s", app.DeliveryDetail)
+	}
+
+	// 交付中断的申请单可能已经创建了部分资源，需要申请人确认后才能重试
+	kt := core.NewBackendKit()
+	kt.User = "applicant"
+	params := map[string]string{"application_id": "app"}
+	if _, err := svc.RetryDeliver(newTestContextsWithBody(kt, params, "{}")); err == nil {
+		t.Fatal("interrupted application should not be retried without confirm")
+	}
+	if len(store.flows) != 0 {
+		t.Fatalf("retry without confirm should not create deliver flow, flows: %d", len(store.flows))
+	}
+
+	if _, err := svc.RetryDeliver(newTestContextsWithBody(kt, params, `{"confirm":true}`)); err != nil {
+		t.Fatalf("retry confirmed application failed, err: %v", err)
+	}
+	if len(store.flows) != 1 || store.apps["app"].Status != enumor.Delivering {
+		t.Errorf("confirmed retry should create deliver flow, flows: %d, status: %s", len(store.flows),
+			store.apps["app"].Status)
+	}
+}
+
+func TestRetryDeliver(t *testing.T) {
+	svc, store := newTestDeliverSvc(new(fakeDeliverHandler))
+	params := map[string]string{"application_id": "app"}
+
+	kt := core.NewBackendKit()
+	kt.User = "applicant"
+	if _, err := svc.RetryDeliver(newTestContextsWithBody(kt, params, "{}")); err == nil {
+		t.Fatal("delivering application should not be retried")
+	}
+
+	store.apps["app"].Status = enumor.DeliverError
+	other := core.NewBackendKit()
+	other.User = "other"
+	_, err := svc.RetryDeliver(newTestContextsWithBody(other, params, "{}"))
+	if ef := errf.Error(err); ef == nil || ef.Code != errf.PermissionDenied {
+		t.Fatalf("retry other's application should be denied, err: %v", err)
+	}
+
+	if _, err = svc.RetryDeliver(newTestContextsWithBody(kt, params, "{}")); err != nil {
+		t.Fatalf("retry deliver failed, err: %v", err)
+	}
+
+	app := store.apps["app"]
+	if len(store.flows) != 1 || app.Status != enumor.Delivering {
+		t.Fatalf("retry should create deliver flow, flows: %d, status: %s", len(store.flows), app.Status)
+	}
+	if step := gjson.Get(app.DeliveryDetail, deliverStepKey).String(); step != string(enumor.CheckDeliverStep) {
+		t.Errorf("retry should restart from check step, got %s", step)
+	}
+	if flowID := gjson.Get(app.DeliveryDetail, deliverFlowKey).String(); flowID != "flow-1" {
+		t.Errorf("retry should record new deliver flow, got %s", flowID)
+	}
+}
+
+func TestConcurrentRetryDeliver(t *testing.T) {
+	svc, store := newTestDeliverSvc(new(fakeDeliverHandler))
+	store.apps["app"].Status = enumor.DeliverError
+	params := map[string]string{"application_id": "app"}
+
+	kt := core.NewBackendKit()
+	kt.User = "applicant"
+	if _, err := svc.RetryDeliver(newTestContextsWithBody(kt, params, "{}")); err != nil {
+		t.Fatalf("retry deliver failed, err: %v", err)
+	}
+
+	// 并发重试的请求在申请单变更为交付中之前读取到了交付异常的申请单
+	app := *store.apps["app"]
+	app.Status = enumor.DeliverError
+	svc.deliverStore = &staleReadDeliverStore{fakeDeliverStore: store, app: &app}
+	if _, err := svc.RetryDeliver(newTestContextsWithBody(kt, params, "{}")); err == nil {
+		t.Fatal("concurrent retry should be rejected")
+	}
+
+	if len(store.flows) != 1 {
+		t.Errorf("concurrent retry should create only one deliver flow, but created %d", len(store.flows))
+	}
+}
+
+// staleReadDeliverStore 读取到的是并发修改前的申请单
+type staleReadDeliverStore struct {
+	*fakeDeliverStore
+	app *dataproto.ApplicationResp
+}
+
+func (s *staleReadDeliverStore) GetApplication(_ *kit.Kit, _ string) (*dataproto.ApplicationResp, error) {
+	copied := *s.app
+	return &copied, nil
+}
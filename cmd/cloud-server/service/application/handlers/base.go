@@ -22,14 +22,17 @@ package handlers
 import (
 	"fmt"
 
+	"hcm/cmd/cloud-server/logics"
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/pkg/api/core"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/cryptography"
+	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	itsm2 "hcm/pkg/thirdparty/api-gateway/itsm"
 	"hcm/pkg/thirdparty/esb"
+	"hcm/pkg/tools/slice"
 )
 
 // HandlerOption 这里是为了方便调用传参构造Handler,避免参数太多
@@ -40,18 +43,23 @@ type HandlerOption struct {
 	Cipher    cryptography.Crypto
 	Audit     audit.Interface
 	ItsmCli   itsm2.Client
+	Logics    *logics.Logics
+	// ApplicationID 交付时为所交付的申请单ID，创建申请单时为空
+	ApplicationID string
 }
 
 // BaseApplicationHandler 基础的Handler 一些公共函数和属性处理，可以给到其他具体Handler组合
 type BaseApplicationHandler struct {
 	applicationType enumor.ApplicationType
 	vendor          enumor.Vendor
+	applicationID   string
 
 	Cts       *rest.Contexts
 	Client    *client.ClientSet
 	EsbClient esb.Client
 	Cipher    cryptography.Crypto
 	Audit     audit.Interface
+	Logics    *logics.Logics
 }
 
 // NewBaseApplicationHandler ...
@@ -61,11 +69,13 @@ func NewBaseApplicationHandler(
 	return BaseApplicationHandler{
 		applicationType: applicationType,
 		vendor:          vendor,
+		applicationID:   opt.ApplicationID,
 		Cts:             opt.Cts,
 		Client:          opt.Client,
 		EsbClient:       opt.EsbClient,
 		Cipher:          opt.Cipher,
 		Audit:           opt.Audit,
+		Logics:          opt.Logics,
 	}
 }
 
@@ -79,6 +89,11 @@ func (a *BaseApplicationHandler) Vendor() enumor.Vendor {
 	return a.vendor
 }
 
+// ApplicationID 申请单ID，仅交付时有值
+func (a *BaseApplicationHandler) ApplicationID() string {
+	return a.applicationID
+}
+
 // ConvertMemoryMBToGB 将内存的MB转换为可用于展示的GB, 特殊展示，不适合其他通用的转换
 func (a *BaseApplicationHandler) ConvertMemoryMBToGB(m int64) string {
 	if m%1024 == 0 {
@@ -96,6 +111,14 @@ func (a *BaseApplicationHandler) getPageOfOneLimit() *core.BasePage {
 func (a *BaseApplicationHandler) GetItsmPlatformAndAccountApprover(managers []string,
 	accountID string) []itsm2.VariableApprover {
 
+	return a.GetItsmPlatformAndAccountsApprover(managers, []string{accountID})
+}
+
+// GetItsmPlatformAndAccountsApprover get itsm platform approver and account approver, account approver is the
+// deduplicated managers of all accounts, used when the resources of an application belong to multiple accounts.
+func (a *BaseApplicationHandler) GetItsmPlatformAndAccountsApprover(managers []string,
+	accountIDs []string) []itsm2.VariableApprover {
+
 	allManagers := []itsm2.VariableApprover{
 		{
 			Variable:  "platform_manager",
@@ -103,14 +126,19 @@ func (a *BaseApplicationHandler) GetItsmPlatformAndAccountApprover(managers []st
 		},
 	}
 
-	accountData, err := a.GetAccount(accountID)
-	if err != nil {
-		return allManagers
+	accountManagers := make([]string, 0)
+	for _, accountID := range slice.Unique(accountIDs) {
+		accountData, err := a.GetAccount(accountID)
+		if err != nil {
+			logs.Errorf("get account failed, err: %v, id: %s, rid: %s", err, accountID, a.Cts.Kit.Rid)
+			return allManagers
+		}
+		accountManagers = append(accountManagers, accountData.Managers...)
 	}
 
 	allManagers = append(allManagers, itsm2.VariableApprover{
 		Variable:  "account_manager",
-		Approvers: accountData.Managers,
+		Approvers: slice.Unique(accountManagers),
 	})
 
 	return allManagers
@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package handlers
+
+import (
+	"fmt"
+
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/types"
+)
+
+// ListBizResBasicInfo 查询资源基础信息，并校验资源都存在、属于该业务且不在回收站中
+func (a *BaseApplicationHandler) ListBizResBasicInfo(resType enumor.CloudResourceType, bizID int64, ids []string,
+	fields ...string) (map[string]types.CloudResourceBasicInfo, error) {
+
+	req := dataproto.ListResourceBasicInfoReq{
+		ResourceType: resType,
+		IDs:          ids,
+		Fields:       append(types.CommonBasicInfoFields, fields...),
+	}
+	infoMap, err := a.Client.DataService().Global.Cloud.ListResBasicInfo(a.Cts.Kit, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		info, exists := infoMap[id]
+		if !exists {
+			return nil, fmt.Errorf("%s: %s not found", resType, id)
+		}
+
+		if info.BkBizID != bizID {
+			return nil, fmt.Errorf("%s: %s not belongs to biz: %d", resType, id, bizID)
+		}
+
+		if info.RecycleStatus == enumor.RecycleStatus {
+			return nil, fmt.Errorf("%s: %s is in recycle bin", resType, id)
+		}
+	}
+
+	return infoMap, nil
+}
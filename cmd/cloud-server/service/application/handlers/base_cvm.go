@@ -23,6 +23,7 @@ import (
 	"hcm/pkg/api/core"
 	corecvm "hcm/pkg/api/core/cloud/cvm"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/runtime/filter"
 )
 
@@ -52,3 +53,19 @@ func (a *BaseApplicationHandler) ListCvm(
 
 	return resp.Details, nil
 }
+
+// ListCvmByIDs 根据主机ID查询主机列表
+func (a *BaseApplicationHandler) ListCvmByIDs(ids []string) ([]corecvm.BaseCvm, error) {
+	resp, err := a.Client.DataService().Global.Cvm.ListCvm(
+		a.Cts.Kit,
+		&core.ListReq{
+			Filter: tools.ContainersExpression("id", ids),
+			Page:   &core.BasePage{Count: false, Start: 0, Limit: uint(len(ids))},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Details, nil
+}
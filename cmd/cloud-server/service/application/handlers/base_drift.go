@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package handlers
+
+import (
+	logicsdrift "hcm/cmd/cloud-server/logics/drift"
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/logs"
+)
+
+// RecordDesiredState 保存交付资源的期望状态，用于后续的配置漂移检测。
+// 资源已交付成功，保存失败只记录日志，不影响交付结果。
+func (a *BaseApplicationHandler) RecordDesiredState(resType enumor.CloudResourceType, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	err := logicsdrift.Snapshot(a.Cts.Kit, a.Client.DataService(), a.applicationID, resType, ids)
+	if err != nil {
+		logs.Errorf("record %s desired state failed, err: %v, ids: %v, application: %s, rid: %s", resType, err,
+			ids, a.applicationID, a.Cts.Kit.Rid)
+	}
+}
+
+// RecordVpcDesiredState 保存交付的vpc及其子网的期望状态
+func (a *BaseApplicationHandler) RecordVpcDesiredState(vpcID string, subnets []corecloud.BaseSubnet) {
+	a.RecordDesiredState(enumor.VpcCloudResType, []string{vpcID})
+
+	subnetIDs := make([]string, 0, len(subnets))
+	for _, one := range subnets {
+		subnetIDs = append(subnetIDs, one.ID)
+	}
+	a.RecordDesiredState(enumor.SubnetCloudResType, subnetIDs)
+}
@@ -22,8 +22,10 @@ package handlers
 import (
 	"fmt"
 
+	logicsipam "hcm/cmd/cloud-server/logics/ipam"
 	"hcm/pkg/api/core"
 	corecloud "hcm/pkg/api/core/cloud"
+	coreipam "hcm/pkg/api/core/ipam"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/runtime/filter"
@@ -101,3 +103,14 @@ func (a *BaseApplicationHandler) GetGcpVpcWithExt(vpcID string) (*corecloud.Vpc[
 	}
 	return &resp.Details[0], nil
 }
+
+// AllocateVpcCidr 申请时未指定vpc网段，从匹配业务、地域的IPAM地址池中分配不与已同步vpc重叠的vpc网段及其第一个子网网段
+func (a *BaseApplicationHandler) AllocateVpcCidr(bizID int64, region string) (*coreipam.AllocateResult, error) {
+	opt := &logicsipam.AllocateOption{BkBizID: bizID, Region: region}
+	result, err := logicsipam.Allocate(a.Cts.Kit, a.Client.DataService(), opt)
+	if err != nil {
+		return nil, fmt.Errorf("allocate vpc cidr from ipam pool failed, err: %v", err)
+	}
+
+	return result, nil
+}
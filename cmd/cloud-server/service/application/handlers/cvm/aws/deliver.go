@@ -29,6 +29,7 @@ import (
 	"hcm/pkg/async/action"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/logs"
 )
 
@@ -46,6 +47,7 @@ func (a *ApplicationOfCreateAwsCvm) Deliver() (enumor.ApplicationStatus, map[str
 					Vendor:            enumor.Aws,
 					AwsBatchCreateReq: *req,
 				},
+				RateLimitKey: tableasync.NewRateLimitKey(enumor.Aws, req.AccountID, req.Region),
 			}
 		})
 	addReq := &ts.AddCustomFlowReq{
@@ -29,6 +29,7 @@ import (
 	ts "hcm/pkg/api/task-server"
 	"hcm/pkg/async/action"
 	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/logs"
 )
 
@@ -45,6 +46,7 @@ func (a *ApplicationOfCreateAzureCvm) Deliver() (enumor.ApplicationStatus, map[s
 					Vendor:         enumor.Azure,
 					AzureCreateReq: *req,
 				},
+				RateLimitKey: tableasync.NewRateLimitKey(enumor.Azure, req.AccountID, req.Region),
 			}
 		})
 
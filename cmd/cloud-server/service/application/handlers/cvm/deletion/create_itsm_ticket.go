@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package deletion
+
+import (
+	"fmt"
+	"strings"
+)
+
+type formItem struct {
+	Label string
+	Value string
+}
+
+// RenderItsmTitle 渲染ITSM单据标题
+func (a *ApplicationOfDeleteCvm) RenderItsmTitle() (string, error) {
+	return fmt.Sprintf("申请删除主机(%d台)", len(a.req.IDs)), nil
+}
+
+// RenderItsmForm 渲染ITSM表单
+func (a *ApplicationOfDeleteCvm) RenderItsmForm() (string, error) {
+	formItems := make([]formItem, 0)
+
+	// 业务
+	bizName, err := a.GetBizName(a.req.BkBizID)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "业务", Value: bizName})
+
+	// 删除的主机
+	cvms, err := a.ListCvmByIDs(a.req.IDs)
+	if err != nil {
+		return "", err
+	}
+	for _, one := range cvms {
+		formItems = append(formItems, formItem{Label: "删除主机",
+			Value: fmt.Sprintf("%s(%s, %s)", one.Name, one.CloudID, strings.Join(one.PrivateIPv4Addresses, ","))})
+	}
+
+	// 转换为ITSM表单内容数据
+	content := make([]string, 0, len(formItems))
+	for _, i := range formItems {
+		content = append(content, fmt.Sprintf("%s: %s", i.Label, i.Value))
+	}
+	return strings.Join(content, "\n"), nil
+}
@@ -0,0 +1,43 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package deletion
+
+import (
+	"hcm/cmd/cloud-server/service/application/handlers"
+	proto "hcm/pkg/api/cloud-server/application"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/types"
+)
+
+// ApplicationOfDeleteCvm 删除主机申请，不区分云厂商
+type ApplicationOfDeleteCvm struct {
+	handlers.BaseApplicationHandler
+
+	req          *proto.CvmDeleteReq
+	basicInfoMap map[string]types.CloudResourceBasicInfo
+}
+
+// NewApplicationOfDeleteCvm ...
+func NewApplicationOfDeleteCvm(opt *handlers.HandlerOption, req *proto.CvmDeleteReq) *ApplicationOfDeleteCvm {
+	return &ApplicationOfDeleteCvm{
+		BaseApplicationHandler: handlers.NewBaseApplicationHandler(opt, enumor.DeleteCvm, ""),
+		req:                    req,
+	}
+}
@@ -19,7 +19,10 @@
 
 package gcp
 
-import logicsaccount "hcm/cmd/cloud-server/logics/account"
+import (
+	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	logicskeypair "hcm/cmd/cloud-server/logics/key-pair"
+)
 
 // CheckReq 检查申请单的数据是否正确
 func (a *ApplicationOfCreateGcpCvm) CheckReq() error {
@@ -31,5 +34,19 @@ func (a *ApplicationOfCreateGcpCvm) CheckReq() error {
 		return err
 	}
 
+	// 选择的密钥对需要与主机属于相同的账号、地域和业务
+	if len(a.req.KeyPairID) != 0 {
+		opt := &logicskeypair.CvmKeyPairOption{
+			KeyPairID: a.req.KeyPairID,
+			Vendor:    a.Vendor(),
+			AccountID: a.req.AccountID,
+			Region:    a.req.Region,
+			BkBizID:   a.req.BkBizID,
+		}
+		if _, err := logicskeypair.GetForCvmCreate(a.Cts.Kit, a.Client.DataService(), opt); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
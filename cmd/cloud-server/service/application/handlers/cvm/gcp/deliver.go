@@ -30,6 +30,7 @@ import (
 	"hcm/pkg/async/action"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/logs"
 )
 
@@ -47,6 +48,7 @@ func (a *ApplicationOfCreateGcpCvm) Deliver() (enumor.ApplicationStatus, map[str
 					Vendor:            enumor.Gcp,
 					GcpBatchCreateReq: *req,
 				},
+				RateLimitKey: tableasync.NewRateLimitKey(enumor.Gcp, req.AccountID, req.Region),
 			}
 		})
 	addReq := &ts.AddCustomFlowReq{
@@ -30,6 +30,7 @@ import (
 	"hcm/pkg/async/action"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/logs"
 )
 
@@ -48,6 +49,7 @@ func (a *ApplicationOfCreateHuaWeiCvm) Deliver() (enumor.ApplicationStatus, map[
 					Vendor:               enumor.HuaWei,
 					HuaWeiBatchCreateReq: *req,
 				},
+				RateLimitKey: tableasync.NewRateLimitKey(enumor.HuaWei, req.AccountID, req.Region),
 			}
 		})
 	addReq := &ts.AddCustomFlowReq{
@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recycle
+
+import (
+	"fmt"
+	"strings"
+
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+)
+
+type formItem struct {
+	Label string
+	Value string
+}
+
+// RenderItsmTitle 渲染ITSM单据标题
+func (a *ApplicationOfRecycleCvm) RenderItsmTitle() (string, error) {
+	return fmt.Sprintf("申请回收主机(%d台)", len(a.req.Infos)), nil
+}
+
+// RenderItsmForm 渲染ITSM表单
+func (a *ApplicationOfRecycleCvm) RenderItsmForm() (string, error) {
+	formItems := make([]formItem, 0)
+
+	// 业务
+	bizName, err := a.GetBizName(a.req.BkBizID)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "业务", Value: bizName})
+
+	// 回收的主机及随主机回收的关联资源
+	cvms, err := a.ListCvmByIDs(a.req.CvmIDs())
+	if err != nil {
+		return "", err
+	}
+	cvmMap := make(map[string]corecvm.BaseCvm, len(cvms))
+	for _, one := range cvms {
+		cvmMap[one.ID] = one
+	}
+	for _, info := range a.req.Infos {
+		one := cvmMap[info.ID]
+		withRes := make([]string, 0)
+		if info.WithDisk {
+			withRes = append(withRes, "云硬盘")
+		}
+		if info.WithEip {
+			withRes = append(withRes, "弹性IP")
+		}
+		if len(withRes) == 0 {
+			withRes = append(withRes, "无")
+		}
+		formItems = append(formItems, formItem{Label: "回收主机",
+			Value: fmt.Sprintf("%s(%s, %s), 随主机回收: %s", one.Name, one.CloudID,
+				strings.Join(one.PrivateIPv4Addresses, ","), strings.Join(withRes, ","))})
+	}
+
+	// 转换为ITSM表单内容数据
+	content := make([]string, 0, len(formItems))
+	for _, i := range formItems {
+		content = append(content, fmt.Sprintf("%s: %s", i.Label, i.Value))
+	}
+	return strings.Join(content, "\n"), nil
+}
@@ -0,0 +1,40 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recycle
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/logs"
+)
+
+// Deliver 执行主机回收，交付前再次校验主机归属，回收预检由回收逻辑完成
+func (a *ApplicationOfRecycleCvm) Deliver() (enumor.ApplicationStatus, map[string]interface{}, error) {
+	if err := a.CheckReq(); err != nil {
+		return enumor.DeliverError, map[string]interface{}{"error": err.Error()}, err
+	}
+
+	taskID, err := a.Logics.Cvm.RecycleCvm(a.Cts.Kit, a.req.Infos, a.basicInfoMap)
+	if err != nil {
+		logs.Errorf("recycle cvm failed, err: %v, ids: %v, rid: %s", err, a.req.CvmIDs(), a.Cts.Kit.Rid)
+		return enumor.DeliverError, map[string]interface{}{"error": err.Error()}, err
+	}
+
+	return enumor.Completed, map[string]interface{}{"task_id": taskID}, nil
+}
@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recycle
+
+import (
+	"sort"
+
+	"hcm/pkg/thirdparty/api-gateway/itsm"
+)
+
+// PrepareReq ...
+func (a *ApplicationOfRecycleCvm) PrepareReq() error {
+	return nil
+}
+
+// GenerateApplicationContent 获取预处理过的数据，以interface格式
+func (a *ApplicationOfRecycleCvm) GenerateApplicationContent() interface{} {
+	return a.req
+}
+
+// PrepareReqFromContent ...
+func (a *ApplicationOfRecycleCvm) PrepareReqFromContent() error {
+	return nil
+}
+
+// GetItsmApprover 获取itsm审批人，主机分属多个账号时由所有账号的负责人(去重)审批
+func (a *ApplicationOfRecycleCvm) GetItsmApprover(managers []string) []itsm.VariableApprover {
+	if len(a.basicInfoMap) == 0 {
+		return []itsm.VariableApprover{{Variable: "platform_manager", Approvers: managers}}
+	}
+
+	accountIDs := make([]string, 0, len(a.basicInfoMap))
+	for _, info := range a.basicInfoMap {
+		accountIDs = append(accountIDs, info.AccountID)
+	}
+	sort.Strings(accountIDs)
+
+	return a.GetItsmPlatformAndAccountsApprover(managers, accountIDs)
+}
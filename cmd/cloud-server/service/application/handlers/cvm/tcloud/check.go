@@ -23,6 +23,7 @@ import (
 	"errors"
 
 	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	logicskeypair "hcm/cmd/cloud-server/logics/key-pair"
 )
 
 // CheckReq 检查申请单的数据是否正确
@@ -35,6 +36,20 @@ func (a *ApplicationOfCreateTCloudCvm) CheckReq() error {
 		return err
 	}
 
+	// 选择的密钥对需要与主机属于相同的账号、地域和业务
+	if len(a.req.KeyPairID) != 0 {
+		opt := &logicskeypair.CvmKeyPairOption{
+			KeyPairID: a.req.KeyPairID,
+			Vendor:    a.Vendor(),
+			AccountID: a.req.AccountID,
+			Region:    a.req.Region,
+			BkBizID:   a.req.BkBizID,
+		}
+		if _, err := logicskeypair.GetForCvmCreate(a.Cts.Kit, a.Client.DataService(), opt); err != nil {
+			return err
+		}
+	}
+
 	// TCloud 支持 DryRun，可预校验
 	result, err := a.Client.HCService().TCloud.Cvm.BatchCreateCvm(a.Cts.Kit, a.toHcProtoTCloudBatchCreateReq(true))
 	if err != nil {
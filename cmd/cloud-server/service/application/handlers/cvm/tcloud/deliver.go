@@ -29,6 +29,7 @@ import (
 	"hcm/pkg/async/action"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/logs"
 )
 
@@ -46,6 +47,7 @@ func (a *ApplicationOfCreateTCloudCvm) Deliver() (enumor.ApplicationStatus, map[
 					Vendor:               enumor.TCloud,
 					TCloudBatchCreateReq: *req,
 				},
+				RateLimitKey: tableasync.NewRateLimitKey(enumor.TCloud, req.AccountID, req.Region),
 			}
 		})
 
@@ -25,7 +25,10 @@ import (
 
 	"github.com/tidwall/gjson"
 
+	logicsdrift "hcm/cmd/cloud-server/logics/drift"
+	logicslease "hcm/cmd/cloud-server/logics/lease"
 	actioncvm "hcm/cmd/task-server/logics/action/cvm"
+	cslease "hcm/pkg/api/cloud-server/lease"
 	"hcm/pkg/api/core"
 	coreasync "hcm/pkg/api/core/async"
 	ds "hcm/pkg/api/data-service"
@@ -107,6 +110,10 @@ func handleDeliverCvm(kt *kit.Kit, dsCli *dataservice.Client, tsCli *taskserver.
 	detail := map[string]interface{}{
 		"result": result,
 	}
+	// 保留申请单交付任务流ID，便于查看交付步骤
+	if deliverFlowID := gjson.Get(app.DeliveryDetail, "deliver_flow_id"); deliverFlowID.Exists() {
+		detail["deliver_flow_id"] = deliverFlowID.String()
+	}
 	if len(result.SuccessCloudIDs) != 0 {
 		req := &core.ListReq{
 			Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{
@@ -133,6 +140,27 @@ func handleDeliverCvm(kt *kit.Kit, dsCli *dataservice.Client, tsCli *taskserver.
 		}
 
 		detail["cvm_ids"] = assignResult.IDs
+		// 按照申请时设置的租约为主机创建租约，失败时下一轮重试
+		leaseOpt := new(cslease.LeaseOption)
+		if err = json.UnmarshalFromString(app.Content, leaseOpt); err != nil {
+			logs.Errorf("unmarshal application lease option failed, err: %v, application: %s, rid: %s", err, app.ID,
+				kt.Rid)
+			return err
+		}
+		err = logicslease.CreateForDelivery(kt, dsCli, *leaseOpt, app.Applicant, enumor.CvmCloudResType,
+			assignResult.IDs)
+		if err != nil {
+			logs.Errorf("create cvm lease failed, err: %v, ids: %v, application: %s, rid: %s", err, assignResult.IDs,
+				app.ID, kt.Rid)
+			return err
+		}
+
+		// 保存主机的期望状态，用于配置漂移检测，失败不影响交付结果
+		if err = logicsdrift.Snapshot(kt, dsCli, app.ID, enumor.CvmCloudResType, assignResult.IDs); err != nil {
+			logs.Errorf("record cvm desired state failed, err: %v, ids: %v, application: %s, rid: %s", err,
+				assignResult.IDs, app.ID, kt.Rid)
+		}
+
 		requiredCount := gjson.Get(app.Content, "required_count").Int()
 		if len(result.SuccessCloudIDs) != int(requiredCount) {
 			state = enumor.DeliverPartial
@@ -34,5 +34,5 @@ func (a *ApplicationOfCreateHuaWeiDisk) Deliver() (enumor.ApplicationStatus, map
 	}
 
 	return logics.CheckResultAndAssign(a.Cts.Kit, a.Client.DataService(), result, uint32(a.req.DiskCount),
-		a.req.BkBizID, a.Audit)
+		a.req.BkBizID, a.req.LeaseOption, a.Audit)
 }
@@ -23,6 +23,8 @@ import (
 	"fmt"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	logicslease "hcm/cmd/cloud-server/logics/lease"
+	cslease "hcm/pkg/api/cloud-server/lease"
 	"hcm/pkg/api/core"
 	dataproto "hcm/pkg/api/data-service/cloud/disk"
 	hcproto "hcm/pkg/api/hc-service/disk"
@@ -33,9 +35,9 @@ import (
 	"hcm/pkg/logs"
 )
 
-// CheckResultAndAssign ...
+// CheckResultAndAssign 检查创建结果，将创建成功的硬盘分配给业务并按照申请时设置的租约创建租约
 func CheckResultAndAssign(kt *kit.Kit, cli *dataservice.Client, result *hcproto.BatchCreateResult,
-	diskCount uint32, bkBizID int64,
+	diskCount uint32, bkBizID int64, leaseOpt cslease.LeaseOption,
 	audit audit.Interface) (enumor.ApplicationStatus, map[string]interface{}, error) {
 
 	deliverDetail := map[string]interface{}{"result": result}
@@ -81,6 +83,12 @@ func CheckResultAndAssign(kt *kit.Kit, cli *dataservice.Client, result *hcproto.
 		return enumor.DeliverError, deliverDetail, err
 	}
 
+	err = logicslease.CreateForDelivery(kt, cli, leaseOpt, kt.User, enumor.DiskCloudResType, ids)
+	if err != nil {
+		deliverDetail["error"] = err.Error()
+		return enumor.DeliverError, deliverDetail, err
+	}
+
 	deliverDetail["disk_ids"] = ids
 	status := enumor.Completed
 	// 部分成功
@@ -0,0 +1,62 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package associate
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// CheckReq 检查弹性IP及待绑定的主机或网络接口都属于申请的业务
+func (a *ApplicationOfAssociateEip) CheckReq() error {
+	if err := a.req.Validate(); err != nil {
+		return err
+	}
+
+	if len(a.req.CvmID) == 0 && len(a.req.NetworkInterfaceID) == 0 {
+		return errors.New("cvm_id or network_interface_id is required")
+	}
+
+	eipInfoMap, err := a.ListBizResBasicInfo(enumor.EipCloudResType, a.req.BkBizID, []string{a.req.EipID},
+		"recycle_status")
+	if err != nil {
+		return err
+	}
+	a.eipInfo = eipInfoMap[a.req.EipID]
+
+	if len(a.req.CvmID) != 0 {
+		_, err = a.ListBizResBasicInfo(enumor.CvmCloudResType, a.req.BkBizID, []string{a.req.CvmID},
+			"recycle_status")
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(a.req.NetworkInterfaceID) != 0 {
+		_, err = a.ListBizResBasicInfo(enumor.NetworkInterfaceCloudResType, a.req.BkBizID,
+			[]string{a.req.NetworkInterfaceID}, "recycle_status")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package associate
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/cmd/cloud-server/service/application/handlers"
+	"hcm/pkg/api/core"
+	"hcm/pkg/dal/dao/tools"
+)
+
+type formItem struct {
+	Label string
+	Value string
+}
+
+// RenderItsmTitle 渲染ITSM单据标题
+func (a *ApplicationOfAssociateEip) RenderItsmTitle() (string, error) {
+	return fmt.Sprintf("申请绑定[%s]弹性IP", handlers.VendorNameMap[a.eipInfo.Vendor]), nil
+}
+
+// RenderItsmForm 渲染ITSM表单
+func (a *ApplicationOfAssociateEip) RenderItsmForm() (string, error) {
+	formItems := make([]formItem, 0)
+
+	// 业务
+	bizName, err := a.GetBizName(a.req.BkBizID)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "业务", Value: bizName})
+
+	// 云账号
+	accountInfo, err := a.GetAccount(a.eipInfo.AccountID)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "云账号", Value: accountInfo.Name})
+	formItems = append(formItems, formItem{Label: "云厂商", Value: handlers.VendorNameMap[a.eipInfo.Vendor]})
+
+	// 弹性IP
+	eipResp, err := a.Client.DataService().Global.ListEip(a.Cts.Kit, &core.ListReq{
+		Filter: tools.EqualExpression("id", a.req.EipID),
+		Page:   core.NewDefaultBasePage(),
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(eipResp.Details) == 0 {
+		return "", fmt.Errorf("eip: %s not found", a.req.EipID)
+	}
+	eip := eipResp.Details[0]
+	formItems = append(formItems, formItem{Label: "弹性IP", Value: fmt.Sprintf("%s(%s)", eip.PublicIp, eip.CloudID)})
+
+	// 绑定的主机或网络接口
+	if len(a.req.CvmID) != 0 {
+		cvms, err := a.ListCvmByIDs([]string{a.req.CvmID})
+		if err != nil {
+			return "", err
+		}
+		for _, one := range cvms {
+			formItems = append(formItems, formItem{Label: "绑定主机",
+				Value: fmt.Sprintf("%s(%s, %s)", one.Name, one.CloudID, strings.Join(one.PrivateIPv4Addresses, ","))})
+		}
+	}
+	if len(a.req.NetworkInterfaceID) != 0 {
+		formItems = append(formItems, formItem{Label: "绑定网络接口", Value: a.req.NetworkInterfaceID})
+	}
+
+	// 转换为ITSM表单内容数据
+	content := make([]string, 0, len(formItems))
+	for _, i := range formItems {
+		content = append(content, fmt.Sprintf("%s: %s", i.Label, i.Value))
+	}
+	return strings.Join(content, "\n"), nil
+}
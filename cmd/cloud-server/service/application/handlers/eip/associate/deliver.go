@@ -0,0 +1,41 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package associate
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/logs"
+)
+
+// Deliver 执行弹性IP绑定，交付前再次校验资源归属
+func (a *ApplicationOfAssociateEip) Deliver() (enumor.ApplicationStatus, map[string]interface{}, error) {
+	if err := a.CheckReq(); err != nil {
+		return enumor.DeliverError, map[string]interface{}{"error": err.Error()}, err
+	}
+
+	err := a.Logics.Eip.AssociateEip(a.Cts.Kit, a.eipInfo.Vendor, a.req.EipID, a.req.CvmID,
+		a.req.NetworkInterfaceID, a.eipInfo.AccountID)
+	if err != nil {
+		logs.Errorf("associate eip failed, err: %v, eip: %s, rid: %s", err, a.req.EipID, a.Cts.Kit.Rid)
+		return enumor.DeliverError, map[string]interface{}{"error": err.Error()}, err
+	}
+
+	return enumor.Completed, map[string]interface{}{"eip_id": a.req.EipID}, nil
+}
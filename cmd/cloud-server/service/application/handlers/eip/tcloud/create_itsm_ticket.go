@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hcm/cmd/cloud-server/service/application/handlers"
+)
+
+type formItem struct {
+	Label string
+	Value string
+}
+
+// RenderItsmTitle 渲染ITSM单据标题
+func (a *ApplicationOfCreateTCloudEip) RenderItsmTitle() (string, error) {
+	return fmt.Sprintf("申请新增[%s]弹性IP(%d个)", handlers.VendorNameMap[a.Vendor()], a.req.EipCount), nil
+}
+
+// RenderItsmForm 渲染ITSM表单
+func (a *ApplicationOfCreateTCloudEip) RenderItsmForm() (string, error) {
+	req := a.req
+	formItems := make([]formItem, 0)
+
+	// 业务
+	bizName, err := a.GetBizName(req.BkBizID)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "业务", Value: bizName})
+
+	// 云账号
+	accountInfo, err := a.GetAccount(req.AccountID)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "云账号", Value: accountInfo.Name})
+
+	// 云厂商
+	formItems = append(formItems, formItem{Label: "云厂商", Value: handlers.VendorNameMap[a.Vendor()]})
+
+	// 云地域
+	regionInfo, err := a.GetTCloudRegion(req.Region)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "云地域", Value: regionInfo.RegionName})
+
+	if req.EipName != nil && *req.EipName != "" {
+		formItems = append(formItems, formItem{Label: "名称", Value: *req.EipName})
+	}
+	formItems = append(formItems, []formItem{
+		{Label: "线路类型", Value: req.ServiceProvider},
+		{Label: "IP类型", Value: req.AddressType},
+		{Label: "购买数量", Value: strconv.FormatInt(req.EipCount, 10)},
+	}...)
+
+	// 转换为ITSM表单内容数据
+	content := make([]string, 0, len(formItems))
+	for _, i := range formItems {
+		content = append(content, fmt.Sprintf("%s: %s", i.Label, i.Value))
+	}
+	return strings.Join(content, "\n"), nil
+}
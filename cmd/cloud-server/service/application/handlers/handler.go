@@ -31,6 +31,8 @@ import (
 // 更好的方式是Handler拆分成两种抽象：申请单创建者Creator、申请单交付者Deliverer，然后定义各自的数据结构
 type ApplicationHandler interface {
 	GetType() enumor.ApplicationType
+	// Vendor 申请单涉及的云厂商，不区分云厂商的申请单为空
+	Vendor() enumor.Vendor
 
 	// GetItsmApprover 获取itsm审批人信息
 	GetItsmApprover(managers []string) []itsm.VariableApprover
@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+)
+
+// CheckReq 检查安全组属于申请的业务，且修改和删除的规则都属于该安全组
+func (a *ApplicationOfChangeTCloudSGRule) CheckReq() error {
+	if err := a.req.Validate(); err != nil {
+		return err
+	}
+
+	sgInfoMap, err := a.ListBizResBasicInfo(enumor.SecurityGroupCloudResType, a.req.BkBizID,
+		[]string{a.req.SecurityGroupID})
+	if err != nil {
+		return err
+	}
+	a.sgInfo = sgInfoMap[a.req.SecurityGroupID]
+
+	if a.sgInfo.Vendor != a.Vendor() {
+		return fmt.Errorf("security group: %s vendor is %s, not %s", a.req.SecurityGroupID, a.sgInfo.Vendor,
+			a.Vendor())
+	}
+
+	a.rules = make(map[string]corecloud.TCloudSecurityGroupRule)
+	ruleIDs := a.req.RuleIDs()
+	if len(ruleIDs) == 0 {
+		return nil
+	}
+
+	listReq := &dataproto.TCloudSGRuleListReq{
+		Filter: tools.ContainersExpression("id", ruleIDs),
+		Page:   &core.BasePage{Count: false, Start: 0, Limit: uint(len(ruleIDs))},
+	}
+	result, err := a.Client.DataService().TCloud.SecurityGroup.ListSecurityGroupRule(a.Cts.Kit.Ctx,
+		a.Cts.Kit.Header(), listReq, a.req.SecurityGroupID)
+	if err != nil {
+		return err
+	}
+	for _, one := range result.Details {
+		a.rules[one.ID] = one
+	}
+
+	for _, id := range ruleIDs {
+		if _, exists := a.rules[id]; !exists {
+			return fmt.Errorf("rule: %s not found in security group: %s", id, a.req.SecurityGroupID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/cmd/cloud-server/service/application/handlers"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/dal/dao/tools"
+)
+
+type formItem struct {
+	Label string
+	Value string
+}
+
+// RenderItsmTitle 渲染ITSM单据标题
+func (a *ApplicationOfChangeTCloudSGRule) RenderItsmTitle() (string, error) {
+	return fmt.Sprintf("申请变更[%s]安全组规则(%s)", handlers.VendorNameMap[a.Vendor()], a.req.SecurityGroupID), nil
+}
+
+// RenderItsmForm 渲染ITSM表单，规则变更以删除、修改、新增的差异形式展示
+func (a *ApplicationOfChangeTCloudSGRule) RenderItsmForm() (string, error) {
+	formItems := make([]formItem, 0)
+
+	// 业务
+	bizName, err := a.GetBizName(a.req.BkBizID)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "业务", Value: bizName})
+
+	// 云账号
+	accountInfo, err := a.GetAccount(a.sgInfo.AccountID)
+	if err != nil {
+		return "", err
+	}
+	formItems = append(formItems, formItem{Label: "云账号", Value: accountInfo.Name})
+	formItems = append(formItems, formItem{Label: "云厂商", Value: handlers.VendorNameMap[a.Vendor()]})
+
+	// 安全组
+	sgResp, err := a.Client.DataService().Global.SecurityGroup.ListSecurityGroup(a.Cts.Kit.Ctx, a.Cts.Kit.Header(),
+		&dataproto.SecurityGroupListReq{
+			Filter: tools.EqualExpression("id", a.req.SecurityGroupID),
+			Page:   core.NewDefaultBasePage(),
+		})
+	if err != nil {
+		return "", err
+	}
+	if len(sgResp.Details) == 0 {
+		return "", fmt.Errorf("security group: %s not found", a.req.SecurityGroupID)
+	}
+	sg := sgResp.Details[0]
+	formItems = append(formItems, formItem{Label: "安全组", Value: fmt.Sprintf("%s(%s)", sg.Name, sg.CloudID)})
+
+	// 规则变更
+	formItems = append(formItems, renderRuleDiff(a.req, a.rules)...)
+
+	// 转换为ITSM表单内容数据
+	content := make([]string, 0, len(formItems))
+	for _, i := range formItems {
+		content = append(content, fmt.Sprintf("%s: %s", i.Label, i.Value))
+	}
+	return strings.Join(content, "\n"), nil
+}
@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	hcproto "hcm/pkg/api/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+)
+
+// Deliver 执行规则变更，依次删除、修改、新增规则，交付前再次校验规则归属，中途失败时返回已完成的变更
+func (a *ApplicationOfChangeTCloudSGRule) Deliver() (enumor.ApplicationStatus, map[string]interface{}, error) {
+	if err := a.CheckReq(); err != nil {
+		return enumor.DeliverError, map[string]interface{}{"error": err.Error()}, err
+	}
+
+	kt := a.Cts.Kit
+	sgID := a.req.SecurityGroupID
+	detail := make(map[string]interface{})
+	deliverError := func(err error) (enumor.ApplicationStatus, map[string]interface{}, error) {
+		logs.Errorf("change security group: %s rule failed, err: %v, rid: %s", sgID, err, kt.Rid)
+		detail["error"] = err.Error()
+		return enumor.DeliverError, detail, err
+	}
+
+	// 删除规则
+	if len(a.req.DeleteIDs) != 0 {
+		err := a.Audit.ChildResDeleteAudit(kt, enumor.SecurityGroupRuleAuditResType, sgID, a.req.DeleteIDs)
+		if err != nil {
+			return deliverError(err)
+		}
+	}
+	deleted := make([]string, 0, len(a.req.DeleteIDs))
+	for _, id := range a.req.DeleteIDs {
+		err := a.Client.HCService().TCloud.SecurityGroup.DeleteSecurityGroupRule(kt.Ctx, kt.Header(), sgID, id)
+		if err != nil {
+			return deliverError(err)
+		}
+		deleted = append(deleted, id)
+		detail["deleted_ids"] = deleted
+	}
+
+	// 修改规则
+	updated := make([]string, 0, len(a.req.Updates))
+	for _, one := range a.req.Updates {
+		updateFields, err := converter.StructToMap(one.TCloudSGRuleUpdateReq)
+		if err != nil {
+			return deliverError(err)
+		}
+		err = a.Audit.ChildResUpdateAudit(kt, enumor.SecurityGroupRuleAuditResType, sgID, one.ID, updateFields)
+		if err != nil {
+			return deliverError(err)
+		}
+
+		updateReq := &hcproto.TCloudSGRuleUpdateReq{
+			Protocol:                   one.Protocol,
+			Port:                       one.Port,
+			CloudServiceID:             one.CloudServiceID,
+			CloudServiceGroupID:        one.CloudServiceGroupID,
+			IPv4Cidr:                   one.IPv4Cidr,
+			IPv6Cidr:                   one.IPv6Cidr,
+			CloudAddressID:             one.CloudAddressID,
+			CloudAddressGroupID:        one.CloudAddressGroupID,
+			CloudTargetSecurityGroupID: one.CloudTargetSecurityGroupID,
+			Action:                     one.Action,
+			Memo:                       one.Memo,
+		}
+		err = a.Client.HCService().TCloud.SecurityGroup.UpdateSecurityGroupRule(kt.Ctx, kt.Header(), sgID, one.ID,
+			updateReq)
+		if err != nil {
+			return deliverError(err)
+		}
+		updated = append(updated, one.ID)
+		detail["updated_ids"] = updated
+	}
+
+	// 新增规则，腾讯云一次只能新增同一方向的规则
+	created := make([]string, 0, len(a.req.Creates))
+	for _, ruleType := range []enumor.SecurityGroupRuleType{enumor.Egress, enumor.Ingress} {
+		rules := make([]hcproto.TCloudSGRuleCreate, 0)
+		for _, one := range a.req.Creates {
+			if one.Type != ruleType {
+				continue
+			}
+			rules = append(rules, hcproto.TCloudSGRuleCreate{
+				Protocol:                   one.Protocol,
+				Port:                       one.Port,
+				CloudServiceID:             one.CloudServiceID,
+				CloudServiceGroupID:        one.CloudServiceGroupID,
+				IPv4Cidr:                   one.IPv4Cidr,
+				IPv6Cidr:                   one.IPv6Cidr,
+				CloudAddressID:             one.CloudAddressID,
+				CloudAddressGroupID:        one.CloudAddressGroupID,
+				CloudTargetSecurityGroupID: one.CloudTargetSecurityGroupID,
+				Action:                     one.Action,
+				Memo:                       one.Memo,
+			})
+		}
+		if len(rules) == 0 {
+			continue
+		}
+
+		createReq := &hcproto.TCloudSGRuleCreateReq{AccountID: a.sgInfo.AccountID}
+		if ruleType == enumor.Egress {
+			createReq.EgressRuleSet = rules
+		} else {
+			createReq.IngressRuleSet = rules
+		}
+		result, err := a.Client.HCService().TCloud.SecurityGroup.BatchCreateSecurityGroupRule(kt.Ctx, kt.Header(),
+			sgID, createReq)
+		if err != nil {
+			return deliverError(err)
+		}
+		created = append(created, result.IDs...)
+		detail["created_ids"] = created
+	}
+
+	return enumor.Completed, detail, nil
+}
@@ -0,0 +1,167 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+	"strings"
+
+	proto "hcm/pkg/api/cloud-server/application"
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+)
+
+// ruleSpec 安全组规则在表单中展示的内容
+type ruleSpec struct {
+	Type                       enumor.SecurityGroupRuleType
+	Protocol                   *string
+	Port                       *string
+	CloudServiceID             *string
+	CloudServiceGroupID        *string
+	IPv4Cidr                   *string
+	IPv6Cidr                   *string
+	CloudAddressID             *string
+	CloudAddressGroupID        *string
+	CloudTargetSecurityGroupID *string
+	Action                     string
+	Memo                       *string
+}
+
+func specFromRule(rule corecloud.TCloudSecurityGroupRule) ruleSpec {
+	return ruleSpec{
+		Type:                       rule.Type,
+		Protocol:                   rule.Protocol,
+		Port:                       rule.Port,
+		CloudServiceID:             rule.CloudServiceID,
+		CloudServiceGroupID:        rule.CloudServiceGroupID,
+		IPv4Cidr:                   rule.IPv4Cidr,
+		IPv6Cidr:                   rule.IPv6Cidr,
+		CloudAddressID:             rule.CloudAddressID,
+		CloudAddressGroupID:        rule.CloudAddressGroupID,
+		CloudTargetSecurityGroupID: rule.CloudTargetSecurityGroupID,
+		Action:                     rule.Action,
+		Memo:                       rule.Memo,
+	}
+}
+
+func specFromCreate(one proto.TCloudSGRuleCreateItem) ruleSpec {
+	return ruleSpec{
+		Type:                       one.Type,
+		Protocol:                   one.Protocol,
+		Port:                       one.Port,
+		CloudServiceID:             one.CloudServiceID,
+		CloudServiceGroupID:        one.CloudServiceGroupID,
+		IPv4Cidr:                   one.IPv4Cidr,
+		IPv6Cidr:                   one.IPv6Cidr,
+		CloudAddressID:             one.CloudAddressID,
+		CloudAddressGroupID:        one.CloudAddressGroupID,
+		CloudTargetSecurityGroupID: one.CloudTargetSecurityGroupID,
+		Action:                     one.Action,
+		Memo:                       one.Memo,
+	}
+}
+
+// specFromUpdate 腾讯云修改规则是整条替换，修改后的规则只由修改请求决定，规则方向不变
+func specFromUpdate(ruleType enumor.SecurityGroupRuleType, one proto.TCloudSGRuleUpdateItem) ruleSpec {
+	return ruleSpec{
+		Type:                       ruleType,
+		Protocol:                   one.Protocol,
+		Port:                       one.Port,
+		CloudServiceID:             one.CloudServiceID,
+		CloudServiceGroupID:        one.CloudServiceGroupID,
+		IPv4Cidr:                   one.IPv4Cidr,
+		IPv6Cidr:                   one.IPv6Cidr,
+		CloudAddressID:             one.CloudAddressID,
+		CloudAddressGroupID:        one.CloudAddressGroupID,
+		CloudTargetSecurityGroupID: one.CloudTargetSecurityGroupID,
+		Action:                     one.Action,
+		Memo:                       one.Memo,
+	}
+}
+
+// String 渲染为 "[入站] 协议端口: tcp:80, 源: 10.0.0.0/8, 策略: ACCEPT" 的格式
+func (r ruleSpec) String() string {
+	direction, peer := "入站", "源"
+	if r.Type == enumor.Egress {
+		direction, peer = "出站", "目标"
+	}
+
+	var service string
+	switch {
+	case r.CloudServiceID != nil:
+		service = "参数模板 " + *r.CloudServiceID
+	case r.CloudServiceGroupID != nil:
+		service = "参数模板组 " + *r.CloudServiceGroupID
+	default:
+		service = fmt.Sprintf("%s:%s", strValue(r.Protocol), strValue(r.Port))
+	}
+
+	var target string
+	switch {
+	case r.IPv4Cidr != nil:
+		target = *r.IPv4Cidr
+	case r.IPv6Cidr != nil:
+		target = *r.IPv6Cidr
+	case r.CloudAddressID != nil:
+		target = "参数模板 " + *r.CloudAddressID
+	case r.CloudAddressGroupID != nil:
+		target = "参数模板组 " + *r.CloudAddressGroupID
+	case r.CloudTargetSecurityGroupID != nil:
+		target = "安全组 " + *r.CloudTargetSecurityGroupID
+	}
+
+	items := []string{
+		fmt.Sprintf("[%s] 协议端口: %s", direction, service),
+		fmt.Sprintf("%s: %s", peer, target),
+		fmt.Sprintf("策略: %s", r.Action),
+	}
+	if r.Memo != nil && *r.Memo != "" {
+		items = append(items, fmt.Sprintf("备注: %s", *r.Memo))
+	}
+	return strings.Join(items, ", ")
+}
+
+func strValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// renderRuleDiff 渲染规则变更的差异，依次为删除、修改、新增的规则，与交付的执行顺序一致
+func renderRuleDiff(req *proto.TCloudSGRuleChangeReq, rules map[string]corecloud.TCloudSecurityGroupRule) []formItem {
+	items := make([]formItem, 0, len(req.DeleteIDs)+len(req.Updates)+len(req.Creates))
+	for _, id := range req.DeleteIDs {
+		items = append(items, formItem{Label: "删除规则", Value: "- " + specFromRule(rules[id]).String()})
+	}
+
+	for _, one := range req.Updates {
+		before := rules[one.ID]
+		after := specFromUpdate(before.Type, one)
+		items = append(items, formItem{Label: "修改规则",
+			Value: fmt.Sprintf("~ %s -> %s", specFromRule(before).String(), after.String())})
+	}
+
+	for _, one := range req.Creates {
+		items = append(items, formItem{Label: "新增规则", Value: "+ " + specFromCreate(one).String()})
+	}
+
+	return items
+}
@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"testing"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	proto "hcm/pkg/api/cloud-server/application"
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+func TestRenderRuleDiff(t *testing.T) {
+	rules := map[string]corecloud.TCloudSecurityGroupRule{
+		"rule-1": {ID: "rule-1", Type: enumor.Ingress, Protocol: converter.ValToPtr("tcp"),
+			Port: converter.ValToPtr("22"), IPv4Cidr: converter.ValToPtr("0.0.0.0/0"), Action: "ACCEPT"},
+		"rule-2": {ID: "rule-2", Type: enumor.Egress, Protocol: converter.ValToPtr("ALL"),
+			Port: converter.ValToPtr("ALL"), CloudTargetSecurityGroupID: converter.ValToPtr("sg-xx"), Action: "DROP"},
+	}
+	req := &proto.TCloudSGRuleChangeReq{
+		Creates: []proto.TCloudSGRuleCreateItem{{
+			Type: enumor.Ingress,
+			TCloudSecurityGroupRule: cloudserver.TCloudSecurityGroupRule{CloudServiceID: converter.ValToPtr("ppm-1"),
+				IPv4Cidr: converter.ValToPtr("10.0.0.0/8"), Action: "ACCEPT", Memo: converter.ValToPtr("office")},
+		}},
+		Updates: []proto.TCloudSGRuleUpdateItem{{
+			ID: "rule-1",
+			TCloudSGRuleUpdateReq: cloudserver.TCloudSGRuleUpdateReq{Protocol: converter.ValToPtr("tcp"),
+				Port: converter.ValToPtr("22"), IPv4Cidr: converter.ValToPtr("10.0.0.0/8"), Action: "ACCEPT"},
+		}},
+		DeleteIDs: []string{"rule-2"},
+	}
+
+	expected := []formItem{
+		{Label: "删除规则", Value: "- [出站] 协议端口: ALL:ALL, 目标: 安全组 sg-xx, 策略: DROP"},
+		{Label: "修改规则", Value: "~ [入站] 协议端口: tcp:22, 源: 0.0.0.0/0, 策略: ACCEPT -> " +
+			"[入站] 协议端口: tcp:22, 源: 10.0.0.0/8, 策略: ACCEPT"},
+		{Label: "新增规则", Value: "+ [入站] 协议端口: 参数模板 ppm-1, 源: 10.0.0.0/8, 策略: ACCEPT, 备注: office"},
+	}
+	items := renderRuleDiff(req, rules)
+	if len(items) != len(expected) {
+		t.Fatalf("diff items count %d not expected", len(items))
+	}
+	for i := range expected {
+		if items[i] != expected[i] {
+			t.Errorf("diff item %d is %+v, expected %+v", i, items[i], expected[i])
+		}
+	}
+}
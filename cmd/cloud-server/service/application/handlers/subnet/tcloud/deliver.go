@@ -0,0 +1,50 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	hcservice "hcm/pkg/api/hc-service/subnet"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Deliver 执行资源交付
+func (a *ApplicationOfCreateTCloudSubnet) Deliver() (enumor.ApplicationStatus, map[string]interface{}, error) {
+	req := &hcservice.TCloudSubnetBatchCreateReq{
+		BkBizID:    a.req.BkBizID,
+		AccountID:  a.req.AccountID,
+		Region:     a.req.Region,
+		CloudVpcID: a.req.CloudVpcID,
+		Subnets: []hcservice.TCloudOneSubnetCreateReq{{
+			IPv4Cidr:          a.req.IPv4Cidr,
+			Name:              a.req.Name,
+			Zone:              a.req.Zone,
+			CloudRouteTableID: a.req.CloudRouteTableID,
+			Memo:              a.req.Memo,
+		}},
+	}
+	result, err := a.Client.HCService().TCloud.Subnet.BatchCreate(a.Cts.Kit.Ctx, a.Cts.Kit.Header(), req)
+	if err != nil {
+		return enumor.DeliverError, map[string]interface{}{"error": err.Error()}, err
+	}
+
+	a.RecordDesiredState(enumor.SubnetCloudResType, result.IDs)
+
+	return enumor.Completed, map[string]interface{}{"subnet_ids": result.IDs}, nil
+}
@@ -25,6 +25,10 @@ import (
 
 // CheckReq 检查申请单的数据是否正确
 func (a *ApplicationOfCreateAwsVpc) CheckReq() error {
+	if err := a.fillCidr(); err != nil {
+		return err
+	}
+
 	if err := a.req.Validate(true); err != nil {
 		return err
 	}
@@ -35,3 +39,18 @@ func (a *ApplicationOfCreateAwsVpc) CheckReq() error {
 
 	return nil
 }
+
+// fillCidr 未指定vpc网段时从IPAM地址池中分配vpc网段
+func (a *ApplicationOfCreateAwsVpc) fillCidr() error {
+	if len(a.req.IPv4Cidr) != 0 {
+		return nil
+	}
+
+	result, err := a.AllocateVpcCidr(a.req.BkBizID, a.req.Region)
+	if err != nil {
+		return err
+	}
+
+	a.req.IPv4Cidr = result.VpcCidr
+	return nil
+}
@@ -87,6 +87,9 @@ func (a *ApplicationOfCreateAwsVpc) Deliver() (enumor.ApplicationStatus, map[str
 		}
 	}
 
+	// 保存vpc及其子网的期望状态，用于配置漂移检测
+	a.RecordVpcDesiredState(result.ID, subnetsInfo)
+
 	return enumor.Completed, map[string]interface{}{"vpc_id": result.ID}, nil
 }
 
@@ -88,6 +88,9 @@ func (a *ApplicationOfCreateAzureVpc) Deliver() (enumor.ApplicationStatus, map[s
 		}
 	}
 
+	// 保存vpc及其子网的期望状态，用于配置漂移检测
+	a.RecordVpcDesiredState(result.ID, subnetsInfo)
+
 	return enumor.Completed, map[string]interface{}{"vpc_id": result.ID}, nil
 }
 
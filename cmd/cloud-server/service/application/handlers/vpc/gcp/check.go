@@ -23,6 +23,10 @@ import logicsaccount "hcm/cmd/cloud-server/logics/account"
 
 // CheckReq 检查申请单的数据是否正确
 func (a *ApplicationOfCreateGcpVpc) CheckReq() error {
+	if err := a.fillCidr(); err != nil {
+		return err
+	}
+
 	if err := a.req.Validate(true); err != nil {
 		return err
 	}
@@ -33,3 +37,18 @@ func (a *ApplicationOfCreateGcpVpc) CheckReq() error {
 
 	return nil
 }
+
+// fillCidr gcp的vpc没有网段，未指定子网网段时从IPAM地址池中分配不与已同步vpc重叠的子网网段
+func (a *ApplicationOfCreateGcpVpc) fillCidr() error {
+	if len(a.req.Subnet.IPv4Cidr) != 0 {
+		return nil
+	}
+
+	result, err := a.AllocateVpcCidr(a.req.BkBizID, a.req.Region)
+	if err != nil {
+		return err
+	}
+
+	a.req.Subnet.IPv4Cidr = result.SubnetCidr
+	return nil
+}
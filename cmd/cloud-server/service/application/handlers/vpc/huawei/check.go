@@ -19,10 +19,17 @@
 
 package huawei
 
-import logicsaccount "hcm/cmd/cloud-server/logics/account"
+import (
+	logicsaccount "hcm/cmd/cloud-server/logics/account"
+	logicsipam "hcm/cmd/cloud-server/logics/ipam"
+)
 
 // CheckReq 检查申请单的数据是否正确
 func (a *ApplicationOfCreateHuaWeiVpc) CheckReq() error {
+	if err := a.fillCidr(); err != nil {
+		return err
+	}
+
 	if err := a.req.Validate(true); err != nil {
 		return err
 	}
@@ -33,3 +40,28 @@ func (a *ApplicationOfCreateHuaWeiVpc) CheckReq() error {
 
 	return nil
 }
+
+// fillCidr 未指定vpc网段时从IPAM地址池中分配vpc网段及子网网段，未指定子网网关时使用子网网段的第一个IP
+func (a *ApplicationOfCreateHuaWeiVpc) fillCidr() error {
+	if len(a.req.IPv4Cidr) == 0 {
+		result, err := a.AllocateVpcCidr(a.req.BkBizID, a.req.Region)
+		if err != nil {
+			return err
+		}
+
+		a.req.IPv4Cidr = result.VpcCidr
+		if len(a.req.Subnet.IPv4Cidr) == 0 {
+			a.req.Subnet.IPv4Cidr = result.SubnetCidr
+		}
+	}
+
+	if len(a.req.Subnet.GatewayIP) == 0 && len(a.req.Subnet.IPv4Cidr) != 0 {
+		gatewayIP, err := logicsipam.FirstHostIP(a.req.Subnet.IPv4Cidr)
+		if err != nil {
+			return err
+		}
+		a.req.Subnet.GatewayIP = gatewayIP
+	}
+
+	return nil
+}
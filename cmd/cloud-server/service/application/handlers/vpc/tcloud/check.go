@@ -23,6 +23,10 @@ import logicsaccount "hcm/cmd/cloud-server/logics/account"
 
 // CheckReq 检查申请单的数据是否正确
 func (a *ApplicationOfCreateTCloudVpc) CheckReq() error {
+	if err := a.fillCidr(); err != nil {
+		return err
+	}
+
 	if err := a.req.Validate(true); err != nil {
 		return err
 	}
@@ -33,3 +37,22 @@ func (a *ApplicationOfCreateTCloudVpc) CheckReq() error {
 
 	return nil
 }
+
+// fillCidr 未指定vpc网段时从IPAM地址池中分配vpc网段，子网网段同时未指定时使用分配的第一个子网网段
+func (a *ApplicationOfCreateTCloudVpc) fillCidr() error {
+	if len(a.req.IPv4Cidr) != 0 {
+		return nil
+	}
+
+	result, err := a.AllocateVpcCidr(a.req.BkBizID, a.req.Region)
+	if err != nil {
+		return err
+	}
+
+	a.req.IPv4Cidr = result.VpcCidr
+	if len(a.req.Subnet.IPv4Cidr) == 0 {
+		a.req.Subnet.IPv4Cidr = result.SubnetCidr
+	}
+
+	return nil
+}
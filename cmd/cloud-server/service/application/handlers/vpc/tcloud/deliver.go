@@ -88,5 +88,8 @@ func (a *ApplicationOfCreateTCloudVpc) Deliver() (enumor.ApplicationStatus, map[
 		}
 	}
 
+	// 保存vpc及其子网的期望状态，用于配置漂移检测
+	a.RecordVpcDesiredState(result.ID, subnetsInfo)
+
 	return enumor.Completed, map[string]interface{}{"vpc_id": result.ID}, nil
 }
\ No newline at end of file
@@ -26,16 +26,19 @@ import (
 
 	"github.com/tidwall/gjson"
 
+	"hcm/cmd/cloud-server/logics"
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/application/handlers"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/api/core"
 	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/cc"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/cryptography"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/runtime/filter"
@@ -52,18 +55,42 @@ func InitApplicationService(c *capability.Capability, bkHcmUrl string) {
 		cipher:     c.Cipher,
 		itsmCli:    c.ItsmCli,
 		esbCli:     c.EsbClient,
+		logics:     c.Logics,
 		bkHcmUrl:   bkHcmUrl,
 	}
+	svc.deliverStore = &clientDeliverStore{client: c.ApiClient}
+	svc.createStore = &clientCreateStore{client: c.ApiClient}
+	svc.nativeApproval = cc.CloudServer().Approval.IsNative()
+	svc.getDeliverHandler = svc.getHandlerByApplication
+
+	// 内置审批引擎在单据审批结束后直接回调申请单的审批结果处理
+	if c.NativeApproval != nil {
+		c.NativeApproval.SetResultHandler(svc.handleApproveResult)
+	}
+
 	h := rest.NewHandler()
 	h.Add("List", "POST", "/applications/list", svc.List)
 	h.Add("Get", "GET", "/applications/{application_id}", svc.Get)
 	h.Add("Cancel", "PATCH", "/applications/{application_id}/cancel", svc.Cancel)
 	h.Add("Approve", "POST", "/applications/approve", svc.Approve)
+	h.Add("RetryDeliver", "POST", "/applications/{application_id}/deliver/retry", svc.RetryDeliver)
 
 	h.Add("CreateForAddAccount", "POST", "/applications/types/add_account", svc.CreateForAddAccount)
 	h.Add("CreateForCreateCvm", "POST", "/vendors/{vendor}/applications/types/create_cvm", svc.CreateForCreateCvm)
 	h.Add("CreateForCreateVpc", "POST", "/vendors/{vendor}/applications/types/create_vpc", svc.CreateForCreateVpc)
 	h.Add("CreateForCreateDisk", "POST", "/vendors/{vendor}/applications/types/create_disk", svc.CreateForCreateDisk)
+	h.Add("CreateForDeleteCvm", "POST", "/applications/types/delete_cvm", svc.CreateForDeleteCvm)
+	h.Add("CreateForRecycleCvm", "POST", "/applications/types/recycle_cvm", svc.CreateForRecycleCvm)
+	h.Add("CreateForAssociateEip", "POST", "/applications/types/associate_eip", svc.CreateForAssociateEip)
+	h.Add("CreateForCreateEip", "POST", "/vendors/{vendor}/applications/types/create_eip", svc.CreateForCreateEip)
+	h.Add("CreateForCreateSubnet", "POST", "/vendors/{vendor}/applications/types/create_subnet",
+		svc.CreateForCreateSubnet)
+	h.Add("CreateForChangeSGRule", "POST", "/vendors/{vendor}/applications/types/change_security_group_rule",
+		svc.CreateForChangeSGRule)
+
+	// 内部接口，只允许task-server的交付任务调用
+	h.Add("ExecuteDeliverStep", "POST", "/applications/{application_id}/deliver_steps/{step}",
+		svc.ExecuteDeliverStep)
 
 	h.Load(c.WebService)
 }
@@ -75,7 +102,15 @@ type applicationSvc struct {
 	cipher     cryptography.Crypto
 	itsmCli    itsm.Client
 	esbCli     esb.Client
+	logics     *logics.Logics
 	bkHcmUrl   string
+	// nativeApproval 使用内置审批引擎时审批人由内置审批流配置，不依赖ITSM的审批流程记录
+	nativeApproval bool
+
+	createStore       createStore
+	deliverStore      deliverStore
+	getDeliverHandler func(cts *rest.Contexts, application *dataproto.ApplicationResp) (handlers.ApplicationHandler,
+		error)
 }
 
 func (a *applicationSvc) getCallbackUrl() string {
@@ -90,18 +125,49 @@ func (a *applicationSvc) getHandlerOption(cts *rest.Contexts) *handlers.HandlerO
 		EsbClient: a.esbCli,
 		Cipher:    a.cipher,
 		Audit:     a.audit,
+		Logics:    a.logics,
 	}
 }
 
 func (a *applicationSvc) getApprovalProcessInfo(
 	cts *rest.Contexts, applicationType enumor.ApplicationType,
 ) (int64, []string, error) {
-	// DB中添加4条记录，分别对应add_account、create_cvm、create_vpc、create_disk
-	// Note：目前4条记录对应一个itsm流程id，后续如果要使用其它流程可直接修改数据库适配
+	// 内置审批引擎按申请单类型和业务匹配内置审批流，审批人由审批流配置，不需要ITSM的审批流程记录
+	if a.nativeApproval {
+		return 0, nil, nil
+	}
+
+	// DB中每种申请单类型对应一条记录，如add_account、create_cvm、create_vpc、create_disk、delete_cvm等
+	// Note：目前所有记录对应一个itsm流程id，后续如果要使用其它流程可直接修改数据库适配
 	// 新增类型只需要增加对应的tye和DB记录
-	result, err := a.client.DataService().Global.ApprovalProcess.List(
-		cts.Kit.Ctx,
-		cts.Kit.Header(),
+	details, err := a.createStore.ListApprovalProcess(cts.Kit, applicationType)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(details) != 1 {
+		return 0, nil, fmt.Errorf("approval process of [%s] not init", applicationType)
+	}
+
+	return details[0].ServiceID, strings.Split(details[0].Managers, ","), nil
+}
+
+// createStore 创建申请单依赖的审批流程和申请单数据操作
+type createStore interface {
+	ListApprovalProcess(kt *kit.Kit, appType enumor.ApplicationType) ([]*dataproto.ApprovalProcessResp, error)
+	CreateApplication(kt *kit.Kit, req *dataproto.ApplicationCreateReq) (*core.CreateResult, error)
+}
+
+type clientCreateStore struct {
+	client *client.ClientSet
+}
+
+// ListApprovalProcess list approval process of application type.
+func (s *clientCreateStore) ListApprovalProcess(kt *kit.Kit, appType enumor.ApplicationType) (
+	[]*dataproto.ApprovalProcessResp, error) {
+
+	result, err := s.client.DataService().Global.ApprovalProcess.List(
+		kt.Ctx,
+		kt.Header(),
 		&dataproto.ApprovalProcessListReq{
 			Filter: &filter.Expression{
 				Op: filter.And,
@@ -109,7 +175,7 @@ func (a *applicationSvc) getApprovalProcessInfo(
 					filter.AtomRule{
 						Field: "application_type",
 						Op:    filter.Equal.Factory(),
-						Value: string(applicationType),
+						Value: string(appType),
 					},
 				},
 			},
@@ -121,53 +187,38 @@ func (a *applicationSvc) getApprovalProcessInfo(
 		},
 	)
 	if err != nil {
-		return 0, nil, err
-	}
-	if result.Details == nil || len(result.Details) != 1 {
-		return 0, nil, fmt.Errorf("approval process of [%s] not init", applicationType)
+		return nil, err
 	}
 
-	return result.Details[0].ServiceID, strings.Split(result.Details[0].Managers, ","), nil
+	return result.Details, nil
+}
+
+// CreateApplication create application.
+func (s *clientCreateStore) CreateApplication(kt *kit.Kit, req *dataproto.ApplicationCreateReq) (
+	*core.CreateResult, error) {
+
+	return s.client.DataService().Global.Application.Create(kt.Ctx, kt.Header(), req)
 }
 
 func (a *applicationSvc) updateStatusWithDetail(
-	cts *rest.Contexts, applicationID string, status enumor.ApplicationStatus, deliveryDetail string,
+	kt *kit.Kit, applicationID string, status enumor.ApplicationStatus, deliveryDetail string,
 ) error {
 	req := &dataproto.ApplicationUpdateReq{Status: status}
 	if deliveryDetail != "" {
 		req.DeliveryDetail = &deliveryDetail
 	}
-	_, err := a.client.DataService().Global.Application.Update(cts.Kit, applicationID, req)
+	_, err := a.client.DataService().Global.Application.Update(kt, applicationID, req)
 	return err
 }
 
-func (a *applicationSvc) getApplicationBySN(cts *rest.Contexts, sn string) (*dataproto.ApplicationResp, error) {
-	// 构造过滤条件，只能查询自己的单据
-	reqFilter := &filter.Expression{
-		Op: filter.And,
-		Rules: []filter.RuleFactory{
-			filter.AtomRule{Field: "sn", Op: filter.Equal.Factory(), Value: sn},
-		},
-	}
-	// 查询
-	resp, err := a.client.DataService().Global.Application.List(
-		cts.Kit,
-		&dataproto.ApplicationListReq{
-			Filter: reqFilter,
-			Page:   &core.BasePage{Count: false, Start: 0, Limit: 1},
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
-	if resp == nil || len(resp.Details) == 0 {
-		return nil, fmt.Errorf("not found application by sn(%s)", sn)
-	}
-
-	return resp.Details[0], nil
+func (a *applicationSvc) checkApplyResPermission(cts *rest.Contexts, resType meta.ResourceType) error {
+	return a.checkBizResPermission(cts, resType, meta.Apply)
 }
 
-func (a *applicationSvc) checkApplyResPermission(cts *rest.Contexts, resType meta.ResourceType) error {
+// checkBizResPermission 校验对请求体中业务下资源的操作权限
+func (a *applicationSvc) checkBizResPermission(cts *rest.Contexts, resType meta.ResourceType,
+	action meta.Action) error {
+
 	body, err := cts.RequestBody()
 	if err != nil {
 		logs.Errorf("get request body failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -180,7 +231,7 @@ func (a *applicationSvc) checkApplyResPermission(cts *rest.Contexts, resType met
 	}
 
 	// authorize
-	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: resType, Action: meta.Apply}, BizID: bizID}
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: resType, Action: action}, BizID: bizID}
 	if err = a.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
 		return err
 	}
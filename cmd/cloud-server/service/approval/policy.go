@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package approval
+
+import (
+	"hcm/pkg/api/core"
+	dsapproval "hcm/pkg/api/data-service/approval"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreatePolicy 创建业务审批策略，业务为-1表示默认审批策略
+func (svc *approvalSvc) CreatePolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsapproval.PolicyCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkConfigPermission(cts); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.Approval.CreatePolicy(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("create approval policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListPolicy ...
+func (svc *approvalSvc) ListPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkConfigPermission(cts); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Approval.ListPolicy(cts.Kit, req)
+}
+
+// UpdatePolicy 更新业务审批策略，已提交的申请单不受影响
+func (svc *approvalSvc) UpdatePolicy(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dsapproval.PolicyUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkConfigPermission(cts); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Approval.UpdatePolicy(cts.Kit, id, req); err != nil {
+		logs.Errorf("update approval policy failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeletePolicy ...
+func (svc *approvalSvc) BatchDeletePolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkConfigPermission(cts); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Approval.BatchDeletePolicy(cts.Kit, req); err != nil {
+		logs.Errorf("batch delete approval policy failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package approval 业务审批策略以及内置审批引擎的审批单据、审批流配置相关接口
+package approval
+
+import (
+	"fmt"
+	"net/http"
+
+	logicsapproval "hcm/cmd/cloud-server/logics/approval"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// InitApprovalService initialize the approval service, the approval ticket and workflow service is only provided
+// when native approval engine is chosen.
+func InitApprovalService(c *capability.Capability) {
+	svc := &approvalSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		engine:     c.NativeApproval,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateApprovalPolicy", http.MethodPost, "/approval/policies/create", svc.CreatePolicy)
+	h.Add("ListApprovalPolicy", http.MethodPost, "/approval/policies/list", svc.ListPolicy)
+	h.Add("UpdateApprovalPolicy", http.MethodPatch, "/approval/policies/{id}", svc.UpdatePolicy)
+	h.Add("BatchDeleteApprovalPolicy", http.MethodDelete, "/approval/policies/batch", svc.BatchDeletePolicy)
+
+	if c.NativeApproval != nil {
+		h.Add("ListApprovalTicket", http.MethodPost, "/approval/tickets/list", svc.ListTicket)
+		h.Add("ListApprovalTicketByUser", http.MethodPost, "/approval/tickets/by_user/list", svc.ListTicketByUser)
+		h.Add("GetApprovalTicket", http.MethodGet, "/approval/tickets/{sn}", svc.GetTicket)
+		h.Add("ApproveApprovalTicket", http.MethodPost, "/approval/tickets/{sn}/approve", svc.ApproveTicket)
+		h.Add("RejectApprovalTicket", http.MethodPost, "/approval/tickets/{sn}/reject", svc.RejectTicket)
+		h.Add("TransferApprovalTicket", http.MethodPost, "/approval/tickets/{sn}/transfer", svc.TransferTicket)
+		h.Add("WithdrawApprovalTicket", http.MethodPost, "/approval/tickets/{sn}/withdraw", svc.WithdrawTicket)
+
+		h.Add("CreateApprovalWorkflow", http.MethodPost, "/approval/workflows/create", svc.CreateWorkflow)
+		h.Add("ListApprovalWorkflow", http.MethodPost, "/approval/workflows/list", svc.ListWorkflow)
+		h.Add("UpdateApprovalWorkflow", http.MethodPatch, "/approval/workflows/{id}", svc.UpdateWorkflow)
+		h.Add("BatchDeleteApprovalWorkflow", http.MethodDelete, "/approval/workflows/batch",
+			svc.BatchDeleteWorkflow)
+	}
+
+	h.Load(c.WebService)
+}
+
+type approvalSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	engine     *logicsapproval.Native
+}
+
+// checkConfigPermission 审批流和审批策略决定了操作是否需要审批以及审批人，与录入账号一样属于平台管理，统一使用录入账号的权限
+func (svc *approvalSvc) checkConfigPermission(cts *rest.Contexts) error {
+	res := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Account, Action: meta.Import}}
+	_, authorized, err := svc.authorizer.Authorize(cts.Kit, res)
+	if err != nil {
+		return errf.NewFromErr(errf.PermissionDenied,
+			fmt.Errorf("check approval config permissions failed, err: %v", err))
+	}
+
+	if !authorized {
+		return errf.NewFromErr(errf.PermissionDenied, fmt.Errorf("you have not permission of approval config"))
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package approval
+
+import (
+	csapproval "hcm/pkg/api/cloud-server/approval"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/rest"
+	"hcm/pkg/thirdparty/api-gateway/itsm"
+	"hcm/pkg/tools/slice"
+)
+
+// ListTicket 查询待我审批或我提交的单据
+func (svc *approvalSvc) ListTicket(cts *rest.Contexts) (interface{}, error) {
+	req := new(csapproval.TicketListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.engine.ListTicket(cts.Kit, req.View, cts.Kit.User, req.Page)
+}
+
+// ListTicketByUser 以ITSM的单据格式查询用户的单据，web-server的审批单据接口使用
+func (svc *approvalSvc) ListTicketByUser(cts *rest.Contexts) (interface{}, error) {
+	req := new(csapproval.TicketListByUserReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	getReq := &itsm.GetTicketsByUserReq{
+		User:     cts.Kit.User,
+		ViewType: itsm.ViewType(req.ViewType),
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	return svc.engine.GetTicketsByUser(cts.Kit, getReq)
+}
+
+// GetTicket 查询单据详情，只有提单人以及单据的审批人可以查看
+func (svc *approvalSvc) GetTicket(cts *rest.Contexts) (interface{}, error) {
+	sn := cts.PathParameter("sn").String()
+	if len(sn) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "sn is required")
+	}
+
+	ticket, err := svc.engine.GetTicket(cts.Kit, sn)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket.Creator == cts.Kit.User {
+		return ticket, nil
+	}
+
+	for _, stage := range ticket.Stages {
+		if slice.IsItemInSlice(stage.Approvers, cts.Kit.User) {
+			return ticket, nil
+		}
+
+		for _, record := range stage.Records {
+			if record.Operator == cts.Kit.User {
+				return ticket, nil
+			}
+		}
+	}
+
+	return nil, errf.New(errf.PermissionDenied, "you can not view the approval ticket of others")
+}
+
+// ApproveTicket 通过当前审批节点
+func (svc *approvalSvc) ApproveTicket(cts *rest.Contexts) (interface{}, error) {
+	sn, req, err := decodeOperateReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, svc.engine.Pass(cts.Kit, sn, req.StateID, cts.Kit.User, req.Memo)
+}
+
+// RejectTicket 拒绝当前审批节点
+func (svc *approvalSvc) RejectTicket(cts *rest.Contexts) (interface{}, error) {
+	sn, req, err := decodeOperateReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, svc.engine.Reject(cts.Kit, sn, req.StateID, cts.Kit.User, req.Memo)
+}
+
+func decodeOperateReq(cts *rest.Contexts) (string, *csapproval.TicketOperateReq, error) {
+	sn := cts.PathParameter("sn").String()
+	if len(sn) == 0 {
+		return "", nil, errf.New(errf.InvalidParameter, "sn is required")
+	}
+
+	req := new(csapproval.TicketOperateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return "", nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return "", nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return sn, req, nil
+}
+
+// TransferTicket 将当前审批节点转给其他审批人
+func (svc *approvalSvc) TransferTicket(cts *rest.Contexts) (interface{}, error) {
+	sn := cts.PathParameter("sn").String()
+	if len(sn) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "sn is required")
+	}
+
+	req := new(csapproval.TicketTransferReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return nil, svc.engine.Transfer(cts.Kit, sn, req.StateID, cts.Kit.User, req.Transferee, req.Memo)
+}
+
+// WithdrawTicket 提单人撤销单据，对应的申请单同时被取消
+func (svc *approvalSvc) WithdrawTicket(cts *rest.Contexts) (interface{}, error) {
+	sn := cts.PathParameter("sn").String()
+	if len(sn) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "sn is required")
+	}
+
+	req := new(csapproval.TicketWithdrawReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return nil, svc.engine.Withdraw(cts.Kit, sn, cts.Kit.User, req.Memo)
+}
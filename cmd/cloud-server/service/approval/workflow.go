@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package approval
+
+import (
+	"hcm/pkg/api/core"
+	dsapproval "hcm/pkg/api/data-service/approval"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateWorkflow 创建审批流，业务为-1表示申请单类型的默认审批流
+func (svc *approvalSvc) CreateWorkflow(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsapproval.WorkflowCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkConfigPermission(cts); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.Approval.CreateWorkflow(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("create approval workflow failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListWorkflow ...
+func (svc *approvalSvc) ListWorkflow(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkConfigPermission(cts); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Approval.ListWorkflow(cts.Kit, req)
+}
+
+// UpdateWorkflow 更新审批流，只影响更新后创建的单据
+func (svc *approvalSvc) UpdateWorkflow(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dsapproval.WorkflowUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkConfigPermission(cts); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Approval.UpdateWorkflow(cts.Kit, id, req); err != nil {
+		logs.Errorf("update approval workflow failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteWorkflow ...
+func (svc *approvalSvc) BatchDeleteWorkflow(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkConfigPermission(cts); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Approval.BatchDeleteWorkflow(cts.Kit, req); err != nil {
+		logs.Errorf("batch delete approval workflow failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
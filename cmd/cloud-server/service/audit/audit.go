@@ -29,12 +29,15 @@ import (
 	coreaudit "hcm/pkg/api/core/audit"
 	"hcm/pkg/api/data-service/audit"
 	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/iam/meta"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
 	"hcm/pkg/tools/hooks/handler"
 )
 
@@ -54,6 +57,12 @@ func InitService(c *capability.Capability) {
 	h.Add("GetBizAudit", http.MethodGet, "/bizs/{bk_biz_id}/audits/{id}", svc.GetBizAudit)
 	h.Add("ListBizAudit", http.MethodPost, "/bizs/{bk_biz_id}/audits/list", svc.ListBizAudit)
 
+	// resource change timeline apis
+	h.Add("ListResChangeEvent", http.MethodPost, "/res_change_events/{res_type}/{res_id}/list",
+		svc.ListResChangeEvent)
+	h.Add("ListBizResChangeEvent", http.MethodPost, "/bizs/{bk_biz_id}/res_change_events/{res_type}/{res_id}/list",
+		svc.ListBizResChangeEvent)
+
 	h.Load(c.WebService)
 }
 
@@ -133,3 +142,67 @@ func (svc svc) listAudit(cts *rest.Contexts, authHandler handler.ListAuthResHand
 	}
 	return svc.client.DataService().Global.Audit.ListAudit(cts.Kit.Ctx, cts.Kit.Header(), listReq)
 }
+
+// ListResChangeEvent list resource change events, which is the change timeline of resource.
+func (svc svc) ListResChangeEvent(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResChangeEvent(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizResChangeEvent list biz resource change events.
+func (svc svc) ListBizResChangeEvent(cts *rest.Contexts) (interface{}, error) {
+	return svc.listResChangeEvent(cts, handler.ListBizAuthRes)
+}
+
+func (svc svc) listResChangeEvent(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{}, error) {
+	resType := enumor.AuditResourceType(cts.PathParameter("res_type").String())
+	if !resType.Exist() {
+		return nil, errf.Newf(errf.InvalidParameter, "res_type: %s not support", resType)
+	}
+
+	resID := cts.PathParameter("res_id").String()
+	if len(resID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "res_id is required")
+	}
+
+	req := new(proto.ResChangeEventListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rules := []filter.RuleFactory{tools.EqualWithOpExpression(filter.And,
+		map[string]interface{}{"res_type": resType, "res_id": resID})}
+	if req.Filter != nil {
+		rules = append(rules, req.Filter)
+	}
+	resFilter, err := tools.And(rules...)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// authorize
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Audit, Action: meta.Find, Filter: resFilter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &audit.ListResChangeEventResult{Count: 0, Details: make([]coreaudit.ResChangeEvent, 0)}, nil
+	}
+
+	// 默认按时间倒序，最近的变更在前
+	if len(req.Page.Sort) == 0 {
+		req.Page.Sort = "id"
+		req.Page.Order = core.Descending
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.Audit.ListResChangeEvent(cts.Kit, listReq)
+}
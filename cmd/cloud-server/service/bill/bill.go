@@ -49,6 +49,9 @@ func InitBillService(c *capability.Capability) {
 
 	h.Add("ListBills", "POST", "/vendors/{vendor}/bills/list", svc.ListBills)
 	h.Add("ListBillsConfig", "POST", "/bills/config/list", svc.ListBillsConfig)
+	h.Add("AggregateBillDaily", "POST", "/bills/daily/aggregate", svc.AggregateBillDaily)
+	h.Add("ListBillDaily", "POST", "/bills/daily/list", svc.ListBillDaily)
+	h.Add("ListBillSyncRecord", "POST", "/bills/sync_records/list", svc.ListBillSyncRecord)
 
 	h.Load(c.WebService)
 }
@@ -0,0 +1,189 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
+	hcbill "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+)
+
+// maxBillSyncMessageLen 账单拉取记录中错误信息的最大长度
+const maxBillSyncMessageLen = 1024
+
+// CloudBillIngest 定时拉取各资源账号最近几天的云账单，按天汇总后存入本地
+func CloudBillIngest(conf cc.BillIngest, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("cloud bill ingest enable && start, syncIntervalMin: %d, lookbackDays: %d", conf.SyncIntervalMin,
+		conf.LookbackDays)
+
+	for {
+		time.Sleep(time.Duration(conf.SyncIntervalMin) * time.Minute)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+
+		start := time.Now()
+		logs.Infof("cloud bill ingest start, time: %v, rid: %s", start, kt.Rid)
+
+		billDates := recentBillDates(start, conf.LookbackDays)
+
+		waitGroup := new(sync.WaitGroup)
+		vendors := []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.HuaWei, enumor.Azure, enumor.Gcp}
+		waitGroup.Add(len(vendors))
+		for _, vendor := range vendors {
+			go func(vendor enumor.Vendor) {
+				defer waitGroup.Done()
+				allAccountBillIngest(kt, cliSet, vendor, billDates)
+			}(vendor)
+		}
+
+		waitGroup.Wait()
+
+		logs.Infof("cloud bill ingest end, cost: %v, rid: %s", time.Since(start), kt.Rid)
+	}
+}
+
+// recentBillDates 返回最近几天的账单日期，不包含当天，当天的账单还未出完
+func recentBillDates(now time.Time, days uint) []string {
+	dates := make([]string, 0, days)
+	for i := int(days); i >= 1; i-- {
+		dates = append(dates, now.AddDate(0, 0, -i).Format(constant.DateLayout))
+	}
+
+	return dates
+}
+
+func allAccountBillIngest(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor, billDates []string) {
+	listReq := &protocloud.AccountListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "type", Op: filter.Equal.Factory(), Value: enumor.ResourceAccount},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: core.DefaultMaxPageLimit,
+		},
+	}
+
+	start := uint32(0)
+	for {
+		listReq.Page.Start = start
+		accounts, err := listAccountWithRetry(kt, cliSet.DataService(), listReq)
+		if err != nil {
+			logs.Errorf("%s bill ingest list account failed, err: %v, rid: %s", vendor, err, kt.Rid)
+			return
+		}
+
+		for _, account := range accounts {
+			for _, billDate := range billDates {
+				accountBillIngest(kt, cliSet, account, billDate)
+			}
+		}
+
+		if len(accounts) < int(core.DefaultMaxPageLimit) {
+			return
+		}
+
+		start += uint32(core.DefaultMaxPageLimit)
+	}
+}
+
+// accountBillIngest 拉取账号某一天的账单并覆盖本地的日汇总数据，失败时记录失败原因，下一轮重新拉取
+func accountBillIngest(kt *kit.Kit, cliSet *client.ClientSet, account *cloud.BaseAccount, billDate string) {
+	pullReq := &hcbill.BillDailyPullReq{AccountID: account.ID, BillDate: billDate}
+
+	var result *hcbill.BillDailyPullResult
+	var err error
+	switch account.Vendor {
+	case enumor.TCloud:
+		result, err = cliSet.HCService().TCloud.Bill.PullDaily(kt, pullReq)
+	case enumor.Aws:
+		result, err = cliSet.HCService().Aws.Bill.PullDaily(kt, pullReq)
+	case enumor.HuaWei:
+		result, err = cliSet.HCService().HuaWei.Bill.PullDaily(kt, pullReq)
+	case enumor.Azure:
+		result, err = cliSet.HCService().Azure.Bill.PullDaily(kt, pullReq)
+	case enumor.Gcp:
+		result, err = cliSet.HCService().Gcp.Bill.PullDaily(kt, pullReq)
+	default:
+		logs.Errorf("bill ingest unsupported vendor: %s, rid: %s", account.Vendor, kt.Rid)
+		return
+	}
+
+	if err == nil {
+		replaceReq := &dsbill.BillDailyItemReplaceReq{
+			Vendor:    account.Vendor,
+			AccountID: account.ID,
+			BkBizID:   accountBillBizID(account),
+			BillDate:  billDate,
+			Items:     result.Items,
+		}
+		err = cliSet.DataService().Global.Bill.ReplaceDailyItem(kt, replaceReq)
+		if err == nil {
+			return
+		}
+	}
+
+	logs.Errorf("%s account bill ingest failed, accountID: %s, date: %s, err: %v, rid: %s", account.Vendor,
+		account.ID, billDate, err, kt.Rid)
+
+	message := err.Error()
+	if len(message) > maxBillSyncMessageLen {
+		message = message[:maxBillSyncMessageLen]
+	}
+	setReq := &dsbill.BillSyncRecordSetReq{
+		Vendor:    account.Vendor,
+		AccountID: account.ID,
+		BillDate:  billDate,
+		State:     enumor.SyncFailed,
+		Message:   message,
+	}
+	if err = cliSet.DataService().Global.Bill.SetSyncRecord(kt, setReq); err != nil {
+		logs.Errorf("set bill sync record failed, req: %+v, err: %v, rid: %s", setReq, err, kt.Rid)
+	}
+}
+
+// accountBillBizID 账号只关联一个业务时，账单归属到该业务，否则标记为未分配业务
+func accountBillBizID(account *cloud.BaseAccount) int64 {
+	if len(account.BkBizIDs) == 1 && account.BkBizIDs[0] != constant.AttachedAllBiz {
+		return account.BkBizIDs[0]
+	}
+
+	return constant.UnassignedBiz
+}
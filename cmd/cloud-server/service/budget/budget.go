@@ -0,0 +1,201 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package budget
+
+import (
+	"time"
+
+	logicsbudget "hcm/cmd/cloud-server/logics/budget"
+	csbudget "hcm/pkg/api/cloud-server/budget"
+	"hcm/pkg/api/core"
+	corebudget "hcm/pkg/api/core/budget"
+	dsbudget "hcm/pkg/api/data-service/budget"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateBudget create budget.
+func (svc *budgetSvc) CreateBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(csbudget.BudgetCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	if req.Scope == enumor.AccountBudgetScope {
+		_, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.AccountCloudResType,
+			req.AccountID)
+		if err != nil {
+			logs.Errorf("get account basic info failed, err: %v, id: %s, rid: %s", err, req.AccountID, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	thresholds := req.Thresholds
+	if len(thresholds) == 0 {
+		thresholds = corebudget.DefaultThresholds
+	}
+
+	createReq := &dsbudget.BudgetCreateReq{
+		Name:       req.Name,
+		Scope:      req.Scope,
+		BkBizID:    req.BkBizID,
+		AccountID:  req.AccountID,
+		Currency:   req.Currency,
+		Amount:     req.Amount,
+		Thresholds: thresholds,
+		Receivers:  req.Receivers,
+		Memo:       req.Memo,
+	}
+	return svc.client.DataService().Global.Budget.CreateBudget(cts.Kit, createReq)
+}
+
+// ListBudget list budget.
+func (svc *budgetSvc) ListBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Budget.ListBudget(cts.Kit, req)
+}
+
+// UpdateBudget update budget.
+func (svc *budgetSvc) UpdateBudget(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csbudget.BudgetUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &dsbudget.BudgetUpdateReq{
+		Name:       req.Name,
+		Amount:     req.Amount,
+		Thresholds: req.Thresholds,
+		Receivers:  req.Receivers,
+		Memo:       req.Memo,
+	}
+	return nil, svc.client.DataService().Global.Budget.UpdateBudget(cts.Kit, id, updateReq)
+}
+
+// BatchDeleteBudget batch delete budget.
+func (svc *budgetSvc) BatchDeleteBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	return nil, svc.client.DataService().Global.Budget.BatchDeleteBudget(cts.Kit, req)
+}
+
+// GetBudgetStatus 查询预算当月的已产生费用、预测的月底费用以及已达到的告警阈值
+func (svc *budgetSvc) GetBudgetStatus(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.checkPermission(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	budget, err := svc.getBudget(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return logicsbudget.GetStatus(cts.Kit, svc.client.DataService(), budget, time.Now())
+}
+
+// ListBudgetAlert list budget alert records.
+func (svc *budgetSvc) ListBudgetAlert(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Budget.ListBudgetAlert(cts.Kit, req)
+}
+
+func (svc *budgetSvc) getBudget(kt *kit.Kit, id string) (*corebudget.Budget, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.Budget.ListBudget(kt, req)
+	if err != nil {
+		logs.Errorf("list budget failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "budget: %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
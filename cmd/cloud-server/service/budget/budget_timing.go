@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package budget
+
+import (
+	"time"
+
+	logicsbudget "hcm/cmd/cloud-server/logics/budget"
+	"hcm/pkg/api/core"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+)
+
+// BudgetEvaluateTiming 定时评估所有预算的当月执行情况，达到告警阈值时进行通知，仅在主节点执行
+func BudgetEvaluateTiming(conf cc.Budget, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	notifier, err := logicsbudget.NewNotifier(conf.Notifier)
+	if err != nil {
+		logs.Errorf("new budget notifier failed, budget evaluate will not start, err: %v", err)
+		return
+	}
+
+	logs.Infof("budget evaluate enable && start, evaluateIntervalMin: %d, notifier: %s", conf.EvaluateIntervalMin,
+		conf.Notifier.Type)
+
+	evaluator := logicsbudget.NewEvaluator(cliSet.DataService(), notifier)
+	for {
+		time.Sleep(time.Duration(conf.EvaluateIntervalMin) * time.Minute)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+
+		start := time.Now()
+		logs.Infof("budget evaluate start, time: %v, rid: %s", start, kt.Rid)
+
+		evaluateAllBudget(kt, cliSet, evaluator, start)
+
+		logs.Infof("budget evaluate end, cost: %v, rid: %s", time.Since(start), kt.Rid)
+	}
+}
+
+func evaluateAllBudget(kt *kit.Kit, cliSet *client.ClientSet, evaluator *logicsbudget.Evaluator, now time.Time) {
+	listReq := &core.ListReq{
+		Filter: tools.AllExpression(),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		result, err := cliSet.DataService().Global.Budget.ListBudget(kt, listReq)
+		if err != nil {
+			logs.Errorf("list budget failed, err: %v, rid: %s", err, kt.Rid)
+			return
+		}
+
+		for idx := range result.Details {
+			budget := &result.Details[idx]
+			// 单个预算评估失败不影响其他预算，失败的告警会在下一轮重新评估
+			if err = evaluator.Evaluate(kt, budget, now); err != nil {
+				logs.Errorf("evaluate budget failed, err: %v, id: %s, rid: %s", err, budget.ID, kt.Rid)
+			}
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package budget 预算管理以及预算告警的定时评估
+package budget
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// InitBudgetService initialize the budget service.
+func InitBudgetService(c *capability.Capability) {
+	svc := &budgetSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateBudget", http.MethodPost, "/budgets/create", svc.CreateBudget)
+	h.Add("ListBudget", http.MethodPost, "/budgets/list", svc.ListBudget)
+	h.Add("UpdateBudget", http.MethodPatch, "/budgets/{id}", svc.UpdateBudget)
+	h.Add("BatchDeleteBudget", http.MethodDelete, "/budgets/batch", svc.BatchDeleteBudget)
+	h.Add("GetBudgetStatus", http.MethodGet, "/budgets/{id}/status", svc.GetBudgetStatus)
+	h.Add("ListBudgetAlert", http.MethodPost, "/budgets/alerts/list", svc.ListBudgetAlert)
+
+	h.Load(c.WebService)
+}
+
+type budgetSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// checkPermission 预算属于费用管理，统一使用费用管理权限
+func (svc *budgetSvc) checkPermission(cts *rest.Contexts, action meta.Action) error {
+	res := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CostManage, Action: action}}
+	_, authorized, err := svc.authorizer.Authorize(cts.Kit, res)
+	if err != nil {
+		return errf.NewFromErr(errf.PermissionDenied,
+			fmt.Errorf("check %s budget permissions failed, err: %v", action, err))
+	}
+
+	if !authorized {
+		return errf.NewFromErr(errf.PermissionDenied, fmt.Errorf("you have not permission of %s", action))
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/emicklei/go-restful/v3"
 
 	"hcm/cmd/cloud-server/logics"
+	"hcm/cmd/cloud-server/logics/approval"
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/pkg/client"
 	"hcm/pkg/cryptography"
@@ -44,4 +45,6 @@ type Capability struct {
 	Logics     *logics.Logics
 	ItsmCli    itsm.Client
 	BKBaseCli  bkbase.Client
+	// NativeApproval 选择内置审批引擎时不为空，与ItsmCli为同一个实例
+	NativeApproval *approval.Native
 }
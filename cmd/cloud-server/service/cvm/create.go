@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"hcm/cmd/cloud-server/logics/async"
+	logicsguardrail "hcm/cmd/cloud-server/logics/guardrail"
 	"hcm/cmd/cloud-server/service/common"
 	actioncvm "hcm/cmd/task-server/logics/action/cvm"
 	cloudserver "hcm/pkg/api/cloud-server"
@@ -33,9 +34,12 @@ import (
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/iam/meta"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+
+	"github.com/tidwall/gjson"
 )
 
 // CreateCvm create cvm.
@@ -61,6 +65,16 @@ func (svc *cvmSvc) CreateCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	target := &logicsguardrail.Target{
+		BkBizID: gjson.GetBytes(req.Data, "bk_biz_id").Int(),
+		ResType: enumor.CvmCloudResType,
+		Vendor:  info.Vendor,
+		Content: string(req.Data),
+	}
+	if err = logicsguardrail.Check(cts.Kit, svc.client.DataService(), target); err != nil {
+		return nil, err
+	}
+
 	tasks := make([]ts.CustomFlowTask, 0)
 	switch info.Vendor {
 	case enumor.TCloud:
@@ -115,6 +129,7 @@ func (svc *cvmSvc) buildCreateAzureCvmTasks(body json.RawMessage) ([]ts.CustomFl
 					Vendor:         enumor.Azure,
 					AzureCreateReq: *common.ConvAzureCvmCreateReq(req),
 				},
+				RateLimitKey: tableasync.NewRateLimitKey(enumor.Azure, req.AccountID, req.Region),
 			}
 		})
 
@@ -143,6 +158,7 @@ func (svc *cvmSvc) buildCreateHuaWeiCvmTasks(body json.RawMessage) ([]ts.CustomF
 					Vendor:               enumor.HuaWei,
 					HuaWeiBatchCreateReq: *common.ConvHuaWeiCvmCreateReq(req),
 				},
+				RateLimitKey: tableasync.NewRateLimitKey(enumor.HuaWei, req.AccountID, req.Region),
 			}
 		})
 
@@ -171,6 +187,7 @@ func (svc *cvmSvc) buildCreateGcpCvmTasks(body json.RawMessage) ([]ts.CustomFlow
 					Vendor:            enumor.Gcp,
 					GcpBatchCreateReq: *common.ConvGcpCvmCreateReq(req),
 				},
+				RateLimitKey: tableasync.NewRateLimitKey(enumor.Gcp, req.AccountID, req.Region),
 			}
 		})
 
@@ -199,6 +216,7 @@ func (svc *cvmSvc) buildCreateAwsCvmTasks(body json.RawMessage) ([]ts.CustomFlow
 					Vendor:            enumor.Aws,
 					AwsBatchCreateReq: *common.ConvAwsCvmCreateReq(req),
 				},
+				RateLimitKey: tableasync.NewRateLimitKey(enumor.Aws, req.AccountID, req.Region),
 			}
 		})
 
@@ -227,6 +245,7 @@ func (svc *cvmSvc) buildCreateTCloudCvmTasks(body json.RawMessage) ([]ts.CustomF
 					Vendor:               enumor.TCloud,
 					TCloudBatchCreateReq: *common.ConvTCloudCvmCreateReq(req),
 				},
+				RateLimitKey: tableasync.NewRateLimitKey(enumor.TCloud, req.AccountID, req.Region),
 			}
 		})
 
@@ -27,6 +27,7 @@ import (
 	"hcm/cmd/cloud-server/logics/cvm"
 	"hcm/cmd/cloud-server/logics/disk"
 	"hcm/cmd/cloud-server/logics/eip"
+	"hcm/cmd/cloud-server/logics/reachability"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
@@ -36,12 +37,13 @@ import (
 // InitCvmService initialize the cvm service.
 func InitCvmService(c *capability.Capability) {
 	svc := &cvmSvc{
-		client:     c.ApiClient,
-		authorizer: c.Authorizer,
-		audit:      c.Audit,
-		diskLgc:    c.Logics.Disk,
-		cvmLgc:     c.Logics.Cvm,
-		eipLgc:     c.Logics.Eip,
+		client:          c.ApiClient,
+		authorizer:      c.Authorizer,
+		audit:           c.Audit,
+		diskLgc:         c.Logics.Disk,
+		cvmLgc:          c.Logics.Cvm,
+		eipLgc:          c.Logics.Eip,
+		reachabilityLgc: reachability.NewReachability(c.ApiClient),
 	}
 
 	h := rest.NewHandler()
@@ -56,6 +58,7 @@ func InitCvmService(c *capability.Capability) {
 	h.Add("BatchStopCvm", http.MethodPost, "/cvms/batch/stop", svc.BatchStopCvm)
 	h.Add("BatchRebootCvm", http.MethodPost, "/cvms/batch/reboot", svc.BatchRebootCvm)
 	h.Add("QueryCvmRelatedRes", http.MethodPost, "/cvms/rel_res/batch", svc.QueryCvmRelatedRes)
+	h.Add("QueryCvmReachability", http.MethodPost, "/cvms/reachability/query", svc.QueryCvmReachability)
 
 	// 资源下回收相关接口
 	h.Add("RecycleCvm", http.MethodPost, "/cvms/recycle", svc.RecycleCvm)
@@ -71,6 +74,8 @@ func InitCvmService(c *capability.Capability) {
 	h.Add("BatchStopBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/batch/stop", svc.BatchStopBizCvm)
 	h.Add("BatchRebootBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/batch/reboot", svc.BatchRebootBizCvm)
 	h.Add("QueryBizCvmRelatedRes", http.MethodPost, "/bizs/{bk_biz_id}/cvms/rel_res/batch", svc.QueryBizCvmRelatedRes)
+	h.Add("QueryBizCvmReachability", http.MethodPost, "/bizs/{bk_biz_id}/cvms/reachability/query",
+		svc.QueryBizCvmReachability)
 
 	// 业务下回收接口
 	h.Add("RecycleBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/cvms/recycle", svc.RecycleBizCvm)
@@ -83,10 +88,11 @@ func InitCvmService(c *capability.Capability) {
 }
 
 type cvmSvc struct {
-	client     *client.ClientSet
-	authorizer auth.Authorizer
-	audit      audit.Interface
-	diskLgc    disk.Interface
-	cvmLgc     cvm.Interface
-	eipLgc     eip.Interface
+	client          *client.ClientSet
+	authorizer      auth.Authorizer
+	audit           audit.Interface
+	diskLgc         disk.Interface
+	cvmLgc          cvm.Interface
+	eipLgc          eip.Interface
+	reachabilityLgc reachability.Interface
 }
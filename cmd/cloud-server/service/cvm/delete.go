@@ -20,6 +20,7 @@
 package cvm
 
 import (
+	logicsapproval "hcm/cmd/cloud-server/logics/approval"
 	"hcm/cmd/cloud-server/logics/async"
 	proto "hcm/pkg/api/cloud-server"
 	dataproto "hcm/pkg/api/data-service/cloud"
@@ -40,6 +41,10 @@ func (svc *cvmSvc) BatchDeleteCvm(cts *rest.Contexts) (interface{}, error) {
 
 // BatchDeleteBizCvm batch delete biz cvm.
 func (svc *cvmSvc) BatchDeleteBizCvm(cts *rest.Contexts) (interface{}, error) {
+	if err := logicsapproval.CheckBizPolicy(cts, svc.client.DataService(), enumor.DeleteCvm, ""); err != nil {
+		return nil, err
+	}
+
 	return svc.batchDeleteCvmSvc(cts, handler.BizOperateAuth)
 }
 
@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// QueryCvmReachability query reachability between two cvms.
+func (svc *cvmSvc) QueryCvmReachability(cts *rest.Contexts) (interface{}, error) {
+	return svc.queryCvmReachability(cts, handler.ResOperateAuth)
+}
+
+// QueryBizCvmReachability query reachability between two cvms in biz.
+func (svc *cvmSvc) QueryBizCvmReachability(cts *rest.Contexts) (interface{}, error) {
+	return svc.queryCvmReachability(cts, handler.BizOperateAuth)
+}
+
+// queryCvmReachability 基于本地同步的网络资源及安全组规则判断两台主机之间的流量是否可达，不调用云厂商接口
+func (svc *cvmSvc) queryCvmReachability(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(cscvm.CvmReachabilityReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := []string{req.SourceCvmID, req.DestinationCvmID}
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{ResourceType: enumor.CvmCloudResType, IDs: ids}
+	basicInfo, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Cvm,
+		Action: meta.Find, BasicInfos: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{Filter: tools.ContainersExpression("id", ids), Page: core.NewDefaultBasePage()}
+	cvms, err := svc.client.DataService().Global.Cvm.ListCvm(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list cvm failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+
+	cvmMap := make(map[string]int, len(cvms.Details))
+	for idx := range cvms.Details {
+		cvmMap[cvms.Details[idx].ID] = idx
+	}
+
+	for _, id := range ids {
+		if _, exist := cvmMap[id]; !exist {
+			return nil, errf.Newf(errf.RecordNotFound, "cvm: %s not found", id)
+		}
+	}
+
+	src, dst := &cvms.Details[cvmMap[req.SourceCvmID]], &cvms.Details[cvmMap[req.DestinationCvmID]]
+	result, err := svc.reachabilityLgc.Query(cts.Kit, src, dst, req.Protocol, req.Port)
+	if err != nil {
+		logs.Errorf("query cvm reachability failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
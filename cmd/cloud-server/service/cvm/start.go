@@ -33,6 +33,7 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/types"
+	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/iam/meta"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
@@ -135,10 +136,11 @@ func buildOperationTasks(actionName enumor.ActionName, basicInfoMap map[string]t
 	count := 1
 	for _, one := range paramMaps {
 		tasks = append(tasks, ts.CustomFlowTask{
-			ActionID:   action.ActIDType(strconv.Itoa(count)),
-			ActionName: actionName,
-			Params:     *one,
-			DependOn:   nil,
+			ActionID:     action.ActIDType(strconv.Itoa(count)),
+			ActionName:   actionName,
+			Params:       *one,
+			DependOn:     nil,
+			RateLimitKey: tableasync.NewRateLimitKey(one.Vendor, one.AccountID, one.Region),
 		})
 		count++
 	}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	logicsguardrail "hcm/cmd/cloud-server/logics/guardrail"
 	"hcm/cmd/cloud-server/service/common"
 	cloudserver "hcm/pkg/api/cloud-server"
 	csdisk "hcm/pkg/api/cloud-server/disk"
@@ -30,6 +31,8 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+
+	"github.com/tidwall/gjson"
 )
 
 // CreateDisk create disk.
@@ -53,6 +56,16 @@ func (svc *diskSvc) CreateDisk(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	target := &logicsguardrail.Target{
+		BkBizID: gjson.GetBytes(req.Data, "bk_biz_id").Int(),
+		ResType: enumor.DiskCloudResType,
+		Vendor:  info.Vendor,
+		Content: string(req.Data),
+	}
+	if err = logicsguardrail.Check(cts.Kit, svc.client.DataService(), target); err != nil {
+		return nil, err
+	}
+
 	switch info.Vendor {
 	case enumor.TCloud:
 		return svc.createTCloudDisk(cts.Kit, req.Data)
@@ -22,20 +22,17 @@ import (
 
 	"hcm/cmd/cloud-server/logics/recycle"
 	csdisk "hcm/pkg/api/cloud-server/disk"
-	csrecycle "hcm/pkg/api/cloud-server/recycle"
 	"hcm/pkg/api/core"
 	corerr "hcm/pkg/api/core/recycle-record"
 	protoaudit "hcm/pkg/api/data-service/audit"
 	"hcm/pkg/api/data-service/cloud"
 	dsrr "hcm/pkg/api/data-service/recycle-record"
-	"hcm/pkg/cc"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/iam/meta"
-	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/tools/hooks/handler"
@@ -62,11 +59,8 @@ func (svc *diskSvc) recycleDiskSvc(cts *rest.Contexts, validHandler handler.Vali
 	}
 
 	ids := make([]string, 0, len(req.Infos))
-	auditInfos := make([]protoaudit.CloudResRecycleAuditInfo, 0, len(req.Infos))
 	for _, info := range req.Infos {
 		ids = append(ids, info.ID)
-		auditInfos = append(auditInfos,
-			protoaudit.CloudResRecycleAuditInfo{ResID: info.ID, Data: info.DiskRecycleOptions})
 	}
 
 	basicInfoReq := cloud.ListResourceBasicInfoReq{
@@ -86,133 +80,7 @@ func (svc *diskSvc) recycleDiskSvc(cts *rest.Contexts, validHandler handler.Vali
 		return nil, err
 	}
 
-	// create recycle audit
-	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
-		ResType: enumor.DiskAuditResType,
-		Action:  protoaudit.Recycle,
-		Infos:   auditInfos,
-	}
-	if err = svc.audit.ResRecycleAudit(cts.Kit, auditReq); err != nil {
-		logs.Errorf("create recycle audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
-	}
-
-	return svc.recycleDisk(cts.Kit, req, ids, basicInfoMap)
-}
-
-func (svc *diskSvc) recycleDisk(kt *kit.Kit, req *csdisk.DiskRecycleReq, ids []string,
-	infoMap map[string]types.CloudResourceBasicInfo) (interface{}, error) {
-
-	// detach disk from cvm
-	detachRes, err := svc.detachDiskByIDs(kt, ids, infoMap)
-	if err != nil {
-		logs.Errorf("detach disks failed, err: %v, ids: +v, result: %+v, rid: %s", err, ids, detachRes, kt.Rid)
-		return nil, err
-	}
-
-	res := new(core.BatchOperateAllResult)
-
-	failedIDMap := make(map[string]struct{})
-	if detachRes != nil {
-		if len(detachRes.Failed) == len(ids) {
-			return res, res.Failed[0].Error
-		}
-
-		res.Failed = detachRes.Failed
-		for _, info := range detachRes.Failed {
-			failedIDMap[info.ID] = struct{}{}
-		}
-	}
-
-	// create recycle record
-	opt := &dsrr.BatchRecycleReq{
-		ResType:            enumor.DiskCloudResType,
-		DefaultRecycleTime: cc.CloudServer().Recycle.AutoDeleteTime,
-		Infos:              make([]dsrr.RecycleReq, 0),
-	}
-	for _, info := range req.Infos {
-		if _, exists := failedIDMap[info.ID]; exists {
-			continue
-		}
-		opt.Infos = append(opt.Infos, dsrr.RecycleReq{
-			ID:     info.ID,
-			Detail: info.DiskRecycleOptions,
-		})
-	}
-
-	taskID, err := svc.client.DataService().Global.RecycleRecord.BatchRecycleCloudRes(kt, opt)
-	if err != nil {
-		for _, id := range detachRes.Succeeded {
-			res.Failed = append(res.Failed, core.FailedInfo{ID: id, Error: err})
-		}
-		return res, err
-	}
-
-	if len(res.Failed) > 0 {
-		return res, res.Failed[0].Error
-	}
-	return &csrecycle.RecycleResult{TaskID: taskID}, nil
-}
-
-func (svc *diskSvc) detachDiskByIDs(kt *kit.Kit, ids []string, basicInfoMap map[string]types.CloudResourceBasicInfo) (
-	*core.BatchOperateAllResult, error) {
-
-	if len(ids) == 0 {
-		return nil, nil
-	}
-
-	if len(ids) > constant.BatchOperationMaxLimit {
-		return nil, errf.Newf(errf.InvalidParameter, "ids should <= %d", constant.BatchOperationMaxLimit)
-	}
-
-	listReq := &core.ListReq{
-		Filter: tools.ContainersExpression("disk_id", ids),
-		Page:   core.NewDefaultBasePage(),
-	}
-	relRes, err := svc.client.DataService().Global.ListDiskCvmRel(kt, listReq)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(relRes.Details) == 0 {
-		return nil, nil
-	}
-
-	res := &core.BatchOperateAllResult{
-		Succeeded: make([]string, 0),
-		Failed:    make([]core.FailedInfo, 0),
-	}
-
-	diskCvmMap := make(map[string]string)
-	for _, detail := range relRes.Details {
-		diskCvmMap[detail.DiskID] = detail.CvmID
-	}
-
-	for _, id := range ids {
-		cvmID, exists := diskCvmMap[id]
-		if !exists {
-			res.Succeeded = append(res.Succeeded, id)
-			continue
-		}
-
-		info, exists := basicInfoMap[id]
-		if !exists {
-			res.Succeeded = append(res.Succeeded, id)
-			continue
-		}
-
-		err = svc.diskLgc.DetachDisk(kt, info.Vendor, cvmID, id)
-		if err != nil {
-			res.Failed = append(res.Failed, core.FailedInfo{ID: id, Error: err})
-			continue
-		}
-		res.Succeeded = append(res.Succeeded, id)
-	}
-
-	if len(res.Failed) > 0 {
-		return res, res.Failed[0].Error
-	}
-	return res, nil
+	return svc.diskLgc.RecycleDisk(cts.Kit, req.Infos, basicInfoMap)
 }
 
 // validateRecycleRecord 只能批量处理处于同一个回收任务的且是等待回收的记录。
@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package drift
+
+import (
+	logicsdrift "hcm/cmd/cloud-server/logics/drift"
+	csdrift "hcm/pkg/api/cloud-server/drift"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// ListDesiredState 查询资源的期望状态以及最近一次漂移检测的状态、差异
+func (svc *driftSvc) ListDesiredState(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkFindPermission(cts); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Drift.ListDesiredState(cts.Kit, req)
+}
+
+// CheckDrift 立即对指定的期望状态进行漂移检测，返回检测后的期望状态
+func (svc *driftSvc) CheckDrift(cts *rest.Contexts) (interface{}, error) {
+	req := new(csdrift.CheckReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkFindPermission(cts); err != nil {
+		return nil, err
+	}
+
+	expr := tools.ContainersExpression("id", req.IDs)
+	if _, err := logicsdrift.Check(cts.Kit, svc.client.DataService(), expr); err != nil {
+		logs.Errorf("check drift failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	listReq := &core.ListReq{Filter: expr, Page: core.NewDefaultBasePage()}
+	return svc.client.DataService().Global.Drift.ListDesiredState(cts.Kit, listReq)
+}
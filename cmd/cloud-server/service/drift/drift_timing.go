@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package drift
+
+import (
+	"time"
+
+	logicsdrift "hcm/cmd/cloud-server/logics/drift"
+	"hcm/pkg/api/core"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+)
+
+// DriftCheckTiming 定时对比全部期望状态与最新同步到的资源，保存漂移状态及差异
+func DriftCheckTiming(conf cc.Drift, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("drift check enable && start, checkIntervalMin: %d", conf.CheckIntervalMin)
+
+	for {
+		time.Sleep(time.Duration(conf.CheckIntervalMin) * time.Minute)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+
+		start := time.Now()
+		logs.Infof("drift check run start, time: %v, rid: %s", start, kt.Rid)
+
+		count, err := logicsdrift.Check(kt, cliSet.DataService(), tools.AllExpression())
+		if err != nil {
+			logs.Errorf("drift check failed, err: %v, rid: %s", err, kt.Rid)
+		}
+
+		logs.Infof("drift check run end, count: %d, cost: %v, rid: %s", count, time.Since(start), kt.Rid)
+	}
+}
@@ -0,0 +1,218 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package drift
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	logicsdrift "hcm/cmd/cloud-server/logics/drift"
+	actioncvm "hcm/cmd/task-server/logics/action/cvm"
+	csdrift "hcm/pkg/api/cloud-server/drift"
+	"hcm/pkg/api/core"
+	coredrift "hcm/pkg/api/core/drift"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/action"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ReconcileDrift 创建异步任务流将发生漂移的资源恢复为期望状态，目前只支持恢复主机的开关机状态，
+// 其他属性的漂移需要人工处理，任务流执行完成后由下次漂移检测更新漂移状态。
+func (svc *driftSvc) ReconcileDrift(cts *rest.Contexts) (interface{}, error) {
+	req := new(csdrift.ReconcileReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{Filter: tools.ContainersExpression("id", req.IDs), Page: core.NewDefaultBasePage()}
+	states, err := svc.client.DataService().Global.Drift.ListDesiredState(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list desired state failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	powerStateIDs, skipped := classifyReconcile(states.Details)
+	result := &csdrift.ReconcileResult{Skipped: skipped}
+	if len(powerStateIDs) == 0 {
+		return result, nil
+	}
+
+	cvmIDs := make([]string, 0)
+	for _, ids := range powerStateIDs {
+		cvmIDs = append(cvmIDs, ids...)
+	}
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.CvmCloudResType,
+		IDs:          cvmIDs,
+		Fields:       append(types.CommonBasicInfoFields, "region"),
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]ts.CustomFlowTask, 0)
+	for _, one := range []struct {
+		powerState string
+		actionName enumor.ActionName
+		authAction meta.Action
+		audit      protoaudit.OperationAction
+	}{
+		{logicsdrift.PowerStateRunning, enumor.ActionStartCvm, meta.Start, protoaudit.Start},
+		{logicsdrift.PowerStateStopped, enumor.ActionStopCvm, meta.Stop, protoaudit.Stop},
+	} {
+		ids := powerStateIDs[one.powerState]
+		if len(ids) == 0 {
+			continue
+		}
+
+		infos := make(map[string]types.CloudResourceBasicInfo, len(ids))
+		for _, id := range ids {
+			if info, exist := basicInfoMap[id]; exist {
+				infos[id] = info
+			}
+		}
+
+		err = handler.ResOperateAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+			ResType: meta.Cvm, Action: one.authAction, BasicInfos: infos})
+		if err != nil {
+			return nil, err
+		}
+
+		if err = svc.audit.ResBaseOperationAudit(cts.Kit, enumor.CvmAuditResType, one.audit, ids); err != nil {
+			logs.Errorf("create operation audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		actionTasks, err := buildCvmOperationTasks(one.actionName, infos, len(tasks)+1)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, actionTasks...)
+	}
+
+	if len(tasks) == 0 {
+		return result, nil
+	}
+
+	addReq := &ts.AddCustomFlowReq{
+		Name:  enumor.FlowReconcileDrift,
+		Tasks: tasks,
+	}
+	flow, err := svc.client.TaskServer().CreateCustomFlow(cts.Kit, addReq)
+	if err != nil {
+		logs.Errorf("call taskserver to create custom flow failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	result.FlowID = flow.ID
+	return result, nil
+}
+
+// classifyReconcile 按期望的开关机状态对需要恢复的主机分组，并返回不支持恢复的期望状态
+func classifyReconcile(states []coredrift.DesiredState) (map[string][]string, []csdrift.ReconcileSkip) {
+	powerStateIDs := make(map[string][]string)
+	skipped := make([]csdrift.ReconcileSkip, 0)
+	for _, state := range states {
+		if state.DriftStatus != enumor.DriftedDriftStatus {
+			skipped = append(skipped, csdrift.ReconcileSkip{ID: state.ID,
+				Reason: fmt.Sprintf("drift status is %s", state.DriftStatus)})
+			continue
+		}
+
+		unsupported := make([]string, 0)
+		for _, diff := range state.DriftDetail {
+			if state.ResType == enumor.CvmCloudResType && diff.Field == logicsdrift.FieldPowerState {
+				desired, _ := diff.Desired.(string)
+				powerStateIDs[desired] = append(powerStateIDs[desired], state.ResID)
+				continue
+			}
+			unsupported = append(unsupported, diff.Field)
+		}
+
+		if len(unsupported) != 0 {
+			skipped = append(skipped, csdrift.ReconcileSkip{ID: state.ID,
+				Reason: fmt.Sprintf("%s fields %s not support reconcile", state.ResType,
+					strings.Join(unsupported, ","))})
+		}
+	}
+
+	return powerStateIDs, skipped
+}
+
+// buildCvmOperationTasks 与主机开关机接口一致，tcloud、aws、huawei按账号、地域批量操作，azure、gcp逐台操作
+func buildCvmOperationTasks(actionName enumor.ActionName, infos map[string]types.CloudResourceBasicInfo,
+	startID int) ([]ts.CustomFlowTask, error) {
+
+	paramMaps := make(map[string]*actioncvm.CvmOperationOption)
+	for _, info := range infos {
+		switch info.Vendor {
+		case enumor.TCloud, enumor.Aws, enumor.HuaWei:
+			key := info.AccountID + "_" + info.Region
+			if _, exist := paramMaps[key]; !exist {
+				paramMaps[key] = &actioncvm.CvmOperationOption{
+					Vendor:    info.Vendor,
+					AccountID: info.AccountID,
+					Region:    info.Region,
+					IDs:       make([]string, 0),
+				}
+			}
+			paramMaps[key].IDs = append(paramMaps[key].IDs, info.ID)
+
+		case enumor.Azure, enumor.Gcp:
+			paramMaps[info.AccountID+"_"+info.ID] = &actioncvm.CvmOperationOption{
+				Vendor:    info.Vendor,
+				AccountID: info.AccountID,
+				IDs:       []string{info.ID},
+			}
+
+		default:
+			return nil, fmt.Errorf("vendor: %s not support", info.Vendor)
+		}
+	}
+
+	tasks := make([]ts.CustomFlowTask, 0, len(paramMaps))
+	count := startID
+	for _, one := range paramMaps {
+		tasks = append(tasks, ts.CustomFlowTask{
+			ActionID:     action.ActIDType(strconv.Itoa(count)),
+			ActionName:   actionName,
+			Params:       *one,
+			RateLimitKey: tableasync.NewRateLimitKey(one.Vendor, one.AccountID, one.Region),
+		})
+		count++
+	}
+	return tasks, nil
+}
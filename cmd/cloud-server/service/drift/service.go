@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package drift 资源配置漂移检测，包括期望状态查询、立即检测、恢复期望状态以及定时检测
+package drift
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// InitDriftService initialize the drift service.
+func InitDriftService(c *capability.Capability) {
+	svc := &driftSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListDesiredState", http.MethodPost, "/drifts/desired_states/list", svc.ListDesiredState)
+	h.Add("CheckDrift", http.MethodPost, "/drifts/check", svc.CheckDrift)
+	h.Add("ReconcileDrift", http.MethodPost, "/drifts/reconcile", svc.ReconcileDrift)
+
+	h.Load(c.WebService)
+}
+
+type driftSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
+
+// checkFindPermission 期望状态包括多种资源，查询、检测统一使用资源查看权限
+func (svc *driftSvc) checkFindPermission(cts *rest.Contexts) error {
+	res := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CloudResource, Action: meta.Find}}
+	_, authorized, err := svc.authorizer.Authorize(cts.Kit, res)
+	if err != nil {
+		return errf.NewFromErr(errf.PermissionDenied,
+			fmt.Errorf("check drift find permissions failed, err: %v", err))
+	}
+
+	if !authorized {
+		return errf.NewFromErr(errf.PermissionDenied, fmt.Errorf("you have not permission of %s", meta.Find))
+	}
+
+	return nil
+}
@@ -22,6 +22,7 @@ package eip
 import (
 	"fmt"
 
+	logicsapproval "hcm/cmd/cloud-server/logics/approval"
 	cloudproto "hcm/pkg/api/cloud-server/eip"
 	protoaudit "hcm/pkg/api/data-service/audit"
 	"hcm/pkg/api/data-service/cloud"
@@ -41,6 +42,10 @@ func (svc *eipSvc) AssociateEip(cts *rest.Contexts) (interface{}, error) {
 
 // AssociateBizEip associate biz eip.
 func (svc *eipSvc) AssociateBizEip(cts *rest.Contexts) (interface{}, error) {
+	if err := logicsapproval.CheckBizPolicy(cts, svc.client.DataService(), enumor.AssociateEip, ""); err != nil {
+		return nil, err
+	}
+
 	return svc.associateEip(cts, handler.BizOperateAuth)
 }
 
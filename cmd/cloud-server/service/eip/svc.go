@@ -22,9 +22,11 @@ package eip
 import (
 	"fmt"
 
+	logicsapproval "hcm/cmd/cloud-server/logics/approval"
 	"hcm/cmd/cloud-server/logics/async"
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/logics/eip"
+	logicsguardrail "hcm/cmd/cloud-server/logics/guardrail"
 	"hcm/cmd/cloud-server/service/common"
 	"hcm/cmd/cloud-server/service/eip/aws"
 	"hcm/cmd/cloud-server/service/eip/azure"
@@ -323,6 +325,7 @@ func (svc *eipSvc) CreateBizEip(cts *rest.Contexts) (interface{}, error) {
 	if err != nil {
 		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
 	}
+
 	return svc.createEip(cts, bkBizID, handler.BizOperateAuth)
 }
 
@@ -349,6 +352,29 @@ func (svc *eipSvc) createEip(cts *rest.Contexts, bizID int64,
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	// 业务下创建弹性IP需要校验审批策略
+	if bizID != constant.UnassignedBiz {
+		err = logicsapproval.CheckPolicy(cts.Kit, svc.client.DataService(), bizID, enumor.CreateEip, baseInfo.Vendor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := cts.RequestBody()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	target := &logicsguardrail.Target{
+		BkBizID: bizID,
+		ResType: enumor.EipCloudResType,
+		Vendor:  baseInfo.Vendor,
+		Content: string(body),
+	}
+	if err = logicsguardrail.Check(cts.Kit, svc.client.DataService(), target); err != nil {
+		return nil, err
+	}
+
 	switch baseInfo.Vendor {
 	case enumor.TCloud:
 		return svc.tcloud.CreateEip(cts, bizID)
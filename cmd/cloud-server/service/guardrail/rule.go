@@ -0,0 +1,167 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package guardrail
+
+import (
+	logicsguardrail "hcm/cmd/cloud-server/logics/guardrail"
+	csguardrail "hcm/pkg/api/cloud-server/guardrail"
+	"hcm/pkg/api/core"
+	dsguardrail "hcm/pkg/api/data-service/guardrail"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateRule 创建管控规则，业务为-1表示规则适用于全部业务
+func (svc *guardrailSvc) CreateRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsguardrail.RuleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkConfigPermission(cts); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.Guardrail.CreateRule(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("create guardrail rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListRule ...
+func (svc *guardrailSvc) ListRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkConfigPermission(cts); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Guardrail.ListRule(cts.Kit, req)
+}
+
+// UpdateRule 更新管控规则，已提交的申请单不受影响
+func (svc *guardrailSvc) UpdateRule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dsguardrail.RuleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkConfigPermission(cts); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Guardrail.UpdateRule(cts.Kit, id, req); err != nil {
+		logs.Errorf("update guardrail rule failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteRule ...
+func (svc *guardrailSvc) BatchDeleteRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkConfigPermission(cts); err != nil {
+		return nil, err
+	}
+
+	if err := svc.client.DataService().Global.Guardrail.BatchDeleteRule(cts.Kit, req); err != nil {
+		logs.Errorf("batch delete guardrail rule failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// resMetaTypes 管控规则的资源类型对应的鉴权资源类型
+var resMetaTypes = map[enumor.CloudResourceType]meta.ResourceType{
+	enumor.CvmCloudResType:    meta.Cvm,
+	enumor.DiskCloudResType:   meta.Disk,
+	enumor.VpcCloudResType:    meta.Vpc,
+	enumor.SubnetCloudResType: meta.Subnet,
+	enumor.EipCloudResType:    meta.Eip,
+}
+
+// EvaluateRule 规则校验试运行，返回请求内容违反的全部规则，不会创建资源。
+// 业务下的试运行需要业务下资源的申请权限，未分配业务的试运行需要管控规则的配置权限
+func (svc *guardrailSvc) EvaluateRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(csguardrail.EvaluateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if req.BkBizID > 0 {
+		authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: resMetaTypes[req.ResType], Action: meta.Apply},
+			BizID: req.BkBizID}
+		if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := svc.checkConfigPermission(cts); err != nil {
+			return nil, err
+		}
+	}
+
+	target := &logicsguardrail.Target{
+		BkBizID: req.BkBizID,
+		ResType: req.ResType,
+		Vendor:  req.Vendor,
+		Content: string(req.Content),
+	}
+	return logicsguardrail.EvaluateTarget(cts.Kit, svc.client.DataService(), target)
+}
@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package guardrail 资源创建管控规则相关接口，包括规则管理以及规则校验试运行
+package guardrail
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// InitGuardrailService initialize the guardrail service.
+func InitGuardrailService(c *capability.Capability) {
+	svc := &guardrailSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateGuardrailRule", http.MethodPost, "/guardrail/rules/create", svc.CreateRule)
+	h.Add("ListGuardrailRule", http.MethodPost, "/guardrail/rules/list", svc.ListRule)
+	h.Add("UpdateGuardrailRule", http.MethodPatch, "/guardrail/rules/{id}", svc.UpdateRule)
+	h.Add("BatchDeleteGuardrailRule", http.MethodDelete, "/guardrail/rules/batch", svc.BatchDeleteRule)
+	h.Add("EvaluateGuardrailRule", http.MethodPost, "/guardrail/rules/evaluate", svc.EvaluateRule)
+
+	h.Load(c.WebService)
+}
+
+type guardrailSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// checkConfigPermission 管控规则对全部业务的资源创建生效，与录入账号一样属于平台管理，统一使用录入账号的权限
+func (svc *guardrailSvc) checkConfigPermission(cts *rest.Contexts) error {
+	res := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Account, Action: meta.Import}}
+	_, authorized, err := svc.authorizer.Authorize(cts.Kit, res)
+	if err != nil {
+		return errf.NewFromErr(errf.PermissionDenied,
+			fmt.Errorf("check guardrail config permissions failed, err: %v", err))
+	}
+
+	if !authorized {
+		return errf.NewFromErr(errf.PermissionDenied, fmt.Errorf("you have not permission of guardrail config"))
+	}
+
+	return nil
+}
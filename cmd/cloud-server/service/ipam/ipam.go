@@ -0,0 +1,220 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	logicsipam "hcm/cmd/cloud-server/logics/ipam"
+	csipam "hcm/pkg/api/cloud-server/ipam"
+	"hcm/pkg/api/core"
+	coreipam "hcm/pkg/api/core/ipam"
+	dsipam "hcm/pkg/api/data-service/ipam"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreatePool create ipam pool.
+func (svc *ipamSvc) CreatePool(cts *rest.Contexts) (interface{}, error) {
+	req := new(csipam.PoolCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Create); err != nil {
+		return nil, err
+	}
+
+	createReq := &dsipam.PoolCreateReq{
+		Name:          req.Name,
+		Cidr:          req.Cidr,
+		Region:        req.Region,
+		BkBizID:       req.BkBizID,
+		VpcMaskLen:    req.VpcMaskLen,
+		SubnetMaskLen: req.SubnetMaskLen,
+		Memo:          req.Memo,
+	}
+	return svc.client.DataService().Global.IPAM.CreatePool(cts.Kit, createReq)
+}
+
+// ListPool list ipam pool.
+func (svc *ipamSvc) ListPool(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.IPAM.ListPool(cts.Kit, req)
+}
+
+// UpdatePool update ipam pool.
+func (svc *ipamSvc) UpdatePool(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csipam.PoolUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Update); err != nil {
+		return nil, err
+	}
+
+	// 掩码长度需要与地址池网段以及另一个掩码长度一起校验
+	if req.VpcMaskLen != 0 || req.SubnetMaskLen != 0 {
+		pool, err := svc.getPool(cts.Kit, id)
+		if err != nil {
+			return nil, err
+		}
+
+		vpcMaskLen, subnetMaskLen := pool.VpcMaskLen, pool.SubnetMaskLen
+		if req.VpcMaskLen != 0 {
+			vpcMaskLen = req.VpcMaskLen
+		}
+		if req.SubnetMaskLen != 0 {
+			subnetMaskLen = req.SubnetMaskLen
+		}
+
+		if err = coreipam.ValidatePool(pool.Cidr, vpcMaskLen, subnetMaskLen); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	updateReq := &dsipam.PoolUpdateReq{
+		Name:          req.Name,
+		VpcMaskLen:    req.VpcMaskLen,
+		SubnetMaskLen: req.SubnetMaskLen,
+		Memo:          req.Memo,
+	}
+	return nil, svc.client.DataService().Global.IPAM.UpdatePool(cts.Kit, id, updateReq)
+}
+
+// BatchDeletePool batch delete ipam pool.
+func (svc *ipamSvc) BatchDeletePool(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	return nil, svc.client.DataService().Global.IPAM.BatchDeletePool(cts.Kit, req)
+}
+
+// AllocateCidr 从匹配业务、地域的地址池中建议不与已同步vpc重叠的vpc网段及其第一个子网网段，建议结果不做预留。
+func (svc *ipamSvc) AllocateCidr(cts *rest.Contexts) (interface{}, error) {
+	req := new(csipam.AllocateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	opt := &logicsipam.AllocateOption{
+		BkBizID:       req.BkBizID,
+		Region:        req.Region,
+		VpcMaskLen:    req.VpcMaskLen,
+		SubnetMaskLen: req.SubnetMaskLen,
+	}
+	return logicsipam.Allocate(cts.Kit, svc.client.DataService(), opt)
+}
+
+// SuggestSubnetCidr 在vpc网段中建议不与已有子网重叠的子网网段
+func (svc *ipamSvc) SuggestSubnetCidr(cts *rest.Contexts) (interface{}, error) {
+	vpcID := cts.PathParameter("vpc_id").String()
+	if len(vpcID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "vpc_id is required")
+	}
+
+	req := new(csipam.SubnetSuggestReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkPermission(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return logicsipam.SuggestSubnet(cts.Kit, svc.client.DataService(), vpcID, req.MaskLen)
+}
+
+// GetConflictReport 查询全部已同步vpc之间的网段冲突报告
+func (svc *ipamSvc) GetConflictReport(cts *rest.Contexts) (interface{}, error) {
+	if err := svc.checkPermission(cts, meta.Find); err != nil {
+		return nil, err
+	}
+
+	return logicsipam.ConflictReport(cts.Kit, svc.client.DataService())
+}
+
+func (svc *ipamSvc) getPool(kt *kit.Kit, id string) (*coreipam.Pool, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.IPAM.ListPool(kt, req)
+	if err != nil {
+		logs.Errorf("list ipam pool failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "ipam pool: %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
@@ -0,0 +1,75 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package ipam IP地址管理，包括地址池管理、vpc及子网网段建议以及vpc网段冲突报告
+package ipam
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// InitIPAMService initialize the ipam service.
+func InitIPAMService(c *capability.Capability) {
+	svc := &ipamSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateIPAMPool", http.MethodPost, "/ipam/pools/create", svc.CreatePool)
+	h.Add("ListIPAMPool", http.MethodPost, "/ipam/pools/list", svc.ListPool)
+	h.Add("UpdateIPAMPool", http.MethodPatch, "/ipam/pools/{id}", svc.UpdatePool)
+	h.Add("BatchDeleteIPAMPool", http.MethodDelete, "/ipam/pools/batch", svc.BatchDeletePool)
+
+	h.Add("AllocateIPAMCidr", http.MethodPost, "/ipam/cidrs/allocate", svc.AllocateCidr)
+	h.Add("SuggestIPAMSubnetCidr", http.MethodPost, "/ipam/vpcs/{vpc_id}/subnets/suggest", svc.SuggestSubnetCidr)
+	h.Add("GetIPAMConflictReport", http.MethodGet, "/ipam/conflicts/report", svc.GetConflictReport)
+
+	h.Load(c.WebService)
+}
+
+type ipamSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// checkPermission 地址池用于vpc、子网的网段规划，统一使用vpc资源的权限
+func (svc *ipamSvc) checkPermission(cts *rest.Contexts, action meta.Action) error {
+	res := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Vpc, Action: action}}
+	_, authorized, err := svc.authorizer.Authorize(cts.Kit, res)
+	if err != nil {
+		return errf.NewFromErr(errf.PermissionDenied,
+			fmt.Errorf("check %s ipam permissions failed, err: %v", action, err))
+	}
+
+	if !authorized {
+		return errf.NewFromErr(errf.PermissionDenied, fmt.Errorf("you have not permission of %s", action))
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	cskeypair "hcm/pkg/api/cloud-server/key-pair"
+	"hcm/pkg/api/core"
+	hckeypair "hcm/pkg/api/hc-service/key-pair"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/sshkey"
+)
+
+// CreateKeyPair create key pair.
+func (svc *keyPairSvc) CreateKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.createKeyPair(cts, handler.ResOperateAuth, constant.UnassignedBiz)
+}
+
+// CreateBizKeyPair create biz key pair.
+func (svc *keyPairSvc) CreateBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.createKeyPair(cts, handler.BizOperateAuth, bizID)
+}
+
+func (svc *keyPairSvc) createKeyPair(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	bizID int64) (interface{}, error) {
+
+	req := new(cskeypair.KeyPairCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfo := &types.CloudResourceBasicInfo{AccountID: req.AccountID}
+	err := validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.KeyPair,
+		Action: meta.Create, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	accountInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.AccountCloudResType,
+		req.AccountID)
+	if err != nil {
+		logs.Errorf("get account basic info failed, id: %s, err: %v, rid: %s", req.AccountID, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 私钥只在创建时生成一次，加密后保存，公钥导入到云上
+	privateKey, publicKey, err := sshkey.GenerateRSA(sshkey.DefaultRSABits)
+	if err != nil {
+		logs.Errorf("generate rsa key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	createReq := &hckeypair.KeyPairCreateReq{
+		AccountID:  req.AccountID,
+		Region:     req.Region,
+		Name:       req.Name,
+		PublicKey:  publicKey,
+		PrivateKey: svc.cipher.EncryptToBase64(privateKey),
+		BkBizID:    bizID,
+		Memo:       req.Memo,
+	}
+	return svc.createVendorKeyPair(cts.Kit, accountInfo.Vendor, createReq)
+}
+
+func (svc *keyPairSvc) createVendorKeyPair(kt *kit.Kit, vendor enumor.Vendor, req *hckeypair.KeyPairCreateReq) (
+	*core.CreateResult, error) {
+
+	var result *core.CreateResult
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		result, err = svc.client.HCService().TCloud.KeyPair.CreateKeyPair(kt, req)
+	case enumor.Aws:
+		result, err = svc.client.HCService().Aws.KeyPair.CreateKeyPair(kt, req)
+	case enumor.HuaWei:
+		result, err = svc.client.HCService().HuaWei.KeyPair.CreateKeyPair(kt, req)
+	case enumor.Gcp:
+		result, err = svc.client.HCService().Gcp.KeyPair.CreateKeyPair(kt, req)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", vendor)
+	}
+	if err != nil {
+		logs.Errorf("create %s key pair failed, err: %v, account: %s, name: %s, rid: %s", vendor, err,
+			req.AccountID, req.Name, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair ...
+package keypair
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
+)
+
+// InitKeyPairService initialize the ssh key pair service.
+func InitKeyPairService(c *capability.Capability) {
+	svc := &keyPairSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+		cipher:     c.Cipher,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("GetKeyPair", http.MethodGet, "/key_pairs/{id}", svc.GetKeyPair)
+	h.Add("ListKeyPair", http.MethodPost, "/key_pairs/list", svc.ListKeyPair)
+	h.Add("CreateKeyPair", http.MethodPost, "/key_pairs/create", svc.CreateKeyPair)
+	h.Add("BatchDeleteKeyPair", http.MethodDelete, "/key_pairs/batch", svc.BatchDeleteKeyPair)
+	h.Add("AssignKeyPairToBiz", http.MethodPost, "/key_pairs/assign/bizs", svc.AssignKeyPairToBiz)
+	h.Add("GetKeyPairPrivateKey", http.MethodGet, "/key_pairs/{id}/private_key", svc.GetKeyPairPrivateKey)
+
+	// key pair apis in biz
+	h.Add("GetBizKeyPair", http.MethodGet, "/bizs/{bk_biz_id}/key_pairs/{id}", svc.GetBizKeyPair)
+	h.Add("ListBizKeyPair", http.MethodPost, "/bizs/{bk_biz_id}/key_pairs/list", svc.ListBizKeyPair)
+	h.Add("CreateBizKeyPair", http.MethodPost, "/bizs/{bk_biz_id}/key_pairs/create", svc.CreateBizKeyPair)
+	h.Add("BatchDeleteBizKeyPair", http.MethodDelete, "/bizs/{bk_biz_id}/key_pairs/batch",
+		svc.BatchDeleteBizKeyPair)
+	h.Add("GetBizKeyPairPrivateKey", http.MethodGet, "/bizs/{bk_biz_id}/key_pairs/{id}/private_key",
+		svc.GetBizKeyPairPrivateKey)
+
+	h.Load(c.WebService)
+}
+
+type keyPairSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+	cipher     cryptography.Crypto
+}
@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	cskeypair "hcm/pkg/api/cloud-server/key-pair"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// GetKeyPairPrivateKey get key pair private key.
+func (svc *keyPairSvc) GetKeyPairPrivateKey(cts *rest.Contexts) (interface{}, error) {
+	return svc.getKeyPairPrivateKey(cts, handler.ResOperateAuth)
+}
+
+// GetBizKeyPairPrivateKey get biz key pair private key.
+func (svc *keyPairSvc) GetBizKeyPairPrivateKey(cts *rest.Contexts) (interface{}, error) {
+	return svc.getKeyPairPrivateKey(cts, handler.BizOperateAuth)
+}
+
+// getKeyPairPrivateKey 私钥只有HCM创建的密钥对才有，下载私钥需要独立的私钥下载权限，每次下载均记录审计
+func (svc *keyPairSvc) getKeyPairPrivateKey(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.KeyPairCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.KeyPair,
+		Action: meta.KeyAccess, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.KeyPair.GetKeyPairPrivateKey(cts.Kit, id)
+	if err != nil {
+		logs.Errorf("get key pair private key failed, id: %s, err: %v, rid: %s", id, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	privateKey, err := svc.cipher.DecryptFromBase64(result.PrivateKey)
+	if err != nil {
+		logs.Errorf("decrypt key pair private key failed, id: %s, err: %v, rid: %s", id, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 审计记录失败时不返回私钥，保证每次下载都有迹可查
+	auditInfo := protoaudit.CloudResourceOperationInfo{
+		ResType: enumor.KeyPairAuditResType,
+		ResID:   id,
+		Action:  protoaudit.DownloadPrivateKey,
+	}
+	if err = svc.audit.ResOperationAudit(cts.Kit, auditInfo); err != nil {
+		logs.Errorf("create download private key audit failed, id: %s, err: %v, rid: %s", id, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &cskeypair.PrivateKeyResult{ID: id, PrivateKey: privateKey}, nil
+}
@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ListKeyPair list key pair.
+func (svc *keyPairSvc) ListKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.listKeyPair(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizKeyPair list biz key pair.
+func (svc *keyPairSvc) ListBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.listKeyPair(cts, handler.ListBizAuthRes)
+}
+
+func (svc *keyPairSvc) listKeyPair(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{},
+	error) {
+
+	req := new(proto.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.KeyPair, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &core.ListResult{Count: 0, Details: make([]interface{}, 0)}, nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.KeyPair.ListKeyPair(cts.Kit, listReq)
+}
+
+// GetKeyPair get key pair.
+func (svc *keyPairSvc) GetKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.getKeyPair(cts, handler.ListResourceAuthRes)
+}
+
+// GetBizKeyPair get biz key pair.
+func (svc *keyPairSvc) GetBizKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return svc.getKeyPair(cts, handler.ListBizAuthRes)
+}
+
+func (svc *keyPairSvc) getKeyPair(cts *rest.Contexts, validHandler handler.ListAuthResHandler) (interface{},
+	error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.KeyPairCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	_, noPerm, err := validHandler(cts,
+		&handler.ListAuthResOption{Authorizer: svc.authorizer, ResType: meta.KeyPair, Action: meta.Find})
+	if err != nil {
+		return nil, err
+	}
+	if noPerm {
+		return nil, errf.New(errf.PermissionDenied, "permission denied for get key pair")
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return svc.client.DataService().TCloud.GetKeyPair(cts.Kit, id)
+
+	case enumor.Aws:
+		return svc.client.DataService().Aws.GetKeyPair(cts.Kit, id)
+
+	case enumor.HuaWei:
+		return svc.client.DataService().HuaWei.GetKeyPair(cts.Kit, id)
+
+	case enumor.Gcp:
+		return svc.client.DataService().Gcp.GetKeyPair(cts.Kit, id)
+
+	default:
+		return nil, errf.Newf(errf.Unknown, "id: %s vendor: %s not support", id, basicInfo.Vendor)
+	}
+}
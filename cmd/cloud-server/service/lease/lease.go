@@ -0,0 +1,324 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package lease
+
+import (
+	"fmt"
+	"time"
+
+	logicslease "hcm/cmd/cloud-server/logics/lease"
+	proto "hcm/pkg/api/cloud-server"
+	cslease "hcm/pkg/api/cloud-server/lease"
+	"hcm/pkg/api/core"
+	corelease "hcm/pkg/api/core/lease"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	dslease "hcm/pkg/api/data-service/lease"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// SetBizLease 设置业务下主机或硬盘的租约，资源已有租约时覆盖原租约
+func (svc *leaseSvc) SetBizLease(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(cslease.SetReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resIDs := slice.Unique(req.ResIDs)
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: req.ResType,
+		IDs:          resIDs,
+		Fields:       append(types.CommonBasicInfoFields, "recycle_status"),
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	err = handler.BizOperateAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+		ResType: authResTypes[req.ResType], Action: meta.Update, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	existLeases, err := svc.listLeaseByRes(cts.Kit, req.ResType, resIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := req.Owner
+	if len(owner) == 0 {
+		owner = cts.Kit.User
+	}
+
+	ids := make([]string, 0, len(resIDs))
+	for _, resID := range resIDs {
+		// 已有租约时重新生效，到期提醒状态随到期时间重置
+		if lease, exist := existLeases[resID]; exist {
+			updateReq := &dslease.LeaseUpdateReq{
+				BkBizID:  bizID,
+				Owner:    owner,
+				ExpireAt: converter.ValToPtr(req.ExpireAt),
+				Reminded: converter.ValToPtr(false),
+				State:    enumor.LeaseActive,
+				Memo:     req.Memo,
+			}
+			if err = svc.client.DataService().Global.Lease.UpdateLease(cts.Kit, lease.ID, updateReq); err != nil {
+				logs.Errorf("update resource lease failed, err: %v, id: %s, rid: %s", err, lease.ID, cts.Kit.Rid)
+				return nil, err
+			}
+			ids = append(ids, lease.ID)
+			continue
+		}
+
+		createReq := &dslease.LeaseCreateReq{
+			ResType:  req.ResType,
+			ResID:    resID,
+			Vendor:   basicInfoMap[resID].Vendor,
+			BkBizID:  bizID,
+			Owner:    owner,
+			ExpireAt: req.ExpireAt,
+			Memo:     req.Memo,
+		}
+		result, err := svc.client.DataService().Global.Lease.CreateLease(cts.Kit, createReq)
+		if err != nil {
+			logs.Errorf("create resource lease failed, err: %v, res: %s(%s), rid: %s", err, req.ResType, resID,
+				cts.Kit.Rid)
+			return nil, err
+		}
+		ids = append(ids, result.ID)
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// ListBizLease list biz resource lease.
+func (svc *leaseSvc) ListBizLease(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 租约按照对应资源类型分别鉴权，只查询有权限的资源类型的租约
+	rules := make([]filter.RuleFactory, 0, len(authResTypes))
+	for resType, authResType := range authResTypes {
+		typeFilter, err := tools.And(tools.EqualExpression("res_type", resType), req.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		expr, noPermFlag, err := handler.ListBizAuthRes(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+			ResType: authResType, Action: meta.Find, Filter: typeFilter})
+		if err != nil {
+			return nil, err
+		}
+
+		if noPermFlag {
+			continue
+		}
+		rules = append(rules, expr)
+	}
+
+	if len(rules) == 0 {
+		return &core.ListResult{Count: 0, Details: make([]interface{}, 0)}, nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{Op: filter.Or, Rules: rules},
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.Lease.ListLease(cts.Kit, listReq)
+}
+
+// RenewBizLease 一键续期，已过期但资源尚未回收的租约从当前时间开始续期
+func (svc *leaseSvc) RenewBizLease(cts *rest.Contexts) (interface{}, error) {
+	req := new(cslease.RenewReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	leases, err := svc.listAndAuthLease(cts, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, one := range leases {
+		if one.State != enumor.LeaseActive {
+			return nil, errf.Newf(errf.InvalidParameter, "lease: %s is %s, recover the resource from recycle bin "+
+				"and set lease again", one.ID, one.State)
+		}
+	}
+
+	days := req.Days
+	if days == 0 {
+		days = cc.CloudServer().Lease.RenewDays
+	}
+
+	now := time.Now()
+	for _, one := range leases {
+		expireAt, err := time.Parse(constant.TimeStdFormat, one.ExpireAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse lease %s expire_at failed, err: %v", one.ID, err)
+		}
+
+		updateReq := &dslease.LeaseUpdateReq{
+			ExpireAt: converter.ValToPtr(logicslease.RenewExpireAt(expireAt, now, days)),
+			Reminded: converter.ValToPtr(false),
+		}
+		if err = svc.client.DataService().Global.Lease.UpdateLease(cts.Kit, one.ID, updateReq); err != nil {
+			logs.Errorf("renew resource lease failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteBizLease 删除租约，资源不再自动回收
+func (svc *leaseSvc) BatchDeleteBizLease(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if _, err := svc.listAndAuthLease(cts, req.IDs); err != nil {
+		return nil, err
+	}
+
+	deleteReq := &core.BatchDeleteReq{IDs: req.IDs}
+	if err := svc.client.DataService().Global.Lease.BatchDeleteLease(cts.Kit, deleteReq); err != nil {
+		logs.Errorf("batch delete resource lease failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// listAndAuthLease 查询租约并按照租约对应资源的类型和所属业务鉴权
+func (svc *leaseSvc) listAndAuthLease(cts *rest.Contexts, ids []string) ([]corelease.Lease, error) {
+	if len(ids) > int(core.DefaultMaxPageLimit) {
+		return nil, errf.Newf(errf.InvalidParameter, "lease ids should <= %d", core.DefaultMaxPageLimit)
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.Lease.ListLease(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list resource lease failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+
+	typeInfos := make(map[enumor.CloudResourceType]map[string]types.CloudResourceBasicInfo)
+	for _, one := range result.Details {
+		if _, exist := typeInfos[one.ResType]; !exist {
+			typeInfos[one.ResType] = make(map[string]types.CloudResourceBasicInfo)
+		}
+		typeInfos[one.ResType][one.ID] = types.CloudResourceBasicInfo{
+			ResType: one.ResType,
+			ID:      one.ID,
+			Vendor:  one.Vendor,
+			BkBizID: one.BkBizID,
+		}
+	}
+
+	for _, id := range ids {
+		found := false
+		for _, infos := range typeInfos {
+			if _, found = infos[id]; found {
+				break
+			}
+		}
+		if !found {
+			return nil, errf.Newf(errf.RecordNotFound, "lease: %s not found", id)
+		}
+	}
+
+	for resType, infos := range typeInfos {
+		err = handler.BizOperateAuth(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer,
+			ResType: authResTypes[resType], Action: meta.Update, BasicInfos: infos})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result.Details, nil
+}
+
+// listLeaseByRes 查询资源已有的租约，返回以资源ID为key的租约
+func (svc *leaseSvc) listLeaseByRes(kt *kit.Kit, resType enumor.CloudResourceType, resIDs []string) (
+	map[string]corelease.Lease, error) {
+
+	expr, err := tools.And(tools.EqualExpression("res_type", resType), tools.ContainersExpression("res_id", resIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.Lease.ListLease(kt, listReq)
+	if err != nil {
+		logs.Errorf("list resource lease failed, err: %v, res_ids: %v, rid: %s", err, resIDs, kt.Rid)
+		return nil, err
+	}
+
+	leases := make(map[string]corelease.Lease, len(result.Details))
+	for _, one := range result.Details {
+		leases[one.ResID] = one
+	}
+
+	return leases, nil
+}
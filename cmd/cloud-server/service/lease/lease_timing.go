@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package lease
+
+import (
+	"time"
+
+	"hcm/cmd/cloud-server/logics"
+	logicslease "hcm/cmd/cloud-server/logics/lease"
+	"hcm/pkg/api/core"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+	"hcm/pkg/thirdparty/esb"
+)
+
+// LeaseCheckTiming 定时检查主机和硬盘的租约，到期前提醒负责人，到期后将资源移入回收站，仅在主节点执行
+func LeaseCheckTiming(conf cc.Lease, sd serviced.ServiceDiscover, cliSet *client.ClientSet, esbClient esb.Client) {
+	notifier, err := logicslease.NewNotifier(conf.Notifier)
+	if err != nil {
+		logs.Errorf("new lease notifier failed, lease check will not start, err: %v", err)
+		return
+	}
+
+	logs.Infof("lease check enable && start, checkIntervalMin: %d, remindBeforeHour: %d, notifier: %s",
+		conf.CheckIntervalMin, conf.RemindBeforeHour, conf.Notifier.Type)
+
+	checker := logicslease.NewChecker(cliSet, logics.NewLogics(cliSet, esbClient), notifier, conf)
+	for {
+		time.Sleep(time.Duration(conf.CheckIntervalMin) * time.Minute)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+
+		start := time.Now()
+		logs.Infof("lease check start, time: %v, rid: %s", start, kt.Rid)
+
+		if err = checker.Check(kt, start); err != nil {
+			logs.Errorf("lease check failed, err: %v, rid: %s", err, kt.Rid)
+		}
+
+		logs.Infof("lease check end, cost: %v, rid: %s", time.Since(start), kt.Rid)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package lease 资源租约相关接口，包括租约设置、一键续期以及到期检查
+package lease
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// InitLeaseService initialize the resource lease service.
+func InitLeaseService(c *capability.Capability) {
+	svc := &leaseSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("SetBizLease", http.MethodPost, "/bizs/{bk_biz_id}/leases/set", svc.SetBizLease)
+	h.Add("ListBizLease", http.MethodPost, "/bizs/{bk_biz_id}/leases/list", svc.ListBizLease)
+	h.Add("RenewBizLease", http.MethodPost, "/bizs/{bk_biz_id}/leases/renew", svc.RenewBizLease)
+	h.Add("BatchDeleteBizLease", http.MethodDelete, "/bizs/{bk_biz_id}/leases/batch", svc.BatchDeleteBizLease)
+
+	h.Load(c.WebService)
+}
+
+type leaseSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// authResTypes 租约按照对应资源的权限进行鉴权
+var authResTypes = map[enumor.CloudResourceType]meta.ResourceType{
+	enumor.CvmCloudResType:  meta.Cvm,
+	enumor.DiskCloudResType: meta.Disk,
+}
@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+
+	cslb "hcm/pkg/api/cloud-server/load-balancer"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	datalb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// AssignLoadBalancerToBiz assign load balancer to biz.
+func (svc *lbSvc) AssignLoadBalancerToBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(cslb.AssignLbToBizReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 权限校验
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.LoadBalancerCloudResType,
+		IDs:          req.LbIDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(basicInfoMap))
+	for _, info := range basicInfoMap {
+		authRes = append(authRes, meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.LoadBalancer,
+			Action: meta.Assign, ResourceID: info.AccountID}, BizID: req.BkBizID})
+	}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...); err != nil {
+		return nil, err
+	}
+
+	if err = svc.validateLbNotAssigned(cts.Kit, req.LbIDs); err != nil {
+		return nil, err
+	}
+
+	if err = svc.audit.ResBizAssignAudit(cts.Kit, enumor.LoadBalancerAuditResType, req.LbIDs,
+		req.BkBizID); err != nil {
+
+		logs.Errorf("create assign audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	updateReq := &datalb.LoadBalancerBizBatchUpdateReq{
+		IDs:     req.LbIDs,
+		BkBizID: req.BkBizID,
+	}
+	if err = svc.client.DataService().Global.LoadBalancer.BatchUpdateLoadBalancerBiz(cts.Kit, updateReq); err != nil {
+		logs.Errorf("batch update load balancer biz failed, err: %v, req: %+v, rid: %s", err, updateReq,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// validateLbNotAssigned 校验负载均衡未分配到业务下
+func (svc *lbSvc) validateLbNotAssigned(kt *kit.Kit, ids []string) error {
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: ids},
+				&filter.AtomRule{Field: "bk_biz_id", Op: filter.NotEqual.Factory(), Value: constant.UnassignedBiz},
+			},
+		},
+		Page: &core.BasePage{Count: true},
+	}
+	result, err := svc.client.DataService().Global.LoadBalancer.ListLoadBalancer(kt, listReq)
+	if err != nil {
+		logs.Errorf("count assigned load balancer failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return err
+	}
+
+	if result.Count != 0 {
+		return fmt.Errorf("%d load balancers are already assigned", result.Count)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"encoding/json"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateLoadBalancer create load balancer.
+func (svc *lbSvc) CreateLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(cloudserver.ResourceCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		logs.Errorf("create load balancer request decode failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.LoadBalancer, Action: meta.Create,
+		ResourceID: req.AccountID}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		logs.Errorf("create load balancer auth failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	info, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.AccountCloudResType, req.AccountID)
+	if err != nil {
+		logs.Errorf("get account basic info failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	switch info.Vendor {
+	case enumor.TCloud:
+		return svc.createTCloudLoadBalancer(cts.Kit, req.AccountID, req.Data)
+	case enumor.Aws:
+		return svc.createAwsLoadBalancer(cts.Kit, req.AccountID, req.Data)
+	case enumor.HuaWei:
+		return svc.createHuaWeiLoadBalancer(cts.Kit, req.AccountID, req.Data)
+	case enumor.Gcp:
+		return svc.createGcpLoadBalancer(cts.Kit, req.AccountID, req.Data)
+	case enumor.Azure:
+		return svc.createAzureLoadBalancer(cts.Kit, req.AccountID, req.Data)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", info.Vendor)
+	}
+}
+
+func (svc *lbSvc) createTCloudLoadBalancer(kt *kit.Kit, accountID string, body json.RawMessage) (
+	*core.BatchCreateResult, error) {
+
+	req := new(hclb.TCloudLoadBalancerCreateReq)
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	req.AccountID = accountID
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.client.HCService().TCloud.LoadBalancer.CreateLoadBalancer(kt, req)
+}
+
+func (svc *lbSvc) createAwsLoadBalancer(kt *kit.Kit, accountID string, body json.RawMessage) (
+	*core.BatchCreateResult, error) {
+
+	req := new(hclb.AwsLoadBalancerCreateReq)
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	req.AccountID = accountID
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.client.HCService().Aws.LoadBalancer.CreateLoadBalancer(kt, req)
+}
+
+func (svc *lbSvc) createHuaWeiLoadBalancer(kt *kit.Kit, accountID string, body json.RawMessage) (
+	*core.BatchCreateResult, error) {
+
+	req := new(hclb.HuaWeiLoadBalancerCreateReq)
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	req.AccountID = accountID
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.client.HCService().HuaWei.LoadBalancer.CreateLoadBalancer(kt, req)
+}
+
+func (svc *lbSvc) createGcpLoadBalancer(kt *kit.Kit, accountID string, body json.RawMessage) (
+	*core.BatchCreateResult, error) {
+
+	req := new(hclb.GcpLoadBalancerCreateReq)
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	req.AccountID = accountID
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.client.HCService().Gcp.LoadBalancer.CreateLoadBalancer(kt, req)
+}
+
+func (svc *lbSvc) createAzureLoadBalancer(kt *kit.Kit, accountID string, body json.RawMessage) (
+	*core.BatchCreateResult, error) {
+
+	req := new(hclb.AzureLoadBalancerCreateReq)
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	req.AccountID = accountID
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.client.HCService().Azure.LoadBalancer.CreateLoadBalancer(kt, req)
+}
@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// BatchDeleteLoadBalancer batch delete load balancer.
+func (svc *lbSvc) BatchDeleteLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteLoadBalancer(cts, handler.ResOperateAuth)
+}
+
+// BatchDeleteBizLoadBalancer batch delete biz load balancer.
+func (svc *lbSvc) BatchDeleteBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteLoadBalancer(cts, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) batchDeleteLoadBalancer(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(proto.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.LoadBalancerCloudResType,
+		IDs:          req.IDs,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer,
+		Action: meta.Delete, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.audit.ResDeleteAudit(cts.Kit, enumor.LoadBalancerAuditResType, req.IDs); err != nil {
+		logs.Errorf("create operation audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	succeeded := make([]string, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		if err = svc.deleteLoadBalancer(cts.Kit, basicInfoMap[id].Vendor, id); err != nil {
+			return core.BatchOperateResult{
+				Succeeded: succeeded,
+				Failed:    &core.FailedInfo{ID: id, Error: err},
+			}, errf.NewFromErr(errf.PartialFailed, err)
+		}
+		succeeded = append(succeeded, id)
+	}
+
+	return nil, nil
+}
+
+func (svc *lbSvc) deleteLoadBalancer(kt *kit.Kit, vendor enumor.Vendor, id string) error {
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		err = svc.client.HCService().TCloud.LoadBalancer.DeleteLoadBalancer(kt, id)
+	case enumor.Aws:
+		err = svc.client.HCService().Aws.LoadBalancer.DeleteLoadBalancer(kt, id)
+	case enumor.HuaWei:
+		err = svc.client.HCService().HuaWei.LoadBalancer.DeleteLoadBalancer(kt, id)
+	case enumor.Gcp:
+		err = svc.client.HCService().Gcp.LoadBalancer.DeleteLoadBalancer(kt, id)
+	case enumor.Azure:
+		err = svc.client.HCService().Azure.LoadBalancer.DeleteLoadBalancer(kt, id)
+	default:
+		return errf.Newf(errf.InvalidParameter, "vendor: %s not support", vendor)
+	}
+	if err != nil {
+		logs.Errorf("delete %s load balancer failed, err: %v, id: %s, rid: %s", vendor, err, id, kt.Rid)
+		return err
+	}
+
+	return nil
+}
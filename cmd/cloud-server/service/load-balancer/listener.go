@@ -0,0 +1,213 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// CreateListener create listener.
+func (svc *lbSvc) CreateListener(cts *rest.Contexts) (interface{}, error) {
+	return svc.createListener(cts, handler.ResOperateAuth)
+}
+
+// CreateBizListener create biz listener.
+func (svc *lbSvc) CreateBizListener(cts *rest.Contexts) (interface{}, error) {
+	return svc.createListener(cts, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) createListener(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	req := new(hclb.ListenerCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	lbCli, err := svc.authorizeLbUpdate(cts, validHandler, req.LbID)
+	if err != nil {
+		return nil, err
+	}
+
+	return lbCli.CreateListener(cts.Kit, req)
+}
+
+// BatchDeleteListener batch delete listener.
+func (svc *lbSvc) BatchDeleteListener(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteListener(cts, handler.ResOperateAuth)
+}
+
+// BatchDeleteBizListener batch delete biz listener.
+func (svc *lbSvc) BatchDeleteBizListener(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteListener(cts, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) batchDeleteListener(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	req := new(hclb.ListenerBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	lbCli, err := svc.authorizeLbUpdate(cts, validHandler, req.LbID)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, lbCli.BatchDeleteListener(cts.Kit, req)
+}
+
+// RegisterTargets register targets.
+func (svc *lbSvc) RegisterTargets(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.operateTargets(cts, handler.ResOperateAuth, true)
+}
+
+// RegisterBizTargets register biz targets.
+func (svc *lbSvc) RegisterBizTargets(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.operateTargets(cts, handler.BizOperateAuth, true)
+}
+
+// DeregisterTargets deregister targets.
+func (svc *lbSvc) DeregisterTargets(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.operateTargets(cts, handler.ResOperateAuth, false)
+}
+
+// DeregisterBizTargets deregister biz targets.
+func (svc *lbSvc) DeregisterBizTargets(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.operateTargets(cts, handler.BizOperateAuth, false)
+}
+
+func (svc *lbSvc) operateTargets(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	isRegister bool) error {
+
+	req := new(hclb.TargetOperateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listener, err := svc.getListener(cts.Kit, req.ListenerID)
+	if err != nil {
+		return err
+	}
+
+	lbCli, err := svc.authorizeLbUpdate(cts, validHandler, listener.LbID)
+	if err != nil {
+		return err
+	}
+
+	if isRegister {
+		return lbCli.RegisterTargets(cts.Kit, req)
+	}
+
+	return lbCli.DeregisterTargets(cts.Kit, req)
+}
+
+// lbOperator is the hc-service load balancer client of one vendor.
+type lbOperator interface {
+	CreateListener(kt *kit.Kit, req *hclb.ListenerCreateReq) (*core.CreateResult, error)
+	BatchDeleteListener(kt *kit.Kit, req *hclb.ListenerBatchDeleteReq) error
+	RegisterTargets(kt *kit.Kit, req *hclb.TargetOperateReq) error
+	DeregisterTargets(kt *kit.Kit, req *hclb.TargetOperateReq) error
+}
+
+// authorizeLbUpdate authorize the update permission of load balancer, and returns its vendor's hc-service client.
+func (svc *lbSvc) authorizeLbUpdate(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	lbID string) (lbOperator, error) {
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.LoadBalancerCloudResType, lbID, types.CommonBasicInfoFields...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer,
+		Action: meta.Update, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	return vendorLbClient(svc.client.HCService(), basicInfo.Vendor)
+}
+
+func vendorLbClient(cli *hcservice.Client, vendor enumor.Vendor) (lbOperator, error) {
+	switch vendor {
+	case enumor.TCloud:
+		return cli.TCloud.LoadBalancer, nil
+	case enumor.Aws:
+		return cli.Aws.LoadBalancer, nil
+	case enumor.HuaWei:
+		return cli.HuaWei.LoadBalancer, nil
+	case enumor.Gcp:
+		return cli.Gcp.LoadBalancer, nil
+	case enumor.Azure:
+		return cli.Azure.LoadBalancer, nil
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", vendor)
+	}
+}
+
+// getListener get listener by id.
+func (svc *lbSvc) getListener(kt *kit.Kit, id string) (*corelb.Listener, error) {
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.Equal.Factory(), Value: id},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.LoadBalancer.ListListener(kt, req)
+	if err != nil {
+		logs.Errorf("get listener failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "listener: %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer ...
+package loadbalancer
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
+)
+
+// InitLoadBalancerService initialize the load balancer service.
+func InitLoadBalancerService(c *capability.Capability) {
+	svc := &lbSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("GetLoadBalancer", http.MethodGet, "/load_balancers/{id}", svc.GetLoadBalancer)
+	h.Add("ListLoadBalancer", http.MethodPost, "/load_balancers/list", svc.ListLoadBalancer)
+	h.Add("CreateLoadBalancer", http.MethodPost, "/load_balancers/create", svc.CreateLoadBalancer)
+	h.Add("BatchDeleteLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteLoadBalancer)
+	h.Add("AssignLoadBalancerToBiz", http.MethodPost, "/load_balancers/assign/bizs", svc.AssignLoadBalancerToBiz)
+	h.Add("ListListener", http.MethodPost, "/load_balancers/{id}/listeners/list", svc.ListListener)
+	h.Add("ListTarget", http.MethodPost, "/load_balancers/{id}/targets/list", svc.ListTarget)
+	h.Add("CreateListener", http.MethodPost, "/load_balancers/listeners/create", svc.CreateListener)
+	h.Add("BatchDeleteListener", http.MethodDelete, "/load_balancers/listeners/batch", svc.BatchDeleteListener)
+	h.Add("RegisterTargets", http.MethodPost, "/load_balancers/targets/register", svc.RegisterTargets)
+	h.Add("DeregisterTargets", http.MethodPost, "/load_balancers/targets/deregister", svc.DeregisterTargets)
+
+	// load balancer apis in biz
+	h.Add("GetBizLoadBalancer", http.MethodGet, "/bizs/{bk_biz_id}/load_balancers/{id}", svc.GetBizLoadBalancer)
+	h.Add("ListBizLoadBalancer", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/list", svc.ListBizLoadBalancer)
+	h.Add("BatchDeleteBizLoadBalancer", http.MethodDelete, "/bizs/{bk_biz_id}/load_balancers/batch",
+		svc.BatchDeleteBizLoadBalancer)
+	h.Add("ListBizListener", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/{id}/listeners/list",
+		svc.ListBizListener)
+	h.Add("ListBizTarget", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/{id}/targets/list", svc.ListBizTarget)
+	h.Add("CreateBizListener", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/listeners/create",
+		svc.CreateBizListener)
+	h.Add("BatchDeleteBizListener", http.MethodDelete, "/bizs/{bk_biz_id}/load_balancers/listeners/batch",
+		svc.BatchDeleteBizListener)
+	h.Add("RegisterBizTargets", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/targets/register",
+		svc.RegisterBizTargets)
+	h.Add("DeregisterBizTargets", http.MethodPost, "/bizs/{bk_biz_id}/load_balancers/targets/deregister",
+		svc.DeregisterBizTargets)
+
+	h.Load(c.WebService)
+}
+
+type lbSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
@@ -0,0 +1,216 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ListLoadBalancer list load balancer.
+func (svc *lbSvc) ListLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.listLoadBalancer(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizLoadBalancer list biz load balancer.
+func (svc *lbSvc) ListBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.listLoadBalancer(cts, handler.ListBizAuthRes)
+}
+
+func (svc *lbSvc) listLoadBalancer(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{}, error) {
+	req := new(proto.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.LoadBalancer, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &core.ListResult{Count: 0, Details: make([]interface{}, 0)}, nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.LoadBalancer.ListLoadBalancer(cts.Kit, listReq)
+}
+
+// GetLoadBalancer get load balancer.
+func (svc *lbSvc) GetLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.getLoadBalancer(cts, handler.ListResourceAuthRes)
+}
+
+// GetBizLoadBalancer get biz load balancer.
+func (svc *lbSvc) GetBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.getLoadBalancer(cts, handler.ListBizAuthRes)
+}
+
+func (svc *lbSvc) getLoadBalancer(cts *rest.Contexts, validHandler handler.ListAuthResHandler) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.LoadBalancerCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	_, noPerm, err := validHandler(cts,
+		&handler.ListAuthResOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer, Action: meta.Find})
+	if err != nil {
+		return nil, err
+	}
+	if noPerm {
+		return nil, errf.New(errf.PermissionDenied, "permission denied for get load balancer")
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return svc.client.DataService().TCloud.GetLoadBalancer(cts.Kit, id)
+
+	case enumor.Aws:
+		return svc.client.DataService().Aws.GetLoadBalancer(cts.Kit, id)
+
+	case enumor.HuaWei:
+		return svc.client.DataService().HuaWei.GetLoadBalancer(cts.Kit, id)
+
+	case enumor.Gcp:
+		return svc.client.DataService().Gcp.GetLoadBalancer(cts.Kit, id)
+
+	case enumor.Azure:
+		return svc.client.DataService().Azure.GetLoadBalancer(cts.Kit, id)
+
+	default:
+		return nil, errf.Newf(errf.Unknown, "id: %s vendor: %s not support", id, basicInfo.Vendor)
+	}
+}
+
+// ListListener list listener of load balancer.
+func (svc *lbSvc) ListListener(cts *rest.Contexts) (interface{}, error) {
+	return svc.listLbRelRes(cts, handler.ListResourceAuthRes, true)
+}
+
+// ListBizListener list listener of biz load balancer.
+func (svc *lbSvc) ListBizListener(cts *rest.Contexts) (interface{}, error) {
+	return svc.listLbRelRes(cts, handler.ListBizAuthRes, true)
+}
+
+// ListTarget list target of load balancer.
+func (svc *lbSvc) ListTarget(cts *rest.Contexts) (interface{}, error) {
+	return svc.listLbRelRes(cts, handler.ListResourceAuthRes, false)
+}
+
+// ListBizTarget list target of biz load balancer.
+func (svc *lbSvc) ListBizTarget(cts *rest.Contexts) (interface{}, error) {
+	return svc.listLbRelRes(cts, handler.ListBizAuthRes, false)
+}
+
+// listLbRelRes list listener or target of the load balancer specified by path parameter.
+func (svc *lbSvc) listLbRelRes(cts *rest.Contexts, authHandler handler.ListAuthResHandler, isListener bool) (
+	interface{}, error) {
+
+	lbID := cts.PathParameter("id").String()
+	if len(lbID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 通过负载均衡的权限校验其关联资源的查看权限
+	lbFilter, err := svc.authorizedLbFilter(cts, authHandler, lbID)
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op:    filter.And,
+			Rules: []filter.RuleFactory{lbFilter, req.Filter},
+		},
+		Page: req.Page,
+	}
+	if isListener {
+		return svc.client.DataService().Global.LoadBalancer.ListListener(cts.Kit, listReq)
+	}
+
+	return svc.client.DataService().Global.LoadBalancer.ListTarget(cts.Kit, listReq)
+}
+
+// authorizedLbFilter check the load balancer is visible to current user, and returns the filter of its relation res.
+func (svc *lbSvc) authorizedLbFilter(cts *rest.Contexts, authHandler handler.ListAuthResHandler, lbID string) (
+	filter.RuleFactory, error) {
+
+	lbExpr := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "id", Op: filter.Equal.Factory(), Value: lbID},
+		},
+	}
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.LoadBalancer, Action: meta.Find, Filter: lbExpr})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return nil, errf.New(errf.PermissionDenied, "permission denied for get load balancer")
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   &core.BasePage{Count: true},
+	}
+	result, err := svc.client.DataService().Global.LoadBalancer.ListLoadBalancer(cts.Kit, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Count == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "load balancer: %s not found", lbID)
+	}
+
+	return &filter.AtomRule{Field: "lb_id", Op: filter.Equal.Factory(), Value: lbID}, nil
+}
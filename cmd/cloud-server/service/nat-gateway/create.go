@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	"encoding/json"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	hcnat "hcm/pkg/api/hc-service/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateNatGateway create nat gateway.
+func (svc *natSvc) CreateNatGateway(cts *rest.Contexts) (interface{}, error) {
+	req := new(cloudserver.ResourceCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		logs.Errorf("create nat gateway request decode failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	authRes := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.NatGateway, Action: meta.Create,
+		ResourceID: req.AccountID}}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes); err != nil {
+		logs.Errorf("create nat gateway auth failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	info, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.AccountCloudResType, req.AccountID)
+	if err != nil {
+		logs.Errorf("get account basic info failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	switch info.Vendor {
+	case enumor.TCloud:
+		return svc.createTCloudNatGateway(cts.Kit, req.AccountID, req.Data)
+	case enumor.Aws:
+		return svc.createAwsNatGateway(cts.Kit, req.AccountID, req.Data)
+	case enumor.HuaWei:
+		return svc.createHuaWeiNatGateway(cts.Kit, req.AccountID, req.Data)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", info.Vendor)
+	}
+}
+
+func (svc *natSvc) createTCloudNatGateway(kt *kit.Kit, accountID string, body json.RawMessage) (
+	*core.CreateResult, error) {
+
+	req := new(hcnat.TCloudNatGatewayCreateReq)
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	req.AccountID = accountID
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.client.HCService().TCloud.NatGateway.CreateNatGateway(kt, req)
+}
+
+func (svc *natSvc) createAwsNatGateway(kt *kit.Kit, accountID string, body json.RawMessage) (
+	*core.CreateResult, error) {
+
+	req := new(hcnat.AwsNatGatewayCreateReq)
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	req.AccountID = accountID
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.client.HCService().Aws.NatGateway.CreateNatGateway(kt, req)
+}
+
+func (svc *natSvc) createHuaWeiNatGateway(kt *kit.Kit, accountID string, body json.RawMessage) (
+	*core.CreateResult, error) {
+
+	req := new(hcnat.HuaWeiNatGatewayCreateReq)
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	req.AccountID = accountID
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.client.HCService().HuaWei.NatGateway.CreateNatGateway(kt, req)
+}
@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway ...
+package natgateway
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
+)
+
+// InitNatGatewayService initialize the nat gateway service.
+func InitNatGatewayService(c *capability.Capability) {
+	svc := &natSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("GetNatGateway", http.MethodGet, "/nat_gateways/{id}", svc.GetNatGateway)
+	h.Add("ListNatGateway", http.MethodPost, "/nat_gateways/list", svc.ListNatGateway)
+	h.Add("CreateNatGateway", http.MethodPost, "/nat_gateways/create", svc.CreateNatGateway)
+	h.Add("BatchDeleteNatGateway", http.MethodDelete, "/nat_gateways/batch", svc.BatchDeleteNatGateway)
+	h.Add("AssignNatGatewayToBiz", http.MethodPost, "/nat_gateways/assign/bizs", svc.AssignNatGatewayToBiz)
+	h.Add("ListNatRule", http.MethodPost, "/nat_gateways/{id}/rules/list", svc.ListNatRule)
+	h.Add("CreateNatRule", http.MethodPost, "/nat_gateways/rules/create", svc.CreateNatRule)
+	h.Add("UpdateNatRule", http.MethodPatch, "/nat_gateways/rules/{id}", svc.UpdateNatRule)
+	h.Add("BatchDeleteNatRule", http.MethodDelete, "/nat_gateways/rules/batch", svc.BatchDeleteNatRule)
+
+	// nat gateway apis in biz
+	h.Add("GetBizNatGateway", http.MethodGet, "/bizs/{bk_biz_id}/nat_gateways/{id}", svc.GetBizNatGateway)
+	h.Add("ListBizNatGateway", http.MethodPost, "/bizs/{bk_biz_id}/nat_gateways/list", svc.ListBizNatGateway)
+	h.Add("BatchDeleteBizNatGateway", http.MethodDelete, "/bizs/{bk_biz_id}/nat_gateways/batch",
+		svc.BatchDeleteBizNatGateway)
+	h.Add("ListBizNatRule", http.MethodPost, "/bizs/{bk_biz_id}/nat_gateways/{id}/rules/list", svc.ListBizNatRule)
+	h.Add("CreateBizNatRule", http.MethodPost, "/bizs/{bk_biz_id}/nat_gateways/rules/create", svc.CreateBizNatRule)
+	h.Add("UpdateBizNatRule", http.MethodPatch, "/bizs/{bk_biz_id}/nat_gateways/rules/{id}", svc.UpdateBizNatRule)
+	h.Add("BatchDeleteBizNatRule", http.MethodDelete, "/bizs/{bk_biz_id}/nat_gateways/rules/batch",
+		svc.BatchDeleteBizNatRule)
+
+	h.Load(c.WebService)
+}
+
+type natSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
@@ -0,0 +1,194 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ListNatGateway list nat gateway.
+func (svc *natSvc) ListNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return svc.listNatGateway(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizNatGateway list biz nat gateway.
+func (svc *natSvc) ListBizNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return svc.listNatGateway(cts, handler.ListBizAuthRes)
+}
+
+func (svc *natSvc) listNatGateway(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{}, error) {
+	req := new(proto.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// list authorized instances
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.NatGateway, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &core.ListResult{Count: 0, Details: make([]interface{}, 0)}, nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.NatGateway.ListNatGateway(cts.Kit, listReq)
+}
+
+// GetNatGateway get nat gateway.
+func (svc *natSvc) GetNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return svc.getNatGateway(cts, handler.ListResourceAuthRes)
+}
+
+// GetBizNatGateway get biz nat gateway.
+func (svc *natSvc) GetBizNatGateway(cts *rest.Contexts) (interface{}, error) {
+	return svc.getNatGateway(cts, handler.ListBizAuthRes)
+}
+
+func (svc *natSvc) getNatGateway(cts *rest.Contexts, validHandler handler.ListAuthResHandler) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.NatGatewayCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	_, noPerm, err := validHandler(cts,
+		&handler.ListAuthResOption{Authorizer: svc.authorizer, ResType: meta.NatGateway, Action: meta.Find})
+	if err != nil {
+		return nil, err
+	}
+	if noPerm {
+		return nil, errf.New(errf.PermissionDenied, "permission denied for get nat gateway")
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		return svc.client.DataService().TCloud.GetNatGateway(cts.Kit, id)
+
+	case enumor.Aws:
+		return svc.client.DataService().Aws.GetNatGateway(cts.Kit, id)
+
+	case enumor.HuaWei:
+		return svc.client.DataService().HuaWei.GetNatGateway(cts.Kit, id)
+
+	default:
+		return nil, errf.Newf(errf.Unknown, "id: %s vendor: %s not support", id, basicInfo.Vendor)
+	}
+}
+
+// ListNatRule list snat/dnat rule of nat gateway.
+func (svc *natSvc) ListNatRule(cts *rest.Contexts) (interface{}, error) {
+	return svc.listNatRule(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizNatRule list snat/dnat rule of biz nat gateway.
+func (svc *natSvc) ListBizNatRule(cts *rest.Contexts) (interface{}, error) {
+	return svc.listNatRule(cts, handler.ListBizAuthRes)
+}
+
+// listNatRule list snat/dnat rule of the nat gateway specified by path parameter.
+func (svc *natSvc) listNatRule(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{}, error) {
+	natID := cts.PathParameter("id").String()
+	if len(natID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 通过NAT网关的权限校验其规则的查看权限
+	natFilter, err := svc.authorizedNatFilter(cts, authHandler, natID)
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op:    filter.And,
+			Rules: []filter.RuleFactory{natFilter, req.Filter},
+		},
+		Page: req.Page,
+	}
+	return svc.client.DataService().Global.NatGateway.ListNatGatewayRule(cts.Kit, listReq)
+}
+
+// authorizedNatFilter check the nat gateway is visible to current user, and returns the filter of its rules.
+func (svc *natSvc) authorizedNatFilter(cts *rest.Contexts, authHandler handler.ListAuthResHandler, natID string) (
+	filter.RuleFactory, error) {
+
+	lbExpr := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "id", Op: filter.Equal.Factory(), Value: natID},
+		},
+	}
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.NatGateway, Action: meta.Find, Filter: lbExpr})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return nil, errf.New(errf.PermissionDenied, "permission denied for get nat gateway")
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   &core.BasePage{Count: true},
+	}
+	result, err := svc.client.DataService().Global.NatGateway.ListNatGateway(cts.Kit, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Count == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "nat gateway: %s not found", natID)
+	}
+
+	return &filter.AtomRule{Field: "nat_gateway_id", Op: filter.Equal.Factory(), Value: natID}, nil
+}
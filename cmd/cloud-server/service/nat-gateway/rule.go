@@ -0,0 +1,163 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	"hcm/pkg/api/core"
+	hcnat "hcm/pkg/api/hc-service/nat-gateway"
+	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// CreateNatRule create nat gateway snat/dnat rule.
+func (svc *natSvc) CreateNatRule(cts *rest.Contexts) (interface{}, error) {
+	return svc.createNatRule(cts, handler.ResOperateAuth)
+}
+
+// CreateBizNatRule create biz nat gateway snat/dnat rule.
+func (svc *natSvc) CreateBizNatRule(cts *rest.Contexts) (interface{}, error) {
+	return svc.createNatRule(cts, handler.BizOperateAuth)
+}
+
+func (svc *natSvc) createNatRule(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	req := new(hcnat.NatRuleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	natCli, err := svc.authorizeNatUpdate(cts, validHandler, req.NatGatewayID)
+	if err != nil {
+		return nil, err
+	}
+
+	return natCli.CreateNatRule(cts.Kit, req)
+}
+
+// UpdateNatRule update nat gateway snat/dnat rule.
+func (svc *natSvc) UpdateNatRule(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.updateNatRule(cts, handler.ResOperateAuth)
+}
+
+// UpdateBizNatRule update biz nat gateway snat/dnat rule.
+func (svc *natSvc) UpdateBizNatRule(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.updateNatRule(cts, handler.BizOperateAuth)
+}
+
+func (svc *natSvc) updateNatRule(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) error {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(hcnat.NatRuleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	natCli, err := svc.authorizeNatUpdate(cts, validHandler, req.NatGatewayID)
+	if err != nil {
+		return err
+	}
+
+	return natCli.UpdateNatRule(cts.Kit, id, req)
+}
+
+// BatchDeleteNatRule batch delete nat gateway snat/dnat rule.
+func (svc *natSvc) BatchDeleteNatRule(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.batchDeleteNatRule(cts, handler.ResOperateAuth)
+}
+
+// BatchDeleteBizNatRule batch delete biz nat gateway snat/dnat rule.
+func (svc *natSvc) BatchDeleteBizNatRule(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.batchDeleteNatRule(cts, handler.BizOperateAuth)
+}
+
+func (svc *natSvc) batchDeleteNatRule(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) error {
+	req := new(hcnat.NatRuleBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	natCli, err := svc.authorizeNatUpdate(cts, validHandler, req.NatGatewayID)
+	if err != nil {
+		return err
+	}
+
+	return natCli.BatchDeleteNatRule(cts.Kit, req)
+}
+
+// natRuleOperator is the hc-service nat gateway client of one vendor which supports snat/dnat rule.
+type natRuleOperator interface {
+	CreateNatRule(kt *kit.Kit, req *hcnat.NatRuleCreateReq) (*core.CreateResult, error)
+	UpdateNatRule(kt *kit.Kit, id string, req *hcnat.NatRuleUpdateReq) error
+	BatchDeleteNatRule(kt *kit.Kit, req *hcnat.NatRuleBatchDeleteReq) error
+}
+
+// authorizeNatUpdate authorize the update permission of nat gateway, and returns its vendor's hc-service client.
+func (svc *natSvc) authorizeNatUpdate(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	natID string) (natRuleOperator, error) {
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.NatGatewayCloudResType, natID, types.CommonBasicInfoFields...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.NatGateway,
+		Action: meta.Update, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	return vendorNatRuleClient(svc.client.HCService(), basicInfo.Vendor)
+}
+
+func vendorNatRuleClient(cli *hcservice.Client, vendor enumor.Vendor) (natRuleOperator, error) {
+	switch vendor {
+	case enumor.TCloud:
+		return cli.TCloud.NatGateway, nil
+	case enumor.HuaWei:
+		return cli.HuaWei.NatGateway, nil
+	case enumor.Aws:
+		return nil, errf.New(errf.InvalidParameter, "aws nat gateway does not support snat/dnat rule")
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", vendor)
+	}
+}
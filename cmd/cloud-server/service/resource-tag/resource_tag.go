@@ -0,0 +1,235 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag ...
+package resourcetag
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	cstag "hcm/pkg/api/cloud-server/resource-tag"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	hctag "hcm/pkg/api/hc-service/resource-tag"
+	"hcm/pkg/client"
+	hcservice "hcm/pkg/client/hc-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitResourceTagService initialize the resource tag service.
+func InitResourceTagService(c *capability.Capability) {
+	svc := &tagSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchAddResTag", http.MethodPost, "/resource_tags/batch/add", svc.BatchAddResTag)
+	h.Add("BatchRemoveResTag", http.MethodPost, "/resource_tags/batch/remove", svc.BatchRemoveResTag)
+
+	// resource tag apis in biz
+	h.Add("BatchAddBizResTag", http.MethodPost, "/bizs/{bk_biz_id}/resource_tags/batch/add", svc.BatchAddBizResTag)
+	h.Add("BatchRemoveBizResTag", http.MethodPost, "/bizs/{bk_biz_id}/resource_tags/batch/remove",
+		svc.BatchRemoveBizResTag)
+
+	h.Load(c.WebService)
+}
+
+type tagSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
+
+// tagResTypeMap 支持标签的资源类型对应的鉴权资源类型和审计资源类型
+var tagResTypeMap = map[enumor.CloudResourceType]struct {
+	authType  meta.ResourceType
+	auditType enumor.AuditResourceType
+}{
+	enumor.CvmCloudResType:    {authType: meta.Cvm, auditType: enumor.CvmAuditResType},
+	enumor.DiskCloudResType:   {authType: meta.Disk, auditType: enumor.DiskAuditResType},
+	enumor.VpcCloudResType:    {authType: meta.Vpc, auditType: enumor.VpcCloudAuditResType},
+	enumor.SubnetCloudResType: {authType: meta.Subnet, auditType: enumor.SubnetAuditResType},
+}
+
+// BatchAddResTag batch add tags to resources.
+func (svc *tagSvc) BatchAddResTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchAddResTag(cts, handler.ResOperateAuth)
+}
+
+// BatchAddBizResTag batch add tags to biz resources.
+func (svc *tagSvc) BatchAddBizResTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchAddResTag(cts, handler.BizOperateAuth)
+}
+
+func (svc *tagSvc) batchAddResTag(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	req := new(cstag.BatchAddResTagReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	updateFields := map[string]interface{}{"add_tags": req.Tags}
+	return svc.operateResTag(cts, validHandler, req.ResType, req.IDs, updateFields,
+		func(cli tagOperator, accountID string, ids []string) error {
+			return cli.BatchTagResource(cts.Kit, &hctag.BatchTagResReq{AccountID: accountID, ResType: req.ResType,
+				IDs: ids, Tags: req.Tags})
+		})
+}
+
+// BatchRemoveResTag batch remove tags from resources.
+func (svc *tagSvc) BatchRemoveResTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchRemoveResTag(cts, handler.ResOperateAuth)
+}
+
+// BatchRemoveBizResTag batch remove tags from biz resources.
+func (svc *tagSvc) BatchRemoveBizResTag(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchRemoveResTag(cts, handler.BizOperateAuth)
+}
+
+func (svc *tagSvc) batchRemoveResTag(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{},
+	error) {
+
+	req := new(cstag.BatchRemoveResTagReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	updateFields := map[string]interface{}{"remove_tag_keys": req.TagKeys}
+	return svc.operateResTag(cts, validHandler, req.ResType, req.IDs, updateFields,
+		func(cli tagOperator, accountID string, ids []string) error {
+			return cli.BatchUntagResource(cts.Kit, &hctag.BatchUntagResReq{AccountID: accountID,
+				ResType: req.ResType, IDs: ids, TagKeys: req.TagKeys})
+		})
+}
+
+// operateResTag 鉴权并审计后，将资源按照账号分组，调用对应云厂商的 hc-service 操作资源标签
+func (svc *tagSvc) operateResTag(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	resType enumor.CloudResourceType, ids []string, updateFields map[string]interface{},
+	operate func(cli tagOperator, accountID string, ids []string) error) (interface{}, error) {
+
+	typeInfo, exists := tagResTypeMap[resType]
+	if !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s does not support tag", resType)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: resType,
+		IDs:          ids,
+		Fields:       types.CommonBasicInfoFields,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: typeInfo.authType,
+		Action: meta.Update, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if err = svc.audit.ResUpdateAudit(cts.Kit, typeInfo.auditType, id, updateFields); err != nil {
+			logs.Errorf("create update audit failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	accountIDsMap, vendorMap := classifyByAccount(ids, basicInfoMap)
+	succeeded := make([]string, 0, len(ids))
+	for accountID, accountResIDs := range accountIDsMap {
+		cli, err := vendorTagClient(svc.client.HCService(), vendorMap[accountID])
+		if err == nil {
+			err = operate(cli, accountID, accountResIDs)
+		}
+		if err != nil {
+			logs.Errorf("operate resource tag failed, err: %v, account: %s, ids: %v, rid: %s", err, accountID,
+				accountResIDs, cts.Kit.Rid)
+			return core.BatchOperateResult{
+				Succeeded: succeeded,
+				Failed:    &core.FailedInfo{ID: accountResIDs[0], Error: err},
+			}, errf.NewFromErr(errf.PartialFailed, err)
+		}
+		succeeded = append(succeeded, accountResIDs...)
+	}
+
+	return nil, nil
+}
+
+// classifyByAccount 按照账号对资源分组，并返回账号对应的云厂商
+func classifyByAccount(ids []string, basicInfoMap map[string]types.CloudResourceBasicInfo) (map[string][]string,
+	map[string]enumor.Vendor) {
+
+	accountIDsMap := make(map[string][]string)
+	vendorMap := make(map[string]enumor.Vendor)
+	for _, id := range ids {
+		info := basicInfoMap[id]
+		accountIDsMap[info.AccountID] = append(accountIDsMap[info.AccountID], id)
+		vendorMap[info.AccountID] = info.Vendor
+	}
+
+	return accountIDsMap, vendorMap
+}
+
+// tagOperator 资源标签操作的 hc-service 接口，各云厂商的 hc-service client 均实现了该接口
+type tagOperator interface {
+	BatchTagResource(kt *kit.Kit, req *hctag.BatchTagResReq) error
+	BatchUntagResource(kt *kit.Kit, req *hctag.BatchUntagResReq) error
+}
+
+func vendorTagClient(cli *hcservice.Client, vendor enumor.Vendor) (tagOperator, error) {
+	switch vendor {
+	case enumor.TCloud:
+		return cli.TCloud.ResourceTag, nil
+	case enumor.Aws:
+		return cli.Aws.ResourceTag, nil
+	case enumor.HuaWei:
+		return cli.HuaWei.ResourceTag, nil
+	case enumor.Gcp:
+		return cli.Gcp.ResourceTag, nil
+	case enumor.Azure:
+		return cli.Azure.ResourceTag, nil
+	default:
+		return nil, fmt.Errorf("vendor: %s not support", vendor)
+	}
+}
@@ -21,13 +21,17 @@ package routetable
 
 import (
 	"hcm/pkg/api/core"
+	corert "hcm/pkg/api/core/cloud/route-table"
 	routetable "hcm/pkg/api/data-service/cloud/route-table"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
 	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
 )
 
 // ListRoute list routes.
@@ -81,6 +85,9 @@ func (svc *routeTableSvc) listRoute(cts *rest.Contexts, validator handler.ValidW
 			logs.Errorf("list tcloud route failed, err: %v, table id: %s, rid: %s", err, tableID, cts.Kit.Rid)
 			return nil, err
 		}
+		if err = svc.fillTCloudRouteNatID(cts.Kit, res.Details); err != nil {
+			return nil, err
+		}
 		return res, nil
 	case enumor.Aws:
 		res, err := svc.client.DataService().Aws.RouteTable.ListRoute(cts.Kit.Ctx, cts.Kit.Header(), tableID, req)
@@ -88,6 +95,9 @@ func (svc *routeTableSvc) listRoute(cts *rest.Contexts, validator handler.ValidW
 			logs.Errorf("list aws route failed, err: %v, table id: %s, rid: %s", err, tableID, cts.Kit.Rid)
 			return nil, err
 		}
+		if err = svc.fillAwsRouteNatID(cts.Kit, res.Details); err != nil {
+			return nil, err
+		}
 		return res, nil
 	case enumor.Azure:
 		res, err := svc.client.DataService().Azure.RouteTable.ListRoute(cts.Kit.Ctx, cts.Kit.Header(), tableID, req)
@@ -102,6 +112,9 @@ func (svc *routeTableSvc) listRoute(cts *rest.Contexts, validator handler.ValidW
 			logs.Errorf("list huawei route failed, err: %v, table id: %s, rid: %s", err, tableID, cts.Kit.Rid)
 			return nil, err
 		}
+		if err = svc.fillHuaWeiRouteNatID(cts.Kit, res.Details); err != nil {
+			return nil, err
+		}
 		return res, nil
 	case enumor.Gcp:
 		// TODO confirm if gcp list route operation needs route table id
@@ -122,3 +135,114 @@ func (svc *routeTableSvc) listRoute(cts *rest.Contexts, validator handler.ValidW
 		return nil, errf.Newf(errf.InvalidParameter, "unsupported cloud vendor: %s", vendor)
 	}
 }
+
+// tcloudNatGatewayType 腾讯云路由下一跳类型为NAT网关
+const tcloudNatGatewayType = "NAT"
+
+// huaweiNatGatewayType 华为云路由下一跳类型为NAT网关
+const huaweiNatGatewayType = "nat"
+
+// fillTCloudRouteNatID 下一跳为NAT网关的路由，填充对应的hcm NAT网关ID
+func (svc *routeTableSvc) fillTCloudRouteNatID(kt *kit.Kit, routes []corert.TCloudRoute) error {
+	cloudNatIDs := make([]string, 0)
+	for _, route := range routes {
+		if route.GatewayType == tcloudNatGatewayType {
+			cloudNatIDs = append(cloudNatIDs, route.CloudGatewayID)
+		}
+	}
+
+	natIDMap, err := svc.getNatIDMap(kt, enumor.TCloud, cloudNatIDs)
+	if err != nil {
+		return err
+	}
+
+	for i, route := range routes {
+		if route.GatewayType == tcloudNatGatewayType {
+			routes[i].NatGatewayID = natIDMap[route.CloudGatewayID]
+		}
+	}
+
+	return nil
+}
+
+// fillAwsRouteNatID 下一跳为NAT网关的路由，填充对应的hcm NAT网关ID
+func (svc *routeTableSvc) fillAwsRouteNatID(kt *kit.Kit, routes []corert.AwsRoute) error {
+	cloudNatIDs := make([]string, 0)
+	for _, route := range routes {
+		if route.CloudNatGatewayID != nil && len(*route.CloudNatGatewayID) != 0 {
+			cloudNatIDs = append(cloudNatIDs, *route.CloudNatGatewayID)
+		}
+	}
+
+	natIDMap, err := svc.getNatIDMap(kt, enumor.Aws, cloudNatIDs)
+	if err != nil {
+		return err
+	}
+
+	for i, route := range routes {
+		if route.CloudNatGatewayID != nil {
+			routes[i].NatGatewayID = natIDMap[*route.CloudNatGatewayID]
+		}
+	}
+
+	return nil
+}
+
+// fillHuaWeiRouteNatID 下一跳为NAT网关的路由，填充对应的hcm NAT网关ID
+func (svc *routeTableSvc) fillHuaWeiRouteNatID(kt *kit.Kit, routes []corert.HuaWeiRoute) error {
+	cloudNatIDs := make([]string, 0)
+	for _, route := range routes {
+		if route.Type == huaweiNatGatewayType {
+			cloudNatIDs = append(cloudNatIDs, route.NextHop)
+		}
+	}
+
+	natIDMap, err := svc.getNatIDMap(kt, enumor.HuaWei, cloudNatIDs)
+	if err != nil {
+		return err
+	}
+
+	for i, route := range routes {
+		if route.Type == huaweiNatGatewayType {
+			routes[i].NatGatewayID = natIDMap[route.NextHop]
+		}
+	}
+
+	return nil
+}
+
+// getNatIDMap 查询NAT网关云ID到hcm ID的映射，未同步的NAT网关不在映射中
+func (svc *routeTableSvc) getNatIDMap(kt *kit.Kit, vendor enumor.Vendor, cloudIDs []string) (map[string]string,
+	error) {
+
+	natIDMap := make(map[string]string)
+	cloudIDs = slice.Unique(cloudIDs)
+	if len(cloudIDs) == 0 {
+		return natIDMap, nil
+	}
+
+	for _, part := range slice.Split(cloudIDs, int(core.DefaultMaxPageLimit)) {
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id"},
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+					&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: part},
+				},
+			},
+			Page: core.NewDefaultBasePage(),
+		}
+		result, err := svc.client.DataService().Global.NatGateway.ListNatGateway(kt, req)
+		if err != nil {
+			logs.Errorf("list nat gateway failed, err: %v, cloud_ids: %v, rid: %s", err, part, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			natIDMap[one.CloudID] = one.ID
+		}
+	}
+
+	return natIDMap, nil
+}
@@ -0,0 +1,46 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package securitygroup
+
+import (
+	logicsapproval "hcm/cmd/cloud-server/logics/approval"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+)
+
+// checkSGRuleChange 变更安全组规则前的校验，云厂商以安全组记录为准，不信任请求路径中的云厂商，
+// 安全组已分配业务时，不论从资源下还是业务下变更规则都需要校验业务的审批策略
+func (svc *securityGroupSvc) checkSGRuleChange(kt *kit.Kit, vendor enumor.Vendor,
+	sgBaseInfo *types.CloudResourceBasicInfo) error {
+
+	if sgBaseInfo.Vendor != vendor {
+		return errf.Newf(errf.InvalidParameter, "security group %s vendor is %s, not %s", sgBaseInfo.ID,
+			sgBaseInfo.Vendor, vendor)
+	}
+
+	if sgBaseInfo.BkBizID == constant.UnassignedBiz {
+		return nil
+	}
+
+	return logicsapproval.CheckPolicy(kt, svc.client.DataService(), sgBaseInfo.BkBizID,
+		enumor.ChangeSecurityGroupRule, sgBaseInfo.Vendor)
+}
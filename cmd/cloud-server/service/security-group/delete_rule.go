@@ -69,6 +69,10 @@ func (svc *securityGroupSvc) deleteSGRule(cts *rest.Contexts, validHandler handl
 		return nil, err
 	}
 
+	if err = svc.checkSGRuleChange(cts.Kit, vendor, basicInfo); err != nil {
+		return nil, err
+	}
+
 	// create delete audit.
 	err = svc.audit.ChildResDeleteAudit(cts.Kit, enumor.SecurityGroupRuleAuditResType, sgID, []string{id})
 	if err != nil {
@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// AnalyzeBizSGExposure analyze biz security group exposure.
+func (svc *securityGroupSvc) AnalyzeBizSGExposure(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(proto.SecurityGroupExposureAnalyzeReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, noPermFlag, err := handler.ListBizAuthRes(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.SecurityGroup, Action: meta.Find})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &corecloud.BizExposureResult{SecurityGroups: make([]corecloud.SecurityGroupExposure, 0),
+			Cvms: make([]corecloud.CvmExposure, 0)}, nil
+	}
+
+	result, err := svc.exposure.AnalyzeBiz(cts.Kit, bizID, req.SecurityGroupIDs)
+	if err != nil {
+		logs.Errorf("analyze biz security group exposure failed, err: %v, bizID: %d, rid: %s", err, bizID,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AnalyzeCvmSGExposure analyze cvm security group exposure.
+func (svc *securityGroupSvc) AnalyzeCvmSGExposure(cts *rest.Contexts) (interface{}, error) {
+	return svc.analyzeCvmSGExposure(cts, handler.ResOperateAuth)
+}
+
+// AnalyzeBizCvmSGExposure analyze biz cvm security group exposure.
+func (svc *securityGroupSvc) AnalyzeBizCvmSGExposure(cts *rest.Contexts) (interface{}, error) {
+	return svc.analyzeCvmSGExposure(cts, handler.BizOperateAuth)
+}
+
+func (svc *securityGroupSvc) analyzeCvmSGExposure(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	cvmID := cts.PathParameter("cvm_id").String()
+	if len(cvmID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "cvm_id is required")
+	}
+
+	baseInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.CvmCloudResType, cvmID)
+	if err != nil {
+		logs.Errorf("get cvm basic info failed, err: %v, cvmID: %s, rid: %s", err, cvmID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.SecurityGroup,
+		Action: meta.Find, BasicInfo: baseInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", cvmID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	cvms, err := svc.client.DataService().Global.Cvm.ListCvm(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list cvm failed, err: %v, cvmID: %s, rid: %s", err, cvmID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(cvms.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "cvm: %s not found", cvmID)
+	}
+
+	result, err := svc.exposure.AnalyzeCvm(cts.Kit, &cvms.Details[0])
+	if err != nil {
+		logs.Errorf("analyze cvm security group exposure failed, err: %v, cvmID: %s, rid: %s", err, cvmID,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	sgexposure "hcm/cmd/cloud-server/logics/sg-exposure"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
@@ -35,6 +36,7 @@ func InitSecurityGroupService(c *capability.Capability) {
 		client:     c.ApiClient,
 		authorizer: c.Authorizer,
 		audit:      c.Audit,
+		exposure:   sgexposure.NewExposure(c.ApiClient),
 	}
 
 	h := rest.NewHandler()
@@ -66,6 +68,8 @@ func InitSecurityGroupService(c *capability.Capability) {
 		"/vendors/{vendor}/security_groups/{security_group_id}/rules/{id}", svc.DeleteSecurityGroupRule)
 	h.Add("GetAzureDefaultSGRule", http.MethodGet, "/vendors/azure/default/security_groups/rules/{type}",
 		svc.GetAzureDefaultSGRule)
+	h.Add("AnalyzeCvmSGExposure", http.MethodGet, "/security_groups/exposures/cvms/{cvm_id}",
+		svc.AnalyzeCvmSGExposure)
 
 	// 业务下安全组相关接口
 	h.Add("CreateBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/create",
@@ -99,6 +103,11 @@ func InitSecurityGroupService(c *capability.Capability) {
 	h.Add("DeleteBizSGRule", http.MethodDelete,
 		"/bizs/{bk_biz_id}/vendors/{vendor}/security_groups/{security_group_id}/rules/{id}", svc.DeleteBizSGRule)
 
+	h.Add("AnalyzeBizSGExposure", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/exposures/analyze",
+		svc.AnalyzeBizSGExposure)
+	h.Add("AnalyzeBizCvmSGExposure", http.MethodGet, "/bizs/{bk_biz_id}/security_groups/exposures/cvms/{cvm_id}",
+		svc.AnalyzeBizCvmSGExposure)
+
 	h.Load(c.WebService)
 }
 
@@ -106,4 +115,5 @@ type securityGroupSvc struct {
 	client     *client.ClientSet
 	authorizer auth.Authorizer
 	audit      audit.Interface
+	exposure   sgexposure.Interface
 }
@@ -74,6 +74,10 @@ func (svc *securityGroupSvc) updateSGRule(cts *rest.Contexts, validHandler handl
 		return nil, err
 	}
 
+	if err = svc.checkSGRuleChange(cts.Kit, vendor, sgBaseInfo); err != nil {
+		return nil, err
+	}
+
 	switch vendor {
 	case enumor.TCloud:
 		return svc.updateTCloudSGRule(cts, sgBaseInfo, id)
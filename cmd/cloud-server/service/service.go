@@ -29,29 +29,42 @@ import (
 	"time"
 
 	"hcm/cmd/cloud-server/logics"
+	logicsapproval "hcm/cmd/cloud-server/logics/approval"
 	logicaudit "hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/service/account"
 	"hcm/cmd/cloud-server/service/application"
 	appcvm "hcm/cmd/cloud-server/service/application/handlers/cvm"
+	"hcm/cmd/cloud-server/service/approval"
 	approvalprocess "hcm/cmd/cloud-server/service/approval_process"
 	argstpl "hcm/cmd/cloud-server/service/argument-template"
 	"hcm/cmd/cloud-server/service/assign"
 	"hcm/cmd/cloud-server/service/audit"
 	"hcm/cmd/cloud-server/service/bill"
+	"hcm/cmd/cloud-server/service/budget"
 	"hcm/cmd/cloud-server/service/capability"
 	cloudselection "hcm/cmd/cloud-server/service/cloud-selection"
 	"hcm/cmd/cloud-server/service/cvm"
 	"hcm/cmd/cloud-server/service/disk"
+	"hcm/cmd/cloud-server/service/drift"
 	"hcm/cmd/cloud-server/service/eip"
 	"hcm/cmd/cloud-server/service/firewall"
+	"hcm/cmd/cloud-server/service/guardrail"
 	"hcm/cmd/cloud-server/service/image"
 	instancetype "hcm/cmd/cloud-server/service/instance-type"
+	"hcm/cmd/cloud-server/service/ipam"
+	keypair "hcm/cmd/cloud-server/service/key-pair"
+	"hcm/cmd/cloud-server/service/lease"
+	loadbalancer "hcm/cmd/cloud-server/service/load-balancer"
+	natgateway "hcm/cmd/cloud-server/service/nat-gateway"
 	networkinterface "hcm/cmd/cloud-server/service/network-interface"
 	"hcm/cmd/cloud-server/service/recycle"
 	"hcm/cmd/cloud-server/service/region"
 	resourcegroup "hcm/cmd/cloud-server/service/resource-group"
+	resourcetag "hcm/cmd/cloud-server/service/resource-tag"
 	routetable "hcm/cmd/cloud-server/service/route-table"
 	securitygroup "hcm/cmd/cloud-server/service/security-group"
+	"hcm/cmd/cloud-server/service/snapshot"
+	"hcm/cmd/cloud-server/service/stack"
 	subaccount "hcm/cmd/cloud-server/service/sub-account"
 	"hcm/cmd/cloud-server/service/subnet"
 	"hcm/cmd/cloud-server/service/sync"
@@ -77,6 +90,7 @@ import (
 	"hcm/pkg/tools/ssl"
 
 	"github.com/emicklei/go-restful/v3"
+	etcd3 "go.etcd.io/etcd/client/v3"
 )
 
 // Service do all the cloud server's work
@@ -91,6 +105,8 @@ type Service struct {
 	// itsmCli itsm client.
 	itsmCli   itsm.Client
 	bkBaseCli bkbase.Client
+	// nativeApproval native approval engine, not nil when native approval engine is chosen.
+	nativeApproval *logicsapproval.Native
 }
 
 // NewService create a service instance.
@@ -131,11 +147,19 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 		return nil, err
 	}
 
-	itsmCfg := cc.CloudServer().Itsm
-	itsmCli, err := itsm.NewClient(&itsmCfg, metrics.Register())
-	if err != nil {
-		logs.Errorf("failed to create itsm client, err: %v", err)
-		return nil, err
+	// 申请单审批引擎，没有ITSM的环境可以选择内置审批引擎
+	var itsmCli itsm.Client
+	var nativeApproval *logicsapproval.Native
+	if cc.CloudServer().Approval.IsNative() {
+		nativeApproval = logicsapproval.NewNative(apiClientSet)
+		itsmCli = nativeApproval
+	} else {
+		itsmCfg := cc.CloudServer().Itsm
+		itsmCli, err = itsm.NewClient(&itsmCfg, metrics.Register())
+		if err != nil {
+			logs.Errorf("failed to create itsm client, err: %v", err)
+			return nil, err
+		}
 	}
 
 	bkbaseCfg := cc.CloudServer().CloudSelection.BkBase
@@ -153,6 +177,8 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 		esbClient:  esbClient,
 		itsmCli:    itsmCli,
 		bkBaseCli:  bkbaseCli,
+
+		nativeApproval: nativeApproval,
 	}
 
 	etcdCfg, err := cc.CloudServer().Service.Etcd.ToConfig()
@@ -163,17 +189,49 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	incrementalSync := cc.CloudServer().CloudResource.IncrementalSync
 	if cc.CloudServer().CloudResource.Sync.Enable {
 		interval := time.Duration(cc.CloudServer().CloudResource.Sync.SyncIntervalMin) * time.Minute
+		// 开启增量同步后全量同步只作为兜底的对账，使用更长的同步间隔
+		if incrementalSync.Enable {
+			interval = time.Duration(incrementalSync.ReconcileIntervalMin) * time.Minute
+		}
 		go sync.CloudResourceSync(interval, sd, apiClientSet)
 	}
+	if incrementalSync.Enable {
+		etcdCli, err := etcd3.New(etcdCfg)
+		if err != nil {
+			return nil, err
+		}
+		go sync.CloudResourceIncrementalSync(incrementalSync, sd, apiClientSet, etcdCli)
+	}
 	if cc.CloudServer().BillConfig.Enable {
 		interval := time.Duration(cc.CloudServer().BillConfig.SyncIntervalMin) * time.Minute
 		go bill.CloudBillConfigCreate(interval, sd, apiClientSet)
 	}
+	if cc.CloudServer().BillIngest.Enable {
+		go bill.CloudBillIngest(cc.CloudServer().BillIngest, sd, apiClientSet)
+	}
+
+	if cc.CloudServer().Budget.Enable {
+		go budget.BudgetEvaluateTiming(cc.CloudServer().Budget, sd, apiClientSet)
+	}
+	if cc.CloudServer().SnapshotPolicy.Enable {
+		go snapshot.SnapshotPolicyTiming(cc.CloudServer().SnapshotPolicy, sd, apiClientSet)
+	}
+	if cc.CloudServer().Drift.Enable {
+		go drift.DriftCheckTiming(cc.CloudServer().Drift, sd, apiClientSet)
+	}
+	if cc.CloudServer().Lease.Enable {
+		go lease.LeaseCheckTiming(cc.CloudServer().Lease, sd, apiClientSet, esbClient)
+	}
+	if nativeApproval != nil {
+		go nativeApproval.ReconcileTiming(cc.CloudServer().Approval.ReconcileIntervalMin, sd)
+	}
 	recycle.RecycleTiming(apiClientSet, sd, cc.CloudServer().Recycle, esbClient)
 
 	go appcvm.TimingHandleDeliverApplication(svr.client, 2*time.Second)
+	go stack.StackFlowWatchTiming(5*time.Second, sd, apiClientSet)
 	return svr, nil
 }
 
@@ -256,6 +314,8 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 		Logics:     logics.NewLogics(s.client, s.esbClient),
 		ItsmCli:    s.itsmCli,
 		BKBaseCli:  s.bkBaseCli,
+
+		NativeApproval: s.nativeApproval,
 	}
 
 	account.InitAccountService(c)
@@ -271,15 +331,27 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	zone.InitZoneService(c)
 	region.InitRegionService(c)
 	eip.InitEipService(c)
+	loadbalancer.InitLoadBalancerService(c)
+	snapshot.InitSnapshotService(c)
+	keypair.InitKeyPairService(c)
+	natgateway.InitNatGatewayService(c)
+	ipam.InitIPAMService(c)
+	drift.InitDriftService(c)
+	stack.InitStackService(c)
+	resourcetag.InitResourceTagService(c)
 	instancetype.InitInstanceTypeService(c)
 	networkinterface.InitNetworkInterfaceService(c)
 	subaccount.InitService(c)
 
 	application.InitApplicationService(c, bkHcmUrl)
+	approval.InitApprovalService(c)
+	guardrail.InitGuardrailService(c)
+	lease.InitLeaseService(c)
 	audit.InitService(c)
 	assign.InitService(c)
 	recycle.InitService(c)
 	bill.InitBillService(c)
+	budget.InitBudgetService(c)
 
 	user.InitService(c)
 
@@ -0,0 +1,145 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"time"
+
+	logicsnapshot "hcm/cmd/cloud-server/logics/snapshot"
+	cssnapshot "hcm/pkg/api/cloud-server/snapshot"
+	"hcm/pkg/api/core"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+const defaultCoverageDays = 7
+
+// ListBizSnapshotCoverage list snapshot coverage of biz disks.
+func (svc *snapshotSvc) ListBizSnapshotCoverage(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(cssnapshot.CoverageReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, noPerm, err := handler.ListBizAuthRes(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Snapshot, Action: meta.Find})
+	if err != nil {
+		return nil, err
+	}
+	if noPerm {
+		return nil, errf.New(errf.PermissionDenied, "permission denied for list snapshot coverage")
+	}
+
+	days := req.Days
+	if days == 0 {
+		days = defaultCoverageDays
+	}
+
+	disks, snapshots, err := svc.listBizDiskSnapshot(cts.Kit, bizID)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]coresnapshot.SnapshotPolicy, 0)
+	listReq := &core.ListReq{Filter: tools.EqualExpression("bk_biz_id", bizID), Page: core.NewDefaultBasePage()}
+	for {
+		result, err := svc.client.DataService().Global.Snapshot.ListSnapshotPolicy(cts.Kit, listReq)
+		if err != nil {
+			logs.Errorf("list biz snapshot policy failed, err: %v, biz: %d, rid: %s", err, bizID, cts.Kit.Rid)
+			return nil, err
+		}
+		policies = append(policies, result.Details...)
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	since := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	return logicsnapshot.Coverage(disks, snapshots, policies, since), nil
+}
+
+// listBizDiskSnapshot 查询业务下所有云盘及这些云盘的快照
+func (svc *snapshotSvc) listBizDiskSnapshot(kt *kit.Kit, bizID int64) ([]*coredisk.BaseDisk,
+	[]coresnapshot.BaseSnapshot, error) {
+
+	disks := make([]*coredisk.BaseDisk, 0)
+	snapshots := make([]coresnapshot.BaseSnapshot, 0)
+	diskReq := &core.ListReq{Filter: tools.EqualExpression("bk_biz_id", bizID), Page: core.NewDefaultBasePage()}
+	for {
+		diskResult, err := svc.client.DataService().Global.ListDisk(kt, diskReq)
+		if err != nil {
+			logs.Errorf("list biz disk failed, err: %v, biz: %d, rid: %s", err, bizID, kt.Rid)
+			return nil, nil, err
+		}
+
+		if len(diskResult.Details) == 0 {
+			break
+		}
+		disks = append(disks, diskResult.Details...)
+
+		diskIDs := make([]string, 0, len(diskResult.Details))
+		for _, one := range diskResult.Details {
+			diskIDs = append(diskIDs, one.ID)
+		}
+
+		snapshotReq := &core.ListReq{
+			Filter: tools.ContainersExpression("disk_id", diskIDs),
+			Page:   core.NewDefaultBasePage(),
+		}
+		for {
+			snapshotResult, err := svc.client.DataService().Global.Snapshot.ListSnapshot(kt, snapshotReq)
+			if err != nil {
+				logs.Errorf("list disk snapshot failed, err: %v, biz: %d, rid: %s", err, bizID, kt.Rid)
+				return nil, nil, err
+			}
+			snapshots = append(snapshots, snapshotResult.Details...)
+
+			if uint(len(snapshotResult.Details)) < snapshotReq.Page.Limit {
+				break
+			}
+			snapshotReq.Page.Start += uint32(snapshotReq.Page.Limit)
+		}
+
+		if uint(len(diskResult.Details)) < diskReq.Page.Limit {
+			break
+		}
+		diskReq.Page.Start += uint32(diskReq.Page.Limit)
+	}
+
+	return disks, snapshots, nil
+}
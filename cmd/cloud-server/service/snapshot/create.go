@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	cssnapshot "hcm/pkg/api/cloud-server/snapshot"
+	"hcm/pkg/api/core"
+	hcsnapshot "hcm/pkg/api/hc-service/snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// CreateSnapshot create snapshot.
+func (svc *snapshotSvc) CreateSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.createSnapshot(cts, handler.ResOperateAuth, 0)
+}
+
+// CreateBizSnapshot create biz snapshot.
+func (svc *snapshotSvc) CreateBizSnapshot(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.createSnapshot(cts, handler.BizOperateAuth, bizID)
+}
+
+func (svc *snapshotSvc) createSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	bizID int64) (interface{}, error) {
+
+	req := new(cssnapshot.SnapshotCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 快照创建权限通过源云盘所属的账号和业务进行校验
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.DiskCloudResType,
+		req.DiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Snapshot,
+		Action: meta.Create, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	createReq := &hcsnapshot.SnapshotCreateReq{
+		DiskID:  req.DiskID,
+		Name:    req.Name,
+		Memo:    req.Memo,
+		BkBizID: bizID,
+	}
+	return svc.createVendorSnapshot(cts.Kit, basicInfo.Vendor, createReq)
+}
+
+func (svc *snapshotSvc) createVendorSnapshot(kt *kit.Kit, vendor enumor.Vendor, req *hcsnapshot.SnapshotCreateReq) (
+	*core.CreateResult, error) {
+
+	var result *core.CreateResult
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		result, err = svc.client.HCService().TCloud.Snapshot.CreateSnapshot(kt, req)
+	case enumor.Aws:
+		result, err = svc.client.HCService().Aws.Snapshot.CreateSnapshot(kt, req)
+	case enumor.HuaWei:
+		result, err = svc.client.HCService().HuaWei.Snapshot.CreateSnapshot(kt, req)
+	case enumor.Gcp:
+		result, err = svc.client.HCService().Gcp.Snapshot.CreateSnapshot(kt, req)
+	case enumor.Azure:
+		result, err = svc.client.HCService().Azure.Snapshot.CreateSnapshot(kt, req)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", vendor)
+	}
+	if err != nil {
+		logs.Errorf("create %s snapshot failed, err: %v, disk: %s, rid: %s", vendor, err, req.DiskID, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,327 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"fmt"
+
+	proto "hcm/pkg/api/cloud-server"
+	cssnapshot "hcm/pkg/api/cloud-server/snapshot"
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	datasnapshot "hcm/pkg/api/data-service/cloud/snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// ListSnapshotPolicy list snapshot policy.
+func (svc *snapshotSvc) ListSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.listSnapshotPolicy(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizSnapshotPolicy list biz snapshot policy.
+func (svc *snapshotSvc) ListBizSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.listSnapshotPolicy(cts, handler.ListBizAuthRes)
+}
+
+func (svc *snapshotSvc) listSnapshotPolicy(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (
+	interface{}, error) {
+
+	req := new(proto.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Snapshot, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &core.ListResult{Count: 0, Details: make([]interface{}, 0)}, nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   req.Page,
+	}
+	return svc.client.DataService().Global.Snapshot.ListSnapshotPolicy(cts.Kit, listReq)
+}
+
+// CreateSnapshotPolicy create snapshot policy.
+func (svc *snapshotSvc) CreateSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.createSnapshotPolicy(cts, handler.ResOperateAuth, 0)
+}
+
+// CreateBizSnapshotPolicy create biz snapshot policy.
+func (svc *snapshotSvc) CreateBizSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.createSnapshotPolicy(cts, handler.BizOperateAuth, bizID)
+}
+
+func (svc *snapshotSvc) createSnapshotPolicy(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	bizID int64) (interface{}, error) {
+
+	req := new(cssnapshot.PolicyCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	diskIDs := slice.Unique(req.DiskIDs)
+	diskInfos, err := svc.getPolicyDiskInfos(cts.Kit, diskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Snapshot,
+		Action: meta.Create, BasicInfos: diskInfos})
+	if err != nil {
+		return nil, err
+	}
+
+	first := diskInfos[diskIDs[0]]
+	createReq := &datasnapshot.PolicyCreateReq{
+		Name:           req.Name,
+		Vendor:         first.Vendor,
+		AccountID:      first.AccountID,
+		BkBizID:        bizID,
+		DiskIDs:        diskIDs,
+		Cron:           req.Cron,
+		RetentionCount: req.RetentionCount,
+		State:          enumor.SnapshotPolicyEnabled,
+		Memo:           req.Memo,
+	}
+	return svc.client.DataService().Global.Snapshot.CreateSnapshotPolicy(cts.Kit, createReq)
+}
+
+// getPolicyDiskInfos 获取策略关联云盘的基础信息，一个策略下的云盘必须属于同一个账号
+func (svc *snapshotSvc) getPolicyDiskInfos(kt *kit.Kit, diskIDs []string) (map[string]types.CloudResourceBasicInfo,
+	error) {
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.DiskCloudResType,
+		IDs:          diskIDs,
+	}
+	diskInfos, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(kt, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var accountID string
+	for _, id := range diskIDs {
+		info, exist := diskInfos[id]
+		if !exist {
+			return nil, errf.Newf(errf.RecordNotFound, "disk: %s not found", id)
+		}
+
+		if len(accountID) == 0 {
+			accountID = info.AccountID
+		}
+		if info.AccountID != accountID {
+			return nil, errf.New(errf.InvalidParameter, "disks of snapshot policy should belong to the same account")
+		}
+	}
+
+	return diskInfos, nil
+}
+
+// UpdateSnapshotPolicy update snapshot policy.
+func (svc *snapshotSvc) UpdateSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.updateSnapshotPolicy(cts, handler.ResOperateAuth)
+}
+
+// UpdateBizSnapshotPolicy update biz snapshot policy.
+func (svc *snapshotSvc) UpdateBizSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.updateSnapshotPolicy(cts, handler.BizOperateAuth)
+}
+
+func (svc *snapshotSvc) updateSnapshotPolicy(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(cssnapshot.PolicyUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	policyInfos, err := svc.listPolicyBasicInfo(cts.Kit, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Snapshot,
+		Action: meta.Update, BasicInfos: policyInfos})
+	if err != nil {
+		return nil, err
+	}
+
+	var diskIDs []string
+	if len(req.DiskIDs) != 0 {
+		diskIDs = slice.Unique(req.DiskIDs)
+		diskInfos, err := svc.getPolicyDiskInfos(cts.Kit, diskIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		if diskInfos[diskIDs[0]].AccountID != policyInfos[id].AccountID {
+			return nil, errf.New(errf.InvalidParameter, "disks should belong to the account of snapshot policy")
+		}
+
+		err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Snapshot,
+			Action: meta.Create, BasicInfos: diskInfos})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	updateReq := &datasnapshot.PolicyUpdateReq{
+		Name:           req.Name,
+		DiskIDs:        diskIDs,
+		Cron:           req.Cron,
+		RetentionCount: req.RetentionCount,
+		State:          req.State,
+		Memo:           req.Memo,
+	}
+	if err = svc.client.DataService().Global.Snapshot.UpdateSnapshotPolicy(cts.Kit, id, updateReq); err != nil {
+		logs.Errorf("update snapshot policy failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteSnapshotPolicy batch delete snapshot policy.
+func (svc *snapshotSvc) BatchDeleteSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteSnapshotPolicy(cts, handler.ResOperateAuth)
+}
+
+// BatchDeleteBizSnapshotPolicy batch delete biz snapshot policy.
+func (svc *snapshotSvc) BatchDeleteBizSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteSnapshotPolicy(cts, handler.BizOperateAuth)
+}
+
+func (svc *snapshotSvc) batchDeleteSnapshotPolicy(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(proto.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	policyInfos, err := svc.listPolicyBasicInfo(cts.Kit, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Snapshot,
+		Action: meta.Delete, BasicInfos: policyInfos})
+	if err != nil {
+		return nil, err
+	}
+
+	// 删除策略不会删除已经创建的快照
+	deleteReq := &core.BatchDeleteReq{IDs: req.IDs}
+	if err = svc.client.DataService().Global.Snapshot.BatchDeleteSnapshotPolicy(cts.Kit, deleteReq); err != nil {
+		logs.Errorf("batch delete snapshot policy failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// listPolicyBasicInfo 快照策略按照账号和业务进行鉴权，转换为资源基础信息以复用通用的鉴权逻辑
+func (svc *snapshotSvc) listPolicyBasicInfo(kt *kit.Kit, ids []string) (map[string]types.CloudResourceBasicInfo,
+	error) {
+
+	policies, err := svc.listPolicy(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make(map[string]types.CloudResourceBasicInfo, len(policies))
+	for _, one := range policies {
+		infos[one.ID] = types.CloudResourceBasicInfo{
+			ID:        one.ID,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			BkBizID:   one.BkBizID,
+		}
+	}
+
+	for _, id := range ids {
+		if _, exist := infos[id]; !exist {
+			return nil, errf.Newf(errf.RecordNotFound, "snapshot policy: %s not found", id)
+		}
+	}
+
+	return infos, nil
+}
+
+func (svc *snapshotSvc) listPolicy(kt *kit.Kit, ids []string) ([]coresnapshot.SnapshotPolicy, error) {
+	if len(ids) > int(core.DefaultMaxPageLimit) {
+		return nil, fmt.Errorf("snapshot policy ids should <= %d", core.DefaultMaxPageLimit)
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.Snapshot.ListSnapshotPolicy(kt, listReq)
+	if err != nil {
+		logs.Errorf("list snapshot policy failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
@@ -0,0 +1,268 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	logicsnapshot "hcm/cmd/cloud-server/logics/snapshot"
+	actionsnapshot "hcm/cmd/task-server/logics/action/snapshot"
+	"hcm/pkg/api/core"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	datasnapshot "hcm/pkg/api/data-service/cloud/snapshot"
+	hcsnapshot "hcm/pkg/api/hc-service/snapshot"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/action"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/cron"
+	"hcm/pkg/tools/times"
+)
+
+// snapshotNameMaxLen 各云厂商快照名称长度限制的最小值
+const snapshotNameMaxLen = 60
+
+// SnapshotPolicyTiming 定时检查到期的快照策略，为每个策略创建一个异步任务流创建快照并清理超出保留数量的快照。
+func SnapshotPolicyTiming(conf cc.SnapshotPolicy, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("snapshot policy enable && start, checkIntervalMin: %d", conf.CheckIntervalMin)
+
+	for {
+		time.Sleep(time.Duration(conf.CheckIntervalMin) * time.Minute)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+
+		start := time.Now()
+		logs.Infof("snapshot policy run start, time: %v, rid: %s", start, kt.Rid)
+
+		runDuePolicies(kt, cliSet, start)
+
+		logs.Infof("snapshot policy run end, cost: %v, rid: %s", time.Since(start), kt.Rid)
+	}
+}
+
+func runDuePolicies(kt *kit.Kit, cliSet *client.ClientSet, now time.Time) {
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "state", Op: filter.Equal.Factory(), Value: enumor.SnapshotPolicyEnabled},
+				&filter.AtomRule{Field: "next_run_at", Op: filter.LessThanEqual.Factory(),
+					Value: times.ConvStdTimeFormat(now)},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+
+	// 执行后策略的下次执行时间会被更新，不再满足查询条件，因此每次都从头查询
+	for {
+		result, err := cliSet.DataService().Global.Snapshot.ListSnapshotPolicy(kt, listReq)
+		if err != nil {
+			logs.Errorf("list due snapshot policy failed, err: %v, rid: %s", err, kt.Rid)
+			return
+		}
+
+		for idx := range result.Details {
+			policy := &result.Details[idx]
+			// 单个策略执行失败不影响其他策略，失败的策略也会更新下次执行时间，避免反复重试
+			flowID, err := runPolicy(kt, cliSet, policy, now)
+			if err != nil {
+				logs.Errorf("run snapshot policy failed, err: %v, id: %s, rid: %s", err, policy.ID, kt.Rid)
+			}
+
+			if err = updatePolicyNextRun(kt, cliSet, policy, flowID, now); err != nil {
+				logs.Errorf("update snapshot policy next run failed, err: %v, id: %s, rid: %s", err, policy.ID,
+					kt.Rid)
+				return
+			}
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+	}
+}
+
+func updatePolicyNextRun(kt *kit.Kit, cliSet *client.ClientSet, policy *coresnapshot.SnapshotPolicy,
+	flowID string, now time.Time) error {
+
+	updateReq := &datasnapshot.PolicyUpdateReq{LastFlowID: flowID}
+	schedule, err := cron.Parse(policy.Cron)
+	if err != nil {
+		return err
+	}
+
+	nextRunAt, err := schedule.Next(now)
+	if err != nil {
+		// 表达式不会再被触发时停用策略，避免每次检查都重复执行
+		logs.Warnf("snapshot policy cron will never trigger, disable it, id: %s, cron: %s, rid: %s", policy.ID,
+			policy.Cron, kt.Rid)
+		updateReq.State = enumor.SnapshotPolicyDisabled
+	} else {
+		updateReq.NextRunAt = &nextRunAt
+	}
+
+	return cliSet.DataService().Global.Snapshot.UpdateSnapshotPolicy(kt, policy.ID, updateReq)
+}
+
+// runPolicy 为策略下的每个云盘创建快照，并在快照创建成功后删除超出保留数量的旧快照
+func runPolicy(kt *kit.Kit, cliSet *client.ClientSet, policy *coresnapshot.SnapshotPolicy, now time.Time) (
+	string, error) {
+
+	disks, err := listPolicyDisk(kt, cliSet, policy.DiskIDs)
+	if err != nil {
+		return "", err
+	}
+
+	if len(disks) == 0 {
+		logs.Warnf("snapshot policy has no exist disk, id: %s, rid: %s", policy.ID, kt.Rid)
+		return "", nil
+	}
+
+	snapshots, err := listPolicySnapshot(kt, cliSet, policy.ID)
+	if err != nil {
+		return "", err
+	}
+
+	tasks := buildPolicyTasks(policy, disks, snapshots, now)
+	flowReq := &ts.AddCustomFlowReq{
+		Name:  enumor.FlowSnapshotPolicy,
+		Tasks: tasks,
+	}
+	result, err := cliSet.TaskServer().CreateCustomFlow(kt, flowReq)
+	if err != nil {
+		logs.Errorf("call taskserver to create custom flow failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	logs.Infof("snapshot policy flow created, policy: %s, flow: %s, rid: %s", policy.ID, result.ID, kt.Rid)
+	return result.ID, nil
+}
+
+func buildPolicyTasks(policy *coresnapshot.SnapshotPolicy, disks []*coredisk.BaseDisk,
+	snapshots []coresnapshot.BaseSnapshot, now time.Time) []ts.CustomFlowTask {
+
+	diskSnapshotMap := make(map[string][]coresnapshot.BaseSnapshot)
+	for _, one := range snapshots {
+		diskSnapshotMap[one.DiskID] = append(diskSnapshotMap[one.DiskID], one)
+	}
+
+	name := policySnapshotName(policy.Name, now)
+	tasks := make([]ts.CustomFlowTask, 0)
+	count := 1
+	for _, disk := range disks {
+		rateLimitKey := tableasync.NewRateLimitKey(policy.Vendor, disk.AccountID, disk.Region)
+		createID := action.ActIDType(strconv.Itoa(count))
+		count++
+		tasks = append(tasks, ts.CustomFlowTask{
+			ActionID:   createID,
+			ActionName: enumor.ActionCreateSnapshot,
+			Params: &actionsnapshot.CreateSnapshotOption{
+				Vendor: policy.Vendor,
+				SnapshotCreateReq: hcsnapshot.SnapshotCreateReq{
+					DiskID:   disk.ID,
+					Name:     name,
+					BkBizID:  policy.BkBizID,
+					PolicyID: policy.ID,
+				},
+			},
+			RateLimitKey: rateLimitKey,
+		})
+
+		for _, id := range logicsnapshot.ExpiredSnapshotIDs(diskSnapshotMap[disk.ID], policy.ID,
+			policy.RetentionCount) {
+
+			tasks = append(tasks, ts.CustomFlowTask{
+				ActionID:     action.ActIDType(strconv.Itoa(count)),
+				ActionName:   enumor.ActionDeleteSnapshot,
+				Params:       &actionsnapshot.DeleteSnapshotOption{Vendor: policy.Vendor, ID: id},
+				DependOn:     []action.ActIDType{createID},
+				RateLimitKey: rateLimitKey,
+			})
+			count++
+		}
+	}
+
+	return tasks
+}
+
+// policySnapshotName 策略创建的快照名称为 策略名称-执行时间
+func policySnapshotName(policyName string, now time.Time) string {
+	suffix := now.Format("20060102150405")
+	maxLen := snapshotNameMaxLen - len(suffix) - 1
+	if utf8.RuneCountInString(policyName) > maxLen {
+		policyName = string([]rune(policyName)[:maxLen])
+	}
+
+	return fmt.Sprintf("%s-%s", policyName, suffix)
+}
+
+func listPolicyDisk(kt *kit.Kit, cliSet *client.ClientSet, diskIDs []string) ([]*coredisk.BaseDisk, error) {
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", diskIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := cliSet.DataService().Global.ListDisk(kt, listReq)
+	if err != nil {
+		logs.Errorf("list snapshot policy disk failed, err: %v, ids: %v, rid: %s", err, diskIDs, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func listPolicySnapshot(kt *kit.Kit, cliSet *client.ClientSet, policyID string) ([]coresnapshot.BaseSnapshot,
+	error) {
+
+	snapshots := make([]coresnapshot.BaseSnapshot, 0)
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("policy_id", policyID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		result, err := cliSet.DataService().Global.Snapshot.ListSnapshot(kt, listReq)
+		if err != nil {
+			logs.Errorf("list policy snapshot failed, err: %v, policy: %s, rid: %s", err, policyID, kt.Rid)
+			return nil, err
+		}
+		snapshots = append(snapshots, result.Details...)
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// RollbackSnapshot rollback disk to snapshot.
+func (svc *snapshotSvc) RollbackSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.rollbackSnapshot(cts, handler.ResOperateAuth)
+}
+
+// RollbackBizSnapshot rollback biz disk to snapshot.
+func (svc *snapshotSvc) RollbackBizSnapshot(cts *rest.Contexts) (interface{}, error) {
+	return svc.rollbackSnapshot(cts, handler.BizOperateAuth)
+}
+
+func (svc *snapshotSvc) rollbackSnapshot(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	basicInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit,
+		enumor.SnapshotCloudResType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 回滚会覆盖云盘数据，按快照的编辑权限进行校验
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.Snapshot,
+		Action: meta.Update, BasicInfo: basicInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	switch basicInfo.Vendor {
+	case enumor.TCloud:
+		err = svc.client.HCService().TCloud.Snapshot.RollbackSnapshot(cts.Kit, id)
+	case enumor.HuaWei:
+		err = svc.client.HCService().HuaWei.Snapshot.RollbackSnapshot(cts.Kit, id)
+	case enumor.Aws, enumor.Gcp, enumor.Azure:
+		// 亚马逊云、谷歌云、微软云没有快照回滚云盘的接口，只能通过快照创建新的云盘
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support rollback snapshot, only support "+
+			"vendor: %s, %s", basicInfo.Vendor, enumor.TCloud, enumor.HuaWei)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support rollback snapshot", basicInfo.Vendor)
+	}
+	if err != nil {
+		logs.Errorf("rollback %s snapshot failed, err: %v, id: %s, rid: %s", basicInfo.Vendor, err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package snapshot ...
+package snapshot
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/rest"
+)
+
+// InitSnapshotService initialize the disk snapshot service.
+func InitSnapshotService(c *capability.Capability) {
+	svc := &snapshotSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("GetSnapshot", http.MethodGet, "/snapshots/{id}", svc.GetSnapshot)
+	h.Add("ListSnapshot", http.MethodPost, "/snapshots/list", svc.ListSnapshot)
+	h.Add("CreateSnapshot", http.MethodPost, "/snapshots/create", svc.CreateSnapshot)
+	h.Add("BatchDeleteSnapshot", http.MethodDelete, "/snapshots/batch", svc.BatchDeleteSnapshot)
+	h.Add("RollbackSnapshot", http.MethodPost, "/snapshots/{id}/rollback", svc.RollbackSnapshot)
+	h.Add("ListSnapshotPolicy", http.MethodPost, "/snapshots/policies/list", svc.ListSnapshotPolicy)
+	h.Add("CreateSnapshotPolicy", http.MethodPost, "/snapshots/policies/create", svc.CreateSnapshotPolicy)
+	h.Add("UpdateSnapshotPolicy", http.MethodPatch, "/snapshots/policies/{id}", svc.UpdateSnapshotPolicy)
+	h.Add("BatchDeleteSnapshotPolicy", http.MethodDelete, "/snapshots/policies/batch",
+		svc.BatchDeleteSnapshotPolicy)
+
+	// snapshot apis in biz
+	h.Add("GetBizSnapshot", http.MethodGet, "/bizs/{bk_biz_id}/snapshots/{id}", svc.GetBizSnapshot)
+	h.Add("ListBizSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/snapshots/list", svc.ListBizSnapshot)
+	h.Add("CreateBizSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/snapshots/create", svc.CreateBizSnapshot)
+	h.Add("BatchDeleteBizSnapshot", http.MethodDelete, "/bizs/{bk_biz_id}/snapshots/batch",
+		svc.BatchDeleteBizSnapshot)
+	h.Add("RollbackBizSnapshot", http.MethodPost, "/bizs/{bk_biz_id}/snapshots/{id}/rollback",
+		svc.RollbackBizSnapshot)
+	h.Add("ListBizSnapshotPolicy", http.MethodPost, "/bizs/{bk_biz_id}/snapshots/policies/list",
+		svc.ListBizSnapshotPolicy)
+	h.Add("CreateBizSnapshotPolicy", http.MethodPost, "/bizs/{bk_biz_id}/snapshots/policies/create",
+		svc.CreateBizSnapshotPolicy)
+	h.Add("UpdateBizSnapshotPolicy", http.MethodPatch, "/bizs/{bk_biz_id}/snapshots/policies/{id}",
+		svc.UpdateBizSnapshotPolicy)
+	h.Add("BatchDeleteBizSnapshotPolicy", http.MethodDelete, "/bizs/{bk_biz_id}/snapshots/policies/batch",
+		svc.BatchDeleteBizSnapshotPolicy)
+	h.Add("ListBizSnapshotCoverage", http.MethodPost, "/bizs/{bk_biz_id}/snapshots/coverage",
+		svc.ListBizSnapshotCoverage)
+
+	h.Load(c.WebService)
+}
+
+type snapshotSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+}
@@ -0,0 +1,308 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package stack
+
+import (
+	"strconv"
+
+	logicsstack "hcm/cmd/cloud-server/logics/stack"
+	csstack "hcm/pkg/api/cloud-server/stack"
+	"hcm/pkg/api/core"
+	corestack "hcm/pkg/api/core/stack"
+	dsstack "hcm/pkg/api/data-service/stack"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// PlanStack 对比资源栈指定版本的模版与已创建的资源，返回apply需要执行的变更
+func (svc *stackSvc) PlanStack(cts *rest.Contexts) (interface{}, error) {
+	stack, req, err := svc.decodePlanReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.checkFindPermission(cts, stack.AccountID); err != nil {
+		return nil, err
+	}
+
+	plan, _, _, err := svc.plan(cts.Kit, stack, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// ApplyStack 按plan结果创建异步任务流，创建模版中新增的资源并删除模版中移除的资源，任务流失败时删除本次创建的资源
+func (svc *stackSvc) ApplyStack(cts *rest.Contexts) (interface{}, error) {
+	stack, req, err := svc.decodePlanReq(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	if stack.Status.InProgress() {
+		return nil, errf.Newf(errf.InvalidParameter, "stack: %s is %s", stack.Name, stack.Status)
+	}
+
+	plan, tpl, current, err := svc.plan(cts.Kit, stack, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0)
+	for _, item := range plan.Items {
+		switch item.Action {
+		case enumor.CreateStackPlanAction:
+			authRes = append(authRes, stackAuthResource(stack, item.ResType, meta.Create))
+		case enumor.DeleteStackPlanAction:
+			authRes = append(authRes, stackAuthResource(stack, item.ResType, meta.Delete))
+		}
+	}
+
+	// 没有需要创建、删除的资源时只更新已apply的版本
+	if len(authRes) == 0 {
+		if err = svc.checkFindPermission(cts, stack.AccountID); err != nil {
+			return nil, err
+		}
+
+		updateReq := &dsstack.StackUpdateReq{Status: enumor.AppliedStackStatus, AppliedVersion: plan.Version,
+			Resources: current}
+		if err = svc.client.DataService().Global.Stack.UpdateStack(cts.Kit, stack.ID, updateReq); err != nil {
+			logs.Errorf("update stack failed, err: %v, id: %s, rid: %s", err, stack.ID, cts.Kit.Rid)
+			return nil, err
+		}
+		return &csstack.ApplyResult{Plan: plan}, nil
+	}
+
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...); err != nil {
+		return nil, err
+	}
+
+	cloudSubnetVpcs, err := svc.getCloudSubnetVpcs(cts.Kit, stack, tpl)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := logicsstack.CompileApply(&logicsstack.CompileOption{Stack: stack, Template: tpl, Plan: plan,
+		Current: current, CloudSubnetVpcs: cloudSubnetVpcs})
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	shareData := tableasync.NewShareData()
+	shareData.Dict[logicsstack.VersionShareDataKey] = strconv.FormatUint(uint64(plan.Version), 10)
+	flowID, err := svc.startFlow(cts.Kit, stack, enumor.FlowApplyStack, shareData, tasks, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csstack.ApplyResult{FlowID: flowID, Plan: plan}, nil
+}
+
+// DestroyStack 创建异步任务流删除资源栈下的全部资源，资源栈及模版保留，可以重新apply
+func (svc *stackSvc) DestroyStack(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	stack, err := svc.getStack(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if stack.Status.InProgress() {
+		return nil, errf.Newf(errf.InvalidParameter, "stack: %s is %s", stack.Name, stack.Status)
+	}
+
+	current, err := logicsstack.Refresh(cts.Kit, svc.client.DataService(), stack.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(current) == 0 {
+		if err = svc.checkFindPermission(cts, stack.AccountID); err != nil {
+			return nil, err
+		}
+
+		updateReq := &dsstack.StackUpdateReq{Status: enumor.DestroyedStackStatus, Resources: current}
+		if err = svc.client.DataService().Global.Stack.UpdateStack(cts.Kit, stack.ID, updateReq); err != nil {
+			logs.Errorf("update stack failed, err: %v, id: %s, rid: %s", err, stack.ID, cts.Kit.Rid)
+			return nil, err
+		}
+		return &csstack.ApplyResult{}, nil
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(current))
+	for _, one := range current {
+		authRes = append(authRes, stackAuthResource(stack, one.ResType, meta.Delete))
+	}
+	if err = svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...); err != nil {
+		return nil, err
+	}
+
+	tasks, err := logicsstack.CompileDestroy(stack, current)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	flowID, err := svc.startFlow(cts.Kit, stack, enumor.FlowDestroyStack, tableasync.NewShareData(), tasks, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csstack.ApplyResult{FlowID: flowID}, nil
+}
+
+func (svc *stackSvc) decodePlanReq(cts *rest.Contexts) (*corestack.Stack, *csstack.PlanReq, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csstack.PlanReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	stack, err := svc.getStack(cts.Kit, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stack, req, nil
+}
+
+func (svc *stackSvc) plan(kt *kit.Kit, stack *corestack.Stack, version uint) (*csstack.PlanResult,
+	*corestack.Template, []corestack.Resource, error) {
+
+	tpl, version, err := svc.getTemplate(kt, stack, version)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	current, err := logicsstack.Refresh(kt, svc.client.DataService(), stack.Resources)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	plan, err := logicsstack.Plan(kt, svc.client.DataService(), version, tpl, current)
+	if err != nil {
+		logs.Errorf("plan stack failed, err: %v, id: %s, version: %d, rid: %s", err, stack.ID, version, kt.Rid)
+		return nil, nil, nil, err
+	}
+
+	return plan, tpl, current, nil
+}
+
+// getCloudSubnetVpcs 查询主机引用的模版外子网所属的vpc，子网需要属于资源栈的账号
+func (svc *stackSvc) getCloudSubnetVpcs(kt *kit.Kit, stack *corestack.Stack, tpl *corestack.Template) (
+	map[string]string, error) {
+
+	cloudIDs := make([]string, 0)
+	for _, cvm := range tpl.Cvms {
+		if _, _, exist := tpl.FindSubnet(cvm.Subnet); !exist {
+			cloudIDs = append(cloudIDs, cvm.Subnet)
+		}
+	}
+
+	result := make(map[string]string)
+	if len(cloudIDs) == 0 {
+		return result, nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: stack.AccountID},
+				filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: slice.Unique(cloudIDs)},
+			},
+		},
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"cloud_id", "cloud_vpc_id"},
+	}
+	subnets, err := svc.client.DataService().Global.Subnet.List(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list subnet failed, err: %v, cloud ids: %v, rid: %s", err, cloudIDs, kt.Rid)
+		return nil, err
+	}
+
+	for _, one := range subnets.Details {
+		result[one.CloudID] = one.CloudVpcID
+	}
+
+	return result, nil
+}
+
+func (svc *stackSvc) startFlow(kt *kit.Kit, stack *corestack.Stack, name enumor.FlowName,
+	shareData *tableasync.ShareData, tasks []ts.CustomFlowTask, current []corestack.Resource) (string, error) {
+
+	addReq := &ts.AddCustomFlowReq{
+		Name:      name,
+		ShareData: shareData,
+		Tasks:     tasks,
+	}
+	flow, err := svc.client.TaskServer().CreateCustomFlow(kt, addReq)
+	if err != nil {
+		logs.Errorf("call taskserver to create custom flow failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	status := enumor.ApplyingStackStatus
+	if name == enumor.FlowDestroyStack {
+		status = enumor.DestroyingStackStatus
+	}
+	updateReq := &dsstack.StackUpdateReq{Status: status, FlowID: flow.ID, Resources: current}
+	if err = svc.client.DataService().Global.Stack.UpdateStack(kt, stack.ID, updateReq); err != nil {
+		logs.Errorf("update stack failed, err: %v, id: %s, flow: %s, rid: %s", err, stack.ID, flow.ID, kt.Rid)
+		return "", err
+	}
+
+	return flow.ID, nil
+}
+
+var stackAuthTypes = map[enumor.CloudResourceType]meta.ResourceType{
+	enumor.VpcCloudResType:           meta.Vpc,
+	enumor.SubnetCloudResType:        meta.Subnet,
+	enumor.SecurityGroupCloudResType: meta.SecurityGroup,
+	enumor.CvmCloudResType:           meta.Cvm,
+	enumor.DiskCloudResType:          meta.Disk,
+	enumor.EipCloudResType:           meta.Eip,
+}
+
+func stackAuthResource(stack *corestack.Stack, resType enumor.CloudResourceType,
+	action meta.Action) meta.ResourceAttribute {
+
+	return meta.ResourceAttribute{Basic: &meta.Basic{Type: stackAuthTypes[resType], Action: action,
+		ResourceID: stack.AccountID}}
+}
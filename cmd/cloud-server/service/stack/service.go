@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package stack 资源栈，使用一份模版描述业务环境下的vpc、子网、安全组、主机、硬盘、弹性IP，支持plan、apply以及destroy
+package stack
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// InitStackService initialize the stack service.
+func InitStackService(c *capability.Capability) {
+	svc := &stackSvc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateStack", http.MethodPost, "/stacks/create", svc.CreateStack)
+	h.Add("ListStack", http.MethodPost, "/stacks/list", svc.ListStack)
+	h.Add("GetStack", http.MethodGet, "/stacks/{id}", svc.GetStack)
+	h.Add("BatchDeleteStack", http.MethodDelete, "/stacks/batch", svc.BatchDeleteStack)
+	h.Add("CreateStackVersion", http.MethodPost, "/stacks/{id}/versions/create", svc.CreateStackVersion)
+	h.Add("ListStackVersion", http.MethodPost, "/stacks/{id}/versions/list", svc.ListStackVersion)
+	h.Add("PlanStack", http.MethodPost, "/stacks/{id}/plan", svc.PlanStack)
+	h.Add("ApplyStack", http.MethodPost, "/stacks/{id}/apply", svc.ApplyStack)
+	h.Add("DestroyStack", http.MethodPost, "/stacks/{id}/destroy", svc.DestroyStack)
+
+	h.Load(c.WebService)
+}
+
+type stackSvc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+// checkFindPermission 资源栈模版的管理不变更云上资源，使用账号下的资源查看权限，apply、destroy按变更的资源类型单独鉴权
+func (svc *stackSvc) checkFindPermission(cts *rest.Contexts, accountID string) error {
+	res := meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.CloudResource, Action: meta.Find,
+		ResourceID: accountID}}
+	_, authorized, err := svc.authorizer.Authorize(cts.Kit, res)
+	if err != nil {
+		return errf.NewFromErr(errf.PermissionDenied,
+			fmt.Errorf("check stack find permissions failed, err: %v", err))
+	}
+
+	if !authorized {
+		return errf.NewFromErr(errf.PermissionDenied, fmt.Errorf("you have not permission of %s", meta.Find))
+	}
+
+	return nil
+}
@@ -0,0 +1,268 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package stack
+
+import (
+	"fmt"
+
+	logicsstack "hcm/cmd/cloud-server/logics/stack"
+	csstack "hcm/pkg/api/cloud-server/stack"
+	"hcm/pkg/api/core"
+	corestack "hcm/pkg/api/core/stack"
+	dsstack "hcm/pkg/api/data-service/stack"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// CreateStack 创建资源栈，模版校验通过后保存为资源栈的第一个版本，创建后需要apply才会创建资源
+func (svc *stackSvc) CreateStack(cts *rest.Contexts) (interface{}, error) {
+	req := new(csstack.CreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkFindPermission(cts, req.AccountID); err != nil {
+		return nil, err
+	}
+
+	if _, err := logicsstack.ParseTemplate(req.Format, req.Content); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	account, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.AccountCloudResType,
+		req.AccountID)
+	if err != nil {
+		logs.Errorf("get account basic info failed, id: %s, err: %v, rid: %s", req.AccountID, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if err = logicsstack.ValidateVendor(account.Vendor); err != nil {
+		return nil, err
+	}
+
+	createReq := &dsstack.StackCreateReq{
+		Name:      req.Name,
+		Vendor:    account.Vendor,
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		BkBizID:   req.BkBizID,
+		Format:    req.Format,
+		Content:   req.Content,
+		Memo:      req.Memo,
+	}
+	return svc.client.DataService().Global.Stack.CreateStack(cts.Kit, createReq)
+}
+
+// ListStack list stack.
+func (svc *stackSvc) ListStack(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.checkFindPermission(cts, ""); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Stack.ListStack(cts.Kit, req)
+}
+
+// GetStack get stack.
+func (svc *stackSvc) GetStack(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	stack, err := svc.getStack(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.checkFindPermission(cts, stack.AccountID); err != nil {
+		return nil, err
+	}
+
+	return stack, nil
+}
+
+// BatchDeleteStack 删除资源栈及其全部版本，资源栈下还有资源时需要先destroy
+func (svc *stackSvc) BatchDeleteStack(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{Filter: tools.ContainersExpression("id", req.IDs), Page: core.NewDefaultBasePage()}
+	result, err := svc.client.DataService().Global.Stack.ListStack(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list stack failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	for _, one := range result.Details {
+		if err = svc.checkFindPermission(cts, one.AccountID); err != nil {
+			return nil, err
+		}
+
+		if one.Status.InProgress() || len(one.Resources) != 0 {
+			return nil, errf.Newf(errf.InvalidParameter, "stack: %s is %s or has resources, please destroy it first",
+				one.Name, one.Status)
+		}
+	}
+
+	if err = svc.client.DataService().Global.Stack.BatchDeleteStack(cts.Kit, req); err != nil {
+		logs.Errorf("delete stack failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// CreateStackVersion 创建资源栈模版的新版本，新版本需要apply后才会变更资源
+func (svc *stackSvc) CreateStackVersion(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(csstack.VersionCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	stack, err := svc.getStack(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.checkFindPermission(cts, stack.AccountID); err != nil {
+		return nil, err
+	}
+
+	if _, err = logicsstack.ParseTemplate(req.Format, req.Content); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	createReq := &dsstack.VersionCreateReq{StackID: id, Format: req.Format, Content: req.Content}
+	return svc.client.DataService().Global.Stack.CreateStackVersion(cts.Kit, createReq)
+}
+
+// ListStackVersion list stack version.
+func (svc *stackSvc) ListStackVersion(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(core.ListWithoutFieldReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	stack, err := svc.getStack(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.checkFindPermission(cts, stack.AccountID); err != nil {
+		return nil, err
+	}
+
+	expr, err := tools.And(tools.EqualExpression("stack_id", id), req.Filter)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{Filter: expr, Page: req.Page}
+	return svc.client.DataService().Global.Stack.ListStackVersion(cts.Kit, listReq)
+}
+
+func (svc *stackSvc) getStack(kt *kit.Kit, id string) (*corestack.Stack, error) {
+	listReq := &core.ListReq{Filter: tools.EqualExpression("id", id), Page: core.NewDefaultBasePage()}
+	result, err := svc.client.DataService().Global.Stack.ListStack(kt, listReq)
+	if err != nil {
+		logs.Errorf("list stack failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "stack: %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
+
+// getTemplate 查询并解析资源栈指定版本的模版，version为0时使用最新版本
+func (svc *stackSvc) getTemplate(kt *kit.Kit, stack *corestack.Stack, version uint) (*corestack.Template, uint,
+	error) {
+
+	if version == 0 {
+		version = stack.Version
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{"stack_id": stack.ID,
+			"version": version}),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.client.DataService().Global.Stack.ListStackVersion(kt, listReq)
+	if err != nil {
+		logs.Errorf("list stack version failed, err: %v, stack: %s, version: %d, rid: %s", err, stack.ID, version,
+			kt.Rid)
+		return nil, 0, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, 0, errf.Newf(errf.RecordNotFound, "stack: %s version %d not found", stack.Name, version)
+	}
+
+	tpl, err := logicsstack.ParseTemplate(result.Details[0].Format, result.Details[0].Content)
+	if err != nil {
+		return nil, 0, fmt.Errorf("parse stack: %s version %d template failed, err: %v", stack.Name, version, err)
+	}
+
+	return tpl, version, nil
+}
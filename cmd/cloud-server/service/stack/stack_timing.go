@@ -0,0 +1,157 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package stack
+
+import (
+	"strconv"
+	"time"
+
+	logicsstack "hcm/cmd/cloud-server/logics/stack"
+	actionstack "hcm/cmd/task-server/logics/action/stack"
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	corestack "hcm/pkg/api/core/stack"
+	dsstack "hcm/pkg/api/data-service/stack"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+)
+
+// StackFlowWatchTiming 定时查询apply、destroy中的资源栈，任务流结束后保存任务流创建的资源并更新资源栈状态
+func StackFlowWatchTiming(interval time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		if err := watchStackFlow(kt, cliSet); err != nil {
+			logs.Errorf("watch stack flow failed, err: %v, rid: %s", err, kt.Rid)
+		}
+	}
+}
+
+func watchStackFlow(kt *kit.Kit, cliSet *client.ClientSet) error {
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("status",
+			[]enumor.StackStatus{enumor.ApplyingStackStatus, enumor.DestroyingStackStatus}),
+		Page: core.NewDefaultBasePage(),
+	}
+	stacks, err := cliSet.DataService().Global.Stack.ListStack(kt, listReq)
+	if err != nil {
+		logs.Errorf("list in progress stack failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	if len(stacks.Details) == 0 {
+		return nil
+	}
+
+	flowIDs := make([]string, 0, len(stacks.Details))
+	for _, one := range stacks.Details {
+		flowIDs = append(flowIDs, one.FlowID)
+	}
+
+	flowReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: flowIDs},
+				filter.AtomRule{Field: "state", Op: filter.In.Factory(),
+					Value: []enumor.FlowState{enumor.FlowSuccess, enumor.FlowFailed, enumor.FlowCancel}},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	flows, err := cliSet.TaskServer().ListFlow(kt, flowReq)
+	if err != nil {
+		logs.Errorf("list stack flow failed, err: %v, ids: %v, rid: %s", err, flowIDs, kt.Rid)
+		return err
+	}
+
+	flowMap := make(map[string]coreasync.AsyncFlow, len(flows.Details))
+	for _, one := range flows.Details {
+		flowMap[one.ID] = one
+	}
+
+	for idx := range stacks.Details {
+		stack := &stacks.Details[idx]
+		flow, exist := flowMap[stack.FlowID]
+		if !exist {
+			continue
+		}
+
+		if err = finishStackFlow(kt, cliSet, stack, &flow); err != nil {
+			logs.Errorf("finish stack flow failed, err: %v, stack: %s, flow: %s, rid: %s", err, stack.ID, flow.ID,
+				kt.Rid)
+		}
+	}
+
+	return nil
+}
+
+// finishStackFlow 合并任务流共享数据中记录的资源，过滤已经删除的资源后更新资源栈
+func finishStackFlow(kt *kit.Kit, cliSet *client.ClientSet, stack *corestack.Stack,
+	flow *coreasync.AsyncFlow) error {
+
+	created := make([]corestack.Resource, 0)
+	if flow.ShareData != nil {
+		created = actionstack.ParseResources(flow.ShareData.Dict)
+	}
+
+	resources, err := logicsstack.Refresh(kt, cliSet.DataService(), append(stack.Resources, created...))
+	if err != nil {
+		return err
+	}
+
+	updateReq := &dsstack.StackUpdateReq{Resources: resources}
+	switch {
+	case stack.Status == enumor.ApplyingStackStatus && flow.State == enumor.FlowSuccess:
+		updateReq.Status = enumor.AppliedStackStatus
+		if flow.ShareData != nil {
+			val, _ := flow.ShareData.Get(logicsstack.VersionShareDataKey)
+			version, err := strconv.ParseUint(val, 10, 64)
+			if err != nil {
+				return err
+			}
+			updateReq.AppliedVersion = uint(version)
+		}
+	case stack.Status == enumor.ApplyingStackStatus:
+		updateReq.Status = enumor.ApplyFailedStackStatus
+	case flow.State == enumor.FlowSuccess:
+		updateReq.Status = enumor.DestroyedStackStatus
+	default:
+		updateReq.Status = enumor.DestroyFailedStackStatus
+	}
+
+	if err = cliSet.DataService().Global.Stack.UpdateStack(kt, stack.ID, updateReq); err != nil {
+		return err
+	}
+
+	logs.Infof("stack flow finished, stack: %s, flow: %s, state: %s, status: %s, rid: %s", stack.ID, flow.ID,
+		flow.State, updateReq.Status, kt.Rid)
+	return nil
+}
@@ -23,8 +23,10 @@ import (
 	"encoding/json"
 	"fmt"
 
+	logicsapproval "hcm/cmd/cloud-server/logics/approval"
 	"hcm/cmd/cloud-server/logics/async"
 	"hcm/cmd/cloud-server/logics/audit"
+	logicsguardrail "hcm/cmd/cloud-server/logics/guardrail"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/cmd/cloud-server/service/common"
 	actionsubnet "hcm/cmd/task-server/logics/action/subnet"
@@ -102,6 +104,7 @@ func (svc *subnetSvc) CreateBizSubnet(cts *rest.Contexts) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+
 	return svc.createSubnet(cts, bizID, handler.BizOperateAuth)
 }
 
@@ -125,6 +128,24 @@ func (svc *subnetSvc) createSubnet(cts *rest.Contexts, bizID int64,
 		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
 	}
 
+	// 业务下创建子网需要校验审批策略
+	if bizID != constant.UnassignedBiz {
+		err = logicsapproval.CheckPolicy(cts.Kit, svc.client.DataService(), bizID, enumor.CreateSubnet, req.Vendor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	target := &logicsguardrail.Target{
+		BkBizID: bizID,
+		ResType: enumor.SubnetCloudResType,
+		Vendor:  req.Vendor,
+		Content: string(req.Data),
+	}
+	if err = logicsguardrail.Check(cts.Kit, svc.client.DataService(), target); err != nil {
+		return nil, err
+	}
+
 	switch req.Vendor {
 	case enumor.TCloud:
 		return svc.createTCloudSubnet(cts.Kit, bizID, req.Data)
@@ -107,6 +107,10 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return enumor.DiskCloudResType, hitErr
 	}
 
+	if hitErr = SyncSnapshot(kt, cliSet, opt.AccountID, resourceGroupNames, sd); hitErr != nil {
+		return enumor.SnapshotCloudResType, hitErr
+	}
+
 	if hitErr = SyncSG(kt, cliSet, opt.AccountID, resourceGroupNames, sd); hitErr != nil {
 		return enumor.SecurityGroupCloudResType, hitErr
 	}
@@ -127,6 +131,10 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return enumor.CvmCloudResType, hitErr
 	}
 
+	if hitErr = SyncLoadBalancer(kt, cliSet, opt.AccountID, resourceGroupNames, sd); hitErr != nil {
+		return enumor.LoadBalancerCloudResType, hitErr
+	}
+
 	if hitErr = SyncRouteTable(kt, cliSet, opt.AccountID, resourceGroupNames, sd); hitErr != nil {
 		return enumor.RouteTableCloudResType, hitErr
 	}
@@ -100,6 +100,14 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return enumor.DiskCloudResType, hitErr
 	}
 
+	if hitErr = SyncSnapshot(kt, cliSet, opt.AccountID, sd); hitErr != nil {
+		return enumor.SnapshotCloudResType, hitErr
+	}
+
+	if hitErr = SyncKeyPair(kt, cliSet, opt.AccountID, sd); hitErr != nil {
+		return enumor.KeyPairCloudResType, hitErr
+	}
+
 	if hitErr = SyncVpc(kt, cliSet, opt.AccountID, sd); hitErr != nil {
 		return enumor.VpcCloudResType, hitErr
 	}
@@ -120,6 +128,10 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return enumor.CvmCloudResType, hitErr
 	}
 
+	if hitErr = SyncLoadBalancer(kt, cliSet, opt.AccountID, regions, sd); hitErr != nil {
+		return enumor.LoadBalancerCloudResType, hitErr
+	}
+
 	if hitErr = SyncRoute(kt, cliSet, opt.AccountID, sd); hitErr != nil {
 		return enumor.RouteTableCloudResType, hitErr
 	}
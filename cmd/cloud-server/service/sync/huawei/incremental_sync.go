@@ -0,0 +1,153 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"time"
+
+	"hcm/pkg/adaptor/huawei"
+	typetrail "hcm/pkg/adaptor/types/audit-trail"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// IncrementalSyncOption ...
+type IncrementalSyncOption struct {
+	AccountID string    `json:"account_id" validate:"required"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required"`
+}
+
+// Validate IncrementalSyncOption
+func (opt *IncrementalSyncOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// IncrementalSync 拉取时间窗口内的云审计事件，只对发生变更的主机、安全组进行同步。
+func IncrementalSync(kt *kit.Kit, cliSet *client.ClientSet, opt *IncrementalSyncOption) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+
+	// 重新设置rid方便定位
+	kt = kt.NewSubKit()
+
+	start := time.Now()
+	logs.V(3).Infof("huawei account[%s] incremental sync start, time: %v, opt: %+v, rid: %s", opt.AccountID, start,
+		opt, kt.Rid)
+
+	defer func() {
+		logs.V(3).Infof("huawei account[%s] incremental sync end, cost: %v, rid: %s", opt.AccountID,
+			time.Since(start), kt.Rid)
+	}()
+
+	regions, err := ListRegionByService(kt, cliSet.DataService(), huawei.Ecs)
+	if err != nil {
+		logs.Errorf("incremental sync huawei list region failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	for _, region := range regions {
+		events, err := listAuditTrailEvent(kt, cliSet, opt, region)
+		// 部分地域未开通云审计服务或账号无权限，跳过该地域
+		if Error(err) != nil {
+			return err
+		}
+
+		for eventRegion, resMap := range typetrail.GroupEvents(events) {
+			if err = syncChangedRes(kt, cliSet, opt.AccountID, eventRegion, resMap); Error(err) != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// listAuditTrailEvent 分页查询指定地域时间窗口内的全部云审计事件
+func listAuditTrailEvent(kt *kit.Kit, cliSet *client.ClientSet, opt *IncrementalSyncOption, region string) (
+	[]typetrail.Event, error) {
+
+	req := &sync.AuditTrailEventListReq{
+		AccountID: opt.AccountID,
+		ListOption: typetrail.ListOption{
+			Region:    region,
+			StartTime: opt.StartTime,
+			EndTime:   opt.EndTime,
+		},
+	}
+
+	events := make([]typetrail.Event, 0)
+	for {
+		result, err := cliSet.HCService().HuaWei.AuditTrail.ListAuditTrailEvent(kt, req)
+		if err != nil {
+			logs.Errorf("list huawei audit trail event failed, err: %v, req: %+v, rid: %s", err, req, kt.Rid)
+			return nil, err
+		}
+
+		events = append(events, result.Events...)
+
+		if len(result.NextToken) == 0 {
+			break
+		}
+		req.NextToken = result.NextToken
+	}
+
+	return events, nil
+}
+
+// syncChangedRes 同步云审计事件中发生变更的资源，先同步安全组再同步主机，保证主机关联的安全组已同步。
+func syncChangedRes(kt *kit.Kit, cliSet *client.ClientSet, accountID, region string,
+	resMap map[enumor.CloudResourceType][]string) error {
+
+	sgCloudIDs := resMap[enumor.SecurityGroupCloudResType]
+	for _, cloudIDs := range slice.Split(sgCloudIDs, constant.CloudResourceSyncMaxLimit) {
+		req := &sync.HuaWeiSyncReq{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		if err := cliSet.HCService().HuaWei.SecurityGroup.SyncSecurityGroup(kt.Ctx, kt.Header(), req); err != nil {
+			logs.Errorf("incremental sync huawei sg failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+			return err
+		}
+	}
+
+	cvmCloudIDs := resMap[enumor.CvmCloudResType]
+	for _, cloudIDs := range slice.Split(cvmCloudIDs, constant.CloudResourceSyncMaxLimit) {
+		req := &sync.HuaWeiSyncReq{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		if err := cliSet.HCService().HuaWei.Cvm.SyncCvmWithRelResource(kt.Ctx, kt.Header(), req); err != nil {
+			logs.Errorf("incremental sync huawei cvm failed, err: %v, req: %v, rid: %s", err, req, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -90,6 +90,14 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return enumor.DiskCloudResType, hitErr
 	}
 
+	if hitErr = SyncSnapshot(kt, cliSet, opt.AccountID, sd); hitErr != nil {
+		return enumor.SnapshotCloudResType, hitErr
+	}
+
+	if hitErr = SyncKeyPair(kt, cliSet, opt.AccountID, sd); hitErr != nil {
+		return enumor.KeyPairCloudResType, hitErr
+	}
+
 	if hitErr = SyncVpc(kt, cliSet, opt.AccountID, sd); hitErr != nil {
 		return enumor.VpcCloudResType, hitErr
 	}
@@ -110,6 +118,14 @@ func SyncAllResource(kt *kit.Kit, cliSet *client.ClientSet,
 		return enumor.CvmCloudResType, hitErr
 	}
 
+	if hitErr = SyncLoadBalancer(kt, cliSet, opt.AccountID, sd); hitErr != nil {
+		return enumor.LoadBalancerCloudResType, hitErr
+	}
+
+	if hitErr = SyncNatGateway(kt, cliSet, opt.AccountID, sd); hitErr != nil {
+		return enumor.NatGatewayCloudResType, hitErr
+	}
+
 	if hitErr = SyncRouteTable(kt, cliSet, opt.AccountID, sd); hitErr != nil {
 		return enumor.RouteTableCloudResType, hitErr
 	}
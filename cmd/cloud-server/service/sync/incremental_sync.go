@@ -0,0 +1,211 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sync
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/cmd/cloud-server/service/sync/aws"
+	"hcm/cmd/cloud-server/service/sync/huawei"
+	"hcm/cmd/cloud-server/service/sync/tcloud"
+	typetrail "hcm/pkg/adaptor/types/audit-trail"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+
+	etcd3 "go.etcd.io/etcd/client/v3"
+)
+
+// incrementalSyncVendors 支持基于云审计事件增量同步的云厂商
+var incrementalSyncVendors = []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.HuaWei}
+
+// CloudResourceIncrementalSync 定时拉取云审计事件，只同步发生变更的主机、安全组，全量同步作为兜底的对账。
+// 各账号已同步到的事件时间(游标)保存在etcd中，主节点切换后新的主节点从游标处继续同步，不会遗漏切换期间的事件。
+func CloudResourceIncrementalSync(cfg cc.CloudResourceIncrementalSync, sd serviced.ServiceDiscover,
+	cliSet *client.ClientSet, etcdCli *etcd3.Client) {
+
+	logs.Infof("cloud resource incremental sync enable, intervalMin: %d, delayMin: %d", cfg.IntervalMin,
+		cfg.DelayMin)
+
+	interval := time.Duration(cfg.IntervalMin) * time.Minute
+	delay := time.Duration(cfg.DelayMin) * time.Minute
+	store := &cursorStore{cli: etcdCli}
+
+	for {
+		time.Sleep(interval)
+
+		if !sd.IsMaster() {
+			continue
+		}
+
+		end := time.Now().Add(-delay)
+		waitGroup := new(sync.WaitGroup)
+		waitGroup.Add(len(incrementalSyncVendors))
+		for _, vendor := range incrementalSyncVendors {
+			go func(vendor enumor.Vendor) {
+				defer waitGroup.Done()
+
+				kt := core.NewBackendKit()
+				kt.RequestSource = enumor.BackgroundSync
+				allAccountIncrementalSync(kt, cliSet, store, vendor, end, interval)
+			}(vendor)
+		}
+
+		waitGroup.Wait()
+	}
+}
+
+// cursorStore 增量同步游标存储，key为 前缀/云厂商/账号ID，value为已同步到的事件时间
+type cursorStore struct {
+	cli *etcd3.Client
+}
+
+func (c *cursorStore) prefix(vendor enumor.Vendor) string {
+	return fmt.Sprintf("/hcm/%s/incremental_sync/cursor/%s/", cc.CloudServerName, vendor)
+}
+
+// List 查询该云厂商下所有账号的游标，key为账号ID
+func (c *cursorStore) List(kt *kit.Kit, vendor enumor.Vendor) (map[string]time.Time, error) {
+	prefix := c.prefix(vendor)
+	resp, err := c.cli.Get(kt.Ctx, prefix, etcd3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	cursors := make(map[string]time.Time, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		cursor, err := time.Parse(time.RFC3339, string(kv.Value))
+		if err != nil {
+			logs.Errorf("parse incremental sync cursor failed, err: %v, key: %s, rid: %s", err, kv.Key, kt.Rid)
+			continue
+		}
+		cursors[strings.TrimPrefix(string(kv.Key), prefix)] = cursor
+	}
+
+	return cursors, nil
+}
+
+// Set 更新账号的游标
+func (c *cursorStore) Set(kt *kit.Kit, vendor enumor.Vendor, accountID string, cursor time.Time) error {
+	_, err := c.cli.Put(kt.Ctx, c.prefix(vendor)+accountID, cursor.Format(time.RFC3339))
+	return err
+}
+
+// allAccountIncrementalSync 对该云厂商下的所有资源账号进行增量同步，同步成功后更新账号的游标。
+func allAccountIncrementalSync(kt *kit.Kit, cliSet *client.ClientSet, store *cursorStore, vendor enumor.Vendor,
+	end time.Time, interval time.Duration) {
+
+	startTime := time.Now()
+	logs.V(3).Infof("%s start incremental sync, end: %v, rid: %s", vendor, end, kt.Rid)
+
+	defer func() {
+		logs.V(3).Infof("%s incremental sync end, cost: %v, rid: %s", vendor, time.Since(startTime), kt.Rid)
+	}()
+
+	cursor, err := store.List(kt, vendor)
+	if err != nil {
+		logs.Errorf("list %s incremental sync cursor failed, err: %v, rid: %s", vendor, err, kt.Rid)
+		return
+	}
+
+	listReq := &protocloud.AccountListReq{
+		Filter: &filter.Expression{Op: filter.And, Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+			&filter.AtomRule{Field: "type", Op: filter.Equal.Factory(), Value: enumor.ResourceAccount}}},
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit},
+	}
+	for {
+		accounts, err := listAccountWithRetry(kt, cliSet.DataService(), listReq)
+		if err != nil {
+			logs.Errorf("list account failed, err: %v, rid: %s", err, kt.Rid)
+			return
+		}
+
+		for _, one := range accounts {
+			start := incrementalSyncStart(cursor[one.ID], end, interval)
+			if !end.After(start) {
+				continue
+			}
+
+			if err = accountIncrementalSync(kt, cliSet, one.Vendor, one.ID, start, end); err != nil {
+				// 同步失败时不更新游标，下一轮从失败的时间窗口重新同步
+				logs.Errorf("%s account incremental sync failed, err: %v, accountID: %s, start: %v, end: %v, rid: %s",
+					vendor, err, one.ID, start, end, kt.Rid)
+				continue
+			}
+
+			// 游标更新失败时下一轮重新同步该时间窗口，重复同步不影响结果
+			if err = store.Set(kt, vendor, one.ID, end); err != nil {
+				logs.Errorf("set %s incremental sync cursor failed, err: %v, accountID: %s, rid: %s", vendor, err,
+					one.ID, kt.Rid)
+			}
+		}
+
+		if len(accounts) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+}
+
+// incrementalSyncStart 计算本次增量同步的开始时间，首次同步时只同步最近一个周期的事件，
+// 游标落后太久时只同步云审计支持查询的最大时间窗口内的事件。
+func incrementalSyncStart(cursor, end time.Time, interval time.Duration) time.Time {
+	if cursor.IsZero() {
+		return end.Add(-interval)
+	}
+
+	if end.Sub(cursor) > typetrail.MaxQueryWindow {
+		return end.Add(-typetrail.MaxQueryWindow)
+	}
+
+	return cursor
+}
+
+func accountIncrementalSync(kt *kit.Kit, cliSet *client.ClientSet, vendor enumor.Vendor, accountID string,
+	start, end time.Time) error {
+
+	switch vendor {
+	case enumor.TCloud:
+		opt := &tcloud.IncrementalSyncOption{AccountID: accountID, StartTime: start, EndTime: end}
+		return tcloud.IncrementalSync(kt, cliSet, opt)
+
+	case enumor.Aws:
+		opt := &aws.IncrementalSyncOption{AccountID: accountID, StartTime: start, EndTime: end}
+		return aws.IncrementalSync(kt, cliSet, opt)
+
+	case enumor.HuaWei:
+		opt := &huawei.IncrementalSyncOption{AccountID: accountID, StartTime: start, EndTime: end}
+		return huawei.IncrementalSync(kt, cliSet, opt)
+
+	default:
+		logs.Errorf("vendor %s not support incremental sync, rid: %s", vendor, kt.Rid)
+		return nil
+	}
+}
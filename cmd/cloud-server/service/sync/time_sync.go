@@ -63,7 +63,9 @@ func CloudResourceSync(intervalMin time.Duration, sd serviced.ServiceDiscover, c
 		waitGroup.Add(len(vendors))
 		for _, vendor := range vendors {
 			go func(vendor enumor.Vendor) {
-				allAccountSync(core.NewBackendKit(), cliSet, vendor)
+				kt := core.NewBackendKit()
+				kt.RequestSource = enumor.BackgroundSync
+				allAccountSync(kt, cliSet, vendor)
 				waitGroup.Done()
 			}(vendor)
 		}
@@ -25,6 +25,7 @@ import (
 	"fmt"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	logicsguardrail "hcm/cmd/cloud-server/logics/guardrail"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/cmd/cloud-server/service/common"
 	cloudserver "hcm/pkg/api/cloud-server"
@@ -45,6 +46,8 @@ import (
 	"hcm/pkg/runtime/filter"
 	"hcm/pkg/tools/converter"
 	"hcm/pkg/tools/hooks/handler"
+
+	"github.com/tidwall/gjson"
 )
 
 // InitVpcService initialize the vpc service.
@@ -106,6 +109,16 @@ func (svc *vpcSvc) CreateVpc(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	target := &logicsguardrail.Target{
+		BkBizID: gjson.GetBytes(req.Data, "bk_biz_id").Int(),
+		ResType: enumor.VpcCloudResType,
+		Vendor:  info.Vendor,
+		Content: string(req.Data),
+	}
+	if err = logicsguardrail.Check(cts.Kit, svc.client.DataService(), target); err != nil {
+		return nil, err
+	}
+
 	// 根据厂商信息转到下方具体的实现
 	switch info.Vendor {
 	case enumor.TCloud:
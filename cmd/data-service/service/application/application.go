@@ -30,6 +30,7 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao"
+	daoapplication "hcm/pkg/dal/dao/application"
 	"hcm/pkg/dal/dao/orm"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
@@ -121,6 +122,22 @@ func (svc *applicationSvc) Update(cts *rest.Contexts) (interface{}, error) {
 		application.DeliveryDetail = tabletype.JsonField(*req.DeliveryDetail)
 	}
 
+	if len(req.SourceStatus) != 0 || len(req.SourceDeliverStep) != 0 || len(req.SourceDeliverFlowID) != 0 {
+		source := &daoapplication.UpdateSource{Status: req.SourceStatus, DeliverStep: req.SourceDeliverStep,
+			DeliverFlowID: req.SourceDeliverFlowID}
+		// 交付步骤和交付任务流只在交付中的申请单上比较
+		if len(source.Status) == 0 {
+			source.Status = enumor.Delivering
+		}
+
+		err := svc.dao.Application().UpdateBySource(cts.Kit, applicationID, source, application)
+		if err != nil {
+			logs.Errorf("update application by source failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+		return nil, nil
+	}
+
 	err := svc.dao.Application().Update(cts.Kit, tools.EqualExpression("id", applicationID), application)
 	if err != nil {
 		logs.Errorf("update application failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package approval 内置审批引擎的审批流配置、审批单据以及业务审批策略相关接口
+package approval
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the approval service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateApprovalWorkflow", http.MethodPost, "/approval/workflows/create", svc.CreateApprovalWorkflow)
+	h.Add("ListApprovalWorkflow", http.MethodPost, "/approval/workflows/list", svc.ListApprovalWorkflow)
+	h.Add("UpdateApprovalWorkflow", http.MethodPatch, "/approval/workflows/{id}", svc.UpdateApprovalWorkflow)
+	h.Add("BatchDeleteApprovalWorkflow", http.MethodDelete, "/approval/workflows/batch",
+		svc.BatchDeleteApprovalWorkflow)
+
+	h.Add("CreateApprovalPolicy", http.MethodPost, "/approval/policies/create", svc.CreateApprovalPolicy)
+	h.Add("ListApprovalPolicy", http.MethodPost, "/approval/policies/list", svc.ListApprovalPolicy)
+	h.Add("UpdateApprovalPolicy", http.MethodPatch, "/approval/policies/{id}", svc.UpdateApprovalPolicy)
+	h.Add("BatchDeleteApprovalPolicy", http.MethodDelete, "/approval/policies/batch", svc.BatchDeleteApprovalPolicy)
+
+	h.Add("CreateApprovalTicket", http.MethodPost, "/approval/tickets/create", svc.CreateApprovalTicket)
+	h.Add("ListApprovalTicket", http.MethodPost, "/approval/tickets/list", svc.ListApprovalTicket)
+	h.Add("UpdateApprovalTicket", http.MethodPatch, "/approval/tickets/{id}", svc.UpdateApprovalTicket)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
@@ -0,0 +1,183 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package approval
+
+import (
+	"hcm/pkg/api/core"
+	coreapproval "hcm/pkg/api/core/approval"
+	dsapproval "hcm/pkg/api/data-service/approval"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	tableapproval "hcm/pkg/dal/table/approval"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+)
+
+// CreateApprovalTicket 创建审批单据，单据从第一个审批节点开始审批
+func (svc *service) CreateApprovalTicket(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsapproval.TicketCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	stages, err := tabletypes.NewJsonField(req.Stages)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	approvers, err := tabletypes.NewJsonField(req.Stages[0].Approvers)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ticket := &tableapproval.TicketTable{
+		ApplicationType:  req.ApplicationType,
+		BkBizID:          req.BkBizID,
+		Title:            req.Title,
+		Content:          req.Content,
+		Status:           enumor.RunningApprovalTicketStatus,
+		CurrentStage:     0,
+		Stages:           stages,
+		CurrentApprovers: approvers,
+		Creator:          cts.Kit.User,
+		Reviser:          cts.Kit.User,
+	}
+	id, err := svc.dao.ApprovalTicket().Create(cts.Kit, ticket)
+	if err != nil {
+		logs.Errorf("create approval ticket failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// ListApprovalTicket ...
+func (svc *service) ListApprovalTicket(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ApprovalTicket().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list approval ticket failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coreapproval.Ticket, 0, len(result.Details))
+	for _, one := range result.Details {
+		ticket := coreapproval.Ticket{
+			ID:              one.ID,
+			ApplicationType: one.ApplicationType,
+			BkBizID:         one.BkBizID,
+			Title:           one.Title,
+			Content:         one.Content,
+			Status:          one.Status,
+			CurrentStage:    one.CurrentStage,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		}
+
+		if !one.Stages.IsEmpty() {
+			if err = json.UnmarshalFromString(string(one.Stages), &ticket.Stages); err != nil {
+				logs.Errorf("unmarshal approval ticket stages failed, err: %v, id: %s, rid: %s", err, one.ID,
+					cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		if !one.CurrentApprovers.IsEmpty() {
+			if err = json.UnmarshalFromString(string(one.CurrentApprovers), &ticket.CurrentApprovers); err != nil {
+				logs.Errorf("unmarshal approval ticket current approvers failed, err: %v, id: %s, rid: %s", err,
+					one.ID, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, ticket)
+	}
+
+	return &core.ListResultT[coreapproval.Ticket]{Count: result.Count, Details: details}, nil
+}
+
+// UpdateApprovalTicket 更新审批单据，只有单据仍处于审批中且停留在source_stage节点时才会更新
+func (svc *service) UpdateApprovalTicket(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dsapproval.TicketUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	stages, err := tabletypes.NewJsonField(req.Stages)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	currentApprovers := req.CurrentApprovers
+	if currentApprovers == nil {
+		currentApprovers = make([]string, 0)
+	}
+	approvers, err := tabletypes.NewJsonField(currentApprovers)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableapproval.TicketTable{
+		Status:           req.Status,
+		CurrentStage:     req.CurrentStage,
+		Stages:           stages,
+		CurrentApprovers: approvers,
+		Reviser:          cts.Kit.User,
+	}
+	if err = svc.dao.ApprovalTicket().UpdateByCAS(cts.Kit, id, req.SourceStage, req.SourceApprovers, model); err != nil {
+		logs.Errorf("update approval ticket failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,185 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package approval
+
+import (
+	"hcm/pkg/api/core"
+	coreapproval "hcm/pkg/api/core/approval"
+	dsapproval "hcm/pkg/api/data-service/approval"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableapproval "hcm/pkg/dal/table/approval"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateApprovalWorkflow ...
+func (svc *service) CreateApprovalWorkflow(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsapproval.WorkflowCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	stages, err := tabletypes.NewJsonField(req.Stages)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	memo := req.Memo
+	if memo == nil {
+		memo = new(string)
+	}
+
+	workflow := &tableapproval.WorkflowTable{
+		ApplicationType: req.ApplicationType,
+		BkBizID:         req.BkBizID,
+		Stages:          stages,
+		Memo:            memo,
+		Creator:         cts.Kit.User,
+		Reviser:         cts.Kit.User,
+	}
+	id, err := svc.dao.ApprovalWorkflow().Create(cts.Kit, workflow)
+	if err != nil {
+		logs.Errorf("create approval workflow failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// ListApprovalWorkflow ...
+func (svc *service) ListApprovalWorkflow(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ApprovalWorkflow().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list approval workflow failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coreapproval.Workflow, 0, len(result.Details))
+	for _, one := range result.Details {
+		workflow := coreapproval.Workflow{
+			ID:              one.ID,
+			ApplicationType: one.ApplicationType,
+			BkBizID:         one.BkBizID,
+			Memo:            one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		}
+
+		if !one.Stages.IsEmpty() {
+			if err = json.UnmarshalFromString(string(one.Stages), &workflow.Stages); err != nil {
+				logs.Errorf("unmarshal approval workflow stages failed, err: %v, id: %s, rid: %s", err, one.ID,
+					cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, workflow)
+	}
+
+	return &core.ListResultT[coreapproval.Workflow]{Count: result.Count, Details: details}, nil
+}
+
+// UpdateApprovalWorkflow ...
+func (svc *service) UpdateApprovalWorkflow(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dsapproval.WorkflowUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableapproval.WorkflowTable{
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+
+	if req.Stages != nil {
+		stages, err := tabletypes.NewJsonField(req.Stages)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		model.Stages = stages
+	}
+
+	if err := svc.dao.ApprovalWorkflow().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update approval workflow failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteApprovalWorkflow ...
+func (svc *service) BatchDeleteApprovalWorkflow(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.ApprovalWorkflow().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("batch delete approval workflow failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -58,6 +58,7 @@ func InitAuditService(cap *capability.Capability) {
 		svc.cloudAudit.CloudResourceRecycleAudit)
 	h.Add("ListAudit", http.MethodPost, "/audits/list", svc.ListAudit)
 	h.Add("GetAudit", http.MethodGet, "/audits/{id}", svc.GetAudit)
+	h.Add("ListResChangeEvent", http.MethodPost, "/res_change_events/list", svc.ListResChangeEvent)
 
 	h.Load(cap.WebService)
 }
@@ -158,3 +159,56 @@ func (svc *svc) GetAudit(cts *rest.Contexts) (interface{}, error) {
 
 	return audit, nil
 }
+
+// ListResChangeEvent list res change events.
+func (svc *svc) ListResChangeEvent(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	result, err := svc.dao.ResChangeEvent().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list res change event failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list res change event failed, err: %v", err)
+	}
+	if req.Page.Count {
+		return &proto.ListResChangeEventResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreaudit.ResChangeEvent, 0, len(result.Details))
+	for _, one := range result.Details {
+		changes := make([]coreaudit.FieldChange, 0, len(one.Changes))
+		for _, change := range one.Changes {
+			changes = append(changes, coreaudit.FieldChange{Field: change.Field, Old: change.Old, New: change.New})
+		}
+
+		details = append(details, coreaudit.ResChangeEvent{
+			ID:         one.ID,
+			ResType:    one.ResType,
+			ResID:      one.ResID,
+			CloudResID: one.CloudResID,
+			ResName:    one.ResName,
+			Action:     one.Action,
+			BkBizID:    one.BkBizID,
+			Vendor:     one.Vendor,
+			AccountID:  one.AccountID,
+			Operator:   one.Operator,
+			Source:     one.Source,
+			Rid:        one.Rid,
+			Changes:    changes,
+			CreatedAt:  one.CreatedAt.String(),
+		})
+	}
+
+	return &proto.ListResChangeEventResult{Details: details}, nil
+}
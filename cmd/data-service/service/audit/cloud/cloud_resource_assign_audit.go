@@ -114,6 +114,12 @@ func (ad Audit) buildAssignAuditInfo(kt *kit.Kit, resType enumor.AuditResourceTy
 		audits, err = ad.routeTable.RouteTableAssignAuditBuild(kt, assigns)
 	case enumor.ArgumentTemplateAuditResType:
 		audits, err = ad.argsTplAssignAuditBuild(kt, assigns)
+	case enumor.LoadBalancerAuditResType:
+		audits, err = ad.loadBalancerAssignAuditBuild(kt, assigns)
+	case enumor.KeyPairAuditResType:
+		audits, err = ad.keyPairAssignAuditBuild(kt, assigns)
+	case enumor.NatGatewayAuditResType:
+		audits, err = ad.natGatewayAssignAuditBuild(kt, assigns)
 	default:
 		return nil, fmt.Errorf("cloud resource type: %s not support", resType)
 	}
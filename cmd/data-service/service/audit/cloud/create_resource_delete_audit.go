@@ -97,6 +97,14 @@ func (ad Audit) buildDeleteAuditInfo(kt *kit.Kit, resType enumor.AuditResourceTy
 		audits, err = ad.diskDeleteAuditBuild(kt, deletes)
 	case enumor.ArgumentTemplateAuditResType:
 		audits, err = ad.argsTplDeleteAuditBuild(kt, deletes)
+	case enumor.LoadBalancerAuditResType:
+		audits, err = ad.loadBalancerDeleteAuditBuild(kt, deletes)
+	case enumor.SnapshotAuditResType:
+		audits, err = ad.snapshotDeleteAuditBuild(kt, deletes)
+	case enumor.KeyPairAuditResType:
+		audits, err = ad.keyPairDeleteAuditBuild(kt, deletes)
+	case enumor.NatGatewayAuditResType:
+		audits, err = ad.natGatewayDeleteAuditBuild(kt, deletes)
 
 	default:
 		return nil, fmt.Errorf("cloud resource type: %s not support", resType)
@@ -87,6 +87,8 @@ func (ad Audit) buildOperationAuditInfo(kt *kit.Kit, resType enumor.AuditResourc
 		audits, err = ad.eipOperationAuditBuild(kt, operations)
 	case enumor.DiskAuditResType:
 		audits, err = ad.diskOperationAuditBuild(kt, operations)
+	case enumor.KeyPairAuditResType:
+		audits, err = ad.keyPairOperationAuditBuild(kt, operations)
 	default:
 		return nil, fmt.Errorf("cloud resource type: %s not support", resType)
 	}
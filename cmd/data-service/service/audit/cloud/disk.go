@@ -226,6 +226,48 @@ func (ad Audit) diskDeleteAuditBuild(
 	return audits, nil
 }
 
+func (ad Audit) diskUpdateAuditBuild(kt *kit.Kit, updates []protoaudit.CloudResourceUpdateInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(updates))
+	for _, one := range updates {
+		ids = append(ids, one.ResID)
+	}
+	diskIDMap, err := ad.listDisk(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(updates))
+	for _, one := range updates {
+		diskData, exist := diskIDMap[one.ResID]
+		if !exist {
+			continue
+		}
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:      one.ResID,
+			CloudResID: diskData.CloudID,
+			ResName:    diskData.Name,
+			ResType:    enumor.DiskAuditResType,
+			Action:     enumor.Update,
+			BkBizID:    diskData.BkBizID,
+			Vendor:     enumor.Vendor(diskData.Vendor),
+			AccountID:  diskData.AccountID,
+			Operator:   kt.User,
+			Source:     kt.GetRequestSource(),
+			Rid:        kt.Rid,
+			AppCode:    kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Data:    diskData,
+				Changed: one.UpdateFields,
+			},
+		})
+	}
+
+	return audits, nil
+}
+
 func (ad Audit) listDisk(kt *kit.Kit, ids []string) (map[string]*disk.DiskModel, error) {
 	opt := &types.ListOption{
 		Filter: tools.ContainersExpression("id", ids),
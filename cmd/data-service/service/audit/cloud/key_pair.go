@@ -0,0 +1,191 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableaudit "hcm/pkg/dal/table/audit"
+	tablekeypair "hcm/pkg/dal/table/cloud/key-pair"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+func (ad Audit) keyPairAssignAuditBuild(kt *kit.Kit, assigns []protoaudit.CloudResourceAssignInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(assigns))
+	for _, one := range assigns {
+		ids = append(ids, one.ResID)
+	}
+	idMap, err := ad.listKeyPair(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(assigns))
+	for _, one := range assigns {
+		keyPair, exist := idMap[one.ResID]
+		if !exist {
+			continue
+		}
+
+		if one.AssignedResType != enumor.BizAuditAssignedResType {
+			return nil, errf.New(errf.InvalidParameter, "assigned resource type is invalid")
+		}
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:      one.ResID,
+			CloudResID: keyPair.CloudID,
+			ResName:    keyPair.Name,
+			ResType:    enumor.KeyPairAuditResType,
+			Action:     enumor.Assign,
+			BkBizID:    keyPair.BkBizID,
+			Vendor:     keyPair.Vendor,
+			AccountID:  keyPair.AccountID,
+			Operator:   kt.User,
+			Source:     kt.GetRequestSource(),
+			Rid:        kt.Rid,
+			AppCode:    kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Changed: map[string]interface{}{"bk_biz_id": one.AssignedResID},
+			},
+		})
+	}
+
+	return audits, nil
+}
+
+func (ad Audit) keyPairDeleteAuditBuild(kt *kit.Kit, deletes []protoaudit.CloudResourceDeleteInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(deletes))
+	for _, one := range deletes {
+		ids = append(ids, one.ResID)
+	}
+	idMap, err := ad.listKeyPair(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(deletes))
+	for _, one := range deletes {
+		keyPair, exist := idMap[one.ResID]
+		if !exist {
+			continue
+		}
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:      one.ResID,
+			CloudResID: keyPair.CloudID,
+			ResName:    keyPair.Name,
+			ResType:    enumor.KeyPairAuditResType,
+			Action:     enumor.Delete,
+			BkBizID:    keyPair.BkBizID,
+			Vendor:     keyPair.Vendor,
+			AccountID:  keyPair.AccountID,
+			Operator:   kt.User,
+			Source:     kt.GetRequestSource(),
+			Rid:        kt.Rid,
+			AppCode:    kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Data: keyPair,
+			},
+		})
+	}
+
+	return audits, nil
+}
+
+func (ad Audit) keyPairOperationAuditBuild(kt *kit.Kit, operations []protoaudit.CloudResourceOperationInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(operations))
+	for _, one := range operations {
+		if one.Action != protoaudit.DownloadPrivateKey {
+			return nil, fmt.Errorf("audit action: %s not support", one.Action)
+		}
+		ids = append(ids, one.ResID)
+	}
+	idMap, err := ad.listKeyPair(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(operations))
+	for _, one := range operations {
+		keyPair, exist := idMap[one.ResID]
+		if !exist {
+			return nil, errf.Newf(errf.RecordNotFound, "key pair: %s not found", one.ResID)
+		}
+
+		action, err := one.Action.ConvAuditAction()
+		if err != nil {
+			return nil, err
+		}
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:      one.ResID,
+			CloudResID: keyPair.CloudID,
+			ResName:    keyPair.Name,
+			ResType:    enumor.KeyPairAuditResType,
+			Action:     action,
+			BkBizID:    keyPair.BkBizID,
+			Vendor:     keyPair.Vendor,
+			AccountID:  keyPair.AccountID,
+			Operator:   kt.User,
+			Source:     kt.GetRequestSource(),
+			Rid:        kt.Rid,
+			AppCode:    kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Data: keyPair,
+			},
+		})
+	}
+
+	return audits, nil
+}
+
+// listKeyPair 私钥不记录到审计中
+func (ad Audit) listKeyPair(kt *kit.Kit, ids []string) (map[string]tablekeypair.KeyPairTable, error) {
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := ad.dao.KeyPair().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	result := make(map[string]tablekeypair.KeyPairTable, len(list.Details))
+	for _, one := range list.Details {
+		one.PrivateKey = ""
+		result[one.ID] = one
+	}
+
+	return result, nil
+}
@@ -0,0 +1,140 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package budget
+
+import (
+	"hcm/pkg/api/core"
+	corebudget "hcm/pkg/api/core/budget"
+	dsbudget "hcm/pkg/api/data-service/budget"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	tablebudget "hcm/pkg/dal/table/budget"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateBudgetAlert ...
+func (svc *service) CreateBudgetAlert(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbudget.BudgetAlertCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablebudget.BudgetAlertTable{
+		BudgetID:  req.BudgetID,
+		Month:     req.Month,
+		Threshold: req.Threshold,
+		Amount:    req.Amount,
+		Spent:     req.Spent,
+		Forecast:  req.Forecast,
+		State:     req.State,
+		Message:   req.Message,
+		Creator:   cts.Kit.User,
+		Reviser:   cts.Kit.User,
+	}
+	id, err := svc.dao.BudgetAlert().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create budget alert failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// ListBudgetAlert ...
+func (svc *service) ListBudgetAlert(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.BudgetAlert().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list budget alert failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corebudget.BudgetAlert, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corebudget.BudgetAlert{
+			ID:        one.ID,
+			BudgetID:  one.BudgetID,
+			Month:     one.Month,
+			Threshold: one.Threshold,
+			Amount:    one.Amount,
+			Spent:     one.Spent,
+			Forecast:  one.Forecast,
+			State:     one.State,
+			Message:   one.Message,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[corebudget.BudgetAlert]{Count: result.Count, Details: details}, nil
+}
+
+// UpdateBudgetAlert 重新通知后更新告警记录的状态
+func (svc *service) UpdateBudgetAlert(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dsbudget.BudgetAlertUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablebudget.BudgetAlertTable{
+		Spent:    req.Spent,
+		Forecast: req.Forecast,
+		State:    req.State,
+		Message:  req.Message,
+		Reviser:  cts.Kit.User,
+	}
+	if err := svc.dao.BudgetAlert().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update budget alert failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
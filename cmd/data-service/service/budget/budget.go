@@ -0,0 +1,191 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package budget
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corebudget "hcm/pkg/api/core/budget"
+	dsbudget "hcm/pkg/api/data-service/budget"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablebudget "hcm/pkg/dal/table/budget"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateBudget ...
+func (svc *service) CreateBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbudget.BudgetCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	bizID := req.BkBizID
+	if bizID <= 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	memo := req.Memo
+	if memo == nil {
+		memo = new(string)
+	}
+
+	model := &tablebudget.BudgetTable{
+		Name:       req.Name,
+		Scope:      req.Scope,
+		BkBizID:    bizID,
+		AccountID:  req.AccountID,
+		Currency:   req.Currency,
+		Amount:     req.Amount,
+		Thresholds: req.Thresholds,
+		Receivers:  req.Receivers,
+		Memo:       memo,
+		Creator:    cts.Kit.User,
+		Reviser:    cts.Kit.User,
+	}
+	id, err := svc.dao.Budget().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create budget failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// ListBudget ...
+func (svc *service) ListBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.Budget().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list budget failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corebudget.Budget, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corebudget.Budget{
+			ID:         one.ID,
+			Name:       one.Name,
+			Scope:      one.Scope,
+			BkBizID:    one.BkBizID,
+			AccountID:  one.AccountID,
+			Currency:   one.Currency,
+			Amount:     one.Amount,
+			Thresholds: one.Thresholds,
+			Receivers:  one.Receivers,
+			Memo:       one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[corebudget.Budget]{Count: result.Count, Details: details}, nil
+}
+
+// UpdateBudget ...
+func (svc *service) UpdateBudget(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dsbudget.BudgetUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablebudget.BudgetTable{
+		Name:       req.Name,
+		Amount:     req.Amount,
+		Thresholds: req.Thresholds,
+		Receivers:  req.Receivers,
+		Memo:       req.Memo,
+		Reviser:    cts.Kit.User,
+	}
+	if err := svc.dao.Budget().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update budget failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteBudget 删除预算时一并删除其告警记录
+func (svc *service) BatchDeleteBudget(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		alertFlt := tools.ContainersExpression("budget_id", req.IDs)
+		if err := svc.dao.BudgetAlert().DeleteWithTx(cts.Kit, txn, alertFlt); err != nil {
+			return nil, fmt.Errorf("delete budget alert failed, err: %v", err)
+		}
+
+		if err := svc.dao.Budget().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs)); err != nil {
+			return nil, fmt.Errorf("delete budget failed, err: %v", err)
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch delete budget failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package budget 预算及预算告警记录相关接口
+package budget
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the budget service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateBudget", http.MethodPost, "/budgets/create", svc.CreateBudget)
+	h.Add("ListBudget", http.MethodPost, "/budgets/list", svc.ListBudget)
+	h.Add("UpdateBudget", http.MethodPatch, "/budgets/{id}", svc.UpdateBudget)
+	h.Add("BatchDeleteBudget", http.MethodDelete, "/budgets/batch", svc.BatchDeleteBudget)
+
+	h.Add("CreateBudgetAlert", http.MethodPost, "/budgets/alerts/create", svc.CreateBudgetAlert)
+	h.Add("ListBudgetAlert", http.MethodPost, "/budgets/alerts/list", svc.ListBudgetAlert)
+	h.Add("UpdateBudgetAlert", http.MethodPatch, "/budgets/alerts/{id}", svc.UpdateBudgetAlert)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
@@ -104,6 +104,14 @@ func (svc *awsSGRuleSvc) BatchCreateAwsRule(cts *rest.Contexts) (interface{}, er
 			return nil, fmt.Errorf("batch create aws security group rule failed, err: %v", err)
 		}
 
+		changes := make([]sgRuleChange, 0, len(rules))
+		for _, one := range rules {
+			changes = append(changes, sgRuleChange{SGID: one.SecurityGroupID, ID: one.ID, New: one})
+		}
+		if err = recordSGRuleChanges(cts.Kit, txn, svc.dao, changes); err != nil {
+			return nil, err
+		}
+
 		return ruleIDs, nil
 	})
 	if err != nil {
@@ -135,7 +143,32 @@ func (svc *awsSGRuleSvc) BatchUpdateAwsRule(cts *rest.Contexts) (interface{}, er
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	// 同步请求需要对比更新前的规则，记录规则变更
+	existRules := make(map[string]tablecloud.AwsSecurityGroupRuleTable)
+	if cts.Kit.GetRequestSource() == enumor.BackgroundSync {
+		ids := make([]string, 0, len(req.Rules))
+		for _, one := range req.Rules {
+			ids = append(ids, one.ID)
+		}
+
+		opt := &types.SGRuleListOption{
+			SecurityGroupID: sgID,
+			Filter:          tools.ContainersExpression("id", ids),
+			Page:            core.NewDefaultBasePage(),
+		}
+		list, err := svc.dao.AwsSGRule().List(cts.Kit, opt)
+		if err != nil {
+			logs.Errorf("list aws security group rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range list.Details {
+			existRules[one.ID] = one
+		}
+	}
+
 	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		changes := make([]sgRuleChange, 0)
 		for _, one := range req.Rules {
 			rule := &tablecloud.AwsSecurityGroupRuleTable{
 				Region:                     one.Region,
@@ -175,6 +208,14 @@ func (svc *awsSGRuleSvc) BatchUpdateAwsRule(cts *rest.Contexts) (interface{}, er
 				logs.Errorf("update aws security group rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
 				return nil, fmt.Errorf("update aws security group rule failed, err: %v", err)
 			}
+
+			if exist, ok := existRules[one.ID]; ok {
+				changes = append(changes, sgRuleChange{SGID: sgID, ID: one.ID, Old: exist, New: rule})
+			}
+		}
+
+		if err := recordSGRuleChanges(cts.Kit, txn, svc.dao, changes); err != nil {
+			return nil, err
 		}
 
 		return nil, nil
@@ -265,7 +306,6 @@ func (svc *awsSGRuleSvc) DeleteAwsRule(cts *rest.Contexts) (interface{}, error)
 
 	opt := &types.SGRuleListOption{
 		SecurityGroupID: sgID,
-		Fields:          []string{"id"},
 		Filter:          req.Filter,
 		Page:            core.NewDefaultBasePage(),
 	}
@@ -284,9 +324,20 @@ func (svc *awsSGRuleSvc) DeleteAwsRule(cts *rest.Contexts) (interface{}, error)
 		delIDs[index] = one.ID
 	}
 
-	delFilter := tools.ContainersExpression("id", delIDs)
-	if err := svc.dao.AwsSGRule().Delete(cts.Kit, delFilter); err != nil {
-		logs.Errorf("delete aws security group rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		delFilter := tools.ContainersExpression("id", delIDs)
+		if err := svc.dao.AwsSGRule().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+			logs.Errorf("delete aws security group rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		changes := make([]sgRuleChange, 0, len(listResp.Details))
+		for _, one := range listResp.Details {
+			changes = append(changes, sgRuleChange{SGID: sgID, ID: one.ID, Old: one})
+		}
+		return nil, recordSGRuleChanges(cts.Kit, txn, svc.dao, changes)
+	})
+	if err != nil {
 		return nil, err
 	}
 
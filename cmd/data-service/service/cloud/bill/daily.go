@@ -0,0 +1,334 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	dsbill "hcm/pkg/api/data-service/cloud/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesbill "hcm/pkg/dal/dao/types/bill"
+	tablebill "hcm/pkg/dal/table/cloud/bill"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/math"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitBillDailyService initialize the bill daily item service.
+func InitBillDailyService(cap *capability.Capability) {
+	svc := &billDailySvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+	h.Add("ListBillDailyItem", "POST", "/bills/daily_items/list", svc.ListBillDailyItem)
+	h.Add("AggregateBillDailyItem", "POST", "/bills/daily_items/aggregate", svc.AggregateBillDailyItem)
+	h.Add("ReplaceBillDailyItem", "PUT", "/bills/daily_items/replace", svc.ReplaceBillDailyItem)
+	h.Add("ListBillSyncRecord", "POST", "/bills/sync_records/list", svc.ListBillSyncRecord)
+	h.Add("SetBillSyncRecord", "PUT", "/bills/sync_records/set", svc.SetBillSyncRecord)
+
+	h.Load(cap.WebService)
+}
+
+type billDailySvc struct {
+	dao dao.Set
+}
+
+// ListBillDailyItem list bill daily item.
+func (svc *billDailySvc) ListBillDailyItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.BillDailyItem().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list bill daily item failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list bill daily item failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dsbill.BillDailyItemListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]cloud.BillDailyItem, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, cloud.BillDailyItem{
+			ID:        one.ID,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			BkBizID:   one.BkBizID,
+			BillDate:  one.BillDate,
+			Region:    one.Region,
+			Product:   one.Product,
+			Currency:  one.Currency,
+			Cost:      one.Cost,
+			ItemCount: one.ItemCount,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dsbill.BillDailyItemListResult{Details: details}, nil
+}
+
+// AggregateBillDailyItem aggregate bill daily item.
+func (svc *billDailySvc) AggregateBillDailyItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.BillDailyAggregateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typesbill.BillDailyAggregateOption{
+		Filter:  req.Filter,
+		GroupBy: req.GroupBy,
+		Page:    req.Page,
+	}
+	daoResp, err := svc.dao.BillDailyItem().Aggregate(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("aggregate bill daily item failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &dsbill.BillDailyAggregateResult{Count: daoResp.Count}, nil
+	}
+
+	return &dsbill.BillDailyAggregateResult{Details: daoResp.Details}, nil
+}
+
+// ReplaceBillDailyItem 用最新拉取的数据覆盖账号某一天的账单日汇总，并在同一事务中将拉取记录置为成功
+func (svc *billDailySvc) ReplaceBillDailyItem(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.BillDailyItemReplaceReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 拉取记录中的费用仅用于核对，多币种时为各币种费用直接相加的结果
+	total := math.Decimal{}
+	itemCount := int64(0)
+	models := make([]tablebill.BillDailyItemTable, 0, len(req.Items))
+	for _, one := range req.Items {
+		cost, err := math.NewDecimalFromString(one.Cost)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		total = total.Add(cost)
+		itemCount += one.ItemCount
+
+		models = append(models, tablebill.BillDailyItemTable{
+			Vendor:    req.Vendor,
+			AccountID: req.AccountID,
+			BkBizID:   req.BkBizID,
+			BillDate:  req.BillDate,
+			Region:    one.Region,
+			Product:   one.Product,
+			Currency:  one.Currency,
+			Cost:      one.Cost,
+			ItemCount: one.ItemCount,
+			Creator:   cts.Kit.User,
+			Reviser:   cts.Kit.User,
+		})
+	}
+
+	record := &tablebill.BillSyncRecordTable{
+		Vendor:    req.Vendor,
+		AccountID: req.AccountID,
+		BillDate:  req.BillDate,
+		State:     enumor.SyncSuccess,
+		ItemCount: itemCount,
+		Cost:      total.ToString(),
+		Message:   "",
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.BillDailyItem().DeleteWithTx(cts.Kit, txn,
+			billDateExpr(req.AccountID, req.BillDate)); err != nil {
+			return nil, err
+		}
+
+		if len(models) != 0 {
+			if _, err := svc.dao.BillDailyItem().BatchCreateWithTx(cts.Kit, txn, models); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, svc.setSyncRecord(cts.Kit, txn, record)
+	})
+	if err != nil {
+		logs.Errorf("replace bill daily item failed, account: %s, date: %s, err: %v, rid: %s", req.AccountID,
+			req.BillDate, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// SetBillSyncRecord set bill sync record.
+func (svc *billDailySvc) SetBillSyncRecord(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsbill.BillSyncRecordSetReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	record := &tablebill.BillSyncRecordTable{
+		Vendor:    req.Vendor,
+		AccountID: req.AccountID,
+		BillDate:  req.BillDate,
+		State:     req.State,
+		Message:   req.Message,
+	}
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.setSyncRecord(cts.Kit, txn, record)
+	})
+	if err != nil {
+		logs.Errorf("set bill sync record failed, req: %+v, err: %v, rid: %s", req, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// setSyncRecord 账号某一天的拉取记录存在时更新，否则创建
+func (svc *billDailySvc) setSyncRecord(kt *kit.Kit, txn *sqlx.Tx, record *tablebill.BillSyncRecordTable) error {
+	expr := billDateExpr(record.AccountID, record.BillDate)
+	listOpt := &types.ListOption{
+		Filter: expr,
+		Page:   &core.BasePage{Limit: 1},
+		Fields: []string{"id"},
+	}
+	result, err := svc.dao.BillSyncRecord().List(kt, listOpt)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Details) == 0 {
+		if len(record.Cost) == 0 {
+			record.Cost = "0"
+		}
+		record.Creator = kt.User
+		record.Reviser = kt.User
+		_, err = svc.dao.BillSyncRecord().CreateWithTx(kt, txn, record)
+		return err
+	}
+
+	record.Reviser = kt.User
+	// 以下字段在更新时不允许修改
+	record.Vendor = ""
+	record.AccountID = ""
+	record.BillDate = ""
+	return svc.dao.BillSyncRecord().UpdateWithTx(kt, txn, tools.EqualExpression("id", result.Details[0].ID), record)
+}
+
+func billDateExpr(accountID, billDate string) *filter.Expression {
+	return &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+			&filter.AtomRule{Field: "bill_date", Op: filter.Equal.Factory(), Value: billDate},
+		},
+	}
+}
+
+// ListBillSyncRecord list bill sync record.
+func (svc *billDailySvc) ListBillSyncRecord(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+	daoResp, err := svc.dao.BillSyncRecord().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list bill sync record failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list bill sync record failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &dsbill.BillSyncRecordListResult{Count: daoResp.Count}, nil
+	}
+
+	details := make([]cloud.BillSyncRecord, 0, len(daoResp.Details))
+	for _, one := range daoResp.Details {
+		details = append(details, cloud.BillSyncRecord{
+			ID:        one.ID,
+			Vendor:    one.Vendor,
+			AccountID: one.AccountID,
+			BillDate:  one.BillDate,
+			State:     one.State,
+			ItemCount: one.ItemCount,
+			Cost:      one.Cost,
+			Message:   one.Message,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dsbill.BillSyncRecordListResult{Details: details}, nil
+}
@@ -117,6 +117,21 @@ func batchCreateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor
 			return nil, fmt.Errorf("batch create cvm failed, err: %v", err)
 		}
 
+		// 同步发现云上新增的主机，记录为资源变更事件
+		created := make([]tablecvm.Table, 0, len(models))
+		for idx := range models {
+			created = append(created, *models[idx])
+		}
+		events, err := buildCvmSnapshotEvents(cts.Kit, created, enumor.Create)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = svc.dao.ResChangeEvent().BatchCreateWithTx(cts.Kit, txn, events); err != nil {
+			logs.Errorf("create cvm change event failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
 		// create cmdb cloud hosts
 		// 如果主机同步Cmdb失败，但写入HCM成功，忽略该错误。
 		err = upsertCmdbHosts[T](svc, cts.Kit, vendor, models)
@@ -24,6 +24,7 @@ import (
 
 	"hcm/pkg/api/core"
 	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/orm"
 	"hcm/pkg/dal/dao/tools"
@@ -49,7 +50,6 @@ func (svc *cvmSvc) BatchDeleteCvm(cts *rest.Contexts) (interface{}, error) {
 	}
 
 	opt := &types.ListOption{
-		Fields: []string{"id", "vendor", "cloud_id", "bk_biz_id"},
 		Filter: req.Filter,
 		Page:   core.NewDefaultBasePage(),
 	}
@@ -86,6 +86,17 @@ func (svc *cvmSvc) BatchDeleteCvm(cts *rest.Contexts) (interface{}, error) {
 			return nil, err
 		}
 
+		// 同步发现云上已删除的主机，记录为资源变更事件
+		events, err := buildCvmSnapshotEvents(cts.Kit, listResp.Details, enumor.Delete)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = svc.dao.ResChangeEvent().BatchCreateWithTx(cts.Kit, txn, events); err != nil {
+			logs.Errorf("create cvm change event failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
 		// delete cmdb cloud hosts
 		if err = deleteCmdbHosts(svc, cts.Kit, listResp.Details); err != nil {
 			logs.Errorf("delete cmdb hosts failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -27,11 +27,14 @@ import (
 	protocloud "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	daoaudit "hcm/pkg/dal/dao/audit"
 	"hcm/pkg/dal/dao/orm"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
+	tableaudit "hcm/pkg/dal/table/audit"
 	tablecvm "hcm/pkg/dal/table/cloud/cvm"
 	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 	"hcm/pkg/tools/converter"
@@ -86,6 +89,7 @@ func batchUpdateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor
 
 	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		models := make([]*tablecvm.Table, 0, len(req.Cvms))
+		events := make([]*tableaudit.ResChangeEventTable, 0)
 
 		for _, one := range req.Cvms {
 			update := &tablecvm.Table{
@@ -125,6 +129,17 @@ func batchUpdateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor
 				return nil, fmt.Errorf("update cvm failed, err: %v", err)
 			}
 
+			// 同步发现的变更，记录为资源变更事件
+			if cts.Kit.GetRequestSource() == enumor.BackgroundSync {
+				event, err := buildCvmChangeEvent(cts.Kit, existCvm, update)
+				if err != nil {
+					return nil, err
+				}
+				if event != nil {
+					events = append(events, event)
+				}
+			}
+
 			if update.BkCloudID == 0 {
 				update.BkCloudID = existCvm.BkCloudID
 			}
@@ -150,6 +165,11 @@ func batchUpdateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor
 			models = append(models, update)
 		}
 
+		if err := svc.dao.ResChangeEvent().BatchCreateWithTx(cts.Kit, txn, events); err != nil {
+			logs.Errorf("create cvm change event failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
 		// upsert cmdb cloud hosts
 		err = upsertCmdbHosts[T](svc, cts.Kit, vendor, models)
 		if err != nil {
@@ -166,6 +186,55 @@ func batchUpdateCvm[T corecvm.Extension](cts *rest.Contexts, svc *cvmSvc, vendor
 	return nil, nil
 }
 
+func buildCvmChangeEvent(kt *kit.Kit, existCvm tablecvm.Table, update *tablecvm.Table) (
+	*tableaudit.ResChangeEventTable, error) {
+
+	event, err := daoaudit.BuildSyncChangeEvent(kt, cvmChangeEventBase(existCvm), existCvm, update)
+	if err != nil {
+		logs.Errorf("build cvm change event failed, err: %v, id: %s, rid: %s", err, existCvm.ID, kt.Rid)
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// buildCvmSnapshotEvents 构建同步发现的主机新增或删除事件，非同步请求返回空
+func buildCvmSnapshotEvents(kt *kit.Kit, cvms []tablecvm.Table, action enumor.AuditAction) (
+	[]*tableaudit.ResChangeEventTable, error) {
+
+	events := make([]*tableaudit.ResChangeEventTable, 0)
+	if kt.GetRequestSource() != enumor.BackgroundSync {
+		return events, nil
+	}
+
+	for _, one := range cvms {
+		changes, err := daoaudit.SnapshotFieldChanges(one, action == enumor.Delete)
+		if err != nil {
+			logs.Errorf("build cvm %s event failed, err: %v, id: %s, rid: %s", action, err, one.ID, kt.Rid)
+			return nil, err
+		}
+
+		if event := daoaudit.NewSyncChangeEvent(kt, cvmChangeEventBase(one), action, changes); event != nil {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// cvmChangeEventBase 主机变更事件的资源基本信息
+func cvmChangeEventBase(one tablecvm.Table) tableaudit.ResChangeEventTable {
+	return tableaudit.ResChangeEventTable{
+		ResType:    enumor.CvmAuditResType,
+		ResID:      one.ID,
+		CloudResID: one.CloudID,
+		ResName:    one.Name,
+		BkBizID:    one.BkBizID,
+		Vendor:     one.Vendor,
+		AccountID:  one.AccountID,
+	}
+}
+
 func listCvmInfo(cts *rest.Contexts, svc *cvmSvc, ids []string) (map[string]tablecvm.Table, error) {
 	opt := &types.ListOption{
 		Filter: tools.ContainersExpression("id", ids),
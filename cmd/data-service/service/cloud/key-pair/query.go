@@ -0,0 +1,252 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package keypair
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	datakeypair "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablekeypair "hcm/pkg/dal/table/cloud/key-pair"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+)
+
+// ListKeyPair list key pair.
+func (svc *keyPairSvc) ListKeyPair(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.KeyPair().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list key pair failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &datakeypair.KeyPairListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corekeypair.BaseKeyPair, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, *convTableToBaseKeyPair(&one))
+	}
+
+	return &datakeypair.KeyPairListResult{Details: details}, nil
+}
+
+// ListKeyPairExt list key pair with extension.
+func (svc *keyPairSvc) ListKeyPairExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	vendorFilter, err := tools.And(filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+		req.Filter)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: vendorFilter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.KeyPair().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list key pair ext failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list key pair ext failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &datakeypair.KeyPairListResult{Count: result.Count}, nil
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convKeyPairListResult[corekeypair.TCloudKeyPairExtension](cts.Kit, result)
+	case enumor.Aws:
+		return convKeyPairListResult[corekeypair.AwsKeyPairExtension](cts.Kit, result)
+	case enumor.HuaWei:
+		return convKeyPairListResult[corekeypair.HuaWeiKeyPairExtension](cts.Kit, result)
+	case enumor.Gcp:
+		return convKeyPairListResult[corekeypair.GcpKeyPairExtension](cts.Kit, result)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func convKeyPairListResult[T corekeypair.Extension](kt *kit.Kit,
+	result *types.ListResult[tablekeypair.KeyPairTable]) (*datakeypair.KeyPairExtListResult[T], error) {
+
+	details := make([]corekeypair.KeyPair[T], 0, len(result.Details))
+	for _, one := range result.Details {
+		keyPair, err := convKeyPairWithExt[T](&one)
+		if err != nil {
+			logs.Errorf("conv key pair with extension failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+			return nil, err
+		}
+		details = append(details, *keyPair)
+	}
+
+	return &datakeypair.KeyPairExtListResult[T]{Details: details}, nil
+}
+
+// GetKeyPair get key pair with extension.
+func (svc *keyPairSvc) GetKeyPair(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "key pair id is required")
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.KeyPair().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("get key pair failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, fmt.Errorf("get key pair failed, err: %v", err)
+	}
+
+	if len(result.Details) != 1 {
+		return nil, errf.Newf(errf.RecordNotFound, "key pair: %s not found", id)
+	}
+
+	one := result.Details[0]
+	if one.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "key pair: %s is not %s vendor", id, vendor)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convKeyPairWithExt[corekeypair.TCloudKeyPairExtension](&one)
+	case enumor.Aws:
+		return convKeyPairWithExt[corekeypair.AwsKeyPairExtension](&one)
+	case enumor.HuaWei:
+		return convKeyPairWithExt[corekeypair.HuaWeiKeyPairExtension](&one)
+	case enumor.Gcp:
+		return convKeyPairWithExt[corekeypair.GcpKeyPairExtension](&one)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func convKeyPairWithExt[T corekeypair.Extension](one *tablekeypair.KeyPairTable) (*corekeypair.KeyPair[T],
+	error) {
+
+	extension := new(T)
+	if len(one.Extension) != 0 {
+		if err := json.UnmarshalFromString(string(one.Extension), extension); err != nil {
+			return nil, fmt.Errorf("UnmarshalFromString key pair json extension failed, err: %v", err)
+		}
+	}
+
+	return &corekeypair.KeyPair[T]{
+		BaseKeyPair: *convTableToBaseKeyPair(one),
+		Extension:   extension,
+	}, nil
+}
+
+func convTableToBaseKeyPair(one *tablekeypair.KeyPairTable) *corekeypair.BaseKeyPair {
+	return &corekeypair.BaseKeyPair{
+		ID:               one.ID,
+		CloudID:          one.CloudID,
+		Name:             one.Name,
+		Vendor:           one.Vendor,
+		AccountID:        one.AccountID,
+		BkBizID:          one.BkBizID,
+		Region:           one.Region,
+		Fingerprint:      one.Fingerprint,
+		PublicKey:        one.PublicKey,
+		HasPrivateKey:    len(one.PrivateKey) != 0,
+		Memo:             one.Memo,
+		CloudCreatedTime: one.CloudCreatedTime,
+		Revision: &core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+// GetKeyPairPrivateKey get key pair encrypted private key.
+func (svc *keyPairSvc) GetKeyPairPrivateKey(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "key pair id is required")
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id", "private_key"},
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.KeyPair().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("get key pair private key failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, fmt.Errorf("get key pair private key failed, err: %v", err)
+	}
+
+	if len(result.Details) != 1 {
+		return nil, errf.Newf(errf.RecordNotFound, "key pair: %s not found", id)
+	}
+
+	if len(result.Details[0].PrivateKey) == 0 {
+		return nil, errf.Newf(errf.InvalidParameter, "key pair: %s has no private key", id)
+	}
+
+	return &datakeypair.PrivateKeyResult{PrivateKey: result.Details[0].PrivateKey}, nil
+}
@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	datalb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateLoadBalancer batch create load balancer.
+func (svc *lbSvc) BatchCreateLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return batchCreateLoadBalancer[corelb.TCloudLoadBalancerExtension](cts, svc, vendor)
+	case enumor.Aws:
+		return batchCreateLoadBalancer[corelb.AwsLoadBalancerExtension](cts, svc, vendor)
+	case enumor.HuaWei:
+		return batchCreateLoadBalancer[corelb.HuaWeiLoadBalancerExtension](cts, svc, vendor)
+	case enumor.Gcp:
+		return batchCreateLoadBalancer[corelb.GcpLoadBalancerExtension](cts, svc, vendor)
+	case enumor.Azure:
+		return batchCreateLoadBalancer[corelb.AzureLoadBalancerExtension](cts, svc, vendor)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func batchCreateLoadBalancer[T corelb.Extension](cts *rest.Contexts, svc *lbSvc, vendor enumor.Vendor) (
+	interface{}, error) {
+
+	req := new(datalb.LoadBalancerBatchCreateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]*tablelb.LoadBalancerTable, 0, len(req.LoadBalancers))
+		for _, one := range req.LoadBalancers {
+			extension, err := json.MarshalToString(one.Extension)
+			if err != nil {
+				return nil, errf.NewFromErr(errf.InvalidParameter, err)
+			}
+
+			models = append(models, &tablelb.LoadBalancerTable{
+				CloudID:              one.CloudID,
+				Name:                 one.Name,
+				Vendor:               vendor,
+				AccountID:            one.AccountID,
+				BkBizID:              one.BkBizID,
+				Region:               one.Region,
+				Zones:                one.Zones,
+				LBType:               one.LBType,
+				IPVersion:            one.IPVersion,
+				CloudVpcID:           one.CloudVpcID,
+				VpcID:                one.VpcID,
+				CloudSubnetID:        one.CloudSubnetID,
+				SubnetID:             one.SubnetID,
+				PrivateIPv4Addresses: one.PrivateIPv4Addresses,
+				PublicIPv4Addresses:  one.PublicIPv4Addresses,
+				Domain:               one.Domain,
+				Status:               one.Status,
+				Memo:                 one.Memo,
+				CloudCreatedTime:     one.CloudCreatedTime,
+				Extension:            tabletype.JsonField(extension),
+				Creator:              cts.Kit.User,
+				Reviser:              cts.Kit.User,
+			})
+		}
+
+		ids, err := svc.dao.LoadBalancer().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create load balancer failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create load balancer but return id type is not []string, id type: %T", result)
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
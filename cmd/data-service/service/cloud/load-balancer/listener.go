@@ -0,0 +1,261 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	datalb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateListener batch create load balancer listener.
+func (svc *lbSvc) BatchCreateListener(cts *rest.Contexts) (interface{}, error) {
+	req := new(datalb.ListenerBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]*tablelb.ListenerTable, 0, len(req.Listeners))
+		for _, one := range req.Listeners {
+			extension, err := convListenerExtToJson(one.Extension)
+			if err != nil {
+				return nil, err
+			}
+
+			models = append(models, &tablelb.ListenerTable{
+				CloudID:            one.CloudID,
+				Name:               one.Name,
+				Vendor:             one.Vendor,
+				AccountID:          one.AccountID,
+				BkBizID:            one.BkBizID,
+				LbID:               one.LbID,
+				CloudLbID:          one.CloudLbID,
+				Protocol:           one.Protocol,
+				Port:               one.Port,
+				CloudTargetGroupID: one.CloudTargetGroupID,
+				Extension:          extension,
+				Memo:               one.Memo,
+				Creator:            cts.Kit.User,
+				Reviser:            cts.Kit.User,
+			})
+		}
+
+		return svc.dao.LbListener().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("batch create listener failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create listener but return id type is not []string, id type: %T", result)
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateListener batch update load balancer listener.
+func (svc *lbSvc) BatchUpdateListener(cts *rest.Contexts) (interface{}, error) {
+	req := new(datalb.ListenerBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Listeners {
+			update := &tablelb.ListenerTable{
+				Name:               one.Name,
+				Protocol:           one.Protocol,
+				Port:               one.Port,
+				CloudTargetGroupID: one.CloudTargetGroupID,
+				Memo:               one.Memo,
+				Reviser:            cts.Kit.User,
+			}
+
+			if one.Extension != nil {
+				extension, err := convListenerExtToJson(one.Extension)
+				if err != nil {
+					return nil, err
+				}
+				update.Extension = extension
+			}
+
+			if err := svc.dao.LbListener().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				return nil, fmt.Errorf("update listener: %s failed, err: %v", one.ID, err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update listener failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListListener list load balancer listener.
+func (svc *lbSvc) ListListener(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.LbListener().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list listener failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list listener failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &datalb.ListenerListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corelb.Listener, 0, len(result.Details))
+	for _, one := range result.Details {
+		extension, err := convJsonToListenerExt(one.Extension)
+		if err != nil {
+			logs.Errorf("conv listener extension failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+			return nil, err
+		}
+
+		details = append(details, corelb.Listener{
+			ID:                 one.ID,
+			CloudID:            one.CloudID,
+			Name:               one.Name,
+			Vendor:             one.Vendor,
+			AccountID:          one.AccountID,
+			BkBizID:            one.BkBizID,
+			LbID:               one.LbID,
+			CloudLbID:          one.CloudLbID,
+			Protocol:           one.Protocol,
+			Port:               one.Port,
+			CloudTargetGroupID: one.CloudTargetGroupID,
+			Extension:          extension,
+			Memo:               one.Memo,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &datalb.ListenerListResult{Details: details}, nil
+}
+
+// BatchDeleteListener batch delete load balancer listener, targets of listener are deleted together.
+func (svc *lbSvc) BatchDeleteListener(cts *rest.Contexts) (interface{}, error) {
+	req := new(datalb.ListenerBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.LbListener().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list listener failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list listener failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.LbTarget().DeleteWithTx(cts.Kit, txn,
+			tools.ContainersExpression("listener_id", delIDs)); err != nil {
+			return nil, err
+		}
+
+		if err := svc.dao.LbListener().DeleteWithTx(cts.Kit, txn,
+			tools.ContainersExpression("id", delIDs)); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete listener failed, ids: %v, err: %v, rid: %s", delIDs, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func convListenerExtToJson(ext *corelb.ListenerExtension) (tabletype.JsonField, error) {
+	if ext == nil {
+		ext = new(corelb.ListenerExtension)
+	}
+
+	extension, err := json.MarshalToString(ext)
+	if err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return tabletype.JsonField(extension), nil
+}
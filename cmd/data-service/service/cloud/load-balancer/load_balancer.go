@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer 负载均衡的DB接口
+package loadbalancer
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+var svc *lbSvc
+
+// InitService initial the load balancer service
+func InitService(cap *capability.Capability) {
+	svc = &lbSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateLoadBalancer", http.MethodPost, "/vendors/{vendor}/load_balancers/batch/create",
+		svc.BatchCreateLoadBalancer)
+	h.Add("BatchUpdateLoadBalancer", http.MethodPatch, "/vendors/{vendor}/load_balancers/batch/update",
+		svc.BatchUpdateLoadBalancer)
+	h.Add("BatchUpdateLoadBalancerBiz", http.MethodPatch, "/load_balancers/biz/batch/update",
+		svc.BatchUpdateLoadBalancerBiz)
+	h.Add("GetLoadBalancer", http.MethodGet, "/vendors/{vendor}/load_balancers/{id}", svc.GetLoadBalancer)
+	h.Add("ListLoadBalancer", http.MethodPost, "/load_balancers/list", svc.ListLoadBalancer)
+	h.Add("ListLoadBalancerExt", http.MethodPost, "/vendors/{vendor}/load_balancers/list", svc.ListLoadBalancerExt)
+	h.Add("BatchDeleteLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteLoadBalancer)
+
+	h.Add("BatchCreateListener", http.MethodPost, "/load_balancers/listeners/batch/create", svc.BatchCreateListener)
+	h.Add("BatchUpdateListener", http.MethodPatch, "/load_balancers/listeners/batch/update", svc.BatchUpdateListener)
+	h.Add("ListListener", http.MethodPost, "/load_balancers/listeners/list", svc.ListListener)
+	h.Add("BatchDeleteListener", http.MethodDelete, "/load_balancers/listeners/batch", svc.BatchDeleteListener)
+
+	h.Add("BatchCreateTarget", http.MethodPost, "/load_balancers/targets/batch/create", svc.BatchCreateTarget)
+	h.Add("BatchUpdateTarget", http.MethodPatch, "/load_balancers/targets/batch/update", svc.BatchUpdateTarget)
+	h.Add("ListTarget", http.MethodPost, "/load_balancers/targets/list", svc.ListTarget)
+	h.Add("BatchDeleteTarget", http.MethodDelete, "/load_balancers/targets/batch", svc.BatchDeleteTarget)
+
+	h.Load(cap.WebService)
+}
+
+type lbSvc struct {
+	dao dao.Set
+}
@@ -0,0 +1,247 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	datalb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+)
+
+// ListLoadBalancer list load balancer.
+func (svc *lbSvc) ListLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.LoadBalancer().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list load balancer failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &datalb.LoadBalancerListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corelb.BaseLoadBalancer, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, *convTableToBaseLoadBalancer(&one))
+	}
+
+	return &datalb.LoadBalancerListResult{Details: details}, nil
+}
+
+// ListLoadBalancerExt list load balancer with extension.
+func (svc *lbSvc) ListLoadBalancerExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	vendorFilter, err := tools.And(filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+		req.Filter)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: vendorFilter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.LoadBalancer().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer ext failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list load balancer ext failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &datalb.LoadBalancerListResult{Count: result.Count}, nil
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convLoadBalancerListResult[corelb.TCloudLoadBalancerExtension](cts.Kit, result)
+	case enumor.Aws:
+		return convLoadBalancerListResult[corelb.AwsLoadBalancerExtension](cts.Kit, result)
+	case enumor.HuaWei:
+		return convLoadBalancerListResult[corelb.HuaWeiLoadBalancerExtension](cts.Kit, result)
+	case enumor.Gcp:
+		return convLoadBalancerListResult[corelb.GcpLoadBalancerExtension](cts.Kit, result)
+	case enumor.Azure:
+		return convLoadBalancerListResult[corelb.AzureLoadBalancerExtension](cts.Kit, result)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func convLoadBalancerListResult[T corelb.Extension](kt *kit.Kit, result *types.ListLoadBalancerDetails) (
+	*datalb.LoadBalancerExtListResult[T], error) {
+
+	details := make([]corelb.LoadBalancer[T], 0, len(result.Details))
+	for _, one := range result.Details {
+		lb, err := convLoadBalancerWithExt[T](&one)
+		if err != nil {
+			logs.Errorf("conv load balancer with extension failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+			return nil, err
+		}
+		details = append(details, *lb)
+	}
+
+	return &datalb.LoadBalancerExtListResult[T]{Details: details}, nil
+}
+
+// GetLoadBalancer get load balancer with extension.
+func (svc *lbSvc) GetLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "load balancer id is required")
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.LoadBalancer().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("get load balancer failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, fmt.Errorf("get load balancer failed, err: %v", err)
+	}
+
+	if len(result.Details) != 1 {
+		return nil, errf.Newf(errf.RecordNotFound, "load balancer: %s not found", id)
+	}
+
+	one := result.Details[0]
+	if one.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "load balancer: %s is not %s vendor", id, vendor)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convLoadBalancerWithExt[corelb.TCloudLoadBalancerExtension](&one)
+	case enumor.Aws:
+		return convLoadBalancerWithExt[corelb.AwsLoadBalancerExtension](&one)
+	case enumor.HuaWei:
+		return convLoadBalancerWithExt[corelb.HuaWeiLoadBalancerExtension](&one)
+	case enumor.Gcp:
+		return convLoadBalancerWithExt[corelb.GcpLoadBalancerExtension](&one)
+	case enumor.Azure:
+		return convLoadBalancerWithExt[corelb.AzureLoadBalancerExtension](&one)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func convLoadBalancerWithExt[T corelb.Extension](one *tablelb.LoadBalancerTable) (*corelb.LoadBalancer[T], error) {
+	extension := new(T)
+	if len(one.Extension) != 0 {
+		if err := json.UnmarshalFromString(string(one.Extension), extension); err != nil {
+			return nil, fmt.Errorf("UnmarshalFromString load balancer json extension failed, err: %v", err)
+		}
+	}
+
+	return &corelb.LoadBalancer[T]{
+		BaseLoadBalancer: *convTableToBaseLoadBalancer(one),
+		Extension:        extension,
+	}, nil
+}
+
+func convTableToBaseLoadBalancer(one *tablelb.LoadBalancerTable) *corelb.BaseLoadBalancer {
+	return &corelb.BaseLoadBalancer{
+		ID:                   one.ID,
+		CloudID:              one.CloudID,
+		Name:                 one.Name,
+		Vendor:               one.Vendor,
+		AccountID:            one.AccountID,
+		BkBizID:              one.BkBizID,
+		Region:               one.Region,
+		Zones:                one.Zones,
+		LBType:               one.LBType,
+		IPVersion:            one.IPVersion,
+		CloudVpcID:           one.CloudVpcID,
+		VpcID:                one.VpcID,
+		CloudSubnetID:        one.CloudSubnetID,
+		SubnetID:             one.SubnetID,
+		PrivateIPv4Addresses: one.PrivateIPv4Addresses,
+		PublicIPv4Addresses:  one.PublicIPv4Addresses,
+		Domain:               one.Domain,
+		Status:               one.Status,
+		Memo:                 one.Memo,
+		CloudCreatedTime:     one.CloudCreatedTime,
+		Revision: &core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
+
+func convJsonToListenerExt(ext tabletype.JsonField) (*corelb.ListenerExtension, error) {
+	extension := new(corelb.ListenerExtension)
+	if len(ext) == 0 {
+		return extension, nil
+	}
+
+	if err := json.UnmarshalFromString(string(ext), extension); err != nil {
+		return nil, fmt.Errorf("UnmarshalFromString listener json extension failed, err: %v", err)
+	}
+
+	return extension, nil
+}
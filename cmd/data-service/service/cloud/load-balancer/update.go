@@ -0,0 +1,175 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	datalb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablelb "hcm/pkg/dal/table/cloud/load-balancer"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchUpdateLoadBalancer batch update load balancer.
+func (svc *lbSvc) BatchUpdateLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return batchUpdateLoadBalancer[corelb.TCloudLoadBalancerExtension](cts, svc)
+	case enumor.Aws:
+		return batchUpdateLoadBalancer[corelb.AwsLoadBalancerExtension](cts, svc)
+	case enumor.HuaWei:
+		return batchUpdateLoadBalancer[corelb.HuaWeiLoadBalancerExtension](cts, svc)
+	case enumor.Gcp:
+		return batchUpdateLoadBalancer[corelb.GcpLoadBalancerExtension](cts, svc)
+	case enumor.Azure:
+		return batchUpdateLoadBalancer[corelb.AzureLoadBalancerExtension](cts, svc)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func batchUpdateLoadBalancer[T corelb.Extension](cts *rest.Contexts, svc *lbSvc) (interface{}, error) {
+	req := new(datalb.LoadBalancerBatchUpdateReq[T])
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := make([]string, 0, len(req.LoadBalancers))
+	for _, one := range req.LoadBalancers {
+		ids = append(ids, one.ID)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id", "extension"},
+		Filter: tools.ContainersExpression("id", ids),
+		Page:   core.NewDefaultBasePage(),
+	}
+	existResult, err := svc.dao.LoadBalancer().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list load balancer failed, err: %v, ids: %v, rid: %s", err, ids, cts.Kit.Rid)
+		return nil, err
+	}
+
+	existExtMap := make(map[string]tabletype.JsonField, len(existResult.Details))
+	for _, one := range existResult.Details {
+		existExtMap[one.ID] = one.Extension
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.LoadBalancers {
+			existExt, exist := existExtMap[one.ID]
+			if !exist {
+				continue
+			}
+
+			update := &tablelb.LoadBalancerTable{
+				Name:                 one.Name,
+				Zones:                one.Zones,
+				LBType:               one.LBType,
+				IPVersion:            one.IPVersion,
+				CloudVpcID:           one.CloudVpcID,
+				VpcID:                one.VpcID,
+				CloudSubnetID:        one.CloudSubnetID,
+				SubnetID:             one.SubnetID,
+				PrivateIPv4Addresses: one.PrivateIPv4Addresses,
+				PublicIPv4Addresses:  one.PublicIPv4Addresses,
+				Domain:               one.Domain,
+				Status:               one.Status,
+				Memo:                 one.Memo,
+				Reviser:              cts.Kit.User,
+			}
+
+			if one.Extension != nil {
+				merge, err := json.UpdateMerge(one.Extension, string(existExt))
+				if err != nil {
+					return nil, fmt.Errorf("json UpdateMerge extension failed, err: %v", err)
+				}
+				update.Extension = tabletype.JsonField(merge)
+			}
+
+			if err := svc.dao.LoadBalancer().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				logs.Errorf("update load balancer by id failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+				return nil, fmt.Errorf("update load balancer failed, err: %v", err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchUpdateLoadBalancerBiz batch update load balancer and its listeners biz.
+func (svc *lbSvc) BatchUpdateLoadBalancerBiz(cts *rest.Contexts) (interface{}, error) {
+	req := new(datalb.LoadBalancerBizBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	lbUpdate := &tablelb.LoadBalancerTable{
+		BkBizID: req.BkBizID,
+		Reviser: cts.Kit.User,
+	}
+	if err := svc.dao.LoadBalancer().Update(cts.Kit, tools.ContainersExpression("id", req.IDs),
+		lbUpdate); err != nil {
+		logs.Errorf("update load balancer biz failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	listenerUpdate := &tablelb.ListenerTable{
+		BkBizID: req.BkBizID,
+		Reviser: cts.Kit.User,
+	}
+	if err := svc.dao.LbListener().Update(cts.Kit, tools.ContainersExpression("lb_id", req.IDs),
+		listenerUpdate); err != nil {
+		logs.Errorf("update listener biz failed, err: %v, lb_ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
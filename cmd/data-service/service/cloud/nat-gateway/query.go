@@ -0,0 +1,222 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	datanat "hcm/pkg/api/data-service/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablenat "hcm/pkg/dal/table/cloud/nat-gateway"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+)
+
+// ListNatGateway list nat gateway.
+func (svc *natSvc) ListNatGateway(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.NatGateway().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list nat gateway failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list nat gateway failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &datanat.NatGatewayListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corenat.BaseNatGateway, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, *convTableToBaseNatGateway(&one))
+	}
+
+	return &datanat.NatGatewayListResult{Details: details}, nil
+}
+
+// ListNatGatewayExt list nat gateway with extension.
+func (svc *natSvc) ListNatGatewayExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	vendorFilter, err := tools.And(filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+		req.Filter)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: vendorFilter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.NatGateway().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list nat gateway ext failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list nat gateway ext failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &datanat.NatGatewayListResult{Count: result.Count}, nil
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convNatGatewayListResult[corenat.TCloudNatGatewayExtension](cts.Kit, result)
+	case enumor.Aws:
+		return convNatGatewayListResult[corenat.AwsNatGatewayExtension](cts.Kit, result)
+	case enumor.HuaWei:
+		return convNatGatewayListResult[corenat.HuaWeiNatGatewayExtension](cts.Kit, result)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func convNatGatewayListResult[T corenat.Extension](kt *kit.Kit, result *types.ListNatGatewayDetails) (
+	*datanat.NatGatewayExtListResult[T], error) {
+
+	details := make([]corenat.NatGateway[T], 0, len(result.Details))
+	for _, one := range result.Details {
+		nat, err := convNatGatewayWithExt[T](&one)
+		if err != nil {
+			logs.Errorf("conv nat gateway with extension failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+			return nil, err
+		}
+		details = append(details, *nat)
+	}
+
+	return &datanat.NatGatewayExtListResult[T]{Details: details}, nil
+}
+
+// GetNatGateway get nat gateway with extension.
+func (svc *natSvc) GetNatGateway(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "nat gateway id is required")
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.NatGateway().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("get nat gateway failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, fmt.Errorf("get nat gateway failed, err: %v", err)
+	}
+
+	if len(result.Details) != 1 {
+		return nil, errf.Newf(errf.RecordNotFound, "nat gateway: %s not found", id)
+	}
+
+	one := result.Details[0]
+	if one.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "nat gateway: %s is not %s vendor", id, vendor)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convNatGatewayWithExt[corenat.TCloudNatGatewayExtension](&one)
+	case enumor.Aws:
+		return convNatGatewayWithExt[corenat.AwsNatGatewayExtension](&one)
+	case enumor.HuaWei:
+		return convNatGatewayWithExt[corenat.HuaWeiNatGatewayExtension](&one)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func convNatGatewayWithExt[T corenat.Extension](one *tablenat.NatGatewayTable) (*corenat.NatGateway[T], error) {
+	extension := new(T)
+	if len(one.Extension) != 0 {
+		if err := json.UnmarshalFromString(string(one.Extension), extension); err != nil {
+			return nil, fmt.Errorf("UnmarshalFromString nat gateway json extension failed, err: %v", err)
+		}
+	}
+
+	return &corenat.NatGateway[T]{
+		BaseNatGateway: *convTableToBaseNatGateway(one),
+		Extension:      extension,
+	}, nil
+}
+
+func convTableToBaseNatGateway(one *tablenat.NatGatewayTable) *corenat.BaseNatGateway {
+	return &corenat.BaseNatGateway{
+		ID:                one.ID,
+		CloudID:           one.CloudID,
+		Name:              one.Name,
+		Vendor:            one.Vendor,
+		AccountID:         one.AccountID,
+		BkBizID:           one.BkBizID,
+		Region:            one.Region,
+		Zone:              one.Zone,
+		CloudVpcID:        one.CloudVpcID,
+		VpcID:             one.VpcID,
+		CloudSubnetID:     one.CloudSubnetID,
+		SubnetID:          one.SubnetID,
+		Spec:              one.Spec,
+		PublicIPAddresses: one.PublicIPAddresses,
+		Status:            one.Status,
+		Memo:              one.Memo,
+		CloudCreatedTime:  one.CloudCreatedTime,
+		Revision: &core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
@@ -0,0 +1,278 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	datanat "hcm/pkg/api/data-service/cloud/nat-gateway"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablenat "hcm/pkg/dal/table/cloud/nat-gateway"
+	tabletype "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateNatGatewayRule batch create nat gateway snat/dnat rule.
+func (svc *natSvc) BatchCreateNatGatewayRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(datanat.NatGatewayRuleBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		models := make([]*tablenat.NatGatewayRuleTable, 0, len(req.Rules))
+		for _, one := range req.Rules {
+			extension, err := convRuleExtToJson(one.Extension)
+			if err != nil {
+				return nil, err
+			}
+
+			models = append(models, &tablenat.NatGatewayRuleTable{
+				CloudID:           one.CloudID,
+				Vendor:            one.Vendor,
+				AccountID:         one.AccountID,
+				NatGatewayID:      one.NatGatewayID,
+				CloudNatGatewayID: one.CloudNatGatewayID,
+				RuleType:          one.RuleType,
+				Protocol:          one.Protocol,
+				CloudSubnetID:     one.CloudSubnetID,
+				SourceCidr:        one.SourceCidr,
+				PublicIP:          one.PublicIP,
+				PublicPort:        one.PublicPort,
+				PrivateIP:         one.PrivateIP,
+				PrivatePort:       one.PrivatePort,
+				Status:            one.Status,
+				Memo:              one.Memo,
+				Extension:         extension,
+				Creator:           cts.Kit.User,
+				Reviser:           cts.Kit.User,
+			})
+		}
+
+		return svc.dao.NatGatewayRule().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("batch create nat gateway rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create nat gateway rule but return id type is not []string, id type: %T",
+			result)
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// BatchUpdateNatGatewayRule batch update nat gateway snat/dnat rule.
+func (svc *natSvc) BatchUpdateNatGatewayRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(datanat.NatGatewayRuleBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, one := range req.Rules {
+			update := &tablenat.NatGatewayRuleTable{
+				Protocol:      one.Protocol,
+				CloudSubnetID: one.CloudSubnetID,
+				SourceCidr:    one.SourceCidr,
+				PublicIP:      one.PublicIP,
+				PublicPort:    one.PublicPort,
+				PrivateIP:     one.PrivateIP,
+				PrivatePort:   one.PrivatePort,
+				Status:        one.Status,
+				Memo:          one.Memo,
+				Reviser:       cts.Kit.User,
+			}
+
+			if one.Extension != nil {
+				extension, err := convRuleExtToJson(one.Extension)
+				if err != nil {
+					return nil, err
+				}
+				update.Extension = extension
+			}
+
+			if err := svc.dao.NatGatewayRule().UpdateByIDWithTx(cts.Kit, txn, one.ID, update); err != nil {
+				return nil, fmt.Errorf("update nat gateway rule: %s failed, err: %v", one.ID, err)
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch update nat gateway rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListNatGatewayRule list nat gateway snat/dnat rule.
+func (svc *natSvc) ListNatGatewayRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.NatGatewayRule().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list nat gateway rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list nat gateway rule failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &datanat.NatGatewayRuleListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corenat.NatGatewayRule, 0, len(result.Details))
+	for _, one := range result.Details {
+		extension, err := convJsonToRuleExt(one.Extension)
+		if err != nil {
+			logs.Errorf("conv nat gateway rule extension failed, err: %v, id: %s, rid: %s", err, one.ID,
+				cts.Kit.Rid)
+			return nil, err
+		}
+
+		details = append(details, corenat.NatGatewayRule{
+			ID:                one.ID,
+			CloudID:           one.CloudID,
+			Vendor:            one.Vendor,
+			AccountID:         one.AccountID,
+			NatGatewayID:      one.NatGatewayID,
+			CloudNatGatewayID: one.CloudNatGatewayID,
+			RuleType:          one.RuleType,
+			Protocol:          one.Protocol,
+			CloudSubnetID:     one.CloudSubnetID,
+			SourceCidr:        one.SourceCidr,
+			PublicIP:          one.PublicIP,
+			PublicPort:        one.PublicPort,
+			PrivateIP:         one.PrivateIP,
+			PrivatePort:       one.PrivatePort,
+			Status:            one.Status,
+			Memo:              one.Memo,
+			Extension:         extension,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &datanat.NatGatewayRuleListResult{Details: details}, nil
+}
+
+// BatchDeleteNatGatewayRule batch delete nat gateway snat/dnat rule.
+func (svc *natSvc) BatchDeleteNatGatewayRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(datanat.NatGatewayRuleBatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	listResp, err := svc.dao.NatGatewayRule().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list nat gateway rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list nat gateway rule failed, err: %v", err)
+	}
+
+	if len(listResp.Details) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, len(listResp.Details))
+	for index, one := range listResp.Details {
+		delIDs[index] = one.ID
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.NatGatewayRule().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", delIDs))
+	})
+	if err != nil {
+		logs.Errorf("delete nat gateway rule failed, ids: %v, err: %v, rid: %s", delIDs, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func convRuleExtToJson(ext *corenat.NatGatewayRuleExtension) (tabletype.JsonField, error) {
+	if ext == nil {
+		ext = new(corenat.NatGatewayRuleExtension)
+	}
+
+	extension, err := json.MarshalToString(ext)
+	if err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return tabletype.JsonField(extension), nil
+}
+
+func convJsonToRuleExt(ext tabletype.JsonField) (*corenat.NatGatewayRuleExtension, error) {
+	extension := new(corenat.NatGatewayRuleExtension)
+	if len(ext) == 0 {
+		return extension, nil
+	}
+
+	if err := json.UnmarshalFromString(string(ext), extension); err != nil {
+		return nil, fmt.Errorf("UnmarshalFromString nat gateway rule json extension failed, err: %v", err)
+	}
+
+	return extension, nil
+}
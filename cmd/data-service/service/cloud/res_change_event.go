@@ -0,0 +1,147 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package cloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao"
+	daoaudit "hcm/pkg/dal/dao/audit"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableaudit "hcm/pkg/dal/table/audit"
+	tablecloud "hcm/pkg/dal/table/cloud"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// sgChangeEventBase 安全组变更事件的资源基本信息
+func sgChangeEventBase(sg tablecloud.SecurityGroupTable) tableaudit.ResChangeEventTable {
+	return tableaudit.ResChangeEventTable{
+		ResType:    enumor.SecurityGroupAuditResType,
+		ResID:      sg.ID,
+		CloudResID: sg.CloudID,
+		ResName:    sg.Name,
+		BkBizID:    sg.BkBizID,
+		Vendor:     sg.Vendor,
+		AccountID:  sg.AccountID,
+	}
+}
+
+// buildSGSnapshotEvents 构建同步发现的安全组新增或删除事件，非同步请求返回空
+func buildSGSnapshotEvents(kt *kit.Kit, sgs []tablecloud.SecurityGroupTable, action enumor.AuditAction) (
+	[]*tableaudit.ResChangeEventTable, error) {
+
+	events := make([]*tableaudit.ResChangeEventTable, 0)
+	if kt.GetRequestSource() != enumor.BackgroundSync {
+		return events, nil
+	}
+
+	for _, sg := range sgs {
+		changes, err := daoaudit.SnapshotFieldChanges(sg, action == enumor.Delete)
+		if err != nil {
+			logs.Errorf("build security group %s event failed, err: %v, id: %s, rid: %s", action, err, sg.ID, kt.Rid)
+			return nil, err
+		}
+
+		if event := daoaudit.NewSyncChangeEvent(kt, sgChangeEventBase(sg), action, changes); event != nil {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// sgRuleChange 安全组规则的一次变更，新增时 Old 为空，删除时 New 为空
+type sgRuleChange struct {
+	SGID string
+	ID   string
+	Old  interface{}
+	New  interface{}
+}
+
+// recordSGRuleChanges 同步发现的安全组规则新增、修改、删除，记录为所属安全组的变更事件，规则属性以 rules.{规则ID}. 为前缀，
+// 这样在安全组的变更时间线中可以看到在HCM之外对规则的修改。非同步请求不记录。
+func recordSGRuleChanges(kt *kit.Kit, txn *sqlx.Tx, daoSet dao.Set, ruleChanges []sgRuleChange) error {
+	if kt.GetRequestSource() != enumor.BackgroundSync || len(ruleChanges) == 0 {
+		return nil
+	}
+
+	sgChanges := make(map[string]tableaudit.FieldChanges)
+	for _, one := range ruleChanges {
+		var changes tableaudit.FieldChanges
+		var err error
+		switch {
+		case one.Old == nil:
+			changes, err = daoaudit.SnapshotFieldChanges(one.New, false)
+		case one.New == nil:
+			changes, err = daoaudit.SnapshotFieldChanges(one.Old, true)
+		default:
+			changes, err = daoaudit.DiffFieldChanges(one.Old, one.New)
+		}
+		if err != nil {
+			logs.Errorf("build security group rule changes failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+			return err
+		}
+
+		if len(changes) != 0 {
+			sgChanges[one.SGID] = append(sgChanges[one.SGID], daoaudit.PrefixFieldChanges("rules."+one.ID, changes)...)
+		}
+	}
+
+	if len(sgChanges) == 0 {
+		return nil
+	}
+
+	sgIDs := make([]string, 0, len(sgChanges))
+	for sgID := range sgChanges {
+		sgIDs = append(sgIDs, sgID)
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.ContainersExpression("id", sgIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := daoSet.SecurityGroup().List(kt, opt)
+	if err != nil {
+		logs.Errorf("list security group failed, err: %v, ids: %v, rid: %s", err, sgIDs, kt.Rid)
+		return err
+	}
+
+	if len(result.Details) != len(sgIDs) {
+		return fmt.Errorf("security groups %v not all found", sgIDs)
+	}
+
+	events := make([]*tableaudit.ResChangeEventTable, 0, len(result.Details))
+	for _, sg := range result.Details {
+		events = append(events, daoaudit.NewSyncChangeEvent(kt, sgChangeEventBase(sg), enumor.Update,
+			sgChanges[sg.ID]))
+	}
+
+	if err = daoSet.ResChangeEvent().BatchCreateWithTx(kt, txn, events); err != nil {
+		logs.Errorf("create security group rule change event failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
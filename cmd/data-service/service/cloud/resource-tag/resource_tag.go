@@ -0,0 +1,184 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag 资源标签的DB接口
+package resourcetag
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
+	datatag "hcm/pkg/api/data-service/cloud/resource-tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	tabletag "hcm/pkg/dal/table/cloud/resource-tag"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initial the resource tag service
+func InitService(cap *capability.Capability) {
+	svc := &resTagSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListResourceTag", http.MethodPost, "/resource_tags/list", svc.ListResourceTag)
+	h.Add("BatchReplaceResourceTag", http.MethodPut, "/resource_tags/batch/replace", svc.BatchReplaceResourceTag)
+
+	h.Load(cap.WebService)
+}
+
+type resTagSvc struct {
+	dao dao.Set
+}
+
+// ListResourceTag list resource tag.
+func (svc *resTagSvc) ListResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ResourceTag().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list resource tag failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list resource tag failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &datatag.ResTagListResult{Count: result.Count}, nil
+	}
+
+	details := make([]coretag.ResourceTag, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coretag.ResourceTag{
+			ID:         one.ID,
+			Vendor:     one.Vendor,
+			AccountID:  one.AccountID,
+			ResType:    one.ResType,
+			ResID:      one.ResID,
+			CloudResID: one.CloudResID,
+			Key:        one.TagKey,
+			Value:      one.TagValue,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &datatag.ResTagListResult{Details: details}, nil
+}
+
+// BatchReplaceResourceTag 全量替换资源的标签，未在本地落库的资源会被忽略
+func (svc *resTagSvc) BatchReplaceResourceTag(cts *rest.Contexts) (interface{}, error) {
+	req := new(datatag.ResTagBatchReplaceReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	cloudIDs := make([]string, 0, len(req.Resources))
+	for _, one := range req.Resources {
+		cloudIDs = append(cloudIDs, one.CloudResID)
+	}
+
+	idMap, err := svc.dao.Cloud().ListResourceIDMapByCloudIDs(cts.Kit, req.ResType, req.AccountID, cloudIDs)
+	if err != nil {
+		logs.Errorf("list %s id by cloud ids failed, err: %v, rid: %s", req.ResType, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(idMap) == 0 {
+		return nil, nil
+	}
+
+	resIDs := make([]string, 0, len(idMap))
+	models := make([]*tabletag.ResourceTagTable, 0)
+	for _, one := range req.Resources {
+		resID, exists := idMap[one.CloudResID]
+		if !exists {
+			continue
+		}
+
+		resIDs = append(resIDs, resID)
+		for _, tag := range one.Tags {
+			models = append(models, &tabletag.ResourceTagTable{
+				Vendor:     req.Vendor,
+				AccountID:  req.AccountID,
+				ResType:    req.ResType,
+				ResID:      resID,
+				CloudResID: one.CloudResID,
+				TagKey:     tag.Key,
+				TagValue:   tag.Value,
+				Creator:    cts.Kit.User,
+				Reviser:    cts.Kit.User,
+			})
+		}
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		delFilter := &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: req.ResType},
+				&filter.AtomRule{Field: "res_id", Op: filter.In.Factory(), Value: resIDs},
+			},
+		}
+		if err := svc.dao.ResourceTag().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+			return nil, err
+		}
+
+		if len(models) == 0 {
+			return nil, nil
+		}
+
+		return svc.dao.ResourceTag().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("replace %s resource tag failed, err: %v, rid: %s", req.ResType, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
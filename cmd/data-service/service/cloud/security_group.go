@@ -31,9 +31,11 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao"
+	daoaudit "hcm/pkg/dal/dao/audit"
 	"hcm/pkg/dal/dao/orm"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
+	tableaudit "hcm/pkg/dal/table/audit"
 	tablecloud "hcm/pkg/dal/table/cloud"
 	tabletype "hcm/pkg/dal/table/types"
 	"hcm/pkg/kit"
@@ -181,7 +183,6 @@ func (svc *securityGroupSvc) BatchDeleteSecurityGroup(cts *rest.Contexts) (inter
 	}
 
 	opt := &types.ListOption{
-		Fields: []string{"id", "vendor"},
 		Filter: req.Filter,
 		Page:   core.NewDefaultBasePage(),
 	}
@@ -210,6 +211,17 @@ func (svc *securityGroupSvc) BatchDeleteSecurityGroup(cts *rest.Contexts) (inter
 			return nil, err
 		}
 
+		// 同步发现云上已删除的安全组，记录为资源变更事件
+		events, err := buildSGSnapshotEvents(cts.Kit, listResp.Details, enumor.Delete)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = svc.dao.ResChangeEvent().BatchCreateWithTx(cts.Kit, txn, events); err != nil {
+			logs.Errorf("create security group change event failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
 		return nil, nil
 	})
 	if err != nil {
@@ -334,12 +346,13 @@ func batchUpdateSecurityGroup[T corecloud.SecurityGroupExtension](cts *rest.Cont
 	for _, one := range req.SecurityGroups {
 		ids = append(ids, one.ID)
 	}
-	extensionMap, err := listSecurityGroupExtension(cts, svc, ids)
+	existSGMap, err := listSecurityGroupByIDs(cts, svc, ids)
 	if err != nil {
 		return nil, err
 	}
 
 	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		events := make([]*tableaudit.ResChangeEventTable, 0)
 		for _, sg := range req.SecurityGroups {
 			update := &tablecloud.SecurityGroupTable{
 				BkBizID: sg.BkBizID,
@@ -348,13 +361,13 @@ func batchUpdateSecurityGroup[T corecloud.SecurityGroupExtension](cts *rest.Cont
 				Reviser: cts.Kit.User,
 			}
 
+			existSG, exist := existSGMap[sg.ID]
 			if sg.Extension != nil {
-				extension, exist := extensionMap[sg.ID]
 				if !exist {
 					continue
 				}
 
-				merge, err := json.UpdateMerge(sg.Extension, string(extension))
+				merge, err := json.UpdateMerge(sg.Extension, string(existSG.Extension))
 				if err != nil {
 					return nil, fmt.Errorf("json UpdateMerge extension failed, err: %v", err)
 				}
@@ -365,6 +378,26 @@ func batchUpdateSecurityGroup[T corecloud.SecurityGroupExtension](cts *rest.Cont
 				logs.Errorf("update security group by id failed, err: %v, id: %s, rid: %s", err, sg.ID, cts.Kit.Rid)
 				return nil, fmt.Errorf("update security group failed, err: %v", err)
 			}
+
+			// 同步发现的变更，记录为资源变更事件
+			if !exist || cts.Kit.GetRequestSource() != enumor.BackgroundSync {
+				continue
+			}
+
+			event, err := daoaudit.BuildSyncChangeEvent(cts.Kit, sgChangeEventBase(existSG), existSG, update)
+			if err != nil {
+				logs.Errorf("build security group change event failed, err: %v, id: %s, rid: %s", err, sg.ID,
+					cts.Kit.Rid)
+				return nil, err
+			}
+			if event != nil {
+				events = append(events, event)
+			}
+		}
+
+		if err := svc.dao.ResChangeEvent().BatchCreateWithTx(cts.Kit, txn, events); err != nil {
+			logs.Errorf("create security group change event failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
 		}
 
 		return nil, nil
@@ -376,11 +409,10 @@ func batchUpdateSecurityGroup[T corecloud.SecurityGroupExtension](cts *rest.Cont
 	return nil, nil
 }
 
-func listSecurityGroupExtension(cts *rest.Contexts, svc *securityGroupSvc, ids []string) (
-	map[string]tabletype.JsonField, error) {
+func listSecurityGroupByIDs(cts *rest.Contexts, svc *securityGroupSvc, ids []string) (
+	map[string]tablecloud.SecurityGroupTable, error) {
 
 	opt := &types.ListOption{
-		Fields: []string{"id", "extension"},
 		Filter: tools.ContainersExpression("id", ids),
 		Page: &core.BasePage{
 			Start: 0,
@@ -392,9 +424,9 @@ func listSecurityGroupExtension(cts *rest.Contexts, svc *securityGroupSvc, ids [
 		return nil, err
 	}
 
-	result := make(map[string]tabletype.JsonField, len(list.Details))
+	result := make(map[string]tablecloud.SecurityGroupTable, len(list.Details))
 	for _, one := range list.Details {
-		result[one.ID] = one.Extension
+		result[one.ID] = one
 	}
 
 	return result, nil
@@ -457,6 +489,21 @@ func batchCreateSecurityGroup[T corecloud.SecurityGroupExtension](vendor enumor.
 			return nil, fmt.Errorf("create security group failed, err: %v", err)
 		}
 
+		// 同步发现云上新增的安全组，记录为资源变更事件
+		created := make([]tablecloud.SecurityGroupTable, 0, len(sgs))
+		for idx := range sgs {
+			created = append(created, *sgs[idx])
+		}
+		events, err := buildSGSnapshotEvents(cts.Kit, created, enumor.Create)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = svc.dao.ResChangeEvent().BatchCreateWithTx(cts.Kit, txn, events); err != nil {
+			logs.Errorf("create security group change event failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
 		return ids, nil
 	})
 	if err != nil {
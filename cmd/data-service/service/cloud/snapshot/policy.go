@@ -0,0 +1,243 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	datasnapshot "hcm/pkg/api/data-service/cloud/snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesnapshot "hcm/pkg/dal/table/cloud/snapshot"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/cron"
+	"hcm/pkg/tools/times"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateSnapshotPolicy create snapshot policy, next run time is calculated by cron.
+func (svc *snapshotSvc) CreateSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(datasnapshot.PolicyCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	nextRunAt, err := nextPolicyRunAt(req.Cron)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	bizID := req.BkBizID
+	if bizID <= 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	memo := req.Memo
+	if memo == nil {
+		memo = new(string)
+	}
+
+	model := &tablesnapshot.SnapshotPolicyTable{
+		Name:           req.Name,
+		Vendor:         req.Vendor,
+		AccountID:      req.AccountID,
+		BkBizID:        bizID,
+		DiskIDs:        req.DiskIDs,
+		Cron:           req.Cron,
+		RetentionCount: req.RetentionCount,
+		State:          req.State,
+		NextRunAt:      nextRunAt,
+		Memo:           memo,
+		Creator:        cts.Kit.User,
+		Reviser:        cts.Kit.User,
+	}
+	id, err := svc.dao.SnapshotPolicy().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create snapshot policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// ListSnapshotPolicy ...
+func (svc *snapshotSvc) ListSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.SnapshotPolicy().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list snapshot policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coresnapshot.SnapshotPolicy, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coresnapshot.SnapshotPolicy{
+			ID:             one.ID,
+			Name:           one.Name,
+			Vendor:         one.Vendor,
+			AccountID:      one.AccountID,
+			BkBizID:        one.BkBizID,
+			DiskIDs:        one.DiskIDs,
+			Cron:           one.Cron,
+			RetentionCount: one.RetentionCount,
+			State:          one.State,
+			NextRunAt:      times.ConvStdTimeFormat(one.NextRunAt),
+			LastFlowID:     one.LastFlowID,
+			Memo:           one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[coresnapshot.SnapshotPolicy]{Count: result.Count, Details: details}, nil
+}
+
+// UpdateSnapshotPolicy 修改cron或启用策略时，如果未指定下次执行时间，按cron重新计算
+func (svc *snapshotSvc) UpdateSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(datasnapshot.PolicyUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablesnapshot.SnapshotPolicyTable{
+		Name:           req.Name,
+		BkBizID:        req.BkBizID,
+		DiskIDs:        req.DiskIDs,
+		Cron:           req.Cron,
+		RetentionCount: req.RetentionCount,
+		State:          req.State,
+		LastFlowID:     req.LastFlowID,
+		Memo:           req.Memo,
+		Reviser:        cts.Kit.User,
+	}
+
+	switch {
+	case req.NextRunAt != nil:
+		model.NextRunAt = *req.NextRunAt
+
+	case len(req.Cron) != 0 || req.State == enumor.SnapshotPolicyEnabled:
+		expr := req.Cron
+		if len(expr) == 0 {
+			opt := &types.ListOption{
+				Fields: []string{"cron"},
+				Filter: tools.EqualExpression("id", id),
+				Page:   core.NewDefaultBasePage(),
+			}
+			result, err := svc.dao.SnapshotPolicy().List(cts.Kit, opt)
+			if err != nil {
+				logs.Errorf("list snapshot policy failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+				return nil, err
+			}
+
+			if len(result.Details) == 0 {
+				return nil, errf.Newf(errf.RecordNotFound, "snapshot policy: %s not found", id)
+			}
+			expr = result.Details[0].Cron
+		}
+
+		nextRunAt, err := nextPolicyRunAt(expr)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		model.NextRunAt = nextRunAt
+	}
+
+	if err := svc.dao.SnapshotPolicy().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update snapshot policy failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteSnapshotPolicy 删除快照策略，已由策略创建的快照保留
+func (svc *snapshotSvc) BatchDeleteSnapshotPolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.SnapshotPolicy().DeleteWithTx(cts.Kit, txn,
+			tools.ContainersExpression("id", req.IDs)); err != nil {
+			return nil, fmt.Errorf("delete snapshot policy failed, err: %v", err)
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch delete snapshot policy failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func nextPolicyRunAt(expr string) (time.Time, error) {
+	sch, err := cron.Parse(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return sch.Next(time.Now())
+}
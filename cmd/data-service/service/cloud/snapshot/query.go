@@ -0,0 +1,230 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	datasnapshot "hcm/pkg/api/data-service/cloud/snapshot"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablesnapshot "hcm/pkg/dal/table/cloud/snapshot"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+)
+
+// ListSnapshot list snapshot.
+func (svc *snapshotSvc) ListSnapshot(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.Snapshot().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list snapshot failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list snapshot failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &datasnapshot.SnapshotListResult{Count: result.Count}, nil
+	}
+
+	details := make([]coresnapshot.BaseSnapshot, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, *convTableToBaseSnapshot(&one))
+	}
+
+	return &datasnapshot.SnapshotListResult{Details: details}, nil
+}
+
+// ListSnapshotExt list snapshot with extension.
+func (svc *snapshotSvc) ListSnapshotExt(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	vendorFilter, err := tools.And(filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+		req.Filter)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: vendorFilter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.Snapshot().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list snapshot ext failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list snapshot ext failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &datasnapshot.SnapshotListResult{Count: result.Count}, nil
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convSnapshotListResult[coresnapshot.TCloudSnapshotExtension](cts.Kit, result)
+	case enumor.Aws:
+		return convSnapshotListResult[coresnapshot.AwsSnapshotExtension](cts.Kit, result)
+	case enumor.HuaWei:
+		return convSnapshotListResult[coresnapshot.HuaWeiSnapshotExtension](cts.Kit, result)
+	case enumor.Gcp:
+		return convSnapshotListResult[coresnapshot.GcpSnapshotExtension](cts.Kit, result)
+	case enumor.Azure:
+		return convSnapshotListResult[coresnapshot.AzureSnapshotExtension](cts.Kit, result)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func convSnapshotListResult[T coresnapshot.Extension](kt *kit.Kit,
+	result *types.ListResult[tablesnapshot.SnapshotTable]) (*datasnapshot.SnapshotExtListResult[T], error) {
+
+	details := make([]coresnapshot.Snapshot[T], 0, len(result.Details))
+	for _, one := range result.Details {
+		snapshot, err := convSnapshotWithExt[T](&one)
+		if err != nil {
+			logs.Errorf("conv snapshot with extension failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+			return nil, err
+		}
+		details = append(details, *snapshot)
+	}
+
+	return &datasnapshot.SnapshotExtListResult[T]{Details: details}, nil
+}
+
+// GetSnapshot get snapshot with extension.
+func (svc *snapshotSvc) GetSnapshot(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "snapshot id is required")
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.Snapshot().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("get snapshot failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, fmt.Errorf("get snapshot failed, err: %v", err)
+	}
+
+	if len(result.Details) != 1 {
+		return nil, errf.Newf(errf.RecordNotFound, "snapshot: %s not found", id)
+	}
+
+	one := result.Details[0]
+	if one.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "snapshot: %s is not %s vendor", id, vendor)
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		return convSnapshotWithExt[coresnapshot.TCloudSnapshotExtension](&one)
+	case enumor.Aws:
+		return convSnapshotWithExt[coresnapshot.AwsSnapshotExtension](&one)
+	case enumor.HuaWei:
+		return convSnapshotWithExt[coresnapshot.HuaWeiSnapshotExtension](&one)
+	case enumor.Gcp:
+		return convSnapshotWithExt[coresnapshot.GcpSnapshotExtension](&one)
+	case enumor.Azure:
+		return convSnapshotWithExt[coresnapshot.AzureSnapshotExtension](&one)
+	default:
+		return nil, fmt.Errorf("unsupport %s vendor for now", vendor)
+	}
+}
+
+func convSnapshotWithExt[T coresnapshot.Extension](one *tablesnapshot.SnapshotTable) (*coresnapshot.Snapshot[T],
+	error) {
+
+	extension := new(T)
+	if len(one.Extension) != 0 {
+		if err := json.UnmarshalFromString(string(one.Extension), extension); err != nil {
+			return nil, fmt.Errorf("UnmarshalFromString snapshot json extension failed, err: %v", err)
+		}
+	}
+
+	return &coresnapshot.Snapshot[T]{
+		BaseSnapshot: *convTableToBaseSnapshot(one),
+		Extension:    extension,
+	}, nil
+}
+
+func convTableToBaseSnapshot(one *tablesnapshot.SnapshotTable) *coresnapshot.BaseSnapshot {
+	return &coresnapshot.BaseSnapshot{
+		ID:               one.ID,
+		CloudID:          one.CloudID,
+		Name:             one.Name,
+		Vendor:           one.Vendor,
+		AccountID:        one.AccountID,
+		BkBizID:          one.BkBizID,
+		Region:           one.Region,
+		Zone:             one.Zone,
+		CloudDiskID:      one.CloudDiskID,
+		DiskID:           one.DiskID,
+		Size:             one.Size,
+		Status:           one.Status,
+		PolicyID:         one.PolicyID,
+		Memo:             one.Memo,
+		CloudCreatedTime: one.CloudCreatedTime,
+		Revision: &core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
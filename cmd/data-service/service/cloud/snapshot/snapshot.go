@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package snapshot 快照及快照策略的DB接口
+package snapshot
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+var svc *snapshotSvc
+
+// InitService initial the snapshot service
+func InitService(cap *capability.Capability) {
+	svc = &snapshotSvc{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateSnapshot", http.MethodPost, "/vendors/{vendor}/snapshots/batch/create", svc.BatchCreateSnapshot)
+	h.Add("BatchUpdateSnapshot", http.MethodPatch, "/vendors/{vendor}/snapshots/batch/update", svc.BatchUpdateSnapshot)
+	h.Add("GetSnapshot", http.MethodGet, "/vendors/{vendor}/snapshots/{id}", svc.GetSnapshot)
+	h.Add("ListSnapshot", http.MethodPost, "/snapshots/list", svc.ListSnapshot)
+	h.Add("ListSnapshotExt", http.MethodPost, "/vendors/{vendor}/snapshots/list", svc.ListSnapshotExt)
+	h.Add("BatchDeleteSnapshot", http.MethodDelete, "/snapshots/batch", svc.BatchDeleteSnapshot)
+
+	h.Add("CreateSnapshotPolicy", http.MethodPost, "/snapshots/policies/create", svc.CreateSnapshotPolicy)
+	h.Add("ListSnapshotPolicy", http.MethodPost, "/snapshots/policies/list", svc.ListSnapshotPolicy)
+	h.Add("UpdateSnapshotPolicy", http.MethodPatch, "/snapshots/policies/{id}", svc.UpdateSnapshotPolicy)
+	h.Add("BatchDeleteSnapshotPolicy", http.MethodDelete, "/snapshots/policies/batch", svc.BatchDeleteSnapshotPolicy)
+
+	h.Load(cap.WebService)
+}
+
+type snapshotSvc struct {
+	dao dao.Set
+}
@@ -118,6 +118,14 @@ func (svc *tcloudSGRuleSvc) BatchCreateTCloudRule(cts *rest.Contexts) (interface
 			return nil, fmt.Errorf("batch create tcloud security group rule failed, err: %v", err)
 		}
 
+		changes := make([]sgRuleChange, 0, len(rules))
+		for _, one := range rules {
+			changes = append(changes, sgRuleChange{SGID: one.SecurityGroupID, ID: one.ID, New: one})
+		}
+		if err = recordSGRuleChanges(cts.Kit, txn, svc.dao, changes); err != nil {
+			return nil, err
+		}
+
 		return ruleIDs, nil
 	})
 	if err != nil {
@@ -149,7 +157,32 @@ func (svc *tcloudSGRuleSvc) BatchUpdateTCloudRule(cts *rest.Contexts) (interface
 		return nil, errf.NewFromErr(errf.InvalidParameter, err)
 	}
 
+	// 同步请求需要对比更新前的规则，记录规则变更
+	existRules := make(map[string]tablecloud.TCloudSecurityGroupRuleTable)
+	if cts.Kit.GetRequestSource() == enumor.BackgroundSync {
+		ids := make([]string, 0, len(req.Rules))
+		for _, one := range req.Rules {
+			ids = append(ids, one.ID)
+		}
+
+		opt := &types.SGRuleListOption{
+			SecurityGroupID: sgID,
+			Filter:          tools.ContainersExpression("id", ids),
+			Page:            core.NewDefaultBasePage(),
+		}
+		list, err := svc.dao.TCloudSGRule().List(cts.Kit, opt)
+		if err != nil {
+			logs.Errorf("list tcloud security group rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		for _, one := range list.Details {
+			existRules[one.ID] = one
+		}
+	}
+
 	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		changes := make([]sgRuleChange, 0)
 		for _, one := range req.Rules {
 			rule := &tablecloud.TCloudSecurityGroupRuleTable{
 				Region:                     one.Region,
@@ -196,6 +229,14 @@ func (svc *tcloudSGRuleSvc) BatchUpdateTCloudRule(cts *rest.Contexts) (interface
 				logs.Errorf("update tcloud security group rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
 				return nil, fmt.Errorf("update tcloud security group rule failed, err: %v", err)
 			}
+
+			if exist, ok := existRules[one.ID]; ok {
+				changes = append(changes, sgRuleChange{SGID: sgID, ID: one.ID, Old: exist, New: rule})
+			}
+		}
+
+		if err := recordSGRuleChanges(cts.Kit, txn, svc.dao, changes); err != nil {
+			return nil, err
 		}
 
 		return nil, nil
@@ -293,7 +334,6 @@ func (svc *tcloudSGRuleSvc) DeleteTCloudRule(cts *rest.Contexts) (interface{}, e
 
 	opt := &types.SGRuleListOption{
 		SecurityGroupID: sgID,
-		Fields:          []string{"id"},
 		Filter:          req.Filter,
 		Page:            core.NewDefaultBasePage(),
 	}
@@ -312,9 +352,20 @@ func (svc *tcloudSGRuleSvc) DeleteTCloudRule(cts *rest.Contexts) (interface{}, e
 		delIDs[index] = one.ID
 	}
 
-	delFilter := tools.ContainersExpression("id", delIDs)
-	if err := svc.dao.TCloudSGRule().Delete(cts.Kit, delFilter); err != nil {
-		logs.Errorf("delete tcloud security group rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		delFilter := tools.ContainersExpression("id", delIDs)
+		if err := svc.dao.TCloudSGRule().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+			logs.Errorf("delete tcloud security group rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		changes := make([]sgRuleChange, 0, len(listResp.Details))
+		for _, one := range listResp.Details {
+			changes = append(changes, sgRuleChange{SGID: sgID, ID: one.ID, Old: one})
+		}
+		return nil, recordSGRuleChanges(cts.Kit, txn, svc.dao, changes)
+	})
+	if err != nil {
 		return nil, err
 	}
 
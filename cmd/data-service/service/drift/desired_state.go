@@ -0,0 +1,250 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package drift
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	coredrift "hcm/pkg/api/core/drift"
+	dsdrift "hcm/pkg/api/data-service/drift"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tabledrift "hcm/pkg/dal/table/drift"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateDesiredState 批量创建资源的期望状态，同一资源已有的期望状态会被替换
+func (svc *service) BatchCreateDesiredState(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsdrift.DesiredStateBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	emptyDetail, err := tabletypes.NewJsonField(make([]coredrift.FieldDiff, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resIDsMap := make(map[enumor.CloudResourceType][]string)
+	models := make([]*tabledrift.DesiredStateTable, 0, len(req.States))
+	for _, one := range req.States {
+		spec, err := tabletypes.NewJsonField(one.Spec)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		bizID := one.BkBizID
+		if bizID == 0 {
+			bizID = constant.UnassignedBiz
+		}
+
+		resIDsMap[one.ResType] = append(resIDsMap[one.ResType], one.ResID)
+		models = append(models, &tabledrift.DesiredStateTable{
+			ResType:       one.ResType,
+			ResID:         one.ResID,
+			CloudResID:    one.CloudResID,
+			Vendor:        one.Vendor,
+			AccountID:     one.AccountID,
+			BkBizID:       bizID,
+			ApplicationID: one.ApplicationID,
+			Spec:          spec,
+			DriftStatus:   enumor.InSyncDriftStatus,
+			DriftDetail:   emptyDetail,
+			CheckedAt:     now,
+			Creator:       cts.Kit.User,
+			Reviser:       cts.Kit.User,
+		})
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for resType, resIDs := range resIDsMap {
+			expr, err := tools.And(tools.EqualExpression("res_type", resType),
+				tools.ContainersExpression("res_id", resIDs))
+			if err != nil {
+				return nil, err
+			}
+
+			if err = svc.dao.DesiredState().DeleteWithTx(cts.Kit, txn, expr); err != nil {
+				return nil, fmt.Errorf("delete old desired state failed, err: %v", err)
+			}
+		}
+
+		return svc.dao.DesiredState().BatchCreateWithTx(cts.Kit, txn, models)
+	})
+	if err != nil {
+		logs.Errorf("batch create desired state failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	createdIDs, ok := ids.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create desired state but return id type not []string, id type: %T", ids)
+	}
+
+	return &core.BatchCreateResult{IDs: createdIDs}, nil
+}
+
+// ListDesiredState ...
+func (svc *service) ListDesiredState(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.DesiredState().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list desired state failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coredrift.DesiredState, 0, len(result.Details))
+	for _, one := range result.Details {
+		state := coredrift.DesiredState{
+			ID:            one.ID,
+			ResType:       one.ResType,
+			ResID:         one.ResID,
+			CloudResID:    one.CloudResID,
+			Vendor:        one.Vendor,
+			AccountID:     one.AccountID,
+			BkBizID:       one.BkBizID,
+			ApplicationID: one.ApplicationID,
+			DriftStatus:   one.DriftStatus,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		}
+
+		if !one.CheckedAt.IsZero() {
+			state.CheckedAt = one.CheckedAt.Format(constant.TimeStdFormat)
+		}
+
+		if !one.Spec.IsEmpty() {
+			if err = json.UnmarshalFromString(string(one.Spec), &state.Spec); err != nil {
+				logs.Errorf("unmarshal desired state spec failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		if !one.DriftDetail.IsEmpty() {
+			if err = json.UnmarshalFromString(string(one.DriftDetail), &state.DriftDetail); err != nil {
+				logs.Errorf("unmarshal desired state drift detail failed, err: %v, id: %s, rid: %s", err, one.ID,
+					cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, state)
+	}
+
+	return &core.ListResultT[coredrift.DesiredState]{Count: result.Count, Details: details}, nil
+}
+
+// BatchUpdateDesiredState 保存漂移检测的结果，检测时间为更新时间
+func (svc *service) BatchUpdateDesiredState(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsdrift.DesiredStateBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	now := time.Now()
+	for _, one := range req.States {
+		detail := one.DriftDetail
+		if detail == nil {
+			detail = make([]coredrift.FieldDiff, 0)
+		}
+
+		detailField, err := tabletypes.NewJsonField(detail)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		model := &tabledrift.DesiredStateTable{
+			DriftStatus: one.DriftStatus,
+			DriftDetail: detailField,
+			CheckedAt:   now,
+			Reviser:     cts.Kit.User,
+		}
+		if err = svc.dao.DesiredState().UpdateByID(cts.Kit, one.ID, model); err != nil {
+			logs.Errorf("update desired state failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteDesiredState 删除资源的期望状态，删除后资源不再进行漂移检测
+func (svc *service) BatchDeleteDesiredState(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		expr := tools.ContainersExpression("id", req.IDs)
+		if err := svc.dao.DesiredState().DeleteWithTx(cts.Kit, txn, expr); err != nil {
+			return nil, fmt.Errorf("delete desired state failed, err: %v", err)
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch delete desired state failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,49 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package drift 资源期望状态以及配置漂移检测结果相关接口
+package drift
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the drift service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchCreateDesiredState", http.MethodPost, "/desired_states/batch/create", svc.BatchCreateDesiredState)
+	h.Add("ListDesiredState", http.MethodPost, "/desired_states/list", svc.ListDesiredState)
+	h.Add("BatchUpdateDesiredState", http.MethodPatch, "/desired_states/batch/update", svc.BatchUpdateDesiredState)
+	h.Add("BatchDeleteDesiredState", http.MethodDelete, "/desired_states/batch", svc.BatchDeleteDesiredState)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
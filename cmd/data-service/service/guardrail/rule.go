@@ -0,0 +1,198 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package guardrail
+
+import (
+	"hcm/pkg/api/core"
+	coreguardrail "hcm/pkg/api/core/guardrail"
+	dsguardrail "hcm/pkg/api/data-service/guardrail"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableguardrail "hcm/pkg/dal/table/guardrail"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateRule ...
+func (svc *service) CreateRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsguardrail.RuleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, err := tabletypes.NewJsonField(req.Expression)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	memo := req.Memo
+	if memo == nil {
+		memo = new(string)
+	}
+
+	rule := &tableguardrail.RuleTable{
+		Name:       req.Name,
+		BkBizID:    req.BkBizID,
+		ResType:    req.ResType,
+		Vendor:     req.Vendor,
+		Expression: expr,
+		Message:    req.Message,
+		Enabled:    req.Enabled,
+		Memo:       memo,
+		Creator:    cts.Kit.User,
+		Reviser:    cts.Kit.User,
+	}
+	id, err := svc.dao.GuardrailRule().Create(cts.Kit, rule)
+	if err != nil {
+		logs.Errorf("create guardrail rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// ListRule ...
+func (svc *service) ListRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.GuardrailRule().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list guardrail rule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coreguardrail.Rule, 0, len(result.Details))
+	for _, one := range result.Details {
+		rule := coreguardrail.Rule{
+			ID:      one.ID,
+			Name:    one.Name,
+			BkBizID: one.BkBizID,
+			ResType: one.ResType,
+			Vendor:  one.Vendor,
+			Message: one.Message,
+			Enabled: one.Enabled != nil && *one.Enabled,
+			Memo:    one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		}
+
+		if !one.Expression.IsEmpty() {
+			rule.Expression = new(filter.Expression)
+			if err = json.UnmarshalFromString(string(one.Expression), rule.Expression); err != nil {
+				logs.Errorf("unmarshal guardrail rule expression failed, err: %v, id: %s, rid: %s", err, one.ID,
+					cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, rule)
+	}
+
+	return &core.ListResultT[coreguardrail.Rule]{Count: result.Count, Details: details}, nil
+}
+
+// UpdateRule ...
+func (svc *service) UpdateRule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dsguardrail.RuleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableguardrail.RuleTable{
+		Name:    req.Name,
+		Message: req.Message,
+		Enabled: req.Enabled,
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+
+	if req.Expression != nil {
+		expr, err := tabletypes.NewJsonField(req.Expression)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		model.Expression = expr
+	}
+
+	if err := svc.dao.GuardrailRule().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update guardrail rule failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteRule ...
+func (svc *service) BatchDeleteRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.GuardrailRule().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("batch delete guardrail rule failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,181 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ipam
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreipam "hcm/pkg/api/core/ipam"
+	dsipam "hcm/pkg/api/data-service/ipam"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableipam "hcm/pkg/dal/table/ipam"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreatePool ...
+func (svc *service) CreatePool(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsipam.PoolCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	bizID := req.BkBizID
+	if bizID <= 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	memo := req.Memo
+	if memo == nil {
+		memo = new(string)
+	}
+
+	model := &tableipam.PoolTable{
+		Name:          req.Name,
+		Cidr:          req.Cidr,
+		Region:        req.Region,
+		BkBizID:       bizID,
+		VpcMaskLen:    req.VpcMaskLen,
+		SubnetMaskLen: req.SubnetMaskLen,
+		Memo:          memo,
+		Creator:       cts.Kit.User,
+		Reviser:       cts.Kit.User,
+	}
+	id, err := svc.dao.IPAMPool().Create(cts.Kit, model)
+	if err != nil {
+		logs.Errorf("create ipam pool failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// ListPool ...
+func (svc *service) ListPool(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.IPAMPool().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list ipam pool failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coreipam.Pool, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coreipam.Pool{
+			ID:            one.ID,
+			Name:          one.Name,
+			Cidr:          one.Cidr,
+			Region:        one.Region,
+			BkBizID:       one.BkBizID,
+			VpcMaskLen:    one.VpcMaskLen,
+			SubnetMaskLen: one.SubnetMaskLen,
+			Memo:          one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[coreipam.Pool]{Count: result.Count, Details: details}, nil
+}
+
+// UpdatePool ...
+func (svc *service) UpdatePool(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dsipam.PoolUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableipam.PoolTable{
+		Name:          req.Name,
+		VpcMaskLen:    req.VpcMaskLen,
+		SubnetMaskLen: req.SubnetMaskLen,
+		Memo:          req.Memo,
+		Reviser:       cts.Kit.User,
+	}
+	if err := svc.dao.IPAMPool().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update ipam pool failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeletePool ...
+func (svc *service) BatchDeletePool(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.IPAMPool().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs)); err != nil {
+			return nil, fmt.Errorf("delete ipam pool failed, err: %v", err)
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch delete ipam pool failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,181 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package lease
+
+import (
+	"hcm/pkg/api/core"
+	corelease "hcm/pkg/api/core/lease"
+	dslease "hcm/pkg/api/data-service/lease"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablelease "hcm/pkg/dal/table/lease"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateLease ...
+func (svc *service) CreateLease(cts *rest.Contexts) (interface{}, error) {
+	req := new(dslease.LeaseCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	memo := req.Memo
+	if memo == nil {
+		memo = new(string)
+	}
+
+	lease := &tablelease.LeaseTable{
+		ResType:  req.ResType,
+		ResID:    req.ResID,
+		Vendor:   req.Vendor,
+		BkBizID:  req.BkBizID,
+		Owner:    req.Owner,
+		ExpireAt: req.ExpireAt,
+		Reminded: converter.ValToPtr(false),
+		State:    enumor.LeaseActive,
+		Memo:     memo,
+		Creator:  cts.Kit.User,
+		Reviser:  cts.Kit.User,
+	}
+	id, err := svc.dao.ResourceLease().Create(cts.Kit, lease)
+	if err != nil {
+		logs.Errorf("create resource lease failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// ListLease ...
+func (svc *service) ListLease(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ResourceLease().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list resource lease failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corelease.Lease, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corelease.Lease{
+			ID:       one.ID,
+			ResType:  one.ResType,
+			ResID:    one.ResID,
+			Vendor:   one.Vendor,
+			BkBizID:  one.BkBizID,
+			Owner:    one.Owner,
+			ExpireAt: times.ConvStdTimeFormat(one.ExpireAt),
+			Reminded: one.Reminded != nil && *one.Reminded,
+			State:    one.State,
+			Memo:     one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &core.ListResultT[corelease.Lease]{Count: result.Count, Details: details}, nil
+}
+
+// UpdateLease ...
+func (svc *service) UpdateLease(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dslease.LeaseUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablelease.LeaseTable{
+		BkBizID:  req.BkBizID,
+		Owner:    req.Owner,
+		Reminded: req.Reminded,
+		State:    req.State,
+		Memo:     req.Memo,
+		Reviser:  cts.Kit.User,
+	}
+	if req.ExpireAt != nil {
+		model.ExpireAt = *req.ExpireAt
+	}
+
+	if err := svc.dao.ResourceLease().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update resource lease failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteLease ...
+func (svc *service) BatchDeleteLease(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, svc.dao.ResourceLease().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs))
+	})
+	if err != nil {
+		logs.Errorf("batch delete resource lease failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
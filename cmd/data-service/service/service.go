@@ -29,8 +29,10 @@ import (
 	"time"
 
 	"hcm/cmd/data-service/service/application"
+	"hcm/cmd/data-service/service/approval"
 	"hcm/cmd/data-service/service/audit"
 	"hcm/cmd/data-service/service/auth"
+	"hcm/cmd/data-service/service/budget"
 	"hcm/cmd/data-service/service/capability"
 	"hcm/cmd/data-service/service/cloud"
 	cloudselection "hcm/cmd/data-service/service/cloud-selection"
@@ -44,16 +46,26 @@ import (
 	"hcm/cmd/data-service/service/cloud/eip"
 	eipcvmrel "hcm/cmd/data-service/service/cloud/eip-cvm-rel"
 	"hcm/cmd/data-service/service/cloud/image"
+	keypair "hcm/cmd/data-service/service/cloud/key-pair"
+	loadbalancer "hcm/cmd/data-service/service/cloud/load-balancer"
+	natgateway "hcm/cmd/data-service/service/cloud/nat-gateway"
 	networkinterface "hcm/cmd/data-service/service/cloud/network-interface"
 	networkcvmrel "hcm/cmd/data-service/service/cloud/network-interface-cvm-rel"
 	"hcm/cmd/data-service/service/cloud/region"
 	resourcegroup "hcm/cmd/data-service/service/cloud/resource-group"
+	resourcetag "hcm/cmd/data-service/service/cloud/resource-tag"
 	routetable "hcm/cmd/data-service/service/cloud/route-table"
 	sgcvmrel "hcm/cmd/data-service/service/cloud/security-group-cvm-rel"
+	"hcm/cmd/data-service/service/cloud/snapshot"
 	subaccount "hcm/cmd/data-service/service/cloud/sub-account"
 	sync "hcm/cmd/data-service/service/cloud/sync"
 	"hcm/cmd/data-service/service/cloud/zone"
+	"hcm/cmd/data-service/service/drift"
+	"hcm/cmd/data-service/service/guardrail"
+	"hcm/cmd/data-service/service/ipam"
+	"hcm/cmd/data-service/service/lease"
 	recyclerecord "hcm/cmd/data-service/service/recycle-record"
+	"hcm/cmd/data-service/service/stack"
 	"hcm/cmd/data-service/service/user"
 	"hcm/pkg/cc"
 	"hcm/pkg/criteria/errf"
@@ -210,11 +222,24 @@ func (s *Service) apiSet() *restful.Container {
 	networkcvmrel.InitService(capability)
 	recyclerecord.InitRecycleRecordService(capability)
 	bill.InitBillConfigService(capability)
+	bill.InitBillDailyService(capability)
 	subaccount.InitService(capability)
 	sync.InitService(capability)
 	user.InitService(capability)
 	cloudselection.InitService(capability)
 	argstpl.InitService(capability)
+	loadbalancer.InitService(capability)
+	resourcetag.InitService(capability)
+	budget.InitService(capability)
+	snapshot.InitService(capability)
+	keypair.InitService(capability)
+	natgateway.InitService(capability)
+	ipam.InitService(capability)
+	drift.InitService(capability)
+	stack.InitService(capability)
+	approval.InitService(capability)
+	guardrail.InitService(capability)
+	lease.InitService(capability)
 
 	return restful.NewContainer().Add(capability.WebService)
 }
@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package stack 资源栈以及资源栈模版版本相关接口
+package stack
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initial the stack service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateStack", http.MethodPost, "/stacks/create", svc.CreateStack)
+	h.Add("ListStack", http.MethodPost, "/stacks/list", svc.ListStack)
+	h.Add("UpdateStack", http.MethodPatch, "/stacks/{id}", svc.UpdateStack)
+	h.Add("BatchDeleteStack", http.MethodDelete, "/stacks/batch", svc.BatchDeleteStack)
+
+	h.Add("CreateStackVersion", http.MethodPost, "/stacks/versions/create", svc.CreateStackVersion)
+	h.Add("ListStackVersion", http.MethodPost, "/stacks/versions/list", svc.ListStackVersion)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
@@ -0,0 +1,234 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package stack
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corestack "hcm/pkg/api/core/stack"
+	dsstack "hcm/pkg/api/data-service/stack"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablestack "hcm/pkg/dal/table/stack"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateStack 创建资源栈，模版内容保存为资源栈的第一个版本
+func (svc *service) CreateStack(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsstack.StackCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	resources, err := tabletypes.NewJsonField(make([]corestack.Resource, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	memo := req.Memo
+	if memo == nil {
+		memo = new(string)
+	}
+
+	stack := &tablestack.StackTable{
+		Name:      req.Name,
+		Vendor:    req.Vendor,
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		BkBizID:   req.BkBizID,
+		Version:   1,
+		Status:    enumor.DraftStackStatus,
+		Resources: resources,
+		Memo:      memo,
+		Creator:   cts.Kit.User,
+		Reviser:   cts.Kit.User,
+	}
+	id, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		stackID, err := svc.dao.Stack().CreateWithTx(cts.Kit, txn, stack)
+		if err != nil {
+			return nil, err
+		}
+
+		version := &tablestack.VersionTable{
+			StackID: stackID,
+			Version: 1,
+			Format:  req.Format,
+			Content: req.Content,
+			Creator: cts.Kit.User,
+		}
+		if _, err = svc.dao.StackVersion().CreateWithTx(cts.Kit, txn, version); err != nil {
+			return nil, fmt.Errorf("create stack version failed, err: %v", err)
+		}
+
+		return stackID, nil
+	})
+	if err != nil {
+		logs.Errorf("create stack failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	stackID, ok := id.(string)
+	if !ok {
+		return nil, fmt.Errorf("create stack but return id type not string, id type: %T", id)
+	}
+
+	return &core.CreateResult{ID: stackID}, nil
+}
+
+// ListStack ...
+func (svc *service) ListStack(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.Stack().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list stack failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corestack.Stack, 0, len(result.Details))
+	for _, one := range result.Details {
+		stack := corestack.Stack{
+			ID:             one.ID,
+			Name:           one.Name,
+			Vendor:         one.Vendor,
+			AccountID:      one.AccountID,
+			Region:         one.Region,
+			BkBizID:        one.BkBizID,
+			Version:        one.Version,
+			AppliedVersion: one.AppliedVersion,
+			Status:         one.Status,
+			FlowID:         one.FlowID,
+			Memo:           one.Memo,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		}
+
+		if !one.Resources.IsEmpty() {
+			if err = json.UnmarshalFromString(string(one.Resources), &stack.Resources); err != nil {
+				logs.Errorf("unmarshal stack resources failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, stack)
+	}
+
+	return &core.ListResultT[corestack.Stack]{Count: result.Count, Details: details}, nil
+}
+
+// UpdateStack ...
+func (svc *service) UpdateStack(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(dsstack.StackUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablestack.StackTable{
+		Status:         req.Status,
+		FlowID:         req.FlowID,
+		AppliedVersion: req.AppliedVersion,
+		Memo:           req.Memo,
+		Reviser:        cts.Kit.User,
+	}
+
+	if req.Resources != nil {
+		resources, err := tabletypes.NewJsonField(req.Resources)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		model.Resources = resources
+	}
+
+	if err := svc.dao.Stack().UpdateByID(cts.Kit, id, model); err != nil {
+		logs.Errorf("update stack failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteStack 删除资源栈以及资源栈的全部模版版本
+func (svc *service) BatchDeleteStack(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		versionExpr := tools.ContainersExpression("stack_id", req.IDs)
+		if err := svc.dao.StackVersion().DeleteWithTx(cts.Kit, txn, versionExpr); err != nil {
+			return nil, fmt.Errorf("delete stack version failed, err: %v", err)
+		}
+
+		if err := svc.dao.Stack().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", req.IDs)); err != nil {
+			return nil, fmt.Errorf("delete stack failed, err: %v", err)
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("batch delete stack failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
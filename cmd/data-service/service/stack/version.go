@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package stack
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corestack "hcm/pkg/api/core/stack"
+	dsstack "hcm/pkg/api/data-service/stack"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablestack "hcm/pkg/dal/table/stack"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateStackVersion 创建资源栈模版的新版本，版本号为资源栈当前最新版本加1
+func (svc *service) CreateStackVersion(cts *rest.Contexts) (interface{}, error) {
+	req := new(dsstack.VersionCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id", "version"},
+		Filter: tools.EqualExpression("id", req.StackID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.Stack().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list stack failed, err: %v, id: %s, rid: %s", err, req.StackID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "stack: %s not found", req.StackID)
+	}
+
+	// 版本表上stack_id与version的唯一索引保证并发创建时版本号不会重复
+	version := &tablestack.VersionTable{
+		StackID: req.StackID,
+		Version: result.Details[0].Version + 1,
+		Format:  req.Format,
+		Content: req.Content,
+		Creator: cts.Kit.User,
+	}
+	id, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		stack := &tablestack.StackTable{
+			Version: version.Version,
+			Reviser: cts.Kit.User,
+		}
+		if err := svc.dao.Stack().UpdateByIDWithTx(cts.Kit, txn, req.StackID, stack); err != nil {
+			return nil, fmt.Errorf("update stack version failed, err: %v", err)
+		}
+
+		return svc.dao.StackVersion().CreateWithTx(cts.Kit, txn, version)
+	})
+	if err != nil {
+		logs.Errorf("create stack version failed, err: %v, stack: %s, rid: %s", err, req.StackID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	versionID, ok := id.(string)
+	if !ok {
+		return nil, fmt.Errorf("create stack version but return id type not string, id type: %T", id)
+	}
+
+	return &dsstack.VersionCreateResult{ID: versionID, Version: version.Version}, nil
+}
+
+// ListStackVersion ...
+func (svc *service) ListStackVersion(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.StackVersion().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list stack version failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]corestack.Version, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corestack.Version{
+			ID:        one.ID,
+			StackID:   one.StackID,
+			Version:   one.Version,
+			Format:    one.Format,
+			Content:   one.Content,
+			Creator:   one.Creator,
+			CreatedAt: one.CreatedAt.String(),
+		})
+	}
+
+	return &core.ListResultT[corestack.Version]{Count: result.Count, Details: details}, nil
+}
@@ -99,6 +99,11 @@ func (cli *client) Disk(kt *kit.Kit, params *SyncBaseParams, opt *SyncDiskOption
 		}
 	}
 
+	tagOpt := &common.SyncResTagOption{Vendor: enumor.Aws, AccountID: params.AccountID, ResType: enumor.DiskCloudResType}
+	if err = common.SyncResTags(kt, cli.dbCli, tagOpt, diskFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
@@ -0,0 +1,310 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	datakeypair "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+)
+
+// SyncKeyPairOption ...
+type SyncKeyPairOption struct {
+	// BkBizID 通过HCM创建的密钥对所属业务
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+	// PrivateKey 通过HCM创建的密钥对已加密的私钥
+	PrivateKey string  `json:"private_key" validate:"omitempty"`
+	Memo       *string `json:"memo" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt SyncKeyPairOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// KeyPair 同步密钥对
+func (cli *client) KeyPair(kt *kit.Kit, params *SyncBaseParams, opt *SyncKeyPairOption) (*SyncResult, error) {
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	keyPairFromCloud, err := cli.listKeyPairFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPairFromDB, err := cli.listKeyPairFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keyPairFromCloud) == 0 && len(keyPairFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typekeypair.AwsKeyPair,
+		corekeypair.KeyPair[corekeypair.AwsKeyPairExtension]](keyPairFromCloud, keyPairFromDB, isKeyPairChange)
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteKeyPair(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	syncOpt := &common.KeyPairSyncOption{
+		Vendor:     enumor.Aws,
+		AccountID:  params.AccountID,
+		BkBizID:    opt.BkBizID,
+		PrivateKey: opt.PrivateKey,
+		Memo:       opt.Memo,
+	}
+
+	createdIDs := make([]string, 0)
+	if len(addSlice) > 0 {
+		if createdIDs, err = cli.createKeyPair(kt, syncOpt, addSlice); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateKeyPair(kt, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	return &SyncResult{CreatedIds: createdIDs}, nil
+}
+
+// RemoveKeyPairDeleteFromCloud ...
+func (cli *client) RemoveKeyPairDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: enumor.Aws},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Global.KeyPair.ListKeyPair(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list key pair failed, err: %v, req: %v, rid: %s",
+				enumor.Aws, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0)
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listKeyPairFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.CloudID)
+			}
+
+			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if err = cli.deleteKeyPair(kt, accountID, region, delCloudIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	return nil
+}
+
+func (cli *client) deleteKeyPair(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete key pair, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delKeyPairFromCloud, err := cli.listKeyPairFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delKeyPairFromCloud) > 0 {
+		logs.Errorf("[%s] validate key pair not exist failed, before delete, opt: %v, failed_count: %d, rid: %s",
+			enumor.Aws, checkParams, len(delKeyPairFromCloud), kt.Rid)
+		return fmt.Errorf("validate key pair not exist failed, before delete")
+	}
+
+	deleteReq, err := common.KeyPairDeleteReqByCloudIDs(enumor.Aws, accountID, delCloudIDs)
+	if err != nil {
+		return err
+	}
+	if err = cli.dbCli.Global.KeyPair.BatchDeleteKeyPair(kt, deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete key pair failed, err: %v, rid: %s", enumor.Aws,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync key pair to delete key pair success, accountID: %s, count: %d, rid: %s", enumor.Aws,
+		accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateKeyPair(kt *kit.Kit, accountID string,
+	updateMap map[string]typekeypair.AwsKeyPair) error {
+
+	updateReq := &datakeypair.KeyPairBatchUpdateReq[corekeypair.AwsKeyPairExtension]{
+		KeyPairs: make([]datakeypair.KeyPairBatchUpdate[corekeypair.AwsKeyPairExtension], 0, len(updateMap)),
+	}
+	for id, one := range updateMap {
+		updateReq.KeyPairs = append(updateReq.KeyPairs, common.BuildKeyPairUpdate(id, one.BaseKeyPair, one.Extension))
+	}
+
+	if err := cli.dbCli.Aws.BatchUpdateKeyPair(kt, updateReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch update key pair failed, err: %v, rid: %s", enumor.Aws,
+			err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync key pair to update key pair success, accountID: %s, count: %d, rid: %s", enumor.Aws,
+		accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createKeyPair(kt *kit.Kit, opt *common.KeyPairSyncOption,
+	addSlice []typekeypair.AwsKeyPair) ([]string, error) {
+
+	createReq := &datakeypair.KeyPairBatchCreateReq[corekeypair.AwsKeyPairExtension]{
+		KeyPairs: make([]datakeypair.KeyPairBatchCreate[corekeypair.AwsKeyPairExtension], 0, len(addSlice)),
+	}
+	for _, one := range addSlice {
+		createReq.KeyPairs = append(createReq.KeyPairs, common.BuildKeyPairCreate(opt, one.BaseKeyPair, one.Extension))
+	}
+
+	result, err := cli.dbCli.Aws.BatchCreateKeyPair(kt, createReq)
+	if err != nil {
+		logs.Errorf("[%s] request dataservice to batch create key pair failed, err: %v, rid: %s", enumor.Aws,
+			err, kt.Rid)
+		return nil, err
+	}
+
+	logs.Infof("[%s] sync key pair to create key pair success, accountID: %s, count: %d, rid: %s", enumor.Aws,
+		opt.AccountID, len(addSlice), kt.Rid)
+
+	return result.IDs, nil
+}
+
+func (cli *client) listKeyPairFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typekeypair.AwsKeyPair,
+	error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &adcore.AwsListOption{
+		Region:   params.Region,
+		CloudIDs: params.CloudIDs,
+	}
+	result, err := cli.cloudCli.ListKeyPair(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list key pair from cloud failed, err: %v, account: %s, opt: %v, rid: %s", enumor.Aws,
+			err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cli *client) listKeyPairFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]corekeypair.KeyPair[corekeypair.AwsKeyPairExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: params.Region},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.Aws.ListKeyPairExt(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list key pair from db failed, err: %v, account: %s, req: %v, rid: %s", enumor.Aws,
+			err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isKeyPairChange(cloud typekeypair.AwsKeyPair,
+	db corekeypair.KeyPair[corekeypair.AwsKeyPairExtension]) bool {
+
+	return common.IsKeyPairChange(cloud.BaseKeyPair, db.BaseKeyPair, cloud.Extension, db.Extension)
+}
@@ -0,0 +1,418 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	adcore "hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	datalb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+)
+
+// SyncLoadBalancerOption ...
+type SyncLoadBalancerOption struct {
+	// BkBizID 负载均衡创建时，通过同步写入DB，需要传入业务ID
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt SyncLoadBalancerOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// LoadBalancer 同步负载均衡，以及负载均衡下的监听器和后端服务
+func (cli *client) LoadBalancer(kt *kit.Kit, params *SyncBaseParams, opt *SyncLoadBalancerOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	lbFromCloud, err := cli.listLbFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	lbFromDB, err := cli.listLbFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lbFromCloud) == 0 && len(lbFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typelb.AwsLoadBalancer,
+		corelb.LoadBalancer[corelb.AwsLoadBalancerExtension]](lbFromCloud, lbFromDB, isLbChange)
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteLb(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if _, err = cli.createLb(kt, params.AccountID, addSlice, opt.BkBizID); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateLb(kt, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(lbFromCloud) > 0 {
+		if err = cli.syncLbRelRes(kt, params); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+// syncLbRelRes 同步负载均衡下的监听器和后端服务
+func (cli *client) syncLbRelRes(kt *kit.Kit, params *SyncBaseParams) error {
+	lbFromDB, err := cli.listLbFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	for _, lb := range lbFromDB {
+		listeners, err := cli.cloudCli.ListListener(kt, &typelb.ListenerListOption{
+			Region:    lb.Region,
+			CloudLbID: lb.CloudID,
+		})
+		if err != nil {
+			logs.Errorf("[%s] list listener from cloud failed, err: %v, lb: %s, rid: %s", enumor.Aws, err,
+				lb.CloudID, kt.Rid)
+			return err
+		}
+
+		targets, err := cli.cloudCli.ListTarget(kt, &typelb.TargetListOption{
+			Region:    lb.Region,
+			CloudLbID: lb.CloudID,
+		})
+		if err != nil {
+			logs.Errorf("[%s] list target from cloud failed, err: %v, lb: %s, rid: %s", enumor.Aws, err,
+				lb.CloudID, kt.Rid)
+			return err
+		}
+
+		relOpt := &common.SyncLbRelOption{
+			Vendor:    enumor.Aws,
+			AccountID: params.AccountID,
+			BkBizID:   lb.BkBizID,
+			LbID:      lb.ID,
+			CloudLbID: lb.CloudID,
+		}
+		if err = common.SyncLbListenerAndTarget(kt, cli.dbCli, relOpt, listeners, targets); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveLoadBalancerDeleteFromCloud ...
+func (cli *client) RemoveLoadBalancerDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Global.LoadBalancer.ListLoadBalancer(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list load balancer failed, err: %v, req: %v, rid: %s",
+				enumor.Aws, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0)
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listLbFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.CloudID)
+			}
+
+			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if err = cli.deleteLb(kt, accountID, region, delCloudIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	return nil
+}
+
+func (cli *client) deleteLb(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete load balancer, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delLbFromCloud, err := cli.listLbFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delLbFromCloud) > 0 {
+		logs.Errorf("[%s] validate load balancer not exist failed, before delete, opt: %v, failed_count: %d, "+
+			"rid: %s", enumor.Aws, checkParams, len(delLbFromCloud), kt.Rid)
+		return fmt.Errorf("validate load balancer not exist failed, before delete")
+	}
+
+	deleteReq, err := common.LbDeleteReqByCloudIDs(accountID, delCloudIDs)
+	if err != nil {
+		return err
+	}
+	if err = cli.dbCli.Global.LoadBalancer.BatchDeleteLoadBalancer(kt, deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete load balancer failed, err: %v, rid: %s",
+			enumor.Aws, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync load balancer to delete load balancer success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateLb(kt *kit.Kit, accountID string,
+	updateMap map[string]typelb.AwsLoadBalancer) error {
+
+	if len(updateMap) == 0 {
+		return fmt.Errorf("update load balancer, load balancers is required")
+	}
+
+	lbs := make([]typelb.BaseLoadBalancer, 0, len(updateMap))
+	for _, one := range updateMap {
+		lbs = append(lbs, one.BaseLoadBalancer)
+	}
+	vpcMap, subnetMap, err := common.GetLbVpcAndSubnetIDMap(kt, cli.dbCli, enumor.Aws, lbs)
+	if err != nil {
+		return err
+	}
+
+	updateReq := &datalb.LoadBalancerBatchUpdateReq[corelb.AwsLoadBalancerExtension]{
+		LoadBalancers: make([]datalb.LoadBalancerBatchUpdate[corelb.AwsLoadBalancerExtension], 0,
+			len(updateMap)),
+	}
+	for id, one := range updateMap {
+		updateReq.LoadBalancers = append(updateReq.LoadBalancers,
+			datalb.LoadBalancerBatchUpdate[corelb.AwsLoadBalancerExtension]{
+				ID:                   id,
+				Name:                 one.Name,
+				Zones:                one.Zones,
+				LBType:               one.LBType,
+				IPVersion:            one.IPVersion,
+				CloudVpcID:           one.CloudVpcID,
+				VpcID:                vpcMap[one.CloudVpcID],
+				CloudSubnetID:        one.CloudSubnetID,
+				SubnetID:             subnetMap[one.CloudSubnetID],
+				PrivateIPv4Addresses: one.PrivateIPv4Addresses,
+				PublicIPv4Addresses:  one.PublicIPv4Addresses,
+				Domain:               one.Domain,
+				Status:               one.Status,
+				Extension:            one.Extension,
+			})
+	}
+
+	if err = cli.dbCli.Aws.BatchUpdateLoadBalancer(kt, updateReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch update load balancer failed, err: %v, rid: %s",
+			enumor.Aws, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync load balancer to update load balancer success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createLb(kt *kit.Kit, accountID string, addSlice []typelb.AwsLoadBalancer,
+	bizID int64) ([]string, error) {
+
+	if len(addSlice) == 0 {
+		return nil, fmt.Errorf("create load balancer, load balancers is required")
+	}
+
+	lbs := make([]typelb.BaseLoadBalancer, 0, len(addSlice))
+	for _, one := range addSlice {
+		lbs = append(lbs, one.BaseLoadBalancer)
+	}
+	vpcMap, subnetMap, err := common.GetLbVpcAndSubnetIDMap(kt, cli.dbCli, enumor.Aws, lbs)
+	if err != nil {
+		return nil, err
+	}
+
+	if bizID == 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	createReq := &datalb.LoadBalancerBatchCreateReq[corelb.AwsLoadBalancerExtension]{
+		LoadBalancers: make([]datalb.LoadBalancerBatchCreate[corelb.AwsLoadBalancerExtension], 0,
+			len(addSlice)),
+	}
+	for _, one := range addSlice {
+		createReq.LoadBalancers = append(createReq.LoadBalancers,
+			datalb.LoadBalancerBatchCreate[corelb.AwsLoadBalancerExtension]{
+				CloudID:              one.CloudID,
+				Name:                 one.Name,
+				AccountID:            accountID,
+				BkBizID:              bizID,
+				Region:               one.Region,
+				Zones:                one.Zones,
+				LBType:               one.LBType,
+				IPVersion:            one.IPVersion,
+				CloudVpcID:           one.CloudVpcID,
+				VpcID:                vpcMap[one.CloudVpcID],
+				CloudSubnetID:        one.CloudSubnetID,
+				SubnetID:             subnetMap[one.CloudSubnetID],
+				PrivateIPv4Addresses: one.PrivateIPv4Addresses,
+				PublicIPv4Addresses:  one.PublicIPv4Addresses,
+				Domain:               one.Domain,
+				Status:               one.Status,
+				CloudCreatedTime:     one.CloudCreatedTime,
+				Extension:            one.Extension,
+			})
+	}
+
+	result, err := cli.dbCli.Aws.BatchCreateLoadBalancer(kt, createReq)
+	if err != nil {
+		logs.Errorf("[%s] request dataservice to batch create load balancer failed, err: %v, rid: %s",
+			enumor.Aws, err, kt.Rid)
+		return nil, err
+	}
+
+	logs.Infof("[%s] sync load balancer to create load balancer success, accountID: %s, count: %d, rid: %s",
+		enumor.Aws, accountID, len(addSlice), kt.Rid)
+
+	return result.IDs, nil
+}
+
+func (cli *client) listLbFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typelb.AwsLoadBalancer, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &adcore.AwsListOption{
+		Region:   params.Region,
+		CloudIDs: params.CloudIDs,
+	}
+	result, err := cli.cloudCli.ListLoadBalancer(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list load balancer from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+			enumor.Aws, err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func (cli *client) listLbFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]corelb.LoadBalancer[corelb.AwsLoadBalancerExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: params.Region},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.Aws.ListLoadBalancerExt(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list load balancer from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.Aws, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isLbChange(cloud typelb.AwsLoadBalancer,
+	db corelb.LoadBalancer[corelb.AwsLoadBalancerExtension]) bool {
+
+	if common.IsLbBaseChange(cloud.BaseLoadBalancer, db.BaseLoadBalancer) {
+		return true
+	}
+
+	return common.IsLbExtensionChange(cloud.Extension, db.Extension)
+}
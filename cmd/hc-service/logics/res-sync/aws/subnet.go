@@ -93,6 +93,11 @@ func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOp
 		}
 	}
 
+	tagOpt := &common.SyncResTagOption{Vendor: enumor.Aws, AccountID: params.AccountID, ResType: enumor.SubnetCloudResType}
+	if err = common.SyncResTags(kt, cli.dbCli, tagOpt, subnetFromCloud); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
@@ -93,6 +93,11 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 		}
 	}
 
+	tagOpt := &common.SyncResTagOption{Vendor: enumor.Azure, AccountID: params.AccountID, ResType: enumor.CvmCloudResType}
+	if err = common.SyncResTags(kt, cli.dbCli, tagOpt, cvmFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
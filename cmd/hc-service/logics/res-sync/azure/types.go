@@ -45,6 +45,7 @@ func (opt SyncBaseParams) Validate() error {
 
 // SyncResult sync result.
 type SyncResult struct {
+	CreatedIds []string
 }
 
 // CloudData
@@ -91,6 +91,11 @@ func (cli *client) Vpc(kt *kit.Kit, params *SyncBaseParams, opt *SyncVpcOption)
 		}
 	}
 
+	tagOpt := &common.SyncResTagOption{Vendor: enumor.Azure, AccountID: params.AccountID, ResType: enumor.VpcCloudResType}
+	if err = common.SyncResTags(kt, cli.dbCli, tagOpt, vpcFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
@@ -28,12 +28,16 @@ import (
 	typeseip "hcm/pkg/adaptor/types/eip"
 	firewallrule "hcm/pkg/adaptor/types/firewall-rule"
 	typesimage "hcm/pkg/adaptor/types/image"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
 	typesni "hcm/pkg/adaptor/types/network-interface"
 	typesregion "hcm/pkg/adaptor/types/region"
 	typesresourcegroup "hcm/pkg/adaptor/types/resource-group"
 	typesroutetable "hcm/pkg/adaptor/types/route-table"
 	securitygroup "hcm/pkg/adaptor/types/security-group"
 	typessecuritygrouprule "hcm/pkg/adaptor/types/security-group-rule"
+	typesnapshot "hcm/pkg/adaptor/types/snapshot"
 	adtysubnet "hcm/pkg/adaptor/types/subnet"
 	typeszone "hcm/pkg/adaptor/types/zone"
 	cloudcore "hcm/pkg/api/core/cloud"
@@ -41,10 +45,14 @@ import (
 	corecvm "hcm/pkg/api/core/cloud/cvm"
 	coredisk "hcm/pkg/api/core/cloud/disk"
 	coreimage "hcm/pkg/api/core/cloud/image"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
 	corecloudni "hcm/pkg/api/core/cloud/network-interface"
 	coreregion "hcm/pkg/api/core/cloud/region"
 	coreresourcegroup "hcm/pkg/api/core/cloud/resource-group"
 	cloudcoreroutetable "hcm/pkg/api/core/cloud/route-table"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
 	coresubaccount "hcm/pkg/api/core/cloud/sub-account"
 	corezone "hcm/pkg/api/core/cloud/zone"
 	corerecyclerecord "hcm/pkg/api/core/recycle-record"
@@ -141,7 +149,31 @@ type CloudResType interface {
 		typeargstpl.TCloudArgsTplAddress |
 		typeargstpl.TCloudArgsTplAddressGroup |
 		typeargstpl.TCloudArgsTplService |
-		typeargstpl.TCloudArgsTplServiceGroup
+		typeargstpl.TCloudArgsTplServiceGroup |
+
+		typelb.TCloudLoadBalancer |
+		typelb.AwsLoadBalancer |
+		typelb.HuaWeiLoadBalancer |
+		typelb.GcpLoadBalancer |
+		typelb.AzureLoadBalancer |
+		typelb.Listener |
+		typelb.Target |
+
+		typesnapshot.TCloudSnapshot |
+		typesnapshot.AwsSnapshot |
+		typesnapshot.HuaWeiSnapshot |
+		typesnapshot.GcpSnapshot |
+		typesnapshot.AzureSnapshot |
+
+		typekeypair.TCloudKeyPair |
+		typekeypair.AwsKeyPair |
+		typekeypair.HuaWeiKeyPair |
+		typekeypair.GcpKeyPair |
+
+		typenat.TCloudNatGateway |
+		typenat.AwsNatGateway |
+		typenat.HuaWeiNatGateway |
+		typenat.NatRule
 }
 
 type DBResType interface {
@@ -229,7 +261,31 @@ type DBResType interface {
 		corerecyclerecord.EipBindInfo |
 		corerecyclerecord.DiskAttachInfo |
 
-		*coreargstpl.ArgsTpl[coreargstpl.TCloudArgsTplExtension]
+		*coreargstpl.ArgsTpl[coreargstpl.TCloudArgsTplExtension] |
+
+		corelb.LoadBalancer[corelb.TCloudLoadBalancerExtension] |
+		corelb.LoadBalancer[corelb.AwsLoadBalancerExtension] |
+		corelb.LoadBalancer[corelb.HuaWeiLoadBalancerExtension] |
+		corelb.LoadBalancer[corelb.GcpLoadBalancerExtension] |
+		corelb.LoadBalancer[corelb.AzureLoadBalancerExtension] |
+		corelb.Listener |
+		corelb.Target |
+
+		coresnapshot.Snapshot[coresnapshot.TCloudSnapshotExtension] |
+		coresnapshot.Snapshot[coresnapshot.AwsSnapshotExtension] |
+		coresnapshot.Snapshot[coresnapshot.HuaWeiSnapshotExtension] |
+		coresnapshot.Snapshot[coresnapshot.GcpSnapshotExtension] |
+		coresnapshot.Snapshot[coresnapshot.AzureSnapshotExtension] |
+
+		corekeypair.KeyPair[corekeypair.TCloudKeyPairExtension] |
+		corekeypair.KeyPair[corekeypair.AwsKeyPairExtension] |
+		corekeypair.KeyPair[corekeypair.HuaWeiKeyPairExtension] |
+		corekeypair.KeyPair[corekeypair.GcpKeyPairExtension] |
+
+		corenat.NatGateway[corenat.TCloudNatGatewayExtension] |
+		corenat.NatGateway[corenat.AwsNatGatewayExtension] |
+		corenat.NatGateway[corenat.HuaWeiNatGatewayExtension] |
+		corenat.NatGatewayRule
 }
 
 // Diff 对比云和db资源，划分出新增数据，更新数据，删除数据。
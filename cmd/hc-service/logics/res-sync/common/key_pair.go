@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	datakeypair "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/runtime/filter"
+)
+
+// KeyPairDeleteReqByCloudIDs return key pair delete request by cloud ids.
+func KeyPairDeleteReqByCloudIDs(vendor enumor.Vendor, accountID string, cloudIDs []string) (
+	*datakeypair.KeyPairBatchDeleteReq, error) {
+
+	if len(cloudIDs) == 0 {
+		return nil, fmt.Errorf("delete key pair, cloudIDs is required")
+	}
+
+	return &datakeypair.KeyPairBatchDeleteReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+			},
+		},
+	}, nil
+}
+
+// IsKeyPairChange 对比密钥对公共字段及扩展字段是否变更，扩展字段按json序列化结果对比
+func IsKeyPairChange[T corekeypair.Extension](cloud typekeypair.BaseKeyPair, db corekeypair.BaseKeyPair,
+	cloudExt, dbExt *T) bool {
+
+	if cloud.Name != db.Name || cloud.Fingerprint != db.Fingerprint || cloud.PublicKey != db.PublicKey {
+		return true
+	}
+
+	cloudJson, err := json.Marshal(cloudExt)
+	if err != nil {
+		return true
+	}
+
+	dbJson, err := json.Marshal(dbExt)
+	if err != nil {
+		return true
+	}
+
+	return string(cloudJson) != string(dbJson)
+}
+
+// KeyPairSyncOption 密钥对同步写入DB时的公共参数
+type KeyPairSyncOption struct {
+	Vendor    enumor.Vendor
+	AccountID string
+	// BkBizID 通过HCM创建的密钥对，同步写入DB时指定所属业务
+	BkBizID int64
+	// PrivateKey 通过HCM创建的密钥对，同步写入DB时保存已加密的私钥
+	PrivateKey string
+	Memo       *string
+}
+
+// BuildKeyPairCreate 根据云上密钥对构造DB创建参数
+func BuildKeyPairCreate[Ext corekeypair.Extension](opt *KeyPairSyncOption, one typekeypair.BaseKeyPair,
+	ext *Ext) datakeypair.KeyPairBatchCreate[Ext] {
+
+	bizID := opt.BkBizID
+	if bizID == 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	return datakeypair.KeyPairBatchCreate[Ext]{
+		CloudID:          one.CloudID,
+		Name:             one.Name,
+		AccountID:        opt.AccountID,
+		BkBizID:          bizID,
+		Region:           one.Region,
+		Fingerprint:      one.Fingerprint,
+		PublicKey:        one.PublicKey,
+		PrivateKey:       opt.PrivateKey,
+		Memo:             opt.Memo,
+		CloudCreatedTime: one.CloudCreatedTime,
+		Extension:        ext,
+	}
+}
+
+// BuildKeyPairUpdate 根据云上密钥对构造DB更新参数，已分配的业务及私钥不会被同步覆盖
+func BuildKeyPairUpdate[Ext corekeypair.Extension](id string, one typekeypair.BaseKeyPair,
+	ext *Ext) datakeypair.KeyPairBatchUpdate[Ext] {
+
+	return datakeypair.KeyPairBatchUpdate[Ext]{
+		ID:          id,
+		Name:        one.Name,
+		Fingerprint: one.Fingerprint,
+		PublicKey:   one.PublicKey,
+		Extension:   ext,
+	}
+}
@@ -0,0 +1,436 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	datalb "hcm/pkg/api/data-service/cloud/load-balancer"
+	dataclient "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncLbRelOption sync load balancer listener and target option.
+type SyncLbRelOption struct {
+	Vendor    enumor.Vendor
+	AccountID string
+	BkBizID   int64
+	LbID      string
+	CloudLbID string
+}
+
+// SyncLbListenerAndTarget 以云上监听器和后端服务为准，同步负载均衡下的监听器和后端服务。
+func SyncLbListenerAndTarget(kt *kit.Kit, dataCli *dataclient.Client, opt *SyncLbRelOption,
+	listeners []typelb.Listener, targets []typelb.Target) error {
+
+	if err := syncLbListener(kt, dataCli, opt, listeners); err != nil {
+		return err
+	}
+
+	return syncLbTarget(kt, dataCli, opt, targets)
+}
+
+func syncLbListener(kt *kit.Kit, dataCli *dataclient.Client, opt *SyncLbRelOption,
+	listeners []typelb.Listener) error {
+
+	listenerFromDB, err := listLbListenerFromDB(kt, dataCli, opt.LbID)
+	if err != nil {
+		return err
+	}
+
+	addSlice, updateMap, delCloudIDs := Diff[typelb.Listener, corelb.Listener](listeners, listenerFromDB,
+		isLbListenerChange)
+
+	if len(delCloudIDs) > 0 {
+		delReq := &datalb.ListenerBatchDeleteReq{
+			Filter: lbResInExpr(opt.LbID, delCloudIDs),
+		}
+		if err = dataCli.Global.LoadBalancer.BatchDeleteListener(kt, delReq); err != nil {
+			logs.Errorf("[%s] request dataservice to delete listener failed, err: %v, lb: %s, rid: %s", opt.Vendor,
+				err, opt.LbID, kt.Rid)
+			return err
+		}
+	}
+
+	for _, batch := range slice.Split(addSlice, constant.BatchOperationMaxLimit) {
+		createReq := &datalb.ListenerBatchCreateReq{
+			Listeners: make([]datalb.ListenerBatchCreate, 0, len(batch)),
+		}
+		for _, one := range batch {
+			createReq.Listeners = append(createReq.Listeners, datalb.ListenerBatchCreate{
+				CloudID:            one.CloudID,
+				Name:               one.Name,
+				Vendor:             opt.Vendor,
+				AccountID:          opt.AccountID,
+				BkBizID:            opt.BkBizID,
+				LbID:               opt.LbID,
+				CloudLbID:          opt.CloudLbID,
+				Protocol:           one.Protocol,
+				Port:               one.Port,
+				CloudTargetGroupID: one.CloudTargetGroupID,
+				Extension:          one.Extension,
+			})
+		}
+		if _, err = dataCli.Global.LoadBalancer.BatchCreateListener(kt, createReq); err != nil {
+			logs.Errorf("[%s] request dataservice to create listener failed, err: %v, lb: %s, rid: %s", opt.Vendor,
+				err, opt.LbID, kt.Rid)
+			return err
+		}
+	}
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	updates := make([]datalb.ListenerBatchUpdate, 0, len(updateMap))
+	for id, one := range updateMap {
+		updates = append(updates, datalb.ListenerBatchUpdate{
+			ID:                 id,
+			Name:               one.Name,
+			Protocol:           one.Protocol,
+			Port:               one.Port,
+			CloudTargetGroupID: one.CloudTargetGroupID,
+			Extension:          one.Extension,
+		})
+	}
+	for _, batch := range slice.Split(updates, constant.BatchOperationMaxLimit) {
+		if err = dataCli.Global.LoadBalancer.BatchUpdateListener(kt,
+			&datalb.ListenerBatchUpdateReq{Listeners: batch}); err != nil {
+
+			logs.Errorf("[%s] request dataservice to update listener failed, err: %v, lb: %s, rid: %s", opt.Vendor,
+				err, opt.LbID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func syncLbTarget(kt *kit.Kit, dataCli *dataclient.Client, opt *SyncLbRelOption, targets []typelb.Target) error {
+	// 监听器同步后，重新查询监听器用于关联后端服务
+	listenerFromDB, err := listLbListenerFromDB(kt, dataCli, opt.LbID)
+	if err != nil {
+		return err
+	}
+
+	listenerIDMap := make(map[string]string, len(listenerFromDB))
+	for _, one := range listenerFromDB {
+		listenerIDMap[one.CloudID] = one.ID
+	}
+
+	targetFromDB, err := listLbTargetFromDB(kt, dataCli, opt.LbID)
+	if err != nil {
+		return err
+	}
+
+	addSlice, updateMap, delCloudIDs := Diff[typelb.Target, corelb.Target](targets, targetFromDB,
+		func(cloud typelb.Target, db corelb.Target) bool {
+			return listenerIDMap[cloud.CloudListenerID] != db.ListenerID || cloud.IP != db.IP ||
+				cloud.Weight != db.Weight
+		})
+
+	if len(delCloudIDs) > 0 {
+		delReq := &datalb.TargetBatchDeleteReq{
+			Filter: lbResInExpr(opt.LbID, delCloudIDs),
+		}
+		if err = dataCli.Global.LoadBalancer.BatchDeleteTarget(kt, delReq); err != nil {
+			logs.Errorf("[%s] request dataservice to delete target failed, err: %v, lb: %s, rid: %s", opt.Vendor,
+				err, opt.LbID, kt.Rid)
+			return err
+		}
+	}
+
+	for _, batch := range slice.Split(addSlice, constant.BatchOperationMaxLimit) {
+		createReq := &datalb.TargetBatchCreateReq{Targets: make([]datalb.TargetBatchCreate, 0, len(batch))}
+		for _, one := range batch {
+			createReq.Targets = append(createReq.Targets, datalb.TargetBatchCreate{
+				CloudID:            one.GetCloudID(),
+				Vendor:             opt.Vendor,
+				AccountID:          opt.AccountID,
+				LbID:               opt.LbID,
+				CloudLbID:          opt.CloudLbID,
+				ListenerID:         listenerIDMap[one.CloudListenerID],
+				CloudListenerID:    one.CloudListenerID,
+				CloudTargetGroupID: one.CloudTargetGroupID,
+				InstType:           one.InstType,
+				CloudInstID:        one.CloudInstID,
+				IP:                 one.IP,
+				Port:               one.Port,
+				Weight:             one.Weight,
+			})
+		}
+		if _, err = dataCli.Global.LoadBalancer.BatchCreateTarget(kt, createReq); err != nil {
+			logs.Errorf("[%s] request dataservice to create target failed, err: %v, lb: %s, rid: %s", opt.Vendor,
+				err, opt.LbID, kt.Rid)
+			return err
+		}
+	}
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	updates := make([]datalb.TargetBatchUpdate, 0, len(updateMap))
+	for id, one := range updateMap {
+		updates = append(updates, datalb.TargetBatchUpdate{
+			ID:         id,
+			ListenerID: listenerIDMap[one.CloudListenerID],
+			IP:         one.IP,
+			Weight:     one.Weight,
+		})
+	}
+	for _, batch := range slice.Split(updates, constant.BatchOperationMaxLimit) {
+		if err = dataCli.Global.LoadBalancer.BatchUpdateTarget(kt,
+			&datalb.TargetBatchUpdateReq{Targets: batch}); err != nil {
+
+			logs.Errorf("[%s] request dataservice to update target failed, err: %v, lb: %s, rid: %s", opt.Vendor,
+				err, opt.LbID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func listLbListenerFromDB(kt *kit.Kit, dataCli *dataclient.Client, lbID string) ([]corelb.Listener, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("lb_id", lbID),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	results := make([]corelb.Listener, 0)
+	for {
+		result, err := dataCli.Global.LoadBalancer.ListListener(kt, req)
+		if err != nil {
+			logs.Errorf("list listener from db failed, err: %v, lb: %s, rid: %s", err, lbID, kt.Rid)
+			return nil, err
+		}
+
+		results = append(results, result.Details...)
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return results, nil
+}
+
+func listLbTargetFromDB(kt *kit.Kit, dataCli *dataclient.Client, lbID string) ([]corelb.Target, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("lb_id", lbID),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	results := make([]corelb.Target, 0)
+	for {
+		result, err := dataCli.Global.LoadBalancer.ListTarget(kt, req)
+		if err != nil {
+			logs.Errorf("list target from db failed, err: %v, lb: %s, rid: %s", err, lbID, kt.Rid)
+			return nil, err
+		}
+
+		results = append(results, result.Details...)
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return results, nil
+}
+
+func lbResInExpr(lbID string, cloudIDs []string) *filter.Expression {
+	return &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "lb_id", Op: filter.Equal.Factory(), Value: lbID},
+			&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+		},
+	}
+}
+
+func isLbListenerChange(cloud typelb.Listener, db corelb.Listener) bool {
+	if cloud.Name != db.Name || cloud.Protocol != db.Protocol || cloud.Port != db.Port {
+		return true
+	}
+
+	if cloud.CloudTargetGroupID != db.CloudTargetGroupID {
+		return true
+	}
+
+	if cloud.Extension == nil || db.Extension == nil {
+		return cloud.Extension != db.Extension
+	}
+
+	if !assert.IsPtrStringEqual(cloud.Extension.Scheduler, db.Extension.Scheduler) {
+		return true
+	}
+
+	if !assert.IsPtrInt64Equal(cloud.Extension.SessionExpireTime, db.Extension.SessionExpireTime) {
+		return true
+	}
+
+	if !assert.IsPtrInt64Equal(cloud.Extension.EndPort, db.Extension.EndPort) {
+		return true
+	}
+
+	return !assert.IsStringSliceEqual(cloud.Extension.CloudCertificateIDs, db.Extension.CloudCertificateIDs)
+}
+
+// LbDeleteReqByCloudIDs return load balancer delete request by cloud ids, listeners and targets will be deleted
+// together.
+func LbDeleteReqByCloudIDs(accountID string, cloudIDs []string) (*datalb.LoadBalancerBatchDeleteReq, error) {
+	if len(cloudIDs) == 0 {
+		return nil, fmt.Errorf("delete load balancer, cloudIDs is required")
+	}
+
+	return &datalb.LoadBalancerBatchDeleteReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+			},
+		},
+	}, nil
+}
+
+// IsLbBaseChange 对比负载均衡公共字段是否变更
+func IsLbBaseChange(cloud typelb.BaseLoadBalancer, db corelb.BaseLoadBalancer) bool {
+	if cloud.Name != db.Name || cloud.LBType != db.LBType || cloud.IPVersion != db.IPVersion {
+		return true
+	}
+
+	if cloud.CloudVpcID != db.CloudVpcID || cloud.CloudSubnetID != db.CloudSubnetID {
+		return true
+	}
+
+	if cloud.Domain != db.Domain || cloud.Status != db.Status {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.Zones, db.Zones) {
+		return true
+	}
+
+	if !assert.IsStringSliceEqual(cloud.PrivateIPv4Addresses, db.PrivateIPv4Addresses) {
+		return true
+	}
+
+	return !assert.IsStringSliceEqual(cloud.PublicIPv4Addresses, db.PublicIPv4Addresses)
+}
+
+// IsLbExtensionChange 对比负载均衡扩展字段是否变更，扩展字段以json存储，空字段会被忽略，所以按json序列化结果对比
+func IsLbExtensionChange[T corelb.Extension](cloud, db *T) bool {
+	cloudJson, err := json.Marshal(cloud)
+	if err != nil {
+		return true
+	}
+
+	dbJson, err := json.Marshal(db)
+	if err != nil {
+		return true
+	}
+
+	return string(cloudJson) != string(dbJson)
+}
+
+// GetLbVpcAndSubnetIDMap 根据负载均衡的云上vpc和子网ID，获取本地vpc和子网ID，返回 云ID->本地ID 映射
+func GetLbVpcAndSubnetIDMap(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor,
+	lbs []typelb.BaseLoadBalancer) (map[string]string, map[string]string, error) {
+
+	cloudVpcIDs := make([]string, 0)
+	cloudSubnetIDs := make([]string, 0)
+	for _, one := range lbs {
+		if len(one.CloudVpcID) != 0 {
+			cloudVpcIDs = append(cloudVpcIDs, one.CloudVpcID)
+		}
+		if len(one.CloudSubnetID) != 0 {
+			cloudSubnetIDs = append(cloudSubnetIDs, one.CloudSubnetID)
+		}
+	}
+
+	return GetVpcAndSubnetIDMap(kt, dataCli, vendor, cloudVpcIDs, cloudSubnetIDs)
+}
+
+// GetVpcAndSubnetIDMap 根据云上vpc和子网ID，获取本地vpc和子网ID，返回 云ID->本地ID 映射
+func GetVpcAndSubnetIDMap(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor, cloudVpcIDs,
+	cloudSubnetIDs []string) (map[string]string, map[string]string, error) {
+
+	vpcMap := make(map[string]string)
+	for _, batch := range slice.Split(slice.Unique(cloudVpcIDs), constant.BatchOperationMaxLimit) {
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id"},
+			Filter: lbVendorCloudIDsExpr(vendor, batch),
+			Page:   core.NewDefaultBasePage(),
+		}
+		result, err := dataCli.Global.Vpc.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] list vpc by cloud ids failed, err: %v, rid: %s", vendor, err, kt.Rid)
+			return nil, nil, err
+		}
+
+		for _, one := range result.Details {
+			vpcMap[one.CloudID] = one.ID
+		}
+	}
+
+	subnetMap := make(map[string]string)
+	for _, batch := range slice.Split(slice.Unique(cloudSubnetIDs), constant.BatchOperationMaxLimit) {
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id"},
+			Filter: lbVendorCloudIDsExpr(vendor, batch),
+			Page:   core.NewDefaultBasePage(),
+		}
+		result, err := dataCli.Global.Subnet.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("[%s] list subnet by cloud ids failed, err: %v, rid: %s", vendor, err, kt.Rid)
+			return nil, nil, err
+		}
+
+		for _, one := range result.Details {
+			subnetMap[one.CloudID] = one.ID
+		}
+	}
+
+	return vpcMap, subnetMap, nil
+}
+
+func lbVendorCloudIDsExpr(vendor enumor.Vendor, cloudIDs []string) *filter.Expression {
+	return &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+			&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+		},
+	}
+}
@@ -0,0 +1,261 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	datanat "hcm/pkg/api/data-service/cloud/nat-gateway"
+	dataclient "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/assert"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncNatRuleOption sync nat gateway snat/dnat rule option.
+type SyncNatRuleOption struct {
+	Vendor            enumor.Vendor
+	AccountID         string
+	NatGatewayID      string
+	CloudNatGatewayID string
+}
+
+// SyncNatGatewayRule 以云上规则为准，同步NAT网关下的SNAT/DNAT规则。
+func SyncNatGatewayRule(kt *kit.Kit, dataCli *dataclient.Client, opt *SyncNatRuleOption,
+	rules []typenat.NatRule) error {
+
+	ruleFromDB, err := listNatRuleFromDB(kt, dataCli, opt.NatGatewayID)
+	if err != nil {
+		return err
+	}
+
+	addSlice, updateMap, delCloudIDs := Diff[typenat.NatRule, corenat.NatGatewayRule](rules, ruleFromDB,
+		isNatRuleChange)
+
+	if len(delCloudIDs) > 0 {
+		delReq := &datanat.NatGatewayRuleBatchDeleteReq{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "nat_gateway_id", Op: filter.Equal.Factory(), Value: opt.NatGatewayID},
+					&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: delCloudIDs},
+				},
+			},
+		}
+		if err = dataCli.Global.NatGateway.BatchDeleteNatGatewayRule(kt, delReq); err != nil {
+			logs.Errorf("[%s] request dataservice to delete nat rule failed, err: %v, nat: %s, rid: %s",
+				opt.Vendor, err, opt.NatGatewayID, kt.Rid)
+			return err
+		}
+	}
+
+	for _, batch := range slice.Split(addSlice, constant.BatchOperationMaxLimit) {
+		createReq := &datanat.NatGatewayRuleBatchCreateReq{
+			Rules: make([]datanat.NatGatewayRuleBatchCreate, 0, len(batch)),
+		}
+		for _, one := range batch {
+			createReq.Rules = append(createReq.Rules, datanat.NatGatewayRuleBatchCreate{
+				CloudID:           one.CloudID,
+				Vendor:            opt.Vendor,
+				AccountID:         opt.AccountID,
+				NatGatewayID:      opt.NatGatewayID,
+				CloudNatGatewayID: opt.CloudNatGatewayID,
+				RuleType:          one.RuleType,
+				Protocol:          one.Protocol,
+				CloudSubnetID:     one.CloudSubnetID,
+				SourceCidr:        one.SourceCidr,
+				PublicIP:          one.PublicIP,
+				PublicPort:        one.PublicPort,
+				PrivateIP:         one.PrivateIP,
+				PrivatePort:       one.PrivatePort,
+				Status:            one.Status,
+				Memo:              one.Memo,
+				Extension:         one.Extension,
+			})
+		}
+		if _, err = dataCli.Global.NatGateway.BatchCreateNatGatewayRule(kt, createReq); err != nil {
+			logs.Errorf("[%s] request dataservice to create nat rule failed, err: %v, nat: %s, rid: %s",
+				opt.Vendor, err, opt.NatGatewayID, kt.Rid)
+			return err
+		}
+	}
+
+	if len(updateMap) == 0 {
+		return nil
+	}
+
+	updates := make([]datanat.NatGatewayRuleBatchUpdate, 0, len(updateMap))
+	for id, one := range updateMap {
+		updates = append(updates, datanat.NatGatewayRuleBatchUpdate{
+			ID:            id,
+			Protocol:      one.Protocol,
+			CloudSubnetID: one.CloudSubnetID,
+			SourceCidr:    one.SourceCidr,
+			PublicIP:      one.PublicIP,
+			PublicPort:    one.PublicPort,
+			PrivateIP:     one.PrivateIP,
+			PrivatePort:   one.PrivatePort,
+			Status:        one.Status,
+			Memo:          one.Memo,
+			Extension:     one.Extension,
+		})
+	}
+	for _, batch := range slice.Split(updates, constant.BatchOperationMaxLimit) {
+		if err = dataCli.Global.NatGateway.BatchUpdateNatGatewayRule(kt,
+			&datanat.NatGatewayRuleBatchUpdateReq{Rules: batch}); err != nil {
+
+			logs.Errorf("[%s] request dataservice to update nat rule failed, err: %v, nat: %s, rid: %s",
+				opt.Vendor, err, opt.NatGatewayID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func listNatRuleFromDB(kt *kit.Kit, dataCli *dataclient.Client, natID string) ([]corenat.NatGatewayRule, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("nat_gateway_id", natID),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	results := make([]corenat.NatGatewayRule, 0)
+	for {
+		result, err := dataCli.Global.NatGateway.ListNatGatewayRule(kt, req)
+		if err != nil {
+			logs.Errorf("list nat rule from db failed, err: %v, nat: %s, rid: %s", err, natID, kt.Rid)
+			return nil, err
+		}
+
+		results = append(results, result.Details...)
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return results, nil
+}
+
+func isNatRuleChange(cloud typenat.NatRule, db corenat.NatGatewayRule) bool {
+	if cloud.Protocol != db.Protocol || cloud.CloudSubnetID != db.CloudSubnetID || cloud.SourceCidr != db.SourceCidr {
+		return true
+	}
+
+	if cloud.PublicIP != db.PublicIP || cloud.PublicPort != db.PublicPort {
+		return true
+	}
+
+	if cloud.PrivateIP != db.PrivateIP || cloud.PrivatePort != db.PrivatePort || cloud.Status != db.Status {
+		return true
+	}
+
+	if !assert.IsPtrStringEqual(cloud.Memo, db.Memo) {
+		return true
+	}
+
+	cloudJson, err := json.Marshal(cloud.Extension)
+	if err != nil {
+		return true
+	}
+
+	dbJson, err := json.Marshal(db.Extension)
+	if err != nil {
+		return true
+	}
+
+	return string(cloudJson) != string(dbJson)
+}
+
+// NatGatewayDeleteReqByCloudIDs return nat gateway delete request by cloud ids, rules will be deleted together.
+func NatGatewayDeleteReqByCloudIDs(accountID string, cloudIDs []string) (*datanat.NatGatewayBatchDeleteReq, error) {
+	if len(cloudIDs) == 0 {
+		return nil, fmt.Errorf("delete nat gateway, cloudIDs is required")
+	}
+
+	return &datanat.NatGatewayBatchDeleteReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+			},
+		},
+	}, nil
+}
+
+// IsNatGatewayBaseChange 对比NAT网关公共字段是否变更
+func IsNatGatewayBaseChange(cloud typenat.BaseNatGateway, db corenat.BaseNatGateway) bool {
+	if cloud.Name != db.Name || cloud.Zone != db.Zone || cloud.Spec != db.Spec || cloud.Status != db.Status {
+		return true
+	}
+
+	if cloud.CloudVpcID != db.CloudVpcID || cloud.CloudSubnetID != db.CloudSubnetID {
+		return true
+	}
+
+	if cloud.Memo != nil && !assert.IsPtrStringEqual(cloud.Memo, db.Memo) {
+		return true
+	}
+
+	return !assert.IsStringSliceEqual(cloud.PublicIPAddresses, db.PublicIPAddresses)
+}
+
+// IsNatGatewayExtensionChange 对比NAT网关扩展字段是否变更，按json序列化结果对比
+func IsNatGatewayExtensionChange[T corenat.Extension](cloud, db *T) bool {
+	cloudJson, err := json.Marshal(cloud)
+	if err != nil {
+		return true
+	}
+
+	dbJson, err := json.Marshal(db)
+	if err != nil {
+		return true
+	}
+
+	return string(cloudJson) != string(dbJson)
+}
+
+// GetNatVpcAndSubnetIDMap 根据NAT网关的云上vpc和子网ID，获取本地vpc和子网ID，返回 云ID->本地ID 映射
+func GetNatVpcAndSubnetIDMap(kt *kit.Kit, dataCli *dataclient.Client, vendor enumor.Vendor,
+	nats []typenat.BaseNatGateway) (map[string]string, map[string]string, error) {
+
+	cloudVpcIDs := make([]string, 0)
+	cloudSubnetIDs := make([]string, 0)
+	for _, one := range nats {
+		if len(one.CloudVpcID) != 0 {
+			cloudVpcIDs = append(cloudVpcIDs, one.CloudVpcID)
+		}
+		if len(one.CloudSubnetID) != 0 {
+			cloudSubnetIDs = append(cloudSubnetIDs, one.CloudSubnetID)
+		}
+	}
+
+	return GetVpcAndSubnetIDMap(kt, dataCli, vendor, cloudVpcIDs, cloudSubnetIDs)
+}
@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
+	datatag "hcm/pkg/api/data-service/cloud/resource-tag"
+	dataclient "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncResTagOption sync resource tag option.
+type SyncResTagOption struct {
+	Vendor    enumor.Vendor
+	AccountID string
+	ResType   enumor.CloudResourceType
+}
+
+// SyncResTags 以云上资源标签为准，全量覆盖资源标签表中这些资源的标签，需要在资源本身同步完成后调用
+func SyncResTags[T coretag.TagResource](kt *kit.Kit, dataCli *dataclient.Client, opt *SyncResTagOption,
+	resources []T) error {
+
+	if len(resources) == 0 {
+		return nil
+	}
+
+	items := make([]datatag.ResTagReplaceItem, 0, len(resources))
+	for _, one := range resources {
+		items = append(items, datatag.ResTagReplaceItem{
+			CloudResID: one.GetCloudID(),
+			Tags:       one.GetTags(),
+		})
+	}
+
+	for _, batch := range slice.Split(items, constant.BatchOperationMaxLimit) {
+		req := &datatag.ResTagBatchReplaceReq{
+			Vendor:    opt.Vendor,
+			AccountID: opt.AccountID,
+			ResType:   opt.ResType,
+			Resources: batch,
+		}
+		if err := dataCli.Global.ResourceTag.BatchReplaceResourceTag(kt, req); err != nil {
+			logs.Errorf("[%s] request dataservice to replace %s tags failed, err: %v, account: %s, rid: %s",
+				opt.Vendor, opt.ResType, err, opt.AccountID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+
+	typesnapshot "hcm/pkg/adaptor/types/snapshot"
+	"hcm/pkg/api/core"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	datasnapshot "hcm/pkg/api/data-service/cloud/snapshot"
+	dataclient "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// SnapshotDeleteReqByCloudIDs return snapshot delete request by cloud ids.
+func SnapshotDeleteReqByCloudIDs(accountID string, cloudIDs []string) (*datasnapshot.SnapshotBatchDeleteReq, error) {
+	if len(cloudIDs) == 0 {
+		return nil, fmt.Errorf("delete snapshot, cloudIDs is required")
+	}
+
+	return &datasnapshot.SnapshotBatchDeleteReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+			},
+		},
+	}, nil
+}
+
+// IsSnapshotBaseChange 对比快照公共字段是否变更
+func IsSnapshotBaseChange(cloud typesnapshot.BaseSnapshot, db coresnapshot.BaseSnapshot) bool {
+	if cloud.Name != db.Name || cloud.Status != db.Status || cloud.Size != db.Size {
+		return true
+	}
+
+	return cloud.CloudDiskID != db.CloudDiskID
+}
+
+// IsSnapshotExtensionChange 对比快照扩展字段是否变更，按json序列化结果对比
+func IsSnapshotExtensionChange[T coresnapshot.Extension](cloud, db *T) bool {
+	cloudJson, err := json.Marshal(cloud)
+	if err != nil {
+		return true
+	}
+
+	dbJson, err := json.Marshal(db)
+	if err != nil {
+		return true
+	}
+
+	return string(cloudJson) != string(dbJson)
+}
+
+// SnapshotSyncOption 快照同步写入DB时的公共参数
+type SnapshotSyncOption struct {
+	Vendor    enumor.Vendor
+	AccountID string
+	// BkBizID 快照创建时指定的业务，未指定时使用源云盘所属业务
+	BkBizID int64
+	// PolicyID 由快照策略创建的快照，需要记录策略ID，用于按保留数量清理
+	PolicyID string
+}
+
+// GetSnapshotDiskMap 根据快照的源云盘云ID，查询本地云盘，返回 云盘云ID->云盘 映射
+func GetSnapshotDiskMap(kt *kit.Kit, dataCli *dataclient.Client, opt *SnapshotSyncOption,
+	snapshots []typesnapshot.BaseSnapshot) (map[string]coredisk.BaseDisk, error) {
+
+	cloudDiskIDs := make([]string, 0, len(snapshots))
+	for _, one := range snapshots {
+		if len(one.CloudDiskID) != 0 {
+			cloudDiskIDs = append(cloudDiskIDs, one.CloudDiskID)
+		}
+	}
+
+	diskMap := make(map[string]coredisk.BaseDisk)
+	for _, batch := range slice.Split(slice.Unique(cloudDiskIDs), constant.BatchOperationMaxLimit) {
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id", "bk_biz_id"},
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: opt.Vendor},
+					&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: opt.AccountID},
+					&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: batch},
+				},
+			},
+			Page: core.NewDefaultBasePage(),
+		}
+		result, err := dataCli.Global.ListDisk(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] list disk for snapshot failed, err: %v, rid: %s", opt.Vendor, err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			diskMap[one.CloudID] = *one
+		}
+	}
+
+	return diskMap, nil
+}
+
+// BuildSnapshotCreate 根据云上快照构造DB创建参数，业务优先使用指定业务，其次使用源云盘所属业务
+func BuildSnapshotCreate[Ext coresnapshot.Extension](opt *SnapshotSyncOption, diskMap map[string]coredisk.BaseDisk,
+	one typesnapshot.BaseSnapshot, ext *Ext) datasnapshot.SnapshotBatchCreate[Ext] {
+
+	disk := diskMap[one.CloudDiskID]
+	bizID := opt.BkBizID
+	if bizID == 0 {
+		bizID = disk.BkBizID
+	}
+	if bizID == 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	return datasnapshot.SnapshotBatchCreate[Ext]{
+		CloudID:          one.CloudID,
+		Name:             one.Name,
+		AccountID:        opt.AccountID,
+		BkBizID:          bizID,
+		Region:           one.Region,
+		Zone:             one.Zone,
+		CloudDiskID:      one.CloudDiskID,
+		DiskID:           disk.ID,
+		Size:             one.Size,
+		Status:           one.Status,
+		PolicyID:         opt.PolicyID,
+		CloudCreatedTime: one.CloudCreatedTime,
+		Extension:        ext,
+	}
+}
+
+// BuildSnapshotUpdate 根据云上快照构造DB更新参数，已分配的业务不会被同步覆盖
+func BuildSnapshotUpdate[Ext coresnapshot.Extension](opt *SnapshotSyncOption, diskMap map[string]coredisk.BaseDisk,
+	id string, one typesnapshot.BaseSnapshot, ext *Ext) datasnapshot.SnapshotBatchUpdate[Ext] {
+
+	return datasnapshot.SnapshotBatchUpdate[Ext]{
+		ID:        id,
+		Name:      one.Name,
+		DiskID:    diskMap[one.CloudDiskID].ID,
+		Size:      one.Size,
+		Status:    one.Status,
+		PolicyID:  opt.PolicyID,
+		Extension: ext,
+	}
+}
@@ -92,6 +92,11 @@ func (cli *client) Subnet(kt *kit.Kit, params *SyncBaseParams, opt *SyncSubnetOp
 		}
 	}
 
+	tagOpt := &common.SyncResTagOption{Vendor: enumor.Gcp, AccountID: params.AccountID, ResType: enumor.SubnetCloudResType}
+	if err = common.SyncResTags(kt, cli.dbCli, tagOpt, subnetFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
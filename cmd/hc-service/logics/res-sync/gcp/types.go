@@ -60,6 +60,7 @@ func (opt ListBySelfLinkOption) Validate() error {
 
 // SyncResult sync result.
 type SyncResult struct {
+	CreatedIds []string
 }
 
 // QueryVpcsAndSyncOption ...
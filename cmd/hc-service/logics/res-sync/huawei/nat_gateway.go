@@ -0,0 +1,402 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	"hcm/cmd/hc-service/logics/res-sync/common"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	datanat "hcm/pkg/api/data-service/cloud/nat-gateway"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+)
+
+// SyncNatGatewayOption ...
+type SyncNatGatewayOption struct {
+	// BkBizID NAT网关创建时，通过同步写入DB，需要传入业务ID
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt SyncNatGatewayOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// NatGateway 同步NAT网关，以及NAT网关下的SNAT/DNAT规则
+func (cli *client) NatGateway(kt *kit.Kit, params *SyncBaseParams, opt *SyncNatGatewayOption) (*SyncResult,
+	error) {
+
+	if err := validator.ValidateTool(params, opt); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	natFromCloud, err := cli.listNatFromCloud(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	natFromDB, err := cli.listNatFromDB(kt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(natFromCloud) == 0 && len(natFromDB) == 0 {
+		return new(SyncResult), nil
+	}
+
+	addSlice, updateMap, delCloudIDs := common.Diff[typenat.HuaWeiNatGateway,
+		corenat.NatGateway[corenat.HuaWeiNatGatewayExtension]](natFromCloud, natFromDB, isNatChange)
+
+	if len(delCloudIDs) > 0 {
+		if err = cli.deleteNat(kt, params.AccountID, params.Region, delCloudIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(addSlice) > 0 {
+		if _, err = cli.createNat(kt, params.AccountID, addSlice, opt.BkBizID); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updateMap) > 0 {
+		if err = cli.updateNat(kt, params.AccountID, updateMap); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(natFromCloud) > 0 {
+		if err = cli.syncNatRule(kt, params); err != nil {
+			return nil, err
+		}
+	}
+
+	return new(SyncResult), nil
+}
+
+// syncNatRule 同步NAT网关下的SNAT/DNAT规则
+func (cli *client) syncNatRule(kt *kit.Kit, params *SyncBaseParams) error {
+	natFromDB, err := cli.listNatFromDB(kt, params)
+	if err != nil {
+		return err
+	}
+
+	for _, nat := range natFromDB {
+		rules, err := cli.cloudCli.ListNatRule(kt, &typenat.RuleListOption{
+			Region:            nat.Region,
+			CloudNatGatewayID: nat.CloudID,
+		})
+		if err != nil {
+			logs.Errorf("[%s] list nat rule from cloud failed, err: %v, nat: %s, rid: %s", enumor.HuaWei, err,
+				nat.CloudID, kt.Rid)
+			return err
+		}
+
+		ruleOpt := &common.SyncNatRuleOption{
+			Vendor:            enumor.HuaWei,
+			AccountID:         params.AccountID,
+			NatGatewayID:      nat.ID,
+			CloudNatGatewayID: nat.CloudID,
+		}
+		if err = common.SyncNatGatewayRule(kt, cli.dbCli, ruleOpt, rules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveNatGatewayDeleteFromCloud ...
+func (cli *client) RemoveNatGatewayDeleteFromCloud(kt *kit.Kit, accountID string, region string) error {
+	req := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: accountID},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: region},
+			},
+		},
+		Page: &core.BasePage{
+			Start: 0,
+			Limit: constant.BatchOperationMaxLimit,
+		},
+	}
+	for {
+		resultFromDB, err := cli.dbCli.Global.NatGateway.ListNatGateway(kt, req)
+		if err != nil {
+			logs.Errorf("[%s] request dataservice to list nat gateway failed, err: %v, req: %v, rid: %s",
+				enumor.HuaWei, err, req, kt.Rid)
+			return err
+		}
+
+		cloudIDs := make([]string, 0)
+		for _, one := range resultFromDB.Details {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+
+		if len(cloudIDs) == 0 {
+			break
+		}
+
+		params := &SyncBaseParams{
+			AccountID: accountID,
+			Region:    region,
+			CloudIDs:  cloudIDs,
+		}
+		resultFromCloud, err := cli.listNatFromCloud(kt, params)
+		if err != nil {
+			return err
+		}
+
+		// 如果有资源没有查询出来，说明数据被从云上删除
+		if len(resultFromCloud) != len(cloudIDs) {
+			cloudIDMap := converter.StringSliceToMap(cloudIDs)
+			for _, one := range resultFromCloud {
+				delete(cloudIDMap, one.CloudID)
+			}
+
+			delCloudIDs := converter.MapKeyToStringSlice(cloudIDMap)
+			if err = cli.deleteNat(kt, accountID, region, delCloudIDs); err != nil {
+				return err
+			}
+		}
+
+		if len(resultFromDB.Details) < constant.BatchOperationMaxLimit {
+			break
+		}
+
+		req.Page.Start += constant.BatchOperationMaxLimit
+	}
+
+	return nil
+}
+
+func (cli *client) deleteNat(kt *kit.Kit, accountID string, region string, delCloudIDs []string) error {
+	if len(delCloudIDs) == 0 {
+		return fmt.Errorf("delete nat gateway, cloudIDs is required")
+	}
+
+	checkParams := &SyncBaseParams{
+		AccountID: accountID,
+		Region:    region,
+		CloudIDs:  delCloudIDs,
+	}
+	delNatFromCloud, err := cli.listNatFromCloud(kt, checkParams)
+	if err != nil {
+		return err
+	}
+
+	if len(delNatFromCloud) > 0 {
+		logs.Errorf("[%s] validate nat gateway not exist failed, before delete, opt: %v, failed_count: %d, "+
+			"rid: %s", enumor.HuaWei, checkParams, len(delNatFromCloud), kt.Rid)
+		return fmt.Errorf("validate nat gateway not exist failed, before delete")
+	}
+
+	deleteReq, err := common.NatGatewayDeleteReqByCloudIDs(accountID, delCloudIDs)
+	if err != nil {
+		return err
+	}
+	if err = cli.dbCli.Global.NatGateway.BatchDeleteNatGateway(kt, deleteReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch delete nat gateway failed, err: %v, rid: %s",
+			enumor.HuaWei, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync nat gateway to delete nat gateway success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(delCloudIDs), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) updateNat(kt *kit.Kit, accountID string,
+	updateMap map[string]typenat.HuaWeiNatGateway) error {
+
+	if len(updateMap) == 0 {
+		return fmt.Errorf("update nat gateway, nat gateways is required")
+	}
+
+	nats := make([]typenat.BaseNatGateway, 0, len(updateMap))
+	for _, one := range updateMap {
+		nats = append(nats, one.BaseNatGateway)
+	}
+	vpcMap, subnetMap, err := common.GetNatVpcAndSubnetIDMap(kt, cli.dbCli, enumor.HuaWei, nats)
+	if err != nil {
+		return err
+	}
+
+	updateReq := &datanat.NatGatewayBatchUpdateReq[corenat.HuaWeiNatGatewayExtension]{
+		NatGateways: make([]datanat.NatGatewayBatchUpdate[corenat.HuaWeiNatGatewayExtension], 0,
+			len(updateMap)),
+	}
+	for id, one := range updateMap {
+		updateReq.NatGateways = append(updateReq.NatGateways,
+			datanat.NatGatewayBatchUpdate[corenat.HuaWeiNatGatewayExtension]{
+				ID:                id,
+				Name:              one.Name,
+				Zone:              one.Zone,
+				CloudVpcID:        one.CloudVpcID,
+				VpcID:             vpcMap[one.CloudVpcID],
+				CloudSubnetID:     one.CloudSubnetID,
+				SubnetID:          subnetMap[one.CloudSubnetID],
+				Spec:              one.Spec,
+				PublicIPAddresses: one.PublicIPAddresses,
+				Memo:              one.Memo,
+				Status:            one.Status,
+				Extension:         one.Extension,
+			})
+	}
+
+	if err = cli.dbCli.HuaWei.BatchUpdateNatGateway(kt, updateReq); err != nil {
+		logs.Errorf("[%s] request dataservice to batch update nat gateway failed, err: %v, rid: %s",
+			enumor.HuaWei, err, kt.Rid)
+		return err
+	}
+
+	logs.Infof("[%s] sync nat gateway to update nat gateway success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(updateMap), kt.Rid)
+
+	return nil
+}
+
+func (cli *client) createNat(kt *kit.Kit, accountID string, addSlice []typenat.HuaWeiNatGateway,
+	bizID int64) ([]string, error) {
+
+	if len(addSlice) == 0 {
+		return nil, fmt.Errorf("create nat gateway, nat gateways is required")
+	}
+
+	nats := make([]typenat.BaseNatGateway, 0, len(addSlice))
+	for _, one := range addSlice {
+		nats = append(nats, one.BaseNatGateway)
+	}
+	vpcMap, subnetMap, err := common.GetNatVpcAndSubnetIDMap(kt, cli.dbCli, enumor.HuaWei, nats)
+	if err != nil {
+		return nil, err
+	}
+
+	if bizID == 0 {
+		bizID = constant.UnassignedBiz
+	}
+
+	createReq := &datanat.NatGatewayBatchCreateReq[corenat.HuaWeiNatGatewayExtension]{
+		NatGateways: make([]datanat.NatGatewayBatchCreate[corenat.HuaWeiNatGatewayExtension], 0,
+			len(addSlice)),
+	}
+	for _, one := range addSlice {
+		createReq.NatGateways = append(createReq.NatGateways,
+			datanat.NatGatewayBatchCreate[corenat.HuaWeiNatGatewayExtension]{
+				CloudID:           one.CloudID,
+				Name:              one.Name,
+				AccountID:         accountID,
+				BkBizID:           bizID,
+				Region:            one.Region,
+				Zone:              one.Zone,
+				CloudVpcID:        one.CloudVpcID,
+				VpcID:             vpcMap[one.CloudVpcID],
+				CloudSubnetID:     one.CloudSubnetID,
+				SubnetID:          subnetMap[one.CloudSubnetID],
+				Spec:              one.Spec,
+				PublicIPAddresses: one.PublicIPAddresses,
+				Memo:              one.Memo,
+				Status:            one.Status,
+				CloudCreatedTime:  one.CloudCreatedTime,
+				Extension:         one.Extension,
+			})
+	}
+
+	result, err := cli.dbCli.HuaWei.BatchCreateNatGateway(kt, createReq)
+	if err != nil {
+		logs.Errorf("[%s] request dataservice to batch create nat gateway failed, err: %v, rid: %s",
+			enumor.HuaWei, err, kt.Rid)
+		return nil, err
+	}
+
+	logs.Infof("[%s] sync nat gateway to create nat gateway success, accountID: %s, count: %d, rid: %s",
+		enumor.HuaWei, accountID, len(addSlice), kt.Rid)
+
+	return result.IDs, nil
+}
+
+func (cli *client) listNatFromCloud(kt *kit.Kit, params *SyncBaseParams) ([]typenat.HuaWeiNatGateway, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &typenat.HuaWeiListOption{
+		Region:   params.Region,
+		CloudIDs: params.CloudIDs,
+	}
+	result, err := cli.cloudCli.ListNatGateway(kt, opt)
+	if err != nil {
+		logs.Errorf("[%s] list nat gateway from cloud failed, err: %v, account: %s, opt: %v, rid: %s",
+			enumor.HuaWei, err, params.AccountID, opt, kt.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cli *client) listNatFromDB(kt *kit.Kit, params *SyncBaseParams) (
+	[]corenat.NatGateway[corenat.HuaWeiNatGatewayExtension], error) {
+
+	if err := params.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "account_id", Op: filter.Equal.Factory(), Value: params.AccountID},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: params.CloudIDs},
+				&filter.AtomRule{Field: "region", Op: filter.Equal.Factory(), Value: params.Region},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := cli.dbCli.HuaWei.ListNatGatewayExt(kt, req)
+	if err != nil {
+		logs.Errorf("[%s] list nat gateway from db failed, err: %v, account: %s, req: %v, rid: %s",
+			enumor.HuaWei, err, params.AccountID, req, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+func isNatChange(cloud typenat.HuaWeiNatGateway,
+	db corenat.NatGateway[corenat.HuaWeiNatGatewayExtension]) bool {
+
+	if common.IsNatGatewayBaseChange(cloud.BaseNatGateway, db.BaseNatGateway) {
+		return true
+	}
+
+	return common.IsNatGatewayExtensionChange(cloud.Extension, db.Extension)
+}
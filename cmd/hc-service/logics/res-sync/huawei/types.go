@@ -47,4 +47,5 @@ func (opt SyncBaseParams) Validate() error {
 
 // SyncResult sync result.
 type SyncResult struct {
+	CreatedIds []string
 }
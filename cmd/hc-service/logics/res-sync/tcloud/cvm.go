@@ -92,6 +92,11 @@ func (cli *client) Cvm(kt *kit.Kit, params *SyncBaseParams, opt *SyncCvmOption)
 		}
 	}
 
+	tagOpt := &common.SyncResTagOption{Vendor: enumor.TCloud, AccountID: params.AccountID, ResType: enumor.CvmCloudResType}
+	if err = common.SyncResTags(kt, cli.dbCli, tagOpt, cvmFromCloud); err != nil {
+		return nil, err
+	}
+
 	return new(SyncResult), nil
 }
 
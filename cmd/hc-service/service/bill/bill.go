@@ -52,6 +52,7 @@ func InitBillService(cap *capability.Capability) {
 	h.Add("HuaWeiGetBillList", "POST", "/vendors/huawei/bills/list", v.HuaWeiGetBillList)
 	h.Add("AzureGetBillList", "POST", "/vendors/azure/bills/list", v.AzureGetBillList)
 	h.Add("GcpGetBillList", "POST", "/vendors/gcp/bills/list", v.GcpGetBillList)
+	h.Add("PullDailyBill", "POST", "/vendors/{vendor}/bills/daily/pull", v.PullDailyBill)
 
 	h.Load(cap.WebService)
 }
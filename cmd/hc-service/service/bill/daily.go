@@ -0,0 +1,437 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	typesBill "hcm/pkg/adaptor/types/bill"
+	"hcm/pkg/adaptor/types/core"
+	apicore "hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	hcbillservice "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/math"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/consumption/armconsumption"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// awsBillDailyPageLimit aws athena单次查询结果最多返回1000行
+	awsBillDailyPageLimit = 1000
+	// tcloudBillCurrency 腾讯云账单明细中不返回币种，国内站账单统一为人民币
+	tcloudBillCurrency = "CNY"
+)
+
+// PullDailyBill 拉取资源账号某一天的云账单明细，归一化后按照地域、产品、币种汇总返回
+func (b bill) PullDailyBill(cts *rest.Contexts) (interface{}, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(hcbillservice.BillDailyPullReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	aggregator := typesBill.NewBillDailyAggregator()
+
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		err = b.pullTCloudDailyBill(cts.Kit, req, aggregator)
+	case enumor.Aws:
+		err = b.pullAwsDailyBill(cts.Kit, req, aggregator)
+	case enumor.HuaWei:
+		err = b.pullHuaWeiDailyBill(cts.Kit, req, aggregator)
+	case enumor.Azure:
+		err = b.pullAzureDailyBill(cts.Kit, req, aggregator)
+	case enumor.Gcp:
+		err = b.pullGcpDailyBill(cts.Kit, req, aggregator)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported vendor: %s", vendor)
+	}
+	if err != nil {
+		logs.Errorf("pull %s daily bill failed, req: %+v, err: %v, rid: %s", vendor, req, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &hcbillservice.BillDailyPullResult{Items: aggregator.Costs()}, nil
+}
+
+func (b bill) pullTCloudDailyBill(kt *kit.Kit, req *hcbillservice.BillDailyPullReq,
+	aggregator *typesBill.BillDailyAggregator) error {
+
+	cli, err := b.ad.TCloud(kt, req.AccountID)
+	if err != nil {
+		return err
+	}
+
+	opt := &typesBill.TCloudBillListOption{
+		AccountID: req.AccountID,
+		BeginDate: req.BillDate + " 00:00:00",
+		EndDate:   req.BillDate + " 23:59:59",
+		Page:      &core.TCloudPage{Offset: 0, Limit: core.TCloudQueryLimit},
+	}
+	for {
+		resp, err := cli.GetBillList(kt, opt)
+		if err != nil {
+			return err
+		}
+
+		for _, one := range resp.DetailSet {
+			if one == nil {
+				continue
+			}
+
+			cost := "0"
+			for _, component := range one.ComponentSet {
+				if component == nil || component.RealCost == nil {
+					continue
+				}
+				cost, err = addCost(cost, *component.RealCost)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = aggregator.Add(typesBill.BillCostItem{
+				Region:   converter.PtrToVal(one.RegionId),
+				Product:  converter.PtrToVal(one.BusinessCodeName),
+				Currency: tcloudBillCurrency,
+				Cost:     cost,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if uint64(len(resp.DetailSet)) < opt.Page.Limit {
+			return nil
+		}
+
+		opt.Page.Offset += opt.Page.Limit
+		opt.Context = resp.Context
+	}
+}
+
+func (b bill) pullAwsDailyBill(kt *kit.Kit, req *hcbillservice.BillDailyPullReq,
+	aggregator *typesBill.BillDailyAggregator) error {
+
+	cli, err := b.ad.Aws(kt, req.AccountID)
+	if err != nil {
+		return err
+	}
+
+	billInfo, err := b.GetBillInfo(kt, req.AccountID)
+	if err != nil {
+		return err
+	}
+	if billInfo == nil {
+		return errf.Newf(errf.RecordNotFound, "account_id: %s bill config is not found", req.AccountID)
+	}
+	if billInfo.Status != constant.StatusSuccess {
+		return errf.Newf(errf.Aborted, "account_id: %s bill config has not ready yet", req.AccountID)
+	}
+
+	opt := &typesBill.AwsBillListOption{
+		AccountID: req.AccountID,
+		BeginDate: req.BillDate,
+		EndDate:   req.BillDate,
+		Page:      &typesBill.AwsBillPage{Offset: 0, Limit: awsBillDailyPageLimit},
+	}
+	for {
+		_, list, err := cli.GetBillList(kt, opt, billInfo)
+		if err != nil {
+			return err
+		}
+
+		rows, ok := list.([]map[string]string)
+		if list != nil && !ok {
+			return fmt.Errorf("aws bill list type %T is invalid", list)
+		}
+
+		for _, row := range rows {
+			err = aggregator.Add(typesBill.BillCostItem{
+				Region:   row["product_region"],
+				Product:  row["line_item_product_code"],
+				Currency: row["line_item_currency_code"],
+				Cost:     row["line_item_unblended_cost"],
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if uint64(len(rows)) < opt.Page.Limit {
+			return nil
+		}
+
+		opt.Page.Offset += opt.Page.Limit
+	}
+}
+
+// pullHuaWeiDailyBill 华为云资源详单只支持按账期查询，按天统计后过滤出指定日期的明细
+func (b bill) pullHuaWeiDailyBill(kt *kit.Kit, req *hcbillservice.BillDailyPullReq,
+	aggregator *typesBill.BillDailyAggregator) error {
+
+	cli, err := b.ad.HuaWei(kt, req.AccountID)
+	if err != nil {
+		return err
+	}
+
+	opt := &typesBill.HuaWeiBillListOption{
+		AccountID: req.AccountID,
+		Month:     req.BillDate[:len("2006-01")],
+		Page: &typesBill.HuaWeiBillPage{
+			Offset: proto.Int32(0),
+			Limit:  proto.Int32(typesBill.HuaWeiQueryLimit),
+		},
+	}
+	for {
+		resp, err := cli.GetBillList(kt, opt)
+		if err != nil {
+			return err
+		}
+
+		records := converter.PtrToVal(resp.MonthlyRecords)
+		for _, one := range records {
+			if !strings.HasPrefix(converter.PtrToVal(one.BillDate), req.BillDate) {
+				continue
+			}
+
+			cost := ""
+			if one.ConsumeAmount != nil {
+				cost = strconv.FormatFloat(*one.ConsumeAmount, 'f', -1, 64)
+			}
+			err = aggregator.Add(typesBill.BillCostItem{
+				Region:   converter.PtrToVal(one.Region),
+				Product:  converter.PtrToVal(one.CloudServiceTypeName),
+				Currency: converter.PtrToVal(resp.Currency),
+				Cost:     cost,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(records) < typesBill.HuaWeiQueryLimit {
+			return nil
+		}
+
+		opt.Page.Offset = proto.Int32(*opt.Page.Offset + typesBill.HuaWeiQueryLimit)
+	}
+}
+
+func (b bill) pullAzureDailyBill(kt *kit.Kit, req *hcbillservice.BillDailyPullReq,
+	aggregator *typesBill.BillDailyAggregator) error {
+
+	cli, err := b.ad.Azure(kt, req.AccountID)
+	if err != nil {
+		return err
+	}
+
+	opt := &typesBill.AzureBillListOption{
+		AccountID: req.AccountID,
+		BeginDate: req.BillDate,
+		EndDate:   req.BillDate,
+		Page:      &typesBill.AzureBillPage{Limit: typesBill.AzureQueryLimit},
+	}
+	for {
+		resp, err := cli.GetBillList(kt, opt)
+		if err != nil {
+			return err
+		}
+
+		for _, one := range resp.Value {
+			item, ok := convAzureBillCostItem(one)
+			if !ok {
+				continue
+			}
+
+			if err = aggregator.Add(item); err != nil {
+				return err
+			}
+		}
+
+		if resp.NextLink == nil || len(*resp.NextLink) == 0 {
+			return nil
+		}
+
+		opt.Page.NextLink = *resp.NextLink
+	}
+}
+
+// convAzureBillCostItem 兼容azure的legacy和modern两种用量明细
+func convAzureBillCostItem(detail armconsumption.UsageDetailClassification) (typesBill.BillCostItem, bool) {
+	var region, product, currency string
+	var cost *float64
+
+	switch one := detail.(type) {
+	case *armconsumption.LegacyUsageDetail:
+		if one.Properties == nil {
+			return typesBill.BillCostItem{}, false
+		}
+		region = converter.PtrToVal(one.Properties.ResourceLocation)
+		product = converter.PtrToVal(one.Properties.ConsumedService)
+		currency = converter.PtrToVal(one.Properties.BillingCurrency)
+		cost = one.Properties.Cost
+	case *armconsumption.ModernUsageDetail:
+		if one.Properties == nil {
+			return typesBill.BillCostItem{}, false
+		}
+		region = converter.PtrToVal(one.Properties.ResourceLocationNormalized)
+		product = converter.PtrToVal(one.Properties.ConsumedService)
+		currency = converter.PtrToVal(one.Properties.BillingCurrencyCode)
+		cost = one.Properties.CostInBillingCurrency
+	default:
+		return typesBill.BillCostItem{}, false
+	}
+
+	item := typesBill.BillCostItem{Region: region, Product: product, Currency: currency}
+	if cost != nil {
+		item.Cost = strconv.FormatFloat(*cost, 'f', -1, 64)
+	}
+
+	return item, true
+}
+
+func (b bill) pullGcpDailyBill(kt *kit.Kit, req *hcbillservice.BillDailyPullReq,
+	aggregator *typesBill.BillDailyAggregator) error {
+
+	billAccountID := req.BillAccountID
+	if len(billAccountID) == 0 {
+		// 未指定账单账号时，使用已配置的gcp账单导出信息
+		billList, err := b.cs.DataService().Global.Bill.List(kt.Ctx, kt.Header(), &apicore.ListReq{
+			Filter: tools.EqualExpression("vendor", enumor.Gcp),
+			Page:   &apicore.BasePage{Count: false, Start: 0, Limit: 1},
+		})
+		if err != nil {
+			return err
+		}
+		if len(billList.Details) == 0 {
+			return errf.New(errf.RecordNotFound, "gcp bill config is not found")
+		}
+		billAccountID = billList.Details[0].AccountID
+	}
+
+	billInfo, err := getBillInfo[cloud.GcpBillConfigExtension](kt, billAccountID, b.cs.DataService())
+	if err != nil {
+		return err
+	}
+	if billInfo == nil {
+		return errf.Newf(errf.RecordNotFound, "bill_account_id: %s is not found", billAccountID)
+	}
+
+	account, err := b.cs.DataService().Gcp.Account.Get(kt.Ctx, kt.Header(), req.AccountID)
+	if err != nil {
+		return err
+	}
+	if account.Extension == nil || account.Extension.CloudProjectID == "" {
+		return fmt.Errorf("account: %s cloud_project_id is empty", req.AccountID)
+	}
+
+	cli, err := b.ad.GcpProxy(kt, billAccountID)
+	if err != nil {
+		return err
+	}
+
+	opt := &typesBill.GcpBillListOption{
+		BillAccountID: billAccountID,
+		AccountID:     req.AccountID,
+		BeginDate:     req.BillDate + "T00:00:00Z",
+		EndDate:       req.BillDate + "T00:00:00Z",
+		ProjectID:     account.Extension.CloudProjectID,
+		Page:          &typesBill.GcpBillPage{Offset: 0, Limit: core.GcpQueryLimit},
+	}
+	for {
+		list, _, err := cli.GetBillList(kt, opt, billInfo)
+		if err != nil {
+			return err
+		}
+
+		rows, ok := list.([]map[string]bigquery.Value)
+		if list != nil && !ok {
+			return fmt.Errorf("gcp bill list type %T is invalid", list)
+		}
+
+		for _, row := range rows {
+			err = aggregator.Add(typesBill.BillCostItem{
+				Region:   bigQueryValueString(row["region"]),
+				Product:  bigQueryValueString(row["service_description"]),
+				Currency: bigQueryValueString(row["currency"]),
+				// total_cost 为扣除了赠金之后的费用
+				Cost: bigQueryValueString(row["total_cost"]),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if uint64(len(rows)) < opt.Page.Limit {
+			return nil
+		}
+
+		opt.Page.Offset += opt.Page.Limit
+	}
+}
+
+func bigQueryValueString(value bigquery.Value) string {
+	switch val := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+func addCost(a, b string) (string, error) {
+	d1, err := math.NewDecimalFromString(a)
+	if err != nil {
+		return "", err
+	}
+
+	d2, err := math.NewDecimalFromString(b)
+	if err != nil {
+		return "", err
+	}
+
+	return d1.Add(d2).ToString(), nil
+}
@@ -28,10 +28,12 @@ import (
 	"hcm/pkg/api/core"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	protocvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
 )
 
 func (svc *cvmSvc) initAwsCvmService(cap *capability.Capability) {
@@ -76,6 +78,14 @@ func (svc *cvmSvc) BatchCreateAwsCvm(cts *rest.Contexts) (interface{}, error) {
 		BlockDeviceMapping:    req.BlockDeviceMapping,
 		PublicIPAssigned:      req.PublicIPAssigned,
 	}
+	if len(req.KeyPairID) != 0 {
+		keyPair, err := svc.getCreateKeyPair(cts.Kit, enumor.Aws, req.AccountID, req.Region, req.KeyPairID)
+		if err != nil {
+			return nil, err
+		}
+		createOpt.KeyName = converter.ValToPtr(keyPair.Name)
+	}
+
 	result, err := awsCli.CreateCvm(cts.Kit, createOpt)
 	if err != nil {
 		logs.Errorf("create aws cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
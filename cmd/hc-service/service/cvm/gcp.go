@@ -31,6 +31,7 @@ import (
 	coreimage "hcm/pkg/api/core/cloud/image"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	protocvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/kit"
@@ -86,6 +87,13 @@ func (svc *cvmSvc) BatchCreateGcpCvm(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	// 谷歌云密钥对保存在项目元数据中，对项目下的主机默认生效，这里只做校验
+	if len(req.KeyPairID) != 0 {
+		if _, err = svc.getCreateKeyPair(cts.Kit, enumor.Gcp, req.AccountID, req.Region, req.KeyPairID); err != nil {
+			return nil, err
+		}
+	}
+
 	createOpt := &typecvm.GcpCreateOption{
 		NamePrefix:          req.NamePrefix,
 		Zone:                req.Zone,
@@ -36,6 +36,7 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
 )
 
 func (svc *cvmSvc) initHuaWeiCvmService(cap *capability.Capability) {
@@ -87,6 +88,14 @@ func (svc *cvmSvc) InquiryPriceHuaWeiCvm(cts *rest.Contexts) (interface{}, error
 		DataVolume:            req.DataVolume,
 		InstanceCharge:        req.InstanceCharge,
 	}
+	if len(req.KeyPairID) != 0 {
+		keyPair, err := svc.getCreateKeyPair(cts.Kit, enumor.HuaWei, req.AccountID, req.Region, req.KeyPairID)
+		if err != nil {
+			return nil, err
+		}
+		opt.KeyName = converter.ValToPtr(keyPair.Name)
+	}
+
 	result, err := huawei.InquiryPriceCvm(cts.Kit, opt)
 	if err != nil {
 		logs.Errorf("inquiry price huawei cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -134,6 +143,14 @@ func (svc *cvmSvc) BatchCreateHuaWeiCvm(cts *rest.Contexts) (interface{}, error)
 		PublicIPAssigned:      req.PublicIPAssigned,
 		Eip:                   req.Eip,
 	}
+	if len(req.KeyPairID) != 0 {
+		keyPair, err := svc.getCreateKeyPair(cts.Kit, enumor.HuaWei, req.AccountID, req.Region, req.KeyPairID)
+		if err != nil {
+			return nil, err
+		}
+		createOpt.KeyName = converter.ValToPtr(keyPair.Name)
+	}
+
 	result, err := huawei.CreateCvm(cts.Kit, createOpt)
 	if err != nil {
 		logs.Errorf("create huawei cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -0,0 +1,62 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// getCreateKeyPair 获取创建主机使用的密钥对，密钥对需要与主机属于相同的账号和地域
+func (svc *cvmSvc) getCreateKeyPair(kt *kit.Kit, vendor enumor.Vendor, accountID, region, id string) (
+	*corekeypair.BaseKeyPair, error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.KeyPair.ListKeyPair(kt, listReq)
+	if err != nil {
+		logs.Errorf("list key pair failed, id: %s, err: %v, rid: %s", id, err, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "key pair: %s not found", id)
+	}
+
+	keyPair := result.Details[0]
+	if keyPair.Vendor != vendor || keyPair.AccountID != accountID {
+		return nil, errf.Newf(errf.InvalidParameter, "key pair: %s not belongs to %s account: %s", id, vendor,
+			accountID)
+	}
+
+	// 腾讯云、谷歌云的密钥对与地域无关
+	if len(keyPair.Region) != 0 && keyPair.Region != region {
+		return nil, errf.Newf(errf.InvalidParameter, "key pair: %s not in region: %s", id, region)
+	}
+
+	return &keyPair, nil
+}
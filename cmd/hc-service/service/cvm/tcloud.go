@@ -28,6 +28,7 @@ import (
 	"hcm/pkg/api/core"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	protocvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/logs"
@@ -84,6 +85,14 @@ func (svc *cvmSvc) InquiryPriceTCloudCvm(cts *rest.Contexts) (interface{}, error
 		PublicIPAssigned:        req.PublicIPAssigned,
 		InternetMaxBandwidthOut: req.InternetMaxBandwidthOut,
 	}
+	if len(req.KeyPairID) != 0 {
+		keyPair, err := svc.getCreateKeyPair(cts.Kit, enumor.TCloud, req.AccountID, req.Region, req.KeyPairID)
+		if err != nil {
+			return nil, err
+		}
+		createOpt.CloudKeyPairIDs = []string{keyPair.CloudID}
+	}
+
 	result, err := tcloud.InquiryPriceCvm(cts.Kit, createOpt)
 	if err != nil {
 		logs.Errorf("inquiry cvm price failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -129,6 +138,14 @@ func (svc *cvmSvc) BatchCreateTCloudCvm(cts *rest.Contexts) (interface{}, error)
 		PublicIPAssigned:        req.PublicIPAssigned,
 		InternetMaxBandwidthOut: req.InternetMaxBandwidthOut,
 	}
+	if len(req.KeyPairID) != 0 {
+		keyPair, err := svc.getCreateKeyPair(cts.Kit, enumor.TCloud, req.AccountID, req.Region, req.KeyPairID)
+		if err != nil {
+			return nil, err
+		}
+		createOpt.CloudKeyPairIDs = []string{keyPair.CloudID}
+	}
+
 	result, err := tcloud.CreateCvm(cts.Kit, createOpt)
 	if err != nil {
 		logs.Errorf("create cvm failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -0,0 +1,320 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair 密钥对相关的云上操作
+package keypair
+
+import (
+	"net/http"
+
+	cloudadaptor "hcm/cmd/hc-service/logics/cloud-adaptor"
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	syncaws "hcm/cmd/hc-service/logics/res-sync/aws"
+	syncgcp "hcm/cmd/hc-service/logics/res-sync/gcp"
+	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
+	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
+	"hcm/cmd/hc-service/service/capability"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	hckeypair "hcm/pkg/api/hc-service/key-pair"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitKeyPairService initial key pair service.
+func InitKeyPairService(cap *capability.Capability) {
+	svc := &keyPairSvc{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+		syncCli: cap.ResSyncCli,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateKeyPair", http.MethodPost, "/vendors/{vendor}/key_pairs/create", svc.CreateKeyPair)
+	h.Add("DeleteKeyPair", http.MethodDelete, "/vendors/{vendor}/key_pairs/{id}", svc.DeleteKeyPair)
+
+	h.Load(cap.WebService)
+}
+
+type keyPairSvc struct {
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
+	syncCli ressync.Interface
+}
+
+// keyPairOperator 密钥对的云上导入、删除操作，各云厂商 adaptor 均实现了该接口
+type keyPairOperator interface {
+	ImportKeyPair(kt *kit.Kit, opt *typekeypair.ImportOption) (string, error)
+	DeleteKeyPair(kt *kit.Kit, opt *typekeypair.DeleteOption) error
+}
+
+func (svc *keyPairSvc) keyPairOperator(kt *kit.Kit, vendor enumor.Vendor, accountID string) (keyPairOperator,
+	error) {
+
+	switch vendor {
+	case enumor.TCloud:
+		return svc.ad.TCloud(kt, accountID)
+	case enumor.Aws:
+		return svc.ad.Aws(kt, accountID)
+	case enumor.HuaWei:
+		return svc.ad.HuaWei(kt, accountID)
+	case enumor.Gcp:
+		return svc.ad.Gcp(kt, accountID)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support key pair", vendor)
+	}
+}
+
+// CreateKeyPair 导入公钥到云上创建密钥对，创建后同步到db并保存已加密的私钥
+func (svc *keyPairSvc) CreateKeyPair(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(hckeypair.KeyPairCreateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	region := req.Region
+	switch vendor {
+	case enumor.Aws, enumor.HuaWei:
+		if len(region) == 0 {
+			return nil, errf.Newf(errf.InvalidParameter, "%s key pair region is required", vendor)
+		}
+	default:
+		// 腾讯云、谷歌云的密钥对与地域无关
+		region = ""
+	}
+
+	operator, err := svc.keyPairOperator(cts.Kit, vendor, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typekeypair.ImportOption{
+		Region:    region,
+		Name:      req.Name,
+		PublicKey: req.PublicKey,
+	}
+	cloudID, err := operator.ImportKeyPair(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("[%s] import key pair failed, err: %v, name: %s, rid: %s", vendor, err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	scope := &keyPairScope{vendor: vendor, accountID: req.AccountID, region: region, cloudID: cloudID}
+	syncOpt := &syncKeyPairOption{bizID: req.BkBizID, privateKey: req.PrivateKey, memo: req.Memo}
+	if err = svc.syncKeyPair(cts.Kit, scope, syncOpt); err != nil {
+		return nil, err
+	}
+
+	id, err := svc.getKeyPairIDByCloudID(cts.Kit, vendor, req.AccountID, cloudID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// DeleteKeyPair 删除云上密钥对，删除后同步db
+func (svc *keyPairSvc) DeleteKeyPair(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPair, err := svc.getKeyPair(cts.Kit, vendor, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	operator, err := svc.keyPairOperator(cts.Kit, vendor, keyPair.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typekeypair.DeleteOption{
+		Region:  keyPair.Region,
+		CloudID: keyPair.CloudID,
+		Name:    keyPair.Name,
+	}
+	if err = operator.DeleteKeyPair(cts.Kit, opt); err != nil {
+		logs.Errorf("[%s] delete key pair failed, err: %v, id: %s, rid: %s", vendor, err, keyPair.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 同步时云上已不存在该密钥对，db数据会被删除
+	scope := &keyPairScope{vendor: vendor, accountID: keyPair.AccountID, region: keyPair.Region,
+		cloudID: keyPair.CloudID}
+	if err = svc.syncKeyPair(cts.Kit, scope, new(syncKeyPairOption)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *keyPairSvc) getKeyPair(kt *kit.Kit, vendor enumor.Vendor, id string) (*corekeypair.BaseKeyPair,
+	error) {
+
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.KeyPair.ListKeyPair(kt, req)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "key pair: %s not found", id)
+	}
+
+	keyPair := result.Details[0]
+	if keyPair.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "key pair: %s vendor is %s, not %s", id, keyPair.Vendor,
+			vendor)
+	}
+
+	return &keyPair, nil
+}
+
+func (svc *keyPairSvc) getKeyPairIDByCloudID(kt *kit.Kit, vendor enumor.Vendor, accountID, cloudID string) (
+	string, error) {
+
+	req := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{
+			"vendor":     vendor,
+			"account_id": accountID,
+			"cloud_id":   cloudID,
+		}),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.KeyPair.ListKeyPair(kt, req)
+	if err != nil {
+		logs.Errorf("list key pair failed, err: %v, cloud_id: %s, rid: %s", err, cloudID, kt.Rid)
+		return "", err
+	}
+
+	if len(result.Details) == 0 {
+		return "", errf.Newf(errf.RecordNotFound, "key pair: %s not found after sync", cloudID)
+	}
+
+	return result.Details[0].ID, nil
+}
+
+// keyPairScope 密钥对所在的账号和地域，腾讯云、谷歌云的密钥对地域为空
+type keyPairScope struct {
+	vendor    enumor.Vendor
+	accountID string
+	region    string
+	cloudID   string
+}
+
+// syncKeyPairOption 同步新建密钥对时写入的业务、私钥和备注
+type syncKeyPairOption struct {
+	bizID      int64
+	privateKey string
+	memo       *string
+}
+
+// syncKeyPair 云上操作后，同步密钥对到db
+func (svc *keyPairSvc) syncKeyPair(kt *kit.Kit, scope *keyPairScope, opt *syncKeyPairOption) error {
+	accountID, cloudIDs := scope.accountID, []string{scope.cloudID}
+
+	var err error
+	switch scope.vendor {
+	case enumor.TCloud:
+		var syncCli synctcloud.Interface
+		if syncCli, err = svc.syncCli.TCloud(kt, accountID); err != nil {
+			return err
+		}
+		params := &synctcloud.SyncBaseParams{AccountID: accountID, Region: constant.TCloudDefaultRegion,
+			CloudIDs: cloudIDs}
+		_, err = syncCli.KeyPair(kt, params, &synctcloud.SyncKeyPairOption{BkBizID: opt.bizID,
+			PrivateKey: opt.privateKey, Memo: opt.memo})
+
+	case enumor.Aws:
+		var syncCli syncaws.Interface
+		if syncCli, err = svc.syncCli.Aws(kt, accountID); err != nil {
+			return err
+		}
+		params := &syncaws.SyncBaseParams{AccountID: accountID, Region: scope.region, CloudIDs: cloudIDs}
+		_, err = syncCli.KeyPair(kt, params, &syncaws.SyncKeyPairOption{BkBizID: opt.bizID,
+			PrivateKey: opt.privateKey, Memo: opt.memo})
+
+	case enumor.HuaWei:
+		var syncCli synchuawei.Interface
+		if syncCli, err = svc.syncCli.HuaWei(kt, accountID); err != nil {
+			return err
+		}
+		params := &synchuawei.SyncBaseParams{AccountID: accountID, Region: scope.region, CloudIDs: cloudIDs}
+		_, err = syncCli.KeyPair(kt, params, &synchuawei.SyncKeyPairOption{BkBizID: opt.bizID,
+			PrivateKey: opt.privateKey, Memo: opt.memo})
+
+	case enumor.Gcp:
+		var syncCli syncgcp.Interface
+		if syncCli, err = svc.syncCli.Gcp(kt, accountID); err != nil {
+			return err
+		}
+		params := &syncgcp.SyncBaseParams{AccountID: accountID, CloudIDs: cloudIDs}
+		_, err = syncCli.KeyPair(kt, params, &syncgcp.SyncKeyPairOption{BkBizID: opt.bizID,
+			PrivateKey: opt.privateKey, Memo: opt.memo})
+
+	default:
+		return errf.Newf(errf.InvalidParameter, "vendor: %s not support key pair", scope.vendor)
+	}
+
+	if err != nil {
+		logs.Errorf("[%s] sync key pair failed, err: %v, account: %s, cloud_ids: %v, rid: %s", scope.vendor, err,
+			accountID, cloudIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func parseVendor(cts *rest.Contexts) (enumor.Vendor, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return vendor, nil
+}
@@ -0,0 +1,256 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	adcore "hcm/pkg/adaptor/types/core"
+	"hcm/pkg/api/core"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateTCloudLoadBalancer create tcloud load balancer.
+func (svc *lbSvc) CreateTCloudLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(hclb.TCloudLoadBalancerCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudIDs, err := client.CreateLoadBalancer(cts.Kit, &req.TCloudCreateOption)
+	if err != nil {
+		logs.Errorf("create tcloud load balancer failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.afterCreateLb(cts.Kit, enumor.TCloud, req.AccountID, req.Region, cloudIDs, req.BkBizID)
+}
+
+// CreateAwsLoadBalancer create aws load balancer.
+func (svc *lbSvc) CreateAwsLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(hclb.AwsLoadBalancerCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudID, err := client.CreateLoadBalancer(cts.Kit, &req.AwsCreateOption)
+	if err != nil {
+		logs.Errorf("create aws load balancer failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.afterCreateLb(cts.Kit, enumor.Aws, req.AccountID, req.Region, []string{cloudID}, req.BkBizID)
+}
+
+// CreateHuaWeiLoadBalancer create huawei load balancer.
+func (svc *lbSvc) CreateHuaWeiLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(hclb.HuaWeiLoadBalancerCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudID, err := client.CreateLoadBalancer(cts.Kit, &req.HuaWeiCreateOption)
+	if err != nil {
+		logs.Errorf("create huawei load balancer failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.afterCreateLb(cts.Kit, enumor.HuaWei, req.AccountID, req.Region, []string{cloudID}, req.BkBizID)
+}
+
+// CreateGcpLoadBalancer create gcp load balancer.
+func (svc *lbSvc) CreateGcpLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(hclb.GcpLoadBalancerCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.Gcp(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudID, err := client.CreateLoadBalancer(cts.Kit, &req.GcpCreateOption)
+	if err != nil {
+		logs.Errorf("create gcp load balancer failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.afterCreateLb(cts.Kit, enumor.Gcp, req.AccountID, req.Region, []string{cloudID}, req.BkBizID)
+}
+
+// CreateAzureLoadBalancer create azure load balancer.
+func (svc *lbSvc) CreateAzureLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	req := new(hclb.AzureLoadBalancerCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.Azure(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudID, err := client.CreateLoadBalancer(cts.Kit, &req.AzureCreateOption)
+	if err != nil {
+		logs.Errorf("create azure load balancer failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.afterCreateLb(cts.Kit, enumor.Azure, req.AccountID, req.ResourceGroupName, []string{cloudID},
+		req.BkBizID)
+}
+
+// afterCreateLb 同步新建的负载均衡到db，返回负载均衡本地ID
+func (svc *lbSvc) afterCreateLb(kt *kit.Kit, vendor enumor.Vendor, accountID, regionOrResGroup string,
+	cloudIDs []string, bizID int64) (*core.BatchCreateResult, error) {
+
+	if err := svc.syncLoadBalancer(kt, vendor, accountID, regionOrResGroup, cloudIDs, bizID); err != nil {
+		return nil, err
+	}
+
+	ids, err := svc.listLbIDsByCloudIDs(kt, vendor, cloudIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
+
+// DeleteLoadBalancer delete load balancer, listeners and targets of load balancer will be deleted together.
+func (svc *lbSvc) DeleteLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	scope, err := svc.getLbScope(cts.Kit, vendor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.deleteCloudLb(cts.Kit, scope); err != nil {
+		logs.Errorf("[%s] delete load balancer failed, err: %v, id: %s, rid: %s", vendor, err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 同步时云上已不存在该负载均衡，db数据会被删除
+	if err = svc.syncLbByScope(cts.Kit, scope); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *lbSvc) deleteCloudLb(kt *kit.Kit, scope *lbScope) error {
+	lb := scope.lb
+	regionalOpt := &adcore.BaseRegionalDeleteOption{
+		BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: lb.CloudID},
+		Region:           lb.Region,
+	}
+
+	switch lb.Vendor {
+	case enumor.TCloud:
+		client, err := svc.ad.TCloud(kt, lb.AccountID)
+		if err != nil {
+			return err
+		}
+		return client.DeleteLoadBalancer(kt, regionalOpt)
+
+	case enumor.Aws:
+		client, err := svc.ad.Aws(kt, lb.AccountID)
+		if err != nil {
+			return err
+		}
+		return client.DeleteLoadBalancer(kt, regionalOpt)
+
+	case enumor.HuaWei:
+		client, err := svc.ad.HuaWei(kt, lb.AccountID)
+		if err != nil {
+			return err
+		}
+		return client.DeleteLoadBalancer(kt, regionalOpt)
+
+	case enumor.Gcp:
+		client, err := svc.ad.Gcp(kt, lb.AccountID)
+		if err != nil {
+			return err
+		}
+		// gcp 负载均衡基于目标池，删除时使用目标池名称
+		regionalOpt.ResourceID = lb.Name
+		return client.DeleteLoadBalancer(kt, regionalOpt)
+
+	case enumor.Azure:
+		client, err := svc.ad.Azure(kt, lb.AccountID)
+		if err != nil {
+			return err
+		}
+		return client.DeleteLoadBalancer(kt, &adcore.AzureDeleteOption{
+			BaseDeleteOption:  adcore.BaseDeleteOption{ResourceID: lb.CloudID},
+			ResourceGroupName: scope.resourceGroupName,
+		})
+
+	default:
+		return errf.Newf(errf.InvalidParameter, "vendor: %s not support load balancer", lb.Vendor)
+	}
+}
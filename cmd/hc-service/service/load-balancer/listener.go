@@ -0,0 +1,195 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// CreateListener create load balancer listener.
+func (svc *lbSvc) CreateListener(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(hclb.ListenerCreateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	scope, err := svc.getLbScope(cts.Kit, vendor, req.LbID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.lbRelOperator(cts.Kit, vendor, scope.lb.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typelb.ListenerCreateOption{
+		Region:             scope.lb.Region,
+		ResourceGroupName:  scope.resourceGroupName,
+		CloudLbID:          scope.lb.CloudID,
+		Name:               req.Name,
+		Protocol:           req.Protocol,
+		Port:               req.Port,
+		CloudTargetGroupID: req.CloudTargetGroupID,
+		Extension:          req.Extension,
+	}
+	cloudID, err := client.CreateListener(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("[%s] create listener failed, err: %v, opt: %+v, rid: %s", vendor, err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if err = svc.syncLbByScope(cts.Kit, scope); err != nil {
+		return nil, err
+	}
+
+	listeners, err := svc.listListener(cts.Kit, scope.lb.ID, "cloud_id", []string{cloudID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(listeners) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "listener: %s not found after sync", cloudID)
+	}
+
+	return &core.CreateResult{ID: listeners[0].ID}, nil
+}
+
+// BatchDeleteListener batch delete load balancer listener.
+func (svc *lbSvc) BatchDeleteListener(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(hclb.ListenerBatchDeleteReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	scope, err := svc.getLbScope(cts.Kit, vendor, req.LbID)
+	if err != nil {
+		return nil, err
+	}
+
+	listeners, err := svc.listListener(cts.Kit, scope.lb.ID, "id", req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(listeners) != len(req.IDs) {
+		return nil, errf.Newf(errf.InvalidParameter, "some listeners not belong to load balancer: %s", req.LbID)
+	}
+
+	cloudIDs := make([]string, 0, len(listeners))
+	for _, one := range listeners {
+		cloudIDs = append(cloudIDs, one.CloudID)
+	}
+
+	client, err := svc.lbRelOperator(cts.Kit, vendor, scope.lb.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typelb.ListenerDeleteOption{
+		Region:            scope.lb.Region,
+		ResourceGroupName: scope.resourceGroupName,
+		CloudLbID:         scope.lb.CloudID,
+		CloudIDs:          cloudIDs,
+	}
+	if err = client.DeleteListener(cts.Kit, opt); err != nil {
+		logs.Errorf("[%s] delete listener failed, err: %v, opt: %+v, rid: %s", vendor, err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if err = svc.syncLbByScope(cts.Kit, scope); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// listListener list listeners of load balancer by the given field values.
+func (svc *lbSvc) listListener(kt *kit.Kit, lbID string, field string, values []string) ([]corelb.Listener,
+	error) {
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "lb_id", Op: filter.Equal.Factory(), Value: lbID},
+				&filter.AtomRule{Field: field, Op: filter.In.Factory(), Value: values},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.LoadBalancer.ListListener(kt, req)
+	if err != nil {
+		logs.Errorf("list listener failed, err: %v, lb: %s, %s: %v, rid: %s", err, lbID, field, values, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
+
+// getListener get listener by id.
+func (svc *lbSvc) getListener(kt *kit.Kit, id string) (*corelb.Listener, error) {
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.Equal.Factory(), Value: id},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.LoadBalancer.ListListener(kt, req)
+	if err != nil {
+		logs.Errorf("get listener failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "listener: %s not found", id)
+	}
+
+	return &result.Details[0], nil
+}
@@ -0,0 +1,257 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer 负载均衡相关的云上操作
+package loadbalancer
+
+import (
+	"net/http"
+
+	cloudadaptor "hcm/cmd/hc-service/logics/cloud-adaptor"
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	syncaws "hcm/cmd/hc-service/logics/res-sync/aws"
+	syncazure "hcm/cmd/hc-service/logics/res-sync/azure"
+	syncgcp "hcm/cmd/hc-service/logics/res-sync/gcp"
+	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
+	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
+	"hcm/cmd/hc-service/service/capability"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitLoadBalancerService initial load balancer service.
+func InitLoadBalancerService(cap *capability.Capability) {
+	svc := &lbSvc{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+		syncCli: cap.ResSyncCli,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateTCloudLoadBalancer", http.MethodPost, "/vendors/tcloud/load_balancers/create",
+		svc.CreateTCloudLoadBalancer)
+	h.Add("CreateAwsLoadBalancer", http.MethodPost, "/vendors/aws/load_balancers/create", svc.CreateAwsLoadBalancer)
+	h.Add("CreateHuaWeiLoadBalancer", http.MethodPost, "/vendors/huawei/load_balancers/create",
+		svc.CreateHuaWeiLoadBalancer)
+	h.Add("CreateGcpLoadBalancer", http.MethodPost, "/vendors/gcp/load_balancers/create", svc.CreateGcpLoadBalancer)
+	h.Add("CreateAzureLoadBalancer", http.MethodPost, "/vendors/azure/load_balancers/create",
+		svc.CreateAzureLoadBalancer)
+	h.Add("DeleteLoadBalancer", http.MethodDelete, "/vendors/{vendor}/load_balancers/{id}", svc.DeleteLoadBalancer)
+
+	h.Add("CreateListener", http.MethodPost, "/vendors/{vendor}/listeners/create", svc.CreateListener)
+	h.Add("BatchDeleteListener", http.MethodDelete, "/vendors/{vendor}/listeners/batch", svc.BatchDeleteListener)
+
+	h.Add("RegisterTargets", http.MethodPost, "/vendors/{vendor}/targets/register", svc.RegisterTargets)
+	h.Add("DeregisterTargets", http.MethodPost, "/vendors/{vendor}/targets/deregister", svc.DeregisterTargets)
+
+	h.Load(cap.WebService)
+}
+
+type lbSvc struct {
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
+	syncCli ressync.Interface
+}
+
+// lbRelOperator 负载均衡监听器和后端服务的云上操作，各云厂商 adaptor 均实现了该接口
+type lbRelOperator interface {
+	CreateListener(kt *kit.Kit, opt *typelb.ListenerCreateOption) (string, error)
+	DeleteListener(kt *kit.Kit, opt *typelb.ListenerDeleteOption) error
+	RegisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error
+	DeregisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error
+}
+
+func (svc *lbSvc) lbRelOperator(kt *kit.Kit, vendor enumor.Vendor, accountID string) (lbRelOperator, error) {
+	switch vendor {
+	case enumor.TCloud:
+		return svc.ad.TCloud(kt, accountID)
+	case enumor.Aws:
+		return svc.ad.Aws(kt, accountID)
+	case enumor.HuaWei:
+		return svc.ad.HuaWei(kt, accountID)
+	case enumor.Gcp:
+		return svc.ad.Gcp(kt, accountID)
+	case enumor.Azure:
+		return svc.ad.Azure(kt, accountID)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support load balancer", vendor)
+	}
+}
+
+// lbScope 负载均衡所在的地域和资源组，资源组仅 azure 有效
+type lbScope struct {
+	lb                *corelb.BaseLoadBalancer
+	resourceGroupName string
+}
+
+func (svc *lbSvc) getLbScope(kt *kit.Kit, vendor enumor.Vendor, lbID string) (*lbScope, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", lbID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.LoadBalancer.ListLoadBalancer(kt, req)
+	if err != nil {
+		logs.Errorf("list load balancer failed, err: %v, id: %s, rid: %s", err, lbID, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "load balancer: %s not found", lbID)
+	}
+
+	lb := result.Details[0]
+	if lb.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "load balancer: %s vendor is %s, not %s", lbID, lb.Vendor,
+			vendor)
+	}
+
+	scope := &lbScope{lb: &lb}
+	if vendor != enumor.Azure {
+		return scope, nil
+	}
+
+	azureLb, err := svc.dataCli.Azure.GetLoadBalancer(kt, lbID)
+	if err != nil {
+		logs.Errorf("get azure load balancer failed, err: %v, id: %s, rid: %s", err, lbID, kt.Rid)
+		return nil, err
+	}
+
+	if azureLb.Extension != nil {
+		scope.resourceGroupName = azureLb.Extension.ResourceGroupName
+	}
+
+	return scope, nil
+}
+
+// syncLoadBalancer 云上操作后，同步负载均衡及其监听器、后端服务到db
+func (svc *lbSvc) syncLoadBalancer(kt *kit.Kit, vendor enumor.Vendor, accountID, regionOrResGroup string,
+	cloudIDs []string, bizID int64) error {
+
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		var syncCli synctcloud.Interface
+		if syncCli, err = svc.syncCli.TCloud(kt, accountID); err != nil {
+			return err
+		}
+		params := &synctcloud.SyncBaseParams{AccountID: accountID, Region: regionOrResGroup, CloudIDs: cloudIDs}
+		_, err = syncCli.LoadBalancer(kt, params, &synctcloud.SyncLoadBalancerOption{BkBizID: bizID})
+
+	case enumor.Aws:
+		var syncCli syncaws.Interface
+		if syncCli, err = svc.syncCli.Aws(kt, accountID); err != nil {
+			return err
+		}
+		params := &syncaws.SyncBaseParams{AccountID: accountID, Region: regionOrResGroup, CloudIDs: cloudIDs}
+		_, err = syncCli.LoadBalancer(kt, params, &syncaws.SyncLoadBalancerOption{BkBizID: bizID})
+
+	case enumor.HuaWei:
+		var syncCli synchuawei.Interface
+		if syncCli, err = svc.syncCli.HuaWei(kt, accountID); err != nil {
+			return err
+		}
+		params := &synchuawei.SyncBaseParams{AccountID: accountID, Region: regionOrResGroup, CloudIDs: cloudIDs}
+		_, err = syncCli.LoadBalancer(kt, params, &synchuawei.SyncLoadBalancerOption{BkBizID: bizID})
+
+	case enumor.Gcp:
+		var syncCli syncgcp.Interface
+		if syncCli, err = svc.syncCli.Gcp(kt, accountID); err != nil {
+			return err
+		}
+		params := &syncgcp.SyncBaseParams{AccountID: accountID, CloudIDs: cloudIDs}
+		_, err = syncCli.LoadBalancer(kt, params, &syncgcp.SyncLoadBalancerOption{Region: regionOrResGroup,
+			BkBizID: bizID})
+
+	case enumor.Azure:
+		var syncCli syncazure.Interface
+		if syncCli, err = svc.syncCli.Azure(kt, accountID); err != nil {
+			return err
+		}
+		params := &syncazure.SyncBaseParams{AccountID: accountID, ResourceGroupName: regionOrResGroup,
+			CloudIDs: cloudIDs}
+		_, err = syncCli.LoadBalancer(kt, params, &syncazure.SyncLoadBalancerOption{BkBizID: bizID})
+
+	default:
+		return errf.Newf(errf.InvalidParameter, "vendor: %s not support load balancer", vendor)
+	}
+
+	if err != nil {
+		logs.Errorf("[%s] sync load balancer failed, err: %v, account: %s, cloud_ids: %v, rid: %s", vendor, err,
+			accountID, cloudIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// syncLbByScope 同步 scope 对应的负载均衡
+func (svc *lbSvc) syncLbByScope(kt *kit.Kit, scope *lbScope) error {
+	regionOrResGroup := scope.lb.Region
+	if scope.lb.Vendor == enumor.Azure {
+		regionOrResGroup = scope.resourceGroupName
+	}
+
+	return svc.syncLoadBalancer(kt, scope.lb.Vendor, scope.lb.AccountID, regionOrResGroup,
+		[]string{scope.lb.CloudID}, scope.lb.BkBizID)
+}
+
+func (svc *lbSvc) listLbIDsByCloudIDs(kt *kit.Kit, vendor enumor.Vendor, cloudIDs []string) ([]string, error) {
+	req := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "cloud_id", Op: filter.In.Factory(), Value: cloudIDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.LoadBalancer.ListLoadBalancer(kt, req)
+	if err != nil {
+		logs.Errorf("list load balancer failed, err: %v, cloud_ids: %v, rid: %s", err, cloudIDs, kt.Rid)
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(result.Details))
+	for _, one := range result.Details {
+		ids = append(ids, one.ID)
+	}
+
+	return ids, nil
+}
+
+func parseVendor(cts *rest.Contexts) (enumor.Vendor, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return vendor, nil
+}
@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// RegisterTargets register targets to load balancer listener.
+func (svc *lbSvc) RegisterTargets(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.operateTargets(cts, true)
+}
+
+// DeregisterTargets deregister targets from load balancer listener.
+func (svc *lbSvc) DeregisterTargets(cts *rest.Contexts) (interface{}, error) {
+	return nil, svc.operateTargets(cts, false)
+}
+
+func (svc *lbSvc) operateTargets(cts *rest.Contexts, isRegister bool) error {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return err
+	}
+
+	req := new(hclb.TargetOperateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listener, err := svc.getListener(cts.Kit, req.ListenerID)
+	if err != nil {
+		return err
+	}
+
+	scope, err := svc.getLbScope(cts.Kit, vendor, listener.LbID)
+	if err != nil {
+		return err
+	}
+
+	client, err := svc.lbRelOperator(cts.Kit, vendor, scope.lb.AccountID)
+	if err != nil {
+		return err
+	}
+
+	opt := &typelb.TargetOperateOption{
+		Region:             scope.lb.Region,
+		ResourceGroupName:  scope.resourceGroupName,
+		CloudLbID:          scope.lb.CloudID,
+		CloudListenerID:    listener.CloudID,
+		CloudTargetGroupID: listener.CloudTargetGroupID,
+		Targets:            req.Targets,
+	}
+
+	if isRegister {
+		err = client.RegisterTargets(cts.Kit, opt)
+	} else {
+		err = client.DeregisterTargets(cts.Kit, opt)
+	}
+	if err != nil {
+		logs.Errorf("[%s] operate targets failed, err: %v, register: %v, opt: %+v, rid: %s", vendor, err,
+			isRegister, opt, cts.Kit.Rid)
+		return err
+	}
+
+	return svc.syncLbByScope(cts.Kit, scope)
+}
@@ -0,0 +1,198 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	adcore "hcm/pkg/adaptor/types/core"
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	hcnat "hcm/pkg/api/hc-service/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// CreateTCloudNatGateway create tcloud nat gateway.
+func (svc *natSvc) CreateTCloudNatGateway(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcnat.TCloudNatGatewayCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudID, err := client.CreateNatGateway(cts.Kit, &req.TCloudCreateOption)
+	if err != nil {
+		logs.Errorf("create tcloud nat gateway failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.afterCreateNat(cts.Kit, enumor.TCloud, req.AccountID, req.Region, cloudID, req.BkBizID)
+}
+
+// CreateAwsNatGateway create aws nat gateway.
+func (svc *natSvc) CreateAwsNatGateway(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcnat.AwsNatGatewayCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.Aws(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudID, err := client.CreateNatGateway(cts.Kit, &req.AwsCreateOption)
+	if err != nil {
+		logs.Errorf("create aws nat gateway failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.afterCreateNat(cts.Kit, enumor.Aws, req.AccountID, req.Region, cloudID, req.BkBizID)
+}
+
+// CreateHuaWeiNatGateway create huawei nat gateway.
+func (svc *natSvc) CreateHuaWeiNatGateway(cts *rest.Contexts) (interface{}, error) {
+	req := new(hcnat.HuaWeiNatGatewayCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.ad.HuaWei(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudID, err := client.CreateNatGateway(cts.Kit, &req.HuaWeiCreateOption)
+	if err != nil {
+		logs.Errorf("create huawei nat gateway failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return svc.afterCreateNat(cts.Kit, enumor.HuaWei, req.AccountID, req.Region, cloudID, req.BkBizID)
+}
+
+// afterCreateNat 创建NAT网关后同步到db，并返回db中的id
+func (svc *natSvc) afterCreateNat(kt *kit.Kit, vendor enumor.Vendor, accountID, region, cloudID string,
+	bizID int64) (*core.CreateResult, error) {
+
+	if err := svc.syncNatGateway(kt, vendor, accountID, region, []string{cloudID}, bizID); err != nil {
+		return nil, err
+	}
+
+	req := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{"vendor": vendor, "cloud_id": cloudID}),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.NatGateway.ListNatGateway(kt, req)
+	if err != nil {
+		logs.Errorf("list nat gateway failed, err: %v, cloud_id: %s, rid: %s", err, cloudID, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "nat gateway: %s not found after sync", cloudID)
+	}
+
+	return &core.CreateResult{ID: result.Details[0].ID}, nil
+}
+
+// DeleteNatGateway delete nat gateway, snat/dnat rules of nat gateway will be deleted together.
+func (svc *natSvc) DeleteNatGateway(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	nat, err := svc.getNatGateway(cts.Kit, vendor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.deleteCloudNat(cts.Kit, nat); err != nil {
+		logs.Errorf("[%s] delete nat gateway failed, err: %v, id: %s, rid: %s", vendor, err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 同步时云上已不存在该NAT网关，db数据会被删除；aws 删除为异步操作，状态为 deleting 的网关会在后续同步中清理
+	if err = svc.syncNat(cts.Kit, nat); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *natSvc) deleteCloudNat(kt *kit.Kit, nat *corenat.BaseNatGateway) error {
+	opt := &adcore.BaseRegionalDeleteOption{
+		BaseDeleteOption: adcore.BaseDeleteOption{ResourceID: nat.CloudID},
+		Region:           nat.Region,
+	}
+
+	switch nat.Vendor {
+	case enumor.TCloud:
+		client, err := svc.ad.TCloud(kt, nat.AccountID)
+		if err != nil {
+			return err
+		}
+		return client.DeleteNatGateway(kt, opt)
+
+	case enumor.Aws:
+		client, err := svc.ad.Aws(kt, nat.AccountID)
+		if err != nil {
+			return err
+		}
+		return client.DeleteNatGateway(kt, opt)
+
+	case enumor.HuaWei:
+		client, err := svc.ad.HuaWei(kt, nat.AccountID)
+		if err != nil {
+			return err
+		}
+		return client.DeleteNatGateway(kt, opt)
+
+	default:
+		return errf.Newf(errf.InvalidParameter, "vendor: %s not support nat gateway", nat.Vendor)
+	}
+}
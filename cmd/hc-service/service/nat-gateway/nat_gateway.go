@@ -0,0 +1,173 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway NAT网关相关的云上操作
+package natgateway
+
+import (
+	"net/http"
+
+	cloudadaptor "hcm/cmd/hc-service/logics/cloud-adaptor"
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	syncaws "hcm/cmd/hc-service/logics/res-sync/aws"
+	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
+	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
+	"hcm/cmd/hc-service/service/capability"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitNatGatewayService initial nat gateway service.
+func InitNatGatewayService(cap *capability.Capability) {
+	svc := &natSvc{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+		syncCli: cap.ResSyncCli,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateTCloudNatGateway", http.MethodPost, "/vendors/tcloud/nat_gateways/create",
+		svc.CreateTCloudNatGateway)
+	h.Add("CreateAwsNatGateway", http.MethodPost, "/vendors/aws/nat_gateways/create", svc.CreateAwsNatGateway)
+	h.Add("CreateHuaWeiNatGateway", http.MethodPost, "/vendors/huawei/nat_gateways/create",
+		svc.CreateHuaWeiNatGateway)
+	h.Add("DeleteNatGateway", http.MethodDelete, "/vendors/{vendor}/nat_gateways/{id}", svc.DeleteNatGateway)
+
+	h.Add("CreateNatRule", http.MethodPost, "/vendors/{vendor}/nat_gateways/rules/create", svc.CreateNatRule)
+	h.Add("UpdateNatRule", http.MethodPatch, "/vendors/{vendor}/nat_gateways/rules/{id}", svc.UpdateNatRule)
+	h.Add("BatchDeleteNatRule", http.MethodDelete, "/vendors/{vendor}/nat_gateways/rules/batch",
+		svc.BatchDeleteNatRule)
+
+	h.Load(cap.WebService)
+}
+
+type natSvc struct {
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
+	syncCli ressync.Interface
+}
+
+// natRuleOperator NAT网关SNAT/DNAT规则的云上操作，aws NAT网关没有规则概念，未实现该接口
+type natRuleOperator interface {
+	CreateNatRule(kt *kit.Kit, opt *typenat.RuleCreateOption) (string, error)
+	UpdateNatRule(kt *kit.Kit, opt *typenat.RuleUpdateOption) error
+	DeleteNatRule(kt *kit.Kit, opt *typenat.RuleDeleteOption) error
+}
+
+func (svc *natSvc) natRuleOperator(kt *kit.Kit, vendor enumor.Vendor, accountID string) (natRuleOperator, error) {
+	switch vendor {
+	case enumor.TCloud:
+		return svc.ad.TCloud(kt, accountID)
+	case enumor.HuaWei:
+		return svc.ad.HuaWei(kt, accountID)
+	case enumor.Aws:
+		return nil, errf.New(errf.InvalidParameter, "aws nat gateway does not support snat/dnat rule")
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support nat gateway", vendor)
+	}
+}
+
+func (svc *natSvc) getNatGateway(kt *kit.Kit, vendor enumor.Vendor, id string) (*corenat.BaseNatGateway, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.NatGateway.ListNatGateway(kt, req)
+	if err != nil {
+		logs.Errorf("list nat gateway failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "nat gateway: %s not found", id)
+	}
+
+	nat := result.Details[0]
+	if nat.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "nat gateway: %s vendor is %s, not %s", id, nat.Vendor, vendor)
+	}
+
+	return &nat, nil
+}
+
+// syncNatGateway 云上操作后，同步NAT网关及其SNAT/DNAT规则到db
+func (svc *natSvc) syncNatGateway(kt *kit.Kit, vendor enumor.Vendor, accountID, region string, cloudIDs []string,
+	bizID int64) error {
+
+	var err error
+	switch vendor {
+	case enumor.TCloud:
+		var syncCli synctcloud.Interface
+		if syncCli, err = svc.syncCli.TCloud(kt, accountID); err != nil {
+			return err
+		}
+		params := &synctcloud.SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: cloudIDs}
+		_, err = syncCli.NatGateway(kt, params, &synctcloud.SyncNatGatewayOption{BkBizID: bizID})
+
+	case enumor.Aws:
+		var syncCli syncaws.Interface
+		if syncCli, err = svc.syncCli.Aws(kt, accountID); err != nil {
+			return err
+		}
+		params := &syncaws.SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: cloudIDs}
+		_, err = syncCli.NatGateway(kt, params, &syncaws.SyncNatGatewayOption{BkBizID: bizID})
+
+	case enumor.HuaWei:
+		var syncCli synchuawei.Interface
+		if syncCli, err = svc.syncCli.HuaWei(kt, accountID); err != nil {
+			return err
+		}
+		params := &synchuawei.SyncBaseParams{AccountID: accountID, Region: region, CloudIDs: cloudIDs}
+		_, err = syncCli.NatGateway(kt, params, &synchuawei.SyncNatGatewayOption{BkBizID: bizID})
+
+	default:
+		return errf.Newf(errf.InvalidParameter, "vendor: %s not support nat gateway", vendor)
+	}
+
+	if err != nil {
+		logs.Errorf("[%s] sync nat gateway failed, err: %v, account: %s, cloud_ids: %v, rid: %s", vendor, err,
+			accountID, cloudIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// syncNat 同步单个NAT网关
+func (svc *natSvc) syncNat(kt *kit.Kit, nat *corenat.BaseNatGateway) error {
+	return svc.syncNatGateway(kt, nat.Vendor, nat.AccountID, nat.Region, []string{nat.CloudID}, nat.BkBizID)
+}
+
+func parseVendor(cts *rest.Contexts) (enumor.Vendor, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return vendor, nil
+}
@@ -0,0 +1,314 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	hcnat "hcm/pkg/api/hc-service/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// natRuleDeleteLimit 单次云上删除规则的最大数量
+const natRuleDeleteLimit = 20
+
+// CreateNatRule create nat gateway snat/dnat rule.
+func (svc *natSvc) CreateNatRule(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(hcnat.NatRuleCreateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	nat, err := svc.getNatGateway(cts.Kit, vendor, req.NatGatewayID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := svc.natRuleOperator(cts.Kit, vendor, nat.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt, err := svc.convNatRuleCreateOption(cts.Kit, nat, req)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudID, err := client.CreateNatRule(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("[%s] create nat rule failed, err: %v, opt: %+v, rid: %s", vendor, err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if err = svc.syncNat(cts.Kit, nat); err != nil {
+		return nil, err
+	}
+
+	rules, err := svc.listNatRule(cts.Kit, nat.ID, "cloud_id", []string{cloudID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "nat rule: %s not found after sync", cloudID)
+	}
+
+	return &core.CreateResult{ID: rules[0].ID}, nil
+}
+
+// convNatRuleCreateOption 将请求中的hcm子网、弹性IP转换为云上资源
+func (svc *natSvc) convNatRuleCreateOption(kt *kit.Kit, nat *corenat.BaseNatGateway, req *hcnat.NatRuleCreateReq) (
+	*typenat.RuleCreateOption, error) {
+
+	opt := &typenat.RuleCreateOption{
+		Region:            nat.Region,
+		CloudNatGatewayID: nat.CloudID,
+		RuleType:          req.RuleType,
+		SourceCidr:        req.SourceCidr,
+		Protocol:          req.Protocol,
+		PublicPort:        req.PublicPort,
+		PrivateIP:         req.PrivateIP,
+		PrivatePort:       req.PrivatePort,
+		Memo:              req.Memo,
+	}
+
+	eipReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", req.EipID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	eipResult, err := svc.dataCli.Global.ListEip(kt, eipReq)
+	if err != nil {
+		logs.Errorf("list eip failed, err: %v, id: %s, rid: %s", err, req.EipID, kt.Rid)
+		return nil, err
+	}
+
+	if len(eipResult.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "eip: %s not found", req.EipID)
+	}
+
+	eip := eipResult.Details[0]
+	if eip.AccountID != nat.AccountID || eip.Region != nat.Region {
+		return nil, errf.Newf(errf.InvalidParameter, "eip: %s and nat gateway: %s not in the same account and region",
+			req.EipID, nat.ID)
+	}
+	opt.PublicIP = eip.PublicIp
+	opt.CloudEipID = eip.CloudID
+
+	if len(req.SubnetID) != 0 {
+		subnetReq := &core.ListReq{
+			Filter: tools.EqualExpression("id", req.SubnetID),
+			Page:   core.NewDefaultBasePage(),
+		}
+		subnetResult, err := svc.dataCli.Global.Subnet.List(kt.Ctx, kt.Header(), subnetReq)
+		if err != nil {
+			logs.Errorf("list subnet failed, err: %v, id: %s, rid: %s", err, req.SubnetID, kt.Rid)
+			return nil, err
+		}
+
+		if len(subnetResult.Details) == 0 {
+			return nil, errf.Newf(errf.RecordNotFound, "subnet: %s not found", req.SubnetID)
+		}
+
+		subnet := subnetResult.Details[0]
+		if subnet.CloudVpcID != nat.CloudVpcID {
+			return nil, errf.Newf(errf.InvalidParameter, "subnet: %s not belong to vpc of nat gateway: %s",
+				req.SubnetID, nat.ID)
+		}
+		opt.CloudSubnetID = subnet.CloudID
+	}
+
+	if err = opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return opt, nil
+}
+
+// UpdateNatRule update nat gateway snat/dnat rule.
+func (svc *natSvc) UpdateNatRule(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(hcnat.NatRuleUpdateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	nat, err := svc.getNatGateway(cts.Kit, vendor, req.NatGatewayID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := svc.listNatRule(cts.Kit, nat.ID, "id", []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "nat rule: %s not found in nat gateway: %s", id, nat.ID)
+	}
+	rule := rules[0]
+
+	client, err := svc.natRuleOperator(cts.Kit, vendor, nat.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typenat.RuleUpdateOption{
+		Region:            nat.Region,
+		CloudNatGatewayID: nat.CloudID,
+		RuleType:          rule.RuleType,
+		CloudID:           rule.CloudID,
+		Memo:              req.Memo,
+		PrivateIP:         rule.PrivateIP,
+		PrivatePort:       rule.PrivatePort,
+	}
+	if len(req.PrivateIP) != 0 {
+		opt.PrivateIP = req.PrivateIP
+	}
+	if req.PrivatePort != 0 {
+		opt.PrivatePort = req.PrivatePort
+	}
+
+	if err = client.UpdateNatRule(cts.Kit, opt); err != nil {
+		logs.Errorf("[%s] update nat rule failed, err: %v, opt: %+v, rid: %s", vendor, err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if err = svc.syncNat(cts.Kit, nat); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteNatRule batch delete nat gateway snat/dnat rule.
+func (svc *natSvc) BatchDeleteNatRule(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(hcnat.NatRuleBatchDeleteReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	nat, err := svc.getNatGateway(cts.Kit, vendor, req.NatGatewayID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := svc.listNatRule(cts.Kit, nat.ID, "id", req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) != len(req.IDs) {
+		return nil, errf.Newf(errf.InvalidParameter, "some rules not belong to nat gateway: %s", nat.ID)
+	}
+
+	client, err := svc.natRuleOperator(cts.Kit, vendor, nat.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 云上按规则类型分别删除
+	cloudIDsMap := make(map[enumor.NatRuleType][]string)
+	for _, one := range rules {
+		cloudIDsMap[one.RuleType] = append(cloudIDsMap[one.RuleType], one.CloudID)
+	}
+
+	for ruleType, cloudIDs := range cloudIDsMap {
+		for _, part := range slice.Split(cloudIDs, natRuleDeleteLimit) {
+			opt := &typenat.RuleDeleteOption{
+				Region:            nat.Region,
+				CloudNatGatewayID: nat.CloudID,
+				RuleType:          ruleType,
+				CloudIDs:          part,
+			}
+			if err = client.DeleteNatRule(cts.Kit, opt); err != nil {
+				logs.Errorf("[%s] delete nat rule failed, err: %v, opt: %+v, rid: %s", vendor, err, opt, cts.Kit.Rid)
+				return nil, err
+			}
+		}
+	}
+
+	if err = svc.syncNat(cts.Kit, nat); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// listNatRule list rules of nat gateway by the given field values.
+func (svc *natSvc) listNatRule(kt *kit.Kit, natID string, field string, values []string) (
+	[]corenat.NatGatewayRule, error) {
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "nat_gateway_id", Op: filter.Equal.Factory(), Value: natID},
+				&filter.AtomRule{Field: field, Op: filter.In.Factory(), Value: values},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.NatGateway.ListNatGatewayRule(kt, req)
+	if err != nil {
+		logs.Errorf("list nat rule failed, err: %v, nat: %s, %s: %v, rid: %s", err, natID, field, values, kt.Rid)
+		return nil, err
+	}
+
+	return result.Details, nil
+}
@@ -0,0 +1,374 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag 资源标签相关的云上操作
+package resourcetag
+
+import (
+	"net/http"
+
+	cloudadaptor "hcm/cmd/hc-service/logics/cloud-adaptor"
+	"hcm/cmd/hc-service/service/capability"
+	typetag "hcm/pkg/adaptor/types/tag"
+	"hcm/pkg/api/core"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
+	datatag "hcm/pkg/api/data-service/cloud/resource-tag"
+	hctag "hcm/pkg/api/hc-service/resource-tag"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitResourceTagService initial resource tag service.
+func InitResourceTagService(cap *capability.Capability) {
+	svc := &tagSvc{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("BatchTagResource", http.MethodPost, "/vendors/{vendor}/resource_tags/batch/add", svc.BatchTagResource)
+	h.Add("BatchUntagResource", http.MethodPost, "/vendors/{vendor}/resource_tags/batch/remove",
+		svc.BatchUntagResource)
+
+	h.Load(cap.WebService)
+}
+
+type tagSvc struct {
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
+}
+
+// tagOperator 资源标签的云上操作，各云厂商 adaptor 均实现了该接口
+type tagOperator interface {
+	TagResources(kt *kit.Kit, opt *typetag.TagResOption) error
+	UntagResources(kt *kit.Kit, opt *typetag.UntagResOption) error
+}
+
+func (svc *tagSvc) tagOperator(kt *kit.Kit, vendor enumor.Vendor, accountID string) (tagOperator, error) {
+	switch vendor {
+	case enumor.TCloud:
+		return svc.ad.TCloud(kt, accountID)
+	case enumor.Aws:
+		return svc.ad.Aws(kt, accountID)
+	case enumor.HuaWei:
+		return svc.ad.HuaWei(kt, accountID)
+	case enumor.Gcp:
+		return svc.ad.Gcp(kt, accountID)
+	case enumor.Azure:
+		return svc.ad.Azure(kt, accountID)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support resource tag", vendor)
+	}
+}
+
+// BatchTagResource batch add tags to resources, tag value will be overwritten if tag key already exists.
+func (svc *tagSvc) BatchTagResource(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(hctag.BatchTagResReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.tagOperator(cts.Kit, vendor, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	regionResMap, err := svc.listTagResByRegion(cts.Kit, vendor, req.AccountID, req.ResType, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for region, resources := range regionResMap {
+		opt := &typetag.TagResOption{
+			Region:    region,
+			ResType:   req.ResType,
+			Resources: resources,
+			Tags:      req.Tags,
+		}
+		if err = client.TagResources(cts.Kit, opt); err != nil {
+			logs.Errorf("[%s] tag resources failed, err: %v, opt: %+v, rid: %s", vendor, err, opt, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	mergeFunc := func(tags map[string]string) {
+		for _, tag := range req.Tags {
+			tags[tag.Key] = tag.Value
+		}
+	}
+	return nil, svc.updateResTags(cts.Kit, vendor, req.AccountID, req.ResType, regionResMap, mergeFunc)
+}
+
+// BatchUntagResource batch remove tags from resources.
+func (svc *tagSvc) BatchUntagResource(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(hctag.BatchUntagResReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := svc.tagOperator(cts.Kit, vendor, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	regionResMap, err := svc.listTagResByRegion(cts.Kit, vendor, req.AccountID, req.ResType, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for region, resources := range regionResMap {
+		opt := &typetag.UntagResOption{
+			Region:    region,
+			ResType:   req.ResType,
+			Resources: resources,
+			TagKeys:   req.TagKeys,
+		}
+		if err = client.UntagResources(cts.Kit, opt); err != nil {
+			logs.Errorf("[%s] untag resources failed, err: %v, opt: %+v, rid: %s", vendor, err, opt, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	mergeFunc := func(tags map[string]string) {
+		for _, key := range req.TagKeys {
+			delete(tags, key)
+		}
+	}
+	return nil, svc.updateResTags(cts.Kit, vendor, req.AccountID, req.ResType, regionResMap, mergeFunc)
+}
+
+// tagRes 需要操作标签的资源在 db 中的基本信息
+type tagRes struct {
+	ID        string
+	CloudID   string
+	Name      string
+	Vendor    enumor.Vendor
+	AccountID string
+	Region    string
+	Zone      string
+}
+
+// listTagResByRegion 查询需要操作标签的资源，并按照地域分组
+func (svc *tagSvc) listTagResByRegion(kt *kit.Kit, vendor enumor.Vendor, accountID string,
+	resType enumor.CloudResourceType, ids []string) (map[string][]typetag.TagResItem, error) {
+
+	resources, err := svc.listTagRes(kt, resType, ids)
+	if err != nil {
+		logs.Errorf("list %s for tag failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+		return nil, err
+	}
+
+	if len(resources) != len(ids) {
+		return nil, errf.Newf(errf.RecordNotFound, "some of %s not found, ids: %v", resType, ids)
+	}
+
+	regionResMap := make(map[string][]typetag.TagResItem)
+	for _, one := range resources {
+		if one.Vendor != vendor || one.AccountID != accountID {
+			return nil, errf.Newf(errf.InvalidParameter, "%s: %s not belongs to %s account: %s", resType, one.ID,
+				vendor, accountID)
+		}
+
+		regionResMap[one.Region] = append(regionResMap[one.Region], typetag.TagResItem{
+			CloudID: one.CloudID,
+			Name:    one.Name,
+			Zone:    one.Zone,
+		})
+	}
+
+	return regionResMap, nil
+}
+
+func (svc *tagSvc) listTagRes(kt *kit.Kit, resType enumor.CloudResourceType, ids []string) ([]tagRes, error) {
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: ids},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+
+	resources := make([]tagRes, 0, len(ids))
+	switch resType {
+	case enumor.CvmCloudResType:
+		result, err := svc.dataCli.Global.Cvm.ListCvm(kt, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, one := range result.Details {
+			resources = append(resources, tagRes{ID: one.ID, CloudID: one.CloudID, Name: one.Name,
+				Vendor: one.Vendor, AccountID: one.AccountID, Region: one.Region, Zone: one.Zone})
+		}
+
+	case enumor.DiskCloudResType:
+		result, err := svc.dataCli.Global.ListDisk(kt, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, one := range result.Details {
+			resources = append(resources, tagRes{ID: one.ID, CloudID: one.CloudID, Name: one.Name,
+				Vendor: enumor.Vendor(one.Vendor), AccountID: one.AccountID, Region: one.Region, Zone: one.Zone})
+		}
+
+	case enumor.VpcCloudResType:
+		result, err := svc.dataCli.Global.Vpc.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			return nil, err
+		}
+		for _, one := range result.Details {
+			resources = append(resources, tagRes{ID: one.ID, CloudID: one.CloudID, Name: one.Name,
+				Vendor: one.Vendor, AccountID: one.AccountID, Region: one.Region})
+		}
+
+	case enumor.SubnetCloudResType:
+		result, err := svc.dataCli.Global.Subnet.List(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			return nil, err
+		}
+		for _, one := range result.Details {
+			resources = append(resources, tagRes{ID: one.ID, CloudID: one.CloudID, Name: one.Name,
+				Vendor: one.Vendor, AccountID: one.AccountID, Region: one.Region, Zone: one.Zone})
+		}
+
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "resource type %s does not support tag", resType)
+	}
+
+	return resources, nil
+}
+
+// updateResTags 云上操作成功后，基于 db 中已有的标签合并出资源的最新标签，并全量覆盖到资源标签表
+func (svc *tagSvc) updateResTags(kt *kit.Kit, vendor enumor.Vendor, accountID string,
+	resType enumor.CloudResourceType, regionResMap map[string][]typetag.TagResItem,
+	mergeFunc func(tags map[string]string)) error {
+
+	cloudIDs := make([]string, 0)
+	for _, resources := range regionResMap {
+		cloudIDs = append(cloudIDs, typetag.CloudIDs(resources)...)
+	}
+
+	resTagMap, err := svc.listResTagMap(kt, vendor, resType, cloudIDs)
+	if err != nil {
+		return err
+	}
+
+	items := make([]datatag.ResTagReplaceItem, 0, len(cloudIDs))
+	for _, cloudID := range cloudIDs {
+		tags, exists := resTagMap[cloudID]
+		if !exists {
+			tags = make(map[string]string)
+		}
+		mergeFunc(tags)
+
+		items = append(items, datatag.ResTagReplaceItem{
+			CloudResID: cloudID,
+			Tags:       coretag.NewTagPairsFromMap(tags),
+		})
+	}
+
+	req := &datatag.ResTagBatchReplaceReq{
+		Vendor:    vendor,
+		AccountID: accountID,
+		ResType:   resType,
+		Resources: items,
+	}
+	if err = svc.dataCli.Global.ResourceTag.BatchReplaceResourceTag(kt, req); err != nil {
+		logs.Errorf("replace resource tags failed, err: %v, res_type: %s, cloud_ids: %v, rid: %s", err, resType,
+			cloudIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// listResTagMap 查询资源在 db 中已有的标签, map[cloudResID]map[tagKey]tagValue
+func (svc *tagSvc) listResTagMap(kt *kit.Kit, vendor enumor.Vendor, resType enumor.CloudResourceType,
+	cloudIDs []string) (map[string]map[string]string, error) {
+
+	req := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "vendor", Op: filter.Equal.Factory(), Value: vendor},
+				&filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: resType},
+				&filter.AtomRule{Field: "cloud_res_id", Op: filter.In.Factory(), Value: cloudIDs},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+
+	resTagMap := make(map[string]map[string]string)
+	for {
+		result, err := svc.dataCli.Global.ResourceTag.ListResourceTag(kt, req)
+		if err != nil {
+			logs.Errorf("list resource tag failed, err: %v, res_type: %s, cloud_ids: %v, rid: %s", err, resType,
+				cloudIDs, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			if _, exists := resTagMap[one.CloudResID]; !exists {
+				resTagMap[one.CloudResID] = make(map[string]string)
+			}
+			resTagMap[one.CloudResID][one.Key] = one.Value
+		}
+
+		if uint(len(result.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return resTagMap, nil
+}
+
+func parseVendor(cts *rest.Contexts) (enumor.Vendor, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return vendor, nil
+}
@@ -39,8 +39,13 @@ import (
 	"hcm/cmd/hc-service/service/eip"
 	"hcm/cmd/hc-service/service/firewall"
 	instancetype "hcm/cmd/hc-service/service/instance-type"
+	keypair "hcm/cmd/hc-service/service/key-pair"
+	loadbalancer "hcm/cmd/hc-service/service/load-balancer"
+	natgateway "hcm/cmd/hc-service/service/nat-gateway"
+	resourcetag "hcm/cmd/hc-service/service/resource-tag"
 	routetable "hcm/cmd/hc-service/service/route-table"
 	securitygroup "hcm/cmd/hc-service/service/security-group"
+	"hcm/cmd/hc-service/service/snapshot"
 	"hcm/cmd/hc-service/service/subnet"
 	"hcm/cmd/hc-service/service/sync"
 	"hcm/cmd/hc-service/service/vpc"
@@ -162,6 +167,11 @@ func (s *Service) apiSet() *restful.Container {
 	cvm.InitCvmService(c)
 	routetable.InitRouteTableService(c)
 	eip.InitEipService(c)
+	loadbalancer.InitLoadBalancerService(c)
+	snapshot.InitSnapshotService(c)
+	keypair.InitKeyPairService(c)
+	natgateway.InitNatGatewayService(c)
+	resourcetag.InitResourceTagService(c)
 	instancetype.InitInstanceTypeService(c)
 	sync.InitService(c)
 	bill.InitBillService(c)
@@ -0,0 +1,453 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package snapshot 云盘快照相关的云上操作
+package snapshot
+
+import (
+	"fmt"
+	"net/http"
+
+	cloudadaptor "hcm/cmd/hc-service/logics/cloud-adaptor"
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	syncaws "hcm/cmd/hc-service/logics/res-sync/aws"
+	syncazure "hcm/cmd/hc-service/logics/res-sync/azure"
+	syncgcp "hcm/cmd/hc-service/logics/res-sync/gcp"
+	synchuawei "hcm/cmd/hc-service/logics/res-sync/huawei"
+	synctcloud "hcm/cmd/hc-service/logics/res-sync/tcloud"
+	"hcm/cmd/hc-service/service/capability"
+	typesnapshot "hcm/pkg/adaptor/types/snapshot"
+	"hcm/pkg/api/core"
+	coredisk "hcm/pkg/api/core/cloud/disk"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	hcsnapshot "hcm/pkg/api/hc-service/snapshot"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitSnapshotService initial snapshot service.
+func InitSnapshotService(cap *capability.Capability) {
+	svc := &snapshotSvc{
+		ad:      cap.CloudAdaptor,
+		dataCli: cap.ClientSet.DataService(),
+		syncCli: cap.ResSyncCli,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateSnapshot", http.MethodPost, "/vendors/{vendor}/snapshots/create", svc.CreateSnapshot)
+	h.Add("DeleteSnapshot", http.MethodDelete, "/vendors/{vendor}/snapshots/{id}", svc.DeleteSnapshot)
+	h.Add("RollbackSnapshot", http.MethodPost, "/vendors/{vendor}/snapshots/{id}/rollback", svc.RollbackSnapshot)
+
+	h.Load(cap.WebService)
+}
+
+type snapshotSvc struct {
+	ad      *cloudadaptor.CloudAdaptorClient
+	dataCli *dataservice.Client
+	syncCli ressync.Interface
+}
+
+// snapshotOperator 快照的云上创建、删除操作，各云厂商 adaptor 均实现了该接口
+type snapshotOperator interface {
+	CreateSnapshot(kt *kit.Kit, opt *typesnapshot.CreateOption) (string, error)
+	DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.DeleteOption) error
+}
+
+// snapshotRollbacker 使用快照回滚云盘，仅 tcloud 和 huawei 支持
+type snapshotRollbacker interface {
+	RollbackSnapshot(kt *kit.Kit, opt *typesnapshot.RollbackOption) error
+}
+
+func (svc *snapshotSvc) snapshotOperator(kt *kit.Kit, vendor enumor.Vendor, accountID string) (snapshotOperator,
+	error) {
+
+	switch vendor {
+	case enumor.TCloud:
+		return svc.ad.TCloud(kt, accountID)
+	case enumor.Aws:
+		return svc.ad.Aws(kt, accountID)
+	case enumor.HuaWei:
+		return svc.ad.HuaWei(kt, accountID)
+	case enumor.Gcp:
+		return svc.ad.Gcp(kt, accountID)
+	case enumor.Azure:
+		return svc.ad.Azure(kt, accountID)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support snapshot", vendor)
+	}
+}
+
+func (svc *snapshotSvc) snapshotRollbacker(kt *kit.Kit, vendor enumor.Vendor, accountID string) (
+	snapshotRollbacker, error) {
+
+	switch vendor {
+	case enumor.TCloud:
+		return svc.ad.TCloud(kt, accountID)
+	case enumor.HuaWei:
+		return svc.ad.HuaWei(kt, accountID)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support rollback disk by snapshot", vendor)
+	}
+}
+
+// CreateSnapshot 创建云盘快照，创建后同步到db
+func (svc *snapshotSvc) CreateSnapshot(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	req := new(hcsnapshot.SnapshotCreateReq)
+	if err = cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	disk, err := svc.getDisk(cts.Kit, vendor, req.DiskID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.CreateOption{
+		Region:      disk.Region,
+		Zone:        disk.Zone,
+		CloudDiskID: disk.CloudID,
+		DiskName:    disk.Name,
+		Name:        req.Name,
+		Memo:        req.Memo,
+	}
+	if vendor == enumor.Azure {
+		if opt.ResourceGroupName, err = svc.getAzureDiskResGroup(cts.Kit, disk.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	operator, err := svc.snapshotOperator(cts.Kit, vendor, disk.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudID, err := operator.CreateSnapshot(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("[%s] create snapshot failed, err: %v, disk: %s, rid: %s", vendor, err, disk.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	scope := &snapshotScope{
+		vendor:            vendor,
+		accountID:         disk.AccountID,
+		region:            disk.Region,
+		resourceGroupName: opt.ResourceGroupName,
+		cloudID:           cloudID,
+	}
+	syncOpt := &syncSnapshotOption{bizID: req.BkBizID, policyID: req.PolicyID}
+	if err = svc.syncSnapshot(cts.Kit, scope, syncOpt); err != nil {
+		return nil, err
+	}
+
+	id, err := svc.getSnapshotIDByCloudID(cts.Kit, vendor, disk.AccountID, cloudID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// DeleteSnapshot 删除云盘快照，删除后同步db
+func (svc *snapshotSvc) DeleteSnapshot(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := svc.getSnapshotScope(cts.Kit, vendor, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	operator, err := svc.snapshotOperator(cts.Kit, vendor, scope.accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.DeleteOption{
+		Region:            scope.region,
+		ResourceGroupName: scope.resourceGroupName,
+		CloudID:           scope.cloudID,
+		Name:              scope.snapshot.Name,
+	}
+	if err = operator.DeleteSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("[%s] delete snapshot failed, err: %v, id: %s, rid: %s", vendor, err, scope.snapshot.ID,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 同步时云上已不存在该快照，db数据会被删除
+	if err = svc.syncSnapshot(cts.Kit, scope, new(syncSnapshotOption)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// RollbackSnapshot 使用快照回滚源云盘，回滚前云盘需要处于未挂载或已关机状态，由云上接口校验
+func (svc *snapshotSvc) RollbackSnapshot(cts *rest.Contexts) (interface{}, error) {
+	vendor, err := parseVendor(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := svc.getSnapshotScope(cts.Kit, vendor, cts.PathParameter("id").String())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(scope.snapshot.CloudDiskID) == 0 {
+		return nil, errf.Newf(errf.InvalidParameter, "snapshot: %s source disk not exist", scope.snapshot.ID)
+	}
+
+	rollbacker, err := svc.snapshotRollbacker(cts.Kit, vendor, scope.accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typesnapshot.RollbackOption{
+		Region:      scope.region,
+		CloudID:     scope.cloudID,
+		CloudDiskID: scope.snapshot.CloudDiskID,
+	}
+	if err = rollbacker.RollbackSnapshot(cts.Kit, opt); err != nil {
+		logs.Errorf("[%s] rollback snapshot failed, err: %v, id: %s, rid: %s", vendor, err, scope.snapshot.ID,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// snapshotScope 快照所在的账号、地域和资源组，资源组仅 azure 有效
+type snapshotScope struct {
+	vendor            enumor.Vendor
+	accountID         string
+	region            string
+	resourceGroupName string
+	cloudID           string
+	snapshot          *coresnapshot.BaseSnapshot
+}
+
+func (svc *snapshotSvc) getSnapshotScope(kt *kit.Kit, vendor enumor.Vendor, id string) (*snapshotScope, error) {
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.Snapshot.ListSnapshot(kt, req)
+	if err != nil {
+		logs.Errorf("list snapshot failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "snapshot: %s not found", id)
+	}
+
+	snapshot := result.Details[0]
+	if snapshot.Vendor != vendor {
+		return nil, errf.Newf(errf.InvalidParameter, "snapshot: %s vendor is %s, not %s", id, snapshot.Vendor,
+			vendor)
+	}
+
+	scope := &snapshotScope{
+		vendor:    vendor,
+		accountID: snapshot.AccountID,
+		region:    snapshot.Region,
+		cloudID:   snapshot.CloudID,
+		snapshot:  &snapshot,
+	}
+	if vendor != enumor.Azure {
+		return scope, nil
+	}
+
+	azureSnapshot, err := svc.dataCli.Azure.GetSnapshot(kt, id)
+	if err != nil {
+		logs.Errorf("get azure snapshot failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+
+	if azureSnapshot.Extension != nil {
+		scope.resourceGroupName = azureSnapshot.Extension.ResourceGroupName
+	}
+
+	return scope, nil
+}
+
+func (svc *snapshotSvc) getDisk(kt *kit.Kit, vendor enumor.Vendor, diskID string) (*coredisk.BaseDisk, error) {
+	req := &core.ListReq{
+		Filter: tools.EqualExpression("id", diskID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.ListDisk(kt, req)
+	if err != nil {
+		logs.Errorf("list disk failed, err: %v, id: %s, rid: %s", err, diskID, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "disk: %s not found", diskID)
+	}
+
+	disk := result.Details[0]
+	if disk.Vendor != string(vendor) {
+		return nil, errf.Newf(errf.InvalidParameter, "disk: %s vendor is %s, not %s", diskID, disk.Vendor, vendor)
+	}
+
+	return disk, nil
+}
+
+func (svc *snapshotSvc) getAzureDiskResGroup(kt *kit.Kit, diskID string) (string, error) {
+	disk, err := svc.dataCli.Azure.RetrieveDisk(kt.Ctx, kt.Header(), diskID)
+	if err != nil {
+		logs.Errorf("get azure disk failed, err: %v, id: %s, rid: %s", err, diskID, kt.Rid)
+		return "", err
+	}
+
+	if disk.Extension == nil || len(disk.Extension.ResourceGroupName) == 0 {
+		return "", fmt.Errorf("azure disk: %s resource group name is empty", diskID)
+	}
+
+	return disk.Extension.ResourceGroupName, nil
+}
+
+func (svc *snapshotSvc) getSnapshotIDByCloudID(kt *kit.Kit, vendor enumor.Vendor, accountID, cloudID string) (
+	string, error) {
+
+	req := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: tools.EqualWithOpExpression(filter.And, map[string]interface{}{
+			"vendor":     vendor,
+			"account_id": accountID,
+			"cloud_id":   cloudID,
+		}),
+		Page: core.NewDefaultBasePage(),
+	}
+	result, err := svc.dataCli.Global.Snapshot.ListSnapshot(kt, req)
+	if err != nil {
+		logs.Errorf("list snapshot failed, err: %v, cloud_id: %s, rid: %s", err, cloudID, kt.Rid)
+		return "", err
+	}
+
+	if len(result.Details) == 0 {
+		return "", errf.Newf(errf.RecordNotFound, "snapshot: %s not found after sync", cloudID)
+	}
+
+	return result.Details[0].ID, nil
+}
+
+// syncSnapshotOption 同步新建快照时写入的业务和策略
+type syncSnapshotOption struct {
+	bizID    int64
+	policyID string
+}
+
+// syncSnapshot 云上操作后，同步快照到db
+func (svc *snapshotSvc) syncSnapshot(kt *kit.Kit, scope *snapshotScope, opt *syncSnapshotOption) error {
+	accountID, cloudIDs := scope.accountID, []string{scope.cloudID}
+
+	var err error
+	switch scope.vendor {
+	case enumor.TCloud:
+		var syncCli synctcloud.Interface
+		if syncCli, err = svc.syncCli.TCloud(kt, accountID); err != nil {
+			return err
+		}
+		params := &synctcloud.SyncBaseParams{AccountID: accountID, Region: scope.region, CloudIDs: cloudIDs}
+		_, err = syncCli.Snapshot(kt, params, &synctcloud.SyncSnapshotOption{BkBizID: opt.bizID,
+			PolicyID: opt.policyID})
+
+	case enumor.Aws:
+		var syncCli syncaws.Interface
+		if syncCli, err = svc.syncCli.Aws(kt, accountID); err != nil {
+			return err
+		}
+		params := &syncaws.SyncBaseParams{AccountID: accountID, Region: scope.region, CloudIDs: cloudIDs}
+		_, err = syncCli.Snapshot(kt, params, &syncaws.SyncSnapshotOption{BkBizID: opt.bizID,
+			PolicyID: opt.policyID})
+
+	case enumor.HuaWei:
+		var syncCli synchuawei.Interface
+		if syncCli, err = svc.syncCli.HuaWei(kt, accountID); err != nil {
+			return err
+		}
+		params := &synchuawei.SyncBaseParams{AccountID: accountID, Region: scope.region, CloudIDs: cloudIDs}
+		_, err = syncCli.Snapshot(kt, params, &synchuawei.SyncSnapshotOption{BkBizID: opt.bizID,
+			PolicyID: opt.policyID})
+
+	case enumor.Gcp:
+		var syncCli syncgcp.Interface
+		if syncCli, err = svc.syncCli.Gcp(kt, accountID); err != nil {
+			return err
+		}
+		params := &syncgcp.SyncBaseParams{AccountID: accountID, CloudIDs: cloudIDs}
+		_, err = syncCli.Snapshot(kt, params, &syncgcp.SyncSnapshotOption{BkBizID: opt.bizID,
+			PolicyID: opt.policyID})
+
+	case enumor.Azure:
+		var syncCli syncazure.Interface
+		if syncCli, err = svc.syncCli.Azure(kt, accountID); err != nil {
+			return err
+		}
+		params := &syncazure.SyncBaseParams{AccountID: accountID, ResourceGroupName: scope.resourceGroupName,
+			CloudIDs: cloudIDs}
+		_, err = syncCli.Snapshot(kt, params, &syncazure.SyncSnapshotOption{BkBizID: opt.bizID,
+			PolicyID: opt.policyID})
+
+	default:
+		return errf.Newf(errf.InvalidParameter, "vendor: %s not support snapshot", scope.vendor)
+	}
+
+	if err != nil {
+		logs.Errorf("[%s] sync snapshot failed, err: %v, account: %s, cloud_ids: %v, rid: %s", scope.vendor, err,
+			accountID, cloudIDs, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func parseVendor(cts *rest.Contexts) (enumor.Vendor, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return vendor, nil
+}
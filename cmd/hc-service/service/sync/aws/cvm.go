@@ -49,7 +49,7 @@ type cvmHandler struct {
 	nextToken *string
 }
 
-var _ handler.Handler = new(cvmHandler)
+var _ handler.IncrementalHandler = new(cvmHandler)
 
 // Prepare ...
 func (hd *cvmHandler) Prepare(cts *rest.Contexts) error {
@@ -123,3 +123,8 @@ func (hd *cvmHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
 func (hd *cvmHandler) Name() enumor.CloudResourceType {
 	return enumor.CvmCloudResType
 }
+
+// IncrementalCloudIDs ...
+func (hd *cvmHandler) IncrementalCloudIDs() []string {
+	return hd.request.CloudIDs
+}
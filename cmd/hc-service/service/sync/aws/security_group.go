@@ -49,7 +49,7 @@ type sgHandler struct {
 	nextToken *string
 }
 
-var _ handler.Handler = new(sgHandler)
+var _ handler.IncrementalHandler = new(sgHandler)
 
 // Prepare ...
 func (hd *sgHandler) Prepare(cts *rest.Contexts) error {
@@ -123,3 +123,8 @@ func (hd *sgHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
 func (hd *sgHandler) Name() enumor.CloudResourceType {
 	return enumor.SecurityGroupCloudResType
 }
+
+// IncrementalCloudIDs ...
+func (hd *sgHandler) IncrementalCloudIDs() []string {
+	return hd.request.CloudIDs
+}
@@ -46,12 +46,18 @@ func InitService(cap *capability.Capability) {
 	h.Add("SyncSecurityGroup", "POST", "/security_groups/sync", v.SyncSecurityGroup)
 	h.Add("SyncCvmWithRelRes", "POST", "/cvms/with/relation_resources/sync", v.SyncCvmWithRelRes)
 	h.Add("SyncEip", "POST", "/eips/sync", v.SyncEip)
+	h.Add("SyncLoadBalancer", "POST", "/load_balancers/sync", v.SyncLoadBalancer)
+	h.Add("SyncSnapshot", "POST", "/snapshots/sync", v.SyncSnapshot)
+	h.Add("SyncKeyPair", "POST", "/key_pairs/sync", v.SyncKeyPair)
+	h.Add("SyncNatGateway", "POST", "/nat_gateways/sync", v.SyncNatGateway)
 	h.Add("SyncRoute", "POST", "/route_tables/sync", v.SyncRouteTable)
 	h.Add("SyncZone", "POST", "/zones/sync", v.SyncZone)
 	h.Add("SyncRegion", "POST", "/regions/sync", v.SyncRegion)
 	h.Add("SyncImage", "POST", "/images/sync", v.SyncImage)
 	h.Add("SyncSubAccount", "POST", "/sub_accounts/sync", v.SyncSubAccount)
 
+	h.Add("ListAuditTrailEvent", "POST", "/audit_trails/events/list", v.ListAuditTrailEvent)
+
 	h.Load(cap.WebService)
 }
 
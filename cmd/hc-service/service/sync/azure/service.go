@@ -43,6 +43,8 @@ func InitService(cap *capability.Capability) {
 	h.Add("SyncVpc", "POST", "/vpcs/sync", v.SyncVpc)
 	h.Add("SyncSubnet", "POST", "/subnets/sync", v.SyncSubnet)
 	h.Add("SyncEip", "POST", "/eips/sync", v.SyncEip)
+	h.Add("SyncLoadBalancer", "POST", "/load_balancers/sync", v.SyncLoadBalancer)
+	h.Add("SyncSnapshot", "POST", "/snapshots/sync", v.SyncSnapshot)
 	h.Add("SyncDisk", "POST", "/disks/sync", v.SyncDisk)
 	h.Add("SyncCvmWithRelRes", "POST", "/cvms/with/relation_resources/sync", v.SyncCvmWithRelRes)
 	h.Add("SyncSecurityGroup", "POST", "/security_groups/sync", v.SyncSecurityGroup)
@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/gcp"
+	"hcm/cmd/hc-service/service/sync/handler"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncKeyPair ....
+func (svc *service) SyncKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return nil, handler.ResourceSync(cts, &keyPairHandler{cli: svc.syncCli})
+}
+
+// keyPairHandler key pair sync handler, gcp key pair is saved in project metadata, so all key pairs are listed
+// at first and synced in batches.
+type keyPairHandler struct {
+	cli ressync.Interface
+
+	// Prepare 构建参数
+	request *sync.GcpGlobalSyncReq
+	syncCli gcp.Interface
+	batches [][]string
+	listed  bool
+}
+
+var _ handler.Handler = new(keyPairHandler)
+
+// Prepare ...
+func (hd *keyPairHandler) Prepare(cts *rest.Contexts) error {
+	req := new(sync.GcpGlobalSyncReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	syncCli, err := hd.cli.Gcp(cts.Kit, req.AccountID)
+	if err != nil {
+		return err
+	}
+
+	hd.request = req
+	hd.syncCli = syncCli
+
+	return nil
+}
+
+// Next ...
+func (hd *keyPairHandler) Next(kt *kit.Kit) ([]string, error) {
+	if !hd.listed {
+		keyPairs, err := hd.syncCli.CloudCli().ListKeyPair(kt)
+		if err != nil {
+			logs.Errorf("request adaptor list gcp key pair failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		cloudIDs := make([]string, 0, len(keyPairs))
+		for _, one := range keyPairs {
+			cloudIDs = append(cloudIDs, one.CloudID)
+		}
+		hd.batches = slice.Split(cloudIDs, constant.CloudResourceSyncMaxLimit)
+		hd.listed = true
+	}
+
+	if len(hd.batches) == 0 {
+		return nil, nil
+	}
+
+	cloudIDs := hd.batches[0]
+	hd.batches = hd.batches[1:]
+	return cloudIDs, nil
+}
+
+// Sync ...
+func (hd *keyPairHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	params := &gcp.SyncBaseParams{
+		AccountID: hd.request.AccountID,
+		CloudIDs:  cloudIDs,
+	}
+	if _, err := hd.syncCli.KeyPair(kt, params, new(gcp.SyncKeyPairOption)); err != nil {
+		logs.Errorf("sync gcp key pair failed, err: %v, opt: %v, rid: %s", err, params, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *keyPairHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	if err := hd.syncCli.RemoveKeyPairDeleteFromCloud(kt, hd.request.AccountID); err != nil {
+		logs.Errorf("remove key pair delete from cloud failed, err: %v, accountID: %s, rid: %s", err,
+			hd.request.AccountID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *keyPairHandler) Name() enumor.CloudResourceType {
+	return enumor.KeyPairCloudResType
+}
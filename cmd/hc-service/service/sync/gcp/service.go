@@ -46,6 +46,9 @@ func InitService(cap *capability.Capability) {
 	h.Add("SyncFirewallRule", "POST", "/firewalls/rules/sync", v.SyncFirewallRule)
 	h.Add("SyncCvmWithRelRes", "POST", "/cvms/with/relation_resources/sync", v.SyncCvmWithRelRes)
 	h.Add("SyncEip", "POST", "/eips/sync", v.SyncEip)
+	h.Add("SyncLoadBalancer", "POST", "/load_balancers/sync", v.SyncLoadBalancer)
+	h.Add("SyncSnapshot", "POST", "/snapshots/sync", v.SyncSnapshot)
+	h.Add("SyncKeyPair", "POST", "/key_pairs/sync", v.SyncKeyPair)
 	h.Add("SyncRoute", "POST", "/routes/sync", v.SyncRoute)
 	h.Add("SyncZone", "POST", "/zones/sync", v.SyncZone)
 	h.Add("SyncRegion", "POST", "/regions/sync", v.SyncRegion)
@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
 )
 
 // Handler 定义了全量同步操作函数。
@@ -42,6 +43,13 @@ type Handler interface {
 	Name() enumor.CloudResourceType
 }
 
+// IncrementalHandler 支持按云ID增量同步的同步操作，请求中指定了云ID时，只同步这些资源。
+type IncrementalHandler interface {
+	Handler
+	// IncrementalCloudIDs 返回请求中指定的需要增量同步的云ID，为空时进行全量同步。
+	IncrementalCloudIDs() []string
+}
+
 // ResourceSync 资源同步流程。
 func ResourceSync(cts *rest.Contexts, handler Handler) error {
 	kt := cts.Kit
@@ -52,6 +60,11 @@ func ResourceSync(cts *rest.Contexts, handler Handler) error {
 		return err
 	}
 
+	// 增量同步只同步指定的资源，已从云上删除的资源会在同步时对比删除，不需要进行全量对比
+	if incHandler, ok := handler.(IncrementalHandler); ok && len(incHandler.IncrementalCloudIDs()) != 0 {
+		return incrementalSync(kt, incHandler)
+	}
+
 	if err := handler.RemoveDeleteFromCloud(kt); err != nil {
 		logs.Errorf("%s sync handler to removeDeleteFromCloud failed, err: %v, rid: %s", handler.Name(), err, kt.Rid)
 		return err
@@ -80,3 +93,16 @@ func ResourceSync(cts *rest.Contexts, handler Handler) error {
 
 	return nil
 }
+
+func incrementalSync(kt *kit.Kit, handler IncrementalHandler) error {
+	cloudIDs := slice.Unique(handler.IncrementalCloudIDs())
+	for _, part := range slice.Split(cloudIDs, constant.CloudResourceSyncMaxLimit) {
+		if err := handler.Sync(kt, part); err != nil {
+			logs.Errorf("%s sync handler to incremental sync failed, err: %v, cloudIDs: %v, rid: %s", handler.Name(),
+				err, part, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
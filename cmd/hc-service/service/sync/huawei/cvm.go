@@ -49,7 +49,7 @@ type cvmHandler struct {
 	offset int32
 }
 
-var _ handler.Handler = new(cvmHandler)
+var _ handler.IncrementalHandler = new(cvmHandler)
 
 // Prepare ...
 func (hd *cvmHandler) Prepare(cts *rest.Contexts) error {
@@ -122,3 +122,8 @@ func (hd *cvmHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
 func (hd *cvmHandler) Name() enumor.CloudResourceType {
 	return enumor.CvmCloudResType
 }
+
+// IncrementalCloudIDs ...
+func (hd *cvmHandler) IncrementalCloudIDs() []string {
+	return hd.request.CloudIDs
+}
@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	ressync "hcm/cmd/hc-service/logics/res-sync"
+	"hcm/cmd/hc-service/logics/res-sync/huawei"
+	"hcm/cmd/hc-service/service/sync/handler"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// SyncKeyPair ....
+func (svc *service) SyncKeyPair(cts *rest.Contexts) (interface{}, error) {
+	return nil, handler.ResourceSync(cts, &keyPairHandler{cli: svc.syncCli})
+}
+
+// keyPairHandler key pair sync handler, huawei key pair is paged by marker and can not be queried by names,
+// so all key pairs are listed at first and synced in batches.
+type keyPairHandler struct {
+	cli ressync.Interface
+
+	// Prepare 构建参数
+	request *sync.HuaWeiSyncReq
+	syncCli huawei.Interface
+	batches [][]string
+	listed  bool
+}
+
+var _ handler.Handler = new(keyPairHandler)
+
+// Prepare ...
+func (hd *keyPairHandler) Prepare(cts *rest.Contexts) error {
+	request, syncCli, err := defaultPrepare(cts, hd.cli)
+	if err != nil {
+		return err
+	}
+
+	hd.request = request
+	hd.syncCli = syncCli
+
+	return nil
+}
+
+// Next ...
+func (hd *keyPairHandler) Next(kt *kit.Kit) ([]string, error) {
+	if !hd.listed {
+		cloudIDs := make([]string, 0)
+		listOpt := &typekeypair.HuaWeiListOption{Region: hd.request.Region}
+		for {
+			result, err := hd.syncCli.CloudCli().ListKeyPair(kt, listOpt)
+			if err != nil {
+				logs.Errorf("request adaptor list huawei key pair failed, err: %v, opt: %v, rid: %s", err, listOpt,
+					kt.Rid)
+				return nil, err
+			}
+
+			for _, one := range result.Details {
+				cloudIDs = append(cloudIDs, one.CloudID)
+			}
+
+			if result.NextMarker == nil || len(*result.NextMarker) == 0 || len(result.Details) == 0 {
+				break
+			}
+			listOpt.Marker = result.NextMarker
+		}
+		hd.batches = slice.Split(cloudIDs, constant.CloudResourceSyncMaxLimit)
+		hd.listed = true
+	}
+
+	if len(hd.batches) == 0 {
+		return nil, nil
+	}
+
+	cloudIDs := hd.batches[0]
+	hd.batches = hd.batches[1:]
+	return cloudIDs, nil
+}
+
+// Sync ...
+func (hd *keyPairHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	params := &huawei.SyncBaseParams{
+		AccountID: hd.request.AccountID,
+		Region:    hd.request.Region,
+		CloudIDs:  cloudIDs,
+	}
+	if _, err := hd.syncCli.KeyPair(kt, params, new(huawei.SyncKeyPairOption)); err != nil {
+		logs.Errorf("sync huawei key pair failed, err: %v, opt: %v, rid: %s", err, params, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDeleteFromCloud ...
+func (hd *keyPairHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	err := hd.syncCli.RemoveKeyPairDeleteFromCloud(kt, hd.request.AccountID, hd.request.Region)
+	if err != nil {
+		logs.Errorf("remove key pair delete from cloud failed, err: %v, accountID: %s, region: %s, rid: %s",
+			err, hd.request.AccountID, hd.request.Region, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// Name ...
+func (hd *keyPairHandler) Name() enumor.CloudResourceType {
+	return enumor.KeyPairCloudResType
+}
@@ -50,7 +50,7 @@ type sgHandler struct {
 	marker *string
 }
 
-var _ handler.Handler = new(sgHandler)
+var _ handler.IncrementalHandler = new(sgHandler)
 
 // Prepare ...
 func (hd *sgHandler) Prepare(cts *rest.Contexts) error {
@@ -124,3 +124,8 @@ func (hd *sgHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
 func (hd *sgHandler) Name() enumor.CloudResourceType {
 	return enumor.SecurityGroupCloudResType
 }
+
+// IncrementalCloudIDs ...
+func (hd *sgHandler) IncrementalCloudIDs() []string {
+	return hd.request.CloudIDs
+}
@@ -48,7 +48,7 @@ type sgHandler struct {
 	offset  uint64
 }
 
-var _ handler.Handler = new(sgHandler)
+var _ handler.IncrementalHandler = new(sgHandler)
 
 // Prepare ...
 func (hd *sgHandler) Prepare(cts *rest.Contexts) error {
@@ -122,3 +122,8 @@ func (hd *sgHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
 func (hd *sgHandler) Name() enumor.CloudResourceType {
 	return enumor.SecurityGroupCloudResType
 }
+
+// IncrementalCloudIDs ...
+func (hd *sgHandler) IncrementalCloudIDs() []string {
+	return hd.request.CloudIDs
+}
@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package actionapp 申请单相关的Action，审批通过后按步骤交付申请单的资源
+package actionapp
+
+import (
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	csapplication "hcm/pkg/api/cloud-server/application"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/logs"
+)
+
+// DeliverAction 申请单交付步骤，交付逻辑由cloud-server中申请单类型对应的handler执行，任务流记录每个步骤的状态
+type DeliverAction struct{}
+
+// DeliverOption define deliver application option.
+type DeliverOption struct {
+	ApplicationID string                        `json:"application_id" validate:"required"`
+	Step          enumor.ApplicationDeliverStep `json:"step" validate:"required"`
+}
+
+// Validate DeliverOption.
+func (opt *DeliverOption) Validate() error {
+	if err := opt.Step.Validate(); err != nil {
+		return err
+	}
+
+	return validator.Validate.Struct(opt)
+}
+
+// ParameterNew return deliver application option.
+func (act DeliverAction) ParameterNew() (params interface{}) {
+	return new(DeliverOption)
+}
+
+// Name return action name.
+func (act DeliverAction) Name() enumor.ActionName {
+	return enumor.ActionDeliverApplication
+}
+
+// Run deliver application step.
+func (act DeliverAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*DeliverOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 携带所属任务流ID，申请单只接受当前记录的交付任务流执行交付步骤
+	req := &csapplication.ExecuteDeliverStepReq{FlowID: kt.FlowID()}
+	err := actcli.GetCloudServer().ApplicationClient.ExecuteDeliverStep(kt.Kit(), opt.ApplicationID, opt.Step, req)
+	if err != nil {
+		logs.Errorf("execute application deliver step failed, err: %v, id: %s, step: %s, flow: %s, rid: %s", err,
+			opt.ApplicationID, opt.Step, req.FlowID, kt.Kit().Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -21,6 +21,7 @@ package actcli
 
 import (
 	"hcm/pkg/client"
+	cloudserver "hcm/pkg/client/cloud-server"
 	dataservice "hcm/pkg/client/data-service"
 	hcservice "hcm/pkg/client/hc-service"
 )
@@ -41,3 +42,8 @@ func GetHCService() *hcservice.Client {
 func GetDataService() *dataservice.Client {
 	return cliSet.DataService()
 }
+
+// GetCloudServer get cloud server.
+func GetCloudServer() *cloudserver.Client {
+	return cliSet.CloudServer()
+}
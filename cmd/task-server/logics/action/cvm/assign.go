@@ -47,6 +47,8 @@ type AssignCvmAction struct{}
 // AssignCvmOption assign cvm option.
 type AssignCvmOption struct {
 	BizID int64 `json:"bk_biz_id" validate:"required"`
+	// CloudIDKey 保存待分配主机云ID的共享数据键，为空时使用 SaveCreateCvmCloudIDKey
+	CloudIDKey string `json:"cloud_id_key,omitempty" validate:"omitempty"`
 }
 
 // Validate AssignCvmOption.
@@ -71,7 +73,11 @@ func (act AssignCvmAction) Run(kt run.ExecuteKit, params interface{}) (result in
 		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
 	}
 
-	idsStr, exist := kt.ShareData().Get(SaveCreateCvmCloudIDKey)
+	key := opt.CloudIDKey
+	if len(key) == 0 {
+		key = SaveCreateCvmCloudIDKey
+	}
+	idsStr, exist := kt.ShareData().Get(key)
 	if !exist {
 		return nil, fmt.Errorf("cvm_cloud_ids is required for assign")
 	}
@@ -45,14 +45,62 @@ var _ action.ParameterAction = new(CreateCvmAction)
 // CreateCvmAction define create cvm action.
 type CreateCvmAction struct{}
 
-// CreateOption define create cvm option.
+// CreateOption define create cvm option, only the request of the vendor is serialized by MarshalJSON, so the
+// embedded requests are ignored by default json encoding to avoid conflicting json tags between vendors.
 type CreateOption struct {
 	Vendor                     enumor.Vendor `json:"vendor" validate:"required"`
-	hccvm.TCloudBatchCreateReq `json:",inline"`
-	hccvm.AwsBatchCreateReq    `json:",inline"`
-	hccvm.HuaWeiBatchCreateReq `json:",inline"`
-	hccvm.GcpBatchCreateReq    `json:",inline"`
-	hccvm.AzureCreateReq       `json:",inline"`
+	CreateExtOption            `json:",inline"`
+	hccvm.TCloudBatchCreateReq `json:"-"`
+	hccvm.AwsBatchCreateReq    `json:"-"`
+	hccvm.HuaWeiBatchCreateReq `json:"-"`
+	hccvm.GcpBatchCreateReq    `json:"-"`
+	hccvm.AzureCreateReq       `json:"-"`
+}
+
+// CreateExtOption 创建主机的扩展参数，与厂商的创建请求一起序列化
+type CreateExtOption struct {
+	// SaveKey 保存创建成功的主机云ID的共享数据键，为空时使用 SaveCreateCvmCloudIDKey
+	SaveKey string `json:"save_key,omitempty"`
+	// CloudIDKeys 不为空时从共享数据中获取前置任务创建的资源云ID填充到创建请求中，目前只支持腾讯云
+	CloudIDKeys *CloudIDKeys `json:"cloud_id_keys,omitempty"`
+}
+
+// CloudIDKeys 主机所属网络资源的云ID在共享数据中的键，用于在同一任务流中先创建网络资源再创建主机
+type CloudIDKeys struct {
+	CloudVpcID            string   `json:"cloud_vpc_id,omitempty"`
+	CloudSubnetID         string   `json:"cloud_subnet_id,omitempty"`
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids,omitempty"`
+}
+
+// fill 从共享数据中获取资源云ID填充到腾讯云创建请求中
+func (keys *CloudIDKeys) fill(kt run.ExecuteKit, req *hccvm.TCloudBatchCreateReq) error {
+	if len(keys.CloudVpcID) != 0 {
+		ids, err := run.GetIDs(kt.ShareData(), keys.CloudVpcID)
+		if err != nil {
+			return err
+		}
+		req.CloudVpcID = ids[0]
+	}
+
+	if len(keys.CloudSubnetID) != 0 {
+		ids, err := run.GetIDs(kt.ShareData(), keys.CloudSubnetID)
+		if err != nil {
+			return err
+		}
+		req.CloudSubnetID = ids[0]
+	}
+
+	sgIDs := append([]string{}, req.CloudSecurityGroupIDs...)
+	for _, key := range keys.CloudSecurityGroupIDs {
+		ids, err := run.GetIDs(kt.ShareData(), key)
+		if err != nil {
+			return err
+		}
+		sgIDs = append(sgIDs, ids...)
+	}
+	req.CloudSecurityGroupIDs = sgIDs
+
+	return nil
 }
 
 // MarshalJSON CreateOption.
@@ -63,42 +111,52 @@ func (opt CreateOption) MarshalJSON() ([]byte, error) {
 	case enumor.TCloud:
 		req = struct {
 			Vendor                     enumor.Vendor `json:"vendor" validate:"required"`
+			CreateExtOption            `json:",inline"`
 			hccvm.TCloudBatchCreateReq `json:",inline"`
 		}{
 			Vendor:               opt.Vendor,
+			CreateExtOption:      opt.CreateExtOption,
 			TCloudBatchCreateReq: opt.TCloudBatchCreateReq,
 		}
 	case enumor.Aws:
 		req = struct {
 			Vendor                  enumor.Vendor `json:"vendor" validate:"required"`
+			CreateExtOption         `json:",inline"`
 			hccvm.AwsBatchCreateReq `json:",inline"`
 		}{
 			Vendor:            opt.Vendor,
+			CreateExtOption:   opt.CreateExtOption,
 			AwsBatchCreateReq: opt.AwsBatchCreateReq,
 		}
 	case enumor.HuaWei:
 		req = struct {
 			Vendor                     enumor.Vendor `json:"vendor" validate:"required"`
+			CreateExtOption            `json:",inline"`
 			hccvm.HuaWeiBatchCreateReq `json:",inline"`
 		}{
 			Vendor:               opt.Vendor,
+			CreateExtOption:      opt.CreateExtOption,
 			HuaWeiBatchCreateReq: opt.HuaWeiBatchCreateReq,
 		}
 	case enumor.Gcp:
 		req = struct {
 			Vendor                  enumor.Vendor `json:"vendor" validate:"required"`
+			CreateExtOption         `json:",inline"`
 			hccvm.GcpBatchCreateReq `json:",inline"`
 		}{
 			Vendor:            opt.Vendor,
+			CreateExtOption:   opt.CreateExtOption,
 			GcpBatchCreateReq: opt.GcpBatchCreateReq,
 		}
 	case enumor.Azure:
 		req = struct {
 			Vendor               enumor.Vendor `json:"vendor" validate:"required"`
+			CreateExtOption      `json:",inline"`
 			hccvm.AzureCreateReq `json:",inline"`
 		}{
-			Vendor:         opt.Vendor,
-			AzureCreateReq: opt.AzureCreateReq,
+			Vendor:          opt.Vendor,
+			CreateExtOption: opt.CreateExtOption,
+			AzureCreateReq:  opt.AzureCreateReq,
 		}
 	default:
 		return nil, fmt.Errorf("vendor: %s not support", opt.Vendor)
@@ -110,6 +168,9 @@ func (opt CreateOption) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON CreateOption.
 func (opt *CreateOption) UnmarshalJSON(raw []byte) (err error) {
 	opt.Vendor = enumor.Vendor(gjson.GetBytes(raw, "vendor").String())
+	if err = json.Unmarshal(raw, &opt.CreateExtOption); err != nil {
+		return err
+	}
 
 	switch opt.Vendor {
 	case enumor.TCloud:
@@ -135,6 +196,14 @@ func (opt CreateOption) Validate() error {
 		return err
 	}
 
+	if opt.CloudIDKeys != nil {
+		if opt.Vendor != enumor.TCloud {
+			return fmt.Errorf("vendor: %s not support create cvm with cloud id keys", opt.Vendor)
+		}
+		// 引用的资源云ID在执行时才填充，因此创建请求在执行时校验
+		return nil
+	}
+
 	var req validator.Interface
 	switch opt.Vendor {
 	case enumor.TCloud:
@@ -175,6 +244,20 @@ func (act CreateCvmAction) Run(kt run.ExecuteKit, params interface{}) (interface
 		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
 	}
 
+	if opt.CloudIDKeys != nil {
+		if err := opt.Validate(); err != nil {
+			return nil, err
+		}
+
+		if err := opt.CloudIDKeys.fill(kt, &opt.TCloudBatchCreateReq); err != nil {
+			return nil, err
+		}
+
+		if err := opt.TCloudBatchCreateReq.Validate(); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
 	var result *hccvm.BatchCreateResult
 	var err error
 	switch opt.Vendor {
@@ -202,13 +285,20 @@ func (act CreateCvmAction) Run(kt run.ExecuteKit, params interface{}) (interface
 		return result, err
 	}
 
-	if len(result.FailedMessage) != 0 {
-		return result, errors.New(result.FailedMessage)
+	// 部分主机创建成功时也需要保存，保证失败后能够获取到已创建的主机进行回收
+	if len(result.SuccessCloudIDs) != 0 {
+		saveKey := opt.SaveKey
+		if len(saveKey) == 0 {
+			saveKey = SaveCreateCvmCloudIDKey
+		}
+		if err = kt.ShareData().AppendIDs(kt.Kit(), saveKey, result.SuccessCloudIDs...); err != nil {
+			logs.Errorf("share data appendIDs failed, err: %v, rid: %s", err, kt.Kit().Rid)
+			return result, err
+		}
 	}
 
-	if err = kt.ShareData().AppendIDs(kt.Kit(), SaveCreateCvmCloudIDKey, result.SuccessCloudIDs...); err != nil {
-		logs.Errorf("share data appendIDs failed, err: %v, rid: %s", err, kt.Kit().Rid)
-		return result, err
+	if len(result.FailedMessage) != 0 {
+		return result, errors.New(result.FailedMessage)
 	}
 
 	return result, nil
@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actioncvm
+
+import (
+	"hcm/pkg/async/action"
+	"hcm/pkg/criteria/enumor"
+)
+
+/*
+	StartCvmTpl、StopCvmTpl: 单个账号、地域下主机开机、关机任务流模版，主要用于定时计划按模版创建任务流，
+	例如每晚关闭、每早开启开发环境主机。任务参数为 CvmOperationOption。
+*/
+
+// StartCvmTpl start cvm flow template.
+var StartCvmTpl = action.FlowTemplate{
+	Name: enumor.FlowStartCvm,
+	Tasks: []action.TaskTemplate{
+		{
+			ActionID:   "1",
+			ActionName: enumor.ActionStartCvm,
+			Params: &action.Params{
+				Type: CvmOperationOption{},
+			},
+		},
+	},
+}
+
+// StopCvmTpl stop cvm flow template.
+var StopCvmTpl = action.FlowTemplate{
+	Name: enumor.FlowStopCvm,
+	Tasks: []action.TaskTemplate{
+		{
+			ActionID:   "1",
+			ActionName: enumor.ActionStopCvm,
+			Params: &action.Params{
+				Type: CvmOperationOption{},
+			},
+		},
+	},
+}
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package actiondisk 硬盘相关的Action
+package actiondisk
+
+import (
+	"errors"
+	"fmt"
+
+	logicsdisk "hcm/cmd/cloud-server/logics/disk"
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/api/core"
+	hcdisk "hcm/pkg/api/hc-service/disk"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/logs"
+)
+
+// SaveCreateDiskCloudIDKey 创建成功的硬盘云ID列表
+const SaveCreateDiskCloudIDKey = "create_disk_cloud_ids"
+
+var _ action.Action = new(CreateDiskAction)
+var _ action.ParameterAction = new(CreateDiskAction)
+
+// CreateDiskAction 创建硬盘，创建成功后分配到指定业务下
+type CreateDiskAction struct{}
+
+// CreateDiskOption 创建硬盘的参数，目前只支持腾讯云
+type CreateDiskOption struct {
+	Vendor enumor.Vendor `json:"vendor" validate:"required"`
+	// BkBizID 硬盘创建后分配的业务，为0时不分配
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+	// SaveKey 保存创建成功的硬盘云ID的共享数据键，为空时使用 SaveCreateDiskCloudIDKey
+	SaveKey    string                      `json:"save_key" validate:"omitempty"`
+	TCloudDisk *hcdisk.TCloudDiskCreateReq `json:"tcloud_disk" validate:"omitempty"`
+}
+
+// Validate CreateDiskOption.
+func (opt CreateDiskOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	switch opt.Vendor {
+	case enumor.TCloud:
+		if opt.TCloudDisk == nil {
+			return fmt.Errorf("tcloud_disk is required")
+		}
+		return opt.TCloudDisk.Validate()
+	default:
+		return fmt.Errorf("vendor: %s not support create disk by async task", opt.Vendor)
+	}
+}
+
+// ParameterNew return create disk params.
+func (act CreateDiskAction) ParameterNew() (params interface{}) {
+	return new(CreateDiskOption)
+}
+
+// Name return action name.
+func (act CreateDiskAction) Name() enumor.ActionName {
+	return enumor.ActionCreateDisk
+}
+
+// Run create disk.
+func (act CreateDiskAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*CreateDiskOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	result, err := actcli.GetHCService().TCloud.Disk.CreateDisk(kt.Kit().Ctx, kt.Kit().Header(), opt.TCloudDisk)
+	if err != nil {
+		logs.Errorf("create disk failed, err: %v, vendor: %s, rid: %s", err, opt.Vendor, kt.Kit().Rid)
+		return result, err
+	}
+
+	// 部分硬盘创建成功时也需要保存，保证后续任务能够获取到已创建的硬盘
+	if len(result.SuccessCloudIDs) != 0 {
+		saveKey := opt.SaveKey
+		if len(saveKey) == 0 {
+			saveKey = SaveCreateDiskCloudIDKey
+		}
+		if err = kt.ShareData().AppendIDs(kt.Kit(), saveKey, result.SuccessCloudIDs...); err != nil {
+			logs.Errorf("share data appendIDs failed, err: %v, rid: %s", err, kt.Kit().Rid)
+			return result, err
+		}
+	}
+
+	if len(result.FailedMessage) != 0 {
+		return result, errors.New(result.FailedMessage)
+	}
+
+	if opt.BkBizID == 0 || len(result.SuccessCloudIDs) == 0 {
+		return result, nil
+	}
+
+	listReq := &core.ListReq{
+		Fields: []string{"id"},
+		Filter: tools.ContainersExpression("cloud_id", result.SuccessCloudIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	disks, err := actcli.GetDataService().Global.ListDisk(kt.Kit(), listReq)
+	if err != nil {
+		logs.Errorf("list disk failed, err: %v, cloud ids: %v, rid: %s", err, result.SuccessCloudIDs, kt.Kit().Rid)
+		return result, err
+	}
+
+	ids := make([]string, 0, len(disks.Details))
+	for _, one := range disks.Details {
+		ids = append(ids, one.ID)
+	}
+	if err = logicsdisk.Assign(kt.Kit(), actcli.GetDataService(), ids, uint64(opt.BkBizID), false); err != nil {
+		logs.Errorf("assign disk failed, err: %v, ids: %v, rid: %s", err, ids, kt.Kit().Rid)
+		return result, err
+	}
+
+	return result, nil
+}
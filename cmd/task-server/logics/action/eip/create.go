@@ -0,0 +1,124 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actioneip
+
+import (
+	"fmt"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/api/core"
+	hcproto "hcm/pkg/api/hc-service/eip"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/logs"
+)
+
+// SaveCreateEIPCloudIDKey 创建成功的弹性IP云ID列表
+const SaveCreateEIPCloudIDKey = "create_eip_cloud_ids"
+
+// CreateEIPAction eip create action
+type CreateEIPAction struct {
+}
+
+// CreateEIPOption 创建弹性IP的参数，目前只支持腾讯云，弹性IP创建时分配到请求中指定的业务下
+type CreateEIPOption struct {
+	Vendor enumor.Vendor `json:"vendor" validate:"required"`
+	// SaveKey 保存创建成功的弹性IP云ID的共享数据键，为空时使用 SaveCreateEIPCloudIDKey
+	SaveKey   string                      `json:"save_key" validate:"omitempty"`
+	TCloudEip *hcproto.TCloudEipCreateReq `json:"tcloud_eip" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt *CreateEIPOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	switch opt.Vendor {
+	case enumor.TCloud:
+		if opt.TCloudEip == nil {
+			return fmt.Errorf("tcloud_eip is required")
+		}
+		return opt.TCloudEip.Validate()
+	default:
+		return fmt.Errorf("vendor: %s not support create eip by async task", opt.Vendor)
+	}
+}
+
+// ParameterNew returns parameter of
+func (s CreateEIPAction) ParameterNew() (params interface{}) {
+	return new(CreateEIPOption)
+}
+
+// Name ActionCreateEIP
+func (s CreateEIPAction) Name() enumor.ActionName {
+	return enumor.ActionCreateEIP
+}
+
+// Run ...
+func (s CreateEIPAction) Run(kt run.ExecuteKit, params any) (any, error) {
+	opt, ok := params.(*CreateEIPOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	result, err := actcli.GetHCService().TCloud.Eip.CreateEip(kt.Kit().Ctx, kt.Kit().Header(), opt.TCloudEip)
+	if err != nil {
+		logs.Errorf("create eip failed, err: %v, vendor: %s, rid: %s", err, opt.Vendor, kt.Kit().Rid)
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: tools.ContainersExpression("id", result.IDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	eips, err := actcli.GetDataService().Global.ListEip(kt.Kit(), listReq)
+	if err != nil {
+		logs.Errorf("list eip failed, err: %v, ids: %v, rid: %s", err, result.IDs, kt.Kit().Rid)
+		return result, err
+	}
+
+	cloudIDs := make([]string, 0, len(eips.Details))
+	for _, one := range eips.Details {
+		cloudIDs = append(cloudIDs, one.CloudID)
+	}
+	if len(cloudIDs) == 0 {
+		return result, fmt.Errorf("eip: %v not found after create", result.IDs)
+	}
+
+	saveKey := opt.SaveKey
+	if len(saveKey) == 0 {
+		saveKey = SaveCreateEIPCloudIDKey
+	}
+	if err = kt.ShareData().AppendIDs(kt.Kit(), saveKey, cloudIDs...); err != nil {
+		logs.Errorf("share data appendIDs failed, err: %v, rid: %s", err, kt.Kit().Rid)
+		return result, err
+	}
+
+	return result, nil
+}
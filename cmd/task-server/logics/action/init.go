@@ -20,12 +20,17 @@
 package logicsaction
 
 import (
+	actionapp "hcm/cmd/task-server/logics/action/application"
 	actcli "hcm/cmd/task-server/logics/action/cli"
 	actioncvm "hcm/cmd/task-server/logics/action/cvm"
+	actiondisk "hcm/cmd/task-server/logics/action/disk"
 	actioneip "hcm/cmd/task-server/logics/action/eip"
 	actionfirewall "hcm/cmd/task-server/logics/action/firewall"
 	actionsg "hcm/cmd/task-server/logics/action/security-group"
+	actionsnapshot "hcm/cmd/task-server/logics/action/snapshot"
+	actionstack "hcm/cmd/task-server/logics/action/stack"
 	actionsubnet "hcm/cmd/task-server/logics/action/subnet"
+	actionvpc "hcm/cmd/task-server/logics/action/vpc"
 	"hcm/pkg/async/action"
 	"hcm/pkg/client"
 )
@@ -47,9 +52,20 @@ func register() {
 
 	action.RegisterAction(actionfirewall.DeleteAction{})
 
+	action.RegisterAction(actionvpc.CreateVpcAction{})
+	action.RegisterAction(actionsubnet.CreateAction{})
 	action.RegisterAction(actionsubnet.DeleteAction{})
+	action.RegisterAction(actionsg.CreateSgAction{})
 	action.RegisterAction(actionsg.DeleteSgAction{})
 	action.RegisterAction(actionsg.CreateHuaweiSGRuleAction{})
+	action.RegisterAction(actiondisk.CreateDiskAction{})
+	action.RegisterAction(actioneip.CreateEIPAction{})
 	action.RegisterAction(actioneip.DeleteEIPAction{})
+	action.RegisterAction(actionsnapshot.CreateSnapshotAction{})
+	action.RegisterAction(actionsnapshot.DeleteSnapshotAction{})
+	action.RegisterAction(actionstack.DeleteResourceAction{})
+	action.RegisterAction(actionapp.DeliverAction{})
 
+	action.RegisterTpl(actioncvm.StartCvmTpl)
+	action.RegisterTpl(actioncvm.StopCvmTpl)
 }
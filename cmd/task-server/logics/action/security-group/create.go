@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionsg
+
+import (
+	"fmt"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	hcservice "hcm/pkg/api/hc-service"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/logs"
+)
+
+// SaveCreateSGCloudIDKey 创建成功的安全组云ID
+const SaveCreateSGCloudIDKey = "create_security_group_cloud_ids"
+
+// CreateSgAction security group create action
+type CreateSgAction struct {
+}
+
+// CreateSGOption 创建安全组的参数，目前只支持腾讯云，安全组创建时分配到请求中指定的业务下
+type CreateSGOption struct {
+	Vendor enumor.Vendor `json:"vendor" validate:"required"`
+	// SaveKey 保存创建成功的安全组云ID的共享数据键，为空时使用 SaveCreateSGCloudIDKey
+	SaveKey             string                                  `json:"save_key" validate:"omitempty"`
+	TCloudSecurityGroup *hcservice.TCloudSecurityGroupCreateReq `json:"tcloud_security_group" validate:"omitempty"`
+}
+
+// Validate ...
+func (opt *CreateSGOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	switch opt.Vendor {
+	case enumor.TCloud:
+		if opt.TCloudSecurityGroup == nil {
+			return fmt.Errorf("tcloud_security_group is required")
+		}
+		return opt.TCloudSecurityGroup.Validate()
+	default:
+		return fmt.Errorf("vendor: %s not support create security group by async task", opt.Vendor)
+	}
+}
+
+// ParameterNew returns parameter of
+func (s CreateSgAction) ParameterNew() (params interface{}) {
+	return new(CreateSGOption)
+}
+
+// Name ActionCreateSecurityGroup
+func (s CreateSgAction) Name() enumor.ActionName {
+	return enumor.ActionCreateSecurityGroup
+}
+
+// Run ...
+func (s CreateSgAction) Run(kt run.ExecuteKit, params any) (any, error) {
+	opt, ok := params.(*CreateSGOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	result, err := actcli.GetHCService().TCloud.SecurityGroup.CreateSecurityGroup(kt.Kit().Ctx, kt.Kit().Header(),
+		opt.TCloudSecurityGroup)
+	if err != nil {
+		logs.Errorf("create security group failed, err: %v, vendor: %s, rid: %s", err, opt.Vendor, kt.Kit().Rid)
+		return nil, err
+	}
+
+	listReq := &protocloud.SecurityGroupListReq{
+		Field:  []string{"id", "cloud_id"},
+		Filter: tools.EqualExpression("id", result.ID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	sgs, err := actcli.GetDataService().Global.SecurityGroup.ListSecurityGroup(kt.Kit().Ctx, kt.Kit().Header(),
+		listReq)
+	if err != nil {
+		logs.Errorf("list security group failed, err: %v, id: %s, rid: %s", err, result.ID, kt.Kit().Rid)
+		return result, err
+	}
+	if len(sgs.Details) == 0 {
+		return result, fmt.Errorf("security group: %s not found after create", result.ID)
+	}
+
+	saveKey := opt.SaveKey
+	if len(saveKey) == 0 {
+		saveKey = SaveCreateSGCloudIDKey
+	}
+	if err = kt.ShareData().AppendIDs(kt.Kit(), saveKey, sgs.Details[0].CloudID); err != nil {
+		logs.Errorf("share data appendIDs failed, err: %v, rid: %s", err, kt.Kit().Rid)
+		return result, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package actionsnapshot ...
+package actionsnapshot
+
+import (
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/api/core"
+	hcsnapshot "hcm/pkg/api/hc-service/snapshot"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/logs"
+)
+
+// CreateSnapshotAction snapshot create action
+type CreateSnapshotAction struct {
+}
+
+// CreateSnapshotOption ...
+type CreateSnapshotOption struct {
+	Vendor                       enumor.Vendor `json:"vendor" validate:"required"`
+	hcsnapshot.SnapshotCreateReq `json:",inline"`
+}
+
+// Validate ...
+func (opt *CreateSnapshotOption) Validate() error {
+	if err := opt.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	return validator.Validate.Struct(opt)
+}
+
+// ParameterNew returns parameter of
+func (s CreateSnapshotAction) ParameterNew() (params interface{}) {
+	return new(CreateSnapshotOption)
+}
+
+// Name ActionCreateSnapshot
+func (s CreateSnapshotAction) Name() enumor.ActionName {
+	return enumor.ActionCreateSnapshot
+}
+
+// Run ...
+func (s CreateSnapshotAction) Run(kt run.ExecuteKit, params any) (any, error) {
+	opt, ok := params.(*CreateSnapshotOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	cli := actcli.GetHCService()
+	var result *core.CreateResult
+	var err error
+	switch opt.Vendor {
+	case enumor.TCloud:
+		result, err = cli.TCloud.Snapshot.CreateSnapshot(kt.Kit(), &opt.SnapshotCreateReq)
+	case enumor.Aws:
+		result, err = cli.Aws.Snapshot.CreateSnapshot(kt.Kit(), &opt.SnapshotCreateReq)
+	case enumor.HuaWei:
+		result, err = cli.HuaWei.Snapshot.CreateSnapshot(kt.Kit(), &opt.SnapshotCreateReq)
+	case enumor.Gcp:
+		result, err = cli.Gcp.Snapshot.CreateSnapshot(kt.Kit(), &opt.SnapshotCreateReq)
+	case enumor.Azure:
+		result, err = cli.Azure.Snapshot.CreateSnapshot(kt.Kit(), &opt.SnapshotCreateReq)
+	default:
+		return nil, errf.Newf(errf.Unknown, "disk: %s vendor: %s not support", opt.DiskID, opt.Vendor)
+	}
+	if err != nil {
+		logs.Errorf("create snapshot failed, err: %v, vendor: %s, opt: %+v, rid: %s",
+			err, opt.Vendor, opt, kt.Kit().Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,214 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionstack
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/api/core"
+	hccvm "hcm/pkg/api/hc-service/cvm"
+	hcdisk "hcm/pkg/api/hc-service/disk"
+	hceip "hcm/pkg/api/hc-service/eip"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+var _ action.Action = new(DeleteResourceAction)
+var _ action.ParameterAction = new(DeleteResourceAction)
+
+// DeleteResourceAction 删除资源栈资源，用于销毁资源栈以及apply失败时回滚本次创建的资源
+type DeleteResourceAction struct{}
+
+// DeleteResourceOption 删除资源栈资源的参数，IDs为空时按Name从共享数据中获取本任务流创建的资源云ID，用于回滚
+type DeleteResourceOption struct {
+	Vendor    enumor.Vendor            `json:"vendor" validate:"required"`
+	AccountID string                   `json:"account_id" validate:"required"`
+	Region    string                   `json:"region" validate:"required"`
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Name      string                   `json:"name" validate:"omitempty"`
+	IDs       []string                 `json:"ids" validate:"omitempty"`
+}
+
+// Validate DeleteResourceOption.
+func (opt DeleteResourceOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if opt.Vendor != enumor.TCloud {
+		return fmt.Errorf("vendor: %s not support", opt.Vendor)
+	}
+
+	if len(opt.IDs) == 0 && len(opt.Name) == 0 {
+		return errors.New("ids or name is required")
+	}
+
+	return nil
+}
+
+// ParameterNew return delete resource params.
+func (act DeleteResourceAction) ParameterNew() (params interface{}) {
+	return new(DeleteResourceOption)
+}
+
+// Name return action name.
+func (act DeleteResourceAction) Name() enumor.ActionName {
+	return enumor.ActionDeleteStackResource
+}
+
+// Run delete stack resource.
+func (act DeleteResourceAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*DeleteResourceOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 已经不存在的资源不再删除，保证重复执行时不会因资源不存在而失败
+	field, values := "id", opt.IDs
+	rollback := len(values) == 0
+	if rollback {
+		val, exist := kt.ShareData().Get(ResourceKey(opt.ResType, opt.Name))
+		// 资源未创建，不需要回滚
+		if !exist || len(val) == 0 {
+			return nil, nil
+		}
+		field, values = "cloud_id", tableasync.ParseIDsStr(val)
+	}
+
+	existIDs, _, err := ListResource(kt.Kit(), actcli.GetDataService(), opt.ResType, field, values)
+	if err != nil {
+		return nil, err
+	}
+
+	failedIDs, err := deleteResource(kt, opt, existIDs)
+	if err != nil {
+		logs.Errorf("delete stack resource %s failed, err: %v, ids: %v, failed: %v, rid: %s", opt.ResType, err,
+			existIDs, failedIDs, kt.Kit().Rid)
+	}
+
+	// 回滚后只保留删除失败的资源，资源栈继续记录这些资源
+	if rollback {
+		failedCloudIDs := make([]string, 0)
+		if len(failedIDs) != 0 {
+			_, failedCloudIDs, _ = ListResource(kt.Kit(), actcli.GetDataService(), opt.ResType, "id", failedIDs)
+		}
+		saveErr := kt.ShareData().Set(kt.Kit(), ResourceKey(opt.ResType, opt.Name), strings.Join(failedCloudIDs, ","))
+		if saveErr != nil {
+			return nil, saveErr
+		}
+	}
+
+	return nil, err
+}
+
+// deleteResource 逐个删除资源，返回删除失败的资源ID以及第一个失败的原因
+func deleteResource(kt run.ExecuteKit, opt *DeleteResourceOption, ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	hcCli := actcli.GetHCService().TCloud
+	if opt.ResType == enumor.CvmCloudResType {
+		failedIDs := make([]string, 0)
+		var firstErr error
+		for _, part := range slice.Split(ids, constant.BatchOperationMaxLimit) {
+			req := &hccvm.TCloudBatchDeleteReq{AccountID: opt.AccountID, Region: opt.Region, IDs: part}
+			if err := hcCli.Cvm.BatchDeleteCvm(kt.Kit(), req); err != nil {
+				failedIDs = append(failedIDs, part...)
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+		return failedIDs, firstErr
+	}
+
+	failedIDs := make([]string, 0)
+	var firstErr error
+	for _, id := range ids {
+		var err error
+		switch opt.ResType {
+		case enumor.VpcCloudResType:
+			err = deleteTCloudVpc(kt.Kit(), id)
+		case enumor.SubnetCloudResType:
+			err = hcCli.Subnet.Delete(kt.Kit(), id)
+		case enumor.SecurityGroupCloudResType:
+			err = hcCli.SecurityGroup.DeleteSecurityGroup(kt.Kit(), id)
+		case enumor.DiskCloudResType:
+			err = hcCli.Disk.DeleteDisk(kt.Kit().Ctx, kt.Kit().Header(), &hcdisk.DiskDeleteReq{DiskID: id})
+		case enumor.EipCloudResType:
+			err = hcCli.Eip.DeleteEip(kt.Kit(), &hceip.EipDeleteReq{EipID: id})
+		default:
+			err = fmt.Errorf("resource type: %s not support", opt.ResType)
+		}
+		if err != nil {
+			failedIDs = append(failedIDs, id)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return failedIDs, firstErr
+}
+
+// deleteTCloudVpc 先删除vpc下的子网，再删除vpc
+func deleteTCloudVpc(kt *kit.Kit, id string) error {
+	_, cloudIDs, err := ListResource(kt, actcli.GetDataService(), enumor.VpcCloudResType, "id", []string{id})
+	if err != nil {
+		return err
+	}
+
+	if len(cloudIDs) != 0 {
+		listReq := &core.ListReq{
+			Fields: []string{"id"},
+			Filter: tools.EqualExpression("cloud_vpc_id", cloudIDs[0]),
+			Page:   core.NewDefaultBasePage(),
+		}
+		subnets, err := actcli.GetDataService().Global.Subnet.List(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			return err
+		}
+
+		for _, one := range subnets.Details {
+			if err = actcli.GetHCService().TCloud.Subnet.Delete(kt, one.ID); err != nil {
+				return fmt.Errorf("delete subnet %s of vpc %s failed, err: %v", one.ID, id, err)
+			}
+		}
+	}
+
+	return actcli.GetHCService().TCloud.Vpc.Delete(kt.Ctx, kt.Header(), id)
+}
@@ -0,0 +1,141 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package actionstack 资源栈相关的Action，删除资源栈下的资源，资源栈的资源由各资源的创建Action创建
+package actionstack
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/api/core"
+	corestack "hcm/pkg/api/core/stack"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/slice"
+)
+
+// ResourceKeyPrefix 资源栈资源在任务流共享数据中的键前缀
+const ResourceKeyPrefix = "stack_resource/"
+
+// ResourceKey 资源栈中资源的创建结果在任务流共享数据中的键，值为创建资源的Action保存的云ID列表
+func ResourceKey(resType enumor.CloudResourceType, name string) string {
+	return fmt.Sprintf("%s%s/%s", ResourceKeyPrefix, resType, name)
+}
+
+// ParseResources 从任务流共享数据中解析资源栈本次创建的资源，返回的资源只有云ID
+func ParseResources(dict map[string]string) []corestack.Resource {
+	resources := make([]corestack.Resource, 0)
+	for key, val := range dict {
+		if !strings.HasPrefix(key, ResourceKeyPrefix) || len(val) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(strings.TrimPrefix(key, ResourceKeyPrefix), "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		resources = append(resources, corestack.Resource{ResType: enumor.CloudResourceType(parts[0]), Name: parts[1],
+			IDs: make([]string, 0), CloudIDs: slice.Unique(tableasync.ParseIDsStr(val))})
+	}
+
+	return resources
+}
+
+// ListResource 按 id 或 cloud_id 查询资源，返回已同步到本地的资源ID以及对应的云ID
+func ListResource(kt *kit.Kit, cli *dataservice.Client, resType enumor.CloudResourceType, field string,
+	values []string) (ids []string, cloudIDs []string, err error) {
+
+	ids, cloudIDs = make([]string, 0, len(values)), make([]string, 0, len(values))
+	for _, part := range slice.Split(values, constant.BatchOperationMaxLimit) {
+		req := &core.ListReq{
+			Fields: []string{"id", "cloud_id"},
+			Filter: tools.ContainersExpression(field, part),
+			Page:   core.NewDefaultBasePage(),
+		}
+
+		switch resType {
+		case enumor.VpcCloudResType:
+			result, err := cli.Global.Vpc.List(kt.Ctx, kt.Header(), req)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, one := range result.Details {
+				ids, cloudIDs = append(ids, one.ID), append(cloudIDs, one.CloudID)
+			}
+
+		case enumor.SubnetCloudResType:
+			result, err := cli.Global.Subnet.List(kt.Ctx, kt.Header(), req)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, one := range result.Details {
+				ids, cloudIDs = append(ids, one.ID), append(cloudIDs, one.CloudID)
+			}
+
+		case enumor.SecurityGroupCloudResType:
+			sgReq := &protocloud.SecurityGroupListReq{Field: req.Fields, Filter: req.Filter, Page: req.Page}
+			result, err := cli.Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), sgReq)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, one := range result.Details {
+				ids, cloudIDs = append(ids, one.ID), append(cloudIDs, one.CloudID)
+			}
+
+		case enumor.CvmCloudResType:
+			result, err := cli.Global.Cvm.ListCvm(kt, req)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, one := range result.Details {
+				ids, cloudIDs = append(ids, one.ID), append(cloudIDs, one.CloudID)
+			}
+
+		case enumor.DiskCloudResType:
+			result, err := cli.Global.ListDisk(kt, req)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, one := range result.Details {
+				ids, cloudIDs = append(ids, one.ID), append(cloudIDs, one.CloudID)
+			}
+
+		case enumor.EipCloudResType:
+			result, err := cli.Global.ListEip(kt, req)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, one := range result.Details {
+				ids, cloudIDs = append(ids, one.ID), append(cloudIDs, one.CloudID)
+			}
+
+		default:
+			return nil, nil, fmt.Errorf("resource type: %s not support", resType)
+		}
+	}
+
+	return ids, cloudIDs, nil
+}
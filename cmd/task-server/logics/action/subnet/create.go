@@ -0,0 +1,143 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionsubnet
+
+import (
+	"fmt"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/api/core"
+	hcsubnet "hcm/pkg/api/hc-service/subnet"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/logs"
+)
+
+// SaveCreateSubnetCloudIDKey 创建成功的子网云ID列表
+const SaveCreateSubnetCloudIDKey = "create_subnet_cloud_ids"
+
+var _ action.Action = new(CreateAction)
+var _ action.ParameterAction = new(CreateAction)
+
+// CreateAction 创建子网，子网创建时分配到请求中指定的业务下
+type CreateAction struct{}
+
+// CreateSubnetOption 创建子网的参数，目前只支持腾讯云
+type CreateSubnetOption struct {
+	Vendor enumor.Vendor `json:"vendor" validate:"required"`
+	// CloudVpcIDKey 不为空时从共享数据中获取前置任务创建的vpc云ID作为子网所属vpc，用于在同一任务流中先创建vpc再创建子网
+	CloudVpcIDKey string `json:"cloud_vpc_id_key" validate:"omitempty"`
+	// SaveKey 保存创建成功的子网云ID的共享数据键，为空时使用 SaveCreateSubnetCloudIDKey
+	SaveKey      string                               `json:"save_key" validate:"omitempty"`
+	TCloudSubnet *hcsubnet.TCloudSubnetBatchCreateReq `json:"tcloud_subnet" validate:"omitempty"`
+}
+
+// Validate CreateSubnetOption, 创建请求中的vpc云ID可能在执行时才填充，因此创建请求在执行时校验
+func (opt CreateSubnetOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	switch opt.Vendor {
+	case enumor.TCloud:
+		if opt.TCloudSubnet == nil {
+			return fmt.Errorf("tcloud_subnet is required")
+		}
+	default:
+		return fmt.Errorf("vendor: %s not support create subnet by async task", opt.Vendor)
+	}
+
+	return nil
+}
+
+// ParameterNew return create subnet params.
+func (act CreateAction) ParameterNew() (params interface{}) {
+	return new(CreateSubnetOption)
+}
+
+// Name return action name.
+func (act CreateAction) Name() enumor.ActionName {
+	return enumor.ActionCreateSubnet
+}
+
+// Run create subnet.
+func (act CreateAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*CreateSubnetOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := *opt.TCloudSubnet
+	if len(opt.CloudVpcIDKey) != 0 {
+		cloudVpcIDs, err := run.GetIDs(kt.ShareData(), opt.CloudVpcIDKey)
+		if err != nil {
+			return nil, err
+		}
+		req.CloudVpcID = cloudVpcIDs[0]
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result, err := actcli.GetHCService().TCloud.Subnet.BatchCreate(kt.Kit().Ctx, kt.Kit().Header(), &req)
+	if err != nil {
+		logs.Errorf("batch create subnet failed, err: %v, vendor: %s, rid: %s", err, opt.Vendor, kt.Kit().Rid)
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: tools.ContainersExpression("id", result.IDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	subnets, err := actcli.GetDataService().Global.Subnet.List(kt.Kit().Ctx, kt.Kit().Header(), listReq)
+	if err != nil {
+		logs.Errorf("list subnet failed, err: %v, ids: %v, rid: %s", err, result.IDs, kt.Kit().Rid)
+		return result, err
+	}
+
+	cloudIDs := make([]string, 0, len(subnets.Details))
+	for _, one := range subnets.Details {
+		cloudIDs = append(cloudIDs, one.CloudID)
+	}
+	if len(cloudIDs) == 0 {
+		return result, fmt.Errorf("subnet: %v not found after create", result.IDs)
+	}
+
+	saveKey := opt.SaveKey
+	if len(saveKey) == 0 {
+		saveKey = SaveCreateSubnetCloudIDKey
+	}
+	if err = kt.ShareData().AppendIDs(kt.Kit(), saveKey, cloudIDs...); err != nil {
+		logs.Errorf("share data appendIDs failed, err: %v, rid: %s", err, kt.Kit().Rid)
+		return result, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,148 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package actionvpc vpc相关的Action
+package actionvpc
+
+import (
+	"fmt"
+
+	logicaudit "hcm/cmd/cloud-server/logics/audit"
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	hcvpc "hcm/pkg/api/hc-service/vpc"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// SaveCreateVpcCloudIDKey 创建成功的vpc云ID
+const SaveCreateVpcCloudIDKey = "create_vpc_cloud_ids"
+
+var _ action.Action = new(CreateVpcAction)
+var _ action.ParameterAction = new(CreateVpcAction)
+
+// CreateVpcAction 创建vpc，创建成功后分配到指定业务下
+type CreateVpcAction struct{}
+
+// CreateVpcOption 创建vpc的参数，目前只支持腾讯云
+type CreateVpcOption struct {
+	Vendor enumor.Vendor `json:"vendor" validate:"required"`
+	// BkBizID vpc创建后分配的业务，为0时不分配
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+	// SaveKey 保存创建成功的vpc云ID的共享数据键，为空时使用 SaveCreateVpcCloudIDKey
+	SaveKey   string                                        `json:"save_key" validate:"omitempty"`
+	TCloudVpc *hcvpc.VpcCreateReq[hcvpc.TCloudVpcCreateExt] `json:"tcloud_vpc" validate:"omitempty"`
+}
+
+// Validate CreateVpcOption.
+func (opt CreateVpcOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	switch opt.Vendor {
+	case enumor.TCloud:
+		if opt.TCloudVpc == nil {
+			return fmt.Errorf("tcloud_vpc is required")
+		}
+		return opt.TCloudVpc.Validate()
+	default:
+		return fmt.Errorf("vendor: %s not support create vpc by async task", opt.Vendor)
+	}
+}
+
+// ParameterNew return create vpc params.
+func (act CreateVpcAction) ParameterNew() (params interface{}) {
+	return new(CreateVpcOption)
+}
+
+// Name return action name.
+func (act CreateVpcAction) Name() enumor.ActionName {
+	return enumor.ActionCreateVpc
+}
+
+// Run create vpc.
+func (act CreateVpcAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*CreateVpcOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	result, err := actcli.GetHCService().TCloud.Vpc.Create(kt.Kit().Ctx, kt.Kit().Header(), opt.TCloudVpc)
+	if err != nil {
+		logs.Errorf("create vpc failed, err: %v, vendor: %s, rid: %s", err, opt.Vendor, kt.Kit().Rid)
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Fields: []string{"id", "cloud_id"},
+		Filter: tools.EqualExpression("id", result.ID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	vpcs, err := actcli.GetDataService().Global.Vpc.List(kt.Kit().Ctx, kt.Kit().Header(), listReq)
+	if err != nil {
+		logs.Errorf("list vpc failed, err: %v, id: %s, rid: %s", err, result.ID, kt.Kit().Rid)
+		return result, err
+	}
+	if len(vpcs.Details) == 0 {
+		return result, fmt.Errorf("vpc: %s not found after create", result.ID)
+	}
+
+	// 分配业务前先保存，保证分配失败时后续任务也能获取到已创建的vpc
+	saveKey := opt.SaveKey
+	if len(saveKey) == 0 {
+		saveKey = SaveCreateVpcCloudIDKey
+	}
+	if err = kt.ShareData().AppendIDs(kt.Kit(), saveKey, vpcs.Details[0].CloudID); err != nil {
+		logs.Errorf("share data appendIDs failed, err: %v, rid: %s", err, kt.Kit().Rid)
+		return result, err
+	}
+
+	if opt.BkBizID != 0 {
+		if err = assign(kt.Kit(), opt.BkBizID, []string{result.ID}); err != nil {
+			logs.Errorf("assign vpc failed, err: %v, id: %s, rid: %s", err, result.ID, kt.Kit().Rid)
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func assign(kt *kit.Kit, bizID int64, ids []string) error {
+	cli := actcli.GetDataService()
+	if err := logicaudit.NewAudit(cli).ResBizAssignAudit(kt, enumor.VpcCloudAuditResType, ids, bizID); err != nil {
+		return err
+	}
+
+	req := &protocloud.VpcBaseInfoBatchUpdateReq{
+		Vpcs: []protocloud.VpcBaseInfoUpdateReq{{IDs: ids, Data: &protocloud.VpcUpdateBaseInfo{BkBizID: bizID}}},
+	}
+	return cli.Global.Vpc.BatchUpdateBaseInfo(kt.Ctx, kt.Header(), req)
+}
@@ -22,6 +22,7 @@ package capability
 
 import (
 	"hcm/pkg/async"
+	"hcm/pkg/async/backend"
 	"hcm/pkg/client"
 	"hcm/pkg/dal/dao"
 
@@ -33,5 +34,7 @@ type Capability struct {
 	WebService *restful.WebService
 	ApiClient  *client.ClientSet
 	Async      async.Async
-	Dao        dao.Set
+	// Backend 异步任务框架使用的存储后端，任务流、任务、定时计划、事件及事件回调等数据需要通过它读写
+	Backend backend.Backend
+	Dao     dao.Set
 }
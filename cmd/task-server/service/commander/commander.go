@@ -0,0 +1,93 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package commander 任务流暂停、恢复、重试相关接口
+package commander
+
+import (
+	"hcm/cmd/task-server/service/capability"
+	"hcm/pkg/async/consumer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// Init initial the commander service
+func Init(cap *capability.Capability) {
+	svc := &service{
+		cmd: cap.Async.GetConsumer().GetCommander(),
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("PauseFlow", "POST", "/flows/{id}/pause", svc.PauseFlow)
+	h.Add("ResumeFlow", "POST", "/flows/{id}/resume", svc.ResumeFlow)
+	h.Add("RetryFlow", "POST", "/flows/{id}/retry", svc.RetryFlow)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	cmd consumer.Commander
+}
+
+// PauseFlow 暂停任务流，执行中的任务会继续执行完，但不会再下发新的任务。
+func (svc *service) PauseFlow(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.cmd.PauseFlow(cts.Kit, id); err != nil {
+		logs.Errorf("pause flow failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ResumeFlow 恢复暂停的任务流。
+func (svc *service) ResumeFlow(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.cmd.ResumeFlow(cts.Kit, id); err != nil {
+		logs.Errorf("resume flow failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// RetryFlow 重试失败或取消的任务流，只重新执行失败、取消的任务，任务流的共享数据保持不变。
+func (svc *service) RetryFlow(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := svc.cmd.RetryFlow(cts.Kit, id); err != nil {
+		logs.Errorf("retry flow failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,302 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package event 任务流事件及事件回调相关接口
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"hcm/cmd/task-server/service/capability"
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+const (
+	// eventPollInterval 共享查询器查询新事件的周期
+	eventPollInterval = 500 * time.Millisecond
+	// streamHeartbeatInterval SSE连接没有新事件时发送心跳的周期，避免连接被代理层因空闲断开
+	streamHeartbeatInterval = 15 * time.Second
+	// initialEventCursor 表示第一个事件之前的游标
+	initialEventCursor = "00000000"
+)
+
+// Init initial the flow event service
+func Init(cap *capability.Capability) {
+	svc := &service{
+		bd: cap.Backend,
+	}
+	// 所有长轮询、SSE连接共享同一个事件查询器，查询器随服务进程一直运行
+	svc.hub = newEventHub(svc.listEvent, svc.latestEventID)
+	go svc.hub.run(nil)
+
+	h := rest.NewHandler()
+
+	h.Add("WatchFlowEvent", "POST", "/flow_events/watch", svc.WatchFlowEvent)
+
+	h.Add("CreateFlowWebhook", "POST", "/flow_webhooks/create", svc.CreateFlowWebhook)
+	h.Add("ListFlowWebhook", "POST", "/flow_webhooks/list", svc.ListFlowWebhook)
+	h.Add("GetFlowWebhook", "GET", "/flow_webhooks/{id}", svc.GetFlowWebhook)
+	h.Add("UpdateFlowWebhook", "PATCH", "/flow_webhooks/{id}", svc.UpdateFlowWebhook)
+	h.Add("BatchDeleteFlowWebhook", "DELETE", "/flow_webhooks/batch", svc.BatchDeleteFlowWebhook)
+
+	h.Load(cap.WebService)
+
+	// SSE需要持续写入响应，不能使用统一的json响应处理
+	cap.WebService.Route(cap.WebService.GET("/flow_events/stream").To(svc.StreamFlowEvent))
+}
+
+type service struct {
+	bd  backend.Backend
+	hub *eventHub
+}
+
+// WatchFlowEvent 长轮询查询任务流事件，游标之后有新事件时立即返回，否则等待到有新事件或超时后返回。
+func (svc *service) WatchFlowEvent(cts *rest.Contexts) (interface{}, error) {
+	req := new(ts.WatchFlowEventReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &backend.EventCursorOption{
+		Cursor:    req.Cursor,
+		FlowIDs:   req.FlowIDs,
+		FlowNames: req.FlowNames,
+		Types:     convEventTypes(req.Types),
+		Limit:     req.Limit,
+	}
+	if err := svc.initCursor(cts.Kit, opt); err != nil {
+		return nil, err
+	}
+
+	deadline := time.NewTimer(time.Duration(req.TimeoutSec) * time.Second)
+	defer deadline.Stop()
+	for {
+		events, next, wait, err := svc.hub.read(cts.Kit, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(events) != 0 || req.TimeoutSec == 0 {
+			return &ts.WatchFlowEventResult{Cursor: next, Details: events}, nil
+		}
+		opt.Cursor = next
+
+		// 等待共享查询器查询到新事件后再读取
+		select {
+		case <-cts.Kit.Ctx.Done():
+			return nil, cts.Kit.Ctx.Err()
+		case <-deadline.C:
+			return &ts.WatchFlowEventResult{Cursor: opt.Cursor, Details: events}, nil
+		case <-wait:
+		}
+	}
+}
+
+// StreamFlowEvent 以SSE(Server-Sent Events)的方式持续推送任务流事件，直到连接断开。
+// 查询参数 cursor、flow_ids、flow_names、types 与 WatchFlowEvent 含义一致，多个值以逗号分隔，
+// 断线重连时请求头 Last-Event-ID 优先于 cursor。
+func (svc *service) StreamFlowEvent(req *restful.Request, resp *restful.Response) {
+	kt, err := kit.FromHeader(req.Request.Context(), req.Request.Header)
+	if err != nil {
+		resp.WriteHeader(http.StatusBadRequest)
+		rest.WriteResp(resp.ResponseWriter, errf.Error(err).Resp())
+		return
+	}
+
+	flusher, ok := resp.ResponseWriter.(http.Flusher)
+	if !ok {
+		resp.WriteHeader(http.StatusInternalServerError)
+		rest.WriteResp(resp.ResponseWriter, errf.Error(errf.New(errf.Aborted, "streaming is not supported")).Resp())
+		return
+	}
+
+	opt := &backend.EventCursorOption{
+		Cursor:    req.QueryParameter("cursor"),
+		FlowIDs:   splitQuery(req.QueryParameter("flow_ids")),
+		FlowNames: splitQuery(req.QueryParameter("flow_names")),
+		Types:     splitQuery(req.QueryParameter("types")),
+	}
+	if lastEventID := req.HeaderParameter("Last-Event-ID"); len(lastEventID) != 0 {
+		opt.Cursor = lastEventID
+	}
+	if err = svc.initCursor(kt, opt); err != nil {
+		resp.WriteHeader(http.StatusBadRequest)
+		rest.WriteResp(resp.ResponseWriter, errf.Error(err).Resp())
+		return
+	}
+
+	header := resp.Header()
+	header.Set(constant.RidKey, kt.Rid)
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	resp.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		events, next, wait, err := svc.hub.read(kt, opt)
+		if err != nil {
+			logs.Errorf("read flow event for stream failed, err: %v, rid: %s", err, kt.Rid)
+		}
+
+		for _, one := range events {
+			data, err := json.Marshal(one)
+			if err != nil {
+				logs.Errorf("marshal flow event failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+				continue
+			}
+
+			if _, err = fmt.Fprintf(resp, "id: %s\nevent: %s\ndata: %s\n\n", one.ID, one.Type, data); err != nil {
+				logs.V(3).Infof("write flow event stream failed, err: %v, rid: %s", err, kt.Rid)
+				return
+			}
+			opt.Cursor = one.ID
+		}
+
+		if err == nil {
+			opt.Cursor = next
+		}
+
+		// 推送了事件时继续读取剩余的事件，否则等待共享查询器查询到新事件
+		if len(events) != 0 {
+			flusher.Flush()
+			heartbeat.Reset(streamHeartbeatInterval)
+			continue
+		}
+
+		select {
+		case <-kt.Ctx.Done():
+			return
+		case <-wait:
+		case <-heartbeat.C:
+			if _, err = fmt.Fprint(resp, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// initCursor 游标为空且未指定任务流ID时，从当前最新的事件之后开始查询
+func (svc *service) initCursor(kt *kit.Kit, opt *backend.EventCursorOption) error {
+	if len(opt.Cursor) != 0 {
+		if _, err := backend.EventSeq(opt.Cursor); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if len(opt.FlowIDs) != 0 {
+		return nil
+	}
+
+	cursor, err := svc.latestEventID(kt)
+	if err != nil {
+		return err
+	}
+	opt.Cursor = cursor
+
+	return nil
+}
+
+// latestEventID 查询最新的事件ID，没有事件时返回第一个事件之前的游标
+func (svc *service) latestEventID(kt *kit.Kit) (string, error) {
+	input := &backend.ListInput{
+		Fields: []string{"id"},
+		Filter: tools.AllExpression(),
+		Page:   &core.BasePage{Limit: 1, Sort: "seq", Order: core.Descending},
+	}
+	events, err := svc.bd.ListEvent(kt, input)
+	if err != nil {
+		logs.Errorf("list latest flow event failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	if len(events) == 0 {
+		return initialEventCursor, nil
+	}
+
+	return events[0].ID, nil
+}
+
+func (svc *service) listEvent(kt *kit.Kit, opt *backend.EventCursorOption) ([]coreasync.AsyncFlowEvent, error) {
+	input, err := opt.ListInput()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := svc.bd.ListEvent(kt, input)
+	if err != nil {
+		logs.Errorf("list flow event failed, err: %v, cursor: %s, rid: %s", err, opt.Cursor, kt.Rid)
+		return nil, err
+	}
+
+	events := make([]coreasync.AsyncFlowEvent, 0, len(list))
+	for _, one := range list {
+		events = append(events, convCoreEvent(one))
+	}
+
+	return events, nil
+}
+
+func convCoreEvent(one model.Event) coreasync.AsyncFlowEvent {
+	return coreasync.AsyncFlowEvent{
+		ID:        one.ID,
+		Type:      one.Type,
+		FlowID:    one.FlowID,
+		FlowName:  one.FlowName,
+		TaskID:    one.TaskID,
+		ActionID:  one.ActionID,
+		Source:    one.Source,
+		Target:    one.Target,
+		Reason:    one.Reason,
+		Creator:   one.Creator,
+		CreatedAt: one.CreatedAt,
+	}
+}
+
+func splitQuery(val string) []string {
+	if len(val) == 0 {
+		return nil
+	}
+
+	return strings.Split(val, ",")
+}
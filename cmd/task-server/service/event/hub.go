@@ -0,0 +1,259 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package event
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// maxHubEvents 共享查询器缓存的最大事件数，游标早于缓存的连接需要自行查询DB
+const maxHubEvents = 2000
+
+// eventHub 任务流事件共享查询器，周期查询新事件并缓存最近的事件，有新事件时唤醒所有等待的长轮询、SSE连接。
+// 连接从缓存中按各自的条件过滤事件，只有游标早于缓存的第一个事件时才查询DB，避免每个连接各自周期查询DB。
+type eventHub struct {
+	list   func(kt *kit.Kit, opt *backend.EventCursorOption) ([]coreasync.AsyncFlowEvent, error)
+	latest func(kt *kit.Kit) (string, error)
+
+	lock sync.RWMutex
+	// ready 是否已确定缓存的起始游标
+	ready bool
+	// base 缓存的第一个事件之前的事件序号，游标不小于该序号的连接可以从缓存中读取到全部新事件
+	base uint64
+	// cursor 已缓存的最后一个事件ID
+	cursor string
+	events []hubEvent
+	// notify 有新事件时关闭并重新创建，用于唤醒等待新事件的连接
+	notify chan struct{}
+}
+
+type hubEvent struct {
+	seq   uint64
+	event coreasync.AsyncFlowEvent
+}
+
+func newEventHub(list func(kt *kit.Kit, opt *backend.EventCursorOption) ([]coreasync.AsyncFlowEvent, error),
+	latest func(kt *kit.Kit) (string, error)) *eventHub {
+
+	return &eventHub{
+		list:   list,
+		latest: latest,
+		events: make([]hubEvent, 0),
+		notify: make(chan struct{}),
+	}
+}
+
+// run 周期查询新事件，查询到整页事件时说明还有积压的事件，立即进行下一次查询
+func (h *eventHub) run(closeCh <-chan struct{}) {
+	for {
+		kt := kit.New()
+		full, err := h.poll(kt)
+		if err != nil {
+			logs.Errorf("flow event hub poll event failed, err: %v, rid: %s", err, kt.Rid)
+		}
+
+		if full {
+			continue
+		}
+
+		select {
+		case <-closeCh:
+			return
+		case <-time.After(eventPollInterval):
+		}
+	}
+}
+
+// poll 查询缓存的最后一个事件之后的新事件并加入缓存，返回是否查询到整页事件
+func (h *eventHub) poll(kt *kit.Kit) (bool, error) {
+	if !h.ready {
+		if err := h.init(kt); err != nil {
+			return false, err
+		}
+	}
+
+	// 只有当前协程会修改游标，读取游标不需要加锁
+	events, err := h.list(kt, &backend.EventCursorOption{Cursor: h.cursor})
+	if err != nil {
+		return false, err
+	}
+
+	if len(events) == 0 {
+		return false, nil
+	}
+
+	added := make([]hubEvent, 0, len(events))
+	for _, one := range events {
+		seq, err := backend.EventSeq(one.ID)
+		if err != nil {
+			return false, err
+		}
+		added = append(added, hubEvent{seq: seq, event: one})
+	}
+
+	h.lock.Lock()
+	h.events = append(h.events, added...)
+	if over := len(h.events) - maxHubEvents; over > 0 {
+		h.base = h.events[over-1].seq
+		h.events = append(make([]hubEvent, 0, maxHubEvents), h.events[over:]...)
+	}
+	h.cursor = events[len(events)-1].ID
+	h.broadcast()
+	h.lock.Unlock()
+
+	return len(events) >= int(core.DefaultMaxPageLimit), nil
+}
+
+// init 从当前最新的事件之后开始缓存事件，失败时同样唤醒等待的连接，由连接自行查询DB
+func (h *eventHub) init(kt *kit.Kit) error {
+	cursor, err := h.latest(kt)
+	if err == nil {
+		var base uint64
+		if base, err = backend.EventSeq(cursor); err == nil {
+			h.lock.Lock()
+			h.ready, h.base, h.cursor = true, base, cursor
+			h.lock.Unlock()
+			return nil
+		}
+	}
+
+	h.lock.Lock()
+	h.broadcast()
+	h.lock.Unlock()
+	return err
+}
+
+// broadcast 唤醒等待新事件的连接，调用方需持有写锁
+func (h *eventHub) broadcast() {
+	close(h.notify)
+	h.notify = make(chan struct{})
+}
+
+// read 读取游标之后符合条件的事件，返回事件、下次读取使用的游标，以及有新事件时会被关闭的通道。
+// 游标为空表示从第一个事件开始读取。
+func (h *eventHub) read(kt *kit.Kit, opt *backend.EventCursorOption) ([]coreasync.AsyncFlowEvent, string,
+	<-chan struct{}, error) {
+
+	var seq uint64
+	if len(opt.Cursor) != 0 {
+		var err error
+		if seq, err = backend.EventSeq(opt.Cursor); err != nil {
+			return nil, "", nil, err
+		}
+	}
+
+	h.lock.RLock()
+	wait, ready, hubCursor := h.notify, h.ready, h.cursor
+	if ready && seq >= h.base {
+		events, next := h.filter(seq, opt)
+		h.lock.RUnlock()
+		return events, next, wait, nil
+	}
+	h.lock.RUnlock()
+
+	events, err := h.list(kt, opt)
+	if err != nil {
+		return nil, "", wait, err
+	}
+
+	next := opt.Cursor
+	if len(events) != 0 {
+		next = events[len(events)-1].ID
+	}
+	// 未达到单次查询上限时，查询前已缓存的事件都已被查询过，后续可以直接从缓存中读取
+	if ready && len(events) < int(eventLimit(opt.Limit)) {
+		next = laterCursor(next, hubCursor)
+	}
+
+	return events, next, wait, nil
+}
+
+// filter 从缓存中过滤序号大于seq且符合条件的事件，调用方需持有读锁
+func (h *eventHub) filter(seq uint64, opt *backend.EventCursorOption) ([]coreasync.AsyncFlowEvent, string) {
+	limit := int(eventLimit(opt.Limit))
+	flowIDs, flowNames, types := toSet(opt.FlowIDs), toSet(opt.FlowNames), toSet(opt.Types)
+
+	events := make([]coreasync.AsyncFlowEvent, 0)
+	start := sort.Search(len(h.events), func(i int) bool { return h.events[i].seq > seq })
+	for _, one := range h.events[start:] {
+		if !matchSet(flowIDs, one.event.FlowID) || !matchSet(flowNames, string(one.event.FlowName)) ||
+			!matchSet(types, string(one.event.Type)) {
+			continue
+		}
+
+		events = append(events, one.event)
+		if len(events) >= limit {
+			return events, one.event.ID
+		}
+	}
+
+	if len(opt.Cursor) == 0 {
+		return events, h.cursor
+	}
+	return events, laterCursor(opt.Cursor, h.cursor)
+}
+
+func eventLimit(limit uint) uint {
+	if limit == 0 || limit > core.DefaultMaxPageLimit {
+		return core.DefaultMaxPageLimit
+	}
+
+	return limit
+}
+
+// laterCursor 返回两个游标中较晚的一个
+func laterCursor(a, b string) string {
+	seqA, errA := backend.EventSeq(a)
+	seqB, errB := backend.EventSeq(b)
+	if errB != nil || (errA == nil && seqA >= seqB) {
+		return a
+	}
+
+	return b
+}
+
+func toSet(values []string) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+
+	set := make(map[string]struct{}, len(values))
+	for _, one := range values {
+		set[one] = struct{}{}
+	}
+	return set
+}
+
+// matchSet 集合为空表示不过滤
+func matchSet(set map[string]struct{}, value string) bool {
+	if set == nil {
+		return true
+	}
+
+	_, exist := set[value]
+	return exist
+}
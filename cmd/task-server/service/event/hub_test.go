@@ -0,0 +1,194 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package event
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+// fakeEventStore 模拟事件表，记录查询次数
+type fakeEventStore struct {
+	lock   sync.Mutex
+	events []coreasync.AsyncFlowEvent
+	lists  int
+}
+
+func (s *fakeEventStore) add(flowID string, eventType enumor.AsyncEventType) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	id := strconv.FormatUint(uint64(len(s.events)+1), 36)
+	s.events = append(s.events, coreasync.AsyncFlowEvent{ID: id, FlowID: flowID, Type: eventType})
+}
+
+func (s *fakeEventStore) list(_ *kit.Kit, opt *backend.EventCursorOption) ([]coreasync.AsyncFlowEvent, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lists++
+
+	var seq uint64
+	if len(opt.Cursor) != 0 {
+		seq, _ = backend.EventSeq(opt.Cursor)
+	}
+	result := make([]coreasync.AsyncFlowEvent, 0)
+	for _, one := range s.events[seq:] {
+		if len(opt.FlowIDs) != 0 && one.FlowID != opt.FlowIDs[0] {
+			continue
+		}
+		result = append(result, one)
+	}
+	return result, nil
+}
+
+func (s *fakeEventStore) latest(_ *kit.Kit) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return strconv.FormatUint(uint64(len(s.events)), 36), nil
+}
+
+func (s *fakeEventStore) listCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.lists
+}
+
+func TestEventHubRead(t *testing.T) {
+	store := new(fakeEventStore)
+	store.add("flow1", enumor.FlowStateEvent)
+	hub := newEventHub(store.list, store.latest)
+	kt := kit.New()
+
+	// 查询器从最新的事件之后开始缓存，之前的事件需要查询DB
+	if _, err := hub.poll(kt); err != nil {
+		t.Fatalf("poll event failed, err: %v", err)
+	}
+	events, next, _, err := hub.read(kt, &backend.EventCursorOption{FlowIDs: []string{"flow1"}})
+	if err != nil || len(events) != 1 || next != "1" {
+		t.Fatalf("read history event failed, err: %v, events: %+v, next: %s", err, events, next)
+	}
+
+	_, _, wait, err := hub.read(kt, &backend.EventCursorOption{Cursor: next})
+	if err != nil {
+		t.Fatalf("read event failed, err: %v", err)
+	}
+	store.add("flow1", enumor.TaskStateEvent)
+	store.add("flow2", enumor.FlowStateEvent)
+	if _, err = hub.poll(kt); err != nil {
+		t.Fatalf("poll event failed, err: %v", err)
+	}
+
+	select {
+	case <-wait:
+	default:
+		t.Fatal("waiting connection should be notified after new events are polled")
+	}
+
+	// 游标在缓存范围内的连接直接从缓存中过滤事件，不再查询DB
+	lists := store.listCount()
+	events, next, _, err = hub.read(kt, &backend.EventCursorOption{Cursor: "1", Types: []string{
+		string(enumor.FlowStateEvent)}})
+	if err != nil || len(events) != 1 || events[0].FlowID != "flow2" || next != "3" {
+		t.Fatalf("read cached event failed, err: %v, events: %+v, next: %s", err, events, next)
+	}
+	events, next, _, err = hub.read(kt, &backend.EventCursorOption{Cursor: "1", FlowIDs: []string{"flow1"},
+		Limit: 1})
+	if err != nil || len(events) != 1 || events[0].ID != "2" || next != "2" {
+		t.Fatalf("read cached event with limit failed, err: %v, events: %+v, next: %s", err, events, next)
+	}
+	if store.listCount() != lists {
+		t.Fatalf("read cached event should not list event from db, list count: %d", store.listCount()-lists)
+	}
+}
+
+func TestEventHubTrim(t *testing.T) {
+	store := new(fakeEventStore)
+	hub := newEventHub(store.list, store.latest)
+	kt := kit.New()
+	if _, err := hub.poll(kt); err != nil {
+		t.Fatalf("poll event failed, err: %v", err)
+	}
+
+	for i := 0; i < maxHubEvents+10; i++ {
+		store.add("flow1", enumor.FlowStateEvent)
+	}
+	for {
+		full, err := hub.poll(kt)
+		if err != nil {
+			t.Fatalf("poll event failed, err: %v", err)
+		}
+		if !full {
+			break
+		}
+	}
+
+	if len(hub.events) != maxHubEvents || hub.base != 10 {
+		t.Fatalf("hub should keep %d events after base 10, got %d events, base: %d", maxHubEvents,
+			len(hub.events), hub.base)
+	}
+
+	// 游标早于缓存的连接查询DB，读取完后游标移动到缓存范围内
+	lists := store.listCount()
+	events, next, _, err := hub.read(kt, &backend.EventCursorOption{Cursor: "5", FlowIDs: []string{"flow2"}})
+	if err != nil || len(events) != 0 || next != hub.cursor || store.listCount() != lists+1 {
+		t.Fatalf("read trimmed event failed, err: %v, events: %d, next: %s", err, len(events), next)
+	}
+}
+
+func TestEventHubRun(t *testing.T) {
+	store := new(fakeEventStore)
+	hub := newEventHub(store.list, store.latest)
+	closeCh := make(chan struct{})
+	defer close(closeCh)
+	go hub.run(closeCh)
+
+	kt := kit.New()
+	for i := 0; i < 100; i++ {
+		hub.lock.RLock()
+		ready := hub.ready
+		hub.lock.RUnlock()
+		if ready {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	events, next, wait, err := hub.read(kt, &backend.EventCursorOption{Cursor: "0"})
+	if err != nil || len(events) != 0 {
+		t.Fatalf("read event failed, err: %v, events: %+v", err, events)
+	}
+
+	store.add("flow1", enumor.FlowStateEvent)
+	select {
+	case <-wait:
+	case <-time.After(10 * eventPollInterval):
+		t.Fatal("connection should be notified by hub")
+	}
+
+	events, _, _, err = hub.read(kt, &backend.EventCursorOption{Cursor: next})
+	if err != nil || len(events) != 1 {
+		t.Fatalf("read new event failed, err: %v, events: %+v", err, events)
+	}
+}
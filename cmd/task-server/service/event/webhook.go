@@ -0,0 +1,234 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package event
+
+import (
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// CreateFlowWebhook 创建任务流事件回调，只投递创建之后产生的事件。
+func (svc *service) CreateFlowWebhook(cts *rest.Contexts) (interface{}, error) {
+	req := new(ts.CreateFlowWebhookReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	lastEventID, err := svc.latestEventID(cts.Kit)
+	if err != nil {
+		return nil, err
+	}
+
+	md := &model.Webhook{
+		Name:        req.Name,
+		URL:         req.URL,
+		Secret:      req.Secret,
+		FlowNames:   req.FlowNames,
+		EventTypes:  convEventTypes(req.EventTypes),
+		State:       enumor.FlowWebhookEnabled,
+		LastEventID: lastEventID,
+		Reason:      new(tableasync.Reason),
+		Memo:        req.Memo,
+	}
+	if md.FlowNames == nil {
+		md.FlowNames = make([]string, 0)
+	}
+
+	id, err := svc.bd.CreateWebhook(cts.Kit, md)
+	if err != nil {
+		logs.Errorf("create flow webhook failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// ListFlowWebhook list flow webhook.
+func (svc *service) ListFlowWebhook(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if req.Page.Count {
+		count, err := svc.bd.CountWebhook(cts.Kit, req.Filter)
+		if err != nil {
+			logs.Errorf("count flow webhook failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		return &ts.ListFlowWebhookResult{Count: count}, nil
+	}
+
+	input := &backend.ListInput{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	list, err := svc.bd.ListWebhook(cts.Kit, input)
+	if err != nil {
+		logs.Errorf("list flow webhook failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	webhooks := make([]coreasync.AsyncFlowWebhook, 0, len(list))
+	for _, one := range list {
+		webhooks = append(webhooks, convCoreWebhook(one))
+	}
+
+	return &ts.ListFlowWebhookResult{Details: webhooks}, nil
+}
+
+// GetFlowWebhook get flow webhook.
+func (svc *service) GetFlowWebhook(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	one, err := svc.getWebhook(cts, id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := convCoreWebhook(*one)
+	return &webhook, nil
+}
+
+// UpdateFlowWebhook 更新任务流事件回调，启用回调时会清空上次投递失败的原因，并从上次成功投递的事件之后继续投递。
+func (svc *service) UpdateFlowWebhook(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(ts.UpdateFlowWebhookReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if _, err := svc.getWebhook(cts, id); err != nil {
+		return nil, err
+	}
+
+	info := &backend.UpdateWebhookInfo{
+		ID:        id,
+		Name:      req.Name,
+		URL:       req.URL,
+		Secret:    req.Secret,
+		FlowNames: req.FlowNames,
+		State:     req.State,
+		Memo:      req.Memo,
+	}
+	if req.EventTypes != nil {
+		info.EventTypes = convEventTypes(req.EventTypes)
+	}
+	if req.State == enumor.FlowWebhookEnabled {
+		info.Reason = new(tableasync.Reason)
+	}
+
+	if err := svc.bd.UpdateWebhookConfig(cts.Kit, info); err != nil {
+		logs.Errorf("update flow webhook failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteFlowWebhook batch delete flow webhook.
+func (svc *service) BatchDeleteFlowWebhook(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.bd.DeleteWebhook(cts.Kit, req.IDs); err != nil {
+		logs.Errorf("delete flow webhook failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *service) getWebhook(cts *rest.Contexts, id string) (*model.Webhook, error) {
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	input := &backend.ListInput{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := svc.bd.ListWebhook(cts.Kit, input)
+	if err != nil {
+		logs.Errorf("list flow webhook failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "flow webhook: %s not found", id)
+	}
+
+	return &list[0], nil
+}
+
+func convEventTypes(eventTypes []enumor.AsyncEventType) []string {
+	return slice.Map(eventTypes, func(one enumor.AsyncEventType) string { return string(one) })
+}
+
+func convCoreWebhook(one model.Webhook) coreasync.AsyncFlowWebhook {
+	return coreasync.AsyncFlowWebhook{
+		ID:          one.ID,
+		Name:        one.Name,
+		URL:         one.URL,
+		FlowNames:   one.FlowNames,
+		EventTypes:  one.EventTypes,
+		State:       one.State,
+		LastEventID: one.LastEventID,
+		Reason:      one.Reason,
+		Memo:        one.Memo,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt,
+			UpdatedAt: one.UpdatedAt,
+		},
+	}
+}
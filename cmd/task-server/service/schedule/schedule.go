@@ -0,0 +1,255 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package schedule 任务流定时计划相关接口
+package schedule
+
+import (
+	"time"
+
+	"hcm/cmd/task-server/service/capability"
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/async/producer"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/cron"
+	"hcm/pkg/tools/times"
+)
+
+// Init initial the flow schedule service
+func Init(cap *capability.Capability) {
+	svc := &service{
+		pro: cap.Async.GetProducer(),
+		bd:  cap.Backend,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("CreateScheduledFlow", "POST", "/scheduled_flows/create", svc.CreateScheduledFlow)
+	h.Add("ListFlowSchedule", "POST", "/flow_schedules/list", svc.ListFlowSchedule)
+	h.Add("GetFlowSchedule", "GET", "/flow_schedules/{id}", svc.GetFlowSchedule)
+	h.Add("UpdateFlowSchedule", "PATCH", "/flow_schedules/{id}", svc.UpdateFlowSchedule)
+	h.Add("BatchDeleteFlowSchedule", "DELETE", "/flow_schedules/batch", svc.BatchDeleteFlowSchedule)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	pro producer.Producer
+	// bd 异步任务框架使用的存储后端，定时计划的读写需要和定时计划触发使用相同的存储后端
+	bd backend.Backend
+}
+
+// CreateScheduledFlow add scheduled flow.
+func (svc *service) CreateScheduledFlow(cts *rest.Contexts) (interface{}, error) {
+	// 请求体使用的是 taskserver.AddScheduledFlowReq，但解析使用的是 producer.AddScheduledFlowOption，
+	// 是想通过http请求去自动序列化 task.Params，而不需要手动 Marshal 请求参数。
+	opt := new(producer.AddScheduledFlowOption)
+	if err := cts.DecodeInto(opt); err != nil {
+		return nil, err
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	id, err := svc.pro.AddScheduledFlow(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("add scheduled flow failed, err: %v, opt: %+v, rid: %s", err, opt, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &core.CreateResult{ID: id}, nil
+}
+
+// ListFlowSchedule list flow schedule.
+func (svc *service) ListFlowSchedule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if req.Page.Count {
+		count, err := svc.bd.CountSchedule(cts.Kit, req.Filter)
+		if err != nil {
+			logs.Errorf("count flow schedule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		return &ts.ListFlowScheduleResult{Count: count}, nil
+	}
+
+	input := &backend.ListInput{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.bd.ListSchedule(cts.Kit, input)
+	if err != nil {
+		logs.Errorf("list flow schedule failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	schedules := make([]coreasync.AsyncFlowSchedule, 0, len(result))
+	for _, one := range result {
+		schedules = append(schedules, convCoreSchedule(one))
+	}
+
+	return &ts.ListFlowScheduleResult{Details: schedules}, nil
+}
+
+// GetFlowSchedule get flow schedule.
+func (svc *service) GetFlowSchedule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	one, err := svc.getSchedule(cts, id)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := convCoreSchedule(*one)
+	return &schedule, nil
+}
+
+// UpdateFlowSchedule 启用或禁用定时计划，周期定时计划启用时会从当前时间重新计算下次执行时间。
+// 基于计划的状态和下次执行时间CAS更新，计划在此期间被触发时返回RecordNotUpdate错误，需要重新查询后再更新。
+func (svc *service) UpdateFlowSchedule(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+
+	req := new(ts.UpdateFlowScheduleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	one, err := svc.getSchedule(cts, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if one.State == enumor.FlowScheduleFinished {
+		return nil, errf.Newf(errf.InvalidParameter, "flow schedule: %s is finished, can not update", id)
+	}
+
+	if one.State == req.State {
+		return nil, nil
+	}
+
+	info := &backend.UpdateScheduleStateInfo{
+		ID:              id,
+		SourceState:     one.State,
+		SourceNextRunAt: one.NextRunAt,
+		TargetState:     req.State,
+		Reason:          new(tableasync.Reason),
+	}
+	if req.State == enumor.FlowScheduleEnabled && len(one.Cron) != 0 {
+		sch, err := cron.Parse(one.Cron)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		if info.TargetNextRunAt, err = sch.Next(time.Now()); err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	}
+
+	if err = svc.bd.UpdateScheduleStateByCAS(cts.Kit, info); err != nil {
+		logs.Errorf("update flow schedule failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteFlowSchedule batch delete flow schedule, created flows will not be deleted.
+func (svc *service) BatchDeleteFlowSchedule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.bd.DeleteSchedule(cts.Kit, req.IDs); err != nil {
+		logs.Errorf("delete flow schedule failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (svc *service) getSchedule(cts *rest.Contexts, id string) (*model.Schedule, error) {
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	input := &backend.ListInput{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.bd.ListSchedule(cts.Kit, input)
+	if err != nil {
+		logs.Errorf("list flow schedule failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "flow schedule: %s not found", id)
+	}
+
+	return &result[0], nil
+}
+
+func convCoreSchedule(one model.Schedule) coreasync.AsyncFlowSchedule {
+	return coreasync.AsyncFlowSchedule{
+		ID:         one.ID,
+		Name:       one.Name,
+		FlowName:   one.FlowName,
+		Memo:       one.Memo,
+		Tasks:      one.Tasks,
+		Cron:       one.Cron,
+		NextRunAt:  times.ConvStdTimeFormat(one.NextRunAt),
+		State:      one.State,
+		Reason:     one.Reason,
+		LastFlowID: one.LastFlowID,
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt,
+			UpdatedAt: one.UpdatedAt,
+		},
+	}
+}
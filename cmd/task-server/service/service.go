@@ -30,7 +30,10 @@ import (
 
 	logicsaction "hcm/cmd/task-server/logics/action"
 	"hcm/cmd/task-server/service/capability"
+	"hcm/cmd/task-server/service/commander"
+	"hcm/cmd/task-server/service/event"
 	"hcm/cmd/task-server/service/producer"
+	"hcm/cmd/task-server/service/schedule"
 	"hcm/cmd/task-server/service/viewer"
 	"hcm/pkg/async"
 	"hcm/pkg/async/backend"
@@ -51,6 +54,7 @@ import (
 	"hcm/pkg/tools/ssl"
 
 	"github.com/emicklei/go-restful/v3"
+	etcd3 "go.etcd.io/etcd/client/v3"
 )
 
 // Service do all the task server's work
@@ -59,6 +63,8 @@ type Service struct {
 	dao    dao.Set
 	serve  *http.Server
 	async  async.Async
+	// bd 异步任务框架使用的存储后端，任务流、任务等数据的查询需要通过它读取，不能直接读取数据库
+	bd backend.Backend
 }
 
 // NewService create a service instance.
@@ -90,7 +96,7 @@ func NewService(sd serviced.ServiceDiscover, shutdownWaitTimeSec int) (*Service,
 	}
 
 	logicsaction.Init(apiClientSet)
-	async, err := createAndStartAsync(sd, dao, shutdownWaitTimeSec)
+	async, bd, err := createAndStartAsync(sd, dao, shutdownWaitTimeSec)
 	if err != nil {
 		return nil, err
 	}
@@ -99,16 +105,19 @@ func NewService(sd serviced.ServiceDiscover, shutdownWaitTimeSec int) (*Service,
 		client: apiClientSet,
 		dao:    dao,
 		async:  async,
+		bd:     bd,
 	}
 
 	return svr, nil
 }
 
-func createAndStartAsync(sd serviced.ServiceDiscover, dao dao.Set, shutdownWaitTimeSec int) (async.Async, error) {
+func createAndStartAsync(sd serviced.ServiceDiscover, dao dao.Set, shutdownWaitTimeSec int) (async.Async,
+	backend.Backend, error) {
+
 	// 创建async框架使用的backend
-	bd, err := backend.Factory(enumor.BackendMysql, dao)
+	bd, closeBackend, err := newAsyncBackend(dao)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	leader := leader.NewLeader(sd)
@@ -123,6 +132,7 @@ func createAndStartAsync(sd serviced.ServiceDiscover, dao dao.Set, shutdownWaitT
 			Executor: &consumer.ExecutorOption{
 				WorkerNumber:       cfg.Executor.WorkerNumber,
 				TaskExecTimeoutSec: cfg.Executor.TaskExecTimeoutSec,
+				RateLimit:          convRateLimitOption(cfg.Executor.RateLimit),
 			},
 			Dispatcher: &consumer.DispatcherOption{
 				WatchIntervalSec: cfg.Dispatcher.WatchIntervalSec,
@@ -131,12 +141,20 @@ func createAndStartAsync(sd serviced.ServiceDiscover, dao dao.Set, shutdownWaitT
 				WatchIntervalSec:    cfg.WatchDog.WatchIntervalSec,
 				TaskRunTimeoutSec:   cfg.WatchDog.TaskTimeoutSec,
 				ShutdownWaitTimeSec: uint(shutdownWaitTimeSec),
+				FlowRetentionHour:   cfg.WatchDog.FlowRetentionHour,
+			},
+			ScheduleTrigger: &consumer.ScheduleTriggerOption{
+				WatchIntervalSec: cfg.ScheduleTrigger.WatchIntervalSec,
+			},
+			WebhookNotifier: &consumer.WebhookNotifierOption{
+				WatchIntervalSec:   cfg.WebhookNotifier.WatchIntervalSec,
+				EventRetentionHour: cfg.WebhookNotifier.EventRetentionHour,
 			},
 		},
 	}
 	async, err := async.NewAsync(bd, leader, opt)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	go func() {
@@ -146,15 +164,16 @@ func createAndStartAsync(sd serviced.ServiceDiscover, dao dao.Set, shutdownWaitT
 			defer notifier.Done()
 			logs.Infof("start shutdown async consumer gracefully...")
 			async.GetConsumer().Close()
+			closeBackend()
 			logs.Infof("shutdown async consumer success...")
 		}
 	}()
 
 	if err = async.GetConsumer().Start(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return async, nil
+	return async, bd, nil
 }
 
 // ListenAndServeRest listen and serve the restful server
@@ -222,11 +241,15 @@ func (s *Service) apiSet() *restful.Container {
 		WebService: ws,
 		ApiClient:  s.client,
 		Async:      s.async,
+		Backend:    s.bd,
 		Dao:        s.dao,
 	}
 
 	producer.Init(c)
 	viewer.Init(c)
+	commander.Init(c)
+	schedule.Init(c)
+	event.Init(c)
 
 	return restful.NewContainer().Add(c.WebService)
 }
@@ -249,3 +272,64 @@ func (s *Service) Healthz(w http.ResponseWriter, _ *http.Request) {
 	rest.WriteResp(w, rest.NewBaseResp(errf.OK, "healthy"))
 	return
 }
+
+// newAsyncBackend 根据配置的存储后端类型创建async框架使用的backend，返回的closeBackend用于释放backend占用的资源
+func newAsyncBackend(dao dao.Set) (backend.Backend, func(), error) {
+	cfg := cc.TaskServer().Async.Backend
+	switch cfg.Type {
+	case enumor.BackendEtcd:
+		etcdCfg, err := cfg.Etcd.ToConfig()
+		if err != nil {
+			return nil, nil, fmt.Errorf("get async backend etcd config failed, err: %v", err)
+		}
+
+		cli, err := etcd3.New(etcdCfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create async backend etcd client failed, err: %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		closeBackend := func() {
+			cancel()
+			if err := cli.Close(); err != nil {
+				logs.Errorf("close async backend etcd client failed, err: %v", err)
+			}
+		}
+
+		opt := &backend.EtcdOption{Ctx: ctx, Client: cli, KeyPrefix: cfg.Etcd.KeyPrefix}
+		bd, err := backend.Factory(cfg.Type, opt)
+		if err != nil {
+			closeBackend()
+			return nil, nil, err
+		}
+
+		return bd, closeBackend, nil
+
+	default:
+		bd, err := backend.Factory(cfg.Type, dao)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return bd, func() {}, nil
+	}
+}
+
+func convRateLimitOption(cfg cc.AsyncRateLimit) *consumer.RateLimitOption {
+	rules := make([]consumer.RateLimitRule, 0, len(cfg.Rules))
+	for _, one := range cfg.Rules {
+		rules = append(rules, consumer.RateLimitRule{
+			Vendor:      one.Vendor,
+			AccountID:   one.AccountID,
+			Region:      one.Region,
+			QPS:         one.QPS,
+			Burst:       one.Burst,
+			Concurrency: one.Concurrency,
+		})
+	}
+
+	return &consumer.RateLimitOption{
+		DeferIntervalMS: cfg.DeferIntervalMS,
+		Rules:           rules,
+	}
+}
@@ -23,10 +23,10 @@ import (
 	"hcm/pkg/api/core"
 	coreasync "hcm/pkg/api/core/async"
 	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
-	"hcm/pkg/dal/dao/types"
-	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 )
@@ -42,30 +42,36 @@ func (svc *service) ListFlow(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	opt := &types.ListOption{
+	if req.Page.Count {
+		count, err := svc.bd.CountFlow(cts.Kit, req.Filter)
+		if err != nil {
+			logs.Errorf("count flow failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		return &ts.ListFlowResult{Count: count}, nil
+	}
+
+	input := &backend.ListInput{
 		Fields: req.Fields,
 		Filter: req.Filter,
 		Page:   req.Page,
 	}
-	result, err := svc.dao.AsyncFlow().List(cts.Kit, opt)
+	result, err := svc.bd.ListFlow(cts.Kit, input)
 	if err != nil {
 		logs.Errorf("list flow failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
 	}
 
-	if req.Page.Count {
-		return &ts.ListFlowResult{Count: result.Count}, nil
-	}
-
-	flows := make([]coreasync.AsyncFlow, 0, len(result.Details))
-	for _, one := range result.Details {
+	flows := make([]coreasync.AsyncFlow, 0, len(result))
+	for _, one := range result {
 		flows = append(flows, convCoreFlow(one))
 	}
 
 	return &ts.ListFlowResult{Details: flows}, nil
 }
 
-func convCoreFlow(one tableasync.AsyncFlowTable) coreasync.AsyncFlow {
+func convCoreFlow(one model.Flow) coreasync.AsyncFlow {
 	return coreasync.AsyncFlow{
 		ID:        one.ID,
 		Name:      one.Name,
@@ -77,8 +83,8 @@ func convCoreFlow(one tableasync.AsyncFlowTable) coreasync.AsyncFlow {
 		Revision: core.Revision{
 			Creator:   one.Creator,
 			Reviser:   one.Reviser,
-			CreatedAt: one.CreatedAt.String(),
-			UpdatedAt: one.UpdatedAt.String(),
+			CreatedAt: one.CreatedAt,
+			UpdatedAt: one.UpdatedAt,
 		},
 	}
 }
@@ -86,20 +92,20 @@ func convCoreFlow(one tableasync.AsyncFlowTable) coreasync.AsyncFlow {
 // GetFlow get flow.
 func (svc *service) GetFlow(cts *rest.Contexts) (interface{}, error) {
 	id := cts.PathParameter("id").String()
-	opt := &types.ListOption{
+	input := &backend.ListInput{
 		Filter: tools.EqualExpression("id", id),
 		Page:   core.NewDefaultBasePage(),
 	}
-	result, err := svc.dao.AsyncFlow().List(cts.Kit, opt)
+	result, err := svc.bd.ListFlow(cts.Kit, input)
 	if err != nil {
 		logs.Errorf("list flow failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
 	}
 
-	if len(result.Details) == 0 {
+	if len(result) == 0 {
 		return nil, errf.Newf(errf.RecordNotFound, "flow: %s not found", id)
 	}
 
-	flow := convCoreFlow(result.Details[0])
+	flow := convCoreFlow(result[0])
 	return &flow, nil
 }
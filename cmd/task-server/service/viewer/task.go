@@ -23,12 +23,14 @@ import (
 	"hcm/pkg/api/core"
 	coreasync "hcm/pkg/api/core/async"
 	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
-	"hcm/pkg/dal/dao/types"
-	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/dal/table/types"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
 )
 
 // ListTask list task.
@@ -42,47 +44,61 @@ func (svc *service) ListTask(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	opt := &types.ListOption{
+	if req.Page.Count {
+		count, err := svc.bd.CountTask(cts.Kit, req.Filter)
+		if err != nil {
+			logs.Errorf("count task failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		return &ts.ListTaskResult{Count: count}, nil
+	}
+
+	input := &backend.ListInput{
 		Fields: req.Fields,
 		Filter: req.Filter,
 		Page:   req.Page,
 	}
-	result, err := svc.dao.AsyncFlowTask().List(cts.Kit, opt)
+	result, err := svc.bd.ListTask(cts.Kit, input)
 	if err != nil {
 		logs.Errorf("list task failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
 	}
 
-	if req.Page.Count {
-		return &ts.ListTaskResult{Count: result.Count}, nil
-	}
-
-	tasks := make([]coreasync.AsyncFlowTask, 0, len(result.Details))
-	for _, one := range result.Details {
+	tasks := make([]coreasync.AsyncFlowTask, 0, len(result))
+	for _, one := range result {
 		tasks = append(tasks, convCoreTask(one))
 	}
 
 	return &ts.ListTaskResult{Details: tasks}, nil
 }
 
-func convCoreTask(one tableasync.AsyncFlowTaskTable) coreasync.AsyncFlowTask {
+func convCoreTask(one model.Task) coreasync.AsyncFlowTask {
+	dependOn := make(types.StringArray, 0, len(one.DependOn))
+	for _, id := range one.DependOn {
+		dependOn = append(dependOn, string(id))
+	}
+
 	return coreasync.AsyncFlowTask{
-		ID:         one.ID,
-		FlowID:     one.FlowID,
-		FlowName:   one.FlowName,
-		ActionID:   one.ActionID,
-		ActionName: one.ActionName,
-		Params:     one.Params,
-		Result:     one.Result,
-		Retry:      one.Retry,
-		DependOn:   one.DependOn,
-		State:      one.State,
-		Reason:     one.Reason,
+		ID:           one.ID,
+		FlowID:       one.FlowID,
+		FlowName:     one.FlowName,
+		ActionID:     string(one.ActionID),
+		ActionName:   one.ActionName,
+		Params:       one.Params,
+		Result:       one.Result,
+		Retry:        one.Retry,
+		DependOn:     dependOn,
+		RunCondition: one.RunCondition,
+		OnFailure:    converter.ValToPtr(one.OnFailure),
+		RateLimitKey: one.RateLimitKey,
+		State:        one.State,
+		Reason:       one.Reason,
 		Revision: core.Revision{
 			Creator:   one.Creator,
 			Reviser:   one.Reviser,
-			CreatedAt: one.CreatedAt.String(),
-			UpdatedAt: one.UpdatedAt.String(),
+			CreatedAt: one.CreatedAt,
+			UpdatedAt: one.UpdatedAt,
 		},
 	}
 }
@@ -90,20 +106,20 @@ func convCoreTask(one tableasync.AsyncFlowTaskTable) coreasync.AsyncFlowTask {
 // GetTask get task.
 func (svc *service) GetTask(cts *rest.Contexts) (interface{}, error) {
 	id := cts.PathParameter("id").String()
-	opt := &types.ListOption{
+	input := &backend.ListInput{
 		Filter: tools.EqualExpression("id", id),
 		Page:   core.NewDefaultBasePage(),
 	}
-	result, err := svc.dao.AsyncFlowTask().List(cts.Kit, opt)
+	result, err := svc.bd.ListTask(cts.Kit, input)
 	if err != nil {
 		logs.Errorf("list task failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
 	}
 
-	if len(result.Details) == 0 {
+	if len(result) == 0 {
 		return nil, errf.Newf(errf.RecordNotFound, "task: %s not found", id)
 	}
 
-	task := convCoreTask(result.Details[0])
+	task := convCoreTask(result[0])
 	return &task, nil
 }
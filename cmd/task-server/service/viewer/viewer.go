@@ -22,16 +22,16 @@ package viewer
 
 import (
 	"hcm/cmd/task-server/service/capability"
+	"hcm/pkg/async/backend"
 	"hcm/pkg/client"
-	"hcm/pkg/dal/dao"
 	"hcm/pkg/rest"
 )
 
 // Init initial the async service
 func Init(cap *capability.Capability) {
 	svc := &service{
-		cs:  cap.ApiClient,
-		dao: cap.Dao,
+		cs: cap.ApiClient,
+		bd: cap.Backend,
 	}
 
 	h := rest.NewHandler()
@@ -45,6 +45,7 @@ func Init(cap *capability.Capability) {
 }
 
 type service struct {
-	cs  *client.ClientSet
-	dao dao.Set
+	cs *client.ClientSet
+	// bd 异步任务框架使用的存储后端，存储后端不是mysql时数据库中没有任务流、任务数据
+	bd backend.Backend
 }
@@ -23,7 +23,9 @@ import (
 	"net/http"
 
 	"hcm/cmd/web-server/service/capability"
+	csapproval "hcm/pkg/api/cloud-server/approval"
 	webserver "hcm/pkg/api/web-server"
+	"hcm/pkg/cc"
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/logs"
@@ -61,6 +63,10 @@ func (svc *service) ListMyApprovalTicket(cts *rest.Contexts) (interface{}, error
 		return nil, err
 	}
 
+	if cc.WebServer().Approval.IsNative() {
+		return svc.listMyNativeApprovalTicket(cts, req)
+	}
+
 	serviceID, err := svc.client.CloudServer().ApprovalProcess.GetApprovalProcessServiceID(cts.Kit)
 	if err != nil {
 		logs.Errorf("call cloud-server to get approval process service id failed, err: %v, rid: %s", err, cts.Kit.Rid)
@@ -99,6 +105,10 @@ func (svc *service) TicketApprove(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	if cc.WebServer().Approval.IsNative() {
+		return nil, svc.nativeTicketApprove(cts, req)
+	}
+
 	getReq := &itsm2.ApproveReq{
 		Sn:       req.Sn,
 		StateID:  req.StateID,
@@ -113,3 +123,51 @@ func (svc *service) TicketApprove(cts *rest.Contexts) (interface{}, error) {
 
 	return nil, nil
 }
+
+// listMyNativeApprovalTicket 从内置审批引擎查询待我审批的单据，返回格式与ITSM保持一致。
+func (svc *service) listMyNativeApprovalTicket(cts *rest.Contexts, req *webserver.ListMyApprovalTicketReq) (
+	interface{}, error) {
+
+	listReq := &csapproval.TicketListByUserReq{
+		ViewType: itsm2.MyApproval,
+		Page:     (int64(req.Page.Start) / int64(req.Page.Limit)) + 1,
+		PageSize: int64(req.Page.Limit),
+	}
+	resp, err := svc.client.CloudServer().Approval.ListTicketByUser(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list native approval tickets by user failed, err: %v, req: %v, rid: %s", err, listReq,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	result := &webserver.ListMyApprovalTicketResp{
+		Count:   resp.Count,
+		Details: resp.Items,
+	}
+
+	return result, nil
+}
+
+// nativeTicketApprove 通过内置审批引擎审批单据。
+func (svc *service) nativeTicketApprove(cts *rest.Contexts, req *webserver.TicketApproveReq) error {
+	opReq := &csapproval.TicketOperateReq{
+		StateID: req.StateID,
+		Memo:    req.Memo,
+	}
+
+	var err error
+	switch req.Action {
+	case webserver.Pass:
+		err = svc.client.CloudServer().Approval.ApproveTicket(cts.Kit, req.Sn, opReq)
+	case webserver.Refuse:
+		err = svc.client.CloudServer().Approval.RejectTicket(cts.Kit, req.Sn, opReq)
+	default:
+		return errf.Newf(errf.InvalidParameter, "action: %s not support", req.Action)
+	}
+	if err != nil {
+		logs.Errorf("native approval ticket approve failed, err: %v, sn: %s, rid: %s", err, req.Sn, cts.Kit.Rid)
+		return err
+	}
+
+	return nil
+}
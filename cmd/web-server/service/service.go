@@ -102,10 +102,14 @@ func NewService(dis serviced.Discover) (*Service, error) {
 		return nil, err
 	}
 
-	itsmCfg := cc.WebServer().Itsm
-	itsmCli, err := pkgitsm.NewClient(&itsmCfg, metrics.Register())
-	if err != nil {
-		return nil, err
+	// 使用内置审批引擎时，审批单据由cloud-server处理，无需ITSM Client
+	var itsmCli pkgitsm.Client
+	if !cc.WebServer().Approval.IsNative() {
+		itsmCfg := cc.WebServer().Itsm
+		itsmCli, err = pkgitsm.NewClient(&itsmCfg, metrics.Register())
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// create authorizer
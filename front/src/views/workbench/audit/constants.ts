@@ -14,7 +14,8 @@ export enum AuditActionEnum {
   BIND = 'bind',
   RECPVER = 'recover',
   DELIVER = 'deliver',
-  EDIT = 'edit'
+  EDIT = 'edit',
+  DOWNLOAD_PRIVATE_KEY = 'download_private_key'
 }
 
 export enum AuditActionNameEnum {
@@ -33,7 +34,8 @@ export enum AuditActionNameEnum {
   RECYCLE = '回收',
   BIND = '绑定',
   RECPVER = '绑定',
-  DELIVER = '交付'
+  DELIVER = '交付',
+  DOWNLOAD_PRIVATE_KEY = '下载私钥'
 }
 
 export enum AuditSourceEnum {
@@ -63,4 +65,5 @@ export const AUDIT_ACTION_MAP = {
   [AuditActionEnum.DELIVER]: AuditActionNameEnum.DELIVER,
   [AuditActionEnum.BIND]: AuditActionNameEnum.BIND,
   [AuditActionEnum.EDIT]: AuditActionNameEnum.EDIT,
+  [AuditActionEnum.DOWNLOAD_PRIVATE_KEY]: AuditActionNameEnum.DOWNLOAD_PRIVATE_KEY,
 };
@@ -0,0 +1,108 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	typetrail "hcm/pkg/adaptor/types/audit-trail"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+)
+
+// cloudTrailQueryLimit CloudTrail 事件单次最多查询的条数
+const cloudTrailQueryLimit int64 = 50
+
+// ListAuditTrailEvent 查询 CloudTrail 中主机、安全组的写操作事件。
+// reference: https://docs.aws.amazon.com/awscloudtrail/latest/APIReference/API_LookupEvents.html
+func (a *Aws) ListAuditTrailEvent(kt *kit.Kit, opt *typetrail.ListOption) (*typetrail.ListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	client, err := a.clientSet.cloudTrailClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new aws cloud trail client failed, err: %v", err)
+	}
+
+	req := &cloudtrail.LookupEventsInput{
+		StartTime:  aws.Time(opt.StartTime),
+		EndTime:    aws.Time(opt.EndTime),
+		MaxResults: aws.Int64(cloudTrailQueryLimit),
+		LookupAttributes: []*cloudtrail.LookupAttribute{{
+			AttributeKey:   aws.String(cloudtrail.LookupAttributeKeyReadOnly),
+			AttributeValue: aws.String("false"),
+		}},
+	}
+	if len(opt.NextToken) != 0 {
+		req.NextToken = aws.String(opt.NextToken)
+	}
+
+	resp, err := client.LookupEventsWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("lookup aws cloud trail events failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return nil, err
+	}
+
+	result := &typetrail.ListResult{
+		NextToken: converter.PtrToVal(resp.NextToken),
+		Events:    make([]typetrail.Event, 0, len(resp.Events)),
+	}
+	for _, one := range resp.Events {
+		result.Events = append(result.Events, convAwsTrailEvent(one, opt.Region)...)
+	}
+
+	return result, nil
+}
+
+func convAwsTrailEvent(one *cloudtrail.Event, region string) []typetrail.Event {
+	base := typetrail.Event{
+		EventID:   converter.PtrToVal(one.EventId),
+		EventName: converter.PtrToVal(one.EventName),
+		EventTime: converter.PtrToVal(one.EventTime),
+		Region:    region,
+		Operator:  converter.PtrToVal(one.Username),
+	}
+
+	resMap := make(map[enumor.CloudResourceType][]string)
+	for _, res := range one.Resources {
+		resType, ok := typetrail.AwsResType(converter.PtrToVal(res.ResourceType))
+		if !ok || res.ResourceName == nil {
+			continue
+		}
+		resMap[resType] = append(resMap[resType], *res.ResourceName)
+	}
+
+	return typetrail.BuildEvents(base, resMap)
+}
@@ -0,0 +1,580 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// elbDescribeArnsLimit 查询负载均衡时，单次最多指定的负载均衡ARN数量
+const elbDescribeArnsLimit = 20
+
+// ListLoadBalancer list load balancer.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeLoadBalancers.html
+func (a *Aws) ListLoadBalancer(kt *kit.Kit, opt *core.AwsListOption) (*typelb.AwsListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbV2Client(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new aws elbv2 client failed, err: %v", err)
+	}
+
+	if len(opt.CloudIDs) != 0 {
+		details, err := a.listLoadBalancerByIDs(kt, client, opt.Region, opt.CloudIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		return &typelb.AwsListResult{Details: details}, nil
+	}
+
+	req := new(elbv2.DescribeLoadBalancersInput)
+	if opt.Page != nil {
+		req.Marker = opt.Page.NextToken
+		if opt.Page.MaxResults != nil {
+			req.PageSize = opt.Page.MaxResults
+		}
+	}
+
+	resp, err := client.DescribeLoadBalancersWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list aws load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	details := make([]typelb.AwsLoadBalancer, 0, len(resp.LoadBalancers))
+	for _, one := range resp.LoadBalancers {
+		details = append(details, convAwsLoadBalancer(one, opt.Region))
+	}
+
+	return &typelb.AwsListResult{NextToken: resp.NextMarker, Details: details}, nil
+}
+
+// listLoadBalancerByIDs 按ARN查询负载均衡，只要有一个ARN不存在，aws就会返回 LoadBalancerNotFound，
+// 所以该批次查询失败时，逐个查询并忽略不存在的负载均衡。
+func (a *Aws) listLoadBalancerByIDs(kt *kit.Kit, client *elbv2.ELBV2, region string, cloudIDs []string) (
+	[]typelb.AwsLoadBalancer, error) {
+
+	details := make([]typelb.AwsLoadBalancer, 0, len(cloudIDs))
+	for _, ids := range slice.Split(cloudIDs, elbDescribeArnsLimit) {
+		req := &elbv2.DescribeLoadBalancersInput{LoadBalancerArns: aws.StringSlice(ids)}
+		resp, err := client.DescribeLoadBalancersWithContext(kt.Ctx, req)
+		if err != nil {
+			if !strings.Contains(err.Error(), elbv2.ErrCodeLoadBalancerNotFoundException) {
+				logs.Errorf("list aws load balancer failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+				return nil, err
+			}
+
+			if len(ids) == 1 {
+				continue
+			}
+
+			for _, id := range ids {
+				lbs, err := a.listLoadBalancerByIDs(kt, client, region, []string{id})
+				if err != nil {
+					return nil, err
+				}
+				details = append(details, lbs...)
+			}
+			continue
+		}
+
+		for _, one := range resp.LoadBalancers {
+			details = append(details, convAwsLoadBalancer(one, region))
+		}
+	}
+
+	return details, nil
+}
+
+func convAwsLoadBalancer(lb *elbv2.LoadBalancer, region string) typelb.AwsLoadBalancer {
+	zones := make([]string, 0, len(lb.AvailabilityZones))
+	subnetIDs := make([]string, 0, len(lb.AvailabilityZones))
+	publicIPs := make([]string, 0)
+	privateIPs := make([]string, 0)
+	for _, zone := range lb.AvailabilityZones {
+		zones = append(zones, converter.PtrToVal(zone.ZoneName))
+		subnetIDs = append(subnetIDs, converter.PtrToVal(zone.SubnetId))
+		for _, addr := range zone.LoadBalancerAddresses {
+			if addr.IpAddress != nil {
+				publicIPs = append(publicIPs, *addr.IpAddress)
+			}
+			if addr.PrivateIPv4Address != nil {
+				privateIPs = append(privateIPs, *addr.PrivateIPv4Address)
+			}
+		}
+	}
+
+	result := typelb.AwsLoadBalancer{
+		BaseLoadBalancer: typelb.BaseLoadBalancer{
+			CloudID:              converter.PtrToVal(lb.LoadBalancerArn),
+			Name:                 converter.PtrToVal(lb.LoadBalancerName),
+			Region:               region,
+			Zones:                zones,
+			LBType:               converter.PtrToVal(lb.Type),
+			IPVersion:            converter.PtrToVal(lb.IpAddressType),
+			CloudVpcID:           converter.PtrToVal(lb.VpcId),
+			PrivateIPv4Addresses: privateIPs,
+			PublicIPv4Addresses:  publicIPs,
+			Domain:               converter.PtrToVal(lb.DNSName),
+			CloudCreatedTime:     times.ConvStdTimeFormat(converter.PtrToVal(lb.CreatedTime)),
+		},
+		Extension: &corelb.AwsLoadBalancerExtension{
+			Scheme:                lb.Scheme,
+			CanonicalHostedZoneID: lb.CanonicalHostedZoneId,
+			CloudSecurityGroupIDs: aws.StringValueSlice(lb.SecurityGroups),
+			CloudSubnetIDs:        subnetIDs,
+		},
+	}
+
+	if len(subnetIDs) != 0 {
+		result.CloudSubnetID = subnetIDs[0]
+	}
+
+	if lb.State != nil {
+		result.Status = converter.PtrToVal(lb.State.Code)
+		result.Extension.StateReason = lb.State.Reason
+	}
+
+	return result
+}
+
+// CreateLoadBalancer create load balancer, return created load balancer arn.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_CreateLoadBalancer.html
+func (a *Aws) CreateLoadBalancer(kt *kit.Kit, opt *typelb.AwsCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbV2Client(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new aws elbv2 client failed, err: %v", err)
+	}
+
+	req := &elbv2.CreateLoadBalancerInput{
+		Name:    aws.String(opt.Name),
+		Type:    aws.String(opt.Type),
+		Subnets: aws.StringSlice(opt.CloudSubnetIDs),
+	}
+	if len(opt.Scheme) != 0 {
+		req.Scheme = aws.String(opt.Scheme)
+	}
+	if len(opt.IPAddressType) != 0 {
+		req.IpAddressType = aws.String(opt.IPAddressType)
+	}
+	if len(opt.CloudSecurityGroupIDs) != 0 {
+		req.SecurityGroups = aws.StringSlice(opt.CloudSecurityGroupIDs)
+	}
+
+	resp, err := client.CreateLoadBalancerWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws load balancer failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	if len(resp.LoadBalancers) == 0 {
+		return "", fmt.Errorf("create aws load balancer but return load balancer is empty")
+	}
+
+	return converter.PtrToVal(resp.LoadBalancers[0].LoadBalancerArn), nil
+}
+
+// DeleteLoadBalancer delete load balancer.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DeleteLoadBalancer.html
+func (a *Aws) DeleteLoadBalancer(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbV2Client(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new aws elbv2 client failed, err: %v", err)
+	}
+
+	req := &elbv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(opt.ResourceID)}
+	if _, err = client.DeleteLoadBalancerWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws load balancer failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ListListener list load balancer listener.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeListeners.html
+func (a *Aws) ListListener(kt *kit.Kit, opt *typelb.ListenerListOption) ([]typelb.Listener, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbV2Client(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new aws elbv2 client failed, err: %v", err)
+	}
+
+	req := new(elbv2.DescribeListenersInput)
+	if len(opt.CloudIDs) != 0 {
+		req.ListenerArns = aws.StringSlice(opt.CloudIDs)
+	} else {
+		req.LoadBalancerArn = aws.String(opt.CloudLbID)
+	}
+
+	results := make([]typelb.Listener, 0)
+	err = client.DescribeListenersPagesWithContext(kt.Ctx, req,
+		func(page *elbv2.DescribeListenersOutput, lastPage bool) bool {
+			for _, one := range page.Listeners {
+				results = append(results, convAwsListener(one))
+			}
+			return true
+		})
+	if err != nil {
+		logs.Errorf("list aws listener failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func convAwsListener(one *elbv2.Listener) typelb.Listener {
+	protocol := converter.PtrToVal(one.Protocol)
+	port := converter.PtrToVal(one.Port)
+
+	listener := typelb.Listener{
+		CloudID: converter.PtrToVal(one.ListenerArn),
+		// aws 监听器没有名称，使用 "协议:端口" 作为名称
+		Name:      fmt.Sprintf("%s:%d", protocol, port),
+		CloudLbID: converter.PtrToVal(one.LoadBalancerArn),
+		Protocol:  protocol,
+		Port:      port,
+		Extension: new(corelb.ListenerExtension),
+	}
+
+	for _, action := range one.DefaultActions {
+		if action.TargetGroupArn != nil {
+			listener.CloudTargetGroupID = *action.TargetGroupArn
+			break
+		}
+	}
+
+	for _, cert := range one.Certificates {
+		listener.Extension.CloudCertificateIDs = append(listener.Extension.CloudCertificateIDs,
+			converter.PtrToVal(cert.CertificateArn))
+	}
+
+	return listener
+}
+
+// CreateListener create load balancer listener, if target group is not specified, a target group with same name
+// of listener will be created, return created listener arn.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_CreateListener.html
+func (a *Aws) CreateListener(kt *kit.Kit, opt *typelb.ListenerCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbV2Client(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new aws elbv2 client failed, err: %v", err)
+	}
+
+	targetGroupArn := opt.CloudTargetGroupID
+	if len(targetGroupArn) == 0 {
+		targetGroupArn, err = a.createTargetGroup(kt, client, opt)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	req := &elbv2.CreateListenerInput{
+		LoadBalancerArn: aws.String(opt.CloudLbID),
+		Protocol:        aws.String(opt.Protocol),
+		Port:            aws.Int64(opt.Port),
+		DefaultActions: []*elbv2.Action{{
+			Type:           aws.String(elbv2.ActionTypeEnumForward),
+			TargetGroupArn: aws.String(targetGroupArn),
+		}},
+	}
+	if opt.Extension != nil && len(opt.Extension.CloudCertificateIDs) != 0 {
+		for _, certID := range opt.Extension.CloudCertificateIDs {
+			req.Certificates = append(req.Certificates, &elbv2.Certificate{CertificateArn: aws.String(certID)})
+		}
+	}
+
+	resp, err := client.CreateListenerWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws listener failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	if len(resp.Listeners) == 0 {
+		return "", fmt.Errorf("create aws listener but return listener is empty")
+	}
+
+	return converter.PtrToVal(resp.Listeners[0].ListenerArn), nil
+}
+
+// createTargetGroup create target group in the vpc of load balancer for listener.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_CreateTargetGroup.html
+func (a *Aws) createTargetGroup(kt *kit.Kit, client *elbv2.ELBV2, opt *typelb.ListenerCreateOption) (string,
+	error) {
+
+	lbResp, err := client.DescribeLoadBalancersWithContext(kt.Ctx,
+		&elbv2.DescribeLoadBalancersInput{LoadBalancerArns: aws.StringSlice([]string{opt.CloudLbID})})
+	if err != nil {
+		logs.Errorf("get aws load balancer failed, arn: %s, err: %v, rid: %s", opt.CloudLbID, err, kt.Rid)
+		return "", err
+	}
+
+	if len(lbResp.LoadBalancers) == 0 {
+		return "", errf.Newf(errf.RecordNotFound, "aws load balancer: %s not found", opt.CloudLbID)
+	}
+
+	// 目标组协议：HTTPS 监听器转发到 HTTP 目标组，TLS 监听器转发到 TCP 目标组
+	protocol := opt.Protocol
+	switch protocol {
+	case elbv2.ProtocolEnumHttps:
+		protocol = elbv2.ProtocolEnumHttp
+	case elbv2.ProtocolEnumTls:
+		protocol = elbv2.ProtocolEnumTcp
+	}
+
+	req := &elbv2.CreateTargetGroupInput{
+		Name:       aws.String(opt.Name),
+		Protocol:   aws.String(protocol),
+		Port:       aws.Int64(opt.Port),
+		VpcId:      lbResp.LoadBalancers[0].VpcId,
+		TargetType: aws.String(elbv2.TargetTypeEnumInstance),
+	}
+	resp, err := client.CreateTargetGroupWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws target group failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	if len(resp.TargetGroups) == 0 {
+		return "", fmt.Errorf("create aws target group but return target group is empty")
+	}
+
+	return converter.PtrToVal(resp.TargetGroups[0].TargetGroupArn), nil
+}
+
+// DeleteListener delete load balancer listener.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DeleteListener.html
+func (a *Aws) DeleteListener(kt *kit.Kit, opt *typelb.ListenerDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.elbV2Client(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new aws elbv2 client failed, err: %v", err)
+	}
+
+	for _, cloudID := range opt.CloudIDs {
+		req := &elbv2.DeleteListenerInput{ListenerArn: aws.String(cloudID)}
+		if _, err = client.DeleteListenerWithContext(kt.Ctx, req); err != nil {
+			logs.Errorf("delete aws listener failed, arn: %s, err: %v, rid: %s", cloudID, err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListTarget list backend target of all target groups which load balancer listeners forward to.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DescribeTargetHealth.html
+func (a *Aws) ListTarget(kt *kit.Kit, opt *typelb.TargetListOption) ([]typelb.Target, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list target option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listeners, err := a.ListListener(kt, &typelb.ListenerListOption{Region: opt.Region, CloudLbID: opt.CloudLbID})
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := a.clientSet.elbV2Client(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new aws elbv2 client failed, err: %v", err)
+	}
+
+	// 多个监听器可以转发到同一个目标组，目标组的后端目标只记录一次
+	results := make([]typelb.Target, 0)
+	groupListener := make(map[string]string)
+	for _, listener := range listeners {
+		if len(listener.CloudTargetGroupID) == 0 {
+			continue
+		}
+
+		if _, exist := groupListener[listener.CloudTargetGroupID]; exist {
+			continue
+		}
+		groupListener[listener.CloudTargetGroupID] = listener.CloudID
+
+		req := &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(listener.CloudTargetGroupID)}
+		resp, err := client.DescribeTargetHealthWithContext(kt.Ctx, req)
+		if err != nil {
+			logs.Errorf("list aws target group health failed, arn: %s, err: %v, rid: %s",
+				listener.CloudTargetGroupID, err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.TargetHealthDescriptions {
+			if one.Target == nil {
+				continue
+			}
+
+			target := typelb.Target{
+				CloudLbID:          opt.CloudLbID,
+				CloudListenerID:    listener.CloudID,
+				CloudTargetGroupID: listener.CloudTargetGroupID,
+				Port:               converter.PtrToVal(one.Target.Port),
+			}
+
+			id := converter.PtrToVal(one.Target.Id)
+			if strings.HasPrefix(id, "i-") {
+				target.InstType = typelb.TargetInstTypeCvm
+				target.CloudInstID = id
+			} else {
+				target.InstType = typelb.TargetInstTypeIP
+				target.IP = id
+			}
+
+			results = append(results, target)
+		}
+	}
+
+	return results, nil
+}
+
+// RegisterTargets register backend target to target group.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_RegisterTargets.html
+func (a *Aws) RegisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error {
+	client, targets, err := a.prepareTargetOperate(opt)
+	if err != nil {
+		return err
+	}
+
+	req := &elbv2.RegisterTargetsInput{TargetGroupArn: aws.String(opt.CloudTargetGroupID), Targets: targets}
+	if _, err = client.RegisterTargetsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("register aws target failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DeregisterTargets deregister backend target from target group.
+// reference: https://docs.aws.amazon.com/elasticloadbalancing/latest/APIReference/API_DeregisterTargets.html
+func (a *Aws) DeregisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error {
+	client, targets, err := a.prepareTargetOperate(opt)
+	if err != nil {
+		return err
+	}
+
+	req := &elbv2.DeregisterTargetsInput{TargetGroupArn: aws.String(opt.CloudTargetGroupID), Targets: targets}
+	if _, err = client.DeregisterTargetsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("deregister aws target failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func (a *Aws) prepareTargetOperate(opt *typelb.TargetOperateOption) (*elbv2.ELBV2, []*elbv2.TargetDescription,
+	error) {
+
+	if opt == nil {
+		return nil, nil, errf.New(errf.InvalidParameter, "operate target option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.CloudTargetGroupID) == 0 {
+		return nil, nil, errf.New(errf.InvalidParameter, "cloud_target_group_id is required")
+	}
+
+	client, err := a.clientSet.elbV2Client(opt.Region)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new aws elbv2 client failed, err: %v", err)
+	}
+
+	targets := make([]*elbv2.TargetDescription, 0, len(opt.Targets))
+	for _, one := range opt.Targets {
+		id := one.CloudInstID
+		if len(id) == 0 {
+			id = one.IP
+		}
+
+		target := &elbv2.TargetDescription{Id: aws.String(id)}
+		if one.Port != 0 {
+			target.Port = aws.Int64(one.Port)
+		}
+		targets = append(targets, target)
+	}
+
+	return client, targets, nil
+}
@@ -0,0 +1,198 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types/core"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// ListNatGateway list nat gateway, nat gateway in deleted state is still visible for a while,
+// so only query nat gateway that is not deleted.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeNatGateways.html
+func (a *Aws) ListNatGateway(kt *kit.Kit, opt *core.AwsListOption) (*typenat.AwsListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &ec2.DescribeNatGatewaysInput{
+		Filter: []*ec2.Filter{{
+			Name: aws.String("state"),
+			Values: aws.StringSlice([]string{ec2.NatGatewayStatePending, ec2.NatGatewayStateAvailable,
+				ec2.NatGatewayStateFailed, ec2.NatGatewayStateDeleting}),
+		}},
+	}
+	if len(opt.CloudIDs) != 0 {
+		req.Filter = append(req.Filter, &ec2.Filter{Name: aws.String("nat-gateway-id"),
+			Values: aws.StringSlice(opt.CloudIDs)})
+	} else if opt.Page != nil {
+		req.MaxResults = opt.Page.MaxResults
+		req.NextToken = opt.Page.NextToken
+	}
+
+	resp, err := client.DescribeNatGatewaysWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list aws nat gateway failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return nil, err
+	}
+
+	details := make([]typenat.AwsNatGateway, 0, len(resp.NatGateways))
+	for _, one := range resp.NatGateways {
+		details = append(details, convAwsNatGateway(one, opt.Region))
+	}
+
+	return &typenat.AwsListResult{NextToken: resp.NextToken, Details: details}, nil
+}
+
+func convAwsNatGateway(one *ec2.NatGateway, region string) typenat.AwsNatGateway {
+	var name string
+	for _, tag := range one.Tags {
+		if converter.PtrToVal(tag.Key) == "Name" {
+			name = converter.PtrToVal(tag.Value)
+			break
+		}
+	}
+
+	publicIPs := make([]string, 0, len(one.NatGatewayAddresses))
+	privateIPs := make([]string, 0, len(one.NatGatewayAddresses))
+	allocationIDs := make([]string, 0, len(one.NatGatewayAddresses))
+	for _, addr := range one.NatGatewayAddresses {
+		if addr.PublicIp != nil {
+			publicIPs = append(publicIPs, *addr.PublicIp)
+		}
+		if addr.PrivateIp != nil {
+			privateIPs = append(privateIPs, *addr.PrivateIp)
+		}
+		if addr.AllocationId != nil {
+			allocationIDs = append(allocationIDs, *addr.AllocationId)
+		}
+	}
+
+	result := typenat.AwsNatGateway{
+		BaseNatGateway: typenat.BaseNatGateway{
+			CloudID:           converter.PtrToVal(one.NatGatewayId),
+			Name:              name,
+			Region:            region,
+			CloudVpcID:        converter.PtrToVal(one.VpcId),
+			CloudSubnetID:     converter.PtrToVal(one.SubnetId),
+			PublicIPAddresses: publicIPs,
+			Status:            converter.PtrToVal(one.State),
+		},
+		Extension: &corenat.AwsNatGatewayExtension{
+			ConnectivityType:   one.ConnectivityType,
+			CloudAllocationIDs: allocationIDs,
+			PrivateIPAddresses: privateIPs,
+			FailureMessage:     one.FailureMessage,
+		},
+	}
+	if one.CreateTime != nil {
+		result.CloudCreatedTime = times.ConvStdTimeFormat(*one.CreateTime)
+	}
+
+	return result
+}
+
+// CreateNatGateway create nat gateway, nat gateway name is saved as Name tag, return nat gateway cloud id.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_CreateNatGateway.html
+func (a *Aws) CreateNatGateway(kt *kit.Kit, opt *typenat.AwsCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	req := &ec2.CreateNatGatewayInput{SubnetId: aws.String(opt.CloudSubnetID)}
+	if len(opt.ConnectivityType) != 0 {
+		req.ConnectivityType = aws.String(opt.ConnectivityType)
+	}
+	if len(opt.CloudAllocationID) != 0 {
+		req.AllocationId = aws.String(opt.CloudAllocationID)
+	}
+	if len(opt.Name) != 0 {
+		req.TagSpecifications = []*ec2.TagSpecification{{
+			ResourceType: aws.String(ec2.ResourceTypeNatgateway),
+			Tags:         []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String(opt.Name)}},
+		}}
+	}
+
+	resp, err := client.CreateNatGatewayWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws nat gateway failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	if resp.NatGateway == nil {
+		return "", fmt.Errorf("create aws nat gateway but return nat gateway is empty")
+	}
+
+	return converter.PtrToVal(resp.NatGateway.NatGatewayId), nil
+}
+
+// DeleteNatGateway delete nat gateway, the associated eip is disassociated but not released.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DeleteNatGateway.html
+func (a *Aws) DeleteNatGateway(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &ec2.DeleteNatGatewayInput{NatGatewayId: aws.String(opt.ResourceID)}
+	if _, err = client.DeleteNatGatewayWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws nat gateway failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
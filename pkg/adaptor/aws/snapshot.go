@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	typesnapshot "hcm/pkg/adaptor/types/snapshot"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// awsSnapshotSelfOwner 只查询当前账号拥有的快照，否则会返回所有公共快照
+const awsSnapshotSelfOwner = "self"
+
+// ListSnapshot list disk snapshot, query by snapshot-id filter so that snapshots not exist will not cause error.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DescribeSnapshots.html
+func (a *Aws) ListSnapshot(kt *kit.Kit, opt *core.AwsListOption) (*typesnapshot.AwsListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &ec2.DescribeSnapshotsInput{OwnerIds: []*string{aws.String(awsSnapshotSelfOwner)}}
+	if len(opt.CloudIDs) != 0 {
+		req.Filters = []*ec2.Filter{{Name: aws.String("snapshot-id"), Values: aws.StringSlice(opt.CloudIDs)}}
+	} else if opt.Page != nil {
+		req.MaxResults = opt.Page.MaxResults
+		req.NextToken = opt.Page.NextToken
+	}
+
+	resp, err := client.DescribeSnapshotsWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list aws snapshot failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return nil, err
+	}
+
+	details := make([]typesnapshot.AwsSnapshot, 0, len(resp.Snapshots))
+	for _, one := range resp.Snapshots {
+		details = append(details, convAwsSnapshot(one, opt.Region))
+	}
+
+	return &typesnapshot.AwsListResult{NextToken: resp.NextToken, Details: details}, nil
+}
+
+func convAwsSnapshot(one *ec2.Snapshot, region string) typesnapshot.AwsSnapshot {
+	var name string
+	for _, tag := range one.Tags {
+		if converter.PtrToVal(tag.Key) == "Name" {
+			name = converter.PtrToVal(tag.Value)
+			break
+		}
+	}
+
+	snapshot := typesnapshot.AwsSnapshot{
+		BaseSnapshot: typesnapshot.BaseSnapshot{
+			CloudID:     converter.PtrToVal(one.SnapshotId),
+			Name:        name,
+			Region:      region,
+			CloudDiskID: converter.PtrToVal(one.VolumeId),
+			Size:        uint64(converter.PtrToVal(one.VolumeSize)),
+			Status:      converter.PtrToVal(one.State),
+		},
+		Extension: &coresnapshot.AwsSnapshotExtension{
+			Encrypted:   one.Encrypted,
+			Progress:    one.Progress,
+			OwnerID:     one.OwnerId,
+			StorageTier: one.StorageTier,
+		},
+	}
+	if one.StartTime != nil {
+		snapshot.CloudCreatedTime = times.ConvStdTimeFormat(*one.StartTime)
+	}
+
+	return snapshot
+}
+
+// CreateSnapshot create disk snapshot, snapshot name is saved as Name tag, return snapshot cloud id.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_CreateSnapshot.html
+func (a *Aws) CreateSnapshot(kt *kit.Kit, opt *typesnapshot.CreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	req := &ec2.CreateSnapshotInput{
+		VolumeId:    aws.String(opt.CloudDiskID),
+		Description: opt.Memo,
+		TagSpecifications: []*ec2.TagSpecification{{
+			ResourceType: aws.String(ec2.ResourceTypeSnapshot),
+			Tags:         []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String(opt.Name)}},
+		}},
+	}
+	resp, err := client.CreateSnapshotWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create aws snapshot failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	return converter.PtrToVal(resp.SnapshotId), nil
+}
+
+// DeleteSnapshot delete disk snapshot.
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DeleteSnapshot.html
+func (a *Aws) DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &ec2.DeleteSnapshotInput{SnapshotId: aws.String(opt.CloudID)}
+	if _, err = client.DeleteSnapshotWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws snapshot failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -230,6 +230,7 @@ func convertSubnet(data *ec2.Subnet, region string) *adtysubnet.AwsSubnet {
 		},
 	}
 
+	s.Tags = convertTags(data.Tags)
 	name, _ := parseTags(data.Tags)
 	s.Name = name
 
@@ -20,6 +20,11 @@
 package aws
 
 import (
+	typetag "hcm/pkg/adaptor/types/tag"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
 	"hcm/pkg/tools/converter"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -69,3 +74,82 @@ func parseTags(tags []*ec2.Tag) (string, []*ec2.Tag) {
 
 	return "", tags
 }
+
+// convertTags convert ec2 tags to tag pairs.
+func convertTags(tags []*ec2.Tag) []coretag.TagPair {
+	pairs := make([]coretag.TagPair, 0, len(tags))
+	for _, tag := range tags {
+		if tag == nil {
+			continue
+		}
+		pairs = append(pairs, coretag.TagPair{Key: converter.PtrToVal(tag.Key), Value: converter.PtrToVal(tag.Value)})
+	}
+
+	return pairs
+}
+
+// TagResources 为资源批量添加标签，ec2 资源(主机、硬盘、vpc、子网)共用同一标签接口
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_CreateTags.html
+func (a *Aws) TagResources(kt *kit.Kit, opt *typetag.TagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]*ec2.Tag, 0, len(opt.Tags))
+	for _, tag := range opt.Tags {
+		tags = append(tags, &ec2.Tag{Key: aws.String(tag.Key), Value: aws.String(tag.Value)})
+	}
+
+	req := &ec2.CreateTagsInput{
+		Resources: aws.StringSlice(typetag.CloudIDs(opt.Resources)),
+		Tags:      tags,
+	}
+	if _, err = client.CreateTagsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("create aws tags failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// UntagResources 批量删除资源的标签
+// reference: https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_DeleteTags.html
+func (a *Aws) UntagResources(kt *kit.Kit, opt *typetag.UntagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := a.clientSet.ec2Client(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]*ec2.Tag, 0, len(opt.TagKeys))
+	for _, key := range opt.TagKeys {
+		tags = append(tags, &ec2.Tag{Key: aws.String(key)})
+	}
+
+	req := &ec2.DeleteTagsInput{
+		Resources: aws.StringSlice(typetag.CloudIDs(opt.Resources)),
+		Tags:      tags,
+	}
+	if _, err = client.DeleteTagsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete aws tags failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
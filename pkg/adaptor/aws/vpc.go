@@ -248,6 +248,7 @@ func convertVpc(data *ec2.Vpc, region string) *types.AwsVpc {
 		},
 	}
 
+	v.Tags = convertTags(data.Tags)
 	name, _ := parseTags(data.Tags)
 	v.Name = name
 
@@ -133,6 +133,15 @@ func (c *clientSet) diskClient() (*armcompute.DisksClient, error) {
 	return armcompute.NewDisksClient(c.credential.CloudSubscriptionID, credential, nil)
 }
 
+// snapshotClient ...
+func (c *clientSet) snapshotClient() (*armcompute.SnapshotsClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+	return armcompute.NewSnapshotsClient(c.credential.CloudSubscriptionID, credential, nil)
+}
+
 // imageClient ...
 func (c *clientSet) imageClient() (*armcompute.VirtualMachineImagesClient, error) {
 	credential, err := c.newClientSecretCredential()
@@ -226,6 +235,21 @@ func (c *clientSet) resourceGroupsClient() (*armresources.ResourceGroupsClient,
 	return client, nil
 }
 
+// tagsClient ...
+func (c *clientSet) tagsClient() (*armresources.TagsClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := armresources.NewTagsClient(c.credential.CloudSubscriptionID, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("init tags client failed, err: %v", err)
+	}
+
+	return client, nil
+}
+
 // regionClient ...
 func (c *clientSet) regionClient() (*armsubscriptions.Client, error) {
 	credential, err := c.newClientSecretCredential()
@@ -288,6 +312,21 @@ func (c *clientSet) publicIPAddressesClient() (*armnetwork.PublicIPAddressesClie
 	return client, nil
 }
 
+// loadBalancerClient ...
+func (c *clientSet) loadBalancerClient() (*armnetwork.LoadBalancersClient, error) {
+	credential, err := c.newClientSecretCredential()
+	if err != nil {
+		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
+	}
+
+	client, err := armnetwork.NewLoadBalancersClient(c.credential.CloudSubscriptionID, credential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("init azure load balancer client failed, err: %v", err)
+	}
+
+	return client, nil
+}
+
 // networkInterfaceClient ...
 func (c *clientSet) networkInterfaceClient() (*armnetwork.InterfacesClient, error) {
 	credential, err := c.newClientSecretCredential()
@@ -0,0 +1,584 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+)
+
+const (
+	azureLbFrontendName = "frontend"
+	azureLbBackendName  = "backend"
+)
+
+// ListLoadBalancer list load balancer of resource group.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/list
+func (az *Azure) ListLoadBalancer(kt *kit.Kit, opt *core.AzureListOption) ([]typelb.AzureLoadBalancer, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.loadBalancerClient()
+	if err != nil {
+		return nil, err
+	}
+
+	idMap := converter.StringSliceToMap(opt.CloudIDs)
+	results := make([]typelb.AzureLoadBalancer, 0)
+	pager := client.NewListPager(opt.ResourceGroupName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(kt.Ctx)
+		if err != nil {
+			logs.Errorf("list azure load balancer failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, fmt.Errorf("list azure load balancer failed, err: %v", err)
+		}
+
+		for _, one := range page.Value {
+			lb := convAzureLoadBalancer(one, opt.ResourceGroupName)
+			if len(idMap) != 0 {
+				if _, exist := idMap[lb.CloudID]; !exist {
+					continue
+				}
+			}
+			results = append(results, lb)
+		}
+	}
+
+	return results, nil
+}
+
+func convAzureLoadBalancer(lb *armnetwork.LoadBalancer, resGroupName string) typelb.AzureLoadBalancer {
+	result := typelb.AzureLoadBalancer{
+		BaseLoadBalancer: typelb.BaseLoadBalancer{
+			CloudID:              strings.ToLower(converter.PtrToVal(lb.ID)),
+			Name:                 converter.PtrToVal(lb.Name),
+			Region:               StrToLowerNoSpaceStr(converter.PtrToVal(lb.Location)),
+			Zones:                make([]string, 0),
+			PrivateIPv4Addresses: make([]string, 0),
+			PublicIPv4Addresses:  make([]string, 0),
+		},
+		Extension: &corelb.AzureLoadBalancerExtension{
+			ResourceGroupName:        strings.ToLower(resGroupName),
+			CloudFrontendIPConfigIDs: make([]string, 0),
+			CloudBackendPoolIDs:      make([]string, 0),
+		},
+	}
+
+	if lb.SKU != nil {
+		result.LBType = string(converter.PtrToVal(lb.SKU.Name))
+		result.Extension.SkuName = (*string)(lb.SKU.Name)
+		result.Extension.SkuTier = (*string)(lb.SKU.Tier)
+	}
+
+	if lb.Properties == nil {
+		return result
+	}
+
+	result.Status = string(converter.PtrToVal(lb.Properties.ProvisioningState))
+
+	for _, frontend := range lb.Properties.FrontendIPConfigurations {
+		result.Extension.CloudFrontendIPConfigIDs = append(result.Extension.CloudFrontendIPConfigIDs,
+			strings.ToLower(converter.PtrToVal(frontend.ID)))
+		result.Zones = append(result.Zones, converter.PtrToSlice(frontend.Zones)...)
+
+		if frontend.Properties == nil {
+			continue
+		}
+
+		if frontend.Properties.PrivateIPAddress != nil {
+			result.PrivateIPv4Addresses = append(result.PrivateIPv4Addresses, *frontend.Properties.PrivateIPAddress)
+		}
+
+		// 前端IP配置只返回公网IP资源ID，公网IP地址需要通过公网IP资源查询
+		if frontend.Properties.PublicIPAddress != nil && frontend.Properties.PublicIPAddress.Properties != nil &&
+			frontend.Properties.PublicIPAddress.Properties.IPAddress != nil {
+			result.PublicIPv4Addresses = append(result.PublicIPv4Addresses,
+				*frontend.Properties.PublicIPAddress.Properties.IPAddress)
+		}
+
+		if frontend.Properties.Subnet != nil && len(result.CloudSubnetID) == 0 {
+			result.CloudSubnetID = strings.ToLower(converter.PtrToVal(frontend.Properties.Subnet.ID))
+		}
+	}
+
+	for _, pool := range lb.Properties.BackendAddressPools {
+		result.Extension.CloudBackendPoolIDs = append(result.Extension.CloudBackendPoolIDs,
+			strings.ToLower(converter.PtrToVal(pool.ID)))
+	}
+
+	return result
+}
+
+// CreateLoadBalancer create load balancer with one frontend ip config and one backend pool, return created
+// load balancer cloud id.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/create-or-update
+func (az *Azure) CreateLoadBalancer(kt *kit.Kit, opt *typelb.AzureCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.loadBalancerClient()
+	if err != nil {
+		return "", err
+	}
+
+	frontend := &armnetwork.FrontendIPConfigurationPropertiesFormat{}
+	if len(opt.CloudPublicIPID) != 0 {
+		frontend.PublicIPAddress = &armnetwork.PublicIPAddress{ID: converter.ValToPtr(opt.CloudPublicIPID)}
+	} else {
+		frontend.Subnet = &armnetwork.Subnet{ID: converter.ValToPtr(opt.CloudSubnetID)}
+	}
+
+	lb := armnetwork.LoadBalancer{
+		Location: converter.ValToPtr(opt.Region),
+		Properties: &armnetwork.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: []*armnetwork.FrontendIPConfiguration{{
+				Name:       converter.ValToPtr(azureLbFrontendName),
+				Properties: frontend,
+			}},
+			BackendAddressPools: []*armnetwork.BackendAddressPool{{
+				Name: converter.ValToPtr(azureLbBackendName),
+			}},
+		},
+	}
+	if len(opt.SkuName) != 0 {
+		lb.SKU = &armnetwork.LoadBalancerSKU{Name: (*armnetwork.LoadBalancerSKUName)(converter.ValToPtr(opt.SkuName))}
+	}
+
+	created, err := az.createOrUpdateLoadBalancer(kt, client, opt.ResourceGroupName, opt.Name, lb)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(converter.PtrToVal(created.ID)), nil
+}
+
+// DeleteLoadBalancer delete load balancer.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/delete
+func (az *Azure) DeleteLoadBalancer(kt *kit.Kit, opt *core.AzureDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.loadBalancerClient()
+	if err != nil {
+		return err
+	}
+
+	poller, err := client.BeginDelete(kt.Ctx, opt.ResourceGroupName, parseIDToName(opt.ResourceID), nil)
+	if err != nil {
+		logs.Errorf("delete azure load balancer failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	if _, err = poller.PollUntilDone(kt.Ctx, nil); err != nil {
+		logs.Errorf("poll azure load balancer delete failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func (az *Azure) getLoadBalancer(kt *kit.Kit, client *armnetwork.LoadBalancersClient, resGroupName,
+	cloudID string) (*armnetwork.LoadBalancer, error) {
+
+	if len(resGroupName) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "resource group name is required")
+	}
+
+	resp, err := client.Get(kt.Ctx, resGroupName, parseIDToName(cloudID), nil)
+	if err != nil {
+		logs.Errorf("get azure load balancer failed, err: %v, id: %s, rid: %s", err, cloudID, kt.Rid)
+		return nil, err
+	}
+
+	if resp.LoadBalancer.Properties == nil {
+		resp.LoadBalancer.Properties = new(armnetwork.LoadBalancerPropertiesFormat)
+	}
+
+	return &resp.LoadBalancer, nil
+}
+
+func (az *Azure) createOrUpdateLoadBalancer(kt *kit.Kit, client *armnetwork.LoadBalancersClient, resGroupName,
+	name string, lb armnetwork.LoadBalancer) (*armnetwork.LoadBalancer, error) {
+
+	poller, err := client.BeginCreateOrUpdate(kt.Ctx, resGroupName, name, lb, nil)
+	if err != nil {
+		logs.Errorf("create or update azure load balancer failed, err: %v, name: %s, rid: %s", err, name, kt.Rid)
+		return nil, err
+	}
+
+	resp, err := poller.PollUntilDone(kt.Ctx, nil)
+	if err != nil {
+		logs.Errorf("poll azure load balancer create or update failed, err: %v, name: %s, rid: %s", err, name,
+			kt.Rid)
+		return nil, err
+	}
+
+	return &resp.LoadBalancer, nil
+}
+
+// ListListener list load balancing rules of load balancer.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/get
+func (az *Azure) ListListener(kt *kit.Kit, opt *typelb.ListenerListOption) ([]typelb.Listener, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.loadBalancerClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lb, err := az.getLoadBalancer(kt, client, opt.ResourceGroupName, opt.CloudLbID)
+	if err != nil {
+		return nil, err
+	}
+
+	idMap := converter.StringSliceToMap(opt.CloudIDs)
+	results := make([]typelb.Listener, 0, len(lb.Properties.LoadBalancingRules))
+	for _, rule := range lb.Properties.LoadBalancingRules {
+		listener := convAzureListener(rule, opt.CloudLbID, opt.ResourceGroupName)
+		if len(idMap) != 0 {
+			if _, exist := idMap[listener.CloudID]; !exist {
+				continue
+			}
+		}
+		results = append(results, listener)
+	}
+
+	return results, nil
+}
+
+func convAzureListener(rule *armnetwork.LoadBalancingRule, cloudLbID, resGroupName string) typelb.Listener {
+	listener := typelb.Listener{
+		CloudID:   strings.ToLower(converter.PtrToVal(rule.ID)),
+		Name:      converter.PtrToVal(rule.Name),
+		CloudLbID: cloudLbID,
+		Extension: &corelb.ListenerExtension{ResourceGroupName: strings.ToLower(resGroupName)},
+	}
+
+	if rule.Properties == nil {
+		return listener
+	}
+
+	listener.Protocol = string(converter.PtrToVal(rule.Properties.Protocol))
+	listener.Port = int64(converter.PtrToVal(rule.Properties.FrontendPort))
+	if rule.Properties.BackendAddressPool != nil {
+		listener.CloudTargetGroupID = strings.ToLower(converter.PtrToVal(rule.Properties.BackendAddressPool.ID))
+	}
+	if rule.Properties.LoadDistribution != nil {
+		listener.Extension.Scheduler = (*string)(rule.Properties.LoadDistribution)
+	}
+
+	return listener
+}
+
+// CreateListener add load balancing rule to load balancer, rule uses the first frontend ip config, and backend
+// pool is the specified one or the first one, return created rule cloud id.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/create-or-update
+func (az *Azure) CreateListener(kt *kit.Kit, opt *typelb.ListenerCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.loadBalancerClient()
+	if err != nil {
+		return "", err
+	}
+
+	lb, err := az.getLoadBalancer(kt, client, opt.ResourceGroupName, opt.CloudLbID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(lb.Properties.FrontendIPConfigurations) == 0 || len(lb.Properties.BackendAddressPools) == 0 {
+		return "", errf.Newf(errf.InvalidParameter, "azure load balancer: %s has no frontend ip config or backend "+
+			"pool", opt.CloudLbID)
+	}
+
+	poolID := opt.CloudTargetGroupID
+	if len(poolID) == 0 {
+		poolID = converter.PtrToVal(lb.Properties.BackendAddressPools[0].ID)
+	}
+
+	rule := &armnetwork.LoadBalancingRule{
+		Name: converter.ValToPtr(opt.Name),
+		Properties: &armnetwork.LoadBalancingRulePropertiesFormat{
+			FrontendPort:            converter.ValToPtr(int32(opt.Port)),
+			BackendPort:             converter.ValToPtr(int32(opt.Port)),
+			Protocol:                (*armnetwork.TransportProtocol)(converter.ValToPtr(opt.Protocol)),
+			FrontendIPConfiguration: &armnetwork.SubResource{ID: lb.Properties.FrontendIPConfigurations[0].ID},
+			BackendAddressPool:      &armnetwork.SubResource{ID: converter.ValToPtr(poolID)},
+		},
+	}
+	if opt.Extension != nil && opt.Extension.Scheduler != nil {
+		rule.Properties.LoadDistribution = (*armnetwork.LoadDistribution)(opt.Extension.Scheduler)
+	}
+	lb.Properties.LoadBalancingRules = append(lb.Properties.LoadBalancingRules, rule)
+
+	updated, err := az.createOrUpdateLoadBalancer(kt, client, opt.ResourceGroupName, converter.PtrToVal(lb.Name),
+		*lb)
+	if err != nil {
+		return "", err
+	}
+
+	for _, one := range updated.Properties.LoadBalancingRules {
+		if converter.PtrToVal(one.Name) == opt.Name {
+			return strings.ToLower(converter.PtrToVal(one.ID)), nil
+		}
+	}
+
+	return "", fmt.Errorf("create azure load balancing rule: %s but not found in load balancer", opt.Name)
+}
+
+// DeleteListener remove load balancing rule from load balancer.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/create-or-update
+func (az *Azure) DeleteListener(kt *kit.Kit, opt *typelb.ListenerDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.loadBalancerClient()
+	if err != nil {
+		return err
+	}
+
+	lb, err := az.getLoadBalancer(kt, client, opt.ResourceGroupName, opt.CloudLbID)
+	if err != nil {
+		return err
+	}
+
+	delMap := converter.StringSliceToMap(opt.CloudIDs)
+	rules := make([]*armnetwork.LoadBalancingRule, 0, len(lb.Properties.LoadBalancingRules))
+	for _, rule := range lb.Properties.LoadBalancingRules {
+		if _, exist := delMap[strings.ToLower(converter.PtrToVal(rule.ID))]; exist {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	if len(rules) == len(lb.Properties.LoadBalancingRules) {
+		return nil
+	}
+	lb.Properties.LoadBalancingRules = rules
+
+	_, err = az.createOrUpdateLoadBalancer(kt, client, opt.ResourceGroupName, converter.PtrToVal(lb.Name), *lb)
+	return err
+}
+
+// ListTarget list backend addresses of all backend pools of load balancer.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/get
+func (az *Azure) ListTarget(kt *kit.Kit, opt *typelb.TargetListOption) ([]typelb.Target, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list target option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.loadBalancerClient()
+	if err != nil {
+		return nil, err
+	}
+
+	lb, err := az.getLoadBalancer(kt, client, opt.ResourceGroupName, opt.CloudLbID)
+	if err != nil {
+		return nil, err
+	}
+
+	poolListener := make(map[string]string)
+	for _, rule := range lb.Properties.LoadBalancingRules {
+		listener := convAzureListener(rule, opt.CloudLbID, opt.ResourceGroupName)
+		if _, exist := poolListener[listener.CloudTargetGroupID]; !exist {
+			poolListener[listener.CloudTargetGroupID] = listener.CloudID
+		}
+	}
+
+	results := make([]typelb.Target, 0)
+	for _, pool := range lb.Properties.BackendAddressPools {
+		if pool.Properties == nil {
+			continue
+		}
+
+		poolID := strings.ToLower(converter.PtrToVal(pool.ID))
+		for _, addr := range pool.Properties.LoadBalancerBackendAddresses {
+			if addr.Properties == nil {
+				continue
+			}
+
+			target := typelb.Target{
+				CloudLbID:          opt.CloudLbID,
+				CloudListenerID:    poolListener[poolID],
+				CloudTargetGroupID: poolID,
+				InstType:           typelb.TargetInstTypeIP,
+				IP:                 converter.PtrToVal(addr.Properties.IPAddress),
+			}
+			// 基于网卡的后端地址，记录网卡IP配置ID
+			if addr.Properties.NetworkInterfaceIPConfiguration != nil {
+				target.InstType = typelb.TargetInstTypeCvm
+				target.CloudInstID = strings.ToLower(
+					converter.PtrToVal(addr.Properties.NetworkInterfaceIPConfiguration.ID))
+			}
+			results = append(results, target)
+		}
+	}
+
+	return results, nil
+}
+
+// RegisterTargets add ip based backend address to backend pool, the virtual network of address is the same as
+// the backend pool.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/create-or-update
+func (az *Azure) RegisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error {
+	return az.updateBackendAddresses(kt, opt, func(pool *armnetwork.BackendAddressPool) error {
+		if pool.Properties.VirtualNetwork == nil {
+			return errf.Newf(errf.InvalidParameter, "azure backend pool: %s is not ip based", opt.CloudTargetGroupID)
+		}
+
+		for _, one := range opt.Targets {
+			pool.Properties.LoadBalancerBackendAddresses = append(pool.Properties.LoadBalancerBackendAddresses,
+				&armnetwork.LoadBalancerBackendAddress{
+					Name: converter.ValToPtr(strings.ReplaceAll(one.IP, ".", "-")),
+					Properties: &armnetwork.LoadBalancerBackendAddressPropertiesFormat{
+						IPAddress:      converter.ValToPtr(one.IP),
+						VirtualNetwork: pool.Properties.VirtualNetwork,
+					},
+				})
+		}
+
+		return nil
+	})
+}
+
+// DeregisterTargets remove ip based backend address from backend pool.
+// reference: https://learn.microsoft.com/en-us/rest/api/load-balancer/load-balancers/create-or-update
+func (az *Azure) DeregisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error {
+	return az.updateBackendAddresses(kt, opt, func(pool *armnetwork.BackendAddressPool) error {
+		delIPs := make(map[string]struct{}, len(opt.Targets))
+		for _, one := range opt.Targets {
+			delIPs[one.IP] = struct{}{}
+		}
+
+		addresses := make([]*armnetwork.LoadBalancerBackendAddress, 0)
+		for _, addr := range pool.Properties.LoadBalancerBackendAddresses {
+			if addr.Properties != nil {
+				if _, exist := delIPs[converter.PtrToVal(addr.Properties.IPAddress)]; exist {
+					continue
+				}
+			}
+			addresses = append(addresses, addr)
+		}
+		pool.Properties.LoadBalancerBackendAddresses = addresses
+
+		return nil
+	})
+}
+
+func (az *Azure) updateBackendAddresses(kt *kit.Kit, opt *typelb.TargetOperateOption,
+	modify func(pool *armnetwork.BackendAddressPool) error) error {
+
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "operate target option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.CloudTargetGroupID) == 0 {
+		return errf.New(errf.InvalidParameter, "cloud_target_group_id is required")
+	}
+
+	for _, one := range opt.Targets {
+		if len(one.IP) == 0 {
+			return errf.New(errf.InvalidParameter, "azure target ip is required")
+		}
+	}
+
+	client, err := az.clientSet.loadBalancerClient()
+	if err != nil {
+		return err
+	}
+
+	lb, err := az.getLoadBalancer(kt, client, opt.ResourceGroupName, opt.CloudLbID)
+	if err != nil {
+		return err
+	}
+
+	var pool *armnetwork.BackendAddressPool
+	for _, one := range lb.Properties.BackendAddressPools {
+		if strings.ToLower(converter.PtrToVal(one.ID)) == strings.ToLower(opt.CloudTargetGroupID) {
+			pool = one
+			break
+		}
+	}
+
+	if pool == nil {
+		return errf.Newf(errf.RecordNotFound, "azure backend pool: %s not found", opt.CloudTargetGroupID)
+	}
+
+	if pool.Properties == nil {
+		pool.Properties = new(armnetwork.BackendAddressPoolPropertiesFormat)
+	}
+
+	if err = modify(pool); err != nil {
+		return err
+	}
+
+	_, err = az.createOrUpdateLoadBalancer(kt, client, opt.ResourceGroupName, converter.PtrToVal(lb.Name), *lb)
+	return err
+}
@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package azure
+
+import (
+	"reflect"
+	"testing"
+
+	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+)
+
+func TestConvAzureLoadBalancer(t *testing.T) {
+	skuName := armnetwork.LoadBalancerSKUNameStandard
+	lb := &armnetwork.LoadBalancer{
+		ID:       converter.ValToPtr("/Subscriptions/S/ResourceGroups/RG/Providers/LB"),
+		Name:     converter.ValToPtr("lb"),
+		Location: converter.ValToPtr("East US "),
+		SKU:      &armnetwork.LoadBalancerSKU{Name: &skuName},
+		Properties: &armnetwork.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: []*armnetwork.FrontendIPConfiguration{{
+				ID:    converter.ValToPtr("/Frontend"),
+				Zones: []*string{converter.ValToPtr("1")},
+				Properties: &armnetwork.FrontendIPConfigurationPropertiesFormat{
+					PrivateIPAddress: converter.ValToPtr("10.0.0.4"),
+					Subnet:           &armnetwork.Subnet{ID: converter.ValToPtr("/Subnet")},
+				},
+			}},
+			BackendAddressPools: []*armnetwork.BackendAddressPool{{ID: converter.ValToPtr("/Backend")}},
+		},
+	}
+
+	result := convAzureLoadBalancer(lb, "RG")
+	if result.CloudID != "/subscriptions/s/resourcegroups/rg/providers/lb" || result.Region != "east us" {
+		t.Fatalf("azure load balancer base info %+v is unexpected", result.BaseLoadBalancer)
+	}
+
+	if result.LBType != "Standard" || result.CloudSubnetID != "/subnet" {
+		t.Errorf("azure load balancer lb type %s, subnet %s is unexpected", result.LBType, result.CloudSubnetID)
+	}
+
+	if !reflect.DeepEqual(result.Zones, []string{"1"}) ||
+		!reflect.DeepEqual(result.PrivateIPv4Addresses, []string{"10.0.0.4"}) || len(result.PublicIPv4Addresses) != 0 {
+		t.Errorf("azure load balancer frontend %+v is unexpected", result.BaseLoadBalancer)
+	}
+
+	ext := result.Extension
+	if ext.ResourceGroupName != "rg" || !reflect.DeepEqual(ext.CloudFrontendIPConfigIDs, []string{"/frontend"}) ||
+		!reflect.DeepEqual(ext.CloudBackendPoolIDs, []string{"/backend"}) {
+		t.Errorf("azure load balancer extension %+v is unexpected", ext)
+	}
+
+	// 没有属性的负载均衡只转换基础信息
+	lb.Properties = nil
+	result = convAzureLoadBalancer(lb, "RG")
+	if len(result.Status) != 0 || len(result.Extension.CloudBackendPoolIDs) != 0 {
+		t.Errorf("azure load balancer without properties %+v is unexpected", result)
+	}
+}
+
+func TestConvAzureListener(t *testing.T) {
+	protocol := armnetwork.TransportProtocolTCP
+	rule := &armnetwork.LoadBalancingRule{
+		ID:   converter.ValToPtr("/Rule"),
+		Name: converter.ValToPtr("rule"),
+		Properties: &armnetwork.LoadBalancingRulePropertiesFormat{
+			Protocol:           &protocol,
+			FrontendPort:       converter.ValToPtr(int32(80)),
+			BackendAddressPool: &armnetwork.SubResource{ID: converter.ValToPtr("/Backend")},
+		},
+	}
+
+	listener := convAzureListener(rule, "/lb", "RG")
+	if listener.CloudID != "/rule" || listener.CloudLbID != "/lb" || listener.Protocol != "Tcp" ||
+		listener.Port != 80 || listener.CloudTargetGroupID != "/backend" {
+		t.Errorf("azure listener %+v is unexpected", listener)
+	}
+
+	if listener.Extension.ResourceGroupName != "rg" || listener.Extension.Scheduler != nil {
+		t.Errorf("azure listener extension %+v is unexpected", listener.Extension)
+	}
+}
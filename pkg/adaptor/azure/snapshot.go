@@ -0,0 +1,180 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types/core"
+	typesnapshot "hcm/pkg/adaptor/types/snapshot"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+)
+
+// ListSnapshot list disk snapshot by resource group.
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/list-by-resource-group?tabs=Go
+func (az *Azure) ListSnapshot(kt *kit.Kit, opt *core.AzureListOption) ([]typesnapshot.AzureSnapshot, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "azure snapshot list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.snapshotClient()
+	if err != nil {
+		return nil, err
+	}
+
+	idMap := converter.StringSliceToMap(opt.CloudIDs)
+	results := make([]typesnapshot.AzureSnapshot, 0)
+	pager := client.NewListByResourceGroupPager(opt.ResourceGroupName, nil)
+	for pager.More() {
+		nextResult, err := pager.NextPage(kt.Ctx)
+		if err != nil {
+			logs.Errorf("list azure snapshot failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+			return nil, fmt.Errorf("failed to advance page: %v", err)
+		}
+
+		for _, one := range nextResult.Value {
+			if len(opt.CloudIDs) > 0 {
+				if _, exist := idMap[SPtrToLowerStr(one.ID)]; !exist {
+					continue
+				}
+			}
+			results = append(results, convAzureSnapshot(one, opt.ResourceGroupName))
+		}
+	}
+
+	return results, nil
+}
+
+func convAzureSnapshot(one *armcompute.Snapshot, resGroupName string) typesnapshot.AzureSnapshot {
+	snapshot := typesnapshot.AzureSnapshot{
+		BaseSnapshot: typesnapshot.BaseSnapshot{
+			CloudID: SPtrToLowerStr(one.ID),
+			Name:    SPtrToLowerStr(one.Name),
+			Region:  SPtrToLowerNoSpaceStr(one.Location),
+		},
+		Extension: &coresnapshot.AzureSnapshotExtension{
+			ResourceGroupName: resGroupName,
+		},
+	}
+
+	if one.SKU != nil {
+		snapshot.Extension.SkuName = (*string)(one.SKU.Name)
+	}
+
+	if one.Properties == nil {
+		return snapshot
+	}
+
+	snapshot.Size = uint64(converter.PtrToVal(one.Properties.DiskSizeGB))
+	snapshot.Status = string(converter.PtrToVal(one.Properties.DiskState))
+	if one.Properties.TimeCreated != nil {
+		snapshot.CloudCreatedTime = one.Properties.TimeCreated.String()
+	}
+	if one.Properties.CreationData != nil {
+		snapshot.CloudDiskID = SPtrToLowerStr(one.Properties.CreationData.SourceResourceID)
+	}
+	snapshot.Extension.Incremental = one.Properties.Incremental
+	snapshot.Extension.ProvisioningState = one.Properties.ProvisioningState
+
+	return snapshot
+}
+
+// CreateSnapshot create disk snapshot by copying managed disk, return snapshot cloud id.
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/create-or-update?tabs=Go
+func (az *Azure) CreateSnapshot(kt *kit.Kit, opt *typesnapshot.CreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "azure snapshot create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.ResourceGroupName) == 0 || len(opt.Region) == 0 {
+		return "", errf.New(errf.InvalidParameter, "azure snapshot create requires resource_group_name and region")
+	}
+
+	client, err := az.clientSet.snapshotClient()
+	if err != nil {
+		return "", err
+	}
+
+	req := armcompute.Snapshot{
+		Location: converter.ValToPtr(opt.Region),
+		Properties: &armcompute.SnapshotProperties{
+			CreationData: &armcompute.CreationData{
+				CreateOption:     converter.ValToPtr(armcompute.DiskCreateOptionCopy),
+				SourceResourceID: converter.ValToPtr(opt.CloudDiskID),
+			},
+		},
+	}
+	pollerResp, err := client.BeginCreateOrUpdate(kt.Ctx, opt.ResourceGroupName, opt.Name, req, nil)
+	if err != nil {
+		logs.Errorf("create azure snapshot failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return "", errorf(err)
+	}
+
+	resp, err := pollerResp.PollUntilDone(kt.Ctx, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return SPtrToLowerStr(resp.ID), nil
+}
+
+// DeleteSnapshot delete disk snapshot by name.
+// reference: https://learn.microsoft.com/en-us/rest/api/compute/snapshots/delete?tabs=Go
+func (az *Azure) DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "azure snapshot delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.ResourceGroupName) == 0 || len(opt.Name) == 0 {
+		return errf.New(errf.InvalidParameter, "azure snapshot delete requires resource_group_name and name")
+	}
+
+	client, err := az.clientSet.snapshotClient()
+	if err != nil {
+		return err
+	}
+
+	pollerResp, err := client.BeginDelete(kt.Ctx, opt.ResourceGroupName, opt.Name, nil)
+	if err != nil {
+		logs.Errorf("delete azure snapshot failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return errorf(err)
+	}
+
+	_, err = pollerResp.PollUntilDone(kt.Ctx, nil)
+	return err
+}
@@ -0,0 +1,111 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package azure
+
+import (
+	typetag "hcm/pkg/adaptor/types/tag"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+)
+
+// TagResources 为资源批量添加标签，azure 资源ID即为标签接口的 scope
+// reference: https://learn.microsoft.com/en-us/rest/api/resources/tags/update-at-scope
+func (az *Azure) TagResources(kt *kit.Kit, opt *typetag.TagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.tagsClient()
+	if err != nil {
+		return err
+	}
+
+	tags := make(map[string]*string, len(opt.Tags))
+	for _, tag := range opt.Tags {
+		tags[tag.Key] = to.Ptr(tag.Value)
+	}
+
+	for _, one := range opt.Resources {
+		req := armresources.TagsPatchResource{
+			Operation:  to.Ptr(armresources.TagsPatchOperationMerge),
+			Properties: &armresources.Tags{Tags: tags},
+		}
+		if _, err = client.UpdateAtScope(kt.Ctx, one.CloudID, req, nil); err != nil {
+			logs.Errorf("merge azure tags failed, err: %v, resource: %s, rid: %s", err, one.CloudID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UntagResources 批量删除资源的标签，azure 按键值对删除标签，所以先查询标签再替换为剩余标签
+// reference: https://learn.microsoft.com/en-us/rest/api/resources/tags/get-at-scope
+func (az *Azure) UntagResources(kt *kit.Kit, opt *typetag.UntagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := az.clientSet.tagsClient()
+	if err != nil {
+		return err
+	}
+
+	for _, one := range opt.Resources {
+		resp, err := client.GetAtScope(kt.Ctx, one.CloudID, nil)
+		if err != nil {
+			logs.Errorf("get azure tags failed, err: %v, resource: %s, rid: %s", err, one.CloudID, kt.Rid)
+			return err
+		}
+
+		tags := make(map[string]*string)
+		if resp.Properties != nil {
+			for key, value := range resp.Properties.Tags {
+				tags[key] = value
+			}
+		}
+		for _, key := range opt.TagKeys {
+			delete(tags, key)
+		}
+
+		req := armresources.TagsPatchResource{
+			Operation:  to.Ptr(armresources.TagsPatchOperationReplace),
+			Properties: &armresources.Tags{Tags: tags},
+		}
+		if _, err = client.UpdateAtScope(kt.Ctx, one.CloudID, req, nil); err != nil {
+			logs.Errorf("replace azure tags failed, err: %v, resource: %s, rid: %s", err, one.CloudID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
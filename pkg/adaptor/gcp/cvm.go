@@ -292,9 +292,17 @@ func (g *Gcp) CreateCvm(kt *kit.Kit, opt *typecvm.GcpCreateOption) (*poller.Base
 		return nil, err
 	}
 
-	script, err := opt.ImageProjectType.StartupScript(opt.Password)
-	if err != nil {
-		return nil, err
+	// 使用项目元数据中的密钥对登录时，不需要通过启动脚本设置密码
+	metadataItems := make([]*compute.MetadataItems, 0)
+	if len(opt.Password) != 0 {
+		script, err := opt.ImageProjectType.StartupScript(opt.Password)
+		if err != nil {
+			return nil, err
+		}
+		metadataItems = append(metadataItems, &compute.MetadataItems{
+			Key:   "startup-script",
+			Value: converter.ValToPtr(script),
+		})
 	}
 
 	req := &compute.BulkInsertInstanceResource{
@@ -314,12 +322,7 @@ func (g *Gcp) CreateCvm(kt *kit.Kit, opt *typecvm.GcpCreateOption) (*poller.Base
 			},
 			MachineType: opt.InstanceType,
 			Metadata: &compute.Metadata{
-				Items: []*compute.MetadataItems{
-					{
-						Key:   "startup-script",
-						Value: converter.ValToPtr(script),
-					},
-				},
+				Items: metadataItems,
 			},
 		},
 		MinCount:    opt.RequiredCount,
@@ -0,0 +1,216 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"fmt"
+	"strings"
+
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/sshkey"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// gcpSSHKeysMetadataKey 项目元数据中保存ssh公钥的key，每行格式为 "username:ssh-rsa AAAA... comment"
+const gcpSSHKeysMetadataKey = "ssh-keys"
+
+// ListKeyPair list key pair from project metadata "ssh-keys", gcp key pair is a global resource,
+// cloud id is composed of user name and public key fingerprint.
+// reference: https://cloud.google.com/compute/docs/connect/add-ssh-keys#add_ssh_keys_to_project_metadata
+func (g *Gcp) ListKeyPair(kt *kit.Kit) ([]typekeypair.GcpKeyPair, error) {
+	_, lines, err := g.getProjectSSHKeys(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]typekeypair.GcpKeyPair, 0, len(lines))
+	for _, line := range lines {
+		keyPair, err := parseGcpSSHKey(line)
+		if err != nil {
+			logs.Warnf("skip invalid gcp ssh key, err: %v, rid: %s", err, kt.Rid)
+			continue
+		}
+		results = append(results, *keyPair)
+	}
+
+	return results, nil
+}
+
+// ImportKeyPair append public key to project metadata "ssh-keys", name is used as login user name,
+// return key pair cloud id.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/projects/setCommonInstanceMetadata
+func (g *Gcp) ImportKeyPair(kt *kit.Kit, opt *typekeypair.ImportOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "import option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	keyPair, err := parseGcpSSHKey(opt.Name + ":" + opt.PublicKey)
+	if err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	metadata, lines, err := g.getProjectSSHKeys(kt)
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range lines {
+		exist, err := parseGcpSSHKey(line)
+		if err == nil && exist.CloudID == keyPair.CloudID {
+			return "", fmt.Errorf("gcp ssh key %s already exists", keyPair.CloudID)
+		}
+	}
+
+	lines = append(lines, opt.Name+":"+strings.TrimSpace(opt.PublicKey))
+	if err = g.setProjectSSHKeys(kt, metadata, lines); err != nil {
+		return "", err
+	}
+
+	return keyPair.CloudID, nil
+}
+
+// DeleteKeyPair remove public key from project metadata "ssh-keys" by cloud id.
+func (g *Gcp) DeleteKeyPair(kt *kit.Kit, opt *typekeypair.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	metadata, lines, err := g.getProjectSSHKeys(kt)
+	if err != nil {
+		return err
+	}
+
+	remains := make([]string, 0, len(lines))
+	for _, line := range lines {
+		keyPair, err := parseGcpSSHKey(line)
+		if err == nil && keyPair.CloudID == opt.CloudID {
+			continue
+		}
+		remains = append(remains, line)
+	}
+
+	if len(remains) == len(lines) {
+		return nil
+	}
+
+	return g.setProjectSSHKeys(kt, metadata, remains)
+}
+
+// getProjectSSHKeys returns project common instance metadata and lines of "ssh-keys".
+func (g *Gcp) getProjectSSHKeys(kt *kit.Kit) (*compute.Metadata, []string, error) {
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	project, err := client.Projects.Get(g.CloudProjectID()).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("get gcp project failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, nil, err
+	}
+
+	metadata := project.CommonInstanceMetadata
+	if metadata == nil {
+		metadata = new(compute.Metadata)
+	}
+
+	lines := make([]string, 0)
+	for _, item := range metadata.Items {
+		if item == nil || item.Key != gcpSSHKeysMetadataKey || item.Value == nil {
+			continue
+		}
+
+		for _, line := range strings.Split(*item.Value, "\n") {
+			if line = strings.TrimSpace(line); len(line) != 0 {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	return metadata, lines, nil
+}
+
+// setProjectSSHKeys overwrite "ssh-keys" of project metadata, metadata fingerprint is used to avoid
+// concurrent modification.
+func (g *Gcp) setProjectSSHKeys(kt *kit.Kit, metadata *compute.Metadata, lines []string) error {
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	value := strings.Join(lines, "\n")
+	items := make([]*compute.MetadataItems, 0, len(metadata.Items)+1)
+	found := false
+	for _, item := range metadata.Items {
+		if item != nil && item.Key == gcpSSHKeysMetadataKey {
+			item.Value = &value
+			found = true
+		}
+		items = append(items, item)
+	}
+	if !found {
+		items = append(items, &compute.MetadataItems{Key: gcpSSHKeysMetadataKey, Value: &value})
+	}
+	metadata.Items = items
+
+	_, err = client.Projects.SetCommonInstanceMetadata(g.CloudProjectID(), metadata).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("set gcp project ssh keys failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// parseGcpSSHKey parse one line of "ssh-keys" metadata, format: "username:ssh-rsa AAAA... comment".
+func parseGcpSSHKey(line string) (*typekeypair.GcpKeyPair, error) {
+	userName, publicKey, found := strings.Cut(strings.TrimSpace(line), ":")
+	if !found || len(userName) == 0 {
+		return nil, fmt.Errorf("gcp ssh key should be in format username:key")
+	}
+
+	fingerprint, err := sshkey.Fingerprint(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &typekeypair.GcpKeyPair{
+		BaseKeyPair: typekeypair.BaseKeyPair{
+			CloudID:     userName + ":" + fingerprint,
+			Name:        userName,
+			Fingerprint: fingerprint,
+			PublicKey:   strings.TrimSpace(publicKey),
+		},
+		Extension: &corekeypair.GcpKeyPairExtension{UserName: userName},
+	}, nil
+}
@@ -0,0 +1,455 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// ListLoadBalancer list gcp network load balancer based on target pool, ip address of load balancer comes from
+// the forwarding rules which point to the target pool.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/targetPools/list
+func (g *Gcp) ListLoadBalancer(kt *kit.Kit, opt *typelb.GcpListOption) (*typelb.GcpListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	request := client.TargetPools.List(g.CloudProjectID(), opt.Region).Context(kt.Ctx)
+	if len(opt.CloudIDs) > 0 {
+		request.Filter(generateResourceIDsFilter(opt.CloudIDs))
+	}
+	request.MaxResults(opt.Page.PageSize).PageToken(opt.Page.PageToken)
+
+	resp, err := request.Do()
+	if err != nil {
+		logs.Errorf("list gcp target pool failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+		return nil, err
+	}
+
+	if len(resp.Items) == 0 {
+		return &typelb.GcpListResult{Details: make([]typelb.GcpLoadBalancer, 0)}, nil
+	}
+
+	rules, err := g.listForwardingRule(kt, client, opt.Region, "")
+	if err != nil {
+		return nil, err
+	}
+
+	poolIPs := make(map[string][]string)
+	for _, rule := range rules {
+		poolIPs[rule.Target] = append(poolIPs[rule.Target], rule.IPAddress)
+	}
+
+	details := make([]typelb.GcpLoadBalancer, 0, len(resp.Items))
+	for _, one := range resp.Items {
+		lb := typelb.GcpLoadBalancer{
+			BaseLoadBalancer: typelb.BaseLoadBalancer{
+				CloudID:              strconv.FormatUint(one.Id, 10),
+				Name:                 one.Name,
+				Region:               opt.Region,
+				Zones:                make([]string, 0),
+				LBType:               "EXTERNAL",
+				PrivateIPv4Addresses: make([]string, 0),
+				PublicIPv4Addresses:  poolIPs[one.SelfLink],
+				CloudCreatedTime:     one.CreationTimestamp,
+			},
+			Extension: &corelb.GcpLoadBalancerExtension{
+				SelfLink:        converter.ValToPtr(one.SelfLink),
+				SessionAffinity: converter.ValToPtr(one.SessionAffinity),
+				HealthChecks:    one.HealthChecks,
+				BackupPool:      converter.ValToPtr(one.BackupPool),
+			},
+		}
+		if lb.PublicIPv4Addresses == nil {
+			lb.PublicIPv4Addresses = make([]string, 0)
+		}
+
+		details = append(details, lb)
+	}
+
+	return &typelb.GcpListResult{NextPageToken: resp.NextPageToken, Details: details}, nil
+}
+
+// listForwardingRule list regional forwarding rules, if target is not empty, only rules point to it are returned.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules/list
+func (g *Gcp) listForwardingRule(kt *kit.Kit, client *compute.Service, region, target string) (
+	[]*compute.ForwardingRule, error) {
+
+	request := client.ForwardingRules.List(g.CloudProjectID(), region).Context(kt.Ctx).
+		MaxResults(core.GcpQueryLimit)
+	if len(target) != 0 {
+		request.Filter(generateResourceFilter("target", []string{target}))
+	}
+
+	rules := make([]*compute.ForwardingRule, 0)
+	for {
+		resp, err := request.Do()
+		if err != nil {
+			logs.Errorf("list gcp forwarding rule failed, err: %v, region: %s, rid: %s", err, region, kt.Rid)
+			return nil, err
+		}
+
+		rules = append(rules, resp.Items...)
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		request.PageToken(resp.NextPageToken)
+	}
+
+	return rules, nil
+}
+
+// CreateLoadBalancer create target pool, return created target pool cloud id.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/targetPools/insert
+func (g *Gcp) CreateLoadBalancer(kt *kit.Kit, opt *typelb.GcpCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return "", err
+	}
+
+	pool := &compute.TargetPool{
+		Name:            opt.Name,
+		SessionAffinity: opt.SessionAffinity,
+		HealthChecks:    opt.HealthChecks,
+		Description:     converter.PtrToVal(opt.Memo),
+	}
+	resp, err := client.TargetPools.Insert(g.CloudProjectID(), opt.Region, pool).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("create gcp target pool failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return "", err
+	}
+
+	return strconv.FormatUint(resp.TargetId, 10), nil
+}
+
+// DeleteLoadBalancer delete target pool.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/targetPools/delete
+func (g *Gcp) DeleteLoadBalancer(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.TargetPools.Delete(g.CloudProjectID(), opt.Region, opt.ResourceID).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("delete gcp target pool failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// getTargetPool get target pool by cloud id.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/targetPools/get
+func (g *Gcp) getTargetPool(kt *kit.Kit, client *compute.Service, region, cloudID string) (*compute.TargetPool,
+	error) {
+
+	if len(region) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	pool, err := client.TargetPools.Get(g.CloudProjectID(), region, cloudID).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("get gcp target pool failed, err: %v, id: %s, rid: %s", err, cloudID, kt.Rid)
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// ListListener list forwarding rules which point to the target pool.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules/list
+func (g *Gcp) ListListener(kt *kit.Kit, opt *typelb.ListenerListOption) ([]typelb.Listener, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := g.getTargetPool(kt, client, opt.Region, opt.CloudLbID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := g.listForwardingRule(kt, client, opt.Region, pool.SelfLink)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudIDMap := converter.StringSliceToMap(opt.CloudIDs)
+	results := make([]typelb.Listener, 0, len(rules))
+	for _, rule := range rules {
+		cloudID := strconv.FormatUint(rule.Id, 10)
+		if len(cloudIDMap) != 0 {
+			if _, exist := cloudIDMap[cloudID]; !exist {
+				continue
+			}
+		}
+
+		port, endPort := parseGcpPortRange(rule.PortRange)
+		listener := typelb.Listener{
+			CloudID:            cloudID,
+			Name:               rule.Name,
+			CloudLbID:          opt.CloudLbID,
+			Protocol:           rule.IPProtocol,
+			Port:               port,
+			CloudTargetGroupID: pool.SelfLink,
+			Extension:          new(corelb.ListenerExtension),
+		}
+		if endPort != port {
+			listener.Extension.EndPort = converter.ValToPtr(endPort)
+		}
+
+		results = append(results, listener)
+	}
+
+	return results, nil
+}
+
+// parseGcpPortRange parse forwarding rule port range like "80-80" to start and end port.
+func parseGcpPortRange(portRange string) (int64, int64) {
+	ports := strings.Split(portRange, "-")
+	start, _ := strconv.ParseInt(ports[0], 10, 64)
+	if len(ports) < 2 {
+		return start, start
+	}
+
+	end, _ := strconv.ParseInt(ports[1], 10, 64)
+	return start, end
+}
+
+// CreateListener create forwarding rule which point to the target pool, return created forwarding rule cloud id.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules/insert
+func (g *Gcp) CreateListener(kt *kit.Kit, opt *typelb.ListenerCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return "", err
+	}
+
+	pool, err := g.getTargetPool(kt, client, opt.Region, opt.CloudLbID)
+	if err != nil {
+		return "", err
+	}
+
+	endPort := opt.Port
+	if opt.Extension != nil && opt.Extension.EndPort != nil {
+		endPort = *opt.Extension.EndPort
+	}
+
+	rule := &compute.ForwardingRule{
+		Name:                opt.Name,
+		IPProtocol:          opt.Protocol,
+		PortRange:           fmt.Sprintf("%d-%d", opt.Port, endPort),
+		Target:              pool.SelfLink,
+		LoadBalancingScheme: "EXTERNAL",
+	}
+	resp, err := client.ForwardingRules.Insert(g.CloudProjectID(), opt.Region, rule).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("create gcp forwarding rule failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return "", err
+	}
+
+	return strconv.FormatUint(resp.TargetId, 10), nil
+}
+
+// DeleteListener delete forwarding rule.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules/delete
+func (g *Gcp) DeleteListener(kt *kit.Kit, opt *typelb.ListenerDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	for _, cloudID := range opt.CloudIDs {
+		_, err = client.ForwardingRules.Delete(g.CloudProjectID(), opt.Region, cloudID).Context(kt.Ctx).Do()
+		if err != nil {
+			logs.Errorf("delete gcp forwarding rule failed, err: %v, id: %s, rid: %s", err, cloudID, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListTarget list instances of target pool, cloud inst id of target is the self link of instance.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/targetPools/get
+func (g *Gcp) ListTarget(kt *kit.Kit, opt *typelb.TargetListOption) ([]typelb.Target, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list target option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := g.getTargetPool(kt, client, opt.Region, opt.CloudLbID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]typelb.Target, 0, len(pool.Instances))
+	for _, instance := range pool.Instances {
+		results = append(results, typelb.Target{
+			CloudLbID:          opt.CloudLbID,
+			CloudTargetGroupID: pool.SelfLink,
+			InstType:           typelb.TargetInstTypeCvm,
+			CloudInstID:        instance,
+		})
+	}
+
+	return results, nil
+}
+
+// RegisterTargets add instances to target pool.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/targetPools/addInstance
+func (g *Gcp) RegisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error {
+	client, refs, err := g.prepareTargetOperate(kt, opt)
+	if err != nil {
+		return err
+	}
+
+	req := &compute.TargetPoolsAddInstanceRequest{Instances: refs}
+	_, err = client.TargetPools.AddInstance(g.CloudProjectID(), opt.Region, opt.CloudLbID, req).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("add gcp target pool instance failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DeregisterTargets remove instances from target pool.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/targetPools/removeInstance
+func (g *Gcp) DeregisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error {
+	client, refs, err := g.prepareTargetOperate(kt, opt)
+	if err != nil {
+		return err
+	}
+
+	req := &compute.TargetPoolsRemoveInstanceRequest{Instances: refs}
+	_, err = client.TargetPools.RemoveInstance(g.CloudProjectID(), opt.Region, opt.CloudLbID, req).Context(kt.Ctx).
+		Do()
+	if err != nil {
+		logs.Errorf("remove gcp target pool instance failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func (g *Gcp) prepareTargetOperate(kt *kit.Kit, opt *typelb.TargetOperateOption) (*compute.Service,
+	[]*compute.InstanceReference, error) {
+
+	if opt == nil {
+		return nil, nil, errf.New(errf.InvalidParameter, "operate target option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return nil, nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	refs := make([]*compute.InstanceReference, 0, len(opt.Targets))
+	for _, one := range opt.Targets {
+		if len(one.CloudInstID) == 0 {
+			return nil, nil, errf.New(errf.InvalidParameter, "gcp target cloud_inst_id(instance self link) is required")
+		}
+		refs = append(refs, &compute.InstanceReference{Instance: one.CloudInstID})
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, refs, nil
+}
@@ -0,0 +1,37 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package gcp
+
+import "testing"
+
+func TestParseGcpPortRange(t *testing.T) {
+	cases := map[string][2]int64{
+		"80-90":   {80, 90},
+		"443-443": {443, 443},
+		"8080":    {8080, 8080},
+		"":        {0, 0},
+	}
+
+	for portRange, expect := range cases {
+		start, end := parseGcpPortRange(portRange)
+		if start != expect[0] || end != expect[1] {
+			t.Errorf("parse gcp port range %s got %d-%d, expect %d-%d", portRange, start, end, expect[0], expect[1])
+		}
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"hcm/pkg/adaptor/types/core"
+	typesnapshot "hcm/pkg/adaptor/types/snapshot"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// ListSnapshot list disk snapshot, gcp snapshot is a global resource.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/snapshots/list
+func (g *Gcp) ListSnapshot(kt *kit.Kit, opt *core.GcpListOption) (*typesnapshot.GcpListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "gcp snapshot list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	request := client.Snapshots.List(g.CloudProjectID()).Context(kt.Ctx)
+
+	if len(opt.CloudIDs) > 0 {
+		request.Filter(generateResourceIDsFilter(opt.CloudIDs))
+	}
+
+	if len(opt.SelfLinks) > 0 {
+		request.Filter(generateResourceFilter("selfLink", opt.SelfLinks))
+	}
+
+	if opt.Page != nil {
+		request.MaxResults(opt.Page.PageSize).PageToken(opt.Page.PageToken)
+	}
+
+	resp, err := request.Do()
+	if err != nil {
+		logs.Errorf("list gcp snapshot failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+		return nil, err
+	}
+
+	details := make([]typesnapshot.GcpSnapshot, 0, len(resp.Items))
+	for _, one := range resp.Items {
+		details = append(details, convGcpSnapshot(one))
+	}
+
+	return &typesnapshot.GcpListResult{NextPageToken: resp.NextPageToken, Details: details}, nil
+}
+
+func convGcpSnapshot(one *compute.Snapshot) typesnapshot.GcpSnapshot {
+	return typesnapshot.GcpSnapshot{
+		BaseSnapshot: typesnapshot.BaseSnapshot{
+			CloudID:          strconv.FormatUint(one.Id, 10),
+			Name:             one.Name,
+			CloudDiskID:      one.SourceDiskId,
+			Size:             uint64(one.DiskSizeGb),
+			Status:           one.Status,
+			CloudCreatedTime: one.CreationTimestamp,
+		},
+		Extension: &coresnapshot.GcpSnapshotExtension{
+			SelfLink:         converter.ValToPtr(one.SelfLink),
+			SourceDisk:       converter.ValToPtr(one.SourceDisk),
+			StorageBytes:     converter.ValToPtr(one.StorageBytes),
+			StorageLocations: one.StorageLocations,
+		},
+	}
+}
+
+// CreateSnapshot create disk snapshot, return snapshot cloud id.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/createSnapshot
+func (g *Gcp) CreateSnapshot(kt *kit.Kit, opt *typesnapshot.CreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "gcp snapshot create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Zone) == 0 || len(opt.DiskName) == 0 {
+		return "", errf.New(errf.InvalidParameter, "gcp snapshot create requires zone and disk_name")
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return "", err
+	}
+
+	req := &compute.Snapshot{
+		Name:        opt.Name,
+		Description: converter.PtrToVal(opt.Memo),
+	}
+	_, err = client.Disks.CreateSnapshot(g.CloudProjectID(), opt.Zone, opt.DiskName, req).Context(kt.Ctx).Do()
+	if err != nil {
+		logs.Errorf("create gcp snapshot failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+		return "", err
+	}
+
+	return g.getSnapshotCloudID(kt, opt.Name)
+}
+
+// DeleteSnapshot delete disk snapshot by name.
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/snapshots/delete
+func (g *Gcp) DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "gcp snapshot delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Name) == 0 {
+		return errf.New(errf.InvalidParameter, "gcp snapshot delete requires name")
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.Snapshots.Delete(g.CloudProjectID(), opt.Name).Context(kt.Ctx).Do(); err != nil {
+		logs.Errorf("delete gcp snapshot failed, err: %v, opt: %v, rid: %s", err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// getSnapshotCloudID 快照创建为异步操作，轮询获取创建出的快照ID
+func (g *Gcp) getSnapshotCloudID(kt *kit.Kit, name string) (string, error) {
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return "", err
+	}
+
+	endTime := time.Now().Add(time.Duration(1) * time.Minute)
+	for {
+		if time.Now().After(endTime) {
+			return "", fmt.Errorf("snapshot not found, name: %s", name)
+		}
+
+		resp, err := client.Snapshots.Get(g.CloudProjectID(), name).Context(kt.Ctx).Do()
+		if err == nil && resp != nil && resp.Name == name {
+			return strconv.FormatUint(resp.Id, 10), nil
+		}
+
+		time.Sleep(time.Second)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	typetag "hcm/pkg/adaptor/types/tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// TagResources 为资源批量添加标签(gcp 中为 labels)，gcp 仅主机和硬盘支持 labels，且需要携带 labelFingerprint 全量设置
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/instances/setLabels
+func (g *Gcp) TagResources(kt *kit.Kit, opt *typetag.TagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return g.updateLabels(kt, opt.ResType, opt.Resources, func(labels map[string]string) {
+		for _, tag := range opt.Tags {
+			labels[tag.Key] = tag.Value
+		}
+	})
+}
+
+// UntagResources 批量删除资源的标签(gcp 中为 labels)
+// reference: https://cloud.google.com/compute/docs/reference/rest/v1/disks/setLabels
+func (g *Gcp) UntagResources(kt *kit.Kit, opt *typetag.UntagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return g.updateLabels(kt, opt.ResType, opt.Resources, func(labels map[string]string) {
+		for _, key := range opt.TagKeys {
+			delete(labels, key)
+		}
+	})
+}
+
+func (g *Gcp) updateLabels(kt *kit.Kit, resType enumor.CloudResourceType, resources []typetag.TagResItem,
+	modify func(labels map[string]string)) error {
+
+	if resType != enumor.CvmCloudResType && resType != enumor.DiskCloudResType {
+		return errf.Newf(errf.InvalidParameter, "gcp resource type %s does not support label", resType)
+	}
+
+	for _, one := range resources {
+		if len(one.Name) == 0 || len(one.Zone) == 0 {
+			return errf.Newf(errf.InvalidParameter, "gcp resource(%s) name and zone are required", one.CloudID)
+		}
+	}
+
+	client, err := g.clientSet.computeClient(kt)
+	if err != nil {
+		return err
+	}
+
+	for _, one := range resources {
+		if resType == enumor.CvmCloudResType {
+			err = g.updateInstanceLabels(kt, client, one, modify)
+		} else {
+			err = g.updateDiskLabels(kt, client, one, modify)
+		}
+		if err != nil {
+			logs.Errorf("update gcp %s labels failed, err: %v, resource: %+v, rid: %s", resType, err, one, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *Gcp) updateInstanceLabels(kt *kit.Kit, client *compute.Service, res typetag.TagResItem,
+	modify func(labels map[string]string)) error {
+
+	instance, err := client.Instances.Get(g.CloudProjectID(), res.Zone, res.Name).Context(kt.Ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	labels := make(map[string]string, len(instance.Labels))
+	for key, value := range instance.Labels {
+		labels[key] = value
+	}
+	modify(labels)
+
+	req := &compute.InstancesSetLabelsRequest{
+		LabelFingerprint: instance.LabelFingerprint,
+		Labels:           labels,
+	}
+	_, err = client.Instances.SetLabels(g.CloudProjectID(), res.Zone, res.Name, req).Context(kt.Ctx).Do()
+	return err
+}
+
+func (g *Gcp) updateDiskLabels(kt *kit.Kit, client *compute.Service, res typetag.TagResItem,
+	modify func(labels map[string]string)) error {
+
+	disk, err := client.Disks.Get(g.CloudProjectID(), res.Zone, res.Name).Context(kt.Ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	labels := make(map[string]string, len(disk.Labels))
+	for key, value := range disk.Labels {
+		labels[key] = value
+	}
+	modify(labels)
+
+	req := &compute.ZoneSetLabelsRequest{
+		LabelFingerprint: disk.LabelFingerprint,
+		Labels:           labels,
+	}
+	_, err = client.Disks.SetLabels(g.CloudProjectID(), res.Zone, res.Name, req).Context(kt.Ctx).Do()
+	return err
+}
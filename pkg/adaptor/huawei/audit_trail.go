@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+	"time"
+
+	typetrail "hcm/pkg/adaptor/types/audit-trail"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cts/v3/model"
+)
+
+// ctsQueryLimit 云审计事件单次最多查询的条数
+const ctsQueryLimit int32 = 200
+
+// ListAuditTrailEvent 通过云审计服务 ListTraces 接口查询主机、安全组的管理类事件。
+func (h *HuaWei) ListAuditTrailEvent(kt *kit.Kit, opt *typetrail.ListOption) (*typetrail.ListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	client, err := h.clientSet.ctsClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new huawei cts client failed, err: %v", err)
+	}
+
+	req := &model.ListTracesRequest{
+		TraceType: model.GetListTracesRequestTraceTypeEnum().SYSTEM,
+		Limit:     converter.ValToPtr(ctsQueryLimit),
+		From:      converter.ValToPtr(opt.StartTime.UnixMilli()),
+		To:        converter.ValToPtr(opt.EndTime.UnixMilli()),
+	}
+	if len(opt.NextToken) != 0 {
+		req.Next = converter.ValToPtr(opt.NextToken)
+	}
+
+	resp, err := client.ListTraces(req)
+	if err != nil {
+		logs.Errorf("list huawei cts traces failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return nil, err
+	}
+
+	result := &typetrail.ListResult{Events: make([]typetrail.Event, 0)}
+	if resp.MetaData != nil {
+		result.NextToken = converter.PtrToVal(resp.MetaData.Marker)
+	}
+
+	if resp.Traces == nil {
+		return result, nil
+	}
+
+	for _, one := range *resp.Traces {
+		result.Events = append(result.Events, convHuaWeiTrace(one, opt.Region)...)
+	}
+
+	return result, nil
+}
+
+func convHuaWeiTrace(one model.Traces, region string) []typetrail.Event {
+	resType, ok := typetrail.HuaWeiResType(converter.PtrToVal(one.ResourceType))
+	if !ok || len(converter.PtrToVal(one.ResourceId)) == 0 {
+		return nil
+	}
+
+	base := typetrail.Event{
+		EventID:   converter.PtrToVal(one.TraceId),
+		EventName: converter.PtrToVal(one.TraceName),
+		Region:    region,
+	}
+	if one.Time != nil {
+		base.EventTime = time.UnixMilli(*one.Time)
+	}
+	if one.User != nil {
+		base.Operator = converter.PtrToVal(one.User.Name)
+	}
+
+	resMap := map[enumor.CloudResourceType][]string{resType: {*one.ResourceId}}
+	return typetrail.BuildEvents(base, resMap)
+}
@@ -30,6 +30,8 @@ import (
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/region"
 	bssintl "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/bssintl/v2"
 	bssintlv2region "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/bssintl/v2/region"
+	cts "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cts/v3"
+	ctsregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cts/v3/region"
 	dcs "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/dcs/v2"
 	dcsregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/dcs/v2/region"
 	ecs "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2"
@@ -38,11 +40,17 @@ import (
 	eipregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/eip/v2/region"
 	eipv3 "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/eip/v3"
 	eipv3region "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/eip/v3/region"
+	elb "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v3"
+	elbregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v3/region"
 	evs "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2"
 	evsregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/region"
 	iam "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/iam/v3"
 	iamregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/iam/v3/region"
 	ims "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ims/v2"
+	kps "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/kps/v3"
+	kpsregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/kps/v3/region"
+	nat "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/nat/v2"
+	natregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/nat/v2/region"
 	rms "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/rms/v1"
 	rmsregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/rms/v1/region"
 	vpcv2 "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v2"
@@ -217,6 +225,40 @@ func (c *clientSet) ecsClient(regionID string) (cli *ecs.EcsClient, err error) {
 	return client, nil
 }
 
+func (c *clientSet) kpsClient(regionID string) (cli *kps.KpsClient, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("huawei error recovered, err: %v", p)
+		}
+	}()
+
+	cli = kps.NewKpsClient(
+		kps.KpsClientBuilder().
+			WithRegion(kpsregion.ValueOf(regionID)).
+			WithCredential(c.credentials()).
+			WithHttpConfig(config.DefaultHttpConfig()).
+			Build())
+
+	return cli, nil
+}
+
+func (c *clientSet) natClient(regionID string) (cli *nat.NatClient, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("huawei error recovered, err: %v", p)
+		}
+	}()
+
+	cli = nat.NewNatClient(
+		nat.NatClientBuilder().
+			WithRegion(natregion.ValueOf(regionID)).
+			WithCredential(c.credentials()).
+			WithHttpConfig(config.DefaultHttpConfig()).
+			Build())
+
+	return cli, nil
+}
+
 func (c *clientSet) dcsClient(regionID string) (cli *dcs.DcsClient, err error) {
 	defer func() {
 		if p := recover(); p != nil {
@@ -268,6 +310,23 @@ func (c *clientSet) eipV3Client(regionID string) (cli *eipv3.EipClient, err erro
 	return cli, nil
 }
 
+func (c *clientSet) elbClient(regionID string) (cli *elb.ElbClient, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("huawei error recovered, err: %v", p)
+		}
+	}()
+
+	cli = elb.NewElbClient(
+		elb.ElbClientBuilder().
+			WithRegion(elbregion.ValueOf(regionID)).
+			WithCredential(c.credentials()).
+			WithHttpConfig(config.DefaultHttpConfig()).
+			Build())
+
+	return cli, nil
+}
+
 func (c *clientSet) bssintlClient(regionID string) (cli *bssintl.BssintlClient, err error) {
 	defer func() {
 		if p := recover(); p != nil {
@@ -316,3 +375,20 @@ func (c *clientSet) newRmsClient() (cli *rms.RmsClient, err error) {
 
 	return client, nil
 }
+
+func (c *clientSet) ctsClient(regionID string) (cli *cts.CtsClient, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("huawei error recovered, err: %v", p)
+		}
+	}()
+
+	cli = cts.NewCtsClient(
+		cts.CtsClientBuilder().
+			WithRegion(ctsregion.ValueOf(regionID)).
+			WithCredential(c.credentials()).
+			WithHttpConfig(config.DefaultHttpConfig()).
+			Build())
+
+	return cli, nil
+}
@@ -580,7 +580,7 @@ func (h *HuaWei) CreateCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (*polle
 				ImageRef:  opt.CloudImageID,
 				FlavorRef: opt.InstanceType,
 				Name:      opt.Name,
-				AdminPass: converter.ValToPtr(opt.Password),
+				KeyName:   opt.KeyName,
 				Vpcid:     opt.CloudVpcID,
 				Nics: []model.PrePaidServerNic{
 					{
@@ -603,6 +603,11 @@ func (h *HuaWei) CreateCvm(kt *kit.Kit, opt *typecvm.HuaWeiCreateOption) (*polle
 		},
 	}
 
+	// 使用密钥对登录时不设置密码
+	if len(opt.Password) != 0 {
+		req.Body.Server.AdminPass = converter.ValToPtr(opt.Password)
+	}
+
 	if opt.PublicIPAssigned {
 		mode, err := opt.Eip.ChargingMode.EipChargingMode()
 		if err != nil {
@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"strconv"
+
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/kps/v3/model"
+)
+
+// ListKeyPair list key pair, huawei key pair has no cloud id, key pair name is used as cloud id.
+// reference: https://support.huaweicloud.com/api-dew/ListKeypairs.html
+func (h *HuaWei) ListKeyPair(kt *kit.Kit, opt *typekeypair.HuaWeiListOption) (*typekeypair.HuaWeiListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.kpsClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &model.ListKeypairsRequest{Marker: opt.Marker}
+	if opt.Limit > 0 {
+		req.Limit = converter.ValToPtr(strconv.Itoa(int(opt.Limit)))
+	}
+
+	resp, err := client.ListKeypairs(req)
+	if err != nil {
+		logs.Errorf("list huawei key pair failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return nil, err
+	}
+
+	result := &typekeypair.HuaWeiListResult{Details: make([]typekeypair.HuaWeiKeyPair, 0)}
+	if resp.PageInfo != nil {
+		result.NextMarker = resp.PageInfo.NextMarker
+	}
+
+	if resp.Keypairs == nil {
+		return result, nil
+	}
+
+	for _, one := range *resp.Keypairs {
+		if one.Keypair == nil {
+			continue
+		}
+		result.Details = append(result.Details, convHuaWeiKeyPair(one.Keypair, opt.Region))
+	}
+
+	return result, nil
+}
+
+func convHuaWeiKeyPair(one *model.Keypair, region string) typekeypair.HuaWeiKeyPair {
+	keyPair := typekeypair.HuaWeiKeyPair{
+		BaseKeyPair: typekeypair.BaseKeyPair{
+			CloudID:     converter.PtrToVal(one.Name),
+			Name:        converter.PtrToVal(one.Name),
+			Region:      region,
+			Fingerprint: converter.PtrToVal(one.Fingerprint),
+			PublicKey:   converter.PtrToVal(one.PublicKey),
+		},
+		Extension: new(corekeypair.HuaWeiKeyPairExtension),
+	}
+	if one.Type != nil {
+		keyPair.Extension.Type = converter.ValToPtr(one.Type.Value())
+	}
+	if one.Scope != nil {
+		keyPair.Extension.Scope = converter.ValToPtr(one.Scope.Value())
+	}
+
+	return keyPair
+}
+
+// ImportKeyPair import public key as key pair, return key pair name as cloud id.
+// reference: https://support.huaweicloud.com/api-dew/CreateKeypair.html
+func (h *HuaWei) ImportKeyPair(kt *kit.Kit, opt *typekeypair.ImportOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "import option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.kpsClient(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	keyType := model.GetCreateKeypairActionTypeEnum().SSH
+	req := &model.CreateKeypairRequest{
+		Body: &model.CreateKeypairRequestBody{
+			Keypair: &model.CreateKeypairAction{
+				Name:      opt.Name,
+				Type:      &keyType,
+				PublicKey: converter.ValToPtr(opt.PublicKey),
+			},
+		},
+	}
+
+	resp, err := client.CreateKeypair(req)
+	if err != nil {
+		logs.Errorf("import huawei key pair failed, name: %s, err: %v, rid: %s", opt.Name, err, kt.Rid)
+		return "", err
+	}
+
+	if resp.Keypair == nil || resp.Keypair.Name == nil {
+		return opt.Name, nil
+	}
+
+	return *resp.Keypair.Name, nil
+}
+
+// DeleteKeyPair delete key pair.
+// reference: https://support.huaweicloud.com/api-dew/DeleteKeypair.html
+func (h *HuaWei) DeleteKeyPair(kt *kit.Kit, opt *typekeypair.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.kpsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.DeleteKeypair(&model.DeleteKeypairRequest{KeypairName: opt.CloudID}); err != nil {
+		logs.Errorf("delete huawei key pair failed, name: %s, err: %v, rid: %s", opt.CloudID, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
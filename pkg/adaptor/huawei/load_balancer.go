@@ -0,0 +1,519 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v3/model"
+)
+
+// ListLoadBalancer list load balancer.
+// reference: https://support.huaweicloud.com/api-elb/ListLoadBalancers.html
+func (h *HuaWei) ListLoadBalancer(kt *kit.Kit, opt *core.HuaWeiListOption) (*typelb.HuaWeiListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new huawei elb client failed, err: %v", err)
+	}
+
+	req := new(model.ListLoadBalancersRequest)
+	if len(opt.CloudIDs) != 0 {
+		req.Id = converter.ValToPtr(opt.CloudIDs)
+	}
+
+	if opt.Page != nil {
+		req.Marker = opt.Page.Marker
+		req.Limit = opt.Page.Limit
+	}
+
+	resp, err := client.ListLoadBalancers(req)
+	if err != nil {
+		logs.Errorf("list huawei load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	details := make([]typelb.HuaWeiLoadBalancer, 0)
+	if resp.Loadbalancers != nil {
+		for _, one := range *resp.Loadbalancers {
+			details = append(details, convHuaWeiLoadBalancer(one, opt.Region))
+		}
+	}
+
+	result := &typelb.HuaWeiListResult{Details: details}
+	if resp.PageInfo != nil {
+		result.NextMarker = resp.PageInfo.NextMarker
+	}
+
+	return result, nil
+}
+
+func convHuaWeiLoadBalancer(lb model.LoadBalancer, region string) typelb.HuaWeiLoadBalancer {
+	publicIPs := make([]string, 0)
+	for _, one := range lb.Eips {
+		if one.EipAddress != nil {
+			publicIPs = append(publicIPs, *one.EipAddress)
+		}
+	}
+	if len(publicIPs) == 0 {
+		for _, one := range lb.Publicips {
+			publicIPs = append(publicIPs, one.PublicipAddress)
+		}
+	}
+
+	privateIPs := make([]string, 0)
+	if len(lb.VipAddress) != 0 {
+		privateIPs = append(privateIPs, lb.VipAddress)
+	}
+
+	// 华为云负载均衡没有公网/内网类型之分，根据是否为独享型区分
+	lbType := "shared"
+	if lb.Guaranteed {
+		lbType = "guaranteed"
+	}
+
+	return typelb.HuaWeiLoadBalancer{
+		BaseLoadBalancer: typelb.BaseLoadBalancer{
+			CloudID:              lb.Id,
+			Name:                 lb.Name,
+			Region:               region,
+			Zones:                lb.AvailabilityZoneList,
+			LBType:               lbType,
+			CloudVpcID:           lb.VpcId,
+			CloudSubnetID:        lb.VipSubnetCidrId,
+			PrivateIPv4Addresses: privateIPs,
+			PublicIPv4Addresses:  publicIPs,
+			Status:               lb.ProvisioningStatus,
+			CloudCreatedTime:     lb.CreatedAt,
+		},
+		Extension: &corelb.HuaWeiLoadBalancerExtension{
+			ProvisioningStatus:  converter.ValToPtr(lb.ProvisioningStatus),
+			OperatingStatus:     converter.ValToPtr(lb.OperatingStatus),
+			Guaranteed:          converter.ValToPtr(lb.Guaranteed),
+			CloudVipPortID:      converter.ValToPtr(lb.VipPortId),
+			EnterpriseProjectID: converter.ValToPtr(lb.EnterpriseProjectId),
+			BillingInfo:         converter.ValToPtr(lb.BillingInfo),
+			CloudSubnetIDs:      lb.ElbVirsubnetIds,
+		},
+	}
+}
+
+// CreateLoadBalancer create load balancer, return created load balancer cloud id.
+// reference: https://support.huaweicloud.com/api-elb/CreateLoadBalancer.html
+func (h *HuaWei) CreateLoadBalancer(kt *kit.Kit, opt *typelb.HuaWeiCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new huawei elb client failed, err: %v", err)
+	}
+
+	lbOpt := &model.CreateLoadBalancerOption{
+		Name:                 converter.ValToPtr(opt.Name),
+		AvailabilityZoneList: opt.Zones,
+	}
+	if len(opt.CloudVpcID) != 0 {
+		lbOpt.VpcId = converter.ValToPtr(opt.CloudVpcID)
+	}
+	if len(opt.CloudSubnetID) != 0 {
+		lbOpt.VipSubnetCidrId = converter.ValToPtr(opt.CloudSubnetID)
+	}
+	if len(opt.CloudElbVirSubnetIDs) != 0 {
+		lbOpt.ElbVirsubnetIds = converter.ValToPtr(opt.CloudElbVirSubnetIDs)
+	}
+	if len(opt.EnterpriseProjectID) != 0 {
+		lbOpt.EnterpriseProjectId = converter.ValToPtr(opt.EnterpriseProjectID)
+	}
+
+	req := &model.CreateLoadBalancerRequest{Body: &model.CreateLoadBalancerRequestBody{Loadbalancer: lbOpt}}
+	resp, err := client.CreateLoadBalancer(req)
+	if err != nil {
+		logs.Errorf("create huawei load balancer failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	if resp.LoadbalancerId != nil {
+		return *resp.LoadbalancerId, nil
+	}
+
+	if resp.Loadbalancer != nil {
+		return resp.Loadbalancer.Id, nil
+	}
+
+	return "", fmt.Errorf("create huawei load balancer but return load balancer id is empty")
+}
+
+// DeleteLoadBalancer delete load balancer.
+// reference: https://support.huaweicloud.com/api-elb/DeleteLoadBalancer.html
+func (h *HuaWei) DeleteLoadBalancer(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new huawei elb client failed, err: %v", err)
+	}
+
+	req := &model.DeleteLoadBalancerRequest{LoadbalancerId: opt.ResourceID}
+	if _, err = client.DeleteLoadBalancer(req); err != nil {
+		logs.Errorf("delete huawei load balancer failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ListListener list load balancer listener.
+// reference: https://support.huaweicloud.com/api-elb/ListListeners.html
+func (h *HuaWei) ListListener(kt *kit.Kit, opt *typelb.ListenerListOption) ([]typelb.Listener, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new huawei elb client failed, err: %v", err)
+	}
+
+	req := &model.ListListenersRequest{
+		LoadbalancerId: converter.ValToPtr([]string{opt.CloudLbID}),
+		Limit:          converter.ValToPtr(int32(core.HuaWeiQueryLimit)),
+	}
+	if len(opt.CloudIDs) != 0 {
+		req.Id = converter.ValToPtr(opt.CloudIDs)
+	}
+
+	results := make([]typelb.Listener, 0)
+	for {
+		resp, err := client.ListListeners(req)
+		if err != nil {
+			logs.Errorf("list huawei listener failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+			return nil, err
+		}
+
+		if resp.Listeners == nil || len(*resp.Listeners) == 0 {
+			break
+		}
+
+		for _, one := range *resp.Listeners {
+			results = append(results, typelb.Listener{
+				CloudID:            one.Id,
+				Name:               one.Name,
+				CloudLbID:          opt.CloudLbID,
+				Protocol:           one.Protocol,
+				Port:               int64(one.ProtocolPort),
+				CloudTargetGroupID: one.DefaultPoolId,
+				Extension:          new(corelb.ListenerExtension),
+			})
+		}
+
+		if resp.PageInfo == nil || resp.PageInfo.NextMarker == nil {
+			break
+		}
+		req.Marker = resp.PageInfo.NextMarker
+	}
+
+	return results, nil
+}
+
+// CreateListener create load balancer listener, if target group is not specified, a backend server group with
+// same name of listener will be created, return created listener cloud id.
+// reference: https://support.huaweicloud.com/api-elb/CreateListener.html
+func (h *HuaWei) CreateListener(kt *kit.Kit, opt *typelb.ListenerCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new huawei elb client failed, err: %v", err)
+	}
+
+	listenerOpt := &model.CreateListenerOption{
+		LoadbalancerId: opt.CloudLbID,
+		Name:           converter.ValToPtr(opt.Name),
+		Protocol:       opt.Protocol,
+		ProtocolPort:   int32(opt.Port),
+	}
+	if len(opt.CloudTargetGroupID) != 0 {
+		listenerOpt.DefaultPoolId = converter.ValToPtr(opt.CloudTargetGroupID)
+	}
+	if opt.Extension != nil && len(opt.Extension.CloudCertificateIDs) != 0 {
+		listenerOpt.DefaultTlsContainerRef = converter.ValToPtr(opt.Extension.CloudCertificateIDs[0])
+	}
+
+	req := &model.CreateListenerRequest{Body: &model.CreateListenerRequestBody{Listener: listenerOpt}}
+	resp, err := client.CreateListener(req)
+	if err != nil {
+		logs.Errorf("create huawei listener failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	if resp.Listener == nil {
+		return "", fmt.Errorf("create huawei listener but return listener is empty")
+	}
+
+	if len(opt.CloudTargetGroupID) != 0 {
+		return resp.Listener.Id, nil
+	}
+
+	// 后端服务器组协议：HTTPS、TERMINATED_HTTPS 监听器使用 HTTP 后端服务器组，其余与监听器协议相同
+	protocol := opt.Protocol
+	if protocol == "HTTPS" || protocol == "TERMINATED_HTTPS" {
+		protocol = "HTTP"
+	}
+
+	algorithm := "ROUND_ROBIN"
+	if opt.Extension != nil && opt.Extension.Scheduler != nil {
+		algorithm = *opt.Extension.Scheduler
+	}
+
+	poolReq := &model.CreatePoolRequest{Body: &model.CreatePoolRequestBody{Pool: &model.CreatePoolOption{
+		Name:        converter.ValToPtr(opt.Name),
+		LbAlgorithm: algorithm,
+		ListenerId:  converter.ValToPtr(resp.Listener.Id),
+		Protocol:    protocol,
+	}}}
+	if _, err = client.CreatePool(poolReq); err != nil {
+		logs.Errorf("create huawei pool for listener: %s failed, err: %v, rid: %s", resp.Listener.Id, err, kt.Rid)
+		return "", err
+	}
+
+	return resp.Listener.Id, nil
+}
+
+// DeleteListener delete load balancer listener.
+// reference: https://support.huaweicloud.com/api-elb/DeleteListener.html
+func (h *HuaWei) DeleteListener(kt *kit.Kit, opt *typelb.ListenerDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new huawei elb client failed, err: %v", err)
+	}
+
+	for _, cloudID := range opt.CloudIDs {
+		if _, err = client.DeleteListener(&model.DeleteListenerRequest{ListenerId: cloudID}); err != nil {
+			logs.Errorf("delete huawei listener failed, id: %s, err: %v, rid: %s", cloudID, err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListTarget list backend member of all pools which load balancer listeners forward to.
+// reference: https://support.huaweicloud.com/api-elb/ListMembers.html
+func (h *HuaWei) ListTarget(kt *kit.Kit, opt *typelb.TargetListOption) ([]typelb.Target, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list target option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listeners, err := h.ListListener(kt, &typelb.ListenerListOption{Region: opt.Region, CloudLbID: opt.CloudLbID})
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new huawei elb client failed, err: %v", err)
+	}
+
+	results := make([]typelb.Target, 0)
+	for _, listener := range listeners {
+		if len(listener.CloudTargetGroupID) == 0 {
+			continue
+		}
+
+		req := &model.ListMembersRequest{
+			PoolId: listener.CloudTargetGroupID,
+			Limit:  converter.ValToPtr(int32(core.HuaWeiQueryLimit)),
+		}
+		for {
+			resp, err := client.ListMembers(req)
+			if err != nil {
+				logs.Errorf("list huawei member failed, pool: %s, err: %v, rid: %s", req.PoolId, err, kt.Rid)
+				return nil, err
+			}
+
+			if resp.Members == nil || len(*resp.Members) == 0 {
+				break
+			}
+
+			for _, one := range *resp.Members {
+				target := typelb.Target{
+					CloudLbID:          opt.CloudLbID,
+					CloudListenerID:    listener.CloudID,
+					CloudTargetGroupID: listener.CloudTargetGroupID,
+					InstType:           typelb.TargetInstTypeIP,
+					CloudInstID:        converter.PtrToVal(one.InstanceId),
+					IP:                 one.Address,
+					Port:               int64(one.ProtocolPort),
+					Weight:             int64(one.Weight),
+				}
+				if len(target.CloudInstID) != 0 {
+					target.InstType = typelb.TargetInstTypeCvm
+				}
+				results = append(results, target)
+			}
+
+			if resp.PageInfo == nil || resp.PageInfo.NextMarker == nil {
+				break
+			}
+			req.Marker = resp.PageInfo.NextMarker
+		}
+	}
+
+	return results, nil
+}
+
+// RegisterTargets add backend member to pool, huawei member is identified by ip address and port.
+// reference: https://support.huaweicloud.com/api-elb/BatchCreateMembers.html
+func (h *HuaWei) RegisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error {
+	if err := validateHuaWeiTargetOperateOpt(opt); err != nil {
+		return err
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new huawei elb client failed, err: %v", err)
+	}
+
+	members := make([]model.BatchCreateMembersOption, 0, len(opt.Targets))
+	for _, one := range opt.Targets {
+		member := model.BatchCreateMembersOption{
+			Address:      one.IP,
+			ProtocolPort: int32(one.Port),
+		}
+		if one.Weight != nil {
+			member.Weight = converter.ValToPtr(int32(*one.Weight))
+		}
+		members = append(members, member)
+	}
+
+	req := &model.BatchCreateMembersRequest{
+		PoolId: opt.CloudTargetGroupID,
+		Body:   &model.BatchCreateMembersRequestBody{Members: members},
+	}
+	if _, err = client.BatchCreateMembers(req); err != nil {
+		logs.Errorf("register huawei member failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DeregisterTargets remove backend member from pool.
+// reference: https://support.huaweicloud.com/api-elb/BatchDeleteMembers.html
+func (h *HuaWei) DeregisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error {
+	if err := validateHuaWeiTargetOperateOpt(opt); err != nil {
+		return err
+	}
+
+	client, err := h.clientSet.elbClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new huawei elb client failed, err: %v", err)
+	}
+
+	members := make([]model.BatchDeleteMembersOption, 0, len(opt.Targets))
+	for _, one := range opt.Targets {
+		members = append(members, model.BatchDeleteMembersOption{
+			Address:      converter.ValToPtr(one.IP),
+			ProtocolPort: converter.ValToPtr(int32(one.Port)),
+		})
+	}
+
+	req := &model.BatchDeleteMembersRequest{
+		PoolId: opt.CloudTargetGroupID,
+		Body:   &model.BatchDeleteMembersRequestBody{Members: members},
+	}
+	if _, err = client.BatchDeleteMembers(req); err != nil {
+		logs.Errorf("deregister huawei member failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func validateHuaWeiTargetOperateOpt(opt *typelb.TargetOperateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "operate target option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 || len(opt.CloudTargetGroupID) == 0 {
+		return errf.New(errf.InvalidParameter, "region and cloud_target_group_id are required")
+	}
+
+	for _, one := range opt.Targets {
+		if len(one.IP) == 0 || one.Port == 0 {
+			return errf.New(errf.InvalidParameter, "huawei target ip and port are required")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package huawei
+
+import (
+	"reflect"
+	"testing"
+
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/elb/v3/model"
+)
+
+func TestConvHuaWeiLoadBalancer(t *testing.T) {
+	lb := model.LoadBalancer{
+		Id:                   "lb-1",
+		Name:                 "lb",
+		VipAddress:           "10.0.0.1",
+		Guaranteed:           true,
+		AvailabilityZoneList: []string{"cn-south-1a"},
+		Eips:                 []model.EipInfo{{EipAddress: converter.ValToPtr("1.1.1.1")}},
+		Publicips:            []model.PublicIpInfo{{PublicipAddress: "2.2.2.2"}},
+	}
+
+	result := convHuaWeiLoadBalancer(lb, "cn-south-1")
+	if result.CloudID != "lb-1" || result.Region != "cn-south-1" || result.LBType != "guaranteed" {
+		t.Fatalf("huawei load balancer base info %+v is unexpected", result.BaseLoadBalancer)
+	}
+
+	if !reflect.DeepEqual(result.PublicIPv4Addresses, []string{"1.1.1.1"}) {
+		t.Errorf("huawei load balancer public ips should use eips first, got %v", result.PublicIPv4Addresses)
+	}
+
+	if !reflect.DeepEqual(result.PrivateIPv4Addresses, []string{"10.0.0.1"}) {
+		t.Errorf("huawei load balancer private ips %v is unexpected", result.PrivateIPv4Addresses)
+	}
+
+	lb.Eips = nil
+	lb.Guaranteed = false
+	lb.VipAddress = ""
+	result = convHuaWeiLoadBalancer(lb, "cn-south-1")
+	if !reflect.DeepEqual(result.PublicIPv4Addresses, []string{"2.2.2.2"}) {
+		t.Errorf("huawei load balancer public ips should fall back to publicips, got %v", result.PublicIPv4Addresses)
+	}
+
+	if result.LBType != "shared" || len(result.PrivateIPv4Addresses) != 0 {
+		t.Errorf("huawei shared load balancer %+v is unexpected", result.BaseLoadBalancer)
+	}
+}
+
+func TestValidateHuaWeiTargetOperateOpt(t *testing.T) {
+	opt := &typelb.TargetOperateOption{
+		Region:             "cn-south-1",
+		CloudLbID:          "lb-1",
+		CloudTargetGroupID: "pool-1",
+		Targets:            []typelb.TargetInfo{{InstType: "ip", IP: "10.0.0.1", Port: 80}},
+	}
+	if err := validateHuaWeiTargetOperateOpt(opt); err != nil {
+		t.Fatalf("validate huawei target operate option failed, err: %v", err)
+	}
+
+	if err := validateHuaWeiTargetOperateOpt(nil); err == nil {
+		t.Errorf("validate nil huawei target operate option should failed")
+	}
+
+	noRegion := *opt
+	noRegion.Region = ""
+	if err := validateHuaWeiTargetOperateOpt(&noRegion); err == nil {
+		t.Errorf("validate huawei target operate option without region should failed")
+	}
+
+	// 华为云后端服务器组是必需的，仅有监听器时不能操作后端服务
+	noGroup := *opt
+	noGroup.CloudTargetGroupID = ""
+	noGroup.CloudListenerID = "listener-1"
+	if err := validateHuaWeiTargetOperateOpt(&noGroup); err == nil {
+		t.Errorf("validate huawei target operate option without target group should failed")
+	}
+
+	noIP := *opt
+	noIP.Targets = []typelb.TargetInfo{{InstType: "ip", CloudInstID: "ins-1", Port: 80}}
+	if err := validateHuaWeiTargetOperateOpt(&noIP); err == nil {
+		t.Errorf("validate huawei target operate option without ip should failed")
+	}
+
+	noPort := *opt
+	noPort.Targets = []typelb.TargetInfo{{InstType: "ip", IP: "10.0.0.1"}}
+	if err := validateHuaWeiTargetOperateOpt(&noPort); err == nil {
+		t.Errorf("validate huawei target operate option without port should failed")
+	}
+}
@@ -0,0 +1,428 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/adaptor/types/core"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	nat "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/nat/v2"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/nat/v2/model"
+)
+
+// natQueryLimit 华为云NAT网关及规则查询接口不分页，单次最多返回2000条
+const natQueryLimit int32 = 2000
+
+// ListNatGateway list nat gateway.
+// reference: https://support.huaweicloud.com/api-nat/nat_api_0003.html
+func (h *HuaWei) ListNatGateway(kt *kit.Kit, opt *typenat.HuaWeiListOption) ([]typenat.HuaWeiNatGateway, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.natClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new huawei nat client failed, err: %v", err)
+	}
+
+	req := &model.ListNatGatewaysRequest{Limit: converter.ValToPtr(natQueryLimit)}
+	if len(opt.CloudIDs) == 1 {
+		req.Id = converter.ValToPtr(opt.CloudIDs[0])
+	}
+
+	resp, err := client.ListNatGateways(req)
+	if err != nil {
+		logs.Errorf("list huawei nat gateway failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if resp.NatGateways == nil {
+		return make([]typenat.HuaWeiNatGateway, 0), nil
+	}
+
+	results := make([]typenat.HuaWeiNatGateway, 0, len(*resp.NatGateways))
+	for _, one := range *resp.NatGateways {
+		if len(opt.CloudIDs) != 0 && !slice.IsItemInSlice(opt.CloudIDs, one.Id) {
+			continue
+		}
+		results = append(results, convHuaWeiNatGateway(one, opt.Region))
+	}
+
+	return results, nil
+}
+
+func convHuaWeiNatGateway(one model.NatGatewayResponseBody, region string) typenat.HuaWeiNatGateway {
+	return typenat.HuaWeiNatGateway{
+		BaseNatGateway: typenat.BaseNatGateway{
+			CloudID:           one.Id,
+			Name:              one.Name,
+			Region:            region,
+			CloudVpcID:        one.RouterId,
+			CloudSubnetID:     one.InternalNetworkId,
+			Spec:              one.Spec.Value(),
+			PublicIPAddresses: make([]string, 0),
+			Status:            one.Status.Value(),
+			Memo:              converter.ValToPtr(one.Description),
+			CloudCreatedTime:  one.CreatedAt,
+		},
+		Extension: &corenat.HuaWeiNatGatewayExtension{
+			CloudInternalNetworkID: one.InternalNetworkId,
+			EnterpriseProjectID:    converter.ValToPtr(one.EnterpriseProjectId),
+			AdminStateUp:           one.AdminStateUp,
+		},
+	}
+}
+
+// CreateNatGateway create nat gateway, return created nat gateway cloud id.
+// reference: https://support.huaweicloud.com/api-nat/nat_api_0001.html
+func (h *HuaWei) CreateNatGateway(kt *kit.Kit, opt *typenat.HuaWeiCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.natClient(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new huawei nat client failed, err: %v", err)
+	}
+
+	specEnum := model.GetCreateNatGatewayOptionSpecEnum()
+	specs := map[string]model.CreateNatGatewayOptionSpec{
+		"1": specEnum.E_1, "2": specEnum.E_2, "3": specEnum.E_3, "4": specEnum.E_4,
+	}
+
+	natOpt := &model.CreateNatGatewayOption{
+		Name:              opt.Name,
+		RouterId:          opt.CloudVpcID,
+		InternalNetworkId: opt.CloudSubnetID,
+		Description:       opt.Memo,
+		Spec:              specs[opt.Spec],
+	}
+	if len(opt.EnterpriseProjectID) != 0 {
+		natOpt.EnterpriseProjectId = converter.ValToPtr(opt.EnterpriseProjectID)
+	}
+
+	req := &model.CreateNatGatewayRequest{Body: &model.CreateNatGatewayRequestBody{NatGateway: natOpt}}
+	resp, err := client.CreateNatGateway(req)
+	if err != nil {
+		logs.Errorf("create huawei nat gateway failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	if resp.NatGateway == nil {
+		return "", fmt.Errorf("create huawei nat gateway but return nat gateway is empty")
+	}
+
+	return resp.NatGateway.Id, nil
+}
+
+// DeleteNatGateway delete nat gateway, nat gateway with snat/dnat rules can not be deleted.
+// reference: https://support.huaweicloud.com/api-nat/nat_api_0006.html
+func (h *HuaWei) DeleteNatGateway(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.natClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new huawei nat client failed, err: %v", err)
+	}
+
+	req := &model.DeleteNatGatewayRequest{NatGatewayId: opt.ResourceID}
+	if _, err = client.DeleteNatGateway(req); err != nil {
+		logs.Errorf("delete huawei nat gateway failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ListNatRule list all snat and dnat rules of nat gateway.
+// reference: https://support.huaweicloud.com/api-nat/nat_api_0009.html
+// reference: https://support.huaweicloud.com/api-nat/nat_api_0014.html
+func (h *HuaWei) ListNatRule(kt *kit.Kit, opt *typenat.RuleListOption) ([]typenat.NatRule, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.natClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new huawei nat client failed, err: %v", err)
+	}
+
+	natIDs := []string{opt.CloudNatGatewayID}
+	snatReq := &model.ListNatGatewaySnatRulesRequest{NatGatewayId: &natIDs, Limit: converter.ValToPtr(natQueryLimit)}
+	snatResp, err := client.ListNatGatewaySnatRules(snatReq)
+	if err != nil {
+		logs.Errorf("list huawei snat rule failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return nil, err
+	}
+
+	dnatReq := &model.ListNatGatewayDnatRulesRequest{NatGatewayId: &natIDs, Limit: converter.ValToPtr(natQueryLimit)}
+	dnatResp, err := client.ListNatGatewayDnatRules(dnatReq)
+	if err != nil {
+		logs.Errorf("list huawei dnat rule failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return nil, err
+	}
+
+	results := make([]typenat.NatRule, 0)
+	if snatResp.SnatRules != nil {
+		for _, one := range *snatResp.SnatRules {
+			results = append(results, convHuaWeiSnatRule(one))
+		}
+	}
+	if dnatResp.DnatRules != nil {
+		for _, one := range *dnatResp.DnatRules {
+			results = append(results, convHuaWeiDnatRule(one))
+		}
+	}
+
+	return results, nil
+}
+
+func convHuaWeiSnatRule(one model.NatGatewaySnatRuleResponseBody) typenat.NatRule {
+	return typenat.NatRule{
+		CloudID:           one.Id,
+		CloudNatGatewayID: one.NatGatewayId,
+		RuleType:          enumor.SnatRuleType,
+		CloudSubnetID:     one.NetworkId,
+		SourceCidr:        one.Cidr,
+		PublicIP:          one.FloatingIpAddress,
+		Status:            one.Status.Value(),
+		Memo:              converter.ValToPtr(one.Description),
+		Extension: &corenat.NatGatewayRuleExtension{
+			CloudEipID:       converter.ValToPtr(one.FloatingIpId),
+			CloudCreatedTime: converter.ValToPtr(one.CreatedAt),
+		},
+	}
+}
+
+func convHuaWeiDnatRule(one model.NatGatewayDnatRuleResponseBody) typenat.NatRule {
+	return typenat.NatRule{
+		CloudID:           one.Id,
+		CloudNatGatewayID: one.NatGatewayId,
+		RuleType:          enumor.DnatRuleType,
+		Protocol:          one.Protocol.Value(),
+		PublicIP:          one.FloatingIpAddress,
+		PublicPort:        int64(one.ExternalServicePort),
+		PrivateIP:         converter.PtrToVal(one.PrivateIp),
+		PrivatePort:       int64(one.InternalServicePort),
+		Status:            one.Status.Value(),
+		Memo:              converter.ValToPtr(one.Description),
+		Extension: &corenat.NatGatewayRuleExtension{
+			CloudEipID:       converter.ValToPtr(one.FloatingIpId),
+			CloudPortID:      one.PortId,
+			CloudCreatedTime: converter.ValToPtr(one.CreatedAt),
+		},
+	}
+}
+
+// CreateNatRule create nat gateway snat/dnat rule, return created rule cloud id.
+// reference: https://support.huaweicloud.com/api-nat/nat_api_0008.html
+// reference: https://support.huaweicloud.com/api-nat/nat_api_0013.html
+func (h *HuaWei) CreateNatRule(kt *kit.Kit, opt *typenat.RuleCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.CloudEipID) == 0 {
+		return "", errf.New(errf.InvalidParameter, "cloud_eip_id is required")
+	}
+
+	client, err := h.clientSet.natClient(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new huawei nat client failed, err: %v", err)
+	}
+
+	if opt.RuleType == enumor.DnatRuleType {
+		return h.createDnatRule(kt, client, opt)
+	}
+
+	snatOpt := &model.CreateNatGatewaySnatRuleOption{
+		NatGatewayId: opt.CloudNatGatewayID,
+		Description:  opt.Memo,
+		FloatingIpId: opt.CloudEipID,
+	}
+	if len(opt.CloudSubnetID) != 0 {
+		snatOpt.NetworkId = converter.ValToPtr(opt.CloudSubnetID)
+	} else {
+		snatOpt.Cidr = converter.ValToPtr(opt.SourceCidr)
+	}
+
+	req := &model.CreateNatGatewaySnatRuleRequest{
+		Body: &model.CreateNatGatewaySnatRuleRequestOption{SnatRule: snatOpt},
+	}
+	resp, err := client.CreateNatGatewaySnatRule(req)
+	if err != nil {
+		logs.Errorf("create huawei snat rule failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	if resp.SnatRule == nil {
+		return "", fmt.Errorf("create huawei snat rule but return rule is empty")
+	}
+
+	return resp.SnatRule.Id, nil
+}
+
+func (h *HuaWei) createDnatRule(kt *kit.Kit, client *nat.NatClient, opt *typenat.RuleCreateOption) (string, error) {
+	dnatOpt := &model.CreateNatGatewayDnatOption{
+		Description:         opt.Memo,
+		PrivateIp:           converter.ValToPtr(opt.PrivateIP),
+		NatGatewayId:        opt.CloudNatGatewayID,
+		InternalServicePort: int32(opt.PrivatePort),
+		FloatingIpId:        opt.CloudEipID,
+		ExternalServicePort: int32(opt.PublicPort),
+		Protocol:            strings.ToLower(opt.Protocol),
+	}
+
+	req := &model.CreateNatGatewayDnatRuleRequest{Body: &model.CreateNatGatewayDnatRuleOption{DnatRule: dnatOpt}}
+	resp, err := client.CreateNatGatewayDnatRule(req)
+	if err != nil {
+		logs.Errorf("create huawei dnat rule failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	if resp.DnatRule == nil {
+		return "", fmt.Errorf("create huawei dnat rule but return rule is empty")
+	}
+
+	return resp.DnatRule.Id, nil
+}
+
+// UpdateNatRule update nat gateway snat/dnat rule.
+// reference: https://support.huaweicloud.com/api-nat/nat_api_0090.html
+// reference: https://support.huaweicloud.com/api-nat/nat_api_0091.html
+func (h *HuaWei) UpdateNatRule(kt *kit.Kit, opt *typenat.RuleUpdateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "update option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.natClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new huawei nat client failed, err: %v", err)
+	}
+
+	if opt.RuleType == enumor.SnatRuleType {
+		req := &model.UpdateNatGatewaySnatRuleRequest{
+			SnatRuleId: opt.CloudID,
+			Body: &model.UpdateNatGatewaySnatRuleRequestOption{
+				SnatRule: &model.UpdateNatGatewaySnatRuleOption{
+					NatGatewayId: opt.CloudNatGatewayID,
+					Description:  opt.Memo,
+				},
+			},
+		}
+		if _, err = client.UpdateNatGatewaySnatRule(req); err != nil {
+			logs.Errorf("update huawei snat rule failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+			return err
+		}
+		return nil
+	}
+
+	dnatOpt := &model.UpdateNatGatewayDnatRuleOption{
+		NatGatewayId: opt.CloudNatGatewayID,
+		Description:  opt.Memo,
+	}
+	if len(opt.PrivateIP) != 0 {
+		dnatOpt.PrivateIp = converter.ValToPtr(opt.PrivateIP)
+	}
+	if opt.PrivatePort != 0 {
+		dnatOpt.InternalServicePort = converter.ValToPtr(int32(opt.PrivatePort))
+	}
+
+	req := &model.UpdateNatGatewayDnatRuleRequest{
+		DnatRuleId: opt.CloudID,
+		Body:       &model.UpdateNatGatewayDnatRuleRequestBody{DnatRule: dnatOpt},
+	}
+	if _, err = client.UpdateNatGatewayDnatRule(req); err != nil {
+		logs.Errorf("update huawei dnat rule failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteNatRule delete nat gateway snat/dnat rule, huawei only support delete rule one by one.
+// reference: https://support.huaweicloud.com/api-nat/nat_api_0012.html
+// reference: https://support.huaweicloud.com/api-nat/nat_api_0017.html
+func (h *HuaWei) DeleteNatRule(kt *kit.Kit, opt *typenat.RuleDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.natClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new huawei nat client failed, err: %v", err)
+	}
+
+	for _, cloudID := range opt.CloudIDs {
+		if opt.RuleType == enumor.SnatRuleType {
+			req := &model.DeleteNatGatewaySnatRuleRequest{NatGatewayId: opt.CloudNatGatewayID, SnatRuleId: cloudID}
+			_, err = client.DeleteNatGatewaySnatRule(req)
+		} else {
+			req := &model.DeleteNatGatewayDnatRuleRequest{NatGatewayId: opt.CloudNatGatewayID, DnatRuleId: cloudID}
+			_, err = client.DeleteNatGatewayDnatRule(req)
+		}
+		if err != nil {
+			logs.Errorf("delete huawei %s rule failed, id: %s, err: %v, rid: %s", opt.RuleType, cloudID, err, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	"strings"
+
+	typesnapshot "hcm/pkg/adaptor/types/snapshot"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+)
+
+// ListSnapshot list disk snapshot, huawei only supports filtering by one snapshot id, so snapshots are queried one
+// by one when cloud ids are specified.
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2029.html
+func (h *HuaWei) ListSnapshot(kt *kit.Kit, opt *typesnapshot.HuaWeiListOption) ([]typesnapshot.HuaWeiSnapshot,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := make([]*model.ListSnapshotsRequest, 0)
+	if len(opt.CloudIDs) != 0 {
+		for _, id := range opt.CloudIDs {
+			reqs = append(reqs, &model.ListSnapshotsRequest{Id: converter.ValToPtr(id)})
+		}
+	} else {
+		req := new(model.ListSnapshotsRequest)
+		if opt.Page != nil {
+			req.Offset = converter.ValToPtr(opt.Page.Offset)
+			req.Limit = converter.ValToPtr(opt.Page.Limit)
+		}
+		reqs = append(reqs, req)
+	}
+
+	results := make([]typesnapshot.HuaWeiSnapshot, 0)
+	for _, req := range reqs {
+		resp, err := client.ListSnapshots(req)
+		if err != nil {
+			if strings.Contains(err.Error(), ErrDataNotFound) {
+				continue
+			}
+			logs.Errorf("list huawei snapshot failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+			return nil, err
+		}
+
+		if resp.Snapshots == nil {
+			continue
+		}
+
+		for _, one := range *resp.Snapshots {
+			results = append(results, typesnapshot.HuaWeiSnapshot{
+				BaseSnapshot: typesnapshot.BaseSnapshot{
+					CloudID:          one.Id,
+					Name:             converter.PtrToVal(one.Name),
+					Region:           opt.Region,
+					CloudDiskID:      one.VolumeId,
+					Size:             uint64(one.Size),
+					Status:           one.Status,
+					CloudCreatedTime: one.CreatedAt,
+				},
+				Extension: &coresnapshot.HuaWeiSnapshotExtension{
+					UpdatedAt: one.UpdatedAt,
+					Metadata:  one.Metadata,
+				},
+			})
+		}
+	}
+
+	return results, nil
+}
+
+// CreateSnapshot create disk snapshot, return snapshot cloud id.
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2025.html
+func (h *HuaWei) CreateSnapshot(kt *kit.Kit, opt *typesnapshot.CreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	req := &model.CreateSnapshotRequest{
+		Body: &model.CreateSnapshotRequestBody{
+			Snapshot: &model.CreateSnapshotOption{
+				VolumeId:    opt.CloudDiskID,
+				Name:        converter.ValToPtr(opt.Name),
+				Description: opt.Memo,
+			},
+		},
+	}
+	resp, err := client.CreateSnapshot(req)
+	if err != nil {
+		logs.Errorf("create huawei snapshot failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	if resp.Snapshot == nil {
+		return "", errf.New(errf.Unknown, "create huawei snapshot return snapshot is empty")
+	}
+
+	return converter.PtrToVal(resp.Snapshot.Id), nil
+}
+
+// DeleteSnapshot delete disk snapshot.
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2027.html
+func (h *HuaWei) DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	if _, err = client.DeleteSnapshot(&model.DeleteSnapshotRequest{SnapshotId: opt.CloudID}); err != nil {
+		logs.Errorf("delete huawei snapshot failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RollbackSnapshot rollback disk by snapshot, disk must be detached.
+// reference: https://support.huaweicloud.com/api-evs/evs_04_2031.html
+func (h *HuaWei) RollbackSnapshot(kt *kit.Kit, opt *typesnapshot.RollbackOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "rollback option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := &model.RollbackSnapshotRequest{
+		SnapshotId: opt.CloudID,
+		Body: &model.RollbackSnapshotRequestBody{
+			Rollback: &model.RollbackSnapshotOption{VolumeId: opt.CloudDiskID},
+		},
+	}
+	if _, err = client.RollbackSnapshot(req); err != nil {
+		logs.Errorf("rollback huawei snapshot failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
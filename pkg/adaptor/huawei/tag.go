@@ -0,0 +1,286 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package huawei
+
+import (
+	typetag "hcm/pkg/adaptor/types/tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	ecsmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2/model"
+	evsmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/evs/v2/model"
+	vpcmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v2/model"
+)
+
+// TagResources 为资源批量添加标签，华为云各服务的标签接口仅支持单个资源，需要逐个资源调用
+// reference: https://support.huaweicloud.com/api-ecs/ecs_02_1002.html
+func (h *HuaWei) TagResources(kt *kit.Kit, opt *typetag.TagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	var err error
+	switch opt.ResType {
+	case enumor.CvmCloudResType:
+		err = h.createServerTags(kt, opt)
+	case enumor.DiskCloudResType:
+		err = h.createVolumeTags(kt, opt)
+	case enumor.VpcCloudResType, enumor.SubnetCloudResType:
+		err = h.createVpcResTags(kt, opt)
+	default:
+		return errf.Newf(errf.InvalidParameter, "huawei resource type %s does not support tag", opt.ResType)
+	}
+	if err != nil {
+		logs.Errorf("create huawei %s tags failed, err: %v, opt: %+v, rid: %s", opt.ResType, err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func (h *HuaWei) createServerTags(kt *kit.Kit, opt *typetag.TagResOption) error {
+	client, err := h.clientSet.ecsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]ecsmodel.ServerTag, 0, len(opt.Tags))
+	for _, tag := range opt.Tags {
+		tags = append(tags, ecsmodel.ServerTag{Key: tag.Key, Value: tag.Value})
+	}
+
+	for _, one := range opt.Resources {
+		req := &ecsmodel.BatchCreateServerTagsRequest{
+			ServerId: one.CloudID,
+			Body: &ecsmodel.BatchCreateServerTagsRequestBody{
+				Action: ecsmodel.GetBatchCreateServerTagsRequestBodyActionEnum().CREATE,
+				Tags:   tags,
+			},
+		}
+		if _, err = client.BatchCreateServerTags(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HuaWei) createVolumeTags(kt *kit.Kit, opt *typetag.TagResOption) error {
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]evsmodel.Tag, 0, len(opt.Tags))
+	for _, tag := range opt.Tags {
+		tags = append(tags, evsmodel.Tag{Key: tag.Key, Value: tag.Value})
+	}
+
+	for _, one := range opt.Resources {
+		req := &evsmodel.BatchCreateVolumeTagsRequest{
+			VolumeId: one.CloudID,
+			Body: &evsmodel.BatchCreateVolumeTagsRequestBody{
+				Action: evsmodel.GetBatchCreateVolumeTagsRequestBodyActionEnum().CREATE,
+				Tags:   tags,
+			},
+		}
+		if _, err = client.BatchCreateVolumeTags(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HuaWei) createVpcResTags(kt *kit.Kit, opt *typetag.TagResOption) error {
+	client, err := h.clientSet.vpcClientV2(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]vpcmodel.ResourceTag, 0, len(opt.Tags))
+	for _, tag := range opt.Tags {
+		tags = append(tags, vpcmodel.ResourceTag{Key: tag.Key, Value: tag.Value})
+	}
+
+	for _, one := range opt.Resources {
+		if opt.ResType == enumor.VpcCloudResType {
+			req := &vpcmodel.BatchCreateVpcTagsRequest{
+				VpcId: one.CloudID,
+				Body: &vpcmodel.BatchCreateVpcTagsRequestBody{
+					Action: vpcmodel.GetBatchCreateVpcTagsRequestBodyActionEnum().CREATE,
+					Tags:   tags,
+				},
+			}
+			_, err = client.BatchCreateVpcTags(req)
+		} else {
+			req := &vpcmodel.BatchCreateSubnetTagsRequest{
+				SubnetId: one.CloudID,
+				Body: &vpcmodel.BatchCreateSubnetTagsRequestBody{
+					Action: vpcmodel.GetBatchCreateSubnetTagsRequestBodyActionEnum().CREATE,
+					Tags:   tags,
+				},
+			}
+			_, err = client.BatchCreateSubnetTags(req)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UntagResources 批量删除资源的标签
+// reference: https://support.huaweicloud.com/api-ecs/ecs_02_1003.html
+func (h *HuaWei) UntagResources(kt *kit.Kit, opt *typetag.UntagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.Region) == 0 {
+		return errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	var err error
+	switch opt.ResType {
+	case enumor.CvmCloudResType:
+		err = h.deleteServerTags(kt, opt)
+	case enumor.DiskCloudResType:
+		err = h.deleteVolumeTags(kt, opt)
+	case enumor.VpcCloudResType, enumor.SubnetCloudResType:
+		err = h.deleteVpcResTags(kt, opt)
+	default:
+		return errf.Newf(errf.InvalidParameter, "huawei resource type %s does not support tag", opt.ResType)
+	}
+	if err != nil {
+		logs.Errorf("delete huawei %s tags failed, err: %v, opt: %+v, rid: %s", opt.ResType, err, opt, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+func (h *HuaWei) deleteServerTags(kt *kit.Kit, opt *typetag.UntagResOption) error {
+	client, err := h.clientSet.ecsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]ecsmodel.ServerTag, 0, len(opt.TagKeys))
+	for _, key := range opt.TagKeys {
+		tags = append(tags, ecsmodel.ServerTag{Key: key})
+	}
+
+	for _, one := range opt.Resources {
+		req := &ecsmodel.BatchDeleteServerTagsRequest{
+			ServerId: one.CloudID,
+			Body: &ecsmodel.BatchDeleteServerTagsRequestBody{
+				Action: ecsmodel.GetBatchDeleteServerTagsRequestBodyActionEnum().DELETE,
+				Tags:   tags,
+			},
+		}
+		if _, err = client.BatchDeleteServerTags(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HuaWei) deleteVolumeTags(kt *kit.Kit, opt *typetag.UntagResOption) error {
+	client, err := h.clientSet.evsClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]evsmodel.DeleteTagsOption, 0, len(opt.TagKeys))
+	for _, key := range opt.TagKeys {
+		tags = append(tags, evsmodel.DeleteTagsOption{Key: key})
+	}
+
+	for _, one := range opt.Resources {
+		req := &evsmodel.BatchDeleteVolumeTagsRequest{
+			VolumeId: one.CloudID,
+			Body: &evsmodel.BatchDeleteVolumeTagsRequestBody{
+				Action: evsmodel.GetBatchDeleteVolumeTagsRequestBodyActionEnum().DELETE,
+				Tags:   tags,
+			},
+		}
+		if _, err = client.BatchDeleteVolumeTags(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HuaWei) deleteVpcResTags(kt *kit.Kit, opt *typetag.UntagResOption) error {
+	client, err := h.clientSet.vpcClientV2(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]vpcmodel.ResourceTag, 0, len(opt.TagKeys))
+	for _, key := range opt.TagKeys {
+		tags = append(tags, vpcmodel.ResourceTag{Key: key})
+	}
+
+	for _, one := range opt.Resources {
+		if opt.ResType == enumor.VpcCloudResType {
+			req := &vpcmodel.BatchDeleteVpcTagsRequest{
+				VpcId: one.CloudID,
+				Body: &vpcmodel.BatchDeleteVpcTagsRequestBody{
+					Action: vpcmodel.GetBatchDeleteVpcTagsRequestBodyActionEnum().DELETE,
+					Tags:   tags,
+				},
+			}
+			_, err = client.BatchDeleteVpcTags(req)
+		} else {
+			req := &vpcmodel.BatchDeleteSubnetTagsRequest{
+				SubnetId: one.CloudID,
+				Body: &vpcmodel.BatchDeleteSubnetTagsRequestBody{
+					Action: vpcmodel.GetBatchDeleteSubnetTagsRequestBodyActionEnum().DELETE,
+					Tags:   tags,
+				},
+			}
+			_, err = client.BatchDeleteSubnetTags(req)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	typetrail "hcm/pkg/adaptor/types/audit-trail"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+const (
+	cloudAuditService = "cloudaudit"
+	cloudAuditVersion = "2019-03-19"
+
+	// cloudAuditRegion 操作审计事件不区分地域，未指定地域时使用该地域调用接口
+	cloudAuditRegion = "ap-guangzhou"
+	// cloudAuditQueryLimit 操作审计事件单次最多查询的条数
+	cloudAuditQueryLimit = 50
+)
+
+// ListAuditTrailEvent 通过操作审计 LookUpEvents 接口查询主机、安全组的写操作事件，
+// NextToken 为不透明的分页标识，原样传入即可。
+func (t *TCloudImpl) ListAuditTrailEvent(kt *kit.Kit, opt *typetrail.ListOption) (*typetrail.ListResult, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	region := opt.Region
+	if len(region) == 0 {
+		region = cloudAuditRegion
+	}
+
+	client, err := t.clientSet.CloudAuditClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud cloud audit client failed, err: %v", err)
+	}
+
+	params := map[string]interface{}{
+		"StartTime":  opt.StartTime.Unix(),
+		"EndTime":    opt.EndTime.Unix(),
+		"MaxResults": cloudAuditQueryLimit,
+		"LookupAttributes": []map[string]string{
+			{"AttributeKey": "ActionType", "AttributeValue": "Write"},
+		},
+	}
+	if len(opt.NextToken) != 0 {
+		params["NextToken"] = json.RawMessage(opt.NextToken)
+	}
+
+	resp := new(cloudAuditLookUpEventsResp)
+	err = commonRequest(kt, client, cloudAuditService, cloudAuditVersion, "LookUpEvents", params, resp)
+	if err != nil {
+		logs.Errorf("look up tcloud cloud audit events failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Rid)
+		return nil, err
+	}
+
+	result := &typetrail.ListResult{Events: make([]typetrail.Event, 0, len(resp.Events))}
+	if !resp.ListOver && len(resp.NextToken) != 0 && string(resp.NextToken) != "null" {
+		result.NextToken = string(resp.NextToken)
+	}
+
+	for _, one := range resp.Events {
+		result.Events = append(result.Events, convTCloudAuditEvent(one)...)
+	}
+
+	return result, nil
+}
+
+func convTCloudAuditEvent(one cloudAuditEvent) []typetrail.Event {
+	base := typetrail.Event{
+		EventID:   one.EventID,
+		EventName: one.EventName,
+		Region:    one.EventRegion,
+		Operator:  one.Username,
+	}
+	if sec, err := strconv.ParseInt(one.EventTime, 10, 64); err == nil {
+		base.EventTime = time.Unix(sec, 0)
+	}
+
+	// 资源名为云资源ID，批量操作时以逗号分隔
+	resMap := make(map[enumor.CloudResourceType][]string)
+	for _, name := range strings.Split(one.Resources.ResourceName, ",") {
+		cloudID := strings.TrimSpace(name)
+		resType, ok := typetrail.TCloudResType(cloudID)
+		if !ok {
+			continue
+		}
+		resMap[resType] = append(resMap[resType], cloudID)
+	}
+
+	return typetrail.BuildEvents(base, resMap)
+}
+
+type cloudAuditLookUpEventsResp struct {
+	Events    []cloudAuditEvent `json:"Events"`
+	ListOver  bool              `json:"ListOver"`
+	NextToken json.RawMessage   `json:"NextToken"`
+}
+
+type cloudAuditEvent struct {
+	EventID     string `json:"EventId"`
+	EventName   string `json:"EventName"`
+	EventTime   string `json:"EventTime"`
+	EventRegion string `json:"EventRegion"`
+	Username    string `json:"Username"`
+	Resources   struct {
+		ResourceType string `json:"ResourceType"`
+		ResourceName string `json:"ResourceName"`
+	} `json:"Resources"`
+}
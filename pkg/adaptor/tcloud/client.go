@@ -43,6 +43,9 @@ type ClientSet interface {
 	CbsClient(region string) (*cbs.Client, error)
 	VpcClient(region string) (*vpc.Client, error)
 	BillClient() (*billing.Client, error)
+	ClbClient(region string) (*common.Client, error)
+	TagClient(region string) (*common.Client, error)
+	CloudAuditClient(region string) (*common.Client, error)
 }
 
 // clientSet to get tcloud sdk client set
@@ -107,3 +110,19 @@ func (c *clientSet) BillClient() (*billing.Client, error) {
 
 	return client, nil
 }
+
+// ClbClient tcloud sdk common client for clb, clb sdk is not imported, so use common client to call clb api.
+func (c *clientSet) ClbClient(region string) (*common.Client, error) {
+	return common.NewCommonClient(c.credential, region, c.profile), nil
+}
+
+// TagClient tcloud sdk common client for tag, tag sdk is not imported, so use common client to call tag api.
+func (c *clientSet) TagClient(region string) (*common.Client, error) {
+	return common.NewCommonClient(c.credential, region, c.profile), nil
+}
+
+// CloudAuditClient tcloud sdk common client for cloud audit, cloud audit sdk is not imported,
+// so use common client to call cloud audit api.
+func (c *clientSet) CloudAuditClient(region string) (*common.Client, error) {
+	return common.NewCommonClient(c.credential, region, c.profile), nil
+}
@@ -319,9 +319,7 @@ func (t *TCloudImpl) CreateCvm(kt *kit.Kit, opt *typecvm.TCloudCreateOption) (*p
 		VpcId:    common.StringPtr(opt.CloudVpcID),
 		SubnetId: common.StringPtr(opt.CloudSubnetID),
 	}
-	req.LoginSettings = &cvm.LoginSettings{
-		Password: common.StringPtr(opt.Password),
-	}
+	req.LoginSettings = convLoginSettings(opt)
 	req.InternetAccessible = &cvm.InternetAccessible{
 		InternetMaxBandwidthOut: common.Int64Ptr(opt.InternetMaxBandwidthOut),
 		PublicIpAssigned:        common.BoolPtr(opt.PublicIPAssigned),
@@ -415,9 +413,7 @@ func (t *TCloudImpl) InquiryPriceCvm(kt *kit.Kit, opt *typecvm.TCloudCreateOptio
 		VpcId:    common.StringPtr(opt.CloudVpcID),
 		SubnetId: common.StringPtr(opt.CloudSubnetID),
 	}
-	req.LoginSettings = &cvm.LoginSettings{
-		Password: common.StringPtr(opt.Password),
-	}
+	req.LoginSettings = convLoginSettings(opt)
 	req.InternetAccessible = &cvm.InternetAccessible{
 		PublicIpAssigned:        common.BoolPtr(opt.PublicIPAssigned),
 		InternetMaxBandwidthOut: common.Int64Ptr(opt.InternetMaxBandwidthOut),
@@ -482,6 +478,15 @@ func (t *TCloudImpl) InquiryPriceCvm(kt *kit.Kit, opt *typecvm.TCloudCreateOptio
 	return result, nil
 }
 
+// convLoginSettings 设置了密钥对时使用密钥对登录，否则使用密码登录
+func convLoginSettings(opt *typecvm.TCloudCreateOption) *cvm.LoginSettings {
+	if len(opt.CloudKeyPairIDs) != 0 {
+		return &cvm.LoginSettings{KeyIds: common.StringPtrs(opt.CloudKeyPairIDs)}
+	}
+
+	return &cvm.LoginSettings{Password: common.StringPtr(opt.Password)}
+}
+
 type startCvmPollingHandler struct {
 	region string
 }
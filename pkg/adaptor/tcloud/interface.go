@@ -26,6 +26,7 @@ import (
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/adaptor/types/account"
 	typeargstpl "hcm/pkg/adaptor/types/argument-template"
+	typetrail "hcm/pkg/adaptor/types/audit-trail"
 	typesBill "hcm/pkg/adaptor/types/bill"
 	"hcm/pkg/adaptor/types/core"
 	"hcm/pkg/adaptor/types/cvm"
@@ -33,11 +34,16 @@ import (
 	"hcm/pkg/adaptor/types/eip"
 	"hcm/pkg/adaptor/types/image"
 	"hcm/pkg/adaptor/types/instance-type"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
 	"hcm/pkg/adaptor/types/region"
 	"hcm/pkg/adaptor/types/route-table"
 	"hcm/pkg/adaptor/types/security-group"
 	"hcm/pkg/adaptor/types/security-group-rule"
+	typesnapshot "hcm/pkg/adaptor/types/snapshot"
 	"hcm/pkg/adaptor/types/subnet"
+	typetag "hcm/pkg/adaptor/types/tag"
 	"hcm/pkg/adaptor/types/zone"
 	"hcm/pkg/api/core/cloud"
 	"hcm/pkg/kit"
@@ -147,4 +153,30 @@ type TCloud interface {
 	DeleteArgsTplServiceGroup(kt *kit.Kit, opt *typeargstpl.TCloudDeleteOption) error
 	UpdateArgsTplServiceGroup(kt *kit.Kit, opt *typeargstpl.TCloudUpdateServiceGroupOption) (
 		*poller.BaseDoneResult, error)
+	ListLoadBalancer(kt *kit.Kit, opt *core.TCloudListOption) ([]typelb.TCloudLoadBalancer, error)
+	CreateLoadBalancer(kt *kit.Kit, opt *typelb.TCloudCreateOption) ([]string, error)
+	DeleteLoadBalancer(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error
+	ListListener(kt *kit.Kit, opt *typelb.ListenerListOption) ([]typelb.Listener, error)
+	CreateListener(kt *kit.Kit, opt *typelb.ListenerCreateOption) (string, error)
+	DeleteListener(kt *kit.Kit, opt *typelb.ListenerDeleteOption) error
+	ListTarget(kt *kit.Kit, opt *typelb.TargetListOption) ([]typelb.Target, error)
+	RegisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error
+	DeregisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error
+	TagResources(kt *kit.Kit, opt *typetag.TagResOption) error
+	UntagResources(kt *kit.Kit, opt *typetag.UntagResOption) error
+	ListSnapshot(kt *kit.Kit, opt *core.TCloudListOption) ([]typesnapshot.TCloudSnapshot, error)
+	CreateSnapshot(kt *kit.Kit, opt *typesnapshot.CreateOption) (string, error)
+	DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.DeleteOption) error
+	RollbackSnapshot(kt *kit.Kit, opt *typesnapshot.RollbackOption) error
+	ListKeyPair(kt *kit.Kit, opt *core.TCloudListOption) ([]typekeypair.TCloudKeyPair, error)
+	ImportKeyPair(kt *kit.Kit, opt *typekeypair.ImportOption) (string, error)
+	DeleteKeyPair(kt *kit.Kit, opt *typekeypair.DeleteOption) error
+	ListNatGateway(kt *kit.Kit, opt *core.TCloudListOption) ([]typenat.TCloudNatGateway, error)
+	CreateNatGateway(kt *kit.Kit, opt *typenat.TCloudCreateOption) (string, error)
+	DeleteNatGateway(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error
+	ListNatRule(kt *kit.Kit, opt *typenat.RuleListOption) ([]typenat.NatRule, error)
+	CreateNatRule(kt *kit.Kit, opt *typenat.RuleCreateOption) (string, error)
+	UpdateNatRule(kt *kit.Kit, opt *typenat.RuleUpdateOption) error
+	DeleteNatRule(kt *kit.Kit, opt *typenat.RuleDeleteOption) error
+	ListAuditTrailEvent(kt *kit.Kit, opt *typetrail.ListOption) (*typetrail.ListResult, error)
 }
@@ -0,0 +1,169 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types/core"
+	typekeypair "hcm/pkg/adaptor/types/key-pair"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/sshkey"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+// ListKeyPair list key pair, tcloud key pair is not bound to region, so region is only used to create client.
+// reference: https://cloud.tencent.com/document/api/213/15699
+func (t *TCloudImpl) ListKeyPair(kt *kit.Kit, opt *core.TCloudListOption) ([]typekeypair.TCloudKeyPair, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CvmClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud cvm client failed, err: %v", err)
+	}
+
+	req := cvm.NewDescribeKeyPairsRequest()
+	if len(opt.CloudIDs) != 0 {
+		req.KeyIds = common.StringPtrs(opt.CloudIDs)
+		req.Limit = common.Int64Ptr(int64(core.TCloudQueryLimit))
+	}
+	if opt.Page != nil {
+		req.Offset = common.Int64Ptr(int64(opt.Page.Offset))
+		req.Limit = common.Int64Ptr(int64(opt.Page.Limit))
+	}
+
+	resp, err := client.DescribeKeyPairsWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list tcloud key pair failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	results := make([]typekeypair.TCloudKeyPair, 0, len(resp.Response.KeyPairSet))
+	for _, one := range resp.Response.KeyPairSet {
+		results = append(results, convTCloudKeyPair(one))
+	}
+
+	return results, nil
+}
+
+func convTCloudKeyPair(one *cvm.KeyPair) typekeypair.TCloudKeyPair {
+	publicKey := converter.PtrToVal(one.PublicKey)
+	// 腾讯云不返回指纹，根据公钥计算
+	fingerprint, _ := sshkey.Fingerprint(publicKey)
+
+	return typekeypair.TCloudKeyPair{
+		BaseKeyPair: typekeypair.BaseKeyPair{
+			CloudID:          converter.PtrToVal(one.KeyId),
+			Name:             converter.PtrToVal(one.KeyName),
+			Fingerprint:      fingerprint,
+			PublicKey:        publicKey,
+			CloudCreatedTime: converter.PtrToVal(one.CreatedTime),
+		},
+		Extension: &corekeypair.TCloudKeyPairExtension{
+			ProjectID:        one.ProjectId,
+			CloudInstanceIDs: converter.PtrToSlice(one.AssociatedInstanceIds),
+		},
+	}
+}
+
+// ImportKeyPair import public key as key pair, return key pair cloud id.
+// reference: https://cloud.tencent.com/document/api/213/15698
+func (t *TCloudImpl) ImportKeyPair(kt *kit.Kit, opt *typekeypair.ImportOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "import option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.keyPairClient(opt.Region)
+	if err != nil {
+		return "", err
+	}
+
+	req := cvm.NewImportKeyPairRequest()
+	req.KeyName = common.StringPtr(opt.Name)
+	req.PublicKey = common.StringPtr(opt.PublicKey)
+	// 导入到默认项目
+	req.ProjectId = common.Int64Ptr(0)
+
+	resp, err := client.ImportKeyPairWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("import tcloud key pair failed, name: %s, err: %v, rid: %s", opt.Name, err, kt.Rid)
+		return "", err
+	}
+
+	return converter.PtrToVal(resp.Response.KeyId), nil
+}
+
+// DeleteKeyPair delete key pair, key pair bound to cvm can not be deleted.
+// reference: https://cloud.tencent.com/document/api/213/15700
+func (t *TCloudImpl) DeleteKeyPair(kt *kit.Kit, opt *typekeypair.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.keyPairClient(opt.Region)
+	if err != nil {
+		return err
+	}
+
+	req := cvm.NewDeleteKeyPairsRequest()
+	req.KeyIds = common.StringPtrs([]string{opt.CloudID})
+
+	if _, err = client.DeleteKeyPairsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete tcloud key pair failed, cloud id: %s, err: %v, rid: %s", opt.CloudID, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// keyPairClient 密钥对与地域无关，未指定地域时使用默认地域
+func (t *TCloudImpl) keyPairClient(region string) (*cvm.Client, error) {
+	if len(region) == 0 {
+		region = constant.TCloudDefaultRegion
+	}
+
+	client, err := t.clientSet.CvmClient(region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud cvm client failed, err: %v", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,581 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"hcm/pkg/adaptor/types/core"
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+)
+
+const (
+	clbService = "clb"
+	clbVersion = "2018-03-17"
+
+	// clbDescribeIDsLimit 查询负载均衡时，单次最多指定的负载均衡ID数量
+	clbDescribeIDsLimit = 20
+)
+
+// ListLoadBalancer list load balancer.
+// reference: https://cloud.tencent.com/document/api/214/30685
+func (t *TCloudImpl) ListLoadBalancer(kt *kit.Kit, opt *core.TCloudListOption) ([]typelb.TCloudLoadBalancer, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 按ID查询时，每次最多查询 clbDescribeIDsLimit 个负载均衡，忽略分页参数
+	if len(opt.CloudIDs) != 0 {
+		results := make([]typelb.TCloudLoadBalancer, 0, len(opt.CloudIDs))
+		for _, ids := range slice.Split(opt.CloudIDs, clbDescribeIDsLimit) {
+			params := map[string]interface{}{
+				"Offset":          0,
+				"Limit":           clbDescribeIDsLimit,
+				"LoadBalancerIds": ids,
+			}
+			lbs, err := t.describeLoadBalancers(kt, opt.Region, params)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, lbs...)
+		}
+
+		return results, nil
+	}
+
+	params := map[string]interface{}{
+		"Offset": opt.Page.Offset,
+		"Limit":  opt.Page.Limit,
+	}
+	return t.describeLoadBalancers(kt, opt.Region, params)
+}
+
+func (t *TCloudImpl) describeLoadBalancers(kt *kit.Kit, region string, params map[string]interface{}) (
+	[]typelb.TCloudLoadBalancer, error) {
+
+	resp := new(clbDescribeLoadBalancersResp)
+	if err := t.clbRequest(kt, region, "DescribeLoadBalancers", params, resp); err != nil {
+		logs.Errorf("list tcloud load balancer failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	results := make([]typelb.TCloudLoadBalancer, 0, len(resp.LoadBalancerSet))
+	for _, one := range resp.LoadBalancerSet {
+		results = append(results, one.toLoadBalancer(region))
+	}
+
+	return results, nil
+}
+
+// CreateLoadBalancer create load balancer, return created load balancer cloud ids.
+// reference: https://cloud.tencent.com/document/api/214/30692
+func (t *TCloudImpl) CreateLoadBalancer(kt *kit.Kit, opt *typelb.TCloudCreateOption) ([]string, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	params := map[string]interface{}{
+		"LoadBalancerType": opt.LBType,
+		"LoadBalancerName": opt.Name,
+		"Forward":          1,
+	}
+	if len(opt.AddressIPVersion) != 0 {
+		params["AddressIPVersion"] = opt.AddressIPVersion
+	}
+	if len(opt.CloudVpcID) != 0 {
+		params["VpcId"] = opt.CloudVpcID
+	}
+	if len(opt.CloudSubnetID) != 0 {
+		params["SubnetId"] = opt.CloudSubnetID
+	}
+	if len(opt.MasterZone) != 0 {
+		params["MasterZoneId"] = opt.MasterZone
+	}
+	if len(opt.VipIsp) != 0 {
+		params["VipIsp"] = opt.VipIsp
+	}
+	if opt.CloudProjectID != nil {
+		params["ProjectId"] = *opt.CloudProjectID
+	}
+	if opt.Number != nil {
+		params["Number"] = *opt.Number
+	}
+	if len(opt.InternetChargeType) != 0 || opt.InternetMaxBandwidthOut != nil {
+		internet := make(map[string]interface{})
+		if len(opt.InternetChargeType) != 0 {
+			internet["InternetChargeType"] = opt.InternetChargeType
+		}
+		if opt.InternetMaxBandwidthOut != nil {
+			internet["InternetMaxBandwidthOut"] = *opt.InternetMaxBandwidthOut
+		}
+		params["InternetAccessible"] = internet
+	}
+
+	resp := new(clbCreateLoadBalancerResp)
+	if err := t.clbRequest(kt, opt.Region, "CreateLoadBalancer", params, resp); err != nil {
+		logs.Errorf("create tcloud load balancer failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return nil, err
+	}
+
+	return resp.LoadBalancerIds, nil
+}
+
+// DeleteLoadBalancer delete load balancer.
+// reference: https://cloud.tencent.com/document/api/214/30689
+func (t *TCloudImpl) DeleteLoadBalancer(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	params := map[string]interface{}{"LoadBalancerIds": opt.ResourceID}
+	if err := t.clbRequest(kt, opt.Region, "DeleteLoadBalancer", params, nil); err != nil {
+		logs.Errorf("delete tcloud load balancer failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ListListener list load balancer listener.
+// reference: https://cloud.tencent.com/document/api/214/30686
+func (t *TCloudImpl) ListListener(kt *kit.Kit, opt *typelb.ListenerListOption) ([]typelb.Listener, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	params := map[string]interface{}{"LoadBalancerId": opt.CloudLbID}
+	if len(opt.CloudIDs) != 0 {
+		params["ListenerIds"] = opt.CloudIDs
+	}
+
+	resp := new(clbDescribeListenersResp)
+	if err := t.clbRequest(kt, opt.Region, "DescribeListeners", params, resp); err != nil {
+		logs.Errorf("list tcloud listener failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return nil, err
+	}
+
+	results := make([]typelb.Listener, 0, len(resp.Listeners))
+	for _, one := range resp.Listeners {
+		listener := typelb.Listener{
+			CloudID:   one.ListenerId,
+			Name:      one.ListenerName,
+			CloudLbID: opt.CloudLbID,
+			Protocol:  one.Protocol,
+			Port:      one.Port,
+			Extension: &corelb.ListenerExtension{
+				Scheduler:         one.Scheduler,
+				SessionExpireTime: one.SessionExpireTime,
+				EndPort:           one.EndPort,
+			},
+		}
+		if one.Certificate != nil {
+			certIDs := make([]string, 0)
+			if len(one.Certificate.CertId) != 0 {
+				certIDs = append(certIDs, one.Certificate.CertId)
+			}
+			listener.Extension.CloudCertificateIDs = certIDs
+		}
+
+		results = append(results, listener)
+	}
+
+	return results, nil
+}
+
+// CreateListener create load balancer listener, return created listener cloud id.
+// reference: https://cloud.tencent.com/document/api/214/30693
+func (t *TCloudImpl) CreateListener(kt *kit.Kit, opt *typelb.ListenerCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	params := map[string]interface{}{
+		"LoadBalancerId": opt.CloudLbID,
+		"Ports":          []int64{opt.Port},
+		"Protocol":       opt.Protocol,
+		"ListenerNames":  []string{opt.Name},
+	}
+	if opt.Extension != nil {
+		if opt.Extension.Scheduler != nil {
+			params["Scheduler"] = *opt.Extension.Scheduler
+		}
+		if opt.Extension.SessionExpireTime != nil {
+			params["SessionExpireTime"] = *opt.Extension.SessionExpireTime
+		}
+		if opt.Extension.EndPort != nil {
+			params["EndPort"] = *opt.Extension.EndPort
+		}
+		if len(opt.Extension.CloudCertificateIDs) != 0 {
+			params["Certificate"] = map[string]interface{}{
+				"SSLMode": "UNIDIRECTIONAL",
+				"CertId":  opt.Extension.CloudCertificateIDs[0],
+			}
+		}
+	}
+
+	resp := new(clbCreateListenerResp)
+	if err := t.clbRequest(kt, opt.Region, "CreateListener", params, resp); err != nil {
+		logs.Errorf("create tcloud listener failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	if len(resp.ListenerIds) == 0 {
+		return "", fmt.Errorf("create tcloud listener but return listener id is empty")
+	}
+
+	return resp.ListenerIds[0], nil
+}
+
+// DeleteListener delete load balancer listener.
+// reference: https://cloud.tencent.com/document/api/214/48519
+func (t *TCloudImpl) DeleteListener(kt *kit.Kit, opt *typelb.ListenerDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete listener option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	params := map[string]interface{}{
+		"LoadBalancerId": opt.CloudLbID,
+		"ListenerIds":    opt.CloudIDs,
+	}
+	if err := t.clbRequest(kt, opt.Region, "DeleteLoadBalancerListeners", params, nil); err != nil {
+		logs.Errorf("delete tcloud listener failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ListTarget list load balancer backend target, include targets of layer-4 listener and layer-7 rule.
+// reference: https://cloud.tencent.com/document/api/214/30684
+func (t *TCloudImpl) ListTarget(kt *kit.Kit, opt *typelb.TargetListOption) ([]typelb.Target, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list target option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	params := map[string]interface{}{"LoadBalancerId": opt.CloudLbID}
+	resp := new(clbDescribeTargetsResp)
+	if err := t.clbRequest(kt, opt.Region, "DescribeTargets", params, resp); err != nil {
+		logs.Errorf("list tcloud target failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return nil, err
+	}
+
+	results := make([]typelb.Target, 0)
+	for _, listener := range resp.Listeners {
+		for _, one := range listener.Targets {
+			results = append(results, one.toTarget(opt.CloudLbID, listener.ListenerId, ""))
+		}
+
+		for _, rule := range listener.Rules {
+			for _, one := range rule.Targets {
+				results = append(results, one.toTarget(opt.CloudLbID, listener.ListenerId, rule.LocationId))
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// RegisterTargets register backend target to load balancer listener.
+// reference: https://cloud.tencent.com/document/api/214/30676
+func (t *TCloudImpl) RegisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error {
+	return t.operateTargets(kt, "RegisterTargets", opt)
+}
+
+// DeregisterTargets deregister backend target from load balancer listener.
+// reference: https://cloud.tencent.com/document/api/214/30687
+func (t *TCloudImpl) DeregisterTargets(kt *kit.Kit, opt *typelb.TargetOperateOption) error {
+	return t.operateTargets(kt, "DeregisterTargets", opt)
+}
+
+func (t *TCloudImpl) operateTargets(kt *kit.Kit, action string, opt *typelb.TargetOperateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "operate target option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.CloudListenerID) == 0 {
+		return errf.New(errf.InvalidParameter, "cloud_listener_id is required")
+	}
+
+	targets := make([]map[string]interface{}, 0, len(opt.Targets))
+	for _, one := range opt.Targets {
+		target := map[string]interface{}{"Port": one.Port}
+		if len(one.CloudInstID) != 0 {
+			target["InstanceId"] = one.CloudInstID
+		} else {
+			target["EniIp"] = one.IP
+		}
+		if one.Weight != nil {
+			target["Weight"] = *one.Weight
+		}
+		targets = append(targets, target)
+	}
+
+	params := map[string]interface{}{
+		"LoadBalancerId": opt.CloudLbID,
+		"ListenerId":     opt.CloudListenerID,
+		"Targets":        targets,
+	}
+	// 七层监听器的后端目标绑定在转发规则上，目标组ID即为转发规则ID
+	if len(opt.CloudTargetGroupID) != 0 {
+		params["LocationId"] = opt.CloudTargetGroupID
+	}
+
+	if err := t.clbRequest(kt, opt.Region, action, params, nil); err != nil {
+		logs.Errorf("%s tcloud target failed, opt: %+v, err: %v, rid: %s", action, opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// clbRequest send clb api request by common client, and decode response into result if result is not nil.
+func (t *TCloudImpl) clbRequest(kt *kit.Kit, region, action string, params map[string]interface{},
+	result interface{}) error {
+
+	client, err := t.clientSet.ClbClient(region)
+	if err != nil {
+		return fmt.Errorf("new tcloud clb client failed, err: %v", err)
+	}
+
+	return commonRequest(kt, client, clbService, clbVersion, action, params, result)
+}
+
+// commonRequest call tcloud api which sdk is not imported by common client, and unmarshal response to result.
+func commonRequest(kt *kit.Kit, client *common.Client, service, version, action string,
+	params map[string]interface{}, result interface{}) error {
+
+	req := tchttp.NewCommonRequest(service, version, action)
+	req.SetContext(kt.Ctx)
+	if err := req.SetActionParameters(params); err != nil {
+		return err
+	}
+
+	resp := tchttp.NewCommonResponse()
+	if err := client.Send(req, resp); err != nil {
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	body := new(commonResponse)
+	if err := json.Unmarshal(resp.GetBody(), body); err != nil {
+		return fmt.Errorf("unmarshal %s response failed, err: %v", action, err)
+	}
+
+	return json.Unmarshal(body.Response, result)
+}
+
+// commonResponse tcloud common api response wrapper.
+type commonResponse struct {
+	Response json.RawMessage `json:"Response"`
+}
+
+type clbDescribeLoadBalancersResp struct {
+	TotalCount      uint64            `json:"TotalCount"`
+	LoadBalancerSet []clbLoadBalancer `json:"LoadBalancerSet"`
+}
+
+type clbLoadBalancer struct {
+	LoadBalancerId   string   `json:"LoadBalancerId"`
+	LoadBalancerName string   `json:"LoadBalancerName"`
+	LoadBalancerType string   `json:"LoadBalancerType"`
+	Forward          *int64   `json:"Forward"`
+	Domain           string   `json:"Domain"`
+	LoadBalancerVips []string `json:"LoadBalancerVips"`
+	Status           *uint64  `json:"Status"`
+	CreateTime       string   `json:"CreateTime"`
+	ProjectId        *int64   `json:"ProjectId"`
+	VpcId            string   `json:"VpcId"`
+	SubnetId         string   `json:"SubnetId"`
+	Isolation        *int64   `json:"Isolation"`
+	AddressIPVersion string   `json:"AddressIPVersion"`
+	SecureGroups     []string `json:"SecureGroups"`
+	ChargeType       *string  `json:"ChargeType"`
+	VipIsp           *string  `json:"VipIsp"`
+	MasterZone       *struct {
+		Zone string `json:"Zone"`
+	} `json:"MasterZone"`
+	BackupZoneSet []struct {
+		Zone string `json:"Zone"`
+	} `json:"BackupZoneSet"`
+	NetworkAttributes *struct {
+		InternetChargeType      *string `json:"InternetChargeType"`
+		InternetMaxBandwidthOut *int64  `json:"InternetMaxBandwidthOut"`
+	} `json:"NetworkAttributes"`
+}
+
+func (lb clbLoadBalancer) toLoadBalancer(region string) typelb.TCloudLoadBalancer {
+	zones := make([]string, 0)
+	if lb.MasterZone != nil {
+		zones = append(zones, lb.MasterZone.Zone)
+	}
+	for _, one := range lb.BackupZoneSet {
+		zones = append(zones, one.Zone)
+	}
+
+	result := typelb.TCloudLoadBalancer{
+		BaseLoadBalancer: typelb.BaseLoadBalancer{
+			CloudID:              lb.LoadBalancerId,
+			Name:                 lb.LoadBalancerName,
+			Region:               region,
+			Zones:                zones,
+			LBType:               lb.LoadBalancerType,
+			IPVersion:            lb.AddressIPVersion,
+			CloudVpcID:           lb.VpcId,
+			CloudSubnetID:        lb.SubnetId,
+			PrivateIPv4Addresses: make([]string, 0),
+			PublicIPv4Addresses:  make([]string, 0),
+			Domain:               lb.Domain,
+			CloudCreatedTime:     lb.CreateTime,
+		},
+		Extension: &corelb.TCloudLoadBalancerExtension{
+			Forward:               lb.Forward,
+			CloudProjectID:        lb.ProjectId,
+			ChargeType:            lb.ChargeType,
+			VipIsp:                lb.VipIsp,
+			CloudSecurityGroupIDs: lb.SecureGroups,
+			Isolation:             lb.Isolation,
+		},
+	}
+
+	// 公网负载均衡的VIP为公网IP，内网负载均衡的VIP为内网IP
+	if lb.LoadBalancerType == "OPEN" {
+		result.PublicIPv4Addresses = lb.LoadBalancerVips
+	} else {
+		result.PrivateIPv4Addresses = lb.LoadBalancerVips
+	}
+
+	// 负载均衡状态，0：创建中，1：正常运行
+	if lb.Status != nil {
+		result.Status = strconv.FormatUint(*lb.Status, 10)
+	}
+
+	if lb.NetworkAttributes != nil {
+		result.Extension.InternetChargeType = lb.NetworkAttributes.InternetChargeType
+		result.Extension.InternetMaxBandwidthOut = lb.NetworkAttributes.InternetMaxBandwidthOut
+	}
+
+	return result
+}
+
+type clbCreateLoadBalancerResp struct {
+	LoadBalancerIds []string `json:"LoadBalancerIds"`
+}
+
+type clbDescribeListenersResp struct {
+	Listeners []struct {
+		ListenerId        string  `json:"ListenerId"`
+		ListenerName      string  `json:"ListenerName"`
+		Protocol          string  `json:"Protocol"`
+		Port              int64   `json:"Port"`
+		Scheduler         *string `json:"Scheduler"`
+		SessionExpireTime *int64  `json:"SessionExpireTime"`
+		EndPort           *int64  `json:"EndPort"`
+		Certificate       *struct {
+			CertId string `json:"CertId"`
+		} `json:"Certificate"`
+	} `json:"Listeners"`
+}
+
+type clbCreateListenerResp struct {
+	ListenerIds []string `json:"ListenerIds"`
+}
+
+type clbDescribeTargetsResp struct {
+	Listeners []struct {
+		ListenerId string      `json:"ListenerId"`
+		Targets    []clbTarget `json:"Targets"`
+		Rules      []struct {
+			LocationId string      `json:"LocationId"`
+			Targets    []clbTarget `json:"Targets"`
+		} `json:"Rules"`
+	} `json:"Listeners"`
+}
+
+type clbTarget struct {
+	Type               string   `json:"Type"`
+	InstanceId         string   `json:"InstanceId"`
+	Port               int64    `json:"Port"`
+	Weight             *int64   `json:"Weight"`
+	PrivateIpAddresses []string `json:"PrivateIpAddresses"`
+}
+
+func (target clbTarget) toTarget(cloudLbID, cloudListenerID, cloudRuleID string) typelb.Target {
+	result := typelb.Target{
+		CloudLbID:          cloudLbID,
+		CloudListenerID:    cloudListenerID,
+		CloudTargetGroupID: cloudRuleID,
+		InstType:           target.Type,
+		CloudInstID:        target.InstanceId,
+		Port:               target.Port,
+		Weight:             converter.PtrToVal(target.Weight),
+	}
+
+	if len(target.PrivateIpAddresses) != 0 {
+		result.IP = target.PrivateIpAddresses[0]
+	}
+
+	return result
+}
@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package tcloud
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"hcm/pkg/tools/converter"
+)
+
+func TestClbLoadBalancerToLoadBalancer(t *testing.T) {
+	resp := `{"LoadBalancerId": "lb-1", "LoadBalancerName": "lb", "LoadBalancerType": "OPEN",
+		"LoadBalancerVips": ["1.1.1.1"], "Status": 1, "VpcId": "vpc-1", "MasterZone": {"Zone": "ap-guangzhou-3"},
+		"BackupZoneSet": [{"Zone": "ap-guangzhou-4"}]}`
+	lb := clbLoadBalancer{}
+	if err := json.Unmarshal([]byte(resp), &lb); err != nil {
+		t.Fatalf("unmarshal tcloud load balancer failed, err: %v", err)
+	}
+
+	result := lb.toLoadBalancer("ap-guangzhou")
+	if result.CloudID != "lb-1" || result.Region != "ap-guangzhou" || result.CloudVpcID != "vpc-1" {
+		t.Fatalf("tcloud load balancer base info %+v is unexpected", result.BaseLoadBalancer)
+	}
+
+	if !reflect.DeepEqual(result.Zones, []string{"ap-guangzhou-3", "ap-guangzhou-4"}) {
+		t.Errorf("tcloud load balancer zones %v is unexpected", result.Zones)
+	}
+
+	if result.Status != "1" {
+		t.Errorf("tcloud load balancer status %s is unexpected", result.Status)
+	}
+
+	if !reflect.DeepEqual(result.PublicIPv4Addresses, []string{"1.1.1.1"}) || len(result.PrivateIPv4Addresses) != 0 {
+		t.Errorf("tcloud public load balancer vips should be public ips, got public: %v, private: %v",
+			result.PublicIPv4Addresses, result.PrivateIPv4Addresses)
+	}
+
+	lb.LoadBalancerType = "INTERNAL"
+	lb.LoadBalancerVips = []string{"10.0.0.1"}
+	lb.MasterZone = nil
+	lb.Status = nil
+	result = lb.toLoadBalancer("ap-guangzhou")
+	if !reflect.DeepEqual(result.PrivateIPv4Addresses, []string{"10.0.0.1"}) || len(result.PublicIPv4Addresses) != 0 {
+		t.Errorf("tcloud internal load balancer vips should be private ips, got public: %v, private: %v",
+			result.PublicIPv4Addresses, result.PrivateIPv4Addresses)
+	}
+
+	if !reflect.DeepEqual(result.Zones, []string{"ap-guangzhou-4"}) || len(result.Status) != 0 {
+		t.Errorf("tcloud load balancer without master zone and status got zones: %v, status: %s",
+			result.Zones, result.Status)
+	}
+}
+
+func TestClbTargetToTarget(t *testing.T) {
+	target := clbTarget{
+		Type:               "CVM",
+		InstanceId:         "ins-1",
+		Port:               80,
+		Weight:             converter.ValToPtr(int64(10)),
+		PrivateIpAddresses: []string{"10.0.0.1", "10.0.0.2"},
+	}
+
+	result := target.toTarget("lb-1", "lbl-1", "loc-1")
+	if result.CloudLbID != "lb-1" || result.CloudListenerID != "lbl-1" || result.CloudTargetGroupID != "loc-1" {
+		t.Fatalf("tcloud target owner %+v is unexpected", result)
+	}
+
+	if result.IP != "10.0.0.1" || result.Weight != 10 || result.GetCloudID() != "loc-1/ins-1:80" {
+		t.Errorf("tcloud target %+v is unexpected", result)
+	}
+
+	target.Weight = nil
+	target.PrivateIpAddresses = nil
+	result = target.toTarget("lb-1", "lbl-1", "")
+	if len(result.IP) != 0 || result.Weight != 0 || result.GetCloudID() != "lbl-1/ins-1:80" {
+		t.Errorf("tcloud target without ip and weight %+v is unexpected", result)
+	}
+}
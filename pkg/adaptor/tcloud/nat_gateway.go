@@ -0,0 +1,539 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+	"strconv"
+
+	"hcm/pkg/adaptor/types/core"
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+)
+
+const (
+	// natRuleQueryLimit 查询NAT网关规则时的分页大小
+	natRuleQueryLimit = 100
+	// tcloudSnatSubnetResType 腾讯云SNAT规则以子网为源
+	tcloudSnatSubnetResType = "SUBNET"
+)
+
+// ListNatGateway list nat gateway.
+// reference: https://cloud.tencent.com/document/api/215/36034
+func (t *TCloudImpl) ListNatGateway(kt *kit.Kit, opt *core.TCloudListOption) ([]typenat.TCloudNatGateway, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.VpcClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud vpc client failed, err: %v", err)
+	}
+
+	req := vpc.NewDescribeNatGatewaysRequest()
+	if len(opt.CloudIDs) != 0 {
+		req.NatGatewayIds = common.StringPtrs(opt.CloudIDs)
+		req.Limit = common.Uint64Ptr(core.TCloudQueryLimit)
+	}
+	if opt.Page != nil {
+		req.Offset = common.Uint64Ptr(opt.Page.Offset)
+		req.Limit = common.Uint64Ptr(opt.Page.Limit)
+	}
+
+	resp, err := client.DescribeNatGatewaysWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list tcloud nat gateway failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	results := make([]typenat.TCloudNatGateway, 0, len(resp.Response.NatGatewaySet))
+	for _, one := range resp.Response.NatGatewaySet {
+		results = append(results, convTCloudNatGateway(one, opt.Region))
+	}
+
+	return results, nil
+}
+
+func convTCloudNatGateway(one *vpc.NatGateway, region string) typenat.TCloudNatGateway {
+	publicIPs := make([]string, 0, len(one.PublicIpAddressSet))
+	eipIDs := make([]string, 0, len(one.PublicIpAddressSet))
+	for _, addr := range one.PublicIpAddressSet {
+		publicIPs = append(publicIPs, converter.PtrToVal(addr.PublicIpAddress))
+		eipIDs = append(eipIDs, converter.PtrToVal(addr.AddressId))
+	}
+
+	result := typenat.TCloudNatGateway{
+		BaseNatGateway: typenat.BaseNatGateway{
+			CloudID:           converter.PtrToVal(one.NatGatewayId),
+			Name:              converter.PtrToVal(one.NatGatewayName),
+			Region:            region,
+			Zone:              converter.PtrToVal(one.Zone),
+			CloudVpcID:        converter.PtrToVal(one.VpcId),
+			CloudSubnetID:     converter.PtrToVal(one.SubnetId),
+			PublicIPAddresses: publicIPs,
+			Status:            converter.PtrToVal(one.State),
+			CloudCreatedTime:  converter.PtrToVal(one.CreatedTime),
+		},
+		Extension: &corenat.TCloudNatGatewayExtension{
+			InternetMaxBandwidthOut: one.InternetMaxBandwidthOut,
+			CloudEipIDs:             eipIDs,
+			CloudSecurityGroupIDs:   converter.PtrToSlice(one.SecurityGroupSet),
+			IsExclusive:             one.IsExclusive,
+			RestrictState:           one.RestrictState,
+		},
+	}
+
+	if one.MaxConcurrentConnection != nil {
+		result.Spec = strconv.FormatUint(*one.MaxConcurrentConnection, 10)
+	}
+
+	return result
+}
+
+// CreateNatGateway create nat gateway, return created nat gateway cloud id.
+// reference: https://cloud.tencent.com/document/api/215/36722
+func (t *TCloudImpl) CreateNatGateway(kt *kit.Kit, opt *typenat.TCloudCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.VpcClient(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new tcloud vpc client failed, err: %v", err)
+	}
+
+	req := vpc.NewCreateNatGatewayRequest()
+	req.NatGatewayName = common.StringPtr(opt.Name)
+	req.VpcId = common.StringPtr(opt.CloudVpcID)
+	req.MaxConcurrentConnection = opt.MaxConcurrentConnection
+	req.InternetMaxBandwidthOut = opt.InternetMaxBandwidthOut
+	req.AddressCount = opt.AddressCount
+	if len(opt.Zone) != 0 {
+		req.Zone = common.StringPtr(opt.Zone)
+	}
+	if len(opt.PublicIPAddresses) != 0 {
+		req.PublicIpAddresses = common.StringPtrs(opt.PublicIPAddresses)
+	}
+
+	resp, err := client.CreateNatGatewayWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create tcloud nat gateway failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	if len(resp.Response.NatGatewaySet) == 0 {
+		return "", fmt.Errorf("create tcloud nat gateway but return nat gateway is empty")
+	}
+
+	return converter.PtrToVal(resp.Response.NatGatewaySet[0].NatGatewayId), nil
+}
+
+// DeleteNatGateway delete nat gateway, eip created with the nat gateway will be released.
+// reference: https://cloud.tencent.com/document/api/215/36717
+func (t *TCloudImpl) DeleteNatGateway(kt *kit.Kit, opt *core.BaseRegionalDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.VpcClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new tcloud vpc client failed, err: %v", err)
+	}
+
+	req := vpc.NewDeleteNatGatewayRequest()
+	req.NatGatewayId = common.StringPtr(opt.ResourceID)
+	if _, err = client.DeleteNatGatewayWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete tcloud nat gateway failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ListNatRule list all snat and dnat rules of nat gateway.
+// reference: https://cloud.tencent.com/document/api/215/59614
+// reference: https://cloud.tencent.com/document/api/215/36052
+func (t *TCloudImpl) ListNatRule(kt *kit.Kit, opt *typenat.RuleListOption) ([]typenat.NatRule, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.VpcClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud vpc client failed, err: %v", err)
+	}
+
+	snatRules, err := t.listSnatRule(kt, client, opt.CloudNatGatewayID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	dnatRules, err := t.listDnatRule(kt, client, opt.CloudNatGatewayID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]typenat.NatRule, 0, len(snatRules)+len(dnatRules))
+	for _, one := range snatRules {
+		results = append(results, convTCloudSnatRule(one, opt.CloudNatGatewayID))
+	}
+	for _, one := range dnatRules {
+		results = append(results, convTCloudDnatRule(one, opt.CloudNatGatewayID))
+	}
+
+	return results, nil
+}
+
+func (t *TCloudImpl) listSnatRule(kt *kit.Kit, client *vpc.Client, cloudNatID string, filters []*vpc.Filter) (
+	[]*vpc.SourceIpTranslationNatRule, error) {
+
+	results := make([]*vpc.SourceIpTranslationNatRule, 0)
+	req := vpc.NewDescribeNatGatewaySourceIpTranslationNatRulesRequest()
+	req.NatGatewayId = common.StringPtr(cloudNatID)
+	req.Filters = filters
+	req.Limit = common.Int64Ptr(natRuleQueryLimit)
+	for offset := int64(0); ; offset += natRuleQueryLimit {
+		req.Offset = common.Int64Ptr(offset)
+		resp, err := client.DescribeNatGatewaySourceIpTranslationNatRulesWithContext(kt.Ctx, req)
+		if err != nil {
+			logs.Errorf("list tcloud snat rule failed, nat: %s, err: %v, rid: %s", cloudNatID, err, kt.Rid)
+			return nil, err
+		}
+
+		results = append(results, resp.Response.SourceIpTranslationNatRuleSet...)
+		if len(resp.Response.SourceIpTranslationNatRuleSet) < natRuleQueryLimit {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+func (t *TCloudImpl) listDnatRule(kt *kit.Kit, client *vpc.Client, cloudNatID string) (
+	[]*vpc.NatGatewayDestinationIpPortTranslationNatRule, error) {
+
+	results := make([]*vpc.NatGatewayDestinationIpPortTranslationNatRule, 0)
+	req := vpc.NewDescribeNatGatewayDestinationIpPortTranslationNatRulesRequest()
+	req.NatGatewayIds = common.StringPtrs([]string{cloudNatID})
+	req.Limit = common.Uint64Ptr(natRuleQueryLimit)
+	for offset := uint64(0); ; offset += natRuleQueryLimit {
+		req.Offset = common.Uint64Ptr(offset)
+		resp, err := client.DescribeNatGatewayDestinationIpPortTranslationNatRulesWithContext(kt.Ctx, req)
+		if err != nil {
+			logs.Errorf("list tcloud dnat rule failed, nat: %s, err: %v, rid: %s", cloudNatID, err, kt.Rid)
+			return nil, err
+		}
+
+		results = append(results, resp.Response.NatGatewayDestinationIpPortTranslationNatRuleSet...)
+		if len(resp.Response.NatGatewayDestinationIpPortTranslationNatRuleSet) < natRuleQueryLimit {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+func convTCloudSnatRule(one *vpc.SourceIpTranslationNatRule, cloudNatID string) typenat.NatRule {
+	publicIPs := converter.PtrToSlice(one.PublicIpAddresses)
+	rule := typenat.NatRule{
+		CloudID:           converter.PtrToVal(one.NatGatewaySnatId),
+		CloudNatGatewayID: cloudNatID,
+		RuleType:          enumor.SnatRuleType,
+		PrivateIP:         converter.PtrToVal(one.PrivateIpAddress),
+		Memo:              one.Description,
+		Extension: &corenat.NatGatewayRuleExtension{
+			ResourceType:     one.ResourceType,
+			PublicIPs:        publicIPs,
+			CloudCreatedTime: one.CreatedTime,
+		},
+	}
+
+	if converter.PtrToVal(one.ResourceType) == tcloudSnatSubnetResType {
+		rule.CloudSubnetID = converter.PtrToVal(one.ResourceId)
+	}
+	if len(publicIPs) != 0 {
+		rule.PublicIP = publicIPs[0]
+	}
+
+	return rule
+}
+
+func convTCloudDnatRule(one *vpc.NatGatewayDestinationIpPortTranslationNatRule, cloudNatID string) typenat.NatRule {
+	protocol := converter.PtrToVal(one.IpProtocol)
+	publicIP := converter.PtrToVal(one.PublicIpAddress)
+	publicPort := int64(converter.PtrToVal(one.PublicPort))
+
+	return typenat.NatRule{
+		CloudID:           typenat.TCloudDnatRuleCloudID(cloudNatID, protocol, publicIP, publicPort),
+		CloudNatGatewayID: cloudNatID,
+		RuleType:          enumor.DnatRuleType,
+		Protocol:          protocol,
+		PublicIP:          publicIP,
+		PublicPort:        publicPort,
+		PrivateIP:         converter.PtrToVal(one.PrivateIpAddress),
+		PrivatePort:       int64(converter.PtrToVal(one.PrivatePort)),
+		Memo:              one.Description,
+		Extension:         &corenat.NatGatewayRuleExtension{CloudCreatedTime: one.CreatedTime},
+	}
+}
+
+// CreateNatRule create nat gateway snat/dnat rule, return created rule cloud id.
+// tcloud snat rule create api do not return rule id, so query it by subnet after created.
+// reference: https://cloud.tencent.com/document/api/215/59619
+// reference: https://cloud.tencent.com/document/api/215/36721
+func (t *TCloudImpl) CreateNatRule(kt *kit.Kit, opt *typenat.RuleCreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(opt.PublicIP) == 0 {
+		return "", errf.New(errf.InvalidParameter, "public_ip is required")
+	}
+
+	client, err := t.clientSet.VpcClient(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new tcloud vpc client failed, err: %v", err)
+	}
+
+	if opt.RuleType == enumor.DnatRuleType {
+		req := vpc.NewCreateNatGatewayDestinationIpPortTranslationNatRuleRequest()
+		req.NatGatewayId = common.StringPtr(opt.CloudNatGatewayID)
+		req.DestinationIpPortTranslationNatRules = []*vpc.DestinationIpPortTranslationNatRule{{
+			IpProtocol:       common.StringPtr(opt.Protocol),
+			PublicIpAddress:  common.StringPtr(opt.PublicIP),
+			PublicPort:       common.Uint64Ptr(uint64(opt.PublicPort)),
+			PrivateIpAddress: common.StringPtr(opt.PrivateIP),
+			PrivatePort:      common.Uint64Ptr(uint64(opt.PrivatePort)),
+			Description:      opt.Memo,
+		}}
+		if _, err = client.CreateNatGatewayDestinationIpPortTranslationNatRuleWithContext(kt.Ctx, req); err != nil {
+			logs.Errorf("create tcloud dnat rule failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+			return "", err
+		}
+
+		return typenat.TCloudDnatRuleCloudID(opt.CloudNatGatewayID, opt.Protocol, opt.PublicIP, opt.PublicPort), nil
+	}
+
+	if len(opt.CloudSubnetID) == 0 {
+		return "", errf.New(errf.InvalidParameter, "tcloud snat rule only support subnet as source")
+	}
+
+	req := vpc.NewCreateNatGatewaySourceIpTranslationNatRuleRequest()
+	req.NatGatewayId = common.StringPtr(opt.CloudNatGatewayID)
+	req.SourceIpTranslationNatRules = []*vpc.SourceIpTranslationNatRule{{
+		ResourceId:        common.StringPtr(opt.CloudSubnetID),
+		ResourceType:      common.StringPtr(tcloudSnatSubnetResType),
+		PublicIpAddresses: common.StringPtrs([]string{opt.PublicIP}),
+		Description:       opt.Memo,
+	}}
+	if _, err = client.CreateNatGatewaySourceIpTranslationNatRuleWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("create tcloud snat rule failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	filters := []*vpc.Filter{
+		{Name: common.StringPtr("resource-id"), Values: common.StringPtrs([]string{opt.CloudSubnetID})},
+	}
+	rules, err := t.listSnatRule(kt, client, opt.CloudNatGatewayID, filters)
+	if err != nil {
+		return "", err
+	}
+
+	if len(rules) == 0 {
+		return "", fmt.Errorf("tcloud snat rule of subnet %s is created but not found", opt.CloudSubnetID)
+	}
+
+	return converter.PtrToVal(rules[0].NatGatewaySnatId), nil
+}
+
+// UpdateNatRule update nat gateway snat/dnat rule.
+// reference: https://cloud.tencent.com/document/api/215/59617
+// reference: https://cloud.tencent.com/document/api/215/36719
+func (t *TCloudImpl) UpdateNatRule(kt *kit.Kit, opt *typenat.RuleUpdateOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "update option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.VpcClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new tcloud vpc client failed, err: %v", err)
+	}
+
+	if opt.RuleType == enumor.SnatRuleType {
+		req := vpc.NewModifyNatGatewaySourceIpTranslationNatRuleRequest()
+		req.NatGatewayId = common.StringPtr(opt.CloudNatGatewayID)
+		req.SourceIpTranslationNatRule = &vpc.SourceIpTranslationNatRule{
+			NatGatewaySnatId: common.StringPtr(opt.CloudID),
+			Description:      opt.Memo,
+		}
+		if _, err = client.ModifyNatGatewaySourceIpTranslationNatRuleWithContext(kt.Ctx, req); err != nil {
+			logs.Errorf("update tcloud snat rule failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+			return err
+		}
+		return nil
+	}
+
+	source, err := t.getDnatRule(kt, client, opt.CloudNatGatewayID, opt.CloudID)
+	if err != nil {
+		return err
+	}
+
+	dest := &vpc.DestinationIpPortTranslationNatRule{
+		IpProtocol:       source.IpProtocol,
+		PublicIpAddress:  source.PublicIpAddress,
+		PublicPort:       source.PublicPort,
+		PrivateIpAddress: source.PrivateIpAddress,
+		PrivatePort:      source.PrivatePort,
+		Description:      source.Description,
+	}
+	if opt.Memo != nil {
+		dest.Description = opt.Memo
+	}
+	if len(opt.PrivateIP) != 0 {
+		dest.PrivateIpAddress = common.StringPtr(opt.PrivateIP)
+	}
+	if opt.PrivatePort != 0 {
+		dest.PrivatePort = common.Uint64Ptr(uint64(opt.PrivatePort))
+	}
+
+	req := vpc.NewModifyNatGatewayDestinationIpPortTranslationNatRuleRequest()
+	req.NatGatewayId = common.StringPtr(opt.CloudNatGatewayID)
+	req.SourceNatRule = source
+	req.DestinationNatRule = dest
+	if _, err = client.ModifyNatGatewayDestinationIpPortTranslationNatRuleWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("update tcloud dnat rule failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// getDnatRule 根据规则ID中的协议、公网IP和端口查询腾讯云DNAT规则，修改和删除DNAT规则需要完整的原规则
+func (t *TCloudImpl) getDnatRule(kt *kit.Kit, client *vpc.Client, cloudNatID, cloudID string) (
+	*vpc.DestinationIpPortTranslationNatRule, error) {
+
+	protocol, publicIP, publicPort, err := typenat.ParseTCloudDnatRuleCloudID(cloudID)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rules, err := t.listDnatRule(kt, client, cloudNatID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, one := range rules {
+		if converter.PtrToVal(one.IpProtocol) != protocol || converter.PtrToVal(one.PublicIpAddress) != publicIP ||
+			int64(converter.PtrToVal(one.PublicPort)) != publicPort {
+			continue
+		}
+
+		return &vpc.DestinationIpPortTranslationNatRule{
+			IpProtocol:       one.IpProtocol,
+			PublicIpAddress:  one.PublicIpAddress,
+			PublicPort:       one.PublicPort,
+			PrivateIpAddress: one.PrivateIpAddress,
+			PrivatePort:      one.PrivatePort,
+			Description:      one.Description,
+		}, nil
+	}
+
+	return nil, errf.Newf(errf.RecordNotFound, "tcloud dnat rule %s not found", cloudID)
+}
+
+// DeleteNatRule delete nat gateway snat/dnat rule.
+// reference: https://cloud.tencent.com/document/api/215/59618
+// reference: https://cloud.tencent.com/document/api/215/36720
+func (t *TCloudImpl) DeleteNatRule(kt *kit.Kit, opt *typenat.RuleDeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.VpcClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new tcloud vpc client failed, err: %v", err)
+	}
+
+	if opt.RuleType == enumor.SnatRuleType {
+		req := vpc.NewDeleteNatGatewaySourceIpTranslationNatRuleRequest()
+		req.NatGatewayId = common.StringPtr(opt.CloudNatGatewayID)
+		req.NatGatewaySnatIds = common.StringPtrs(opt.CloudIDs)
+		if _, err = client.DeleteNatGatewaySourceIpTranslationNatRuleWithContext(kt.Ctx, req); err != nil {
+			logs.Errorf("delete tcloud snat rule failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+			return err
+		}
+		return nil
+	}
+
+	rules := make([]*vpc.DestinationIpPortTranslationNatRule, 0, len(opt.CloudIDs))
+	for _, cloudID := range opt.CloudIDs {
+		rule, err := t.getDnatRule(kt, client, opt.CloudNatGatewayID, cloudID)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+	}
+
+	req := vpc.NewDeleteNatGatewayDestinationIpPortTranslationNatRuleRequest()
+	req.NatGatewayId = common.StringPtr(opt.CloudNatGatewayID)
+	req.DestinationIpPortTranslationNatRules = rules
+	if _, err = client.DeleteNatGatewayDestinationIpPortTranslationNatRuleWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete tcloud dnat rule failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types/core"
+	typesnapshot "hcm/pkg/adaptor/types/snapshot"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	cbs "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cbs/v20170312"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+)
+
+// ListSnapshot list disk snapshot.
+// reference: https://cloud.tencent.com/document/api/362/15647
+func (t *TCloudImpl) ListSnapshot(kt *kit.Kit, opt *core.TCloudListOption) ([]typesnapshot.TCloudSnapshot, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CbsClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud cbs client failed, err: %v", err)
+	}
+
+	req := cbs.NewDescribeSnapshotsRequest()
+	if len(opt.CloudIDs) != 0 {
+		req.SnapshotIds = common.StringPtrs(opt.CloudIDs)
+		req.Limit = common.Uint64Ptr(uint64(core.TCloudQueryLimit))
+	}
+	if opt.Page != nil {
+		req.Offset = &opt.Page.Offset
+		req.Limit = &opt.Page.Limit
+	}
+
+	resp, err := client.DescribeSnapshotsWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("list tcloud snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	results := make([]typesnapshot.TCloudSnapshot, 0, len(resp.Response.SnapshotSet))
+	for _, one := range resp.Response.SnapshotSet {
+		results = append(results, convTCloudSnapshot(one, opt.Region))
+	}
+
+	return results, nil
+}
+
+func convTCloudSnapshot(one *cbs.Snapshot, region string) typesnapshot.TCloudSnapshot {
+	snapshot := typesnapshot.TCloudSnapshot{
+		BaseSnapshot: typesnapshot.BaseSnapshot{
+			CloudID:          converter.PtrToVal(one.SnapshotId),
+			Name:             converter.PtrToVal(one.SnapshotName),
+			Region:           region,
+			CloudDiskID:      converter.PtrToVal(one.DiskId),
+			Size:             converter.PtrToVal(one.DiskSize),
+			Status:           converter.PtrToVal(one.SnapshotState),
+			CloudCreatedTime: converter.PtrToVal(one.CreateTime),
+		},
+		Extension: &coresnapshot.TCloudSnapshotExtension{
+			DiskUsage:    one.DiskUsage,
+			Percent:      one.Percent,
+			Encrypt:      one.Encrypt,
+			IsPermanent:  one.IsPermanent,
+			DeadlineTime: one.DeadlineTime,
+			SnapshotType: one.SnapshotType,
+		},
+	}
+	if one.Placement != nil {
+		snapshot.Zone = converter.PtrToVal(one.Placement.Zone)
+	}
+
+	return snapshot
+}
+
+// CreateSnapshot create disk snapshot, return snapshot cloud id.
+// reference: https://cloud.tencent.com/document/api/362/15648
+func (t *TCloudImpl) CreateSnapshot(kt *kit.Kit, opt *typesnapshot.CreateOption) (string, error) {
+	if opt == nil {
+		return "", errf.New(errf.InvalidParameter, "create option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CbsClient(opt.Region)
+	if err != nil {
+		return "", fmt.Errorf("new tcloud cbs client failed, err: %v", err)
+	}
+
+	req := cbs.NewCreateSnapshotRequest()
+	req.DiskId = common.StringPtr(opt.CloudDiskID)
+	req.SnapshotName = common.StringPtr(opt.Name)
+
+	resp, err := client.CreateSnapshotWithContext(kt.Ctx, req)
+	if err != nil {
+		logs.Errorf("create tcloud snapshot failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return "", err
+	}
+
+	return converter.PtrToVal(resp.Response.SnapshotId), nil
+}
+
+// DeleteSnapshot delete disk snapshot.
+// reference: https://cloud.tencent.com/document/api/362/15649
+func (t *TCloudImpl) DeleteSnapshot(kt *kit.Kit, opt *typesnapshot.DeleteOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "delete option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CbsClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new tcloud cbs client failed, err: %v", err)
+	}
+
+	req := cbs.NewDeleteSnapshotsRequest()
+	req.SnapshotIds = common.StringPtrs([]string{opt.CloudID})
+	if _, err = client.DeleteSnapshotsWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("delete tcloud snapshot failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RollbackSnapshot rollback disk by snapshot, disk must be detached or its instance must be stopped.
+// reference: https://cloud.tencent.com/document/api/362/15650
+func (t *TCloudImpl) RollbackSnapshot(kt *kit.Kit, opt *typesnapshot.RollbackOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "rollback option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CbsClient(opt.Region)
+	if err != nil {
+		return fmt.Errorf("new tcloud cbs client failed, err: %v", err)
+	}
+
+	req := cbs.NewApplySnapshotRequest()
+	req.SnapshotId = common.StringPtr(opt.CloudID)
+	req.DiskId = common.StringPtr(opt.CloudDiskID)
+	if _, err = client.ApplySnapshotWithContext(kt.Ctx, req); err != nil {
+		logs.Errorf("rollback tcloud snapshot failed, opt: %+v, err: %v, rid: %s", opt, err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
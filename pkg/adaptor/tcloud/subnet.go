@@ -212,6 +212,7 @@ func convertSubnet(data *vpc.Subnet, region string) *adtysubnet.TCloudSubnet {
 		CloudID:    converter.PtrToVal(data.SubnetId),
 		Name:       converter.PtrToVal(data.SubnetName),
 		Region:     region,
+		Tags:       convertTags(data.TagSet),
 		Extension: &adtysubnet.TCloudSubnetExtension{
 			IsDefault:               converter.PtrToVal(data.IsDefault),
 			Zone:                    converter.PtrToVal(data.Zone),
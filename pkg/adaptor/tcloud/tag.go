@@ -0,0 +1,155 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+
+	typetag "hcm/pkg/adaptor/types/tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	vpc "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/vpc/v20170312"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
+)
+
+const (
+	tagService = "tag"
+	tagVersion = "2018-08-13"
+
+	// tagResourceLimit 标签接口单次最多操作的资源数量
+	tagResourceLimit = 10
+)
+
+// tagResourcePrefix 资源类型对应的六段式中的服务类型及资源前缀
+// reference: https://cloud.tencent.com/document/product/598/10606
+var tagResourcePrefix = map[enumor.CloudResourceType][2]string{
+	enumor.CvmCloudResType:    {"cvm", "instance"},
+	enumor.DiskCloudResType:   {"cvm", "volume"},
+	enumor.VpcCloudResType:    {"vpc", "vpc"},
+	enumor.SubnetCloudResType: {"vpc", "subnet"},
+}
+
+// TagResources 为资源批量添加标签
+// reference: https://cloud.tencent.com/document/api/651/72270
+func (t *TCloudImpl) TagResources(kt *kit.Kit, opt *typetag.TagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "tag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	tags := make([]map[string]string, 0, len(opt.Tags))
+	for _, tag := range opt.Tags {
+		tags = append(tags, map[string]string{"TagKey": tag.Key, "TagValue": tag.Value})
+	}
+
+	return t.operateResourceTags(kt, opt.Region, opt.ResType, typetag.CloudIDs(opt.Resources), "TagResources",
+		"Tags", tags)
+}
+
+// UntagResources 批量解绑资源的标签
+// reference: https://cloud.tencent.com/document/api/651/72269
+func (t *TCloudImpl) UntagResources(kt *kit.Kit, opt *typetag.UntagResOption) error {
+	if opt == nil {
+		return errf.New(errf.InvalidParameter, "untag resource option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return t.operateResourceTags(kt, opt.Region, opt.ResType, typetag.CloudIDs(opt.Resources), "UnTagResources",
+		"TagKeys", opt.TagKeys)
+}
+
+func (t *TCloudImpl) operateResourceTags(kt *kit.Kit, region string, resType enumor.CloudResourceType,
+	cloudIDs []string, action, tagField string, tags interface{}) error {
+
+	if len(region) == 0 {
+		return errf.New(errf.InvalidParameter, "region is required")
+	}
+
+	resourceNames, err := t.tagResourceNames(kt, region, resType, cloudIDs)
+	if err != nil {
+		return err
+	}
+
+	client, err := t.clientSet.TagClient(region)
+	if err != nil {
+		return fmt.Errorf("new tcloud tag client failed, err: %v", err)
+	}
+
+	for _, names := range slice.Split(resourceNames, tagResourceLimit) {
+		params := map[string]interface{}{
+			"ResourceList": names,
+			tagField:       tags,
+		}
+		if err = commonRequest(kt, client, tagService, tagVersion, action, params, nil); err != nil {
+			logs.Errorf("%s failed, err: %v, resources: %v, rid: %s", action, err, names, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// convertTags convert tcloud vpc tags to tag pairs.
+func convertTags(tags []*vpc.Tag) []coretag.TagPair {
+	pairs := make([]coretag.TagPair, 0, len(tags))
+	for _, tag := range tags {
+		if tag == nil {
+			continue
+		}
+		pairs = append(pairs, coretag.TagPair{Key: converter.PtrToVal(tag.Key), Value: converter.PtrToVal(tag.Value)})
+	}
+
+	return pairs
+}
+
+// tagResourceNames 将资源ID转换为标签接口需要的资源六段式，如 qcs::cvm:ap-guangzhou:uin/123:instance/ins-xxx
+func (t *TCloudImpl) tagResourceNames(kt *kit.Kit, region string, resType enumor.CloudResourceType,
+	cloudIDs []string) ([]string, error) {
+
+	prefix, exists := tagResourcePrefix[resType]
+	if !exists {
+		return nil, errf.Newf(errf.InvalidParameter, "tcloud resource type %s does not support tag", resType)
+	}
+
+	info, err := t.GetAccountInfoBySecret(kt)
+	if err != nil {
+		logs.Errorf("get tcloud account info by secret failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	names := make([]string, 0, len(cloudIDs))
+	for _, id := range cloudIDs {
+		names = append(names, fmt.Sprintf("qcs::%s:%s:uin/%s:%s/%s", prefix[0], region, info.CloudMainAccountID,
+			prefix[1], id))
+	}
+
+	return names, nil
+}
@@ -0,0 +1,161 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package typetrail 云上操作审计事件（AWS CloudTrail、腾讯云 CloudAudit、华为云 CTS）相关定义，用于增量同步
+package typetrail
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/slice"
+)
+
+// MaxQueryWindow 单次查询操作审计事件的最大时间范围，云厂商一般只保留最近7天内的事件
+const MaxQueryWindow = 7 * 24 * time.Hour
+
+// ListOption defines list audit trail event options.
+type ListOption struct {
+	// Region 查询地域，腾讯云操作审计不区分地域，可不传
+	Region    string    `json:"region"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required"`
+	NextToken string    `json:"next_token"`
+}
+
+// Validate list option.
+func (opt ListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if !opt.EndTime.After(opt.StartTime) {
+		return errors.New("end_time should be after start_time")
+	}
+
+	if opt.EndTime.Sub(opt.StartTime) > MaxQueryWindow {
+		return errors.New("query time window should be no more than 7 days")
+	}
+
+	return nil
+}
+
+// ListResult defines list audit trail event result.
+type ListResult struct {
+	// NextToken 为空时表示已查询完所有事件
+	NextToken string  `json:"next_token"`
+	Events    []Event `json:"events"`
+}
+
+// Event 云上资源的变更事件，只包含增量同步支持的资源类型的写操作。
+type Event struct {
+	EventID   string                   `json:"event_id"`
+	EventName string                   `json:"event_name"`
+	EventTime time.Time                `json:"event_time"`
+	Region    string                   `json:"region"`
+	Operator  string                   `json:"operator"`
+	ResType   enumor.CloudResourceType `json:"res_type"`
+	CloudIDs  []string                 `json:"cloud_ids"`
+}
+
+// IncrementalSyncResTypes 支持增量同步的资源类型
+var IncrementalSyncResTypes = []enumor.CloudResourceType{
+	enumor.SecurityGroupCloudResType,
+	enumor.CvmCloudResType,
+}
+
+// BuildEvents 按资源类型拆分事件，一个云上事件可能同时涉及多种资源，如创建主机时会指定安全组。
+func BuildEvents(base Event, resMap map[enumor.CloudResourceType][]string) []Event {
+	events := make([]Event, 0, len(resMap))
+	for _, resType := range IncrementalSyncResTypes {
+		cloudIDs := resMap[resType]
+		if len(cloudIDs) == 0 {
+			continue
+		}
+
+		event := base
+		event.ResType = resType
+		event.CloudIDs = slice.Unique(cloudIDs)
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// GroupEvents 将事件按地域、资源类型对受影响的云资源ID进行分组去重，返回 {region: {resType: cloudIDs}}。
+func GroupEvents(events []Event) map[string]map[enumor.CloudResourceType][]string {
+	result := make(map[string]map[enumor.CloudResourceType][]string)
+	for _, one := range events {
+		if len(one.Region) == 0 || len(one.CloudIDs) == 0 {
+			continue
+		}
+
+		if _, exist := result[one.Region]; !exist {
+			result[one.Region] = make(map[enumor.CloudResourceType][]string)
+		}
+		result[one.Region][one.ResType] = append(result[one.Region][one.ResType], one.CloudIDs...)
+	}
+
+	for _, resMap := range result {
+		for resType, cloudIDs := range resMap {
+			resMap[resType] = slice.Unique(cloudIDs)
+		}
+	}
+
+	return result
+}
+
+// TCloudResType 腾讯云操作审计事件中的资源名为云资源ID，按ID前缀识别资源类型。
+func TCloudResType(cloudID string) (enumor.CloudResourceType, bool) {
+	switch {
+	case strings.HasPrefix(cloudID, "ins-"):
+		return enumor.CvmCloudResType, true
+	case strings.HasPrefix(cloudID, "sg-"):
+		return enumor.SecurityGroupCloudResType, true
+	default:
+		return "", false
+	}
+}
+
+// AwsResType 按 CloudTrail 事件中的资源类型识别资源类型。
+func AwsResType(resourceType string) (enumor.CloudResourceType, bool) {
+	switch resourceType {
+	case "AWS::EC2::Instance":
+		return enumor.CvmCloudResType, true
+	case "AWS::EC2::SecurityGroup":
+		return enumor.SecurityGroupCloudResType, true
+	default:
+		return "", false
+	}
+}
+
+// HuaWeiResType 按 CTS 事件中的资源类型识别资源类型，安全组规则事件的资源ID为规则ID，不做处理，由全量同步兜底。
+func HuaWeiResType(resourceType string) (enumor.CloudResourceType, bool) {
+	switch strings.ToLower(resourceType) {
+	case "ecs", "server", "cloudserver":
+		return enumor.CvmCloudResType, true
+	case "security_group", "securitygroup", "security_groups":
+		return enumor.SecurityGroupCloudResType, true
+	default:
+		return "", false
+	}
+}
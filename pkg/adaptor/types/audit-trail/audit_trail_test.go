@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package typetrail
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestGroupEvents(t *testing.T) {
+	events := []Event{
+		{Region: "ap-guangzhou", ResType: enumor.CvmCloudResType, CloudIDs: []string{"ins-1", "ins-2"}},
+		{Region: "ap-guangzhou", ResType: enumor.CvmCloudResType, CloudIDs: []string{"ins-2", "ins-3"}},
+		{Region: "ap-guangzhou", ResType: enumor.SecurityGroupCloudResType, CloudIDs: []string{"sg-1"}},
+		{Region: "ap-shanghai", ResType: enumor.CvmCloudResType, CloudIDs: []string{"ins-4"}},
+		{Region: "", ResType: enumor.CvmCloudResType, CloudIDs: []string{"ins-5"}},
+		{Region: "ap-beijing", ResType: enumor.CvmCloudResType},
+	}
+
+	result := GroupEvents(events)
+	if len(result) != 2 {
+		t.Fatalf("region count should be 2, but got %d, result: %v", len(result), result)
+	}
+
+	cvmIDs := result["ap-guangzhou"][enumor.CvmCloudResType]
+	sort.Strings(cvmIDs)
+	if !reflect.DeepEqual(cvmIDs, []string{"ins-1", "ins-2", "ins-3"}) {
+		t.Errorf("ap-guangzhou cvm ids should be unique, but got %v", cvmIDs)
+	}
+
+	if !reflect.DeepEqual(result["ap-guangzhou"][enumor.SecurityGroupCloudResType], []string{"sg-1"}) {
+		t.Errorf("ap-guangzhou sg ids not right, got %v", result["ap-guangzhou"][enumor.SecurityGroupCloudResType])
+	}
+
+	if !reflect.DeepEqual(result["ap-shanghai"][enumor.CvmCloudResType], []string{"ins-4"}) {
+		t.Errorf("ap-shanghai cvm ids not right, got %v", result["ap-shanghai"][enumor.CvmCloudResType])
+	}
+}
+
+func TestTCloudResType(t *testing.T) {
+	cases := map[string]enumor.CloudResourceType{
+		"ins-abcd1234": enumor.CvmCloudResType,
+		"sg-abcd1234":  enumor.SecurityGroupCloudResType,
+		"vpc-abcd1234": "",
+	}
+
+	for cloudID, expect := range cases {
+		resType, ok := TCloudResType(cloudID)
+		if ok != (len(expect) != 0) || resType != expect {
+			t.Errorf("cloud id %s res type should be %s, but got %s", cloudID, expect, resType)
+		}
+	}
+}
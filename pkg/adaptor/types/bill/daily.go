@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"sort"
+
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/tools/math"
+)
+
+// BillCostItem 归一化后的单条云账单费用，各云厂商的账单明细都会先转换成该结构再做汇总
+type BillCostItem struct {
+	Region   string
+	Product  string
+	Currency string
+	Cost     string
+}
+
+type billCostKey struct {
+	region   string
+	product  string
+	currency string
+}
+
+type billCostSum struct {
+	cost  math.Decimal
+	count int64
+}
+
+// BillDailyAggregator 将单日的云账单明细按照地域、产品、币种汇总
+type BillDailyAggregator struct {
+	sums map[billCostKey]*billCostSum
+}
+
+// NewBillDailyAggregator new bill daily aggregator.
+func NewBillDailyAggregator() *BillDailyAggregator {
+	return &BillDailyAggregator{sums: make(map[billCostKey]*billCostSum)}
+}
+
+// Add 累加一条账单明细，费用为空的明细只计数
+func (a *BillDailyAggregator) Add(item BillCostItem) error {
+	cost := math.Decimal{}
+	if item.Cost != "" {
+		var err error
+		cost, err = math.NewDecimalFromString(item.Cost)
+		if err != nil {
+			return fmt.Errorf("parse bill cost %s failed, err: %v", item.Cost, err)
+		}
+	}
+
+	key := billCostKey{region: item.Region, product: item.Product, currency: item.Currency}
+	sum, exists := a.sums[key]
+	if !exists {
+		sum = new(billCostSum)
+		a.sums[key] = sum
+	}
+	sum.cost = sum.cost.Add(cost)
+	sum.count++
+
+	return nil
+}
+
+// Costs 返回汇总后的日费用，按照地域、产品、币种排序
+func (a *BillDailyAggregator) Costs() []cloud.BillDailyCost {
+	costs := make([]cloud.BillDailyCost, 0, len(a.sums))
+	for key, sum := range a.sums {
+		costs = append(costs, cloud.BillDailyCost{
+			Region:    key.region,
+			Product:   key.product,
+			Currency:  key.currency,
+			Cost:      sum.cost.ToString(),
+			ItemCount: sum.count,
+		})
+	}
+
+	sort.Slice(costs, func(i, j int) bool {
+		if costs[i].Region != costs[j].Region {
+			return costs[i].Region < costs[j].Region
+		}
+		if costs[i].Product != costs[j].Product {
+			return costs[i].Product < costs[j].Product
+		}
+		return costs[i].Currency < costs[j].Currency
+	})
+
+	return costs
+}
@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"testing"
+
+	"hcm/pkg/api/core/cloud"
+)
+
+func TestBillDailyAggregator(t *testing.T) {
+	items := []BillCostItem{
+		{Region: "ap-guangzhou", Product: "cvm", Currency: "CNY", Cost: "1.25"},
+		{Region: "ap-guangzhou", Product: "cvm", Currency: "CNY", Cost: "0.005"},
+		{Region: "ap-guangzhou", Product: "cvm", Currency: "USD", Cost: "3"},
+		{Region: "ap-beijing", Product: "cbs", Currency: "CNY", Cost: "-0.5"},
+		{Region: "ap-beijing", Product: "cbs", Currency: "CNY", Cost: "1.2E-2"},
+		{Region: "ap-beijing", Product: "cbs", Currency: "CNY"},
+	}
+
+	aggregator := NewBillDailyAggregator()
+	for _, item := range items {
+		if err := aggregator.Add(item); err != nil {
+			t.Fatalf("add bill item failed, err: %v", err)
+		}
+	}
+
+	expects := []cloud.BillDailyCost{
+		{Region: "ap-beijing", Product: "cbs", Currency: "CNY", Cost: "-0.488", ItemCount: 3},
+		{Region: "ap-guangzhou", Product: "cvm", Currency: "CNY", Cost: "1.255", ItemCount: 2},
+		{Region: "ap-guangzhou", Product: "cvm", Currency: "USD", Cost: "3", ItemCount: 1},
+	}
+
+	costs := aggregator.Costs()
+	if len(costs) != len(expects) {
+		t.Fatalf("aggregate bill costs count %d, expect %d", len(costs), len(expects))
+	}
+
+	for idx := range expects {
+		if costs[idx] != expects[idx] {
+			t.Errorf("aggregate bill cost %+v, expect %+v", costs[idx], expects[idx])
+		}
+	}
+
+	if err := aggregator.Add(BillCostItem{Cost: "abc"}); err == nil {
+		t.Errorf("add invalid bill cost should be failed")
+	}
+}
@@ -21,6 +21,7 @@ package cvm
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -128,13 +129,15 @@ type AwsCreateOption struct {
 	Zone                  string                  `json:"zone" validate:"required"`
 	InstanceType          string                  `json:"instance_type" validate:"required"`
 	CloudImageID          string                  `json:"cloud_image_id" validate:"required"`
-	Password              string                  `json:"password" validate:"required"`
+	Password              string                  `json:"password" validate:"required_without=KeyName"`
 	RequiredCount         int64                   `json:"required_count" validate:"required"`
 	CloudSecurityGroupIDs []string                `json:"cloud_security_group_ids" validate:"required"`
 	ClientToken           *string                 `json:"client_token" validate:"omitempty"`
 	CloudSubnetID         string                  `json:"cloud_subnet_id" validate:"required"`
 	BlockDeviceMapping    []AwsBlockDeviceMapping `json:"block_device_mapping" validate:"required"`
 	PublicIPAssigned      bool                    `json:"public_ip_assigned" validate:"omitempty"`
+	// KeyName 登录使用的密钥对名称，与密码二选一
+	KeyName *string `json:"key_name" validate:"omitempty"`
 }
 
 // AwsBlockDeviceMapping ...
@@ -186,3 +189,16 @@ type AwsCvm struct {
 func (cvm AwsCvm) GetCloudID() string {
 	return converter.PtrToVal(cvm.InstanceId)
 }
+
+// GetTags ...
+func (cvm AwsCvm) GetTags() []coretag.TagPair {
+	tags := make([]coretag.TagPair, 0, len(cvm.Tags))
+	for _, tag := range cvm.Tags {
+		if tag == nil {
+			continue
+		}
+		tags = append(tags, coretag.TagPair{Key: converter.PtrToVal(tag.Key), Value: converter.PtrToVal(tag.Value)})
+	}
+
+	return tags
+}
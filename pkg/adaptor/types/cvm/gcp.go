@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"hcm/pkg/adaptor/types/core"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
 	"hcm/pkg/criteria/validator"
 
 	"google.golang.org/api/compute/v1"
@@ -119,7 +120,7 @@ type GcpCreateOption struct {
 	Zone               string `json:"zone" validate:"required"`
 	InstanceType       string `json:"instance_type" validate:"required"`
 	CloudImageSelfLink string `json:"cloud_image_self_link" validate:"required"`
-	Password           string `json:"password" validate:"required"`
+	Password           string `json:"password" validate:"omitempty"`
 	RequiredCount      int64  `json:"required_count" validate:"required"`
 	// RequestID 唯一标识支持生产请求
 	RequestID           string `json:"request_id" validate:"omitempty"`
@@ -209,3 +210,12 @@ type GcpCvm struct {
 func (cvm GcpCvm) GetCloudID() string {
 	return fmt.Sprint(cvm.Id)
 }
+
+// GetTags ...
+func (cvm GcpCvm) GetTags() []coretag.TagPair {
+	if cvm.Instance == nil {
+		return nil
+	}
+
+	return coretag.NewTagPairsFromMap(cvm.Labels)
+}
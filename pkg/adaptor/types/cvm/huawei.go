@@ -21,8 +21,10 @@ package cvm
 
 import (
 	"fmt"
+	"strings"
 
 	"hcm/pkg/adaptor/types/core"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
 	"hcm/pkg/criteria/validator"
 
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/ecs/v2/model"
@@ -132,7 +134,7 @@ type HuaWeiCreateOption struct {
 	Zone                  string                `json:"zone" validate:"required"`
 	InstanceType          string                `json:"instance_type" validate:"required"`
 	CloudImageID          string                `json:"cloud_image_id" validate:"required"`
-	Password              string                `json:"password" validate:"required"`
+	Password              string                `json:"password" validate:"required_without=KeyName"`
 	RequiredCount         int32                 `json:"required_count" validate:"required"`
 	CloudSecurityGroupIDs []string              `json:"cloud_security_group_ids" validate:"required"`
 	ClientToken           *string               `json:"client_token" validate:"omitempty"`
@@ -144,6 +146,8 @@ type HuaWeiCreateOption struct {
 	InstanceCharge        *HuaWeiInstanceCharge `json:"instance_charge" validate:"required"`
 	PublicIPAssigned      bool                  `json:"public_ip_assigned" validate:"omitempty"`
 	Eip                   *HuaWeiEip            `json:"eip" validate:"omitempty"`
+	// KeyName 登录使用的密钥对名称，与密码二选一
+	KeyName *string `json:"key_name" validate:"omitempty"`
 }
 
 // Validate aws cvm operation option.
@@ -320,3 +324,19 @@ type HuaWeiCvm struct {
 func (cvm HuaWeiCvm) GetCloudID() string {
 	return cvm.Id
 }
+
+// GetTags ...
+func (cvm HuaWeiCvm) GetTags() []coretag.TagPair {
+	if cvm.Tags == nil {
+		return nil
+	}
+
+	// 华为云主机标签格式为 key=value
+	tags := make([]coretag.TagPair, 0, len(*cvm.Tags))
+	for _, one := range *cvm.Tags {
+		key, value, _ := strings.Cut(one, "=")
+		tags = append(tags, coretag.TagPair{Key: key, Value: value})
+	}
+
+	return tags
+}
@@ -23,6 +23,7 @@ import (
 	"errors"
 
 	"hcm/pkg/adaptor/types/core"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -168,7 +169,7 @@ type TCloudCreateOption struct {
 	Zone                    string                       `json:"zone" validate:"required"`
 	InstanceType            string                       `json:"instance_type" validate:"required"`
 	CloudImageID            string                       `json:"cloud_image_id" validate:"required"`
-	Password                string                       `json:"password" validate:"required"`
+	Password                string                       `json:"password" validate:"required_without=CloudKeyPairIDs"`
 	RequiredCount           int64                        `json:"required_count" validate:"required"`
 	CloudSecurityGroupIDs   []string                     `json:"cloud_security_group_ids" validate:"required"`
 	ClientToken             *string                      `json:"client_token" validate:"omitempty"`
@@ -180,6 +181,8 @@ type TCloudCreateOption struct {
 	DataDisk                []TCloudDataDisk             `json:"data_disk" validate:"omitempty"`
 	PublicIPAssigned        bool                         `json:"public_ip_assigned" validate:"omitempty"`
 	InternetMaxBandwidthOut int64                        `json:"internet_max_bandwidth_out" validate:"omitempty"`
+	// CloudKeyPairIDs 登录使用的密钥对，与密码二选一
+	CloudKeyPairIDs []string `json:"cloud_key_pair_ids" validate:"omitempty"`
 }
 
 // Validate aws cvm operation option.
@@ -297,6 +300,19 @@ func (cvm TCloudCvm) GetCloudID() string {
 	return converter.PtrToVal(cvm.InstanceId)
 }
 
+// GetTags ...
+func (cvm TCloudCvm) GetTags() []coretag.TagPair {
+	tags := make([]coretag.TagPair, 0, len(cvm.Tags))
+	for _, tag := range cvm.Tags {
+		if tag == nil {
+			continue
+		}
+		tags = append(tags, coretag.TagPair{Key: converter.PtrToVal(tag.Key), Value: converter.PtrToVal(tag.Value)})
+	}
+
+	return tags
+}
+
 // InquiryPriceResult define tcloud inquiry price result.
 type InquiryPriceResult struct {
 	DiscountPrice float64 `json:"discount_price"`
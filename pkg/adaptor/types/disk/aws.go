@@ -21,6 +21,7 @@ package disk
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -155,3 +156,16 @@ type AwsDisk struct {
 func (disk AwsDisk) GetCloudID() string {
 	return converter.PtrToVal(disk.VolumeId)
 }
+
+// GetTags ...
+func (disk AwsDisk) GetTags() []coretag.TagPair {
+	tags := make([]coretag.TagPair, 0, len(disk.Tags))
+	for _, tag := range disk.Tags {
+		if tag == nil {
+			continue
+		}
+		tags = append(tags, coretag.TagPair{Key: converter.PtrToVal(tag.Key), Value: converter.PtrToVal(tag.Value)})
+	}
+
+	return tags
+}
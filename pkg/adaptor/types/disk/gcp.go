@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"hcm/pkg/adaptor/types/core"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
 	"hcm/pkg/criteria/validator"
 
 	"google.golang.org/api/compute/v1"
@@ -151,3 +152,12 @@ type GcpDisk struct {
 func (disk GcpDisk) GetCloudID() string {
 	return fmt.Sprint(disk.Id)
 }
+
+// GetTags ...
+func (disk GcpDisk) GetTags() []coretag.TagPair {
+	if disk.Disk == nil {
+		return nil
+	}
+
+	return coretag.NewTagPairsFromMap(disk.Labels)
+}
@@ -21,6 +21,7 @@ package disk
 
 import (
 	"hcm/pkg/adaptor/types/core"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/tools/converter"
 
@@ -196,6 +197,19 @@ func (disk TCloudDisk) GetCloudID() string {
 	return converter.PtrToVal(disk.DiskId)
 }
 
+// GetTags ...
+func (disk TCloudDisk) GetTags() []coretag.TagPair {
+	tags := make([]coretag.TagPair, 0, len(disk.Tags))
+	for _, tag := range disk.Tags {
+		if tag == nil {
+			continue
+		}
+		tags = append(tags, coretag.TagPair{Key: converter.PtrToVal(tag.Key), Value: converter.PtrToVal(tag.Value)})
+	}
+
+	return tags
+}
+
 // InquiryPriceResult define tcloud inquiry price result.
 type InquiryPriceResult struct {
 	DiscountPrice float64 `json:"discount_price"`
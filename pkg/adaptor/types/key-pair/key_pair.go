@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package typekeypair 密钥对相关的云上资源定义
+package typekeypair
+
+import (
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/criteria/validator"
+)
+
+// BaseKeyPair defines the common fields of key pair.
+type BaseKeyPair struct {
+	CloudID          string `json:"cloud_id"`
+	Name             string `json:"name"`
+	Region           string `json:"region"`
+	Fingerprint      string `json:"fingerprint"`
+	PublicKey        string `json:"public_key"`
+	CloudCreatedTime string `json:"cloud_created_time"`
+}
+
+// GetCloudID ...
+func (k BaseKeyPair) GetCloudID() string {
+	return k.CloudID
+}
+
+// TCloudKeyPair tcloud key pair.
+type TCloudKeyPair struct {
+	BaseKeyPair `json:",inline"`
+	Extension   *corekeypair.TCloudKeyPairExtension `json:"extension"`
+}
+
+// AwsKeyPair aws key pair.
+type AwsKeyPair struct {
+	BaseKeyPair `json:",inline"`
+	Extension   *corekeypair.AwsKeyPairExtension `json:"extension"`
+}
+
+// HuaWeiKeyPair huawei key pair.
+type HuaWeiKeyPair struct {
+	BaseKeyPair `json:",inline"`
+	Extension   *corekeypair.HuaWeiKeyPairExtension `json:"extension"`
+}
+
+// GcpKeyPair gcp key pair.
+type GcpKeyPair struct {
+	BaseKeyPair `json:",inline"`
+	Extension   *corekeypair.GcpKeyPairExtension `json:"extension"`
+}
+
+// HuaWeiListOption defines options to list huawei key pair, huawei key pair is paged by marker.
+type HuaWeiListOption struct {
+	Region string  `json:"region" validate:"required"`
+	Limit  int32   `json:"limit" validate:"omitempty"`
+	Marker *string `json:"marker" validate:"omitempty"`
+}
+
+// Validate huawei key pair list option.
+func (opt HuaWeiListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiListResult defines huawei list key pair result.
+type HuaWeiListResult struct {
+	NextMarker *string         `json:"next_marker,omitempty"`
+	Details    []HuaWeiKeyPair `json:"details"`
+}
+
+// ImportOption defines options to import public key to cloud, region is ignored by tcloud and gcp,
+// name is used as login user name by gcp.
+type ImportOption struct {
+	Region    string `json:"region" validate:"omitempty"`
+	Name      string `json:"name" validate:"required,max=64"`
+	PublicKey string `json:"public_key" validate:"required"`
+}
+
+// Validate key pair import option.
+func (opt ImportOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// DeleteOption defines options to delete key pair, aws and huawei key pair is deleted by name,
+// gcp key pair is deleted by cloud id which is composed of user name and fingerprint.
+type DeleteOption struct {
+	Region  string `json:"region" validate:"omitempty"`
+	CloudID string `json:"cloud_id" validate:"required"`
+	Name    string `json:"name" validate:"omitempty"`
+}
+
+// Validate key pair delete option.
+func (opt DeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
@@ -0,0 +1,277 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package typelb 负载均衡相关的云上资源定义
+package typelb
+
+import (
+	"fmt"
+
+	"hcm/pkg/adaptor/types/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+)
+
+const (
+	// TargetInstTypeCvm 后端目标为云主机
+	TargetInstTypeCvm = "CVM"
+	// TargetInstTypeIP 后端目标为IP
+	TargetInstTypeIP = "IP"
+)
+
+// TargetCloudID 负载均衡后端目标在云上没有唯一ID，使用 "监听器或目标组ID/实例ID或IP:端口" 作为其云上ID
+func TargetCloudID(cloudGroupID, cloudInstOrIP string, port int64) string {
+	return fmt.Sprintf("%s/%s:%d", cloudGroupID, cloudInstOrIP, port)
+}
+
+// -------------------------- Load Balancer --------------------------
+
+// BaseLoadBalancer defines the common fields of cloud load balancer.
+type BaseLoadBalancer struct {
+	CloudID              string   `json:"cloud_id"`
+	Name                 string   `json:"name"`
+	Region               string   `json:"region"`
+	Zones                []string `json:"zones"`
+	LBType               string   `json:"lb_type"`
+	IPVersion            string   `json:"ip_version"`
+	CloudVpcID           string   `json:"cloud_vpc_id"`
+	CloudSubnetID        string   `json:"cloud_subnet_id"`
+	PrivateIPv4Addresses []string `json:"private_ipv4_addresses"`
+	PublicIPv4Addresses  []string `json:"public_ipv4_addresses"`
+	Domain               string   `json:"domain"`
+	Status               string   `json:"status"`
+	CloudCreatedTime     string   `json:"cloud_created_time"`
+}
+
+// GetCloudID ...
+func (lb BaseLoadBalancer) GetCloudID() string {
+	return lb.CloudID
+}
+
+// TCloudLoadBalancer tcloud load balancer.
+type TCloudLoadBalancer struct {
+	BaseLoadBalancer `json:",inline"`
+	Extension        *corelb.TCloudLoadBalancerExtension `json:"extension"`
+}
+
+// AwsLoadBalancer aws load balancer.
+type AwsLoadBalancer struct {
+	BaseLoadBalancer `json:",inline"`
+	Extension        *corelb.AwsLoadBalancerExtension `json:"extension"`
+}
+
+// HuaWeiLoadBalancer huawei load balancer.
+type HuaWeiLoadBalancer struct {
+	BaseLoadBalancer `json:",inline"`
+	Extension        *corelb.HuaWeiLoadBalancerExtension `json:"extension"`
+}
+
+// GcpLoadBalancer gcp load balancer.
+type GcpLoadBalancer struct {
+	BaseLoadBalancer `json:",inline"`
+	Extension        *corelb.GcpLoadBalancerExtension `json:"extension"`
+}
+
+// AzureLoadBalancer azure load balancer.
+type AzureLoadBalancer struct {
+	BaseLoadBalancer `json:",inline"`
+	Extension        *corelb.AzureLoadBalancerExtension `json:"extension"`
+}
+
+// AwsListResult defines aws list load balancer result.
+type AwsListResult struct {
+	NextToken *string           `json:"next_token,omitempty"`
+	Details   []AwsLoadBalancer `json:"details"`
+}
+
+// HuaWeiListResult defines huawei list load balancer result.
+type HuaWeiListResult struct {
+	NextMarker *string              `json:"next_marker,omitempty"`
+	Details    []HuaWeiLoadBalancer `json:"details"`
+}
+
+// GcpListResult defines gcp list load balancer result.
+type GcpListResult struct {
+	NextPageToken string            `json:"next_page_token,omitempty"`
+	Details       []GcpLoadBalancer `json:"details"`
+}
+
+// GcpListOption defines options to list gcp load balancer, gcp target pool is regional resource.
+type GcpListOption struct {
+	Region   string        `json:"region" validate:"required"`
+	CloudIDs []string      `json:"cloud_ids" validate:"omitempty"`
+	Page     *core.GcpPage `json:"page" validate:"required"`
+}
+
+// Validate gcp load balancer list option.
+func (opt GcpListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) > core.GcpQueryLimit {
+		return errf.Newf(errf.InvalidParameter, "gcp resource ids length should <= %d", core.GcpQueryLimit)
+	}
+
+	return opt.Page.Validate()
+}
+
+// -------------------------- Listener --------------------------
+
+// Listener defines load balancer listener of cloud.
+type Listener struct {
+	CloudID   string `json:"cloud_id"`
+	Name      string `json:"name"`
+	CloudLbID string `json:"cloud_lb_id"`
+	Protocol  string `json:"protocol"`
+	Port      int64  `json:"port"`
+	// CloudTargetGroupID 监听器转发的后端目标组，aws 为目标组ARN，huawei 为后端服务器组ID，azure 为后端地址池ID
+	CloudTargetGroupID string                    `json:"cloud_target_group_id"`
+	Extension          *corelb.ListenerExtension `json:"extension"`
+}
+
+// GetCloudID ...
+func (l Listener) GetCloudID() string {
+	return l.CloudID
+}
+
+// ListenerListOption defines options to list load balancer listener.
+type ListenerListOption struct {
+	Region            string   `json:"region" validate:"omitempty"`
+	ResourceGroupName string   `json:"resource_group_name" validate:"omitempty"`
+	CloudLbID         string   `json:"cloud_lb_id" validate:"required"`
+	CloudIDs          []string `json:"cloud_ids" validate:"omitempty"`
+}
+
+// Validate listener list option.
+func (opt ListenerListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ListenerCreateOption defines options to create load balancer listener.
+type ListenerCreateOption struct {
+	Region            string `json:"region" validate:"omitempty"`
+	ResourceGroupName string `json:"resource_group_name" validate:"omitempty"`
+	CloudLbID         string `json:"cloud_lb_id" validate:"required"`
+	Name              string `json:"name" validate:"required"`
+	Protocol          string `json:"protocol" validate:"required"`
+	Port              int64  `json:"port" validate:"required,min=1,max=65535"`
+	// CloudTargetGroupID 为空时，aws、huawei 会创建同名的目标组
+	CloudTargetGroupID string                    `json:"cloud_target_group_id" validate:"omitempty"`
+	Extension          *corelb.ListenerExtension `json:"extension" validate:"omitempty"`
+}
+
+// Validate listener create option.
+func (opt ListenerCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ListenerDeleteOption defines options to delete load balancer listener.
+type ListenerDeleteOption struct {
+	Region            string   `json:"region" validate:"omitempty"`
+	ResourceGroupName string   `json:"resource_group_name" validate:"omitempty"`
+	CloudLbID         string   `json:"cloud_lb_id" validate:"required"`
+	CloudIDs          []string `json:"cloud_ids" validate:"required,min=1"`
+}
+
+// Validate listener delete option.
+func (opt ListenerDeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Target --------------------------
+
+// Target defines load balancer backend target of cloud.
+type Target struct {
+	CloudLbID          string `json:"cloud_lb_id"`
+	CloudListenerID    string `json:"cloud_listener_id"`
+	CloudTargetGroupID string `json:"cloud_target_group_id"`
+	InstType           string `json:"inst_type"`
+	CloudInstID        string `json:"cloud_inst_id"`
+	IP                 string `json:"ip"`
+	Port               int64  `json:"port"`
+	Weight             int64  `json:"weight"`
+}
+
+// GetCloudID ...
+func (t Target) GetCloudID() string {
+	groupID := t.CloudTargetGroupID
+	if len(groupID) == 0 {
+		groupID = t.CloudListenerID
+	}
+
+	instOrIP := t.CloudInstID
+	if len(instOrIP) == 0 {
+		instOrIP = t.IP
+	}
+
+	return TargetCloudID(groupID, instOrIP, t.Port)
+}
+
+// TargetListOption defines options to list load balancer backend target.
+type TargetListOption struct {
+	Region            string `json:"region" validate:"omitempty"`
+	ResourceGroupName string `json:"resource_group_name" validate:"omitempty"`
+	CloudLbID         string `json:"cloud_lb_id" validate:"required"`
+}
+
+// Validate target list option.
+func (opt TargetListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TargetInfo defines load balancer backend target to operate.
+type TargetInfo struct {
+	// InstType 后端类型，CVM 或 IP
+	InstType    string `json:"inst_type" validate:"required"`
+	CloudInstID string `json:"cloud_inst_id" validate:"omitempty"`
+	IP          string `json:"ip" validate:"omitempty"`
+	Port        int64  `json:"port" validate:"omitempty,min=0,max=65535"`
+	Weight      *int64 `json:"weight" validate:"omitempty"`
+}
+
+// TargetOperateOption defines options to register or deregister load balancer backend target.
+type TargetOperateOption struct {
+	Region             string       `json:"region" validate:"omitempty"`
+	ResourceGroupName  string       `json:"resource_group_name" validate:"omitempty"`
+	CloudLbID          string       `json:"cloud_lb_id" validate:"required"`
+	CloudListenerID    string       `json:"cloud_listener_id" validate:"omitempty"`
+	CloudTargetGroupID string       `json:"cloud_target_group_id" validate:"omitempty"`
+	Targets            []TargetInfo `json:"targets" validate:"required,min=1,max=100,dive"`
+}
+
+// Validate target operate option.
+func (opt TargetOperateOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudListenerID) == 0 && len(opt.CloudTargetGroupID) == 0 {
+		return errf.New(errf.InvalidParameter, "one of cloud_listener_id and cloud_target_group_id is required")
+	}
+
+	for _, one := range opt.Targets {
+		if len(one.CloudInstID) == 0 && len(one.IP) == 0 {
+			return errf.New(errf.InvalidParameter, "one of target cloud_inst_id and ip is required")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package typelb
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCreateOptionValidate(t *testing.T) {
+	tcloud := TCloudCreateOption{Region: "ap-guangzhou", Name: "lb", LBType: "OPEN"}
+	if err := tcloud.Validate(); err != nil {
+		t.Fatalf("validate tcloud create option failed, err: %v", err)
+	}
+
+	aws := AwsCreateOption{Region: "us-east-1", Name: "lb", Type: "application", CloudSubnetIDs: []string{"subnet-1"}}
+	if err := aws.Validate(); err != nil {
+		t.Fatalf("validate aws create option failed, err: %v", err)
+	}
+
+	huawei := HuaWeiCreateOption{Region: "cn-south-1", Name: "lb", Zones: []string{"cn-south-1a"}}
+	if err := huawei.Validate(); err != nil {
+		t.Fatalf("validate huawei create option failed, err: %v", err)
+	}
+
+	gcp := GcpCreateOption{Region: "us-central1", Name: "lb"}
+	if err := gcp.Validate(); err != nil {
+		t.Fatalf("validate gcp create option failed, err: %v", err)
+	}
+
+	azure := AzureCreateOption{ResourceGroupName: "rg", Region: "eastus", Name: "lb", CloudSubnetID: "subnet"}
+	if err := azure.Validate(); err != nil {
+		t.Fatalf("validate azure create option failed, err: %v", err)
+	}
+
+	invalids := map[string]interface{ Validate() error }{
+		"tcloud lb type": TCloudCreateOption{Region: "ap-guangzhou", Name: "lb", LBType: "PUBLIC"},
+		"tcloud name":    TCloudCreateOption{Region: "ap-guangzhou", Name: strings.Repeat("a", 61), LBType: "OPEN"},
+		"aws type": AwsCreateOption{Region: "us-east-1", Name: "lb", Type: "classic",
+			CloudSubnetIDs: []string{"subnet-1"}},
+		"aws subnet": AwsCreateOption{Region: "us-east-1", Name: "lb", Type: "network"},
+		"aws scheme": AwsCreateOption{Region: "us-east-1", Name: "lb", Type: "network", Scheme: "public",
+			CloudSubnetIDs: []string{"subnet-1"}},
+		"huawei zones": HuaWeiCreateOption{Region: "cn-south-1", Name: "lb"},
+		"gcp region":   GcpCreateOption{Name: "lb"},
+		"azure frontend": AzureCreateOption{ResourceGroupName: "rg", Region: "eastus", Name: "lb",
+			SkuName: "Standard"},
+		"azure sku": AzureCreateOption{ResourceGroupName: "rg", Region: "eastus", Name: "lb", SkuName: "Premium",
+			CloudPublicIPID: "ip"},
+	}
+	for name, opt := range invalids {
+		if err := opt.Validate(); err == nil {
+			t.Errorf("validate invalid create option %s should failed", name)
+		}
+	}
+}
+
+func TestTargetCloudID(t *testing.T) {
+	if cloudID := TargetCloudID("tg-1", "ins-1", 80); cloudID != "tg-1/ins-1:80" {
+		t.Fatalf("target cloud id %s is unexpected", cloudID)
+	}
+
+	target := Target{CloudListenerID: "lbl-1", IP: "10.0.0.1", Port: 8080}
+	if cloudID := target.GetCloudID(); cloudID != "lbl-1/10.0.0.1:8080" {
+		t.Errorf("target without group and instance got cloud id %s", cloudID)
+	}
+
+	target.CloudTargetGroupID = "tg-1"
+	target.CloudInstID = "ins-1"
+	if cloudID := target.GetCloudID(); cloudID != "tg-1/ins-1:8080" {
+		t.Errorf("target with group and instance got cloud id %s", cloudID)
+	}
+}
+
+func TestListenerOptionValidate(t *testing.T) {
+	create := ListenerCreateOption{CloudLbID: "lb-1", Name: "listener", Protocol: "TCP", Port: 80}
+	if err := create.Validate(); err != nil {
+		t.Fatalf("validate listener create option failed, err: %v", err)
+	}
+
+	for _, port := range []int64{0, 65536} {
+		create.Port = port
+		if err := create.Validate(); err == nil {
+			t.Errorf("validate listener create option with port %d should failed", port)
+		}
+	}
+
+	del := ListenerDeleteOption{CloudLbID: "lb-1"}
+	if err := del.Validate(); err == nil {
+		t.Errorf("validate listener delete option without cloud ids should failed")
+	}
+
+	del.CloudIDs = []string{"lbl-1"}
+	if err := del.Validate(); err != nil {
+		t.Errorf("validate listener delete option failed, err: %v", err)
+	}
+}
+
+func TestTargetOperateOptionValidate(t *testing.T) {
+	opt := TargetOperateOption{
+		CloudLbID:       "lb-1",
+		CloudListenerID: "lbl-1",
+		Targets:         []TargetInfo{{InstType: "CVM", CloudInstID: "ins-1", Port: 80}},
+	}
+	if err := opt.Validate(); err != nil {
+		t.Fatalf("validate target operate option failed, err: %v", err)
+	}
+
+	noGroup := opt
+	noGroup.CloudListenerID = ""
+	if err := noGroup.Validate(); err == nil {
+		t.Errorf("validate target operate option without listener and target group should failed")
+	}
+
+	noInst := opt
+	noInst.Targets = []TargetInfo{{InstType: "ENI", Port: 80}}
+	if err := noInst.Validate(); err == nil {
+		t.Errorf("validate target operate option without instance and ip should failed")
+	}
+
+	badPort := opt
+	badPort.Targets = []TargetInfo{{InstType: "CVM", CloudInstID: "ins-1", Port: 70000}}
+	if err := badPort.Validate(); err == nil {
+		t.Errorf("validate target operate option with invalid port should failed")
+	}
+
+	tooMany := opt
+	tooMany.Targets = make([]TargetInfo, 101)
+	for i := range tooMany.Targets {
+		tooMany.Targets[i] = TargetInfo{InstType: "CVM", CloudInstID: "ins-1", Port: 80}
+	}
+	if err := tooMany.Validate(); err == nil {
+		t.Errorf("validate target operate option with 101 targets should failed")
+	}
+}
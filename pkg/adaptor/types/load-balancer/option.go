@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package typelb
+
+import (
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+)
+
+// TCloudCreateOption defines options to create tcloud load balancer.
+type TCloudCreateOption struct {
+	Region string `json:"region" validate:"required"`
+	Name   string `json:"name" validate:"required,max=60"`
+	// LBType OPEN(公网)、INTERNAL(内网)
+	LBType           string `json:"lb_type" validate:"required,oneof=OPEN INTERNAL"`
+	AddressIPVersion string `json:"address_ip_version" validate:"omitempty"`
+	CloudVpcID       string `json:"cloud_vpc_id" validate:"omitempty"`
+	// CloudSubnetID 内网负载均衡必须指定子网
+	CloudSubnetID           string  `json:"cloud_subnet_id" validate:"omitempty"`
+	MasterZone              string  `json:"master_zone" validate:"omitempty"`
+	VipIsp                  string  `json:"vip_isp" validate:"omitempty"`
+	InternetChargeType      string  `json:"internet_charge_type" validate:"omitempty"`
+	InternetMaxBandwidthOut *int64  `json:"internet_max_bandwidth_out" validate:"omitempty"`
+	CloudProjectID          *int64  `json:"cloud_project_id" validate:"omitempty"`
+	Number                  *uint64 `json:"number" validate:"omitempty"`
+}
+
+// Validate tcloud load balancer create option.
+func (opt TCloudCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsCreateOption defines options to create aws load balancer.
+type AwsCreateOption struct {
+	Region string `json:"region" validate:"required"`
+	Name   string `json:"name" validate:"required,max=32"`
+	// Type application、network、gateway
+	Type string `json:"type" validate:"required,oneof=application network gateway"`
+	// Scheme internet-facing、internal
+	Scheme                string   `json:"scheme" validate:"omitempty,oneof=internet-facing internal"`
+	IPAddressType         string   `json:"ip_address_type" validate:"omitempty"`
+	CloudSubnetIDs        []string `json:"cloud_subnet_ids" validate:"required,min=1"`
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids" validate:"omitempty"`
+}
+
+// Validate aws load balancer create option.
+func (opt AwsCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// HuaWeiCreateOption defines options to create huawei load balancer.
+type HuaWeiCreateOption struct {
+	Region string `json:"region" validate:"required"`
+	Name   string `json:"name" validate:"required,max=255"`
+	// Zones 可用区列表，华为云独享型负载均衡必须指定
+	Zones      []string `json:"zones" validate:"required,min=1"`
+	CloudVpcID string   `json:"cloud_vpc_id" validate:"omitempty"`
+	// CloudSubnetID 负载均衡 IPv4 虚拟IP所在的子网ID(neutron_subnet_id)
+	CloudSubnetID string `json:"cloud_subnet_id" validate:"omitempty"`
+	// CloudElbVirSubnetIDs 负载均衡下发流量到后端服务器所在的子网ID(neutron_network_id)
+	CloudElbVirSubnetIDs []string `json:"cloud_elb_vir_subnet_ids" validate:"omitempty"`
+	L4FlavorID           string   `json:"l4_flavor_id" validate:"omitempty"`
+	L7FlavorID           string   `json:"l7_flavor_id" validate:"omitempty"`
+	EnterpriseProjectID  string   `json:"enterprise_project_id" validate:"omitempty"`
+}
+
+// Validate huawei load balancer create option.
+func (opt HuaWeiCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// GcpCreateOption defines options to create gcp load balancer(target pool).
+type GcpCreateOption struct {
+	Region          string `json:"region" validate:"required"`
+	Name            string `json:"name" validate:"required"`
+	SessionAffinity string `json:"session_affinity" validate:"omitempty"`
+	// HealthChecks 健康检查的 self link，目标池只支持旧版 HTTP 健康检查
+	HealthChecks []string `json:"health_checks" validate:"omitempty"`
+	Memo         *string  `json:"memo" validate:"omitempty"`
+}
+
+// Validate gcp load balancer create option.
+func (opt GcpCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AzureCreateOption defines options to create azure load balancer.
+type AzureCreateOption struct {
+	ResourceGroupName string `json:"resource_group_name" validate:"required"`
+	Region            string `json:"region" validate:"required"`
+	Name              string `json:"name" validate:"required"`
+	// SkuName Basic、Standard、Gateway
+	SkuName string `json:"sku_name" validate:"omitempty,oneof=Basic Standard Gateway"`
+	// CloudPublicIPID 公网负载均衡前端绑定的公网IP，与 CloudSubnetID 二选一
+	CloudPublicIPID string `json:"cloud_public_ip_id" validate:"omitempty"`
+	// CloudSubnetID 内网负载均衡前端所在的子网
+	CloudSubnetID string `json:"cloud_subnet_id" validate:"omitempty"`
+}
+
+// Validate azure load balancer create option.
+func (opt AzureCreateOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudPublicIPID) == 0 && len(opt.CloudSubnetID) == 0 {
+		return errf.New(errf.InvalidParameter, "one of cloud_public_ip_id and cloud_subnet_id is required")
+	}
+
+	return nil
+}
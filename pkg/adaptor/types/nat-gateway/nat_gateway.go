@@ -0,0 +1,124 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package typenat NAT网关相关的云上资源定义
+package typenat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/enumor"
+)
+
+// BaseNatGateway defines the common fields of nat gateway.
+type BaseNatGateway struct {
+	CloudID           string   `json:"cloud_id"`
+	Name              string   `json:"name"`
+	Region            string   `json:"region"`
+	Zone              string   `json:"zone"`
+	CloudVpcID        string   `json:"cloud_vpc_id"`
+	CloudSubnetID     string   `json:"cloud_subnet_id"`
+	Spec              string   `json:"spec"`
+	PublicIPAddresses []string `json:"public_ip_addresses"`
+	Status            string   `json:"status"`
+	Memo              *string  `json:"memo"`
+	CloudCreatedTime  string   `json:"cloud_created_time"`
+}
+
+// GetCloudID ...
+func (n BaseNatGateway) GetCloudID() string {
+	return n.CloudID
+}
+
+// TCloudNatGateway tcloud nat gateway.
+type TCloudNatGateway struct {
+	BaseNatGateway `json:",inline"`
+	Extension      *corenat.TCloudNatGatewayExtension `json:"extension"`
+}
+
+// AwsNatGateway aws nat gateway.
+type AwsNatGateway struct {
+	BaseNatGateway `json:",inline"`
+	Extension      *corenat.AwsNatGatewayExtension `json:"extension"`
+}
+
+// HuaWeiNatGateway huawei nat gateway.
+type HuaWeiNatGateway struct {
+	BaseNatGateway `json:",inline"`
+	Extension      *corenat.HuaWeiNatGatewayExtension `json:"extension"`
+}
+
+// AwsListResult defines aws list nat gateway result.
+type AwsListResult struct {
+	NextToken *string         `json:"next_token,omitempty"`
+	Details   []AwsNatGateway `json:"details"`
+}
+
+// -------------------------- Rule --------------------------
+
+// NatRule defines nat gateway snat/dnat rule of cloud.
+type NatRule struct {
+	CloudID           string                           `json:"cloud_id"`
+	CloudNatGatewayID string                           `json:"cloud_nat_gateway_id"`
+	RuleType          enumor.NatRuleType               `json:"rule_type"`
+	Protocol          string                           `json:"protocol"`
+	CloudSubnetID     string                           `json:"cloud_subnet_id"`
+	SourceCidr        string                           `json:"source_cidr"`
+	PublicIP          string                           `json:"public_ip"`
+	PublicPort        int64                            `json:"public_port"`
+	PrivateIP         string                           `json:"private_ip"`
+	PrivatePort       int64                            `json:"private_port"`
+	Status            string                           `json:"status"`
+	Memo              *string                          `json:"memo"`
+	Extension         *corenat.NatGatewayRuleExtension `json:"extension"`
+}
+
+// GetCloudID ...
+func (r NatRule) GetCloudID() string {
+	return r.CloudID
+}
+
+// TCloudDnatRuleCloudID 腾讯云DNAT规则没有ID，由NAT网关ID、协议、公网IP和公网端口组成唯一标识，
+// 格式为 nat-xxx/TCP/1.1.1.1:80
+func TCloudDnatRuleCloudID(cloudNatID, protocol, publicIP string, publicPort int64) string {
+	return fmt.Sprintf("%s/%s/%s:%d", cloudNatID, protocol, publicIP, publicPort)
+}
+
+// ParseTCloudDnatRuleCloudID parse tcloud dnat rule cloud id, return protocol, public ip and public port.
+func ParseTCloudDnatRuleCloudID(cloudID string) (protocol string, publicIP string, publicPort int64, err error) {
+	fields := strings.Split(cloudID, "/")
+	if len(fields) != 3 {
+		return "", "", 0, fmt.Errorf("tcloud dnat rule cloud id %s is invalid", cloudID)
+	}
+
+	idx := strings.LastIndex(fields[2], ":")
+	if idx <= 0 {
+		return "", "", 0, fmt.Errorf("tcloud dnat rule cloud id %s is invalid", cloudID)
+	}
+
+	publicPort, err = strconv.ParseInt(fields[2][idx+1:], 10, 64)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("tcloud dnat rule cloud id %s has invalid port, err: %v", cloudID, err)
+	}
+
+	return fields[1], fields[2][:idx], publicPort, nil
+}
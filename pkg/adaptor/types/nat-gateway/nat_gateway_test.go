@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package typenat
+
+import "testing"
+
+func TestTCloudDnatRuleCloudID(t *testing.T) {
+	cloudID := TCloudDnatRuleCloudID("nat-123", "TCP", "1.1.1.1", 8080)
+	if cloudID != "nat-123/TCP/1.1.1.1:8080" {
+		t.Fatalf("tcloud dnat rule cloud id %s is unexpected", cloudID)
+	}
+
+	protocol, publicIP, publicPort, err := ParseTCloudDnatRuleCloudID(cloudID)
+	if err != nil {
+		t.Fatalf("parse tcloud dnat rule cloud id failed, err: %v", err)
+	}
+
+	if protocol != "TCP" || publicIP != "1.1.1.1" || publicPort != 8080 {
+		t.Errorf("parse tcloud dnat rule cloud id got %s %s %d", protocol, publicIP, publicPort)
+	}
+
+	invalids := []string{"", "nat-123", "nat-123/TCP/1.1.1.1", "nat-123/TCP/1.1.1.1:port", "nat-123/TCP/:80"}
+	for _, one := range invalids {
+		if _, _, _, err = ParseTCloudDnatRuleCloudID(one); err == nil {
+			t.Errorf("parse invalid tcloud dnat rule cloud id %s should failed", one)
+		}
+	}
+}
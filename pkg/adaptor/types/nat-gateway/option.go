@@ -0,0 +1,203 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package typenat
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// HuaWeiListOption defines options to list huawei nat gateway, huawei nat gateway list api is not paged,
+// all nat gateways of the region are returned at once.
+type HuaWeiListOption struct {
+	Region   string   `json:"region" validate:"required"`
+	CloudIDs []string `json:"cloud_ids" validate:"omitempty"`
+}
+
+// Validate huawei nat gateway list option.
+func (opt HuaWeiListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudCreateOption defines options to create tcloud nat gateway.
+type TCloudCreateOption struct {
+	Region     string `json:"region" validate:"required"`
+	Name       string `json:"name" validate:"required,max=60"`
+	CloudVpcID string `json:"cloud_vpc_id" validate:"required"`
+	Zone       string `json:"zone" validate:"omitempty"`
+	// MaxConcurrentConnection 最大并发连接数，1000000、3000000、10000000，默认1000000
+	MaxConcurrentConnection *uint64 `json:"max_concurrent_connection" validate:"omitempty"`
+	InternetMaxBandwidthOut *uint64 `json:"internet_max_bandwidth_out" validate:"omitempty"`
+	// AddressCount 需要新申请的弹性IP个数，与 PublicIPAddresses 至少指定一个
+	AddressCount      *uint64  `json:"address_count" validate:"omitempty,min=1,max=10"`
+	PublicIPAddresses []string `json:"public_ip_addresses" validate:"omitempty,max=10"`
+}
+
+// Validate tcloud nat gateway create option.
+func (opt TCloudCreateOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if opt.AddressCount == nil && len(opt.PublicIPAddresses) == 0 {
+		return errors.New("one of address_count and public_ip_addresses is required")
+	}
+
+	return nil
+}
+
+// AwsCreateOption defines options to create aws nat gateway.
+type AwsCreateOption struct {
+	Region string `json:"region" validate:"required"`
+	// Name aws nat网关没有名称，以 Name 标签的形式设置
+	Name          string `json:"name" validate:"omitempty,max=255"`
+	CloudSubnetID string `json:"cloud_subnet_id" validate:"required"`
+	// ConnectivityType public、private，默认 public
+	ConnectivityType string `json:"connectivity_type" validate:"omitempty,oneof=public private"`
+	// CloudAllocationID 公网NAT网关必须指定弹性IP的分配ID
+	CloudAllocationID string `json:"cloud_allocation_id" validate:"omitempty"`
+}
+
+// Validate aws nat gateway create option.
+func (opt AwsCreateOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if opt.ConnectivityType != "private" && len(opt.CloudAllocationID) == 0 {
+		return errors.New("cloud_allocation_id is required for public nat gateway")
+	}
+
+	return nil
+}
+
+// HuaWeiCreateOption defines options to create huawei nat gateway.
+type HuaWeiCreateOption struct {
+	Region     string `json:"region" validate:"required"`
+	Name       string `json:"name" validate:"required,max=64"`
+	CloudVpcID string `json:"cloud_vpc_id" validate:"required"`
+	// CloudSubnetID NAT网关下行口所在的子网，对应华为云的 internal_network_id
+	CloudSubnetID string `json:"cloud_subnet_id" validate:"required"`
+	// Spec 1(小型)、2(中型)、3(大型)、4(超大型)
+	Spec                string  `json:"spec" validate:"required,oneof=1 2 3 4"`
+	Memo                *string `json:"memo" validate:"omitempty,max=255"`
+	EnterpriseProjectID string  `json:"enterprise_project_id" validate:"omitempty"`
+}
+
+// Validate huawei nat gateway create option.
+func (opt HuaWeiCreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// -------------------------- Rule --------------------------
+
+// RuleListOption defines options to list nat gateway snat/dnat rule.
+type RuleListOption struct {
+	Region            string `json:"region" validate:"required"`
+	CloudNatGatewayID string `json:"cloud_nat_gateway_id" validate:"required"`
+}
+
+// Validate rule list option.
+func (opt RuleListOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// RuleCreateOption defines options to create nat gateway snat/dnat rule.
+type RuleCreateOption struct {
+	Region            string             `json:"region" validate:"required"`
+	CloudNatGatewayID string             `json:"cloud_nat_gateway_id" validate:"required"`
+	RuleType          enumor.NatRuleType `json:"rule_type" validate:"required"`
+	// CloudSubnetID、SourceCidr SNAT规则的源，至少指定一个，tcloud 只支持子网
+	CloudSubnetID string `json:"cloud_subnet_id" validate:"omitempty"`
+	SourceCidr    string `json:"source_cidr" validate:"omitempty,cidr"`
+	// PublicIP 规则使用的公网IP地址，tcloud 使用
+	PublicIP string `json:"public_ip" validate:"omitempty,ip"`
+	// CloudEipID 规则使用的弹性公网IP的云ID，huawei 使用
+	CloudEipID  string  `json:"cloud_eip_id" validate:"omitempty"`
+	Protocol    string  `json:"protocol" validate:"omitempty"`
+	PublicPort  int64   `json:"public_port" validate:"omitempty,min=0,max=65535"`
+	PrivateIP   string  `json:"private_ip" validate:"omitempty,ip"`
+	PrivatePort int64   `json:"private_port" validate:"omitempty,min=0,max=65535"`
+	Memo        *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate rule create option.
+func (opt RuleCreateOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if err := opt.RuleType.Validate(); err != nil {
+		return err
+	}
+
+	switch opt.RuleType {
+	case enumor.SnatRuleType:
+		if len(opt.CloudSubnetID) == 0 && len(opt.SourceCidr) == 0 {
+			return errors.New("one of cloud_subnet_id and source_cidr is required for snat rule")
+		}
+	case enumor.DnatRuleType:
+		if len(opt.Protocol) == 0 || len(opt.PrivateIP) == 0 {
+			return errors.New("protocol and private_ip is required for dnat rule")
+		}
+	}
+
+	return nil
+}
+
+// RuleUpdateOption defines options to update nat gateway snat/dnat rule,
+// PrivateIP and PrivatePort is only used by dnat rule, and should be the full target value.
+type RuleUpdateOption struct {
+	Region            string             `json:"region" validate:"required"`
+	CloudNatGatewayID string             `json:"cloud_nat_gateway_id" validate:"required"`
+	RuleType          enumor.NatRuleType `json:"rule_type" validate:"required"`
+	CloudID           string             `json:"cloud_id" validate:"required"`
+	Memo              *string            `json:"memo" validate:"omitempty,max=255"`
+	PrivateIP         string             `json:"private_ip" validate:"omitempty,ip"`
+	PrivatePort       int64              `json:"private_port" validate:"omitempty,min=0,max=65535"`
+}
+
+// Validate rule update option.
+func (opt RuleUpdateOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	return opt.RuleType.Validate()
+}
+
+// RuleDeleteOption defines options to delete nat gateway snat/dnat rule.
+type RuleDeleteOption struct {
+	Region            string             `json:"region" validate:"required"`
+	CloudNatGatewayID string             `json:"cloud_nat_gateway_id" validate:"required"`
+	RuleType          enumor.NatRuleType `json:"rule_type" validate:"required"`
+	CloudIDs          []string           `json:"cloud_ids" validate:"required,min=1,max=20"`
+}
+
+// Validate rule delete option.
+func (opt RuleDeleteOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	return opt.RuleType.Validate()
+}
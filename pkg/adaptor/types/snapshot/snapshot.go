@@ -0,0 +1,150 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package typesnapshot 云盘快照相关的云上资源定义
+package typesnapshot
+
+import (
+	"hcm/pkg/adaptor/types/core"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+)
+
+// BaseSnapshot defines the common fields of cloud disk snapshot.
+type BaseSnapshot struct {
+	CloudID     string `json:"cloud_id"`
+	Name        string `json:"name"`
+	Region      string `json:"region"`
+	Zone        string `json:"zone"`
+	CloudDiskID string `json:"cloud_disk_id"`
+	// Size 源云盘大小，单位：GB
+	Size             uint64 `json:"size"`
+	Status           string `json:"status"`
+	CloudCreatedTime string `json:"cloud_created_time"`
+}
+
+// GetCloudID ...
+func (s BaseSnapshot) GetCloudID() string {
+	return s.CloudID
+}
+
+// TCloudSnapshot tcloud snapshot.
+type TCloudSnapshot struct {
+	BaseSnapshot `json:",inline"`
+	Extension    *coresnapshot.TCloudSnapshotExtension `json:"extension"`
+}
+
+// AwsSnapshot aws snapshot.
+type AwsSnapshot struct {
+	BaseSnapshot `json:",inline"`
+	Extension    *coresnapshot.AwsSnapshotExtension `json:"extension"`
+}
+
+// HuaWeiSnapshot huawei snapshot.
+type HuaWeiSnapshot struct {
+	BaseSnapshot `json:",inline"`
+	Extension    *coresnapshot.HuaWeiSnapshotExtension `json:"extension"`
+}
+
+// GcpSnapshot gcp snapshot.
+type GcpSnapshot struct {
+	BaseSnapshot `json:",inline"`
+	Extension    *coresnapshot.GcpSnapshotExtension `json:"extension"`
+}
+
+// AzureSnapshot azure snapshot.
+type AzureSnapshot struct {
+	BaseSnapshot `json:",inline"`
+	Extension    *coresnapshot.AzureSnapshotExtension `json:"extension"`
+}
+
+// AwsListResult defines aws list snapshot result.
+type AwsListResult struct {
+	NextToken *string       `json:"next_token,omitempty"`
+	Details   []AwsSnapshot `json:"details"`
+}
+
+// GcpListResult defines gcp list snapshot result.
+type GcpListResult struct {
+	NextPageToken string        `json:"next_page_token,omitempty"`
+	Details       []GcpSnapshot `json:"details"`
+}
+
+// HuaWeiListOption defines options to list huawei snapshot, huawei snapshot can only be filtered by one id.
+type HuaWeiListOption struct {
+	Region   string                    `json:"region" validate:"required"`
+	CloudIDs []string                  `json:"cloud_ids" validate:"omitempty"`
+	Page     *core.HuaWeiCvmOffsetPage `json:"page" validate:"omitempty"`
+}
+
+// Validate huawei snapshot list option.
+func (opt HuaWeiListOption) Validate() error {
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.CloudIDs) > core.HuaWeiQueryLimit {
+		return errf.Newf(errf.InvalidParameter, "huawei resource ids length should <= %d", core.HuaWeiQueryLimit)
+	}
+
+	return nil
+}
+
+// CreateOption defines options to create disk snapshot, fields required by vendors are different:
+// gcp requires zone and disk name, azure requires resource group name.
+type CreateOption struct {
+	Region            string  `json:"region" validate:"omitempty"`
+	Zone              string  `json:"zone" validate:"omitempty"`
+	ResourceGroupName string  `json:"resource_group_name" validate:"omitempty"`
+	CloudDiskID       string  `json:"cloud_disk_id" validate:"required"`
+	DiskName          string  `json:"disk_name" validate:"omitempty"`
+	Name              string  `json:"name" validate:"required,max=60"`
+	Memo              *string `json:"memo" validate:"omitempty"`
+}
+
+// Validate snapshot create option.
+func (opt CreateOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// DeleteOption defines options to delete disk snapshot, gcp and azure snapshot is deleted by name.
+type DeleteOption struct {
+	Region            string `json:"region" validate:"omitempty"`
+	ResourceGroupName string `json:"resource_group_name" validate:"omitempty"`
+	CloudID           string `json:"cloud_id" validate:"required"`
+	Name              string `json:"name" validate:"omitempty"`
+}
+
+// Validate snapshot delete option.
+func (opt DeleteOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// RollbackOption defines options to rollback disk by snapshot.
+type RollbackOption struct {
+	Region      string `json:"region" validate:"required"`
+	CloudID     string `json:"cloud_id" validate:"required"`
+	CloudDiskID string `json:"cloud_disk_id" validate:"required"`
+}
+
+// Validate snapshot rollback option.
+func (opt RollbackOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
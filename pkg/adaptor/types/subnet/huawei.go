@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"hcm/pkg/adaptor/types/core"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
@@ -150,3 +151,8 @@ type HuaWeiSubnet Subnet[HuaWeiSubnetExtension]
 func (vpc HuaWeiSubnet) GetCloudID() string {
 	return vpc.CloudID
 }
+
+// GetTags ...
+func (vpc HuaWeiSubnet) GetTags() []coretag.TagPair {
+	return vpc.Tags
+}
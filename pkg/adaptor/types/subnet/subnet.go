@@ -20,6 +20,7 @@
 package adtysubnet
 
 import (
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
 	"hcm/pkg/criteria/errf"
 )
 
@@ -73,6 +74,8 @@ type Subnet[T SubnetExtension] struct {
 	Ipv6Cidr   []string `json:"ipv6_cidr,omitempty"`
 	Memo       *string  `json:"memo,omitempty"`
 	Extension  *T       `json:"extension"`
+	// Tags 云上资源标签，仅用于同步到资源标签表，不落库到子网表
+	Tags []coretag.TagPair `json:"tags,omitempty"`
 }
 
 // SubnetExtension defines subnet extensional info.
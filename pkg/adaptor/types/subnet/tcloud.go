@@ -19,7 +19,10 @@
 
 package adtysubnet
 
-import "hcm/pkg/criteria/validator"
+import (
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
+	"hcm/pkg/criteria/validator"
+)
 
 // TCloudSubnetCreateExt defines tencent cloud create subnet extensional info.
 type TCloudSubnetCreateExt struct {
@@ -90,3 +93,8 @@ type TCloudSubnet Subnet[TCloudSubnetExtension]
 func (vpc TCloudSubnet) GetCloudID() string {
 	return vpc.CloudID
 }
+
+// GetTags ...
+func (vpc TCloudSubnet) GetTags() []coretag.TagPair {
+	return vpc.Tags
+}
@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package typetag 资源标签相关的云上操作参数定义
+package typetag
+
+import (
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+)
+
+const (
+	// MaxTagResourceLimit 单次打标签的最大资源数量
+	MaxTagResourceLimit = 100
+	// MaxTagLimit 单次打标签的最大标签数量
+	MaxTagLimit = 50
+)
+
+// SupportedTagResTypes 支持统一标签管理的资源类型
+var SupportedTagResTypes = map[enumor.CloudResourceType]struct{}{
+	enumor.CvmCloudResType:    {},
+	enumor.DiskCloudResType:   {},
+	enumor.VpcCloudResType:    {},
+	enumor.SubnetCloudResType: {},
+}
+
+// TagResItem 需要操作标签的云资源，Name、Zone 仅 gcp 需要，gcp 通过名称及可用区定位资源
+type TagResItem struct {
+	CloudID string `json:"cloud_id" validate:"required"`
+	Name    string `json:"name" validate:"omitempty"`
+	Zone    string `json:"zone" validate:"omitempty"`
+}
+
+// TagResOption 为云资源批量添加标签，标签键已存在时覆盖其值
+type TagResOption struct {
+	Region    string                   `json:"region" validate:"omitempty"`
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Resources []TagResItem             `json:"resources" validate:"required,min=1,max=100,dive"`
+	Tags      []coretag.TagPair        `json:"tags" validate:"required,min=1,max=50,dive"`
+}
+
+// Validate tag resource option.
+func (opt TagResOption) Validate() error {
+	if err := validateResType(opt.ResType); err != nil {
+		return err
+	}
+
+	return validator.Validate.Struct(opt)
+}
+
+// UntagResOption 批量删除云资源的标签
+type UntagResOption struct {
+	Region    string                   `json:"region" validate:"omitempty"`
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Resources []TagResItem             `json:"resources" validate:"required,min=1,max=100,dive"`
+	TagKeys   []string                 `json:"tag_keys" validate:"required,min=1,max=50"`
+}
+
+// Validate untag resource option.
+func (opt UntagResOption) Validate() error {
+	if err := validateResType(opt.ResType); err != nil {
+		return err
+	}
+
+	return validator.Validate.Struct(opt)
+}
+
+func validateResType(resType enumor.CloudResourceType) error {
+	if _, exists := SupportedTagResTypes[resType]; !exists {
+		return errf.Newf(errf.InvalidParameter, "resource type %s does not support tag", resType)
+	}
+
+	return nil
+}
+
+// CloudIDs return cloud ids of resources.
+func CloudIDs(items []TagResItem) []string {
+	ids := make([]string, 0, len(items))
+	for _, one := range items {
+		ids = append(ids, one.CloudID)
+	}
+
+	return ids
+}
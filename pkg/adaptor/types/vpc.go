@@ -23,6 +23,7 @@ import (
 	"hcm/pkg/adaptor/types/core"
 	"hcm/pkg/adaptor/types/subnet"
 	"hcm/pkg/api/core/cloud"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
 )
@@ -276,6 +277,8 @@ type Vpc[T VpcExtension] struct {
 	Region    string  `json:"region"`
 	Memo      *string `json:"memo,omitempty"`
 	Extension *T      `json:"extension"`
+	// Tags 云上资源标签，仅用于同步到资源标签表，不落库到vpc表
+	Tags []coretag.TagPair `json:"tags,omitempty"`
 }
 
 // AzureVpcExtension defines azure vpc extensional info.
@@ -300,6 +303,11 @@ func (vpc TCloudVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTags ...
+func (vpc TCloudVpc) GetTags() []coretag.TagPair {
+	return vpc.Tags
+}
+
 // AwsVpc defines aws vpc.
 type AwsVpc Vpc[cloud.AwsVpcExtension]
 
@@ -308,6 +316,11 @@ func (vpc AwsVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTags ...
+func (vpc AwsVpc) GetTags() []coretag.TagPair {
+	return vpc.Tags
+}
+
 // GcpVpc defines gcp vpc.
 type GcpVpc Vpc[cloud.GcpVpcExtension]
 
@@ -316,6 +329,11 @@ func (vpc GcpVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTags ...
+func (vpc GcpVpc) GetTags() []coretag.TagPair {
+	return vpc.Tags
+}
+
 // AzureVpc defines azure vpc.
 type AzureVpc Vpc[AzureVpcExtension]
 
@@ -324,6 +342,11 @@ func (vpc AzureVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTags ...
+func (vpc AzureVpc) GetTags() []coretag.TagPair {
+	return vpc.Tags
+}
+
 // HuaWeiVpc defines huawei vpc.
 type HuaWeiVpc Vpc[cloud.HuaWeiVpcExtension]
 
@@ -332,6 +355,11 @@ func (vpc HuaWeiVpc) GetCloudID() string {
 	return vpc.CloudID
 }
 
+// GetTags ...
+func (vpc HuaWeiVpc) GetTags() []coretag.TagPair {
+	return vpc.Tags
+}
+
 // VpcUsage define vpc usage.
 type VpcUsage struct {
 	ID           *string  `json:"id"`
@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+	"hcm/pkg/criteria/validator"
+)
+
+// CvmDeleteReq 删除主机申请
+type CvmDeleteReq struct {
+	BkBizID int64    `json:"bk_biz_id" validate:"required,min=1"`
+	IDs     []string `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate ...
+func (req *CvmDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// CvmRecycleReq 回收主机申请
+type CvmRecycleReq struct {
+	BkBizID int64                  `json:"bk_biz_id" validate:"required,min=1"`
+	Infos   []cscvm.CvmRecycleInfo `json:"infos" validate:"required,min=1,max=100"`
+}
+
+// Validate ...
+func (req *CvmRecycleReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// CvmIDs 申请回收的主机ID
+func (req *CvmRecycleReq) CvmIDs() []string {
+	ids := make([]string, 0, len(req.Infos))
+	for _, info := range req.Infos {
+		ids = append(ids, info.ID)
+	}
+	return ids
+}
@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// ExecuteDeliverStepReq 执行申请单交付步骤的请求，FlowID 为调用方所属的交付任务流
+type ExecuteDeliverStepReq struct {
+	FlowID string `json:"flow_id" validate:"required"`
+}
+
+// Validate ...
+func (req *ExecuteDeliverStepReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RetryDeliverReq 重新交付申请单的请求，交付步骤已经执行过的申请单可能已经创建了部分资源，需要申请人确认后才能重试
+type RetryDeliverReq struct {
+	Confirm bool `json:"confirm" validate:"omitempty"`
+}
+
+// Validate ...
+func (req *RetryDeliverReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
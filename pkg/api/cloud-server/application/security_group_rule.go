@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// TCloudSGRuleChangeReq 申请变更腾讯云安全组规则，一个申请单内可同时新增、修改和删除同一安全组的规则
+type TCloudSGRuleChangeReq struct {
+	BkBizID         int64                    `json:"bk_biz_id" validate:"required,min=1"`
+	SecurityGroupID string                   `json:"security_group_id" validate:"required"`
+	Creates         []TCloudSGRuleCreateItem `json:"creates" validate:"omitempty,max=100,dive"`
+	Updates         []TCloudSGRuleUpdateItem `json:"updates" validate:"omitempty,max=100,dive"`
+	DeleteIDs       []string                 `json:"delete_ids" validate:"omitempty,max=100"`
+}
+
+// TCloudSGRuleCreateItem 新增的安全组规则
+type TCloudSGRuleCreateItem struct {
+	Type                                enumor.SecurityGroupRuleType `json:"type" validate:"required"`
+	cloudserver.TCloudSecurityGroupRule `json:",inline"`
+}
+
+// TCloudSGRuleUpdateItem 修改的安全组规则
+type TCloudSGRuleUpdateItem struct {
+	ID                                string `json:"id" validate:"required"`
+	cloudserver.TCloudSGRuleUpdateReq `json:",inline"`
+}
+
+// Validate ...
+func (req *TCloudSGRuleChangeReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Creates) == 0 && len(req.Updates) == 0 && len(req.DeleteIDs) == 0 {
+		return errors.New("creates, updates or delete_ids at least one is required")
+	}
+
+	for _, one := range req.Creates {
+		if one.Type != enumor.Egress && one.Type != enumor.Ingress {
+			return fmt.Errorf("rule type: %s not support", one.Type)
+		}
+
+		if err := one.ValidateSGRule(); err != nil {
+			return err
+		}
+	}
+
+	// 同一条规则不能既修改又删除
+	changed := make(map[string]struct{}, len(req.Updates)+len(req.DeleteIDs))
+	for _, one := range req.Updates {
+		if _, exists := changed[one.ID]; exists {
+			return fmt.Errorf("rule: %s is repeated", one.ID)
+		}
+		changed[one.ID] = struct{}{}
+	}
+	for _, id := range req.DeleteIDs {
+		if _, exists := changed[id]; exists {
+			return fmt.Errorf("rule: %s is repeated", id)
+		}
+		changed[id] = struct{}{}
+	}
+
+	return nil
+}
+
+// RuleIDs 修改和删除的规则ID
+func (req *TCloudSGRuleChangeReq) RuleIDs() []string {
+	ids := make([]string, 0, len(req.Updates)+len(req.DeleteIDs))
+	for _, one := range req.Updates {
+		ids = append(ids, one.ID)
+	}
+	return append(ids, req.DeleteIDs...)
+}
@@ -0,0 +1,105 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package csapproval 内置审批引擎相关的 cloud-server 接口定义
+package csapproval
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// TicketListReq define approval ticket list request, list tickets of the request user by view.
+type TicketListReq struct {
+	View enumor.ApprovalTicketView `json:"view" validate:"required"`
+	Page *core.BasePage            `json:"page" validate:"required"`
+}
+
+// Validate TicketListReq.
+func (req *TicketListReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.View.Validate(); err != nil {
+		return err
+	}
+
+	return req.Page.Validate()
+}
+
+// TicketListByUserReq define approval ticket list request in itsm format, used by web-server when native approval
+// engine is chosen.
+type TicketListByUserReq struct {
+	ViewType string `json:"view_type" validate:"required"`
+	Page     int64  `json:"page" validate:"omitempty"`
+	PageSize int64  `json:"page_size" validate:"omitempty"`
+}
+
+// Validate TicketListByUserReq.
+func (req *TicketListByUserReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TicketOperateReq define approval ticket approve or reject request.
+type TicketOperateReq struct {
+	// StateID 当前审批节点的编号，从1开始，用于避免重复审批，为0时不校验
+	StateID int    `json:"state_id" validate:"omitempty,min=0"`
+	Memo    string `json:"memo" validate:"omitempty,lte=255"`
+}
+
+// Validate TicketOperateReq.
+func (req *TicketOperateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TicketTransferReq define approval ticket transfer request.
+type TicketTransferReq struct {
+	StateID    int      `json:"state_id" validate:"omitempty,min=0"`
+	Transferee []string `json:"transferee" validate:"required,min=1,max=20"`
+	Memo       string   `json:"memo" validate:"omitempty,lte=255"`
+}
+
+// Validate TicketTransferReq.
+func (req *TicketTransferReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, one := range req.Transferee {
+		if len(one) == 0 {
+			return errors.New("transferee can not be empty")
+		}
+	}
+
+	return nil
+}
+
+// TicketWithdrawReq define approval ticket withdraw request.
+type TicketWithdrawReq struct {
+	Memo string `json:"memo" validate:"omitempty,lte=255"`
+}
+
+// Validate TicketWithdrawReq.
+func (req *TicketWithdrawReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
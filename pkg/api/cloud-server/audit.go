@@ -37,3 +37,15 @@ type AuditListReq struct {
 func (req *AuditListReq) Validate() error {
 	return validator.Validate.Struct(req)
 }
+
+// ResChangeEventListReq define res change event list req.
+type ResChangeEventListReq struct {
+	// Filter 额外的过滤条件，如按 created_at 过滤时间范围
+	Filter *filter.Expression `json:"filter" validate:"omitempty"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate res change event list req.
+func (req *ResChangeEventListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
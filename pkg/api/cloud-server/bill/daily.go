@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// -------------------------- Aggregate --------------------------
+
+// BillDailyAggregateReq 账单日汇总聚合查询请求，可按照业务、账号、地域、产品、日期等维度汇总费用
+type BillDailyAggregateReq struct {
+	Filter  *filter.Expression `json:"filter" validate:"required"`
+	GroupBy []string           `json:"group_by" validate:"required,min=1,max=7"`
+	Page    *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate bill daily aggregate req.
+func (req *BillDailyAggregateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, field := range req.GroupBy {
+		if !slice.IsItemInSlice(cloud.BillDailyGroupFields, field) {
+			return fmt.Errorf("group_by field %s is not supported", field)
+		}
+	}
+
+	return req.Page.Validate(core.NewDefaultPageOption())
+}
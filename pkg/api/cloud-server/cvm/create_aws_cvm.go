@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	typecvm "hcm/pkg/adaptor/types/cvm"
+	cslease "hcm/pkg/api/cloud-server/lease"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
@@ -55,13 +56,18 @@ type AwsCvmCreateReq struct {
 		DiskCount  int64                 `json:"disk_count" validate:"required,min=1"`
 	} `json:"data_disk" validate:"omitempty"`
 
+	// KeyPairID 登录使用的密钥对，与密码二选一
+	KeyPairID string `json:"key_pair_id" validate:"omitempty"`
+
 	// Note: aws是通过执行用户脚本添加密码的，可能有特殊字符会导致脚本执行失败，而且这是无法通过DryRun测试出来的
-	Password          string `json:"password" validate:"required"`
+	Password          string `json:"password" validate:"required_without=KeyPairID"`
 	ConfirmedPassword string `json:"confirmed_password" validate:"eqfield=Password"`
 
 	RequiredCount int64 `json:"required_count" validate:"required,min=1,max=500"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
+
+	cslease.LeaseOption `json:",inline"`
 }
 
 // Validate ...
@@ -74,6 +80,10 @@ func (req *AwsCvmCreateReq) Validate(bizRequired bool) error {
 		return errors.New("biz is required")
 	}
 
+	if err := req.LeaseOption.Validate(); err != nil {
+		return err
+	}
+
 	if err := validator.ValidateCvmName(enumor.Aws, req.Name); err != nil {
 		return errf.NewFromErr(errf.InvalidParameter, err)
 	}
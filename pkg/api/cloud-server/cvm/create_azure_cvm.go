@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	typecvm "hcm/pkg/adaptor/types/cvm"
+	cslease "hcm/pkg/api/cloud-server/lease"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
@@ -81,6 +82,8 @@ type AzureCvmCreateReq struct {
 	Memo *string `json:"memo" validate:"omitempty"`
 
 	PublicIPAssigned bool `json:"public_ip_assigned" validate:"omitempty"`
+
+	cslease.LeaseOption `json:",inline"`
 }
 
 // Validate ...
@@ -93,6 +96,10 @@ func (req *AzureCvmCreateReq) Validate(bizRequired bool) error {
 		return errors.New("bk_biz_id is required")
 	}
 
+	if err := req.LeaseOption.Validate(); err != nil {
+		return err
+	}
+
 	if err := validator.ValidateCvmName(enumor.Azure, req.Name); err != nil {
 		return errf.NewFromErr(errf.InvalidParameter, err)
 	}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	typecvm "hcm/pkg/adaptor/types/cvm"
+	cslease "hcm/pkg/api/cloud-server/lease"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
@@ -55,14 +56,19 @@ type GcpCvmCreateReq struct {
 		AutoDelete *bool               `json:"auto_delete" validate:"required"`
 	} `json:"data_disk" validate:"omitempty"`
 
+	// KeyPairID 登录使用的密钥对，谷歌云的密钥对保存在项目元数据中，对项目下的主机默认生效
+	KeyPairID string `json:"key_pair_id" validate:"omitempty"`
+
 	// 访问主机的ssh公钥
-	Password string `json:"password" validate:"required"`
+	Password string `json:"password" validate:"required_without=KeyPairID"`
 
 	RequiredCount int64 `json:"required_count" validate:"required,min=1,max=500"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
 
 	PublicIPAssigned bool `json:"public_ip_assigned" validate:"omitempty"`
+
+	cslease.LeaseOption `json:",inline"`
 }
 
 // Validate ...
@@ -75,6 +81,10 @@ func (req *GcpCvmCreateReq) Validate(bizRequired bool) error {
 		return errors.New("bk_biz_id is required")
 	}
 
+	if err := req.LeaseOption.Validate(); err != nil {
+		return err
+	}
+
 	if err := validator.ValidateCvmName(enumor.Gcp, req.Name); err != nil {
 		return errf.NewFromErr(errf.InvalidParameter, err)
 	}
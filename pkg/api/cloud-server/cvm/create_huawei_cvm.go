@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	typecvm "hcm/pkg/adaptor/types/cvm"
+	cslease "hcm/pkg/api/cloud-server/lease"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
@@ -68,7 +69,10 @@ type HuaWeiCvmCreateReq struct {
 		DiskCount  int64                    `json:"disk_count" validate:"required,min=1"`
 	} `json:"data_disk" validate:"omitempty,max=23"`
 
-	Password          string `json:"password" validate:"required"`
+	// KeyPairID 登录使用的密钥对，与密码二选一
+	KeyPairID string `json:"key_pair_id" validate:"omitempty"`
+
+	Password          string `json:"password" validate:"required_without=KeyPairID"`
 	ConfirmedPassword string `json:"confirmed_password" validate:"eqfield=Password"`
 
 	InstanceChargeType typecvm.HuaWeiChargingMode `json:"instance_charge_type" validate:"required"`
@@ -78,6 +82,8 @@ type HuaWeiCvmCreateReq struct {
 	RequiredCount            int64 `json:"required_count" validate:"required,min=1,max=500"`
 
 	Memo *string `json:"memo" validate:"omitempty"`
+
+	cslease.LeaseOption `json:",inline"`
 }
 
 // Validate ...
@@ -90,6 +96,10 @@ func (req *HuaWeiCvmCreateReq) Validate(bizRequired bool) error {
 		return errors.New("bk_biz_id is required")
 	}
 
+	if err := req.LeaseOption.Validate(); err != nil {
+		return err
+	}
+
 	if req.PublicIPAssigned {
 		if err := req.Eip.Validate(); err != nil {
 			return err
@@ -128,9 +138,11 @@ func (req *HuaWeiCvmCreateReq) Validate(bizRequired bool) error {
 		)
 	}
 
-	// 校验密码是否符合要求
-	if err := req.validatePassword(); err != nil {
-		return err
+	// 校验密码是否符合要求，使用密钥对登录时可不设置密码
+	if len(req.Password) != 0 {
+		if err := req.validatePassword(); err != nil {
+			return err
+		}
 	}
 
 	return nil
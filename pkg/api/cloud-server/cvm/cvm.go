@@ -160,3 +160,30 @@ type CvmRelatedInfo struct {
 	EipCount  int      `json:"eip_count"`
 	Eip       []string `json:"eip"`
 }
+
+// CvmReachabilityReq 查询两台主机之间流量可达性的请求
+type CvmReachabilityReq struct {
+	SourceCvmID      string `json:"source_cvm_id" validate:"required"`
+	DestinationCvmID string `json:"destination_cvm_id" validate:"required"`
+	// Protocol 协议，支持 tcp、udp、icmp、icmpv6、all
+	Protocol string `json:"protocol" validate:"required,oneof=tcp udp icmp icmpv6 all"`
+	// Port 目的端口，协议为 tcp、udp 时必填
+	Port int64 `json:"port" validate:"omitempty,min=0,max=65535"`
+}
+
+// Validate ...
+func (req CvmReachabilityReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.SourceCvmID == req.DestinationCvmID {
+		return errors.New("source_cvm_id and destination_cvm_id can not be the same")
+	}
+
+	if (req.Protocol == "tcp" || req.Protocol == "udp") && req.Port <= 0 {
+		return fmt.Errorf("port is required when protocol is %s", req.Protocol)
+	}
+
+	return nil
+}
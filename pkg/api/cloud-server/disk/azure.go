@@ -22,6 +22,7 @@ package csdisk
 import (
 	"errors"
 
+	cslease "hcm/pkg/api/cloud-server/lease"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/criteria/validator"
@@ -52,6 +53,8 @@ type AzureDiskCreateReq struct {
 	DiskSize          int32   `json:"disk_size" validate:"required"`
 	DiskCount         int32   `json:"disk_count" validate:"required"`
 	Memo              *string `json:"memo" validate:"omitempty"`
+
+	cslease.LeaseOption `json:",inline"`
 }
 
 // Validate ...
@@ -64,6 +67,10 @@ func (req *AzureDiskCreateReq) Validate(bizRequired bool) error {
 		return errors.New("bk_biz_id is required")
 	}
 
+	if err := req.LeaseOption.Validate(); err != nil {
+		return err
+	}
+
 	if req.DiskCount > constant.BatchOperationMaxLimit {
 		return errors.New("disk count should <= 100")
 	}
@@ -22,6 +22,7 @@ package csdisk
 import (
 	"errors"
 
+	cslease "hcm/pkg/api/cloud-server/lease"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/validator"
 )
@@ -48,6 +49,8 @@ type GcpDiskCreateReq struct {
 	DiskSize  int32   `json:"disk_size" validate:"required"`
 	DiskCount int32   `json:"disk_count" validate:"required"`
 	Memo      *string `json:"memo" validate:"omitempty"`
+
+	cslease.LeaseOption `json:",inline"`
 }
 
 // Validate ...
@@ -60,5 +63,9 @@ func (req *GcpDiskCreateReq) Validate(bizRequired bool) error {
 		return errors.New("bk_biz_id is required")
 	}
 
+	if err := req.LeaseOption.Validate(); err != nil {
+		return err
+	}
+
 	return validator.Validate.Struct(req)
 }
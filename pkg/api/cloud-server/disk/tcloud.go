@@ -22,6 +22,7 @@ package csdisk
 import (
 	"errors"
 
+	cslease "hcm/pkg/api/cloud-server/lease"
 	hcproto "hcm/pkg/api/hc-service/disk"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/validator"
@@ -40,6 +41,8 @@ type TCloudDiskCreateReq struct {
 	DiskChargeType    string                           `json:"disk_charge_type" validate:"required"`
 	DiskChargePrepaid *hcproto.TCloudDiskChargePrepaid `json:"disk_charge_prepaid" validate:"omitempty"`
 	Memo              *string                          `json:"memo" validate:"omitempty"`
+
+	cslease.LeaseOption `json:",inline"`
 }
 
 // Validate ...
@@ -52,5 +55,9 @@ func (req *TCloudDiskCreateReq) Validate(bizRequired bool) error {
 		return errors.New("bk_biz_id is required")
 	}
 
+	if err := req.LeaseOption.Validate(); err != nil {
+		return err
+	}
+
 	return validator.Validate.Struct(req)
 }
@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package csdrift 资源配置漂移检测相关的 cloud-server 接口定义
+package csdrift
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// CheckReq 立即对指定资源期望状态进行漂移检测的请求
+type CheckReq struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate CheckReq.
+func (req *CheckReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ReconcileReq 将发生漂移的资源恢复为期望状态的请求
+type ReconcileReq struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate ReconcileReq.
+func (req *ReconcileReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ReconcileResult 恢复期望状态的结果，没有需要恢复的资源时不创建任务流，flow_id为空
+type ReconcileResult struct {
+	FlowID  string          `json:"flow_id"`
+	Skipped []ReconcileSkip `json:"skipped"`
+}
+
+// ReconcileSkip 不支持恢复的期望状态及原因
+type ReconcileSkip struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
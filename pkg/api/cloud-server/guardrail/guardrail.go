@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package csguardrail 资源创建管控规则相关的 cloud-server 接口定义
+package csguardrail
+
+import (
+	"encoding/json"
+	"errors"
+
+	coreguardrail "hcm/pkg/api/core/guardrail"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/tidwall/gjson"
+)
+
+// EvaluateReq 规则校验试运行请求，content为直接创建资源或资源创建申请单中对应云厂商的请求内容
+type EvaluateReq struct {
+	BkBizID int64                    `json:"bk_biz_id" validate:"required"`
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Vendor  enumor.Vendor            `json:"vendor" validate:"required"`
+	Content json.RawMessage          `json:"content" validate:"required"`
+}
+
+// Validate EvaluateReq.
+func (req *EvaluateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.BkBizID <= 0 && req.BkBizID != constant.UnassignedBiz {
+		return errors.New("bk_biz_id should be greater than 0 or -1")
+	}
+
+	if err := coreguardrail.ValidateResType(req.ResType); err != nil {
+		return err
+	}
+
+	if err := req.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	if !gjson.ValidBytes(req.Content) || !gjson.ParseBytes(req.Content).IsObject() {
+		return errors.New("content should be a json object")
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package csipam IP地址管理相关的 cloud-server 接口定义
+package csipam
+
+import (
+	"errors"
+
+	coreipam "hcm/pkg/api/core/ipam"
+	"hcm/pkg/criteria/validator"
+)
+
+// PoolCreateReq 创建地址池请求，未指定业务、地域时地址池对全部业务、地域生效
+type PoolCreateReq struct {
+	Name          string  `json:"name" validate:"required,max=255"`
+	Cidr          string  `json:"cidr" validate:"required,cidrv4"`
+	Region        string  `json:"region" validate:"omitempty,max=64"`
+	BkBizID       int64   `json:"bk_biz_id" validate:"omitempty"`
+	VpcMaskLen    uint    `json:"vpc_mask_len" validate:"required"`
+	SubnetMaskLen uint    `json:"subnet_mask_len" validate:"required"`
+	Memo          *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate PoolCreateReq.
+func (req *PoolCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return coreipam.ValidatePool(req.Cidr, req.VpcMaskLen, req.SubnetMaskLen)
+}
+
+// PoolUpdateReq 更新地址池请求，地址池网段以及生效的业务、地域不允许修改
+type PoolUpdateReq struct {
+	Name          string  `json:"name" validate:"omitempty,max=255"`
+	VpcMaskLen    uint    `json:"vpc_mask_len" validate:"omitempty,max=32"`
+	SubnetMaskLen uint    `json:"subnet_mask_len" validate:"omitempty,max=32"`
+	Memo          *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate PoolUpdateReq.
+func (req *PoolUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && req.VpcMaskLen == 0 && req.SubnetMaskLen == 0 && req.Memo == nil {
+		return errors.New("not found update field")
+	}
+
+	return nil
+}
+
+// AllocateReq 建议vpc网段请求，掩码长度未指定时使用地址池的默认值
+type AllocateReq struct {
+	BkBizID       int64  `json:"bk_biz_id" validate:"omitempty"`
+	Region        string `json:"region" validate:"required,max=64"`
+	VpcMaskLen    uint   `json:"vpc_mask_len" validate:"omitempty,max=32"`
+	SubnetMaskLen uint   `json:"subnet_mask_len" validate:"omitempty,max=32"`
+}
+
+// Validate AllocateReq.
+func (req *AllocateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.VpcMaskLen != 0 && req.SubnetMaskLen != 0 && req.SubnetMaskLen < req.VpcMaskLen {
+		return errors.New("subnet_mask_len should not be shorter than vpc_mask_len")
+	}
+
+	return nil
+}
+
+// SubnetSuggestReq 建议子网网段请求
+type SubnetSuggestReq struct {
+	MaskLen uint `json:"mask_len" validate:"required,min=1,max=32"`
+}
+
+// Validate SubnetSuggestReq.
+func (req *SubnetSuggestReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cskeypair ...
+package cskeypair
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// KeyPairCreateReq create key pair request, the key pair is generated by hcm.
+type KeyPairCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	// Region 腾讯云、谷歌云的密钥对与地域无关，可不指定
+	Region string  `json:"region" validate:"omitempty"`
+	Name   string  `json:"name" validate:"required,max=64"`
+	Memo   *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate KeyPairCreateReq.
+func (req *KeyPairCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// AssignKeyPairToBizReq assign key pair to biz request.
+type AssignKeyPairToBizReq struct {
+	KeyPairIDs []string `json:"key_pair_ids" validate:"required,min=1,max=100"`
+	BkBizID    int64    `json:"bk_biz_id" validate:"required,min=1"`
+}
+
+// Validate AssignKeyPairToBizReq.
+func (req *AssignKeyPairToBizReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// PrivateKeyResult key pair private key result.
+type PrivateKeyResult struct {
+	ID         string `json:"id"`
+	PrivateKey string `json:"private_key"`
+}
@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cslease 资源租约相关的 cloud-server 接口定义
+package cslease
+
+import (
+	"errors"
+	"time"
+
+	corelease "hcm/pkg/api/core/lease"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// SetReq 为主机或硬盘设置租约，资源已有租约时覆盖负责人和到期时间并重新生效
+type SetReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	ResIDs  []string                 `json:"res_ids" validate:"required,min=1,max=100"`
+	// Owner 资源负责人，为空时为当前用户
+	Owner    string    `json:"owner" validate:"omitempty,max=64"`
+	ExpireAt time.Time `json:"expire_at" validate:"required"`
+	Memo     *string   `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SetReq.
+func (req *SetReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := corelease.ValidateResType(req.ResType); err != nil {
+		return err
+	}
+
+	if !req.ExpireAt.After(time.Now()) {
+		return errors.New("expire_at should be later than now")
+	}
+
+	return nil
+}
+
+// RenewReq 一键续期请求，未指定天数时使用配置的默认续期天数
+type RenewReq struct {
+	IDs  []string `json:"ids" validate:"required,min=1,max=100"`
+	Days uint64   `json:"days" validate:"omitempty,max=365"`
+}
+
+// Validate RenewReq.
+func (req *RenewReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// LeaseOption 申请主机或硬盘时设置的租约，设置了到期时间时在资源交付成功后创建租约
+type LeaseOption struct {
+	// Owner 资源负责人，为空时为申请人
+	Owner    string     `json:"owner,omitempty" validate:"omitempty,max=64"`
+	ExpireAt *time.Time `json:"expire_at,omitempty" validate:"omitempty"`
+}
+
+// Validate LeaseOption.
+func (opt LeaseOption) Validate() error {
+	if opt.ExpireAt == nil {
+		if len(opt.Owner) != 0 {
+			return errors.New("expire_at is required when owner is set")
+		}
+		return nil
+	}
+
+	if !opt.ExpireAt.After(time.Now()) {
+		return errors.New("expire_at should be later than now")
+	}
+
+	return nil
+}
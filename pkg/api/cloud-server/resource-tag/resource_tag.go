@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cstag ...
+package cstag
+
+import (
+	typetag "hcm/pkg/adaptor/types/tag"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+)
+
+// BatchAddResTagReq batch add tags to resources request.
+type BatchAddResTagReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs     []string                 `json:"ids" validate:"required,min=1,max=100"`
+	Tags    []coretag.TagPair        `json:"tags" validate:"required,min=1,max=50,dive"`
+}
+
+// Validate batch add tags to resources request.
+func (req *BatchAddResTagReq) Validate() error {
+	if err := validateTagResType(req.ResType); err != nil {
+		return err
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// BatchRemoveResTagReq batch remove tags from resources request.
+type BatchRemoveResTagReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs     []string                 `json:"ids" validate:"required,min=1,max=100"`
+	TagKeys []string                 `json:"tag_keys" validate:"required,min=1,max=50"`
+}
+
+// Validate batch remove tags from resources request.
+func (req *BatchRemoveResTagReq) Validate() error {
+	if err := validateTagResType(req.ResType); err != nil {
+		return err
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+func validateTagResType(resType enumor.CloudResourceType) error {
+	if _, exists := typetag.SupportedTagResTypes[resType]; !exists {
+		return errf.Newf(errf.InvalidParameter, "resource type %s does not support tag", resType)
+	}
+
+	return nil
+}
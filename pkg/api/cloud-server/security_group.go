@@ -191,3 +191,16 @@ type SecurityGroup[Extension cloud.SecurityGroupExtension] struct {
 	SubnetCount             uint64     `json:"subnet_count"`
 	Extension               *Extension `json:"extension"`
 }
+
+// -------------------------- Exposure --------------------------
+
+// SecurityGroupExposureAnalyzeReq security group exposure analyze request.
+type SecurityGroupExposureAnalyzeReq struct {
+	// SecurityGroupIDs 为空时分析业务下全部的安全组及gcp防火墙规则
+	SecurityGroupIDs []string `json:"security_group_ids" validate:"omitempty,max=500"`
+}
+
+// Validate security group exposure analyze request.
+func (req *SecurityGroupExposureAnalyzeReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,131 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cssnapshot ...
+package cssnapshot
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/cron"
+)
+
+// SnapshotCreateReq create snapshot request.
+type SnapshotCreateReq struct {
+	DiskID string  `json:"disk_id" validate:"required"`
+	Name   string  `json:"name" validate:"required,max=60"`
+	Memo   *string `json:"memo" validate:"omitempty"`
+}
+
+// Validate SnapshotCreateReq.
+func (req *SnapshotCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// PolicyCreateReq create snapshot policy request.
+type PolicyCreateReq struct {
+	Name           string   `json:"name" validate:"required,max=255"`
+	DiskIDs        []string `json:"disk_ids" validate:"required,min=1,max=100"`
+	Cron           string   `json:"cron" validate:"required,max=64"`
+	RetentionCount uint     `json:"retention_count" validate:"required,min=1,max=100"`
+	Memo           *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate PolicyCreateReq.
+func (req *PolicyCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if _, err := cron.Parse(req.Cron); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PolicyUpdateReq update snapshot policy request.
+type PolicyUpdateReq struct {
+	Name           string                     `json:"name" validate:"omitempty,max=255"`
+	DiskIDs        []string                   `json:"disk_ids" validate:"omitempty,max=100"`
+	Cron           string                     `json:"cron" validate:"omitempty,max=64"`
+	RetentionCount uint                       `json:"retention_count" validate:"omitempty,max=100"`
+	State          enumor.SnapshotPolicyState `json:"state" validate:"omitempty"`
+	Memo           *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate PolicyUpdateReq.
+func (req *PolicyUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && len(req.DiskIDs) == 0 && len(req.Cron) == 0 && req.RetentionCount == 0 &&
+		len(req.State) == 0 && req.Memo == nil {
+		return errors.New("not found update field")
+	}
+
+	if len(req.State) != 0 {
+		if err := req.State.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(req.Cron) != 0 {
+		if _, err := cron.Parse(req.Cron); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CoverageReq list biz disk snapshot coverage request.
+type CoverageReq struct {
+	// Days 快照在最近多少天内创建才算作已备份，默认7天
+	Days uint `json:"days" validate:"omitempty,max=365"`
+}
+
+// Validate CoverageReq.
+func (req *CoverageReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// CoverageResult biz disk snapshot coverage result.
+type CoverageResult struct {
+	Total     uint           `json:"total"`
+	Covered   uint           `json:"covered"`
+	Uncovered uint           `json:"uncovered"`
+	Details   []DiskCoverage `json:"details"`
+}
+
+// DiskCoverage snapshot coverage of one disk.
+type DiskCoverage struct {
+	DiskID         string        `json:"disk_id"`
+	CloudDiskID    string        `json:"cloud_disk_id"`
+	DiskName       string        `json:"disk_name"`
+	Vendor         enumor.Vendor `json:"vendor"`
+	AccountID      string        `json:"account_id"`
+	Covered        bool          `json:"covered"`
+	SnapshotCount  uint          `json:"snapshot_count"`
+	LatestSnapshot string        `json:"latest_snapshot"`
+	PolicyIDs      []string      `json:"policy_ids"`
+}
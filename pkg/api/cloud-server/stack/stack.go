@@ -0,0 +1,85 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package csstack 资源栈相关的 cloud-server 接口定义
+package csstack
+
+import (
+	coredrift "hcm/pkg/api/core/drift"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// CreateReq 创建资源栈的请求，模版内容保存为资源栈的第一个版本，云厂商与账号一致
+type CreateReq struct {
+	Name      string                     `json:"name" validate:"required,lte=255"`
+	AccountID string                     `json:"account_id" validate:"required"`
+	Region    string                     `json:"region" validate:"required"`
+	BkBizID   int64                      `json:"bk_biz_id" validate:"required,min=1"`
+	Format    enumor.StackTemplateFormat `json:"format" validate:"required,oneof=yaml json"`
+	Content   string                     `json:"content" validate:"required"`
+	Memo      *string                    `json:"memo" validate:"omitempty,lte=255"`
+}
+
+// Validate CreateReq.
+func (req *CreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// VersionCreateReq 创建资源栈模版新版本的请求
+type VersionCreateReq struct {
+	Format  enumor.StackTemplateFormat `json:"format" validate:"required,oneof=yaml json"`
+	Content string                     `json:"content" validate:"required"`
+}
+
+// Validate VersionCreateReq.
+func (req *VersionCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// PlanReq 对比模版与资源栈已创建资源的请求，version为空时使用最新版本，apply使用同样的请求
+type PlanReq struct {
+	Version uint `json:"version" validate:"omitempty"`
+}
+
+// Validate PlanReq.
+func (req *PlanReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// PlanResult 资源栈plan结果
+type PlanResult struct {
+	Version uint       `json:"version"`
+	Items   []PlanItem `json:"items"`
+}
+
+// PlanItem 资源栈中单个资源的变更，ids为已创建的资源ID，diffs为已创建资源与模版不一致的属性
+type PlanItem struct {
+	ResType enumor.CloudResourceType `json:"res_type"`
+	Name    string                   `json:"name"`
+	Action  enumor.StackPlanAction   `json:"action"`
+	IDs     []string                 `json:"ids"`
+	Diffs   []coredrift.FieldDiff    `json:"diffs"`
+}
+
+// ApplyResult apply、destroy的结果，没有需要变更的资源时不创建任务流，flow_id为空，destroy没有plan结果
+type ApplyResult struct {
+	FlowID string      `json:"flow_id"`
+	Plan   *PlanResult `json:"plan,omitempty"`
+}
@@ -0,0 +1,134 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package coreapproval 内置审批引擎相关的核心结构体
+package coreapproval
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Workflow 审批流配置，按申请单类型和业务配置多级审批节点，业务为-1表示该申请单类型的默认审批流
+type Workflow struct {
+	ID              string                 `json:"id"`
+	ApplicationType enumor.ApplicationType `json:"application_type"`
+	BkBizID         int64                  `json:"bk_biz_id"`
+	Stages          []WorkflowStage        `json:"stages"`
+	Memo            *string                `json:"memo"`
+	core.Revision   `json:",inline"`
+}
+
+// WorkflowStage 审批节点，审批人为配置的审批人与变量引用的审批人的并集，节点内任意一个审批人通过即进入下个节点
+type WorkflowStage struct {
+	Name      string   `json:"name" validate:"required,lte=64"`
+	Approvers []string `json:"approvers" validate:"omitempty"`
+	// Variable 引用申请单提供的审批人变量，如 platform_manager、account_manager
+	Variable string `json:"variable" validate:"omitempty"`
+}
+
+// ValidateStages validate workflow stages.
+func ValidateStages(stages []WorkflowStage) error {
+	if len(stages) == 0 {
+		return errors.New("stages is required")
+	}
+
+	for idx, stage := range stages {
+		if len(stage.Name) == 0 {
+			return fmt.Errorf("stages[%d].name is required", idx)
+		}
+
+		if len(stage.Approvers) == 0 && len(stage.Variable) == 0 {
+			return fmt.Errorf("stages[%d] approvers or variable is required", idx)
+		}
+	}
+
+	return nil
+}
+
+// Ticket 内置审批引擎的审批单据，ID即为申请单的单据号
+type Ticket struct {
+	ID              string                      `json:"id"`
+	ApplicationType enumor.ApplicationType      `json:"application_type"`
+	BkBizID         int64                       `json:"bk_biz_id"`
+	Title           string                      `json:"title"`
+	Content         string                      `json:"content"`
+	Status          enumor.ApprovalTicketStatus `json:"status"`
+	// CurrentStage 当前审批节点在Stages中的下标
+	CurrentStage uint          `json:"current_stage"`
+	Stages       []TicketStage `json:"stages"`
+	// CurrentApprovers 当前审批节点的审批人，单据结束后为空
+	CurrentApprovers []string `json:"current_approvers"`
+	core.Revision    `json:",inline"`
+}
+
+// TicketStage 审批单据上的审批节点，Records为该节点上的全部操作记录
+type TicketStage struct {
+	Name      string         `json:"name"`
+	Approvers []string       `json:"approvers"`
+	Records   []TicketRecord `json:"records"`
+}
+
+// TicketRecord 审批操作记录
+type TicketRecord struct {
+	Operator string                `json:"operator"`
+	Action   enumor.ApprovalAction `json:"action"`
+	// Transferee 转审时的新审批人
+	Transferee []string `json:"transferee,omitempty"`
+	Remark     string   `json:"remark"`
+	OperatedAt string   `json:"operated_at"`
+}
+
+// Policy 业务审批策略，ApplicationTypes中的操作需要提交申请单审批通过后执行，业务为-1表示默认策略
+type Policy struct {
+	ID               string                   `json:"id"`
+	BkBizID          int64                    `json:"bk_biz_id"`
+	ApplicationTypes []enumor.ApplicationType `json:"application_types"`
+	Memo             *string                  `json:"memo"`
+	core.Revision    `json:",inline"`
+}
+
+// NeedApproval 判断该操作是否需要审批
+func (p *Policy) NeedApproval(appType enumor.ApplicationType) bool {
+	for _, one := range p.ApplicationTypes {
+		if one == appType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidatePolicyTypes validate policy application types.
+func ValidatePolicyTypes(appTypes []enumor.ApplicationType) error {
+	if len(appTypes) == 0 {
+		return errors.New("application_types is required")
+	}
+
+	for _, one := range appTypes {
+		if err := one.ValidatePolicyType(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
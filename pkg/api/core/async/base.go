@@ -40,16 +40,76 @@ type AsyncFlow struct {
 
 // AsyncFlowTask ...
 type AsyncFlowTask struct {
-	ID            string             `json:"id"`
-	FlowID        string             `json:"flow_id"`
-	FlowName      enumor.FlowName    `json:"flow_name"`
-	ActionID      string             `json:"action_id"`
-	ActionName    enumor.ActionName  `json:"action_name"`
-	Params        types.JsonField    `json:"params"`
-	Result        types.JsonField    `json:"result"`
-	Retry         *tableasync.Retry  `json:"retry"`
-	DependOn      types.StringArray  `json:"depend_on"`
-	State         enumor.TaskState   `json:"state"`
-	Reason        *tableasync.Reason `json:"reason"`
+	ID            string                    `json:"id"`
+	FlowID        string                    `json:"flow_id"`
+	FlowName      enumor.FlowName           `json:"flow_name"`
+	ActionID      string                    `json:"action_id"`
+	ActionName    enumor.ActionName         `json:"action_name"`
+	Params        types.JsonField           `json:"params"`
+	Result        types.JsonField           `json:"result"`
+	Retry         *tableasync.Retry         `json:"retry"`
+	DependOn      types.StringArray         `json:"depend_on"`
+	RunCondition  *tableasync.TaskCondition `json:"run_condition"`
+	OnFailure     *bool                     `json:"on_failure"`
+	RateLimitKey  *tableasync.RateLimitKey  `json:"rate_limit_key"`
+	State         enumor.TaskState          `json:"state"`
+	Reason        *tableasync.Reason        `json:"reason"`
 	core.Revision `json:",inline"`
 }
+
+// AsyncFlowSchedule ...
+type AsyncFlowSchedule struct {
+	ID            string                   `json:"id"`
+	Name          string                   `json:"name"`
+	FlowName      enumor.FlowName          `json:"flow_name"`
+	Memo          string                   `json:"memo"`
+	Tasks         tableasync.ScheduleTasks `json:"tasks"`
+	Cron          string                   `json:"cron"`
+	NextRunAt     string                   `json:"next_run_at"`
+	State         enumor.FlowScheduleState `json:"state"`
+	Reason        *tableasync.Reason       `json:"reason"`
+	LastFlowID    string                   `json:"last_flow_id"`
+	core.Revision `json:",inline"`
+}
+
+// AsyncFlowEvent ...
+type AsyncFlowEvent struct {
+	ID        string                `json:"id"`
+	Type      enumor.AsyncEventType `json:"type"`
+	FlowID    string                `json:"flow_id"`
+	FlowName  enumor.FlowName       `json:"flow_name"`
+	TaskID    string                `json:"task_id"`
+	ActionID  string                `json:"action_id"`
+	Source    string                `json:"source"`
+	Target    string                `json:"target"`
+	Reason    *tableasync.Reason    `json:"reason"`
+	Creator   string                `json:"creator"`
+	CreatedAt string                `json:"created_at"`
+}
+
+// AsyncFlowWebhook 任务流事件回调，回调密钥不对外展示
+type AsyncFlowWebhook struct {
+	ID            string                  `json:"id"`
+	Name          string                  `json:"name"`
+	URL           string                  `json:"url"`
+	FlowNames     types.StringArray       `json:"flow_names"`
+	EventTypes    types.StringArray       `json:"event_types"`
+	State         enumor.FlowWebhookState `json:"state"`
+	LastEventID   string                  `json:"last_event_id"`
+	Reason        *tableasync.Reason      `json:"reason"`
+	Memo          string                  `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// FlowWebhookPayload 任务流事件回调的请求体，Events 按事件产生的顺序排列
+type FlowWebhookPayload struct {
+	WebhookID string           `json:"webhook_id"`
+	Events    []AsyncFlowEvent `json:"events"`
+}
+
+const (
+	// FlowWebhookIDHeader 任务流事件回调请求头，值为回调ID
+	FlowWebhookIDHeader = "X-Hcm-Webhook-Id"
+	// FlowWebhookSignatureHeader 任务流事件回调请求头，值为使用回调密钥对请求体进行HMAC-SHA256签名后的十六进制字符串
+	FlowWebhookSignatureHeader = "X-Hcm-Webhook-Signature"
+)
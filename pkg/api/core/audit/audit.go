@@ -46,3 +46,28 @@ type Audit struct {
 	Detail               interface{}              `json:"detail,omitempty"` // Detail list接口该字段默认不返回
 	CreatedAt            string                   `json:"created_at"`
 }
+
+// ResChangeEvent define resource change event.
+type ResChangeEvent struct {
+	ID         uint64                   `json:"id"`
+	ResType    enumor.AuditResourceType `json:"res_type"`
+	ResID      string                   `json:"res_id"`
+	CloudResID string                   `json:"cloud_res_id"`
+	ResName    string                   `json:"res_name"`
+	Action     enumor.AuditAction       `json:"action"`
+	BkBizID    int64                    `json:"bk_biz_id"`
+	Vendor     enumor.Vendor            `json:"vendor"`
+	AccountID  string                   `json:"account_id"`
+	Operator   string                   `json:"operator"`
+	Source     enumor.RequestSourceType `json:"source"`
+	Rid        string                   `json:"rid"`
+	Changes    []FieldChange            `json:"changes"`
+	CreatedAt  string                   `json:"created_at"`
+}
+
+// FieldChange define resource attribute change.
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
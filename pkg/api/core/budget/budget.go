@@ -0,0 +1,135 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package corebudget 预算相关的核心结构体
+package corebudget
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// DefaultThresholds 默认的预算告警阈值，单位为预算金额的百分比
+var DefaultThresholds = []int64{50, 80, 100}
+
+// Budget 预算
+type Budget struct {
+	ID            string             `json:"id"`
+	Name          string             `json:"name"`
+	Scope         enumor.BudgetScope `json:"scope"`
+	BkBizID       int64              `json:"bk_biz_id"`
+	AccountID     string             `json:"account_id"`
+	Currency      string             `json:"currency"`
+	Amount        string             `json:"amount"`
+	Thresholds    []int64            `json:"thresholds"`
+	Receivers     []string           `json:"receivers"`
+	Memo          *string            `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// BudgetAlert 预算告警记录
+type BudgetAlert struct {
+	ID            string                  `json:"id"`
+	BudgetID      string                  `json:"budget_id"`
+	Month         string                  `json:"month"`
+	Threshold     uint                    `json:"threshold"`
+	Amount        string                  `json:"amount"`
+	Spent         string                  `json:"spent"`
+	Forecast      string                  `json:"forecast"`
+	State         enumor.BudgetAlertState `json:"state"`
+	Message       string                  `json:"message"`
+	core.Revision `json:",inline"`
+}
+
+// BudgetStatus 预算当月的执行情况
+type BudgetStatus struct {
+	BudgetID string `json:"budget_id"`
+	Month    string `json:"month"`
+	Currency string `json:"currency"`
+	Amount   string `json:"amount"`
+	// Spent 当月已产生的费用
+	Spent string `json:"spent"`
+	// Forecast 按照当月已出账天数的日均费用预测的月底费用
+	Forecast string `json:"forecast"`
+	// UsedPercent 已产生费用占预算金额的百分比
+	UsedPercent float64 `json:"used_percent"`
+	// ReachedThresholds 当月已达到的告警阈值
+	ReachedThresholds []int64 `json:"reached_thresholds"`
+}
+
+// ValidateScope 按业务设置的预算需要指定业务，按账号设置的预算需要指定账号
+func ValidateScope(scope enumor.BudgetScope, bkBizID int64, accountID string) error {
+	if err := scope.Validate(); err != nil {
+		return err
+	}
+
+	switch scope {
+	case enumor.BizBudgetScope:
+		if bkBizID <= 0 {
+			return errors.New("bk_biz_id is required when scope is biz")
+		}
+		if len(accountID) != 0 {
+			return errors.New("account_id should be empty when scope is biz")
+		}
+	case enumor.AccountBudgetScope:
+		if len(accountID) == 0 {
+			return errors.New("account_id is required when scope is account")
+		}
+		if bkBizID > 0 {
+			return errors.New("bk_biz_id should be empty when scope is account")
+		}
+	}
+
+	return nil
+}
+
+// ValidateAmount 预算金额必须是大于0的数字
+func ValidateAmount(amount string) error {
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return fmt.Errorf("amount %s is invalid, err: %v", amount, err)
+	}
+
+	if value <= 0 {
+		return errors.New("amount should > 0")
+	}
+
+	return nil
+}
+
+// ValidateThresholds 告警阈值范围为1~1000，且不能重复
+func ValidateThresholds(thresholds []int64) error {
+	exists := make(map[int64]struct{}, len(thresholds))
+	for _, one := range thresholds {
+		if one < 1 || one > 1000 {
+			return fmt.Errorf("threshold %d should be in range [1, 1000]", one)
+		}
+
+		if _, ok := exists[one]; ok {
+			return fmt.Errorf("threshold %d is duplicated", one)
+		}
+		exists[one] = struct{}{}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// BillDailyCost 归一化后的单日账单费用，由云厂商账单明细按照地域、产品、币种聚合得到
+type BillDailyCost struct {
+	Region    string `json:"region" validate:"max=255"`
+	Product   string `json:"product" validate:"max=255"`
+	Currency  string `json:"currency" validate:"max=16"`
+	Cost      string `json:"cost" validate:"required"`
+	ItemCount int64  `json:"item_count"`
+}
+
+// BillDailyItem 账单日汇总
+type BillDailyItem struct {
+	ID             string        `json:"id"`
+	Vendor         enumor.Vendor `json:"vendor"`
+	AccountID      string        `json:"account_id"`
+	BkBizID        int64         `json:"bk_biz_id"`
+	BillDate       string        `json:"bill_date"`
+	Region         string        `json:"region"`
+	Product        string        `json:"product"`
+	Currency       string        `json:"currency"`
+	Cost           string        `json:"cost"`
+	ItemCount      int64         `json:"item_count"`
+	*core.Revision `json:",inline"`
+}
+
+// BillSyncRecord 账单拉取记录
+type BillSyncRecord struct {
+	ID             string            `json:"id"`
+	Vendor         enumor.Vendor     `json:"vendor"`
+	AccountID      string            `json:"account_id"`
+	BillDate       string            `json:"bill_date"`
+	State          enumor.SyncStatus `json:"state"`
+	ItemCount      int64             `json:"item_count"`
+	Cost           string            `json:"cost"`
+	Message        string            `json:"message"`
+	*core.Revision `json:",inline"`
+}
+
+// BillDailyGroupFields 账单日汇总支持的聚合维度，币种不同的费用不能相加，所以聚合时总是会按照币种分组
+var BillDailyGroupFields = []string{"vendor", "account_id", "bk_biz_id", "bill_date", "region", "product",
+	"currency"}
+
+// BillDailyAggregate 账单日汇总聚合结果，未参与聚合的维度字段为空
+type BillDailyAggregate struct {
+	Vendor    enumor.Vendor `db:"vendor" json:"vendor,omitempty"`
+	AccountID string        `db:"account_id" json:"account_id,omitempty"`
+	BkBizID   *int64        `db:"bk_biz_id" json:"bk_biz_id,omitempty"`
+	BillDate  string        `db:"bill_date" json:"bill_date,omitempty"`
+	Region    string        `db:"region" json:"region,omitempty"`
+	Product   string        `db:"product" json:"product,omitempty"`
+	Currency  string        `db:"currency" json:"currency"`
+	Cost      string        `db:"cost" json:"cost"`
+	ItemCount int64         `db:"item_count" json:"item_count"`
+}
@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package corekeypair 密钥对相关的核心数据结构
+package corekeypair
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// BaseKeyPair define base key pair, private key is not returned.
+type BaseKeyPair struct {
+	ID        string        `json:"id"`
+	CloudID   string        `json:"cloud_id"`
+	Name      string        `json:"name"`
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	BkBizID   int64         `json:"bk_biz_id"`
+	// Region 腾讯云、谷歌云的密钥对与地域无关，为空
+	Region      string `json:"region"`
+	Fingerprint string `json:"fingerprint"`
+	PublicKey   string `json:"public_key"`
+	// HasPrivateKey 是否由HCM生成并保存了私钥
+	HasPrivateKey    bool    `json:"has_private_key"`
+	Memo             *string `json:"memo"`
+	CloudCreatedTime string  `json:"cloud_created_time"`
+	*core.Revision   `json:",inline"`
+}
+
+// KeyPair define key pair.
+type KeyPair[Ext Extension] struct {
+	BaseKeyPair `json:",inline"`
+	Extension   *Ext `json:"extension"`
+}
+
+// GetID ...
+func (k KeyPair[T]) GetID() string {
+	return k.BaseKeyPair.ID
+}
+
+// GetCloudID ...
+func (k KeyPair[T]) GetCloudID() string {
+	return k.BaseKeyPair.CloudID
+}
+
+// Extension key pair extension.
+type Extension interface {
+	TCloudKeyPairExtension | AwsKeyPairExtension | HuaWeiKeyPairExtension | GcpKeyPairExtension
+}
+
+// TCloudKeyPairExtension tcloud key pair extension.
+type TCloudKeyPairExtension struct {
+	ProjectID *int64 `json:"project_id,omitempty"`
+	// CloudInstanceIDs 密钥对已绑定的实例
+	CloudInstanceIDs []string `json:"cloud_instance_ids,omitempty"`
+}
+
+// AwsKeyPairExtension aws key pair extension.
+type AwsKeyPairExtension struct {
+	// KeyType rsa 或 ed25519
+	KeyType *string `json:"key_type,omitempty"`
+}
+
+// HuaWeiKeyPairExtension huawei key pair extension.
+type HuaWeiKeyPairExtension struct {
+	// Type ssh 或 x509
+	Type *string `json:"type,omitempty"`
+	// Scope domain：租户级，user：用户级
+	Scope *string `json:"scope,omitempty"`
+}
+
+// GcpKeyPairExtension gcp key pair extension, gcp key pair is an entry of project metadata "ssh-keys".
+type GcpKeyPairExtension struct {
+	UserName string `json:"user_name"`
+}
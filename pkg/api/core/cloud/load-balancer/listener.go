@@ -0,0 +1,95 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package corelb
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Listener define load balancer listener.
+type Listener struct {
+	ID                 string             `json:"id"`
+	CloudID            string             `json:"cloud_id"`
+	Name               string             `json:"name"`
+	Vendor             enumor.Vendor      `json:"vendor"`
+	AccountID          string             `json:"account_id"`
+	BkBizID            int64              `json:"bk_biz_id"`
+	LbID               string             `json:"lb_id"`
+	CloudLbID          string             `json:"cloud_lb_id"`
+	Protocol           string             `json:"protocol"`
+	Port               int64              `json:"port"`
+	CloudTargetGroupID string             `json:"cloud_target_group_id"`
+	Extension          *ListenerExtension `json:"extension"`
+	Memo               *string            `json:"memo"`
+	*core.Revision     `json:",inline"`
+}
+
+// GetID ...
+func (l Listener) GetID() string {
+	return l.ID
+}
+
+// GetCloudID ...
+func (l Listener) GetCloudID() string {
+	return l.CloudID
+}
+
+// ListenerExtension listener extension, fields are optional and depend on vendor.
+type ListenerExtension struct {
+	// Scheduler 转发算法，如 WRR、LEAST_CONN、ROUND_ROBIN 等
+	Scheduler         *string `json:"scheduler,omitempty"`
+	SessionExpireTime *int64  `json:"session_expire_time,omitempty"`
+	// EndPort 端口段监听器的结束端口，gcp 转发规则支持端口段
+	EndPort *int64 `json:"end_port,omitempty"`
+	// CloudCertificateIDs HTTPS/TLS 监听器绑定的证书ID
+	CloudCertificateIDs []string `json:"cloud_certificate_ids,omitempty"`
+	// ResourceGroupName azure 负载均衡规则所在的资源组
+	ResourceGroupName string `json:"resource_group_name,omitempty"`
+}
+
+// Target define load balancer backend target.
+type Target struct {
+	ID                 string        `json:"id"`
+	CloudID            string        `json:"cloud_id"`
+	Vendor             enumor.Vendor `json:"vendor"`
+	AccountID          string        `json:"account_id"`
+	LbID               string        `json:"lb_id"`
+	CloudLbID          string        `json:"cloud_lb_id"`
+	ListenerID         string        `json:"listener_id"`
+	CloudListenerID    string        `json:"cloud_listener_id"`
+	CloudTargetGroupID string        `json:"cloud_target_group_id"`
+	InstType           string        `json:"inst_type"`
+	CloudInstID        string        `json:"cloud_inst_id"`
+	IP                 string        `json:"ip"`
+	Port               int64         `json:"port"`
+	Weight             int64         `json:"weight"`
+	*core.Revision     `json:",inline"`
+}
+
+// GetID ...
+func (t Target) GetID() string {
+	return t.ID
+}
+
+// GetCloudID ...
+func (t Target) GetCloudID() string {
+	return t.CloudID
+}
@@ -0,0 +1,138 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package corelb 负载均衡相关的核心数据结构
+package corelb
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// BaseLoadBalancer define base load balancer.
+type BaseLoadBalancer struct {
+	ID        string        `json:"id"`
+	CloudID   string        `json:"cloud_id"`
+	Name      string        `json:"name"`
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	BkBizID   int64         `json:"bk_biz_id"`
+	Region    string        `json:"region"`
+	Zones     []string      `json:"zones"`
+	/*
+		tcloud: OPEN(公网)、INTERNAL(内网)
+		aws: application、network、gateway
+		huawei: guaranteed(独享型)、shared(共享型)
+		gcp: EXTERNAL、INTERNAL
+		azure: Basic、Standard、Gateway
+	*/
+	LBType    string `json:"lb_type"`
+	IPVersion string `json:"ip_version"`
+
+	CloudVpcID    string `json:"cloud_vpc_id"`
+	VpcID         string `json:"vpc_id"`
+	CloudSubnetID string `json:"cloud_subnet_id"`
+	SubnetID      string `json:"subnet_id"`
+
+	PrivateIPv4Addresses []string `json:"private_ipv4_addresses"`
+	PublicIPv4Addresses  []string `json:"public_ipv4_addresses"`
+	Domain               string   `json:"domain"`
+	Status               string   `json:"status"`
+	Memo                 *string  `json:"memo"`
+	CloudCreatedTime     string   `json:"cloud_created_time"`
+	*core.Revision       `json:",inline"`
+}
+
+// LoadBalancer define load balancer.
+type LoadBalancer[Ext Extension] struct {
+	BaseLoadBalancer `json:",inline"`
+	Extension        *Ext `json:"extension"`
+}
+
+// GetID ...
+func (lb LoadBalancer[T]) GetID() string {
+	return lb.BaseLoadBalancer.ID
+}
+
+// GetCloudID ...
+func (lb LoadBalancer[T]) GetCloudID() string {
+	return lb.BaseLoadBalancer.CloudID
+}
+
+// Extension load balancer extension.
+type Extension interface {
+	TCloudLoadBalancerExtension | AwsLoadBalancerExtension | HuaWeiLoadBalancerExtension |
+		GcpLoadBalancerExtension | AzureLoadBalancerExtension
+}
+
+// TCloudLoadBalancerExtension tcloud load balancer extension.
+type TCloudLoadBalancerExtension struct {
+	// Forward 负载均衡类型标识，1：负载均衡，0：传统型负载均衡。
+	Forward                 *int64   `json:"forward,omitempty"`
+	CloudProjectID          *int64   `json:"cloud_project_id,omitempty"`
+	ChargeType              *string  `json:"charge_type,omitempty"`
+	InternetChargeType      *string  `json:"internet_charge_type,omitempty"`
+	InternetMaxBandwidthOut *int64   `json:"internet_max_bandwidth_out,omitempty"`
+	VipIsp                  *string  `json:"vip_isp,omitempty"`
+	CloudSecurityGroupIDs   []string `json:"cloud_security_group_ids,omitempty"`
+	// Isolation 是否被隔离，0：未隔离，1：已隔离。
+	Isolation *int64 `json:"isolation,omitempty"`
+}
+
+// AwsLoadBalancerExtension aws load balancer extension.
+type AwsLoadBalancerExtension struct {
+	// Scheme internet-facing、internal
+	Scheme                *string  `json:"scheme,omitempty"`
+	CanonicalHostedZoneID *string  `json:"canonical_hosted_zone_id,omitempty"`
+	CloudSecurityGroupIDs []string `json:"cloud_security_group_ids,omitempty"`
+	CloudSubnetIDs        []string `json:"cloud_subnet_ids,omitempty"`
+	StateReason           *string  `json:"state_reason,omitempty"`
+}
+
+// HuaWeiLoadBalancerExtension huawei load balancer extension.
+type HuaWeiLoadBalancerExtension struct {
+	ProvisioningStatus  *string  `json:"provisioning_status,omitempty"`
+	OperatingStatus     *string  `json:"operating_status,omitempty"`
+	Guaranteed          *bool    `json:"guaranteed,omitempty"`
+	L4FlavorID          *string  `json:"l4_flavor_id,omitempty"`
+	L7FlavorID          *string  `json:"l7_flavor_id,omitempty"`
+	CloudVipPortID      *string  `json:"cloud_vip_port_id,omitempty"`
+	EnterpriseProjectID *string  `json:"enterprise_project_id,omitempty"`
+	BillingInfo         *string  `json:"billing_info,omitempty"`
+	CloudSubnetIDs      []string `json:"cloud_subnet_ids,omitempty"`
+}
+
+// GcpLoadBalancerExtension gcp load balancer extension, gcp load balancer is based on target pool.
+type GcpLoadBalancerExtension struct {
+	SelfLink        *string  `json:"self_link,omitempty"`
+	SessionAffinity *string  `json:"session_affinity,omitempty"`
+	HealthChecks    []string `json:"health_checks,omitempty"`
+	BackupPool      *string  `json:"backup_pool,omitempty"`
+}
+
+// AzureLoadBalancerExtension azure load balancer extension.
+type AzureLoadBalancerExtension struct {
+	ResourceGroupName string  `json:"resource_group_name,omitempty"`
+	SkuName           *string `json:"sku_name,omitempty"`
+	SkuTier           *string `json:"sku_tier,omitempty"`
+	// CloudFrontendIPConfigIDs 前端IP配置ID列表
+	CloudFrontendIPConfigIDs []string `json:"cloud_frontend_ip_config_ids,omitempty"`
+	// CloudBackendPoolIDs 后端地址池ID列表
+	CloudBackendPoolIDs []string `json:"cloud_backend_pool_ids,omitempty"`
+}
@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package corenat NAT网关相关的核心数据结构
+package corenat
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// BaseNatGateway define base nat gateway.
+type BaseNatGateway struct {
+	ID            string        `json:"id"`
+	CloudID       string        `json:"cloud_id"`
+	Name          string        `json:"name"`
+	Vendor        enumor.Vendor `json:"vendor"`
+	AccountID     string        `json:"account_id"`
+	BkBizID       int64         `json:"bk_biz_id"`
+	Region        string        `json:"region"`
+	Zone          string        `json:"zone"`
+	CloudVpcID    string        `json:"cloud_vpc_id"`
+	VpcID         string        `json:"vpc_id"`
+	CloudSubnetID string        `json:"cloud_subnet_id"`
+	SubnetID      string        `json:"subnet_id"`
+	/*
+		tcloud: 最大并发连接数，如 1000000、3000000、10000000
+		huawei: 1(小型)、2(中型)、3(大型)、4(超大型)
+		aws: 无规格概念，为空
+	*/
+	Spec              string   `json:"spec"`
+	PublicIPAddresses []string `json:"public_ip_addresses"`
+	Status            string   `json:"status"`
+	Memo              *string  `json:"memo"`
+	CloudCreatedTime  string   `json:"cloud_created_time"`
+	*core.Revision    `json:",inline"`
+}
+
+// NatGateway define nat gateway.
+type NatGateway[Ext Extension] struct {
+	BaseNatGateway `json:",inline"`
+	Extension      *Ext `json:"extension"`
+}
+
+// GetID ...
+func (n NatGateway[T]) GetID() string {
+	return n.BaseNatGateway.ID
+}
+
+// GetCloudID ...
+func (n NatGateway[T]) GetCloudID() string {
+	return n.BaseNatGateway.CloudID
+}
+
+// Extension nat gateway extension.
+type Extension interface {
+	TCloudNatGatewayExtension | AwsNatGatewayExtension | HuaWeiNatGatewayExtension
+}
+
+// TCloudNatGatewayExtension tcloud nat gateway extension.
+type TCloudNatGatewayExtension struct {
+	InternetMaxBandwidthOut *uint64  `json:"internet_max_bandwidth_out,omitempty"`
+	CloudEipIDs             []string `json:"cloud_eip_ids,omitempty"`
+	CloudSecurityGroupIDs   []string `json:"cloud_security_group_ids,omitempty"`
+	IsExclusive             *bool    `json:"is_exclusive,omitempty"`
+	// RestrictState 是否被封禁，NORMAL：未被封禁，RESTRICTED：已被封禁
+	RestrictState *string `json:"restrict_state,omitempty"`
+}
+
+// AwsNatGatewayExtension aws nat gateway extension.
+type AwsNatGatewayExtension struct {
+	// ConnectivityType public、private
+	ConnectivityType   *string  `json:"connectivity_type,omitempty"`
+	CloudAllocationIDs []string `json:"cloud_allocation_ids,omitempty"`
+	PrivateIPAddresses []string `json:"private_ip_addresses,omitempty"`
+	FailureMessage     *string  `json:"failure_message,omitempty"`
+}
+
+// HuaWeiNatGatewayExtension huawei nat gateway extension.
+type HuaWeiNatGatewayExtension struct {
+	// CloudInternalNetworkID NAT网关下行口所属的子网网络ID
+	CloudInternalNetworkID string  `json:"cloud_internal_network_id,omitempty"`
+	EnterpriseProjectID    *string `json:"enterprise_project_id,omitempty"`
+	AdminStateUp           bool    `json:"admin_state_up"`
+}
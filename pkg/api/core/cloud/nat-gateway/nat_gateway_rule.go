@@ -0,0 +1,70 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package corenat
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// NatGatewayRule define nat gateway snat/dnat rule.
+type NatGatewayRule struct {
+	ID                string                   `json:"id"`
+	CloudID           string                   `json:"cloud_id"`
+	Vendor            enumor.Vendor            `json:"vendor"`
+	AccountID         string                   `json:"account_id"`
+	NatGatewayID      string                   `json:"nat_gateway_id"`
+	CloudNatGatewayID string                   `json:"cloud_nat_gateway_id"`
+	RuleType          enumor.NatRuleType       `json:"rule_type"`
+	Protocol          string                   `json:"protocol"`
+	CloudSubnetID     string                   `json:"cloud_subnet_id"`
+	SourceCidr        string                   `json:"source_cidr"`
+	PublicIP          string                   `json:"public_ip"`
+	PublicPort        int64                    `json:"public_port"`
+	PrivateIP         string                   `json:"private_ip"`
+	PrivatePort       int64                    `json:"private_port"`
+	Status            string                   `json:"status"`
+	Memo              *string                  `json:"memo"`
+	Extension         *NatGatewayRuleExtension `json:"extension"`
+	*core.Revision    `json:",inline"`
+}
+
+// GetID ...
+func (r NatGatewayRule) GetID() string {
+	return r.ID
+}
+
+// GetCloudID ...
+func (r NatGatewayRule) GetCloudID() string {
+	return r.CloudID
+}
+
+// NatGatewayRuleExtension nat gateway rule extension, fields are optional and depend on vendor.
+type NatGatewayRuleExtension struct {
+	// ResourceType tcloud SNAT规则的源资源类型，SUBNET 或 NETWORKINTERFACE
+	ResourceType *string `json:"resource_type,omitempty"`
+	// PublicIPs tcloud SNAT规则可绑定多个公网IP
+	PublicIPs []string `json:"public_ips,omitempty"`
+	// CloudEipID huawei 规则绑定的弹性公网IP的云ID
+	CloudEipID *string `json:"cloud_eip_id,omitempty"`
+	// CloudPortID huawei DNAT规则映射的虚拟机网卡端口ID
+	CloudPortID      *string `json:"cloud_port_id,omitempty"`
+	CloudCreatedTime *string `json:"cloud_created_time,omitempty"`
+}
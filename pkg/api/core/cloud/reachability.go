@@ -0,0 +1,47 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import "hcm/pkg/criteria/enumor"
+
+// ReachabilityResult 两台主机之间指定协议、端口的流量是否可达及判断依据
+type ReachabilityResult struct {
+	Reachable bool                    `json:"reachable"`
+	Path      enumor.ReachabilityPath `json:"path"`
+	// SourceIP 流量的源IP
+	SourceIP string `json:"source_ip"`
+	// DestinationIP 流量的目的IP，公网路径时为目的主机的公网IP
+	DestinationIP string `json:"destination_ip"`
+	// Steps 依次为网络路径、源主机出站规则、目的主机入站规则的判断结果，不可达时止于第一个不通过的步骤
+	Steps []ReachabilityStep `json:"steps"`
+}
+
+// ReachabilityStep 可达性判断的步骤
+type ReachabilityStep struct {
+	Stage   enumor.ReachabilityStage `json:"stage"`
+	Allowed bool                     `json:"allowed"`
+	// SecurityGroupID 决定流量是否放通的安全组ID，gcp为防火墙规则所属的vpc ID
+	SecurityGroupID string `json:"security_group_id,omitempty"`
+	// RuleID 匹配流量的规则ID，为空表示没有匹配的规则，按默认策略处理
+	RuleID       string `json:"rule_id,omitempty"`
+	RouteTableID string `json:"route_table_id,omitempty"`
+	RouteID      string `json:"route_id,omitempty"`
+	Message      string `json:"message"`
+}
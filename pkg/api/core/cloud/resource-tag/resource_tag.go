@@ -0,0 +1,75 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package coretag 资源标签相关的核心结构体
+package coretag
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// TagPair define tag key value pair.
+type TagPair struct {
+	Key   string `json:"key" validate:"required,max=255"`
+	Value string `json:"value" validate:"max=255"`
+}
+
+// ResourceTag define resource tag.
+type ResourceTag struct {
+	ID             string                   `json:"id"`
+	Vendor         enumor.Vendor            `json:"vendor"`
+	AccountID      string                   `json:"account_id"`
+	ResType        enumor.CloudResourceType `json:"res_type"`
+	ResID          string                   `json:"res_id"`
+	CloudResID     string                   `json:"cloud_res_id"`
+	Key            string                   `json:"key"`
+	Value          string                   `json:"value"`
+	*core.Revision `json:",inline"`
+}
+
+// TagResource 带标签的云资源，同步时用于抽取资源上的标签
+type TagResource interface {
+	GetCloudID() string
+	GetTags() []TagPair
+}
+
+// NewTagPairsFromMap convert tag map to tag pairs.
+func NewTagPairsFromMap(tags map[string]string) []TagPair {
+	pairs := make([]TagPair, 0, len(tags))
+	for key, value := range tags {
+		pairs = append(pairs, TagPair{Key: key, Value: value})
+	}
+
+	return pairs
+}
+
+// NewTagPairsFromPtrMap convert tag map whose value is pointer to tag pairs.
+func NewTagPairsFromPtrMap(tags map[string]*string) []TagPair {
+	pairs := make([]TagPair, 0, len(tags))
+	for key, value := range tags {
+		pair := TagPair{Key: key}
+		if value != nil {
+			pair.Value = *value
+		}
+		pairs = append(pairs, pair)
+	}
+
+	return pairs
+}
@@ -46,6 +46,7 @@ type AwsRoute struct {
 	CloudVpcPeeringConnectionID      *string `json:"cloud_vpc_peering_connection_id,omitempty"`
 	State                            string  `json:"state"`
 	Propagated                       bool    `json:"propagated"`
+	NatGatewayID                     string  `json:"nat_gateway_id,omitempty"`
 	*core.Revision                   `json:",inline"`
 }
 
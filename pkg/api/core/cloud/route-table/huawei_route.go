@@ -32,6 +32,7 @@ type HuaWeiRoute struct {
 	Destination       string  `json:"destination"`
 	NextHop           string  `json:"nexthop"`
 	Memo              *string `json:"memo,omitempty"`
+	NatGatewayID      string  `json:"nat_gateway_id,omitempty"`
 	*core.Revision    `json:",inline"`
 }
 
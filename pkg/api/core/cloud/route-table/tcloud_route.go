@@ -37,6 +37,7 @@ type TCloudRoute struct {
 	RouteType                string  `json:"route_type"`
 	PublishedToVbc           bool    `json:"published_to_vbc"`
 	Memo                     *string `json:"memo,omitempty"`
+	NatGatewayID             string  `json:"nat_gateway_id,omitempty"`
 	*core.Revision           `json:",inline"`
 }
 
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import "hcm/pkg/criteria/enumor"
+
+// ExposureFinding 安全组规则分析出的风险项
+type ExposureFinding struct {
+	Kind  enumor.ExposureRiskKind  `json:"kind"`
+	Level enumor.ExposureRiskLevel `json:"level"`
+	// SecurityGroupID 风险规则所属的安全组ID，gcp为防火墙规则所属的vpc ID
+	SecurityGroupID string                       `json:"security_group_id"`
+	RuleType        enumor.SecurityGroupRuleType `json:"rule_type"`
+	// RuleID 风险规则ID
+	RuleID string `json:"rule_id"`
+	// RelatedRuleID 覆盖或与风险规则重叠的更高优先级规则ID
+	RelatedRuleID string `json:"related_rule_id,omitempty"`
+	Protocol      string `json:"protocol,omitempty"`
+	Port          string `json:"port,omitempty"`
+	Cidr          string `json:"cidr,omitempty"`
+	Message       string `json:"message"`
+}
+
+// SecurityGroupExposure 安全组的风险分析结果
+type SecurityGroupExposure struct {
+	ID        string            `json:"id"`
+	CloudID   string            `json:"cloud_id"`
+	Name      string            `json:"name"`
+	Vendor    enumor.Vendor     `json:"vendor"`
+	AccountID string            `json:"account_id"`
+	Region    string            `json:"region"`
+	Findings  []ExposureFinding `json:"findings"`
+}
+
+// CvmExposure 主机通过绑定的安全组对公网暴露的风险分析结果
+type CvmExposure struct {
+	ID                  string                   `json:"id"`
+	CloudID             string                   `json:"cloud_id"`
+	Name                string                   `json:"name"`
+	Vendor              enumor.Vendor            `json:"vendor"`
+	PublicIPv4Addresses []string                 `json:"public_ipv4_addresses"`
+	PublicIPv6Addresses []string                 `json:"public_ipv6_addresses"`
+	SecurityGroupIDs    []string                 `json:"security_group_ids"`
+	Level               enumor.ExposureRiskLevel `json:"level"`
+	Findings            []ExposureFinding        `json:"findings"`
+}
+
+// BizExposureResult 业务下安全组、主机的风险分析结果，只返回存在风险的安全组和主机。
+type BizExposureResult struct {
+	SecurityGroups []SecurityGroupExposure `json:"security_groups"`
+	Cvms           []CvmExposure           `json:"cvms"`
+}
@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package coresnapshot 快照相关的核心数据结构
+package coresnapshot
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// BaseSnapshot define base snapshot.
+type BaseSnapshot struct {
+	ID          string        `json:"id"`
+	CloudID     string        `json:"cloud_id"`
+	Name        string        `json:"name"`
+	Vendor      enumor.Vendor `json:"vendor"`
+	AccountID   string        `json:"account_id"`
+	BkBizID     int64         `json:"bk_biz_id"`
+	Region      string        `json:"region"`
+	Zone        string        `json:"zone"`
+	CloudDiskID string        `json:"cloud_disk_id"`
+	DiskID      string        `json:"disk_id"`
+	// Size 源云盘大小，单位：GB
+	Size             uint64  `json:"size"`
+	Status           string  `json:"status"`
+	PolicyID         string  `json:"policy_id"`
+	Memo             *string `json:"memo"`
+	CloudCreatedTime string  `json:"cloud_created_time"`
+	*core.Revision   `json:",inline"`
+}
+
+// Snapshot define snapshot.
+type Snapshot[Ext Extension] struct {
+	BaseSnapshot `json:",inline"`
+	Extension    *Ext `json:"extension"`
+}
+
+// GetID ...
+func (s Snapshot[T]) GetID() string {
+	return s.BaseSnapshot.ID
+}
+
+// GetCloudID ...
+func (s Snapshot[T]) GetCloudID() string {
+	return s.BaseSnapshot.CloudID
+}
+
+// Extension snapshot extension.
+type Extension interface {
+	TCloudSnapshotExtension | AwsSnapshotExtension | HuaWeiSnapshotExtension |
+		GcpSnapshotExtension | AzureSnapshotExtension
+}
+
+// TCloudSnapshotExtension tcloud snapshot extension.
+type TCloudSnapshotExtension struct {
+	// DiskUsage 源云盘类型，SYSTEM_DISK：系统盘，DATA_DISK：数据盘
+	DiskUsage    *string `json:"disk_usage,omitempty"`
+	Percent      *uint64 `json:"percent,omitempty"`
+	Encrypt      *bool   `json:"encrypt,omitempty"`
+	IsPermanent  *bool   `json:"is_permanent,omitempty"`
+	DeadlineTime *string `json:"deadline_time,omitempty"`
+	// SnapshotType 快照类型，PRIVATE_SNAPSHOT：私有快照，SHARED_SNAPSHOT：共享快照
+	SnapshotType *string `json:"snapshot_type,omitempty"`
+}
+
+// AwsSnapshotExtension aws snapshot extension.
+type AwsSnapshotExtension struct {
+	Encrypted   *bool   `json:"encrypted,omitempty"`
+	Progress    *string `json:"progress,omitempty"`
+	OwnerID     *string `json:"owner_id,omitempty"`
+	StorageTier *string `json:"storage_tier,omitempty"`
+}
+
+// HuaWeiSnapshotExtension huawei snapshot extension.
+type HuaWeiSnapshotExtension struct {
+	UpdatedAt *string           `json:"updated_at,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// GcpSnapshotExtension gcp snapshot extension.
+type GcpSnapshotExtension struct {
+	SelfLink         *string  `json:"self_link,omitempty"`
+	SourceDisk       *string  `json:"source_disk,omitempty"`
+	StorageBytes     *int64   `json:"storage_bytes,omitempty"`
+	StorageLocations []string `json:"storage_locations,omitempty"`
+}
+
+// AzureSnapshotExtension azure snapshot extension.
+type AzureSnapshotExtension struct {
+	ResourceGroupName string  `json:"resource_group_name,omitempty"`
+	SkuName           *string `json:"sku_name,omitempty"`
+	Incremental       *bool   `json:"incremental,omitempty"`
+	ProvisioningState *string `json:"provisioning_state,omitempty"`
+}
+
+// SnapshotPolicy define snapshot policy.
+type SnapshotPolicy struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	BkBizID   int64         `json:"bk_biz_id"`
+	DiskIDs   []string      `json:"disk_ids"`
+	Cron      string        `json:"cron"`
+	// RetentionCount 每块云盘由该策略创建的快照保留数量
+	RetentionCount uint                       `json:"retention_count"`
+	State          enumor.SnapshotPolicyState `json:"state"`
+	NextRunAt      string                     `json:"next_run_at"`
+	LastFlowID     string                     `json:"last_flow_id"`
+	Memo           *string                    `json:"memo"`
+	core.Revision  `json:",inline"`
+}
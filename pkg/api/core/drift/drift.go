@@ -0,0 +1,53 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package coredrift 资源配置漂移检测相关的核心结构体
+package coredrift
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Spec 资源的期望配置，key为属性名，value为属性值，列表类型的属性值按升序排列
+type Spec map[string]interface{}
+
+// FieldDiff 资源属性的期望值与当前值的差异
+type FieldDiff struct {
+	Field   string      `json:"field"`
+	Desired interface{} `json:"desired"`
+	Actual  interface{} `json:"actual"`
+}
+
+// DesiredState 资源交付时的期望状态以及最近一次漂移检测的结果
+type DesiredState struct {
+	ID            string                   `json:"id"`
+	ResType       enumor.CloudResourceType `json:"res_type"`
+	ResID         string                   `json:"res_id"`
+	CloudResID    string                   `json:"cloud_res_id"`
+	Vendor        enumor.Vendor            `json:"vendor"`
+	AccountID     string                   `json:"account_id"`
+	BkBizID       int64                    `json:"bk_biz_id"`
+	ApplicationID string                   `json:"application_id"`
+	Spec          Spec                     `json:"spec"`
+	DriftStatus   enumor.DriftStatus       `json:"drift_status"`
+	DriftDetail   []FieldDiff              `json:"drift_detail"`
+	CheckedAt     string                   `json:"checked_at"`
+	core.Revision `json:",inline"`
+}
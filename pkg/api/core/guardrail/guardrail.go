@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package coreguardrail 资源创建管控规则相关的核心结构体
+package coreguardrail
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/runtime/filter"
+)
+
+// Rule 资源创建管控规则，资源创建请求需要满足规则表达式，不满足时拒绝创建并返回规则中的原因
+type Rule struct {
+	ID      string                   `json:"id"`
+	Name    string                   `json:"name"`
+	BkBizID int64                    `json:"bk_biz_id"`
+	ResType enumor.CloudResourceType `json:"res_type"`
+	Vendor  enumor.Vendor            `json:"vendor"`
+	// Expression 规则表达式，字段为资源创建请求中的json路径，如region、data_disk.#.disk_type
+	Expression    *filter.Expression `json:"expression"`
+	Message       string             `json:"message"`
+	Enabled       bool               `json:"enabled"`
+	Memo          *string            `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// Violation 资源创建请求违反的规则
+type Violation struct {
+	RuleID   string `json:"rule_id"`
+	RuleName string `json:"rule_name"`
+	Message  string `json:"message"`
+}
+
+// EvaluateResult 资源创建请求的规则校验结果
+type EvaluateResult struct {
+	Passed     bool        `json:"passed"`
+	Violations []Violation `json:"violations"`
+}
+
+// SupportedResTypes 支持配置管控规则的资源类型
+var SupportedResTypes = map[enumor.CloudResourceType]struct{}{
+	enumor.CvmCloudResType:    {},
+	enumor.DiskCloudResType:   {},
+	enumor.VpcCloudResType:    {},
+	enumor.SubnetCloudResType: {},
+	enumor.EipCloudResType:    {},
+}
+
+// ValidateResType 校验资源类型是否支持配置管控规则
+func ValidateResType(resType enumor.CloudResourceType) error {
+	if _, exist := SupportedResTypes[resType]; !exist {
+		return fmt.Errorf("res_type %s not support guardrail rule", resType)
+	}
+
+	return nil
+}
+
+// ValidateExpression 校验规则表达式，规则在内存中对请求内容求值，只支持比较、包含类的操作符
+func ValidateExpression(expr *filter.Expression) error {
+	if expr.IsEmpty() {
+		return errors.New("expression is required")
+	}
+
+	if err := expr.Validate(nil); err != nil {
+		return err
+	}
+
+	return validateOperator(expr)
+}
+
+func validateOperator(expr *filter.Expression) error {
+	for _, one := range expr.Rules {
+		switch rule := one.(type) {
+		case *filter.Expression:
+			if err := validateOperator(rule); err != nil {
+				return err
+			}
+		case *filter.AtomRule:
+			if err := validateAtomOperator(rule.Op); err != nil {
+				return err
+			}
+		case filter.AtomRule:
+			if err := validateAtomOperator(rule.Op); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported expression rule: %T", one)
+		}
+	}
+
+	return nil
+}
+
+func validateAtomOperator(op filter.OpFactory) error {
+	switch filter.OpType(op) {
+	case filter.Equal, filter.NotEqual, filter.GreaterThan, filter.GreaterThanEqual, filter.LessThan,
+		filter.LessThanEqual, filter.In, filter.NotIn, filter.ContainsSensitive, filter.ContainsInsensitive:
+		return nil
+	default:
+		return fmt.Errorf("guardrail rule not support operator: %s", op)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package coreipam IP地址管理相关的核心结构体
+package coreipam
+
+import (
+	"fmt"
+	"net"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Pool IPAM地址池
+type Pool struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Cidr          string  `json:"cidr"`
+	Region        string  `json:"region"`
+	BkBizID       int64   `json:"bk_biz_id"`
+	VpcMaskLen    uint    `json:"vpc_mask_len"`
+	SubnetMaskLen uint    `json:"subnet_mask_len"`
+	Memo          *string `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// AllocateResult 从地址池中分配的网段
+type AllocateResult struct {
+	PoolID string `json:"pool_id"`
+	// VpcCidr 分配的vpc网段
+	VpcCidr string `json:"vpc_cidr"`
+	// SubnetCidr vpc网段中的第一个子网网段
+	SubnetCidr string `json:"subnet_cidr"`
+}
+
+// SubnetSuggestResult vpc下建议使用的子网网段
+type SubnetSuggestResult struct {
+	VpcID string `json:"vpc_id"`
+	// VpcCidr 分配子网使用的vpc网段
+	VpcCidr    string `json:"vpc_cidr"`
+	SubnetCidr string `json:"subnet_cidr"`
+}
+
+// VpcCidr vpc及其IPv4网段，gcp的vpc没有网段，使用其子网的网段
+type VpcCidr struct {
+	ID        string        `json:"id"`
+	CloudID   string        `json:"cloud_id"`
+	Name      string        `json:"name"`
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	Region    string        `json:"region"`
+	BkBizID   int64         `json:"bk_biz_id"`
+	Cidrs     []string      `json:"cidrs"`
+}
+
+// Conflict 两个vpc之间重叠的网段
+type Conflict struct {
+	Kind enumor.IPAMConflictKind `json:"kind"`
+	// Vpc、PeerVpc 网段重叠的两个vpc
+	Vpc     VpcCidr `json:"vpc"`
+	PeerVpc VpcCidr `json:"peer_vpc"`
+	// Cidr、PeerCidr 两个vpc中相互重叠的网段
+	Cidr     string `json:"cidr"`
+	PeerCidr string `json:"peer_cidr"`
+}
+
+// ConflictReport 全部已同步vpc的网段冲突报告
+type ConflictReport struct {
+	// VpcCount 参与分析的vpc数量
+	VpcCount  int        `json:"vpc_count"`
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// ValidatePool 地址池网段必须为IPv4网段，vpc网段掩码长度不能小于地址池网段掩码长度，子网网段掩码长度不能小于vpc网段掩码长度
+func ValidatePool(cidr string, vpcMaskLen, subnetMaskLen uint) error {
+	ip, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil || ip.To4() == nil {
+		return fmt.Errorf("cidr %s is not a valid ipv4 cidr", cidr)
+	}
+
+	if ipNet.String() != cidr {
+		return fmt.Errorf("cidr %s should be %s", cidr, ipNet.String())
+	}
+
+	ones, _ := ipNet.Mask.Size()
+	if vpcMaskLen < uint(ones) || vpcMaskLen > 32 {
+		return fmt.Errorf("vpc_mask_len should be in range [%d, 32]", ones)
+	}
+
+	if subnetMaskLen < vpcMaskLen || subnetMaskLen > 32 {
+		return fmt.Errorf("subnet_mask_len should be in range [%d, 32]", vpcMaskLen)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package corelease 资源租约相关的核心结构体
+package corelease
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Lease 资源租约，记录主机和硬盘的负责人及到期时间，到期前提醒负责人，到期后资源自动进入回收站
+type Lease struct {
+	ID       string                   `json:"id"`
+	ResType  enumor.CloudResourceType `json:"res_type"`
+	ResID    string                   `json:"res_id"`
+	Vendor   enumor.Vendor            `json:"vendor"`
+	BkBizID  int64                    `json:"bk_biz_id"`
+	Owner    string                   `json:"owner"`
+	ExpireAt string                   `json:"expire_at"`
+	// Reminded 当前到期时间是否已发送过到期提醒
+	Reminded      bool              `json:"reminded"`
+	State         enumor.LeaseState `json:"state"`
+	Memo          *string           `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// SupportedResTypes 支持设置租约的资源类型
+var SupportedResTypes = map[enumor.CloudResourceType]struct{}{
+	enumor.CvmCloudResType:  {},
+	enumor.DiskCloudResType: {},
+}
+
+// ValidateResType 校验资源类型是否支持设置租约
+func ValidateResType(resType enumor.CloudResourceType) error {
+	if _, exist := SupportedResTypes[resType]; !exist {
+		return fmt.Errorf("res_type %s not support lease", resType)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package corestack 资源栈相关的核心结构体
+package corestack
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Stack 资源栈，资源栈使用一份模版描述一个业务环境下的全部资源
+type Stack struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	Region    string        `json:"region"`
+	BkBizID   int64         `json:"bk_biz_id"`
+	// Version 最新的模版版本
+	Version uint `json:"version"`
+	// AppliedVersion 最近一次apply使用的模版版本，0表示还没有apply过
+	AppliedVersion uint               `json:"applied_version"`
+	Status         enumor.StackStatus `json:"status"`
+	FlowID         string             `json:"flow_id"`
+	// Resources 资源栈已创建的资源
+	Resources     []Resource `json:"resources"`
+	Memo          *string    `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// Resource 资源栈中按模版创建的一组资源，Name为资源在模版中的名称，主机、硬盘等按数量创建的资源对应多个ID
+type Resource struct {
+	ResType  enumor.CloudResourceType `json:"res_type"`
+	Name     string                   `json:"name"`
+	IDs      []string                 `json:"ids"`
+	CloudIDs []string                 `json:"cloud_ids"`
+}
+
+// Version 资源栈模版版本
+type Version struct {
+	ID        string                     `json:"id"`
+	StackID   string                     `json:"stack_id"`
+	Version   uint                       `json:"version"`
+	Format    enumor.StackTemplateFormat `json:"format"`
+	Content   string                     `json:"content"`
+	Creator   string                     `json:"creator"`
+	CreatedAt string                     `json:"created_at"`
+}
@@ -0,0 +1,218 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package corestack
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/validator"
+)
+
+// Template 资源栈模版，资源之间通过模版中的名称相互引用，引用的名称不在模版中时按已存在资源的云ID处理
+type Template struct {
+	Vpcs           []VpcTemplate           `json:"vpcs" validate:"omitempty,max=10,dive"`
+	SecurityGroups []SecurityGroupTemplate `json:"security_groups" validate:"omitempty,max=20,dive"`
+	Cvms           []CvmTemplate           `json:"cvms" validate:"omitempty,max=20,dive"`
+	Disks          []DiskTemplate          `json:"disks" validate:"omitempty,max=20,dive"`
+	Eips           []EipTemplate           `json:"eips" validate:"omitempty,max=20,dive"`
+}
+
+// VpcTemplate vpc模版，子网随vpc一起创建
+type VpcTemplate struct {
+	Name     string           `json:"name" validate:"required,lte=60"`
+	IPv4Cidr string           `json:"ipv4_cidr" validate:"required,cidrv4"`
+	Subnets  []SubnetTemplate `json:"subnets" validate:"omitempty,max=100,dive"`
+	// BkCloudID vpc所属的管控区域
+	BkCloudID int64   `json:"bk_cloud_id" validate:"required"`
+	Memo      *string `json:"memo" validate:"omitempty"`
+}
+
+// SubnetTemplate 子网模版
+type SubnetTemplate struct {
+	Name     string `json:"name" validate:"required,lte=60"`
+	Zone     string `json:"zone" validate:"required"`
+	IPv4Cidr string `json:"ipv4_cidr" validate:"required,cidrv4"`
+}
+
+// SecurityGroupTemplate 安全组模版
+type SecurityGroupTemplate struct {
+	Name string  `json:"name" validate:"required,lte=60"`
+	Memo *string `json:"memo" validate:"omitempty"`
+}
+
+// CvmTemplate 主机模版，Subnet为子网名称或已存在子网的云ID，SecurityGroups为安全组名称或已存在安全组的云ID。
+// 模版内容会按版本保存并在版本列表中返回，编译出的任务参数也会入库，所以模版不支持设置登录密码，只能使用密钥对登录
+type CvmTemplate struct {
+	Name                    string            `json:"name" validate:"required,lte=60"`
+	Count                   int64             `json:"count" validate:"omitempty,min=1,max=100"`
+	Zone                    string            `json:"zone" validate:"required"`
+	InstanceType            string            `json:"instance_type" validate:"required"`
+	CloudImageID            string            `json:"cloud_image_id" validate:"required"`
+	Subnet                  string            `json:"subnet" validate:"required"`
+	SecurityGroups          []string          `json:"security_groups" validate:"required,min=1,max=5"`
+	KeyPairID               string            `json:"key_pair_id" validate:"required"`
+	InstanceChargeType      string            `json:"instance_charge_type" validate:"omitempty"`
+	SystemDisk              CvmDiskTemplate   `json:"system_disk" validate:"required"`
+	DataDisks               []CvmDiskTemplate `json:"data_disks" validate:"omitempty,max=20,dive"`
+	PublicIPAssigned        bool              `json:"public_ip_assigned" validate:"omitempty"`
+	InternetMaxBandwidthOut int64             `json:"internet_max_bandwidth_out" validate:"omitempty"`
+}
+
+// CvmDiskTemplate 主机系统盘、数据盘模版
+type CvmDiskTemplate struct {
+	DiskType   string `json:"disk_type" validate:"required"`
+	DiskSizeGB int64  `json:"disk_size_gb" validate:"required,min=1"`
+}
+
+// DiskTemplate 云硬盘模版
+type DiskTemplate struct {
+	Name           string `json:"name" validate:"required,lte=60"`
+	Count          uint32 `json:"count" validate:"omitempty,min=1,max=50"`
+	Zone           string `json:"zone" validate:"required"`
+	DiskType       string `json:"disk_type" validate:"required"`
+	DiskSize       uint64 `json:"disk_size" validate:"required,min=1"`
+	DiskChargeType string `json:"disk_charge_type" validate:"omitempty"`
+}
+
+// EipTemplate 弹性公网IP模版
+type EipTemplate struct {
+	Name  string `json:"name" validate:"required,lte=60"`
+	Count int64  `json:"count" validate:"omitempty,min=1,max=50"`
+}
+
+const (
+	// DefaultInstanceChargeType 主机默认计费模式
+	DefaultInstanceChargeType = "POSTPAID_BY_HOUR"
+	// DefaultDiskChargeType 云硬盘默认计费模式
+	DefaultDiskChargeType = "POSTPAID_BY_HOUR"
+)
+
+// Validate Template.
+func (t *Template) Validate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Vpcs)+len(t.SecurityGroups)+len(t.Cvms)+len(t.Disks)+len(t.Eips) == 0 {
+		return errors.New("template has no resource")
+	}
+
+	// 同类资源的名称在模版中唯一，子网名称在全部vpc中唯一
+	names := make(map[string]map[string]struct{})
+	checkName := func(kind, name string) error {
+		if _, exist := names[kind]; !exist {
+			names[kind] = make(map[string]struct{})
+		}
+		if _, exist := names[kind][name]; exist {
+			return fmt.Errorf("%s name: %s is duplicated", kind, name)
+		}
+		names[kind][name] = struct{}{}
+		return nil
+	}
+
+	for _, vpc := range t.Vpcs {
+		if err := checkName("vpc", vpc.Name); err != nil {
+			return err
+		}
+		for _, subnet := range vpc.Subnets {
+			if err := checkName("subnet", subnet.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, sg := range t.SecurityGroups {
+		if err := checkName("security_group", sg.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, cvm := range t.Cvms {
+		if err := checkName("cvm", cvm.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, disk := range t.Disks {
+		if err := checkName("disk", disk.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, eip := range t.Eips {
+		if err := checkName("eip", eip.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetDefault set template default values.
+func (t *Template) SetDefault() {
+	for idx := range t.Cvms {
+		if t.Cvms[idx].Count == 0 {
+			t.Cvms[idx].Count = 1
+		}
+		if len(t.Cvms[idx].InstanceChargeType) == 0 {
+			t.Cvms[idx].InstanceChargeType = DefaultInstanceChargeType
+		}
+	}
+
+	for idx := range t.Disks {
+		if t.Disks[idx].Count == 0 {
+			t.Disks[idx].Count = 1
+		}
+		if len(t.Disks[idx].DiskChargeType) == 0 {
+			t.Disks[idx].DiskChargeType = DefaultDiskChargeType
+		}
+	}
+
+	for idx := range t.Eips {
+		if t.Eips[idx].Count == 0 {
+			t.Eips[idx].Count = 1
+		}
+	}
+}
+
+// FindSubnet find subnet template and the vpc template it belongs to by subnet name.
+func (t *Template) FindSubnet(name string) (*VpcTemplate, *SubnetTemplate, bool) {
+	for i := range t.Vpcs {
+		for j := range t.Vpcs[i].Subnets {
+			if t.Vpcs[i].Subnets[j].Name == name {
+				return &t.Vpcs[i], &t.Vpcs[i].Subnets[j], true
+			}
+		}
+	}
+
+	return nil, nil, false
+}
+
+// HasSecurityGroup return whether the security group name is defined in template.
+func (t *Template) HasSecurityGroup(name string) bool {
+	for _, sg := range t.SecurityGroups {
+		if sg.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -47,6 +47,12 @@ func (req *ApplicationCreateReq) Validate() error {
 type ApplicationUpdateReq struct {
 	Status         enumor.ApplicationStatus `json:"status" validate:"required"`
 	DeliveryDetail *string                  `json:"delivery_detail" validate:"omitempty"`
+	// SourceStatus 设置时只更新状态为该状态的申请单，未更新时返回 RecordNotUpdate 错误
+	SourceStatus enumor.ApplicationStatus `json:"source_status" validate:"omitempty"`
+	// SourceDeliverStep 设置时只更新交付中且交付详情中交付步骤为该步骤的申请单，未更新时返回 RecordNotUpdate 错误
+	SourceDeliverStep enumor.ApplicationDeliverStep `json:"source_deliver_step" validate:"omitempty"`
+	// SourceDeliverFlowID 设置时只更新交付详情中交付任务流为该任务流的申请单，未更新时返回 RecordNotUpdate 错误
+	SourceDeliverFlowID string `json:"source_deliver_flow_id" validate:"omitempty"`
 }
 
 // Validate ...
@@ -0,0 +1,146 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dsapproval 内置审批引擎相关的 data-service 接口定义
+package dsapproval
+
+import (
+	"errors"
+
+	coreapproval "hcm/pkg/api/core/approval"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// WorkflowCreateReq define approval workflow create request.
+type WorkflowCreateReq struct {
+	ApplicationType enumor.ApplicationType       `json:"application_type" validate:"required"`
+	BkBizID         int64                        `json:"bk_biz_id" validate:"required"`
+	Stages          []coreapproval.WorkflowStage `json:"stages" validate:"required,dive"`
+	Memo            *string                      `json:"memo" validate:"omitempty,lte=255"`
+}
+
+// Validate WorkflowCreateReq.
+func (req *WorkflowCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return coreapproval.ValidateStages(req.Stages)
+}
+
+// WorkflowUpdateReq define approval workflow update request.
+type WorkflowUpdateReq struct {
+	Stages []coreapproval.WorkflowStage `json:"stages" validate:"omitempty,dive"`
+	Memo   *string                      `json:"memo" validate:"omitempty,lte=255"`
+}
+
+// Validate WorkflowUpdateReq.
+func (req *WorkflowUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Stages == nil && req.Memo == nil {
+		return errors.New("at least one field needs to be updated")
+	}
+
+	if req.Stages != nil {
+		return coreapproval.ValidateStages(req.Stages)
+	}
+
+	return nil
+}
+
+// TicketCreateReq define approval ticket create request, the ticket starts at the first stage.
+type TicketCreateReq struct {
+	ApplicationType enumor.ApplicationType     `json:"application_type" validate:"required"`
+	BkBizID         int64                      `json:"bk_biz_id" validate:"required"`
+	Title           string                     `json:"title" validate:"required,lte=255"`
+	Content         string                     `json:"content" validate:"omitempty"`
+	Stages          []coreapproval.TicketStage `json:"stages" validate:"required,min=1"`
+}
+
+// Validate TicketCreateReq.
+func (req *TicketCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TicketUpdateReq define approval ticket update request, the ticket is updated only when it is still running at
+// the source stage with the source approvers.
+type TicketUpdateReq struct {
+	SourceStage      uint                        `json:"source_stage" validate:"omitempty"`
+	SourceApprovers  []string                    `json:"source_approvers" validate:"omitempty"`
+	Status           enumor.ApprovalTicketStatus `json:"status" validate:"required"`
+	CurrentStage     uint                        `json:"current_stage" validate:"omitempty"`
+	Stages           []coreapproval.TicketStage  `json:"stages" validate:"required,min=1"`
+	CurrentApprovers []string                    `json:"current_approvers" validate:"omitempty"`
+}
+
+// Validate TicketUpdateReq.
+func (req *TicketUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.CurrentStage < req.SourceStage {
+		return errors.New("current_stage can not be less than source_stage")
+	}
+
+	return nil
+}
+
+// PolicyCreateReq define approval policy create request.
+type PolicyCreateReq struct {
+	BkBizID          int64                    `json:"bk_biz_id" validate:"required"`
+	ApplicationTypes []enumor.ApplicationType `json:"application_types" validate:"required"`
+	Memo             *string                  `json:"memo" validate:"omitempty,lte=255"`
+}
+
+// Validate PolicyCreateReq.
+func (req *PolicyCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return coreapproval.ValidatePolicyTypes(req.ApplicationTypes)
+}
+
+// PolicyUpdateReq define approval policy update request.
+type PolicyUpdateReq struct {
+	ApplicationTypes []enumor.ApplicationType `json:"application_types" validate:"omitempty"`
+	Memo             *string                  `json:"memo" validate:"omitempty,lte=255"`
+}
+
+// Validate PolicyUpdateReq.
+func (req *PolicyUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.ApplicationTypes == nil && req.Memo == nil {
+		return errors.New("at least one field needs to be updated")
+	}
+
+	if req.ApplicationTypes != nil {
+		return coreapproval.ValidatePolicyTypes(req.ApplicationTypes)
+	}
+
+	return nil
+}
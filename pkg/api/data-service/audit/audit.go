@@ -140,6 +140,8 @@ func (o *OperationAction) ConvAuditAction() (enumor.AuditAction, error) {
 		return enumor.Associate, nil
 	case Disassociate:
 		return enumor.Disassociate, nil
+	case DownloadPrivateKey:
+		return enumor.DownloadPrivateKey, nil
 
 	default:
 		return "", fmt.Errorf("action is not corresponding audit action")
@@ -155,6 +157,8 @@ const (
 	Associate OperationAction = "associate"
 	// Disassociate 解绑、解挂载等操作
 	Disassociate OperationAction = "disassociate"
+	// DownloadPrivateKey 下载密钥对私钥
+	DownloadPrivateKey OperationAction = "download_private_key"
 )
 
 // CloudResourceOperationAuditReq define cloud resource operation audit req.
@@ -236,3 +240,17 @@ type GetResp struct {
 	rest.BaseResp `json:",inline"`
 	Data          *audit.Audit `json:"data"`
 }
+
+// -------------------------- List Res Change Event --------------------------
+
+// ListResChangeEventResp defines list res change event response.
+type ListResChangeEventResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *ListResChangeEventResult `json:"data"`
+}
+
+// ListResChangeEventResult defines list res change event result.
+type ListResChangeEventResult struct {
+	Count   uint64                 `json:"count"`
+	Details []audit.ResChangeEvent `json:"details"`
+}
@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dsbudget 预算相关的 data-service 接口定义
+package dsbudget
+
+import (
+	"errors"
+
+	corebudget "hcm/pkg/api/core/budget"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// BudgetCreateReq define budget create request.
+type BudgetCreateReq struct {
+	Name       string             `json:"name" validate:"required,max=255"`
+	Scope      enumor.BudgetScope `json:"scope" validate:"required"`
+	BkBizID    int64              `json:"bk_biz_id" validate:"omitempty"`
+	AccountID  string             `json:"account_id" validate:"omitempty,max=64"`
+	Currency   string             `json:"currency" validate:"required,max=16"`
+	Amount     string             `json:"amount" validate:"required"`
+	Thresholds []int64            `json:"thresholds" validate:"required,min=1,max=10"`
+	Receivers  []string           `json:"receivers" validate:"omitempty,max=50"`
+	Memo       *string            `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate BudgetCreateReq.
+func (req *BudgetCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := corebudget.ValidateScope(req.Scope, req.BkBizID, req.AccountID); err != nil {
+		return err
+	}
+
+	if err := corebudget.ValidateAmount(req.Amount); err != nil {
+		return err
+	}
+
+	return corebudget.ValidateThresholds(req.Thresholds)
+}
+
+// BudgetUpdateReq define budget update request, scope of budget can not be updated.
+type BudgetUpdateReq struct {
+	Name       string   `json:"name" validate:"omitempty,max=255"`
+	Amount     string   `json:"amount" validate:"omitempty"`
+	Thresholds []int64  `json:"thresholds" validate:"omitempty,max=10"`
+	Receivers  []string `json:"receivers" validate:"omitempty,max=50"`
+	Memo       *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate BudgetUpdateReq.
+func (req *BudgetUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && len(req.Amount) == 0 && len(req.Thresholds) == 0 && req.Receivers == nil &&
+		req.Memo == nil {
+		return errors.New("not found update field")
+	}
+
+	if len(req.Amount) != 0 {
+		if err := corebudget.ValidateAmount(req.Amount); err != nil {
+			return err
+		}
+	}
+
+	return corebudget.ValidateThresholds(req.Thresholds)
+}
+
+// BudgetAlertCreateReq define budget alert create request.
+type BudgetAlertCreateReq struct {
+	BudgetID  string                  `json:"budget_id" validate:"required"`
+	Month     string                  `json:"month" validate:"required,len=7"`
+	Threshold uint                    `json:"threshold" validate:"required"`
+	Amount    string                  `json:"amount" validate:"required"`
+	Spent     string                  `json:"spent" validate:"required"`
+	Forecast  string                  `json:"forecast" validate:"required"`
+	State     enumor.BudgetAlertState `json:"state" validate:"required"`
+	Message   string                  `json:"message" validate:"max=1024"`
+}
+
+// Validate BudgetAlertCreateReq.
+func (req *BudgetAlertCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.State.Validate()
+}
+
+// BudgetAlertUpdateReq define budget alert update request.
+type BudgetAlertUpdateReq struct {
+	Spent    string                  `json:"spent" validate:"required"`
+	Forecast string                  `json:"forecast" validate:"required"`
+	State    enumor.BudgetAlertState `json:"state" validate:"required"`
+	Message  string                  `json:"message" validate:"max=1024"`
+}
+
+// Validate BudgetAlertUpdateReq.
+func (req *BudgetAlertUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.State.Validate()
+}
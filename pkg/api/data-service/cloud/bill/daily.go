@@ -0,0 +1,148 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// -------------------------- Replace --------------------------
+
+// BillDailyItemReplaceReq 覆盖写入账号某一天的账单日汇总，并将该天的拉取记录置为成功
+type BillDailyItemReplaceReq struct {
+	Vendor    enumor.Vendor         `json:"vendor" validate:"required"`
+	AccountID string                `json:"account_id" validate:"required"`
+	BkBizID   int64                 `json:"bk_biz_id"`
+	BillDate  string                `json:"bill_date" validate:"required"`
+	Items     []cloud.BillDailyCost `json:"items" validate:"omitempty,max=5000,dive"`
+}
+
+// Validate BillDailyItemReplaceReq.
+func (req *BillDailyItemReplaceReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse(constant.DateLayout, req.BillDate); err != nil {
+		return fmt.Errorf("bill_date %s is invalid, err: %v", req.BillDate, err)
+	}
+
+	return nil
+}
+
+// -------------------------- SetSyncRecord --------------------------
+
+// BillSyncRecordSetReq 设置账号某一天的账单拉取记录，不存在时创建
+type BillSyncRecordSetReq struct {
+	Vendor    enumor.Vendor     `json:"vendor" validate:"required"`
+	AccountID string            `json:"account_id" validate:"required"`
+	BillDate  string            `json:"bill_date" validate:"required"`
+	State     enumor.SyncStatus `json:"state" validate:"required"`
+	Message   string            `json:"message" validate:"max=1024"`
+}
+
+// Validate BillSyncRecordSetReq.
+func (req *BillSyncRecordSetReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.State != enumor.SyncSuccess && req.State != enumor.SyncFailed {
+		return fmt.Errorf("state %s is not supported", req.State)
+	}
+
+	if _, err := time.Parse(constant.DateLayout, req.BillDate); err != nil {
+		return fmt.Errorf("bill_date %s is invalid, err: %v", req.BillDate, err)
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// BillDailyItemListResult defines list bill daily item result.
+type BillDailyItemListResult struct {
+	Count   uint64                `json:"count"`
+	Details []cloud.BillDailyItem `json:"details"`
+}
+
+// BillDailyItemListResp defines list bill daily item response.
+type BillDailyItemListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *BillDailyItemListResult `json:"data"`
+}
+
+// BillSyncRecordListResult defines list bill sync record result.
+type BillSyncRecordListResult struct {
+	Count   uint64                 `json:"count"`
+	Details []cloud.BillSyncRecord `json:"details"`
+}
+
+// BillSyncRecordListResp defines list bill sync record response.
+type BillSyncRecordListResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *BillSyncRecordListResult `json:"data"`
+}
+
+// -------------------------- Aggregate --------------------------
+
+// BillDailyAggregateReq 账单日汇总聚合查询请求，按照 group_by 中的维度汇总费用
+type BillDailyAggregateReq struct {
+	Filter  *filter.Expression `json:"filter" validate:"required"`
+	GroupBy []string           `json:"group_by" validate:"required,min=1,max=7"`
+	Page    *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate BillDailyAggregateReq.
+func (req *BillDailyAggregateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, field := range req.GroupBy {
+		if !slice.IsItemInSlice(cloud.BillDailyGroupFields, field) {
+			return fmt.Errorf("group_by field %s is not supported", field)
+		}
+	}
+
+	return req.Page.Validate(core.NewDefaultPageOption())
+}
+
+// BillDailyAggregateResult defines bill daily aggregate result.
+type BillDailyAggregateResult struct {
+	Count   uint64                     `json:"count"`
+	Details []cloud.BillDailyAggregate `json:"details"`
+}
+
+// BillDailyAggregateResp defines bill daily aggregate response.
+type BillDailyAggregateResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *BillDailyAggregateResult `json:"data"`
+}
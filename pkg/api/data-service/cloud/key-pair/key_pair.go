@@ -0,0 +1,132 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package keypair 密钥对相关的 data-service 接口定义
+package keypair
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// KeyPairBatchCreateReq key pair batch create req.
+type KeyPairBatchCreateReq[Ext corekeypair.Extension] struct {
+	KeyPairs []KeyPairBatchCreate[Ext] `json:"key_pairs" validate:"required,min=1,dive"`
+}
+
+// KeyPairBatchCreate define key pair batch create.
+type KeyPairBatchCreate[Ext corekeypair.Extension] struct {
+	CloudID     string `json:"cloud_id" validate:"required"`
+	Name        string `json:"name"`
+	AccountID   string `json:"account_id" validate:"required"`
+	BkBizID     int64  `json:"bk_biz_id" validate:"omitempty"`
+	Region      string `json:"region"`
+	Fingerprint string `json:"fingerprint"`
+	PublicKey   string `json:"public_key"`
+	// PrivateKey 已加密的私钥，仅由HCM生成的密钥对有值
+	PrivateKey       string  `json:"private_key"`
+	Memo             *string `json:"memo"`
+	CloudCreatedTime string  `json:"cloud_created_time"`
+	Extension        *Ext    `json:"extension" validate:"required"`
+}
+
+// Validate key pair batch create request.
+func (req *KeyPairBatchCreateReq[T]) Validate() error {
+	if len(req.KeyPairs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("key pairs count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// KeyPairBatchUpdateReq key pair batch update req.
+type KeyPairBatchUpdateReq[Ext corekeypair.Extension] struct {
+	KeyPairs []KeyPairBatchUpdate[Ext] `json:"key_pairs" validate:"required,min=1,dive"`
+}
+
+// KeyPairBatchUpdate define key pair batch update, private key can not be updated.
+type KeyPairBatchUpdate[Ext corekeypair.Extension] struct {
+	ID          string  `json:"id" validate:"required"`
+	Name        string  `json:"name"`
+	Fingerprint string  `json:"fingerprint"`
+	PublicKey   string  `json:"public_key"`
+	Memo        *string `json:"memo"`
+	Extension   *Ext    `json:"extension"`
+}
+
+// Validate key pair batch update request.
+func (req *KeyPairBatchUpdateReq[T]) Validate() error {
+	if len(req.KeyPairs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("key pairs count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// KeyPairBatchUpdateExprReq key pair batch update by ids request, used to assign key pair to biz.
+type KeyPairBatchUpdateExprReq struct {
+	IDs     []string `json:"ids" validate:"required,min=1"`
+	BkBizID int64    `json:"bk_biz_id" validate:"required"`
+}
+
+// Validate key pair batch update by ids request.
+func (req *KeyPairBatchUpdateExprReq) Validate() error {
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- List --------------------------
+
+// KeyPairListResult define key pair list result.
+type KeyPairListResult = core.ListResultT[corekeypair.BaseKeyPair]
+
+// KeyPairExtListResult define key pair with extension list result.
+type KeyPairExtListResult[T corekeypair.Extension] struct {
+	Count   uint64                   `json:"count,omitempty"`
+	Details []corekeypair.KeyPair[T] `json:"details,omitempty"`
+}
+
+// PrivateKeyResult define get key pair private key result, private key is encrypted.
+type PrivateKeyResult struct {
+	PrivateKey string `json:"private_key"`
+}
+
+// -------------------------- Delete --------------------------
+
+// KeyPairBatchDeleteReq key pair delete request.
+type KeyPairBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate key pair delete request.
+func (req *KeyPairBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
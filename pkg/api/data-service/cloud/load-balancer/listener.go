@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// ListenerBatchCreateReq listener batch create req.
+type ListenerBatchCreateReq struct {
+	Listeners []ListenerBatchCreate `json:"listeners" validate:"required,min=1,dive"`
+}
+
+// ListenerBatchCreate define listener batch create.
+type ListenerBatchCreate struct {
+	CloudID            string                    `json:"cloud_id" validate:"required"`
+	Name               string                    `json:"name"`
+	Vendor             enumor.Vendor             `json:"vendor" validate:"required"`
+	AccountID          string                    `json:"account_id" validate:"required"`
+	BkBizID            int64                     `json:"bk_biz_id" validate:"omitempty"`
+	LbID               string                    `json:"lb_id" validate:"required"`
+	CloudLbID          string                    `json:"cloud_lb_id" validate:"required"`
+	Protocol           string                    `json:"protocol"`
+	Port               int64                     `json:"port"`
+	CloudTargetGroupID string                    `json:"cloud_target_group_id"`
+	Extension          *corelb.ListenerExtension `json:"extension"`
+	Memo               *string                   `json:"memo"`
+}
+
+// Validate listener batch create req.
+func (req *ListenerBatchCreateReq) Validate() error {
+	if len(req.Listeners) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("listeners count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// ListenerBatchUpdateReq listener batch update req.
+type ListenerBatchUpdateReq struct {
+	Listeners []ListenerBatchUpdate `json:"listeners" validate:"required,min=1,dive"`
+}
+
+// ListenerBatchUpdate define listener batch update.
+type ListenerBatchUpdate struct {
+	ID                 string                    `json:"id" validate:"required"`
+	Name               string                    `json:"name"`
+	Protocol           string                    `json:"protocol"`
+	Port               int64                     `json:"port"`
+	CloudTargetGroupID string                    `json:"cloud_target_group_id"`
+	Extension          *corelb.ListenerExtension `json:"extension"`
+	Memo               *string                   `json:"memo"`
+}
+
+// Validate listener batch update req.
+func (req *ListenerBatchUpdateReq) Validate() error {
+	if len(req.Listeners) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("listeners count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// ListenerListResult define listener list result.
+type ListenerListResult = core.ListResultT[corelb.Listener]
+
+// ListenerBatchDeleteReq listener delete request, targets of listener will be deleted together.
+type ListenerBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate listener delete request.
+func (req *ListenerBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TargetBatchCreateReq target batch create req.
+type TargetBatchCreateReq struct {
+	Targets []TargetBatchCreate `json:"targets" validate:"required,min=1,dive"`
+}
+
+// TargetBatchCreate define target batch create.
+type TargetBatchCreate struct {
+	CloudID            string        `json:"cloud_id" validate:"required"`
+	Vendor             enumor.Vendor `json:"vendor" validate:"required"`
+	AccountID          string        `json:"account_id" validate:"required"`
+	LbID               string        `json:"lb_id" validate:"required"`
+	CloudLbID          string        `json:"cloud_lb_id" validate:"required"`
+	ListenerID         string        `json:"listener_id"`
+	CloudListenerID    string        `json:"cloud_listener_id"`
+	CloudTargetGroupID string        `json:"cloud_target_group_id"`
+	InstType           string        `json:"inst_type"`
+	CloudInstID        string        `json:"cloud_inst_id"`
+	IP                 string        `json:"ip"`
+	Port               int64         `json:"port"`
+	Weight             int64         `json:"weight"`
+}
+
+// Validate target batch create req.
+func (req *TargetBatchCreateReq) Validate() error {
+	if len(req.Targets) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("targets count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// TargetBatchUpdateReq target batch update req.
+type TargetBatchUpdateReq struct {
+	Targets []TargetBatchUpdate `json:"targets" validate:"required,min=1,dive"`
+}
+
+// TargetBatchUpdate define target batch update.
+type TargetBatchUpdate struct {
+	ID         string `json:"id" validate:"required"`
+	ListenerID string `json:"listener_id"`
+	IP         string `json:"ip"`
+	Weight     int64  `json:"weight"`
+}
+
+// Validate target batch update req.
+func (req *TargetBatchUpdateReq) Validate() error {
+	if len(req.Targets) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("targets count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// TargetListResult define target list result.
+type TargetListResult = core.ListResultT[corelb.Target]
+
+// TargetBatchDeleteReq target delete request.
+type TargetBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate target delete request.
+func (req *TargetBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
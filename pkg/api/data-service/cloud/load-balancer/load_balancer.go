@@ -0,0 +1,154 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package loadbalancer 负载均衡相关的 data-service 接口定义
+package loadbalancer
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// LoadBalancerBatchCreateReq load balancer batch create req.
+type LoadBalancerBatchCreateReq[Ext corelb.Extension] struct {
+	LoadBalancers []LoadBalancerBatchCreate[Ext] `json:"load_balancers" validate:"required,min=1,dive"`
+}
+
+// LoadBalancerBatchCreate define load balancer batch create.
+type LoadBalancerBatchCreate[Ext corelb.Extension] struct {
+	CloudID              string   `json:"cloud_id" validate:"required"`
+	Name                 string   `json:"name"`
+	AccountID            string   `json:"account_id" validate:"required"`
+	BkBizID              int64    `json:"bk_biz_id" validate:"omitempty"`
+	Region               string   `json:"region" validate:"required"`
+	Zones                []string `json:"zones"`
+	LBType               string   `json:"lb_type"`
+	IPVersion            string   `json:"ip_version"`
+	CloudVpcID           string   `json:"cloud_vpc_id"`
+	VpcID                string   `json:"vpc_id"`
+	CloudSubnetID        string   `json:"cloud_subnet_id"`
+	SubnetID             string   `json:"subnet_id"`
+	PrivateIPv4Addresses []string `json:"private_ipv4_addresses"`
+	PublicIPv4Addresses  []string `json:"public_ipv4_addresses"`
+	Domain               string   `json:"domain"`
+	Status               string   `json:"status"`
+	Memo                 *string  `json:"memo"`
+	CloudCreatedTime     string   `json:"cloud_created_time"`
+	Extension            *Ext     `json:"extension" validate:"required"`
+}
+
+// Validate load balancer batch create request.
+func (req *LoadBalancerBatchCreateReq[T]) Validate() error {
+	if len(req.LoadBalancers) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("load_balancers count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// LoadBalancerBatchUpdateReq load balancer batch update req.
+type LoadBalancerBatchUpdateReq[Ext corelb.Extension] struct {
+	LoadBalancers []LoadBalancerBatchUpdate[Ext] `json:"load_balancers" validate:"required,min=1,dive"`
+}
+
+// LoadBalancerBatchUpdate define load balancer batch update.
+type LoadBalancerBatchUpdate[Ext corelb.Extension] struct {
+	ID                   string   `json:"id" validate:"required"`
+	Name                 string   `json:"name"`
+	Zones                []string `json:"zones"`
+	LBType               string   `json:"lb_type"`
+	IPVersion            string   `json:"ip_version"`
+	CloudVpcID           string   `json:"cloud_vpc_id"`
+	VpcID                string   `json:"vpc_id"`
+	CloudSubnetID        string   `json:"cloud_subnet_id"`
+	SubnetID             string   `json:"subnet_id"`
+	PrivateIPv4Addresses []string `json:"private_ipv4_addresses"`
+	PublicIPv4Addresses  []string `json:"public_ipv4_addresses"`
+	Domain               string   `json:"domain"`
+	Status               string   `json:"status"`
+	Memo                 *string  `json:"memo"`
+	Extension            *Ext     `json:"extension"`
+}
+
+// Validate load balancer batch update request.
+func (req *LoadBalancerBatchUpdateReq[T]) Validate() error {
+	if len(req.LoadBalancers) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("load_balancers count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// LoadBalancerBizBatchUpdateReq define load balancer biz batch update req, listeners of load balancer will be
+// updated together.
+type LoadBalancerBizBatchUpdateReq struct {
+	IDs     []string `json:"ids" validate:"required"`
+	BkBizID int64    `json:"bk_biz_id" validate:"required"`
+}
+
+// Validate load balancer biz batch update req.
+func (req *LoadBalancerBizBatchUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.IDs) == 0 {
+		return errors.New("ids required")
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// LoadBalancerListResult define load balancer list result.
+type LoadBalancerListResult = core.ListResultT[corelb.BaseLoadBalancer]
+
+// LoadBalancerExtListResult define load balancer with extension list result.
+type LoadBalancerExtListResult[T corelb.Extension] struct {
+	Count   uint64                   `json:"count,omitempty"`
+	Details []corelb.LoadBalancer[T] `json:"details,omitempty"`
+}
+
+// -------------------------- Delete --------------------------
+
+// LoadBalancerBatchDeleteReq load balancer delete request, listeners and targets of load balancer will be
+// deleted together.
+type LoadBalancerBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate load balancer delete request.
+func (req *LoadBalancerBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,146 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package natgateway NAT网关相关的 data-service 接口定义
+package natgateway
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// NatGatewayBatchCreateReq nat gateway batch create req.
+type NatGatewayBatchCreateReq[Ext corenat.Extension] struct {
+	NatGateways []NatGatewayBatchCreate[Ext] `json:"nat_gateways" validate:"required,min=1,dive"`
+}
+
+// NatGatewayBatchCreate define nat gateway batch create.
+type NatGatewayBatchCreate[Ext corenat.Extension] struct {
+	CloudID           string   `json:"cloud_id" validate:"required"`
+	Name              string   `json:"name"`
+	AccountID         string   `json:"account_id" validate:"required"`
+	BkBizID           int64    `json:"bk_biz_id" validate:"omitempty"`
+	Region            string   `json:"region" validate:"required"`
+	Zone              string   `json:"zone"`
+	CloudVpcID        string   `json:"cloud_vpc_id"`
+	VpcID             string   `json:"vpc_id"`
+	CloudSubnetID     string   `json:"cloud_subnet_id"`
+	SubnetID          string   `json:"subnet_id"`
+	Spec              string   `json:"spec"`
+	PublicIPAddresses []string `json:"public_ip_addresses"`
+	Status            string   `json:"status"`
+	Memo              *string  `json:"memo"`
+	CloudCreatedTime  string   `json:"cloud_created_time"`
+	Extension         *Ext     `json:"extension" validate:"required"`
+}
+
+// Validate nat gateway batch create request.
+func (req *NatGatewayBatchCreateReq[T]) Validate() error {
+	if len(req.NatGateways) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("nat_gateways count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// NatGatewayBatchUpdateReq nat gateway batch update req.
+type NatGatewayBatchUpdateReq[Ext corenat.Extension] struct {
+	NatGateways []NatGatewayBatchUpdate[Ext] `json:"nat_gateways" validate:"required,min=1,dive"`
+}
+
+// NatGatewayBatchUpdate define nat gateway batch update.
+type NatGatewayBatchUpdate[Ext corenat.Extension] struct {
+	ID                string   `json:"id" validate:"required"`
+	Name              string   `json:"name"`
+	Zone              string   `json:"zone"`
+	CloudVpcID        string   `json:"cloud_vpc_id"`
+	VpcID             string   `json:"vpc_id"`
+	CloudSubnetID     string   `json:"cloud_subnet_id"`
+	SubnetID          string   `json:"subnet_id"`
+	Spec              string   `json:"spec"`
+	PublicIPAddresses []string `json:"public_ip_addresses"`
+	Status            string   `json:"status"`
+	Memo              *string  `json:"memo"`
+	Extension         *Ext     `json:"extension"`
+}
+
+// Validate nat gateway batch update request.
+func (req *NatGatewayBatchUpdateReq[T]) Validate() error {
+	if len(req.NatGateways) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("nat_gateways count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// NatGatewayBizBatchUpdateReq define nat gateway biz batch update req.
+type NatGatewayBizBatchUpdateReq struct {
+	IDs     []string `json:"ids" validate:"required"`
+	BkBizID int64    `json:"bk_biz_id" validate:"required"`
+}
+
+// Validate nat gateway biz batch update req.
+func (req *NatGatewayBizBatchUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.IDs) == 0 {
+		return errors.New("ids required")
+	}
+
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// -------------------------- List --------------------------
+
+// NatGatewayListResult define nat gateway list result.
+type NatGatewayListResult = core.ListResultT[corenat.BaseNatGateway]
+
+// NatGatewayExtListResult define nat gateway with extension list result.
+type NatGatewayExtListResult[T corenat.Extension] struct {
+	Count   uint64                  `json:"count,omitempty"`
+	Details []corenat.NatGateway[T] `json:"details,omitempty"`
+}
+
+// -------------------------- Delete --------------------------
+
+// NatGatewayBatchDeleteReq nat gateway delete request, rules of nat gateway will be deleted together.
+type NatGatewayBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate nat gateway delete request.
+func (req *NatGatewayBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,117 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package natgateway
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// NatGatewayRuleBatchCreateReq nat gateway rule batch create req.
+type NatGatewayRuleBatchCreateReq struct {
+	Rules []NatGatewayRuleBatchCreate `json:"rules" validate:"required,min=1,dive"`
+}
+
+// NatGatewayRuleBatchCreate define nat gateway rule batch create.
+type NatGatewayRuleBatchCreate struct {
+	CloudID           string                           `json:"cloud_id" validate:"required"`
+	Vendor            enumor.Vendor                    `json:"vendor" validate:"required"`
+	AccountID         string                           `json:"account_id" validate:"required"`
+	NatGatewayID      string                           `json:"nat_gateway_id" validate:"required"`
+	CloudNatGatewayID string                           `json:"cloud_nat_gateway_id" validate:"required"`
+	RuleType          enumor.NatRuleType               `json:"rule_type" validate:"required"`
+	Protocol          string                           `json:"protocol"`
+	CloudSubnetID     string                           `json:"cloud_subnet_id"`
+	SourceCidr        string                           `json:"source_cidr"`
+	PublicIP          string                           `json:"public_ip"`
+	PublicPort        int64                            `json:"public_port"`
+	PrivateIP         string                           `json:"private_ip"`
+	PrivatePort       int64                            `json:"private_port"`
+	Status            string                           `json:"status"`
+	Memo              *string                          `json:"memo"`
+	Extension         *corenat.NatGatewayRuleExtension `json:"extension"`
+}
+
+// Validate nat gateway rule batch create req.
+func (req *NatGatewayRuleBatchCreateReq) Validate() error {
+	if len(req.Rules) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("rules count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, one := range req.Rules {
+		if err := one.RuleType.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NatGatewayRuleBatchUpdateReq nat gateway rule batch update req.
+type NatGatewayRuleBatchUpdateReq struct {
+	Rules []NatGatewayRuleBatchUpdate `json:"rules" validate:"required,min=1,dive"`
+}
+
+// NatGatewayRuleBatchUpdate define nat gateway rule batch update.
+type NatGatewayRuleBatchUpdate struct {
+	ID            string                           `json:"id" validate:"required"`
+	Protocol      string                           `json:"protocol"`
+	CloudSubnetID string                           `json:"cloud_subnet_id"`
+	SourceCidr    string                           `json:"source_cidr"`
+	PublicIP      string                           `json:"public_ip"`
+	PublicPort    int64                            `json:"public_port"`
+	PrivateIP     string                           `json:"private_ip"`
+	PrivatePort   int64                            `json:"private_port"`
+	Status        string                           `json:"status"`
+	Memo          *string                          `json:"memo"`
+	Extension     *corenat.NatGatewayRuleExtension `json:"extension"`
+}
+
+// Validate nat gateway rule batch update req.
+func (req *NatGatewayRuleBatchUpdateReq) Validate() error {
+	if len(req.Rules) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("rules count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// NatGatewayRuleListResult define nat gateway rule list result.
+type NatGatewayRuleListResult = core.ListResultT[corenat.NatGatewayRule]
+
+// NatGatewayRuleBatchDeleteReq nat gateway rule delete request.
+type NatGatewayRuleBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate nat gateway rule delete request.
+func (req *NatGatewayRuleBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package resourcetag 资源标签相关的 data-service 接口定义
+package resourcetag
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coretag "hcm/pkg/api/core/cloud/resource-tag"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// ResTagBatchReplaceReq 批量替换资源标签，请求中资源的标签会被全量覆盖，标签为空表示清空该资源的标签
+type ResTagBatchReplaceReq struct {
+	Vendor    enumor.Vendor            `json:"vendor" validate:"required"`
+	AccountID string                   `json:"account_id" validate:"required"`
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Resources []ResTagReplaceItem      `json:"resources" validate:"required,min=1,dive"`
+}
+
+// ResTagReplaceItem define resource tags to replace.
+type ResTagReplaceItem struct {
+	CloudResID string            `json:"cloud_res_id" validate:"required"`
+	Tags       []coretag.TagPair `json:"tags" validate:"omitempty,dive"`
+}
+
+// Validate resource tag batch replace request.
+func (req *ResTagBatchReplaceReq) Validate() error {
+	if len(req.Resources) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("resources count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	if err := req.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// ResTagListResult define resource tag list result.
+type ResTagListResult = core.ListResultT[coretag.ResourceTag]
@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package snapshot
+
+import (
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/tools/cron"
+)
+
+// PolicyCreateReq define snapshot policy create request.
+type PolicyCreateReq struct {
+	Name           string                     `json:"name" validate:"required,max=255"`
+	Vendor         enumor.Vendor              `json:"vendor" validate:"required"`
+	AccountID      string                     `json:"account_id" validate:"required,max=64"`
+	BkBizID        int64                      `json:"bk_biz_id" validate:"omitempty"`
+	DiskIDs        []string                   `json:"disk_ids" validate:"required,min=1,max=100"`
+	Cron           string                     `json:"cron" validate:"required,max=64"`
+	RetentionCount uint                       `json:"retention_count" validate:"required,min=1,max=100"`
+	State          enumor.SnapshotPolicyState `json:"state" validate:"required"`
+	Memo           *string                    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate PolicyCreateReq.
+func (req *PolicyCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.State.Validate(); err != nil {
+		return err
+	}
+
+	_, err := cron.Parse(req.Cron)
+	return err
+}
+
+// PolicyUpdateReq define snapshot policy update request, vendor and account of policy can not be updated.
+type PolicyUpdateReq struct {
+	Name           string                     `json:"name" validate:"omitempty,max=255"`
+	BkBizID        int64                      `json:"bk_biz_id" validate:"omitempty"`
+	DiskIDs        []string                   `json:"disk_ids" validate:"omitempty,max=100"`
+	Cron           string                     `json:"cron" validate:"omitempty,max=64"`
+	RetentionCount uint                       `json:"retention_count" validate:"omitempty,max=100"`
+	State          enumor.SnapshotPolicyState `json:"state" validate:"omitempty"`
+	// NextRunAt 下次执行时间，仅由快照策略执行循环在创建任务流后推进
+	NextRunAt  *time.Time `json:"next_run_at" validate:"omitempty"`
+	LastFlowID string     `json:"last_flow_id" validate:"omitempty,max=64"`
+	Memo       *string    `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate PolicyUpdateReq.
+func (req *PolicyUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && req.BkBizID == 0 && len(req.DiskIDs) == 0 && len(req.Cron) == 0 &&
+		req.RetentionCount == 0 && len(req.State) == 0 && req.NextRunAt == nil && len(req.LastFlowID) == 0 &&
+		req.Memo == nil {
+		return errors.New("not found update field")
+	}
+
+	if len(req.State) != 0 {
+		if err := req.State.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(req.Cron) != 0 {
+		if _, err := cron.Parse(req.Cron); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package snapshot 快照相关的 data-service 接口定义
+package snapshot
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// -------------------------- Create --------------------------
+
+// SnapshotBatchCreateReq snapshot batch create req.
+type SnapshotBatchCreateReq[Ext coresnapshot.Extension] struct {
+	Snapshots []SnapshotBatchCreate[Ext] `json:"snapshots" validate:"required,min=1,dive"`
+}
+
+// SnapshotBatchCreate define snapshot batch create.
+type SnapshotBatchCreate[Ext coresnapshot.Extension] struct {
+	CloudID          string  `json:"cloud_id" validate:"required"`
+	Name             string  `json:"name"`
+	AccountID        string  `json:"account_id" validate:"required"`
+	BkBizID          int64   `json:"bk_biz_id" validate:"omitempty"`
+	Region           string  `json:"region"`
+	Zone             string  `json:"zone"`
+	CloudDiskID      string  `json:"cloud_disk_id"`
+	DiskID           string  `json:"disk_id"`
+	Size             uint64  `json:"size"`
+	Status           string  `json:"status"`
+	PolicyID         string  `json:"policy_id"`
+	Memo             *string `json:"memo"`
+	CloudCreatedTime string  `json:"cloud_created_time"`
+	Extension        *Ext    `json:"extension" validate:"required"`
+}
+
+// Validate snapshot batch create request.
+func (req *SnapshotBatchCreateReq[T]) Validate() error {
+	if len(req.Snapshots) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("snapshots count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// SnapshotBatchUpdateReq snapshot batch update req.
+type SnapshotBatchUpdateReq[Ext coresnapshot.Extension] struct {
+	Snapshots []SnapshotBatchUpdate[Ext] `json:"snapshots" validate:"required,min=1,dive"`
+}
+
+// SnapshotBatchUpdate define snapshot batch update, policy id is only updated when it is not empty, so that
+// snapshots created by policy will not be detached by sync.
+type SnapshotBatchUpdate[Ext coresnapshot.Extension] struct {
+	ID        string  `json:"id" validate:"required"`
+	Name      string  `json:"name"`
+	BkBizID   int64   `json:"bk_biz_id"`
+	DiskID    string  `json:"disk_id"`
+	Size      uint64  `json:"size"`
+	Status    string  `json:"status"`
+	PolicyID  string  `json:"policy_id"`
+	Memo      *string `json:"memo"`
+	Extension *Ext    `json:"extension"`
+}
+
+// Validate snapshot batch update request.
+func (req *SnapshotBatchUpdateReq[T]) Validate() error {
+	if len(req.Snapshots) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("snapshots count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- List --------------------------
+
+// SnapshotListResult define snapshot list result.
+type SnapshotListResult = core.ListResultT[coresnapshot.BaseSnapshot]
+
+// SnapshotExtListResult define snapshot with extension list result.
+type SnapshotExtListResult[T coresnapshot.Extension] struct {
+	Count   uint64                     `json:"count,omitempty"`
+	Details []coresnapshot.Snapshot[T] `json:"details,omitempty"`
+}
+
+// -------------------------- Delete --------------------------
+
+// SnapshotBatchDeleteReq snapshot delete request.
+type SnapshotBatchDeleteReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+}
+
+// Validate snapshot delete request.
+func (req *SnapshotBatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
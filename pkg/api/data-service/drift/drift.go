@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dsdrift 资源配置漂移检测相关的 data-service 接口定义
+package dsdrift
+
+import (
+	"fmt"
+
+	coredrift "hcm/pkg/api/core/drift"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// DesiredStateBatchCreateReq define desired state batch create request, the desired state of the same resource
+// will be replaced.
+type DesiredStateBatchCreateReq struct {
+	States []DesiredStateCreate `json:"states" validate:"required,min=1,max=100,dive"`
+}
+
+// DesiredStateCreate define desired state create option.
+type DesiredStateCreate struct {
+	ResType       enumor.CloudResourceType `json:"res_type" validate:"required"`
+	ResID         string                   `json:"res_id" validate:"required"`
+	CloudResID    string                   `json:"cloud_res_id" validate:"omitempty"`
+	Vendor        enumor.Vendor            `json:"vendor" validate:"required"`
+	AccountID     string                   `json:"account_id" validate:"required"`
+	BkBizID       int64                    `json:"bk_biz_id" validate:"omitempty"`
+	ApplicationID string                   `json:"application_id" validate:"omitempty"`
+	Spec          coredrift.Spec           `json:"spec" validate:"required"`
+}
+
+// Validate DesiredStateBatchCreateReq.
+func (req *DesiredStateBatchCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// DesiredStateBatchUpdateReq define desired state batch update request, used to save drift check result, checked_at
+// will be set to the update time.
+type DesiredStateBatchUpdateReq struct {
+	States []DesiredStateUpdate `json:"states" validate:"required,min=1,max=100,dive"`
+}
+
+// DesiredStateUpdate define desired state update option.
+type DesiredStateUpdate struct {
+	ID          string                `json:"id" validate:"required"`
+	DriftStatus enumor.DriftStatus    `json:"drift_status" validate:"required"`
+	DriftDetail []coredrift.FieldDiff `json:"drift_detail" validate:"omitempty"`
+}
+
+// Validate DesiredStateBatchUpdateReq.
+func (req *DesiredStateBatchUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	for _, one := range req.States {
+		if one.DriftStatus == enumor.DriftedDriftStatus && len(one.DriftDetail) == 0 {
+			return fmt.Errorf("drift_detail is required when desired state: %s is drifted", one.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dsguardrail 资源创建管控规则相关的 data-service 接口定义
+package dsguardrail
+
+import (
+	"errors"
+
+	coreguardrail "hcm/pkg/api/core/guardrail"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// RuleCreateReq define guardrail rule create request.
+type RuleCreateReq struct {
+	Name       string                   `json:"name" validate:"required,max=255"`
+	BkBizID    int64                    `json:"bk_biz_id" validate:"required"`
+	ResType    enumor.CloudResourceType `json:"res_type" validate:"required"`
+	Vendor     enumor.Vendor            `json:"vendor" validate:"omitempty"`
+	Expression *filter.Expression       `json:"expression" validate:"required"`
+	Message    string                   `json:"message" validate:"required,max=255"`
+	Enabled    *bool                    `json:"enabled" validate:"required"`
+	Memo       *string                  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate RuleCreateReq.
+func (req *RuleCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := coreguardrail.ValidateResType(req.ResType); err != nil {
+		return err
+	}
+
+	if len(req.Vendor) != 0 {
+		if err := req.Vendor.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return coreguardrail.ValidateExpression(req.Expression)
+}
+
+// RuleUpdateReq define guardrail rule update request, scope of rule can not be updated.
+type RuleUpdateReq struct {
+	Name       string             `json:"name" validate:"omitempty,max=255"`
+	Expression *filter.Expression `json:"expression" validate:"omitempty"`
+	Message    string             `json:"message" validate:"omitempty,max=255"`
+	Enabled    *bool              `json:"enabled" validate:"omitempty"`
+	Memo       *string            `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate RuleUpdateReq.
+func (req *RuleUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && req.Expression == nil && len(req.Message) == 0 && req.Enabled == nil &&
+		req.Memo == nil {
+		return errors.New("not found update field")
+	}
+
+	if req.Expression != nil {
+		return coreguardrail.ValidateExpression(req.Expression)
+	}
+
+	return nil
+}
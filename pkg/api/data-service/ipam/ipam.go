@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dsipam IP地址管理相关的 data-service 接口定义
+package dsipam
+
+import (
+	"errors"
+
+	coreipam "hcm/pkg/api/core/ipam"
+	"hcm/pkg/criteria/validator"
+)
+
+// PoolCreateReq define ipam pool create request.
+type PoolCreateReq struct {
+	Name          string  `json:"name" validate:"required,max=255"`
+	Cidr          string  `json:"cidr" validate:"required,cidrv4"`
+	Region        string  `json:"region" validate:"omitempty,max=64"`
+	BkBizID       int64   `json:"bk_biz_id" validate:"omitempty"`
+	VpcMaskLen    uint    `json:"vpc_mask_len" validate:"required"`
+	SubnetMaskLen uint    `json:"subnet_mask_len" validate:"required"`
+	Memo          *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate PoolCreateReq.
+func (req *PoolCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return coreipam.ValidatePool(req.Cidr, req.VpcMaskLen, req.SubnetMaskLen)
+}
+
+// PoolUpdateReq define ipam pool update request, cidr and scope of pool can not be updated.
+type PoolUpdateReq struct {
+	Name          string  `json:"name" validate:"omitempty,max=255"`
+	VpcMaskLen    uint    `json:"vpc_mask_len" validate:"omitempty,max=32"`
+	SubnetMaskLen uint    `json:"subnet_mask_len" validate:"omitempty,max=32"`
+	Memo          *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate PoolUpdateReq.
+func (req *PoolUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && req.VpcMaskLen == 0 && req.SubnetMaskLen == 0 && req.Memo == nil {
+		return errors.New("not found update field")
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dslease 资源租约相关的 data-service 接口定义
+package dslease
+
+import (
+	"errors"
+	"time"
+
+	corelease "hcm/pkg/api/core/lease"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// LeaseCreateReq define resource lease create request.
+type LeaseCreateReq struct {
+	ResType  enumor.CloudResourceType `json:"res_type" validate:"required"`
+	ResID    string                   `json:"res_id" validate:"required,max=64"`
+	Vendor   enumor.Vendor            `json:"vendor" validate:"required"`
+	BkBizID  int64                    `json:"bk_biz_id" validate:"required"`
+	Owner    string                   `json:"owner" validate:"required,max=64"`
+	ExpireAt time.Time                `json:"expire_at" validate:"required"`
+	Memo     *string                  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate LeaseCreateReq.
+func (req *LeaseCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := corelease.ValidateResType(req.ResType); err != nil {
+		return err
+	}
+
+	return req.Vendor.Validate()
+}
+
+// LeaseUpdateReq define resource lease update request, resource of lease can not be updated.
+type LeaseUpdateReq struct {
+	BkBizID  int64             `json:"bk_biz_id" validate:"omitempty"`
+	Owner    string            `json:"owner" validate:"omitempty,max=64"`
+	ExpireAt *time.Time        `json:"expire_at" validate:"omitempty"`
+	Reminded *bool             `json:"reminded" validate:"omitempty"`
+	State    enumor.LeaseState `json:"state" validate:"omitempty"`
+	Memo     *string           `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate LeaseUpdateReq.
+func (req *LeaseUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.BkBizID == 0 && len(req.Owner) == 0 && req.ExpireAt == nil && req.Reminded == nil &&
+		len(req.State) == 0 && req.Memo == nil {
+		return errors.New("not found update field")
+	}
+
+	if len(req.State) != 0 {
+		return req.State.Validate()
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dsstack 资源栈相关的 data-service 接口定义
+package dsstack
+
+import (
+	"errors"
+
+	corestack "hcm/pkg/api/core/stack"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// StackCreateReq define stack create request, the template content will be saved as version 1.
+type StackCreateReq struct {
+	Name      string                     `json:"name" validate:"required,lte=255"`
+	Vendor    enumor.Vendor              `json:"vendor" validate:"required"`
+	AccountID string                     `json:"account_id" validate:"required"`
+	Region    string                     `json:"region" validate:"required"`
+	BkBizID   int64                      `json:"bk_biz_id" validate:"required"`
+	Format    enumor.StackTemplateFormat `json:"format" validate:"required"`
+	Content   string                     `json:"content" validate:"required"`
+	Memo      *string                    `json:"memo" validate:"omitempty,lte=255"`
+}
+
+// Validate StackCreateReq.
+func (req *StackCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// StackUpdateReq define stack update request, nil resources means resources will not be updated.
+type StackUpdateReq struct {
+	Status         enumor.StackStatus   `json:"status" validate:"omitempty"`
+	FlowID         string               `json:"flow_id" validate:"omitempty"`
+	AppliedVersion uint                 `json:"applied_version" validate:"omitempty"`
+	Resources      []corestack.Resource `json:"resources" validate:"omitempty"`
+	Memo           *string              `json:"memo" validate:"omitempty,lte=255"`
+}
+
+// Validate StackUpdateReq.
+func (req *StackUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Status) == 0 && len(req.FlowID) == 0 && req.AppliedVersion == 0 && req.Resources == nil &&
+		req.Memo == nil {
+		return errors.New("at least one field needs to be updated")
+	}
+
+	return nil
+}
+
+// VersionCreateReq define stack version create request, the version number is the latest version of stack plus 1.
+type VersionCreateReq struct {
+	StackID string                     `json:"stack_id" validate:"required"`
+	Format  enumor.StackTemplateFormat `json:"format" validate:"required"`
+	Content string                     `json:"content" validate:"required"`
+}
+
+// Validate VersionCreateReq.
+func (req *VersionCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// VersionCreateResult define stack version create result.
+type VersionCreateResult struct {
+	ID      string `json:"id"`
+	Version uint   `json:"version"`
+}
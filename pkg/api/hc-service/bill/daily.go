@@ -0,0 +1,65 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/rest"
+)
+
+// -------------------------- PullDaily --------------------------
+
+// BillDailyPullReq 拉取资源账号某一天的云账单，并按照地域、产品、币种汇总
+type BillDailyPullReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	// BillDate 账单日期，格式为yyyy-mm-dd
+	BillDate string `json:"bill_date" validate:"required"`
+	// BillAccountID gcp账单导出所在的账号ID，为空时使用已配置的gcp账单信息，其他云厂商无需传入
+	BillAccountID string `json:"bill_account_id" validate:"omitempty"`
+}
+
+// Validate bill daily pull req.
+func (req BillDailyPullReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse(constant.DateLayout, req.BillDate); err != nil {
+		return fmt.Errorf("bill_date %s is invalid, err: %v", req.BillDate, err)
+	}
+
+	return nil
+}
+
+// BillDailyPullResult define bill daily pull result.
+type BillDailyPullResult struct {
+	Items []cloud.BillDailyCost `json:"items"`
+}
+
+// BillDailyPullResp define bill daily pull resp.
+type BillDailyPullResp struct {
+	rest.BaseResp `json:",inline"`
+	Data          *BillDailyPullResult `json:"data"`
+}
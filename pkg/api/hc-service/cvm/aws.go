@@ -126,9 +126,11 @@ type AwsBatchCreateReq struct {
 	PublicIPAssigned      bool                            `json:"public_ip_assigned" validate:"omitempty"`
 	CloudSecurityGroupIDs []string                        `json:"cloud_security_group_ids" validate:"required"`
 	BlockDeviceMapping    []typecvm.AwsBlockDeviceMapping `json:"block_device_mapping" validate:"required"`
-	Password              string                          `json:"password" validate:"required"`
+	Password              string                          `json:"password" validate:"required_without=KeyPairID"`
 	RequiredCount         int64                           `json:"required_count" validate:"required"`
 	ClientToken           *string                         `json:"client_token" validate:"omitempty"`
+	// KeyPairID 登录使用的密钥对ID，与密码二选一
+	KeyPairID string `json:"key_pair_id" validate:"omitempty"`
 }
 
 // Validate request.
@@ -56,7 +56,7 @@ type GcpBatchCreateReq struct {
 	Zone          string `json:"zone" validate:"required"`
 	InstanceType  string `json:"instance_type" validate:"required"`
 	CloudImageID  string `json:"cloud_image_id" validate:"required"`
-	Password      string `json:"password" validate:"required"`
+	Password      string `json:"password" validate:"required_without=KeyPairID"`
 	RequiredCount int64  `json:"required_count" validate:"required"`
 	// RequestID 唯一标识支持生产请求
 	RequestID        string                `json:"request_id" validate:"omitempty"`
@@ -66,6 +66,8 @@ type GcpBatchCreateReq struct {
 	SystemDisk       *typecvm.GcpOsDisk    `json:"system_disk" validate:"required"`
 	DataDisk         []typecvm.GcpDataDisk `json:"data_disk" validate:"omitempty"`
 	PublicIPAssigned bool                  `json:"public_ip_assigned" validate:"omitempty"`
+	// KeyPairID 登录使用的密钥对ID，与密码二选一
+	KeyPairID string `json:"key_pair_id" validate:"omitempty"`
 }
 
 // Validate request.
@@ -141,7 +141,7 @@ type HuaWeiBatchCreateReq struct {
 	Zone                  string                        `json:"zone" validate:"required"`
 	InstanceType          string                        `json:"instance_type" validate:"required"`
 	CloudImageID          string                        `json:"cloud_image_id" validate:"required"`
-	Password              string                        `json:"password" validate:"required"`
+	Password              string                        `json:"password" validate:"required_without=KeyPairID"`
 	RequiredCount         int32                         `json:"required_count" validate:"required"`
 	CloudSecurityGroupIDs []string                      `json:"cloud_security_group_ids" validate:"required"`
 	ClientToken           *string                       `json:"client_token" validate:"omitempty"`
@@ -153,6 +153,8 @@ type HuaWeiBatchCreateReq struct {
 	InstanceCharge        *typecvm.HuaWeiInstanceCharge `json:"instance_charge" validate:"required"`
 	PublicIPAssigned      bool                          `json:"public_ip_assigned" validate:"omitempty"`
 	Eip                   *typecvm.HuaWeiEip            `json:"eip" validate:"omitempty"`
+	// KeyPairID 登录使用的密钥对ID，与密码二选一
+	KeyPairID string `json:"key_pair_id" validate:"omitempty"`
 }
 
 // Validate request.
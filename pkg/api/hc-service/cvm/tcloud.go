@@ -20,6 +20,7 @@
 package hccvm
 
 import (
+	"errors"
 	"fmt"
 
 	typecvm "hcm/pkg/adaptor/types/cvm"
@@ -123,7 +124,7 @@ type TCloudBatchCreateReq struct {
 	Zone                    string                               `json:"zone" validate:"required"`
 	InstanceType            string                               `json:"instance_type" validate:"required"`
 	CloudImageID            string                               `json:"cloud_image_id" validate:"required"`
-	Password                string                               `json:"password" validate:"required"`
+	Password                string                               `json:"password" validate:"omitempty"`
 	RequiredCount           int64                                `json:"required_count" validate:"required"`
 	CloudSecurityGroupIDs   []string                             `json:"cloud_security_group_ids" validate:"required"`
 	ClientToken             *string                              `json:"client_token" validate:"omitempty"`
@@ -135,6 +136,8 @@ type TCloudBatchCreateReq struct {
 	DataDisk                []typecvm.TCloudDataDisk             `json:"data_disk" validate:"omitempty"`
 	PublicIPAssigned        bool                                 `json:"public_ip_assigned" validate:"omitempty"`
 	InternetMaxBandwidthOut int64                                `json:"internet_max_bandwidth_out" validate:"omitempty"`
+	// KeyPairID 登录使用的密钥对ID，与密码二选一
+	KeyPairID string `json:"key_pair_id" validate:"omitempty"`
 }
 
 // Validate request.
@@ -143,6 +146,10 @@ func (req *TCloudBatchCreateReq) Validate() error {
 		return fmt.Errorf("required_count should <= %d", constant.BatchCreateCvmFromCloudMaxLimit)
 	}
 
+	if len(req.Password) == 0 && len(req.KeyPairID) == 0 {
+		return errors.New("password or key_pair_id is required")
+	}
+
 	return validator.Validate.Struct(req)
 }
 
@@ -0,0 +1,43 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package hckeypair 密钥对相关的 hc-service 接口定义
+package hckeypair
+
+import (
+	"hcm/pkg/criteria/validator"
+)
+
+// KeyPairCreateReq key pair create request, public key is imported to cloud and encrypted private key is saved.
+type KeyPairCreateReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	// Region 腾讯云、谷歌云的密钥对与地域无关，可不指定
+	Region    string `json:"region" validate:"omitempty"`
+	Name      string `json:"name" validate:"required,max=64"`
+	PublicKey string `json:"public_key" validate:"required"`
+	// PrivateKey 已加密的私钥
+	PrivateKey string  `json:"private_key" validate:"omitempty"`
+	BkBizID    int64   `json:"bk_biz_id" validate:"omitempty"`
+	Memo       *string `json:"memo" validate:"omitempty"`
+}
+
+// Validate key pair create request.
+func (req *KeyPairCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,157 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package hclb 负载均衡相关的 hc-service 接口定义
+package hclb
+
+import (
+	"fmt"
+
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/validator"
+)
+
+// TCloudLoadBalancerCreateReq tcloud load balancer create request.
+type TCloudLoadBalancerCreateReq struct {
+	AccountID                 string `json:"account_id" validate:"required"`
+	BkBizID                   int64  `json:"bk_biz_id" validate:"omitempty"`
+	typelb.TCloudCreateOption `json:",inline"`
+}
+
+// Validate tcloud load balancer create request.
+func (req *TCloudLoadBalancerCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.TCloudCreateOption.Validate()
+}
+
+// AwsLoadBalancerCreateReq aws load balancer create request.
+type AwsLoadBalancerCreateReq struct {
+	AccountID              string `json:"account_id" validate:"required"`
+	BkBizID                int64  `json:"bk_biz_id" validate:"omitempty"`
+	typelb.AwsCreateOption `json:",inline"`
+}
+
+// Validate aws load balancer create request.
+func (req *AwsLoadBalancerCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.AwsCreateOption.Validate()
+}
+
+// HuaWeiLoadBalancerCreateReq huawei load balancer create request.
+type HuaWeiLoadBalancerCreateReq struct {
+	AccountID                 string `json:"account_id" validate:"required"`
+	BkBizID                   int64  `json:"bk_biz_id" validate:"omitempty"`
+	typelb.HuaWeiCreateOption `json:",inline"`
+}
+
+// Validate huawei load balancer create request.
+func (req *HuaWeiLoadBalancerCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.HuaWeiCreateOption.Validate()
+}
+
+// GcpLoadBalancerCreateReq gcp load balancer create request.
+type GcpLoadBalancerCreateReq struct {
+	AccountID              string `json:"account_id" validate:"required"`
+	BkBizID                int64  `json:"bk_biz_id" validate:"omitempty"`
+	typelb.GcpCreateOption `json:",inline"`
+}
+
+// Validate gcp load balancer create request.
+func (req *GcpLoadBalancerCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.GcpCreateOption.Validate()
+}
+
+// AzureLoadBalancerCreateReq azure load balancer create request.
+type AzureLoadBalancerCreateReq struct {
+	AccountID                string `json:"account_id" validate:"required"`
+	BkBizID                  int64  `json:"bk_biz_id" validate:"omitempty"`
+	typelb.AzureCreateOption `json:",inline"`
+}
+
+// Validate azure load balancer create request.
+func (req *AzureLoadBalancerCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.AzureCreateOption.Validate()
+}
+
+// ListenerCreateReq listener create request.
+type ListenerCreateReq struct {
+	LbID string `json:"lb_id" validate:"required"`
+	Name string `json:"name" validate:"required"`
+	// Protocol 监听协议，取值依赖云厂商，如 TCP、UDP、HTTP、HTTPS
+	Protocol string `json:"protocol" validate:"required"`
+	Port     int64  `json:"port" validate:"required,min=1,max=65535"`
+	// CloudTargetGroupID 为空时，aws、huawei 会自动创建目标组，azure 使用默认后端地址池
+	CloudTargetGroupID string                    `json:"cloud_target_group_id" validate:"omitempty"`
+	Extension          *corelb.ListenerExtension `json:"extension" validate:"omitempty"`
+}
+
+// Validate listener create request.
+func (req *ListenerCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ListenerBatchDeleteReq listener batch delete request, listeners must belong to the same load balancer.
+type ListenerBatchDeleteReq struct {
+	LbID string   `json:"lb_id" validate:"required"`
+	IDs  []string `json:"ids" validate:"required,min=1"`
+}
+
+// Validate listener batch delete request.
+func (req *ListenerBatchDeleteReq) Validate() error {
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// TargetOperateReq register or deregister target request.
+type TargetOperateReq struct {
+	ListenerID string              `json:"listener_id" validate:"required"`
+	Targets    []typelb.TargetInfo `json:"targets" validate:"required,min=1,dive"`
+}
+
+// Validate target operate request.
+func (req *TargetOperateReq) Validate() error {
+	if len(req.Targets) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("targets count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package hclb
+
+import (
+	"testing"
+
+	typelb "hcm/pkg/adaptor/types/load-balancer"
+	"hcm/pkg/criteria/constant"
+)
+
+func TestLoadBalancerCreateReqValidate(t *testing.T) {
+	req := &TCloudLoadBalancerCreateReq{
+		AccountID:          "00000001",
+		TCloudCreateOption: typelb.TCloudCreateOption{Region: "ap-guangzhou", Name: "lb", LBType: "INTERNAL"},
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("validate tcloud load balancer create request failed, err: %v", err)
+	}
+
+	req.AccountID = ""
+	if err := req.Validate(); err == nil {
+		t.Errorf("validate tcloud load balancer create request without account id should failed")
+	}
+
+	// 内嵌的创建参数校验需要透传到请求校验中
+	azure := &AzureLoadBalancerCreateReq{
+		AccountID:         "00000001",
+		AzureCreateOption: typelb.AzureCreateOption{ResourceGroupName: "rg", Region: "eastus", Name: "lb"},
+	}
+	if err := azure.Validate(); err == nil {
+		t.Errorf("validate azure load balancer create request without frontend should failed")
+	}
+}
+
+func TestListenerReqValidate(t *testing.T) {
+	create := &ListenerCreateReq{LbID: "00000001", Name: "listener", Protocol: "TCP", Port: 443}
+	if err := create.Validate(); err != nil {
+		t.Fatalf("validate listener create request failed, err: %v", err)
+	}
+
+	create.Port = 0
+	if err := create.Validate(); err == nil {
+		t.Errorf("validate listener create request with port 0 should failed")
+	}
+
+	del := &ListenerBatchDeleteReq{LbID: "00000001", IDs: make([]string, constant.BatchOperationMaxLimit)}
+	for i := range del.IDs {
+		del.IDs[i] = "00000002"
+	}
+	if err := del.Validate(); err != nil {
+		t.Fatalf("validate listener batch delete request failed, err: %v", err)
+	}
+
+	del.IDs = append(del.IDs, "00000003")
+	if err := del.Validate(); err == nil {
+		t.Errorf("validate listener batch delete request exceeds limit should failed")
+	}
+
+	del.IDs = nil
+	if err := del.Validate(); err == nil {
+		t.Errorf("validate listener batch delete request without ids should failed")
+	}
+}
+
+func TestTargetOperateReqValidate(t *testing.T) {
+	target := typelb.TargetInfo{InstType: "CVM", CloudInstID: "ins-1", Port: 80}
+	req := &TargetOperateReq{ListenerID: "00000001", Targets: []typelb.TargetInfo{target}}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("validate target operate request failed, err: %v", err)
+	}
+
+	req.Targets = []typelb.TargetInfo{{CloudInstID: "ins-1", Port: 80}}
+	if err := req.Validate(); err == nil {
+		t.Errorf("validate target operate request without inst type should failed")
+	}
+
+	req.Targets = make([]typelb.TargetInfo, constant.BatchOperationMaxLimit+1)
+	for i := range req.Targets {
+		req.Targets[i] = target
+	}
+	if err := req.Validate(); err == nil {
+		t.Errorf("validate target operate request exceeds limit should failed")
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package hcnat NAT网关相关的 hc-service 接口定义
+package hcnat
+
+import (
+	"fmt"
+
+	typenat "hcm/pkg/adaptor/types/nat-gateway"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// TCloudNatGatewayCreateReq tcloud nat gateway create request.
+type TCloudNatGatewayCreateReq struct {
+	AccountID                  string `json:"account_id" validate:"required"`
+	BkBizID                    int64  `json:"bk_biz_id" validate:"omitempty"`
+	typenat.TCloudCreateOption `json:",inline"`
+}
+
+// Validate tcloud nat gateway create request.
+func (req *TCloudNatGatewayCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.TCloudCreateOption.Validate()
+}
+
+// AwsNatGatewayCreateReq aws nat gateway create request.
+type AwsNatGatewayCreateReq struct {
+	AccountID               string `json:"account_id" validate:"required"`
+	BkBizID                 int64  `json:"bk_biz_id" validate:"omitempty"`
+	typenat.AwsCreateOption `json:",inline"`
+}
+
+// Validate aws nat gateway create request.
+func (req *AwsNatGatewayCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.AwsCreateOption.Validate()
+}
+
+// HuaWeiNatGatewayCreateReq huawei nat gateway create request.
+type HuaWeiNatGatewayCreateReq struct {
+	AccountID                  string `json:"account_id" validate:"required"`
+	BkBizID                    int64  `json:"bk_biz_id" validate:"omitempty"`
+	typenat.HuaWeiCreateOption `json:",inline"`
+}
+
+// Validate huawei nat gateway create request.
+func (req *HuaWeiNatGatewayCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.HuaWeiCreateOption.Validate()
+}
+
+// NatRuleCreateReq nat gateway snat/dnat rule create request.
+type NatRuleCreateReq struct {
+	NatGatewayID string             `json:"nat_gateway_id" validate:"required"`
+	RuleType     enumor.NatRuleType `json:"rule_type" validate:"required"`
+	// SubnetID、SourceCidr SNAT规则的源，至少指定一个，tcloud 只支持子网
+	SubnetID   string `json:"subnet_id" validate:"omitempty"`
+	SourceCidr string `json:"source_cidr" validate:"omitempty,cidr"`
+	// EipID 规则使用的弹性公网IP
+	EipID       string  `json:"eip_id" validate:"required"`
+	Protocol    string  `json:"protocol" validate:"omitempty"`
+	PublicPort  int64   `json:"public_port" validate:"omitempty,min=0,max=65535"`
+	PrivateIP   string  `json:"private_ip" validate:"omitempty,ip"`
+	PrivatePort int64   `json:"private_port" validate:"omitempty,min=0,max=65535"`
+	Memo        *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate nat gateway rule create request.
+func (req *NatRuleCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.RuleType.Validate(); err != nil {
+		return err
+	}
+
+	switch req.RuleType {
+	case enumor.SnatRuleType:
+		if len(req.SubnetID) == 0 && len(req.SourceCidr) == 0 {
+			return fmt.Errorf("one of subnet_id and source_cidr is required for snat rule")
+		}
+	case enumor.DnatRuleType:
+		if len(req.Protocol) == 0 || len(req.PrivateIP) == 0 {
+			return fmt.Errorf("protocol and private_ip is required for dnat rule")
+		}
+	}
+
+	return nil
+}
+
+// NatRuleUpdateReq nat gateway snat/dnat rule update request, private_ip and private_port only used by dnat rule.
+type NatRuleUpdateReq struct {
+	NatGatewayID string  `json:"nat_gateway_id" validate:"required"`
+	Memo         *string `json:"memo" validate:"omitempty,max=255"`
+	PrivateIP    string  `json:"private_ip" validate:"omitempty,ip"`
+	PrivatePort  int64   `json:"private_port" validate:"omitempty,min=0,max=65535"`
+}
+
+// Validate nat gateway rule update request.
+func (req *NatRuleUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// NatRuleBatchDeleteReq nat gateway rule batch delete request, rules must belong to the same nat gateway.
+type NatRuleBatchDeleteReq struct {
+	NatGatewayID string   `json:"nat_gateway_id" validate:"required"`
+	IDs          []string `json:"ids" validate:"required,min=1"`
+}
+
+// Validate nat gateway rule batch delete request.
+func (req *NatRuleBatchDeleteReq) Validate() error {
+	if len(req.IDs) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("ids count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
@@ -36,6 +36,8 @@ func (req *TCloudGlobalSyncReq) Validate() error {
 type TCloudSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
+	// CloudIDs 指定云资源ID时只增量同步这些资源，目前仅主机、安全组同步支持
+	CloudIDs []string `json:"cloud_ids,omitempty" validate:"omitempty,max=100"`
 }
 
 // Validate tcloud sync request.
@@ -57,6 +59,8 @@ func (req *AwsGlobalSyncReq) Validate() error {
 type AwsSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
+	// CloudIDs 指定云资源ID时只增量同步这些资源，目前仅主机、安全组同步支持
+	CloudIDs []string `json:"cloud_ids,omitempty" validate:"omitempty,max=100"`
 }
 
 // Validate aws sync request.
@@ -78,6 +82,8 @@ func (req *HuaWeiGlobalSyncReq) Validate() error {
 type HuaWeiSyncReq struct {
 	AccountID string `json:"account_id" validate:"required"`
 	Region    string `json:"region" validate:"required"`
+	// CloudIDs 指定云资源ID时只增量同步这些资源，目前仅主机、安全组同步支持
+	CloudIDs []string `json:"cloud_ids,omitempty" validate:"omitempty,max=100"`
 }
 
 // Validate huawei sync request.
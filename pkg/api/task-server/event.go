@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package taskserver
+
+import (
+	"errors"
+
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// WatchFlowEventReq define watch flow event option.
+type WatchFlowEventReq struct {
+	// Cursor 上次查询返回的游标，为空时：指定了 FlowIDs 则从这些任务流的第一个事件开始，否则从当前最新的事件之后开始
+	Cursor string `json:"cursor" validate:"omitempty,lte=64"`
+	// FlowIDs 关注的任务流ID，为空表示关注所有任务流
+	FlowIDs []string `json:"flow_ids" validate:"omitempty,max=100"`
+	// FlowNames 关注的任务流名称，为空表示关注所有任务流
+	FlowNames []string `json:"flow_names" validate:"omitempty,max=100"`
+	// Types 关注的事件类型，为空表示关注所有类型
+	Types []enumor.AsyncEventType `json:"types" validate:"omitempty"`
+	// Limit 单次返回的最大事件数，默认500
+	Limit uint `json:"limit" validate:"omitempty,max=500"`
+	// TimeoutSec 没有新事件时的最长等待时间，为0时不等待直接返回
+	TimeoutSec uint `json:"timeout_sec" validate:"omitempty,max=60"`
+}
+
+// Validate WatchFlowEventReq
+func (req *WatchFlowEventReq) Validate() error {
+	for _, one := range req.Types {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// WatchFlowEventResult ...
+type WatchFlowEventResult struct {
+	// Cursor 下次查询使用的游标
+	Cursor  string                     `json:"cursor"`
+	Details []coreasync.AsyncFlowEvent `json:"details"`
+}
+
+// CreateFlowWebhookReq define create flow webhook option.
+type CreateFlowWebhookReq struct {
+	Name string `json:"name" validate:"required,lte=255"`
+	// URL 事件回调地址，事件通过POST请求投递，请求体为 coreasync.FlowWebhookPayload
+	URL string `json:"url" validate:"required,url,lte=1024"`
+	// Secret 回调密钥，不为空时使用该密钥对请求体进行HMAC-SHA256签名
+	Secret string `json:"secret" validate:"omitempty,lte=255"`
+	// FlowNames 关注的任务流名称，为空表示关注所有任务流
+	FlowNames []string `json:"flow_names" validate:"omitempty,max=100"`
+	// EventTypes 关注的事件类型，为空表示关注所有类型
+	EventTypes []enumor.AsyncEventType `json:"event_types" validate:"omitempty"`
+	Memo       string                  `json:"memo" validate:"omitempty,lte=255"`
+}
+
+// Validate CreateFlowWebhookReq
+func (req *CreateFlowWebhookReq) Validate() error {
+	for _, one := range req.EventTypes {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// UpdateFlowWebhookReq define update flow webhook option, 未设置的字段不更新，
+// FlowNames、EventTypes 设置为空数组时表示关注所有任务流、所有事件类型。
+type UpdateFlowWebhookReq struct {
+	Name       string                  `json:"name" validate:"omitempty,lte=255"`
+	URL        string                  `json:"url" validate:"omitempty,url,lte=1024"`
+	Secret     *string                 `json:"secret" validate:"omitempty,lte=255"`
+	FlowNames  []string                `json:"flow_names" validate:"omitempty,max=100"`
+	EventTypes []enumor.AsyncEventType `json:"event_types" validate:"omitempty"`
+	State      enumor.FlowWebhookState `json:"state" validate:"omitempty"`
+	Memo       *string                 `json:"memo" validate:"omitempty,lte=255"`
+}
+
+// Validate UpdateFlowWebhookReq
+func (req *UpdateFlowWebhookReq) Validate() error {
+	if len(req.Name) == 0 && len(req.URL) == 0 && req.Secret == nil && req.FlowNames == nil &&
+		req.EventTypes == nil && len(req.State) == 0 && req.Memo == nil {
+		return errors.New("at least one field needs to be updated")
+	}
+
+	if len(req.State) != 0 {
+		if err := req.State.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, one := range req.EventTypes {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// ListFlowWebhookResult ...
+type ListFlowWebhookResult struct {
+	Count   uint64                       `json:"count"`
+	Details []coreasync.AsyncFlowWebhook `json:"details"`
+}
@@ -20,6 +20,9 @@
 package taskserver
 
 import (
+	"errors"
+	"time"
+
 	"hcm/pkg/async/action"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
@@ -58,13 +61,72 @@ type TemplateFlowTask struct {
 	ActionID action.ActIDType `json:"action_id" validate:"required"`
 	// Params 任务执行请求参数
 	Params interface{} `json:"params" validate:"required"`
+	// RateLimitKey 任务限流键，执行器按限流键对任务进行限流，超出限额的任务延迟执行，为空表示不限流。
+	RateLimitKey *tableasync.RateLimitKey `json:"rate_limit_key" validate:"omitempty"`
 }
 
 // Validate TemplateFlowTask
 func (task *TemplateFlowTask) Validate() error {
+	if task.RateLimitKey != nil {
+		if err := task.RateLimitKey.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return validator.Validate.Struct(task)
 }
 
+// AddScheduledFlowReq define add scheduled flow option.
+type AddScheduledFlowReq struct {
+	// Name 定时计划名称
+	Name string `json:"name" validate:"required,lte=255"`
+	// FlowName 任务流模版名称
+	FlowName enumor.FlowName `json:"flow_name" validate:"required"`
+	// Memo 备注
+	Memo string `json:"memo" validate:"omitempty,lte=64"`
+	// Tasks 任务私有化参数设置
+	Tasks []TemplateFlowTask `json:"tasks" validate:"omitempty"`
+	// Cron 周期执行的5段式cron表达式（分 时 日 月 周），与 RunAt 二选一
+	Cron string `json:"cron" validate:"omitempty,lte=64"`
+	// RunAt 单次执行的时间，与 Cron 二选一
+	RunAt *time.Time `json:"run_at" validate:"omitempty"`
+}
+
+// Validate AddScheduledFlowReq
+func (req *AddScheduledFlowReq) Validate() error {
+
+	if err := req.FlowName.Validate(); err != nil {
+		return err
+	}
+
+	if len(req.Cron) == 0 && req.RunAt == nil {
+		return errors.New("one of cron and run_at is required")
+	}
+
+	for _, task := range req.Tasks {
+		if err := task.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// UpdateFlowScheduleReq define update flow schedule option.
+type UpdateFlowScheduleReq struct {
+	// State 定时计划状态，只能设置为 enabled 或 disabled
+	State enumor.FlowScheduleState `json:"state" validate:"required"`
+}
+
+// Validate UpdateFlowScheduleReq
+func (req *UpdateFlowScheduleReq) Validate() error {
+	if err := req.State.Validate(); err != nil {
+		return err
+	}
+
+	return validator.Validate.Struct(req)
+}
+
 // AddCustomFlowReq define add custom flow option.
 type AddCustomFlowReq struct {
 	// Name 任务流模版名称
@@ -106,9 +168,19 @@ type CustomFlowTask struct {
 
 	// Retry 任务运行重试相关配置参数，如果不设置，默认不允许进行重试。
 	Retry *tableasync.Retry `json:"retry" validate:"omitempty"`
+	// OnFailure 是否为失败分支任务，只在任务流存在失败任务且其他任务都结束后执行。
+	OnFailure bool `json:"on_failure" validate:"omitempty"`
+	// RateLimitKey 任务限流键，执行器按限流键对任务进行限流，超出限额的任务延迟执行，为空表示不限流。
+	RateLimitKey *tableasync.RateLimitKey `json:"rate_limit_key" validate:"omitempty"`
 }
 
 // Validate CustomFlowTask
 func (task *CustomFlowTask) Validate() error {
+	if task.RateLimitKey != nil {
+		if err := task.RateLimitKey.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return validator.Validate.Struct(task)
 }
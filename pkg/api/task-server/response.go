@@ -32,3 +32,9 @@ type ListTaskResult struct {
 	Count   uint64                    `json:"count"`
 	Details []coreasync.AsyncFlowTask `json:"details"`
 }
+
+// ListFlowScheduleResult ...
+type ListFlowScheduleResult struct {
+	Count   uint64                        `json:"count"`
+	Details []coreasync.AsyncFlowSchedule `json:"details"`
+}
@@ -103,6 +103,11 @@ func (am *Manager) RegisterFlowTpl(templates ...FlowTemplate) error {
 			}
 		}
 
+		// 执行条件、扇出、失败分支校验
+		if err := ValidateTaskBranch(tpl.Tasks); err != nil {
+			return err
+		}
+
 		am.flowTplMap[tpl.Name] = tpl
 	}
 
@@ -19,11 +19,18 @@
 
 package run
 
-import "hcm/pkg/kit"
+import (
+	"fmt"
+
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+)
 
 // ExecuteKit is a kit using by action
 type ExecuteKit interface {
 	Kit() *kit.Kit
+	// FlowID 返回任务所属的任务流ID
+	FlowID() string
 	ShareData() ShareDataOperator
 }
 
@@ -34,10 +41,21 @@ type ShareDataOperator interface {
 	AppendIDs(kt *kit.Kit, key string, ids ...string) error
 }
 
+// GetIDs 获取前置任务通过AppendIDs保存到共享数据中的ID列表
+func GetIDs(shareData ShareDataOperator, key string) ([]string, error) {
+	val, exist := shareData.Get(key)
+	if !exist || len(val) == 0 {
+		return nil, fmt.Errorf("ids of share data key: %s not found", key)
+	}
+
+	return tableasync.ParseIDsStr(val), nil
+}
+
 // NewExecuteContext new execute context for task exec.
-func NewExecuteContext(kt *kit.Kit, shareData ShareDataOperator) ExecuteKit {
+func NewExecuteContext(kt *kit.Kit, flowID string, shareData ShareDataOperator) ExecuteKit {
 	return &DefExecuteContext{
 		kit:       kt,
+		flowID:    flowID,
 		shareData: shareData,
 	}
 }
@@ -45,6 +63,7 @@ func NewExecuteContext(kt *kit.Kit, shareData ShareDataOperator) ExecuteKit {
 // DefExecuteContext default execute context.
 type DefExecuteContext struct {
 	kit       *kit.Kit
+	flowID    string
 	shareData ShareDataOperator
 }
 
@@ -53,6 +72,11 @@ func (ctx *DefExecuteContext) Kit() *kit.Kit {
 	return ctx.kit
 }
 
+// FlowID return flow id.
+func (ctx *DefExecuteContext) FlowID() string {
+	return ctx.flowID
+}
+
 // ShareData return share data.
 func (ctx *DefExecuteContext) ShareData() ShareDataOperator {
 	return ctx.shareData
@@ -20,6 +20,9 @@
 package action
 
 import (
+	"errors"
+	"fmt"
+
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 	tableasync "hcm/pkg/dal/table/async"
@@ -44,6 +47,68 @@ func (tpl *FlowTemplate) Validate() error {
 		}
 	}
 
+	return ValidateTaskBranch(tpl.Tasks)
+}
+
+// ValidateTaskBranch 校验任务的执行条件、扇出、失败分支设置
+// 1. 依赖的任务必须存在，且正常任务和失败分支任务只能依赖同类任务
+// 2. 执行条件引用的任务必须存在，且不能是扇出任务
+// 3. 扇出任务必须设置请求参数
+func ValidateTaskBranch(tasks []TaskTemplate) error {
+	taskMap := make(map[ActIDType]TaskTemplate, len(tasks))
+	hasNormal := false
+	for _, one := range tasks {
+		if !one.OnFailure {
+			hasNormal = true
+		}
+
+		if _, exist := taskMap[one.ActionID]; exist {
+			return fmt.Errorf("action_id: %s repeat", one.ActionID)
+		}
+		taskMap[one.ActionID] = one
+
+		if one.RunCondition != nil {
+			if err := one.RunCondition.Validate(); err != nil {
+				return fmt.Errorf("task: %s condition is invalid, err: %v", one.ActionID, err)
+			}
+		}
+
+		if len(one.ForEach) != 0 && one.Params == nil {
+			return fmt.Errorf("task: %s has for_each, but params not set", one.ActionID)
+		}
+	}
+
+	if !hasNormal {
+		return errors.New("at least one task is not on failure task")
+	}
+
+	for _, one := range tasks {
+		for _, id := range one.DependOn {
+			depend, exist := taskMap[id]
+			if !exist {
+				return fmt.Errorf("task: %s depend on action_id: %s not exist", one.ActionID, id)
+			}
+
+			if depend.OnFailure != one.OnFailure {
+				return fmt.Errorf("task: %s can not depend on task: %s, on_failure is not the same", one.ActionID,
+					id)
+			}
+		}
+
+		if one.RunCondition == nil || one.RunCondition.Source != enumor.TaskConditionResult {
+			continue
+		}
+
+		ref, exist := taskMap[ActIDType(one.RunCondition.ActionID)]
+		if !exist {
+			return fmt.Errorf("task: %s condition action_id: %s not exist", one.ActionID, one.RunCondition.ActionID)
+		}
+
+		if len(ref.ForEach) != 0 {
+			return fmt.Errorf("task: %s condition can not reference fan-out task: %s", one.ActionID, ref.ActionID)
+		}
+	}
+
 	return nil
 }
 
@@ -61,6 +126,17 @@ type TaskTemplate struct {
 
 	// Retry 任务运行重试相关配置参数，如果不设置，默认不允许进行重试。
 	Retry *tableasync.Retry `json:"retry" validate:"omitempty"`
+
+	// RunCondition 任务执行条件，依赖的任务都执行完成后判断，条件不满足时任务被跳过，为空表示无条件执行。
+	RunCondition *tableasync.TaskCondition `json:"run_condition" validate:"omitempty"`
+
+	// ForEach 扇出参数，值为请求参数中的数组字段名，创建任务流时按数组元素将任务扇出为多个并行执行的任务，
+	// 每个任务该字段的值为对应的数组元素，依赖该任务的任务会等待所有扇出的任务执行完成。
+	ForEach string `json:"for_each" validate:"omitempty"`
+
+	// OnFailure 是否为失败分支任务，失败分支任务只在任务流存在失败任务且其他任务都结束后执行，用于跨任务的补偿处理，
+	// 任务流没有失败任务时失败分支任务被跳过。失败分支任务只能依赖失败分支任务。
+	OnFailure bool `json:"on_failure" validate:"omitempty"`
 }
 
 // Validate TaskTemplate.
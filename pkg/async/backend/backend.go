@@ -21,11 +21,16 @@
 package backend
 
 import (
+	"time"
+
 	"hcm/pkg/api/core"
 	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/validator"
 	typesasync "hcm/pkg/dal/dao/types/async"
+	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
 )
 
 // Backend - a common interface for all backends
@@ -39,6 +44,8 @@ type Backend interface {
 	BatchUpdateFlow(kt *kit.Kit, flows []model.Flow) error
 	// ListFlow 查询任务流
 	ListFlow(kt *kit.Kit, input *ListInput) ([]model.Flow, error)
+	// CountFlow 查询满足过滤条件的任务流数量
+	CountFlow(kt *kit.Kit, expr *filter.Expression) (uint64, error)
 	// BatchUpdateFlowStateByCAS CAS批量更新Flow状态
 	BatchUpdateFlowStateByCAS(kt *kit.Kit, infos []UpdateFlowInfo) error
 
@@ -53,6 +60,61 @@ type Backend interface {
 	UpdateTaskStateByCAS(kt *kit.Kit, info *UpdateTaskInfo) error
 	// ListTask 查询任务
 	ListTask(kt *kit.Kit, input *ListInput) ([]model.Task, error)
+	// CountTask 查询满足过滤条件的任务数量
+	CountTask(kt *kit.Kit, expr *filter.Expression) (uint64, error)
+
+	/*
+		Schedule 相关接口
+	*/
+	// CreateSchedule 创建任务流定时计划
+	CreateSchedule(kt *kit.Kit, schedule *model.Schedule) (string, error)
+	// ListSchedule 查询任务流定时计划
+	ListSchedule(kt *kit.Kit, input *ListInput) ([]model.Schedule, error)
+	// TriggerSchedule 触发任务流定时计划，创建任务流(flow不为空时)并CAS更新定时计划的下次执行时间，返回创建的任务流ID
+	TriggerSchedule(kt *kit.Kit, info *TriggerScheduleInfo, flow *model.Flow) (string, error)
+	// CountSchedule 查询满足过滤条件的任务流定时计划数量
+	CountSchedule(kt *kit.Kit, expr *filter.Expression) (uint64, error)
+	// UpdateScheduleStateByCAS CAS更新任务流定时计划状态，状态或下次执行时间已被修改(如被触发)时返回RecordNotUpdate错误
+	UpdateScheduleStateByCAS(kt *kit.Kit, info *UpdateScheduleStateInfo) error
+	// DeleteSchedule 删除任务流定时计划，已创建的任务流不会被删除
+	DeleteSchedule(kt *kit.Kit, ids []string) error
+
+	/*
+		Event 相关接口，事件在任务流、任务状态变更时与状态变更一起写入
+	*/
+	// ListEvent 查询任务流事件
+	ListEvent(kt *kit.Kit, input *ListInput) ([]model.Event, error)
+	// DeleteEvent 删除创建时间早于指定时间的任务流事件
+	DeleteEvent(kt *kit.Kit, before time.Time) error
+
+	/*
+		Webhook 相关接口
+	*/
+	// CreateWebhook 创建任务流事件回调
+	CreateWebhook(kt *kit.Kit, webhook *model.Webhook) (string, error)
+	// ListWebhook 查询任务流事件回调
+	ListWebhook(kt *kit.Kit, input *ListInput) ([]model.Webhook, error)
+	// CountWebhook 查询满足过滤条件的任务流事件回调数量
+	CountWebhook(kt *kit.Kit, expr *filter.Expression) (uint64, error)
+	// UpdateWebhook 更新任务流事件回调的投递进度
+	UpdateWebhook(kt *kit.Kit, webhook *model.Webhook) error
+	// UpdateWebhookConfig 更新任务流事件回调的配置，未设置的字段不更新
+	UpdateWebhookConfig(kt *kit.Kit, info *UpdateWebhookInfo) error
+	// DeleteWebhook 删除任务流事件回调
+	DeleteWebhook(kt *kit.Kit, ids []string) error
+}
+
+// Watcher 支持监听数据变更的后端，消费者在任务流变更时被及时唤醒，不再只依赖周期查询
+type Watcher interface {
+	// WatchFlow 监听任务流变更，有变更时通过返回的通道通知，closeCh关闭后停止监听
+	WatchFlow(closeCh <-chan struct{}) <-chan struct{}
+}
+
+// Cleaner 需要清理历史任务流的后端，etcd后端在每个节点内存中缓存全部记录，需要定期删除已结束的任务流，
+// 避免内存占用与全量同步的耗时随历史数据无限增长
+type Cleaner interface {
+	// DeleteFinishedFlow 删除更新时间早于指定时间且已结束(成功、失败、取消)的任务流及其任务
+	DeleteFinishedFlow(kt *kit.Kit, before time.Time) error
 }
 
 // ListInput 查询输入参数
@@ -73,3 +135,38 @@ type UpdateTaskInfo typesasync.UpdateTaskInfo
 func (info *UpdateTaskInfo) Validate() error {
 	return validator.Validate.Struct(info)
 }
+
+// UpdateScheduleStateInfo define update schedule state info.
+type UpdateScheduleStateInfo typesasync.UpdateScheduleStateInfo
+
+// Validate UpdateScheduleStateInfo
+func (info *UpdateScheduleStateInfo) Validate() error {
+	return (*typesasync.UpdateScheduleStateInfo)(info).Validate()
+}
+
+// UpdateWebhookInfo define update webhook config info, 未设置的字段不更新，
+// FlowNames、EventTypes 设置为空数组时表示关注所有任务流、所有事件类型。
+type UpdateWebhookInfo struct {
+	ID         string                  `validate:"required"`
+	Name       string                  `validate:"omitempty"`
+	URL        string                  `validate:"omitempty"`
+	Secret     *string                 `validate:"omitempty"`
+	FlowNames  []string                `validate:"omitempty"`
+	EventTypes []string                `validate:"omitempty"`
+	State      enumor.FlowWebhookState `validate:"omitempty"`
+	Reason     *tableasync.Reason      `validate:"omitempty"`
+	Memo       *string                 `validate:"omitempty"`
+}
+
+// Validate UpdateWebhookInfo
+func (info *UpdateWebhookInfo) Validate() error {
+	return validator.Validate.Struct(info)
+}
+
+// TriggerScheduleInfo define trigger schedule info.
+type TriggerScheduleInfo typesasync.TriggerScheduleInfo
+
+// Validate TriggerScheduleInfo
+func (info *TriggerScheduleInfo) Validate() error {
+	return validator.Validate.Struct(info)
+}
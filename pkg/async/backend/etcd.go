@@ -0,0 +1,247 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	etcd3 "go.etcd.io/etcd/client/v3"
+)
+
+// defaultEtcdKeyPrefix 异步任务数据在etcd中的默认key前缀
+const defaultEtcdKeyPrefix = "/hcm/async"
+
+// NewEtcd create etcd instance.
+// 写操作通过etcd事务比较记录版本号实现CAS，查询操作读取本地缓存，本地缓存通过watch与etcd保持同步，
+// 不会因为消费者的周期查询给etcd带来压力。ctx结束后停止监听etcd。
+// 本地缓存包含前缀下的全部记录，已结束的任务流及其任务由主节点的 WatchDog 在超过保留时长后删除。
+func NewEtcd(ctx context.Context, cli *etcd3.Client, prefix string) (Backend, error) {
+	if ctx == nil {
+		return nil, errors.New("context is required")
+	}
+
+	if cli == nil {
+		return nil, errors.New("etcd client is required")
+	}
+
+	if len(prefix) == 0 {
+		prefix = defaultEtcdKeyPrefix
+	}
+
+	store := &etcdStore{
+		cli:      cli,
+		prefix:   strings.TrimSuffix(prefix, "/") + "/",
+		cache:    make(map[string]kvPair),
+		watchers: newKvWatchers(),
+	}
+
+	revision, err := store.resync()
+	if err != nil {
+		return nil, err
+	}
+
+	go store.loopWatch(ctx, revision)
+
+	return &kvBackend{store: store}, nil
+}
+
+// etcdStore 基于etcd实现的键值存储
+type etcdStore struct {
+	cli    *etcd3.Client
+	prefix string
+	// cache 本地缓存的所有记录，key不包含前缀
+	cacheLock sync.RWMutex
+	cache     map[string]kvPair
+	watchers  *kvWatchers
+}
+
+// Get 查询记录，直接读取etcd，保证CAS更新前读取到的是最新版本
+func (e *etcdStore) Get(kt *kit.Kit, key string) (*kvPair, error) {
+	resp, err := e.cli.Get(kt.Ctx, e.prefix+key)
+	if err != nil {
+		logs.Errorf("get etcd key failed, err: %v, key: %s, rid: %s", err, key, kt.Rid)
+		return nil, err
+	}
+
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+
+	return &kvPair{Key: key, Value: resp.Kvs[0].Value, Revision: resp.Kvs[0].ModRevision}, nil
+}
+
+// List 查询指定前缀的所有记录，读取本地缓存
+func (e *etcdStore) List(_ *kit.Kit, prefix string) ([]kvPair, error) {
+	e.cacheLock.RLock()
+	defer e.cacheLock.RUnlock()
+
+	pairs := make([]kvPair, 0)
+	for key, pair := range e.cache {
+		if strings.HasPrefix(key, prefix) {
+			pairs = append(pairs, pair)
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+
+	return pairs, nil
+}
+
+// Commit 通过etcd事务原子的执行写操作，注意单个事务的操作数受etcd的max-txn-ops配置限制
+func (e *etcdStore) Commit(kt *kit.Kit, ops []kvOp) error {
+	cmps := make([]etcd3.Cmp, 0, len(ops))
+	puts := make([]etcd3.Op, 0, len(ops))
+	for _, op := range ops {
+		key := e.prefix + op.Key
+		if op.Revision == 0 {
+			cmps = append(cmps, etcd3.Compare(etcd3.CreateRevision(key), "=", 0))
+		} else {
+			cmps = append(cmps, etcd3.Compare(etcd3.ModRevision(key), "=", op.Revision))
+		}
+		if op.Delete {
+			puts = append(puts, etcd3.OpDelete(key))
+		} else {
+			puts = append(puts, etcd3.OpPut(key, string(op.Value)))
+		}
+	}
+
+	resp, err := e.cli.Txn(kt.Ctx).If(cmps...).Then(puts...).Commit()
+	if err != nil {
+		logs.Errorf("commit etcd txn failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	if !resp.Succeeded {
+		return errf.New(errf.RecordNotUpdate, "record has been changed")
+	}
+
+	// 提交成功后立即更新本地缓存，保证后续查询可以读取到本次写入
+	keys := make([]string, 0, len(ops))
+	e.cacheLock.Lock()
+	for _, op := range ops {
+		if op.Delete {
+			delete(e.cache, op.Key)
+		} else {
+			e.setCache(kvPair{Key: op.Key, Value: op.Value, Revision: resp.Header.Revision})
+		}
+		keys = append(keys, op.Key)
+	}
+	e.cacheLock.Unlock()
+
+	e.watchers.notify(keys...)
+	return nil
+}
+
+// Watch 监听指定前缀的记录变更
+func (e *etcdStore) Watch(prefix string, closeCh <-chan struct{}) <-chan struct{} {
+	return e.watchers.add(prefix, closeCh)
+}
+
+// setCache 更新本地缓存，版本号小于缓存中的记录时忽略，调用方需要持有cacheLock
+func (e *etcdStore) setCache(pair kvPair) {
+	if cached, exist := e.cache[pair.Key]; exist && cached.Revision > pair.Revision {
+		return
+	}
+
+	e.cache[pair.Key] = pair
+}
+
+// resync 全量同步etcd中的记录到本地缓存，返回同步时的etcd版本号
+func (e *etcdStore) resync() (int64, error) {
+	resp, err := e.cli.Get(context.Background(), e.prefix, etcd3.WithPrefix())
+	if err != nil {
+		return 0, fmt.Errorf("list etcd key with prefix %s failed, err: %v", e.prefix, err)
+	}
+
+	cache := make(map[string]kvPair, len(resp.Kvs))
+	for _, one := range resp.Kvs {
+		key := strings.TrimPrefix(string(one.Key), e.prefix)
+		cache[key] = kvPair{Key: key, Value: one.Value, Revision: one.ModRevision}
+	}
+
+	e.cacheLock.Lock()
+	e.cache = cache
+	e.cacheLock.Unlock()
+
+	return resp.Header.Revision, nil
+}
+
+// loopWatch 监听etcd中的记录变更并更新本地缓存，watch中断(如版本被压缩)后重新全量同步，ctx结束后退出
+func (e *etcdStore) loopWatch(ctx context.Context, revision int64) {
+	for {
+		watchCtx, cancel := context.WithCancel(ctx)
+		watchCh := e.cli.Watch(etcd3.WithRequireLeader(watchCtx), e.prefix, etcd3.WithPrefix(),
+			etcd3.WithRev(revision+1))
+
+		for resp := range watchCh {
+			if err := resp.Err(); err != nil {
+				logs.Errorf("watch async etcd key failed, err: %v, prefix: %s", err, e.prefix)
+				break
+			}
+
+			keys := make([]string, 0, len(resp.Events))
+			e.cacheLock.Lock()
+			for _, event := range resp.Events {
+				key := strings.TrimPrefix(string(event.Kv.Key), e.prefix)
+				switch event.Type {
+				case mvccpb.PUT:
+					e.setCache(kvPair{Key: key, Value: event.Kv.Value, Revision: event.Kv.ModRevision})
+				case mvccpb.DELETE:
+					delete(e.cache, key)
+				}
+				keys = append(keys, key)
+			}
+			e.cacheLock.Unlock()
+
+			revision = resp.Header.Revision
+			e.watchers.notify(keys...)
+		}
+		cancel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logs.Infof("stop watch async etcd key, prefix: %s", e.prefix)
+				return
+			case <-time.After(time.Second):
+			}
+
+			var err error
+			if revision, err = e.resync(); err != nil {
+				logs.Errorf("resync async etcd key failed, err: %v", err)
+				continue
+			}
+			break
+		}
+
+		// 全量同步期间的变更无法区分，通知所有监听者
+		e.watchers.notifyAll()
+	}
+}
@@ -0,0 +1,323 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	etcd3 "go.etcd.io/etcd/client/v3"
+)
+
+// fakeEtcd 基于内存模拟etcd的KV和Watch接口，只实现etcdStore用到的方法
+type fakeEtcd struct {
+	etcd3.KV
+	etcd3.Watcher
+
+	lock     sync.Mutex
+	revision int64
+	data     map[string]*mvccpb.KeyValue
+	watchChs []chan etcd3.WatchResponse
+}
+
+func newFakeEtcdClient(ctx context.Context) (*etcd3.Client, *fakeEtcd) {
+	fake := &fakeEtcd{data: make(map[string]*mvccpb.KeyValue)}
+	cli := etcd3.NewCtxClient(ctx)
+	cli.KV = fake
+	cli.Watcher = fake
+	return cli, fake
+}
+
+// Get 查询记录，带WithPrefix参数时按前缀查询
+func (f *fakeEtcd) Get(_ context.Context, key string, opts ...etcd3.OpOption) (*etcd3.GetResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	isPrefix := len(etcd3.OpGet(key, opts...).RangeBytes()) != 0
+	resp := &etcd3.GetResponse{Header: &pb.ResponseHeader{Revision: f.revision}}
+	for k, kv := range f.data {
+		if k == key || (isPrefix && strings.HasPrefix(k, key)) {
+			resp.Kvs = append(resp.Kvs, kv)
+		}
+	}
+	return resp, nil
+}
+
+// Txn 开启事务
+func (f *fakeEtcd) Txn(_ context.Context) etcd3.Txn {
+	return &fakeTxn{etcd: f}
+}
+
+// Watch 监听记录变更，ctx结束后关闭通道
+func (f *fakeEtcd) Watch(ctx context.Context, _ string, _ ...etcd3.OpOption) etcd3.WatchChan {
+	ch := make(chan etcd3.WatchResponse, 100)
+	f.lock.Lock()
+	f.watchChs = append(f.watchChs, ch)
+	f.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		for i := range f.watchChs {
+			if f.watchChs[i] == ch {
+				f.watchChs = append(f.watchChs[:i], f.watchChs[i+1:]...)
+				break
+			}
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// put 模拟其他节点写入记录，并通知所有监听者
+func (f *fakeEtcd) put(key, value string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	kv := f.putLocked(key, []byte(value))
+	resp := etcd3.WatchResponse{
+		Header: pb.ResponseHeader{Revision: f.revision},
+		Events: []*etcd3.Event{{Type: mvccpb.PUT, Kv: kv}},
+	}
+	for _, ch := range f.watchChs {
+		ch <- resp
+	}
+}
+
+func (f *fakeEtcd) putLocked(key string, value []byte) *mvccpb.KeyValue {
+	f.revision++
+	kv := &mvccpb.KeyValue{Key: []byte(key), Value: value, ModRevision: f.revision, CreateRevision: f.revision}
+	if old, exist := f.data[key]; exist {
+		kv.CreateRevision = old.CreateRevision
+	}
+	f.data[key] = kv
+	return kv
+}
+
+func (f *fakeEtcd) watchCount() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.watchChs)
+}
+
+type fakeTxn struct {
+	etcd *fakeEtcd
+	cmps []etcd3.Cmp
+	ops  []etcd3.Op
+}
+
+// If ...
+func (t *fakeTxn) If(cs ...etcd3.Cmp) etcd3.Txn {
+	t.cmps = append(t.cmps, cs...)
+	return t
+}
+
+// Then ...
+func (t *fakeTxn) Then(ops ...etcd3.Op) etcd3.Txn {
+	t.ops = append(t.ops, ops...)
+	return t
+}
+
+// Else ...
+func (t *fakeTxn) Else(_ ...etcd3.Op) etcd3.Txn {
+	return t
+}
+
+// Commit 比较记录的创建版本或修改版本，全部相等时执行写操作
+func (t *fakeTxn) Commit() (*etcd3.TxnResponse, error) {
+	f := t.etcd
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, cmp := range t.cmps {
+		var actual int64
+		kv, exist := f.data[string(cmp.KeyBytes())]
+		compare := pb.Compare(cmp)
+		expect := compare.GetModRevision()
+		switch {
+		case compare.Target == pb.Compare_CREATE:
+			expect = compare.GetCreateRevision()
+			if exist {
+				actual = kv.CreateRevision
+			}
+		case exist:
+			actual = kv.ModRevision
+		}
+		if actual != expect {
+			return &etcd3.TxnResponse{Header: &pb.ResponseHeader{Revision: f.revision}}, nil
+		}
+	}
+
+	f.revision++
+	for _, op := range t.ops {
+		key := string(op.KeyBytes())
+		if op.IsDelete() {
+			delete(f.data, key)
+			continue
+		}
+		kv := &mvccpb.KeyValue{Key: op.KeyBytes(), Value: op.ValueBytes(), ModRevision: f.revision,
+			CreateRevision: f.revision}
+		if old, exist := f.data[key]; exist {
+			kv.CreateRevision = old.CreateRevision
+		}
+		f.data[key] = kv
+	}
+
+	return &etcd3.TxnResponse{Header: &pb.ResponseHeader{Revision: f.revision}, Succeeded: true}, nil
+}
+
+func TestEtcdFlow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cli, _ := newFakeEtcdClient(ctx)
+	bd, err := NewEtcd(ctx, cli, "")
+	if err != nil {
+		t.Fatalf("new etcd backend failed, err: %v", err)
+	}
+	kt := kit.New()
+
+	flowID, err := bd.CreateFlow(kt, &model.Flow{Name: enumor.FlowStartCvm, Tasks: []model.Task{{ActionID: "1"}}})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	input := &ListInput{Filter: tools.EqualExpression("state", enumor.FlowPending), Page: core.NewDefaultBasePage()}
+	flows, err := bd.ListFlow(kt, input)
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(flows) != 1 || flows[0].ID != flowID {
+		t.Fatalf("list pending flow should return created flow, but got %+v", flows)
+	}
+
+	info := UpdateFlowInfo{ID: flowID, Source: enumor.FlowPending, Target: enumor.FlowScheduled, Worker: "node1"}
+	if err = bd.BatchUpdateFlowStateByCAS(kt, []UpdateFlowInfo{info}); err != nil {
+		t.Fatalf("update flow state failed, err: %v", err)
+	}
+
+	// 状态已被修改，再次CAS更新应失败
+	err = bd.BatchUpdateFlowStateByCAS(kt, []UpdateFlowInfo{info})
+	if errf.Error(err).Code != errf.RecordNotUpdate {
+		t.Fatalf("update flow state by cas again should return record not update, but got %v", err)
+	}
+
+	flows, err = bd.ListFlow(kt, &ListInput{Filter: tools.EqualExpression("id", flowID)})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(flows) != 1 || flows[0].State != enumor.FlowScheduled || flows[0].Worker == nil ||
+		*flows[0].Worker != "node1" {
+		t.Fatalf("flow should be scheduled to node1, but got %+v", flows)
+	}
+}
+
+func TestEtcdWatchSyncCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cli, fake := newFakeEtcdClient(ctx)
+	fake.put(defaultEtcdKeyPrefix+"/exist", "1")
+
+	bd, err := NewEtcd(ctx, cli, "")
+	if err != nil {
+		t.Fatalf("new etcd backend failed, err: %v", err)
+	}
+	store := bd.(*kvBackend).store
+
+	// 创建时全量同步已有的记录
+	pairs, err := store.List(kit.New(), "exist")
+	if err != nil || len(pairs) != 1 {
+		t.Fatalf("list synced key should return 1 record, but got %+v, err: %v", pairs, err)
+	}
+
+	closeCh := make(chan struct{})
+	defer close(closeCh)
+	notifyCh := store.Watch("other", closeCh)
+
+	waitWatch(t, fake, 1)
+	// 其他节点写入的记录通过watch同步到本地缓存
+	fake.put(defaultEtcdKeyPrefix+"/other", "2")
+
+	select {
+	case <-notifyCh:
+	case <-time.After(time.Second):
+		t.Fatal("put key by other node should notify watcher")
+	}
+
+	pairs, err = store.List(kit.New(), "other")
+	if err != nil || len(pairs) != 1 || string(pairs[0].Value) != "2" {
+		t.Fatalf("list watched key should return put record, but got %+v, err: %v", pairs, err)
+	}
+}
+
+func TestEtcdStopWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cli, fake := newFakeEtcdClient(context.Background())
+
+	store := &etcdStore{
+		cli:      cli,
+		prefix:   defaultEtcdKeyPrefix + "/",
+		cache:    make(map[string]kvPair),
+		watchers: newKvWatchers(),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		store.loopWatch(ctx, 0)
+		close(done)
+	}()
+
+	waitWatch(t, fake, 1)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("loop watch should exit after context canceled")
+	}
+
+	if count := fake.watchCount(); count != 0 {
+		t.Fatalf("all watches should be canceled, but got %d", count)
+	}
+}
+
+func waitWatch(t *testing.T, fake *fakeEtcd, count int) {
+	for i := 0; i < 100; i++ {
+		if fake.watchCount() == count {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("watch count should be %d, but got %d", count, fake.watchCount())
+}
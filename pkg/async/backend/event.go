@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"strconv"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/runtime/filter"
+)
+
+// EventCursorOption 按游标增量查询事件的条件
+type EventCursorOption struct {
+	// Cursor 已读取的最后一个事件ID，为空表示从第一个事件开始读取
+	Cursor    string
+	FlowIDs   []string
+	FlowNames []string
+	Types     []string
+	Limit     uint
+}
+
+// ListInput 生成查询条件，只查询ID大于游标的事件，并按事件产生的顺序返回。
+// 事件ID与事件在同一事务(提交)中生成，事件按ID的顺序提交，游标不会越过尚未提交的事件。
+func (opt *EventCursorOption) ListInput() (*ListInput, error) {
+	rules := make([]filter.RuleFactory, 0)
+
+	if len(opt.Cursor) != 0 {
+		seq, err := EventSeq(opt.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, filter.AtomRule{Field: "seq", Op: filter.GreaterThan.Factory(), Value: seq})
+	}
+
+	if len(opt.FlowIDs) != 0 {
+		rules = append(rules, filter.AtomRule{Field: "flow_id", Op: filter.In.Factory(), Value: opt.FlowIDs})
+	}
+
+	if len(opt.FlowNames) != 0 {
+		rules = append(rules, filter.AtomRule{Field: "flow_name", Op: filter.In.Factory(), Value: opt.FlowNames})
+	}
+
+	if len(opt.Types) != 0 {
+		rules = append(rules, filter.AtomRule{Field: "type", Op: filter.In.Factory(), Value: opt.Types})
+	}
+
+	limit := opt.Limit
+	if limit == 0 || limit > core.DefaultMaxPageLimit {
+		limit = core.DefaultMaxPageLimit
+	}
+
+	input := &ListInput{
+		Filter: &filter.Expression{Op: filter.And, Rules: rules},
+		Page:   &core.BasePage{Limit: limit, Sort: "seq", Order: core.Ascending},
+	}
+	return input, nil
+}
+
+// EventSeq 事件ID为36进制的自增序号，转换为数值后用于按游标增量查询事件
+func EventSeq(id string) (uint64, error) {
+	seq, err := strconv.ParseUint(id, 36, 64)
+	if err != nil {
+		return 0, errf.Newf(errf.InvalidParameter, "invalid event id: %s", id)
+	}
+
+	return seq, nil
+}
+
+func newFlowEvent(id string, name enumor.FlowName, source, target enumor.FlowState,
+	reason *tableasync.Reason) model.Event {
+
+	return model.Event{
+		Type:     enumor.FlowStateEvent,
+		FlowID:   id,
+		FlowName: name,
+		Source:   string(source),
+		Target:   string(target),
+		Reason:   reason,
+	}
+}
+
+func newTaskEvent(task *model.Task, target enumor.TaskState, reason *tableasync.Reason) model.Event {
+	return model.Event{
+		Type:     enumor.TaskStateEvent,
+		FlowID:   task.FlowID,
+		FlowName: task.FlowName,
+		TaskID:   task.ID,
+		ActionID: string(task.ActionID),
+		Source:   string(task.State),
+		Target:   string(target),
+		Reason:   reason,
+	}
+}
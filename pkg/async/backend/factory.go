@@ -20,13 +20,25 @@
 package backend
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/dal/dao"
+
+	etcd3 "go.etcd.io/etcd/client/v3"
 )
 
+// EtcdOption etcd存储后端的创建参数
+type EtcdOption struct {
+	// Ctx 结束后停止监听etcd
+	Ctx    context.Context
+	Client *etcd3.Client
+	// KeyPrefix 异步任务数据在etcd中的key前缀，为空时使用默认前缀
+	KeyPrefix string
+}
+
 // Factory 根据类型返回不同的backend接口的实现
 func Factory(typ enumor.BackendType, client interface{}) (Backend, error) {
 	switch typ {
@@ -36,7 +48,15 @@ func Factory(typ enumor.BackendType, client interface{}) (Backend, error) {
 			return nil, errors.New("client is not mysql dao set")
 		}
 		return NewMysql(cli), nil
+	case enumor.BackendMemory:
+		return NewMemory(), nil
+	case enumor.BackendEtcd:
+		opt, ok := client.(*EtcdOption)
+		if !ok || opt == nil {
+			return nil, errors.New("client is not etcd option")
+		}
+		return NewEtcd(opt.Ctx, opt.Client, opt.KeyPrefix)
 	default:
-		return nil, fmt.Errorf("unsupported mysql type: %s", typ)
+		return nil, fmt.Errorf("unsupported backend type: %s", typ)
 	}
 }
@@ -0,0 +1,1171 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+)
+
+const (
+	kvFlowPrefix        = "flow/"
+	kvTaskPrefix        = "task/"
+	kvSchedulePrefix    = "schedule/"
+	kvEventPrefix       = "event/"
+	kvWebhookPrefix     = "webhook/"
+	kvIDGeneratorPrefix = "id_generator/"
+
+	// kvNextIDMaxRetry 生成ID发生冲突时的最大重试次数
+	kvNextIDMaxRetry = 10
+	// kvUpdateMaxRetry 更新记录发生版本冲突时的最大重试次数
+	kvUpdateMaxRetry = 10
+	// kvDeleteBatchSize 批量删除记录时单次提交的最大操作数
+	kvDeleteBatchSize = 100
+)
+
+// kvPair 键值存储中的一条记录，Revision为记录最后一次修改的版本号
+type kvPair struct {
+	Key      string
+	Value    []byte
+	Revision int64
+}
+
+// kvOp 键值存储的写操作，Revision为写入前记录的版本号，为0表示记录必须不存在，Delete为true时删除记录
+type kvOp struct {
+	Key      string
+	Value    []byte
+	Revision int64
+	Delete   bool
+}
+
+// kvStore 键值存储，memory、etcd后端基于该接口实现Backend
+type kvStore interface {
+	// Get 查询记录，记录不存在时返回nil
+	Get(kt *kit.Kit, key string) (*kvPair, error)
+	// List 查询指定前缀的所有记录
+	List(kt *kit.Kit, prefix string) ([]kvPair, error)
+	// Commit 原子的执行写操作，任一记录的版本号与预期不一致时全部不执行，并返回RecordNotUpdate错误
+	Commit(kt *kit.Kit, ops []kvOp) error
+	// Watch 监听指定前缀的记录变更，有变更时通过返回的通道通知，closeCh关闭后停止监听
+	Watch(prefix string, closeCh <-chan struct{}) <-chan struct{}
+}
+
+// kvBackend 基于键值存储实现的Backend
+type kvBackend struct {
+	store kvStore
+}
+
+var _ Backend = new(kvBackend)
+var _ Watcher = new(kvBackend)
+var _ Cleaner = new(kvBackend)
+
+type flowRecord struct {
+	model.Flow
+}
+
+func (r flowRecord) values() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         r.ID,
+		"name":       r.Name,
+		"state":      r.State,
+		"memo":       r.Memo,
+		"worker":     converter.PtrToVal(r.Worker),
+		"creator":    r.Creator,
+		"reviser":    r.Reviser,
+		"created_at": parseStdTime(r.CreatedAt),
+		"updated_at": parseStdTime(r.UpdatedAt),
+	}
+}
+
+type taskRecord struct {
+	model.Task
+}
+
+func (r taskRecord) values() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          r.ID,
+		"flow_id":     r.FlowID,
+		"flow_name":   r.FlowName,
+		"action_id":   r.ActionID,
+		"action_name": r.ActionName,
+		"state":       r.State,
+		"creator":     r.Creator,
+		"reviser":     r.Reviser,
+		"created_at":  parseStdTime(r.CreatedAt),
+		"updated_at":  parseStdTime(r.UpdatedAt),
+	}
+}
+
+type scheduleRecord struct {
+	model.Schedule
+}
+
+func (r scheduleRecord) values() map[string]interface{} {
+	return map[string]interface{}{
+		"id":           r.ID,
+		"name":         r.Name,
+		"flow_name":    r.FlowName,
+		"memo":         r.Memo,
+		"cron":         r.Cron,
+		"next_run_at":  r.NextRunAt,
+		"state":        r.State,
+		"last_flow_id": r.LastFlowID,
+		"creator":      r.Creator,
+		"reviser":      r.Reviser,
+		"created_at":   parseStdTime(r.CreatedAt),
+		"updated_at":   parseStdTime(r.UpdatedAt),
+	}
+}
+
+type eventRecord struct {
+	model.Event
+}
+
+func (r eventRecord) values() map[string]interface{} {
+	seq, _ := EventSeq(r.ID)
+	return map[string]interface{}{
+		"id":         r.ID,
+		"seq":        seq,
+		"type":       r.Type,
+		"flow_id":    r.FlowID,
+		"flow_name":  r.FlowName,
+		"task_id":    r.TaskID,
+		"action_id":  r.ActionID,
+		"source":     r.Source,
+		"target":     r.Target,
+		"creator":    r.Creator,
+		"created_at": parseStdTime(r.CreatedAt),
+	}
+}
+
+type webhookRecord struct {
+	model.Webhook
+}
+
+func (r webhookRecord) values() map[string]interface{} {
+	return map[string]interface{}{
+		"id":            r.ID,
+		"name":          r.Name,
+		"url":           r.URL,
+		"state":         r.State,
+		"last_event_id": r.LastEventID,
+		"creator":       r.Creator,
+		"reviser":       r.Reviser,
+		"created_at":    parseStdTime(r.CreatedAt),
+		"updated_at":    parseStdTime(r.UpdatedAt),
+	}
+}
+
+func parseStdTime(val string) time.Time {
+	t, err := time.Parse(constant.TimeStdFormat, val)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
+// WatchFlow 监听任务流变更
+func (kv *kvBackend) WatchFlow(closeCh <-chan struct{}) <-chan struct{} {
+	return kv.store.Watch(kvFlowPrefix, closeCh)
+}
+
+// CreateFlow 创建任务流
+func (kv *kvBackend) CreateFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
+	flowID := ""
+	err := kv.commitEventOps(kt, func() ([]kvOp, error) {
+		ops, id, err := kv.createFlowOps(kt, flow)
+		flowID = id
+		return ops, err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return flowID, nil
+}
+
+// createFlowOps 生成创建任务流及其任务的写操作
+func (kv *kvBackend) createFlowOps(kt *kit.Kit, flow *model.Flow) ([]kvOp, string, error) {
+	ids, err := kv.nextIDs(kt, kvFlowPrefix, 1)
+	if err != nil {
+		return nil, "", err
+	}
+	flowID := ids[0]
+
+	now := times.ConvStdTimeFormat(time.Now())
+	md := model.Flow{
+		ID:        flowID,
+		Name:      flow.Name,
+		State:     enumor.FlowPending,
+		Reason:    new(tableasync.Reason),
+		ShareData: flow.ShareData,
+		Memo:      flow.Memo,
+		Worker:    converter.ValToPtr(""),
+		Creator:   kt.User,
+		Reviser:   kt.User,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	flowOp, err := newKvOp(kvFlowPrefix+flowID, md, 0)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tasks := make([]model.Task, 0, len(flow.Tasks))
+	for _, one := range flow.Tasks {
+		one.FlowID = flowID
+		one.State = enumor.TaskPending
+		one.Reason = new(tableasync.Reason)
+		one.Creator = kt.User
+		one.Reviser = kt.User
+		tasks = append(tasks, one)
+	}
+	taskOps, _, err := kv.createTaskOps(kt, tasks)
+	if err != nil {
+		return nil, "", err
+	}
+
+	eventOps, err := kv.eventOps(kt, []model.Event{newFlowEvent(flowID, flow.Name, "", md.State, nil)})
+	if err != nil {
+		return nil, "", err
+	}
+
+	ops := append([]kvOp{flowOp}, taskOps...)
+	return append(ops, eventOps...), flowID, nil
+}
+
+// BatchUpdateFlow 批量更新任务流
+func (kv *kvBackend) BatchUpdateFlow(kt *kit.Kit, flows []model.Flow) error {
+	ops := make([]kvOp, 0, len(flows))
+	events := make([]model.Event, 0)
+	for _, one := range flows {
+		md := new(model.Flow)
+		pair, err := kv.get(kt, kvFlowPrefix+one.ID, md)
+		if err != nil {
+			return err
+		}
+
+		if len(one.State) != 0 && one.State != md.State {
+			events = append(events, newFlowEvent(md.ID, md.Name, md.State, one.State, one.Reason))
+			md.State = one.State
+		}
+		if one.Reason != nil {
+			md.Reason = one.Reason
+		}
+		if one.ShareData != nil {
+			md.ShareData = one.ShareData
+		}
+		if len(one.Memo) != 0 {
+			md.Memo = one.Memo
+		}
+		if one.Worker != nil {
+			md.Worker = one.Worker
+		}
+		if len(one.Reviser) != 0 {
+			md.Reviser = one.Reviser
+		}
+		md.UpdatedAt = times.ConvStdTimeFormat(time.Now())
+
+		op, err := newKvOp(pair.Key, md, pair.Revision)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, op)
+	}
+
+	return kv.commitWithEvents(kt, ops, events)
+}
+
+// ListFlow 查询任务流
+func (kv *kvBackend) ListFlow(kt *kit.Kit, input *ListInput) ([]model.Flow, error) {
+	records, err := listRecords[flowRecord](kt, kv.store, kvFlowPrefix, input)
+	if err != nil {
+		return nil, err
+	}
+
+	flows := make([]model.Flow, 0, len(records))
+	for _, one := range records {
+		flows = append(flows, one.Flow)
+	}
+
+	return flows, nil
+}
+
+// CountFlow 查询满足过滤条件的任务流数量
+func (kv *kvBackend) CountFlow(kt *kit.Kit, expr *filter.Expression) (uint64, error) {
+	records, err := listRecords[flowRecord](kt, kv.store, kvFlowPrefix, &ListInput{Filter: expr})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(len(records)), nil
+}
+
+// BatchUpdateFlowStateByCAS CAS批量更新任务流状态
+func (kv *kvBackend) BatchUpdateFlowStateByCAS(kt *kit.Kit, infos []UpdateFlowInfo) error {
+	ops := make([]kvOp, 0, len(infos))
+	events := make([]model.Event, 0, len(infos))
+	for _, one := range infos {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+
+		md := new(model.Flow)
+		pair, err := kv.get(kt, kvFlowPrefix+one.ID, md)
+		if err != nil {
+			return err
+		}
+
+		if md.State != one.Source {
+			return errf.Newf(errf.RecordNotUpdate, "flow[%s: %s] update state: %s, worker: %s failed",
+				one.ID, one.Source, one.Target, one.Worker)
+		}
+
+		events = append(events, newFlowEvent(md.ID, md.Name, one.Source, one.Target, one.Reason))
+		md.State = one.Target
+		if len(one.Worker) != 0 {
+			md.Worker = converter.ValToPtr(one.Worker)
+		}
+		if one.Reason != nil {
+			md.Reason = one.Reason
+		}
+		md.UpdatedAt = times.ConvStdTimeFormat(time.Now())
+
+		op, err := newKvOp(pair.Key, md, pair.Revision)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, op)
+	}
+
+	return kv.commitWithEvents(kt, ops, events)
+}
+
+// BatchCreateTask 批量创建任务
+func (kv *kvBackend) BatchCreateTask(kt *kit.Kit, tasks []model.Task) ([]string, error) {
+	mds := make([]model.Task, 0, len(tasks))
+	for _, one := range tasks {
+		one.State = enumor.TaskPending
+		mds = append(mds, one)
+	}
+
+	ops, ids, err := kv.createTaskOps(kt, mds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = kv.store.Commit(kt, ops); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// createTaskOps 生成创建任务的写操作
+func (kv *kvBackend) createTaskOps(kt *kit.Kit, tasks []model.Task) ([]kvOp, []string, error) {
+	if len(tasks) == 0 {
+		return make([]kvOp, 0), make([]string, 0), nil
+	}
+
+	ids, err := kv.nextIDs(kt, kvTaskPrefix, len(tasks))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := times.ConvStdTimeFormat(time.Now())
+	ops := make([]kvOp, 0, len(tasks))
+	for idx, one := range tasks {
+		one.ID = ids[idx]
+		one.CreatedAt = now
+		one.UpdatedAt = now
+
+		op, err := newKvOp(kvTaskPrefix+one.ID, one, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		ops = append(ops, op)
+	}
+
+	return ops, ids, nil
+}
+
+// UpdateTask 更新任务
+func (kv *kvBackend) UpdateTask(kt *kit.Kit, task *model.Task) error {
+	md := new(model.Task)
+	pair, err := kv.get(kt, kvTaskPrefix+task.ID, md)
+	if err != nil {
+		return err
+	}
+
+	if task.Retry != nil {
+		md.Retry = task.Retry
+	}
+	if task.DependOn != nil {
+		md.DependOn = task.DependOn
+	}
+	events := make([]model.Event, 0, 1)
+	if len(task.State) != 0 && task.State != md.State {
+		events = append(events, newTaskEvent(md, task.State, task.Reason))
+		md.State = task.State
+	}
+	if len(task.Result) != 0 {
+		md.Result = task.Result
+	}
+	if task.Reason != nil {
+		md.Reason = task.Reason
+	}
+	md.Reviser = kt.User
+	md.UpdatedAt = times.ConvStdTimeFormat(time.Now())
+
+	op, err := newKvOp(pair.Key, md, pair.Revision)
+	if err != nil {
+		return err
+	}
+
+	return kv.commitWithEvents(kt, []kvOp{op}, events)
+}
+
+// UpdateTaskStateByCAS CAS更新任务状态
+func (kv *kvBackend) UpdateTaskStateByCAS(kt *kit.Kit, info *UpdateTaskInfo) error {
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	md := new(model.Task)
+	pair, err := kv.get(kt, kvTaskPrefix+info.ID, md)
+	if err != nil {
+		return err
+	}
+
+	if md.State != info.Source {
+		return errf.Newf(errf.RecordNotUpdate, "task[%s: %s] update state: %s failed", info.ID, info.Source,
+			info.Target)
+	}
+
+	event := newTaskEvent(md, info.Target, info.Reason)
+	md.State = info.Target
+	if info.Reason != nil {
+		md.Reason = info.Reason
+	}
+	md.UpdatedAt = times.ConvStdTimeFormat(time.Now())
+
+	op, err := newKvOp(pair.Key, md, pair.Revision)
+	if err != nil {
+		return err
+	}
+
+	return kv.commitWithEvents(kt, []kvOp{op}, []model.Event{event})
+}
+
+// ListTask 查询任务
+func (kv *kvBackend) ListTask(kt *kit.Kit, input *ListInput) ([]model.Task, error) {
+	records, err := listRecords[taskRecord](kt, kv.store, kvTaskPrefix, input)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]model.Task, 0, len(records))
+	for _, one := range records {
+		tasks = append(tasks, one.Task)
+	}
+
+	return tasks, nil
+}
+
+// CountTask 查询满足过滤条件的任务数量
+func (kv *kvBackend) CountTask(kt *kit.Kit, expr *filter.Expression) (uint64, error) {
+	records, err := listRecords[taskRecord](kt, kv.store, kvTaskPrefix, &ListInput{Filter: expr})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(len(records)), nil
+}
+
+// CreateSchedule 创建任务流定时计划
+func (kv *kvBackend) CreateSchedule(kt *kit.Kit, schedule *model.Schedule) (string, error) {
+	ids, err := kv.nextIDs(kt, kvSchedulePrefix, 1)
+	if err != nil {
+		return "", err
+	}
+
+	now := times.ConvStdTimeFormat(time.Now())
+	md := model.Schedule{
+		ID:         ids[0],
+		Name:       schedule.Name,
+		FlowName:   schedule.FlowName,
+		Memo:       schedule.Memo,
+		Tasks:      schedule.Tasks,
+		Cron:       schedule.Cron,
+		NextRunAt:  schedule.NextRunAt,
+		State:      enumor.FlowScheduleEnabled,
+		Reason:     new(tableasync.Reason),
+		LastFlowID: "",
+		Creator:    kt.User,
+		Reviser:    kt.User,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	op, err := newKvOp(kvSchedulePrefix+md.ID, md, 0)
+	if err != nil {
+		return "", err
+	}
+
+	if err = kv.store.Commit(kt, []kvOp{op}); err != nil {
+		return "", err
+	}
+
+	return md.ID, nil
+}
+
+// ListSchedule 查询任务流定时计划
+func (kv *kvBackend) ListSchedule(kt *kit.Kit, input *ListInput) ([]model.Schedule, error) {
+	records, err := listRecords[scheduleRecord](kt, kv.store, kvSchedulePrefix, input)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]model.Schedule, 0, len(records))
+	for _, one := range records {
+		schedules = append(schedules, one.Schedule)
+	}
+
+	return schedules, nil
+}
+
+// TriggerSchedule 触发任务流定时计划，任务流创建和定时计划更新在同一次提交中，避免重复创建任务流
+func (kv *kvBackend) TriggerSchedule(kt *kit.Kit, info *TriggerScheduleInfo, flow *model.Flow) (string, error) {
+	if err := info.Validate(); err != nil {
+		return "", err
+	}
+
+	md := new(model.Schedule)
+	pair, err := kv.get(kt, kvSchedulePrefix+info.ID, md)
+	if err != nil {
+		return "", err
+	}
+
+	if md.State != enumor.FlowScheduleEnabled || !md.NextRunAt.Equal(info.SourceNextRunAt) {
+		return "", errf.Newf(errf.RecordNotUpdate, "schedule[%s: %s] has been changed, skip trigger", info.ID,
+			info.SourceNextRunAt)
+	}
+
+	md.State = info.State
+	if !info.TargetNextRunAt.IsZero() {
+		md.NextRunAt = info.TargetNextRunAt
+	}
+	if info.Reason != nil {
+		md.Reason = info.Reason
+	}
+	md.UpdatedAt = times.ConvStdTimeFormat(time.Now())
+
+	flowID := ""
+	err = kv.commitEventOps(kt, func() ([]kvOp, error) {
+		ops := make([]kvOp, 0)
+		if flow != nil {
+			var err error
+			if ops, flowID, err = kv.createFlowOps(kt, flow); err != nil {
+				return nil, err
+			}
+		}
+
+		md.LastFlowID = info.LastFlowID
+		if len(flowID) != 0 {
+			md.LastFlowID = flowID
+		}
+
+		op, err := newKvOp(pair.Key, md, pair.Revision)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(ops, op), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return flowID, nil
+}
+
+// CountSchedule 查询满足过滤条件的任务流定时计划数量
+func (kv *kvBackend) CountSchedule(kt *kit.Kit, expr *filter.Expression) (uint64, error) {
+	records, err := listRecords[scheduleRecord](kt, kv.store, kvSchedulePrefix, &ListInput{Filter: expr})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(len(records)), nil
+}
+
+// UpdateScheduleStateByCAS CAS更新任务流定时计划状态，与定时计划的触发都基于记录版本号提交，不会互相覆盖
+func (kv *kvBackend) UpdateScheduleStateByCAS(kt *kit.Kit, info *UpdateScheduleStateInfo) error {
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	md := new(model.Schedule)
+	pair, err := kv.get(kt, kvSchedulePrefix+info.ID, md)
+	if err != nil {
+		return err
+	}
+
+	if md.State != info.SourceState || !md.NextRunAt.Equal(info.SourceNextRunAt) {
+		return errf.Newf(errf.RecordNotUpdate, "schedule[%s: %s] has been changed, please retry", info.ID,
+			info.SourceState)
+	}
+
+	md.State = info.TargetState
+	if !info.TargetNextRunAt.IsZero() {
+		md.NextRunAt = info.TargetNextRunAt
+	}
+	if info.Reason != nil {
+		md.Reason = info.Reason
+	}
+	md.Reviser = kt.User
+	md.UpdatedAt = times.ConvStdTimeFormat(time.Now())
+
+	op, err := newKvOp(pair.Key, md, pair.Revision)
+	if err != nil {
+		return err
+	}
+
+	return kv.store.Commit(kt, []kvOp{op})
+}
+
+// DeleteSchedule 删除任务流定时计划，删除和触发都基于记录版本号提交，已删除的计划不会再被触发
+func (kv *kvBackend) DeleteSchedule(kt *kit.Kit, ids []string) error {
+	if len(ids) == 0 {
+		return errf.New(errf.InvalidParameter, "ids is required")
+	}
+
+	ops := make([]kvOp, 0, len(ids))
+	for _, id := range ids {
+		pair, err := kv.store.Get(kt, kvSchedulePrefix+id)
+		if err != nil {
+			return err
+		}
+
+		if pair == nil {
+			continue
+		}
+		ops = append(ops, kvOp{Key: pair.Key, Revision: pair.Revision, Delete: true})
+	}
+
+	for _, part := range slice.Split(ops, kvDeleteBatchSize) {
+		if err := kv.store.Commit(kt, part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListEvent 查询任务流事件
+func (kv *kvBackend) ListEvent(kt *kit.Kit, input *ListInput) ([]model.Event, error) {
+	records, err := listRecords[eventRecord](kt, kv.store, kvEventPrefix, input)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]model.Event, 0, len(records))
+	for _, one := range records {
+		events = append(events, one.Event)
+	}
+
+	return events, nil
+}
+
+// DeleteEvent 删除创建时间早于指定时间的任务流事件
+func (kv *kvBackend) DeleteEvent(kt *kit.Kit, before time.Time) error {
+	pairs, err := kv.store.List(kt, kvEventPrefix)
+	if err != nil {
+		return err
+	}
+
+	ops := make([]kvOp, 0)
+	for _, pair := range pairs {
+		event := new(model.Event)
+		if err = json.Unmarshal(pair.Value, event); err != nil {
+			return fmt.Errorf("unmarshal %s failed, err: %v", pair.Key, err)
+		}
+
+		if parseStdTime(event.CreatedAt).Before(before) {
+			ops = append(ops, kvOp{Key: pair.Key, Revision: pair.Revision, Delete: true})
+		}
+	}
+
+	// 分批删除，避免单次提交的操作数过多
+	for _, part := range slice.Split(ops, kvDeleteBatchSize) {
+		if err = kv.store.Commit(kt, part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteFinishedFlow 删除更新时间早于指定时间且已结束(成功、失败、取消)的任务流及其任务，
+// 同时删除所属任务流已不存在的过期任务(上一轮删除任务流时中途失败遗留的任务)
+func (kv *kvBackend) DeleteFinishedFlow(kt *kit.Kit, before time.Time) error {
+	flowPairs, err := kv.store.List(kt, kvFlowPrefix)
+	if err != nil {
+		return err
+	}
+
+	flowIDs := make(map[string]struct{}, len(flowPairs))
+	expiredFlows := make([]kvPair, 0)
+	for _, pair := range flowPairs {
+		flow := new(model.Flow)
+		if err = json.Unmarshal(pair.Value, flow); err != nil {
+			return fmt.Errorf("unmarshal %s failed, err: %v", pair.Key, err)
+		}
+
+		flowIDs[flow.ID] = struct{}{}
+		switch flow.State {
+		case enumor.FlowSuccess, enumor.FlowFailed, enumor.FlowCancel:
+			if parseStdTime(flow.UpdatedAt).Before(before) {
+				expiredFlows = append(expiredFlows, pair)
+			}
+		}
+	}
+
+	taskPairs, err := kv.store.List(kt, kvTaskPrefix)
+	if err != nil {
+		return err
+	}
+
+	taskOps := make(map[string][]kvOp)
+	orphanOps := make([]kvOp, 0)
+	for _, pair := range taskPairs {
+		task := new(model.Task)
+		if err = json.Unmarshal(pair.Value, task); err != nil {
+			return fmt.Errorf("unmarshal %s failed, err: %v", pair.Key, err)
+		}
+
+		op := kvOp{Key: pair.Key, Revision: pair.Revision, Delete: true}
+		if _, exist := flowIDs[task.FlowID]; !exist {
+			if parseStdTime(task.UpdatedAt).Before(before) {
+				orphanOps = append(orphanOps, op)
+			}
+			continue
+		}
+		taskOps[task.FlowID] = append(taskOps[task.FlowID], op)
+	}
+
+	for _, pair := range expiredFlows {
+		flowID := strings.TrimPrefix(pair.Key, kvFlowPrefix)
+		ops := append(taskOps[flowID], kvOp{Key: pair.Key, Revision: pair.Revision, Delete: true})
+
+		// 分批删除时最后删除任务流，中途失败时任务流仍存在，下一轮会重新删除
+		for _, part := range slice.Split(ops, kvDeleteBatchSize) {
+			if err = kv.store.Commit(kt, part); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			continue
+		}
+
+		// 任务流在查询后被重新修改，跳过该任务流，等下一轮重新判断
+		if errf.Error(err).Code == errf.RecordNotUpdate {
+			logs.Warnf("flow %s is changed while deleting, skip it, rid: %s", flowID, kt.Rid)
+			continue
+		}
+		return err
+	}
+
+	for _, part := range slice.Split(orphanOps, kvDeleteBatchSize) {
+		if err = kv.store.Commit(kt, part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListWebhook 查询任务流事件回调
+func (kv *kvBackend) ListWebhook(kt *kit.Kit, input *ListInput) ([]model.Webhook, error) {
+	records, err := listRecords[webhookRecord](kt, kv.store, kvWebhookPrefix, input)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]model.Webhook, 0, len(records))
+	for _, one := range records {
+		webhooks = append(webhooks, one.Webhook)
+	}
+
+	return webhooks, nil
+}
+
+// CreateWebhook 创建任务流事件回调
+func (kv *kvBackend) CreateWebhook(kt *kit.Kit, webhook *model.Webhook) (string, error) {
+	ids, err := kv.nextIDs(kt, kvWebhookPrefix, 1)
+	if err != nil {
+		return "", err
+	}
+
+	now := times.ConvStdTimeFormat(time.Now())
+	md := *webhook
+	md.ID = ids[0]
+	md.Creator = kt.User
+	md.Reviser = kt.User
+	md.CreatedAt = now
+	md.UpdatedAt = now
+
+	op, err := newKvOp(kvWebhookPrefix+md.ID, md, 0)
+	if err != nil {
+		return "", err
+	}
+
+	if err = kv.store.Commit(kt, []kvOp{op}); err != nil {
+		return "", err
+	}
+
+	return md.ID, nil
+}
+
+// CountWebhook 查询满足过滤条件的任务流事件回调数量
+func (kv *kvBackend) CountWebhook(kt *kit.Kit, expr *filter.Expression) (uint64, error) {
+	records, err := listRecords[webhookRecord](kt, kv.store, kvWebhookPrefix, &ListInput{Filter: expr})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(len(records)), nil
+}
+
+// UpdateWebhook 更新任务流事件回调的投递进度
+func (kv *kvBackend) UpdateWebhook(kt *kit.Kit, webhook *model.Webhook) error {
+	return kv.updateWebhook(kt, webhook.ID, func(md *model.Webhook) {
+		if len(webhook.State) != 0 {
+			md.State = webhook.State
+		}
+		if len(webhook.LastEventID) != 0 {
+			md.LastEventID = webhook.LastEventID
+		}
+		if webhook.Reason != nil {
+			md.Reason = webhook.Reason
+		}
+		if len(webhook.Reviser) != 0 {
+			md.Reviser = webhook.Reviser
+		}
+	})
+}
+
+// UpdateWebhookConfig 更新任务流事件回调的配置
+func (kv *kvBackend) UpdateWebhookConfig(kt *kit.Kit, info *UpdateWebhookInfo) error {
+	if err := info.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return kv.updateWebhook(kt, info.ID, func(md *model.Webhook) {
+		if len(info.Name) != 0 {
+			md.Name = info.Name
+		}
+		if len(info.URL) != 0 {
+			md.URL = info.URL
+		}
+		if info.Secret != nil {
+			md.Secret = *info.Secret
+		}
+		if info.FlowNames != nil {
+			md.FlowNames = info.FlowNames
+		}
+		if info.EventTypes != nil {
+			md.EventTypes = info.EventTypes
+		}
+		if len(info.State) != 0 {
+			md.State = info.State
+		}
+		if info.Reason != nil {
+			md.Reason = info.Reason
+		}
+		if info.Memo != nil {
+			md.Memo = *info.Memo
+		}
+		md.Reviser = kt.User
+	})
+}
+
+// updateWebhook 读取任务流事件回调修改后写入，投递进度与配置同时更新发生冲突时重新读取后重试
+func (kv *kvBackend) updateWebhook(kt *kit.Kit, id string, modify func(md *model.Webhook)) error {
+	for retry := 0; retry < kvUpdateMaxRetry; retry++ {
+		md := new(model.Webhook)
+		pair, err := kv.get(kt, kvWebhookPrefix+id, md)
+		if err != nil {
+			return err
+		}
+
+		modify(md)
+		md.UpdatedAt = times.ConvStdTimeFormat(time.Now())
+
+		op, err := newKvOp(pair.Key, md, pair.Revision)
+		if err != nil {
+			return err
+		}
+
+		err = kv.store.Commit(kt, []kvOp{op})
+		if err != nil && errf.Error(err).Code == errf.RecordNotUpdate {
+			continue
+		}
+		return err
+	}
+
+	return fmt.Errorf("update webhook %s failed, conflict exceeds max retry %d", id, kvUpdateMaxRetry)
+}
+
+// DeleteWebhook 删除任务流事件回调
+func (kv *kvBackend) DeleteWebhook(kt *kit.Kit, ids []string) error {
+	if len(ids) == 0 {
+		return errf.New(errf.InvalidParameter, "ids is required")
+	}
+
+	ops := make([]kvOp, 0, len(ids))
+	for _, id := range ids {
+		pair, err := kv.store.Get(kt, kvWebhookPrefix+id)
+		if err != nil {
+			return err
+		}
+
+		if pair == nil {
+			continue
+		}
+		ops = append(ops, kvOp{Key: pair.Key, Revision: pair.Revision, Delete: true})
+	}
+
+	for _, part := range slice.Split(ops, kvDeleteBatchSize) {
+		if err := kv.store.Commit(kt, part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commitWithEvents 将状态变更与事件在同一次提交中写入
+func (kv *kvBackend) commitWithEvents(kt *kit.Kit, ops []kvOp, events []model.Event) error {
+	return kv.commitEventOps(kt, func() ([]kvOp, error) {
+		eventOps, err := kv.eventOps(kt, events)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(append(make([]kvOp, 0, len(ops)+len(eventOps)), ops...), eventOps...), nil
+	})
+}
+
+// commitEventOps 提交包含事件的写操作，事件ID生成器的更新与事件在同一次提交中写入，
+// 事件按ID的顺序提交。仅因其他提交同时生成事件ID导致冲突时，重新生成写操作后重试。
+func (kv *kvBackend) commitEventOps(kt *kit.Kit, genOps func() ([]kvOp, error)) error {
+	var err error
+	for retry := 0; retry < kvUpdateMaxRetry; retry++ {
+		var ops []kvOp
+		if ops, err = genOps(); err != nil {
+			return err
+		}
+
+		err = kv.store.Commit(kt, ops)
+		if err == nil || errf.Error(err).Code != errf.RecordNotUpdate {
+			return err
+		}
+
+		conflict, getErr := kv.eventIDConflict(kt, ops)
+		if getErr != nil {
+			return getErr
+		}
+
+		if !conflict {
+			return err
+		}
+	}
+
+	return err
+}
+
+// eventIDConflict 提交失败后检查事件ID生成器是否已被其他提交修改
+func (kv *kvBackend) eventIDConflict(kt *kit.Kit, ops []kvOp) (bool, error) {
+	key := kvIDGeneratorPrefix + kvEventPrefix
+	for _, op := range ops {
+		if op.Key != key {
+			continue
+		}
+
+		pair, err := kv.store.Get(kt, key)
+		if err != nil {
+			return false, err
+		}
+
+		var revision int64
+		if pair != nil {
+			revision = pair.Revision
+		}
+		return revision != op.Revision, nil
+	}
+
+	return false, nil
+}
+
+// eventOps 生成写入事件的操作，包括更新事件ID生成器的操作，需要与事件在同一次提交中写入
+func (kv *kvBackend) eventOps(kt *kit.Kit, events []model.Event) ([]kvOp, error) {
+	if len(events) == 0 {
+		return make([]kvOp, 0), nil
+	}
+
+	idOp, ids, err := kv.nextIDsOp(kt, kvEventPrefix, len(events))
+	if err != nil {
+		return nil, err
+	}
+
+	now := times.ConvStdTimeFormat(time.Now())
+	ops := make([]kvOp, 0, len(events)+1)
+	ops = append(ops, idOp)
+	for idx, one := range events {
+		one.ID = ids[idx]
+		one.Creator = kt.User
+		one.CreatedAt = now
+
+		op, err := newKvOp(kvEventPrefix+one.ID, one, 0)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+
+	return ops, nil
+}
+
+// get 查询记录并解析到result中，记录不存在时返回RecordNotFound错误
+func (kv *kvBackend) get(kt *kit.Kit, key string, result interface{}) (*kvPair, error) {
+	pair, err := kv.store.Get(kt, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if pair == nil {
+		return nil, errf.Newf(errf.RecordNotFound, "%s not found", key)
+	}
+
+	if err = json.Unmarshal(pair.Value, result); err != nil {
+		return nil, fmt.Errorf("unmarshal %s failed, err: %v", key, err)
+	}
+
+	return pair, nil
+}
+
+// nextIDs 生成指定资源的num个ID，ID生成规则与mysql后端一致
+func (kv *kvBackend) nextIDs(kt *kit.Kit, resource string, num int) ([]string, error) {
+	for retry := 0; retry < kvNextIDMaxRetry; retry++ {
+		op, ids, err := kv.nextIDsOp(kt, resource, num)
+		if err != nil {
+			return nil, err
+		}
+
+		err = kv.store.Commit(kt, []kvOp{op})
+		if err != nil {
+			if errf.Error(err).Code == errf.RecordNotUpdate {
+				continue
+			}
+			return nil, err
+		}
+
+		return ids, nil
+	}
+
+	return nil, fmt.Errorf("generate %s id failed, conflict exceeds max retry %d", resource, kvNextIDMaxRetry)
+}
+
+// nextIDsOp 生成指定资源的num个ID及更新ID生成器的操作，该操作提交成功后ID才生效
+func (kv *kvBackend) nextIDsOp(kt *kit.Kit, resource string, num int) (kvOp, []string, error) {
+	key := kvIDGeneratorPrefix + resource
+
+	pair, err := kv.store.Get(kt, key)
+	if err != nil {
+		return kvOp{}, nil, err
+	}
+
+	var current uint64
+	var revision int64
+	if pair != nil {
+		if current, err = strconv.ParseUint(string(pair.Value), 10, 64); err != nil {
+			return kvOp{}, nil, fmt.Errorf("parse %s max id failed, err: %v", resource, err)
+		}
+		revision = pair.Revision
+	}
+
+	next := current + uint64(num)
+	op := kvOp{Key: key, Value: []byte(strconv.FormatUint(next, 10)), Revision: revision}
+
+	ids := make([]string, 0, num)
+	for id := current + 1; id <= next; id++ {
+		ids = append(ids, fmt.Sprintf("%08s", strconv.FormatUint(id, 36)))
+	}
+
+	return op, ids, nil
+}
+
+func newKvOp(key string, value interface{}, revision int64) (kvOp, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return kvOp{}, fmt.Errorf("marshal %s failed, err: %v", key, err)
+	}
+
+	return kvOp{Key: key, Value: raw, Revision: revision}, nil
+}
+
+// listRecords 查询指定前缀的记录，并在内存中过滤、排序、分页
+func listRecords[T record](kt *kit.Kit, store kvStore, prefix string, input *ListInput) ([]T, error) {
+	if input == nil {
+		return nil, errf.New(errf.InvalidParameter, "list input is required")
+	}
+
+	pairs, err := store.List(kt, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]T, 0, len(pairs))
+	for _, pair := range pairs {
+		var one T
+		if err = json.Unmarshal(pair.Value, &one); err != nil {
+			return nil, fmt.Errorf("unmarshal %s failed, err: %v", pair.Key, err)
+		}
+		list = append(list, one)
+	}
+
+	return filterRecords(list, input.Filter, input.Page)
+}
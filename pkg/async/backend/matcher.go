@@ -0,0 +1,269 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/runtime/filter"
+)
+
+// record 非关系型存储(memory、etcd)中的一条记录，values为参与过滤、排序的字段值
+type record interface {
+	values() map[string]interface{}
+}
+
+// matchExpr 在内存中判断记录是否满足过滤条件
+func matchExpr(expr *filter.Expression, values map[string]interface{}) (bool, error) {
+	if expr == nil || len(expr.Rules) == 0 {
+		return true, nil
+	}
+
+	for _, rule := range expr.Rules {
+		var matched bool
+		var err error
+		switch r := rule.(type) {
+		case *filter.Expression:
+			matched, err = matchExpr(r, values)
+		case *filter.AtomRule:
+			matched, err = matchAtom(r, values)
+		case filter.AtomRule:
+			matched, err = matchAtom(&r, values)
+		default:
+			return false, fmt.Errorf("unsupported rule type: %T", rule)
+		}
+		if err != nil {
+			return false, err
+		}
+
+		switch expr.Op {
+		case filter.And:
+			if !matched {
+				return false, nil
+			}
+		case filter.Or:
+			if matched {
+				return true, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported logic operator: %s", expr.Op)
+		}
+	}
+
+	return expr.Op == filter.And, nil
+}
+
+// matchAtom 在内存中判断记录是否满足单个过滤规则
+func matchAtom(rule *filter.AtomRule, values map[string]interface{}) (bool, error) {
+	val, exist := values[rule.Field]
+	if !exist {
+		return false, fmt.Errorf("field %s not support filter", rule.Field)
+	}
+
+	switch filter.OpType(rule.Op) {
+	case filter.Equal, filter.NotEqual, filter.GreaterThan, filter.GreaterThanEqual, filter.LessThan,
+		filter.LessThanEqual:
+		ret, err := compareValue(val, rule.Value)
+		if err != nil {
+			return false, err
+		}
+		switch filter.OpType(rule.Op) {
+		case filter.Equal:
+			return ret == 0, nil
+		case filter.NotEqual:
+			return ret != 0, nil
+		case filter.GreaterThan:
+			return ret > 0, nil
+		case filter.GreaterThanEqual:
+			return ret >= 0, nil
+		case filter.LessThan:
+			return ret < 0, nil
+		default:
+			return ret <= 0, nil
+		}
+
+	case filter.In, filter.NotIn:
+		in, err := containsValue(val, rule.Value)
+		if err != nil {
+			return false, err
+		}
+		if filter.OpType(rule.Op) == filter.In {
+			return in, nil
+		}
+		return !in, nil
+
+	case filter.ContainsSensitive:
+		return strings.Contains(toString(val), toString(rule.Value)), nil
+
+	case filter.ContainsInsensitive:
+		return strings.Contains(strings.ToLower(toString(val)), strings.ToLower(toString(rule.Value))), nil
+
+	default:
+		return false, fmt.Errorf("operator %s not support", rule.Op)
+	}
+}
+
+// containsValue 判断val是否在数组target中
+func containsValue(val interface{}, target interface{}) (bool, error) {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return false, fmt.Errorf("in/nin operator value should be an array, but got %T", target)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		ret, err := compareValue(val, rv.Index(i).Interface())
+		if err != nil {
+			return false, err
+		}
+		if ret == 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// compareValue 比较记录字段值与过滤值，时间字段按照标准时间格式解析过滤值后比较，数值按照浮点数比较，其余按照字符串比较
+func compareValue(val interface{}, target interface{}) (int, error) {
+	if t, ok := val.(time.Time); ok {
+		var targetTime time.Time
+		switch tv := target.(type) {
+		case time.Time:
+			targetTime = tv
+		case string:
+			parsed, err := time.Parse(constant.TimeStdFormat, tv)
+			if err != nil {
+				return 0, fmt.Errorf("parse time %s failed, err: %v", tv, err)
+			}
+			targetTime = parsed
+		default:
+			return 0, fmt.Errorf("time field can not compare with %T", target)
+		}
+
+		switch {
+		case t.Before(targetTime):
+			return -1, nil
+		case t.After(targetTime):
+			return 1, nil
+		default:
+			return 0, nil
+		}
+	}
+
+	valNum, valIsNum := toFloat(val)
+	targetNum, targetIsNum := toFloat(target)
+	if valIsNum && targetIsNum {
+		switch {
+		case valNum < targetNum:
+			return -1, nil
+		case valNum > targetNum:
+			return 1, nil
+		default:
+			return 0, nil
+		}
+	}
+
+	return strings.Compare(toString(val), toString(target)), nil
+}
+
+func toFloat(val interface{}) (float64, bool) {
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func toString(val interface{}) string {
+	if val == nil {
+		return ""
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() == reflect.String {
+		return rv.String()
+	}
+
+	return fmt.Sprint(val)
+}
+
+// filterRecords 按照查询条件过滤记录，并排序、分页
+func filterRecords[T record](list []T, expr *filter.Expression, page *core.BasePage) ([]T, error) {
+	result := make([]T, 0)
+	for _, one := range list {
+		matched, err := matchExpr(expr, one.values())
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			result = append(result, one)
+		}
+	}
+
+	sortField := "id"
+	desc := false
+	if page != nil && len(page.Sort) != 0 {
+		sortField = page.Sort
+		desc = page.Order == core.Descending
+	}
+
+	var sortErr error
+	sort.SliceStable(result, func(i, j int) bool {
+		ret, err := compareValue(result[i].values()[sortField], result[j].values()[sortField])
+		if err != nil {
+			sortErr = err
+			return false
+		}
+		if desc {
+			return ret > 0
+		}
+		return ret < 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	if page == nil || page.Limit == 0 {
+		return result, nil
+	}
+
+	start := int(page.Start)
+	if start >= len(result) {
+		return make([]T, 0), nil
+	}
+	end := start + int(page.Limit)
+	if end > len(result) {
+		end = len(result)
+	}
+
+	return result[start:end], nil
+}
@@ -0,0 +1,185 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+// NewMemory create memory instance, 数据仅保存在进程内存中，用于单元测试及本地调试
+func NewMemory() Backend {
+	return &kvBackend{
+		store: newMemoryStore(),
+	}
+}
+
+// memoryStore 基于内存实现的键值存储
+type memoryStore struct {
+	lock     sync.RWMutex
+	revision int64
+	data     map[string]kvPair
+	watchers *kvWatchers
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		data:     make(map[string]kvPair),
+		watchers: newKvWatchers(),
+	}
+}
+
+// Get 查询记录
+func (m *memoryStore) Get(_ *kit.Kit, key string) (*kvPair, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	pair, exist := m.data[key]
+	if !exist {
+		return nil, nil
+	}
+
+	return &pair, nil
+}
+
+// List 查询指定前缀的所有记录
+func (m *memoryStore) List(_ *kit.Kit, prefix string) ([]kvPair, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	pairs := make([]kvPair, 0)
+	for key, pair := range m.data {
+		if strings.HasPrefix(key, prefix) {
+			pairs = append(pairs, pair)
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+
+	return pairs, nil
+}
+
+// Commit 原子的执行写操作
+func (m *memoryStore) Commit(_ *kit.Kit, ops []kvOp) error {
+	m.lock.Lock()
+
+	for _, op := range ops {
+		if m.data[op.Key].Revision != op.Revision {
+			m.lock.Unlock()
+			return errf.Newf(errf.RecordNotUpdate, "%s has been changed, revision: %d", op.Key, op.Revision)
+		}
+	}
+
+	m.revision++
+	keys := make([]string, 0, len(ops))
+	for _, op := range ops {
+		if op.Delete {
+			delete(m.data, op.Key)
+		} else {
+			m.data[op.Key] = kvPair{Key: op.Key, Value: op.Value, Revision: m.revision}
+		}
+		keys = append(keys, op.Key)
+	}
+
+	m.lock.Unlock()
+
+	m.watchers.notify(keys...)
+	return nil
+}
+
+// Watch 监听指定前缀的记录变更
+func (m *memoryStore) Watch(prefix string, closeCh <-chan struct{}) <-chan struct{} {
+	return m.watchers.add(prefix, closeCh)
+}
+
+// kvWatchers 记录变更的监听者
+type kvWatchers struct {
+	lock     sync.Mutex
+	watchers map[*kvWatcher]struct{}
+}
+
+type kvWatcher struct {
+	prefix string
+	ch     chan struct{}
+}
+
+func newKvWatchers() *kvWatchers {
+	return &kvWatchers{
+		watchers: make(map[*kvWatcher]struct{}),
+	}
+}
+
+// add 添加监听者，closeCh关闭后移除
+func (w *kvWatchers) add(prefix string, closeCh <-chan struct{}) <-chan struct{} {
+	watcher := &kvWatcher{
+		prefix: prefix,
+		// 通知只用于唤醒，缓冲为1即可合并短时间内的多次变更
+		ch: make(chan struct{}, 1),
+	}
+
+	w.lock.Lock()
+	w.watchers[watcher] = struct{}{}
+	w.lock.Unlock()
+
+	go func() {
+		<-closeCh
+		w.lock.Lock()
+		delete(w.watchers, watcher)
+		w.lock.Unlock()
+	}()
+
+	return watcher.ch
+}
+
+// notify 通知监听了变更记录前缀的监听者
+func (w *kvWatchers) notify(keys ...string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for watcher := range w.watchers {
+		for _, key := range keys {
+			if !strings.HasPrefix(key, watcher.prefix) {
+				continue
+			}
+
+			select {
+			case watcher.ch <- struct{}{}:
+			default:
+			}
+			break
+		}
+	}
+}
+
+// notifyAll 通知所有监听者
+func (w *kvWatchers) notifyAll() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for watcher := range w.watchers {
+		select {
+		case watcher.ch <- struct{}{}:
+		default:
+		}
+	}
+}
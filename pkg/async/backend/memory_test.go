@@ -0,0 +1,487 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package backend
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+)
+
+func TestMemoryFlow(t *testing.T) {
+	bd := NewMemory()
+	kt := kit.New()
+
+	flow := &model.Flow{
+		Name:      enumor.FlowStartCvm,
+		ShareData: tableasync.NewShareData(),
+		Tasks: []model.Task{
+			{FlowName: enumor.FlowStartCvm, ActionID: "1", ActionName: enumor.ActionStartCvm},
+			{FlowName: enumor.FlowStartCvm, ActionID: "2", ActionName: enumor.ActionStartCvm, DependOn: nil},
+		},
+	}
+	flowID, err := bd.CreateFlow(kt, flow)
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+	if flowID != "00000001" {
+		t.Errorf("flow id should be 00000001, but got %s", flowID)
+	}
+
+	input := &ListInput{Filter: tools.EqualExpression("state", enumor.FlowPending), Page: core.NewDefaultBasePage()}
+	flows, err := bd.ListFlow(kt, input)
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(flows) != 1 || flows[0].ID != flowID {
+		t.Fatalf("list pending flow should return created flow, but got %+v", flows)
+	}
+
+	input = &ListInput{Filter: tools.EqualExpression("flow_id", flowID), Page: core.NewDefaultBasePage()}
+	tasks, err := bd.ListTask(kt, input)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].State != enumor.TaskPending {
+		t.Fatalf("list task should return 2 pending tasks, but got %+v", tasks)
+	}
+
+	info := UpdateFlowInfo{ID: flowID, Source: enumor.FlowPending, Target: enumor.FlowScheduled, Worker: "node1"}
+	if err = bd.BatchUpdateFlowStateByCAS(kt, []UpdateFlowInfo{info}); err != nil {
+		t.Fatalf("update flow state failed, err: %v", err)
+	}
+
+	// 状态已被修改，再次CAS更新应失败
+	err = bd.BatchUpdateFlowStateByCAS(kt, []UpdateFlowInfo{info})
+	if errf.Error(err).Code != errf.RecordNotUpdate {
+		t.Fatalf("update flow state by cas again should return record not update, but got %v", err)
+	}
+
+	input = &ListInput{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "state", Op: filter.Equal.Factory(), Value: enumor.FlowScheduled},
+				filter.AtomRule{Field: "worker", Op: filter.Equal.Factory(), Value: "node1"},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	flows, err = bd.ListFlow(kt, input)
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(flows) != 1 || flows[0].ShareData == nil {
+		t.Fatalf("list scheduled flow should return created flow, but got %+v", flows)
+	}
+
+	count, err := bd.CountTask(kt, tools.EqualExpression("flow_id", flowID))
+	if err != nil {
+		t.Fatalf("count task failed, err: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("count task of flow should be 2, but got %d", count)
+	}
+
+	count, err = bd.CountFlow(kt, tools.EqualExpression("state", enumor.FlowPending))
+	if err != nil {
+		t.Fatalf("count flow failed, err: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("count pending flow should be 0, but got %d", count)
+	}
+}
+
+func TestMemoryTriggerSchedule(t *testing.T) {
+	bd := NewMemory()
+	kt := kit.New()
+
+	runAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	id, err := bd.CreateSchedule(kt, &model.Schedule{Name: "test", FlowName: enumor.FlowStartCvm, NextRunAt: runAt})
+	if err != nil {
+		t.Fatalf("create schedule failed, err: %v", err)
+	}
+
+	input := &ListInput{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "state", Op: filter.Equal.Factory(), Value: enumor.FlowScheduleEnabled},
+				filter.AtomRule{Field: "next_run_at", Op: filter.LessThanEqual.Factory(),
+					Value: runAt.Format(time.RFC3339)},
+			},
+		},
+		Page: core.NewDefaultBasePage(),
+	}
+	schedules, err := bd.ListSchedule(kt, input)
+	if err != nil {
+		t.Fatalf("list schedule failed, err: %v", err)
+	}
+	if len(schedules) != 1 || schedules[0].ID != id {
+		t.Fatalf("list due schedule should return created schedule, but got %+v", schedules)
+	}
+
+	info := &TriggerScheduleInfo{ID: id, SourceNextRunAt: runAt, State: enumor.FlowScheduleFinished}
+	flow := &model.Flow{Name: enumor.FlowStartCvm, Tasks: []model.Task{{ActionID: "1"}}}
+	flowID, err := bd.TriggerSchedule(kt, info, flow)
+	if err != nil {
+		t.Fatalf("trigger schedule failed, err: %v", err)
+	}
+
+	// 已触发的计划不能再次触发，也不能重复创建任务流
+	if _, err = bd.TriggerSchedule(kt, info, flow); errf.Error(err).Code != errf.RecordNotUpdate {
+		t.Fatalf("trigger schedule again should return record not update, but got %v", err)
+	}
+
+	flows, err := bd.ListFlow(kt, &ListInput{Page: core.NewDefaultBasePage()})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(flows) != 1 || flows[0].ID != flowID {
+		t.Fatalf("trigger schedule should create one flow, but got %+v", flows)
+	}
+
+	schedules, err = bd.ListSchedule(kt, &ListInput{Filter: tools.EqualExpression("id", id)})
+	if err != nil {
+		t.Fatalf("list schedule failed, err: %v", err)
+	}
+	if schedules[0].State != enumor.FlowScheduleFinished || schedules[0].LastFlowID != flowID {
+		t.Fatalf("schedule should be finished with last flow id %s, but got %+v", flowID, schedules[0])
+	}
+}
+
+func TestMemoryWatchFlow(t *testing.T) {
+	bd := NewMemory()
+	kt := kit.New()
+
+	closeCh := make(chan struct{})
+	defer close(closeCh)
+	notifyCh := bd.(Watcher).WatchFlow(closeCh)
+
+	if _, err := bd.CreateFlow(kt, &model.Flow{Name: enumor.FlowStartCvm}); err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	select {
+	case <-notifyCh:
+	case <-time.After(time.Second):
+		t.Fatal("create flow should notify watcher")
+	}
+}
+
+func TestFilterRecordsPage(t *testing.T) {
+	list := make([]flowRecord, 0)
+	for _, id := range []string{"00000003", "00000001", "00000002"} {
+		list = append(list, flowRecord{Flow: model.Flow{ID: id, State: enumor.FlowPending}})
+	}
+
+	expr := &filter.Expression{
+		Op: filter.Or,
+		Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "id", Op: filter.In.Factory(), Value: []string{"00000001", "00000003"}},
+			&filter.AtomRule{Field: "id", Op: filter.Equal.Factory(), Value: "00000002"},
+		},
+	}
+	page := &core.BasePage{Start: 1, Limit: 1, Sort: "id", Order: core.Descending}
+	result, err := filterRecords(list, expr, page)
+	if err != nil {
+		t.Fatalf("filter records failed, err: %v", err)
+	}
+	if len(result) != 1 || result[0].ID != "00000002" {
+		t.Fatalf("second record sort by id desc should be 00000002, but got %+v", result)
+	}
+
+	if _, err = filterRecords(list, tools.EqualExpression("unknown", "x"), nil); err == nil {
+		t.Fatal("filter by unknown field should return error")
+	}
+}
+
+func TestMemoryFlowEvent(t *testing.T) {
+	bd := NewMemory()
+	kt := kit.New()
+
+	flowID, err := bd.CreateFlow(kt, &model.Flow{Name: enumor.FlowStartCvm, Tasks: []model.Task{{ActionID: "1"}}})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	info := UpdateFlowInfo{ID: flowID, Source: enumor.FlowPending, Target: enumor.FlowScheduled, Worker: "node1"}
+	if err = bd.BatchUpdateFlowStateByCAS(kt, []UpdateFlowInfo{info}); err != nil {
+		t.Fatalf("update flow state failed, err: %v", err)
+	}
+
+	// CAS更新失败时不应产生事件
+	if err = bd.BatchUpdateFlowStateByCAS(kt, []UpdateFlowInfo{info}); err == nil {
+		t.Fatal("update flow state by cas again should failed")
+	}
+
+	input := &ListInput{
+		Filter: tools.EqualExpression("type", enumor.FlowStateEvent),
+		Page:   &core.BasePage{Limit: core.DefaultMaxPageLimit, Sort: "seq", Order: core.Ascending},
+	}
+	events, err := bd.ListEvent(kt, input)
+	if err != nil {
+		t.Fatalf("list event failed, err: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("flow should have 2 state events, but got %+v", events)
+	}
+	if events[0].Target != string(enumor.FlowPending) || events[1].Source != string(enumor.FlowPending) ||
+		events[1].Target != string(enumor.FlowScheduled) || events[1].FlowID != flowID {
+		t.Fatalf("flow state events not match, got %+v", events)
+	}
+
+	cursor, err := EventSeq(events[0].ID)
+	if err != nil {
+		t.Fatalf("parse event seq failed, err: %v", err)
+	}
+	input.Filter = &filter.Expression{
+		Op:    filter.And,
+		Rules: []filter.RuleFactory{filter.AtomRule{Field: "seq", Op: filter.GreaterThan.Factory(), Value: cursor}},
+	}
+	events, err = bd.ListEvent(kt, input)
+	if err != nil {
+		t.Fatalf("list event after cursor failed, err: %v", err)
+	}
+	if len(events) != 1 || events[0].Target != string(enumor.FlowScheduled) {
+		t.Fatalf("list event after cursor should return scheduled event, but got %+v", events)
+	}
+}
+
+func TestMemoryConcurrentFlowEvent(t *testing.T) {
+	bd := NewMemory()
+	kt := kit.New()
+
+	const count = 20
+	wg := sync.WaitGroup{}
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := bd.CreateFlow(kt, &model.Flow{Name: enumor.FlowStartCvm, Tasks: []model.Task{{ActionID: "1"}}})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	// 同时生成事件ID发生冲突时重试，不应返回错误
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("create flow concurrently failed, err: %v", err)
+		}
+	}
+
+	// 事件ID与事件在同一次提交中生成，按提交顺序连续递增
+	input := &ListInput{Page: &core.BasePage{Limit: core.DefaultMaxPageLimit, Sort: "seq", Order: core.Ascending}}
+	events, err := bd.ListEvent(kt, input)
+	if err != nil {
+		t.Fatalf("list event failed, err: %v", err)
+	}
+	if len(events) != count {
+		t.Fatalf("should have %d events, but got %d", count, len(events))
+	}
+	for idx, one := range events {
+		seq, err := EventSeq(one.ID)
+		if err != nil {
+			t.Fatalf("parse event seq failed, err: %v", err)
+		}
+		if seq != uint64(idx+1) {
+			t.Fatalf("event seq should be %d, but got %d", idx+1, seq)
+		}
+	}
+}
+
+func TestMemoryDeleteFinishedFlow(t *testing.T) {
+	bd := NewMemory()
+	kt := kit.New()
+
+	flowIDs := make([]string, 0, 2)
+	for i := 0; i < 2; i++ {
+		flow := &model.Flow{
+			Name:      enumor.FlowStartCvm,
+			ShareData: tableasync.NewShareData(),
+			Tasks: []model.Task{
+				{FlowName: enumor.FlowStartCvm, ActionID: "1", ActionName: enumor.ActionStartCvm},
+			},
+		}
+		flowID, err := bd.CreateFlow(kt, flow)
+		if err != nil {
+			t.Fatalf("create flow failed, err: %v", err)
+		}
+		flowIDs = append(flowIDs, flowID)
+	}
+
+	info := UpdateFlowInfo{ID: flowIDs[0], Source: enumor.FlowPending, Target: enumor.FlowSuccess}
+	if err := bd.BatchUpdateFlowStateByCAS(kt, []UpdateFlowInfo{info}); err != nil {
+		t.Fatalf("update flow state failed, err: %v", err)
+	}
+
+	cleaner := bd.(Cleaner)
+	listInput := &ListInput{Filter: tools.AllExpression(), Page: core.NewDefaultBasePage()}
+
+	// 未超过保留时长的任务流不应被删除
+	if err := cleaner.DeleteFinishedFlow(kt, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("delete finished flow failed, err: %v", err)
+	}
+	flows, err := bd.ListFlow(kt, listInput)
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(flows) != 2 {
+		t.Fatalf("flow in retention should not be deleted, but got %+v", flows)
+	}
+
+	if err = cleaner.DeleteFinishedFlow(kt, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("delete finished flow failed, err: %v", err)
+	}
+	flows, err = bd.ListFlow(kt, listInput)
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(flows) != 1 || flows[0].ID != flowIDs[1] {
+		t.Fatalf("only unfinished flow %s should be kept, but got %+v", flowIDs[1], flows)
+	}
+
+	tasks, err := bd.ListTask(kt, listInput)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].FlowID != flowIDs[1] {
+		t.Fatalf("only tasks of unfinished flow %s should be kept, but got %+v", flowIDs[1], tasks)
+	}
+}
+
+func TestMemoryUpdateAndDeleteSchedule(t *testing.T) {
+	bd := NewMemory()
+	kt := kit.New()
+
+	runAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	id, err := bd.CreateSchedule(kt, &model.Schedule{Name: "test", FlowName: enumor.FlowStartCvm, Cron: "* * * * *",
+		NextRunAt: runAt})
+	if err != nil {
+		t.Fatalf("create schedule failed, err: %v", err)
+	}
+
+	// 计划已被触发，基于旧的下次执行时间更新应失败，不能覆盖触发结果
+	trigger := &TriggerScheduleInfo{ID: id, SourceNextRunAt: runAt, TargetNextRunAt: runAt.Add(time.Minute),
+		State: enumor.FlowScheduleEnabled}
+	if _, err = bd.TriggerSchedule(kt, trigger, nil); err != nil {
+		t.Fatalf("trigger schedule failed, err: %v", err)
+	}
+
+	update := &UpdateScheduleStateInfo{ID: id, SourceState: enumor.FlowScheduleEnabled, SourceNextRunAt: runAt,
+		TargetState: enumor.FlowScheduleDisabled}
+	if err = bd.UpdateScheduleStateByCAS(kt, update); errf.Error(err).Code != errf.RecordNotUpdate {
+		t.Fatalf("update triggered schedule should return record not update, but got %v", err)
+	}
+
+	update.SourceNextRunAt = trigger.TargetNextRunAt
+	if err = bd.UpdateScheduleStateByCAS(kt, update); err != nil {
+		t.Fatalf("update schedule state failed, err: %v", err)
+	}
+
+	count, err := bd.CountSchedule(kt, tools.EqualExpression("state", enumor.FlowScheduleDisabled))
+	if err != nil {
+		t.Fatalf("count schedule failed, err: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("count disabled schedule should be 1, but got %d", count)
+	}
+
+	if err = bd.DeleteSchedule(kt, []string{id, "not_exist"}); err != nil {
+		t.Fatalf("delete schedule failed, err: %v", err)
+	}
+
+	// 已删除的计划不能再被触发
+	trigger = &TriggerScheduleInfo{ID: id, SourceNextRunAt: update.SourceNextRunAt, State: enumor.FlowScheduleEnabled}
+	flow := &model.Flow{Name: enumor.FlowStartCvm, Tasks: []model.Task{{ActionID: "1"}}}
+	if _, err = bd.TriggerSchedule(kt, trigger, flow); err == nil {
+		t.Fatalf("trigger deleted schedule should fail")
+	}
+
+	flows, err := bd.ListFlow(kt, &ListInput{Page: core.NewDefaultBasePage()})
+	if err != nil {
+		t.Fatalf("list flow failed, err: %v", err)
+	}
+	if len(flows) != 0 {
+		t.Fatalf("deleted schedule should not create flow, but got %+v", flows)
+	}
+}
+
+func TestMemoryWebhook(t *testing.T) {
+	bd := NewMemory()
+	kt := kit.New()
+
+	id, err := bd.CreateWebhook(kt, &model.Webhook{Name: "test", URL: "http://127.0.0.1/callback", Secret: "secret",
+		State: enumor.FlowWebhookEnabled, LastEventID: "00000001"})
+	if err != nil {
+		t.Fatalf("create webhook failed, err: %v", err)
+	}
+
+	// 投递进度与配置的更新互不覆盖
+	if err = bd.UpdateWebhook(kt, &model.Webhook{ID: id, LastEventID: "00000002"}); err != nil {
+		t.Fatalf("update webhook progress failed, err: %v", err)
+	}
+
+	info := &UpdateWebhookInfo{ID: id, URL: "http://127.0.0.1/new", Secret: converter.ValToPtr(""),
+		State: enumor.FlowWebhookDisabled}
+	if err = bd.UpdateWebhookConfig(kt, info); err != nil {
+		t.Fatalf("update webhook config failed, err: %v", err)
+	}
+
+	webhooks, err := bd.ListWebhook(kt, &ListInput{Filter: tools.EqualExpression("id", id),
+		Page: core.NewDefaultBasePage()})
+	if err != nil {
+		t.Fatalf("list webhook failed, err: %v", err)
+	}
+	if len(webhooks) != 1 {
+		t.Fatalf("list webhook should return 1, but got %d", len(webhooks))
+	}
+
+	one := webhooks[0]
+	if one.Name != "test" || one.URL != info.URL || one.Secret != "" || one.State != enumor.FlowWebhookDisabled ||
+		one.LastEventID != "00000002" {
+		t.Fatalf("webhook is not updated as expected, got %+v", one)
+	}
+
+	if err = bd.DeleteWebhook(kt, []string{id, "not_exist"}); err != nil {
+		t.Fatalf("delete webhook failed, err: %v", err)
+	}
+
+	count, err := bd.CountWebhook(kt, tools.AllExpression())
+	if err != nil {
+		t.Fatalf("count webhook failed, err: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("count webhook should be 0 after delete, but got %d", count)
+	}
+}
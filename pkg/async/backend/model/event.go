@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package model
+
+import (
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+)
+
+// Event 任务流、任务的状态变更事件，与状态变更在同一事务中写入
+type Event struct {
+	ID       string                `json:"id"`
+	Type     enumor.AsyncEventType `json:"type"`
+	FlowID   string                `json:"flow_id"`
+	FlowName enumor.FlowName       `json:"flow_name"`
+	// TaskID、ActionID 仅任务状态变更事件有值
+	TaskID   string `json:"task_id"`
+	ActionID string `json:"action_id"`
+	// Source 变更前的状态，任务流创建时为空
+	Source    string             `json:"source"`
+	Target    string             `json:"target"`
+	Reason    *tableasync.Reason `json:"reason"`
+	Creator   string             `json:"creator"`
+	CreatedAt string             `json:"created_at"`
+}
+
+// Webhook 任务流事件回调，由主节点将关注的事件按顺序投递到回调地址
+type Webhook struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+	// FlowNames、EventTypes 为空表示关注所有任务流、所有事件类型
+	FlowNames   []string                `json:"flow_names"`
+	EventTypes  []string                `json:"event_types"`
+	State       enumor.FlowWebhookState `json:"state"`
+	LastEventID string                  `json:"last_event_id"`
+	Reason      *tableasync.Reason      `json:"reason"`
+	Memo        string                  `json:"memo"`
+	Creator     string                  `json:"creator"`
+	Reviser     string                  `json:"reviser"`
+	CreatedAt   string                  `json:"created_at"`
+	UpdatedAt   string                  `json:"updated_at"`
+}
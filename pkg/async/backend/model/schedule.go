@@ -0,0 +1,46 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package model
+
+import (
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+)
+
+// Schedule 任务流定时计划，到达执行时间后按照任务流模版创建任务流
+type Schedule struct {
+	ID       string                   `json:"id"`
+	Name     string                   `json:"name"`
+	FlowName enumor.FlowName          `json:"flow_name"`
+	Memo     string                   `json:"memo"`
+	Tasks    tableasync.ScheduleTasks `json:"tasks"`
+	// Cron 周期执行的cron表达式，为空表示只执行一次
+	Cron       string                   `json:"cron"`
+	NextRunAt  time.Time                `json:"next_run_at"`
+	State      enumor.FlowScheduleState `json:"state"`
+	Reason     *tableasync.Reason       `json:"reason"`
+	LastFlowID string                   `json:"last_flow_id"`
+	Creator    string                   `json:"creator"`
+	Reviser    string                   `json:"reviser"`
+	CreatedAt  string                   `json:"created_at"`
+	UpdatedAt  string                   `json:"updated_at"`
+}
@@ -38,13 +38,19 @@ type Task struct {
 	Params     types.JsonField    `json:"params"`
 	Retry      *tableasync.Retry  `json:"can_retry"`
 	DependOn   []action.ActIDType `json:"depend_on"`
-	State      enumor.TaskState   `json:"state"`
-	Reason     *tableasync.Reason `json:"reason"`
-	Result     types.JsonField    `json:"result"`
-	Creator    string             `json:"creator"`
-	Reviser    string             `json:"reviser"`
-	CreatedAt  string             `json:"created_at"`
-	UpdatedAt  string             `json:"updated_at"`
+	// RunCondition 任务执行条件，为空表示无条件执行
+	RunCondition *tableasync.TaskCondition `json:"run_condition"`
+	// OnFailure 是否为失败分支任务
+	OnFailure bool `json:"on_failure"`
+	// RateLimitKey 任务限流键，为空表示不限流
+	RateLimitKey *tableasync.RateLimitKey `json:"rate_limit_key"`
+	State        enumor.TaskState         `json:"state"`
+	Reason       *tableasync.Reason       `json:"reason"`
+	Result       types.JsonField          `json:"result"`
+	Creator      string                   `json:"creator"`
+	Reviser      string                   `json:"reviser"`
+	CreatedAt    string                   `json:"created_at"`
+	UpdatedAt    string                   `json:"updated_at"`
 }
 
 // CreateValidate Task create validate.
@@ -22,20 +22,28 @@ package backend
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
+	"hcm/pkg/api/core"
 	"hcm/pkg/async/action"
 	"hcm/pkg/async/backend/model"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
 	"hcm/pkg/dal/dao/types/async"
 	tableasync "hcm/pkg/dal/table/async"
 	tabletypes "hcm/pkg/dal/table/types"
 	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
 	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
 )
 
 // NewMysql create mysql instance
@@ -60,6 +68,16 @@ func (db *mysql) BatchUpdateFlowStateByCAS(kt *kit.Kit, infos []UpdateFlowInfo)
 	}
 
 	_, err := db.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids := make([]string, 0, len(infos))
+		for _, one := range infos {
+			ids = append(ids, one.ID)
+		}
+		flowMap, err := db.listFlowWithTx(kt, txn, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		events := make([]model.Event, 0, len(infos))
 		for _, one := range infos {
 			info := &typesasync.UpdateFlowInfo{
 				ID:     one.ID,
@@ -68,12 +86,14 @@ func (db *mysql) BatchUpdateFlowStateByCAS(kt *kit.Kit, infos []UpdateFlowInfo)
 				Reason: one.Reason,
 				Worker: one.Worker,
 			}
-			if err := db.dao.AsyncFlow().UpdateStateByCAS(kt, txn, info); err != nil {
+			if err = db.dao.AsyncFlow().UpdateStateByCAS(kt, txn, info); err != nil {
 				return nil, err
 			}
+
+			events = append(events, newFlowEvent(one.ID, flowMap[one.ID].Name, one.Source, one.Target, one.Reason))
 		}
 
-		return nil, nil
+		return nil, db.createEventWithTx(kt, txn, events)
 	})
 	if err != nil {
 		return err
@@ -84,13 +104,34 @@ func (db *mysql) BatchUpdateFlowStateByCAS(kt *kit.Kit, infos []UpdateFlowInfo)
 
 // UpdateTaskStateByCAS CAS更新任务状态
 func (db *mysql) UpdateTaskStateByCAS(kt *kit.Kit, info *UpdateTaskInfo) error {
-	update := &typesasync.UpdateTaskInfo{
-		ID:     info.ID,
-		Source: info.Source,
-		Target: info.Target,
-		Reason: info.Reason,
+	if err := info.Validate(); err != nil {
+		return err
 	}
-	return db.dao.AsyncFlowTask().UpdateStateByCAS(kt, update)
+
+	task, err := db.getTask(kt, info.ID)
+	if err != nil {
+		return err
+	}
+	task.State = info.Source
+
+	_, err = db.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		update := &typesasync.UpdateTaskInfo{
+			ID:     info.ID,
+			Source: info.Source,
+			Target: info.Target,
+			Reason: info.Reason,
+		}
+		if err := db.dao.AsyncFlowTask().UpdateStateByCASWithTx(kt, txn, update); err != nil {
+			return nil, err
+		}
+
+		return nil, db.createEventWithTx(kt, txn, []model.Event{newTaskEvent(task, info.Target, info.Reason)})
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 var _ Backend = new(mysql)
@@ -99,45 +140,7 @@ var _ Backend = new(mysql)
 func (db *mysql) CreateFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
 
 	result, err := db.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
-		// 创建任务流
-		md := &tableasync.AsyncFlowTable{
-			Name:      flow.Name,
-			State:     enumor.FlowPending,
-			Reason:    new(tableasync.Reason),
-			ShareData: flow.ShareData,
-			Memo:      flow.Memo,
-			Worker:    converter.ValToPtr(""),
-			Creator:   kt.User,
-			Reviser:   kt.User,
-		}
-		flowID, err := db.dao.AsyncFlow().Create(kt, txn, md)
-		if err != nil {
-			return nil, err
-		}
-
-		// 创建任务
-		tasks := flow.Tasks
-		mds := make([]tableasync.AsyncFlowTaskTable, 0, len(tasks))
-		for _, one := range tasks {
-			mds = append(mds, tableasync.AsyncFlowTaskTable{
-				FlowID:     flowID,
-				FlowName:   one.FlowName,
-				ActionID:   string(one.ActionID),
-				ActionName: one.ActionName,
-				Params:     one.Params,
-				Retry:      one.Retry,
-				DependOn:   dependOnToStringArray(one.DependOn),
-				State:      enumor.TaskPending,
-				Reason:     new(tableasync.Reason),
-				Creator:    kt.User,
-				Reviser:    kt.User,
-			})
-		}
-		if _, err = db.dao.AsyncFlowTask().BatchCreateWithTx(kt, txn, mds); err != nil {
-			return nil, err
-		}
-
-		return flowID, nil
+		return db.createFlowWithTx(kt, txn, flow)
 	})
 	if err != nil {
 		return "", err
@@ -151,10 +154,73 @@ func (db *mysql) CreateFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
 	return flowID, nil
 }
 
+// createFlowWithTx 在事务中创建任务流及其任务
+func (db *mysql) createFlowWithTx(kt *kit.Kit, txn *sqlx.Tx, flow *model.Flow) (string, error) {
+	// 创建任务流
+	md := &tableasync.AsyncFlowTable{
+		Name:      flow.Name,
+		State:     enumor.FlowPending,
+		Reason:    new(tableasync.Reason),
+		ShareData: flow.ShareData,
+		Memo:      flow.Memo,
+		Worker:    converter.ValToPtr(""),
+		Creator:   kt.User,
+		Reviser:   kt.User,
+	}
+	flowID, err := db.dao.AsyncFlow().Create(kt, txn, md)
+	if err != nil {
+		return "", err
+	}
+
+	// 创建任务
+	tasks := flow.Tasks
+	mds := make([]tableasync.AsyncFlowTaskTable, 0, len(tasks))
+	for _, one := range tasks {
+		mds = append(mds, tableasync.AsyncFlowTaskTable{
+			FlowID:       flowID,
+			FlowName:     one.FlowName,
+			ActionID:     string(one.ActionID),
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			Retry:        one.Retry,
+			DependOn:     dependOnToStringArray(one.DependOn),
+			RunCondition: one.RunCondition,
+			OnFailure:    converter.ValToPtr(one.OnFailure),
+			RateLimitKey: one.RateLimitKey,
+			State:        enumor.TaskPending,
+			Reason:       new(tableasync.Reason),
+			Creator:      kt.User,
+			Reviser:      kt.User,
+		})
+	}
+	if _, err = db.dao.AsyncFlowTask().BatchCreateWithTx(kt, txn, mds); err != nil {
+		return "", err
+	}
+
+	event := newFlowEvent(flowID, flow.Name, "", enumor.FlowPending, nil)
+	if err = db.createEventWithTx(kt, txn, []model.Event{event}); err != nil {
+		return "", err
+	}
+
+	return flowID, nil
+}
+
 // BatchUpdateFlow 批量更新任务流
 func (db *mysql) BatchUpdateFlow(kt *kit.Kit, flows []model.Flow) error {
 
 	_, err := db.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids := make([]string, 0)
+		for _, one := range flows {
+			if len(one.State) != 0 {
+				ids = append(ids, one.ID)
+			}
+		}
+		flowMap, err := db.listFlowWithTx(kt, txn, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		events := make([]model.Event, 0, len(ids))
 		for _, one := range flows {
 			md := &tableasync.AsyncFlowTable{
 				State:     one.State,
@@ -165,12 +231,16 @@ func (db *mysql) BatchUpdateFlow(kt *kit.Kit, flows []model.Flow) error {
 				Reviser:   one.Reviser,
 			}
 
-			if err := db.dao.AsyncFlow().UpdateByIDWithTx(kt, txn, one.ID, md); err != nil {
+			if err = db.dao.AsyncFlow().UpdateByIDWithTx(kt, txn, one.ID, md); err != nil {
 				return nil, err
 			}
+
+			if origin, exist := flowMap[one.ID]; exist && origin.State != one.State {
+				events = append(events, newFlowEvent(one.ID, origin.Name, origin.State, one.State, one.Reason))
+			}
 		}
 
-		return nil, nil
+		return nil, db.createEventWithTx(kt, txn, events)
 	})
 	if err != nil {
 		return err
@@ -212,23 +282,40 @@ func (db *mysql) ListFlow(kt *kit.Kit, input *ListInput) ([]model.Flow, error) {
 	return flows, nil
 }
 
+// CountFlow 查询满足过滤条件的任务流数量
+func (db *mysql) CountFlow(kt *kit.Kit, expr *filter.Expression) (uint64, error) {
+	opt := &types.ListOption{
+		Filter: expr,
+		Page:   core.NewCountPage(),
+	}
+	list, err := db.dao.AsyncFlow().List(kt, opt)
+	if err != nil {
+		return 0, err
+	}
+
+	return list.Count, nil
+}
+
 // BatchCreateTask 批量创建任务
 func (db *mysql) BatchCreateTask(kt *kit.Kit, tasks []model.Task) ([]string, error) {
 
 	mds := make([]tableasync.AsyncFlowTaskTable, 0, len(tasks))
 	for _, one := range tasks {
 		mds = append(mds, tableasync.AsyncFlowTaskTable{
-			FlowID:     one.FlowID,
-			FlowName:   one.FlowName,
-			ActionID:   string(one.ActionID),
-			ActionName: one.ActionName,
-			Params:     one.Params,
-			Retry:      one.Retry,
-			DependOn:   dependOnToStringArray(one.DependOn),
-			State:      enumor.TaskPending,
-			Reason:     one.Reason,
-			Creator:    one.Creator,
-			Reviser:    one.Reviser,
+			FlowID:       one.FlowID,
+			FlowName:     one.FlowName,
+			ActionID:     string(one.ActionID),
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			Retry:        one.Retry,
+			DependOn:     dependOnToStringArray(one.DependOn),
+			RunCondition: one.RunCondition,
+			OnFailure:    converter.ValToPtr(one.OnFailure),
+			RateLimitKey: one.RateLimitKey,
+			State:        enumor.TaskPending,
+			Reason:       one.Reason,
+			Creator:      one.Creator,
+			Reviser:      one.Reviser,
 		})
 	}
 
@@ -247,7 +334,31 @@ func (db *mysql) UpdateTask(kt *kit.Kit, task *model.Task) error {
 		Reviser:  kt.User,
 	}
 
-	return db.dao.AsyncFlowTask().UpdateByID(kt, task.ID, md)
+	if len(task.State) == 0 {
+		return db.dao.AsyncFlowTask().UpdateByID(kt, task.ID, md)
+	}
+
+	origin, err := db.getTask(kt, task.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := db.dao.AsyncFlowTask().UpdateByIDWithTx(kt, txn, task.ID, md); err != nil {
+			return nil, err
+		}
+
+		if origin.State == task.State {
+			return nil, nil
+		}
+
+		return nil, db.createEventWithTx(kt, txn, []model.Event{newTaskEvent(origin, task.State, task.Reason)})
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 // ListTask 查询任务
@@ -266,17 +377,89 @@ func (db *mysql) ListTask(kt *kit.Kit, input *ListInput) ([]model.Task, error) {
 	tasks := make([]model.Task, 0, len(list.Details))
 	for _, one := range list.Details {
 		tasks = append(tasks, model.Task{
+			ID:           one.ID,
+			FlowID:       one.FlowID,
+			FlowName:     one.FlowName,
+			ActionID:     action.ActIDType(one.ActionID),
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			Retry:        one.Retry,
+			DependOn:     dependOnToActIDArray(one.DependOn),
+			RunCondition: one.RunCondition,
+			OnFailure:    converter.PtrToVal(one.OnFailure),
+			RateLimitKey: one.RateLimitKey,
+			State:        one.State,
+			Reason:       one.Reason,
+			Result:       one.Result,
+			Creator:      one.Creator,
+			Reviser:      one.Reviser,
+			CreatedAt:    one.CreatedAt.String(),
+			UpdatedAt:    one.UpdatedAt.String(),
+		})
+	}
+
+	return tasks, nil
+}
+
+// CountTask 查询满足过滤条件的任务数量
+func (db *mysql) CountTask(kt *kit.Kit, expr *filter.Expression) (uint64, error) {
+	opt := &types.ListOption{
+		Filter: expr,
+		Page:   core.NewCountPage(),
+	}
+	list, err := db.dao.AsyncFlowTask().List(kt, opt)
+	if err != nil {
+		return 0, err
+	}
+
+	return list.Count, nil
+}
+
+// CreateSchedule 创建任务流定时计划
+func (db *mysql) CreateSchedule(kt *kit.Kit, schedule *model.Schedule) (string, error) {
+	md := &tableasync.AsyncFlowScheduleTable{
+		Name:       schedule.Name,
+		FlowName:   schedule.FlowName,
+		Memo:       schedule.Memo,
+		Tasks:      schedule.Tasks,
+		Cron:       schedule.Cron,
+		NextRunAt:  schedule.NextRunAt,
+		State:      enumor.FlowScheduleEnabled,
+		Reason:     new(tableasync.Reason),
+		LastFlowID: "",
+		Creator:    kt.User,
+		Reviser:    kt.User,
+	}
+
+	return db.dao.AsyncFlowSchedule().Create(kt, md)
+}
+
+// ListSchedule 查询任务流定时计划
+func (db *mysql) ListSchedule(kt *kit.Kit, input *ListInput) ([]model.Schedule, error) {
+
+	opt := &types.ListOption{
+		Fields: input.Fields,
+		Filter: input.Filter,
+		Page:   input.Page,
+	}
+	list, err := db.dao.AsyncFlowSchedule().List(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]model.Schedule, 0, len(list.Details))
+	for _, one := range list.Details {
+		schedules = append(schedules, model.Schedule{
 			ID:         one.ID,
-			FlowID:     one.FlowID,
+			Name:       one.Name,
 			FlowName:   one.FlowName,
-			ActionID:   action.ActIDType(one.ActionID),
-			ActionName: one.ActionName,
-			Params:     one.Params,
-			Retry:      one.Retry,
-			DependOn:   dependOnToActIDArray(one.DependOn),
+			Memo:       one.Memo,
+			Tasks:      one.Tasks,
+			Cron:       one.Cron,
+			NextRunAt:  one.NextRunAt,
 			State:      one.State,
 			Reason:     one.Reason,
-			Result:     one.Result,
+			LastFlowID: one.LastFlowID,
 			Creator:    one.Creator,
 			Reviser:    one.Reviser,
 			CreatedAt:  one.CreatedAt.String(),
@@ -284,7 +467,319 @@ func (db *mysql) ListTask(kt *kit.Kit, input *ListInput) ([]model.Task, error) {
 		})
 	}
 
-	return tasks, nil
+	return schedules, nil
+}
+
+// TriggerSchedule 触发任务流定时计划，任务流创建和定时计划更新在同一事务中，避免重复创建任务流
+func (db *mysql) TriggerSchedule(kt *kit.Kit, info *TriggerScheduleInfo, flow *model.Flow) (string, error) {
+	if err := info.Validate(); err != nil {
+		return "", err
+	}
+
+	result, err := db.dao.Txn().AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		flowID := ""
+		if flow != nil {
+			var err error
+			if flowID, err = db.createFlowWithTx(kt, txn, flow); err != nil {
+				return nil, err
+			}
+		}
+
+		update := &typesasync.TriggerScheduleInfo{
+			ID:              info.ID,
+			SourceNextRunAt: info.SourceNextRunAt,
+			TargetNextRunAt: info.TargetNextRunAt,
+			State:           info.State,
+			Reason:          info.Reason,
+			LastFlowID:      flowID,
+		}
+		if len(flowID) == 0 {
+			update.LastFlowID = info.LastFlowID
+		}
+		if err := db.dao.AsyncFlowSchedule().TriggerByCASWithTx(kt, txn, update); err != nil {
+			return nil, err
+		}
+
+		return flowID, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	flowID, ok := result.(string)
+	if !ok {
+		return "", fmt.Errorf("return result not string type, type: %s", reflect.TypeOf(result).String())
+	}
+
+	return flowID, nil
+}
+
+// CountSchedule 查询满足过滤条件的任务流定时计划数量
+func (db *mysql) CountSchedule(kt *kit.Kit, expr *filter.Expression) (uint64, error) {
+	opt := &types.ListOption{
+		Filter: expr,
+		Page:   core.NewCountPage(),
+	}
+	list, err := db.dao.AsyncFlowSchedule().List(kt, opt)
+	if err != nil {
+		return 0, err
+	}
+
+	return list.Count, nil
+}
+
+// UpdateScheduleStateByCAS CAS更新任务流定时计划状态
+func (db *mysql) UpdateScheduleStateByCAS(kt *kit.Kit, info *UpdateScheduleStateInfo) error {
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	return db.dao.AsyncFlowSchedule().UpdateStateByCAS(kt, (*typesasync.UpdateScheduleStateInfo)(info))
+}
+
+// DeleteSchedule 删除任务流定时计划，定时计划触发时CAS更新计划失败会回滚任务流的创建，不会触发已删除的计划
+func (db *mysql) DeleteSchedule(kt *kit.Kit, ids []string) error {
+	if len(ids) == 0 {
+		return errf.New(errf.InvalidParameter, "ids is required")
+	}
+
+	return db.dao.AsyncFlowSchedule().Delete(kt, tools.ContainersExpression("id", ids))
+}
+
+// ListEvent 查询任务流事件
+func (db *mysql) ListEvent(kt *kit.Kit, input *ListInput) ([]model.Event, error) {
+
+	opt := &types.ListOption{
+		Fields: input.Fields,
+		Filter: input.Filter,
+		Page:   input.Page,
+	}
+	list, err := db.dao.AsyncFlowEvent().List(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]model.Event, 0, len(list.Details))
+	for _, one := range list.Details {
+		events = append(events, model.Event{
+			ID:        one.ID,
+			Type:      one.Type,
+			FlowID:    one.FlowID,
+			FlowName:  one.FlowName,
+			TaskID:    one.TaskID,
+			ActionID:  one.ActionID,
+			Source:    one.Source,
+			Target:    one.Target,
+			Reason:    one.Reason,
+			Creator:   one.Creator,
+			CreatedAt: one.CreatedAt.String(),
+		})
+	}
+
+	return events, nil
+}
+
+// DeleteEvent 删除创建时间早于指定时间的任务流事件
+func (db *mysql) DeleteEvent(kt *kit.Kit, before time.Time) error {
+	expr := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			filter.AtomRule{Field: "created_at", Op: filter.LessThan.Factory(),
+				Value: times.ConvStdTimeFormat(before)},
+		},
+	}
+
+	return db.dao.AsyncFlowEvent().Delete(kt, expr)
+}
+
+// CreateWebhook 创建任务流事件回调
+func (db *mysql) CreateWebhook(kt *kit.Kit, webhook *model.Webhook) (string, error) {
+	md := &tableasync.AsyncFlowWebhookTable{
+		Name:        webhook.Name,
+		URL:         webhook.URL,
+		Secret:      converter.ValToPtr(webhook.Secret),
+		FlowNames:   webhook.FlowNames,
+		EventTypes:  webhook.EventTypes,
+		State:       webhook.State,
+		LastEventID: webhook.LastEventID,
+		Reason:      webhook.Reason,
+		Memo:        converter.ValToPtr(webhook.Memo),
+		Creator:     kt.User,
+		Reviser:     kt.User,
+	}
+
+	return db.dao.AsyncFlowWebhook().Create(kt, md)
+}
+
+// ListWebhook 查询任务流事件回调
+func (db *mysql) ListWebhook(kt *kit.Kit, input *ListInput) ([]model.Webhook, error) {
+
+	opt := &types.ListOption{
+		Fields: input.Fields,
+		Filter: input.Filter,
+		Page:   input.Page,
+	}
+	list, err := db.dao.AsyncFlowWebhook().List(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]model.Webhook, 0, len(list.Details))
+	for _, one := range list.Details {
+		webhooks = append(webhooks, model.Webhook{
+			ID:          one.ID,
+			Name:        one.Name,
+			URL:         one.URL,
+			Secret:      converter.PtrToVal(one.Secret),
+			FlowNames:   one.FlowNames,
+			EventTypes:  one.EventTypes,
+			State:       one.State,
+			LastEventID: one.LastEventID,
+			Reason:      one.Reason,
+			Memo:        converter.PtrToVal(one.Memo),
+			Creator:     one.Creator,
+			Reviser:     one.Reviser,
+			CreatedAt:   one.CreatedAt.String(),
+			UpdatedAt:   one.UpdatedAt.String(),
+		})
+	}
+
+	return webhooks, nil
+}
+
+// UpdateWebhook 更新任务流事件回调的投递进度
+func (db *mysql) UpdateWebhook(kt *kit.Kit, webhook *model.Webhook) error {
+	md := &tableasync.AsyncFlowWebhookTable{
+		State:       webhook.State,
+		LastEventID: webhook.LastEventID,
+		Reason:      webhook.Reason,
+		Reviser:     webhook.Reviser,
+	}
+
+	return db.dao.AsyncFlowWebhook().UpdateByID(kt, webhook.ID, md)
+}
+
+// CountWebhook 查询满足过滤条件的任务流事件回调数量
+func (db *mysql) CountWebhook(kt *kit.Kit, expr *filter.Expression) (uint64, error) {
+	opt := &types.ListOption{
+		Filter: expr,
+		Page:   core.NewCountPage(),
+	}
+	list, err := db.dao.AsyncFlowWebhook().List(kt, opt)
+	if err != nil {
+		return 0, err
+	}
+
+	return list.Count, nil
+}
+
+// UpdateWebhookConfig 更新任务流事件回调的配置
+func (db *mysql) UpdateWebhookConfig(kt *kit.Kit, info *UpdateWebhookInfo) error {
+	if err := info.Validate(); err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	md := &tableasync.AsyncFlowWebhookTable{
+		Name:       info.Name,
+		URL:        info.URL,
+		Secret:     info.Secret,
+		FlowNames:  info.FlowNames,
+		EventTypes: info.EventTypes,
+		State:      info.State,
+		Reason:     info.Reason,
+		Memo:       info.Memo,
+		Reviser:    kt.User,
+	}
+
+	return db.dao.AsyncFlowWebhook().UpdateByID(kt, info.ID, md)
+}
+
+// DeleteWebhook 删除任务流事件回调
+func (db *mysql) DeleteWebhook(kt *kit.Kit, ids []string) error {
+	if len(ids) == 0 {
+		return errf.New(errf.InvalidParameter, "ids is required")
+	}
+
+	return db.dao.AsyncFlowWebhook().Delete(kt, tools.ContainersExpression("id", ids))
+}
+
+// createEventWithTx 在状态变更的事务中写入事件
+func (db *mysql) createEventWithTx(kt *kit.Kit, txn *sqlx.Tx, events []model.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	mds := make([]tableasync.AsyncFlowEventTable, 0, len(events))
+	for _, one := range events {
+		mds = append(mds, tableasync.AsyncFlowEventTable{
+			Type:     one.Type,
+			FlowID:   one.FlowID,
+			FlowName: one.FlowName,
+			TaskID:   one.TaskID,
+			ActionID: one.ActionID,
+			Source:   one.Source,
+			Target:   one.Target,
+			Reason:   one.Reason,
+			Creator:  kt.User,
+		})
+	}
+
+	if _, err := db.dao.AsyncFlowEvent().BatchCreateWithTx(kt, txn, mds); err != nil {
+		logs.Errorf("create async flow event failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// listFlowWithTx 查询任务流的名称及状态，用于生成事件
+func (db *mysql) listFlowWithTx(kt *kit.Kit, txn *sqlx.Tx, ids []string) (map[string]tableasync.AsyncFlowTable,
+	error) {
+
+	result := make(map[string]tableasync.AsyncFlowTable, len(ids))
+	for _, part := range slice.Split(slice.Unique(ids), int(core.DefaultMaxPageLimit)) {
+		opt := &types.ListOption{
+			Fields: []string{"id", "name", "state"},
+			Filter: tools.ContainersExpression("id", part),
+			Page:   core.NewDefaultBasePage(),
+		}
+		list, err := db.dao.AsyncFlow().ListWithTx(kt, txn, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range list.Details {
+			result[one.ID] = one
+		}
+	}
+
+	return result, nil
+}
+
+// getTask 查询任务，用于生成事件
+func (db *mysql) getTask(kt *kit.Kit, id string) (*model.Task, error) {
+	opt := &types.ListOption{
+		Fields: []string{"id", "flow_id", "flow_name", "action_id", "state"},
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	list, err := db.dao.AsyncFlowTask().List(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "task: %s not found", id)
+	}
+
+	one := list.Details[0]
+	return &model.Task{
+		ID:       one.ID,
+		FlowID:   one.FlowID,
+		FlowName: one.FlowName,
+		ActionID: action.ActIDType(one.ActionID),
+		State:    one.State,
+	}, nil
 }
 
 func dependOnToStringArray(d []action.ActIDType) tabletypes.StringArray {
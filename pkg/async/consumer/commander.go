@@ -19,23 +19,43 @@
 
 package consumer
 
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
 /*
 Commander （指挥者）
 		1. 强制关闭处于执行中的任务
+		2. 暂停任务流，执行中的任务会继续执行完，但不会再执行新的任务
+		3. 恢复暂停的任务流
+		4. 重试失败或取消的任务流，只重新执行失败、取消的任务，保留任务流的共享数据
 */
 type Commander interface {
 	CancelTasks(taskIDs []string) error
+	PauseFlow(kt *kit.Kit, flowID string) error
+	ResumeFlow(kt *kit.Kit, flowID string) error
+	RetryFlow(kt *kit.Kit, flowID string) error
 }
 
 // NewCommander new commander.
-func NewCommander(exec Executor) Commander {
+func NewCommander(bd backend.Backend, exec Executor) Commander {
 	return &commander{
+		backend:  bd,
 		executor: exec,
 	}
 }
 
 // commander ...
 type commander struct {
+	backend  backend.Backend
 	executor Executor
 }
 
@@ -43,3 +63,146 @@ type commander struct {
 func (cmd *commander) CancelTasks(taskIDs []string) error {
 	return cmd.executor.CancelTasks(taskIDs)
 }
+
+// PauseFlow 暂停处于等待、执行状态的任务流。处于调度状态的任务流正在由调度器开始执行，不允许暂停。
+func (cmd *commander) PauseFlow(kt *kit.Kit, flowID string) error {
+	flow, err := getFlow(kt, cmd.backend, flowID)
+	if err != nil {
+		return err
+	}
+
+	switch flow.State {
+	case enumor.FlowPending, enumor.FlowRunning:
+	case enumor.FlowScheduled:
+		return errf.Newf(errf.Aborted, "flow %s is being scheduled, please pause later", flowID)
+	default:
+		return errf.Newf(errf.InvalidParameter, "flow %s can not pause, state: %s", flowID, flow.State)
+	}
+
+	info := backend.UpdateFlowInfo{
+		ID:     flowID,
+		Source: flow.State,
+		Target: enumor.FlowPaused,
+	}
+	if err = cmd.backend.BatchUpdateFlowStateByCAS(kt, []backend.UpdateFlowInfo{info}); err != nil {
+		logs.Errorf("pause flow failed, err: %v, id: %s, rid: %s", err, flowID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// ResumeFlow 恢复暂停的任务流，任务流重新进入等待状态由派发器重新派发。暂停前还在执行中的任务执行完之前，不允许恢复。
+func (cmd *commander) ResumeFlow(kt *kit.Kit, flowID string) error {
+	flow, err := getFlow(kt, cmd.backend, flowID)
+	if err != nil {
+		return err
+	}
+
+	if flow.State != enumor.FlowPaused {
+		return errf.Newf(errf.InvalidParameter, "flow %s can not resume, state: %s", flowID, flow.State)
+	}
+
+	tasks, err := listTaskByFlowID(kt, cmd.backend, flowID)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if task.State == enumor.TaskRunning || task.State == enumor.TaskRollback {
+			return errf.Newf(errf.Aborted, "task %s of flow %s is still %s, please resume later", task.ID,
+				flowID, task.State)
+		}
+	}
+
+	info := backend.UpdateFlowInfo{
+		ID:     flowID,
+		Source: enumor.FlowPaused,
+		Target: enumor.FlowPending,
+	}
+	if err = cmd.backend.BatchUpdateFlowStateByCAS(kt, []backend.UpdateFlowInfo{info}); err != nil {
+		logs.Errorf("resume flow failed, err: %v, id: %s, rid: %s", err, flowID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// RetryFlow 将失败、取消的任务及已执行的失败分支任务重置为等待状态，并将任务流重新置为等待状态，
+// 已执行成功的任务不会重复执行。
+func (cmd *commander) RetryFlow(kt *kit.Kit, flowID string) error {
+	flow, err := getFlow(kt, cmd.backend, flowID)
+	if err != nil {
+		return err
+	}
+
+	switch flow.State {
+	case enumor.FlowFailed, enumor.FlowCancel:
+	default:
+		return errf.Newf(errf.InvalidParameter, "flow %s can not retry, state: %s", flowID, flow.State)
+	}
+
+	tasks, err := listTaskByFlowID(kt, cmd.backend, flowID)
+	if err != nil {
+		return err
+	}
+
+	retryCount := 0
+	for _, task := range tasks {
+		// 失败分支任务也需要重置，重试后任务流再次失败时重新执行补偿
+		if task.State != enumor.TaskFailed && task.State != enumor.TaskCancel &&
+			!(task.OnFailure && task.State != enumor.TaskPending) {
+			continue
+		}
+
+		info := &backend.UpdateTaskInfo{
+			ID:     task.ID,
+			Source: task.State,
+			Target: enumor.TaskPending,
+			Reason: new(tableasync.Reason),
+		}
+		if err = cmd.backend.UpdateTaskStateByCAS(kt, info); err != nil {
+			logs.Errorf("reset task to pending failed, err: %v, id: %s, rid: %s", err, task.ID, kt.Rid)
+			return err
+		}
+		retryCount++
+	}
+
+	if retryCount == 0 {
+		return errf.Newf(errf.InvalidParameter, "flow %s has no failed or cancelled task to retry", flowID)
+	}
+
+	info := backend.UpdateFlowInfo{
+		ID:     flowID,
+		Source: flow.State,
+		Target: enumor.FlowPending,
+		Reason: new(tableasync.Reason),
+	}
+	if err = cmd.backend.BatchUpdateFlowStateByCAS(kt, []backend.UpdateFlowInfo{info}); err != nil {
+		logs.Errorf("retry flow failed, err: %v, id: %s, rid: %s", err, flowID, kt.Rid)
+		return err
+	}
+
+	logs.Infof("retry flow success, id: %s, retry task count: %d, rid: %s", flowID, retryCount, kt.Rid)
+
+	return nil
+}
+
+// getFlow 查询指定ID的任务流
+func getFlow(kt *kit.Kit, bd backend.Backend, flowID string) (*model.Flow, error) {
+	input := &backend.ListInput{
+		Filter: tools.EqualExpression("id", flowID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	flows, err := bd.ListFlow(kt, input)
+	if err != nil {
+		logs.Errorf("list flow failed, err: %v, id: %s, rid: %s", err, flowID, kt.Rid)
+		return nil, err
+	}
+
+	if len(flows) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "flow: %s not found", flowID)
+	}
+
+	return &flows[0], nil
+}
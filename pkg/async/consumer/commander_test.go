@@ -0,0 +1,187 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"testing"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+// newTestFlow 创建任务流，并将任务流和任务更新为指定的状态
+func newTestFlow(t *testing.T, bd backend.Backend, state enumor.FlowState, tasks []model.Task,
+	taskStates []enumor.TaskState) (string, []*Task) {
+
+	kt := kit.New()
+	flowID, err := bd.CreateFlow(kt, &model.Flow{Name: enumor.FlowStartCvm, Tasks: tasks})
+	if err != nil {
+		t.Fatalf("create flow failed, err: %v", err)
+	}
+
+	if state != enumor.FlowPending {
+		info := backend.UpdateFlowInfo{ID: flowID, Source: enumor.FlowPending, Target: state}
+		if err = bd.BatchUpdateFlowStateByCAS(kt, []backend.UpdateFlowInfo{info}); err != nil {
+			t.Fatalf("update flow state failed, err: %v", err)
+		}
+	}
+
+	created, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	for i, task := range created {
+		if taskStates[i] == enumor.TaskPending {
+			continue
+		}
+		info := &backend.UpdateTaskInfo{ID: task.ID, Source: enumor.TaskPending, Target: taskStates[i]}
+		if err = bd.UpdateTaskStateByCAS(kt, info); err != nil {
+			t.Fatalf("update task state failed, err: %v", err)
+		}
+	}
+
+	created, err = listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	return flowID, created
+}
+
+func assertFlowState(t *testing.T, bd backend.Backend, flowID string, state enumor.FlowState) {
+	flow, err := getFlow(kit.New(), bd, flowID)
+	if err != nil {
+		t.Fatalf("get flow failed, err: %v", err)
+	}
+	if flow.State != state {
+		t.Fatalf("flow %s state should be %s, but got %s", flowID, state, flow.State)
+	}
+}
+
+func assertErrCode(t *testing.T, err error, code int32) {
+	if err == nil {
+		t.Fatalf("should return error with code %d, but got nil", code)
+	}
+	if errf.Error(err).Code != code {
+		t.Fatalf("should return error with code %d, but got %v", code, err)
+	}
+}
+
+func TestPauseFlow(t *testing.T) {
+	bd := backend.NewMemory()
+	cmd := NewCommander(bd, nil)
+	kt := kit.New()
+	tasks := []model.Task{{ActionID: "1", ActionName: enumor.ActionStartCvm}}
+
+	for _, state := range []enumor.FlowState{enumor.FlowPending, enumor.FlowRunning} {
+		flowID, _ := newTestFlow(t, bd, state, tasks, []enumor.TaskState{enumor.TaskPending})
+		if err := cmd.PauseFlow(kt, flowID); err != nil {
+			t.Fatalf("pause %s flow failed, err: %v", state, err)
+		}
+		assertFlowState(t, bd, flowID, enumor.FlowPaused)
+
+		// 已暂停的任务流不能重复暂停
+		assertErrCode(t, cmd.PauseFlow(kt, flowID), errf.InvalidParameter)
+	}
+
+	// 调度状态的任务流正在由调度器开始执行，不能暂停
+	flowID, _ := newTestFlow(t, bd, enumor.FlowScheduled, tasks, []enumor.TaskState{enumor.TaskPending})
+	assertErrCode(t, cmd.PauseFlow(kt, flowID), errf.Aborted)
+	assertFlowState(t, bd, flowID, enumor.FlowScheduled)
+
+	flowID, _ = newTestFlow(t, bd, enumor.FlowSuccess, tasks, []enumor.TaskState{enumor.TaskSuccess})
+	assertErrCode(t, cmd.PauseFlow(kt, flowID), errf.InvalidParameter)
+}
+
+func TestResumeFlow(t *testing.T) {
+	bd := backend.NewMemory()
+	cmd := NewCommander(bd, nil)
+	kt := kit.New()
+	tasks := []model.Task{
+		{ActionID: "1", ActionName: enumor.ActionStartCvm},
+		{ActionID: "2", ActionName: enumor.ActionStartCvm, DependOn: []action.ActIDType{"1"}},
+	}
+
+	flowID, created := newTestFlow(t, bd, enumor.FlowPaused, tasks,
+		[]enumor.TaskState{enumor.TaskRunning, enumor.TaskPending})
+
+	// 暂停前下发的任务还在执行中，不能恢复
+	assertErrCode(t, cmd.ResumeFlow(kt, flowID), errf.Aborted)
+	assertFlowState(t, bd, flowID, enumor.FlowPaused)
+
+	info := &backend.UpdateTaskInfo{ID: created[0].ID, Source: enumor.TaskRunning, Target: enumor.TaskSuccess}
+	if err := bd.UpdateTaskStateByCAS(kt, info); err != nil {
+		t.Fatalf("update task state failed, err: %v", err)
+	}
+
+	if err := cmd.ResumeFlow(kt, flowID); err != nil {
+		t.Fatalf("resume flow failed, err: %v", err)
+	}
+	assertFlowState(t, bd, flowID, enumor.FlowPending)
+
+	// 未暂停的任务流不能恢复
+	assertErrCode(t, cmd.ResumeFlow(kt, flowID), errf.InvalidParameter)
+}
+
+func TestRetryFlow(t *testing.T) {
+	bd := backend.NewMemory()
+	cmd := NewCommander(bd, nil)
+	kt := kit.New()
+	tasks := []model.Task{
+		{ActionID: "1", ActionName: enumor.ActionStartCvm},
+		{ActionID: "2", ActionName: enumor.ActionStartCvm, DependOn: []action.ActIDType{"1"}},
+		{ActionID: "3", ActionName: enumor.ActionStartCvm, OnFailure: true},
+	}
+
+	flowID, created := newTestFlow(t, bd, enumor.FlowFailed, tasks,
+		[]enumor.TaskState{enumor.TaskSuccess, enumor.TaskFailed, enumor.TaskSuccess})
+
+	if err := cmd.RetryFlow(kt, flowID); err != nil {
+		t.Fatalf("retry flow failed, err: %v", err)
+	}
+	assertFlowState(t, bd, flowID, enumor.FlowPending)
+
+	retried, err := listTaskByFlowID(kt, bd, flowID)
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	// 执行成功的任务不重复执行，失败的任务和已执行的失败分支任务重置为等待状态
+	expect := map[string]enumor.TaskState{
+		created[0].ID: enumor.TaskSuccess,
+		created[1].ID: enumor.TaskPending,
+		created[2].ID: enumor.TaskPending,
+	}
+	for _, task := range retried {
+		if task.State != expect[task.ID] {
+			t.Fatalf("task %s state should be %s, but got %s", task.ID, expect[task.ID], task.State)
+		}
+	}
+
+	// 等待状态的任务流不能重试
+	assertErrCode(t, cmd.RetryFlow(kt, flowID), errf.InvalidParameter)
+
+	// 没有失败、取消任务的任务流不能重试
+	flowID, _ = newTestFlow(t, bd, enumor.FlowCancel, tasks[:1], []enumor.TaskState{enumor.TaskSuccess})
+	assertErrCode(t, cmd.RetryFlow(kt, flowID), errf.InvalidParameter)
+	assertFlowState(t, bd, flowID, enumor.FlowCancel)
+}
@@ -22,6 +22,7 @@ package consumer
 
 import (
 	"errors"
+	"time"
 
 	// 注册Action和Template
 	_ "hcm/pkg/async/action"
@@ -49,11 +50,14 @@ Consumer 异步任务消费者。组件分为两类，公共组件、主节点
 	- executor（执行器）: 准备任务执行所需要的超时控制，共享数据等工具，并执行任务。
 	- commander（指挥者）:
 		1. 强制关闭处于执行中的任务
+		2. 暂停、恢复任务流，重试失败的任务流
 */
 type Consumer interface {
 	compctrl.Closer
 	// Start 启动消费者，开始消费异步任务。
 	Start() error
+	// GetCommander 获取指挥者，需要在消费者启动后调用。
+	GetCommander() Commander
 }
 
 var _ Consumer = new(consumer)
@@ -118,7 +122,7 @@ func (csm *consumer) initLeaderComponent(opt *Option) {
 // initCommonComponent 初始化主从节点公共组件并启动，同时设置关闭函数
 func (csm *consumer) initCommonComponent(opt *Option) {
 	// 设置执行器
-	csm.executor = NewExecutor(csm.backend, opt.Executor)
+	csm.executor = NewExecutor(csm.backend, csm.leader, opt.Executor)
 
 	// 设置调度器
 	csm.scheduler = NewScheduler(csm.backend, csm.executor, csm.leader, opt.Scheduler)
@@ -137,7 +141,12 @@ func (csm *consumer) initCommonComponent(opt *Option) {
 	csm.closers = append(csm.closers, csm.scheduler)
 
 	// 设置命令工具
-	csm.cmd = NewCommander(csm.executor)
+	csm.cmd = NewCommander(csm.backend, csm.executor)
+}
+
+// GetCommander 获取指挥者，指挥者负责强制关闭任务、暂停恢复任务流、重试任务流。
+func (csm *consumer) GetCommander() Commander {
+	return csm.cmd
 }
 
 // Close 执行异步任务框架所有组件的关闭函数
@@ -152,3 +161,24 @@ func (csm *consumer) Close() {
 	logs.Infof("consumer close success")
 
 }
+
+// watchFlow 后端支持监听时返回任务流变更通知通道，否则返回nil
+func watchFlow(bd backend.Backend, closeCh <-chan struct{}) <-chan struct{} {
+	watcher, ok := bd.(backend.Watcher)
+	if !ok {
+		return nil
+	}
+
+	return watcher.WatchFlow(closeCh)
+}
+
+// waitNextRound 等待下一轮执行，收到任务流变更通知时提前唤醒，notifyCh为nil时仅按间隔等待
+func waitNextRound(interval time.Duration, notifyCh <-chan struct{}) {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-notifyCh:
+	}
+}
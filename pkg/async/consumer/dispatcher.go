@@ -36,11 +36,13 @@ import (
 
 // NewDispatcher new dispatcher.
 func NewDispatcher(bd backend.Backend, ld leader.Leader, opt *DispatcherOption) *Dispatcher {
+	closeCh := make(chan struct{})
 	return &Dispatcher{
 		watchIntervalSec: time.Duration(opt.WatchIntervalSec) * time.Second,
 		bd:               bd,
 		ld:               ld,
-		closeCh:          make(chan struct{}),
+		flowCh:           watchFlow(bd, closeCh),
+		closeCh:          closeCh,
 		wg:               new(sync.WaitGroup),
 	}
 }
@@ -51,6 +53,8 @@ type Dispatcher struct {
 
 	bd backend.Backend
 	ld leader.Leader
+	// flowCh 任务流变更通知，后端不支持监听时为nil
+	flowCh <-chan struct{}
 
 	wg      *sync.WaitGroup
 	closeCh chan struct{}
@@ -76,7 +80,7 @@ func (d *Dispatcher) WatchPendingFlow() {
 			logs.Errorf("%s: dispatcher do failed, err: %v, rid: %s", constant.AsyncTaskWarnSign, err, kt.Rid)
 		}
 
-		time.Sleep(d.watchIntervalSec)
+		waitNextRound(d.watchIntervalSec, d.flowCh)
 	}
 
 	d.wg.Done()
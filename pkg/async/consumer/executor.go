@@ -21,16 +21,23 @@ package consumer
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
+	"hcm/pkg/api/core"
 	"hcm/pkg/async/action/run"
 	"hcm/pkg/async/backend"
 	"hcm/pkg/async/backend/model"
 	"hcm/pkg/async/compctrl"
+	"hcm/pkg/async/consumer/leader"
 	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
 	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
 )
 
 // Executor （执行器）: 准备任务执行所需要的超时控制，共享数据等工具，并执行任务。
@@ -62,6 +69,16 @@ type executor struct {
 	initQueue   chan *initPayload
 	backend     backend.Backend
 
+	// rateLimiter 按任务限流键对任务进行限流，超出限额的任务放入 deferredTasks 延迟执行
+	rateLimiter *rateLimiter
+	// ld 用于获取存活节点，集群限额按存活节点数均分到每个节点
+	ld            leader.Leader
+	nodeSyncTime  time.Time
+	deferInterval time.Duration
+	deferLock     sync.Mutex
+	deferredTasks map[string]*initPayload
+	deferredOrder []string
+
 	closeCh chan struct{}
 
 	GetSchedulerFunc func() Scheduler
@@ -73,9 +90,15 @@ func (exec *executor) SetGetSchedulerFunc(f func() Scheduler) {
 }
 
 // NewExecutor 实例化任务执行器
-func NewExecutor(bd backend.Backend, opt *ExecutorOption) Executor {
+func NewExecutor(bd backend.Backend, ld leader.Leader, opt *ExecutorOption) Executor {
+	deferInterval := defaultDeferInterval
+	if opt.RateLimit != nil && opt.RateLimit.DeferIntervalMS != 0 {
+		deferInterval = time.Duration(opt.RateLimit.DeferIntervalMS) * time.Millisecond
+	}
+
 	return &executor{
 		backend:            bd,
+		ld:                 ld,
 		workerWg:           sync.WaitGroup{},
 		initWg:             sync.WaitGroup{},
 		workerQueue:        make(chan *Task, 10),
@@ -83,14 +106,26 @@ func NewExecutor(bd backend.Backend, opt *ExecutorOption) Executor {
 		closeCh:            make(chan struct{}, 1),
 		workerNumber:       opt.WorkerNumber,
 		taskExecTimeoutSec: opt.TaskExecTimeoutSec,
+		rateLimiter:        newRateLimiter(opt.RateLimit),
+		deferInterval:      deferInterval,
+		deferredTasks:      make(map[string]*initPayload),
 	}
 }
 
+const (
+	// defaultDeferInterval 超出限额的任务默认重新尝试执行的间隔
+	defaultDeferInterval = time.Second
+	// nodeSyncInterval 重新获取存活节点并分配集群限额的间隔
+	nodeSyncInterval = 10 * time.Second
+)
+
 // Start 初始化执行器并启动执行
 func (exec *executor) Start() {
 
 	logs.Infof("executor start, worker number: %d", exec.workerNumber)
 
+	exec.syncRateLimitNodes()
+
 	// 待执行的任务预处理
 	exec.initWg.Add(1)
 	go exec.watchInitQueue()
@@ -102,13 +137,149 @@ func (exec *executor) Start() {
 	}
 }
 
-// 从initQueue队列获取待执行的任务协程
+// 从initQueue队列获取待执行的任务协程，并周期性重新尝试执行超出限额被延迟的任务
 func (exec *executor) watchInitQueue() {
-	for p := range exec.initQueue {
+	ticker := time.NewTicker(exec.deferInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case p, ok := <-exec.initQueue:
+			if !ok {
+				exec.initWg.Done()
+				return
+			}
+			exec.initWorkerTask(p.flow, p.task)
+
+		case <-ticker.C:
+			if time.Since(exec.nodeSyncTime) >= nodeSyncInterval {
+				exec.syncRateLimitNodes()
+			}
+			exec.retryDeferredTasks()
+		}
+	}
+}
+
+// syncRateLimitNodes 按当前存活节点重新分配集群限额，获取存活节点失败时保持原有分配
+func (exec *executor) syncRateLimitNodes() {
+	exec.nodeSyncTime = time.Now()
+	if exec.ld == nil || len(exec.rateLimiter.rules) == 0 {
+		return
+	}
+
+	nodes, err := exec.ld.AliveNodes()
+	if err != nil {
+		logs.Errorf("query alive nodes for rate limit failed, err: %v", err)
+		return
+	}
+
+	// 所有节点按相同的顺序计算序号，保证轮流分配的余数限额不会被多个节点同时使用
+	sort.Strings(nodes)
+	curr := exec.ld.CurrNode()
+	for idx, node := range nodes {
+		if node == curr {
+			exec.rateLimiter.SetNodes(uint(idx), uint(len(nodes)))
+			return
+		}
+	}
+
+	logs.Warnf("current node %s is not in alive nodes %v, keep rate limit quota unchanged", curr, nodes)
+}
+
+// deferTask 超出限额的任务延迟执行，任务状态保持Pending，第一次延迟时将延迟原因写入任务，便于查看任务未执行的原因。
+// 延迟的任务只保存在当前节点内存中，节点下线后由主节点的 WatchDog 将其所属的任务流重新置为Pending并重新派发。
+func (exec *executor) deferTask(flow *Flow, task *Task) {
+	exec.deferLock.Lock()
+	if _, exist := exec.deferredTasks[task.ID]; exist {
+		exec.deferLock.Unlock()
+		return
+	}
+
+	exec.deferredTasks[task.ID] = &initPayload{flow: flow, task: task}
+	exec.deferredOrder = append(exec.deferredOrder, task.ID)
+	exec.deferLock.Unlock()
+
+	if task.deferred {
+		return
+	}
+	task.deferred = true
+
+	md := &model.Task{ID: task.ID, Reason: &tableasync.Reason{Message: ErrTaskRateLimited}}
+	if err := exec.backend.UpdateTask(task.Kit, md); err != nil {
+		logs.Errorf("update deferred task reason failed, err: %v, id: %s, rid: %s", err, task.ID, task.Kit.Rid)
+	}
+}
+
+// retryDeferredTasks 按延迟的先后顺序重新尝试执行被延迟的任务，仍超出限额的任务会再次被延迟。
+// 执行前重新查询任务流状态，任务流已不在执行中(如被暂停、取消)时丢弃其延迟的任务，任务保持Pending，
+// 任务流恢复后由调度器重新下发。
+func (exec *executor) retryDeferredTasks() {
+	exec.deferLock.Lock()
+	order := exec.deferredOrder
+	exec.deferredOrder = nil
+	flowIDs := make([]string, 0)
+	for _, id := range order {
+		if p, exist := exec.deferredTasks[id]; exist {
+			flowIDs = append(flowIDs, p.flow.ID)
+		}
+	}
+	exec.deferLock.Unlock()
+
+	if len(flowIDs) == 0 {
+		return
+	}
+
+	kt := core.NewBackendKit()
+	states, err := listFlowStates(kt, exec.backend, slice.Unique(flowIDs))
+	if err != nil {
+		// 查询失败时保持延迟，下次再重新尝试
+		logs.Errorf("list deferred task flow states failed, err: %v, rid: %s", err, kt.Rid)
+		exec.deferLock.Lock()
+		exec.deferredOrder = append(order, exec.deferredOrder...)
+		exec.deferLock.Unlock()
+		return
+	}
+
+	for _, id := range order {
+		exec.deferLock.Lock()
+		p, exist := exec.deferredTasks[id]
+		delete(exec.deferredTasks, id)
+		exec.deferLock.Unlock()
+
+		// 已被取消的任务不再执行
+		if !exist {
+			continue
+		}
+
+		if state := states[p.flow.ID]; state != enumor.FlowRunning {
+			logs.Infof("flow %s of deferred task %s is %s, drop the task, rid: %s", p.flow.ID, id, state,
+				p.task.Kit.Rid)
+			continue
+		}
+
 		exec.initWorkerTask(p.flow, p.task)
 	}
+}
 
-	exec.initWg.Done()
+// listFlowStates 查询任务流的当前状态，不存在的任务流不在返回结果中
+func listFlowStates(kt *kit.Kit, bd backend.Backend, flowIDs []string) (map[string]enumor.FlowState, error) {
+	states := make(map[string]enumor.FlowState, len(flowIDs))
+	for _, partIDs := range slice.Split(flowIDs, int(core.DefaultMaxPageLimit)) {
+		input := &backend.ListInput{
+			Filter: tools.ContainersExpression("id", partIDs),
+			Page:   core.NewDefaultBasePage(),
+		}
+		flows, err := bd.ListFlow(kt, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, one := range flows {
+			states[one.ID] = one.State
+		}
+	}
+
+	return states, nil
 }
 
 // 待执行任务的预处理函数
@@ -119,6 +290,15 @@ func (exec *executor) initWorkerTask(flow *Flow, task *Task) {
 		return
 	}
 
+	release, ok := exec.rateLimiter.TryAcquire(task.RateLimitKey)
+	if !ok {
+		logs.V(3).Infof("task %s exceeds rate limit of %s, defer to execute, rid: %s", task.ID,
+			task.RateLimitKey, task.Kit.Rid)
+		exec.deferTask(flow, task)
+		return
+	}
+	task.releaseQuota = release
+
 	// 设置超时控制
 	cancel := task.Kit.CtxWithTimeoutMS(int(exec.taskExecTimeoutSec) * 1000)
 
@@ -128,7 +308,7 @@ func (exec *executor) initWorkerTask(flow *Flow, task *Task) {
 	}
 
 	// 设置task执行所需要的 kit，更新Task函数，所属流
-	task.InitDep(run.NewExecuteContext(task.Kit, flow.ShareData), func(kt *kit.Kit, task *model.Task) error {
+	task.InitDep(run.NewExecuteContext(task.Kit, flow.ID, flow.ShareData), func(kt *kit.Kit, task *model.Task) error {
 		return exec.backend.UpdateTask(kt, task)
 	}, flow)
 
@@ -154,8 +334,33 @@ func (exec *executor) subWorkerQueue() {
 // 任务执行体
 func (exec *executor) workerDo(task *Task) (err error) {
 
-	// cancelMap清理执行成功/失败的任务
-	defer exec.cancelMap.Delete(task.ID)
+	// cancelMap清理执行成功/失败的任务，并释放任务占用的并发额度
+	defer func() {
+		exec.cancelMap.Delete(task.ID)
+		if task.releaseQuota != nil {
+			task.releaseQuota()
+		}
+	}()
+
+	// 判断任务是否需要跳过，跳过的任务同样交给调度器获取子任务
+	if task.State == enumor.TaskPending {
+		skip, reason, evalErr := evaluateSkip(exec.backend, task)
+		if evalErr != nil {
+			logs.Errorf("evaluate task skip failed, err: %v, id: %s, rid: %s", evalErr, task.ID, task.Kit.Rid)
+			if err = task.UpdateTask(enumor.TaskFailed, evalErr.Error(), nil); err == nil {
+				err = evalErr
+			}
+			exec.GetSchedulerFunc().EntryTask(task)
+			return err
+		}
+
+		if skip {
+			logs.Infof("task %s is skipped, reason: %s, rid: %s", task.ID, reason, task.Kit.Rid)
+			err = task.UpdateTask(enumor.TaskSkipped, reason, nil)
+			exec.GetSchedulerFunc().EntryTask(task)
+			return err
+		}
+	}
 
 	// 执行任务
 	if err = task.Run(); err != nil {
@@ -196,6 +401,12 @@ type initPayload struct {
 
 // CancelTasks 停止指定id的任务
 func (exec *executor) CancelTasks(taskIDs []string) error {
+	exec.deferLock.Lock()
+	for _, id := range taskIDs {
+		delete(exec.deferredTasks, id)
+	}
+	exec.deferLock.Unlock()
+
 	for _, id := range taskIDs {
 		if cancel, ok := exec.cancelMap.Load(id); ok {
 			exec.cancelMap.Delete(id)
@@ -216,6 +427,14 @@ func (exec *executor) Close() {
 
 	close(exec.initQueue)
 	exec.initWg.Wait()
+
+	exec.deferLock.Lock()
+	if len(exec.deferredTasks) != 0 {
+		logs.Infof("executor drop %d deferred tasks, their flows will be redispatched after node is offline",
+			len(exec.deferredTasks))
+	}
+	exec.deferLock.Unlock()
+
 	close(exec.workerQueue)
 	exec.workerWg.Wait()
 
@@ -50,6 +50,8 @@ type LeaderChangeHandler struct {
 
 	dispatcher *Dispatcher
 	watchDog   WatchDog
+	trigger    *ScheduleTrigger
+	notifier   *WebhookNotifier
 
 	closeCh chan struct{}
 
@@ -113,6 +115,18 @@ func (handler *LeaderChangeHandler) startLeaderComponent() {
 	wd.Start()
 	handler.closers = append(handler.closers, wd)
 	handler.watchDog = wd
+
+	// 初始化定时计划触发器，只有主节点负责将到期的定时计划创建为任务流
+	trigger := NewScheduleTrigger(handler.bd, handler.opt.ScheduleTrigger)
+	trigger.Start()
+	handler.closers = append(handler.closers, trigger)
+	handler.trigger = trigger
+
+	// 初始化事件回调通知器，只有主节点负责投递任务流事件，保证同一回调的事件按顺序投递
+	notifier := NewWebhookNotifier(handler.bd, handler.opt.WebhookNotifier)
+	notifier.Start()
+	handler.closers = append(handler.closers, notifier)
+	handler.notifier = notifier
 }
 
 // Close 主从切换处理器
@@ -27,6 +27,10 @@ type Option struct {
 	Executor   *ExecutorOption   `json:"executor" validate:"required"`
 	Dispatcher *DispatcherOption `json:"dispatcher" validate:"required"`
 	WatchDog   *WatchDogOption   `json:"watch_dog" validate:"required"`
+	// ScheduleTrigger 主节点组件，负责将到期的任务流定时计划创建为任务流
+	ScheduleTrigger *ScheduleTriggerOption `json:"schedule_trigger" validate:"required"`
+	// WebhookNotifier 主节点组件，负责将任务流事件投递到事件回调地址，并清理过期事件
+	WebhookNotifier *WebhookNotifierOption `json:"webhook_notifier" validate:"required"`
 }
 
 // Validate Option
@@ -49,6 +53,8 @@ func (opt SchedulerOption) Validate() error {
 type ExecutorOption struct {
 	WorkerNumber       uint `json:"worker_number" validate:"required"`
 	TaskExecTimeoutSec uint `json:"task_exec_timeout_sec" validate:"required"`
+	// RateLimit 集群按任务限流键对任务进行限流和并发控制，为空表示不限流
+	RateLimit *RateLimitOption `json:"rate_limit" validate:"omitempty"`
 }
 
 // Validate ExecutorOption
@@ -56,6 +62,36 @@ func (opt ExecutorOption) Validate() error {
 	return validator.Validate.Struct(opt)
 }
 
+// RateLimitOption 任务限流配置，规则中的限额为整个集群的限额，按存活节点数均分到每个节点
+type RateLimitOption struct {
+	// DeferIntervalMS 超出限额的任务重新尝试执行的间隔
+	DeferIntervalMS uint `json:"defer_interval_ms" validate:"required"`
+	// Rules 限流规则，任务需要满足所有匹配规则的限额才能执行
+	Rules []RateLimitRule `json:"rules" validate:"omitempty,dive"`
+}
+
+// Validate RateLimitOption
+func (opt RateLimitOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// RateLimitRule 任务限流规则，Vendor、AccountID、Region 为空表示匹配所有取值且共享同一限额，
+// 为 RateLimitEachValue 表示匹配所有取值且每个取值单独计算限额，其他值表示只匹配该取值。
+type RateLimitRule struct {
+	Vendor    string `json:"vendor" validate:"omitempty"`
+	AccountID string `json:"account_id" validate:"omitempty"`
+	Region    string `json:"region" validate:"omitempty"`
+	// QPS 每秒允许开始执行的任务数，为0表示不限制
+	QPS float64 `json:"qps" validate:"gte=0"`
+	// Burst 允许突发开始执行的任务数，QPS不为0时最小为1
+	Burst uint `json:"burst" validate:"omitempty"`
+	// Concurrency 同时执行的最大任务数，为0表示不限制
+	Concurrency uint `json:"concurrency" validate:"omitempty"`
+}
+
+// RateLimitEachValue 限流规则中表示每个取值单独计算限额的通配符
+const RateLimitEachValue = "*"
+
 // DispatcherOption 主节点组件，负责派发任务
 type DispatcherOption struct {
 	WatchIntervalSec uint `json:"watch_interval_sec" validate:"required"`
@@ -71,9 +107,32 @@ type WatchDogOption struct {
 	WatchIntervalSec    uint `json:"watch_interval_sec" validate:"required"`
 	TaskRunTimeoutSec   uint `json:"task_run_timeout_sec" validate:"required"`
 	ShutdownWaitTimeSec uint `json:"shutdown_wait_time_sec" validate:"required"`
+	// FlowRetentionHour 已结束任务流的保留时长，仅对需要清理历史任务流的后端(etcd)生效，为0时不清理
+	FlowRetentionHour uint `json:"flow_retention_hour"`
 }
 
 // Validate WatchDogOption
 func (opt WatchDogOption) Validate() error {
 	return validator.Validate.Struct(opt)
 }
+
+// ScheduleTriggerOption 主节点组件，负责将到期的任务流定时计划创建为任务流
+type ScheduleTriggerOption struct {
+	WatchIntervalSec uint `json:"watch_interval_sec" validate:"required"`
+}
+
+// Validate ScheduleTriggerOption
+func (opt ScheduleTriggerOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// WebhookNotifierOption 主节点组件，负责将任务流事件投递到事件回调地址，并清理过期事件
+type WebhookNotifierOption struct {
+	WatchIntervalSec   uint `json:"watch_interval_sec" validate:"required"`
+	EventRetentionHour uint `json:"event_retention_hour" validate:"required"`
+}
+
+// Validate WebhookNotifierOption
+func (opt WebhookNotifierOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
@@ -0,0 +1,229 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	tableasync "hcm/pkg/dal/table/async"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimiter 按任务限流键对任务进行限流和并发控制，规则中的限额为集群限额，当前节点只使用按存活节点数均分后的限额
+type rateLimiter struct {
+	rules []RateLimitRule
+
+	lock sync.Mutex
+	// nodeIndex 当前节点在存活节点中的序号，nodeNum 存活节点数
+	nodeIndex uint
+	nodeNum   uint
+	// buckets 限额桶，key为规则下标和规则中按取值单独计算限额的字段取值，空闲的限额桶定期清理
+	buckets   map[string]*rateBucket
+	cleanTime time.Time
+}
+
+// rateBucket 一个限流规则下共享同一限额的任务的限额
+type rateBucket struct {
+	rule *RateLimitRule
+	// limiter 为空表示不限制QPS，速率和突发数为当前节点分得的限额
+	limiter *rate.Limiter
+	running uint
+}
+
+const (
+	// nodeShareRotateSec 集群限额不能被节点数整除时，余下的限额按该时间片在节点间轮流分配
+	nodeShareRotateSec = 10
+	// bucketCleanInterval 清理空闲限额桶的间隔
+	bucketCleanInterval = time.Minute
+)
+
+func newRateLimiter(opt *RateLimitOption) *rateLimiter {
+	limiter := &rateLimiter{
+		nodeNum: 1,
+		buckets: make(map[string]*rateBucket),
+	}
+
+	if opt != nil {
+		limiter.rules = opt.Rules
+	}
+
+	return limiter
+}
+
+// TryAcquire 尝试为任务申请执行额度，所有匹配规则都有剩余额度时申请成功，并返回任务执行结束后释放并发额度的函数，
+// 任务没有限流键时不限流。
+func (l *rateLimiter) TryAcquire(key *tableasync.RateLimitKey) (func(), bool) {
+	if key == nil || len(l.rules) == 0 {
+		return func() {}, true
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.cleanTime) >= bucketCleanInterval {
+		l.cleanIdleBuckets(now)
+	}
+
+	buckets := l.matchBuckets(key)
+
+	// 先检查所有规则的额度，避免部分规则占用额度后申请失败
+	for _, one := range buckets {
+		if one.rule.Concurrency != 0 && one.running >= l.nodeShare(one.rule.Concurrency, now) {
+			return nil, false
+		}
+
+		if one.limiter != nil && one.limiter.TokensAt(now) < 1 {
+			return nil, false
+		}
+	}
+
+	for _, one := range buckets {
+		if one.limiter != nil {
+			one.limiter.AllowN(now, 1)
+		}
+		one.running++
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			l.lock.Lock()
+			defer l.lock.Unlock()
+
+			for _, one := range buckets {
+				one.running--
+			}
+		})
+	}
+
+	return release, true
+}
+
+func (l *rateLimiter) matchBuckets(key *tableasync.RateLimitKey) []*rateBucket {
+	buckets := make([]*rateBucket, 0)
+	for idx, rule := range l.rules {
+		vendor, vendorOk := matchRateLimitField(rule.Vendor, string(key.Vendor))
+		account, accountOk := matchRateLimitField(rule.AccountID, key.AccountID)
+		region, regionOk := matchRateLimitField(rule.Region, key.Region)
+		if !vendorOk || !accountOk || !regionOk {
+			continue
+		}
+
+		bucketKey := fmt.Sprintf("%d/%s/%s/%s", idx, vendor, account, region)
+		bucket, exist := l.buckets[bucketKey]
+		if !exist {
+			bucket = &rateBucket{rule: &l.rules[idx]}
+			if rule.QPS > 0 {
+				bucket.limiter = rate.NewLimiter(l.nodeQPS(&rule), l.nodeBurst(&rule))
+			}
+			l.buckets[bucketKey] = bucket
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets
+}
+
+// cleanIdleBuckets 清理没有执行中的任务且QPS令牌已满的限额桶，清理后重新创建的限额桶与原限额桶状态一致，
+// 避免按账号、地域等取值单独计算限额时限额桶随取值无限增长
+func (l *rateLimiter) cleanIdleBuckets(now time.Time) {
+	l.cleanTime = now
+	for key, bucket := range l.buckets {
+		if bucket.running != 0 {
+			continue
+		}
+
+		if bucket.limiter != nil && bucket.limiter.TokensAt(now) < float64(bucket.limiter.Burst()) {
+			continue
+		}
+
+		delete(l.buckets, key)
+	}
+}
+
+// SetNodes 设置当前节点在存活节点中的序号和存活节点数，重新计算当前节点分得的限额
+func (l *rateLimiter) SetNodes(index, num uint) {
+	if num == 0 || index >= num {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.nodeIndex == index && l.nodeNum == num {
+		return
+	}
+	l.nodeIndex, l.nodeNum = index, num
+
+	now := time.Now()
+	for _, bucket := range l.buckets {
+		if bucket.limiter != nil {
+			bucket.limiter.SetLimitAt(now, l.nodeQPS(bucket.rule))
+			bucket.limiter.SetBurstAt(now, l.nodeBurst(bucket.rule))
+		}
+	}
+}
+
+// nodeQPS 当前节点分得的QPS
+func (l *rateLimiter) nodeQPS(rule *RateLimitRule) rate.Limit {
+	return rate.Limit(rule.QPS / float64(l.nodeNum))
+}
+
+// nodeBurst 当前节点分得的突发数，最小为1，集群突发数小于节点数时集群内的突发数可能超出配置
+func (l *rateLimiter) nodeBurst(rule *RateLimitRule) int {
+	burst := rule.Burst / l.nodeNum
+	if burst == 0 {
+		burst = 1
+	}
+	return int(burst)
+}
+
+// nodeShare 将集群限额均分到各个节点，不能整除的余数按时间片轮流分配给各个节点，
+// 同一时间片内各节点分得的限额之和等于集群限额，限额小于节点数时各节点的任务也能轮流执行。
+func (l *rateLimiter) nodeShare(total uint, now time.Time) uint {
+	share := total / l.nodeNum
+	remainder := total % l.nodeNum
+	if remainder == 0 {
+		return share
+	}
+
+	slot := uint(now.Unix() / nodeShareRotateSec)
+	if (l.nodeIndex+slot)%l.nodeNum < remainder {
+		share++
+	}
+	return share
+}
+
+// matchRateLimitField 判断规则字段是否匹配取值，返回该字段在限额桶key中的取值，共享限额时为空
+func matchRateLimitField(ruleValue, value string) (string, bool) {
+	switch ruleValue {
+	case "":
+		return "", true
+	case RateLimitEachValue:
+		return value, true
+	default:
+		return value, ruleValue == value
+	}
+}
@@ -0,0 +1,294 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"testing"
+	"time"
+
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+)
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	limiter := newRateLimiter(&RateLimitOption{
+		DeferIntervalMS: 1000,
+		Rules: []RateLimitRule{
+			// 每个腾讯云账号单独计算并发限额
+			{Vendor: string(enumor.TCloud), AccountID: RateLimitEachValue, Concurrency: 1},
+			// 所有亚马逊云账号共享并发限额
+			{Vendor: string(enumor.Aws), Concurrency: 1},
+		},
+	})
+
+	release, ok := limiter.TryAcquire(tableasync.NewRateLimitKey(enumor.TCloud, "a1", "ap-guangzhou"))
+	if !ok {
+		t.Fatal("first task of tcloud account a1 should acquire quota")
+	}
+
+	if _, ok = limiter.TryAcquire(tableasync.NewRateLimitKey(enumor.TCloud, "a1", "ap-shanghai")); ok {
+		t.Fatal("second task of tcloud account a1 should be deferred")
+	}
+
+	if _, ok = limiter.TryAcquire(tableasync.NewRateLimitKey(enumor.TCloud, "a2", "ap-guangzhou")); !ok {
+		t.Fatal("task of tcloud account a2 should acquire quota")
+	}
+
+	release()
+	if _, ok = limiter.TryAcquire(tableasync.NewRateLimitKey(enumor.TCloud, "a1", "ap-shanghai")); !ok {
+		t.Fatal("task of tcloud account a1 should acquire quota after release")
+	}
+
+	if _, ok = limiter.TryAcquire(tableasync.NewRateLimitKey(enumor.Aws, "b1", "us-east-1")); !ok {
+		t.Fatal("first task of aws should acquire quota")
+	}
+
+	if _, ok = limiter.TryAcquire(tableasync.NewRateLimitKey(enumor.Aws, "b2", "us-east-1")); ok {
+		t.Fatal("task of another aws account should be deferred, aws accounts share the same quota")
+	}
+
+	// 没有匹配规则或没有限流键的任务不限流
+	if _, ok = limiter.TryAcquire(tableasync.NewRateLimitKey(enumor.HuaWei, "c1", "cn-south-1")); !ok {
+		t.Fatal("task without matched rule should not be limited")
+	}
+
+	if _, ok = limiter.TryAcquire(nil); !ok {
+		t.Fatal("task without rate limit key should not be limited")
+	}
+}
+
+func TestRateLimiterQPS(t *testing.T) {
+	limiter := newRateLimiter(&RateLimitOption{
+		DeferIntervalMS: 1000,
+		Rules: []RateLimitRule{
+			{Vendor: RateLimitEachValue, AccountID: RateLimitEachValue, QPS: 0.001, Burst: 2},
+			{Vendor: string(enumor.TCloud), AccountID: "a1", Concurrency: 1},
+		},
+	})
+
+	key := tableasync.NewRateLimitKey(enumor.TCloud, "a2", "ap-guangzhou")
+	for i := 0; i < 2; i++ {
+		if _, ok := limiter.TryAcquire(key); !ok {
+			t.Fatalf("task %d should acquire quota within burst", i)
+		}
+	}
+
+	if _, ok := limiter.TryAcquire(key); ok {
+		t.Fatal("task exceeds burst should be deferred")
+	}
+
+	// 并发额度不足时不应占用QPS额度
+	key = tableasync.NewRateLimitKey(enumor.TCloud, "a1", "ap-guangzhou")
+	if _, ok := limiter.TryAcquire(key); !ok {
+		t.Fatal("first task of account a1 should acquire quota")
+	}
+
+	if _, ok := limiter.TryAcquire(key); ok {
+		t.Fatal("second task of account a1 should be deferred by concurrency")
+	}
+
+	bucket := limiter.buckets["0/tcloud/a1/"]
+	if bucket == nil || bucket.limiter.TokensAt(time.Now()) < 1 {
+		t.Fatal("deferred task should not consume qps quota of account a1")
+	}
+}
+
+func TestRateLimiterNodeShare(t *testing.T) {
+	opt := &RateLimitOption{
+		DeferIntervalMS: 1000,
+		Rules:           []RateLimitRule{{Vendor: RateLimitEachValue, QPS: 10, Burst: 4, Concurrency: 3}},
+	}
+	nodes := []*rateLimiter{newRateLimiter(opt), newRateLimiter(opt)}
+	for idx, one := range nodes {
+		one.SetNodes(uint(idx), uint(len(nodes)))
+	}
+
+	// 同一时刻各节点分得的并发限额之和等于集群限额
+	key := tableasync.NewRateLimitKey(enumor.TCloud, "a1", "ap-guangzhou")
+	now := time.Now()
+	total := uint(0)
+	for _, one := range nodes {
+		total += one.nodeShare(opt.Rules[0].Concurrency, now)
+	}
+	if total != opt.Rules[0].Concurrency {
+		t.Fatalf("sum of node concurrency should be %d, but got %d", opt.Rules[0].Concurrency, total)
+	}
+
+	// 限额小于节点数时，余下的限额在时间片间轮流分配给各个节点
+	shares := make([]uint, len(nodes))
+	for slot := 0; slot < len(nodes); slot++ {
+		at := time.Unix(int64(slot*nodeShareRotateSec), 0)
+		for idx, one := range nodes {
+			shares[idx] += one.nodeShare(1, at)
+		}
+	}
+	for idx, share := range shares {
+		if share != 1 {
+			t.Errorf("node %d should get the remainder quota once in %d slots, but got %d", idx, len(nodes), share)
+		}
+	}
+
+	// QPS和突发数按节点数均分
+	nodes[0].TryAcquire(key)
+	bucket := nodes[0].buckets["0/tcloud//"]
+	if bucket == nil || bucket.limiter.Limit() != 5 || bucket.limiter.Burst() != 2 {
+		t.Fatalf("node qps and burst should be split by node number, bucket: %+v", bucket)
+	}
+
+	// 节点数变化后重新分配已有限额桶的限额
+	nodes[0].SetNodes(0, 1)
+	if bucket.limiter.Limit() != 10 || bucket.limiter.Burst() != 4 {
+		t.Errorf("node qps and burst should be reset after node number changed, qps: %v, burst: %d",
+			bucket.limiter.Limit(), bucket.limiter.Burst())
+	}
+}
+
+func TestExecutorDeferTask(t *testing.T) {
+	bd := backend.NewMemory()
+	exec := NewExecutor(bd, nil, &ExecutorOption{WorkerNumber: 1, TaskExecTimeoutSec: 10,
+		RateLimit: &RateLimitOption{DeferIntervalMS: 1000, Rules: []RateLimitRule{
+			{Vendor: RateLimitEachValue, Concurrency: 1},
+		}}}).(*executor)
+
+	tasks := []model.Task{{ActionID: "1", ActionName: enumor.ActionStartCvm}}
+	flowID, created := newTestFlow(t, bd, enumor.FlowRunning, tasks, []enumor.TaskState{enumor.TaskPending})
+	task := created[0]
+	task.RateLimitKey = tableasync.NewRateLimitKey(enumor.TCloud, "a1", "ap-guangzhou")
+
+	// 占用全部并发额度，任务只能被延迟
+	if _, ok := exec.rateLimiter.TryAcquire(task.RateLimitKey); !ok {
+		t.Fatal("first acquire should success")
+	}
+
+	flow := &Flow{Flow: model.Flow{ID: flowID}, Kit: kit.New()}
+	exec.initWorkerTask(flow, task)
+	exec.retryDeferredTasks()
+
+	if _, exist := exec.deferredTasks[task.ID]; !exist || len(exec.deferredOrder) != 1 {
+		t.Fatalf("task should be deferred once, deferred order: %v", exec.deferredOrder)
+	}
+
+	// 延迟原因写入任务，任务状态保持Pending
+	updated, err := listTaskByIDs(kit.New(), bd, []string{task.ID})
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	if updated[0].State != enumor.TaskPending || updated[0].Reason == nil ||
+		updated[0].Reason.Message != ErrTaskRateLimited {
+		t.Fatalf("deferred task should keep pending with rate limited reason, got: %+v", updated[0].Task)
+	}
+
+	if err = exec.CancelTasks([]string{task.ID}); err != nil {
+		t.Fatalf("cancel task failed, err: %v", err)
+	}
+	if _, exist := exec.deferredTasks[task.ID]; exist {
+		t.Fatal("canceled task should be removed from deferred tasks")
+	}
+}
+
+func TestExecutorDropDeferredTaskOfPausedFlow(t *testing.T) {
+	bd := backend.NewMemory()
+	exec := NewExecutor(bd, nil, &ExecutorOption{WorkerNumber: 1, TaskExecTimeoutSec: 10,
+		RateLimit: &RateLimitOption{DeferIntervalMS: 1000, Rules: []RateLimitRule{
+			{Vendor: RateLimitEachValue, Concurrency: 1},
+		}}}).(*executor)
+
+	tasks := []model.Task{{ActionID: "1", ActionName: enumor.ActionStartCvm}}
+	flowID, created := newTestFlow(t, bd, enumor.FlowRunning, tasks, []enumor.TaskState{enumor.TaskPending})
+	task := created[0]
+	task.RateLimitKey = tableasync.NewRateLimitKey(enumor.TCloud, "a1", "ap-guangzhou")
+
+	release, ok := exec.rateLimiter.TryAcquire(task.RateLimitKey)
+	if !ok {
+		t.Fatal("first acquire should success")
+	}
+
+	flow := &Flow{Flow: model.Flow{ID: flowID}, Kit: kit.New()}
+	exec.initWorkerTask(flow, task)
+	if _, exist := exec.deferredTasks[task.ID]; !exist {
+		t.Fatal("task should be deferred")
+	}
+
+	// 任务延迟期间暂停任务流，释放额度后延迟的任务也不能再执行
+	cmd := NewCommander(bd, exec)
+	if err := cmd.PauseFlow(kit.New(), flowID); err != nil {
+		t.Fatalf("pause flow failed, err: %v", err)
+	}
+	release()
+	exec.retryDeferredTasks()
+
+	if len(exec.deferredTasks) != 0 || len(exec.deferredOrder) != 0 {
+		t.Fatalf("deferred task of paused flow should be dropped, deferred order: %v", exec.deferredOrder)
+	}
+	if len(exec.workerQueue) != 0 {
+		t.Fatal("deferred task of paused flow should not be executed")
+	}
+
+	updated, err := listTaskByIDs(kit.New(), bd, []string{task.ID})
+	if err != nil {
+		t.Fatalf("list task failed, err: %v", err)
+	}
+	if updated[0].State != enumor.TaskPending {
+		t.Errorf("dropped task should keep pending to be executed after resume, got: %s", updated[0].State)
+	}
+}
+
+func TestRateLimiterCleanIdleBuckets(t *testing.T) {
+	limiter := newRateLimiter(&RateLimitOption{Rules: []RateLimitRule{
+		{Vendor: string(enumor.TCloud), AccountID: RateLimitEachValue, Concurrency: 1},
+		{Vendor: string(enumor.Aws), AccountID: RateLimitEachValue, QPS: 1, Burst: 1},
+	}})
+
+	release, ok := limiter.TryAcquire(tableasync.NewRateLimitKey(enumor.TCloud, "a1", "ap-guangzhou"))
+	if !ok {
+		t.Fatal("task of tcloud account a1 should acquire quota")
+	}
+	releaseA2, ok := limiter.TryAcquire(tableasync.NewRateLimitKey(enumor.TCloud, "a2", "ap-guangzhou"))
+	if !ok {
+		t.Fatal("task of tcloud account a2 should acquire quota")
+	}
+	releaseA2()
+	releaseB1, ok := limiter.TryAcquire(tableasync.NewRateLimitKey(enumor.Aws, "b1", "us-east-1"))
+	if !ok {
+		t.Fatal("task of aws account b1 should acquire quota")
+	}
+	releaseB1()
+
+	// 执行中任务的限额桶和令牌未恢复的限额桶不能清理，其余空闲的限额桶被清理
+	limiter.cleanIdleBuckets(time.Now())
+	if _, exist := limiter.buckets["0/tcloud/a1/"]; !exist {
+		t.Error("bucket with running task should not be cleaned")
+	}
+	if _, exist := limiter.buckets["0/tcloud/a2/"]; exist {
+		t.Error("idle bucket should be cleaned")
+	}
+	if _, exist := limiter.buckets["1/aws/b1/"]; !exist {
+		t.Error("bucket whose qps tokens are not refilled should not be cleaned")
+	}
+
+	release()
+	limiter.cleanIdleBuckets(time.Now().Add(2 * time.Second))
+	if len(limiter.buckets) != 0 {
+		t.Errorf("all idle buckets should be cleaned, but got %d", len(limiter.buckets))
+	}
+}
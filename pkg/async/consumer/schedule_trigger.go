@@ -0,0 +1,213 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/async/producer"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/cron"
+	"hcm/pkg/tools/times"
+)
+
+// NewScheduleTrigger new schedule trigger.
+func NewScheduleTrigger(bd backend.Backend, opt *ScheduleTriggerOption) *ScheduleTrigger {
+	return &ScheduleTrigger{
+		watchIntervalSec: time.Duration(opt.WatchIntervalSec) * time.Second,
+		bd:               bd,
+		closeCh:          make(chan struct{}),
+		wg:               new(sync.WaitGroup),
+	}
+}
+
+// ScheduleTrigger 定时计划触发器，负责将到期的任务流定时计划按照模版创建为任务流，并计算下次执行时间。
+type ScheduleTrigger struct {
+	watchIntervalSec time.Duration
+
+	bd backend.Backend
+
+	wg      *sync.WaitGroup
+	closeCh chan struct{}
+}
+
+// Start schedule trigger.
+func (t *ScheduleTrigger) Start() {
+	t.wg.Add(1)
+	go t.WatchDueSchedule()
+}
+
+// WatchDueSchedule 监听到期的定时计划，并创建任务流。
+func (t *ScheduleTrigger) WatchDueSchedule() {
+	defer t.wg.Done()
+
+	for {
+		select {
+		case <-t.closeCh:
+			return
+		default:
+		}
+
+		kt := NewKit()
+		if err := t.Do(kt); err != nil {
+			logs.Errorf("%s: schedule trigger do failed, err: %v, rid: %s", constant.AsyncTaskWarnSign, err, kt.Rid)
+		}
+
+		time.Sleep(t.watchIntervalSec)
+	}
+}
+
+// Do 查询处于启用状态且已到执行时间的定时计划，并逐个触发。
+func (t *ScheduleTrigger) Do(kt *kit.Kit) error {
+	expr, err := tools.And(
+		tools.EqualExpression("state", enumor.FlowScheduleEnabled),
+		&filter.AtomRule{Field: "next_run_at", Op: filter.LessThanEqual.Factory(),
+			Value: times.ConvStdTimeFormat(time.Now())},
+	)
+	if err != nil {
+		return err
+	}
+
+	input := &backend.ListInput{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+	}
+	schedules, err := t.bd.ListSchedule(kt, input)
+	if err != nil {
+		logs.Errorf("list flow schedule failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	if len(schedules) == 0 {
+		logs.V(3).Infof("currently no due flow schedule, skip trigger, rid: %s", kt.Rid)
+		return nil
+	}
+
+	for _, one := range schedules {
+		if err = t.trigger(one); err != nil {
+			logs.Errorf("trigger flow schedule failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+		}
+	}
+
+	return nil
+}
+
+// trigger 按照模版创建任务流并更新定时计划，任务流创建失败时仍会推进下次执行时间，失败原因记录在定时计划中。
+func (t *ScheduleTrigger) trigger(schedule model.Schedule) error {
+	kt := NewKit()
+	// 任务流以定时计划创建者的身份创建
+	kt.User = schedule.Creator
+
+	info := &backend.TriggerScheduleInfo{
+		ID:              schedule.ID,
+		SourceNextRunAt: schedule.NextRunAt,
+		State:           enumor.FlowScheduleEnabled,
+		Reason:          new(tableasync.Reason),
+		LastFlowID:      schedule.LastFlowID,
+	}
+
+	if len(schedule.Cron) == 0 {
+		info.State = enumor.FlowScheduleFinished
+	} else {
+		next, err := nextRunAt(schedule.Cron, time.Now())
+		if err != nil {
+			// cron表达式无法计算出下次执行时间，禁用该定时计划，避免反复触发
+			info.State = enumor.FlowScheduleDisabled
+			info.Reason.Message = err.Error()
+		}
+		info.TargetNextRunAt = next
+	}
+
+	var flow *model.Flow
+	if info.State != enumor.FlowScheduleDisabled {
+		var err error
+		flow, err = producer.BuildTemplateFlow(kt, convTemplateFlowOption(schedule))
+		if err != nil {
+			logs.Errorf("build flow by schedule failed, err: %v, schedule: %s, rid: %s", err, schedule.ID, kt.Rid)
+			info.Reason.Message = fmt.Sprintf("build flow failed, err: %v", err)
+		}
+	}
+
+	flowID, err := t.bd.TriggerSchedule(kt, info, flow)
+	if err != nil {
+		if errf.Error(err).Code == errf.RecordNotUpdate {
+			logs.V(3).Infof("flow schedule has been changed, skip trigger, id: %s, rid: %s", schedule.ID, kt.Rid)
+			return nil
+		}
+
+		return err
+	}
+
+	logs.Infof("trigger flow schedule success, id: %s, flow: %s, next state: %s, rid: %s", schedule.ID, flowID,
+		info.State, kt.Rid)
+
+	return nil
+}
+
+func nextRunAt(expr string, from time.Time) (time.Time, error) {
+	sch, err := cron.Parse(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return sch.Next(from)
+}
+
+func convTemplateFlowOption(schedule model.Schedule) *producer.AddTemplateFlowOption {
+	tasks := make([]producer.TemplateFlowTask, 0, len(schedule.Tasks))
+	for _, one := range schedule.Tasks {
+		tasks = append(tasks, producer.TemplateFlowTask{
+			ActionID:     action.ActIDType(one.ActionID),
+			Params:       one.Params,
+			RateLimitKey: one.RateLimitKey,
+		})
+	}
+
+	return &producer.AddTemplateFlowOption{
+		Name:  schedule.FlowName,
+		Memo:  schedule.Memo,
+		Tasks: tasks,
+	}
+}
+
+// Close schedule trigger.
+func (t *ScheduleTrigger) Close() {
+
+	logs.Infof("schedule trigger receive close cmd, start to close")
+
+	close(t.closeCh)
+	t.wg.Wait()
+
+	logs.Infof("schedule trigger close success")
+
+}
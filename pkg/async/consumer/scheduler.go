@@ -30,6 +30,7 @@ import (
 	"hcm/pkg/async/consumer/leader"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/kit"
@@ -67,14 +68,18 @@ type scheduler struct {
 	executor Executor
 	leader   leader.Leader
 
+	// flowCh 任务流变更通知，后端不支持监听时为nil
+	flowCh  <-chan struct{}
 	closeCh chan struct{}
 }
 
 // NewScheduler 实例化任务流调度器
 func NewScheduler(bd backend.Backend, exec Executor, ld leader.Leader, opt *SchedulerOption) Scheduler {
 
+	closeCh := make(chan struct{})
 	return &scheduler{
-		closeCh:          make(chan struct{}),
+		flowCh:           watchFlow(bd, closeCh),
+		closeCh:          closeCh,
 		workerWg:         sync.WaitGroup{},
 		workerQueue:      make(chan *Task, 10),
 		workerNumber:     opt.WorkerNumber,
@@ -115,7 +120,7 @@ func (sch *scheduler) startWatcher(do func(kt *kit.Kit) error) {
 			logs.Errorf("%s: scheduler watcher do failed, err: %v, rid: %s", constant.AsyncTaskWarnSign, err, kt.Rid)
 		}
 
-		time.Sleep(sch.watchIntervalSec)
+		waitNextRound(sch.watchIntervalSec, sch.flowCh)
 	}
 
 	sch.workerWg.Done()
@@ -246,7 +251,7 @@ func (sch *scheduler) parseFlowAndPushTask(kt *kit.Kit, flow *Flow) error {
 	}
 
 	// 构造执行流树
-	root, err := BuildTaskRoot(tasks)
+	taskTree, err := BuildTaskTree(flow, tasks)
 	if err != nil {
 		if stateErr := updateFlowStateAndReason(kt, sch.backend, flow.ID, enumor.FlowRunning, enumor.FlowFailed,
 			err.Error()); stateErr != nil {
@@ -259,17 +264,16 @@ func (sch *scheduler) parseFlowAndPushTask(kt *kit.Kit, flow *Flow) error {
 		return err
 	}
 
-	taskTree := &TaskTree{
-		Flow: flow,
-		Root: root,
-	}
-
 	// 获取可执行的节点
-	executableTaskNodes := taskTree.Root.GetExecutableTasks()
+	executableTaskNodes := taskTree.GetExecutableTasks()
 	if len(executableTaskNodes) == 0 {
-		state := taskTree.Root.ComputeState()
+		state := taskTree.ComputeState()
 
 		if state == enumor.FlowSuccess {
+			if err = skipFailureBranch(kt, sch.backend, taskTree); err != nil {
+				return err
+			}
+
 			if err = updateFlowState(kt, sch.backend, flow.ID, enumor.FlowRunning, state); err != nil {
 				logs.Errorf("update flow state to %s failed, err: %v, rid: %s", state, err, kt.Rid)
 				return err
@@ -299,7 +303,29 @@ func (sch *scheduler) parseFlowAndPushTask(kt *kit.Kit, flow *Flow) error {
 	// 可执行任务推送到执行器
 	flow.State = enumor.FlowRunning
 	for _, taskID := range executableTaskNodes {
-		sch.executor.Push(flow, taskIDMap[taskID])
+		task := taskIDMap[taskID]
+		task.ParentsSkipped = taskTree.ParentsSkipped(taskID)
+		sch.executor.Push(flow, task)
+	}
+
+	return nil
+}
+
+// skipFailureBranch 任务流执行成功时，失败分支任务不需要执行，将其置为跳过状态
+func skipFailureBranch(kt *kit.Kit, bd backend.Backend, tree *TaskTree) error {
+	for _, id := range tree.GetPendingFailureTasks() {
+		info := &backend.UpdateTaskInfo{
+			ID:     id,
+			Source: enumor.TaskPending,
+			Target: enumor.TaskSkipped,
+			Reason: &tableasync.Reason{
+				Message: "flow has no failed task, on failure task is skipped",
+			},
+		}
+		if err := bd.UpdateTaskStateByCAS(kt, info); err != nil {
+			logs.Errorf("skip on failure task failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+			return err
+		}
 	}
 
 	return nil
@@ -344,7 +370,8 @@ func (sch *scheduler) watchScheduledFlow(kt *kit.Kit) error {
 	// 从DB中获取一条待执行的任务流并更新状态为执行中
 	flows, err := sch.queryCurrNodeFlow(kt, listScheduledFlowLimit)
 	if err != nil {
-		logs.Errorf("")
+		logs.Errorf("query current node scheduled flow failed, err: %v, node: %s, rid: %s", err,
+			sch.leader.CurrNode(), kt.Rid)
 		return err
 	}
 
@@ -355,8 +382,16 @@ func (sch *scheduler) watchScheduledFlow(kt *kit.Kit) error {
 	}
 
 	for _, flow := range flows {
-		if err = updateFlowState(flow.Kit, sch.backend, flow.ID, enumor.FlowScheduled, enumor.FlowRunning); err != nil {
-			logs.Errorf("update flow state failed, err: %v, rid: %s", err, flow.Kit.Rid)
+		info := backend.UpdateFlowInfo{ID: flow.ID, Source: enumor.FlowScheduled, Target: enumor.FlowRunning}
+		err = sch.backend.BatchUpdateFlowStateByCAS(flow.Kit, []backend.UpdateFlowInfo{info})
+		if err != nil {
+			// 任务流状态已被修改（如被暂停），跳过该任务流，不影响同批次的其他任务流
+			if errf.Error(err).Code == errf.RecordNotUpdate {
+				logs.Warnf("flow state has been changed, skip running it, id: %s, rid: %s", flow.ID, flow.Kit.Rid)
+				continue
+			}
+
+			logs.Errorf("update flow state failed, err: %v, id: %s, rid: %s", err, flow.ID, flow.Kit.Rid)
 			return err
 		}
 
@@ -390,12 +425,23 @@ func (sch *scheduler) executeNext(kt *kit.Kit, task *Task) error {
 	}
 
 	// 获取下次执行的任务
-	ids := tree.Root.GetNextTaskNodes(task)
+	ids := tree.GetNextTaskNodes(task)
+
+	// 任务流被暂停后，执行中的任务执行完不再下发新的任务，任务流恢复后由调度器重新解析任务流
+	source, err := sch.getFlowState(kt, task.FlowID)
+	if err != nil {
+		return err
+	}
+
 	if len(ids) == 0 {
-		state := tree.Root.ComputeState()
+		state := tree.ComputeState()
 
 		if state == enumor.FlowSuccess {
-			if err := updateFlowState(kt, sch.backend, task.FlowID, enumor.FlowRunning, state); err != nil {
+			if err = skipFailureBranch(kt, sch.backend, tree); err != nil {
+				return err
+			}
+
+			if err = updateFlowState(kt, sch.backend, task.FlowID, source, state); err != nil {
 				logs.Errorf("update flow state to %s failed, err: %v, rid: %s", state, err, kt.Rid)
 				return err
 			}
@@ -404,7 +450,7 @@ func (sch *scheduler) executeNext(kt *kit.Kit, task *Task) error {
 		}
 
 		if state == enumor.FlowFailed {
-			if err := updateFlowStateAndReason(kt, sch.backend, task.FlowID, enumor.FlowRunning, state,
+			if err = updateFlowStateAndReason(kt, sch.backend, task.FlowID, source, state,
 				ErrSomeTaskExecFailed); err != nil {
 
 				logs.Errorf("update flow state to %s failed, err: %v, rid: %s", state, err, kt.Rid)
@@ -417,10 +463,31 @@ func (sch *scheduler) executeNext(kt *kit.Kit, task *Task) error {
 		return nil
 	}
 
-	return sch.pushTasks(kt, tree.Flow, ids)
+	if source == enumor.FlowPaused {
+		logs.Infof("flow %s is paused, skip push next tasks: %v, rid: %s", task.FlowID, ids, kt.Rid)
+		return nil
+	}
+
+	return sch.pushTasks(kt, tree, ids)
+}
+
+// getFlowState 查询任务流当前状态，调度器只处理执行中和暂停中的任务流
+func (sch *scheduler) getFlowState(kt *kit.Kit, flowID string) (enumor.FlowState, error) {
+	flow, err := getFlow(kt, sch.backend, flowID)
+	if err != nil {
+		return "", err
+	}
+
+	switch flow.State {
+	case enumor.FlowRunning, enumor.FlowPaused:
+	default:
+		return "", fmt.Errorf("flow %s state %s is not running or paused", flowID, flow.State)
+	}
+
+	return flow.State, nil
 }
 
-func (sch *scheduler) pushTasks(kt *kit.Kit, flow *Flow, ids []string) error {
+func (sch *scheduler) pushTasks(kt *kit.Kit, tree *TaskTree, ids []string) error {
 
 	tasks, err := listTaskByIDs(kt, sch.backend, ids)
 	if err != nil {
@@ -430,7 +497,8 @@ func (sch *scheduler) pushTasks(kt *kit.Kit, flow *Flow, ids []string) error {
 
 	// 可执行任务推送到执行器
 	for _, one := range tasks {
-		sch.executor.Push(flow, one)
+		one.ParentsSkipped = tree.ParentsSkipped(one.ID)
+		sch.executor.Push(tree.Flow, one)
 	}
 
 	return nil
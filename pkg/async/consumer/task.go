@@ -44,6 +44,14 @@ type Task struct {
 	ExecuteKit run.ExecuteKit `json:"-"`
 	Patch      func(kt *kit.Kit, task *model.Task) error
 	Flow       *Flow
+
+	// ParentsSkipped 依赖的任务是否全部被跳过，由调度器下发任务时设置，为true时执行器跳过该任务
+	ParentsSkipped bool `json:"-"`
+
+	// releaseQuota 任务执行结束后释放占用的限流并发额度，由执行器设置
+	releaseQuota func()
+	// deferred 任务是否已因超出限额被延迟过，延迟原因只在第一次延迟时写入
+	deferred bool
 }
 
 // ValidateBeforeExec task validate before execute.
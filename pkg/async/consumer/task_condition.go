@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/tidwall/gjson"
+)
+
+// evaluateSkip 判断任务是否需要跳过，依赖的任务全部被跳过或执行条件不满足时跳过，返回跳过原因
+func evaluateSkip(bd backend.Backend, task *Task) (bool, string, error) {
+	if task.ParentsSkipped {
+		return true, "all depend on tasks are skipped", nil
+	}
+
+	cond := task.RunCondition
+	if cond == nil {
+		return false, "", nil
+	}
+
+	value, exist, err := conditionValue(bd, task)
+	if err != nil {
+		return false, "", err
+	}
+
+	if cond.Match(value, exist) {
+		return false, "", nil
+	}
+
+	return true, fmt.Sprintf("run condition not match, source: %s, action_id: %s, key: %s, op: %s, expect: %v, "+
+		"actual: %s, exist: %v", cond.Source, cond.ActionID, cond.Key, cond.Op, cond.Expect, value, exist), nil
+}
+
+// conditionValue 获取执行条件的取值，exist表示取值是否存在
+func conditionValue(bd backend.Backend, task *Task) (value string, exist bool, err error) {
+	cond := task.RunCondition
+
+	switch cond.Source {
+	case enumor.TaskConditionShareData:
+		if task.Flow == nil || task.Flow.ShareData == nil {
+			return "", false, nil
+		}
+
+		value, exist = task.Flow.ShareData.Get(cond.Key)
+		return value, exist, nil
+
+	case enumor.TaskConditionResult:
+		input := &backend.ListInput{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					tools.EqualExpression("flow_id", task.FlowID),
+					tools.EqualExpression("action_id", cond.ActionID),
+				},
+			},
+			Page: core.NewDefaultBasePage(),
+		}
+		tasks, err := bd.ListTask(task.Kit, input)
+		if err != nil {
+			return "", false, err
+		}
+
+		if len(tasks) == 0 {
+			return "", false, fmt.Errorf("condition task: %s not found in flow: %s", cond.ActionID, task.FlowID)
+		}
+
+		// 被引用的任务未执行成功时，认为取值不存在
+		if tasks[0].State != enumor.TaskSuccess || tasks[0].Result.IsEmpty() {
+			return "", false, nil
+		}
+
+		result := gjson.Get(string(tasks[0].Result), cond.Key)
+		return result.String(), result.Exists(), nil
+
+	default:
+		return "", false, fmt.Errorf("unsupported task condition source: %s", cond.Source)
+	}
+}
@@ -22,6 +22,7 @@ package consumer
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	"hcm/pkg/async/action"
 	"hcm/pkg/criteria/enumor"
@@ -34,7 +35,178 @@ const (
 // TaskTree task tree
 type TaskTree struct {
 	Root *TaskNode
-	Flow *Flow
+	// FailureRoot 失败分支任务的虚拟根节点，没有失败分支任务时为nil
+	FailureRoot *TaskNode
+	Flow        *Flow
+
+	// nodes 任务ID到任务节点的映射
+	nodes map[string]*TaskNode
+	// lock 多个调度协程会并发更新任务树中节点的状态
+	lock sync.Mutex
+}
+
+// BuildTaskTree 构建任务流执行树，正常任务与失败分支任务分别构建
+func BuildTaskTree(flow *Flow, tasks []*Task) (*TaskTree, error) {
+	normal, failure := make([]*Task, 0, len(tasks)), make([]*Task, 0)
+	for _, one := range tasks {
+		if one.OnFailure {
+			failure = append(failure, one)
+			continue
+		}
+		normal = append(normal, one)
+	}
+
+	root, err := BuildTaskRoot(normal)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &TaskTree{
+		Root:  root,
+		Flow:  flow,
+		nodes: make(map[string]*TaskNode, len(tasks)),
+	}
+	collectNodes(root, tree.nodes)
+
+	if len(failure) != 0 {
+		if tree.FailureRoot, err = BuildTaskRoot(failure); err != nil {
+			return nil, fmt.Errorf("build on failure task root failed, err: %v", err)
+		}
+		collectNodes(tree.FailureRoot, tree.nodes)
+	}
+
+	return tree, nil
+}
+
+// GetExecutableTasks 获取可执行的任务，正常任务都已结束且存在失败任务时，返回失败分支中可执行的任务。
+// 返回的任务在任务树中被标记为执行中，避免被重复下发。
+func (t *TaskTree) GetExecutableTasks() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	ids := t.Root.GetExecutableTasks()
+	if len(ids) == 0 && t.needCompensate() {
+		ids = t.FailureRoot.GetExecutableTasks()
+	}
+
+	return t.markRunning(ids)
+}
+
+// GetNextTaskNodes 更新执行完成的任务状态，并获取下一批可执行的任务，返回的任务在任务树中被标记为执行中。
+func (t *TaskTree) GetNextTaskNodes(task *Task) []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if task.OnFailure && t.FailureRoot != nil {
+		return t.markRunning(t.FailureRoot.GetNextTaskNodes(task))
+	}
+
+	ids := t.Root.GetNextTaskNodes(task)
+	if len(ids) == 0 && t.needCompensate() {
+		ids = t.FailureRoot.GetExecutableTasks()
+	}
+
+	return t.markRunning(ids)
+}
+
+// ComputeState 计算任务流状态，存在失败任务时，需要等待失败分支任务执行结束，任务流才处于失败状态。
+func (t *TaskTree) ComputeState() enumor.FlowState {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	state := t.Root.ComputeState()
+	if state != enumor.FlowFailed || t.FailureRoot == nil {
+		return state
+	}
+
+	if len(t.Root.GetExecStateTasks()) != 0 {
+		return enumor.FlowRunning
+	}
+
+	if t.FailureRoot.ComputeState() == enumor.FlowRunning {
+		return enumor.FlowRunning
+	}
+
+	return enumor.FlowFailed
+}
+
+// GetExecStateTasks 获取执行状态的任务，包括失败分支中的任务
+func (t *TaskTree) GetExecStateTasks() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	ids := t.Root.GetExecStateTasks()
+	if t.FailureRoot != nil {
+		ids = append(ids, t.FailureRoot.GetExecStateTasks()...)
+	}
+
+	return ids
+}
+
+// GetPendingFailureTasks 获取失败分支中未执行的任务
+func (t *TaskTree) GetPendingFailureTasks() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	ids := make([]string, 0)
+	if t.FailureRoot == nil {
+		return ids
+	}
+
+	nodes := make(map[string]*TaskNode)
+	collectNodes(t.FailureRoot, nodes)
+	for id, node := range nodes {
+		if node.State == enumor.TaskPending {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// ParentsSkipped 判断任务依赖的任务是否全部被跳过
+func (t *TaskTree) ParentsSkipped(taskID string) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	node, exist := t.nodes[taskID]
+	if !exist {
+		return false
+	}
+
+	return node.AllParentsSkipped()
+}
+
+// needCompensate 是否需要执行失败分支，正常任务存在失败任务且没有执行中的任务时需要执行，调用方需要持有lock
+func (t *TaskTree) needCompensate() bool {
+	if t.FailureRoot == nil {
+		return false
+	}
+
+	return t.Root.ComputeState() == enumor.FlowFailed && len(t.Root.GetExecStateTasks()) == 0
+}
+
+// markRunning 将下发执行的任务标记为执行中，调用方需要持有lock
+func (t *TaskTree) markRunning(ids []string) []string {
+	for _, id := range ids {
+		if node, exist := t.nodes[id]; exist {
+			node.State = enumor.TaskRunning
+		}
+	}
+
+	return ids
+}
+
+// collectNodes 收集根节点下的所有任务节点，不包含虚拟根节点
+func collectNodes(root *TaskNode, nodes map[string]*TaskNode) {
+	for _, child := range root.children {
+		if _, exist := nodes[child.TaskID]; exist {
+			continue
+		}
+
+		nodes[child.TaskID] = child
+		collectNodes(child, nodes)
+	}
 }
 
 // TaskNode task node
@@ -74,9 +246,24 @@ func (t *TaskNode) GetParents() []*TaskNode {
 	return t.parents
 }
 
-// CanExecuteChild can execute child
+// CanExecuteChild can execute child, 任务执行成功或被跳过后，子任务可以执行
 func (t *TaskNode) CanExecuteChild() bool {
-	return t.State == enumor.TaskSuccess
+	return t.State == enumor.TaskSuccess || t.State == enumor.TaskSkipped
+}
+
+// AllParentsSkipped 依赖的任务是否全部被跳过，全部被跳过时当前任务也需要跳过
+func (t *TaskNode) AllParentsSkipped() bool {
+	if len(t.parents) == 0 {
+		return false
+	}
+
+	for _, p := range t.parents {
+		if p.State != enumor.TaskSkipped {
+			return false
+		}
+	}
+
+	return true
 }
 
 // CanBeExecuted check whether task could be executed
@@ -120,8 +307,8 @@ func (t *TaskNode) ComputeState() (state enumor.FlowState) {
 		case enumor.TaskFailed:
 			state = enumor.FlowFailed
 			return false
-		// 如果当前节点运行成功，继续遍历当前节点子节点。
-		case enumor.TaskSuccess:
+		// 如果当前节点运行成功或被跳过，继续遍历当前节点子节点。
+		case enumor.TaskSuccess, enumor.TaskSkipped:
 			state = enumor.FlowSuccess
 			return true
 		// 如果当前节点处于其他运行中间状态，无法继续遍历当前节点子节点。
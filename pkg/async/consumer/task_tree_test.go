@@ -0,0 +1,170 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"sort"
+	"testing"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+)
+
+func newTestTask(id string, onFailure bool, dependOn ...action.ActIDType) *Task {
+	return &Task{
+		Task: model.Task{
+			ID:        id,
+			ActionID:  action.ActIDType(id),
+			DependOn:  dependOn,
+			State:     enumor.TaskPending,
+			OnFailure: onFailure,
+		},
+	}
+}
+
+func finishTask(tree *TaskTree, id string, state enumor.TaskState) []string {
+	task := newTestTask(id, tree.FailureRoot != nil && isFailureNode(tree, id))
+	task.State = state
+	ids := tree.GetNextTaskNodes(task)
+	sort.Strings(ids)
+	return ids
+}
+
+func isFailureNode(tree *TaskTree, id string) bool {
+	nodes := make(map[string]*TaskNode)
+	collectNodes(tree.FailureRoot, nodes)
+	_, exist := nodes[id]
+	return exist
+}
+
+func TestTaskTreeSkipped(t *testing.T) {
+	// 1 -> 2 -> 3, 1 -> 4, 3、4 -> 5
+	tasks := []*Task{
+		newTestTask("1", false),
+		newTestTask("2", false, "1"),
+		newTestTask("3", false, "2"),
+		newTestTask("4", false, "1"),
+		newTestTask("5", false, "3", "4"),
+	}
+	tree, err := BuildTaskTree(nil, tasks)
+	if err != nil {
+		t.Fatalf("build task tree failed, err: %v", err)
+	}
+
+	if ids := tree.GetExecutableTasks(); len(ids) != 1 || ids[0] != "1" {
+		t.Fatalf("executable tasks should be [1], but got %v", ids)
+	}
+
+	if ids := finishTask(tree, "1", enumor.TaskSuccess); len(ids) != 2 || ids[0] != "2" || ids[1] != "4" {
+		t.Fatalf("next tasks should be [2 4], but got %v", ids)
+	}
+
+	// 2 被跳过，3 依赖的任务全部被跳过
+	if ids := finishTask(tree, "2", enumor.TaskSkipped); len(ids) != 1 || ids[0] != "3" {
+		t.Fatalf("next tasks should be [3], but got %v", ids)
+	}
+	if !tree.ParentsSkipped("3") {
+		t.Fatal("task 3 parents should be all skipped")
+	}
+
+	finishTask(tree, "3", enumor.TaskSkipped)
+	if ids := finishTask(tree, "4", enumor.TaskSuccess); len(ids) != 1 || ids[0] != "5" {
+		t.Fatalf("next tasks should be [5], but got %v", ids)
+	}
+	if tree.ParentsSkipped("5") {
+		t.Fatal("task 5 parents should not be all skipped")
+	}
+
+	finishTask(tree, "5", enumor.TaskSuccess)
+	if state := tree.ComputeState(); state != enumor.FlowSuccess {
+		t.Fatalf("flow state should be success, but got %s", state)
+	}
+}
+
+func TestTaskTreeFailureBranch(t *testing.T) {
+	// 1 -> 2, 1 -> 3, 失败分支 f1 -> f2
+	tasks := []*Task{
+		newTestTask("1", false),
+		newTestTask("2", false, "1"),
+		newTestTask("3", false, "1"),
+		newTestTask("f1", true),
+		newTestTask("f2", true, "f1"),
+	}
+	tree, err := BuildTaskTree(nil, tasks)
+	if err != nil {
+		t.Fatalf("build task tree failed, err: %v", err)
+	}
+
+	tree.GetExecutableTasks()
+	finishTask(tree, "1", enumor.TaskSuccess)
+
+	// 2 失败时 3 仍在执行，不执行失败分支
+	if ids := finishTask(tree, "2", enumor.TaskFailed); len(ids) != 0 {
+		t.Fatalf("next tasks should be empty, but got %v", ids)
+	}
+	if state := tree.ComputeState(); state != enumor.FlowRunning {
+		t.Fatalf("flow state should be running, but got %s", state)
+	}
+
+	// 3 结束后开始执行失败分支
+	if ids := finishTask(tree, "3", enumor.TaskSuccess); len(ids) != 1 || ids[0] != "f1" {
+		t.Fatalf("next tasks should be [f1], but got %v", ids)
+	}
+	if state := tree.ComputeState(); state != enumor.FlowRunning {
+		t.Fatalf("flow state should be running, but got %s", state)
+	}
+
+	if ids := finishTask(tree, "f1", enumor.TaskSuccess); len(ids) != 1 || ids[0] != "f2" {
+		t.Fatalf("next tasks should be [f2], but got %v", ids)
+	}
+	finishTask(tree, "f2", enumor.TaskSuccess)
+
+	if state := tree.ComputeState(); state != enumor.FlowFailed {
+		t.Fatalf("flow state should be failed, but got %s", state)
+	}
+}
+
+func TestTaskTreeFailureBranchNotRun(t *testing.T) {
+	tasks := []*Task{
+		newTestTask("1", false),
+		newTestTask("f1", true),
+	}
+	tree, err := BuildTaskTree(nil, tasks)
+	if err != nil {
+		t.Fatalf("build task tree failed, err: %v", err)
+	}
+
+	if ids := tree.GetExecutableTasks(); len(ids) != 1 || ids[0] != "1" {
+		t.Fatalf("executable tasks should be [1], but got %v", ids)
+	}
+
+	if ids := finishTask(tree, "1", enumor.TaskSuccess); len(ids) != 0 {
+		t.Fatalf("next tasks should be empty, but got %v", ids)
+	}
+
+	if state := tree.ComputeState(); state != enumor.FlowSuccess {
+		t.Fatalf("flow state should be success, but got %s", state)
+	}
+
+	if ids := tree.GetPendingFailureTasks(); len(ids) != 1 || ids[0] != "f1" {
+		t.Fatalf("pending failure tasks should be [f1], but got %v", ids)
+	}
+}
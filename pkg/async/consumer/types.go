@@ -44,6 +44,8 @@ const (
 	ErrTaskNodeShutdown = "task node shutdown"
 	// ErrSomeTaskExecFailed 部分任务执行失败
 	ErrSomeTaskExecFailed = "some tasks failed to be executed"
+	// ErrTaskRateLimited 任务超出当前节点分得的集群限额，等待执行
+	ErrTaskRateLimited = "task exceeds rate limit quota of current node, waiting to be executed"
 
 	//  listScheduledFlowLimit 每次调度器查询分配给当前节点的任务流数量
 	listScheduledFlowLimit = 10
@@ -32,6 +32,7 @@ import (
 	"hcm/pkg/async/consumer/leader"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
 	tableasync "hcm/pkg/dal/table/async"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -45,6 +46,8 @@ WatchDog （看门狗）:
 	1. 处理超时任务
 	2. 处理处于Scheduled状态，但执行节点已经挂掉的任务流
 	3. 处理处于Running状态，但执行节点正在Shutdown或者已经挂掉的任务流
+	4. 处理处于Paused状态，但执行节点已经挂掉，仍有执行中任务的任务流
+	5. 后端需要清理历史任务流时(etcd)，删除超过保留时长的已结束任务流及其任务
 */
 type WatchDog interface {
 	compctrl.Closer
@@ -60,11 +63,14 @@ type watchDog struct {
 	taskTimeoutSec      time.Duration
 	shutdownWaitTimeSec time.Duration
 	watchIntervalSec    time.Duration
+	flowRetention       time.Duration
+	lastCleanAt         time.Time
 
 	wg      sync.WaitGroup
 	closeCh chan struct{}
 
 	runningFlowMap map[string]time.Time
+	pausedFlowMap  map[string]time.Time
 }
 
 // NewWatchDog 创建一个watchdog
@@ -76,9 +82,11 @@ func NewWatchDog(bd backend.Backend, ld leader.Leader, opt *WatchDogOption) Watc
 		taskTimeoutSec:      time.Duration(opt.TaskRunTimeoutSec) * time.Second,
 		shutdownWaitTimeSec: time.Duration(opt.ShutdownWaitTimeSec) * time.Second,
 		watchIntervalSec:    time.Duration(opt.WatchIntervalSec) * time.Second,
+		flowRetention:       time.Duration(opt.FlowRetentionHour) * time.Hour,
 		wg:                  sync.WaitGroup{},
 		closeCh:             make(chan struct{}),
 		runningFlowMap:      make(map[string]time.Time),
+		pausedFlowMap:       make(map[string]time.Time),
 	}
 }
 
@@ -90,6 +98,36 @@ func (wd *watchDog) Start() {
 	go wd.watchWrapper(wd.handleScheduledNotExistWorkerFlow)
 	wd.wg.Add(1)
 	go wd.watchWrapper(wd.handleRunningNotExistWorkerFlow)
+	wd.wg.Add(1)
+	go wd.watchWrapper(wd.handlePausedNotExistWorkerFlow)
+
+	if _, ok := wd.bd.(backend.Cleaner); ok && wd.flowRetention > 0 {
+		wd.wg.Add(1)
+		go wd.watchWrapper(wd.cleanFinishedFlow)
+	}
+}
+
+// flowCleanInterval 已结束任务流的清理间隔
+const flowCleanInterval = time.Hour
+
+// cleanFinishedFlow 定期删除超过保留时长的已结束任务流及其任务
+func (wd *watchDog) cleanFinishedFlow(kt *kit.Kit) error {
+	if time.Since(wd.lastCleanAt) < flowCleanInterval {
+		return nil
+	}
+
+	cleaner, ok := wd.bd.(backend.Cleaner)
+	if !ok {
+		return nil
+	}
+
+	if err := cleaner.DeleteFinishedFlow(kt, time.Now().Add(-wd.flowRetention)); err != nil {
+		logs.Errorf("delete finished flow failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	wd.lastCleanAt = time.Now()
+	return nil
 }
 
 // 定期处理异常任务流或任务
@@ -160,16 +198,17 @@ func (wd *watchDog) handleExpiredTasks(kt *kit.Kit) error {
 			return err
 		}
 
-		flows := []model.Flow{
-			{
-				ID:    one.FlowID,
-				State: enumor.FlowFailed,
-				Reason: &tableasync.Reason{
-					Message: ErrTaskExecTimeout,
-				},
+		// 暂停中的任务流保持暂停状态，恢复后由调度器根据任务状态更新任务流状态
+		info := backend.UpdateFlowInfo{
+			ID:     one.FlowID,
+			Source: enumor.FlowRunning,
+			Target: enumor.FlowFailed,
+			Reason: &tableasync.Reason{
+				Message: ErrTaskExecTimeout,
 			},
 		}
-		if err = wd.bd.BatchUpdateFlow(kt, flows); err != nil {
+		err = wd.bd.BatchUpdateFlowStateByCAS(kt, []backend.UpdateFlowInfo{info})
+		if err != nil && errf.Error(err).Code != errf.RecordNotUpdate {
 			logs.Errorf("update flow to failed state failed, err: %v, rid: %s", err, kt.Rid)
 			return err
 		}
@@ -217,7 +256,7 @@ func (wd *watchDog) handleScheduledNotExistWorkerFlow(kt *kit.Kit) error {
 			Worker: converter.ValToPtr(""),
 		})
 	}
-	if err = wd.bd.BatchUpdateFlow(kt, flows); err != nil {
+	if err = wd.bd.BatchUpdateFlow(kt, mds); err != nil {
 		logs.Errorf("update flows failed, err: %v, rid: %s", err, kt.Rid)
 		return err
 	}
@@ -304,6 +343,63 @@ func (wd *watchDog) handleRunningNotExistWorkerFlow(kt *kit.Kit) error {
 	return nil
 }
 
+// handlePausedNotExistWorkerFlow 处理处于Paused状态且处理Worker已经下线的Flow，将其执行中的任务回滚或者置于失败状态，
+// 否则任务流因存在执行中的任务而无法恢复。
+func (wd *watchDog) handlePausedNotExistWorkerFlow(kt *kit.Kit) error {
+
+	flows, err := wd.queryNotExistNodesFlowByState(kt, enumor.FlowPaused)
+	if err != nil {
+		return err
+	}
+
+	if len(flows) == 0 {
+		logs.V(3).Infof("handlePausedNotExistWorkerFlow not found flow, skip, rid: %s", kt.Rid)
+		return nil
+	}
+
+	ids := make([]string, 0, len(flows))
+	for _, flow := range flows {
+		// 同Running状态的Flow，需要等待上一个节点Shutdown结束后再处理
+		firstWatchTime, exist := wd.pausedFlowMap[flow.ID]
+		if !exist {
+			wd.pausedFlowMap[flow.ID] = times.ConvStdTimeNow()
+			continue
+		}
+
+		if !firstWatchTime.Before(times.ConvStdTimeNow().Add(-wd.shutdownWaitTimeSec)) {
+			continue
+		}
+
+		taskModels, err := listTaskByFlowID(kt, wd.bd, flow.ID)
+		if err != nil {
+			return err
+		}
+
+		execIDs := make([]string, 0)
+		for _, one := range taskModels {
+			if one.State == enumor.TaskRunning || one.State == enumor.TaskRollback {
+				execIDs = append(execIDs, one.ID)
+			}
+		}
+
+		if len(execIDs) != 0 {
+			if err = wd.handleRunningTasks(kt, flow, execIDs); err != nil {
+				logs.Errorf("handle paused flow in not exist worker failed, id: %s, rid: %s", flow.ID, kt.Rid)
+				return err
+			}
+			ids = append(ids, flow.ID)
+		}
+
+		delete(wd.pausedFlowMap, flow.ID)
+	}
+
+	if len(ids) != 0 {
+		logs.Infof("handlePausedNotExistWorkerFlow, count: %d, ids: %v, rid: %s", len(ids), ids, kt.Rid)
+	}
+
+	return nil
+}
+
 func (wd *watchDog) handleRunningFlow(kt *kit.Kit, flow model.Flow) error {
 	// 根据任务流ID获取对应的任务集合
 	taskModels, err := listTaskByFlowID(kt, wd.bd, flow.ID)
@@ -312,14 +408,20 @@ func (wd *watchDog) handleRunningFlow(kt *kit.Kit, flow model.Flow) error {
 	}
 
 	// 构造执行流树
-	root, err := BuildTaskRoot(taskModels)
+	tree, err := BuildTaskTree(&Flow{Flow: flow, Kit: kt}, taskModels)
 	if err != nil {
 		return err
 	}
 
 	// 如果树已经处于结束状态，则直接更新
-	state := root.ComputeState()
+	state := tree.ComputeState()
 	if state == enumor.FlowSuccess || state == enumor.FlowFailed {
+		if state == enumor.FlowSuccess {
+			if err = skipFailureBranch(kt, wd.bd, tree); err != nil {
+				return err
+			}
+		}
+
 		if err = updateFlowState(kt, wd.bd, flow.ID, enumor.FlowRunning, state); err != nil {
 			logs.Errorf("update flow state to %s failed, err: %v, rid: %s", state, err, kt.Rid)
 			return err
@@ -328,7 +430,7 @@ func (wd *watchDog) handleRunningFlow(kt *kit.Kit, flow model.Flow) error {
 		return nil
 	}
 
-	ids := root.GetExecStateTasks()
+	ids := tree.GetExecStateTasks()
 	// 如果没有处于执行中的节点，将Flow置于Pending状态，等待重新被调度
 	if len(ids) == 0 {
 		mds := []model.Flow{
@@ -386,7 +488,8 @@ func (wd *watchDog) handleRunningTasks(kt *kit.Kit, flow model.Flow, ids []strin
 			}
 		}
 
-		task.InitDep(run.NewExecuteContext(task.Kit, flow.ShareData), func(kt *kit.Kit, task *model.Task) error {
+		executeKit := run.NewExecuteContext(task.Kit, flow.ID, flow.ShareData)
+		task.InitDep(executeKit, func(kt *kit.Kit, task *model.Task) error {
 			return wd.bd.UpdateTask(kt, task)
 		}, &Flow{Flow: flow})
 
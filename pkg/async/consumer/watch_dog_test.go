@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package consumer
+
+import (
+	"testing"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/converter"
+)
+
+// fakeLeader 测试使用的主节点控制器，只有 alive 节点存活
+type fakeLeader struct{}
+
+func (fakeLeader) IsLeader() bool { return true }
+
+func (fakeLeader) AliveNodes() ([]string, error) { return []string{"alive"}, nil }
+
+func (fakeLeader) CurrNode() string { return "alive" }
+
+func setFlowWorker(t *testing.T, bd backend.Backend, flowID, worker string) {
+	flows := []model.Flow{{ID: flowID, Worker: converter.ValToPtr(worker)}}
+	if err := bd.BatchUpdateFlow(kit.New(), flows); err != nil {
+		t.Fatalf("update flow worker failed, err: %v", err)
+	}
+}
+
+func assertFlowRedispatched(t *testing.T, bd backend.Backend, flowID string) {
+	assertFlowState(t, bd, flowID, enumor.FlowPending)
+	flow, err := getFlow(kit.New(), bd, flowID)
+	if err != nil {
+		t.Fatalf("get flow failed, err: %v", err)
+	}
+	if flow.Worker == nil || len(*flow.Worker) != 0 {
+		t.Fatalf("flow %s worker should be reset, but got %v", flowID, flow.Worker)
+	}
+}
+
+func TestHandleScheduledNotExistWorkerFlow(t *testing.T) {
+	bd := backend.NewMemory()
+	wd := NewWatchDog(bd, fakeLeader{}, &WatchDogOption{WatchIntervalSec: 1, TaskRunTimeoutSec: 60,
+		ShutdownWaitTimeSec: 1}).(*watchDog)
+	tasks := []model.Task{{ActionID: "1", ActionName: enumor.ActionStartCvm}}
+
+	offline, _ := newTestFlow(t, bd, enumor.FlowScheduled, tasks, []enumor.TaskState{enumor.TaskPending})
+	setFlowWorker(t, bd, offline, "offline")
+	alive, _ := newTestFlow(t, bd, enumor.FlowScheduled, tasks, []enumor.TaskState{enumor.TaskPending})
+	setFlowWorker(t, bd, alive, "alive")
+
+	if err := wd.handleScheduledNotExistWorkerFlow(kit.New()); err != nil {
+		t.Fatalf("handle scheduled flow failed, err: %v", err)
+	}
+
+	assertFlowRedispatched(t, bd, offline)
+	assertFlowState(t, bd, alive, enumor.FlowScheduled)
+}
+
+func TestHandleRunningNotExistWorkerFlow(t *testing.T) {
+	bd := backend.NewMemory()
+	wd := NewWatchDog(bd, fakeLeader{}, &WatchDogOption{WatchIntervalSec: 1, TaskRunTimeoutSec: 60,
+		ShutdownWaitTimeSec: 0}).(*watchDog)
+	tasks := []model.Task{
+		{ActionID: "1", ActionName: enumor.ActionStartCvm},
+		{ActionID: "2", ActionName: enumor.ActionStartCvm, DependOn: []action.ActIDType{"1"}},
+	}
+
+	// 节点下线前任务2因超出节点限额被延迟，仍处于Pending状态
+	flowID, _ := newTestFlow(t, bd, enumor.FlowRunning, tasks,
+		[]enumor.TaskState{enumor.TaskSuccess, enumor.TaskPending})
+	setFlowWorker(t, bd, flowID, "offline")
+
+	// 第一次发现时等待节点彻底关闭，第二次才处理
+	kt := kit.New()
+	if err := wd.handleRunningNotExistWorkerFlow(kt); err != nil {
+		t.Fatalf("handle running flow failed, err: %v", err)
+	}
+	assertFlowState(t, bd, flowID, enumor.FlowRunning)
+
+	if err := wd.handleRunningNotExistWorkerFlow(kt); err != nil {
+		t.Fatalf("handle running flow failed, err: %v", err)
+	}
+	assertFlowRedispatched(t, bd, flowID)
+}
@@ -0,0 +1,266 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package consumer
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+const (
+	// webhookBatchSize 单次回调请求投递的最大事件数
+	webhookBatchSize = 100
+	// webhookMaxBatchPerRound 每轮对单个回调的最大投递次数，避免积压过多的回调阻塞其他回调
+	webhookMaxBatchPerRound = 10
+	// webhookRequestTimeout 回调请求超时时间
+	webhookRequestTimeout = 10 * time.Second
+	// eventCleanInterval 过期事件清理间隔
+	eventCleanInterval = time.Hour
+)
+
+// NewWebhookNotifier new webhook notifier.
+func NewWebhookNotifier(bd backend.Backend, opt *WebhookNotifierOption) *WebhookNotifier {
+	return &WebhookNotifier{
+		watchIntervalSec: time.Duration(opt.WatchIntervalSec) * time.Second,
+		eventRetention:   time.Duration(opt.EventRetentionHour) * time.Hour,
+		bd:               bd,
+		cli:              &http.Client{Timeout: webhookRequestTimeout},
+		closeCh:          make(chan struct{}),
+		wg:               new(sync.WaitGroup),
+	}
+}
+
+// WebhookNotifier 任务流事件回调通知器，负责将任务流事件按顺序投递到启用的回调地址，并清理过期的事件。
+// 投递进度记录在回调中，投递失败时下一轮从失败的事件开始重新投递，保证事件至少投递一次。
+type WebhookNotifier struct {
+	watchIntervalSec time.Duration
+	eventRetention   time.Duration
+
+	bd  backend.Backend
+	cli *http.Client
+
+	lastCleanAt time.Time
+
+	wg      *sync.WaitGroup
+	closeCh chan struct{}
+}
+
+// Start webhook notifier.
+func (n *WebhookNotifier) Start() {
+	n.wg.Add(1)
+	go n.WatchEvent()
+}
+
+// WatchEvent 监听新产生的任务流事件，并投递到回调地址。
+func (n *WebhookNotifier) WatchEvent() {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case <-n.closeCh:
+			return
+		default:
+		}
+
+		kt := NewKit()
+		if err := n.Do(kt); err != nil {
+			logs.Errorf("%s: webhook notifier do failed, err: %v, rid: %s", constant.AsyncTaskWarnSign, err, kt.Rid)
+		}
+
+		n.cleanExpiredEvent(kt)
+
+		time.Sleep(n.watchIntervalSec)
+	}
+}
+
+// Do 查询处于启用状态的回调，并逐个投递新产生的事件。
+func (n *WebhookNotifier) Do(kt *kit.Kit) error {
+	input := &backend.ListInput{
+		Filter: tools.EqualExpression("state", enumor.FlowWebhookEnabled),
+		Page:   core.NewDefaultBasePage(),
+	}
+	webhooks, err := n.bd.ListWebhook(kt, input)
+	if err != nil {
+		logs.Errorf("list flow webhook failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	for _, one := range webhooks {
+		if err = n.deliver(kt, one); err != nil {
+			logs.Errorf("deliver flow event to webhook failed, err: %v, id: %s, rid: %s", err, one.ID, kt.Rid)
+		}
+	}
+
+	return nil
+}
+
+// deliver 按事件产生的顺序分批投递事件，每批投递成功后推进回调的投递进度。
+func (n *WebhookNotifier) deliver(kt *kit.Kit, webhook model.Webhook) error {
+	for i := 0; i < webhookMaxBatchPerRound; i++ {
+		opt := &backend.EventCursorOption{
+			Cursor:    webhook.LastEventID,
+			FlowNames: webhook.FlowNames,
+			Types:     webhook.EventTypes,
+			Limit:     webhookBatchSize,
+		}
+		input, err := opt.ListInput()
+		if err != nil {
+			return err
+		}
+
+		events, err := n.bd.ListEvent(kt, input)
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		update := &model.Webhook{ID: webhook.ID, Reason: new(tableasync.Reason)}
+		if err = n.post(kt, webhook, events); err != nil {
+			update.Reason.Message = err.Error()
+			if updateErr := n.bd.UpdateWebhook(kt, update); updateErr != nil {
+				logs.Errorf("update flow webhook reason failed, err: %v, id: %s, rid: %s", updateErr, webhook.ID,
+					kt.Rid)
+			}
+			return err
+		}
+
+		webhook.LastEventID = events[len(events)-1].ID
+		update.LastEventID = webhook.LastEventID
+		if err = n.bd.UpdateWebhook(kt, update); err != nil {
+			return err
+		}
+
+		if len(events) < webhookBatchSize {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// post 将事件发送到回调地址，回调地址返回2xx状态码表示投递成功。
+func (n *WebhookNotifier) post(kt *kit.Kit, webhook model.Webhook, events []model.Event) error {
+	payload := &coreasync.FlowWebhookPayload{
+		WebhookID: webhook.ID,
+		Events:    make([]coreasync.AsyncFlowEvent, 0, len(events)),
+	}
+	for _, one := range events {
+		payload.Events = append(payload.Events, convCoreEvent(one))
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(coreasync.FlowWebhookIDHeader, webhook.ID)
+	if len(webhook.Secret) != 0 {
+		req.Header.Set(coreasync.FlowWebhookSignatureHeader, signWebhookBody(webhook.Secret, body))
+	}
+
+	resp, err := n.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// 读取完响应体，以便复用连接
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responds with unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// cleanExpiredEvent 定期清理超过保留时长的事件
+func (n *WebhookNotifier) cleanExpiredEvent(kt *kit.Kit) {
+	if time.Since(n.lastCleanAt) < eventCleanInterval {
+		return
+	}
+
+	if err := n.bd.DeleteEvent(kt, time.Now().Add(-n.eventRetention)); err != nil {
+		logs.Errorf("delete expired flow event failed, err: %v, rid: %s", err, kt.Rid)
+		return
+	}
+
+	n.lastCleanAt = time.Now()
+}
+
+// Close webhook notifier.
+func (n *WebhookNotifier) Close() {
+
+	logs.Infof("webhook notifier receive close cmd, start to close")
+
+	close(n.closeCh)
+	n.wg.Wait()
+
+	logs.Infof("webhook notifier close success")
+
+}
+
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func convCoreEvent(event model.Event) coreasync.AsyncFlowEvent {
+	return coreasync.AsyncFlowEvent{
+		ID:        event.ID,
+		Type:      event.Type,
+		FlowID:    event.FlowID,
+		FlowName:  event.FlowName,
+		TaskID:    event.TaskID,
+		ActionID:  event.ActionID,
+		Source:    event.Source,
+		Target:    event.Target,
+		Reason:    event.Reason,
+		Creator:   event.Creator,
+		CreatedAt: event.CreatedAt,
+	}
+}
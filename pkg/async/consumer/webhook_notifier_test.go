@@ -0,0 +1,195 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+package consumer
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/async/backend"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+)
+
+// fakeWebhookBackend 在内存后端的基础上保存回调，内存后端不支持创建回调
+type fakeWebhookBackend struct {
+	backend.Backend
+
+	lock     sync.Mutex
+	webhooks []*model.Webhook
+}
+
+func (bd *fakeWebhookBackend) ListWebhook(_ *kit.Kit, _ *backend.ListInput) ([]model.Webhook, error) {
+	bd.lock.Lock()
+	defer bd.lock.Unlock()
+
+	result := make([]model.Webhook, 0, len(bd.webhooks))
+	for _, one := range bd.webhooks {
+		if one.State == enumor.FlowWebhookEnabled {
+			result = append(result, *one)
+		}
+	}
+	return result, nil
+}
+
+func (bd *fakeWebhookBackend) UpdateWebhook(_ *kit.Kit, webhook *model.Webhook) error {
+	bd.lock.Lock()
+	defer bd.lock.Unlock()
+
+	for _, one := range bd.webhooks {
+		if one.ID != webhook.ID {
+			continue
+		}
+		if len(webhook.LastEventID) != 0 {
+			one.LastEventID = webhook.LastEventID
+		}
+		if webhook.Reason != nil {
+			one.Reason = webhook.Reason
+		}
+	}
+	return nil
+}
+
+func (bd *fakeWebhookBackend) get(id string) model.Webhook {
+	bd.lock.Lock()
+	defer bd.lock.Unlock()
+
+	for _, one := range bd.webhooks {
+		if one.ID == id {
+			return *one
+		}
+	}
+	return model.Webhook{}
+}
+
+// webhookReceiver 回调地址，校验签名并记录收到的事件，failed 为true时返回500
+type webhookReceiver struct {
+	t      *testing.T
+	lock   sync.Mutex
+	failed bool
+	posts  int
+	events []coreasync.AsyncFlowEvent
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		r.t.Errorf("read webhook body failed, err: %v", err)
+	}
+	if sign := req.Header.Get(coreasync.FlowWebhookSignatureHeader); sign != signWebhookBody("secret", body) {
+		r.t.Errorf("webhook signature %s is invalid", sign)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.posts++
+	if r.failed {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	payload := new(coreasync.FlowWebhookPayload)
+	if err = json.Unmarshal(body, payload); err != nil {
+		r.t.Errorf("unmarshal webhook payload failed, err: %v", err)
+	}
+	r.events = append(r.events, payload.Events...)
+}
+
+func (r *webhookReceiver) setFailed(failed bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.failed = failed
+}
+
+func (r *webhookReceiver) result() (int, []coreasync.AsyncFlowEvent) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.posts, r.events
+}
+
+func TestWebhookNotifierDeliverAndRetry(t *testing.T) {
+	receiver := &webhookReceiver{t: t, failed: true}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	bd := &fakeWebhookBackend{
+		Backend: backend.NewMemory(),
+		webhooks: []*model.Webhook{
+			{ID: "1", URL: server.URL, Secret: "secret", State: enumor.FlowWebhookEnabled},
+			// 只关注其他任务流的回调不会收到事件
+			{ID: "2", URL: server.URL, Secret: "secret", FlowNames: []string{"other"},
+				State: enumor.FlowWebhookEnabled},
+			{ID: "3", URL: server.URL, Secret: "secret", State: enumor.FlowWebhookDisabled},
+		},
+	}
+	tasks := []model.Task{{ActionID: "1", ActionName: enumor.ActionStartCvm}}
+	newTestFlow(t, bd, enumor.FlowRunning, tasks, []enumor.TaskState{enumor.TaskSuccess})
+
+	kt := kit.New()
+	events, err := bd.ListEvent(kt, &backend.ListInput{Page: core.NewDefaultBasePage()})
+	if err != nil || len(events) == 0 {
+		t.Fatalf("list event failed, err: %v, events: %d", err, len(events))
+	}
+
+	notifier := NewWebhookNotifier(bd, &WebhookNotifierOption{WatchIntervalSec: 1, EventRetentionHour: 1})
+
+	// 投递失败时不推进投递进度，并记录失败原因
+	if err = notifier.Do(kt); err != nil {
+		t.Fatalf("notifier do failed, err: %v", err)
+	}
+	webhook := bd.get("1")
+	if len(webhook.LastEventID) != 0 || webhook.Reason == nil || !strings.Contains(webhook.Reason.Message, "500") {
+		t.Fatalf("failed delivery should keep progress and record reason, got: %+v", webhook)
+	}
+
+	// 下一轮从失败的事件开始重新投递，成功后推进投递进度并清空失败原因
+	receiver.setFailed(false)
+	if err = notifier.Do(kt); err != nil {
+		t.Fatalf("notifier do failed, err: %v", err)
+	}
+	webhook = bd.get("1")
+	if webhook.LastEventID != events[len(events)-1].ID || webhook.Reason == nil || len(webhook.Reason.Message) != 0 {
+		t.Fatalf("retry delivery should update progress and clear reason, got: %+v", webhook)
+	}
+
+	posts, delivered := receiver.result()
+	if posts != 2 || len(delivered) != len(events) || delivered[0].ID != events[0].ID {
+		t.Fatalf("webhook should receive all %d events after 2 posts, got %d events in %d posts", len(events),
+			len(delivered), posts)
+	}
+
+	// 没有新事件时不再投递
+	if err = notifier.Do(kt); err != nil {
+		t.Fatalf("notifier do failed, err: %v", err)
+	}
+	if posts, _ = receiver.result(); posts != 2 {
+		t.Fatalf("webhook should not be posted without new events, posts: %d", posts)
+	}
+	if len(bd.get("2").LastEventID) != 0 || len(bd.get("3").LastEventID) != 0 {
+		t.Fatal("webhook of other flows or disabled webhook should not receive events")
+	}
+}
@@ -40,7 +40,10 @@ func (p *producer) AddCustomFlow(kt *kit.Kit, opt *AddCustomFlowOption) (id stri
 		return "", err
 	}
 
-	flow := buildCustomFlow(opt)
+	flow, err := buildCustomFlow(kt, opt)
+	if err != nil {
+		return "", err
+	}
 
 	id, err = p.backend.CreateFlow(kt, flow)
 	if err != nil {
@@ -68,8 +71,8 @@ func validateCustomFlowParam(kt *kit.Kit, opt *AddCustomFlowOption) error {
 			return fmt.Errorf("action: %s not exist", task.ActionName)
 		}
 
-		// 参数校验
-		if !task.Params.IsEmpty() {
+		// 参数校验，扇出任务在扇出后校验
+		if !task.Params.IsEmpty() && len(task.ForEach) == 0 {
 			paramAct, ok := act.(action.ParameterAction)
 			if !ok {
 				return fmt.Errorf("action: %s need params, but not impl ParameterAction", task.ActionName)
@@ -99,10 +102,27 @@ func validateCustomFlowParam(kt *kit.Kit, opt *AddCustomFlowOption) error {
 		}
 	}
 
-	return nil
+	// 执行条件、扇出、失败分支校验
+	tpls := make([]action.TaskTemplate, 0, len(opt.Tasks))
+	for _, one := range opt.Tasks {
+		tpl := action.TaskTemplate{
+			ActionID:     one.ActionID,
+			ActionName:   one.ActionName,
+			DependOn:     one.DependOn,
+			RunCondition: one.RunCondition,
+			ForEach:      one.ForEach,
+			OnFailure:    one.OnFailure,
+		}
+		if !one.Params.IsEmpty() {
+			tpl.Params = &action.Params{Type: one.Params}
+		}
+		tpls = append(tpls, tpl)
+	}
+
+	return action.ValidateTaskBranch(tpls)
 }
 
-func buildCustomFlow(opt *AddCustomFlowOption) *model.Flow {
+func buildCustomFlow(kt *kit.Kit, opt *AddCustomFlowOption) (*model.Flow, error) {
 	if opt.ShareData == nil {
 		opt.ShareData = new(tableasync.ShareData)
 	}
@@ -114,20 +134,34 @@ func buildCustomFlow(opt *AddCustomFlowOption) *model.Flow {
 		Tasks:     make([]model.Task, 0, len(opt.Tasks)),
 	}
 
+	forEach := make(map[action.ActIDType]string)
 	for _, one := range opt.Tasks {
 		if one.Retry == nil {
 			one.Retry = new(tableasync.Retry)
 		}
 
+		if len(one.ForEach) != 0 {
+			forEach[one.ActionID] = one.ForEach
+		}
+
 		flow.Tasks = append(flow.Tasks, model.Task{
-			FlowName:   opt.Name,
-			ActionID:   one.ActionID,
-			ActionName: one.ActionName,
-			Params:     one.Params,
-			Retry:      one.Retry,
-			DependOn:   one.DependOn,
+			FlowName:     opt.Name,
+			ActionID:     one.ActionID,
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			Retry:        one.Retry,
+			DependOn:     one.DependOn,
+			RunCondition: one.RunCondition,
+			OnFailure:    one.OnFailure,
+			RateLimitKey: one.RateLimitKey,
 		})
 	}
 
-	return flow
+	tasks, err := fanOutTasks(kt, flow.Tasks, forEach)
+	if err != nil {
+		return nil, err
+	}
+	flow.Tasks = tasks
+
+	return flow, nil
 }
@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package producer
+
+import (
+	"time"
+
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/criteria/errf"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/cron"
+)
+
+// AddScheduledFlow add scheduled flow, task-server leader will create flow by template when schedule is due.
+func (p *producer) AddScheduledFlow(kt *kit.Kit, opt *AddScheduledFlowOption) (id string, err error) {
+	if err = opt.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 提前校验模版参数，避免到期后才发现任务流无法创建
+	if _, err = BuildTemplateFlow(kt, opt.TemplateFlowOption()); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	var nextRunAt time.Time
+	if len(opt.Cron) != 0 {
+		sch, err := cron.Parse(opt.Cron)
+		if err != nil {
+			return "", errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		if nextRunAt, err = sch.Next(time.Now()); err != nil {
+			return "", errf.NewFromErr(errf.InvalidParameter, err)
+		}
+	} else {
+		nextRunAt = *opt.RunAt
+	}
+
+	tasks := make(tableasync.ScheduleTasks, 0, len(opt.Tasks))
+	for _, one := range opt.Tasks {
+		tasks = append(tasks, tableasync.ScheduleTask{
+			ActionID:     string(one.ActionID),
+			Params:       one.Params,
+			RateLimitKey: one.RateLimitKey,
+		})
+	}
+
+	schedule := &model.Schedule{
+		Name:      opt.Name,
+		FlowName:  opt.FlowName,
+		Memo:      opt.Memo,
+		Tasks:     tasks,
+		Cron:      opt.Cron,
+		NextRunAt: nextRunAt,
+	}
+	id, err = p.backend.CreateSchedule(kt, schedule)
+	if err != nil {
+		logs.Errorf("create flow schedule failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	return id, nil
+}
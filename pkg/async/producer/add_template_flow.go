@@ -32,22 +32,11 @@ import (
 
 // AddTemplateFlow add template flow
 func (p *producer) AddTemplateFlow(kt *kit.Kit, opt *AddTemplateFlowOption) (id string, err error) {
-	if err = opt.Validate(); err != nil {
-		return "", err
-	}
-
-	tpl, exist := action.GetTpl(opt.Name)
-	if !exist {
-		return "", fmt.Errorf("flow tempalte: %s not found", opt.Name)
-	}
-
-	if err = validateTplUseParam(kt, tpl, opt); err != nil {
-		logs.Errorf("validate flow template use param failed, err: %v, rid: %s", err, kt.Rid)
+	flow, err := BuildTemplateFlow(kt, opt)
+	if err != nil {
 		return "", err
 	}
 
-	flow := buildFlow(tpl, opt)
-
 	id, err = p.backend.CreateFlow(kt, flow)
 	if err != nil {
 		logs.Errorf("create flow failed, err: %v, rid: %s", err, kt.Rid)
@@ -57,7 +46,26 @@ func (p *producer) AddTemplateFlow(kt *kit.Kit, opt *AddTemplateFlowOption) (id
 	return id, nil
 }
 
-func buildFlow(tpl action.FlowTemplate, opt *AddTemplateFlowOption) *model.Flow {
+// BuildTemplateFlow 校验模版任务流参数，并按照任务流模版构建任务流
+func BuildTemplateFlow(kt *kit.Kit, opt *AddTemplateFlowOption) (*model.Flow, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	tpl, exist := action.GetTpl(opt.Name)
+	if !exist {
+		return nil, fmt.Errorf("flow tempalte: %s not found", opt.Name)
+	}
+
+	if err := validateTplUseParam(kt, tpl, opt); err != nil {
+		logs.Errorf("validate flow template use param failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	return buildFlow(kt, tpl, opt)
+}
+
+func buildFlow(kt *kit.Kit, tpl action.FlowTemplate, opt *AddTemplateFlowOption) (*model.Flow, error) {
 	flow := &model.Flow{
 		Name:      tpl.Name,
 		ShareData: tpl.ShareData,
@@ -65,27 +73,41 @@ func buildFlow(tpl action.FlowTemplate, opt *AddTemplateFlowOption) *model.Flow
 		Tasks:     make([]model.Task, 0, len(tpl.Tasks)),
 	}
 
-	m := make(map[action.ActIDType]types.JsonField, len(opt.Tasks))
+	m := make(map[action.ActIDType]TemplateFlowTask, len(opt.Tasks))
 	for _, one := range opt.Tasks {
-		m[one.ActionID] = one.Params
+		m[one.ActionID] = one
 	}
 
+	forEach := make(map[action.ActIDType]string)
 	for _, one := range tpl.Tasks {
 		if one.Retry == nil {
 			one.Retry = new(tableasync.Retry)
 		}
 
+		if len(one.ForEach) != 0 {
+			forEach[one.ActionID] = one.ForEach
+		}
+
 		flow.Tasks = append(flow.Tasks, model.Task{
-			FlowName:   tpl.Name,
-			ActionID:   one.ActionID,
-			ActionName: one.ActionName,
-			Params:     m[one.ActionID],
-			Retry:      one.Retry,
-			DependOn:   one.DependOn,
+			FlowName:     tpl.Name,
+			ActionID:     one.ActionID,
+			ActionName:   one.ActionName,
+			Params:       m[one.ActionID].Params,
+			Retry:        one.Retry,
+			DependOn:     one.DependOn,
+			RunCondition: one.RunCondition,
+			OnFailure:    one.OnFailure,
+			RateLimitKey: m[one.ActionID].RateLimitKey,
 		})
 	}
 
-	return flow
+	tasks, err := fanOutTasks(kt, flow.Tasks, forEach)
+	if err != nil {
+		return nil, err
+	}
+	flow.Tasks = tasks
+
+	return flow, nil
 }
 
 // validateTplUseParam 校验任务流执行动作所需参数满足要求
@@ -105,8 +127,8 @@ func validateTplUseParam(kt *kit.Kit, template action.FlowTemplate, opt *AddTemp
 			return fmt.Errorf("action: %s not exist", task.ActionName)
 		}
 
-		// Task 参数校验
-		if task.Params != nil && task.Params.Type != nil {
+		// Task 参数校验，扇出任务在扇出后校验
+		if task.Params != nil && task.Params.Type != nil && len(task.ForEach) == 0 {
 			fields, exist := m[task.ActionID]
 			if !exist {
 				return fmt.Errorf("action: %s need params", task.ActionName)
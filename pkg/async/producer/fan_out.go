@@ -0,0 +1,159 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package producer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/backend/model"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// fanOutTasks 按照扇出参数将任务扇出为多个并行任务，扇出任务的ActionID为 原ActionID-数组下标，
+// 依赖原任务的任务改为依赖所有扇出的任务。forEach为ActionID到扇出参数字段名的映射。
+func fanOutTasks(kt *kit.Kit, tasks []model.Task, forEach map[action.ActIDType]string) ([]model.Task, error) {
+	if len(forEach) == 0 {
+		return tasks, nil
+	}
+
+	exist := make(map[action.ActIDType]bool, len(tasks))
+	for _, one := range tasks {
+		exist[one.ActionID] = true
+	}
+
+	expanded := make(map[action.ActIDType][]action.ActIDType)
+	result := make([]model.Task, 0, len(tasks))
+	for _, one := range tasks {
+		field, ok := forEach[one.ActionID]
+		if !ok {
+			result = append(result, one)
+			continue
+		}
+
+		params, err := fanOutParams(one.Params, field)
+		if err != nil {
+			return nil, fmt.Errorf("task: %s fan out failed, err: %v", one.ActionID, err)
+		}
+
+		ids := make([]action.ActIDType, 0, len(params))
+		for idx, param := range params {
+			id := action.ActIDType(fmt.Sprintf("%s-%d", one.ActionID, idx))
+			if exist[id] {
+				return nil, fmt.Errorf("task: %s fan out action_id: %s conflict with other task", one.ActionID, id)
+			}
+
+			if err = validateFanOutParam(kt, one, param); err != nil {
+				return nil, err
+			}
+
+			task := one
+			task.ActionID = id
+			task.Params = param
+			result = append(result, task)
+			ids = append(ids, id)
+		}
+		expanded[one.ActionID] = ids
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("flow has no task after fan out")
+	}
+
+	// 依赖扇出任务的任务，改为依赖所有扇出的任务
+	for idx := range result {
+		if len(result[idx].DependOn) == 0 {
+			continue
+		}
+
+		dependOn := make([]action.ActIDType, 0, len(result[idx].DependOn))
+		for _, id := range result[idx].DependOn {
+			ids, ok := expanded[id]
+			if !ok {
+				dependOn = append(dependOn, id)
+				continue
+			}
+			dependOn = append(dependOn, ids...)
+		}
+		result[idx].DependOn = dependOn
+	}
+
+	return result, nil
+}
+
+// fanOutParams 将请求参数中的数组字段按元素拆分为多个请求参数
+func fanOutParams(params types.JsonField, field string) ([]types.JsonField, error) {
+	if params.IsEmpty() {
+		return nil, errors.New("params is required")
+	}
+
+	obj := make(map[string]json.RawMessage)
+	if err := json.Unmarshal([]byte(params), &obj); err != nil {
+		return nil, fmt.Errorf("params should be an object, err: %v", err)
+	}
+
+	raw, exist := obj[field]
+	if !exist {
+		return nil, fmt.Errorf("params field %s not exist", field)
+	}
+
+	items := make([]json.RawMessage, 0)
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("params field %s should be an array, err: %v", field, err)
+	}
+
+	result := make([]types.JsonField, 0, len(items))
+	for _, item := range items {
+		obj[field] = item
+		one, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, types.JsonField(one))
+	}
+
+	return result, nil
+}
+
+// validateFanOutParam 校验扇出后的请求参数可以被Action解析
+func validateFanOutParam(kt *kit.Kit, task model.Task, param types.JsonField) error {
+	act, exist := action.GetAction(task.ActionName)
+	if !exist {
+		return fmt.Errorf("action: %s not exist", task.ActionName)
+	}
+
+	paramAct, ok := act.(action.ParameterAction)
+	if !ok {
+		return fmt.Errorf("action: %s need params, but not impl ParameterAction", task.ActionName)
+	}
+
+	params := paramAct.ParameterNew()
+	if err := action.Decode(param, params); err != nil {
+		logs.Errorf("action: %s can not decode fan out params, err: %v, field: %s, type: %T, rid: %s",
+			task.ActionName, err, param, params, kt.Rid)
+		return fmt.Errorf("action: %s can not decode fan out param, err: %v", task.ActionName, err)
+	}
+
+	return nil
+}
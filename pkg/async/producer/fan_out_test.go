@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package producer
+
+import (
+	"testing"
+
+	"hcm/pkg/dal/table/types"
+)
+
+func TestFanOutParams(t *testing.T) {
+	params := types.JsonField(`{"region":"ap-guangzhou","ids":["a","b"]}`)
+	result, err := fanOutParams(params, "ids")
+	if err != nil {
+		t.Fatalf("fan out params failed, err: %v", err)
+	}
+
+	expects := []string{`{"ids":"a","region":"ap-guangzhou"}`, `{"ids":"b","region":"ap-guangzhou"}`}
+	if len(result) != len(expects) {
+		t.Fatalf("fan out params count should be %d, but got %d", len(expects), len(result))
+	}
+	for idx := range expects {
+		if string(result[idx]) != expects[idx] {
+			t.Fatalf("fan out params[%d] should be %s, but got %s", idx, expects[idx], result[idx])
+		}
+	}
+
+	if _, err = fanOutParams(params, "region"); err == nil {
+		t.Fatal("fan out params by not array field should be failed")
+	}
+
+	if _, err = fanOutParams(params, "names"); err == nil {
+		t.Fatal("fan out params by not exist field should be failed")
+	}
+}
@@ -33,6 +33,7 @@ import (
 type Producer interface {
 	AddTemplateFlow(kt *kit.Kit, opt *AddTemplateFlowOption) (id string, err error)
 	AddCustomFlow(kt *kit.Kit, opt *AddCustomFlowOption) (id string, err error)
+	AddScheduledFlow(kt *kit.Kit, opt *AddScheduledFlowOption) (id string, err error)
 }
 
 var _ Producer = new(producer)
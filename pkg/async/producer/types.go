@@ -21,6 +21,7 @@ package producer
 
 import (
 	"errors"
+	"time"
 
 	"hcm/pkg/async/action"
 	"hcm/pkg/criteria/enumor"
@@ -65,11 +66,70 @@ type TemplateFlowTask struct {
 	ActionID action.ActIDType `json:"action_id" validate:"required"`
 	// Params 任务执行请求参数
 	Params types.JsonField `json:"params" validate:"required"`
+	// RateLimitKey 任务限流键，执行器按限流键对任务进行限流，超出限额的任务延迟执行，为空表示不限流。
+	RateLimitKey *tableasync.RateLimitKey `json:"rate_limit_key" validate:"omitempty"`
 }
 
 // Validate TemplateFlowTask
 func (task *TemplateFlowTask) Validate() error {
-	return validator.Validate.Struct(task)
+	if err := validator.Validate.Struct(task); err != nil {
+		return err
+	}
+
+	if task.RateLimitKey != nil {
+		if err := task.RateLimitKey.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddScheduledFlowOption define add scheduled flow option.
+type AddScheduledFlowOption struct {
+	// Name 定时计划名称
+	Name string `json:"name" validate:"required,lte=255"`
+	// FlowName 任务流模版名称
+	FlowName enumor.FlowName `json:"flow_name" validate:"required"`
+	// Memo 备注
+	Memo string `json:"memo" validate:"omitempty,lte=64"`
+	// Tasks 任务私有化参数设置
+	Tasks []TemplateFlowTask `json:"tasks" validate:"omitempty"`
+	// Cron 周期执行的5段式cron表达式（分 时 日 月 周），与 RunAt 二选一
+	Cron string `json:"cron" validate:"omitempty,lte=64"`
+	// RunAt 单次执行的时间，与 Cron 二选一
+	RunAt *time.Time `json:"run_at" validate:"omitempty"`
+}
+
+// Validate AddScheduledFlowOption
+func (opt *AddScheduledFlowOption) Validate() error {
+
+	if err := validator.Validate.Struct(opt); err != nil {
+		return err
+	}
+
+	if len(opt.Cron) == 0 && opt.RunAt == nil {
+		return errors.New("one of cron and run_at is required")
+	}
+
+	if len(opt.Cron) != 0 && opt.RunAt != nil {
+		return errors.New("cron and run_at can not be set at the same time")
+	}
+
+	if opt.RunAt != nil && !opt.RunAt.After(time.Now()) {
+		return errors.New("run_at should be later than now")
+	}
+
+	return opt.TemplateFlowOption().Validate()
+}
+
+// TemplateFlowOption 转换为定时计划到期后创建任务流使用的模版任务流参数
+func (opt *AddScheduledFlowOption) TemplateFlowOption() *AddTemplateFlowOption {
+	return &AddTemplateFlowOption{
+		Name:  opt.FlowName,
+		Memo:  opt.Memo,
+		Tasks: opt.Tasks,
+	}
 }
 
 // AddCustomFlowOption define add custom flow option.
@@ -116,6 +176,14 @@ type CustomFlowTask struct {
 	Params types.JsonField `json:"params" validate:"omitempty"`
 	// Retry 任务运行重试相关配置参数，如果不设置，默认不允许进行重试。
 	Retry *tableasync.Retry `json:"retry" validate:"omitempty"`
+	// RunCondition 任务执行条件，条件不满足时任务被跳过，为空表示无条件执行。
+	RunCondition *tableasync.TaskCondition `json:"run_condition" validate:"omitempty"`
+	// ForEach 扇出参数，值为请求参数中的数组字段名，按数组元素将任务扇出为多个并行执行的任务。
+	ForEach string `json:"for_each" validate:"omitempty"`
+	// OnFailure 是否为失败分支任务，只在任务流存在失败任务且其他任务都结束后执行。
+	OnFailure bool `json:"on_failure" validate:"omitempty"`
+	// RateLimitKey 任务限流键，执行器按限流键对任务进行限流，超出限额的任务延迟执行，为空表示不限流。
+	RateLimitKey *tableasync.RateLimitKey `json:"rate_limit_key" validate:"omitempty"`
 }
 
 // Validate CustomFlowTask
@@ -128,5 +196,11 @@ func (task *CustomFlowTask) Validate() error {
 		return err
 	}
 
+	if task.RateLimitKey != nil {
+		if err := task.RateLimitKey.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
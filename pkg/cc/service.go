@@ -119,6 +119,12 @@ type CloudServerSetting struct {
 	CloudResource  CloudResource  `yaml:"cloudResource"`
 	Recycle        Recycle        `yaml:"recycle"`
 	BillConfig     BillConfig     `yaml:"billConfig"`
+	BillIngest     BillIngest     `yaml:"billIngest"`
+	Budget         Budget         `yaml:"budget"`
+	SnapshotPolicy SnapshotPolicy `yaml:"snapshotPolicy"`
+	Drift          Drift          `yaml:"drift"`
+	Lease          Lease          `yaml:"lease"`
+	Approval       Approval       `yaml:"approval"`
 	Itsm           ApiGateway     `yaml:"itsm"`
 	CloudSelection CloudSelection `yaml:"cloudSelection"`
 }
@@ -133,6 +139,13 @@ func (s *CloudServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.CloudResource.trySetDefault()
+	s.BillIngest.trySetDefault()
+	s.Budget.trySetDefault()
+	s.SnapshotPolicy.trySetDefault()
+	s.Drift.trySetDefault()
+	s.Lease.trySetDefault()
+	s.Approval.trySetDefault()
 
 	return
 }
@@ -167,10 +180,29 @@ func (s CloudServerSetting) Validate() error {
 		return err
 	}
 
-	if err := s.Itsm.validate(); err != nil {
+	if err := s.BillIngest.validate(); err != nil {
 		return err
 	}
 
+	if err := s.Budget.validate(); err != nil {
+		return err
+	}
+
+	if err := s.Lease.validate(); err != nil {
+		return err
+	}
+
+	if err := s.Approval.validate(); err != nil {
+		return err
+	}
+
+	// 使用内置审批引擎时不依赖ITSM
+	if !s.Approval.IsNative() {
+		if err := s.Itsm.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -306,12 +338,13 @@ func (s AuthServerSetting) Validate() error {
 
 // WebServerSetting defines api server used setting options.
 type WebServerSetting struct {
-	Network Network    `yaml:"network"`
-	Service Service    `yaml:"service"`
-	Log     LogOption  `yaml:"log"`
-	Web     Web        `yaml:"web"`
-	Esb     Esb        `yaml:"esb"`
-	Itsm    ApiGateway `yaml:"itsm"`
+	Network  Network    `yaml:"network"`
+	Service  Service    `yaml:"service"`
+	Log      LogOption  `yaml:"log"`
+	Web      Web        `yaml:"web"`
+	Esb      Esb        `yaml:"esb"`
+	Approval Approval   `yaml:"approval"`
+	Itsm     ApiGateway `yaml:"itsm"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -324,6 +357,7 @@ func (s *WebServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Approval.trySetDefault()
 
 	return
 }
@@ -347,10 +381,17 @@ func (s WebServerSetting) Validate() error {
 		return err
 	}
 
-	if err := s.Itsm.validate(); err != nil {
+	if err := s.Approval.validate(); err != nil {
 		return err
 	}
 
+	// 使用内置审批引擎时不依赖ITSM
+	if !s.Approval.IsNative() {
+		if err := s.Itsm.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -374,6 +415,7 @@ func (s *TaskServerSetting) trySetDefault() {
 	s.Service.trySetDefault()
 	s.Database.trySetDefault()
 	s.Log.trySetDefault()
+	s.Async.trySetDefault()
 
 	return
 }
@@ -393,5 +435,9 @@ func (s TaskServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Async.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -163,14 +163,85 @@ type Async struct {
 	Executor   Executor   `yaml:"executor"`
 	Dispatcher Dispatcher `yaml:"dispatcher"`
 	WatchDog   WatchDog   `yaml:"watchDog"`
+	// ScheduleTrigger 主节点组件，负责将到期的任务流定时计划创建为任务流
+	ScheduleTrigger ScheduleTrigger `yaml:"scheduleTrigger"`
+	// WebhookNotifier 主节点组件，负责将任务流事件投递到事件回调地址，并清理过期事件
+	WebhookNotifier WebhookNotifier `yaml:"webhookNotifier"`
+	// Backend 异步任务框架使用的存储后端
+	Backend AsyncBackend `yaml:"backend"`
+}
+
+// trySetDefault set the Async default value if user not configured.
+func (a *Async) trySetDefault() {
+	if a.ScheduleTrigger.WatchIntervalSec == 0 {
+		a.ScheduleTrigger.WatchIntervalSec = 10
+	}
+
+	if a.WebhookNotifier.WatchIntervalSec == 0 {
+		a.WebhookNotifier.WatchIntervalSec = 3
+	}
+
+	if a.WebhookNotifier.EventRetentionHour == 0 {
+		a.WebhookNotifier.EventRetentionHour = 168
+	}
+
+	if a.WatchDog.FlowRetentionHour == 0 {
+		a.WatchDog.FlowRetentionHour = 168
+	}
+
+	if a.Executor.RateLimit.DeferIntervalMS == 0 {
+		a.Executor.RateLimit.DeferIntervalMS = 1000
+	}
+
+	a.Backend.trySetDefault()
 }
 
 // Validate Async
 func (a Async) Validate() error {
-	// 这里不进行校验，统一由异步任务框架进行校验
+	// 消费者组件的配置不在这里校验，统一由异步任务框架进行校验
+	return a.Backend.validate()
+}
+
+// AsyncBackend 异步任务框架使用的存储后端配置
+type AsyncBackend struct {
+	// Type 存储后端类型，支持mysql、etcd，默认为mysql
+	Type enumor.BackendType `yaml:"type"`
+	// Etcd 存储后端类型为etcd时使用的etcd配置
+	Etcd AsyncEtcd `yaml:"etcd"`
+}
+
+// trySetDefault set the AsyncBackend default value if user not configured.
+func (b *AsyncBackend) trySetDefault() {
+	if len(b.Type) == 0 {
+		b.Type = enumor.BackendMysql
+	}
+
+	if b.Type == enumor.BackendEtcd {
+		b.Etcd.trySetDefault()
+	}
+}
+
+func (b AsyncBackend) validate() error {
+	switch b.Type {
+	case enumor.BackendMysql:
+	case enumor.BackendEtcd:
+		if err := b.Etcd.validate(); err != nil {
+			return fmt.Errorf("async backend %v", err)
+		}
+	default:
+		return fmt.Errorf("unsupported async backend type: %s", b.Type)
+	}
+
 	return nil
 }
 
+// AsyncEtcd 异步任务框架使用的etcd配置
+type AsyncEtcd struct {
+	Etcd `yaml:",inline"`
+	// KeyPrefix 异步任务数据在etcd中的key前缀，默认为/hcm/async
+	KeyPrefix string `yaml:"keyPrefix"`
+}
+
 // Parser 公共组件，负责获取分配给当前节点的任务流，并解析成任务树后，派发当前要执行的任务给executor执行
 type Parser struct {
 	WatchIntervalSec uint `yaml:"watchIntervalSec"`
@@ -181,6 +252,26 @@ type Parser struct {
 type Executor struct {
 	WorkerNumber       uint `yaml:"workerNumber"`
 	TaskExecTimeoutSec uint `yaml:"taskExecTimeoutSec"`
+	// RateLimit 集群按任务限流键（云厂商+账号+地域）对任务进行限流和并发控制，超出限额的任务延迟执行
+	RateLimit AsyncRateLimit `yaml:"rateLimit"`
+}
+
+// AsyncRateLimit 异步任务限流配置，限额为整个集群的限额，按存活节点数均分到每个节点。
+type AsyncRateLimit struct {
+	// DeferIntervalMS 超出限额的任务重新尝试执行的间隔
+	DeferIntervalMS uint                 `yaml:"deferIntervalMS"`
+	Rules           []AsyncRateLimitRule `yaml:"rules"`
+}
+
+// AsyncRateLimitRule 异步任务限流规则，vendor、accountID、region 为空表示所有取值共享同一限额，
+// 为"*"表示每个取值单独计算限额，其他值表示只匹配该取值。
+type AsyncRateLimitRule struct {
+	Vendor      string  `yaml:"vendor"`
+	AccountID   string  `yaml:"accountID"`
+	Region      string  `yaml:"region"`
+	QPS         float64 `yaml:"qps"`
+	Burst       uint    `yaml:"burst"`
+	Concurrency uint    `yaml:"concurrency"`
 }
 
 // Dispatcher 主节点组件，负责派发任务
@@ -192,6 +283,19 @@ type Dispatcher struct {
 type WatchDog struct {
 	WatchIntervalSec uint `yaml:"watchIntervalSec"`
 	TaskTimeoutSec   uint `yaml:"taskTimeoutSec"`
+	// FlowRetentionHour 已结束任务流的保留时长，仅对etcd存储后端生效，超过保留时长的任务流及其任务会被删除
+	FlowRetentionHour uint `yaml:"flowRetentionHour"`
+}
+
+// ScheduleTrigger 主节点组件，负责将到期的任务流定时计划创建为任务流
+type ScheduleTrigger struct {
+	WatchIntervalSec uint `yaml:"watchIntervalSec"`
+}
+
+// WebhookNotifier 主节点组件，负责将任务流事件投递到事件回调地址，并清理过期事件
+type WebhookNotifier struct {
+	WatchIntervalSec   uint `yaml:"watchIntervalSec"`
+	EventRetentionHour uint `yaml:"eventRetentionHour"`
 }
 
 // DataBase defines database related runtime
@@ -602,7 +706,12 @@ func (c Crypto) validate() error {
 
 // CloudResource 云资源配置
 type CloudResource struct {
-	Sync CloudResourceSync `yaml:"sync"`
+	Sync            CloudResourceSync            `yaml:"sync"`
+	IncrementalSync CloudResourceIncrementalSync `yaml:"incrementalSync"`
+}
+
+func (c *CloudResource) trySetDefault() {
+	c.IncrementalSync.trySetDefault()
 }
 
 func (c CloudResource) validate() error {
@@ -610,6 +719,10 @@ func (c CloudResource) validate() error {
 		return err
 	}
 
+	if err := c.IncrementalSync.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -630,6 +743,39 @@ func (c CloudResourceSync) validate() error {
 	return nil
 }
 
+// CloudResourceIncrementalSync 基于云审计事件的云资源增量同步配置
+type CloudResourceIncrementalSync struct {
+	Enable bool `yaml:"enable"`
+	// IntervalMin 拉取云审计事件的间隔，单位：分钟
+	IntervalMin uint64 `yaml:"intervalMin"`
+	// DelayMin 云审计事件从发生到可查询存在延迟，只拉取当前时间之前 DelayMin 分钟的事件，单位：分钟
+	DelayMin uint64 `yaml:"delayMin"`
+	// ReconcileIntervalMin 开启增量同步后，全量同步只作为兜底的对账，使用该间隔代替 sync.syncIntervalMin，单位：分钟
+	ReconcileIntervalMin uint64 `yaml:"reconcileIntervalMin"`
+}
+
+func (c *CloudResourceIncrementalSync) trySetDefault() {
+	if c.IntervalMin == 0 {
+		c.IntervalMin = 5
+	}
+
+	if c.DelayMin == 0 {
+		c.DelayMin = 10
+	}
+
+	if c.ReconcileIntervalMin == 0 {
+		c.ReconcileIntervalMin = 1440
+	}
+}
+
+func (c CloudResourceIncrementalSync) validate() error {
+	if c.Enable && c.IntervalMin < 1 {
+		return errors.New("incrementalSync.intervalMin must >= 1")
+	}
+
+	return nil
+}
+
 // Recycle configuration.
 type Recycle struct {
 	AutoDeleteTime uint `yaml:"autoDeleteTimeHour"`
@@ -657,6 +803,260 @@ func (c BillConfig) validate() error {
 	return nil
 }
 
+// BillIngest 云账单拉取入库配置
+type BillIngest struct {
+	Enable bool `yaml:"enable"`
+	// SyncIntervalMin 拉取间隔，单位：分钟
+	SyncIntervalMin uint64 `yaml:"syncIntervalMin"`
+	// LookbackDays 每次拉取最近多少天的账单，云厂商会对近几天的账单做出账调整，所以需要重复拉取
+	LookbackDays uint `yaml:"lookbackDays"`
+}
+
+func (c *BillIngest) trySetDefault() {
+	if c.SyncIntervalMin == 0 {
+		c.SyncIntervalMin = 720
+	}
+
+	if c.LookbackDays == 0 {
+		c.LookbackDays = 3
+	}
+}
+
+func (c BillIngest) validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	if c.SyncIntervalMin < 1 {
+		return errors.New("billIngest.syncIntervalMin must >= 1")
+	}
+
+	if c.LookbackDays > 31 {
+		return errors.New("billIngest.lookbackDays must <= 31")
+	}
+
+	return nil
+}
+
+// Budget 预算告警配置
+type Budget struct {
+	Enable bool `yaml:"enable"`
+	// EvaluateIntervalMin 预算评估间隔，单位：分钟
+	EvaluateIntervalMin uint64 `yaml:"evaluateIntervalMin"`
+	// Notifier 预算告警通知方式
+	Notifier BudgetNotifier `yaml:"notifier"`
+}
+
+func (c *Budget) trySetDefault() {
+	if c.EvaluateIntervalMin == 0 {
+		c.EvaluateIntervalMin = 60
+	}
+
+	c.Notifier.trySetDefault()
+}
+
+func (c Budget) validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	return c.Notifier.validate()
+}
+
+// SnapshotPolicy 快照策略执行配置
+type SnapshotPolicy struct {
+	Enable bool `yaml:"enable"`
+	// CheckIntervalMin 检查到期快照策略的间隔，单位：分钟
+	CheckIntervalMin uint64 `yaml:"checkIntervalMin"`
+}
+
+func (c *SnapshotPolicy) trySetDefault() {
+	if c.CheckIntervalMin == 0 {
+		c.CheckIntervalMin = 5
+	}
+}
+
+// Drift 资源配置漂移检测配置
+type Drift struct {
+	Enable bool `yaml:"enable"`
+	// CheckIntervalMin 漂移检测的间隔，单位：分钟
+	CheckIntervalMin uint64 `yaml:"checkIntervalMin"`
+}
+
+func (c *Drift) trySetDefault() {
+	if c.CheckIntervalMin == 0 {
+		c.CheckIntervalMin = 60
+	}
+}
+
+// Lease 资源租约配置，到期前提醒负责人，到期后自动回收资源
+type Lease struct {
+	Enable bool `yaml:"enable"`
+	// CheckIntervalMin 检查到期租约的间隔，单位：分钟
+	CheckIntervalMin uint64 `yaml:"checkIntervalMin"`
+	// RemindBeforeHour 到期前多少小时提醒负责人
+	RemindBeforeHour uint64 `yaml:"remindBeforeHour"`
+	// RenewDays 一键续期未指定天数时默认延长的天数
+	RenewDays uint64 `yaml:"renewDays"`
+	// Notifier 租约到期提醒通知方式
+	Notifier LeaseNotifier `yaml:"notifier"`
+}
+
+func (c *Lease) trySetDefault() {
+	if c.CheckIntervalMin == 0 {
+		c.CheckIntervalMin = 10
+	}
+
+	if c.RemindBeforeHour == 0 {
+		c.RemindBeforeHour = 24
+	}
+
+	if c.RenewDays == 0 {
+		c.RenewDays = 7
+	}
+
+	c.Notifier.trySetDefault()
+}
+
+func (c Lease) validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	return c.Notifier.validate()
+}
+
+// ApprovalEngine 申请单审批引擎类型
+type ApprovalEngine string
+
+const (
+	// ItsmApprovalEngine 使用蓝鲸ITSM进行审批
+	ItsmApprovalEngine ApprovalEngine = "itsm"
+	// NativeApprovalEngine 使用hcm内置的审批流进行审批，不依赖ITSM
+	NativeApprovalEngine ApprovalEngine = "native"
+)
+
+// Approval 申请单审批配置
+type Approval struct {
+	Engine ApprovalEngine `yaml:"engine"`
+	// ReconcileIntervalMin 内置审批引擎补偿单据已结束但申请单仍为审批中的审批结果的间隔，单位：分钟
+	ReconcileIntervalMin uint64 `yaml:"reconcileIntervalMin"`
+}
+
+func (c *Approval) trySetDefault() {
+	if len(c.Engine) == 0 {
+		c.Engine = ItsmApprovalEngine
+	}
+
+	if c.ReconcileIntervalMin == 0 {
+		c.ReconcileIntervalMin = 5
+	}
+}
+
+func (c Approval) validate() error {
+	switch c.Engine {
+	case ItsmApprovalEngine, NativeApprovalEngine:
+	default:
+		return fmt.Errorf("approval engine %s is not supported", c.Engine)
+	}
+
+	return nil
+}
+
+// IsNative return if the native approval engine is chosen.
+func (c Approval) IsNative() bool {
+	return c.Engine == NativeApprovalEngine
+}
+
+// BudgetNotifierType 预算告警通知方式类型
+type BudgetNotifierType string
+
+const (
+	// LogBudgetNotifier 只将告警内容打印到日志中
+	LogBudgetNotifier BudgetNotifierType = "log"
+	// WebhookBudgetNotifier 将告警内容以json格式POST到指定地址
+	WebhookBudgetNotifier BudgetNotifierType = "webhook"
+)
+
+// BudgetNotifier 预算告警通知配置
+type BudgetNotifier struct {
+	Type    BudgetNotifierType `yaml:"type"`
+	Webhook Webhook            `yaml:"webhook"`
+}
+
+func (c *BudgetNotifier) trySetDefault() {
+	if len(c.Type) == 0 {
+		c.Type = LogBudgetNotifier
+	}
+
+	if c.Webhook.TimeoutSec == 0 {
+		c.Webhook.TimeoutSec = 10
+	}
+}
+
+func (c BudgetNotifier) validate() error {
+	switch c.Type {
+	case LogBudgetNotifier:
+	case WebhookBudgetNotifier:
+		if len(c.Webhook.Url) == 0 {
+			return errors.New("budget.notifier.webhook.url is required when notifier type is webhook")
+		}
+	default:
+		return fmt.Errorf("unsupported budget.notifier.type: %s", c.Type)
+	}
+
+	return nil
+}
+
+// LeaseNotifierType 租约到期提醒通知方式类型
+type LeaseNotifierType string
+
+const (
+	// LogLeaseNotifier 只将提醒内容打印到日志中
+	LogLeaseNotifier LeaseNotifierType = "log"
+	// WebhookLeaseNotifier 将提醒内容以json格式POST到指定地址
+	WebhookLeaseNotifier LeaseNotifierType = "webhook"
+)
+
+// LeaseNotifier 租约到期提醒通知配置
+type LeaseNotifier struct {
+	Type    LeaseNotifierType `yaml:"type"`
+	Webhook Webhook           `yaml:"webhook"`
+}
+
+func (c *LeaseNotifier) trySetDefault() {
+	if len(c.Type) == 0 {
+		c.Type = LogLeaseNotifier
+	}
+
+	if c.Webhook.TimeoutSec == 0 {
+		c.Webhook.TimeoutSec = 10
+	}
+}
+
+func (c LeaseNotifier) validate() error {
+	switch c.Type {
+	case LogLeaseNotifier:
+	case WebhookLeaseNotifier:
+		if len(c.Webhook.Url) == 0 {
+			return errors.New("lease.notifier.webhook.url is required when notifier type is webhook")
+		}
+	default:
+		return fmt.Errorf("unsupported lease.notifier.type: %s", c.Type)
+	}
+
+	return nil
+}
+
+// Webhook 回调地址配置
+type Webhook struct {
+	Url string `yaml:"url"`
+	// TimeoutSec 请求超时时间，单位：秒
+	TimeoutSec uint `yaml:"timeoutSec"`
+	// Headers 请求时附带的额外请求头
+	Headers map[string]string `yaml:"headers"`
+}
+
 // ApiGateway defines the api gateway config.
 type ApiGateway struct {
 	// Endpoints is a seed list of host:port addresses of api gateway.
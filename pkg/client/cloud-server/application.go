@@ -22,6 +22,7 @@ package cloudserver
 import (
 	"hcm/pkg/api/cloud-server/application"
 	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/rest"
@@ -62,3 +63,27 @@ func (v *ApplicationClient) CreateForAddAccount(kt *kit.Kit, req *application.Ac
 
 	return resp.Data, nil
 }
+
+// ExecuteDeliverStep 执行申请单交付任务流的步骤
+func (v *ApplicationClient) ExecuteDeliverStep(kt *kit.Kit, applicationID string,
+	step enumor.ApplicationDeliverStep, req *application.ExecuteDeliverStepReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := v.client.Post().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/applications/%s/deliver_steps/%s", applicationID, step).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import (
+	csapproval "hcm/pkg/api/cloud-server/approval"
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+	"hcm/pkg/thirdparty/api-gateway/itsm"
+)
+
+// ApprovalClient is native approval engine client.
+type ApprovalClient struct {
+	client rest.ClientInterface
+}
+
+// NewApprovalClient create a new native approval engine client.
+func NewApprovalClient(client rest.ClientInterface) *ApprovalClient {
+	return &ApprovalClient{
+		client: client,
+	}
+}
+
+// ListTicketByUser 以ITSM的单据格式查询用户的审批单据
+func (cli *ApprovalClient) ListTicketByUser(kt *kit.Kit, req *csapproval.TicketListByUserReq) (
+	*itsm.GetTicketsByUserRespData, error) {
+
+	resp := new(core.BaseResp[*itsm.GetTicketsByUserRespData])
+
+	err := cli.client.Post().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/approval/tickets/by_user/list").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// ApproveTicket 通过审批单据的当前审批节点
+func (cli *ApprovalClient) ApproveTicket(kt *kit.Kit, sn string, req *csapproval.TicketOperateReq) error {
+	return cli.operate(kt, sn, "approve", req)
+}
+
+// RejectTicket 拒绝审批单据的当前审批节点
+func (cli *ApprovalClient) RejectTicket(kt *kit.Kit, sn string, req *csapproval.TicketOperateReq) error {
+	return cli.operate(kt, sn, "reject", req)
+}
+
+func (cli *ApprovalClient) operate(kt *kit.Kit, sn, action string, req *csapproval.TicketOperateReq) error {
+	resp := new(rest.BaseResp)
+
+	err := cli.client.Post().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/approval/tickets/%s/%s", sn, action).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
@@ -38,6 +38,7 @@ type Client struct {
 	RouteTable        *RouteTableClient
 	ApprovalProcess   *ApprovalProcessClient
 	ApplicationClient *ApplicationClient
+	Approval          *ApprovalClient
 }
 
 // NewClient create a new cloud-server api client.
@@ -52,5 +53,6 @@ func NewClient(c *client.Capability, version string) *Client {
 		ApprovalProcess:   NewApprovalProcessClient(restCli),
 		RouteTable:        NewRouteTable(restCli),
 		ApplicationClient: NewApplicationClient(restCli),
+		Approval:          NewApprovalClient(restCli),
 	}
 }
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	datasnapshot "hcm/pkg/api/data-service/cloud/snapshot"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// BatchCreateSnapshot batch create snapshot.
+func (cli *restClient) BatchCreateSnapshot(kt *kit.Kit,
+	req *datasnapshot.SnapshotBatchCreateReq[coresnapshot.AwsSnapshotExtension]) (*core.BatchCreateResult, error) {
+
+	return common.Request[datasnapshot.SnapshotBatchCreateReq[coresnapshot.AwsSnapshotExtension],
+		core.BatchCreateResult](cli.client, rest.POST, kt, req, "/snapshots/batch/create")
+}
+
+// BatchUpdateSnapshot batch update snapshot.
+func (cli *restClient) BatchUpdateSnapshot(kt *kit.Kit,
+	req *datasnapshot.SnapshotBatchUpdateReq[coresnapshot.AwsSnapshotExtension]) error {
+
+	return common.RequestNoResp[datasnapshot.SnapshotBatchUpdateReq[coresnapshot.AwsSnapshotExtension]](
+		cli.client, rest.PATCH, kt, req, "/snapshots/batch/update")
+}
+
+// ListSnapshotExt list snapshot with extension.
+func (cli *restClient) ListSnapshotExt(kt *kit.Kit, req *core.ListReq) (
+	*datasnapshot.SnapshotExtListResult[coresnapshot.AwsSnapshotExtension], error) {
+
+	return common.Request[core.ListReq, datasnapshot.SnapshotExtListResult[coresnapshot.AwsSnapshotExtension]](
+		cli.client, rest.POST, kt, req, "/snapshots/list")
+}
+
+// GetSnapshot get snapshot with extension.
+func (cli *restClient) GetSnapshot(kt *kit.Kit, id string) (
+	*coresnapshot.Snapshot[coresnapshot.AwsSnapshotExtension], error) {
+
+	return common.Request[common.Empty, coresnapshot.Snapshot[coresnapshot.AwsSnapshotExtension]](
+		cli.client, rest.GET, kt, common.NoData, "/snapshots/%s", id)
+}
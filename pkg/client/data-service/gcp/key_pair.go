@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"hcm/pkg/api/core"
+	corekeypair "hcm/pkg/api/core/cloud/key-pair"
+	datakeypair "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// BatchCreateKeyPair batch create key pair.
+func (cli *restClient) BatchCreateKeyPair(kt *kit.Kit,
+	req *datakeypair.KeyPairBatchCreateReq[corekeypair.GcpKeyPairExtension]) (*core.BatchCreateResult, error) {
+
+	return common.Request[datakeypair.KeyPairBatchCreateReq[corekeypair.GcpKeyPairExtension],
+		core.BatchCreateResult](cli.client, rest.POST, kt, req, "/key_pairs/batch/create")
+}
+
+// BatchUpdateKeyPair batch update key pair.
+func (cli *restClient) BatchUpdateKeyPair(kt *kit.Kit,
+	req *datakeypair.KeyPairBatchUpdateReq[corekeypair.GcpKeyPairExtension]) error {
+
+	return common.RequestNoResp[datakeypair.KeyPairBatchUpdateReq[corekeypair.GcpKeyPairExtension]](
+		cli.client, rest.PATCH, kt, req, "/key_pairs/batch/update")
+}
+
+// ListKeyPairExt list key pair with extension.
+func (cli *restClient) ListKeyPairExt(kt *kit.Kit, req *core.ListReq) (
+	*datakeypair.KeyPairExtListResult[corekeypair.GcpKeyPairExtension], error) {
+
+	return common.Request[core.ListReq, datakeypair.KeyPairExtListResult[corekeypair.GcpKeyPairExtension]](
+		cli.client, rest.POST, kt, req, "/key_pairs/list")
+}
+
+// GetKeyPair get key pair with extension.
+func (cli *restClient) GetKeyPair(kt *kit.Kit, id string) (
+	*corekeypair.KeyPair[corekeypair.GcpKeyPairExtension], error) {
+
+	return common.Request[common.Empty, corekeypair.KeyPair[corekeypair.GcpKeyPairExtension]](
+		cli.client, rest.GET, kt, common.NoData, "/key_pairs/%s", id)
+}
@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coreapproval "hcm/pkg/api/core/approval"
+	dsapproval "hcm/pkg/api/data-service/approval"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewApprovalClient create a new native approval api client.
+func NewApprovalClient(client rest.ClientInterface) *ApprovalClient {
+	return &ApprovalClient{
+		client: client,
+	}
+}
+
+// ApprovalClient is data service native approval api client.
+type ApprovalClient struct {
+	client rest.ClientInterface
+}
+
+// CreateWorkflow create approval workflow.
+func (cli *ApprovalClient) CreateWorkflow(kt *kit.Kit, req *dsapproval.WorkflowCreateReq) (*core.CreateResult,
+	error) {
+
+	return common.Request[dsapproval.WorkflowCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/approval/workflows/create")
+}
+
+// ListWorkflow list approval workflow.
+func (cli *ApprovalClient) ListWorkflow(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[coreapproval.Workflow], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coreapproval.Workflow]](cli.client, rest.POST, kt, req,
+		"/approval/workflows/list")
+}
+
+// UpdateWorkflow update approval workflow.
+func (cli *ApprovalClient) UpdateWorkflow(kt *kit.Kit, id string, req *dsapproval.WorkflowUpdateReq) error {
+
+	return common.RequestNoResp[dsapproval.WorkflowUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/approval/workflows/%s", id)
+}
+
+// BatchDeleteWorkflow batch delete approval workflow.
+func (cli *ApprovalClient) BatchDeleteWorkflow(kt *kit.Kit, req *core.BatchDeleteReq) error {
+
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/approval/workflows/batch")
+}
+
+// CreatePolicy create approval policy.
+func (cli *ApprovalClient) CreatePolicy(kt *kit.Kit, req *dsapproval.PolicyCreateReq) (*core.CreateResult,
+	error) {
+
+	return common.Request[dsapproval.PolicyCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/approval/policies/create")
+}
+
+// ListPolicy list approval policy.
+func (cli *ApprovalClient) ListPolicy(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[coreapproval.Policy], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coreapproval.Policy]](cli.client, rest.POST, kt, req,
+		"/approval/policies/list")
+}
+
+// UpdatePolicy update approval policy.
+func (cli *ApprovalClient) UpdatePolicy(kt *kit.Kit, id string, req *dsapproval.PolicyUpdateReq) error {
+
+	return common.RequestNoResp[dsapproval.PolicyUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/approval/policies/%s", id)
+}
+
+// BatchDeletePolicy batch delete approval policy.
+func (cli *ApprovalClient) BatchDeletePolicy(kt *kit.Kit, req *core.BatchDeleteReq) error {
+
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/approval/policies/batch")
+}
+
+// CreateTicket create approval ticket.
+func (cli *ApprovalClient) CreateTicket(kt *kit.Kit, req *dsapproval.TicketCreateReq) (*core.CreateResult, error) {
+
+	return common.Request[dsapproval.TicketCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/approval/tickets/create")
+}
+
+// ListTicket list approval ticket.
+func (cli *ApprovalClient) ListTicket(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[coreapproval.Ticket],
+	error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coreapproval.Ticket]](cli.client, rest.POST, kt, req,
+		"/approval/tickets/list")
+}
+
+// UpdateTicket update approval ticket.
+func (cli *ApprovalClient) UpdateTicket(kt *kit.Kit, id string, req *dsapproval.TicketUpdateReq) error {
+
+	return common.RequestNoResp[dsapproval.TicketUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/approval/tickets/%s", id)
+}
@@ -26,7 +26,9 @@ import (
 	"hcm/pkg/api/core"
 	coreaudit "hcm/pkg/api/core/audit"
 	protoaudit "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/client/common"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
 	"hcm/pkg/rest"
 )
 
@@ -208,3 +210,11 @@ func (a *AuditClient) GetAudit(ctx context.Context, h http.Header, id uint64) (
 
 	return resp.Data, nil
 }
+
+// ListResChangeEvent list res change event.
+func (a *AuditClient) ListResChangeEvent(kt *kit.Kit, req *core.ListReq) (
+	*protoaudit.ListResChangeEventResult, error) {
+
+	return common.Request[core.ListReq, protoaudit.ListResChangeEventResult](a.client, rest.POST, kt, req,
+		"/res_change_events/list")
+}
@@ -26,7 +26,9 @@ import (
 	"hcm/pkg/api/core"
 	dataservice "hcm/pkg/api/data-service"
 	datacloudbillproto "hcm/pkg/api/data-service/cloud/bill"
+	"hcm/pkg/client/common"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
 	"hcm/pkg/rest"
 )
 
@@ -87,3 +89,39 @@ func (b *BillClient) BatchDelete(ctx context.Context, h http.Header, req *datase
 
 	return nil
 }
+
+// ListDailyItem list bill daily item.
+func (b *BillClient) ListDailyItem(kt *kit.Kit, req *core.ListReq) (*datacloudbillproto.BillDailyItemListResult,
+	error) {
+
+	return common.Request[core.ListReq, datacloudbillproto.BillDailyItemListResult](b.client, rest.POST, kt, req,
+		"/bills/daily_items/list")
+}
+
+// AggregateDailyItem aggregate bill daily item.
+func (b *BillClient) AggregateDailyItem(kt *kit.Kit, req *datacloudbillproto.BillDailyAggregateReq) (
+	*datacloudbillproto.BillDailyAggregateResult, error) {
+
+	return common.Request[datacloudbillproto.BillDailyAggregateReq, datacloudbillproto.BillDailyAggregateResult](
+		b.client, rest.POST, kt, req, "/bills/daily_items/aggregate")
+}
+
+// ReplaceDailyItem replace bill daily item of one account and one day.
+func (b *BillClient) ReplaceDailyItem(kt *kit.Kit, req *datacloudbillproto.BillDailyItemReplaceReq) error {
+	return common.RequestNoResp[datacloudbillproto.BillDailyItemReplaceReq](b.client, rest.PUT, kt, req,
+		"/bills/daily_items/replace")
+}
+
+// ListSyncRecord list bill sync record.
+func (b *BillClient) ListSyncRecord(kt *kit.Kit, req *core.ListReq) (*datacloudbillproto.BillSyncRecordListResult,
+	error) {
+
+	return common.Request[core.ListReq, datacloudbillproto.BillSyncRecordListResult](b.client, rest.POST, kt, req,
+		"/bills/sync_records/list")
+}
+
+// SetSyncRecord set bill sync record.
+func (b *BillClient) SetSyncRecord(kt *kit.Kit, req *datacloudbillproto.BillSyncRecordSetReq) error {
+	return common.RequestNoResp[datacloudbillproto.BillSyncRecordSetReq](b.client, rest.PUT, kt, req,
+		"/bills/sync_records/set")
+}
@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	corebudget "hcm/pkg/api/core/budget"
+	dsbudget "hcm/pkg/api/data-service/budget"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewBudgetClient create a new budget api client.
+func NewBudgetClient(client rest.ClientInterface) *BudgetClient {
+	return &BudgetClient{
+		client: client,
+	}
+}
+
+// BudgetClient is data service budget api client.
+type BudgetClient struct {
+	client rest.ClientInterface
+}
+
+// CreateBudget create budget.
+func (cli *BudgetClient) CreateBudget(kt *kit.Kit, req *dsbudget.BudgetCreateReq) (*core.CreateResult, error) {
+
+	return common.Request[dsbudget.BudgetCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/budgets/create")
+}
+
+// ListBudget list budget.
+func (cli *BudgetClient) ListBudget(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[corebudget.Budget], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corebudget.Budget]](cli.client, rest.POST, kt, req,
+		"/budgets/list")
+}
+
+// UpdateBudget update budget.
+func (cli *BudgetClient) UpdateBudget(kt *kit.Kit, id string, req *dsbudget.BudgetUpdateReq) error {
+
+	return common.RequestNoResp[dsbudget.BudgetUpdateReq](cli.client, rest.PATCH, kt, req, "/budgets/%s", id)
+}
+
+// BatchDeleteBudget batch delete budget and its alert records.
+func (cli *BudgetClient) BatchDeleteBudget(kt *kit.Kit, req *core.BatchDeleteReq) error {
+
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/budgets/batch")
+}
+
+// CreateBudgetAlert create budget alert record.
+func (cli *BudgetClient) CreateBudgetAlert(kt *kit.Kit, req *dsbudget.BudgetAlertCreateReq) (*core.CreateResult,
+	error) {
+
+	return common.Request[dsbudget.BudgetAlertCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/budgets/alerts/create")
+}
+
+// ListBudgetAlert list budget alert record.
+func (cli *BudgetClient) ListBudgetAlert(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[corebudget.BudgetAlert], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corebudget.BudgetAlert]](cli.client, rest.POST, kt, req,
+		"/budgets/alerts/list")
+}
+
+// UpdateBudgetAlert update budget alert record.
+func (cli *BudgetClient) UpdateBudgetAlert(kt *kit.Kit, id string, req *dsbudget.BudgetAlertUpdateReq) error {
+
+	return common.RequestNoResp[dsbudget.BudgetAlertUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/budgets/alerts/%s", id)
+}
@@ -55,6 +55,20 @@ type Client struct {
 
 	CloudSelection *CloudSelectionClient
 	ArgsTpl        *ArgsTplClient
+
+	LoadBalancer *LoadBalancerClient
+	ResourceTag  *ResourceTagClient
+
+	Budget     *BudgetClient
+	Snapshot   *SnapshotClient
+	KeyPair    *KeyPairClient
+	NatGateway *NatGatewayClient
+	IPAM       *IPAMClient
+	Drift      *DriftClient
+	Stack      *StackClient
+	Approval   *ApprovalClient
+	Guardrail  *GuardrailClient
+	Lease      *LeaseClient
 }
 
 type restClient struct {
@@ -93,5 +107,19 @@ func NewClient(client rest.ClientInterface) *Client {
 
 		CloudSelection: NewCloudCloudSelectionClient(client),
 		ArgsTpl:        NewCloudArgumentTemplateClient(client),
+
+		LoadBalancer: NewLoadBalancerClient(client),
+		ResourceTag:  NewResourceTagClient(client),
+
+		Budget:     NewBudgetClient(client),
+		Snapshot:   NewSnapshotClient(client),
+		KeyPair:    NewKeyPairClient(client),
+		NatGateway: NewNatGatewayClient(client),
+		IPAM:       NewIPAMClient(client),
+		Drift:      NewDriftClient(client),
+		Stack:      NewStackClient(client),
+		Approval:   NewApprovalClient(client),
+		Guardrail:  NewGuardrailClient(client),
+		Lease:      NewLeaseClient(client),
 	}
 }
@@ -0,0 +1,70 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coredrift "hcm/pkg/api/core/drift"
+	dsdrift "hcm/pkg/api/data-service/drift"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewDriftClient create a new drift api client.
+func NewDriftClient(client rest.ClientInterface) *DriftClient {
+	return &DriftClient{
+		client: client,
+	}
+}
+
+// DriftClient is data service drift api client.
+type DriftClient struct {
+	client rest.ClientInterface
+}
+
+// BatchCreateDesiredState batch create desired state.
+func (cli *DriftClient) BatchCreateDesiredState(kt *kit.Kit, req *dsdrift.DesiredStateBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[dsdrift.DesiredStateBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST, kt,
+		req, "/desired_states/batch/create")
+}
+
+// ListDesiredState list desired state.
+func (cli *DriftClient) ListDesiredState(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[coredrift.DesiredState], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coredrift.DesiredState]](cli.client, rest.POST, kt, req,
+		"/desired_states/list")
+}
+
+// BatchUpdateDesiredState batch update desired state drift result.
+func (cli *DriftClient) BatchUpdateDesiredState(kt *kit.Kit, req *dsdrift.DesiredStateBatchUpdateReq) error {
+
+	return common.RequestNoResp[dsdrift.DesiredStateBatchUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/desired_states/batch/update")
+}
+
+// BatchDeleteDesiredState batch delete desired state.
+func (cli *DriftClient) BatchDeleteDesiredState(kt *kit.Kit, req *core.BatchDeleteReq) error {
+
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/desired_states/batch")
+}
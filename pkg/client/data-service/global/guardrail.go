@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coreguardrail "hcm/pkg/api/core/guardrail"
+	dsguardrail "hcm/pkg/api/data-service/guardrail"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewGuardrailClient create a new guardrail api client.
+func NewGuardrailClient(client rest.ClientInterface) *GuardrailClient {
+	return &GuardrailClient{
+		client: client,
+	}
+}
+
+// GuardrailClient is data service guardrail api client.
+type GuardrailClient struct {
+	client rest.ClientInterface
+}
+
+// CreateRule create guardrail rule.
+func (cli *GuardrailClient) CreateRule(kt *kit.Kit, req *dsguardrail.RuleCreateReq) (*core.CreateResult, error) {
+
+	return common.Request[dsguardrail.RuleCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/guardrail/rules/create")
+}
+
+// ListRule list guardrail rule.
+func (cli *GuardrailClient) ListRule(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[coreguardrail.Rule], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coreguardrail.Rule]](cli.client, rest.POST, kt, req,
+		"/guardrail/rules/list")
+}
+
+// UpdateRule update guardrail rule.
+func (cli *GuardrailClient) UpdateRule(kt *kit.Kit, id string, req *dsguardrail.RuleUpdateReq) error {
+
+	return common.RequestNoResp[dsguardrail.RuleUpdateReq](cli.client, rest.PATCH, kt, req, "/guardrail/rules/%s",
+		id)
+}
+
+// BatchDeleteRule batch delete guardrail rule.
+func (cli *GuardrailClient) BatchDeleteRule(kt *kit.Kit, req *core.BatchDeleteReq) error {
+
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/guardrail/rules/batch")
+}
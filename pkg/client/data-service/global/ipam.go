@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coreipam "hcm/pkg/api/core/ipam"
+	dsipam "hcm/pkg/api/data-service/ipam"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewIPAMClient create a new ipam api client.
+func NewIPAMClient(client rest.ClientInterface) *IPAMClient {
+	return &IPAMClient{
+		client: client,
+	}
+}
+
+// IPAMClient is data service ipam api client.
+type IPAMClient struct {
+	client rest.ClientInterface
+}
+
+// CreatePool create ipam pool.
+func (cli *IPAMClient) CreatePool(kt *kit.Kit, req *dsipam.PoolCreateReq) (*core.CreateResult, error) {
+
+	return common.Request[dsipam.PoolCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/ipam/pools/create")
+}
+
+// ListPool list ipam pool.
+func (cli *IPAMClient) ListPool(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[coreipam.Pool], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coreipam.Pool]](cli.client, rest.POST, kt, req,
+		"/ipam/pools/list")
+}
+
+// UpdatePool update ipam pool.
+func (cli *IPAMClient) UpdatePool(kt *kit.Kit, id string, req *dsipam.PoolUpdateReq) error {
+
+	return common.RequestNoResp[dsipam.PoolUpdateReq](cli.client, rest.PATCH, kt, req, "/ipam/pools/%s", id)
+}
+
+// BatchDeletePool batch delete ipam pool.
+func (cli *IPAMClient) BatchDeletePool(kt *kit.Kit, req *core.BatchDeleteReq) error {
+
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/ipam/pools/batch")
+}
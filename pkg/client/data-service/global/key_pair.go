@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	datakeypair "hcm/pkg/api/data-service/cloud/key-pair"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewKeyPairClient create a new key pair api client.
+func NewKeyPairClient(client rest.ClientInterface) *KeyPairClient {
+	return &KeyPairClient{
+		client: client,
+	}
+}
+
+// KeyPairClient is data service key pair api client.
+type KeyPairClient struct {
+	client rest.ClientInterface
+}
+
+// ListKeyPair list key pair.
+func (cli *KeyPairClient) ListKeyPair(kt *kit.Kit, req *core.ListReq) (*datakeypair.KeyPairListResult, error) {
+	return common.Request[core.ListReq, datakeypair.KeyPairListResult](cli.client, rest.POST, kt, req,
+		"/key_pairs/list")
+}
+
+// BatchUpdateKeyPairBizID batch update key pair biz id.
+func (cli *KeyPairClient) BatchUpdateKeyPairBizID(kt *kit.Kit, req *datakeypair.KeyPairBatchUpdateExprReq) error {
+	return common.RequestNoResp[datakeypair.KeyPairBatchUpdateExprReq](cli.client, rest.PATCH, kt, req,
+		"/key_pairs/biz/batch/update")
+}
+
+// GetKeyPairPrivateKey get key pair encrypted private key.
+func (cli *KeyPairClient) GetKeyPairPrivateKey(kt *kit.Kit, id string) (*datakeypair.PrivateKeyResult, error) {
+	return common.Request[common.Empty, datakeypair.PrivateKeyResult](cli.client, rest.GET, kt, common.NoData,
+		"/key_pairs/%s/private_key", id)
+}
+
+// BatchDeleteKeyPair batch delete key pair.
+func (cli *KeyPairClient) BatchDeleteKeyPair(kt *kit.Kit, req *datakeypair.KeyPairBatchDeleteReq) error {
+	return common.RequestNoResp[datakeypair.KeyPairBatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/key_pairs/batch")
+}
@@ -0,0 +1,67 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	corelease "hcm/pkg/api/core/lease"
+	dslease "hcm/pkg/api/data-service/lease"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewLeaseClient create a new resource lease api client.
+func NewLeaseClient(client rest.ClientInterface) *LeaseClient {
+	return &LeaseClient{
+		client: client,
+	}
+}
+
+// LeaseClient is data service resource lease api client.
+type LeaseClient struct {
+	client rest.ClientInterface
+}
+
+// CreateLease create resource lease.
+func (cli *LeaseClient) CreateLease(kt *kit.Kit, req *dslease.LeaseCreateReq) (*core.CreateResult, error) {
+
+	return common.Request[dslease.LeaseCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/leases/create")
+}
+
+// ListLease list resource lease.
+func (cli *LeaseClient) ListLease(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[corelease.Lease], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corelease.Lease]](cli.client, rest.POST, kt, req,
+		"/leases/list")
+}
+
+// UpdateLease update resource lease.
+func (cli *LeaseClient) UpdateLease(kt *kit.Kit, id string, req *dslease.LeaseUpdateReq) error {
+
+	return common.RequestNoResp[dslease.LeaseUpdateReq](cli.client, rest.PATCH, kt, req, "/leases/%s", id)
+}
+
+// BatchDeleteLease batch delete resource lease.
+func (cli *LeaseClient) BatchDeleteLease(kt *kit.Kit, req *core.BatchDeleteReq) error {
+
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/leases/batch")
+}
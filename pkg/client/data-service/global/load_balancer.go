@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	datalb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewLoadBalancerClient create a new load balancer api client.
+func NewLoadBalancerClient(client rest.ClientInterface) *LoadBalancerClient {
+	return &LoadBalancerClient{
+		client: client,
+	}
+}
+
+// LoadBalancerClient is data service load balancer api client.
+type LoadBalancerClient struct {
+	client rest.ClientInterface
+}
+
+// ListLoadBalancer list load balancer.
+func (cli *LoadBalancerClient) ListLoadBalancer(kt *kit.Kit, req *core.ListReq) (*datalb.LoadBalancerListResult,
+	error) {
+
+	return common.Request[core.ListReq, datalb.LoadBalancerListResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/list")
+}
+
+// BatchUpdateLoadBalancerBiz batch update load balancer bk_biz_id.
+func (cli *LoadBalancerClient) BatchUpdateLoadBalancerBiz(kt *kit.Kit,
+	req *datalb.LoadBalancerBizBatchUpdateReq) error {
+
+	return common.RequestNoResp[datalb.LoadBalancerBizBatchUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/load_balancers/biz/batch/update")
+}
+
+// BatchDeleteLoadBalancer batch delete load balancer.
+func (cli *LoadBalancerClient) BatchDeleteLoadBalancer(kt *kit.Kit, req *datalb.LoadBalancerBatchDeleteReq) error {
+	return common.RequestNoResp[datalb.LoadBalancerBatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/load_balancers/batch")
+}
+
+// BatchCreateListener batch create listener.
+func (cli *LoadBalancerClient) BatchCreateListener(kt *kit.Kit, req *datalb.ListenerBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[datalb.ListenerBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/listeners/batch/create")
+}
+
+// BatchUpdateListener batch update listener.
+func (cli *LoadBalancerClient) BatchUpdateListener(kt *kit.Kit, req *datalb.ListenerBatchUpdateReq) error {
+	return common.RequestNoResp[datalb.ListenerBatchUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/load_balancers/listeners/batch/update")
+}
+
+// ListListener list listener.
+func (cli *LoadBalancerClient) ListListener(kt *kit.Kit, req *core.ListReq) (*datalb.ListenerListResult, error) {
+	return common.Request[core.ListReq, datalb.ListenerListResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/listeners/list")
+}
+
+// BatchDeleteListener batch delete listener.
+func (cli *LoadBalancerClient) BatchDeleteListener(kt *kit.Kit, req *datalb.ListenerBatchDeleteReq) error {
+	return common.RequestNoResp[datalb.ListenerBatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/load_balancers/listeners/batch")
+}
+
+// BatchCreateTarget batch create target.
+func (cli *LoadBalancerClient) BatchCreateTarget(kt *kit.Kit, req *datalb.TargetBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[datalb.TargetBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/targets/batch/create")
+}
+
+// BatchUpdateTarget batch update target.
+func (cli *LoadBalancerClient) BatchUpdateTarget(kt *kit.Kit, req *datalb.TargetBatchUpdateReq) error {
+	return common.RequestNoResp[datalb.TargetBatchUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/load_balancers/targets/batch/update")
+}
+
+// ListTarget list target.
+func (cli *LoadBalancerClient) ListTarget(kt *kit.Kit, req *core.ListReq) (*datalb.TargetListResult, error) {
+	return common.Request[core.ListReq, datalb.TargetListResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/targets/list")
+}
+
+// BatchDeleteTarget batch delete target.
+func (cli *LoadBalancerClient) BatchDeleteTarget(kt *kit.Kit, req *datalb.TargetBatchDeleteReq) error {
+	return common.RequestNoResp[datalb.TargetBatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/load_balancers/targets/batch")
+}
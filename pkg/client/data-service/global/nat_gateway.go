@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	datanat "hcm/pkg/api/data-service/cloud/nat-gateway"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewNatGatewayClient create a new nat gateway api client.
+func NewNatGatewayClient(client rest.ClientInterface) *NatGatewayClient {
+	return &NatGatewayClient{
+		client: client,
+	}
+}
+
+// NatGatewayClient is data service nat gateway api client.
+type NatGatewayClient struct {
+	client rest.ClientInterface
+}
+
+// ListNatGateway list nat gateway.
+func (cli *NatGatewayClient) ListNatGateway(kt *kit.Kit, req *core.ListReq) (*datanat.NatGatewayListResult,
+	error) {
+
+	return common.Request[core.ListReq, datanat.NatGatewayListResult](cli.client, rest.POST, kt, req,
+		"/nat_gateways/list")
+}
+
+// BatchUpdateNatGatewayBiz batch update nat gateway bk_biz_id.
+func (cli *NatGatewayClient) BatchUpdateNatGatewayBiz(kt *kit.Kit, req *datanat.NatGatewayBizBatchUpdateReq) error {
+	return common.RequestNoResp[datanat.NatGatewayBizBatchUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/nat_gateways/biz/batch/update")
+}
+
+// BatchDeleteNatGateway batch delete nat gateway.
+func (cli *NatGatewayClient) BatchDeleteNatGateway(kt *kit.Kit, req *datanat.NatGatewayBatchDeleteReq) error {
+	return common.RequestNoResp[datanat.NatGatewayBatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/nat_gateways/batch")
+}
+
+// BatchCreateNatGatewayRule batch create nat gateway rule.
+func (cli *NatGatewayClient) BatchCreateNatGatewayRule(kt *kit.Kit, req *datanat.NatGatewayRuleBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[datanat.NatGatewayRuleBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST, kt,
+		req, "/nat_gateways/rules/batch/create")
+}
+
+// BatchUpdateNatGatewayRule batch update nat gateway rule.
+func (cli *NatGatewayClient) BatchUpdateNatGatewayRule(kt *kit.Kit, req *datanat.NatGatewayRuleBatchUpdateReq) error {
+	return common.RequestNoResp[datanat.NatGatewayRuleBatchUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/nat_gateways/rules/batch/update")
+}
+
+// ListNatGatewayRule list nat gateway rule.
+func (cli *NatGatewayClient) ListNatGatewayRule(kt *kit.Kit, req *core.ListReq) (*datanat.NatGatewayRuleListResult,
+	error) {
+
+	return common.Request[core.ListReq, datanat.NatGatewayRuleListResult](cli.client, rest.POST, kt, req,
+		"/nat_gateways/rules/list")
+}
+
+// BatchDeleteNatGatewayRule batch delete nat gateway rule.
+func (cli *NatGatewayClient) BatchDeleteNatGatewayRule(kt *kit.Kit, req *datanat.NatGatewayRuleBatchDeleteReq) error {
+	return common.RequestNoResp[datanat.NatGatewayRuleBatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/nat_gateways/rules/batch")
+}
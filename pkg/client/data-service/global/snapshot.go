@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coresnapshot "hcm/pkg/api/core/cloud/snapshot"
+	datasnapshot "hcm/pkg/api/data-service/cloud/snapshot"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewSnapshotClient create a new snapshot api client.
+func NewSnapshotClient(client rest.ClientInterface) *SnapshotClient {
+	return &SnapshotClient{
+		client: client,
+	}
+}
+
+// SnapshotClient is data service snapshot api client.
+type SnapshotClient struct {
+	client rest.ClientInterface
+}
+
+// ListSnapshot list snapshot.
+func (cli *SnapshotClient) ListSnapshot(kt *kit.Kit, req *core.ListReq) (*datasnapshot.SnapshotListResult, error) {
+	return common.Request[core.ListReq, datasnapshot.SnapshotListResult](cli.client, rest.POST, kt, req,
+		"/snapshots/list")
+}
+
+// BatchDeleteSnapshot batch delete snapshot.
+func (cli *SnapshotClient) BatchDeleteSnapshot(kt *kit.Kit, req *datasnapshot.SnapshotBatchDeleteReq) error {
+	return common.RequestNoResp[datasnapshot.SnapshotBatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/snapshots/batch")
+}
+
+// CreateSnapshotPolicy create snapshot policy.
+func (cli *SnapshotClient) CreateSnapshotPolicy(kt *kit.Kit, req *datasnapshot.PolicyCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[datasnapshot.PolicyCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/snapshots/policies/create")
+}
+
+// ListSnapshotPolicy list snapshot policy.
+func (cli *SnapshotClient) ListSnapshotPolicy(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[coresnapshot.SnapshotPolicy], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[coresnapshot.SnapshotPolicy]](cli.client, rest.POST, kt,
+		req, "/snapshots/policies/list")
+}
+
+// UpdateSnapshotPolicy update snapshot policy.
+func (cli *SnapshotClient) UpdateSnapshotPolicy(kt *kit.Kit, id string, req *datasnapshot.PolicyUpdateReq) error {
+	return common.RequestNoResp[datasnapshot.PolicyUpdateReq](cli.client, rest.PATCH, kt, req,
+		"/snapshots/policies/%s", id)
+}
+
+// BatchDeleteSnapshotPolicy batch delete snapshot policy.
+func (cli *SnapshotClient) BatchDeleteSnapshotPolicy(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/snapshots/policies/batch")
+}
@@ -0,0 +1,83 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	corestack "hcm/pkg/api/core/stack"
+	dsstack "hcm/pkg/api/data-service/stack"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewStackClient create a new stack api client.
+func NewStackClient(client rest.ClientInterface) *StackClient {
+	return &StackClient{
+		client: client,
+	}
+}
+
+// StackClient is data service stack api client.
+type StackClient struct {
+	client rest.ClientInterface
+}
+
+// CreateStack create stack.
+func (cli *StackClient) CreateStack(kt *kit.Kit, req *dsstack.StackCreateReq) (*core.CreateResult, error) {
+
+	return common.Request[dsstack.StackCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/stacks/create")
+}
+
+// ListStack list stack.
+func (cli *StackClient) ListStack(kt *kit.Kit, req *core.ListReq) (*core.ListResultT[corestack.Stack], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corestack.Stack]](cli.client, rest.POST, kt, req,
+		"/stacks/list")
+}
+
+// UpdateStack update stack.
+func (cli *StackClient) UpdateStack(kt *kit.Kit, id string, req *dsstack.StackUpdateReq) error {
+
+	return common.RequestNoResp[dsstack.StackUpdateReq](cli.client, rest.PATCH, kt, req, "/stacks/%s", id)
+}
+
+// BatchDeleteStack batch delete stack.
+func (cli *StackClient) BatchDeleteStack(kt *kit.Kit, req *core.BatchDeleteReq) error {
+
+	return common.RequestNoResp[core.BatchDeleteReq](cli.client, rest.DELETE, kt, req, "/stacks/batch")
+}
+
+// CreateStackVersion create stack version.
+func (cli *StackClient) CreateStackVersion(kt *kit.Kit, req *dsstack.VersionCreateReq) (
+	*dsstack.VersionCreateResult, error) {
+
+	return common.Request[dsstack.VersionCreateReq, dsstack.VersionCreateResult](cli.client, rest.POST, kt, req,
+		"/stacks/versions/create")
+}
+
+// ListStackVersion list stack version.
+func (cli *StackClient) ListStackVersion(kt *kit.Kit, req *core.ListReq) (
+	*core.ListResultT[corestack.Version], error) {
+
+	return common.Request[core.ListReq, core.ListResultT[corestack.Version]](cli.client, rest.POST, kt, req,
+		"/stacks/versions/list")
+}
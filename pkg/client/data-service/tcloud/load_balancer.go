@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	datalb "hcm/pkg/api/data-service/cloud/load-balancer"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// BatchCreateLoadBalancer batch create load balancer.
+func (cli *restClient) BatchCreateLoadBalancer(kt *kit.Kit,
+	req *datalb.LoadBalancerBatchCreateReq[corelb.TCloudLoadBalancerExtension]) (*core.BatchCreateResult, error) {
+
+	return common.Request[datalb.LoadBalancerBatchCreateReq[corelb.TCloudLoadBalancerExtension], core.BatchCreateResult](
+		cli.client, rest.POST, kt, req, "/load_balancers/batch/create")
+}
+
+// BatchUpdateLoadBalancer batch update load balancer.
+func (cli *restClient) BatchUpdateLoadBalancer(kt *kit.Kit,
+	req *datalb.LoadBalancerBatchUpdateReq[corelb.TCloudLoadBalancerExtension]) error {
+
+	return common.RequestNoResp[datalb.LoadBalancerBatchUpdateReq[corelb.TCloudLoadBalancerExtension]](
+		cli.client, rest.PATCH, kt, req, "/load_balancers/batch/update")
+}
+
+// ListLoadBalancerExt list load balancer with extension.
+func (cli *restClient) ListLoadBalancerExt(kt *kit.Kit, req *core.ListReq) (
+	*datalb.LoadBalancerExtListResult[corelb.TCloudLoadBalancerExtension], error) {
+
+	return common.Request[core.ListReq, datalb.LoadBalancerExtListResult[corelb.TCloudLoadBalancerExtension]](
+		cli.client, rest.POST, kt, req, "/load_balancers/list")
+}
+
+// GetLoadBalancer get load balancer with extension.
+func (cli *restClient) GetLoadBalancer(kt *kit.Kit, id string) (
+	*corelb.LoadBalancer[corelb.TCloudLoadBalancerExtension], error) {
+
+	return common.Request[common.Empty, corelb.LoadBalancer[corelb.TCloudLoadBalancerExtension]](
+		cli.client, rest.GET, kt, common.NoData, "/load_balancers/%s", id)
+}
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"hcm/pkg/api/core"
+	corenat "hcm/pkg/api/core/cloud/nat-gateway"
+	datanat "hcm/pkg/api/data-service/cloud/nat-gateway"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// BatchCreateNatGateway batch create nat gateway.
+func (cli *restClient) BatchCreateNatGateway(kt *kit.Kit,
+	req *datanat.NatGatewayBatchCreateReq[corenat.TCloudNatGatewayExtension]) (*core.BatchCreateResult, error) {
+
+	return common.Request[datanat.NatGatewayBatchCreateReq[corenat.TCloudNatGatewayExtension],
+		core.BatchCreateResult](cli.client, rest.POST, kt, req, "/nat_gateways/batch/create")
+}
+
+// BatchUpdateNatGateway batch update nat gateway.
+func (cli *restClient) BatchUpdateNatGateway(kt *kit.Kit,
+	req *datanat.NatGatewayBatchUpdateReq[corenat.TCloudNatGatewayExtension]) error {
+
+	return common.RequestNoResp[datanat.NatGatewayBatchUpdateReq[corenat.TCloudNatGatewayExtension]](
+		cli.client, rest.PATCH, kt, req, "/nat_gateways/batch/update")
+}
+
+// ListNatGatewayExt list nat gateway with extension.
+func (cli *restClient) ListNatGatewayExt(kt *kit.Kit, req *core.ListReq) (
+	*datanat.NatGatewayExtListResult[corenat.TCloudNatGatewayExtension], error) {
+
+	return common.Request[core.ListReq, datanat.NatGatewayExtListResult[corenat.TCloudNatGatewayExtension]](
+		cli.client, rest.POST, kt, req, "/nat_gateways/list")
+}
+
+// GetNatGateway get nat gateway with extension.
+func (cli *restClient) GetNatGateway(kt *kit.Kit, id string) (
+	*corenat.NatGateway[corenat.TCloudNatGatewayExtension], error) {
+
+	return common.Request[common.Empty, corenat.NatGateway[corenat.TCloudNatGatewayExtension]](
+		cli.client, rest.GET, kt, common.NoData, "/nat_gateways/%s", id)
+}
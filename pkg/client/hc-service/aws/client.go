@@ -30,6 +30,11 @@ type Client struct {
 	Vpc           *VpcClient
 	Subnet        *SubnetClient
 	Eip           *EipClient
+	LoadBalancer  *LoadBalancerClient
+	Snapshot      *SnapshotClient
+	KeyPair       *KeyPairClient
+	NatGateway    *NatGatewayClient
+	ResourceTag   *ResourceTagClient
 	Disk          *DiskClient
 	Zone          *ZoneClient
 	Region        *RegionClient
@@ -38,6 +43,7 @@ type Client struct {
 	RouteTable    *RouteTableClient
 	InstanceType  *InstanceTypeClient
 	Bill          *BillClient
+	AuditTrail    *AuditTrailClient
 }
 
 // NewClient create a new aws api client.
@@ -48,6 +54,11 @@ func NewClient(client rest.ClientInterface) *Client {
 		Vpc:           NewVpcClient(client),
 		Subnet:        NewSubnetClient(client),
 		Eip:           NewEipClient(client),
+		LoadBalancer:  NewLoadBalancerClient(client),
+		Snapshot:      NewSnapshotClient(client),
+		KeyPair:       NewKeyPairClient(client),
+		NatGateway:    NewNatGatewayClient(client),
+		ResourceTag:   NewResourceTagClient(client),
 		Disk:          NewCloudDiskClient(client),
 		Zone:          NewZoneClient(client),
 		Region:        NewRegionClient(client),
@@ -56,5 +67,6 @@ func NewClient(client rest.ClientInterface) *Client {
 		RouteTable:    NewRouteTableClient(client),
 		InstanceType:  NewInstanceTypeClient(client),
 		Bill:          NewBillClient(client),
+		AuditTrail:    NewAuditTrailClient(client),
 	}
 }
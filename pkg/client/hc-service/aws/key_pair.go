@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package aws
+
+import (
+	"hcm/pkg/api/core"
+	hckeypair "hcm/pkg/api/hc-service/key-pair"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewKeyPairClient create a new key pair api client.
+func NewKeyPairClient(client rest.ClientInterface) *KeyPairClient {
+	return &KeyPairClient{
+		client: client,
+	}
+}
+
+// KeyPairClient is hc service key pair api client.
+type KeyPairClient struct {
+	client rest.ClientInterface
+}
+
+// SyncKeyPair sync key pair.
+func (cli *KeyPairClient) SyncKeyPair(kt *kit.Kit, req *sync.AwsSyncReq) error {
+	return common.RequestNoResp[sync.AwsSyncReq](cli.client, rest.POST, kt, req, "/key_pairs/sync")
+}
+
+// CreateKeyPair import public key to create key pair.
+func (cli *KeyPairClient) CreateKeyPair(kt *kit.Kit, req *hckeypair.KeyPairCreateReq) (*core.CreateResult,
+	error) {
+
+	return common.Request[hckeypair.KeyPairCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/key_pairs/create")
+}
+
+// DeleteKeyPair delete key pair.
+func (cli *KeyPairClient) DeleteKeyPair(kt *kit.Kit, id string) error {
+	return common.RequestNoResp[common.Empty](cli.client, rest.DELETE, kt, common.NoData, "/key_pairs/%s", id)
+}
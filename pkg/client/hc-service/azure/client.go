@@ -30,6 +30,9 @@ type Client struct {
 	Vpc              *VpcClient
 	Subnet           *SubnetClient
 	Eip              *EipClient
+	LoadBalancer     *LoadBalancerClient
+	Snapshot         *SnapshotClient
+	ResourceTag      *ResourceTagClient
 	Disk             *DiskClient
 	Region           *RegionClient
 	ResourceGroup    *ResourceGroupClient
@@ -49,6 +52,9 @@ func NewClient(client rest.ClientInterface) *Client {
 		Vpc:              NewVpcClient(client),
 		Subnet:           NewSubnetClient(client),
 		Eip:              NewEipClient(client),
+		LoadBalancer:     NewLoadBalancerClient(client),
+		Snapshot:         NewSnapshotClient(client),
+		ResourceTag:      NewResourceTagClient(client),
 		Disk:             NewCloudDiskClient(client),
 		Region:           NewRegionClient(client),
 		ResourceGroup:    NewResourceGroupClient(client),
@@ -36,6 +36,10 @@ type Client struct {
 	Zone             *ZoneClient
 	Region           *RegionClient
 	Eip              *EipClient
+	LoadBalancer     *LoadBalancerClient
+	Snapshot         *SnapshotClient
+	KeyPair          *KeyPairClient
+	ResourceTag      *ResourceTagClient
 	InstanceType     *InstanceTypeClient
 	NetworkInterface *NetworkInterfaceClient
 	Bill             *BillClient
@@ -55,6 +59,10 @@ func NewClient(client rest.ClientInterface) *Client {
 		Zone:             NewZoneClient(client),
 		Region:           NewRegionClient(client),
 		Eip:              NewEipClient(client),
+		LoadBalancer:     NewLoadBalancerClient(client),
+		Snapshot:         NewSnapshotClient(client),
+		KeyPair:          NewKeyPairClient(client),
+		ResourceTag:      NewResourceTagClient(client),
 		InstanceType:     NewInstanceTypeClient(client),
 		NetworkInterface: NewNetworkInterfaceClient(client),
 		Bill:             NewBillClient(client),
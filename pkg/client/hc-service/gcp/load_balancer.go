@@ -0,0 +1,82 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package gcp
+
+import (
+	"hcm/pkg/api/core"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewLoadBalancerClient create a new load balancer api client.
+func NewLoadBalancerClient(client rest.ClientInterface) *LoadBalancerClient {
+	return &LoadBalancerClient{
+		client: client,
+	}
+}
+
+// LoadBalancerClient is hc service load balancer api client.
+type LoadBalancerClient struct {
+	client rest.ClientInterface
+}
+
+// SyncLoadBalancer sync load balancer.
+func (cli *LoadBalancerClient) SyncLoadBalancer(kt *kit.Kit, req *sync.GcpSyncReq) error {
+	return common.RequestNoResp[sync.GcpSyncReq](cli.client, rest.POST, kt, req, "/load_balancers/sync")
+}
+
+// CreateLoadBalancer create load balancer.
+func (cli *LoadBalancerClient) CreateLoadBalancer(kt *kit.Kit, req *hclb.GcpLoadBalancerCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[hclb.GcpLoadBalancerCreateReq, core.BatchCreateResult](cli.client, rest.POST, kt, req,
+		"/load_balancers/create")
+}
+
+// DeleteLoadBalancer delete load balancer.
+func (cli *LoadBalancerClient) DeleteLoadBalancer(kt *kit.Kit, id string) error {
+	return common.RequestNoResp[common.Empty](cli.client, rest.DELETE, kt, common.NoData, "/load_balancers/%s", id)
+}
+
+// CreateListener create load balancer listener.
+func (cli *LoadBalancerClient) CreateListener(kt *kit.Kit, req *hclb.ListenerCreateReq) (*core.CreateResult,
+	error) {
+
+	return common.Request[hclb.ListenerCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/listeners/create")
+}
+
+// BatchDeleteListener batch delete load balancer listener.
+func (cli *LoadBalancerClient) BatchDeleteListener(kt *kit.Kit, req *hclb.ListenerBatchDeleteReq) error {
+	return common.RequestNoResp[hclb.ListenerBatchDeleteReq](cli.client, rest.DELETE, kt, req, "/listeners/batch")
+}
+
+// RegisterTargets register targets to listener.
+func (cli *LoadBalancerClient) RegisterTargets(kt *kit.Kit, req *hclb.TargetOperateReq) error {
+	return common.RequestNoResp[hclb.TargetOperateReq](cli.client, rest.POST, kt, req, "/targets/register")
+}
+
+// DeregisterTargets deregister targets from listener.
+func (cli *LoadBalancerClient) DeregisterTargets(kt *kit.Kit, req *hclb.TargetOperateReq) error {
+	return common.RequestNoResp[hclb.TargetOperateReq](cli.client, rest.POST, kt, req, "/targets/deregister")
+}
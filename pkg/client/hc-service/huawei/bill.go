@@ -24,7 +24,9 @@ import (
 	"net/http"
 
 	hcbillservice "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/client/common"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
 	"hcm/pkg/rest"
 )
 
@@ -64,3 +66,11 @@ func (v *BillClient) List(ctx context.Context, h http.Header, req *hcbillservice
 
 	return resp.Data, nil
 }
+
+// PullDaily pull bill of one day and aggregate it by region, product and currency.
+func (v *BillClient) PullDaily(kt *kit.Kit, req *hcbillservice.BillDailyPullReq) (
+	*hcbillservice.BillDailyPullResult, error) {
+
+	return common.Request[hcbillservice.BillDailyPullReq, hcbillservice.BillDailyPullResult](v.client, rest.POST, kt,
+		req, "/bills/daily/pull")
+}
@@ -30,6 +30,11 @@ type Client struct {
 	Vpc              *VpcClient
 	Subnet           *SubnetClient
 	Eip              *EipClient
+	LoadBalancer     *LoadBalancerClient
+	Snapshot         *SnapshotClient
+	KeyPair          *KeyPairClient
+	NatGateway       *NatGatewayClient
+	ResourceTag      *ResourceTagClient
 	Disk             *DiskClient
 	Zone             *ZoneClient
 	Region           *RegionClient
@@ -39,6 +44,7 @@ type Client struct {
 	InstanceType     *InstanceTypeClient
 	NetworkInterface *NetworkInterfaceClient
 	Bill             *BillClient
+	AuditTrail       *AuditTrailClient
 }
 
 // NewClient create a new huawei api client.
@@ -50,6 +56,11 @@ func NewClient(client rest.ClientInterface) *Client {
 		RouteTable:       NewRouteTableClient(client),
 		SecurityGroup:    NewCloudSecurityGroupClient(client),
 		Eip:              NewEipClient(client),
+		LoadBalancer:     NewLoadBalancerClient(client),
+		Snapshot:         NewSnapshotClient(client),
+		KeyPair:          NewKeyPairClient(client),
+		NatGateway:       NewNatGatewayClient(client),
+		ResourceTag:      NewResourceTagClient(client),
 		Disk:             NewCloudDiskClient(client),
 		Zone:             NewZoneClient(client),
 		Region:           NewRegionClient(client),
@@ -58,5 +69,6 @@ func NewClient(client rest.ClientInterface) *Client {
 		InstanceType:     NewInstanceTypeClient(client),
 		NetworkInterface: NewNetworkInterfaceClient(client),
 		Bill:             NewBillClient(client),
+		AuditTrail:       NewAuditTrailClient(client),
 	}
 }
@@ -30,6 +30,11 @@ type Client struct {
 	SecurityGroup *SecurityGroupClient
 	Vpc           *VpcClient
 	Eip           *EipClient
+	LoadBalancer  *LoadBalancerClient
+	Snapshot      *SnapshotClient
+	KeyPair       *KeyPairClient
+	NatGateway    *NatGatewayClient
+	ResourceTag   *ResourceTagClient
 	Disk          *DiskClient
 	Zone          *ZoneClient
 	Region        *RegionClient
@@ -39,6 +44,7 @@ type Client struct {
 	InstanceType  *InstanceTypeClient
 	Bill          *BillClient
 	ArgsTpl       *ArgsTplClient
+	AuditTrail    *AuditTrailClient
 }
 
 // NewClient create a new tcloud api client.
@@ -49,6 +55,11 @@ func NewClient(client rest.ClientInterface) *Client {
 		Subnet:        NewSubnetClient(client),
 		Vpc:           NewVpcClient(client),
 		Eip:           NewEipClient(client),
+		LoadBalancer:  NewLoadBalancerClient(client),
+		Snapshot:      NewSnapshotClient(client),
+		KeyPair:       NewKeyPairClient(client),
+		NatGateway:    NewNatGatewayClient(client),
+		ResourceTag:   NewResourceTagClient(client),
 		Disk:          NewCloudDiskClient(client),
 		Zone:          NewZoneClient(client),
 		Region:        NewRegionClient(client),
@@ -58,5 +69,6 @@ func NewClient(client rest.ClientInterface) *Client {
 		InstanceType:  NewInstanceTypeClient(client),
 		Bill:          NewBillClient(client),
 		ArgsTpl:       NewArgsTplClient(client),
+		AuditTrail:    NewAuditTrailClient(client),
 	}
 }
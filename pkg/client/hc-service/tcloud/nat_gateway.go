@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"hcm/pkg/api/core"
+	hcnat "hcm/pkg/api/hc-service/nat-gateway"
+	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewNatGatewayClient create a new nat gateway api client.
+func NewNatGatewayClient(client rest.ClientInterface) *NatGatewayClient {
+	return &NatGatewayClient{
+		client: client,
+	}
+}
+
+// NatGatewayClient is hc service nat gateway api client.
+type NatGatewayClient struct {
+	client rest.ClientInterface
+}
+
+// SyncNatGateway sync nat gateway.
+func (cli *NatGatewayClient) SyncNatGateway(kt *kit.Kit, req *sync.TCloudSyncReq) error {
+	return common.RequestNoResp[sync.TCloudSyncReq](cli.client, rest.POST, kt, req, "/nat_gateways/sync")
+}
+
+// CreateNatGateway create nat gateway.
+func (cli *NatGatewayClient) CreateNatGateway(kt *kit.Kit, req *hcnat.TCloudNatGatewayCreateReq) (*core.CreateResult,
+	error) {
+
+	return common.Request[hcnat.TCloudNatGatewayCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/nat_gateways/create")
+}
+
+// DeleteNatGateway delete nat gateway.
+func (cli *NatGatewayClient) DeleteNatGateway(kt *kit.Kit, id string) error {
+	return common.RequestNoResp[common.Empty](cli.client, rest.DELETE, kt, common.NoData, "/nat_gateways/%s", id)
+}
+
+// CreateNatRule create nat gateway snat/dnat rule.
+func (cli *NatGatewayClient) CreateNatRule(kt *kit.Kit, req *hcnat.NatRuleCreateReq) (*core.CreateResult, error) {
+	return common.Request[hcnat.NatRuleCreateReq, core.CreateResult](cli.client, rest.POST, kt, req,
+		"/nat_gateways/rules/create")
+}
+
+// UpdateNatRule update nat gateway snat/dnat rule.
+func (cli *NatGatewayClient) UpdateNatRule(kt *kit.Kit, id string, req *hcnat.NatRuleUpdateReq) error {
+	return common.RequestNoResp[hcnat.NatRuleUpdateReq](cli.client, rest.PATCH, kt, req, "/nat_gateways/rules/%s",
+		id)
+}
+
+// BatchDeleteNatRule batch delete nat gateway snat/dnat rule.
+func (cli *NatGatewayClient) BatchDeleteNatRule(kt *kit.Kit, req *hcnat.NatRuleBatchDeleteReq) error {
+	return common.RequestNoResp[hcnat.NatRuleBatchDeleteReq](cli.client, rest.DELETE, kt, req,
+		"/nat_gateways/rules/batch")
+}
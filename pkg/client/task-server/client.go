@@ -155,3 +155,306 @@ func (c *Client) GetTask(kt *kit.Kit, id string) (*coreasync.AsyncFlowTask, erro
 
 	return resp.Data, err
 }
+
+// PauseFlow pause flow, executing tasks will run to the end.
+func (c *Client) PauseFlow(kt *kit.Kit, id string) error {
+	resp := new(rest.BaseResp)
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		SubResourcef("/flows/%s/pause", id).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// ResumeFlow resume paused flow.
+func (c *Client) ResumeFlow(kt *kit.Kit, id string) error {
+	resp := new(rest.BaseResp)
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		SubResourcef("/flows/%s/resume", id).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// RetryFlow retry failed or cancelled tasks of flow.
+func (c *Client) RetryFlow(kt *kit.Kit, id string) error {
+	resp := new(rest.BaseResp)
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		SubResourcef("/flows/%s/retry", id).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// CreateScheduledFlow add scheduled flow.
+func (c *Client) CreateScheduledFlow(kt *kit.Kit, request *apits.AddScheduledFlowReq) (*core.CreateResult, error) {
+	resp := new(core.CreateResp)
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(request).
+		SubResourcef("/scheduled_flows/create").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// ListFlowSchedule list flow schedule.
+func (c *Client) ListFlowSchedule(kt *kit.Kit, req *core.ListReq) (*apits.ListFlowScheduleResult, error) {
+	resp := new(core.BaseResp[*apits.ListFlowScheduleResult])
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/flow_schedules/list").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// GetFlowSchedule get flow schedule.
+func (c *Client) GetFlowSchedule(kt *kit.Kit, id string) (*coreasync.AsyncFlowSchedule, error) {
+	resp := new(core.BaseResp[*coreasync.AsyncFlowSchedule])
+
+	err := c.client.Get().
+		WithContext(kt.Ctx).
+		SubResourcef("/flow_schedules/%s", id).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// UpdateFlowSchedule enable or disable flow schedule.
+func (c *Client) UpdateFlowSchedule(kt *kit.Kit, id string, req *apits.UpdateFlowScheduleReq) error {
+	resp := new(rest.BaseResp)
+
+	err := c.client.Patch().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/flow_schedules/%s", id).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// BatchDeleteFlowSchedule batch delete flow schedule.
+func (c *Client) BatchDeleteFlowSchedule(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	resp := new(rest.BaseResp)
+
+	err := c.client.Delete().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/flow_schedules/batch").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// WatchFlowEvent long poll flow events after cursor.
+func (c *Client) WatchFlowEvent(kt *kit.Kit, req *apits.WatchFlowEventReq) (*apits.WatchFlowEventResult, error) {
+	resp := new(core.BaseResp[*apits.WatchFlowEventResult])
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/flow_events/watch").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// CreateFlowWebhook create flow webhook.
+func (c *Client) CreateFlowWebhook(kt *kit.Kit, req *apits.CreateFlowWebhookReq) (*core.CreateResult, error) {
+	resp := new(core.BaseResp[*core.CreateResult])
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/flow_webhooks/create").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// ListFlowWebhook list flow webhook.
+func (c *Client) ListFlowWebhook(kt *kit.Kit, req *core.ListReq) (*apits.ListFlowWebhookResult, error) {
+	resp := new(core.BaseResp[*apits.ListFlowWebhookResult])
+
+	err := c.client.Post().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/flow_webhooks/list").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// GetFlowWebhook get flow webhook.
+func (c *Client) GetFlowWebhook(kt *kit.Kit, id string) (*coreasync.AsyncFlowWebhook, error) {
+	resp := new(core.BaseResp[*coreasync.AsyncFlowWebhook])
+
+	err := c.client.Get().
+		WithContext(kt.Ctx).
+		SubResourcef("/flow_webhooks/%s", id).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code != errf.OK {
+		return nil, errf.New(resp.Code, resp.Message)
+	}
+
+	return resp.Data, nil
+}
+
+// UpdateFlowWebhook update flow webhook.
+func (c *Client) UpdateFlowWebhook(kt *kit.Kit, id string, req *apits.UpdateFlowWebhookReq) error {
+	resp := new(rest.BaseResp)
+
+	err := c.client.Patch().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/flow_webhooks/%s", id).
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
+// BatchDeleteFlowWebhook batch delete flow webhook.
+func (c *Client) BatchDeleteFlowWebhook(kt *kit.Kit, req *core.BatchDeleteReq) error {
+	resp := new(rest.BaseResp)
+
+	err := c.client.Delete().
+		WithContext(kt.Ctx).
+		Body(req).
+		SubResourcef("/flow_webhooks/batch").
+		WithHeaders(kt.Header()).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
@@ -33,6 +33,12 @@ func (a ApplicationType) Validate() error {
 	case CreateCvm:
 	case CreateVpc:
 	case CreateDisk:
+	case DeleteCvm:
+	case RecycleCvm:
+	case ChangeSecurityGroupRule:
+	case CreateSubnet:
+	case CreateEip:
+	case AssociateEip:
 	default:
 		return fmt.Errorf("unsupported application type: %s", a)
 	}
@@ -49,8 +55,37 @@ const (
 	CreateVpc ApplicationType = "create_vpc"
 	// CreateDisk 创建云盘
 	CreateDisk ApplicationType = "create_disk"
+	// DeleteCvm 删除虚拟机
+	DeleteCvm ApplicationType = "delete_cvm"
+	// RecycleCvm 回收虚拟机
+	RecycleCvm ApplicationType = "recycle_cvm"
+	// ChangeSecurityGroupRule 变更安全组规则
+	ChangeSecurityGroupRule ApplicationType = "change_security_group_rule"
+	// CreateSubnet 创建子网
+	CreateSubnet ApplicationType = "create_subnet"
+	// CreateEip 创建弹性IP
+	CreateEip ApplicationType = "create_eip"
+	// AssociateEip 绑定弹性IP
+	AssociateEip ApplicationType = "associate_eip"
 )
 
+// ValidatePolicyType 校验申请单类型是否可以通过业务审批策略配置，资源申请类的申请单总是需要审批，
+// 只有删除、回收以及网络变更等原本直接执行的操作可以由业务决定是否需要审批
+func (a ApplicationType) ValidatePolicyType() error {
+	switch a {
+	case DeleteCvm:
+	case RecycleCvm:
+	case ChangeSecurityGroupRule:
+	case CreateSubnet:
+	case CreateEip:
+	case AssociateEip:
+	default:
+		return fmt.Errorf("application type: %s can not be configured in approval policy", a)
+	}
+
+	return nil
+}
+
 type ApplicationStatus string
 
 const (
@@ -71,3 +106,28 @@ const (
 	// DeliverError 单据交付异常
 	DeliverError ApplicationStatus = "deliver_error"
 )
+
+// ApplicationDeliverStep 申请单交付任务流的步骤
+type ApplicationDeliverStep string
+
+// Validate the ApplicationDeliverStep is valid or not
+func (s ApplicationDeliverStep) Validate() error {
+	switch s {
+	case CheckDeliverStep:
+	case DeliverDeliverStep:
+	case FailDeliverStep:
+	default:
+		return fmt.Errorf("unsupported application deliver step: %s", s)
+	}
+
+	return nil
+}
+
+const (
+	// CheckDeliverStep 解析申请单内容并再次校验交付请求，可以重试
+	CheckDeliverStep ApplicationDeliverStep = "check"
+	// DeliverDeliverStep 执行资源交付，同一次交付只会执行一次
+	DeliverDeliverStep ApplicationDeliverStep = "deliver"
+	// FailDeliverStep 交付任务流存在失败任务时，将申请单更新为交付异常
+	FailDeliverStep ApplicationDeliverStep = "fail"
+)
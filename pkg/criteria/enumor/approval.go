@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// ApprovalTicketStatus 内置审批引擎的审批单据状态
+type ApprovalTicketStatus string
+
+const (
+	// RunningApprovalTicketStatus 审批中
+	RunningApprovalTicketStatus ApprovalTicketStatus = "running"
+	// ApprovedApprovalTicketStatus 全部审批节点已通过
+	ApprovedApprovalTicketStatus ApprovalTicketStatus = "approved"
+	// RejectedApprovalTicketStatus 某个审批节点拒绝
+	RejectedApprovalTicketStatus ApprovalTicketStatus = "rejected"
+	// WithdrawnApprovalTicketStatus 提单人撤销
+	WithdrawnApprovalTicketStatus ApprovalTicketStatus = "withdrawn"
+)
+
+// IsFinished return whether the approval ticket is finished.
+func (s ApprovalTicketStatus) IsFinished() bool {
+	return s != RunningApprovalTicketStatus
+}
+
+// ApprovalAction 审批节点上的审批操作
+type ApprovalAction string
+
+const (
+	// ApproveApprovalAction 通过
+	ApproveApprovalAction ApprovalAction = "approve"
+	// RejectApprovalAction 拒绝
+	RejectApprovalAction ApprovalAction = "reject"
+	// TransferApprovalAction 转审，将当前节点的审批人转给其他人
+	TransferApprovalAction ApprovalAction = "transfer"
+	// WithdrawApprovalAction 提单人撤销
+	WithdrawApprovalAction ApprovalAction = "withdraw"
+)
+
+// ApprovalTicketView 审批单据的查询视角
+type ApprovalTicketView string
+
+const (
+	// MyTodoApprovalTicketView 待我审批的单据
+	MyTodoApprovalTicketView ApprovalTicketView = "my_todo"
+	// MyCreatedApprovalTicketView 我提交的单据
+	MyCreatedApprovalTicketView ApprovalTicketView = "my_created"
+)
+
+// Validate ApprovalTicketView.
+func (v ApprovalTicketView) Validate() error {
+	switch v {
+	case MyTodoApprovalTicketView, MyCreatedApprovalTicketView:
+	default:
+		return fmt.Errorf("unsupported approval ticket view: %s", v)
+	}
+
+	return nil
+}
@@ -37,6 +37,59 @@ const (
 	TaskSuccess TaskState = "success"
 	// TaskFailed task state is failed
 	TaskFailed TaskState = "failed"
+	// TaskSkipped task state is skipped, 任务执行条件不满足或依赖的任务全部被跳过时，任务被跳过
+	TaskSkipped TaskState = "skipped"
+)
+
+// TaskConditionSource is task condition source.
+type TaskConditionSource string
+
+// Validate TaskConditionSource.
+func (v TaskConditionSource) Validate() error {
+	switch v {
+	case TaskConditionResult, TaskConditionShareData:
+	default:
+		return fmt.Errorf("unsupported task condition source: %s", v)
+	}
+
+	return nil
+}
+
+const (
+	// TaskConditionResult 条件取值来源于任务流中指定任务的执行结果
+	TaskConditionResult TaskConditionSource = "result"
+	// TaskConditionShareData 条件取值来源于任务流共享数据
+	TaskConditionShareData TaskConditionSource = "share_data"
+)
+
+// TaskConditionOp is task condition operator.
+type TaskConditionOp string
+
+// Validate TaskConditionOp.
+func (v TaskConditionOp) Validate() error {
+	switch v {
+	case TaskConditionEqual, TaskConditionNotEqual, TaskConditionIn, TaskConditionNotIn, TaskConditionExist,
+		TaskConditionNotExist:
+	default:
+		return fmt.Errorf("unsupported task condition op: %s", v)
+	}
+
+	return nil
+}
+
+const (
+	// TaskConditionEqual 取值等于指定值
+	TaskConditionEqual TaskConditionOp = "eq"
+	// TaskConditionNotEqual 取值不等于指定值
+	TaskConditionNotEqual TaskConditionOp = "neq"
+	// TaskConditionIn 取值在指定值数组中
+	TaskConditionIn TaskConditionOp = "in"
+	// TaskConditionNotIn 取值不在指定值数组中
+	TaskConditionNotIn TaskConditionOp = "nin"
+	// TaskConditionExist 取值存在
+	TaskConditionExist TaskConditionOp = "exist"
+	// TaskConditionNotExist 取值不存在
+	TaskConditionNotExist TaskConditionOp = "not_exist"
 )
 
 // FlowState is flow state.
@@ -49,6 +102,8 @@ const (
 	FlowScheduled FlowState = "scheduled"
 	// FlowRunning flow state is running
 	FlowRunning FlowState = "running"
+	// FlowPaused flow state is paused, executing tasks will run to the end, but no new task will be executed.
+	FlowPaused FlowState = "paused"
 	// FlowCancel flow state is cancel
 	FlowCancel FlowState = "cancel"
 	// FlowSuccess flow state is success
@@ -57,6 +112,71 @@ const (
 	FlowFailed FlowState = "failed"
 )
 
+// FlowScheduleState is flow schedule state.
+type FlowScheduleState string
+
+// Validate FlowScheduleState.
+func (v FlowScheduleState) Validate() error {
+	switch v {
+	case FlowScheduleEnabled, FlowScheduleDisabled:
+	default:
+		return fmt.Errorf("unsupported flow schedule state: %s", v)
+	}
+
+	return nil
+}
+
+const (
+	// FlowScheduleEnabled flow schedule is enabled, due schedule will be materialized into flow.
+	FlowScheduleEnabled FlowScheduleState = "enabled"
+	// FlowScheduleDisabled flow schedule is disabled.
+	FlowScheduleDisabled FlowScheduleState = "disabled"
+	// FlowScheduleFinished one-shot flow schedule has been materialized, will not trigger again.
+	FlowScheduleFinished FlowScheduleState = "finished"
+)
+
+// AsyncEventType is async flow event type.
+type AsyncEventType string
+
+// Validate AsyncEventType.
+func (v AsyncEventType) Validate() error {
+	switch v {
+	case FlowStateEvent, TaskStateEvent:
+	default:
+		return fmt.Errorf("unsupported async event type: %s", v)
+	}
+
+	return nil
+}
+
+const (
+	// FlowStateEvent flow state changed event.
+	FlowStateEvent AsyncEventType = "flow_state"
+	// TaskStateEvent task state changed event.
+	TaskStateEvent AsyncEventType = "task_state"
+)
+
+// FlowWebhookState is flow event webhook state.
+type FlowWebhookState string
+
+// Validate FlowWebhookState.
+func (v FlowWebhookState) Validate() error {
+	switch v {
+	case FlowWebhookEnabled, FlowWebhookDisabled:
+	default:
+		return fmt.Errorf("unsupported flow webhook state: %s", v)
+	}
+
+	return nil
+}
+
+const (
+	// FlowWebhookEnabled flow webhook is enabled, events will be delivered to webhook url.
+	FlowWebhookEnabled FlowWebhookState = "enabled"
+	// FlowWebhookDisabled flow webhook is disabled.
+	FlowWebhookDisabled FlowWebhookState = "disabled"
+)
+
 // BackendType is backend type.
 type BackendType string
 
@@ -64,6 +184,8 @@ type BackendType string
 func (v BackendType) Validate() error {
 	switch v {
 	case BackendMysql:
+	case BackendMemory:
+	case BackendEtcd:
 	default:
 		return fmt.Errorf("unsupported backend type: %s", v)
 	}
@@ -74,4 +196,8 @@ func (v BackendType) Validate() error {
 const (
 	// BackendMysql mysql backend
 	BackendMysql BackendType = "mysql"
+	// BackendMemory memory backend, 数据仅保存在进程内存中，用于单元测试
+	BackendMemory BackendType = "memory"
+	// BackendEtcd etcd backend
+	BackendEtcd BackendType = "etcd"
 )
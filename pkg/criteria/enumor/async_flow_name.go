@@ -33,6 +33,10 @@ func (v FlowName) Validate() error {
 	case FlowNormalTest, FlowSleepTest:
 	case FlowDeleteSecurityGroup, FlowCreateHuaweiSGRule:
 	case FlowDeleteEIP:
+	case FlowSnapshotPolicy:
+	case FlowReconcileDrift:
+	case FlowApplyStack, FlowDestroyStack:
+	case FlowDeliverApplication:
 
 	default:
 		return fmt.Errorf("unsupported tpl: %s", v)
@@ -79,3 +83,29 @@ const (
 	// FlowDeleteEIP ...
 	FlowDeleteEIP FlowName = "delete_eip"
 )
+
+// 快照相关Flow
+const (
+	// FlowSnapshotPolicy 执行快照策略，创建快照并清理超出保留数量的快照
+	FlowSnapshotPolicy FlowName = "snapshot_policy"
+)
+
+// 配置漂移相关Flow
+const (
+	// FlowReconcileDrift 将发生漂移的资源恢复为交付时的期望状态
+	FlowReconcileDrift FlowName = "reconcile_drift"
+)
+
+// 资源栈相关Flow
+const (
+	// FlowApplyStack 按资源栈模版创建资源，失败时回滚本次已创建的资源
+	FlowApplyStack FlowName = "apply_stack"
+	// FlowDestroyStack 销毁资源栈下的全部资源
+	FlowDestroyStack FlowName = "destroy_stack"
+)
+
+// 申请单相关Flow
+const (
+	// FlowDeliverApplication 审批通过后交付申请单的资源
+	FlowDeliverApplication FlowName = "deliver_application"
+)
@@ -32,9 +32,14 @@ func (v ActionName) Validate() error {
 
 	case ActionDeleteFirewallRule:
 
-	case ActionDeleteSubnet:
-	case ActionDeleteSecurityGroup, ActionCreateHuaweiSGRule:
-	case ActionDeleteEIP:
+	case ActionCreateVpc:
+	case ActionCreateSubnet, ActionDeleteSubnet:
+	case ActionCreateSecurityGroup, ActionDeleteSecurityGroup, ActionCreateHuaweiSGRule:
+	case ActionCreateDisk:
+	case ActionCreateEIP, ActionDeleteEIP:
+	case ActionCreateSnapshot, ActionDeleteSnapshot:
+	case ActionDeleteStackResource:
+	case ActionDeliverApplication:
 
 	case VirRoot:
 	case ActionCreateFactoryTest, ActionProduceTest, ActionAssembleTest, ActionSleep:
@@ -64,8 +69,14 @@ const (
 	ActionDeleteFirewallRule ActionName = "delete_firewall_rule"
 )
 
+// vpc相关Action
+const (
+	ActionCreateVpc ActionName = "create_vpc"
+)
+
 // 子网相关Action
 const (
+	ActionCreateSubnet ActionName = "create_subnet"
 	ActionDeleteSubnet ActionName = "delete_subnet"
 )
 
@@ -83,12 +94,36 @@ const (
 
 // Security Group
 const (
+	ActionCreateSecurityGroup ActionName = "create_security_group"
 	ActionDeleteSecurityGroup ActionName = "delete_security_group"
 	ActionCreateHuaweiSGRule  ActionName = "create_huawei_sg_rule"
 )
 
+// 硬盘相关Action
+const (
+	ActionCreateDisk ActionName = "create_disk"
+)
+
 // EIP related action
 const (
+	// ActionCreateEIP ...
+	ActionCreateEIP ActionName = "create_eip"
 	// ActionDeleteEIP ...
 	ActionDeleteEIP ActionName = "delete_eip"
 )
+
+// 快照相关Action
+const (
+	ActionCreateSnapshot ActionName = "create_snapshot"
+	ActionDeleteSnapshot ActionName = "delete_snapshot"
+)
+
+// 资源栈相关Action
+const (
+	ActionDeleteStackResource ActionName = "delete_stack_resource"
+)
+
+// 申请单相关Action
+const (
+	ActionDeliverApplication ActionName = "deliver_application"
+)
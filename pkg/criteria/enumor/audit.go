@@ -41,6 +41,10 @@ const (
 	GcpFirewallRuleAuditResType   AuditResourceType = "gcp_firewall_rule"
 	NetworkInterfaceAuditResType  AuditResourceType = "network_interface"
 	ArgumentTemplateAuditResType  AuditResourceType = "argument_template"
+	LoadBalancerAuditResType      AuditResourceType = "load_balancer"
+	SnapshotAuditResType          AuditResourceType = "snapshot"
+	KeyPairAuditResType           AuditResourceType = "key_pair"
+	NatGatewayAuditResType        AuditResourceType = "nat_gateway"
 )
 
 // AuditResourceTypeEnums resource type map.
@@ -58,6 +62,10 @@ var AuditResourceTypeEnums = map[AuditResourceType]struct{}{
 	GcpFirewallRuleAuditResType:   {},
 	NetworkInterfaceAuditResType:  {},
 	ArgumentTemplateAuditResType:  {},
+	LoadBalancerAuditResType:      {},
+	SnapshotAuditResType:          {},
+	KeyPairAuditResType:           {},
+	NatGatewayAuditResType:        {},
 }
 
 // Exist judge enum value exist.
@@ -98,6 +106,8 @@ const (
 	Bind AuditAction = "bind"
 	// Deliver 交付
 	Deliver AuditAction = "deliver"
+	// DownloadPrivateKey 下载私钥
+	DownloadPrivateKey AuditAction = "download_private_key"
 )
 
 // AuditActionEnums op type map.
@@ -116,6 +126,8 @@ var AuditActionEnums = map[AuditAction]struct{}{
 	Disassociate: {},
 	Bind:         {},
 	Deliver:      {},
+
+	DownloadPrivateKey: {},
 }
 
 // Exist judge enum value exist.
@@ -0,0 +1,66 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// BudgetScope is budget scope.
+type BudgetScope string
+
+// Validate BudgetScope.
+func (s BudgetScope) Validate() error {
+	switch s {
+	case BizBudgetScope:
+	case AccountBudgetScope:
+	default:
+		return fmt.Errorf("unsupported budget scope: %s", s)
+	}
+
+	return nil
+}
+
+const (
+	// BizBudgetScope 按业务设置的预算
+	BizBudgetScope BudgetScope = "biz"
+	// AccountBudgetScope 按账号设置的预算
+	AccountBudgetScope BudgetScope = "account"
+)
+
+// BudgetAlertState is budget alert state.
+type BudgetAlertState string
+
+// Validate BudgetAlertState.
+func (s BudgetAlertState) Validate() error {
+	switch s {
+	case BudgetAlertNotified:
+	case BudgetAlertNotifyFailed:
+	default:
+		return fmt.Errorf("unsupported budget alert state: %s", s)
+	}
+
+	return nil
+}
+
+const (
+	// BudgetAlertNotified 告警已通知
+	BudgetAlertNotified BudgetAlertState = "notified"
+	// BudgetAlertNotifyFailed 告警通知失败，下一轮评估时会重新通知
+	BudgetAlertNotifyFailed BudgetAlertState = "notify_failed"
+)
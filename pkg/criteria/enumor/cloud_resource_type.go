@@ -64,6 +64,14 @@ func (rt CloudResourceType) ConvTableName() (table.Name, error) {
 		return table.AzureRGTable, nil
 	case ArgumentTemplateResType:
 		return table.ArgumentTemplateTable, nil
+	case LoadBalancerCloudResType:
+		return table.LoadBalancerTable, nil
+	case SnapshotCloudResType:
+		return table.SnapshotTable, nil
+	case KeyPairCloudResType:
+		return table.KeyPairTable, nil
+	case NatGatewayCloudResType:
+		return table.NatGatewayTable, nil
 	default:
 		return "", fmt.Errorf("%s does not have a corresponding table name", rt)
 	}
@@ -88,4 +96,8 @@ const (
 	ZoneCloudResType             CloudResourceType = "zone"
 	AzureResourceGroup           CloudResourceType = "azure_resource_group"
 	ArgumentTemplateResType      CloudResourceType = "argument_template"
+	LoadBalancerCloudResType     CloudResourceType = "load_balancer"
+	SnapshotCloudResType         CloudResourceType = "snapshot"
+	KeyPairCloudResType          CloudResourceType = "key_pair"
+	NatGatewayCloudResType       CloudResourceType = "nat_gateway"
 )
@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// LeaseState is resource lease state.
+type LeaseState string
+
+// Validate LeaseState.
+func (s LeaseState) Validate() error {
+	switch s {
+	case LeaseActive:
+	case LeaseRecycled:
+	default:
+		return fmt.Errorf("unsupported lease state: %s", s)
+	}
+
+	return nil
+}
+
+const (
+	// LeaseActive 租约生效中，到期后自动回收资源
+	LeaseActive LeaseState = "active"
+	// LeaseRecycled 租约已到期，资源已进入回收站
+	LeaseRecycled LeaseState = "recycled"
+)
@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// NatRuleType is nat gateway rule type.
+type NatRuleType string
+
+// Validate NatRuleType.
+func (t NatRuleType) Validate() error {
+	switch t {
+	case SnatRuleType:
+	case DnatRuleType:
+	default:
+		return fmt.Errorf("unsupported nat rule type: %s", t)
+	}
+
+	return nil
+}
+
+const (
+	// SnatRuleType 源地址转换规则，子网内的实例通过NAT网关的公网IP访问公网
+	SnatRuleType NatRuleType = "SNAT"
+	// DnatRuleType 目的地址端口转换规则，公网IP的端口映射到内网实例的端口
+	DnatRuleType NatRuleType = "DNAT"
+)
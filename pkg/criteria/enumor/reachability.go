@@ -0,0 +1,46 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+// ReachabilityPath is the network path between two cvms.
+type ReachabilityPath string
+
+const (
+	// SameVpcPath 两台主机处于同一vpc，通过vpc内的本地路由互通
+	SameVpcPath ReachabilityPath = "same_vpc"
+	// RoutePath 两台主机处于不同vpc，通过路由表中的对等连接、云联网、专线等私网路由互通
+	RoutePath ReachabilityPath = "route"
+	// PublicPath 两台主机之间没有私网路由，通过目的主机的公网IP互通
+	PublicPath ReachabilityPath = "public"
+	// NonePath 两台主机之间没有可用的网络路径
+	NonePath ReachabilityPath = "none"
+)
+
+// ReachabilityStage is the evaluation stage of reachability query.
+type ReachabilityStage string
+
+const (
+	// NetworkStage 网络路径，判断源、目的主机之间是否存在可用的路由
+	NetworkStage ReachabilityStage = "network"
+	// SourceEgressStage 源主机安全组出站规则
+	SourceEgressStage ReachabilityStage = "source_egress"
+	// DestinationIngressStage 目的主机安全组入站规则
+	DestinationIngressStage ReachabilityStage = "destination_ingress"
+)
@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+// ExposureRiskLevel is security group exposure risk level.
+type ExposureRiskLevel string
+
+const (
+	// HighExposureRisk 高风险，如敏感端口、全部端口对公网开放
+	HighExposureRisk ExposureRiskLevel = "high"
+	// MediumExposureRisk 中风险，如规则被高优先级规则覆盖导致不会生效
+	MediumExposureRisk ExposureRiskLevel = "medium"
+	// LowExposureRisk 低风险，如冗余规则
+	LowExposureRisk ExposureRiskLevel = "low"
+)
+
+// Weight 风险等级的权重，权重越大风险越高。
+func (l ExposureRiskLevel) Weight() int {
+	switch l {
+	case HighExposureRisk:
+		return 3
+	case MediumExposureRisk:
+		return 2
+	case LowExposureRisk:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ExposureRiskKind is security group exposure risk kind.
+type ExposureRiskKind string
+
+const (
+	// PublicSensitivePortRisk SSH、RDP、数据库等敏感端口对公网开放
+	PublicSensitivePortRisk ExposureRiskKind = "public_sensitive_port"
+	// PublicAllPortRisk 全部端口对公网开放
+	PublicAllPortRisk ExposureRiskKind = "public_all_port"
+	// ShadowedRuleRisk 规则被更高优先级且动作相反的规则完全覆盖，永远不会生效
+	ShadowedRuleRisk ExposureRiskKind = "shadowed_rule"
+	// RedundantRuleRisk 规则被更高优先级且动作相同的规则完全覆盖，属于冗余规则
+	RedundantRuleRisk ExposureRiskKind = "redundant_rule"
+	// OverlappingRuleRisk 规则与更高优先级且动作相反的规则部分重叠，实际生效范围与预期可能不一致
+	OverlappingRuleRisk ExposureRiskKind = "overlapping_rule"
+	// PublicCvmRisk 拥有公网IP的主机绑定了对公网开放的安全组
+	PublicCvmRisk ExposureRiskKind = "public_cvm"
+)
@@ -0,0 +1,44 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// SnapshotPolicyState is snapshot policy state.
+type SnapshotPolicyState string
+
+// Validate SnapshotPolicyState.
+func (s SnapshotPolicyState) Validate() error {
+	switch s {
+	case SnapshotPolicyEnabled:
+	case SnapshotPolicyDisabled:
+	default:
+		return fmt.Errorf("unsupported snapshot policy state: %s", s)
+	}
+
+	return nil
+}
+
+const (
+	// SnapshotPolicyEnabled 快照策略启用，到达执行时间后自动创建和清理快照
+	SnapshotPolicyEnabled SnapshotPolicyState = "enabled"
+	// SnapshotPolicyDisabled 快照策略停用
+	SnapshotPolicyDisabled SnapshotPolicyState = "disabled"
+)
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+// StackStatus 资源栈状态
+type StackStatus string
+
+const (
+	// DraftStackStatus 资源栈已创建，尚未执行过apply
+	DraftStackStatus StackStatus = "draft"
+	// ApplyingStackStatus 正在按模版创建资源
+	ApplyingStackStatus StackStatus = "applying"
+	// AppliedStackStatus 资源已按模版创建
+	AppliedStackStatus StackStatus = "applied"
+	// ApplyFailedStackStatus 创建资源失败，本次创建的资源已回滚
+	ApplyFailedStackStatus StackStatus = "apply_failed"
+	// DestroyingStackStatus 正在销毁资源栈下的资源
+	DestroyingStackStatus StackStatus = "destroying"
+	// DestroyedStackStatus 资源栈下的资源已全部销毁
+	DestroyedStackStatus StackStatus = "destroyed"
+	// DestroyFailedStackStatus 销毁资源失败，可以重新执行销毁
+	DestroyFailedStackStatus StackStatus = "destroy_failed"
+)
+
+// InProgress return whether the stack has a running flow.
+func (s StackStatus) InProgress() bool {
+	return s == ApplyingStackStatus || s == DestroyingStackStatus
+}
+
+// StackTemplateFormat 资源栈模版格式
+type StackTemplateFormat string
+
+const (
+	// YamlStackTemplateFormat yaml格式
+	YamlStackTemplateFormat StackTemplateFormat = "yaml"
+	// JsonStackTemplateFormat json格式
+	JsonStackTemplateFormat StackTemplateFormat = "json"
+)
+
+// StackPlanAction 资源栈plan中单个资源的变更动作
+type StackPlanAction string
+
+const (
+	// CreateStackPlanAction 模版中新增的资源，apply时创建
+	CreateStackPlanAction StackPlanAction = "create"
+	// NoChangeStackPlanAction 资源已存在且与模版一致
+	NoChangeStackPlanAction StackPlanAction = "no_change"
+	// DriftedStackPlanAction 资源已存在但与模版不一致，apply不会修改该资源
+	DriftedStackPlanAction StackPlanAction = "drifted"
+	// DeleteStackPlanAction 资源已从模版中移除，apply时删除
+	DeleteStackPlanAction StackPlanAction = "delete"
+)
@@ -61,4 +61,10 @@ const (
 	RecordNotUpdate int32 = 2000010
 	// RecordDuplicated 数据重复，对应 MySQL Error 1062 (23000)
 	RecordDuplicated int32 = 2000011
+	// ApprovalRequired 业务审批策略要求该操作提交申请单审批通过后执行
+	ApprovalRequired int32 = 2000012
+	// GuardrailViolated 资源创建请求违反了管控规则
+	GuardrailViolated int32 = 2000013
+	// ApplicationUnsupported 业务审批策略要求该操作审批，但云厂商不支持提交该类型的申请单，操作被拒绝
+	ApplicationUnsupported int32 = 2000014
 )
@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
@@ -43,6 +44,7 @@ import (
 type Application interface {
 	CreateWithTx(kt *kit.Kit, tx *sqlx.Tx, model *application.ApplicationTable) (string, error)
 	Update(kt *kit.Kit, expr *filter.Expression, model *application.ApplicationTable) error
+	UpdateBySource(kt *kit.Kit, id string, source *UpdateSource, model *application.ApplicationTable) error
 	List(kt *kit.Kit, opt *types.ListOption) (*types.ListApplicationDetails, error)
 }
 
@@ -123,6 +125,74 @@ func (a *ApplicationDao) Update(kt *kit.Kit, filterExpr *filter.Expression, mode
 	return nil
 }
 
+// UpdateSource 申请单条件更新时要求申请单当前所处的状态，为空的条件不参与比较
+type UpdateSource struct {
+	Status        enumor.ApplicationStatus
+	DeliverStep   enumor.ApplicationDeliverStep
+	DeliverFlowID string
+}
+
+// UpdateBySource update application which is still at the source status, deliver step and deliver flow,
+// returns RecordNotUpdate error when the application has been changed by others, updating with the same
+// values as stored is not treated as a conflict.
+func (a *ApplicationDao) UpdateBySource(kt *kit.Kit, id string, source *UpdateSource,
+	model *application.ApplicationTable) error {
+
+	if len(id) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if source == nil || len(source.Status) == 0 {
+		return errf.New(errf.InvalidParameter, "source status is required")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddBlankedFields("memo").AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	whereExpr := "where id = :id and status = :source_status"
+	toUpdate["id"] = id
+	toUpdate["source_status"] = string(source.Status)
+	if len(source.DeliverStep) != 0 {
+		whereExpr += ` and delivery_detail->>"$.deliver_step" = :source_step`
+		toUpdate["source_step"] = string(source.DeliverStep)
+	}
+	if len(source.DeliverFlowID) != 0 {
+		whereExpr += ` and delivery_detail->>"$.deliver_flow_id" = :source_flow_id`
+		toUpdate["source_flow_id"] = source.DeliverFlowID
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s %s`, model.TableName(), setExpr, whereExpr)
+	effect, err := a.Orm.Do().Update(kt.Ctx, sql, toUpdate)
+	if err != nil {
+		logs.ErrorJson("update application by source failed, err: %v, id: %s, rid: %v", err, id, kt.Rid)
+		return err
+	}
+
+	if effect == 0 {
+		// 更新的值与已存储的值相同时影响行数也为0，重新查询申请单是否仍处于源状态，区分重复更新与被他人变更
+		countSql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, model.TableName(), whereExpr)
+		count, err := a.Orm.Do().Count(kt.Ctx, countSql, toUpdate)
+		if err != nil {
+			logs.ErrorJson("count application by source failed, err: %v, id: %s, rid: %v", err, id, kt.Rid)
+			return err
+		}
+
+		if count == 0 {
+			return errf.Newf(errf.RecordNotUpdate, "application[%s] is not at source %+v, has been changed by others",
+				id, *source)
+		}
+	}
+
+	return nil
+}
+
 // List ...
 func (a *ApplicationDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListApplicationDetails, error) {
 	if opt == nil {
@@ -0,0 +1,173 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daoapproval
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	tableapproval "hcm/pkg/dal/table/approval"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// TicketInterface only used for approval ticket.
+type TicketInterface interface {
+	Create(kt *kit.Kit, model *tableapproval.TicketTable) (string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableapproval.TicketTable], error)
+	UpdateByCAS(kt *kit.Kit, id string, sourceStage uint, sourceApprovers []string,
+		model *tableapproval.TicketTable) error
+}
+
+var _ TicketInterface = new(TicketDao)
+
+// TicketDao approval ticket dao.
+type TicketDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// Create approval ticket.
+func (dao TicketDao) Create(kt *kit.Kit, model *tableapproval.TicketTable) (string, error) {
+	if model == nil {
+		return "", errf.New(errf.InvalidParameter, "model to create cannot be nil")
+	}
+
+	id, err := dao.IDGen.One(kt, table.ApprovalTicketTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, model.TableName(), tableapproval.TicketColumns.ColumnExpr(),
+		tableapproval.TicketColumns.ColonNameExpr())
+
+	if err = dao.Orm.Do().Insert(kt.Ctx, sql, model); err != nil {
+		logs.Errorf("insert %s failed, err: %v, model: %+v, rid: %s", model.TableName(), err, model, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %v", model.TableName(), err)
+	}
+
+	return id, nil
+}
+
+// List approval ticket.
+func (dao TicketDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[tableapproval.TicketTable], error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list approval ticket options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tableapproval.TicketColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ApprovalTicketTable, whereExpr)
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count approval ticket failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[tableapproval.TicketTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableapproval.TicketColumns.FieldsNamedExpr(opt.Fields),
+		table.ApprovalTicketTable, whereExpr, pageExpr)
+
+	details := make([]tableapproval.TicketTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.Errorf("select approval ticket failed, err: %v, sql: %s, rid: %s", err, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[tableapproval.TicketTable]{Details: details}, nil
+}
+
+// UpdateByCAS update approval ticket which is still running at the source stage with the source approvers,
+// returns RecordNotUpdate error when the ticket has been operated or transferred by others.
+func (dao TicketDao) UpdateByCAS(kt *kit.Kit, id string, sourceStage uint, sourceApprovers []string,
+	model *tableapproval.TicketTable) error {
+	if len(id) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	if sourceApprovers == nil {
+		sourceApprovers = make([]string, 0)
+	}
+	approvers, err := tabletypes.NewJsonField(sourceApprovers)
+	if err != nil {
+		return errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 比较当前节点审批人，避免转审与审批并发时转审丢失或被移除的审批人仍能审批
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id and status = :source_status and current_stage = :source_stage `+
+		`and current_approvers = CAST(:source_approvers AS JSON)`, model.TableName(), setExpr)
+
+	toUpdate["id"] = id
+	toUpdate["source_status"] = enumor.RunningApprovalTicketStatus
+	toUpdate["source_stage"] = sourceStage
+	toUpdate["source_approvers"] = string(approvers)
+	effect, err := dao.Orm.Do().Update(kt.Ctx, sql, toUpdate)
+	if err != nil {
+		logs.ErrorJson("update approval ticket failed, err: %v, id: %s, rid: %v", err, id, kt.Rid)
+		return err
+	}
+
+	if effect == 0 {
+		return errf.Newf(errf.RecordNotUpdate, "approval ticket[%s] has been operated by others", id)
+	}
+
+	return nil
+}
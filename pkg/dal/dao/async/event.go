@@ -0,0 +1,159 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daoasync
+
+import (
+	"fmt"
+	"strconv"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesasync "hcm/pkg/dal/dao/types/async"
+	"hcm/pkg/dal/table"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AsyncFlowEvent only used async flow event.
+type AsyncFlowEvent interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableasync.AsyncFlowEventTable) ([]string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlowEvents, error)
+	Delete(kt *kit.Kit, expr *filter.Expression) error
+}
+
+var _ AsyncFlowEvent = new(AsyncFlowEventDao)
+
+// AsyncFlowEventDao async flow event dao.
+type AsyncFlowEventDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// BatchCreateWithTx async flow event with tx. 事件ID在同一事务中生成，ID生成器的行锁持续到事务结束，
+// 事件按ID的顺序提交，按ID游标增量读取事件时不会越过尚未提交的事件。
+func (dao *AsyncFlowEventDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx,
+	models []tableasync.AsyncFlowEventTable) ([]string, error) {
+
+	if len(models) == 0 {
+		return make([]string, 0), nil
+	}
+
+	ids, err := dao.IDGen.BatchWithTx(kt, tx, table.AsyncFlowEventTable, len(models))
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+		if models[index].Seq, err = strconv.ParseUint(ids[index], 36, 64); err != nil {
+			return nil, fmt.Errorf("parse event id %s to seq failed, err: %v", ids[index], err)
+		}
+
+		if err := models[index].InsertValidate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AsyncFlowEventTable,
+		tableasync.AsyncFlowEventColumns.ColumnExpr(), tableasync.AsyncFlowEventColumns.ColonNameExpr())
+
+	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", table.AsyncFlowEventTable, err, sql, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", table.AsyncFlowEventTable, err)
+	}
+
+	return ids, nil
+}
+
+// List async flow event.
+func (dao *AsyncFlowEventDao) List(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlowEvents, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list async flow event options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(
+		tableasync.AsyncFlowEventColumns.ColumnTypes())), core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AsyncFlowEventTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count async flow event failed, err: %v, filter: %s, rid: %s", err,
+				opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesasync.ListAsyncFlowEvents{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableasync.AsyncFlowEventColumns.FieldsNamedExpr(opt.Fields),
+		table.AsyncFlowEventTable, whereExpr, pageExpr)
+
+	details := make([]tableasync.AsyncFlowEventTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.ErrorJson("select async flow event failed, err: %v, sql: %s, filter: %v, rid: %s", err, sql,
+			opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &typesasync.ListAsyncFlowEvents{Details: details}, nil
+}
+
+// Delete async flow event.
+func (dao *AsyncFlowEventDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AsyncFlowEventTable, whereExpr)
+	if _, err = dao.Orm.Do().Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete async flow event failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
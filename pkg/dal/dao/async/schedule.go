@@ -0,0 +1,270 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package daoasync
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesasync "hcm/pkg/dal/dao/types/async"
+	"hcm/pkg/dal/table"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/dal/table/utils"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AsyncFlowSchedule only used async flow schedule.
+type AsyncFlowSchedule interface {
+	Create(kt *kit.Kit, model *tableasync.AsyncFlowScheduleTable) (string, error)
+	UpdateByID(kt *kit.Kit, id string, model *tableasync.AsyncFlowScheduleTable) error
+	TriggerByCASWithTx(kt *kit.Kit, tx *sqlx.Tx, info *typesasync.TriggerScheduleInfo) error
+	UpdateStateByCAS(kt *kit.Kit, info *typesasync.UpdateScheduleStateInfo) error
+	List(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlowSchedules, error)
+	Delete(kt *kit.Kit, expr *filter.Expression) error
+}
+
+var _ AsyncFlowSchedule = new(AsyncFlowScheduleDao)
+
+// AsyncFlowScheduleDao async flow schedule dao.
+type AsyncFlowScheduleDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// Create async flow schedule.
+func (dao *AsyncFlowScheduleDao) Create(kt *kit.Kit, model *tableasync.AsyncFlowScheduleTable) (string, error) {
+	if model == nil {
+		return "", errf.New(errf.InvalidParameter, "model to create cannot be nil")
+	}
+
+	id, err := dao.IDGen.One(kt, table.AsyncFlowScheduleTable)
+	if err != nil {
+		return "", err
+	}
+	model.ID = id
+
+	if err = model.InsertValidate(); err != nil {
+		return "", err
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AsyncFlowScheduleTable,
+		tableasync.AsyncFlowScheduleColumns.ColumnExpr(), tableasync.AsyncFlowScheduleColumns.ColonNameExpr())
+
+	if err = dao.Orm.Do().Insert(kt.Ctx, sql, model); err != nil {
+		logs.Errorf("insert %s failed, err: %v, sql: %s, rid: %s", table.AsyncFlowScheduleTable, err, sql, kt.Rid)
+		return "", fmt.Errorf("insert %s failed, err: %v", table.AsyncFlowScheduleTable, err)
+	}
+
+	return id, nil
+}
+
+// UpdateByID async flow schedule.
+func (dao *AsyncFlowScheduleDao) UpdateByID(kt *kit.Kit, id string, model *tableasync.AsyncFlowScheduleTable) error {
+	if len(id) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+
+	toUpdate["id"] = id
+	if _, err = dao.Orm.Do().Update(kt.Ctx, sql, toUpdate); err != nil {
+		logs.Errorf("update async flow schedule failed, err: %v, id: %s, sql: %s, rid: %v", err, id, sql, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// TriggerByCASWithTx 定时计划触发后更新下次执行时间等信息，只有计划处于启用状态且下次执行时间未被修改时才会更新成功。
+func (dao *AsyncFlowScheduleDao) TriggerByCASWithTx(kt *kit.Kit, tx *sqlx.Tx,
+	info *typesasync.TriggerScheduleInfo) error {
+
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	setSql := "set state = :state, last_flow_id = :last_flow_id"
+	if !info.TargetNextRunAt.IsZero() {
+		setSql += ", next_run_at = :target_next_run_at"
+	}
+
+	if info.Reason != nil {
+		setSql += ", reason = :reason"
+	}
+
+	sql := fmt.Sprintf(`update %s %s where id = :id and state = :source_state and next_run_at = :source_next_run_at`,
+		table.AsyncFlowScheduleTable, setSql)
+
+	values := map[string]interface{}{
+		"id":                 info.ID,
+		"source_state":       enumor.FlowScheduleEnabled,
+		"source_next_run_at": info.SourceNextRunAt,
+		"target_next_run_at": info.TargetNextRunAt,
+		"state":              info.State,
+		"reason":             info.Reason,
+		"last_flow_id":       info.LastFlowID,
+	}
+	effected, err := dao.Orm.Txn(tx).Update(kt.Ctx, sql, values)
+	if err != nil {
+		logs.Errorf("trigger async flow schedule failed, err: %v, id: %s, sql: %s, rid: %v", err, info.ID, sql,
+			kt.Rid)
+		return err
+	}
+
+	if effected == 0 {
+		return errf.Newf(errf.RecordNotUpdate, "schedule[%s: %s] has been changed, skip trigger", info.ID,
+			info.SourceNextRunAt)
+	}
+
+	return nil
+}
+
+// UpdateStateByCAS 更新定时计划状态，只有计划的状态和下次执行时间都未被修改时才会更新成功，避免和定时计划触发互相覆盖。
+func (dao *AsyncFlowScheduleDao) UpdateStateByCAS(kt *kit.Kit, info *typesasync.UpdateScheduleStateInfo) error {
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	setSql := "set state = :target_state, reviser = :reviser"
+	if !info.TargetNextRunAt.IsZero() {
+		setSql += ", next_run_at = :target_next_run_at"
+	}
+
+	if info.Reason != nil {
+		setSql += ", reason = :reason"
+	}
+
+	sql := fmt.Sprintf(`update %s %s where id = :id and state = :source_state and next_run_at = :source_next_run_at`,
+		table.AsyncFlowScheduleTable, setSql)
+
+	values := map[string]interface{}{
+		"id":                 info.ID,
+		"source_state":       info.SourceState,
+		"source_next_run_at": info.SourceNextRunAt,
+		"target_state":       info.TargetState,
+		"target_next_run_at": info.TargetNextRunAt,
+		"reason":             info.Reason,
+		"reviser":            kt.User,
+	}
+	effected, err := dao.Orm.Do().Update(kt.Ctx, sql, values)
+	if err != nil {
+		logs.Errorf("update async flow schedule state failed, err: %v, id: %s, sql: %s, rid: %v", err, info.ID, sql,
+			kt.Rid)
+		return err
+	}
+
+	// 源状态与目标状态不同，匹配到记录时一定会有记录被更新
+	if effected == 0 {
+		return errf.Newf(errf.RecordNotUpdate, "schedule[%s: %s] has been changed, please retry", info.ID,
+			info.SourceState)
+	}
+
+	return nil
+}
+
+// List async flow schedule.
+func (dao *AsyncFlowScheduleDao) List(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlowSchedules,
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list async flow schedule options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(
+		tableasync.AsyncFlowScheduleColumns.ColumnTypes())), core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		// this is dao count request, then do count operation only.
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AsyncFlowScheduleTable, whereExpr)
+
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count async flow schedule failed, err: %v, filter: %s, rid: %s", err,
+				opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesasync.ListAsyncFlowSchedules{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tableasync.AsyncFlowScheduleColumns.FieldsNamedExpr(opt.Fields),
+		table.AsyncFlowScheduleTable, whereExpr, pageExpr)
+
+	details := make([]tableasync.AsyncFlowScheduleTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.ErrorJson("select async flow schedule failed, err: %v, sql: %s, filter: %v, rid: %s", err, sql,
+			opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &typesasync.ListAsyncFlowSchedules{Details: details}, nil
+}
+
+// Delete async flow schedule.
+func (dao *AsyncFlowScheduleDao) Delete(kt *kit.Kit, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.AsyncFlowScheduleTable, whereExpr)
+	if _, err = dao.Orm.Do().Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete async flow schedule failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
@@ -45,7 +45,9 @@ type AsyncFlowTask interface {
 	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tableasync.AsyncFlowTaskTable) ([]string, error)
 	Update(kt *kit.Kit, expr *filter.Expression, model *tableasync.AsyncFlowTaskTable) error
 	UpdateByID(kt *kit.Kit, id string, model *tableasync.AsyncFlowTaskTable) error
+	UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string, model *tableasync.AsyncFlowTaskTable) error
 	UpdateStateByCAS(kt *kit.Kit, info *typesasync.UpdateTaskInfo) error
+	UpdateStateByCASWithTx(kt *kit.Kit, tx *sqlx.Tx, info *typesasync.UpdateTaskInfo) error
 	List(kt *kit.Kit, opt *types.ListOption) (*typesasync.ListAsyncFlowTasks, error)
 	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
 	GenIDs(kt *kit.Kit, num int) ([]string, error)
@@ -164,6 +166,40 @@ func (dao *AsyncFlowTaskDao) UpdateStateByCAS(kt *kit.Kit, info *typesasync.Upda
 	return nil
 }
 
+// UpdateStateByCASWithTx update async flow task state by cas with tx.
+func (dao *AsyncFlowTaskDao) UpdateStateByCASWithTx(kt *kit.Kit, tx *sqlx.Tx, info *typesasync.UpdateTaskInfo) error {
+
+	if err := info.Validate(); err != nil {
+		return err
+	}
+
+	setSql := "set state = :target"
+	if info.Reason != nil {
+		setSql += ",reason = :reason"
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id and state = :source`, table.AsyncFlowTaskTable, setSql)
+
+	values := map[string]interface{}{
+		"id":     info.ID,
+		"target": info.Target,
+		"source": info.Source,
+		"reason": info.Reason,
+	}
+	effect, err := dao.Orm.Txn(tx).Update(kt.Ctx, sql, values)
+	if err != nil {
+		logs.Errorf("update async flow task failed, err: %v, id: %s, sql: %s, rid: %v", err, info.ID, sql, kt.Rid)
+		return err
+	}
+
+	if effect == 0 {
+		return errf.Newf(errf.RecordNotUpdate, "task[%s: %s] update state to %s failed", info.ID, info.Source,
+			info.Target)
+	}
+
+	return nil
+}
+
 // UpdateByID async flow task.
 func (dao *AsyncFlowTaskDao) UpdateByID(kt *kit.Kit, id string, model *tableasync.AsyncFlowTaskTable) error {
 
@@ -193,6 +229,35 @@ func (dao *AsyncFlowTaskDao) UpdateByID(kt *kit.Kit, id string, model *tableasyn
 	return nil
 }
 
+// UpdateByIDWithTx async flow task with tx.
+func (dao *AsyncFlowTaskDao) UpdateByIDWithTx(kt *kit.Kit, tx *sqlx.Tx, id string,
+	model *tableasync.AsyncFlowTaskTable) error {
+
+	if len(id) == 0 {
+		return errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	if err := model.UpdateValidate(); err != nil {
+		return err
+	}
+
+	opts := utils.NewFieldOptions().AddIgnoredFields(types.DefaultIgnoredFields...)
+	setExpr, toUpdate, err := utils.RearrangeSQLDataWithOption(model, opts)
+	if err != nil {
+		return fmt.Errorf("prepare parsed sql set filter expr failed, err: %v", err)
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s %s where id = :id`, model.TableName(), setExpr)
+
+	toUpdate["id"] = id
+	if _, err = dao.Orm.Txn(tx).Update(kt.Ctx, sql, toUpdate); err != nil {
+		logs.Errorf("update async flow task failed, err: %v, id: %s, sql: %s, rid: %v", err, id, sql, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
 // GenIDs gen async flow task ids.
 func (dao *AsyncFlowTaskDao) GenIDs(kt *kit.Kit, num int) ([]string, error) {
 	ids, err := dao.IDGen.Batch(kt, table.AsyncFlowTaskTable, num)
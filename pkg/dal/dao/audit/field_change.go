@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+)
+
+const extensionField = "extension"
+
+// ignoredChangeFields 不计入资源变更的字段
+var ignoredChangeFields = map[string]struct{}{
+	"id":         {},
+	"creator":    {},
+	"reviser":    {},
+	"created_at": {},
+	"updated_at": {},
+}
+
+// DiffFieldChanges 对比资源更新前的db数据和更新数据，返回发生变更的属性。
+// Note: update 中非 extension 的零值字段不会被更新，所以视为未变更；extension 是合并后的完整数据，按属性逐一对比。
+func DiffFieldChanges(old, update interface{}) (audit.FieldChanges, error) {
+	oldFields, err := flattenFields(old)
+	if err != nil {
+		return nil, err
+	}
+
+	updateFields, err := flattenFields(update)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(audit.FieldChanges, 0)
+	for field, newVal := range updateFields {
+		if _, ignored := ignoredChangeFields[field]; ignored {
+			continue
+		}
+
+		if !strings.HasPrefix(field, extensionField+".") && isZeroValue(newVal) {
+			continue
+		}
+
+		oldVal := oldFields[field]
+		if reflect.DeepEqual(oldVal, newVal) {
+			continue
+		}
+
+		changes = append(changes, audit.FieldChange{Field: field, Old: oldVal, New: newVal})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes, nil
+}
+
+// BuildSyncChangeEvent 根据同步更新前后的数据构建资源变更事件，base 中需设置资源基本信息，无属性变更时返回 nil。
+func BuildSyncChangeEvent(kt *kit.Kit, base audit.ResChangeEventTable, old, update interface{}) (
+	*audit.ResChangeEventTable, error) {
+
+	changes, err := DiffFieldChanges(old, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSyncChangeEvent(kt, base, enumor.Update, changes), nil
+}
+
+// SnapshotFieldChanges 将资源的全部非零值属性作为变更返回，用于记录同步发现的资源新增和删除，
+// deleted 为 false 时属性值作为变更后的值，为 true 时属性值作为变更前的值。
+func SnapshotFieldChanges(obj interface{}, deleted bool) (audit.FieldChanges, error) {
+	fields, err := flattenFields(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(audit.FieldChanges, 0, len(fields))
+	for field, value := range fields {
+		if _, ignored := ignoredChangeFields[field]; ignored || isZeroValue(value) {
+			continue
+		}
+
+		if deleted {
+			changes = append(changes, audit.FieldChange{Field: field, Old: value})
+		} else {
+			changes = append(changes, audit.FieldChange{Field: field, New: value})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes, nil
+}
+
+// PrefixFieldChanges 为属性名增加前缀，用于将子资源的属性变更记录到所属资源的变更事件中，如安全组规则：rules.{规则ID}.port
+func PrefixFieldChanges(prefix string, changes audit.FieldChanges) audit.FieldChanges {
+	for idx := range changes {
+		changes[idx].Field = prefix + "." + changes[idx].Field
+	}
+
+	return changes
+}
+
+// NewSyncChangeEvent 使用属性变更构建同步发现的资源变更事件，base 中需设置资源基本信息，无属性变更时返回 nil。
+func NewSyncChangeEvent(kt *kit.Kit, base audit.ResChangeEventTable, action enumor.AuditAction,
+	changes audit.FieldChanges) *audit.ResChangeEventTable {
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	base.Action = action
+	base.Operator = kt.User
+	base.Source = enumor.BackgroundSync
+	base.Rid = kt.Rid
+	base.Changes = changes
+	return &base
+}
+
+// flattenFields 将资源按 json 字段展开，extension 中的属性以 extension. 为前缀展开。
+func flattenFields(obj interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("marshal resource failed, err: %v", err)
+	}
+
+	fields := make(map[string]interface{})
+	if err = json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshal resource failed, err: %v", err)
+	}
+
+	result := make(map[string]interface{}, len(fields))
+	for field, value := range fields {
+		if field != extensionField {
+			result[field] = value
+			continue
+		}
+
+		// extension 为空时序列化为 {}，视为未更新
+		ext, ok := value.(map[string]interface{})
+		if !ok {
+			result[field] = value
+			continue
+		}
+		flattenMap(extensionField, ext, result)
+	}
+
+	return result, nil
+}
+
+func flattenMap(prefix string, fields map[string]interface{}, result map[string]interface{}) {
+	for field, value := range fields {
+		key := prefix + "." + field
+		if sub, ok := value.(map[string]interface{}); ok && len(sub) != 0 {
+			flattenMap(key, sub, result)
+			continue
+		}
+
+		result[key] = value
+	}
+}
+
+func isZeroValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(v) == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"testing"
+
+	"hcm/pkg/dal/table/cloud"
+	tabletype "hcm/pkg/dal/table/types"
+)
+
+func TestDiffFieldChanges(t *testing.T) {
+	memo := "new memo"
+	old := &cloud.SecurityGroupTable{
+		ID:        "sg-00000001",
+		CloudID:   "sg-cloud",
+		BkBizID:   100,
+		Name:      "old",
+		Extension: `{"vpc_id":"vpc-1","cloud_project_id":"p1","tags":{"env":"prod"}}`,
+		Reviser:   "admin",
+	}
+	update := &cloud.SecurityGroupTable{
+		Name:      "new",
+		Memo:      &memo,
+		Extension: `{"vpc_id":"vpc-2","cloud_project_id":"p1","tags":{"env":"test"}}`,
+		Reviser:   "sync",
+	}
+
+	changes, err := DiffFieldChanges(old, update)
+	if err != nil {
+		t.Fatalf("diff field changes failed, err: %v", err)
+	}
+
+	expect := map[string][2]interface{}{
+		"extension.tags.env": {"prod", "test"},
+		"extension.vpc_id":   {"vpc-1", "vpc-2"},
+		"memo":               {nil, "new memo"},
+		"name":               {"old", "new"},
+	}
+	if len(changes) != len(expect) {
+		t.Fatalf("changes count should be %d, but got %d, changes: %+v", len(expect), len(changes), changes)
+	}
+
+	for i, one := range changes {
+		if i > 0 && changes[i-1].Field >= one.Field {
+			t.Errorf("changes should be sorted by field, but got: %+v", changes)
+		}
+
+		val, exist := expect[one.Field]
+		if !exist {
+			t.Errorf("field %s should not be changed", one.Field)
+			continue
+		}
+
+		if one.Old != val[0] || one.New != val[1] {
+			t.Errorf("field %s should be changed from %v to %v, but got %v to %v", one.Field, val[0], val[1],
+				one.Old, one.New)
+		}
+	}
+
+	// 更新数据与db数据一致时，无变更
+	changes, err = DiffFieldChanges(old, &cloud.SecurityGroupTable{Name: "old", Extension: old.Extension})
+	if err != nil {
+		t.Fatalf("diff field changes failed, err: %v", err)
+	}
+
+	if len(changes) != 0 {
+		t.Errorf("changes should be empty, but got: %+v", changes)
+	}
+
+	// 未更新 extension 时，不对比 extension
+	changes, err = DiffFieldChanges(old, &cloud.SecurityGroupTable{Extension: tabletype.JsonField("")})
+	if err != nil {
+		t.Fatalf("diff field changes failed, err: %v", err)
+	}
+
+	if len(changes) != 0 {
+		t.Errorf("changes should be empty, but got: %+v", changes)
+	}
+}
+
+func TestSnapshotFieldChanges(t *testing.T) {
+	sg := &cloud.SecurityGroupTable{
+		ID:        "sg-00000001",
+		CloudID:   "sg-cloud",
+		Name:      "web",
+		Extension: `{"vpc_id":"vpc-1"}`,
+		Creator:   "sync",
+	}
+
+	changes, err := SnapshotFieldChanges(sg, false)
+	if err != nil {
+		t.Fatalf("snapshot field changes failed, err: %v", err)
+	}
+
+	expect := []string{"cloud_id", "extension.vpc_id", "name"}
+	if len(changes) != len(expect) {
+		t.Fatalf("changes should be %v, but got: %+v", expect, changes)
+	}
+
+	for i, one := range changes {
+		if one.Field != expect[i] || one.Old != nil || one.New == nil {
+			t.Errorf("created field %s should only has new value, but got: %+v", expect[i], one)
+		}
+	}
+
+	changes, err = SnapshotFieldChanges(sg, true)
+	if err != nil {
+		t.Fatalf("snapshot field changes failed, err: %v", err)
+	}
+
+	changes = PrefixFieldChanges("rules.00000001", changes)
+	if len(changes) != len(expect) {
+		t.Fatalf("changes should be %v, but got: %+v", expect, changes)
+	}
+
+	for i, one := range changes {
+		if one.Field != "rules.00000001."+expect[i] || one.Old == nil || one.New != nil {
+			t.Errorf("deleted field %s should only has old value, but got: %+v", expect[i], one)
+		}
+	}
+}
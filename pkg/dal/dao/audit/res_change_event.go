@@ -0,0 +1,124 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ResChangeEvent define res change event interface.
+type ResChangeEvent interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, events []*audit.ResChangeEventTable) error
+	List(kt *kit.Kit, opt *types.ListOption) (*types.ListResChangeEventDetails, error)
+}
+
+var _ ResChangeEvent = new(ResChangeEventDao)
+
+// NewResChangeEventDao new res change event dao.
+func NewResChangeEventDao(orm orm.Interface) ResChangeEvent {
+	return &ResChangeEventDao{
+		Orm: orm,
+	}
+}
+
+// ResChangeEventDao res change event dao.
+type ResChangeEventDao struct {
+	Orm orm.Interface
+}
+
+// BatchCreateWithTx batch create res change event with tx.
+func (d ResChangeEventDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, events []*audit.ResChangeEventTable) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	for _, one := range events {
+		if err := one.CreateValidate(); err != nil {
+			return err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.ResChangeEventTable,
+		audit.ResChangeEventColumns.ColumnExpr(), audit.ResChangeEventColumns.ColonNameExpr())
+
+	if err := d.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, events); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.ResChangeEventTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.ResChangeEventTable, err)
+	}
+
+	return nil
+}
+
+// List res change event.
+func (d ResChangeEventDao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListResChangeEventDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(audit.ResChangeEventColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.ResChangeEventTable, whereExpr)
+
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count res change event failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResChangeEventDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, audit.ResChangeEventColumns.FieldsNamedExpr(opt.Fields),
+		table.ResChangeEventTable, whereExpr, pageExpr)
+
+	details := make([]audit.ResChangeEventTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &types.ListResChangeEventDetails{Details: details}, nil
+}
@@ -0,0 +1,201 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/errf"
+	idgenerator "hcm/pkg/dal/dao/id-generator"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	typesbill "hcm/pkg/dal/dao/types/bill"
+	"hcm/pkg/dal/table"
+	tablebill "hcm/pkg/dal/table/cloud/bill"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DailyItemInterface only used for bill daily item.
+type DailyItemInterface interface {
+	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tablebill.BillDailyItemTable) ([]string, error)
+	List(kt *kit.Kit, opt *types.ListOption) (*typesbill.ListBillDailyItemDetails, error)
+	Aggregate(kt *kit.Kit, opt *typesbill.BillDailyAggregateOption) (*typesbill.BillDailyAggregateDetails, error)
+	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error
+}
+
+var _ DailyItemInterface = new(DailyItemDao)
+
+// DailyItemDao bill daily item dao.
+type DailyItemDao struct {
+	Orm   orm.Interface
+	IDGen idgenerator.IDGenInterface
+}
+
+// BatchCreateWithTx create bill daily item with tx.
+func (dao DailyItemDao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, models []tablebill.BillDailyItemTable) (
+	[]string, error) {
+
+	if len(models) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "models to create cannot be empty")
+	}
+
+	ids, err := dao.IDGen.Batch(kt, table.BillDailyItemTable, len(models))
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range models {
+		models[index].ID = ids[index]
+		if err = models[index].InsertValidate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.BillDailyItemTable,
+		tablebill.BillDailyItemColumns.ColumnExpr(), tablebill.BillDailyItemColumns.ColonNameExpr())
+
+	if err = dao.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.BillDailyItemTable, err, kt.Rid)
+		return nil, fmt.Errorf("insert %s failed, err: %v", table.BillDailyItemTable, err)
+	}
+
+	return ids, nil
+}
+
+// List bill daily item.
+func (dao DailyItemDao) List(kt *kit.Kit, opt *types.ListOption) (*typesbill.ListBillDailyItemDetails, error) {
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list bill daily item options is nil")
+	}
+
+	columnTypes := tablebill.BillDailyItemColumns.ColumnTypes()
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.BillDailyItemTable, whereExpr)
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count bill daily item failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+			return nil, err
+		}
+
+		return &typesbill.ListBillDailyItemDetails{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, tablebill.BillDailyItemColumns.FieldsNamedExpr(opt.Fields),
+		table.BillDailyItemTable, whereExpr, pageExpr)
+
+	details := make([]tablebill.BillDailyItemTable, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		return nil, err
+	}
+
+	return &typesbill.ListBillDailyItemDetails{Details: details}, nil
+}
+
+// Aggregate 按照指定维度聚合账单日汇总数据，费用和明细条数求和
+func (dao DailyItemDao) Aggregate(kt *kit.Kit, opt *typesbill.BillDailyAggregateOption) (
+	*typesbill.BillDailyAggregateDetails, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "aggregate bill daily item options is nil")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	columnTypes := tablebill.BillDailyItemColumns.ColumnTypes()
+	if err := opt.Filter.Validate(filter.NewExprOption(filter.RuleFields(columnTypes))); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	groupExpr := strings.Join(opt.GroupFields(), ", ")
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM (SELECT %s FROM %s %s GROUP BY %s) AS t`, groupExpr,
+			table.BillDailyItemTable, whereExpr, groupExpr)
+		count, err := dao.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count bill daily aggregate failed, err: %v, filter: %s, rid: %s", err, opt.Filter,
+				kt.Rid)
+			return nil, err
+		}
+
+		return &typesbill.BillDailyAggregateDetails{Count: count}, nil
+	}
+
+	sql := fmt.Sprintf(`SELECT %s, SUM(cost) AS cost, SUM(item_count) AS item_count FROM %s %s GROUP BY %s `+
+		`ORDER BY %s LIMIT %d OFFSET %d`, groupExpr, table.BillDailyItemTable, whereExpr, groupExpr, groupExpr,
+		opt.Page.Limit, opt.Page.Start)
+
+	details := make([]cloud.BillDailyAggregate, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.ErrorJson("aggregate bill daily item failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &typesbill.BillDailyAggregateDetails{Details: details}, nil
+}
+
+// DeleteWithTx delete bill daily item with tx.
+func (dao DailyItemDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, expr *filter.Expression) error {
+	if expr == nil {
+		return errf.New(errf.InvalidParameter, "filter expr is required")
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s %s`, table.BillDailyItemTable, whereExpr)
+	if _, err = dao.Orm.Txn(tx).Delete(kt.Ctx, sql, whereValue); err != nil {
+		logs.ErrorJson("delete bill daily item failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return err
+	}
+
+	return nil
+}
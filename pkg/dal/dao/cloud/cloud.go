@@ -42,6 +42,8 @@ type Cloud interface {
 	ListResourceBasicInfo(kt *kit.Kit, resType enumor.CloudResourceType, ids []string, fields ...string) (
 		[]types.CloudResourceBasicInfo, error)
 	ListResourceIDs(kt *kit.Kit, resType enumor.CloudResourceType, expr *filter.Expression) ([]string, error)
+	ListResourceIDMapByCloudIDs(kt *kit.Kit, resType enumor.CloudResourceType, accountID string,
+		cloudIDs []string) (map[string]string, error)
 	AssignResourceToBiz(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType, expr *filter.Expression,
 		bizID int64) error
 }
@@ -131,6 +133,41 @@ func (dao CloudDao) ListResourceIDs(kt *kit.Kit, resType enumor.CloudResourceTyp
 	return ids, nil
 }
 
+// ListResourceIDMapByCloudIDs list cloud resource ids by cloud ids, returns map[cloudID]id.
+func (dao CloudDao) ListResourceIDMapByCloudIDs(kt *kit.Kit, resType enumor.CloudResourceType, accountID string,
+	cloudIDs []string) (map[string]string, error) {
+
+	tableName, err := resType.ConvTableName()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if len(accountID) == 0 || len(cloudIDs) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "account_id and cloud_ids are required")
+	}
+
+	sql := fmt.Sprintf("select id, cloud_id from %s where account_id = :account_id and cloud_id in (:cloud_ids)",
+		tableName)
+	args := map[string]interface{}{
+		"account_id": accountID,
+		"cloud_ids":  cloudIDs,
+	}
+
+	list := make([]types.CloudResourceIDInfo, 0)
+	if err = dao.Orm.Do().Select(kt.Ctx, &list, sql, args); err != nil {
+		logs.Errorf("select %s resource id by cloud id failed, err: %v, cloud ids: %v, rid: %s", resType, err,
+			cloudIDs, kt.Rid)
+		return nil, err
+	}
+
+	idMap := make(map[string]string, len(list))
+	for _, one := range list {
+		idMap[one.CloudID] = one.ID
+	}
+
+	return idMap, nil
+}
+
 // AssignResourceToBiz assign an account's cloud resource to biz, **only for ui**.
 func (dao CloudDao) AssignResourceToBiz(kt *kit.Kit, tx *sqlx.Tx, resType enumor.CloudResourceType,
 	expr *filter.Expression, bizID int64) error {
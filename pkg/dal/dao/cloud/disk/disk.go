@@ -187,13 +187,19 @@ func (diskDao DiskDao) List(kt *kit.Kit, opt *types.ListOption) (*cloud.DiskList
 	columnTypes["extension.resource_group_name"] = enumor.String
 	columnTypes["extension.self_link"] = enumor.String
 	columnTypes["extension.zones"] = enumor.Json
+	tools.AddTagRuleFields(columnTypes, opt.Filter)
 	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
 		core.NewDefaultPageOption()); err != nil {
 		return nil, err
 	}
 
 	whereOpt := tools.DefaultSqlWhereOption
-	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(whereOpt)
+	filterExpr, err := tools.ConvTagRules(opt.Filter, enumor.DiskCloudResType, table.DiskTable)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	whereExpr, whereValue, err := filterExpr.SQLWhereExpr(whereOpt)
 	if err != nil {
 		return nil, err
 	}
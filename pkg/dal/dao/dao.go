@@ -27,9 +27,11 @@ import (
 
 	"hcm/pkg/cc"
 	"hcm/pkg/dal/dao/application"
+	daoapproval "hcm/pkg/dal/dao/approval"
 	daoasync "hcm/pkg/dal/dao/async"
 	"hcm/pkg/dal/dao/audit"
 	"hcm/pkg/dal/dao/auth"
+	daobudget "hcm/pkg/dal/dao/budget"
 	"hcm/pkg/dal/dao/cloud"
 	daoselection "hcm/pkg/dal/dao/cloud-selection"
 	argstpl "hcm/pkg/dal/dao/cloud/argument-template"
@@ -40,19 +42,29 @@ import (
 	"hcm/pkg/dal/dao/cloud/eip"
 	eipcvmrel "hcm/pkg/dal/dao/cloud/eip-cvm-rel"
 	cimage "hcm/pkg/dal/dao/cloud/image"
+	daokeypair "hcm/pkg/dal/dao/cloud/key-pair"
+	daolb "hcm/pkg/dal/dao/cloud/load-balancer"
+	daonat "hcm/pkg/dal/dao/cloud/nat-gateway"
 	networkinterface "hcm/pkg/dal/dao/cloud/network-interface"
 	nicvmrel "hcm/pkg/dal/dao/cloud/network-interface-cvm-rel"
 	"hcm/pkg/dal/dao/cloud/region"
 	resourcegroup "hcm/pkg/dal/dao/cloud/resource-group"
+	daotag "hcm/pkg/dal/dao/cloud/resource-tag"
 	routetable "hcm/pkg/dal/dao/cloud/route-table"
 	securitygroup "hcm/pkg/dal/dao/cloud/security-group"
 	sgcvmrel "hcm/pkg/dal/dao/cloud/security-group-cvm-rel"
+	daosnapshot "hcm/pkg/dal/dao/cloud/snapshot"
 	daosubaccount "hcm/pkg/dal/dao/cloud/sub-account"
 	daosync "hcm/pkg/dal/dao/cloud/sync"
 	"hcm/pkg/dal/dao/cloud/zone"
+	daodrift "hcm/pkg/dal/dao/drift"
+	daoguardrail "hcm/pkg/dal/dao/guardrail"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
+	daoipam "hcm/pkg/dal/dao/ipam"
+	daolease "hcm/pkg/dal/dao/lease"
 	"hcm/pkg/dal/dao/orm"
 	recyclerecord "hcm/pkg/dal/dao/recycle-record"
+	daostack "hcm/pkg/dal/dao/stack"
 	daouser "hcm/pkg/dal/dao/user"
 	"hcm/pkg/kit"
 	"hcm/pkg/metrics"
@@ -64,6 +76,7 @@ import (
 // Set defines all the DAO to be operated.
 type Set interface {
 	Audit() audit.Interface
+	ResChangeEvent() audit.ResChangeEvent
 	Auth() auth.Auth
 	Account() cloud.Account
 	SubAccount() daosubaccount.SubAccount
@@ -102,11 +115,36 @@ type Set interface {
 	AccountBillConfig() bill.Interface
 	AsyncFlow() daoasync.AsyncFlow
 	AsyncFlowTask() daoasync.AsyncFlowTask
+	AsyncFlowSchedule() daoasync.AsyncFlowSchedule
+	AsyncFlowEvent() daoasync.AsyncFlowEvent
+	AsyncFlowWebhook() daoasync.AsyncFlowWebhook
 	UserCollection() daouser.Interface
 	CloudSelectionScheme() daoselection.SchemeInterface
 	CloudSelectionBizType() daoselection.BizTypeInterface
 	CloudSelectionIdc() daoselection.IdcInterface
 	ArgsTpl() argstpl.Interface
+	LoadBalancer() daolb.LoadBalancerInterface
+	LbListener() daolb.ListenerInterface
+	LbTarget() daolb.TargetInterface
+	ResourceTag() daotag.ResourceTagInterface
+	BillDailyItem() bill.DailyItemInterface
+	BillSyncRecord() bill.SyncRecordInterface
+	Budget() daobudget.BudgetInterface
+	BudgetAlert() daobudget.AlertInterface
+	Snapshot() daosnapshot.SnapshotInterface
+	SnapshotPolicy() daosnapshot.PolicyInterface
+	KeyPair() daokeypair.KeyPairInterface
+	NatGateway() daonat.NatGatewayInterface
+	NatGatewayRule() daonat.NatGatewayRuleInterface
+	IPAMPool() daoipam.PoolInterface
+	DesiredState() daodrift.DesiredStateInterface
+	Stack() daostack.StackInterface
+	StackVersion() daostack.VersionInterface
+	ApprovalWorkflow() daoapproval.WorkflowInterface
+	ApprovalTicket() daoapproval.TicketInterface
+	ApprovalPolicy() daoapproval.PolicyInterface
+	GuardrailRule() daoguardrail.RuleInterface
+	ResourceLease() daolease.LeaseInterface
 
 	Txn() *Txn
 }
@@ -412,6 +450,11 @@ func (s *set) Audit() audit.Interface {
 	return s.audit
 }
 
+// ResChangeEvent return res change event dao.
+func (s *set) ResChangeEvent() audit.ResChangeEvent {
+	return audit.NewResChangeEventDao(s.orm)
+}
+
 // Application return application dao.
 func (s *set) Application() application.Application {
 	return &application.ApplicationDao{
@@ -492,6 +535,30 @@ func (s *set) AsyncFlowTask() daoasync.AsyncFlowTask {
 	}
 }
 
+// AsyncFlowSchedule return AsyncFlowSchedule dao.
+func (s *set) AsyncFlowSchedule() daoasync.AsyncFlowSchedule {
+	return &daoasync.AsyncFlowScheduleDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AsyncFlowEvent return AsyncFlowEvent dao.
+func (s *set) AsyncFlowEvent() daoasync.AsyncFlowEvent {
+	return &daoasync.AsyncFlowEventDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AsyncFlowWebhook return AsyncFlowWebhook dao.
+func (s *set) AsyncFlowWebhook() daoasync.AsyncFlowWebhook {
+	return &daoasync.AsyncFlowWebhookDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
 // CloudSelectionScheme returns cloud selection scheme dao.
 func (s *set) CloudSelectionScheme() daoselection.SchemeInterface {
 	return &daoselection.SchemeDao{
@@ -524,3 +591,183 @@ func (s *set) ArgsTpl() argstpl.Interface {
 		Audit: s.audit,
 	}
 }
+
+// LoadBalancer return load balancer dao.
+func (s *set) LoadBalancer() daolb.LoadBalancerInterface {
+	return &daolb.LoadBalancerDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// LbListener return load balancer listener dao.
+func (s *set) LbListener() daolb.ListenerInterface {
+	return &daolb.ListenerDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// LbTarget return load balancer target dao.
+func (s *set) LbTarget() daolb.TargetInterface {
+	return &daolb.TargetDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ResourceTag return resource tag dao.
+func (s *set) ResourceTag() daotag.ResourceTagInterface {
+	return &daotag.ResourceTagDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// BillDailyItem return bill daily item dao.
+func (s *set) BillDailyItem() bill.DailyItemInterface {
+	return &bill.DailyItemDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// BillSyncRecord return bill sync record dao.
+func (s *set) BillSyncRecord() bill.SyncRecordInterface {
+	return &bill.SyncRecordDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// Budget return budget dao.
+func (s *set) Budget() daobudget.BudgetInterface {
+	return &daobudget.BudgetDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// BudgetAlert return budget alert dao.
+func (s *set) BudgetAlert() daobudget.AlertInterface {
+	return &daobudget.AlertDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// Snapshot return snapshot dao.
+func (s *set) Snapshot() daosnapshot.SnapshotInterface {
+	return &daosnapshot.SnapshotDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// SnapshotPolicy return snapshot policy dao.
+func (s *set) SnapshotPolicy() daosnapshot.PolicyInterface {
+	return &daosnapshot.PolicyDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// KeyPair return key pair dao.
+func (s *set) KeyPair() daokeypair.KeyPairInterface {
+	return &daokeypair.KeyPairDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// NatGateway return nat gateway dao.
+func (s *set) NatGateway() daonat.NatGatewayInterface {
+	return &daonat.NatGatewayDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+		Audit: s.audit,
+	}
+}
+
+// NatGatewayRule return nat gateway rule dao.
+func (s *set) NatGatewayRule() daonat.NatGatewayRuleInterface {
+	return &daonat.NatGatewayRuleDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// IPAMPool return ipam pool dao.
+func (s *set) IPAMPool() daoipam.PoolInterface {
+	return &daoipam.PoolDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// DesiredState return desired state dao.
+func (s *set) DesiredState() daodrift.DesiredStateInterface {
+	return &daodrift.DesiredStateDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// Stack return stack dao.
+func (s *set) Stack() daostack.StackInterface {
+	return &daostack.StackDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// StackVersion return stack version dao.
+func (s *set) StackVersion() daostack.VersionInterface {
+	return &daostack.VersionDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ApprovalWorkflow return approval workflow dao.
+func (s *set) ApprovalWorkflow() daoapproval.WorkflowInterface {
+	return &daoapproval.WorkflowDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ApprovalTicket return approval ticket dao.
+func (s *set) ApprovalTicket() daoapproval.TicketInterface {
+	return &daoapproval.TicketDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ApprovalPolicy return approval policy dao.
+func (s *set) ApprovalPolicy() daoapproval.PolicyInterface {
+	return &daoapproval.PolicyDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// GuardrailRule return guardrail rule dao.
+func (s *set) GuardrailRule() daoguardrail.RuleInterface {
+	return &daoguardrail.RuleDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ResourceLease return resource lease dao.
+func (s *set) ResourceLease() daolease.LeaseInterface {
+	return &daolease.LeaseDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
@@ -20,6 +20,7 @@
 package idgenerator
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strconv"
@@ -39,6 +40,8 @@ type IDGenInterface interface {
 	Batch(kt *kit.Kit, resource table.Name, count int) ([]string, error)
 	// One return one unique id for this resource.
 	One(kt *kit.Kit, resource table.Name) (string, error)
+	// BatchWithTx return a list of resource's unique id generated in the given transaction.
+	BatchWithTx(kt *kit.Kit, txn *sqlx.Tx, resource table.Name, count int) ([]string, error)
 }
 
 var _ IDGenInterface = new(idGenerator)
@@ -80,6 +83,37 @@ func (ig idGenerator) Batch(kt *kit.Kit, resource table.Name, count int) ([]stri
 		return nil, fmt.Errorf("gen %s unique id, but begin txn failed, err: %v", resource, err)
 	}
 
+	ids, err := genIDs(kt, txn, resource, count)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return nil, fmt.Errorf("gen %s unique id, but commit failed, err: %v", resource, err)
+	}
+
+	return ids, nil
+}
+
+// BatchWithTx is to generate unique resource id list in the given transaction.
+// the resource's row in id_generator is locked until the transaction ends, transactions generating ids
+// of the same resource are serialized, so a smaller id is always committed before a larger one.
+func (ig idGenerator) BatchWithTx(kt *kit.Kit, txn *sqlx.Tx, resource table.Name, count int) ([]string, error) {
+	if err := resource.Validate(); err != nil {
+		return nil, err
+	}
+
+	return genIDs(kt, txn, resource, count)
+}
+
+// txExecutor is the transaction used to generate ids, both *sql.Tx and *sqlx.Tx implement it.
+type txExecutor interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// genIDs lock the resource's max id in the transaction and generate the id list.
+func genIDs(kt *kit.Kit, txn txExecutor, resource table.Name, count int) ([]string, error) {
 	// get current max id
 	queryExpr := fmt.Sprintf(`SELECT max_id from id_generator WHERE resource = "%s" FOR UPDATE`, resource)
 
@@ -127,10 +161,6 @@ func (ig idGenerator) Batch(kt *kit.Kit, resource table.Name, count int) ([]stri
 		return nil, fmt.Errorf("gen %s unique id, but rows affected %d is not 1", resource, rowsAffected)
 	}
 
-	if err := txn.Commit(); err != nil {
-		return nil, fmt.Errorf("gen %s unique id, but commit failed, err: %v", resource, err)
-	}
-
 	// generate the id list that can be used.
 	ids := make([]string, count)
 	for idx := 0; idx < count; idx++ {
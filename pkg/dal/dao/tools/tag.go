@@ -0,0 +1,196 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/runtime/filter"
+)
+
+// TagFieldPrefix 标签过滤字段前缀，如 tag.env 表示标签键为 env 的标签值
+const TagFieldPrefix = "tag."
+
+// tagSupportedOps 标签值仅支持字符串类的比较操作
+var tagSupportedOps = map[filter.OpFactory]struct{}{
+	filter.Equal.Factory():               {},
+	filter.NotEqual.Factory():            {},
+	filter.In.Factory():                  {},
+	filter.NotIn.Factory():               {},
+	filter.ContainsSensitive.Factory():   {},
+	filter.ContainsInsensitive.Factory(): {},
+}
+
+// AddTagRuleFields 将过滤条件中出现的标签字段加入可过滤字段，标签值统一按字符串校验
+func AddTagRuleFields(columnTypes map[string]enumor.ColumnType, expr *filter.Expression) {
+	if expr == nil {
+		return
+	}
+
+	for _, rule := range expr.Rules {
+		switch r := rule.(type) {
+		case *filter.Expression:
+			AddTagRuleFields(columnTypes, r)
+		default:
+			if rule.WithType() == filter.AtomType && strings.HasPrefix(rule.RuleField(), TagFieldPrefix) {
+				columnTypes[rule.RuleField()] = enumor.String
+			}
+		}
+	}
+}
+
+// ConvTagRules 将过滤条件中的标签规则转换为资源标签表的子查询，返回新的过滤条件，不修改原过滤条件。
+// resTable 为资源所在的表，用于限定子查询关联的资源ID字段，避免与联表查询中其他表的 id 字段产生歧义。
+func ConvTagRules(expr *filter.Expression, resType enumor.CloudResourceType, resTable table.Name) (
+	*filter.Expression, error) {
+
+	index := 0
+	return convTagRules(expr, resType, resTable, &index)
+}
+
+// convTagRules 递归转换标签规则，index 为已转换的标签规则数量，用于生成各标签规则不重复的占位符
+func convTagRules(expr *filter.Expression, resType enumor.CloudResourceType, resTable table.Name, index *int) (
+	*filter.Expression, error) {
+
+	if expr == nil {
+		return nil, nil
+	}
+
+	rules := make([]filter.RuleFactory, 0, len(expr.Rules))
+	for _, rule := range expr.Rules {
+		switch r := rule.(type) {
+		case *filter.Expression:
+			sub, err := convTagRules(r, resType, resTable, index)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, sub)
+
+		case filter.AtomRule:
+			tagRule, err := convTagAtomRule(&r, resType, resTable, index)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, tagRule)
+
+		case *filter.AtomRule:
+			tagRule, err := convTagAtomRule(r, resType, resTable, index)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, tagRule)
+
+		default:
+			rules = append(rules, rule)
+		}
+	}
+
+	return &filter.Expression{Op: expr.Op, Rules: rules}, nil
+}
+
+func convTagAtomRule(rule *filter.AtomRule, resType enumor.CloudResourceType, resTable table.Name, index *int) (
+	filter.RuleFactory, error) {
+
+	if !strings.HasPrefix(rule.Field, TagFieldPrefix) {
+		return rule, nil
+	}
+
+	key := strings.TrimPrefix(rule.Field, TagFieldPrefix)
+	if len(key) == 0 {
+		return nil, fmt.Errorf("tag key of field %s is empty", rule.Field)
+	}
+
+	if _, exists := tagSupportedOps[rule.Op]; !exists {
+		return nil, fmt.Errorf("tag field %s does not support operator %s", rule.Field, rule.Op)
+	}
+
+	tagRule := &TagRule{ResType: resType, ResTable: resTable, Index: *index, Key: key, Op: rule.Op,
+		Value: rule.Value}
+	*index++
+	return tagRule, nil
+}
+
+var _ filter.RuleFactory = new(TagRule)
+
+// TagRule 资源标签过滤规则，转换为 资源表.id in (资源标签表子查询) 的形式
+type TagRule struct {
+	ResType  enumor.CloudResourceType
+	ResTable table.Name
+	// Index 标签规则在过滤条件中的序号，同一过滤条件中的标签规则序号不重复，用于生成不冲突的占位符
+	Index int
+	Key   string
+	Op    filter.OpFactory
+	Value interface{}
+}
+
+// WithType return tag rule's type.
+func (tr TagRule) WithType() filter.RuleType {
+	return filter.AtomType
+}
+
+// Validate tag rule, tag rule is converted from a validated atom rule.
+func (tr TagRule) Validate(_ *filter.ExprOption) error {
+	if len(tr.ResType) == 0 || len(tr.ResTable) == 0 || len(tr.Key) == 0 {
+		return fmt.Errorf("tag rule res_type, res_table and key are required")
+	}
+
+	return nil
+}
+
+// RuleField get tag rule's field.
+func (tr TagRule) RuleField() string {
+	return TagFieldPrefix + tr.Key
+}
+
+// SQLExprAndValue convert tag rule to a mysql's sub query expression, and field's value.
+func (tr TagRule) SQLExprAndValue(_ *filter.SQLWhereOption) (string, map[string]interface{}, error) {
+	if err := tr.Validate(nil); err != nil {
+		return "", nil, err
+	}
+
+	tagTable := table.ResourceTagTable
+	valueExpr, opValue, err := tr.Op.Operator().SQLExprAndValue(fmt.Sprintf("%s.tag_value", tagTable), tr.Value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// 操作符生成的占位符带有随机后缀，替换为由标签规则序号生成的占位符，保证同一过滤条件中的占位符不冲突
+	value := make(map[string]interface{}, len(opValue)+2)
+	for placeholder, val := range opValue {
+		valuePlaceholder := fmt.Sprintf("tag_value_%d", tr.Index)
+		valueExpr = strings.ReplaceAll(valueExpr, filter.SqlPlaceholder+placeholder,
+			filter.SqlPlaceholder+valuePlaceholder)
+		value[valuePlaceholder] = val
+	}
+
+	resTypePlaceholder := fmt.Sprintf("tag_res_type_%d", tr.Index)
+	keyPlaceholder := fmt.Sprintf("tag_key_%d", tr.Index)
+	value[resTypePlaceholder] = tr.ResType
+	value[keyPlaceholder] = tr.Key
+
+	expr := fmt.Sprintf("%s.id IN (SELECT %s.res_id FROM %s WHERE %s.res_type = %s%s AND %s.tag_key = %s%s AND %s)",
+		tr.ResTable, tagTable, tagTable, tagTable, filter.SqlPlaceholder, resTypePlaceholder, tagTable,
+		filter.SqlPlaceholder, keyPlaceholder, valueExpr)
+
+	return expr, value, nil
+}
@@ -0,0 +1,163 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/runtime/filter"
+)
+
+func TestConvTagRules(t *testing.T) {
+	exprJson := `
+{
+	"op": "and",
+	"rules": [{
+			"field": "name",
+			"op": "eq",
+			"value": "hcm"
+		},
+		{
+			"field": "tag.env",
+			"op": "eq",
+			"value": "prod"
+		}
+	]
+}
+`
+	expr := new(filter.Expression)
+	if err := expr.UnmarshalJSON([]byte(exprJson)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	columnTypes := map[string]enumor.ColumnType{"id": enumor.String, "name": enumor.String}
+	if err := expr.Validate(filter.NewExprOption(filter.RuleFields(columnTypes))); err == nil {
+		t.Errorf("tag field should not pass validation before added to rule fields")
+		return
+	}
+
+	AddTagRuleFields(columnTypes, expr)
+	if err := expr.Validate(filter.NewExprOption(filter.RuleFields(columnTypes))); err != nil {
+		t.Error(err)
+		return
+	}
+
+	converted, err := ConvTagRules(expr, enumor.CvmCloudResType, table.CvmTable)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	where, value, err := converted.SQLWhereExpr(DefaultSqlWhereOption)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !strings.Contains(where, "cvm.id IN (SELECT resource_tag.res_id FROM resource_tag WHERE "+
+		"resource_tag.res_type = :tag_res_type_0 AND resource_tag.tag_key = :tag_key_0 AND "+
+		"resource_tag.tag_value = :tag_value_0)") {
+		t.Errorf("unexpected tag where expression: %s", where)
+		return
+	}
+
+	hitKey, hitValue := false, false
+	for _, v := range value {
+		if v == "env" {
+			hitKey = true
+		}
+		if v == "prod" {
+			hitValue = true
+		}
+	}
+	if !hitKey || !hitValue || len(value) != 4 {
+		t.Errorf("unexpected tag where value: %v", value)
+		return
+	}
+
+	if _, ok := expr.Rules[1].(*filter.AtomRule); !ok {
+		t.Errorf("origin expression should not be modified")
+		return
+	}
+
+	unsupported := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "tag.env", Op: filter.GreaterThan.Factory(), Value: "prod"},
+		},
+	}
+	if _, err = ConvTagRules(unsupported, enumor.CvmCloudResType, table.CvmTable); err == nil {
+		t.Errorf("tag rule with gt operator should be rejected")
+		return
+	}
+}
+
+func TestConvTagRulesPlaceholder(t *testing.T) {
+	expr := &filter.Expression{
+		Op: filter.And,
+		Rules: []filter.RuleFactory{
+			&filter.AtomRule{Field: "tag.env", Op: filter.Equal.Factory(), Value: "prod"},
+			&filter.Expression{
+				Op: filter.Or,
+				Rules: []filter.RuleFactory{
+					&filter.AtomRule{Field: "tag.env", Op: filter.NotEqual.Factory(), Value: "test"},
+					&filter.AtomRule{Field: "tag.app", Op: filter.In.Factory(), Value: []string{"hcm"}},
+				},
+			},
+		},
+	}
+
+	converted, err := ConvTagRules(expr, enumor.VpcCloudResType, table.VpcTable)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	where, value, err := converted.SQLWhereExpr(DefaultSqlWhereOption)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// 3个标签规则，每个规则包含资源类型、标签键、标签值3个占位符，占位符不能冲突
+	if len(value) != 9 {
+		t.Errorf("tag rule placeholders should not conflict, where: %s, value: %v", where, value)
+		return
+	}
+
+	expects := map[string]interface{}{"tag_key_0": "env", "tag_value_0": "prod", "tag_key_1": "env",
+		"tag_value_1": "test", "tag_key_2": "app"}
+	for placeholder, expect := range expects {
+		if value[placeholder] != expect {
+			t.Errorf("placeholder %s value should be %v, but got %v", placeholder, expect, value[placeholder])
+		}
+		if !strings.Contains(where, ":"+placeholder) {
+			t.Errorf("where expression should contain placeholder %s, but got %s", placeholder, where)
+		}
+	}
+
+	if strings.Count(where, "vpc.id IN (SELECT resource_tag.res_id FROM resource_tag") != 3 {
+		t.Errorf("tag rule should use qualified resource id, but got %s", where)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package typesasync
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	tableasync "hcm/pkg/dal/table/async"
+)
+
+// ListAsyncFlowSchedules list async flow schedules.
+type ListAsyncFlowSchedules struct {
+	Count   uint64                              `json:"count,omitempty"`
+	Details []tableasync.AsyncFlowScheduleTable `json:"details,omitempty"`
+}
+
+// TriggerScheduleInfo define trigger schedule info, schedule will be updated only when
+// its state is enabled and next_run_at is equal to SourceNextRunAt.
+type TriggerScheduleInfo struct {
+	ID              string                   `json:"id" validate:"required"`
+	SourceNextRunAt time.Time                `json:"source_next_run_at" validate:"required"`
+	TargetNextRunAt time.Time                `json:"target_next_run_at" validate:"omitempty"`
+	State           enumor.FlowScheduleState `json:"state" validate:"required"`
+	Reason          *tableasync.Reason       `json:"reason" validate:"omitempty"`
+	LastFlowID      string                   `json:"last_flow_id" validate:"omitempty"`
+}
+
+// Validate TriggerScheduleInfo.
+func (info *TriggerScheduleInfo) Validate() error {
+	return validator.Validate.Struct(info)
+}
+
+// UpdateScheduleStateInfo define update schedule state info, schedule will be updated only when
+// its state is SourceState and next_run_at is equal to SourceNextRunAt.
+type UpdateScheduleStateInfo struct {
+	ID              string                   `json:"id" validate:"required"`
+	SourceState     enumor.FlowScheduleState `json:"source_state" validate:"required"`
+	SourceNextRunAt time.Time                `json:"source_next_run_at" validate:"required"`
+	TargetState     enumor.FlowScheduleState `json:"target_state" validate:"required"`
+	TargetNextRunAt time.Time                `json:"target_next_run_at" validate:"omitempty"`
+	Reason          *tableasync.Reason       `json:"reason" validate:"omitempty"`
+}
+
+// Validate UpdateScheduleStateInfo.
+func (info *UpdateScheduleStateInfo) Validate() error {
+	if err := validator.Validate.Struct(info); err != nil {
+		return err
+	}
+
+	// 状态不变时更新不会修改任何记录，无法区分是否发生冲突
+	if info.SourceState == info.TargetState {
+		return fmt.Errorf("schedule state is already %s", info.TargetState)
+	}
+
+	return nil
+}
@@ -26,3 +26,9 @@ type ListAuditDetails struct {
 	Count   uint64             `json:"count"`
 	Details []audit.AuditTable `json:"details"`
 }
+
+// ListResChangeEventDetails list res change event details.
+type ListResChangeEventDetails struct {
+	Count   uint64                      `json:"count"`
+	Details []audit.ResChangeEventTable `json:"details"`
+}
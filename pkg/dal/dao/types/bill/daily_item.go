@@ -0,0 +1,93 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/cloud"
+	tablebill "hcm/pkg/dal/table/cloud/bill"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// ListBillDailyItemDetails list bill daily item details.
+type ListBillDailyItemDetails struct {
+	Count   uint64                         `json:"count,omitempty"`
+	Details []tablebill.BillDailyItemTable `json:"details,omitempty"`
+}
+
+// ListBillSyncRecordDetails list bill sync record details.
+type ListBillSyncRecordDetails struct {
+	Count   uint64                          `json:"count,omitempty"`
+	Details []tablebill.BillSyncRecordTable `json:"details,omitempty"`
+}
+
+// BillDailyAggregateOption 账单日汇总聚合参数，币种不同的费用不能相加，所以聚合时总是会按照币种分组
+type BillDailyAggregateOption struct {
+	Filter  *filter.Expression `json:"filter"`
+	GroupBy []string           `json:"group_by"`
+	Page    *core.BasePage     `json:"page"`
+}
+
+// Validate bill daily item aggregate option.
+func (opt *BillDailyAggregateOption) Validate() error {
+	if opt.Filter == nil {
+		return errors.New("filter is required")
+	}
+
+	if opt.Page == nil {
+		return errors.New("page is required")
+	}
+
+	if err := opt.Page.Validate(core.NewDefaultPageOption()); err != nil {
+		return err
+	}
+
+	if len(opt.GroupBy) == 0 {
+		return errors.New("group_by is required")
+	}
+
+	for _, field := range opt.GroupBy {
+		if !slice.IsItemInSlice(cloud.BillDailyGroupFields, field) {
+			return fmt.Errorf("group_by field %s is not supported", field)
+		}
+	}
+
+	return nil
+}
+
+// GroupFields return group by fields, currency is always included.
+func (opt *BillDailyAggregateOption) GroupFields() []string {
+	fields := slice.Unique(opt.GroupBy)
+	if !slice.IsItemInSlice(fields, "currency") {
+		fields = append(fields, "currency")
+	}
+
+	return fields
+}
+
+// BillDailyAggregateDetails bill daily item aggregate details.
+type BillDailyAggregateDetails struct {
+	Count   uint64                     `json:"count,omitempty"`
+	Details []cloud.BillDailyAggregate `json:"details,omitempty"`
+}
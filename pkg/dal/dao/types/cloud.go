@@ -34,6 +34,12 @@ type CloudResourceBasicInfo struct {
 	RecycleStatus string `json:"recycle_status" db:"recycle_status"`
 }
 
+// CloudResourceIDInfo defines cloud resource id and cloud id.
+type CloudResourceIDInfo struct {
+	ID      string `json:"id" db:"id"`
+	CloudID string `json:"cloud_id" db:"cloud_id"`
+}
+
 // CommonBasicInfoFields defines common cloud resource basic info fields.
 var CommonBasicInfoFields = []string{"id", "vendor", "account_id", "bk_biz_id"}
 
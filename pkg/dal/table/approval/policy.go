@@ -0,0 +1,114 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableapproval
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// PolicyColumns defines all the approval policy table's columns.
+var PolicyColumns = utils.MergeColumns(nil, PolicyColumnDescriptor)
+
+// PolicyColumnDescriptor is approval policy's column descriptors.
+var PolicyColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "application_types", NamedC: "application_types", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// PolicyTable approval_policy表，保存业务下需要提交申请单审批后才能执行的操作
+type PolicyTable struct {
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// BkBizID 业务ID，-1表示未配置审批策略的业务使用的默认策略
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// ApplicationTypes 需要审批的申请单类型
+	ApplicationTypes types.JsonField `db:"application_types" json:"application_types"`
+	Memo             *string         `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	Creator          string          `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser          string          `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt        types.Time      `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt        types.Time      `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return approval policy table name.
+func (t PolicyTable) TableName() table.Name {
+	return table.ApprovalPolicyTable
+}
+
+// InsertValidate validate approval policy table on insert.
+func (t PolicyTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if t.BkBizID == 0 {
+		return errors.New("bk_biz_id is required")
+	}
+
+	if len(t.ApplicationTypes) == 0 {
+		return errors.New("application_types is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate approval policy table on update.
+func (t PolicyTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if t.BkBizID != 0 {
+		return errors.New("bk_biz_id can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
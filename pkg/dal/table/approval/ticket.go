@@ -0,0 +1,130 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableapproval
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// TicketColumns defines all the approval ticket table's columns.
+var TicketColumns = utils.MergeColumns(nil, TicketColumnDescriptor)
+
+// TicketColumnDescriptor is approval ticket's column descriptors.
+var TicketColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "application_type", NamedC: "application_type", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "title", NamedC: "title", Type: enumor.String},
+	{Column: "content", NamedC: "content", Type: enumor.String},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "current_stage", NamedC: "current_stage", Type: enumor.Numeric},
+	{Column: "stages", NamedC: "stages", Type: enumor.Json},
+	{Column: "current_approvers", NamedC: "current_approvers", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// TicketTable approval_ticket表，保存内置审批引擎的审批单据以及各个审批节点的操作记录
+type TicketTable struct {
+	ID              string                 `db:"id" validate:"lte=64" json:"id"`
+	ApplicationType enumor.ApplicationType `db:"application_type" validate:"lte=64" json:"application_type"`
+	BkBizID         int64                  `db:"bk_biz_id" json:"bk_biz_id"`
+	Title           string                 `db:"title" validate:"lte=255" json:"title"`
+	// Content 单据展示内容
+	Content string                      `db:"content" json:"content"`
+	Status  enumor.ApprovalTicketStatus `db:"status" validate:"lte=32" json:"status"`
+	// CurrentStage 当前审批节点在Stages中的下标
+	CurrentStage uint `db:"current_stage" json:"current_stage"`
+	// Stages 审批节点以及节点上的操作记录
+	Stages types.JsonField `db:"stages" json:"stages"`
+	// CurrentApprovers 当前审批节点的审批人，用于查询待我审批的单据
+	CurrentApprovers types.JsonField `db:"current_approvers" json:"current_approvers"`
+	Creator          string          `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser          string          `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt        types.Time      `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt        types.Time      `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return approval ticket table name.
+func (t TicketTable) TableName() table.Name {
+	return table.ApprovalTicketTable
+}
+
+// InsertValidate validate approval ticket table on insert.
+func (t TicketTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.ApplicationType) == 0 {
+		return errors.New("application_type is required")
+	}
+
+	if len(t.Status) == 0 {
+		return errors.New("status is required")
+	}
+
+	if len(t.Stages) == 0 {
+		return errors.New("stages is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate approval ticket table on update.
+func (t TicketTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ApplicationType) != 0 {
+		return errors.New("application_type can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tableapproval 内置审批引擎相关的表结构定义
+package tableapproval
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// WorkflowColumns defines all the approval workflow table's columns.
+var WorkflowColumns = utils.MergeColumns(nil, WorkflowColumnDescriptor)
+
+// WorkflowColumnDescriptor is approval workflow's column descriptors.
+var WorkflowColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "application_type", NamedC: "application_type", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "stages", NamedC: "stages", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// WorkflowTable approval_workflow表，保存申请单类型在各个业务下的多级审批节点配置
+type WorkflowTable struct {
+	ID              string                 `db:"id" validate:"lte=64" json:"id"`
+	ApplicationType enumor.ApplicationType `db:"application_type" validate:"lte=64" json:"application_type"`
+	// BkBizID 业务ID，-1表示该申请单类型的默认审批流
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// Stages 审批节点
+	Stages    types.JsonField `db:"stages" json:"stages"`
+	Memo      *string         `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	Creator   string          `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string          `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time      `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt types.Time      `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return approval workflow table name.
+func (t WorkflowTable) TableName() table.Name {
+	return table.ApprovalWorkflowTable
+}
+
+// InsertValidate validate approval workflow table on insert.
+func (t WorkflowTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.ApplicationType) == 0 {
+		return errors.New("application_type is required")
+	}
+
+	if t.BkBizID == 0 {
+		return errors.New("bk_biz_id is required")
+	}
+
+	if len(t.Stages) == 0 {
+		return errors.New("stages is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate approval workflow table on update.
+func (t WorkflowTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ApplicationType) != 0 {
+		return errors.New("application_type can not update")
+	}
+
+	if t.BkBizID != 0 {
+		return errors.New("bk_biz_id can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableasync
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table/types"
+)
+
+// TaskCondition 任务执行条件，任务依赖的任务都执行完成后判断条件，条件不满足时任务被跳过
+type TaskCondition struct {
+	// Source 条件取值来源
+	Source enumor.TaskConditionSource `json:"source" validate:"required"`
+	// ActionID 取值来源为任务执行结果时，指定取值的任务ID
+	ActionID string `json:"action_id" validate:"omitempty"`
+	// Key 取值来源为任务执行结果时，为结果中字段的json路径(如 a.b)；为共享数据时，为共享数据的key
+	Key string `json:"key" validate:"required"`
+	// Op 比较操作符
+	Op enumor.TaskConditionOp `json:"op" validate:"required"`
+	// Expect 比较的值，eq、neq时为单个值，in、nin时为数组，值统一转为字符串后比较
+	Expect interface{} `json:"expect" validate:"omitempty"`
+}
+
+// Validate TaskCondition.
+func (c TaskCondition) Validate() error {
+	if err := validator.Validate.Struct(c); err != nil {
+		return err
+	}
+
+	if err := c.Source.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Op.Validate(); err != nil {
+		return err
+	}
+
+	if c.Source == enumor.TaskConditionResult && len(c.ActionID) == 0 {
+		return errors.New("condition action_id is required when source is result")
+	}
+
+	switch c.Op {
+	case enumor.TaskConditionEqual, enumor.TaskConditionNotEqual:
+		if c.Expect == nil {
+			return fmt.Errorf("condition expect is required when op is %s", c.Op)
+		}
+	case enumor.TaskConditionIn, enumor.TaskConditionNotIn:
+		kind := reflect.ValueOf(c.Expect).Kind()
+		if kind != reflect.Slice && kind != reflect.Array {
+			return fmt.Errorf("condition expect should be an array when op is %s", c.Op)
+		}
+	}
+
+	return nil
+}
+
+// Match 判断取值是否满足条件，exist表示取值是否存在
+func (c TaskCondition) Match(value string, exist bool) bool {
+	switch c.Op {
+	case enumor.TaskConditionExist:
+		return exist
+	case enumor.TaskConditionNotExist:
+		return !exist
+	case enumor.TaskConditionEqual:
+		return exist && value == fmt.Sprint(c.Expect)
+	case enumor.TaskConditionNotEqual:
+		return !exist || value != fmt.Sprint(c.Expect)
+	case enumor.TaskConditionIn:
+		return exist && c.valueContains(value)
+	case enumor.TaskConditionNotIn:
+		return !exist || !c.valueContains(value)
+	default:
+		return false
+	}
+}
+
+func (c TaskCondition) valueContains(value string) bool {
+	rv := reflect.ValueOf(c.Expect)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return false
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if fmt.Sprint(rv.Index(i).Interface()) == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Scan is used to decode raw message which is read from db into TaskCondition.
+func (c *TaskCondition) Scan(raw interface{}) error {
+	return types.Scan(raw, c)
+}
+
+// Value encode the TaskCondition to a json raw, so that it can be stored to db with json raw.
+func (c TaskCondition) Value() (driver.Value, error) {
+	return types.Value(c)
+}
@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableasync
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AsyncFlowEventColumns defines all the async_flow_event table's columns.
+var AsyncFlowEventColumns = utils.MergeColumns(nil, AsyncFlowEventTableColumnDescriptor)
+
+// AsyncFlowEventTableColumnDescriptor is async_flow_event's column descriptors.
+var AsyncFlowEventTableColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "seq", NamedC: "seq", Type: enumor.Numeric},
+	{Column: "type", NamedC: "type", Type: enumor.String},
+	{Column: "flow_id", NamedC: "flow_id", Type: enumor.String},
+	{Column: "flow_name", NamedC: "flow_name", Type: enumor.String},
+	{Column: "task_id", NamedC: "task_id", Type: enumor.String},
+	{Column: "action_id", NamedC: "action_id", Type: enumor.String},
+	{Column: "source", NamedC: "source", Type: enumor.String},
+	{Column: "target", NamedC: "target", Type: enumor.String},
+	{Column: "reason", NamedC: "reason", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// AsyncFlowEventTable define async_flow_event table, 记录任务流、任务的状态变更事件，事件只增不改。
+type AsyncFlowEventTable struct {
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Seq 事件ID对应的数值序号，用于按游标增量查询事件
+	Seq      uint64                `db:"seq" json:"seq"`
+	Type     enumor.AsyncEventType `db:"type" json:"type" validate:"lte=16"`
+	FlowID   string                `db:"flow_id" json:"flow_id" validate:"lte=64"`
+	FlowName enumor.FlowName       `db:"flow_name" json:"flow_name" validate:"lte=64"`
+	TaskID   string                `db:"task_id" json:"task_id" validate:"lte=64"`
+	ActionID string                `db:"action_id" json:"action_id" validate:"lte=64"`
+	// Source 变更前的状态，任务流创建时为空
+	Source    string     `db:"source" json:"source" validate:"lte=16"`
+	Target    string     `db:"target" json:"target" validate:"lte=16"`
+	Reason    *Reason    `db:"reason" json:"reason"`
+	Creator   string     `db:"creator" json:"creator" validate:"lte=64"`
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+}
+
+// TableName return async_flow_event table name.
+func (a AsyncFlowEventTable) TableName() table.Name {
+	return table.AsyncFlowEventTable
+}
+
+// InsertValidate async_flow_event table when insert.
+func (a AsyncFlowEventTable) InsertValidate() error {
+	// length validate.
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	if len(a.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if err := a.Type.Validate(); err != nil {
+		return err
+	}
+
+	if len(a.FlowID) == 0 {
+		return errors.New("flow_id is required")
+	}
+
+	if a.Type == enumor.TaskStateEvent && len(a.TaskID) == 0 {
+		return errors.New("task_id is required")
+	}
+
+	if len(a.Target) == 0 {
+		return errors.New("target is required")
+	}
+
+	if len(a.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableasync
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table/types"
+)
+
+// RateLimitKey 任务限流键，由任务访问的云厂商、账号、地域组成，执行器按限流键对访问同一云厂商账号的任务进行限流
+type RateLimitKey struct {
+	Vendor    enumor.Vendor `json:"vendor" validate:"required"`
+	AccountID string        `json:"account_id" validate:"required"`
+	Region    string        `json:"region" validate:"omitempty"`
+}
+
+// NewRateLimitKey new rate limit key.
+func NewRateLimitKey(vendor enumor.Vendor, accountID, region string) *RateLimitKey {
+	return &RateLimitKey{
+		Vendor:    vendor,
+		AccountID: accountID,
+		Region:    region,
+	}
+}
+
+// Validate RateLimitKey.
+func (k RateLimitKey) Validate() error {
+	if err := k.Vendor.Validate(); err != nil {
+		return err
+	}
+
+	return validator.Validate.Struct(k)
+}
+
+// String return rate limit key string.
+func (k RateLimitKey) String() string {
+	return fmt.Sprintf("%s/%s/%s", k.Vendor, k.AccountID, k.Region)
+}
+
+// Scan is used to decode raw message which is read from db into RateLimitKey.
+func (k *RateLimitKey) Scan(raw interface{}) error {
+	return types.Scan(raw, k)
+}
+
+// Value encode the RateLimitKey to a json raw, so that it can be stored to db with json raw.
+func (k RateLimitKey) Value() (driver.Value, error) {
+	return types.Value(k)
+}
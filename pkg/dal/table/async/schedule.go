@@ -0,0 +1,153 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableasync
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AsyncFlowScheduleColumns defines all the async_flow_schedule table's columns.
+var AsyncFlowScheduleColumns = utils.MergeColumns(nil, AsyncFlowScheduleTableColumnDescriptor)
+
+// AsyncFlowScheduleTableColumnDescriptor is async_flow_schedule's column descriptors.
+var AsyncFlowScheduleTableColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "flow_name", NamedC: "flow_name", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "tasks", NamedC: "tasks", Type: enumor.Json},
+	{Column: "cron", NamedC: "cron", Type: enumor.String},
+	{Column: "next_run_at", NamedC: "next_run_at", Type: enumor.Time},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "reason", NamedC: "reason", Type: enumor.Json},
+	{Column: "last_flow_id", NamedC: "last_flow_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AsyncFlowScheduleTable define async_flow_schedule table.
+type AsyncFlowScheduleTable struct {
+	ID       string          `db:"id" json:"id" validate:"lte=64"`
+	Name     string          `db:"name" json:"name" validate:"lte=255"`
+	FlowName enumor.FlowName `db:"flow_name" json:"flow_name" validate:"lte=64"`
+	Memo     string          `db:"memo" json:"memo" validate:"lte=64"`
+	Tasks    ScheduleTasks   `db:"tasks" json:"tasks"`
+	// Cron 周期执行的cron表达式，为空表示只在 NextRunAt 执行一次
+	Cron       string                   `db:"cron" json:"cron" validate:"lte=64"`
+	NextRunAt  time.Time                `db:"next_run_at" json:"next_run_at"`
+	State      enumor.FlowScheduleState `db:"state" json:"state" validate:"lte=16"`
+	Reason     *Reason                  `db:"reason" json:"reason"`
+	LastFlowID string                   `db:"last_flow_id" json:"last_flow_id" validate:"lte=64"`
+	Creator    string                   `db:"creator" json:"creator" validate:"lte=64"`
+	Reviser    string                   `db:"reviser" json:"reviser" validate:"lte=64"`
+	CreatedAt  types.Time               `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	UpdatedAt  types.Time               `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return async_flow_schedule table name.
+func (a AsyncFlowScheduleTable) TableName() table.Name {
+	return table.AsyncFlowScheduleTable
+}
+
+// InsertValidate async_flow_schedule table when insert.
+func (a AsyncFlowScheduleTable) InsertValidate() error {
+	// length validate.
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	if len(a.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(a.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(a.FlowName) == 0 {
+		return errors.New("flow_name is required")
+	}
+
+	if a.NextRunAt.IsZero() {
+		return errors.New("next_run_at is required")
+	}
+
+	if len(a.State) == 0 {
+		return errors.New("state is required")
+	}
+
+	if len(a.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(a.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate async_flow_schedule table when update.
+func (a AsyncFlowScheduleTable) UpdateValidate() error {
+	// length validate.
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	if len(a.FlowName) != 0 {
+		return errors.New("flow_name can not update")
+	}
+
+	if len(a.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
+
+// ScheduleTask 定时任务流中模版任务的私有化参数
+type ScheduleTask struct {
+	ActionID     string          `json:"action_id"`
+	Params       types.JsonField `json:"params"`
+	RateLimitKey *RateLimitKey   `json:"rate_limit_key,omitempty"`
+}
+
+// ScheduleTasks define async flow schedule tasks.
+type ScheduleTasks []ScheduleTask
+
+// Scan is used to decode raw message which is read from db into ScheduleTasks.
+func (t *ScheduleTasks) Scan(raw interface{}) error {
+	return types.Scan(raw, t)
+}
+
+// Value encode the ScheduleTasks to a json raw, so that it can be stored to db with json raw.
+func (t ScheduleTasks) Value() (driver.Value, error) {
+	return types.Value(t)
+}
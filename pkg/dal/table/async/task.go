@@ -42,6 +42,9 @@ var AsyncFlowTaskTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "params", NamedC: "params", Type: enumor.Json},
 	{Column: "retry", NamedC: "retry", Type: enumor.Json},
 	{Column: "depend_on", NamedC: "depend_on", Type: enumor.Json},
+	{Column: "run_condition", NamedC: "run_condition", Type: enumor.Json},
+	{Column: "on_failure", NamedC: "on_failure", Type: enumor.Boolean},
+	{Column: "rate_limit_key", NamedC: "rate_limit_key", Type: enumor.Json},
 	{Column: "state", NamedC: "state", Type: enumor.String},
 	{Column: "reason", NamedC: "reason", Type: enumor.Json},
 	{Column: "result", NamedC: "result", Type: enumor.Json},
@@ -53,21 +56,24 @@ var AsyncFlowTaskTableColumnDescriptor = utils.ColumnDescriptors{
 
 // AsyncFlowTaskTable define async_flow_task table.
 type AsyncFlowTaskTable struct {
-	ID         string            `db:"id" json:"id" validate:"lte=64"`
-	FlowID     string            `db:"flow_id" json:"flow_id"`
-	FlowName   enumor.FlowName   `db:"flow_name" json:"flow_name"`
-	ActionID   string            `db:"action_id" json:"action_id"`
-	ActionName enumor.ActionName `db:"action_name" json:"action_name"`
-	Params     types.JsonField   `db:"params" json:"params"`
-	Retry      *Retry            `db:"retry" json:"retry"`
-	DependOn   types.StringArray `db:"depend_on" json:"depend_on"`
-	State      enumor.TaskState  `db:"state" json:"state"`
-	Reason     *Reason           `db:"reason" json:"reason"`
-	Result     types.JsonField   `db:"result" json:"result"`
-	Creator    string            `db:"creator" json:"creator" validate:"lte=64"`
-	Reviser    string            `db:"reviser" json:"reviser" validate:"lte=64"`
-	CreatedAt  types.Time        `db:"created_at" json:"created_at" validate:"excluded_unless"`
-	UpdatedAt  types.Time        `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+	ID           string            `db:"id" json:"id" validate:"lte=64"`
+	FlowID       string            `db:"flow_id" json:"flow_id"`
+	FlowName     enumor.FlowName   `db:"flow_name" json:"flow_name"`
+	ActionID     string            `db:"action_id" json:"action_id"`
+	ActionName   enumor.ActionName `db:"action_name" json:"action_name"`
+	Params       types.JsonField   `db:"params" json:"params"`
+	Retry        *Retry            `db:"retry" json:"retry"`
+	DependOn     types.StringArray `db:"depend_on" json:"depend_on"`
+	RunCondition *TaskCondition    `db:"run_condition" json:"run_condition"`
+	OnFailure    *bool             `db:"on_failure" json:"on_failure"`
+	RateLimitKey *RateLimitKey     `db:"rate_limit_key" json:"rate_limit_key"`
+	State        enumor.TaskState  `db:"state" json:"state"`
+	Reason       *Reason           `db:"reason" json:"reason"`
+	Result       types.JsonField   `db:"result" json:"result"`
+	Creator      string            `db:"creator" json:"creator" validate:"lte=64"`
+	Reviser      string            `db:"reviser" json:"reviser" validate:"lte=64"`
+	CreatedAt    types.Time        `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	UpdatedAt    types.Time        `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
 }
 
 // TableName return async_flow_task table name.
@@ -0,0 +1,126 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tableasync
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AsyncFlowWebhookColumns defines all the async_flow_webhook table's columns.
+var AsyncFlowWebhookColumns = utils.MergeColumns(nil, AsyncFlowWebhookTableColumnDescriptor)
+
+// AsyncFlowWebhookTableColumnDescriptor is async_flow_webhook's column descriptors.
+var AsyncFlowWebhookTableColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "url", NamedC: "url", Type: enumor.String},
+	{Column: "secret", NamedC: "secret", Type: enumor.String},
+	{Column: "flow_names", NamedC: "flow_names", Type: enumor.Json},
+	{Column: "event_types", NamedC: "event_types", Type: enumor.Json},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "last_event_id", NamedC: "last_event_id", Type: enumor.String},
+	{Column: "reason", NamedC: "reason", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AsyncFlowWebhookTable define async_flow_webhook table.
+type AsyncFlowWebhookTable struct {
+	ID   string `db:"id" json:"id" validate:"lte=64"`
+	Name string `db:"name" json:"name" validate:"lte=255"`
+	URL  string `db:"url" json:"url" validate:"lte=1024"`
+	// Secret 用于对回调请求体进行HMAC-SHA256签名，为空时不签名
+	Secret *string `db:"secret" json:"secret" validate:"omitempty,lte=255"`
+	// FlowNames 关注的任务流名称，为空表示关注所有任务流
+	FlowNames types.StringArray `db:"flow_names" json:"flow_names"`
+	// EventTypes 关注的事件类型，为空表示关注所有类型
+	EventTypes types.StringArray       `db:"event_types" json:"event_types"`
+	State      enumor.FlowWebhookState `db:"state" json:"state" validate:"lte=16"`
+	// LastEventID 已成功投递的最后一个事件ID
+	LastEventID string     `db:"last_event_id" json:"last_event_id" validate:"lte=64"`
+	Reason      *Reason    `db:"reason" json:"reason"`
+	Memo        *string    `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	Creator     string     `db:"creator" json:"creator" validate:"lte=64"`
+	Reviser     string     `db:"reviser" json:"reviser" validate:"lte=64"`
+	CreatedAt   types.Time `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	UpdatedAt   types.Time `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+}
+
+// TableName return async_flow_webhook table name.
+func (a AsyncFlowWebhookTable) TableName() table.Name {
+	return table.AsyncFlowWebhookTable
+}
+
+// InsertValidate async_flow_webhook table when insert.
+func (a AsyncFlowWebhookTable) InsertValidate() error {
+	// length validate.
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	if len(a.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(a.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(a.URL) == 0 {
+		return errors.New("url is required")
+	}
+
+	if len(a.State) == 0 {
+		return errors.New("state is required")
+	}
+
+	if len(a.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(a.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate async_flow_webhook table when update.
+func (a AsyncFlowWebhookTable) UpdateValidate() error {
+	// length validate.
+	if err := validator.Validate.Struct(a); err != nil {
+		return err
+	}
+
+	if len(a.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ResChangeEventColumns defines all the res_change_event table's columns.
+var ResChangeEventColumns = utils.MergeColumns(utils.InsertWithoutPrimaryID, ResChangeEventColumnDescriptor)
+
+// ResChangeEventColumnDescriptor is ResChangeEventTable's column descriptors.
+var ResChangeEventColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.Numeric},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "cloud_res_id", NamedC: "cloud_res_id", Type: enumor.String},
+	{Column: "res_name", NamedC: "res_name", Type: enumor.String},
+	{Column: "action", NamedC: "action", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "operator", NamedC: "operator", Type: enumor.String},
+	{Column: "source", NamedC: "source", Type: enumor.String},
+	{Column: "rid", NamedC: "rid", Type: enumor.String},
+	{Column: "changes", NamedC: "changes", Type: enumor.Json},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// ResChangeEventTable is used to save resource's attribute level change events, e.g. changes found by sync.
+type ResChangeEventTable struct {
+	ID         uint64                   `db:"id" json:"id"`
+	ResType    enumor.AuditResourceType `db:"res_type" json:"res_type" validate:"lte=50"`
+	ResID      string                   `db:"res_id" json:"res_id" validate:"required,lte=64"`
+	CloudResID string                   `db:"cloud_res_id" json:"cloud_res_id" validate:"lte=255"`
+	ResName    string                   `db:"res_name" json:"res_name" validate:"lte=255"`
+	Action     enumor.AuditAction       `db:"action" json:"action" validate:"lte=20"`
+	BkBizID    int64                    `db:"bk_biz_id" json:"bk_biz_id"`
+	Vendor     enumor.Vendor            `db:"vendor" json:"vendor" validate:"lte=16"`
+	AccountID  string                   `db:"account_id" json:"account_id" validate:"lte=64"`
+	Operator   string                   `db:"operator" json:"operator" validate:"lte=64"`
+	Source     enumor.RequestSourceType `db:"source" json:"source" validate:"lte=20"`
+	Rid        string                   `db:"rid" json:"rid" validate:"lte=64"`
+	Changes    FieldChanges             `db:"changes" json:"changes" validate:"-"`
+	CreatedAt  types.Time               `db:"created_at" json:"created_at"`
+}
+
+// CreateValidate res change event when created
+func (e ResChangeEventTable) CreateValidate() error {
+	if err := validator.Validate.Struct(e); err != nil {
+		return err
+	}
+
+	if !e.ResType.Exist() {
+		return fmt.Errorf("resource type: %s not support", e.ResType)
+	}
+
+	if !e.Action.Exist() {
+		return fmt.Errorf("action: %s not support", e.Action)
+	}
+
+	if !e.Source.Exist() {
+		return fmt.Errorf("source: %s not support", e.Source)
+	}
+
+	if len(e.Changes) == 0 {
+		return errors.New("changes is required")
+	}
+
+	return nil
+}
+
+// TableName is the res change event's database table name.
+func (e ResChangeEventTable) TableName() table.Name {
+	return table.ResChangeEventTable
+}
+
+// FieldChange defines one attribute's change of resource.
+type FieldChange struct {
+	// Field 属性名，extension中的属性以 extension. 为前缀，如：extension.vpc_id
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// FieldChanges is the attribute changes of one change event.
+type FieldChanges []FieldChange
+
+// Scan is used to decode raw message which is read from db into FieldChanges.
+func (c *FieldChanges) Scan(raw interface{}) error {
+	if c == nil {
+		return errors.New("field changes is not initialized")
+	}
+
+	if raw == nil {
+		return nil
+	}
+
+	switch v := raw.(type) {
+	case []byte:
+		if err := json.Unmarshal(v, &c); err != nil {
+			return fmt.Errorf("decode into field changes failed, err: %v", err)
+		}
+		return nil
+	case string:
+		if err := json.Unmarshal([]byte(v), &c); err != nil {
+			return fmt.Errorf("decode into field changes failed, err: %v", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported field changes raw type: %T", v)
+	}
+}
+
+// Value encode the field changes to a json raw, so that it can be stored to db with json raw.
+func (c FieldChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(c)
+}
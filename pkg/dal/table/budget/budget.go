@@ -0,0 +1,248 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tablebudget 预算相关的表结构定义
+package tablebudget
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// BudgetColumns defines all the budget table's columns.
+var BudgetColumns = utils.MergeColumns(nil, BudgetColumnDescriptor)
+
+// BudgetColumnDescriptor is budget's column descriptors.
+var BudgetColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "scope", NamedC: "scope", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "currency", NamedC: "currency", Type: enumor.String},
+	{Column: "amount", NamedC: "amount", Type: enumor.Numeric},
+	{Column: "thresholds", NamedC: "thresholds", Type: enumor.Json},
+	{Column: "receivers", NamedC: "receivers", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// BudgetTable budget表，按业务或账号设置的每月预算
+type BudgetTable struct {
+	// ID 预算ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// Name 预算名称
+	Name string `db:"name" validate:"lte=255" json:"name"`
+	// Scope 预算范围，按业务或按账号
+	Scope enumor.BudgetScope `db:"scope" validate:"lte=16" json:"scope"`
+	// BkBizID 业务ID，按账号设置的预算为-1
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// AccountID 账号ID，按业务设置的预算为空
+	AccountID string `db:"account_id" validate:"lte=64" json:"account_id"`
+	// Currency 预算币种，只统计该币种的费用
+	Currency string `db:"currency" validate:"lte=16" json:"currency"`
+	// Amount 每月预算金额
+	Amount string `db:"amount" json:"amount"`
+	// Thresholds 告警阈值，预算金额的百分比
+	Thresholds types.Int64Array `db:"thresholds" json:"thresholds"`
+	// Receivers 告警接收人
+	Receivers types.StringArray `db:"receivers" json:"receivers"`
+	// Memo 备注
+	Memo *string `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"lte=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"lte=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return budget table name.
+func (t BudgetTable) TableName() table.Name {
+	return table.BudgetTable
+}
+
+// InsertValidate validate budget table on insert.
+func (t BudgetTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if err := t.Scope.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Currency) == 0 {
+		return errors.New("currency is required")
+	}
+
+	if len(t.Amount) == 0 {
+		return errors.New("amount is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate budget table on update.
+func (t BudgetTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// BudgetAlertColumns defines all the budget alert table's columns.
+var BudgetAlertColumns = utils.MergeColumns(nil, BudgetAlertColumnDescriptor)
+
+// BudgetAlertColumnDescriptor is budget alert's column descriptors.
+var BudgetAlertColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "budget_id", NamedC: "budget_id", Type: enumor.String},
+	{Column: "month", NamedC: "month", Type: enumor.String},
+	{Column: "threshold", NamedC: "threshold", Type: enumor.Numeric},
+	{Column: "amount", NamedC: "amount", Type: enumor.Numeric},
+	{Column: "spent", NamedC: "spent", Type: enumor.Numeric},
+	{Column: "forecast", NamedC: "forecast", Type: enumor.Numeric},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "message", NamedC: "message", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// BudgetAlertTable budget_alert表，记录每个预算每月各告警阈值的通知情况
+type BudgetAlertTable struct {
+	// ID 告警记录ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// BudgetID 预算ID
+	BudgetID string `db:"budget_id" validate:"lte=64" json:"budget_id"`
+	// Month 预算月份，格式为yyyy-mm
+	Month string `db:"month" validate:"lte=7" json:"month"`
+	// Threshold 触发的告警阈值
+	Threshold uint `db:"threshold" json:"threshold"`
+	// Amount 触发告警时的预算金额
+	Amount string `db:"amount" json:"amount"`
+	// Spent 触发告警时的当月费用
+	Spent string `db:"spent" json:"spent"`
+	// Forecast 触发告警时预测的月底费用
+	Forecast string `db:"forecast" json:"forecast"`
+	// State 通知状态
+	State enumor.BudgetAlertState `db:"state" validate:"lte=16" json:"state"`
+	// Message 通知失败时的错误信息
+	Message string `db:"message" validate:"lte=1024" json:"message"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"lte=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"lte=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return budget alert table name.
+func (t BudgetAlertTable) TableName() table.Name {
+	return table.BudgetAlertTable
+}
+
+// InsertValidate validate budget alert table on insert.
+func (t BudgetAlertTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.BudgetID) == 0 {
+		return errors.New("budget_id is required")
+	}
+
+	if len(t.Month) == 0 {
+		return errors.New("month is required")
+	}
+
+	if err := t.State.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate budget alert table on update.
+func (t BudgetAlertTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
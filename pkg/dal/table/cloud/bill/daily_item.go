@@ -0,0 +1,254 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// BillDailyItemColumns defines all the bill daily item table's columns.
+var BillDailyItemColumns = utils.MergeColumns(nil, BillDailyItemColumnDescriptor)
+
+// BillDailyItemColumnDescriptor is bill daily item's column descriptors.
+var BillDailyItemColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "bill_date", NamedC: "bill_date", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "product", NamedC: "product", Type: enumor.String},
+	{Column: "currency", NamedC: "currency", Type: enumor.String},
+	{Column: "cost", NamedC: "cost", Type: enumor.Numeric},
+	{Column: "item_count", NamedC: "item_count", Type: enumor.Numeric},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// BillDailyItemTable bill_daily_item表，存储按账号、日期、地域、产品、币种汇总后的云账单
+type BillDailyItemTable struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" validate:"lte=64" json:"account_id"`
+	// BkBizID 账号所属业务ID，账号未关联或关联多个业务时为-1
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// BillDate 账单日期，格式为yyyy-mm-dd
+	BillDate string `db:"bill_date" validate:"lte=10" json:"bill_date"`
+	// Region 地域
+	Region string `db:"region" validate:"lte=255" json:"region"`
+	// Product 云产品/服务名称
+	Product string `db:"product" validate:"lte=255" json:"product"`
+	// Currency 币种
+	Currency string `db:"currency" validate:"lte=16" json:"currency"`
+	// Cost 费用
+	Cost string `db:"cost" json:"cost"`
+	// ItemCount 汇总的账单明细条数
+	ItemCount int64 `db:"item_count" json:"item_count"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"lte=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"lte=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return bill daily item table name.
+func (t BillDailyItemTable) TableName() table.Name {
+	return table.BillDailyItemTable
+}
+
+// InsertValidate validate bill daily item table on insert.
+func (t BillDailyItemTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.BillDate) == 0 {
+		return errors.New("bill_date is required")
+	}
+
+	if len(t.Cost) == 0 {
+		return errors.New("cost is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate bill daily item table on update.
+func (t BillDailyItemTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// BillSyncRecordColumns defines all the bill sync record table's columns.
+var BillSyncRecordColumns = utils.MergeColumns(nil, BillSyncRecordColumnDescriptor)
+
+// BillSyncRecordColumnDescriptor is bill sync record's column descriptors.
+var BillSyncRecordColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bill_date", NamedC: "bill_date", Type: enumor.String},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "item_count", NamedC: "item_count", Type: enumor.Numeric},
+	{Column: "cost", NamedC: "cost", Type: enumor.Numeric},
+	{Column: "message", NamedC: "message", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// BillSyncRecordTable bill_sync_record表，记录每个账号每天账单的拉取状态
+type BillSyncRecordTable struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" validate:"lte=64" json:"account_id"`
+	// BillDate 账单日期，格式为yyyy-mm-dd
+	BillDate string `db:"bill_date" validate:"lte=10" json:"bill_date"`
+	// State 拉取状态
+	State enumor.SyncStatus `db:"state" validate:"lte=16" json:"state"`
+	// ItemCount 拉取到的账单明细条数
+	ItemCount int64 `db:"item_count" json:"item_count"`
+	// Cost 当天的总费用
+	Cost string `db:"cost" json:"cost"`
+	// Message 拉取失败时的错误信息
+	Message string `db:"message" validate:"lte=1024" json:"message"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"lte=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"lte=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return bill sync record table name.
+func (t BillSyncRecordTable) TableName() table.Name {
+	return table.BillSyncRecordTable
+}
+
+// InsertValidate validate bill sync record table on insert.
+func (t BillSyncRecordTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.BillDate) == 0 {
+		return errors.New("bill_date is required")
+	}
+
+	if err := t.State.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate bill sync record table on update.
+func (t BillSyncRecordTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.State) != 0 {
+		if err := t.State.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
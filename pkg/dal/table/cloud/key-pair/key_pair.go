@@ -0,0 +1,137 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tablekeypair 密钥对相关的表定义
+package tablekeypair
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// KeyPairColumns defines all the key_pair table's columns.
+var KeyPairColumns = utils.MergeColumns(nil, KeyPairColumnDescriptor)
+
+// KeyPairColumnDescriptor is key_pair table column descriptors.
+var KeyPairColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "fingerprint", NamedC: "fingerprint", Type: enumor.String},
+	{Column: "public_key", NamedC: "public_key", Type: enumor.String},
+	{Column: "private_key", NamedC: "private_key", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "cloud_created_time", NamedC: "cloud_created_time", Type: enumor.String},
+	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// KeyPairTable define key_pair table.
+type KeyPairTable struct {
+	ID        string        `db:"id" validate:"lte=64" json:"id"`
+	CloudID   string        `db:"cloud_id" validate:"lte=255" json:"cloud_id"`
+	Name      string        `db:"name" validate:"lte=255" json:"name"`
+	Vendor    enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string        `db:"account_id" validate:"lte=64" json:"account_id"`
+	BkBizID   int64         `db:"bk_biz_id" json:"bk_biz_id"`
+	// Region 密钥对所属地域，腾讯云、谷歌云的密钥对与地域无关，为空
+	Region      string `db:"region" validate:"lte=255" json:"region"`
+	Fingerprint string `db:"fingerprint" validate:"lte=255" json:"fingerprint"`
+	PublicKey   string `db:"public_key" json:"public_key"`
+	// PrivateKey 由HCM生成的密钥对的私钥，使用cryptography.Crypto加密后存储，从云上同步的密钥对为空
+	PrivateKey       string          `db:"private_key" json:"private_key"`
+	Memo             *string         `db:"memo" json:"memo"`
+	CloudCreatedTime string          `db:"cloud_created_time" json:"cloud_created_time"`
+	Extension        types.JsonField `db:"extension" json:"extension"`
+	Creator          string          `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser          string          `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt        types.Time      `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt        types.Time      `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return key_pair table name.
+func (t KeyPairTable) TableName() table.Name {
+	return table.KeyPairTable
+}
+
+// InsertValidate key_pair table when insert.
+func (t KeyPairTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.Extension) == 0 {
+		return errors.New("extension is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate key_pair table when update.
+func (t KeyPairTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
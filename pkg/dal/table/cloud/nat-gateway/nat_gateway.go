@@ -0,0 +1,150 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tablenat NAT网关相关的表定义
+package tablenat
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// NatGatewayColumns defines all the nat_gateway table's columns.
+var NatGatewayColumns = utils.MergeColumns(nil, NatGatewayColumnDescriptor)
+
+// NatGatewayColumnDescriptor is nat_gateway table column descriptors.
+var NatGatewayColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "zone", NamedC: "zone", Type: enumor.String},
+	{Column: "cloud_vpc_id", NamedC: "cloud_vpc_id", Type: enumor.String},
+	{Column: "vpc_id", NamedC: "vpc_id", Type: enumor.String},
+	{Column: "cloud_subnet_id", NamedC: "cloud_subnet_id", Type: enumor.String},
+	{Column: "subnet_id", NamedC: "subnet_id", Type: enumor.String},
+	{Column: "spec", NamedC: "spec", Type: enumor.String},
+	{Column: "public_ip_addresses", NamedC: "public_ip_addresses", Type: enumor.Json},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "cloud_created_time", NamedC: "cloud_created_time", Type: enumor.String},
+	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// NatGatewayTable define nat_gateway table.
+type NatGatewayTable struct {
+	ID            string        `db:"id" validate:"lte=64" json:"id"`
+	CloudID       string        `db:"cloud_id" validate:"lte=255" json:"cloud_id"`
+	Name          string        `db:"name" validate:"lte=255" json:"name"`
+	Vendor        enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID     string        `db:"account_id" validate:"lte=64" json:"account_id"`
+	BkBizID       int64         `db:"bk_biz_id" json:"bk_biz_id"`
+	Region        string        `db:"region" validate:"lte=255" json:"region"`
+	Zone          string        `db:"zone" validate:"lte=255" json:"zone"`
+	CloudVpcID    string        `db:"cloud_vpc_id" validate:"lte=255" json:"cloud_vpc_id"`
+	VpcID         string        `db:"vpc_id" validate:"lte=64" json:"vpc_id"`
+	CloudSubnetID string        `db:"cloud_subnet_id" validate:"lte=255" json:"cloud_subnet_id"`
+	SubnetID      string        `db:"subnet_id" validate:"lte=64" json:"subnet_id"`
+	// Spec NAT网关规格，如 tcloud 的最大并发连接数，huawei 的 1~4 规格，aws 无规格概念为空
+	Spec              string            `db:"spec" validate:"lte=64" json:"spec"`
+	PublicIPAddresses types.StringArray `db:"public_ip_addresses" json:"public_ip_addresses"`
+	Status            string            `db:"status" validate:"lte=64" json:"status"`
+	Memo              *string           `db:"memo" json:"memo"`
+	CloudCreatedTime  string            `db:"cloud_created_time" json:"cloud_created_time"`
+	Extension         types.JsonField   `db:"extension" json:"extension"`
+	Creator           string            `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser           string            `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt         types.Time        `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt         types.Time        `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return nat_gateway table name.
+func (t NatGatewayTable) TableName() table.Name {
+	return table.NatGatewayTable
+}
+
+// InsertValidate nat_gateway table when insert.
+func (t NatGatewayTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.Region) == 0 {
+		return errors.New("region is required")
+	}
+
+	if len(t.Extension) == 0 {
+		return errors.New("extension is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate nat_gateway table when update.
+func (t NatGatewayTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tablenat
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// NatGatewayRuleColumns defines all the nat_gateway_rule table's columns.
+var NatGatewayRuleColumns = utils.MergeColumns(nil, NatGatewayRuleColumnDescriptor)
+
+// NatGatewayRuleColumnDescriptor is nat_gateway_rule table column descriptors.
+var NatGatewayRuleColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "cloud_id", NamedC: "cloud_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "nat_gateway_id", NamedC: "nat_gateway_id", Type: enumor.String},
+	{Column: "cloud_nat_gateway_id", NamedC: "cloud_nat_gateway_id", Type: enumor.String},
+	{Column: "rule_type", NamedC: "rule_type", Type: enumor.String},
+	{Column: "protocol", NamedC: "protocol", Type: enumor.String},
+	{Column: "cloud_subnet_id", NamedC: "cloud_subnet_id", Type: enumor.String},
+	{Column: "source_cidr", NamedC: "source_cidr", Type: enumor.String},
+	{Column: "public_ip", NamedC: "public_ip", Type: enumor.String},
+	{Column: "public_port", NamedC: "public_port", Type: enumor.Numeric},
+	{Column: "private_ip", NamedC: "private_ip", Type: enumor.String},
+	{Column: "private_port", NamedC: "private_port", Type: enumor.Numeric},
+	{Column: "status", NamedC: "status", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// NatGatewayRuleTable define nat_gateway_rule table.
+type NatGatewayRuleTable struct {
+	ID                string             `db:"id" validate:"lte=64" json:"id"`
+	CloudID           string             `db:"cloud_id" validate:"lte=255" json:"cloud_id"`
+	Vendor            enumor.Vendor      `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID         string             `db:"account_id" validate:"lte=64" json:"account_id"`
+	NatGatewayID      string             `db:"nat_gateway_id" validate:"lte=64" json:"nat_gateway_id"`
+	CloudNatGatewayID string             `db:"cloud_nat_gateway_id" validate:"lte=255" json:"cloud_nat_gateway_id"`
+	RuleType          enumor.NatRuleType `db:"rule_type" validate:"lte=16" json:"rule_type"`
+	// Protocol DNAT规则的协议，SNAT规则为空
+	Protocol string `db:"protocol" validate:"lte=16" json:"protocol"`
+	// CloudSubnetID、SourceCidr SNAT规则的源子网或网段
+	CloudSubnetID string `db:"cloud_subnet_id" validate:"lte=255" json:"cloud_subnet_id"`
+	SourceCidr    string `db:"source_cidr" validate:"lte=64" json:"source_cidr"`
+	PublicIP      string `db:"public_ip" validate:"lte=64" json:"public_ip"`
+	// PublicPort、PrivateIP、PrivatePort DNAT规则的端口映射，SNAT规则为空
+	PublicPort  int64           `db:"public_port" json:"public_port"`
+	PrivateIP   string          `db:"private_ip" validate:"lte=64" json:"private_ip"`
+	PrivatePort int64           `db:"private_port" json:"private_port"`
+	Status      string          `db:"status" validate:"lte=64" json:"status"`
+	Memo        *string         `db:"memo" json:"memo"`
+	Extension   types.JsonField `db:"extension" json:"extension"`
+	Creator     string          `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser     string          `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt   types.Time      `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt   types.Time      `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return nat_gateway_rule table name.
+func (t NatGatewayRuleTable) TableName() table.Name {
+	return table.NatGatewayRuleTable
+}
+
+// InsertValidate nat_gateway_rule table when insert.
+func (t NatGatewayRuleTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.CloudID) == 0 {
+		return errors.New("cloud_id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.NatGatewayID) == 0 {
+		return errors.New("nat_gateway_id is required")
+	}
+
+	if err := t.RuleType.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Extension) == 0 {
+		return errors.New("extension is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate nat_gateway_rule table when update.
+func (t NatGatewayRuleTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
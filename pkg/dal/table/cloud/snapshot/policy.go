@@ -0,0 +1,156 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tablesnapshot
+
+import (
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SnapshotPolicyColumns defines all the snapshot_policy table's columns.
+var SnapshotPolicyColumns = utils.MergeColumns(nil, SnapshotPolicyColumnDescriptor)
+
+// SnapshotPolicyColumnDescriptor is snapshot_policy table column descriptors.
+var SnapshotPolicyColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "disk_ids", NamedC: "disk_ids", Type: enumor.Json},
+	{Column: "cron", NamedC: "cron", Type: enumor.String},
+	{Column: "retention_count", NamedC: "retention_count", Type: enumor.Numeric},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "next_run_at", NamedC: "next_run_at", Type: enumor.Time},
+	{Column: "last_flow_id", NamedC: "last_flow_id", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SnapshotPolicyTable define snapshot_policy table.
+type SnapshotPolicyTable struct {
+	ID        string        `db:"id" validate:"lte=64" json:"id"`
+	Name      string        `db:"name" validate:"lte=255" json:"name"`
+	Vendor    enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID string        `db:"account_id" validate:"lte=64" json:"account_id"`
+	BkBizID   int64         `db:"bk_biz_id" json:"bk_biz_id"`
+	// DiskIDs 策略关联的云盘ID列表
+	DiskIDs types.StringArray `db:"disk_ids" json:"disk_ids"`
+	// Cron 周期创建快照的cron表达式
+	Cron string `db:"cron" validate:"lte=64" json:"cron"`
+	// RetentionCount 每块云盘由该策略创建的快照保留数量，超出部分从最早的开始清理
+	RetentionCount uint                       `db:"retention_count" json:"retention_count"`
+	State          enumor.SnapshotPolicyState `db:"state" validate:"lte=16" json:"state"`
+	NextRunAt      time.Time                  `db:"next_run_at" json:"next_run_at"`
+	LastFlowID     string                     `db:"last_flow_id" validate:"lte=64" json:"last_flow_id"`
+	Memo           *string                    `db:"memo" json:"memo"`
+	Creator        string                     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser        string                     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt      types.Time                 `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt      types.Time                 `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return snapshot_policy table name.
+func (t SnapshotPolicyTable) TableName() table.Name {
+	return table.SnapshotPolicyTable
+}
+
+// InsertValidate snapshot_policy table when insert.
+func (t SnapshotPolicyTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.Cron) == 0 {
+		return errors.New("cron is required")
+	}
+
+	if t.RetentionCount == 0 {
+		return errors.New("retention_count is required")
+	}
+
+	if len(t.State) == 0 {
+		return errors.New("state is required")
+	}
+
+	if t.NextRunAt.IsZero() {
+		return errors.New("next_run_at is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate snapshot_policy table when update.
+func (t SnapshotPolicyTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Vendor) != 0 {
+		return errors.New("vendor can not update")
+	}
+
+	if len(t.AccountID) != 0 {
+		return errors.New("account_id can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tabledrift 资源配置漂移检测相关的表结构定义
+package tabledrift
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// DesiredStateColumns defines all the desired state table's columns.
+var DesiredStateColumns = utils.MergeColumns(nil, DesiredStateColumnDescriptor)
+
+// DesiredStateColumnDescriptor is desired state's column descriptors.
+var DesiredStateColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "cloud_res_id", NamedC: "cloud_res_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "application_id", NamedC: "application_id", Type: enumor.String},
+	{Column: "spec", NamedC: "spec", Type: enumor.Json},
+	{Column: "drift_status", NamedC: "drift_status", Type: enumor.String},
+	{Column: "drift_detail", NamedC: "drift_detail", Type: enumor.Json},
+	{Column: "checked_at", NamedC: "checked_at", Type: enumor.Time},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// DesiredStateTable desired_state表，保存资源交付时的期望配置以及最近一次漂移检测的结果
+type DesiredStateTable struct {
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// ResType 资源类型，如：vpc、subnet、cvm
+	ResType enumor.CloudResourceType `db:"res_type" validate:"lte=64" json:"res_type"`
+	// ResID 资源ID
+	ResID string `db:"res_id" validate:"lte=64" json:"res_id"`
+	// CloudResID 资源云ID
+	CloudResID string        `db:"cloud_res_id" validate:"lte=255" json:"cloud_res_id"`
+	Vendor     enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	AccountID  string        `db:"account_id" validate:"lte=64" json:"account_id"`
+	BkBizID    int64         `db:"bk_biz_id" json:"bk_biz_id"`
+	// ApplicationID 交付资源的申请单ID
+	ApplicationID string `db:"application_id" validate:"lte=64" json:"application_id"`
+	// Spec 交付时资源的期望配置
+	Spec types.JsonField `db:"spec" json:"spec"`
+	// DriftStatus 最近一次漂移检测的结果
+	DriftStatus enumor.DriftStatus `db:"drift_status" validate:"lte=16" json:"drift_status"`
+	// DriftDetail 最近一次漂移检测发现的属性差异
+	DriftDetail types.JsonField `db:"drift_detail" json:"drift_detail"`
+	// CheckedAt 最近一次漂移检测的时间
+	CheckedAt time.Time  `db:"checked_at" json:"checked_at"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return desired state table name.
+func (t DesiredStateTable) TableName() table.Name {
+	return table.DesiredStateTable
+}
+
+// InsertValidate validate desired state table on insert.
+func (t DesiredStateTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.ResType) == 0 || len(t.ResID) == 0 {
+		return errors.New("res_type and res_id are required")
+	}
+
+	if t.Spec.IsEmpty() {
+		return errors.New("spec is required")
+	}
+
+	if err := validateDriftStatus(t.DriftStatus); err != nil {
+		return err
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate desired state table on update.
+func (t DesiredStateTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.DriftStatus) != 0 {
+		if err := validateDriftStatus(t.DriftStatus); err != nil {
+			return err
+		}
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+func validateDriftStatus(status enumor.DriftStatus) error {
+	switch status {
+	case enumor.InSyncDriftStatus, enumor.DriftedDriftStatus, enumor.MissingDriftStatus:
+	default:
+		return fmt.Errorf("unsupported drift status: %s", status)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tableipam IP地址管理相关的表结构定义
+package tableipam
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// PoolColumns defines all the ipam pool table's columns.
+var PoolColumns = utils.MergeColumns(nil, PoolColumnDescriptor)
+
+// PoolColumnDescriptor is ipam pool's column descriptors.
+var PoolColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "cidr", NamedC: "cidr", Type: enumor.String},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "vpc_mask_len", NamedC: "vpc_mask_len", Type: enumor.Numeric},
+	{Column: "subnet_mask_len", NamedC: "subnet_mask_len", Type: enumor.Numeric},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// PoolTable ipam_pool表，管理员按地域或业务划分的vpc、子网网段分配地址池
+type PoolTable struct {
+	// ID 地址池ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// Name 地址池名称
+	Name string `db:"name" validate:"lte=255" json:"name"`
+	// Cidr 地址池网段，只支持IPv4
+	Cidr string `db:"cidr" validate:"lte=64" json:"cidr"`
+	// Region 地址池适用的地域，为空表示适用于全部地域
+	Region string `db:"region" validate:"lte=64" json:"region"`
+	// BkBizID 地址池适用的业务，-1表示适用于全部业务
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// VpcMaskLen 默认分配的vpc网段掩码长度
+	VpcMaskLen uint `db:"vpc_mask_len" json:"vpc_mask_len"`
+	// SubnetMaskLen 默认分配的子网网段掩码长度
+	SubnetMaskLen uint `db:"subnet_mask_len" json:"subnet_mask_len"`
+	// Memo 备注
+	Memo *string `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"lte=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"lte=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return ipam pool table name.
+func (t PoolTable) TableName() table.Name {
+	return table.IPAMPoolTable
+}
+
+// InsertValidate validate ipam pool table on insert.
+func (t PoolTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name is required")
+	}
+
+	if len(t.Cidr) == 0 {
+		return errors.New("cidr is required")
+	}
+
+	if t.VpcMaskLen == 0 || t.SubnetMaskLen == 0 {
+		return errors.New("vpc_mask_len and subnet_mask_len are required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate ipam pool table on update.
+func (t PoolTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tablelease 资源租约相关的表结构定义
+package tablelease
+
+import (
+	"errors"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// LeaseColumns defines all the resource lease table's columns.
+var LeaseColumns = utils.MergeColumns(nil, LeaseColumnDescriptor)
+
+// LeaseColumnDescriptor is resource lease's column descriptors.
+var LeaseColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "owner", NamedC: "owner", Type: enumor.String},
+	{Column: "expire_at", NamedC: "expire_at", Type: enumor.Time},
+	{Column: "reminded", NamedC: "reminded", Type: enumor.Boolean},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// LeaseTable resource_lease表，保存主机和硬盘的负责人及到期时间
+type LeaseTable struct {
+	// ID 租约ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// ResType 资源类型，目前支持cvm和disk
+	ResType enumor.CloudResourceType `db:"res_type" validate:"lte=64" json:"res_type"`
+	// ResID 资源ID，同一资源只有一个租约
+	ResID string `db:"res_id" validate:"lte=64" json:"res_id"`
+	// Vendor 资源所属云厂商
+	Vendor enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	// BkBizID 设置租约时资源所属的业务
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// Owner 资源负责人，到期提醒发送给该用户
+	Owner string `db:"owner" validate:"lte=64" json:"owner"`
+	// ExpireAt 到期时间，到期后资源自动进入回收站
+	ExpireAt time.Time `db:"expire_at" json:"expire_at"`
+	// Reminded 当前到期时间是否已发送过提醒，续期后重置
+	Reminded *bool `db:"reminded" json:"reminded"`
+	// State 租约状态
+	State enumor.LeaseState `db:"state" validate:"lte=16" json:"state"`
+	// Memo 备注
+	Memo *string `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"lte=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"lte=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return resource lease table name.
+func (t LeaseTable) TableName() table.Name {
+	return table.ResourceLeaseTable
+}
+
+// InsertValidate validate resource lease table on insert.
+func (t LeaseTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.ResType) == 0 {
+		return errors.New("res_type is required")
+	}
+
+	if len(t.ResID) == 0 {
+		return errors.New("res_id is required")
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.Owner) == 0 {
+		return errors.New("owner is required")
+	}
+
+	if t.ExpireAt.IsZero() {
+		return errors.New("expire_at is required")
+	}
+
+	if t.Reminded == nil {
+		return errors.New("reminded is required")
+	}
+
+	if len(t.State) == 0 {
+		return errors.New("state is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate resource lease table on update.
+func (t LeaseTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ResType) != 0 {
+		return errors.New("res_type can not update")
+	}
+
+	if len(t.ResID) != 0 {
+		return errors.New("res_id can not update")
+	}
+
+	if len(t.Vendor) != 0 {
+		return errors.New("vendor can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tablestack
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// VersionColumns defines all the stack version table's columns.
+var VersionColumns = utils.MergeColumns(nil, VersionColumnDescriptor)
+
+// VersionColumnDescriptor is stack version's column descriptors.
+var VersionColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "stack_id", NamedC: "stack_id", Type: enumor.String},
+	{Column: "version", NamedC: "version", Type: enumor.Numeric},
+	{Column: "format", NamedC: "format", Type: enumor.String},
+	{Column: "content", NamedC: "content", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// VersionTable stack_version表，保存资源栈模版的历史版本，版本创建后不可修改
+type VersionTable struct {
+	ID      string `db:"id" validate:"lte=64" json:"id"`
+	StackID string `db:"stack_id" validate:"lte=64" json:"stack_id"`
+	// Version 模版版本，从1开始递增
+	Version uint `db:"version" json:"version"`
+	// Format 模版格式
+	Format enumor.StackTemplateFormat `db:"format" validate:"lte=16" json:"format"`
+	// Content 模版内容
+	Content   string     `db:"content" json:"content"`
+	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+}
+
+// TableName return stack version table name.
+func (t VersionTable) TableName() table.Name {
+	return table.StackVersionTable
+}
+
+// InsertValidate validate stack version table on insert.
+func (t VersionTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id is required")
+	}
+
+	if len(t.StackID) == 0 {
+		return errors.New("stack_id is required")
+	}
+
+	if t.Version == 0 {
+		return errors.New("version is required")
+	}
+
+	if len(t.Content) == 0 {
+		return errors.New("content is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
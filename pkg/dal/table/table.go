@@ -38,6 +38,8 @@ const (
 	IDGenerator Name = "id_generator"
 	// AuditTable is audit table's name
 	AuditTable Name = "audit"
+	// ResChangeEventTable is resource change event table's name
+	ResChangeEventTable Name = "res_change_event"
 	// RecycleRecordTable is recycle record table name
 	RecycleRecordTable Name = "recycle_record"
 	// AccountTable is account table's name.
@@ -138,6 +140,12 @@ const (
 	AsyncFlowTable Name = "async_flow"
 	// AsyncFlowTaskTable is async flow task table's name.
 	AsyncFlowTaskTable Name = "async_flow_task"
+	// AsyncFlowScheduleTable is async flow schedule table's name.
+	AsyncFlowScheduleTable Name = "async_flow_schedule"
+	// AsyncFlowEventTable is async flow event table's name.
+	AsyncFlowEventTable Name = "async_flow_event"
+	// AsyncFlowWebhookTable is async flow webhook table's name.
+	AsyncFlowWebhookTable Name = "async_flow_webhook"
 
 	// CloudSelectionSchemeTable is cloud selection scheme table's name.
 	CloudSelectionSchemeTable Name = "cloud_selection_scheme"
@@ -148,6 +156,52 @@ const (
 
 	// ArgumentTemplateTable is argument template table's name.
 	ArgumentTemplateTable Name = "argument_template"
+
+	// LoadBalancerTable is load balancer table's name.
+	LoadBalancerTable Name = "load_balancer"
+	// LoadBalancerListenerTable is load balancer listener table's name.
+	LoadBalancerListenerTable Name = "load_balancer_listener"
+	// LoadBalancerTargetTable is load balancer target table's name.
+	LoadBalancerTargetTable Name = "load_balancer_target"
+
+	// ResourceTagTable is resource tag table's name.
+	ResourceTagTable Name = "resource_tag"
+	// BillDailyItemTable is bill daily item table's name.
+	BillDailyItemTable Name = "bill_daily_item"
+	// BillSyncRecordTable is bill sync record table's name.
+	BillSyncRecordTable Name = "bill_sync_record"
+	// BudgetTable is budget table's name.
+	BudgetTable Name = "budget"
+	// BudgetAlertTable is budget alert table's name.
+	BudgetAlertTable Name = "budget_alert"
+	// SnapshotTable is snapshot table's name.
+	SnapshotTable Name = "snapshot"
+	// SnapshotPolicyTable is snapshot policy table's name.
+	SnapshotPolicyTable Name = "snapshot_policy"
+	// KeyPairTable is key pair table's name.
+	KeyPairTable Name = "key_pair"
+	// NatGatewayTable is nat gateway table's name.
+	NatGatewayTable Name = "nat_gateway"
+	// NatGatewayRuleTable is nat gateway snat/dnat rule table's name.
+	NatGatewayRuleTable Name = "nat_gateway_rule"
+	// IPAMPoolTable is ipam cidr pool table's name.
+	IPAMPoolTable Name = "ipam_pool"
+	// DesiredStateTable is resource desired state table's name.
+	DesiredStateTable Name = "desired_state"
+	// StackTable is resource stack table's name.
+	StackTable Name = "stack"
+	// StackVersionTable is resource stack template version table's name.
+	StackVersionTable Name = "stack_version"
+	// ApprovalWorkflowTable is native approval workflow table's name.
+	ApprovalWorkflowTable Name = "approval_workflow"
+	// ApprovalTicketTable is native approval ticket table's name.
+	ApprovalTicketTable Name = "approval_ticket"
+	// ApprovalPolicyTable is business approval policy table's name.
+	ApprovalPolicyTable Name = "approval_policy"
+	// GuardrailRuleTable is resource creation guardrail rule table's name.
+	GuardrailRuleTable Name = "guardrail_rule"
+	// ResourceLeaseTable is resource ownership and expiry lease table's name.
+	ResourceLeaseTable Name = "resource_lease"
 )
 
 // Validate whether the table name is valid or not.
@@ -167,6 +221,7 @@ func (n Name) Validate() error {
 // TableMap table map config
 var TableMap = map[Name]struct{}{
 	AuditTable:                   {},
+	ResChangeEventTable:          {},
 	AccountTable:                 {},
 	SubAccountTable:              {},
 	AccountBizRelTable:           {},
@@ -218,10 +273,40 @@ var TableMap = map[Name]struct{}{
 	// TODO: 临时方案
 	RecycleRecordTableTaskID: {},
 
-	AsyncFlowTable:     {},
-	AsyncFlowTaskTable: {},
+	AsyncFlowTable:         {},
+	AsyncFlowTaskTable:     {},
+	AsyncFlowScheduleTable: {},
+	AsyncFlowEventTable:    {},
+	AsyncFlowWebhookTable:  {},
 
 	ArgumentTemplateTable: {},
+
+	LoadBalancerTable:         {},
+	LoadBalancerListenerTable: {},
+	LoadBalancerTargetTable:   {},
+
+	ResourceTagTable:    {},
+	BillDailyItemTable:  {},
+	BillSyncRecordTable: {},
+	BudgetTable:         {},
+	BudgetAlertTable:    {},
+	SnapshotTable:       {},
+	SnapshotPolicyTable: {},
+	KeyPairTable:        {},
+	NatGatewayTable:     {},
+	NatGatewayRuleTable: {},
+	IPAMPoolTable:       {},
+	DesiredStateTable:   {},
+	StackTable:          {},
+	StackVersionTable:   {},
+
+	ApprovalWorkflowTable: {},
+	ApprovalTicketTable:   {},
+	ApprovalPolicyTable:   {},
+
+	GuardrailRuleTable: {},
+
+	ResourceLeaseTable: {},
 }
 
 // Register 注册表名
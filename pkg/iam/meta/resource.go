@@ -81,4 +81,12 @@ const (
 	CloudSelectionDataSource ResourceType = "cloud_selection_data_source"
 	// ArgumentTemplate 参数模版
 	ArgumentTemplate ResourceType = "argument_template"
+	// LoadBalancer defines load balancer's hcm auth resource type
+	LoadBalancer ResourceType = "load_balancer"
+	// Snapshot defines disk snapshot's hcm auth resource type
+	Snapshot ResourceType = "snapshot"
+	// KeyPair defines ssh key pair's hcm auth resource type
+	KeyPair ResourceType = "key_pair"
+	// NatGateway defines nat gateway's hcm auth resource type
+	NatGateway ResourceType = "nat_gateway"
 )
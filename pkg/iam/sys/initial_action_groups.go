@@ -48,6 +48,7 @@ func genResManagementActionGroups() []client.ActionGroup {
 						{ID: BizIaaSResCreate},
 						{ID: BizIaaSResOperate},
 						{ID: BizIaaSResDelete},
+						{ID: BizKeyPairPrivateKeyDownload},
 					},
 				},
 				/*{
@@ -144,6 +145,7 @@ func genResourceAccessActionGroups() client.ActionGroup {
 					{ID: IaaSResCreate},
 					{ID: IaaSResOperate},
 					{ID: IaaSResDelete},
+					{ID: KeyPairPrivateKeyDownload},
 				},
 			},
 			/*{
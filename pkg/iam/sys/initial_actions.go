@@ -107,6 +107,14 @@ func genResManagementActions() []client.ResourceAction {
 		RelatedResourceTypes: bizResource,
 		RelatedActions:       []client.ActionID{BizAccess},
 		Version:              1,
+	}, {
+		ID:                   BizKeyPairPrivateKeyDownload,
+		Name:                 ActionIDNameMap[BizKeyPairPrivateKeyDownload],
+		NameEn:               "Download Biz Key Pair Private Key",
+		Type:                 View,
+		RelatedResourceTypes: bizResource,
+		RelatedActions:       []client.ActionID{BizAccess},
+		Version:              1,
 	}}
 	// TODO 开启clb和编排相关功能后放开注释
 	// actions = append(actions, genCLBResManActions()...)
@@ -367,6 +375,14 @@ func genIaaSResAccessActions() []client.ResourceAction {
 			RelatedResourceTypes: accountResource,
 			RelatedActions:       []client.ActionID{ResourceFind},
 			Version:              1,
+		}, {
+			ID:                   KeyPairPrivateKeyDownload,
+			Name:                 ActionIDNameMap[KeyPairPrivateKeyDownload],
+			NameEn:               "Download Key Pair Private Key",
+			Type:                 View,
+			RelatedResourceTypes: accountResource,
+			RelatedActions:       []client.ActionID{ResourceFind},
+			Version:              1,
 		},
 	}
 }
@@ -84,6 +84,8 @@ const (
 	BizIaaSResOperate client.ActionID = "biz_iaas_resource_operate"
 	// BizIaaSResDelete biz iaas resource delete action id to register iam.
 	BizIaaSResDelete client.ActionID = "biz_iaas_resource_delete"
+	// BizKeyPairPrivateKeyDownload biz key pair private key download action id to register iam.
+	BizKeyPairPrivateKeyDownload client.ActionID = "biz_key_pair_private_key_download"
 
 	// BizCLBResCreate biz clb resource create action id to register iam.
 	// BizCLBResCreate client.ActionID = "biz_clb_resource_create"
@@ -128,6 +130,8 @@ const (
 	IaaSResOperate client.ActionID = "iaas_resource_operate"
 	// IaaSResDelete iaas resource delete action id to register iam.
 	IaaSResDelete client.ActionID = "iaas_resource_delete"
+	// KeyPairPrivateKeyDownload key pair private key download action id to register iam.
+	KeyPairPrivateKeyDownload client.ActionID = "key_pair_private_key_download"
 
 	// CLBResCreate clb resource create action id to register iam.
 	// CLBResCreate client.ActionID = "clb_resource_create"
@@ -173,6 +177,8 @@ var ActionIDNameMap = map[client.ActionID]string{
 	BizIaaSResCreate:  "业务-IaaS资源创建",
 	BizIaaSResOperate: "业务-IaaS资源操作",
 	BizIaaSResDelete:  "业务-IaaS资源删除",
+
+	BizKeyPairPrivateKeyDownload: "业务-密钥对私钥下载",
 	// BizCLBResCreate:        "业务-负载均衡创建",
 	// BizCLBResOperate:       "业务-负载均衡操作",
 	// BizCLBResDelete:        "负载均衡删除",
@@ -193,6 +199,8 @@ var ActionIDNameMap = map[client.ActionID]string{
 	IaaSResCreate:  "资源-IaaS资源创建",
 	IaaSResOperate: "资源-IaaS资源操作",
 	IaaSResDelete:  "资源-IaaS资源删除",
+
+	KeyPairPrivateKeyDownload: "资源-密钥对私钥下载",
 	// CLBResCreate:        "负载均衡创建",
 	// CLBResOperate:       "负载均衡操作",
 	// CLBResDelete:        "负载均衡删除",
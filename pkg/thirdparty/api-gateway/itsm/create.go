@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"strings"
 
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/thirdparty/api-gateway"
 )
@@ -42,6 +43,9 @@ type CreateTicketParams struct {
 	Title             string
 	ContentDisplay    string
 	VariableApprovers []VariableApprover
+	// ApplicationType、BkBizID 内置审批引擎用于匹配审批流配置，ITSM通过ServiceID确定审批流程，不使用这两个字段
+	ApplicationType enumor.ApplicationType
+	BkBizID         int64
 }
 
 type createTicketResult struct {
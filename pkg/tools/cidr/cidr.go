@@ -166,3 +166,57 @@ func NextAvailableNet(outer net.IPNet, used []net.IPNet, masklen int) (net.IPNet
 	return nextAvailable, nil
 
 }
+
+// FirstAvailableNet find first available net, unlike NextAvailableNet, gaps between used nets will be reused.
+// Params:
+// 1. outer: 待分配的IPv4网段
+// 2. used: 已经分配出去的网段，允许相交，也允许超出outer范围
+// 3. masklen: 待分配的网段掩码长度
+func FirstAvailableNet(outer net.IPNet, used []net.IPNet, masklen int) (net.IPNet, error) {
+	outerIP := outer.IP.To4()
+	if outerIP == nil {
+		return net.IPNet{}, errors.New("only ipv4 net is supported")
+	}
+
+	outerMasklen, _ := outer.Mask.Size()
+	if masklen < outerMasklen || masklen > 32 {
+		return net.IPNet{}, errors.New("new net mask length is shorter than outer net")
+	}
+
+	type ipRange struct{ start, end uint64 }
+	ranges := make([]ipRange, 0, len(used))
+	for _, u := range used {
+		ip := u.IP.To4()
+		if ip == nil {
+			continue
+		}
+		ones, _ := u.Mask.Size()
+		start := uint64(binary.BigEndian.Uint32(ip.Mask(u.Mask)))
+		ranges = append(ranges, ipRange{start: start, end: start + 1<<(32-ones)})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start < ranges[j].start })
+
+	outerStart := uint64(binary.BigEndian.Uint32(outerIP.Mask(outer.Mask)))
+	outerEnd := outerStart + 1<<(32-outerMasklen)
+	size := uint64(1) << (32 - masklen)
+
+	// 按起始地址从小到大遍历已用网段，候选网段与已用网段重叠时跳到已用网段之后的第一个对齐位置
+	candidate := outerStart
+	for _, r := range ranges {
+		if r.end <= candidate {
+			continue
+		}
+		if r.start >= candidate+size {
+			break
+		}
+		candidate = (r.end + size - 1) / size * size
+	}
+
+	if candidate+size > outerEnd {
+		return net.IPNet{}, errors.New("out of range")
+	}
+
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, uint32(candidate))
+	return net.IPNet{IP: ip, Mask: net.CIDRMask(masklen, 32)}, nil
+}
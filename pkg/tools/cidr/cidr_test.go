@@ -183,3 +183,45 @@ func TestNextAvailableNetByIPNum(t *testing.T) {
 
 	}
 }
+
+func TestFirstAvailableNet(t *testing.T) {
+	_, testNet, _ := net.ParseCIDR("10.0.0.0/16")
+	usedNetStr := []string{
+		"10.0.0.0/24",
+		"10.0.0.128/25",
+		"10.0.2.0/24",
+		"9.0.0.0/8",
+		"10.1.0.0/16",
+		"10.0.3.0/26",
+	}
+	usedNetList := make([]net.IPNet, len(usedNetStr))
+	for idx, netStr := range usedNetStr {
+		_, _net, _ := net.ParseCIDR(netStr)
+		usedNetList[idx] = *_net
+	}
+
+	cases := map[int]NextAvailableNetResult{
+		15: {"", fmt.Errorf("new net mask length is shorter than outer net")},
+		16: {"", fmt.Errorf("out of range")},
+		22: {"10.0.4.0/22", nil},
+		23: {"10.0.4.0/23", nil},
+		24: {"10.0.1.0/24", nil},
+		26: {"10.0.1.0/26", nil},
+	}
+	for masklen, result := range cases {
+		next, err := FirstAvailableNet(*testNet, usedNetList, masklen)
+		if err != nil && result.Err != nil && err.Error() == result.Err.Error() {
+			continue
+		}
+		if err != nil || next.String() != result.NetStr {
+			t.Errorf("masklen %d got next=%v,err=%v, except=%v, except err=%v", masklen, next.String(), err,
+				result.NetStr, result.Err)
+		}
+	}
+
+	_, fullNet, _ := net.ParseCIDR("10.0.3.0/24")
+	next, err := FirstAvailableNet(*fullNet, usedNetList, 26)
+	if err != nil || next.String() != "10.0.3.64/26" {
+		t.Errorf("got next=%v,err=%v, except=10.0.3.64/26", next.String(), err)
+	}
+}
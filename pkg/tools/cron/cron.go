@@ -0,0 +1,217 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package cron 提供标准5段式cron表达式的解析与下次触发时间的计算
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// field 描述cron表达式中某一段的取值范围
+type field struct {
+	name string
+	min  uint
+	max  uint
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// searchYears 计算下次触发时间时最多向后查找的年数，避免类似 2月30日 这种永远不会触发的表达式陷入死循环
+const searchYears = 5
+
+// Schedule 解析后的cron表达式，每一段使用bit位记录允许的取值
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domStar、dowStar 记录日、周是否以 * 开头(包括 * 和 */n)，二者均被限定时按照cron惯例取并集
+	domStar bool
+	dowStar bool
+}
+
+// Parse 解析5段式cron表达式: 分 时 日 月 周。
+// 每一段支持 *、数字、范围(a-b)、步长(*/n、a-b/n)以及逗号分隔的列表，周的取值中 0 和 7 均表示周日。
+func Parse(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("cron expression should have %d fields, but got %d", len(fields), len(parts))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, part := range parts {
+		b, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		bits[i] = b
+	}
+
+	sch := &Schedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: strings.HasPrefix(parts[2], "*"),
+		dowStar: strings.HasPrefix(parts[4], "*"),
+	}
+
+	// 周日统一使用 0 表示
+	if sch.dow&(1<<7) != 0 {
+		sch.dow = sch.dow&^(1<<7) | 1
+	}
+
+	return sch, nil
+}
+
+func parseField(expr string, f field) (uint64, error) {
+	var bits uint64
+	for _, one := range strings.Split(expr, ",") {
+		b, err := parseRange(one, f)
+		if err != nil {
+			return 0, err
+		}
+		bits |= b
+	}
+
+	return bits, nil
+}
+
+func parseRange(expr string, f field) (uint64, error) {
+	if len(expr) == 0 {
+		return 0, fmt.Errorf("%s field has empty value", f.name)
+	}
+
+	rangeExpr, step := expr, uint(1)
+	if idx := strings.Index(expr, "/"); idx != -1 {
+		rangeExpr = expr[:idx]
+		s, err := strconv.ParseUint(expr[idx+1:], 10, 32)
+		if err != nil || s == 0 {
+			return 0, fmt.Errorf("%s field has invalid step: %s", f.name, expr)
+		}
+		step = uint(s)
+	}
+
+	var start, end uint
+	switch {
+	case rangeExpr == "*":
+		start, end = f.min, f.max
+
+	case strings.Contains(rangeExpr, "-"):
+		bounds := strings.SplitN(rangeExpr, "-", 2)
+		var err error
+		if start, err = parseValue(bounds[0], f); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(bounds[1], f); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, fmt.Errorf("%s field has invalid range: %s", f.name, expr)
+		}
+
+	default:
+		value, err := parseValue(rangeExpr, f)
+		if err != nil {
+			return 0, err
+		}
+		start, end = value, value
+		// 形如 5/10 的写法表示从 5 开始到最大值按步长取值
+		if step != 1 {
+			end = f.max
+		}
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << i
+	}
+
+	return bits, nil
+}
+
+func parseValue(expr string, f field) (uint, error) {
+	value, err := strconv.ParseUint(expr, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%s field has invalid value: %s", f.name, expr)
+	}
+
+	if uint(value) < f.min || uint(value) > f.max {
+		return 0, fmt.Errorf("%s field value %d out of range [%d, %d]", f.name, value, f.min, f.max)
+	}
+
+	return uint(value), nil
+}
+
+// Next 返回严格晚于给定时间的下一次触发时间，使用给定时间所在时区计算。
+func (s *Schedule) Next(t time.Time) (time.Time, error) {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(searchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.dayMatch(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t, nil
+	}
+
+	return time.Time{}, errors.New("cron expression has no trigger time in the next years")
+}
+
+func (s *Schedule) dayMatch(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
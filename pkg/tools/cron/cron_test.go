@@ -0,0 +1,106 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInvalid(t *testing.T) {
+	exprs := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"1,,2 * * * *",
+	}
+
+	for _, expr := range exprs {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) should return error", expr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// 2024-04-01 为周一
+	base := time.Date(2024, 4, 1, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"* * * * *", base, time.Date(2024, 4, 1, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", base, time.Date(2024, 4, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 20 * * *", base, time.Date(2024, 4, 1, 20, 0, 0, 0, time.UTC)},
+		{"0 8 * * *", base, time.Date(2024, 4, 2, 8, 0, 0, 0, time.UTC)},
+		{"0 20 * * 1-5", time.Date(2024, 4, 5, 21, 0, 0, 0, time.UTC),
+			time.Date(2024, 4, 8, 20, 0, 0, 0, time.UTC)},
+		{"0 3 * * 0", base, time.Date(2024, 4, 7, 3, 0, 0, 0, time.UTC)},
+		{"0 3 * * 7", base, time.Date(2024, 4, 7, 3, 0, 0, 0, time.UTC)},
+		{"30 2 1 * *", base, time.Date(2024, 5, 1, 2, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", base, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 9,18 * * *", base, time.Date(2024, 4, 1, 18, 0, 0, 0, time.UTC)},
+		// 日、周均被限定时取并集
+		{"0 0 15 * 3", base, time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 12 *", base, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)},
+		// 日或周为 */n 时视为 *，与另一字段取交集
+		{"0 0 */2 * 1", base, time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 */10 * 5", base, time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 15 * */3", base, time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		sch, err := Parse(tt.expr)
+		if err != nil {
+			t.Errorf("Parse(%q) failed, err: %v", tt.expr, err)
+			continue
+		}
+
+		got, err := sch.Next(tt.from)
+		if err != nil {
+			t.Errorf("Next(%q) failed, err: %v", tt.expr, err)
+			continue
+		}
+
+		if !got.Equal(tt.want) {
+			t.Errorf("Next(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestNextNeverTrigger(t *testing.T) {
+	sch, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("parse failed, err: %v", err)
+	}
+
+	if _, err = sch.Next(time.Now()); err == nil {
+		t.Errorf("Next should return error for never triggered expression")
+	}
+}
@@ -148,6 +148,22 @@ func (d Decimal) ToString() string {
 	return number
 }
 
+// Add returns d + d2.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	exp := d.exp
+	if d2.exp < exp {
+		exp = d2.exp
+	}
+
+	d1 := d.rescale(exp)
+	d2 = d2.rescale(exp)
+
+	return Decimal{
+		value: new(big.Int).Add(d1.value, d2.value),
+		exp:   exp,
+	}
+}
+
 // rescale returns a rescaled version of the decimal.
 func (d Decimal) rescale(exp int32) Decimal {
 	if d.value == nil {
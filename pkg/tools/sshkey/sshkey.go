@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package sshkey 提供ssh密钥对的生成与解析
+package sshkey
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// RSAKeyType ssh-rsa 公钥类型
+	RSAKeyType = "ssh-rsa"
+	// DefaultRSABits 默认生成的rsa密钥长度
+	DefaultRSABits = 4096
+)
+
+// GenerateRSA generate rsa key pair, returns pem encoded private key and openssh authorized public key.
+func GenerateRSA(bits int) (privateKey string, publicKey string, err error) {
+	if bits <= 0 {
+		bits = DefaultRSABits
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return "", "", fmt.Errorf("generate rsa key failed, err: %v", err)
+	}
+
+	block := &pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}
+
+	return string(pem.EncodeToMemory(block)), MarshalRSAPublicKey(&key.PublicKey), nil
+}
+
+// MarshalRSAPublicKey marshal rsa public key to openssh authorized key format, e.g. "ssh-rsa AAAA...".
+func MarshalRSAPublicKey(key *rsa.PublicKey) string {
+	buf := new(bytes.Buffer)
+	writeBytes(buf, []byte(RSAKeyType))
+	writeBytes(buf, mpint(big.NewInt(int64(key.E))))
+	writeBytes(buf, mpint(key.N))
+
+	return RSAKeyType + " " + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// ParseAuthorizedKey parse openssh authorized key "type base64 [comment]", returns key type and wire format blob.
+func ParseAuthorizedKey(key string) (keyType string, blob []byte, err error) {
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return "", nil, errors.New("invalid authorized key format")
+	}
+
+	blob, err = base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", nil, fmt.Errorf("decode authorized key failed, err: %v", err)
+	}
+
+	if len(blob) < 4 {
+		return "", nil, errors.New("authorized key is too short")
+	}
+
+	length := binary.BigEndian.Uint32(blob[:4])
+	if uint64(len(blob)) < 4+uint64(length) || string(blob[4:4+length]) != fields[0] {
+		return "", nil, errors.New("authorized key type mismatch")
+	}
+
+	return fields[0], blob, nil
+}
+
+// Fingerprint returns the openssh SHA256 fingerprint of authorized key, e.g. "SHA256:xxx".
+func Fingerprint(key string) (string, error) {
+	_, blob, err := ParseAuthorizedKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]), nil
+}
+
+func writeBytes(buf *bytes.Buffer, b []byte) {
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(b)))
+	buf.Write(length)
+	buf.Write(b)
+}
+
+// mpint encode positive big int in ssh mpint format, a leading zero byte is needed when highest bit is set.
+func mpint(n *big.Int) []byte {
+	b := n.Bytes()
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		return append([]byte{0}, b...)
+	}
+	return b
+}
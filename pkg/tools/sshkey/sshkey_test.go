@@ -0,0 +1,78 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sshkey
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestGenerateRSA(t *testing.T) {
+	privateKey, publicKey, err := GenerateRSA(1024)
+	if err != nil {
+		t.Fatalf("generate rsa failed, err: %v", err)
+	}
+
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		t.Fatalf("private key is not pem encoded")
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("parse private key failed, err: %v", err)
+	}
+
+	if publicKey != MarshalRSAPublicKey(&key.PublicKey) {
+		t.Errorf("public key not match private key")
+	}
+
+	keyType, _, err := ParseAuthorizedKey(publicKey + " root@hcm")
+	if err != nil {
+		t.Fatalf("parse authorized key failed, err: %v", err)
+	}
+
+	if keyType != RSAKeyType {
+		t.Errorf("key type should be %s, but got %s", RSAKeyType, keyType)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	// 由 ssh-keygen -lf 计算得到的指纹
+	key := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGf2cBgq3zUTWAJ3r8vz7lDALl8Hb+UjxJWZqpXgeRcv test"
+	fingerprint, err := Fingerprint(key)
+	if err != nil {
+		t.Fatalf("fingerprint failed, err: %v", err)
+	}
+
+	if fingerprint != "SHA256:c54OLNGuVOS3DfXafKstg9C1QSyMk++EtEdJgM/m9cI" {
+		t.Errorf("invalid fingerprint: %s", fingerprint)
+	}
+
+	mismatch := "ssh-rsa AAAAC3NzaC1lZDI1NTE5AAAAIGf2cBgq3zUTWAJ3r8vz7lDALl8Hb+UjxJWZqpXgeRcv"
+	if _, err := Fingerprint(mismatch); err == nil {
+		t.Errorf("key type mismatch should return error")
+	}
+
+	if _, err := Fingerprint("ssh-rsa"); err == nil {
+		t.Errorf("invalid format should return error")
+	}
+}
@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0017,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增资源标签表，统一存储主机、硬盘、VPC、子网等资源的标签
+*/
+
+START TRANSACTION;
+
+create table if not exists `resource_tag`
+(
+    `id`           varchar(64)  not null,
+    `vendor`       varchar(16)  not null,
+    `account_id`   varchar(64)  not null,
+    `res_type`     varchar(64)  not null,
+    `res_id`       varchar(64)  not null,
+    `cloud_res_id` varchar(255) not null,
+    `tag_key`      varchar(255) not null,
+    `tag_value`    varchar(255) not null default '',
+    `creator`      varchar(64)  not null,
+    `reviser`      varchar(64)  not null,
+    `created_at`   timestamp    not null default current_timestamp,
+    `updated_at`   timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_res_type_res_id_tag_key` (`res_type`, `res_id`, `tag_key`),
+    key `idx_res_type_tag_key_tag_value` (`res_type`, `tag_key`, `tag_value`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='资源标签表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('resource_tag', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0017' as `sql_ver`;
+
+COMMIT
@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0018,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增账单日汇总表，存储按账号、日期、地域、产品归一化汇总后的云账单
+    2. 新增账单拉取记录表，记录每个账号每天账单的拉取状态
+*/
+
+START TRANSACTION;
+
+create table if not exists `bill_daily_item`
+(
+    `id`         varchar(64)     not null,
+    `vendor`     varchar(16)     not null,
+    `account_id` varchar(64)     not null,
+    `bk_biz_id`  bigint          not null default -1,
+    `bill_date`  varchar(10)     not null,
+    `region`     varchar(255)    not null default '',
+    `product`    varchar(255)    not null default '',
+    `currency`   varchar(16)     not null default '',
+    `cost`       decimal(38, 10) not null default 0,
+    `item_count` bigint          not null default 0,
+    `creator`    varchar(64)     not null,
+    `reviser`    varchar(64)     not null,
+    `created_at` timestamp       not null default current_timestamp,
+    `updated_at` timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_account_id_bill_date_region_product_currency` (`account_id`, `bill_date`, `region`, `product`,
+                                                                     `currency`),
+    key `idx_bill_date_bk_biz_id` (`bill_date`, `bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='账单日汇总表';
+
+create table if not exists `bill_sync_record`
+(
+    `id`         varchar(64)     not null,
+    `vendor`     varchar(16)     not null,
+    `account_id` varchar(64)     not null,
+    `bill_date`  varchar(10)     not null,
+    `state`      varchar(16)     not null,
+    `item_count` bigint          not null default 0,
+    `cost`       decimal(38, 10) not null default 0,
+    `message`    varchar(1024)   not null default '',
+    `creator`    varchar(64)     not null,
+    `reviser`    varchar(64)     not null,
+    `created_at` timestamp       not null default current_timestamp,
+    `updated_at` timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_account_id_bill_date` (`account_id`, `bill_date`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='账单拉取记录表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('bill_daily_item', '0'),
+       ('bill_sync_record', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0018' as `sql_ver`;
+
+COMMIT
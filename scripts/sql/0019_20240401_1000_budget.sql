@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0019,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增预算表，支持按业务或账号设置每月预算
+    2. 新增预算告警记录表，记录每个预算每月各告警阈值的通知情况
+*/
+
+START TRANSACTION;
+
+create table if not exists `budget`
+(
+    `id`         varchar(64)     not null,
+    `name`       varchar(255)    not null,
+    `scope`      varchar(16)     not null,
+    `bk_biz_id`  bigint          not null default -1,
+    `account_id` varchar(64)     not null default '',
+    `currency`   varchar(16)     not null,
+    `amount`     decimal(38, 10) not null,
+    `thresholds` json            not null,
+    `receivers`  json            not null,
+    `memo`       varchar(255)    not null default '',
+    `creator`    varchar(64)     not null,
+    `reviser`    varchar(64)     not null,
+    `created_at` timestamp       not null default current_timestamp,
+    `updated_at` timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    key `idx_bk_biz_id` (`bk_biz_id`),
+    key `idx_account_id` (`account_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='预算表';
+
+create table if not exists `budget_alert`
+(
+    `id`         varchar(64)     not null,
+    `budget_id`  varchar(64)     not null,
+    `month`      varchar(7)      not null,
+    `threshold`  int unsigned    not null,
+    `amount`     decimal(38, 10) not null,
+    `spent`      decimal(38, 10) not null,
+    `forecast`   decimal(38, 10) not null,
+    `state`      varchar(16)     not null,
+    `message`    varchar(1024)   not null default '',
+    `creator`    varchar(64)     not null,
+    `reviser`    varchar(64)     not null,
+    `created_at` timestamp       not null default current_timestamp,
+    `updated_at` timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_budget_id_month_threshold` (`budget_id`, `month`, `threshold`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='预算告警记录表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('budget', '0'),
+       ('budget_alert', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0019' as `sql_ver`;
+
+COMMIT
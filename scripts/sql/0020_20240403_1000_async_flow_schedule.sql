@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0020,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增异步任务流定时计划表，支持按cron表达式周期或在指定时间单次创建任务流
+*/
+
+START TRANSACTION;
+
+create table if not exists `async_flow_schedule`
+(
+    `id`           varchar(64)  not null,
+    `name`         varchar(255) not null,
+    `flow_name`    varchar(64)  not null,
+    `memo`         varchar(64)  not null default '',
+    `tasks`        json         not null,
+    `cron`         varchar(64)  not null default '',
+    `next_run_at`  timestamp    not null default current_timestamp,
+    `state`        varchar(16)  not null,
+    `reason`       json                  default null,
+    `last_flow_id` varchar(64)  not null default '',
+    `creator`      varchar(64)  not null,
+    `reviser`      varchar(64)  not null,
+    `created_at`   timestamp    not null default current_timestamp,
+    `updated_at`   timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    key `idx_state_next_run_at` (`state`, `next_run_at`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='异步任务流定时计划表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('async_flow_schedule', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0020' as `sql_ver`;
+
+COMMIT
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0022,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增异步任务流事件表，记录任务流、任务的状态变更事件
+    2. 新增异步任务流事件回调表，用于向外部系统推送任务流事件
+*/
+
+START TRANSACTION;
+
+create table if not exists `async_flow_event`
+(
+    `id`         varchar(64)        not null,
+    `seq`        bigint(1) unsigned not null,
+    `type`       varchar(16)        not null,
+    `flow_id`    varchar(64)        not null,
+    `flow_name`  varchar(64)        not null default '',
+    `task_id`    varchar(64)        not null default '',
+    `action_id`  varchar(64)        not null default '',
+    `source`     varchar(16)        not null default '',
+    `target`     varchar(16)        not null,
+    `reason`     json                        default null,
+    `creator`    varchar(64)        not null,
+    `created_at` timestamp          not null default current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_seq` (`seq`),
+    key `idx_flow_id` (`flow_id`),
+    key `idx_created_at` (`created_at`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='异步任务流事件表';
+
+create table if not exists `async_flow_webhook`
+(
+    `id`            varchar(64)   not null,
+    `name`          varchar(255)  not null,
+    `url`           varchar(1024) not null,
+    `secret`        varchar(255)  not null default '',
+    `flow_names`    json                   default null,
+    `event_types`   json                   default null,
+    `state`         varchar(16)   not null,
+    `last_event_id` varchar(64)   not null default '',
+    `reason`        json                   default null,
+    `memo`          varchar(255)  not null default '',
+    `creator`       varchar(64)   not null,
+    `reviser`       varchar(64)   not null,
+    `created_at`    timestamp     not null default current_timestamp,
+    `updated_at`    timestamp     not null default current_timestamp on update current_timestamp,
+    primary key (`id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='异步任务流事件回调表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('async_flow_event', '0'),
+       ('async_flow_webhook', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0022' as `sql_ver`;
+
+COMMIT
@@ -0,0 +1,91 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+/*
+    SQLVER=0024,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增云盘快照表
+    2. 新增快照策略表，按cron周期为关联云盘创建快照并按保留数量清理
+*/
+
+START TRANSACTION;
+
+create table if not exists `snapshot`
+(
+    `id`                 varchar(64)  not null,
+    `cloud_id`           varchar(255) not null,
+    `name`               varchar(255) not null default '',
+    `vendor`             varchar(16)  not null,
+    `account_id`         varchar(64)  not null,
+    `bk_biz_id`          bigint       not null default '-1',
+    `region`             varchar(255) not null default '',
+    `zone`               varchar(255) not null default '',
+    `cloud_disk_id`      varchar(255) not null default '',
+    `disk_id`            varchar(64)  not null default '',
+    `size`               bigint unsigned not null default 0,
+    `status`             varchar(64)  not null default '',
+    `policy_id`          varchar(64)  not null default '',
+    `memo`               varchar(255)          default '',
+    `cloud_created_time` varchar(64)  not null default '',
+    `extension`          json         not null,
+    `creator`            varchar(64)  not null,
+    `reviser`            varchar(64)  not null,
+    `created_at`         timestamp    not null default current_timestamp,
+    `updated_at`         timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_vendor_account_id_cloud_id` (`vendor`, `account_id`, `cloud_id`),
+    key `idx_disk_id` (`disk_id`),
+    key `idx_policy_id` (`policy_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='云盘快照表';
+
+create table if not exists `snapshot_policy`
+(
+    `id`              varchar(64)  not null,
+    `name`            varchar(255) not null,
+    `vendor`          varchar(16)  not null,
+    `account_id`      varchar(64)  not null,
+    `bk_biz_id`       bigint       not null default '-1',
+    `disk_ids`        json         not null,
+    `cron`            varchar(64)  not null,
+    `retention_count` int unsigned not null,
+    `state`           varchar(16)  not null,
+    `next_run_at`     timestamp    not null default current_timestamp,
+    `last_flow_id`    varchar(64)  not null default '',
+    `memo`            varchar(255)          default '',
+    `creator`         varchar(64)  not null,
+    `reviser`         varchar(64)  not null,
+    `created_at`      timestamp    not null default current_timestamp,
+    `updated_at`      timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    key `idx_state_next_run_at` (`state`, `next_run_at`),
+    key `idx_bk_biz_id` (`bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='快照策略表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('snapshot', '0'),
+       ('snapshot_policy', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0024' as `sql_ver`;
+
+COMMIT
@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+/*
+    SQLVER=0026,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增NAT网关、NAT网关SNAT/DNAT规则表
+*/
+
+START TRANSACTION;
+
+create table if not exists `nat_gateway`
+(
+    `id`                  varchar(64)  not null,
+    `cloud_id`            varchar(255) not null,
+    `name`                varchar(255) not null default '',
+    `vendor`              varchar(16)  not null,
+    `account_id`          varchar(64)  not null,
+    `bk_biz_id`           bigint       not null default '-1',
+    `region`              varchar(255) not null,
+    `zone`                varchar(255) not null default '',
+    `cloud_vpc_id`        varchar(255) not null default '',
+    `vpc_id`              varchar(64)  not null default '',
+    `cloud_subnet_id`     varchar(255) not null default '',
+    `subnet_id`           varchar(64)  not null default '',
+    `spec`                varchar(64)  not null default '',
+    `public_ip_addresses` json                  default null,
+    `status`              varchar(64)  not null default '',
+    `memo`                varchar(255)          default '',
+    `cloud_created_time`  varchar(64)  not null default '',
+    `extension`           json         not null,
+    `creator`             varchar(64)  not null,
+    `reviser`             varchar(64)  not null,
+    `created_at`          timestamp    not null default current_timestamp,
+    `updated_at`          timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_vendor_account_id_cloud_id` (`vendor`, `account_id`, `cloud_id`),
+    key `idx_vpc_id` (`vpc_id`),
+    key `idx_bk_biz_id` (`bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='NAT网关表';
+
+create table if not exists `nat_gateway_rule`
+(
+    `id`                   varchar(64)  not null,
+    `cloud_id`             varchar(255) not null,
+    `vendor`               varchar(16)  not null,
+    `account_id`           varchar(64)  not null,
+    `nat_gateway_id`       varchar(64)  not null,
+    `cloud_nat_gateway_id` varchar(255) not null,
+    `rule_type`            varchar(16)  not null,
+    `protocol`             varchar(16)  not null default '',
+    `cloud_subnet_id`      varchar(255) not null default '',
+    `source_cidr`          varchar(64)  not null default '',
+    `public_ip`            varchar(64)  not null default '',
+    `public_port`          bigint       not null default '0',
+    `private_ip`           varchar(64)  not null default '',
+    `private_port`         bigint       not null default '0',
+    `status`               varchar(64)  not null default '',
+    `memo`                 varchar(255)          default '',
+    `extension`            json         not null,
+    `creator`              varchar(64)  not null,
+    `reviser`              varchar(64)  not null,
+    `created_at`           timestamp    not null default current_timestamp,
+    `updated_at`           timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_vendor_account_id_cloud_id` (`vendor`, `account_id`, `cloud_id`),
+    key `idx_nat_gateway_id` (`nat_gateway_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='NAT网关SNAT/DNAT规则表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('nat_gateway', '0'),
+       ('nat_gateway_rule', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0026' as `sql_ver`;
+
+COMMIT
@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0027,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增资源变更事件表，记录同步过程中发现的资源属性级变更
+*/
+
+START TRANSACTION;
+
+create table if not exists `res_change_event`
+(
+    `id`           bigint(1) unsigned not null auto_increment,
+    `res_type`     varchar(50)        not null,
+    `res_id`       varchar(64)        not null,
+    `cloud_res_id` varchar(255)                default '',
+    `res_name`     varchar(255)                default '',
+    `action`       varchar(20)        not null,
+    `bk_biz_id`    bigint(1)          not null default -1,
+    `vendor`       varchar(16)                 default '',
+    `account_id`   varchar(64)                 default '',
+    `operator`     varchar(64)        not null,
+    `source`       varchar(20)        not null,
+    `rid`          varchar(64)        not null,
+    `changes`      json                        default null,
+    `created_at`   timestamp          not null default current_timestamp,
+    primary key (`id`),
+    key `idx_res_type_res_id` (`res_type`, `res_id`),
+    key `idx_created_at` (`created_at`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='资源变更事件表';
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0027' as `sql_ver`;
+
+COMMIT
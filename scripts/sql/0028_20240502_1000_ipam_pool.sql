@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0028,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增IPAM地址池表，管理员按地域或业务划分vpc、子网网段的分配范围
+*/
+
+START TRANSACTION;
+
+create table if not exists `ipam_pool`
+(
+    `id`              varchar(64)  not null,
+    `name`            varchar(255) not null,
+    `cidr`            varchar(64)  not null,
+    `region`          varchar(64)  not null default '',
+    `bk_biz_id`       bigint       not null default -1,
+    `vpc_mask_len`    int unsigned not null,
+    `subnet_mask_len` int unsigned not null,
+    `memo`            varchar(255) not null default '',
+    `creator`         varchar(64)  not null,
+    `reviser`         varchar(64)  not null,
+    `created_at`      timestamp    not null default current_timestamp,
+    `updated_at`      timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_cidr` (`cidr`),
+    key `idx_bk_biz_id_region` (`bk_biz_id`, `region`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='IPAM地址池表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('ipam_pool', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0028' as `sql_ver`;
+
+COMMIT
@@ -0,0 +1,76 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+/*
+    SQLVER=0030,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增资源栈表，保存资源栈以及资源栈已创建的资源
+    2. 新增资源栈版本表，保存资源栈模版的历史版本
+*/
+
+START TRANSACTION;
+
+create table if not exists `stack`
+(
+    `id`              varchar(64)  not null,
+    `name`            varchar(255) not null,
+    `vendor`          varchar(16)  not null,
+    `account_id`      varchar(64)  not null,
+    `region`          varchar(64)  not null default '',
+    `bk_biz_id`       bigint       not null default -1,
+    `version`         int unsigned not null default 0,
+    `applied_version` int unsigned not null default 0,
+    `status`          varchar(32)  not null,
+    `flow_id`         varchar(64)  not null default '',
+    `resources`       json         not null,
+    `memo`            varchar(255) not null default '',
+    `creator`         varchar(64)  not null,
+    `reviser`         varchar(64)  not null,
+    `created_at`      timestamp    not null default current_timestamp,
+    `updated_at`      timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_bk_biz_id_name` (`bk_biz_id`, `name`),
+    key `idx_status` (`status`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='资源栈表';
+
+create table if not exists `stack_version`
+(
+    `id`         varchar(64)  not null,
+    `stack_id`   varchar(64)  not null,
+    `version`    int unsigned not null,
+    `format`     varchar(16)  not null,
+    `content`    mediumtext   not null,
+    `creator`    varchar(64)  not null,
+    `created_at` timestamp    not null default current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_stack_id_version` (`stack_id`, `version`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='资源栈模版版本表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('stack', '0'),
+       ('stack_version', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0030' as `sql_ver`;
+
+COMMIT
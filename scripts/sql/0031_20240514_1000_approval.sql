@@ -0,0 +1,75 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+/*
+    SQLVER=0031,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增审批流配置表，按申请单类型和业务配置内置审批引擎的多级审批节点
+    2. 新增审批单据表，保存内置审批引擎的审批单据以及各个节点的审批记录
+*/
+
+START TRANSACTION;
+
+create table if not exists `approval_workflow`
+(
+    `id`               varchar(64)  not null,
+    `application_type` varchar(64)  not null,
+    `bk_biz_id`        bigint       not null default -1,
+    `stages`           json         not null,
+    `memo`             varchar(255) not null default '',
+    `creator`          varchar(64)  not null,
+    `reviser`          varchar(64)  not null,
+    `created_at`       timestamp    not null default current_timestamp,
+    `updated_at`       timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_application_type_bk_biz_id` (`application_type`, `bk_biz_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='审批流配置表';
+
+create table if not exists `approval_ticket`
+(
+    `id`                varchar(64)  not null,
+    `application_type`  varchar(64)  not null,
+    `bk_biz_id`         bigint       not null default -1,
+    `title`             varchar(255) not null,
+    `content`           mediumtext   not null,
+    `status`            varchar(32)  not null,
+    `current_stage`     int unsigned not null default 0,
+    `stages`            json         not null,
+    `current_approvers` json         not null,
+    `creator`           varchar(64)  not null,
+    `reviser`           varchar(64)  not null,
+    `created_at`        timestamp    not null default current_timestamp,
+    `updated_at`        timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    key `idx_creator` (`creator`),
+    key `idx_status` (`status`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='审批单据表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('approval_workflow', '0'),
+       ('approval_ticket', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0031' as `sql_ver`;
+
+COMMIT
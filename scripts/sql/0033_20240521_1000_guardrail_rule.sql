@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+/*
+    SQLVER=0033,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增资源创建管控规则表，直接创建资源以及提交资源创建申请单前校验请求是否满足规则
+*/
+
+START TRANSACTION;
+
+create table if not exists `guardrail_rule`
+(
+    `id`         varchar(64)  not null,
+    `name`       varchar(255) not null,
+    `bk_biz_id`  bigint       not null default -1,
+    `res_type`   varchar(64)  not null,
+    `vendor`     varchar(16)  not null default '',
+    `expression` json         not null,
+    `message`    varchar(255) not null,
+    `enabled`    boolean      not null default true,
+    `memo`       varchar(255) not null default '',
+    `creator`    varchar(64)  not null,
+    `reviser`    varchar(64)  not null,
+    `created_at` timestamp    not null default current_timestamp,
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    key `idx_bk_biz_id_res_type` (`bk_biz_id`, `res_type`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='资源创建管控规则表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('guardrail_rule', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0033' as `sql_ver`;
+
+COMMIT
@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2022 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+/*
+    SQLVER=0034,HCMVER=v1.4.1
+
+    Notes:
+    1. 新增资源租约表，记录主机和硬盘的负责人及到期时间，到期前提醒负责人，到期后自动进入回收站
+*/
+
+START TRANSACTION;
+
+create table if not exists `resource_lease`
+(
+    `id`         varchar(64)  not null,
+    `res_type`   varchar(64)  not null,
+    `res_id`     varchar(64)  not null,
+    `vendor`     varchar(16)  not null,
+    `bk_biz_id`  bigint       not null default -1,
+    `owner`      varchar(64)  not null,
+    `expire_at`  timestamp    not null,
+    `reminded`   boolean      not null default false,
+    `state`      varchar(16)  not null,
+    `memo`       varchar(255) not null default '',
+    `creator`    varchar(64)  not null,
+    `reviser`    varchar(64)  not null,
+    `created_at` timestamp    not null default current_timestamp,
+    `updated_at` timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_res_type_res_id` (`res_type`, `res_id`),
+    key `idx_state_expire_at` (`state`, `expire_at`)
+) engine = innodb
+  default charset = utf8mb4
+  collate utf8mb4_bin comment ='资源租约表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('resource_lease', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.4.1' as `hcm_ver`, '0034' as `sql_ver`;
+
+COMMIT
\ No newline at end of file